@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 
+	"github.com/jwwelbor/shark-task-manager/internal/config"
 	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/events"
+	"github.com/jwwelbor/shark-task-manager/internal/metrics"
+	"github.com/jwwelbor/shark-task-manager/internal/reload"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
 )
 
 func main() {
@@ -41,6 +49,63 @@ func main() {
 		fmt.Fprintf(w, "OK")
 	})
 
+	// Status dashboard: /api/v1/status (JSON) and /dashboard (HTML)
+	// Dashboards are cached briefly so frequent polling doesn't re-run every query
+	repoDb := repository.NewDB(database)
+	repoDb.EnableCache(repository.DefaultCacheTTL)
+	// A workflow stored in the database takes precedence over .sharkconfig.json
+	storedWorkflow, err := repository.NewWorkflowRepository(repoDb).Load(context.Background())
+	if err != nil {
+		log.Println("Warning: ignoring stored workflow:", err)
+	}
+	config.UseStoredWorkflow(storedWorkflow)
+	// Health is rated with the rules in .shark.yaml and health.* settings, if
+	// any. The settings are reloaded when .shark.yaml or .sharkconfig.json changes.
+	settings, err := reload.New(context.Background(), repoDb, ".sharkconfig.json", ".", slog.Default().With("component", "config"))
+	if err != nil {
+		log.Fatal("Failed to load project settings:", err)
+	}
+	go func() {
+		if err := settings.Watch(context.Background(), reload.DefaultDebounce); err != nil {
+			log.Println("Warning: not reloading config on change:", err)
+		}
+	}()
+	statusDefaults := func() *status.StatusRequest {
+		return &status.StatusRequest{Health: settings.Current().Health}
+	}
+	statusHandler := status.NewHTTPHandler(status.NewStatusService(repoDb), statusDefaults)
+
+	// Prometheus metrics: /metrics; completions are moves into the workflow's done statuses
+	doneStatuses := func() []string {
+		return settings.Current().Workflow.GetStatusesByPhase("done")
+	}
+	metricsHandler := metrics.NewHTTPHandler(status.NewStatusService(repoDb), repository.NewStatsRepository(repoDb), doneStatuses, statusDefaults)
+
+	// Changes feed: /api/v1/events (server-sent events)
+	eventsHandler := events.NewHTTPHandler(repository.NewEventRepository(repoDb), events.DefaultPollInterval)
+
+	// Once an API key exists (shark apikey create), the API requires one. The
+	// middleware checks for keys on each request, so keys created or revoked
+	// while the server runs take effect within the cache TTL.
+	apiKeys := repository.NewAPIKeyRepository(repoDb)
+	statusHandler = status.RequireAPIKey(apiKeys, statusHandler)
+	eventsHandler = status.RequireAPIKey(apiKeys, eventsHandler)
+	metricsHandler = status.RequireAPIKey(apiKeys, metricsHandler)
+	activeKeys, err := apiKeys.CountActive(context.Background())
+	if err != nil {
+		log.Fatal("Failed to check API keys:", err)
+	}
+	if activeKeys > 0 {
+		log.Println("API key authentication enabled")
+	} else {
+		log.Println("WARNING: no API keys exist, so the API is open to anyone who can reach this server.")
+		log.Println("WARNING: create one with 'shark apikey create'; every request needs a key from then on, without a restart.")
+	}
+	http.Handle("/api/v1/status", statusHandler)
+	http.Handle("/dashboard", statusHandler)
+	http.Handle("/api/v1/events", eventsHandler)
+	http.Handle("/metrics", metricsHandler)
+
 	// Start server
 	port := "8080"
 	log.Printf("Starting server on port %s", port)
@@ -14,7 +14,5 @@ func main() {
 	// Set version in CLI before executing
 	cli.SetVersion(Version)
 
-	if err := cli.RootCmd.Execute(); err != nil {
-		os.Exit(1)
-	}
+	os.Exit(cli.Execute())
 }
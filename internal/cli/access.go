@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Roles limit what a client may do to a shared database. They guard against
+// mistakes rather than enforce security: anyone who can edit the config can
+// change their role. Give readers a read-only Turso token to enforce it on the
+// server as well.
+const (
+	RoleReader      = "reader"      // Read-only commands only, as with --read-only
+	RoleContributor = "contributor" // Everything except deletes and --force
+	RoleAdmin       = "admin"       // Everything (the default)
+)
+
+// ReadOnlyAnnotation marks commands (and their subcommands) that never write
+// to the database. Only these run in read-only mode.
+const ReadOnlyAnnotation = "shark_read_only"
+
+// WritingFlagsAnnotation lists, comma-separated, the flags that make a
+// read-only command write, such as doctor's --fix
+const WritingFlagsAnnotation = "shark_writing_flags"
+
+// DestructiveAnnotation marks commands (and their subcommands) that delete
+// data or rewrite the whole project. Only admins can run them.
+const DestructiveAnnotation = "shark_destructive"
+
+// ValidateRole checks a role from the config. Empty means admin.
+func ValidateRole(role string) error {
+	switch role {
+	case "", RoleReader, RoleContributor, RoleAdmin:
+		return nil
+	}
+	return fmt.Errorf("invalid role %q: must be one of %s, %s, %s", role, RoleReader, RoleContributor, RoleAdmin)
+}
+
+// IsReadOnly reports whether the database is opened read-only: with
+// --read-only, read_only in the config, or the reader role
+func IsReadOnly() bool {
+	return GlobalConfig.ReadOnly || GlobalConfig.Role == RoleReader
+}
+
+// CheckAccess rejects a command the read-only mode or the configured role
+// doesn't allow, before it opens the database
+func CheckAccess(cmd *cobra.Command) error {
+	if !cmd.Runnable() || isBuiltinCommand(cmd) {
+		return nil
+	}
+
+	if IsReadOnly() && !isReadOnlyCommand(cmd) {
+		reason := "read-only mode"
+		if !GlobalConfig.ReadOnly {
+			reason = "the reader role"
+		}
+		return NewError(ErrCodePermission,
+			fmt.Sprintf("'%s' changes the database and is not allowed in %s", cmd.CommandPath(), reason)).
+			WithHint("Read-only mode is set by --read-only, or read_only and role in .sharkconfig.json")
+	}
+
+	if GlobalConfig.Role == RoleContributor {
+		if hasAnnotation(cmd, DestructiveAnnotation) {
+			return NewError(ErrCodePermission,
+				fmt.Sprintf("'%s' requires the admin role", cmd.CommandPath())).
+				WithHint("Ask an admin to run it, or set role in .sharkconfig.json")
+		}
+		if force := cmd.Flags().Lookup("force"); force != nil && force.Changed {
+			return NewError(ErrCodePermission,
+				fmt.Sprintf("'%s --force' requires the admin role", cmd.CommandPath())).
+				WithHint("Run it without --force, or ask an admin")
+		}
+	}
+
+	return nil
+}
+
+// isReadOnlyCommand reports whether cmd is annotated read-only and none of
+// its writing flags are set
+func isReadOnlyCommand(cmd *cobra.Command) bool {
+	if !hasAnnotation(cmd, ReadOnlyAnnotation) {
+		return false
+	}
+	for _, name := range strings.Split(cmd.Annotations[WritingFlagsAnnotation], ",") {
+		if flag := cmd.Flags().Lookup(strings.TrimSpace(name)); flag != nil && flag.Changed {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAnnotation reports whether cmd or one of its parents is annotated
+func hasAnnotation(cmd *cobra.Command, annotation string) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[annotation] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// isBuiltinCommand reports whether cmd is one of cobra's help and completion
+// commands, which never touch the database
+func isBuiltinCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccess(t *testing.T) {
+	saved := *GlobalConfig
+	t.Cleanup(func() { *GlobalConfig = saved })
+
+	run := func(cmd *cobra.Command, args []string) {}
+	root := &cobra.Command{Use: "shark"}
+	report := &cobra.Command{Use: "report", Annotations: map[string]string{ReadOnlyAnnotation: "true"}}
+	burndown := &cobra.Command{Use: "burndown", Run: run}
+	doctor := &cobra.Command{Use: "doctor", Run: run, Annotations: map[string]string{ReadOnlyAnnotation: "true", WritingFlagsAnnotation: "fix"}}
+	doctor.Flags().Bool("fix", false, "")
+	create := &cobra.Command{Use: "create", Run: run}
+	create.Flags().Bool("force", false, "")
+	remove := &cobra.Command{Use: "delete", Run: run, Annotations: map[string]string{DestructiveAnnotation: "true"}}
+	report.AddCommand(burndown)
+	root.AddCommand(report, doctor, create, remove)
+	root.InitDefaultHelpCmd()
+	help, _, err := root.Find([]string{"help"})
+	require.NoError(t, err)
+
+	check := func(readOnly bool, role string, cmd *cobra.Command) error {
+		GlobalConfig.ReadOnly = readOnly
+		GlobalConfig.Role = role
+		return CheckAccess(cmd)
+	}
+
+	// Admins can run anything
+	assert.NoError(t, check(false, "", create))
+	assert.NoError(t, check(false, RoleAdmin, remove))
+
+	// Read-only mode allows annotated commands, inherited from parents
+	assert.NoError(t, check(true, "", burndown))
+	assert.NoError(t, check(true, "", doctor))
+	assert.NoError(t, check(true, "", help))
+	err = check(true, "", create)
+	require.Error(t, err)
+	assert.Equal(t, ErrCodePermission, AsCommandError(err).Code)
+	assert.Contains(t, err.Error(), "read-only mode")
+	assert.ErrorContains(t, check(false, RoleReader, create), "reader role")
+
+	// A writing flag makes a read-only command write
+	require.NoError(t, doctor.Flags().Set("fix", "true"))
+	assert.Error(t, check(true, "", doctor))
+
+	// Contributors can't delete or force
+	assert.NoError(t, check(false, RoleContributor, create))
+	assert.ErrorContains(t, check(false, RoleContributor, remove), "requires the admin role")
+	require.NoError(t, create.Flags().Set("force", "true"))
+	assert.ErrorContains(t, check(false, RoleContributor, create), "--force' requires the admin role")
+}
+
+func TestValidateRole(t *testing.T) {
+	for _, role := range []string{"", RoleReader, RoleContributor, RoleAdmin} {
+		assert.NoError(t, ValidateRole(role))
+	}
+	assert.ErrorContains(t, ValidateRole("owner"), "must be one of reader, contributor, admin")
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccess_TaskNext(t *testing.T) {
+	saved := *cli.GlobalConfig
+	t.Cleanup(func() {
+		*cli.GlobalConfig = saved
+		for _, name := range []string{"claim", "lease"} {
+			flag := taskNextCmd.Flags().Lookup(name)
+			_ = flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		}
+	})
+	cli.GlobalConfig.ReadOnly = true
+
+	// Finding the next task only reads
+	assert.NoError(t, cli.CheckAccess(taskNextCmd))
+
+	// Claiming starts the task and leasing reserves it, so both write
+	require.NoError(t, taskNextCmd.Flags().Set("claim", "true"))
+	err := cli.CheckAccess(taskNextCmd)
+	require.Error(t, err)
+	assert.Equal(t, cli.ErrCodePermission, cli.AsCommandError(err).Code)
+
+	cli.GlobalConfig.ReadOnly = false
+	cli.GlobalConfig.Role = cli.RoleReader
+	assert.ErrorContains(t, cli.CheckAccess(taskNextCmd), "reader role")
+
+	taskNextCmd.Flags().Lookup("claim").Changed = false
+	require.NoError(t, taskNextCmd.Flags().Set("lease", "5m"))
+	assert.ErrorContains(t, cli.CheckAccess(taskNextCmd), "reader role")
+}
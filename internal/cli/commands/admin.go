@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// adminCmd is the parent command for project-wide maintenance operations
+var adminCmd = &cobra.Command{
+	Use:         "admin",
+	Short:       "Project-wide maintenance operations",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	GroupID:     "setup",
+	Long:        `Maintenance operations that rewrite the whole project, such as renumbering keys.`,
+}
+
+// adminRenumberCmd renumbers epics, features, and tasks contiguously
+var adminRenumberCmd = &cobra.Command{
+	Use:   "renumber",
+	Short: "Renumber epic, feature, and task keys contiguously",
+	Long: `Renumber keys that have become sparse after deletions (E02, E07, E11) so they
+run contiguously: epics become E01..En, each epic's features F01..Fn, and each
+feature's tasks 001..n, keeping their current order.
+
+Everything that refers to a key follows it: task depends_on lists, the audit
+log, undo journal, progress history, idea conversion links, and the search
+index. Epic, feature, and task directories and markdown files are renamed, and
+the *_key frontmatter in moved files is updated. Trashed and archived entities
+keep their place in the numbering.
+
+The database changes are made in a single transaction after a mandatory
+backup, so renumbering is only available for local databases. Use --dry-run to
+preview the new keys and get a confirmation token.
+
+Examples:
+  shark admin renumber --dry-run
+  shark admin renumber --confirm=<token>`,
+	Args: cobra.NoArgs,
+	RunE: runAdminRenumber,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminRenumberCmd)
+
+	addConfirmationFlags(adminRenumberCmd)
+}
+
+// runAdminRenumber handles the admin renumber command
+func runAdminRenumber(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(2 * time.Minute)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	renumberRepo := repository.NewRenumberRepository(repoDb)
+	plan, err := renumberRepo.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to plan renumber: %w", err)
+	}
+
+	if len(plan.Changes) == 0 {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"changes": []*repository.KeyChange{}})
+		}
+		cli.Success("Keys are already contiguous; nothing to renumber")
+		return nil
+	}
+
+	op := renumberOperation(plan)
+	if isDryRun(cmd) {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{
+				"dry_run":            true,
+				"changes":            plan.Changes,
+				"confirmation_token": op.Token(),
+			})
+		}
+		displayRenumberPlan(plan)
+		fmt.Println()
+		fmt.Printf("Confirmation token: %s\n", op.Token())
+		cli.Info(fmt.Sprintf("Re-run with --confirm=%s to execute", op.Token()))
+		return nil
+	}
+
+	if err := verifyConfirmationToken(cmd, op); err != nil {
+		return err
+	}
+
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return fmt.Errorf("renumbering requires a backup: %w", err)
+	}
+	backupPath, err := createDatabaseBackup(dbPath, "admin renumber")
+	if err != nil {
+		return fmt.Errorf("failed to create backup before renumbering (nothing changed): %w", err)
+	}
+
+	renames := planRenumberRenames(plan)
+	done, err := applyRenumberRenames(projectRoot, renames)
+	if err != nil {
+		undoRenumberRenames(projectRoot, done)
+		return fmt.Errorf("failed to rename files (nothing changed): %w", err)
+	}
+
+	if err := renumberRepo.Apply(ctx, plan); err != nil {
+		undoRenumberRenames(projectRoot, done)
+		return fmt.Errorf("failed to renumber (files restored; database backup at %s): %w", backupPath, err)
+	}
+
+	var frontmatterErrors []string
+	for _, change := range plan.Changes {
+		if change.NewFilePath == nil || !strings.HasSuffix(*change.NewFilePath, ".md") {
+			continue
+		}
+		if err := rewriteFrontmatterKeys(resolveProjectPath(projectRoot, *change.NewFilePath), plan.RewriteName); err != nil {
+			frontmatterErrors = append(frontmatterErrors, fmt.Sprintf("%s: %v", *change.NewFilePath, err))
+		}
+	}
+
+	for _, change := range plan.Changes {
+		if change.OldKey == change.NewKey {
+			continue
+		}
+		recordAudit(ctx, repoDb, &models.AuditEntry{
+			EntityType: change.EntityType,
+			EntityKey:  change.NewKey,
+			Action:     models.AuditActionUpdate,
+			Summary:    fmt.Sprintf("Renumbered from %s", change.OldKey),
+			Changes:    map[string]models.AuditChange{"key": {Old: change.OldKey, New: change.NewKey}},
+		})
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"changes":            plan.Changes,
+			"files_renamed":      len(done),
+			"backup_path":        backupPath,
+			"frontmatter_errors": frontmatterErrors,
+		})
+	}
+
+	displayRenumberPlan(plan)
+	cli.Success(fmt.Sprintf("Renumbered %d entities and renamed %d files or directories", len(plan.Changes), len(done)))
+	cli.Info(fmt.Sprintf("Database backup created: %s", backupPath))
+	for _, message := range frontmatterErrors {
+		cli.Warning(fmt.Sprintf("Failed to update frontmatter in %s", message))
+	}
+	return nil
+}
+
+// renumberOperation describes a renumber for confirmation tokens
+func renumberOperation(plan *repository.RenumberPlan) *DestructiveOperation {
+	affected := make([]string, len(plan.Changes))
+	for i, change := range plan.Changes {
+		affected[i] = fmt.Sprintf("%s->%s", change.OldKey, change.NewKey)
+	}
+	return &DestructiveOperation{
+		Operation: "admin",
+		Key:       "renumber",
+		Summary:   fmt.Sprintf("Would renumber %d epics, features, and tasks", len(plan.Changes)),
+		Affected:  affected,
+	}
+}
+
+// displayRenumberPlan prints the key and file changes
+func displayRenumberPlan(plan *repository.RenumberPlan) {
+	rows := make([][]string, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		file := ""
+		if change.NewFilePath != nil && *change.NewFilePath != *change.OldFilePath {
+			file = *change.NewFilePath
+		}
+		rows = append(rows, []string{change.EntityType, change.OldKey, change.NewKey, file})
+	}
+	cli.OutputTable([]string{"Type", "Old Key", "New Key", "New File"}, rows)
+}
+
+// renumberRename renames the last segment of a path (in its pre-renumber form)
+type renumberRename struct {
+	from string // Path before any renames
+	to   string // New name for the last segment
+}
+
+// planRenumberRenames breaks file path changes into renames of single path
+// segments. They are ordered deepest first, so a task file is renamed inside
+// its feature directory before that directory is renamed, and in ascending
+// order within a level, so E02 -> E01 frees E02 before E03 -> E02.
+func planRenumberRenames(plan *repository.RenumberPlan) []renumberRename {
+	seen := make(map[string]bool)
+	var renames []renumberRename
+	for _, change := range plan.Changes {
+		if change.OldFilePath == nil || change.NewFilePath == nil || *change.OldFilePath == *change.NewFilePath {
+			continue
+		}
+		oldSegments := strings.Split(*change.OldFilePath, "/")
+		newSegments := strings.Split(*change.NewFilePath, "/")
+		for i := range oldSegments {
+			from := strings.Join(oldSegments[:i+1], "/")
+			if oldSegments[i] == newSegments[i] || seen[from] {
+				continue
+			}
+			seen[from] = true
+			renames = append(renames, renumberRename{from: from, to: newSegments[i]})
+		}
+	}
+
+	sort.Slice(renames, func(i, j int) bool {
+		di, dj := strings.Count(renames[i].from, "/"), strings.Count(renames[j].from, "/")
+		if di != dj {
+			return di > dj
+		}
+		return renames[i].from < renames[j].from
+	})
+	return renames
+}
+
+// applyRenumberRenames performs the renames, returning those that were made.
+// Paths that no longer exist are skipped; an existing target is an error.
+func applyRenumberRenames(projectRoot string, renames []renumberRename) ([]renumberRename, error) {
+	var done []renumberRename
+	for _, rename := range renames {
+		from := resolveProjectPath(projectRoot, rename.from)
+		if _, err := os.Stat(from); os.IsNotExist(err) {
+			continue
+		}
+		to := filepath.Join(filepath.Dir(from), rename.to)
+		if _, err := os.Stat(to); err == nil {
+			return done, fmt.Errorf("cannot rename %s: %s already exists", rename.from, to)
+		}
+		if err := os.Rename(from, to); err != nil {
+			return done, err
+		}
+		done = append(done, rename)
+	}
+	return done, nil
+}
+
+// undoRenumberRenames reverts completed renames in reverse order
+func undoRenumberRenames(projectRoot string, done []renumberRename) {
+	for i := len(done) - 1; i >= 0; i-- {
+		from := resolveProjectPath(projectRoot, done[i].from)
+		to := filepath.Join(filepath.Dir(from), done[i].to)
+		if err := os.Rename(to, from); err != nil {
+			cli.Warning(fmt.Sprintf("Failed to restore %s: %v", done[i].from, err))
+		}
+	}
+}
+
+// resolveProjectPath makes a stored file path absolute
+func resolveProjectPath(projectRoot, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(projectRoot, filepath.FromSlash(path))
+}
+
+var frontmatterKeyLine = regexp.MustCompile(`^(\s*(?:\w+_)?key:\s*)(\S+)(\s*)$`)
+
+// rewriteFrontmatterKeys rewrites key, epic_key, feature_key, and task_key
+// values in a markdown file's frontmatter. Missing files are ignored.
+func rewriteFrontmatterKeys(path string, rewrite func(string) string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	changed := false
+	for i := 1; i < len(lines) && lines[i] != "---"; i++ {
+		match := frontmatterKeyLine.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		if value := rewrite(match[2]); value != match[2] {
+			lines[i] = match[1] + value + match[3]
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")), info.Mode().Perm())
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenumberRenames(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	plan := &repository.RenumberPlan{Changes: []*repository.KeyChange{
+		{OldKey: "E03", NewKey: "E02", OldFilePath: strPtr("docs/plan/E03-b/epic.md"), NewFilePath: strPtr("docs/plan/E02-b/epic.md")},
+		{OldKey: "E02", NewKey: "E01", OldFilePath: strPtr("docs/plan/E02-a/epic.md"), NewFilePath: strPtr("docs/plan/E01-a/epic.md")},
+		{OldKey: "T-E03-F02-004", NewKey: "T-E02-F01-001",
+			OldFilePath: strPtr("docs/plan/E03-b/E03-F02-x/tasks/T-E03-F02-004.md"),
+			NewFilePath: strPtr("docs/plan/E02-b/E02-F01-x/tasks/T-E02-F01-001.md")},
+	}}
+
+	renames := planRenumberRenames(plan)
+	assert.Equal(t, []renumberRename{
+		{from: "docs/plan/E03-b/E03-F02-x/tasks/T-E03-F02-004.md", to: "T-E02-F01-001.md"},
+		{from: "docs/plan/E03-b/E03-F02-x", to: "E02-F01-x"},
+		{from: "docs/plan/E02-a", to: "E01-a"},
+		{from: "docs/plan/E03-b", to: "E02-b"},
+	}, renames)
+
+	root := t.TempDir()
+	for _, path := range []string{"docs/plan/E02-a/epic.md", "docs/plan/E03-b/epic.md", "docs/plan/E03-b/E03-F02-x/tasks/T-E03-F02-004.md"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte("x"), 0644))
+	}
+
+	done, err := applyRenumberRenames(root, renames)
+	require.NoError(t, err)
+	assert.Len(t, done, 4)
+	for _, change := range plan.Changes {
+		assert.FileExists(t, filepath.Join(root, *change.NewFilePath))
+	}
+
+	undoRenumberRenames(root, done)
+	for _, change := range plan.Changes {
+		assert.FileExists(t, filepath.Join(root, *change.OldFilePath))
+	}
+}
+
+func TestRewriteFrontmatterKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "T-E05-F01-003.md")
+	content := "---\nkey: T-E05-F01-004\nfeature_key: E05-F01\ndepends_on: T-E05-F01-004\n---\n\nkey: T-E05-F01-004\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	rewrite := func(key string) string {
+		if key == "T-E05-F01-004" {
+			return "T-E05-F01-003"
+		}
+		return key
+	}
+	require.NoError(t, rewriteFrontmatterKeys(path, rewrite))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nkey: T-E05-F01-003\nfeature_key: E05-F01\ndepends_on: T-E05-F01-004\n---\n\nkey: T-E05-F01-004\n", string(data),
+		"only key fields in the frontmatter change")
+}
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// AgentListJSON is one agent in the JSON output of agent list
+type AgentListJSON struct {
+	*models.Agent
+	InProgress int      `json:"in_progress"`
+	Tasks      []string `json:"tasks"` // Keys of the in-progress tasks it holds
+}
+
+// agentCmd is the parent command for the agent registry
+var agentCmd = &cobra.Command{
+	Use:     "agent",
+	Short:   "Register agents and track the tasks they hold",
+	GroupID: "setup",
+	Long: `Agents register once under a name, then identify themselves with that name:
+with --agent on task start, --agent-id on task next --claim, or the SHARK_AGENT
+environment variable. When a registered agent starts or claims a task, the task
+records the agent in assigned_agent and the agent is marked seen.
+
+Use 'shark status --by-agent' for the workload of each agent.
+
+Examples:
+  shark agent register worker-1 --type=backend --capabilities=go,sql
+  shark agent list
+  SHARK_AGENT=worker-1 shark agent heartbeat`,
+}
+
+// agentRegisterCmd registers an agent
+var agentRegisterCmd = &cobra.Command{
+	Use:   "register <name>",
+	Short: "Register an agent",
+	Long: `Register an agent under a name. Registering a name again replaces its type and
+capabilities.
+
+Examples:
+  shark agent register worker-1 --type=backend
+  shark agent register reviewer --capabilities=go,security`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentRegister,
+}
+
+// agentListCmd lists registered agents
+var agentListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List registered agents",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List registered agents with their type, capabilities, the tasks they have in
+progress, and when they were last seen.
+
+Examples:
+  shark agent list
+  shark agent list --json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentList,
+}
+
+// agentHeartbeatCmd marks an agent seen
+var agentHeartbeatCmd = &cobra.Command{
+	Use:   "heartbeat [name]",
+	Short: "Mark a registered agent as seen",
+	Long: `Mark a registered agent as seen now. The name defaults to SHARK_AGENT, else
+the USER environment variable.
+
+Examples:
+  shark agent heartbeat worker-1
+  SHARK_AGENT=worker-1 shark agent heartbeat`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAgentHeartbeat,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(agentCmd)
+	agentCmd.AddCommand(agentRegisterCmd)
+	agentCmd.AddCommand(agentListCmd)
+	agentCmd.AddCommand(agentHeartbeatCmd)
+
+	agentRegisterCmd.Flags().String("type", "", "Agent type, matched against task agent types (e.g. backend)")
+	agentRegisterCmd.Flags().StringSlice("capabilities", nil, "Comma-separated capabilities (e.g. go,sql)")
+}
+
+// runAgentRegister handles the agent register command
+func runAgentRegister(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	agent := &models.Agent{Name: strings.TrimSpace(args[0])}
+	if agentType, _ := cmd.Flags().GetString("type"); strings.TrimSpace(agentType) != "" {
+		agentType = strings.TrimSpace(agentType)
+		agent.AgentType = &agentType
+	}
+	agent.Capabilities, _ = cmd.Flags().GetStringSlice("capabilities")
+	if err := models.ValidateAgentName(agent.Name); err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	if err := repository.NewAgentRepository(repoDb).Register(ctx, agent); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(agent)
+	}
+	cli.Success(fmt.Sprintf("Registered agent %s", agent.Name))
+	cli.Info("Set SHARK_AGENT=%s so tasks it starts or claims record it", agent.Name)
+	return nil
+}
+
+// runAgentList handles the agent list command
+func runAgentList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	repo := repository.NewAgentRepository(repoDb)
+	agents, err := repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	workloads, err := repo.Workloads(ctx)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]*models.AgentWorkload, len(workloads))
+	for _, workload := range workloads {
+		byName[workload.Agent] = workload
+	}
+
+	result := make([]*AgentListJSON, len(agents))
+	for i, agent := range agents {
+		result[i] = &AgentListJSON{Agent: agent, Tasks: []string{}}
+		if workload, ok := byName[agent.Name]; ok {
+			result[i].InProgress = workload.InProgress
+			result[i].Tasks = workload.Tasks
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+
+	if len(result) == 0 {
+		cli.Info("No agents registered. Register one with 'shark agent register <name>'")
+		return nil
+	}
+
+	headers := []string{"Name", "Type", "Capabilities", "In Progress", "Last Seen"}
+	rows := make([][]string, len(result))
+	for i, agent := range result {
+		agentType := "-"
+		if agent.AgentType != nil {
+			agentType = *agent.AgentType
+		}
+		capabilities := "-"
+		if len(agent.Capabilities) > 0 {
+			capabilities = strings.Join(agent.Capabilities, ", ")
+		}
+		inProgress := strconv.Itoa(agent.InProgress)
+		if len(agent.Tasks) > 0 {
+			inProgress += " (" + strings.Join(agent.Tasks, ", ") + ")"
+		}
+		rows[i] = []string{agent.Name, agentType, capabilities, inProgress, utils.FormatRelativeTime(agent.LastSeen)}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// runAgentHeartbeat handles the agent heartbeat command
+func runAgentHeartbeat(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	name := ""
+	if len(args) > 0 {
+		name = strings.TrimSpace(args[0])
+	}
+	name = getAgentIdentifier(name)
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	if err := repository.NewAgentRepository(repoDb).Heartbeat(ctx, name); err != nil {
+		if errors.Is(err, repository.ErrAgentNotFound) {
+			return cli.NewError(cli.ErrCodeNotFound, err.Error()).
+				WithHint(fmt.Sprintf("Register it first with 'shark agent register %s'", name))
+		}
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{"name": name, "seen": true})
+	}
+	cli.Success(fmt.Sprintf("Agent %s seen", name))
+	return nil
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// aliasCmd is the parent command for task aliases
+var aliasCmd = &cobra.Command{
+	Use:     "alias",
+	Short:   "Manage short names for tasks",
+	GroupID: "details",
+	Long: `Aliases are short names that task commands accept in place of a task key.
+They are stored in the database, so the whole team shares them, and they
+follow a task when it is moved.
+
+Task commands also accept, without an alias, a task number (003, F01-003) or
+a task slug (build-login) when it matches a single task; see 'shark focus'.
+
+Examples:
+  shark alias add login T-E05-F01-003
+  shark task start login
+  shark alias list
+  shark alias remove login`,
+}
+
+// aliasAddCmd adds an alias
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <alias> <task-key>",
+	Short: "Add a short name for a task",
+	Long: `Add an alias for a task. Aliases are lowercase letters, digits, _ and -,
+start with a letter, and can't look like a task key or task number.
+
+Examples:
+  shark alias add login T-E05-F01-003
+  shark alias add login E05-F01-003`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAliasAdd,
+}
+
+// aliasListCmd lists aliases
+var aliasListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List task aliases",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List every alias with the key of its task.
+
+Examples:
+  shark alias list
+  shark alias list --json`,
+	Args: cobra.NoArgs,
+	RunE: runAliasList,
+}
+
+// aliasRemoveCmd removes an alias
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove <alias>",
+	Short: "Remove a task alias",
+	Long: `Remove an alias. The task is not affected.
+
+Examples:
+  shark alias remove login`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAliasRemove,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasAddCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+}
+
+// runAliasAdd handles the alias add command
+func runAliasAdd(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	alias, err := models.NormalizeTaskAlias(args[0])
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+	taskKey, err := ResolveTaskKey(cmd, args[1])
+	if err != nil {
+		return err
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	task, err := repository.NewTaskRepository(repoDb).GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found", taskKey)
+	}
+	if err := repository.NewTaskAliasRepository(repoDb).Create(ctx, alias, task.ID); err != nil {
+		return cli.NewError(cli.ErrCodeConflict, err.Error()).
+			WithHint(fmt.Sprintf("Use 'shark alias remove %s' first to point it at another task", alias))
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(&models.TaskAlias{Alias: alias, TaskID: task.ID, TaskKey: task.Key, CreatedAt: time.Now().UTC()})
+	}
+	cli.Success(fmt.Sprintf("Alias %s now points at %s", alias, task.Key))
+	return nil
+}
+
+// runAliasList handles the alias list command
+func runAliasList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	aliases, err := repository.NewTaskAliasRepository(repoDb).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(aliases)
+	}
+
+	if len(aliases) == 0 {
+		cli.Info("No aliases found")
+		return nil
+	}
+
+	headers := []string{"Alias", "Task"}
+	rows := make([][]string, len(aliases))
+	for i, alias := range aliases {
+		rows[i] = []string{alias.Alias, alias.TaskKey}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// runAliasRemove handles the alias remove command
+func runAliasRemove(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	alias, err := models.NormalizeTaskAlias(args[0])
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	if err := repository.NewTaskAliasRepository(repoDb).Delete(ctx, alias); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{"alias": alias, "removed": true})
+	}
+	cli.Success(fmt.Sprintf("Alias %s removed", alias))
+	return nil
+}
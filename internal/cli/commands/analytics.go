@@ -1,9 +1,7 @@
 package commands
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
@@ -13,9 +11,10 @@ import (
 
 // analyticsCmd represents the analytics command group
 var analyticsCmd = &cobra.Command{
-	Use:     "analytics",
-	Short:   "Analyze work session patterns and metrics",
-	GroupID: "status",
+	Use:         "analytics",
+	Short:       "Analyze work session patterns and metrics",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
 	Long: `Analyze work session patterns across epics, features, and tasks.
 
 Provides insights into:
@@ -43,7 +42,7 @@ func init() {
 
 // runAnalytics executes the analytics command
 func runAnalytics(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	sessionDuration, _ := cmd.Flags().GetBool("session-duration")
@@ -54,14 +53,12 @@ func runAnalytics(cmd *cobra.Command, args []string) error {
 
 	// Validate: at least one analysis type must be selected
 	if !sessionDuration && !pauseFrequency {
-		cli.Error("Please specify at least one analysis type: --session-duration or --pause-frequency")
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, "Please specify at least one analysis type: --session-duration or --pause-frequency")
 	}
 
 	// Validate: epic or feature must be specified
 	if epicKey == "" && featureKey == "" {
-		cli.Error("Please specify --epic or --feature for analysis scope")
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, "Please specify --epic or --feature for analysis scope")
 	}
 
 	// Get database connection
@@ -85,8 +82,7 @@ func runAnalytics(cmd *cobra.Command, args []string) error {
 		// Feature-level analytics
 		feature, err := featureRepo.GetByKey(ctx, featureKey)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Feature %s not found", featureKey))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Feature %s not found", featureKey))
 		}
 
 		var agentTypePtr *string
@@ -108,8 +104,7 @@ func runAnalytics(cmd *cobra.Command, args []string) error {
 		// Epic-level analytics
 		epic, err := epicRepo.GetByKey(ctx, epicKey)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Epic %s not found", epicKey))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Epic %s not found", epicKey))
 		}
 
 		var agentTypePtr *string
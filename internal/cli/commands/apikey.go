@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// APIKeyCreateJSON is the JSON output of apikey create. Key is only ever
+// shown here.
+type APIKeyCreateJSON struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// apikeyCmd is the parent command for API keys
+var apikeyCmd = &cobra.Command{
+	Use:     "apikey",
+	Short:   "Manage API keys for shark serve",
+	GroupID: "setup",
+	Long: `API keys let clients of 'shark serve' and the status server authenticate
+with "Authorization: Bearer <key>". Keys are stored as hashes in the database;
+a key is shown once, when it is created.
+
+Keys with read scope can only call endpoints that don't change data. Revoked
+keys stop working at once and stay listed with their last use.
+
+Examples:
+  shark apikey create --name=ci --scope=read
+  shark apikey list
+  shark apikey revoke ci`,
+}
+
+// apikeyCreateCmd creates an API key
+var apikeyCreateCmd = &cobra.Command{
+	Use:         "create",
+	Short:       "Create an API key",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"}, // Admins only
+	Long: `Create an API key and print it. Store it right away: only its hash is kept.
+
+Examples:
+  shark apikey create --name=ci --scope=read
+  shark apikey create --name=orchestrator --scope=write`,
+	Args: cobra.NoArgs,
+	RunE: runAPIKeyCreate,
+}
+
+// apikeyListCmd lists API keys
+var apikeyListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List API keys",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List API keys with their scope, the start of the key, and when they were
+created, last used, and revoked.
+
+Examples:
+  shark apikey list
+  shark apikey list --json`,
+	Args: cobra.NoArgs,
+	RunE: runAPIKeyList,
+}
+
+// apikeyRevokeCmd revokes an API key
+var apikeyRevokeCmd = &cobra.Command{
+	Use:         "revoke <name>",
+	Short:       "Revoke an API key",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"}, // Admins only
+	Long: `Revoke an API key. Clients using it are rejected from their next call.
+
+Examples:
+  shark apikey revoke ci`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAPIKeyRevoke,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(apikeyCmd)
+	apikeyCmd.AddCommand(apikeyCreateCmd)
+	apikeyCmd.AddCommand(apikeyListCmd)
+	apikeyCmd.AddCommand(apikeyRevokeCmd)
+
+	apikeyCreateCmd.Flags().String("name", "", "Name of the key, e.g. the client using it (required)")
+	apikeyCreateCmd.Flags().String("scope", string(models.APIKeyScopeRead), "Scope: read, or write to also change data")
+	_ = apikeyCreateCmd.MarkFlagRequired("name")
+}
+
+// runAPIKeyCreate handles the apikey create command
+func runAPIKeyCreate(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	nameFlag, _ := cmd.Flags().GetString("name")
+	scope, _ := cmd.Flags().GetString("scope")
+	name, err := models.NormalizeAPIKeyName(nameFlag)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+	if err := models.ValidateAPIKeyScope(scope); err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	key, secret, err := repository.NewAPIKeyRepository(repoDb).Create(ctx, name, models.APIKeyScope(scope))
+	if err != nil {
+		return cli.NewError(cli.ErrCodeConflict, err.Error()).
+			WithHint(fmt.Sprintf("Pick another name, or 'shark apikey revoke %s' and use a new one", name))
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(&APIKeyCreateJSON{APIKey: key, Key: secret})
+	}
+	cli.Success(fmt.Sprintf("Created %s API key %s", key.Scope, key.Name))
+	fmt.Println(secret)
+	cli.Warning("Store this key now: it can't be shown again")
+	return nil
+}
+
+// runAPIKeyList handles the apikey list command
+func runAPIKeyList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	keys, err := repository.NewAPIKeyRepository(repoDb).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(keys)
+	}
+
+	if len(keys) == 0 {
+		cli.Info("No API keys found")
+		return nil
+	}
+
+	headers := []string{"Name", "Scope", "Key", "Created", "Last Used", "Revoked"}
+	rows := make([][]string, len(keys))
+	for i, key := range keys {
+		rows[i] = []string{key.Name, string(key.Scope), key.Prefix + "...", formatAPIKeyTime(&key.CreatedAt), formatAPIKeyTime(key.LastUsedAt), formatAPIKeyTime(key.RevokedAt)}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// runAPIKeyRevoke handles the apikey revoke command
+func runAPIKeyRevoke(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	name, err := models.NormalizeAPIKeyName(args[0])
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	if err := repository.NewAPIKeyRepository(repoDb).Revoke(ctx, name); err != nil {
+		return cli.NewError(cli.ErrCodeNotFound, err.Error()).WithHint("Use 'shark apikey list' to see active keys")
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{"name": name, "revoked": true})
+	}
+	cli.Success(fmt.Sprintf("API key %s revoked", name))
+	return nil
+}
+
+// formatAPIKeyTime formats an API key timestamp for the list table, "-" if unset
+func formatAPIKeyTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
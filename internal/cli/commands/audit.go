@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// auditCmd represents the audit command group
+var auditCmd = &cobra.Command{
+	Use:         "audit",
+	Short:       "Review the audit log",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
+	Long: `Review what agents and users changed and when.
+
+The audit log records epic, feature, and task creations, updates (with the
+changed fields), deletions and restores, file reassignments, and forced
+operations. Status transitions are in 'shark history'.`,
+}
+
+// auditListCmd lists audit log entries
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List audit log entries",
+	Long: `List audit log entries, newest first.
+
+--entity matches the entity and everything under it: an epic key includes its
+features and tasks, a feature key includes its tasks. --since takes a duration
+(12h, 7d, 2w) or a date (YYYY-MM-DD or RFC3339).
+
+Examples:
+  shark audit list
+  shark audit list --entity=E05 --since=7d
+  shark audit list --actor=backend-agent --action=update
+  shark audit list --entity=T-E05-F01-003 --json`,
+	Args: cobra.NoArgs,
+	RunE: runAuditList,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditListCmd)
+
+	auditListCmd.Flags().String("entity", "", "Only include this epic, feature, or task and everything under it")
+	auditListCmd.Flags().String("action", "", "Only include this action (create, update, delete, restore, file_reassign, force)")
+	auditListCmd.Flags().String("actor", "", "Only include changes made by this agent or user")
+	auditListCmd.Flags().String("since", "", "Only include changes since a duration ago (7d) or a date (YYYY-MM-DD)")
+	auditListCmd.Flags().Int("limit", 50, "Maximum number of entries (0 for all)")
+}
+
+// runAuditList executes the audit list command
+func runAuditList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	entity, _ := cmd.Flags().GetString("entity")
+	action, _ := cmd.Flags().GetString("action")
+	actor, _ := cmd.Flags().GetString("actor")
+	since, _ := cmd.Flags().GetString("since")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	filter := repository.AuditFilter{Limit: limit}
+	if entity != "" {
+		key := NormalizeKey(entity)
+		if taskKey, err := NormalizeTaskKey(entity); err == nil {
+			key = taskKey
+		}
+		filter.EntityKey = &key
+	}
+	if action != "" {
+		filter.Action = &action
+	}
+	if actor != "" {
+		filter.Actor = &actor
+	}
+	if since != "" {
+		sinceTime, err := parseAuditSince(since, time.Now())
+		if err != nil {
+			return err
+		}
+		filter.Since = &sinceTime
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	entries, err := repository.NewAuditLogRepository(repoDb).List(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(entries)
+	}
+
+	if len(entries) == 0 {
+		cli.Info("No audit entries found")
+		return nil
+	}
+
+	headers := []string{"When", "Actor", "Action", "Type", "Key", "Details"}
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		details := e.Summary
+		if len(e.Changes) > 0 {
+			details = formatAuditChanges(e.Changes)
+		}
+		rows[i] = []string{
+			e.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			e.Actor,
+			e.Action,
+			e.EntityType,
+			e.EntityKey,
+			details,
+		}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// recordAudit stores an audit entry, attributing it to the current user when
+// no actor is given. Failures only warn: the audited change has already been made.
+func recordAudit(ctx context.Context, repoDb *repository.DB, entry *models.AuditEntry) {
+	if entry.Actor == "" {
+		entry.Actor = getAgentIdentifier("")
+	}
+	if err := repository.NewAuditLogRepository(repoDb).Record(ctx, entry); err != nil {
+		message := fmt.Sprintf("Failed to record audit entry: %v", err)
+		if cli.GlobalConfig.JSON {
+			fmt.Fprintln(os.Stderr, "Warning: "+message)
+			return
+		}
+		cli.Warning(message)
+	}
+}
+
+// recordAuditUpdate records the fields of an entity that changed since before
+// was captured with auditFields. Nothing is recorded if no field changed.
+func recordAuditUpdate(ctx context.Context, repoDb *repository.DB, entityType, key string, before map[string]interface{}, after interface{}) {
+	changes := auditChanges(before, auditFields(after))
+	if len(changes) == 0 {
+		return
+	}
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: entityType, EntityKey: key, Action: models.AuditActionUpdate, Changes: changes})
+}
+
+// recordForcedTransition records a task status change that bypassed workflow
+// validation with --force
+func recordForcedTransition(ctx context.Context, repoDb *repository.DB, task *models.Task, newStatus models.TaskStatus, agent string) {
+	recordAudit(ctx, repoDb, &models.AuditEntry{
+		EntityType: models.AuditEntityTask,
+		EntityKey:  task.Key,
+		Action:     models.AuditActionForce,
+		Summary:    fmt.Sprintf("Forced status from %s to %s", task.Status, newStatus),
+		Actor:      agent,
+	})
+}
+
+// auditFields captures an entity's fields by their JSON names, for diffing
+// with auditChanges. Returns nil if the entity can't be encoded.
+func auditFields(entity interface{}) map[string]interface{} {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// auditIgnoredFields change on every write and are left out of audit diffs
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// auditChanges returns the fields that differ between two auditFields captures
+func auditChanges(before, after map[string]interface{}) map[string]models.AuditChange {
+	changes := make(map[string]models.AuditChange)
+	for field, old := range before {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		if value, ok := after[field]; !ok || !reflect.DeepEqual(old, value) {
+			changes[field] = models.AuditChange{Old: old, New: after[field]}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok && !auditIgnoredFields[field] {
+			changes[field] = models.AuditChange{New: value}
+		}
+	}
+	return changes
+}
+
+// formatAuditChanges renders changes as "field: old -> new" pairs, sorted by field
+func formatAuditChanges(changes map[string]models.AuditChange) string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		change := changes[field]
+		parts[i] = fmt.Sprintf("%s: %s -> %s", field, formatAuditValue(change.Old), formatAuditValue(change.New))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// formatAuditValue renders a changed value for table output
+func formatAuditValue(value interface{}) string {
+	if value == nil {
+		return "(none)"
+	}
+	return fmt.Sprint(value)
+}
+
+// parseAuditSince parses --since: a duration before now (12h, 7d, 2w) or a
+// date (YYYY-MM-DD or RFC3339)
+func parseAuditSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := models.ParseRecurrenceRule(value); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration such as 12h, 7d, or 2w, or a date (YYYY-MM-DD)", value)
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditChanges(t *testing.T) {
+	description := "Old description"
+	before := auditFields(&models.Epic{Key: "E05", Title: "Old", Description: &description, Priority: models.PriorityHigh, UpdatedAt: time.Now()})
+	after := auditFields(&models.Epic{Key: "E05", Title: "New", Priority: models.PriorityHigh, UpdatedAt: time.Now().Add(time.Minute)})
+
+	changes := auditChanges(before, after)
+	assert.Equal(t, models.AuditChange{Old: "Old", New: "New"}, changes["title"])
+	assert.Equal(t, models.AuditChange{Old: "Old description"}, changes["description"], "omitted fields are changed to nil")
+	assert.NotContains(t, changes, "updated_at")
+	assert.NotContains(t, changes, "priority")
+	assert.Len(t, changes, 2)
+
+	assert.Empty(t, auditChanges(before, before))
+}
+
+func TestFormatAuditChanges(t *testing.T) {
+	changes := map[string]models.AuditChange{
+		"title":    {Old: "Old", New: "New"},
+		"priority": {Old: 3.0, New: nil},
+	}
+	assert.Equal(t, "priority: 3 -> (none); title: Old -> New", formatAuditChanges(changes))
+}
+
+func TestParseAuditSince(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseAuditSince("7d", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-7*24*time.Hour), since)
+
+	since, err = parseAuditSince("12h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-12*time.Hour), since)
+
+	since, err = parseAuditSince("2026-01-05T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC), since)
+
+	since, err = parseAuditSince("2026-01-05", now)
+	require.NoError(t, err)
+	assert.Equal(t, 5, since.Day())
+
+	_, err = parseAuditSince("last week", now)
+	assert.ErrorContains(t, err, "--since")
+}
@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/credentials"
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// AuthResultJSON is the JSON output of auth login and auth logout
+type AuthResultJSON struct {
+	Database         string `json:"database"`
+	CredentialsFile  string `json:"credentials_file"`
+	LoggedIn         bool   `json:"logged_in"`
+	RemovedPlaintext bool   `json:"removed_plaintext,omitempty"` // An auth_token was removed from .sharkconfig.json
+	KeySource        string `json:"key_source,omitempty"`        // Where the key is kept: env, keychain, or file (login only)
+}
+
+// authCmd is the parent command for remote database credentials
+var authCmd = &cobra.Command{
+	Use:     "auth",
+	Short:   "Store and clear remote database credentials",
+	GroupID: "setup",
+	Long: `Store Turso auth tokens encrypted, outside the project, so they never sit in
+plaintext in .sharkconfig.json or the shell environment.
+
+Tokens are kept in ~/.shark/credentials.json ($SHARK_HOME overrides the
+directory), one per database, encrypted with AES-256-GCM. The key is kept
+apart from them: $SHARK_CREDENTIALS_KEY (a base64-encoded 32-byte key, e.g.
+from a CI secret) if set, else the OS keychain (macOS Keychain, or the Secret
+Service via secret-tool on Linux), where it is created on first login. Without
+a keychain the key goes in ~/.shark/credentials.key, readable only by you, and
+login warns about it.
+
+Tokens are used when .sharkconfig.json sets no auth_token_file and
+TURSO_AUTH_TOKEN is unset.
+
+Examples:
+  shark auth login
+  shark auth login --url=libsql://mydb-org.turso.io --token-file ~/turso.token
+  turso db tokens create mydb | shark auth login
+  shark auth logout`,
+}
+
+// authLoginCmd stores an auth token
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Store an encrypted auth token for the Turso database",
+	Long: `Store an encrypted auth token for a Turso database: the database in
+.sharkconfig.json unless --url is given.
+
+The token is read, in order, from --token-file, TURSO_AUTH_TOKEN, a plaintext
+auth_token in .sharkconfig.json (which is then removed from the file), or
+standard input (prompted for, without echo, on a terminal). There is no flag
+for the token itself, so it doesn't end up in shell history.
+
+Examples:
+  shark auth login
+  shark auth login --url=libsql://mydb-org.turso.io --token-file ~/turso.token
+  turso db tokens create mydb | shark auth login`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogin,
+}
+
+// authLogoutCmd removes an auth token
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the stored auth token for the Turso database",
+	Long: `Remove the stored auth token for a Turso database: the database in
+.sharkconfig.json unless --url is given. A plaintext auth_token in
+.sharkconfig.json is removed too.
+
+Examples:
+  shark auth logout
+  shark auth logout --url=libsql://mydb-org.turso.io`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogout,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+
+	authLoginCmd.Flags().String("url", "", "Turso database URL (default: database.url in .sharkconfig.json)")
+	authLoginCmd.Flags().String("token-file", "", "Read the token from this file")
+	authLogoutCmd.Flags().String("url", "", "Turso database URL (default: database.url in .sharkconfig.json)")
+}
+
+// runAuthLogin handles the auth login command
+func runAuthLogin(cmd *cobra.Command, args []string) error {
+	databaseURL, configPath, err := authDatabaseURL(cmd)
+	if err != nil {
+		return err
+	}
+
+	token, fromConfig, err := readLoginToken(cmd, configPath)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "no auth token given").
+			WithHint("Pipe it in, e.g. 'turso db tokens create <database> | shark auth login', or use --token-file")
+	}
+	if err := db.ValidateAuthToken(token); err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	store, err := credentials.DefaultStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Save(databaseURL, token); err != nil {
+		return fmt.Errorf("failed to store auth token: %w", err)
+	}
+	database, _ := credentials.NormalizeDatabase(databaseURL)
+	keySource, err := store.KeySource()
+	if err != nil {
+		return err
+	}
+
+	// Once the token is encrypted, a plaintext copy has no reason to stay
+	removed := false
+	if configPath != "" {
+		if removed, err = removePlaintextAuthToken(configPath); err != nil {
+			return err
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(&AuthResultJSON{Database: database, CredentialsFile: store.Path(), LoggedIn: true, RemovedPlaintext: removed, KeySource: string(keySource)})
+	}
+	cli.Success(fmt.Sprintf("Stored auth token %s for %s", maskToken(token), database))
+	cli.Info("Encrypted in: %s", store.Path())
+	if keySource == credentials.KeySourceFile {
+		cli.Warning(fmt.Sprintf("The key that decrypts stored tokens is in %s, not an OS keychain: anyone who can read that file can use the tokens. Install a keychain (secret-tool on Linux) or set %s to keep the key elsewhere.", store.KeyPath(), credentials.KeyEnv))
+	}
+	if removed {
+		if fromConfig {
+			cli.Info("Moved the plaintext auth_token out of %s", configPath)
+		} else {
+			cli.Info("Removed the plaintext auth_token from %s", configPath)
+		}
+	}
+	if os.Getenv("TURSO_AUTH_TOKEN") != "" {
+		cli.Warning("TURSO_AUTH_TOKEN is set and takes precedence over the stored token; unset it to use the stored one")
+	}
+	return nil
+}
+
+// runAuthLogout handles the auth logout command
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	databaseURL, configPath, err := authDatabaseURL(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, err := credentials.DefaultStore()
+	if err != nil {
+		return err
+	}
+	deleted, err := store.Delete(databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to remove auth token: %w", err)
+	}
+	database, _ := credentials.NormalizeDatabase(databaseURL)
+
+	removed := false
+	if configPath != "" {
+		if removed, err = removePlaintextAuthToken(configPath); err != nil {
+			return err
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(&AuthResultJSON{Database: database, CredentialsFile: store.Path(), LoggedIn: false, RemovedPlaintext: removed})
+	}
+	if deleted {
+		cli.Success(fmt.Sprintf("Removed stored auth token for %s", database))
+	} else {
+		cli.Info("No stored auth token for %s", database)
+	}
+	if removed {
+		cli.Info("Removed the plaintext auth_token from %s", configPath)
+	}
+	return nil
+}
+
+// authDatabaseURL returns the database URL from --url, else the Turso database
+// in .sharkconfig.json. configPath is .sharkconfig.json when it configures
+// that database, else "", so its plaintext auth_token is only touched for it.
+func authDatabaseURL(cmd *cobra.Command) (databaseURL, configPath string, err error) {
+	configURL := ""
+	if path, err := cli.GetConfigPath(); err == nil {
+		if _, statErr := os.Stat(path); statErr == nil {
+			dbConfig, err := cli.GetDatabaseConfig(path)
+			if err != nil {
+				return "", "", err
+			}
+			if dbConfig.Backend == "turso" {
+				configURL, configPath = dbConfig.URL, path
+			}
+		}
+	}
+
+	databaseURL, _ = cmd.Flags().GetString("url")
+	if databaseURL == "" {
+		if configURL == "" {
+			return "", "", cli.NewError(cli.ErrCodeInvalidArgument, "no Turso database configured").
+				WithHint("Pass --url=libsql://<database>, or run 'shark cloud init' first")
+		}
+		return configURL, configPath, nil
+	}
+
+	if err := validateTursoURL(databaseURL); err != nil {
+		return "", "", cli.NewError(cli.ErrCodeInvalidArgument, fmt.Sprintf("invalid URL: %v", err))
+	}
+	database, err := credentials.NormalizeDatabase(databaseURL)
+	if err != nil {
+		return "", "", cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+	if configured, _ := credentials.NormalizeDatabase(configURL); configured != database {
+		configPath = ""
+	}
+	return databaseURL, configPath, nil
+}
+
+// readLoginToken reads the token for auth login, reporting whether it was
+// the plaintext auth_token in .sharkconfig.json
+func readLoginToken(cmd *cobra.Command, configPath string) (token string, fromConfig bool, err error) {
+	if tokenFile, _ := cmd.Flags().GetString("token-file"); tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read token file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), false, nil
+	}
+	if token := strings.TrimSpace(os.Getenv("TURSO_AUTH_TOKEN")); token != "" {
+		return token, false, nil
+	}
+	if configPath != "" {
+		if token := plaintextAuthToken(configPath); token != "" {
+			return token, true, nil
+		}
+	}
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr, "Turso auth token: ")
+		data, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read token: %w", err)
+		}
+		return strings.TrimSpace(string(data)), false, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read token from standard input: %w", err)
+	}
+	return strings.TrimSpace(string(data)), false, nil
+}
+
+// plaintextAuthToken returns the auth_token in the database section of
+// .sharkconfig.json, written there by older versions of cloud init
+func plaintextAuthToken(configPath string) string {
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil || cfg.RawData == nil {
+		return ""
+	}
+	dbConfig, _ := cfg.RawData["database"].(map[string]interface{})
+	token, _ := dbConfig["auth_token"].(string)
+	return strings.TrimSpace(token)
+}
+
+// removePlaintextAuthToken removes auth_token from the database section of
+// .sharkconfig.json, reporting whether there was one
+func removePlaintextAuthToken(configPath string) (bool, error) {
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil || cfg.RawData == nil {
+		return false, nil
+	}
+	dbConfig, ok := cfg.RawData["database"].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	if _, ok := dbConfig["auth_token"]; !ok {
+		return false, nil
+	}
+	delete(dbConfig, "auth_token")
+	if err := writeConfig(configPath, cfg.RawData); err != nil {
+		return false, fmt.Errorf("failed to remove auth_token from %s: %w", configPath, err)
+	}
+	return true, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/credentials"
 	"github.com/jwwelbor/shark-task-manager/internal/db"
 	"github.com/spf13/cobra"
 )
@@ -31,10 +32,9 @@ var cloudCmd = &cobra.Command{
 
 The cloud command group provides:
   - init: Configure Turso as your database backend
-  - login: Authenticate with Turso
-  - logout: Clear Turso credentials
   - status: Check cloud connection status
-  - sync: Manually trigger cloud synchronization`,
+
+Store and clear Turso auth tokens with 'shark auth login' and 'shark auth logout'.`,
 }
 
 // cloudInitCmd initializes cloud database configuration
@@ -45,7 +45,11 @@ var cloudInitCmd = &cobra.Command{
 
 This command updates .sharkconfig.json to use Turso as the database backend
 instead of local SQLite. You can provide the database URL and authentication
-token via flags or interactive prompts.`,
+token via flags or interactive prompts.
+
+A token given with --auth-token or TURSO_AUTH_TOKEN is encrypted into
+~/.shark/credentials.json (see 'shark auth login'), never written to
+.sharkconfig.json. With --auth-file, only the file's path is recorded.`,
 	Example: `  # Interactive setup with prompts
   shark cloud init
 
@@ -62,8 +66,9 @@ token via flags or interactive prompts.`,
 
 // cloudStatusCmd shows current cloud database configuration status
 var cloudStatusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Show cloud database configuration status",
+	Use:         "status",
+	Short:       "Show cloud database configuration status",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display the current cloud database configuration including backend type,
 connection URL, and authentication status.
 
@@ -106,6 +111,13 @@ func runCloudStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read cloud status: %w", err)
 	}
 
+	if status.IsCloudConfigured {
+		dbConfig := config.DatabaseConfig{URL: status.URL, AuthTokenFile: status.AuthTokenFile}
+		if _, source, err := cli.TursoAuthToken(dbConfig); err == nil {
+			status.AuthSource = source
+		}
+	}
+
 	// Test actual database connection
 	status.ConnectionTested = true
 	repoDb, connErr := cli.GetDB(cmd.Context())
@@ -138,6 +150,17 @@ func runCloudStatus(cmd *cobra.Command, args []string) error {
 		if status.AuthTokenFile != "" {
 			cli.Info("Auth token file: %s", status.AuthTokenFile)
 		}
+		switch status.AuthSource {
+		case "env":
+			cli.Info("Auth token: TURSO_AUTH_TOKEN")
+		case "credentials":
+			cli.Info("Auth token: encrypted credentials (shark auth login)")
+		case "":
+			cli.Warning("No auth token: run 'shark auth login'")
+		}
+		if status.PlaintextToken {
+			cli.Warning("An auth token is stored in plaintext in .sharkconfig.json: run 'shark auth login' to encrypt it and remove it from the file")
+		}
 	} else {
 		if status.Backend != "" {
 			cli.Warning("Using local database")
@@ -216,8 +239,21 @@ func runCloudInit(cmd *cobra.Command, args []string) error {
 	}
 	configPath := filepath.Join(projectRoot, ".sharkconfig.json")
 
+	// Keep the token out of the project: encrypt it into the credentials store
+	storedAt := ""
+	if authToken != "" && cloudAuthFile == "" {
+		store, err := credentials.DefaultStore()
+		if err != nil {
+			return err
+		}
+		if err := store.Save(cloudURL, authToken); err != nil {
+			return fmt.Errorf("failed to store auth token: %w", err)
+		}
+		storedAt = store.Path()
+	}
+
 	// Update .sharkconfig.json with turso backend configuration
-	if err := updateCloudConfig(configPath, cloudURL, authToken, cloudAuthFile); err != nil {
+	if err := updateCloudConfig(configPath, cloudURL, cloudAuthFile); err != nil {
 		return fmt.Errorf("failed to update config: %w", err)
 	}
 
@@ -228,6 +264,9 @@ func runCloudInit(cmd *cobra.Command, args []string) error {
 		cli.Info("Auth token file: %s", cloudAuthFile)
 	} else {
 		cli.Info("Auth: %s", maskToken(authToken))
+		if storedAt != "" {
+			cli.Info("Auth token encrypted in: %s", storedAt)
+		}
 	}
 	cli.Info("")
 	cli.Info("Configuration saved to: %s", configPath)
@@ -235,8 +274,9 @@ func runCloudInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// updateCloudConfig updates the config file with Turso cloud database settings
-func updateCloudConfig(configPath, url, authToken, authFile string) error {
+// updateCloudConfig updates the config file with Turso cloud database settings.
+// Auth tokens are never written to it, only the path of an auth token file.
+func updateCloudConfig(configPath, url, authFile string) error {
 	// Load existing config or create new one
 	mgr := config.NewManager(configPath)
 	cfg, err := mgr.Load()
@@ -255,13 +295,8 @@ func updateCloudConfig(configPath, url, authToken, authFile string) error {
 		"url":     url,
 	}
 
-	// Add auth token or auth file
 	if authFile != "" {
 		dbConfig["auth_token_file"] = authFile
-	} else if authToken != "" {
-		// Store token directly in config (user's choice)
-		// Note: This is less secure than using a separate file
-		dbConfig["auth_token"] = authToken
 	}
 
 	// Update database section
@@ -377,6 +412,8 @@ type CloudStatus struct {
 	Backend           string `json:"backend"`
 	URL               string `json:"url"`
 	AuthTokenFile     string `json:"auth_token_file,omitempty"`
+	AuthSource        string `json:"auth_source,omitempty"`     // "file", "env", or "credentials"
+	PlaintextToken    bool   `json:"plaintext_token,omitempty"` // .sharkconfig.json holds an auth_token
 	IsCloudConfigured bool   `json:"is_cloud_configured"`
 	ConnectionTested  bool   `json:"connection_tested"`
 	ConnectionStatus  string `json:"connection_status,omitempty"` // "success" or "failed"
@@ -424,5 +461,10 @@ func getCloudStatus(configPath string) (*CloudStatus, error) {
 		status.AuthTokenFile = authFile
 	}
 
+	// Tokens written by older versions of cloud init
+	if token, ok := dbConfig["auth_token"].(string); ok && token != "" {
+		status.PlaintextToken = true
+	}
+
 	return status, nil
 }
@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// keyKind is the kind of key a positional argument or flag takes
+type keyKind int
+
+const (
+	keyNone keyKind = iota
+	keyEpic
+	keyFeature
+	keyFeatureInEpic // F## of the epic in the previous argument, or a full feature key
+	keyTask
+	keyAny // Epic, feature, or task key
+)
+
+// usageArgKeys maps argument placeholders in command Use strings to the keys
+// suggested for them
+var usageArgKeys = map[string]keyKind{
+	"<epic-key>":    keyEpic,
+	"[epic-key]":    keyEpic,
+	"[EPIC]":        keyEpic,
+	"<feature-key>": keyFeature,
+	"[FEATURE]":     keyFeatureInEpic,
+	"<task-key>":    keyTask,
+	"<task>...":     keyTask,
+	"<KEY>":         keyAny,
+}
+
+// flagKeys maps flag names to the keys suggested for them. --depends-on and
+// --blocks take comma-separated task keys.
+var flagKeys = map[string]keyKind{
+	"epic":       keyEpic,
+	"feature":    keyFeature,
+	"task":       keyTask,
+	"depends-on": keyTask,
+	"blocks":     keyTask,
+}
+
+// keyCandidate is a key offered for completion with its title
+type keyCandidate struct {
+	Key   string
+	Title string
+}
+
+func init() {
+	// Every command file adds its commands in init, so completions are
+	// registered once the command tree is complete
+	cobra.OnInitialize(registerKeyCompletions)
+}
+
+// registerKeyCompletions adds completion of epic, feature, and task keys from
+// the database to every command, based on the argument placeholders in its
+// Use string and the key flags it defines
+func registerKeyCompletions() {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.ValidArgsFunction == nil {
+			if kinds, variadic := usageArgKinds(cmd.Use); kinds != nil {
+				cmd.ValidArgsFunction = completeArgKeys(kinds, variadic)
+			}
+		}
+		for name, kind := range flagKeys {
+			if cmd.LocalFlags().Lookup(name) == nil {
+				continue
+			}
+			if _, exists := cmd.GetFlagCompletionFunc(name); exists {
+				continue
+			}
+			_ = cmd.RegisterFlagCompletionFunc(name, completeFlagKeys(kind))
+		}
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(cli.RootCmd)
+}
+
+// usageArgKinds returns the key kind of each positional argument in a Use
+// string such as "create [EPIC] [FEATURE] <title>", and whether the last
+// argument repeats ("<task>..."). Returns nil if no argument takes a key.
+func usageArgKinds(use string) ([]keyKind, bool) {
+	fields := strings.Fields(use)
+	if len(fields) < 2 {
+		return nil, false
+	}
+
+	var kinds []keyKind
+	variadic := false
+	found := false
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "-") || field == "[flags]" {
+			continue
+		}
+		kind := usageArgKeys[field]
+		if kind != keyNone {
+			found = true
+		}
+		kinds = append(kinds, kind)
+		variadic = strings.HasSuffix(field, "...")
+	}
+	if !found {
+		return nil, false
+	}
+	return kinds, variadic
+}
+
+// completeArgKeys suggests keys for positional arguments of the given kinds
+func completeArgKeys(kinds []keyKind, variadic bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		position := len(args)
+		if position >= len(kinds) {
+			if !variadic {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			position = len(kinds) - 1
+		}
+
+		kind := kinds[position]
+		if kind == keyNone {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		epicKey := ""
+		if kind == keyFeatureInEpic && position > 0 {
+			epicKey = NormalizeKey(args[position-1])
+		}
+		candidates, err := loadKeyCandidates(kind, epicKey)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return matchKeyCandidates(candidates, "", toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFlagKeys suggests keys for a flag. For comma-separated lists only the
+// key after the last comma is completed.
+func completeFlagKeys(kind keyKind) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		epicKey := ""
+		if kind == keyFeature {
+			if f := cmd.Flags().Lookup("epic"); f != nil && f.Changed {
+				epicKey = NormalizeKey(f.Value.String())
+			}
+		}
+		candidates, err := loadKeyCandidates(kind, epicKey)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		prefix := ""
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			prefix, toComplete = toComplete[:i+1], toComplete[i+1:]
+		}
+		return matchKeyCandidates(candidates, prefix, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// matchKeyCandidates returns "key\ttitle" completions for candidates whose key
+// starts with toComplete, ignoring case. Task keys also match without the
+// "T-" prefix, as commands accept them that way. prefix is prepended to each key.
+func matchKeyCandidates(candidates []keyCandidate, prefix, toComplete string) []string {
+	want := strings.ToUpper(toComplete)
+	var completions []string
+	for _, c := range candidates {
+		key := c.Key
+		switch {
+		case strings.HasPrefix(key, want):
+		case strings.HasPrefix(key, "T-") && want != "" && strings.HasPrefix(key[2:], want):
+			key = key[2:]
+		default:
+			continue
+		}
+		if c.Title != "" {
+			completions = append(completions, prefix+key+"\t"+c.Title)
+		} else {
+			completions = append(completions, prefix+key)
+		}
+	}
+	return completions
+}
+
+// loadKeyCandidates reads the keys of a kind from the project database. A
+// non-empty epicKey limits feature keys to that epic. Completion never creates
+// a database: it returns nothing outside a project or for cloud databases.
+func loadKeyCandidates(kind keyKind, epicKey string) ([]keyCandidate, error) {
+	dbPath, isLocal, err := cli.GetDatabasePathForBackup()
+	if err != nil {
+		return nil, err
+	}
+	if !isLocal {
+		return nil, nil
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+	// PersistentPostRunE doesn't run for completion requests
+	defer cli.CloseDB()
+
+	var candidates []keyCandidate
+	if kind == keyEpic || kind == keyAny {
+		epics, err := repository.NewEpicRepository(repoDb).List(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range epics {
+			candidates = append(candidates, keyCandidate{Key: e.Key, Title: e.Title})
+		}
+	}
+	if kind == keyFeature || kind == keyFeatureInEpic || kind == keyAny {
+		features, err := repository.NewFeatureRepository(repoDb).List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range features {
+			if epicKey != "" && !strings.HasPrefix(f.Key, epicKey+"-") {
+				continue
+			}
+			key := f.Key
+			// "task create E07 F01" takes the feature's short form after an epic
+			if kind == keyFeatureInEpic && epicKey != "" {
+				key = strings.TrimPrefix(key, epicKey+"-")
+			}
+			candidates = append(candidates, keyCandidate{Key: key, Title: f.Title})
+		}
+	}
+	if kind == keyTask || kind == keyAny {
+		tasks, err := repository.NewTaskRepository(repoDb).List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			candidates = append(candidates, keyCandidate{Key: t.Key, Title: t.Title})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Key < candidates[j].Key })
+	return candidates, nil
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageArgKinds(t *testing.T) {
+	tests := []struct {
+		use          string
+		wantKinds    []keyKind
+		wantVariadic bool
+	}{
+		{"start <task-key>", []keyKind{keyTask}, false},
+		{"create [EPIC] [FEATURE] <title> [flags]", []keyKind{keyEpic, keyFeatureInEpic, keyNone}, false},
+		{"reorder --feature=<feature-key> <task>...", []keyKind{keyTask}, true},
+		{"check <task-key> <criterion-id>", []keyKind{keyTask, keyNone}, false},
+		{"get <KEY>", []keyKind{keyAny}, false},
+		{"create <title>", nil, false},
+		{"list", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.use, func(t *testing.T) {
+			kinds, variadic := usageArgKinds(tt.use)
+			assert.Equal(t, tt.wantKinds, kinds)
+			assert.Equal(t, tt.wantVariadic, variadic)
+		})
+	}
+}
+
+func TestMatchKeyCandidates(t *testing.T) {
+	candidates := []keyCandidate{
+		{Key: "E01", Title: "Auth"},
+		{Key: "E01-F01", Title: "Login"},
+		{Key: "T-E01-F01-001", Title: "Add form"},
+		{Key: "T-E02-F01-001"},
+	}
+
+	assert.Equal(t, []string{"E01\tAuth", "E01-F01\tLogin", "E01-F01-001\tAdd form"}, matchKeyCandidates(candidates, "", "e01"))
+	assert.Equal(t, []string{"T-E01-F01-001\tAdd form", "T-E02-F01-001"}, matchKeyCandidates(candidates, "", "T-"))
+	assert.Equal(t, []string{"E02-F01-001"}, matchKeyCandidates(candidates, "", "E02"), "task keys match without T-")
+	assert.Equal(t, []string{"T-E01-F01-001,T-E02-F01-001"}, matchKeyCandidates(candidates, "T-E01-F01-001,", "T-E02"))
+	assert.Len(t, matchKeyCandidates(candidates, "", ""), 4)
+	assert.Empty(t, matchKeyCandidates(candidates, "", "E09"))
+}
@@ -32,9 +32,10 @@ Examples:
 
 // configShowCmd shows current configuration
 var configShowCmd = &cobra.Command{
-	Use:   "show",
-	Short: "Show current configuration",
-	Long:  `Display the current configuration including file location and all settings.`,
+	Use:         "show",
+	Short:       "Show current configuration",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long:        `Display the current configuration including file location and all settings.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		patternsOnly, _ := cmd.Flags().GetBool("patterns")
 
@@ -97,9 +98,10 @@ var configShowCmd = &cobra.Command{
 
 // configValidateCmd validates configuration
 var configValidateCmd = &cobra.Command{
-	Use:   "validate",
-	Short: "Validate configuration file",
-	Long:  `Check configuration file for errors and validate settings.`,
+	Use:         "validate",
+	Short:       "Validate configuration file",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long:        `Check configuration file for errors and validate settings.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configFile := viper.ConfigFileUsed()
 		if configFile == "" {
@@ -116,8 +118,9 @@ var configValidateCmd = &cobra.Command{
 
 // configValidatePatternsCmd validates all patterns in configuration
 var configValidatePatternsCmd = &cobra.Command{
-	Use:   "validate-patterns",
-	Short: "Validate all patterns in configuration",
+	Use:         "validate-patterns",
+	Short:       "Validate all patterns in configuration",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Validate all regex patterns in .sharkconfig.json.
 Reports validation results grouped by entity type (epic, feature, task).
 Exits with non-zero status if any errors found (for CI integration).`,
@@ -184,7 +187,7 @@ Exits with non-zero status if any errors found (for CI integration).`,
 
 		if hasErrors || validationErr != nil {
 			fmt.Println("")
-			os.Exit(1)
+			cli.Exit(cli.ExitFailure)
 		}
 
 		cli.Success("\nAll patterns validated successfully")
@@ -194,8 +197,9 @@ Exits with non-zero status if any errors found (for CI integration).`,
 
 // configTestPatternCmd tests a pattern against a test string
 var configTestPatternCmd = &cobra.Command{
-	Use:   "test-pattern",
-	Short: "Test a regex pattern against a test string",
+	Use:         "test-pattern",
+	Short:       "Test a regex pattern against a test string",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Test a regex pattern to see if it matches a test string.
 Displays captured groups and validates pattern for specified entity type.
 
@@ -282,8 +286,9 @@ Examples:
 
 // configGetFormatCmd returns the generation format for an entity type
 var configGetFormatCmd = &cobra.Command{
-	Use:   "get-format",
-	Short: "Get generation format for entity type",
+	Use:         "get-format",
+	Short:       "Get generation format for entity type",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Query the configured generation format template for a specific entity type.
 Supports JSON output for programmatic access (AI agents).
 
@@ -345,8 +350,9 @@ Examples:
 
 // configListPresetsCmd lists available pattern presets
 var configListPresetsCmd = &cobra.Command{
-	Use:   "list-presets",
-	Short: "List available pattern presets",
+	Use:         "list-presets",
+	Short:       "List available pattern presets",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display all available pattern presets with their descriptions.
 
 Pattern presets provide pre-built pattern collections that can be added to your
@@ -373,8 +379,9 @@ Examples:
 
 // configShowPresetCmd shows details of a specific preset
 var configShowPresetCmd = &cobra.Command{
-	Use:   "show-preset <name>",
-	Short: "Show details of a pattern preset",
+	Use:         "show-preset <name>",
+	Short:       "Show details of a pattern preset",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display the full pattern structure for a specific preset.
 
 The output shows patterns in JSON format ready for manual copying if needed.
@@ -585,8 +592,9 @@ Examples:
 
 // configGetStatusActionCmd returns the orchestrator action for a status
 var configGetStatusActionCmd = &cobra.Command{
-	Use:   "get-status-action <status>",
-	Short: "Get orchestrator action for a status",
+	Use:         "get-status-action <status>",
+	Short:       "Get orchestrator action for a status",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Get the orchestrator action definition for a specific status from workflow configuration.
 
 This command is useful for debugging and testing workflow configuration without actually
@@ -607,28 +615,24 @@ func runConfigGetStatusAction(cmd *cobra.Command, args []string) error {
 	// Load workflow config
 	configPath, err := cli.GetConfigPath()
 	if err != nil {
-		cli.Error(fmt.Sprintf("Failed to get config path: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Failed to get config path: %v", err))
 	}
 
 	// Load the workflow configuration
 	workflowConfig, err := config.LoadWorkflowConfig(configPath)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Failed to load workflow config: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Failed to load workflow config: %v", err))
 	}
 
 	// Check if workflow config or metadata exists
 	if workflowConfig == nil || workflowConfig.StatusMetadata == nil {
-		cli.Error("No workflow configuration found")
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, "No workflow configuration found")
 	}
 
 	// Check if status exists in config
 	metadata, exists := workflowConfig.StatusMetadata[status]
 	if !exists {
-		cli.Error(fmt.Sprintf("Status '%s' not found in workflow config", status))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Status '%s' not found in workflow config", status))
 	}
 
 	// Get the orchestrator action (may be nil)
@@ -639,8 +643,7 @@ func runConfigGetStatusAction(cmd *cobra.Command, args []string) error {
 		// Validate task key format
 		taskKey, err := NormalizeTaskKey(taskKeyFlag)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Invalid task key format: %s", taskKeyFlag))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Invalid task key format: %s", taskKeyFlag))
 		}
 
 		// Populate template with task ID
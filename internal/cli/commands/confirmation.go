@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// confirmationTokenLength is the number of hex characters in a confirmation token
+const confirmationTokenLength = 12
+
+// DestructiveOperation describes a destructive command (cascade delete, force
+// completion) that can be previewed with --dry-run and confirmed with --confirm.
+type DestructiveOperation struct {
+	Operation string   `json:"operation"` // e.g. "epic delete"
+	Key       string   `json:"key"`       // Target entity key
+	Summary   string   `json:"summary"`   // Human-readable description of the effect
+	Affected  []string `json:"affected"`  // Keys (with state) of entities that will be changed or removed
+}
+
+// Token returns the confirmation token for this operation.
+// The token is derived from the operation, target, summary, and affected entities,
+// so it stops matching when the underlying data changes after the dry-run.
+func (op *DestructiveOperation) Token() string {
+	affected := append([]string{}, op.Affected...)
+	sort.Strings(affected)
+
+	h := sha256.New()
+	h.Write([]byte(op.Operation))
+	h.Write([]byte{0})
+	h.Write([]byte(op.Key))
+	h.Write([]byte{0})
+	h.Write([]byte(op.Summary))
+	for _, a := range affected {
+		h.Write([]byte{0})
+		h.Write([]byte(a))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:confirmationTokenLength]
+}
+
+// addConfirmationFlags registers --dry-run and --confirm on a destructive command
+func addConfirmationFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "Preview the operation and print a confirmation token without making changes")
+	cmd.Flags().String("confirm", "", "Confirmation token printed by --dry-run")
+}
+
+// isDryRun reports whether --dry-run was passed
+func isDryRun(cmd *cobra.Command) bool {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	return dryRun
+}
+
+// printDestructiveDryRun prints the dry-run preview and confirmation token
+func printDestructiveDryRun(op *DestructiveOperation) error {
+	token := op.Token()
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"dry_run":            true,
+			"operation":          op.Operation,
+			"key":                op.Key,
+			"summary":            op.Summary,
+			"affected":           op.Affected,
+			"confirmation_token": token,
+		})
+	}
+
+	fmt.Printf("Dry run: shark %s %s\n", op.Operation, op.Key)
+	fmt.Printf("  %s\n", op.Summary)
+	if len(op.Affected) > 0 {
+		fmt.Printf("  Affected: %s\n", strings.Join(op.Affected, ", "))
+	}
+	fmt.Println()
+	fmt.Printf("Confirmation token: %s\n", token)
+	cli.Info(fmt.Sprintf("Re-run with --confirm=%s to execute", token))
+	return nil
+}
+
+// verifyConfirmationToken checks --confirm against the operation's token.
+// A token is required when require_confirmation_tokens is enabled in
+// .sharkconfig.json; otherwise it is only checked if one was supplied.
+func verifyConfirmationToken(cmd *cobra.Command, op *DestructiveOperation) error {
+	supplied, _ := cmd.Flags().GetString("confirm")
+
+	if supplied == "" {
+		required, err := confirmationTokensRequired()
+		if err != nil {
+			return err
+		}
+		if !required {
+			return nil
+		}
+		return fmt.Errorf("'shark %s' requires a confirmation token in this project; run 'shark %s %s --dry-run' to preview and obtain one",
+			op.Operation, op.Operation, op.Key)
+	}
+
+	if supplied != op.Token() {
+		return fmt.Errorf("confirmation token does not match (the data may have changed since the dry-run); run 'shark %s %s --dry-run' again",
+			op.Operation, op.Key)
+	}
+
+	return nil
+}
+
+// confirmationTokensRequired reports whether the project config requires
+// confirmation tokens. A config that can't be read is an error rather than a
+// reason to skip the check.
+func confirmationTokensRequired() (bool, error) {
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether confirmation tokens are required: %w", err)
+	}
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether confirmation tokens are required: %w", err)
+	}
+	return cfg.IsConfirmationTokenRequired(), nil
+}
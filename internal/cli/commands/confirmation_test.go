@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestructiveOperation_Token(t *testing.T) {
+	op := &DestructiveOperation{
+		Operation: "feature delete",
+		Key:       "E04-F02",
+		Summary:   "Would delete feature E04-F02 and cascade delete 2 task(s)",
+		Affected:  []string{"T-E04-F02-001", "T-E04-F02-002"},
+	}
+
+	token := op.Token()
+	assert.Len(t, token, confirmationTokenLength)
+
+	reordered := *op
+	reordered.Affected = []string{"T-E04-F02-002", "T-E04-F02-001"}
+	assert.Equal(t, token, reordered.Token(), "token should not depend on affected order")
+
+	changed := *op
+	changed.Affected = []string{"T-E04-F02-001", "T-E04-F02-002", "T-E04-F02-003"}
+	assert.NotEqual(t, token, changed.Token(), "token should change when affected entities change")
+
+	other := *op
+	other.Key = "E04-F03"
+	assert.NotEqual(t, token, other.Token())
+}
+
+func TestVerifyConfirmationToken(t *testing.T) {
+	op := &DestructiveOperation{Operation: "epic delete", Key: "E05", Summary: "Would delete epic E05", Affected: []string{"E05-F01"}}
+
+	newCmd := func(confirm string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addConfirmationFlags(cmd)
+		if confirm != "" {
+			require.NoError(t, cmd.Flags().Set("confirm", confirm))
+		}
+		return cmd
+	}
+
+	configPath := filepath.Join(t.TempDir(), ".sharkconfig.json")
+	originalConfigFile := cli.GlobalConfig.ConfigFile
+	cli.GlobalConfig.ConfigFile = configPath
+	defer func() { cli.GlobalConfig.ConfigFile = originalConfigFile }()
+
+	// Not required: no token is fine, a wrong token is still rejected
+	require.NoError(t, os.WriteFile(configPath, []byte(`{}`), 0644))
+	assert.NoError(t, verifyConfirmationToken(newCmd(""), op))
+	assert.Error(t, verifyConfirmationToken(newCmd("deadbeef0000"), op))
+	assert.NoError(t, verifyConfirmationToken(newCmd(op.Token()), op))
+
+	// Required: a matching token must be supplied
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"require_confirmation_tokens": true}`), 0644))
+	assert.Error(t, verifyConfirmationToken(newCmd(""), op))
+	assert.Error(t, verifyConfirmationToken(newCmd("deadbeef0000"), op))
+	assert.NoError(t, verifyConfirmationToken(newCmd(op.Token()), op))
+
+	// Unreadable config: fail closed rather than skip the check
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"require_confirmation_tokens": tru`), 0644))
+	assert.Error(t, verifyConfirmationToken(newCmd(""), op))
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// ifNotExistsFlag makes a create command return the entity it would duplicate
+// instead of failing, so automation can safely retry creates
+const ifNotExistsFlag = "if-not-exists"
+
+// addIfNotExistsFlag adds --if-not-exists to a create command. parent is what
+// titles must be unique within ("epic", "feature"), or "" for top-level entities.
+func addIfNotExistsFlag(cmd *cobra.Command, entityType, parent string) {
+	usage := fmt.Sprintf("If a %s with the same key or title exists, return it instead of creating another", entityType)
+	if parent != "" {
+		usage = fmt.Sprintf("If a %s with the same key, or the same title in the %s, exists, return it instead of creating another", entityType, parent)
+	}
+	cmd.Flags().Bool(ifNotExistsFlag, false, usage)
+}
+
+// outputExistingEntity reports the entity a create command given
+// --if-not-exists found instead of creating it. The JSON output mirrors that of
+// a create, with "created": false and the entity under its type ("task": {...}).
+// filePath may be relative to projectRoot; like a create, JSON gives it absolute.
+func outputExistingEntity(entityType, key, title string, filePath *string, projectRoot string, entity interface{}) error {
+	if cli.GlobalConfig.JSON {
+		result := map[string]interface{}{
+			"status":      "exists",
+			"created":     false,
+			"entity_type": entityType,
+			"key":         key,
+			"title":       title,
+			entityType:    entity,
+		}
+		if filePath != nil && *filePath != "" {
+			path := *filePath
+			if !filepath.IsAbs(path) && projectRoot != "" {
+				path = filepath.Join(projectRoot, path)
+			}
+			result["file_path"] = path
+		}
+		return cli.OutputJSON(result)
+	}
+
+	cli.Info(fmt.Sprintf("Found existing %s %s: %s (nothing created)", entityType, key, title))
+	return nil
+}
@@ -0,0 +1,635 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/spf13/cobra"
+)
+
+// dbCmd is the parent command for database maintenance
+var dbCmd = &cobra.Command{
+	Use:     "db",
+	Short:   "Database backup, restore, encryption, and diagnostics",
+	GroupID: "setup",
+	Long: `Create, list, prune, and restore backups of the local SQLite database,
+encrypt or decrypt it at rest (SQLCipher builds only), and inspect its settings.
+
+Backups are also created automatically before cascade deletes and --force
+operations. Only the most recent backups are kept (10 by default); set
+"backup_retention" in .sharkconfig.json to change this (0 keeps all).
+
+Cloud (Turso) databases are backed up by the provider and are not supported.`,
+}
+
+// dbBackupCmd creates a backup
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Create a database backup",
+	Long: `Create a timestamped backup of the database next to the database file.
+
+Examples:
+  shark db backup
+  shark db backup --json`,
+	Args: cobra.NoArgs,
+	RunE: runDBBackup,
+}
+
+// dbBackupsCmd lists backups
+var dbBackupsCmd = &cobra.Command{
+	Use:   "backups",
+	Short: "List database backups",
+	Long: `List database backups, newest first, with the operation that triggered each.
+
+Examples:
+  shark db backups
+  shark db backups --json`,
+	Args: cobra.NoArgs,
+	RunE: runDBBackups,
+}
+
+// dbBackupsVerifyCmd verifies backups
+var dbBackupsVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the integrity of database backups",
+	Long: `Open each backup read-only and run SQLite's integrity check on it.
+
+With --prune, old backups are deleted afterwards according to the retention
+setting (or --keep), so a scheduled CI job can verify and prune in one step.
+
+Exit codes:
+  0 - All backups passed
+  1 - At least one backup is corrupt or unreadable
+
+Examples:
+  shark db backups verify
+  shark db backups verify --json
+  shark db backups verify --prune --keep=5 --json`,
+	Args: cobra.NoArgs,
+	RunE: runDBBackupsVerify,
+}
+
+// dbPruneCmd prunes old backups
+var dbPruneCmd = &cobra.Command{
+	Use:         "prune",
+	Short:       "Delete old database backups",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Delete all but the most recent backups.
+
+Uses "backup_retention" from .sharkconfig.json unless --keep is given.
+
+Examples:
+  shark db prune
+  shark db prune --keep=3`,
+	Args: cobra.NoArgs,
+	RunE: runDBPrune,
+}
+
+// dbRestoreCmd restores a backup
+var dbRestoreCmd = &cobra.Command{
+	Use:         "restore <backup-file>",
+	Short:       "Restore the database from a backup",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Replace the database with a backup file.
+
+The current database is backed up first (trigger "pre-restore"), so a restore
+can itself be undone by restoring that backup.
+
+Examples:
+  shark db restore shark-tasks_20260105_143000_backup.db
+  shark db restore /path/to/shark-tasks.db.force-complete-epic-20260105-143000.backup`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDBRestore,
+}
+
+// dbEncryptCmd encrypts the database
+var dbEncryptCmd = &cobra.Command{
+	Use:         "encrypt",
+	Short:       "Encrypt the database at rest",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Convert the local database to an encrypted (SQLCipher) database.
+
+The key is read from the SHARK_DB_KEY environment variable, or from the OS
+keychain under service "shark-task-manager" with the absolute database path as
+the account. Every later command needs the same key to open the database.
+
+The database is backed up first (trigger "pre-encrypt"). That backup and any
+older ones are NOT encrypted; delete them once the encrypted database works.
+
+Requires a shark build with SQLCipher support ('make shark-sqlcipher').
+
+Examples:
+  SHARK_DB_KEY=... shark db encrypt
+  security add-generic-password -s shark-task-manager -a "$PWD/shark-tasks.db" -w   # macOS keychain
+  secret-tool store --label=shark service shark-task-manager database "$PWD/shark-tasks.db"  # Linux`,
+	Args: cobra.NoArgs,
+	RunE: runDBEncrypt,
+}
+
+// dbDecryptCmd decrypts the database
+var dbDecryptCmd = &cobra.Command{
+	Use:         "decrypt",
+	Short:       "Convert an encrypted database back to plain SQLite",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Convert an encrypted (SQLCipher) database back to a plain SQLite database.
+
+The key is read from SHARK_DB_KEY or the OS keychain, as for 'shark db encrypt'.
+The encrypted database is backed up first (trigger "pre-decrypt").
+
+Examples:
+  SHARK_DB_KEY=... shark db decrypt`,
+	Args: cobra.NoArgs,
+	RunE: runDBDecrypt,
+}
+
+// dbStatsCmd prints database settings and statistics
+var dbStatsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show database settings, size, and index statistics",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Show the SQLite settings in effect (journal mode, busy timeout, synchronous,
+cache size), file and WAL sizes, page counts, connection pool usage, row counts
+per table, and indexes.
+
+Use this to diagnose "database is locked" (SQLITE_BUSY) errors when many agents
+run shark concurrently: check that journal_mode is wal and busy_timeout is long
+enough, and raise database.busy_timeout_ms in .sharkconfig.json (or pass
+--db-busy-timeout) if agents wait longer than that for writes.
+
+SQLite does not count index lookups. With --analyze, ANALYZE is run first and
+each index shows its sqlite_stat1 entry: the table's row count followed by the
+average number of rows per key prefix (lower means more selective).
+
+Examples:
+  shark db stats
+  shark db stats --analyze
+  shark db stats --json`,
+	Args: cobra.NoArgs,
+	RunE: runDBStats,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbBackupsCmd)
+	dbCmd.AddCommand(dbPruneCmd)
+	dbCmd.AddCommand(dbRestoreCmd)
+	dbCmd.AddCommand(dbEncryptCmd)
+	dbCmd.AddCommand(dbDecryptCmd)
+	dbCmd.AddCommand(dbStatsCmd)
+	dbBackupsCmd.AddCommand(dbBackupsVerifyCmd)
+
+	dbPruneCmd.Flags().Int("keep", -1, "Number of backups to keep (default: backup_retention from config)")
+	dbBackupsVerifyCmd.Flags().Bool("prune", false, "Delete old backups after verifying")
+	dbBackupsVerifyCmd.Flags().Int("keep", -1, "Number of backups to keep with --prune (default: backup_retention from config)")
+	dbStatsCmd.Flags().Bool("analyze", false, "Run ANALYZE first to refresh index statistics")
+}
+
+// localDatabasePath returns the local database path, or an error for cloud databases
+func localDatabasePath() (string, error) {
+	dbPath, canBackup, err := cli.GetDatabasePathForBackup()
+	if err != nil {
+		return "", fmt.Errorf("failed to get database path: %w", err)
+	}
+	if !canBackup {
+		return "", fmt.Errorf("backups are only supported for local databases (cloud backups are handled by the provider)")
+	}
+	// Encrypted backups are verified and restored with the database's key
+	if err := db.ConfigureEncryption(dbPath); err != nil {
+		return "", err
+	}
+	return dbPath, nil
+}
+
+// backupRetention returns the configured number of backups to keep
+func backupRetention() int {
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return db.DefaultBackupRetention
+	}
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil {
+		return db.DefaultBackupRetention
+	}
+	return cfg.GetBackupRetention()
+}
+
+// createDatabaseBackup backs up the database with metadata and prunes old backups
+func createDatabaseBackup(dbPath, trigger string) (string, error) {
+	backupPath, err := db.BackupDatabaseWithTrigger(dbPath, trigger)
+	if err != nil {
+		return "", err
+	}
+	pruneDatabaseBackups(dbPath)
+	return backupPath, nil
+}
+
+// pruneDatabaseBackups applies the configured retention; failures only warn
+// since the backup itself already succeeded
+func pruneDatabaseBackups(dbPath string) {
+	if _, err := db.PruneBackups(dbPath, backupRetention()); err != nil && cli.GlobalConfig.Verbose {
+		cli.Warning(fmt.Sprintf("Failed to prune old backups: %v", err))
+	}
+}
+
+// runDBBackup handles the db backup command
+func runDBBackup(cmd *cobra.Command, args []string) error {
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	backupPath, err := createDatabaseBackup(dbPath, "manual")
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"backup_path": backupPath,
+			"trigger":     "manual",
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Database backup created: %s", backupPath))
+	return nil
+}
+
+// runDBBackups handles the db backups command
+func runDBBackups(cmd *cobra.Command, args []string) error {
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	backups, err := db.ListBackups(dbPath)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		if backups == nil {
+			backups = []db.BackupInfo{}
+		}
+		return cli.OutputJSON(backups)
+	}
+
+	if len(backups) == 0 {
+		fmt.Println("No backups found")
+		return nil
+	}
+
+	headers := []string{"Created", "Trigger", "Size", "File"}
+	rows := make([][]string, len(backups))
+	for i, b := range backups {
+		rows[i] = []string{
+			b.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			b.Trigger,
+			formatBytes(b.SizeBytes),
+			filepath.Base(b.Path),
+		}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// runDBBackupsVerify handles the db backups verify command
+func runDBBackupsVerify(cmd *cobra.Command, args []string) error {
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	results, err := db.VerifyBackups(dbPath)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+		}
+	}
+
+	// Prune after verifying so every existing backup is reported
+	removed := []string{}
+	keep := 0
+	if prune, _ := cmd.Flags().GetBool("prune"); prune {
+		keep, _ = cmd.Flags().GetInt("keep")
+		if keep < 0 {
+			keep = backupRetention()
+		}
+		pruned, err := db.PruneBackups(dbPath, keep)
+		if err != nil {
+			return err
+		}
+		removed = append(removed, pruned...)
+	}
+
+	if cli.GlobalConfig.JSON {
+		if results == nil {
+			results = []db.BackupVerification{}
+		}
+		output := map[string]interface{}{
+			"ok":       failed == 0,
+			"verified": len(results),
+			"failed":   failed,
+			"backups":  results,
+		}
+		if cmd.Flags().Changed("prune") {
+			output["kept"] = keep
+			output["removed"] = removed
+		}
+		if err := cli.OutputJSON(output); err != nil {
+			return err
+		}
+	} else {
+		displayBackupVerification(results, failed, removed)
+	}
+
+	if failed > 0 {
+		cli.Exit(cli.ExitFailure)
+	}
+	return nil
+}
+
+// displayBackupVerification prints backup verification results as a table
+func displayBackupVerification(results []db.BackupVerification, failed int, removed []string) {
+	if len(results) == 0 {
+		fmt.Println("No backups found")
+		return
+	}
+
+	headers := []string{"Created", "Trigger", "Result", "File"}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		result := "ok"
+		if !r.OK {
+			result = strings.Join(r.Errors, "; ")
+		}
+		rows[i] = []string{
+			r.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			r.Trigger,
+			result,
+			filepath.Base(r.Path),
+		}
+	}
+	cli.OutputTable(headers, rows)
+
+	if failed > 0 {
+		cli.Error(fmt.Sprintf("%d of %d backup(s) failed verification", failed, len(results)))
+	} else {
+		cli.Success(fmt.Sprintf("All %d backup(s) passed verification", len(results)))
+	}
+	if len(removed) > 0 {
+		cli.Info(fmt.Sprintf("Removed %d old backup(s)", len(removed)))
+	}
+}
+
+// runDBPrune handles the db prune command
+func runDBPrune(cmd *cobra.Command, args []string) error {
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	keep, _ := cmd.Flags().GetInt("keep")
+	if keep < 0 {
+		keep = backupRetention()
+	}
+
+	removed, err := db.PruneBackups(dbPath, keep)
+	if err != nil {
+		return err
+	}
+	if removed == nil {
+		removed = []string{}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"kept":    keep,
+			"removed": removed,
+		})
+	}
+
+	if len(removed) == 0 {
+		fmt.Println("No backups to prune")
+		return nil
+	}
+	cli.Success(fmt.Sprintf("Removed %d old backup(s), keeping the %d most recent", len(removed), keep))
+	return nil
+}
+
+// runDBRestore handles the db restore command
+func runDBRestore(cmd *cobra.Command, args []string) error {
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	backupPath, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid backup path: %w", err)
+	}
+	if backupPath == dbPath {
+		return fmt.Errorf("cannot restore the database onto itself")
+	}
+
+	safetyBackup, err := db.RestoreDatabase(dbPath, backupPath)
+	if err != nil {
+		return err
+	}
+	pruneDatabaseBackups(dbPath)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"restored_from": backupPath,
+			"database_path": dbPath,
+			"safety_backup": safetyBackup,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Database restored from %s", backupPath))
+	if safetyBackup != "" {
+		cli.Info(fmt.Sprintf("Previous database saved to %s", safetyBackup))
+	}
+	return nil
+}
+
+// runDBEncrypt handles the db encrypt command
+func runDBEncrypt(cmd *cobra.Command, args []string) error {
+	if !db.EncryptionSupported {
+		return db.ErrEncryptionUnsupported
+	}
+
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	key := db.ResolveEncryptionKey(dbPath)
+	if key == "" {
+		return fmt.Errorf("no encryption key: set %s or store the key in the OS keychain (service %q, account %s)",
+			db.EncryptionKeyEnv, db.KeychainService, dbPath)
+	}
+
+	backupPath, err := createDatabaseBackup(dbPath, "pre-encrypt")
+	if err != nil {
+		return fmt.Errorf("failed to back up database before encrypting: %w", err)
+	}
+
+	if err := db.EncryptDatabase(dbPath, key); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"database_path": dbPath,
+			"encrypted":     true,
+			"backup_path":   backupPath,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Database encrypted: %s", dbPath))
+	cli.Warning(fmt.Sprintf("Backups made before encryption are not encrypted (latest: %s). Delete them once the encrypted database works.", backupPath))
+	return nil
+}
+
+// runDBDecrypt handles the db decrypt command
+func runDBDecrypt(cmd *cobra.Command, args []string) error {
+	if !db.EncryptionSupported {
+		return db.ErrEncryptionUnsupported
+	}
+
+	// Resolves the key, and fails if the database is encrypted without one
+	dbPath, err := localDatabasePath()
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := db.IsEncrypted(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database: %w", err)
+	}
+	if !encrypted {
+		return fmt.Errorf("%s is not encrypted", dbPath)
+	}
+
+	backupPath, err := createDatabaseBackup(dbPath, "pre-decrypt")
+	if err != nil {
+		return fmt.Errorf("failed to back up database before decrypting: %w", err)
+	}
+
+	if err := db.DecryptDatabase(dbPath, db.EncryptionKey()); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"database_path": dbPath,
+			"encrypted":     false,
+			"backup_path":   backupPath,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Database decrypted: %s", dbPath))
+	return nil
+}
+
+// runDBStats handles the db stats command
+func runDBStats(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(60 * time.Second)
+	defer cancel()
+
+	dbPath, local, err := cli.GetDatabasePathForBackup()
+	if err != nil {
+		return fmt.Errorf("failed to get database path: %w", err)
+	}
+	if !local {
+		return fmt.Errorf("db stats is only supported for local databases")
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	analyze, _ := cmd.Flags().GetBool("analyze")
+	stats, err := db.CollectStats(ctx, repoDb.DB, dbPath, analyze)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(stats)
+	}
+
+	displayDBStats(stats)
+	return nil
+}
+
+// displayDBStats prints database statistics as tables
+func displayDBStats(stats *db.DatabaseStats) {
+	fmt.Printf("Database: %s\n", stats.Path)
+	fmt.Printf("Size: %s (WAL: %s)\n\n", formatBytes(stats.SizeBytes), formatBytes(stats.WALSizeBytes))
+
+	maxOpen := "unlimited"
+	if stats.Pool.MaxOpenConnections > 0 {
+		maxOpen = strconv.Itoa(stats.Pool.MaxOpenConnections)
+	}
+	settings := [][]string{}
+	for _, name := range db.StatsPragmas {
+		settings = append(settings, []string{name, stats.Pragmas[name]})
+	}
+	settings = append(settings,
+		[]string{"max_open_conns", maxOpen},
+		[]string{"open_conns", strconv.Itoa(stats.Pool.OpenConnections)},
+		[]string{"conn_waits", fmt.Sprintf("%d (%dms)", stats.Pool.WaitCount, stats.Pool.WaitDurationMs)},
+	)
+	cli.OutputTable([]string{"Setting", "Value"}, settings)
+
+	fmt.Println()
+	tables := make([][]string, len(stats.Tables))
+	for i, t := range stats.Tables {
+		tables[i] = []string{t.Name, strconv.FormatInt(t.Rows, 10)}
+	}
+	cli.OutputTable([]string{"Table", "Rows"}, tables)
+
+	fmt.Println()
+	indexes := make([][]string, len(stats.Indexes))
+	for i, idx := range stats.Indexes {
+		unique := ""
+		if idx.Unique {
+			unique = "yes"
+		}
+		indexes[i] = []string{idx.Name, idx.Table, unique, idx.Stat}
+	}
+	cli.OutputTable([]string{"Index", "Table", "Unique", "Stat"}, indexes)
+
+	if !stats.Analyzed {
+		cli.Info("Index statistics are not collected yet; run 'shark db stats --analyze' to gather them")
+	}
+}
+
+// formatBytes formats a byte count for display
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,379 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// docCmd represents the doc command group
+var docCmd = &cobra.Command{
+	Use:     "doc",
+	Short:   "Link documents to epics, features, and tasks",
+	GroupID: "details",
+	Long: `Link documents such as PRDs, designs, and notes to epics, features, and tasks.
+
+Document paths must be relative to the project root.
+
+Examples:
+  shark doc add --epic=E05 --path=docs/prd.md --title="PRD"
+  shark doc list --task=T-E05-F01-001
+  shark doc rm "PRD" --epic=E05`,
+}
+
+// docAddCmd links a document to an epic, feature, or task
+var docAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Link a document",
+	Long: `Link a document to an epic, feature, or task.
+
+Requires --path and exactly one of --epic, --feature, or --task. The title
+defaults to the file name without its extension. A document with the same
+title and path is reused, so one document can be linked to several entities.
+
+Examples:
+  shark doc add --epic=E05 --path=docs/prd.md --title="PRD"
+  shark doc add --feature=E05-F01 --path=docs/design/auth.md
+  shark doc add --task=T-E05-F01-001 --path=docs/notes.md --json`,
+	Args: cobra.NoArgs,
+	RunE: runDocAdd,
+}
+
+// docListCmd lists documents
+var docListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List documents",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List the documents linked to an epic, feature, or task.
+
+Without --epic, --feature, or --task, lists every document with the
+entities it is linked to.
+
+Examples:
+  shark doc list
+  shark doc list --epic=E05
+  shark doc list --task=T-E05-F01-001 --json`,
+	Args: cobra.NoArgs,
+	RunE: runDocList,
+}
+
+// docRmCmd unlinks or deletes a document
+var docRmCmd = &cobra.Command{
+	Use:   "rm <id|title>",
+	Short: "Unlink or delete a document",
+	Long: `Unlink a document from an epic, feature, or task, or delete it.
+
+With --epic, --feature, or --task, only that link is removed. Without them,
+the document and all of its links are deleted. The file itself is never touched.
+
+Examples:
+  shark doc rm "PRD" --epic=E05
+  shark doc rm 12 --task=T-E05-F01-001
+  shark doc rm 12`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDocRm,
+}
+
+// docParent is the epic, feature, or task a doc command applies to
+type docParent struct {
+	Type string
+	Key  string
+	ID   int64
+}
+
+// resolveDocParent looks up the entity named by --epic, --feature, or --task.
+// Returns nil when none of the flags is set.
+func resolveDocParent(ctx context.Context, cmd *cobra.Command, repoDb *repository.DB) (*docParent, error) {
+	epic, _ := cmd.Flags().GetString("epic")
+	feature, _ := cmd.Flags().GetString("feature")
+	task, _ := cmd.Flags().GetString("task")
+
+	count := 0
+	for _, key := range []string{epic, feature, task} {
+		if key != "" {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	if count > 1 {
+		return nil, fmt.Errorf("specify only one of --epic, --feature, or --task")
+	}
+
+	switch {
+	case epic != "":
+		e, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, epic)
+		if err != nil {
+			return nil, fmt.Errorf("epic not found: %s", epic)
+		}
+		return &docParent{Type: "epic", Key: e.Key, ID: e.ID}, nil
+	case feature != "":
+		f, err := repository.NewFeatureRepository(repoDb).GetByKey(ctx, feature)
+		if err != nil {
+			return nil, fmt.Errorf("feature not found: %s", feature)
+		}
+		return &docParent{Type: "feature", Key: f.Key, ID: f.ID}, nil
+	default:
+		t, err := repository.NewTaskRepository(repoDb).GetByKey(ctx, task)
+		if err != nil {
+			return nil, fmt.Errorf("task not found: %s", task)
+		}
+		return &docParent{Type: "task", Key: t.Key, ID: t.ID}, nil
+	}
+}
+
+// normalizeDocumentPath cleans a document path and validates it with ValidateDocumentPath
+func normalizeDocumentPath(docPath string) (string, error) {
+	if err := ValidateDocumentPath(docPath); err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(filepath.Clean(docPath)), nil
+}
+
+// findDocument looks up a document by numeric ID or by title
+func findDocument(ctx context.Context, docRepo *repository.DocumentRepository, ref string) (*models.Document, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		if doc, err := docRepo.GetByID(ctx, id); err == nil {
+			return doc, nil
+		}
+	}
+	doc, err := docRepo.GetByTitle(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("document not found: %s", ref)
+	}
+	return doc, nil
+}
+
+// runDocAdd handles linking a document
+func runDocAdd(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	docPath, _ := cmd.Flags().GetString("path")
+	title, _ := cmd.Flags().GetString("title")
+
+	docPath, err := normalizeDocumentPath(docPath)
+	if err != nil {
+		return err
+	}
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(docPath), filepath.Ext(docPath))
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+
+	parent, err := resolveDocParent(ctx, cmd, repoDb)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return fmt.Errorf("one of --epic, --feature, or --task must be specified")
+	}
+
+	docRepo := repository.NewDocumentRepository(repoDb)
+	doc, err := docRepo.CreateOrGet(ctx, title, docPath)
+	if err != nil {
+		return fmt.Errorf("failed to create or get document: %w", err)
+	}
+
+	switch parent.Type {
+	case "epic":
+		err = docRepo.LinkToEpic(ctx, parent.ID, doc.ID)
+	case "feature":
+		err = docRepo.LinkToFeature(ctx, parent.ID, doc.ID)
+	case "task":
+		err = docRepo.LinkToTask(ctx, parent.ID, doc.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to link document to %s: %w", parent.Type, err)
+	}
+
+	// Linking a document that doesn't exist yet is allowed (it may be written later)
+	fileMissing := false
+	if projectRoot, err := cli.FindProjectRoot(); err == nil {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(docPath))); os.IsNotExist(err) {
+			fileMissing = true
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"document_id":  doc.ID,
+			"title":        doc.Title,
+			"path":         doc.FilePath,
+			"linked_to":    parent.Type,
+			"parent_key":   parent.Key,
+			"file_missing": fileMissing,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Linked %q (%s) to %s %s", doc.Title, doc.FilePath, parent.Type, parent.Key))
+	if fileMissing {
+		cli.Warning(fmt.Sprintf("File %s does not exist", doc.FilePath))
+	}
+	return nil
+}
+
+// runDocList handles listing documents
+func runDocList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+
+	parent, err := resolveDocParent(ctx, cmd, repoDb)
+	if err != nil {
+		return err
+	}
+
+	docRepo := repository.NewDocumentRepository(repoDb)
+
+	if parent == nil {
+		docs, err := docRepo.ListAll(ctx)
+		if err != nil {
+			return err
+		}
+		if docs == nil {
+			docs = []*models.DocumentWithLinks{}
+		}
+
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(docs)
+		}
+
+		rows := make([][]string, 0, len(docs))
+		for _, doc := range docs {
+			linked := append(append(append([]string{}, doc.Epics...), doc.Features...), doc.Tasks...)
+			linkedTo := strings.Join(linked, ", ")
+			if linkedTo == "" {
+				linkedTo = "-"
+			}
+			rows = append(rows, []string{strconv.FormatInt(doc.ID, 10), doc.Title, doc.FilePath, linkedTo})
+		}
+		cli.OutputTable([]string{"ID", "Title", "Path", "Linked To"}, rows)
+		return nil
+	}
+
+	var docs []*models.Document
+	switch parent.Type {
+	case "epic":
+		docs, err = docRepo.ListForEpic(ctx, parent.ID)
+	case "feature":
+		docs, err = docRepo.ListForFeature(ctx, parent.ID)
+	case "task":
+		docs, err = docRepo.ListForTask(ctx, parent.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list documents for %s: %w", parent.Type, err)
+	}
+	if docs == nil {
+		docs = []*models.Document{}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(docs)
+	}
+
+	rows := make([][]string, 0, len(docs))
+	for _, doc := range docs {
+		rows = append(rows, []string{strconv.FormatInt(doc.ID, 10), doc.Title, doc.FilePath})
+	}
+	cli.OutputTable([]string{"ID", "Title", "Path"}, rows)
+	return nil
+}
+
+// runDocRm handles unlinking or deleting a document
+func runDocRm(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+
+	parent, err := resolveDocParent(ctx, cmd, repoDb)
+	if err != nil {
+		return err
+	}
+
+	docRepo := repository.NewDocumentRepository(repoDb)
+	doc, err := findDocument(ctx, docRepo, args[0])
+	if err != nil {
+		return err
+	}
+
+	if parent == nil {
+		// Links are removed by ON DELETE CASCADE
+		if err := docRepo.Delete(ctx, doc.ID); err != nil {
+			return err
+		}
+
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{
+				"status":      "deleted",
+				"document_id": doc.ID,
+				"title":       doc.Title,
+			})
+		}
+		cli.Success(fmt.Sprintf("Deleted document %q and all of its links", doc.Title))
+		return nil
+	}
+
+	switch parent.Type {
+	case "epic":
+		err = docRepo.UnlinkFromEpic(ctx, parent.ID, doc.ID)
+	case "feature":
+		err = docRepo.UnlinkFromFeature(ctx, parent.ID, doc.ID)
+	case "task":
+		err = docRepo.UnlinkFromTask(ctx, parent.ID, doc.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unlink document: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"status":      "unlinked",
+			"document_id": doc.ID,
+			"title":       doc.Title,
+			"parent":      parent.Type,
+			"parent_key":  parent.Key,
+		})
+	}
+	cli.Success(fmt.Sprintf("Unlinked %q from %s %s", doc.Title, parent.Type, parent.Key))
+	return nil
+}
+
+func init() {
+	cli.RootCmd.AddCommand(docCmd)
+
+	docCmd.AddCommand(docAddCmd)
+	docCmd.AddCommand(docListCmd)
+	docCmd.AddCommand(docRmCmd)
+
+	for _, c := range []*cobra.Command{docAddCmd, docListCmd, docRmCmd} {
+		c.Flags().String("epic", "", "Epic key (e.g., E05)")
+		c.Flags().String("feature", "", "Feature key (e.g., E05-F01)")
+		c.Flags().String("task", "", "Task key (e.g., T-E05-F01-001)")
+	}
+
+	docAddCmd.Flags().String("path", "", "Document path relative to the project root (required)")
+	docAddCmd.Flags().String("title", "", "Document title (default: file name without extension)")
+	_ = docAddCmd.MarkFlagRequired("path")
+}
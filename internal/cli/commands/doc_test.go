@@ -0,0 +1,32 @@
+package commands
+
+import "testing"
+
+// TestNormalizeDocumentPath tests path validation and cleaning for shark doc add
+func TestNormalizeDocumentPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		docPath string
+		want    string
+		wantErr bool
+	}{
+		{name: "relative path", docPath: "docs/prd.md", want: "docs/prd.md"},
+		{name: "leading dot", docPath: "./docs/prd.md", want: "docs/prd.md"},
+		{name: "redundant separators", docPath: "docs//design/./auth.md", want: "docs/design/auth.md"},
+		{name: "empty", docPath: "", wantErr: true},
+		{name: "absolute", docPath: "/etc/passwd", wantErr: true},
+		{name: "traversal", docPath: "docs/../../secret.md", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeDocumentPath(tt.docPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeDocumentPath(%q) error = %v, wantErr %v", tt.docPath, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeDocumentPath(%q) = %q, want %q", tt.docPath, got, tt.want)
+			}
+		})
+	}
+}
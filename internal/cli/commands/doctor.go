@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/jwwelbor/shark-task-manager/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd audits database and filesystem consistency
+var doctorCmd = &cobra.Command{
+	Use:         "doctor",
+	Short:       "Check database and file consistency",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true", cli.WritingFlagsAnnotation: "fix"},
+	GroupID:     "setup",
+	Long: `Audit the project for referential and filesystem problems.
+
+Checks:
+  missing_parent        Features or tasks whose parent epic/feature no longer exists
+  missing_dependency    depends_on entries naming tasks that don't exist
+  missing_file          Recorded file paths whose files are missing on disk
+  duplicate_file_claim  Files claimed by more than one epic, feature, or task
+  orphaned_file         Task, feature.md, and epic.md files under docs/plan with no entity
+  stale_progress        Features whose stored progress doesn't match their tasks
+  quota_exceeded        Epics, features, or the database over a soft limit in
+                        quotas (see 'shark status'), with an archival suggestion
+
+With --fix, safe repairs are applied: stale progress is recalculated and
+missing dependencies are removed from depends_on. Other problems are reported
+with a suggested fix. Exits with an error if any problem remains.
+
+Examples:
+  shark doctor
+  shark doctor --fix
+  shark doctor --json`,
+	RunE: runDoctor,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Bool("fix", false, "Apply safe automatic repairs")
+}
+
+// runDoctor handles the doctor command
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(60 * time.Second)
+	defer cancel()
+
+	fix, _ := cmd.Flags().GetBool("fix")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	repoAdapter := validation.NewRepositoryAdapter(
+		repository.NewEpicRepository(repoDb),
+		repository.NewFeatureRepository(repoDb),
+		repository.NewTaskRepository(repoDb),
+	)
+
+	doctor := validation.NewDoctor(repoAdapter, projectRoot)
+	limits := projectQuotaLimits()
+	doctor.SetQuotaCheck(func(ctx context.Context) ([]*status.QuotaWarning, error) {
+		return status.NewStatusService(repoDb).CheckQuotas(ctx, limits, "", localDatabaseSize())
+	})
+
+	report, err := doctor.Run(ctx, fix)
+	if err != nil {
+		return fmt.Errorf("doctor failed: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		if err := report.FormatJSON(os.Stdout); err != nil {
+			return fmt.Errorf("failed to format JSON output: %w", err)
+		}
+	} else if err := report.FormatHuman(os.Stdout); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if !report.IsHealthy() {
+		return fmt.Errorf("doctor found %d unresolved issue(s)", report.Summary.Remaining)
+	}
+	return nil
+}
@@ -1,24 +1,26 @@
 package commands
 
 import (
-	"bytes"
+	"cmp"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/config"
-	"github.com/jwwelbor/shark-task-manager/internal/db"
 	"github.com/jwwelbor/shark-task-manager/internal/fileops"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/progress"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
 	"github.com/jwwelbor/shark-task-manager/internal/status"
 	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
 	"github.com/jwwelbor/shark-task-manager/internal/utils"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -61,6 +63,47 @@ type FeatureWithDetails struct {
 	TaskCount int `json:"task_count"`
 }
 
+// EpicListJSON is the JSON output of epic list
+type EpicListJSON struct {
+	Results []EpicWithProgress `json:"results"`
+	Count   int                `json:"count"`
+	Total   int                `json:"total"` // Epics matching the filter across all pages
+}
+
+// EpicGetJSON is the JSON output of epic get
+type EpicGetJSON struct {
+	ID                   int64                `json:"id"`
+	Key                  string               `json:"key"`
+	Title                string               `json:"title"`
+	Description          *string              `json:"description"`
+	Status               models.EpicStatus    `json:"status"`
+	StatusSource         string               `json:"status_source"`
+	Priority             models.Priority      `json:"priority"`
+	BusinessValue        *models.Priority     `json:"business_value"`
+	Slug                 *string              `json:"slug"`
+	ProgressPct          float64              `json:"progress_pct"`
+	Path                 string               `json:"path"`
+	Filename             string               `json:"filename"`
+	FilePath             *string              `json:"file_path"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+	Features             []FeatureWithDetails `json:"features"`
+	RelatedDocuments     []*models.Document   `json:"related_documents"`
+	Notes                []*models.EpicNote   `json:"notes"`
+	FeatureStatusRollup  map[string]int       `json:"feature_status_rollup"`
+	TaskStatusRollup     map[string]int       `json:"task_status_rollup"`
+	Impediments          []EpicImpedimentJSON `json:"impediments"`
+	ApprovalBacklogCount int                  `json:"approval_backlog_count"`
+}
+
+// EpicImpedimentJSON is a blocked task listed by epic get
+type EpicImpedimentJSON struct {
+	TaskKey      string     `json:"task_key"`
+	Title        string     `json:"title"`
+	BlockedSince *time.Time `json:"blocked_since"`
+	Reason       string     `json:"reason"`
+}
+
 // epicCmd represents the epic command group
 var epicCmd = &cobra.Command{
 	Use:     "epic",
@@ -71,25 +114,34 @@ var epicCmd = &cobra.Command{
 Examples:
   shark epic list                 List all epics
   shark epic get E04             Get epic details with progress
-  shark epic status              Show status of all epics`,
+  shark epic status              Show status of all epics
+  shark epic note add E04 "..."  Add a planning note to an epic`,
 }
 
 // epicListCmd lists epics
 var epicListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all epics",
+	Use:         "list",
+	Short:       "List all epics",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List all epics with progress information.
 
+Epics are listed by key unless --sort-by or list.epic_sort in .sharkconfig.json
+says otherwise. --limit, --offset, and --page show one page of a long list;
+list.page_size in .sharkconfig.json sets the default --limit.
+
 Examples:
-  shark epic list                 List all epics
-  shark epic list --json          Output as JSON`,
+  shark epic list                          List all epics
+  shark epic list --sort-by=progress:desc  Most complete epics first
+  shark epic list --limit=10 --page=2      Epics 11-20
+  shark epic list --json                   Output as JSON`,
 	RunE: runEpicList,
 }
 
 // epicGetCmd gets a specific epic
 var epicGetCmd = &cobra.Command{
-	Use:   "get <epic-key>",
-	Short: "Get epic details",
+	Use:         "get <epic-key>",
+	Short:       "Get epic details",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display detailed information about a specific epic including all features and progress.
 
 Supports both numeric and slugged key formats:
@@ -104,18 +156,6 @@ Examples:
 	RunE: runEpicGet,
 }
 
-// epicStatusCmd shows status of all epics
-var epicStatusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Show epic status summary",
-	Long:  `Display a summary of all epics with completion percentages and task counts.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// TODO: Implement in E05-F01 (Status Dashboard)
-		cli.Warning("Not yet implemented - coming in E05-F01")
-		return nil
-	},
-}
-
 // epicCompleteCmd completes all tasks in an epic
 var epicCompleteCmd = &cobra.Command{
 	Use:   "complete <epic-key>",
@@ -125,6 +165,10 @@ var epicCompleteCmd = &cobra.Command{
 Without --force, shows a warning summary if any tasks are incomplete and fails.
 With --force, completes all tasks regardless of status.
 
+Use --dry-run to preview the effect and get a confirmation token. When
+require_confirmation_tokens is enabled in .sharkconfig.json, the token must be
+passed with --confirm to execute.
+
 Supports both numeric and slugged key formats:
   - Numeric key: E07
   - Slugged key: E07-epic-name
@@ -132,7 +176,9 @@ Supports both numeric and slugged key formats:
 Examples:
   shark epic complete E07                   Complete epic by numeric key
   shark epic complete E07-enhancements      Complete epic by slugged key
-  shark epic complete E07 --force           Force complete all tasks`,
+  shark epic complete E07 --force           Force complete all tasks
+  shark epic complete E07 --dry-run         Preview and print a confirmation token
+  shark epic complete E07 --force --confirm=<token>`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEpicComplete,
 }
@@ -151,25 +197,39 @@ Flags:
   --description string Epic description
   --priority string    Priority: high, medium, low (default: medium)
   --business-value string Business value: high, medium, low
+  --if-not-exists      Return an existing epic with the same --key or title instead of failing
+  --template string    Named epic template (shark-templates/epics/<name>.md) or template file
+  --var key=value      Template variable, available as {{.Vars.key}} (repeatable)
+
+--if-not-exists makes retries safe: if an epic with the --key, or without --key
+one with the same title (ignoring case), exists, it is returned and nothing is
+created. JSON output has "created": false for an existing epic.
 
 Examples:
   shark epic create "User Authentication System"
+  shark epic create "User Authentication System" --if-not-exists --json
   shark epic create "User Auth" --description="Add OAuth and MFA"
   shark epic create "Platform Roadmap" --file="docs/specs/roadmap.md"
-  shark epic create "Q1 Goals" --file="docs/roadmap/q1.md" --force`,
+  shark epic create "Q1 Goals" --file="docs/roadmap/q1.md" --force
+  shark epic create "Billing Revamp" --template=initiative --var owner=payments`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEpicCreate,
 }
 
 // epicDeleteCmd deletes an epic
 var epicDeleteCmd = &cobra.Command{
-	Use:   "delete <epic-key>",
-	Short: "Delete an epic",
+	Use:         "delete <epic-key>",
+	Short:       "Delete an epic",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
 	Long: `Delete an epic from the database (and all its features/tasks via CASCADE).
 
 WARNING: This action cannot be undone. All features and tasks under this epic will also be deleted.
 If the epic has features, you must use --force to confirm the cascade deletion.
 
+Use --dry-run to preview the effect and get a confirmation token. When
+require_confirmation_tokens is enabled in .sharkconfig.json, the token must be
+passed with --confirm to execute.
+
 Supports both numeric and slugged key formats:
   - Numeric key: E05
   - Slugged key: E05-epic-name
@@ -177,7 +237,9 @@ Supports both numeric and slugged key formats:
 Examples:
   shark epic delete E05                     Delete epic with no features
   shark epic delete E05-enhancements        Delete epic by slugged key
-  shark epic delete E05 --force             Force delete epic with features`,
+  shark epic delete E05 --force             Force delete epic with features
+  shark epic delete E05 --dry-run           Preview and print a confirmation token
+  shark epic delete E05 --force --confirm=<token>`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEpicDelete,
 }
@@ -192,11 +254,22 @@ Supports both numeric and slugged key formats:
   - Numeric key: E01
   - Slugged key: E01-epic-name
 
+A new file path must be relative to the project root. If it is already claimed by
+another epic, feature, or task the update fails; use --force to reassign it (the
+database is backed up first).
+
+A new --key also changes the keys of the epic's features and tasks (E05-F01
+becomes E09-F01, T-E05-F01-001 becomes T-E09-F01-001), the dependencies that
+name them, and key-named files and directories, in one transaction. If the epic
+has features, this needs --force, and the database is backed up first.
+
 Examples:
   shark epic update E01 --title "New Title"
   shark epic update E01-enhancements --description "New description"
   shark epic update E01 --status active
-  shark epic update E01 --file "docs/roadmap/2025.md"`,
+  shark epic update E01 --file "docs/roadmap/2025.md"
+  shark epic update E01 --file "docs/roadmap/shared.md" --force
+  shark epic update E05 --key E09 --force`,
 	Args: cobra.ExactArgs(1),
 	RunE: runEpicUpdate,
 }
@@ -213,18 +286,21 @@ func init() {
 	// Add subcommands
 	epicCmd.AddCommand(epicListCmd)
 	epicCmd.AddCommand(epicGetCmd)
-	epicCmd.AddCommand(epicStatusCmd)
 	epicCmd.AddCommand(epicCompleteCmd)
 	epicCmd.AddCommand(epicCreateCmd)
 	epicCmd.AddCommand(epicDeleteCmd)
 	epicCmd.AddCommand(epicUpdateCmd)
 
 	// Add flags for list command
-	epicListCmd.Flags().String("sort-by", "", "Sort by: key, progress, status (default: key)")
+	epicListCmd.Flags().String("sort-by", "", "Sort by: key, title, status, priority, progress, created_at, updated_at; add :desc to reverse (default: list.epic_sort in .sharkconfig.json, else key)")
+	addListPagingFlags(epicListCmd)
 	epicListCmd.Flags().String("status", "", "Filter by status: draft, active, completed, archived")
+	addWeightedFlag(epicListCmd)
+	addWeightedFlag(epicGetCmd)
 
 	// Add flags for complete command
 	epicCompleteCmd.Flags().Bool("force", false, "Force completion of all tasks regardless of status")
+	addConfirmationFlags(epicCompleteCmd)
 
 	// Add flags for create command
 	epicCreateCmd.Flags().StringVar(&epicCreateDescription, "description", "", "Epic description (optional)")
@@ -238,12 +314,16 @@ func init() {
 	_ = epicCreateCmd.Flags().MarkHidden("path")
 
 	epicCreateCmd.Flags().Bool("force", false, "Force reassignment if file already claimed by another epic or feature")
-	epicCreateCmd.Flags().String("priority", "medium", "Priority: low, medium, high (default: medium)")
+	epicCreateCmd.Flags().String("priority", "medium", "Priority: low, medium, high (default: medium, or the epic.default_priority setting)")
 	epicCreateCmd.Flags().String("business-value", "", "Business value: low, medium, high (optional)")
 	epicCreateCmd.Flags().String("status", "draft", "Status: draft, active, completed, archived (default: draft)")
+	epicCreateCmd.Flags().String("template", "", "Named epic template (see 'shark template list --kind=epic') or path to a template file")
+	epicCreateCmd.Flags().StringArray("var", nil, "Template variable as key=value (repeatable)")
+	addIfNotExistsFlag(epicCreateCmd, "epic", "")
 
 	// Add flags for delete command
 	epicDeleteCmd.Flags().Bool("force", false, "Force deletion even if epic has features")
+	addConfirmationFlags(epicDeleteCmd)
 
 	// Add flags for update command
 	epicUpdateCmd.Flags().String("title", "", "New title for the epic")
@@ -251,7 +331,7 @@ func init() {
 	epicUpdateCmd.Flags().String("status", "", "New status: draft, active, completed, archived")
 	epicUpdateCmd.Flags().String("priority", "", "New priority: low, medium, high")
 	epicUpdateCmd.Flags().String("business-value", "", "New business value: low, medium, high")
-	epicUpdateCmd.Flags().String("key", "", "New key for the epic (must be unique, cannot contain spaces)")
+	epicUpdateCmd.Flags().String("key", "", "New key for the epic (must be unique, cannot contain spaces); its features and tasks follow with --force")
 
 	// File path flags: --file is primary, --filename and --path are hidden aliases
 	epicUpdateCmd.Flags().String("file", "", "New file path (e.g., docs/custom/epic.md)")
@@ -260,43 +340,48 @@ func init() {
 	_ = epicUpdateCmd.Flags().MarkHidden("filename")
 	_ = epicUpdateCmd.Flags().MarkHidden("path")
 
-	epicUpdateCmd.Flags().Bool("force", false, "Force reassignment if file already claimed")
+	epicUpdateCmd.Flags().Bool("force", false, "Force reassignment if file already claimed, or rekey features and tasks along with --key")
 }
 
 // runEpicList executes the epic list command
 func runEpicList(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Get flags
-	sortBy, _ := cmd.Flags().GetString("sort-by")
 	statusFilter, _ := cmd.Flags().GetString("status")
 
 	// Validate status filter using shared parsing function
 	if statusFilter != "" {
 		validatedStatus, err := ParseEpicStatus(statusFilter)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 		statusFilter = validatedStatus
 	}
 
-	// Validate sort-by option
-	if sortBy != "" && sortBy != "key" && sortBy != "progress" && sortBy != "status" {
-		cli.Error(fmt.Sprintf("Error: Invalid sort-by '%s'. Must be one of: key, progress, status", sortBy))
-		os.Exit(1)
+	// Sort and page: --sort-by, --limit, --offset, and --page, else the config's defaults
+	cfg := listConfig()
+	defaultSort := cfg.GetEpicListSort()
+	if defaultSort == "" {
+		defaultSort = "key"
+	}
+	opts, err := listOptions(cmd, defaultSort, cfg.GetListPageSize())
+	if err != nil {
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err))
+	}
+	// Progress isn't stored, so epics sorted by it are sorted and paged here
+	byProgress := opts.SortBy == "progress"
+	if _, ok := repository.EpicSortFields[opts.SortBy]; !ok && !byProgress {
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid sort-by '%s'. Must be one of: key, title, status, priority, progress, created_at, updated_at", opts.SortBy))
 	}
 
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -310,66 +395,90 @@ func runEpicList(cmd *cobra.Command, args []string) error {
 		statusPtr = &status
 	}
 
-	// Get all epics
-	epics, err := epicRepo.List(ctx, statusPtr)
+	// Get the page of epics, or all of them to sort by progress
+	query := opts
+	if byProgress {
+		query = repository.ListOptions{SortBy: "key"}
+	}
+	epics, err := epicRepo.ListPage(ctx, statusPtr, query)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Failed to list epics: %v\n", err)
+		slog.Error("Failed to list epics", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
+	}
+	total := len(epics)
+	if !byProgress && isPaged(opts) {
+		if total, err = epicRepo.Count(ctx, statusPtr); err != nil {
+			slog.Error("Failed to count epics", "error", err)
+			cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 		}
-		os.Exit(2)
 	}
 
 	// Handle empty results
 	if len(epics) == 0 {
 		if cli.GlobalConfig.JSON {
-			result := map[string]interface{}{
-				"results": []interface{}{},
-				"count":   0,
-			}
-			return cli.OutputJSON(result)
+			return cli.OutputJSON(&EpicListJSON{Results: []EpicWithProgress{}, Total: total})
+		}
+		if total > 0 {
+			notePage(opts, 0, total, "epics")
+			return nil
 		}
 		cli.Info("No epics found")
 		return nil
 	}
 
+	// Weight progress by task estimates if asked to
+	var estimateTotals map[int64]progress.EstimateTotals
+	if useWeightedProgress(cmd) {
+		estimateTotals, err = repository.NewTaskEstimateRepository(repoDb).EstimateTotalsByEpic(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Calculate progress for each epic
 	epicsWithProgress := make([]EpicWithProgress, 0, len(epics))
 	for _, epic := range epics {
 		progress, err := epicRepo.CalculateProgress(ctx, epic.ID)
 		if err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to calculate progress for epic %s: %v\n", epic.Key, err)
-			}
+			slog.Warn("Failed to calculate progress for epic", "epic", epic.Key, "error", err)
 			progress = 0.0
 		}
+		if estimateTotals != nil {
+			progress = estimateTotals[epic.ID].WeightedPct()
+		}
 		epicsWithProgress = append(epicsWithProgress, EpicWithProgress{
 			Epic:        epic,
 			ProgressPct: progress,
 		})
 	}
 
-	// Apply sorting
-	sortEpics(epicsWithProgress, sortBy)
+	// Sort by progress and page here; other sorts came from the database
+	if byProgress {
+		sortEpicsByProgress(epicsWithProgress)
+		if opts.Desc {
+			slices.Reverse(epicsWithProgress)
+		}
+		epicsWithProgress = pageSlice(epicsWithProgress, opts)
+	}
 
 	// Output as JSON if requested
 	if cli.GlobalConfig.JSON {
-		result := map[string]interface{}{
-			"results": epicsWithProgress,
-			"count":   len(epicsWithProgress),
-		}
-		return cli.OutputJSON(result)
+		return cli.OutputJSON(&EpicListJSON{Results: epicsWithProgress, Count: len(epicsWithProgress), Total: total})
 	}
 
 	// Output as table
+	if estimateTotals != nil {
+		noteWeightedProgress(ctx, repoDb)
+	}
 	renderEpicListTable(epicsWithProgress)
+	notePage(opts, len(epicsWithProgress), total, "epics")
 	return nil
 }
 
 // runEpicGet executes the epic get command
 func runEpicGet(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	epicKey := args[0]
@@ -377,11 +486,8 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -390,17 +496,16 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 	featureRepo := repository.NewFeatureRepository(repoDb)
 	taskRepo := repository.NewTaskRepository(repoDb)
 	documentRepo := repository.NewDocumentRepository(repoDb)
+	epicNoteRepo := repository.NewEpicNoteRepository(repoDb)
 
 	// Get epic by key
 	epic, err := epicRepo.GetByKey(ctx, epicKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Epic %s does not exist", epicKey))
-		cli.Info("Use 'shark epic list' to see available epics")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Epic %s does not exist", epicKey), "Use 'shark epic list' to see available epics")
 	}
 
 	// Get project root for path resolution
-	projectRoot, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
 		projectRoot = ""
 	}
@@ -412,28 +517,23 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 		absPath, err := pathResolver.ResolveEpicPath(ctx, epic.Key)
 		if err == nil {
 			resolvedPath = getRelativePath(absPath, projectRoot)
-		} else if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to resolve epic path: %v\n", err)
+		} else {
+			slog.Warn("Failed to resolve epic path", "error", err)
 		}
 	}
 
 	// Calculate epic progress
 	epicProgress, err := epicRepo.CalculateProgress(ctx, epic.ID)
 	if err != nil {
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to calculate epic progress: %v\n", err)
-		}
+		slog.Warn("Failed to calculate epic progress", "error", err)
 		epicProgress = 0.0
 	}
 
 	// Get features for this epic
 	features, err := featureRepo.ListByEpic(ctx, epic.ID)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Failed to list features: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Failed to list features", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 
 	// Calculate progress and task count for each feature
@@ -441,26 +541,20 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 	for _, feature := range features {
 		// Update feature progress (in case it's stale)
 		if err := featureRepo.UpdateProgress(ctx, feature.ID); err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to update progress for feature %s: %v\n", feature.Key, err)
-			}
+			slog.Warn("Failed to update progress for feature", "feature", feature.Key, "error", err)
 		}
 
 		// Get updated feature
 		feature, err = featureRepo.GetByID(ctx, feature.ID)
 		if err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to get feature %s: %v\n", feature.Key, err)
-			}
+			slog.Warn("Failed to get feature", "feature", feature.Key, "error", err)
 			continue
 		}
 
 		// Get task count
 		taskCount, err := taskRepo.GetTaskCountForFeature(ctx, feature.ID)
 		if err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to get task count for feature %s: %v\n", feature.Key, err)
-			}
+			slog.Warn("Failed to get task count for feature", "feature", feature.Key, "error", err)
 			taskCount = 0
 		}
 
@@ -470,6 +564,24 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 		})
 	}
 
+	// Weight epic and feature progress by task estimates if asked to
+	weighted := useWeightedProgress(cmd)
+	if weighted {
+		estimateRepo := repository.NewTaskEstimateRepository(repoDb)
+		epicTotals, err := estimateRepo.EstimateTotalsByEpic(ctx)
+		if err != nil {
+			return err
+		}
+		featureTotals, err := estimateRepo.EstimateTotalsByFeature(ctx)
+		if err != nil {
+			return err
+		}
+		epicProgress = epicTotals[epic.ID].WeightedPct()
+		for i := range featuresWithDetails {
+			featuresWithDetails[i].ProgressPct = featureTotals[featuresWithDetails[i].ID].WeightedPct()
+		}
+	}
+
 	// Extract directory path and filename
 	var dirPath, filename string
 	if resolvedPath != "" {
@@ -479,17 +591,26 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 
 	// Get related documents
 	relatedDocs, err := documentRepo.ListForEpic(ctx, epic.ID)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch related documents: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to fetch related documents", "error", err)
 	}
 	if relatedDocs == nil {
 		relatedDocs = []*models.Document{}
 	}
 
+	// Get planning notes (chronological)
+	epicNotes, err := epicNoteRepo.ListByEpicID(ctx, epic.ID)
+	if err != nil {
+		slog.Warn("Failed to fetch epic notes", "error", err)
+	}
+	if epicNotes == nil {
+		epicNotes = []*models.EpicNote{}
+	}
+
 	// Get feature status rollup
 	featureRollup, err := epicRepo.GetFeatureStatusRollup(ctx, epic.ID)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to get feature status rollup: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to get feature status rollup", "error", err)
 	}
 	if featureRollup == nil {
 		featureRollup = make(map[string]int)
@@ -497,8 +618,8 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 
 	// Get task status rollup
 	taskRollup, err := epicRepo.GetTaskStatusRollup(ctx, epic.ID)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to get task status rollup: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to get task status rollup", "error", err)
 	}
 	if taskRollup == nil {
 		taskRollup = make(map[string]int)
@@ -510,8 +631,8 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 		// Get only blocked tasks using optimized query
 		var err error
 		blockedTasks, err = taskRepo.ListBlockedTasksByEpic(ctx, epic.Key)
-		if err != nil && cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to get blocked tasks: %v\n", err)
+		if err != nil {
+			slog.Warn("Failed to get blocked tasks", "error", err)
 		}
 	}
 
@@ -536,52 +657,50 @@ func runEpicGet(cmd *cobra.Command, args []string) error {
 		}
 
 		// Build impediments list
-		impediments := make([]map[string]interface{}, 0)
+		impediments := make([]EpicImpedimentJSON, 0)
 		for _, task := range blockedTasks {
-			blockReason := ""
+			impediment := EpicImpedimentJSON{TaskKey: task.Key, Title: task.Title}
 			if task.BlockedReason != nil {
-				blockReason = *task.BlockedReason
+				impediment.Reason = *task.BlockedReason
 			}
-			blockedSince := interface{}(nil)
 			if task.BlockedAt.Valid {
-				blockedSince = task.BlockedAt.Time
+				impediment.BlockedSince = &task.BlockedAt.Time
 			}
-			impediments = append(impediments, map[string]interface{}{
-				"task_key":      task.Key,
-				"title":         task.Title,
-				"blocked_since": blockedSince,
-				"reason":        blockReason,
-			})
-		}
-
-		result := map[string]interface{}{
-			"id":                     epic.ID,
-			"key":                    epic.Key,
-			"title":                  epic.Title,
-			"description":            epic.Description,
-			"status":                 epic.Status,
-			"status_source":          "calculated", // Epic status is always calculated from features
-			"priority":               epic.Priority,
-			"business_value":         epic.BusinessValue,
-			"slug":                   epic.Slug,
-			"progress_pct":           epicProgress,
-			"path":                   dirPath,
-			"filename":               filename,
-			"file_path":              epic.FilePath,
-			"created_at":             epic.CreatedAt,
-			"updated_at":             epic.UpdatedAt,
-			"features":               featuresWithDetails,
-			"related_documents":      relatedDocs,
-			"feature_status_rollup":  featureSummary,
-			"task_status_rollup":     taskSummary,
-			"impediments":            impediments,
-			"approval_backlog_count": approvalBacklogCount,
+			impediments = append(impediments, impediment)
+		}
+
+		result := &EpicGetJSON{
+			ID:                   epic.ID,
+			Key:                  epic.Key,
+			Title:                epic.Title,
+			Description:          epic.Description,
+			Status:               epic.Status,
+			StatusSource:         "calculated", // Epic status is always calculated from features
+			Priority:             epic.Priority,
+			BusinessValue:        epic.BusinessValue,
+			Slug:                 epic.Slug,
+			ProgressPct:          epicProgress,
+			Path:                 dirPath,
+			Filename:             filename,
+			FilePath:             epic.FilePath,
+			CreatedAt:            epic.CreatedAt,
+			UpdatedAt:            epic.UpdatedAt,
+			Features:             featuresWithDetails,
+			RelatedDocuments:     relatedDocs,
+			Notes:                epicNotes,
+			FeatureStatusRollup:  featureSummary,
+			TaskStatusRollup:     taskSummary,
+			Impediments:          impediments,
+			ApprovalBacklogCount: approvalBacklogCount,
 		}
 		return cli.OutputJSON(result)
 	}
 
 	// Output as formatted text
-	renderEpicDetails(epic, epicProgress, featuresWithDetails, dirPath, filename, relatedDocs, featureRollup, taskRollup, blockedTasks, approvalBacklogCount)
+	if weighted {
+		noteWeightedProgress(ctx, repoDb)
+	}
+	renderEpicDetails(epic, epicProgress, featuresWithDetails, dirPath, filename, entityFileLink(projectRoot, resolvedPath), relatedDocs, epicNotes, featureRollup, taskRollup, blockedTasks, approvalBacklogCount)
 	return nil
 }
 
@@ -616,7 +735,7 @@ func renderEpicListTable(epics []EpicWithProgress) {
 }
 
 // renderEpicDetails renders epic details with features table and rollup information
-func renderEpicDetails(epic *models.Epic, progress float64, features []FeatureWithDetails, path, filename string, relatedDocs []*models.Document, featureRollup map[string]int, taskRollup map[string]int, blockedTasks []*models.Task, approvalBacklogCount int) {
+func renderEpicDetails(epic *models.Epic, progress float64, features []FeatureWithDetails, path, filename, link string, relatedDocs []*models.Document, notes []*models.EpicNote, featureRollup map[string]int, taskRollup map[string]int, blockedTasks []*models.Task, approvalBacklogCount int) {
 	// Print epic metadata
 	pterm.DefaultSection.Printf("Epic: %s", epic.Key)
 	fmt.Println()
@@ -637,6 +756,10 @@ func renderEpicDetails(epic *models.Epic, progress float64, features []FeatureWi
 		info = append(info, []string{"Filename", filename})
 	}
 
+	if link != "" {
+		info = append(info, []string{"Link", link})
+	}
+
 	if epic.Description != nil && *epic.Description != "" {
 		info = append(info, []string{"Description", *epic.Description})
 	}
@@ -659,6 +782,20 @@ func renderEpicDetails(epic *models.Epic, progress float64, features []FeatureWi
 		fmt.Println()
 	}
 
+	// Planning notes section (chronological)
+	if len(notes) > 0 {
+		pterm.DefaultSection.Println("Notes")
+		fmt.Println()
+		for _, note := range notes {
+			creator := ""
+			if note.CreatedBy != nil && *note.CreatedBy != "" {
+				creator = fmt.Sprintf(" (%s)", *note.CreatedBy)
+			}
+			fmt.Printf("  %s%s: %s\n", note.CreatedAt.Format("2006-01-02 15:04"), creator, note.Content)
+		}
+		fmt.Println()
+	}
+
 	// Feature Status Rollup section
 	if len(featureRollup) > 0 {
 		pterm.DefaultSection.Println("Feature Status Rollup")
@@ -763,72 +900,47 @@ func renderEpicDetails(epic *models.Epic, progress float64, features []FeatureWi
 	_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
 }
 
-// sortEpics sorts epics by the specified field
-func sortEpics(epics []EpicWithProgress, sortBy string) {
-	if sortBy == "" || sortBy == "key" {
-		// Sort by key (default)
-		sortEpicsByKey(epics)
-	} else if sortBy == "progress" {
-		// Sort by progress
-		sortEpicsByProgress(epics)
-	} else if sortBy == "status" {
-		// Sort by status
-		sortEpicsByStatus(epics)
-	}
+// sortEpicsByProgress sorts epics by progress (ascending), keeping the order
+// of epics with the same progress
+func sortEpicsByProgress(epics []EpicWithProgress) {
+	slices.SortStableFunc(epics, func(a, b EpicWithProgress) int {
+		return cmp.Compare(a.ProgressPct, b.ProgressPct)
+	})
 }
 
-// sortEpicsByKey sorts epics by key
-func sortEpicsByKey(epics []EpicWithProgress) {
-	for i := 0; i < len(epics); i++ {
-		for j := i + 1; j < len(epics); j++ {
-			if epics[i].Key > epics[j].Key {
-				epics[i], epics[j] = epics[j], epics[i]
-			}
-		}
+// renderEpicTemplate renders shark-templates/epic.md with the given data
+func renderEpicTemplate(data templates.EpicTemplateData) ([]byte, error) {
+	templateContent, err := os.ReadFile(projectTemplatePath("epic.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read epic template: %w", err)
 	}
-}
 
-// sortEpicsByProgress sorts epics by progress (ascending)
-func sortEpicsByProgress(epics []EpicWithProgress) {
-	for i := 0; i < len(epics); i++ {
-		for j := i + 1; j < len(epics); j++ {
-			if epics[i].ProgressPct > epics[j].ProgressPct {
-				epics[i], epics[j] = epics[j], epics[i]
-			}
-		}
+	content, err := templates.RenderEpic(string(templateContent), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render epic template: %w", err)
 	}
+	return []byte(content), nil
 }
 
-// sortEpicsByStatus sorts epics by status (draft, active, completed, archived)
-func sortEpicsByStatus(epics []EpicWithProgress) {
-	statusOrder := map[models.EpicStatus]int{
-		models.EpicStatusDraft:     1,
-		models.EpicStatusActive:    2,
-		models.EpicStatusCompleted: 3,
-		models.EpicStatusArchived:  4,
-	}
-	for i := 0; i < len(epics); i++ {
-		for j := i + 1; j < len(epics); j++ {
-			if statusOrder[epics[i].Status] > statusOrder[epics[j].Status] {
-				epics[i], epics[j] = epics[j], epics[i]
-			}
-		}
+// renderNamedEpicTemplate renders a named epic template (see 'shark template
+// list') or template file with the given data
+func renderNamedEpicTemplate(projectRoot, name string, data templates.EpicTemplateData) ([]byte, error) {
+	registry := templates.NewEpicRegistry(filepath.Join(projectRoot, templates.DefaultProjectEpicTemplateDir))
+	templateContent, _, err := registry.Get(name)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// EpicTemplateData holds data for epic template rendering
-type EpicTemplateData struct {
-	EpicKey     string
-	EpicSlug    string
-	Title       string
-	Description string
-	FilePath    string
-	Date        string
+	content, err := templates.RenderEpic(templateContent, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render epic template %s: %w", name, err)
+	}
+	return []byte(content), nil
 }
 
 // runEpicCreate executes the epic create command
 func runEpicCreate(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Get title from args
@@ -852,52 +964,63 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 
 	force, _ := cmd.Flags().GetBool("force")
 
+	templateName, _ := cmd.Flags().GetString("template")
+	varPairs, _ := cmd.Flags().GetStringArray("var")
+	templateVars, err := templates.ParseVars(varPairs)
+	if err != nil {
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err))
+	}
+
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
-	// Get project root (current working directory)
-	projectRoot, err := os.Getwd()
+	// Get project root
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
-		cli.Error(fmt.Sprintf("Failed to get working directory: %s", err.Error()))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Failed to find project root: %s", err.Error()))
 	}
 
 	// Get repositories
 	epicRepo := repository.NewEpicRepository(repoDb)
-	featureRepo := repository.NewFeatureRepository(repoDb)
+
+	// With --if-not-exists, a retried create returns the epic it made
+	if ifNotExists, _ := cmd.Flags().GetBool(ifNotExistsFlag); ifNotExists {
+		existing, err := findExistingEpic(ctx, epicRepo, epicCreateKey, epicTitle)
+		if err != nil {
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err))
+		}
+		if existing != nil {
+			return outputExistingEntity("epic", existing.Key, existing.Title, existing.FilePath, projectRoot, existing)
+		}
+	}
 
 	// Get epic key (custom or auto-generated)
 	var nextKey string
 	if epicCreateKey != "" {
 		// Validate custom key using shared validator: no spaces allowed
 		if err := ValidateNoSpaces(epicCreateKey, "epic"); err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 
 		// Check if key already exists
 		existing, err := epicRepo.GetByKey(ctx, epicCreateKey)
 		if err == nil && existing != nil {
-			cli.Error(fmt.Sprintf("Error: Epic with key '%s' already exists", epicCreateKey))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Epic with key '%s' already exists", epicCreateKey),
+				"Use --if-not-exists to return the existing epic instead")
 		}
 
 		nextKey = epicCreateKey
 	} else {
 		// Auto-generate next epic key
 		var err error
-		nextKey, err = getNextEpicKey(ctx, epicRepo)
+		nextKey, err = epicRepo.NextKey(ctx)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to get next epic key: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to get next epic key: %v", err))
 		}
 	}
 
@@ -905,49 +1028,35 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 	var customFilePath *string
 	var actualFilePath string // The path where the file will be created
 
+	// Previous owners of a force-reassigned file, journaled for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	var reassignedFrom []string
+
 	if customFile != "" {
 		// Validate custom filename
 		absPath, relPath, err := taskcreation.ValidateCustomFilename(customFile, projectRoot)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Invalid filename: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid filename: %v", err))
 		}
 
-		// Collision detection
-		existingEpic, err := epicRepo.GetByFilePath(ctx, relPath)
+		// Collision detection against the files every epic, feature, and task claims
+		collision, err := claimedFileCollision(ctx, repoDb, relPath)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to check for file collision: %v", err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to check for file collision: %v", err))
 		}
-
-		// Check if feature owns the file
-		existingFeature, _ := featureRepo.GetByFilePath(ctx, relPath)
-
-		// Handle collision
-		if existingEpic != nil && !force {
-			cli.Error(fmt.Sprintf("Error: file '%s' is already claimed by epic %s ('%s'). Use --force to reassign",
-				relPath, existingEpic.Key, existingEpic.Title))
-			os.Exit(1)
+		if collision != nil && !force {
+			cli.Fail(cli.ErrCodeFailure, "Error: "+claimedFileError(collision))
 		}
 
-		if existingFeature != nil && !force {
-			cli.Error(fmt.Sprintf("Error: file '%s' is already claimed by feature %s ('%s'). Use --force to reassign",
-				relPath, existingFeature.Key, existingFeature.Title))
-			os.Exit(1)
-		}
-
-		// Create backup before force reassignment
-		if (existingEpic != nil || existingFeature != nil) && force {
+		if collision != nil {
+			// Create backup before force reassignment
 			dbPath, canBackup, err := cli.GetDatabasePathForBackup()
 			if err != nil {
-				cli.Error(fmt.Sprintf("Error: failed to get database path for backup: %v", err))
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
 			}
 			if canBackup {
 				if _, err := backupDatabaseOnForce(force, dbPath, "force file reassignment"); err != nil {
-					cli.Error(fmt.Sprintf("Error: %v", err))
-					cli.Info("Aborting operation to prevent data loss")
-					os.Exit(2)
+					cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err), "Aborting operation to prevent data loss")
 				}
 			} else {
 				// Cloud database - backup is handled by cloud provider
@@ -955,23 +1064,14 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 					cli.Info("Using cloud database - backup handled by provider")
 				}
 			}
-		}
-
-		// Force reassignment if collision exists and --force is set
-		if existingEpic != nil && force {
-			if err := epicRepo.UpdateFilePath(ctx, existingEpic.Key, nil); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to reassign file from epic %s: %v", existingEpic.Key, err))
-				os.Exit(2)
-			}
-			cli.Warning(fmt.Sprintf("Reassigned file from epic %s ('%s')", existingEpic.Key, existingEpic.Title))
-		}
 
-		if existingFeature != nil && force {
-			if err := featureRepo.UpdateFilePath(ctx, existingFeature.Key, nil); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to reassign file from feature %s: %v", existingFeature.Key, err))
-				os.Exit(2)
+			// Force reassignment: take the file from its owner
+			entityType, ownerKey, ownerTitle := collision.owner()
+			reassignedFrom = append(reassignedFrom, ownerKey)
+			if err := releaseFileClaim(ctx, repoDb, journal, collision); err != nil {
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to reassign file from %s %s: %v", entityType, ownerKey, err))
 			}
-			cli.Warning(fmt.Sprintf("Reassigned file from feature %s ('%s')", existingFeature.Key, existingFeature.Title))
+			cli.Warning(fmt.Sprintf("Reassigned file from %s %s ('%s')", entityType, ownerKey, ownerTitle))
 		}
 
 		customFilePath = &relPath
@@ -986,71 +1086,54 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 		epicDir := fmt.Sprintf("docs/plan/%s", epicSlug)
 
 		// Check if epic already exists (shouldn't happen with auto-increment)
-		if _, err := os.Stat(epicDir); err == nil {
-			cli.Error(fmt.Sprintf("Error: Epic directory already exists: %s", epicDir))
-			os.Exit(1)
+		if _, err := os.Stat(filepath.Join(projectRoot, epicDir)); err == nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Epic directory already exists: %s", epicDir))
 		}
 
-		// Create epic directory
-		if err := os.MkdirAll(epicDir, 0755); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to create epic directory: %v", err))
-			os.Exit(1)
-		}
-
-		// Set both actualFilePath and customFilePath
+		// The file writer creates the epic directory. Set both actualFilePath and customFilePath
 		actualFilePath = fmt.Sprintf("%s/epic.md", epicDir)
 		relPath := actualFilePath // This is already a relative path from project root
 		customFilePath = &relPath
 	}
 
-	// Read epic template
-	templatePath := "shark-templates/epic.md"
-	templateContent, err := os.ReadFile(templatePath)
-	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to read epic template: %v", err))
-		cli.Info("Make sure you've run 'shark init' to create templates")
-		os.Exit(1)
-	}
-
-	// Prepare template data
-	data := EpicTemplateData{
+	// Render epic template
+	templateData := templates.EpicTemplateData{
 		EpicKey:     nextKey,
 		EpicSlug:    nextKey,
 		Title:       epicTitle,
 		Description: epicCreateDescription,
 		FilePath:    actualFilePath,
 		Date:        time.Now().Format("2006-01-02"),
+		Vars:        templateVars,
 	}
-
-	// Parse and execute template
-	tmpl, err := template.New("epic").Parse(string(templateContent))
-	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to parse epic template: %v", err))
-		os.Exit(1)
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to render epic template: %v", err))
-		os.Exit(1)
+	var content []byte
+	if templateName != "" {
+		content, err = renderNamedEpicTemplate(projectRoot, templateName, templateData)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err), "Run 'shark template list --kind=epic' to see available epic templates")
+		}
+	} else {
+		content, err = renderEpicTemplate(templateData)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err), "Make sure you've run 'shark init' to create templates")
+		}
 	}
 
 	// Write epic file using unified file writer
 	writer := fileops.NewEntityFileWriter()
 	result, err := writer.WriteEntityFile(fileops.WriteOptions{
-		Content:        buf.Bytes(),
+		Content:        content,
 		ProjectRoot:    projectRoot,
 		FilePath:       actualFilePath,
 		Verbose:        cli.GlobalConfig.Verbose,
 		EntityType:     "epic",
-		UseAtomicWrite: false, // Epic creation doesn't need atomic write (single process)
+		UseAtomicWrite: true, // Agents creating the same epic at once can't both write it
 		Logger: func(message string) {
 			cli.Info(message)
 		},
 	})
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 	}
 
 	// Capture whether file was linked to existing content
@@ -1058,13 +1141,15 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 
 	// Parse priority flag using shared parsing function (with default "medium")
 	priorityStr, _ := cmd.Flags().GetString("priority")
+	if !cmd.Flags().Changed("priority") {
+		priorityStr = defaultEpicPriority(ctx, repoDb, priorityStr)
+	}
 	if priorityStr == "" {
 		priorityStr = "medium"
 	}
 	priorityStr, err = ParseEpicPriority(priorityStr)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 	}
 	priority := models.Priority(priorityStr)
 
@@ -1074,8 +1159,7 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 	if businessValueStr != "" {
 		businessValueStr, err = ParseEpicPriority(businessValueStr)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Invalid business-value: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid business-value: %v", err))
 		}
 		bv := models.Priority(businessValueStr)
 		businessValue = &bv
@@ -1088,8 +1172,7 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 	}
 	statusStr, err = ParseEpicStatus(statusStr)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 	}
 	status := models.EpicStatus(statusStr)
 
@@ -1105,10 +1188,19 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := epicRepo.Create(ctx, epic); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to create epic in database: %v", err))
-		// Clean up file on DB error
-		os.Remove(actualFilePath)
-		os.Exit(1)
+		// Clean up file on DB error, unless it was an existing file we linked to
+		if result.Written {
+			os.Remove(result.AbsolutePath)
+		}
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to create epic in database: %v", err))
+	}
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityEpic, EntityKey: epic.Key, Action: models.AuditActionCreate, Summary: epic.Title})
+
+	if len(reassignedFrom) > 0 {
+		journal.setOnUndo("epics", epic.ID, "file_path", nil)
+		journal.record(ctx, "file reassign", epic.Key, fmt.Sprintf("Reassigned %s from %s to epic %s", *customFilePath, strings.Join(reassignedFrom, ", "), epic.Key))
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityEpic, EntityKey: epic.Key, Action: models.AuditActionFileReassign,
+			Summary: fmt.Sprintf("Took %s from %s", *customFilePath, strings.Join(reassignedFrom, ", "))})
 	}
 
 	// Success output
@@ -1127,31 +1219,23 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// getNextEpicKey finds the next available epic key
-func getNextEpicKey(ctx context.Context, epicRepo *repository.EpicRepository) (string, error) {
-	epics, err := epicRepo.List(ctx, nil)
-	if err != nil {
-		return "", err
-	}
-
-	maxNum := 0
-	for _, epic := range epics {
-		// Extract number from key in DB (E01 -> 1, E02 -> 2, etc.)
-		var num int
-		if _, err := fmt.Sscanf(epic.Key, "E%d", &num); err == nil {
-			if num > maxNum {
-				maxNum = num
-			}
+// findExistingEpic returns the epic a create would duplicate: the epic with the
+// custom key, or without one the epic with the same title. Returns nil if none.
+func findExistingEpic(ctx context.Context, epicRepo *repository.EpicRepository, key, title string) (*models.Epic, error) {
+	if key != "" {
+		existing, err := epicRepo.GetByKey(ctx, key)
+		if err == nil && existing != nil {
+			return existing, nil
 		}
+		return nil, nil
 	}
-
-	return fmt.Sprintf("E%02d", maxNum+1), nil
+	return epicRepo.GetByTitle(ctx, title)
 }
 
 // runEpicComplete executes the epic complete command
 func runEpicComplete(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	epicKey := args[0]
@@ -1160,11 +1244,8 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1176,16 +1257,13 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 	// Get epic by key
 	epic, err := epicRepo.GetByKey(ctx, epicKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Epic %s does not exist", epicKey))
-		cli.Info("Use 'shark epic list' to see available epics")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Epic %s does not exist", epicKey), "Use 'shark epic list' to see available epics")
 	}
 
 	// Get all features in epic
 	features, err := featureRepo.ListByEpic(ctx, epic.ID)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to list features: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to list features: %v", err))
 	}
 
 	// If no features, inform user
@@ -1203,8 +1281,7 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 	for _, feature := range features {
 		tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to list tasks in feature %s: %v", feature.Key, err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to list tasks in feature %s: %v", feature.Key, err))
 		}
 
 		allTasks = append(allTasks, tasks...)
@@ -1213,8 +1290,7 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 		// Get status breakdown using new workflow-aware method
 		statusBreakdownSlice, err := taskRepo.GetStatusBreakdown(ctx, feature.ID)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to get status breakdown for feature %s: %v", feature.Key, err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to get status breakdown for feature %s: %v", feature.Key, err))
 		}
 
 		// Convert to map for efficient lookup during aggregation
@@ -1243,6 +1319,24 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 	// Check if all tasks are already completed/reviewed
 	hasIncomplete := allDoneCount < len(allTasks)
 
+	// Describe the force completion for --dry-run and confirmation tokens
+	forceAffected := []string{}
+	for _, task := range allTasks {
+		if task.Status != models.TaskStatusCompleted && task.Status != models.TaskStatusReadyForReview {
+			forceAffected = append(forceAffected, fmt.Sprintf("%s:%s", task.Key, task.Status))
+		}
+	}
+	op := &DestructiveOperation{
+		Operation: "epic complete",
+		Key:       epic.Key,
+		Summary:   fmt.Sprintf("Would force %d incomplete task(s) in epic %s to completed", len(forceAffected), epic.Key),
+		Affected:  forceAffected,
+	}
+
+	if isDryRun(cmd) {
+		return printDestructiveDryRun(op)
+	}
+
 	// Show warning if incomplete tasks exist
 	if hasIncomplete && !force {
 		cli.Warning("Cannot complete epic with incomplete tasks")
@@ -1335,21 +1429,22 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 
 		cli.Info("Use --force to complete all tasks regardless of status")
-		os.Exit(3)
+		cli.Exit(cli.ExitInvalidState)
 	}
 
 	// Create backup before force completing tasks
 	if force && hasIncomplete {
+		if err := verifyConfirmationToken(cmd, op); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+		}
+
 		dbPath, canBackup, err := cli.GetDatabasePathForBackup()
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: failed to get database path for backup: %v", err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
 		}
 		if canBackup {
 			if _, err := backupDatabaseOnForce(force, dbPath, "force complete epic"); err != nil {
-				cli.Error(fmt.Sprintf("Error: %v", err))
-				cli.Info("Aborting operation to prevent data loss")
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err), "Aborting operation to prevent data loss")
 			}
 		} else {
 			// Cloud database - backup is handled by cloud provider
@@ -1359,6 +1454,18 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Snapshot statuses changed by a forced completion for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	if force && hasIncomplete {
+		featureIDs := make([]int64, len(features))
+		for i, feature := range features {
+			featureIDs[i] = feature.ID
+		}
+		journal.captureColumns(ctx, "tasks", incompleteTaskIDs(allTasks), "status", "completed_at")
+		journal.captureColumns(ctx, "features", featureIDs, "status", "progress_pct")
+		journal.captureColumns(ctx, "epics", []int64{epic.ID}, "status")
+	}
+
 	// Complete all tasks in a transaction
 	agent := getAgentIdentifier("")
 	completedTaskCount := 0
@@ -1373,8 +1480,7 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 
 		// Mark as completed
 		if err := taskRepo.UpdateStatusForced(ctx, task.ID, models.TaskStatusCompleted, &agent, nil, nil, nil, true); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to complete task %s: %v", task.Key, err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to complete task %s: %v", task.Key, err))
 		}
 		completedTaskCount++
 		affectedTaskKeys = append(affectedTaskKeys, task.Key)
@@ -1384,15 +1490,13 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 	for _, feature := range features {
 		// Update progress first (will auto-complete if all tasks are done)
 		if err := featureRepo.UpdateProgress(ctx, feature.ID); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update progress for feature %s: %v", feature.Key, err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to update progress for feature %s: %v", feature.Key, err))
 		}
 
 		// Fetch the updated feature to check its status
 		updatedFeature, err := featureRepo.GetByID(ctx, feature.ID)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to get updated feature %s: %v", feature.Key, err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to get updated feature %s: %v", feature.Key, err))
 		}
 
 		// Explicitly mark feature as completed if not already
@@ -1400,8 +1504,7 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 		if updatedFeature.Status != models.FeatureStatusCompleted {
 			updatedFeature.Status = models.FeatureStatusCompleted
 			if err := featureRepo.Update(ctx, updatedFeature); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to complete feature %s: %v", updatedFeature.Key, err))
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to complete feature %s: %v", updatedFeature.Key, err))
 			}
 		}
 	}
@@ -1411,8 +1514,13 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 	// Set epic status to completed
 	epic.Status = models.EpicStatusCompleted
 	if err := epicRepo.Update(ctx, epic); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to update epic status: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to update epic status: %v", err))
+	}
+
+	if force && hasIncomplete {
+		summary := fmt.Sprintf("Force completed %d task(s) in epic %s", len(affectedTaskKeys), epic.Key)
+		journal.record(ctx, "epic complete", epic.Key, summary)
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityEpic, EntityKey: epic.Key, Action: models.AuditActionForce, Summary: summary})
 	}
 
 	// Output results
@@ -1457,7 +1565,7 @@ func runEpicComplete(cmd *cobra.Command, args []string) error {
 
 // runEpicDelete executes the epic delete command
 func runEpicDelete(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	epicKey := args[0]
@@ -1466,11 +1574,8 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1481,39 +1586,58 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 	// Get epic by key to verify it exists
 	epic, err := epicRepo.GetByKey(ctx, epicKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Epic %s does not exist", epicKey))
-		cli.Info("Use 'shark epic list' to see available epics")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Epic %s does not exist", epicKey), "Use 'shark epic list' to see available epics")
 	}
 
 	// Check for child features
 	features, err := featureRepo.ListByEpic(ctx, epic.ID)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to check for features: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to check for features: %v", err))
+	}
+
+	// Describe the cascade for --dry-run and confirmation tokens
+	taskCount := 0
+	affected := make([]string, 0, len(features))
+	for _, feature := range features {
+		count, err := featureRepo.GetTaskCount(ctx, feature.ID)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to count tasks in feature %s: %v", feature.Key, err))
+		}
+		taskCount += count
+		affected = append(affected, feature.Key)
+	}
+	op := &DestructiveOperation{
+		Operation: "epic delete",
+		Key:       epic.Key,
+		Summary:   fmt.Sprintf("Would delete epic %s and cascade delete %d feature(s) and %d task(s)", epic.Key, len(features), taskCount),
+		Affected:  affected,
+	}
+
+	if isDryRun(cmd) {
+		return printDestructiveDryRun(op)
 	}
 
 	// If there are features, require --force flag
 	if len(features) > 0 && !force {
-		cli.Error(fmt.Sprintf("Error: Epic %s has %d feature(s)", epicKey, len(features)))
 		cli.Warning("This will CASCADE DELETE all features and their tasks")
-		cli.Info(fmt.Sprintf("Use --force to confirm deletion: shark epic delete %s --force", epicKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Error: Epic %s has %d feature(s)", epicKey, len(features)),
+			fmt.Sprintf("Use --force to confirm deletion: shark epic delete %s --force", epicKey))
 	}
 
 	// Create backup before cascade delete (when epic has features)
 	if len(features) > 0 {
+		if err := verifyConfirmationToken(cmd, op); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+		}
+
 		dbPath, canBackup, err := cli.GetDatabasePathForBackup()
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: failed to get database path for backup: %v", err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
 		}
 		if canBackup {
-			backupPath, err := db.BackupDatabase(dbPath)
+			backupPath, err := createDatabaseBackup(dbPath, fmt.Sprintf("cascade delete epic %s", epic.Key))
 			if err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to create backup before deletion: %v", err))
-				cli.Info("Aborting deletion to prevent data loss")
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to create backup before deletion: %v", err), "Aborting deletion to prevent data loss")
 			}
 			if !cli.GlobalConfig.JSON {
 				cli.Info(fmt.Sprintf("Database backup created: %s", backupPath))
@@ -1526,11 +1650,17 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Snapshot the epic and its cascade for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	journal.captureDelete(ctx, "epics", epic.ID)
+
 	// Delete epic from database (CASCADE will handle features/tasks)
 	if err := epicRepo.Delete(ctx, epic.ID); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to delete epic: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to delete epic: %v", err))
 	}
+	summary := fmt.Sprintf("Deleted epic %s with %d feature(s) and %d task(s)", epic.Key, len(features), taskCount)
+	journal.record(ctx, "epic delete", epic.Key, summary)
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityEpic, EntityKey: epic.Key, Action: models.AuditActionDelete, Summary: summary})
 
 	cli.Success(fmt.Sprintf("Epic %s deleted successfully", epicKey))
 	if len(features) > 0 {
@@ -1547,7 +1677,7 @@ func containsSpace(s string) bool {
 
 // runEpicUpdate executes the epic update command
 func runEpicUpdate(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	epicKey := args[0]
@@ -1555,11 +1685,8 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1569,10 +1696,9 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 	// Get epic by key to verify it exists
 	epic, err := epicRepo.GetByKey(ctx, epicKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Epic %s does not exist", epicKey))
-		cli.Info("Use 'shark epic list' to see available epics")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Epic %s does not exist", epicKey), "Use 'shark epic list' to see available epics")
 	}
+	auditBefore := auditFields(epic)
 
 	// Track if any changes were made
 	changed := false
@@ -1606,20 +1732,19 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 		if strings.ToLower(statusFlag) == "auto" {
 			// Load workflow config
 			configPath, err := cli.GetConfigPath()
-			if err != nil && cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to get config path: %v\n", err)
+			if err != nil {
+				slog.Warn("Failed to get config path", "error", err)
 			}
 			cfg, err := config.LoadWorkflowConfig(configPath)
-			if err != nil && cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", err)
+			if err != nil {
+				slog.Warn("Failed to load config", "error", err)
 			}
 
 			// Recalculate status from features
 			calcService := status.NewCalculationService(repoDb, cfg)
 			result, err := calcService.RecalculateEpicStatus(ctx, epic.ID)
 			if err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to recalculate status: %v", err))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to recalculate status: %v", err))
 			}
 
 			cli.Success(fmt.Sprintf("Epic %s status recalculated: %s (calculated from features)", epic.Key, result.NewStatus))
@@ -1629,8 +1754,7 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 		// Regular status update
 		validatedStatus, err := ParseEpicStatus(statusFlag)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 		epic.Status = models.EpicStatus(validatedStatus)
 		changed = true
@@ -1638,8 +1762,7 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 		// Cascade status to child features and tasks if --force is used and status is completed
 		if force && epic.Status == models.EpicStatusCompleted {
 			if err := epicRepo.CascadeStatusToFeaturesAndTasks(ctx, epic.ID, models.FeatureStatusCompleted, models.TaskStatusCompleted); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to cascade status to features and tasks: %v", err))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to cascade status to features and tasks: %v", err))
 			}
 		}
 	}
@@ -1649,8 +1772,7 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 	if priority != "" {
 		validatedPriority, err := ParseEpicPriority(priority)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 		epic.Priority = models.Priority(validatedPriority)
 		changed = true
@@ -1661,70 +1783,115 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 	if businessValue != "" {
 		validatedBV, err := ParseEpicPriority(businessValue)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Invalid business-value: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid business-value: %v", err))
 		}
 		bv := models.Priority(validatedBV)
 		epic.BusinessValue = &bv
 		changed = true
 	}
 
-	// Apply core field updates if any changed
-	if changed {
-		if err := epicRepo.Update(ctx, epic); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update epic: %v", err))
-			os.Exit(1)
+	// Handle filename update separately (uses different repository method)
+	// Try all three flag aliases: --file, --filename, --path (last one wins)
+	file, _ := cmd.Flags().GetString("file")
+	filename, _ := cmd.Flags().GetString("filename")
+	path, _ := cmd.Flags().GetString("path")
+
+	// Determine which flag was provided (priority: path > filename > file)
+	var customFile string
+	if path != "" {
+		customFile = path
+	} else if filename != "" {
+		customFile = filename
+	} else if file != "" {
+		customFile = file
+	}
+
+	// Validate the path and resolve collisions before changing anything
+	// (--force reassigns a file claimed by another entity, with a backup)
+	var relPath string
+	if customFile != "" {
+		relPath, err = AssignFileForUpdate(ctx, repoDb, customFile, force, func(c *FileCollision) bool {
+			return c.Epic != nil && c.Epic.ID == epic.ID
+		})
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 	}
 
-	// Handle key update separately (requires unique validation)
+	// Plan a key change before changing anything. Features and tasks keyed
+	// under the epic (E05-F01, T-E05-F01-001) take the new key too, which
+	// needs --force; the database is backed up first.
+	// Use the canonical key: the argument may be a numeric or slugged form
+	currentKey := epic.Key
 	newKey, _ := cmd.Flags().GetString("key")
+	var rekeyPlan *repository.RenumberPlan
+	var projectRoot string
 	if newKey != "" {
 		// Validate new key using shared validator: no spaces allowed
 		if err := ValidateNoSpaces(newKey, "epic"); err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 
 		// Check if new key already exists (and is different from current key)
-		if newKey != epicKey {
+		if newKey != currentKey {
 			existing, err := epicRepo.GetByKey(ctx, newKey)
 			if err == nil && existing != nil {
-				cli.Error(fmt.Sprintf("Error: Epic with key '%s' already exists", newKey))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Epic with key '%s' already exists", newKey))
 			}
 
-			// Update the key
-			if err := epicRepo.UpdateKey(ctx, epicKey, newKey); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to update epic key: %v", err))
-				os.Exit(1)
+			projectRoot, err = cli.FindProjectRoot()
+			if err != nil {
+				return err
+			}
+			rekeyPlan, err = planEpicRekey(ctx, repoDb, projectRoot, epic, newKey)
+			if err != nil {
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to plan key change: %v", err))
+			}
+			if children := len(rekeyPlan.Changes) - 1; children > 0 && !force {
+				cli.Fail(cli.ErrCodeConflict,
+					fmt.Sprintf("Error: %d features and tasks are keyed under %s and would be rekeyed to %s", children, currentKey, newKey),
+					"Use --force to change their keys, dependencies, and file names too (the database is backed up first)")
 			}
-			changed = true
 		}
 	}
 
-	// Handle filename update separately (uses different repository method)
-	// Try all three flag aliases: --file, --filename, --path (last one wins)
-	file, _ := cmd.Flags().GetString("file")
-	filename, _ := cmd.Flags().GetString("filename")
-	path, _ := cmd.Flags().GetString("path")
+	// Apply core field updates if any changed
+	if changed {
+		if err := epicRepo.Update(ctx, epic); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update epic: %v", err))
+		}
+	}
 
-	// Determine which flag was provided (priority: path > filename > file)
-	var customFile string
-	if path != "" {
-		customFile = path
-	} else if filename != "" {
-		customFile = filename
-	} else if file != "" {
-		customFile = file
+	// Handle the key change: the epic, its features and tasks, their
+	// dependencies, and key-named files change together
+	var frontmatterErrors []string
+	if rekeyPlan != nil {
+		if len(rekeyPlan.Changes) > 1 {
+			dbPath, canBackup, err := cli.GetDatabasePathForBackup()
+			if err != nil {
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
+			}
+			if canBackup {
+				if _, err := backupDatabaseOnForce(force, dbPath, "epic rekey"); err != nil {
+					cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err), "Aborting operation to prevent data loss")
+				}
+			} else if cli.GlobalConfig.Verbose {
+				// Cloud database - backup is handled by cloud provider
+				cli.Info("Using cloud database - backup handled by provider")
+			}
+		}
+
+		frontmatterErrors, err = applyEpicRekey(ctx, repoDb, projectRoot, rekeyPlan)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update epic key: %v", err))
+		}
+		currentKey = newKey
+		changed = true
 	}
 
-	if customFile != "" {
-		// This is handled separately as it may involve file reassignment
-		// For now, just update the file path in the database
-		if err := epicRepo.UpdateFilePath(ctx, epicKey, &customFile); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update epic file path: %v", err))
-			os.Exit(1)
+	if relPath != "" {
+		if err := epicRepo.UpdateFilePath(ctx, currentKey, &relPath); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update epic file path: %v", err))
 		}
 		changed = true
 	}
@@ -1734,6 +1901,16 @@ func runEpicUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	cli.Success(fmt.Sprintf("Epic %s updated successfully", epicKey))
+	if updated, err := epicRepo.GetByID(ctx, epic.ID); err == nil {
+		recordAuditUpdate(ctx, repoDb, models.AuditEntityEpic, currentKey, auditBefore, updated)
+	}
+
+	cli.Success(fmt.Sprintf("Epic %s updated successfully", currentKey))
+	if rekeyPlan != nil && len(rekeyPlan.Changes) > 1 {
+		cli.Info(fmt.Sprintf("Rekeyed %d features and tasks from %s to %s", len(rekeyPlan.Changes)-1, rekeyPlan.Changes[0].OldKey, currentKey))
+	}
+	for _, message := range frontmatterErrors {
+		cli.Warning(fmt.Sprintf("Failed to update frontmatter in %s", message))
+	}
 	return nil
 }
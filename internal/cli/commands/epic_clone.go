@@ -0,0 +1,381 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/fileops"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// epicCloneCmd starts a new epic from the structure of an existing one
+var epicCloneCmd = &cobra.Command{
+	Use:   "clone <epic-key> <title>",
+	Short: "Start a new epic from an existing epic's features and tasks",
+	Long: `Create a new epic with the same features and task skeletons as an existing
+one, as a quick start for a recurring kind of project.
+
+Features keep their titles and descriptions. Tasks keep their titles,
+descriptions, agent types, priorities, execution order, and acceptance
+criteria, and start in the workflow's initial status with criteria pending.
+Dependencies between tasks of the source epic point at the matching new tasks;
+dependencies on tasks outside the epic are kept as they are. The new epic and
+its features start as draft.
+
+Examples:
+  shark epic clone E05 "Q3 compliance audit"
+  shark epic clone E05 "Q3 compliance audit" --dry-run
+  shark epic clone E05 "Partner onboarding" --features-only --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEpicClone,
+}
+
+func init() {
+	epicCmd.AddCommand(epicCloneCmd)
+
+	epicCloneCmd.Flags().Bool("features-only", false, "Copy features but not their tasks")
+	epicCloneCmd.Flags().Bool("dry-run", false, "Show what would be created without creating it")
+}
+
+// EpicCloneJSON is the JSON output of epic clone
+type EpicCloneJSON struct {
+	Source   string             `json:"source"`
+	Epic     string             `json:"epic,omitempty"` // Empty on a dry run
+	Title    string             `json:"title"`
+	DryRun   bool               `json:"dry_run"`
+	Features []EpicCloneFeature `json:"features"`
+}
+
+// EpicCloneFeature is a feature of the source epic and its copy
+type EpicCloneFeature struct {
+	Source string          `json:"source"`
+	Key    string          `json:"key,omitempty"`
+	Title  string          `json:"title"`
+	Tasks  []EpicCloneTask `json:"tasks"`
+}
+
+// EpicCloneTask is a task of the source epic and its copy. DependsOn holds the
+// copy's dependencies: new keys for tasks within the epic, source keys otherwise.
+type EpicCloneTask struct {
+	Source    string   `json:"source"`
+	Key       string   `json:"key,omitempty"`
+	Title     string   `json:"title"`
+	AgentType string   `json:"agent_type"`
+	DependsOn []string `json:"depends_on"`
+}
+
+// runEpicClone handles the epic clone command
+func runEpicClone(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(60 * time.Second)
+	defer cancel()
+
+	sourceKey := NormalizeKey(args[0])
+	if !IsEpicKey(sourceKey) {
+		return InvalidEpicKeyError(args[0])
+	}
+	title := strings.TrimSpace(args[1])
+	if title == "" {
+		return fmt.Errorf("the new epic needs a title")
+	}
+	featuresOnly, _ := cmd.Flags().GetBool("features-only")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	taskRepo := repository.NewTaskRepository(repoDb)
+
+	source, err := epicRepo.GetByKey(ctx, sourceKey)
+	if err != nil {
+		return fmt.Errorf("epic %s not found", sourceKey)
+	}
+	features, err := featureRepo.ListByEpic(ctx, source.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list features for epic %s: %w", source.Key, err)
+	}
+	tasksByFeature := make(map[int64][]*models.Task, len(features))
+	if !featuresOnly {
+		for _, feature := range features {
+			tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list tasks for feature %s: %w", feature.Key, err)
+			}
+			tasksByFeature[feature.ID] = tasks
+		}
+	}
+
+	output := &EpicCloneJSON{Source: source.Key, Title: title, DryRun: dryRun, Features: buildEpicClonePlan(features, tasksByFeature)}
+
+	if !dryRun {
+		projectRoot, err := cli.FindProjectRoot()
+		if err != nil {
+			return fmt.Errorf("failed to find project root: %w", err)
+		}
+		if err := cloneEpic(ctx, repoDb, projectRoot, source, features, tasksByFeature, output); err != nil {
+			return err
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(output)
+	}
+
+	printEpicClone(output)
+	taskCount := 0
+	for _, feature := range output.Features {
+		taskCount += len(feature.Tasks)
+	}
+	if dryRun {
+		cli.Info("Dry run: %d feature(s) and %d task(s) would be copied from %s", len(output.Features), taskCount, source.Key)
+		return nil
+	}
+	cli.Success(fmt.Sprintf("Created epic %s from %s with %d feature(s) and %d task(s)", output.Epic, source.Key, len(output.Features), taskCount))
+	return nil
+}
+
+// buildEpicClonePlan lists the features and tasks to copy, with dependencies
+// still given by source key
+func buildEpicClonePlan(features []*models.Feature, tasksByFeature map[int64][]*models.Task) []EpicCloneFeature {
+	plan := make([]EpicCloneFeature, 0, len(features))
+	for _, feature := range features {
+		clone := EpicCloneFeature{Source: feature.Key, Title: feature.Title, Tasks: []EpicCloneTask{}}
+		for _, task := range tasksByFeature[feature.ID] {
+			agentType := ""
+			if task.AgentType != nil {
+				agentType = *task.AgentType
+			}
+			clone.Tasks = append(clone.Tasks, EpicCloneTask{
+				Source:    task.Key,
+				Title:     task.Title,
+				AgentType: agentType,
+				DependsOn: taskDependsOnKeys(task),
+			})
+		}
+		plan = append(plan, clone)
+	}
+	return plan
+}
+
+// remapCloneDependencies points dependencies on copied tasks at their copies
+// and keeps dependencies on other tasks unchanged
+func remapCloneDependencies(dependsOn []string, newKeys map[string]string) []string {
+	remapped := make([]string, 0, len(dependsOn))
+	for _, key := range dependsOn {
+		if newKey, ok := newKeys[key]; ok {
+			key = newKey
+		}
+		remapped = appendUnique(remapped, key)
+	}
+	return remapped
+}
+
+// taskDependsOnKeys parses a task's depends_on column; malformed values are empty
+func taskDependsOnKeys(task *models.Task) []string {
+	keys := []string{}
+	if task.DependsOn != nil && *task.DependsOn != "" {
+		_ = json.Unmarshal([]byte(*task.DependsOn), &keys)
+	}
+	return keys
+}
+
+// cloneEpic creates the new epic, its features, and its tasks, filling in the
+// new keys in output. Tasks are created first and their dependencies set once
+// every copy has a key, so a task may depend on one that comes after it.
+func cloneEpic(ctx context.Context, repoDb *repository.DB, projectRoot string, source *models.Epic, features []*models.Feature, tasksByFeature map[int64][]*models.Task, output *EpicCloneJSON) error {
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	taskRepo := repository.NewTaskRepository(repoDb)
+	criteriaRepo := repository.NewTaskCriteriaRepository(repoDb)
+
+	epic, epicDir, err := createClonedEpic(ctx, epicRepo, projectRoot, source, output.Title)
+	if err != nil {
+		return err
+	}
+	output.Epic = epic.Key
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityEpic, EntityKey: epic.Key, Action: models.AuditActionCreate, Summary: fmt.Sprintf("%s (cloned from %s)", epic.Title, source.Key)})
+
+	keygen := taskcreation.NewKeyGenerator(taskRepo, featureRepo)
+	validator := taskcreation.NewValidator(epicRepo, featureRepo, taskRepo)
+	registry := templates.NewRegistry(filepath.Join(projectRoot, templates.DefaultProjectTemplateDir))
+	renderer := templates.NewRendererWithRegistry(templates.NewLoader(""), registry)
+	creator := taskcreation.NewCreator(repoDb, keygen, validator, renderer, taskRepo, repository.NewTaskHistoryRepository(repoDb), epicRepo, featureRepo, projectRoot, nil)
+
+	newKeys := map[string]string{}
+	newTasks := map[string]*models.Task{}
+	for i, sourceFeature := range features {
+		stub := parser.EpicFeatureStub{Title: sourceFeature.Title}
+		if sourceFeature.Description != nil {
+			stub.Description = *sourceFeature.Description
+		}
+		feature, _, err := createFeatureFromStub(ctx, featureRepo, epic, epicDir, projectRoot, stub, models.FeatureStatusDraft)
+		if err != nil {
+			return fmt.Errorf("epic %s created but feature %q could not be copied: %w", epic.Key, sourceFeature.Title, err)
+		}
+		output.Features[i].Key = feature.Key
+
+		for j, sourceTask := range tasksByFeature[sourceFeature.ID] {
+			input := taskcreation.CreateTaskInput{
+				EpicKey:    epic.Key,
+				FeatureKey: feature.Key,
+				Title:      sourceTask.Title,
+				AgentType:  output.Features[i].Tasks[j].AgentType,
+				Priority:   sourceTask.Priority,
+			}
+			if sourceTask.Description != nil {
+				input.Description = *sourceTask.Description
+			}
+			if sourceTask.ExecutionOrder != nil {
+				input.ExecutionOrder = *sourceTask.ExecutionOrder
+			}
+			result, err := creator.CreateTask(ctx, input)
+			if err != nil {
+				return fmt.Errorf("epic %s created but task %s could not be copied: %w", epic.Key, sourceTask.Key, err)
+			}
+			recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityTask, EntityKey: result.Task.Key, Action: models.AuditActionCreate, Summary: result.Task.Title})
+
+			criteria, err := criteriaRepo.GetByTaskID(ctx, sourceTask.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get criteria of %s: %w", sourceTask.Key, err)
+			}
+			for _, criterion := range criteria {
+				if err := criteriaRepo.Create(ctx, &models.TaskCriteria{TaskID: result.Task.ID, Criterion: criterion.Criterion, Status: models.CriteriaStatusPending}); err != nil {
+					return fmt.Errorf("task %s created but criteria could not be copied: %w", result.Task.Key, err)
+				}
+			}
+
+			newKeys[sourceTask.Key] = result.Task.Key
+			newTasks[sourceTask.Key] = result.Task
+			output.Features[i].Tasks[j].Key = result.Task.Key
+		}
+	}
+
+	for i := range output.Features {
+		for j := range output.Features[i].Tasks {
+			clone := &output.Features[i].Tasks[j]
+			clone.DependsOn = remapCloneDependencies(clone.DependsOn, newKeys)
+			if len(clone.DependsOn) == 0 {
+				continue
+			}
+			data, _ := json.Marshal(clone.DependsOn)
+			dependsOn := string(data)
+			if err := taskRepo.UpdateDependsOn(ctx, newTasks[clone.Source].ID, &dependsOn); err != nil {
+				return fmt.Errorf("failed to set dependencies of %s: %w", clone.Key, err)
+			}
+		}
+	}
+
+	for _, feature := range output.Features {
+		if created, err := featureRepo.GetByKey(ctx, feature.Key); err == nil {
+			triggerStatusCascade(ctx, repoDb, created.ID)
+		}
+	}
+	return nil
+}
+
+// createClonedEpic creates the epic document and database entry for a copy of
+// source. Returns the epic and its absolute directory.
+func createClonedEpic(ctx context.Context, epicRepo *repository.EpicRepository, projectRoot string, source *models.Epic, title string) (*models.Epic, string, error) {
+	key, err := epicRepo.NextKey(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get next epic key: %w", err)
+	}
+
+	epicDir := filepath.Join(projectRoot, "docs", "plan", fmt.Sprintf("%s-%s", key, utils.GenerateSlug(title)))
+	if _, err := os.Stat(epicDir); err == nil {
+		return nil, "", fmt.Errorf("epic directory already exists: %s", relativeToRoot(projectRoot, epicDir))
+	}
+	relPath := relativeToRoot(projectRoot, filepath.Join(epicDir, "epic.md"))
+
+	description := ""
+	if source.Description != nil {
+		description = *source.Description
+	}
+	content, err := renderEpicTemplate(templates.EpicTemplateData{
+		EpicKey:     key,
+		EpicSlug:    key,
+		Title:       title,
+		Description: description,
+		FilePath:    relPath,
+		Date:        time.Now().Format("2006-01-02"),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	writer := fileops.NewEntityFileWriter()
+	writeResult, err := writer.WriteEntityFile(fileops.WriteOptions{
+		Content:        content,
+		ProjectRoot:    projectRoot,
+		FilePath:       relPath,
+		Verbose:        cli.GlobalConfig.Verbose,
+		EntityType:     "epic",
+		UseAtomicWrite: true,
+		Logger: func(message string) {
+			cli.Info(message)
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	epic := &models.Epic{
+		Key:           key,
+		Title:         title,
+		Description:   source.Description,
+		Status:        models.EpicStatusDraft,
+		Priority:      source.Priority,
+		BusinessValue: source.BusinessValue,
+		FilePath:      &relPath,
+	}
+	if err := epicRepo.Create(ctx, epic); err != nil {
+		if writeResult.Written {
+			os.Remove(writeResult.AbsolutePath)
+		}
+		return nil, "", fmt.Errorf("failed to create epic in database: %w", err)
+	}
+	return epic, epicDir, nil
+}
+
+// printEpicClone prints the copied tasks, or the features when none have tasks
+func printEpicClone(output *EpicCloneJSON) {
+	rows := [][]string{}
+	for _, feature := range output.Features {
+		featureKey := feature.Source
+		if feature.Key != "" {
+			featureKey = feature.Source + " → " + feature.Key
+		}
+		if len(feature.Tasks) == 0 {
+			rows = append(rows, []string{featureKey, "-", truncateCell(feature.Title, 45), "-", "-"})
+			continue
+		}
+		for _, task := range feature.Tasks {
+			taskKey := task.Source
+			if task.Key != "" {
+				taskKey = task.Source + " → " + task.Key
+			}
+			dependsOn := "-"
+			if len(task.DependsOn) > 0 {
+				dependsOn = strings.Join(task.DependsOn, ", ")
+			}
+			rows = append(rows, []string{featureKey, taskKey, truncateCell(task.Title, 45), task.AgentType, dependsOn})
+		}
+	}
+	cli.OutputTable([]string{"Feature", "Task", "Title", "Agent", "Depends On"}, rows)
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEpicClonePlan(t *testing.T) {
+	backend := "backend"
+	deps := `["T-E05-F01-001", "T-E02-F01-004"]`
+	features := []*models.Feature{
+		{ID: 1, Key: "E05-F01", Title: "Collect evidence"},
+		{ID: 2, Key: "E05-F02", Title: "Report"},
+	}
+	tasks := map[int64][]*models.Task{
+		1: {
+			{Key: "T-E05-F01-001", Title: "Gather logs", AgentType: &backend, Status: models.TaskStatusCompleted},
+			{Key: "T-E05-F01-002", Title: "Review access", DependsOn: &deps},
+		},
+	}
+
+	plan := buildEpicClonePlan(features, tasks)
+	require.Len(t, plan, 2)
+	assert.Equal(t, "E05-F01", plan[0].Source)
+	require.Len(t, plan[0].Tasks, 2)
+	assert.Equal(t, "backend", plan[0].Tasks[0].AgentType)
+	assert.Empty(t, plan[0].Tasks[0].DependsOn)
+	assert.Equal(t, []string{"T-E05-F01-001", "T-E02-F01-004"}, plan[0].Tasks[1].DependsOn)
+	assert.Empty(t, plan[1].Tasks, "features without tasks are still copied")
+}
+
+func TestRemapCloneDependencies(t *testing.T) {
+	newKeys := map[string]string{
+		"T-E05-F01-001": "T-E09-F01-001",
+		"T-E05-F02-003": "T-E09-F02-001",
+	}
+
+	remapped := remapCloneDependencies([]string{"T-E05-F02-003", "T-E02-F01-004", "T-E05-F01-001", "T-E05-F02-003"}, newKeys)
+	assert.Equal(t, []string{"T-E09-F02-001", "T-E02-F01-004", "T-E09-F01-001"}, remapped, "tasks outside the epic keep their key")
+	assert.Empty(t, remapCloneDependencies(nil, newKeys))
+}
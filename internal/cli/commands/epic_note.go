@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// epicNoteCmd is the parent command for epic note operations
+var epicNoteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Manage epic planning notes",
+	Long: `Add, view, and remove lightweight planning annotations on epics.
+
+Notes are separate from the epic description and are shown chronologically
+in 'shark epic get', so planning context isn't lost in chat history.`,
+}
+
+// epicNoteAddCmd adds a note to an epic
+var epicNoteAddCmd = &cobra.Command{
+	Use:   "add <epic-key> <content>",
+	Short: "Add a planning note to an epic",
+	Long: `Add a planning note to an epic.
+
+Examples:
+  shark epic note add E05 "Descoped reporting to v2"
+  shark epic note add E05 "Agreed to ship behind a flag" --created-by alice
+  shark epic note add E05 "Pushed target to Q3" --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runEpicNoteAdd,
+}
+
+// epicNoteListCmd lists notes for an epic
+var epicNoteListCmd = &cobra.Command{
+	Use:         "list <epic-key>",
+	Short:       "List planning notes for an epic",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List all planning notes for an epic in chronological order.
+
+Examples:
+  shark epic note list E05
+  shark epic note list E05 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEpicNoteList,
+}
+
+// epicNoteDeleteCmd deletes a note by ID
+var epicNoteDeleteCmd = &cobra.Command{
+	Use:   "delete <note-id>",
+	Short: "Delete a planning note",
+	Long: `Delete a planning note by its ID (shown in 'shark epic note list').
+
+Examples:
+  shark epic note delete 12`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEpicNoteDelete,
+}
+
+// runEpicNoteAdd handles the epic note add command
+func runEpicNoteAdd(cmd *cobra.Command, args []string) error {
+	epicKey := args[0]
+	content := args[1]
+	createdBy, _ := cmd.Flags().GetString("created-by")
+
+	// Get database connection
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+	epicRepo := repository.NewEpicRepository(repoDb)
+	noteRepo := repository.NewEpicNoteRepository(repoDb)
+
+	epic, err := epicRepo.GetByKey(ctx, epicKey)
+	if err != nil {
+		return fmt.Errorf("epic %s not found", epicKey)
+	}
+
+	var createdByPtr *string
+	if createdBy != "" {
+		createdByPtr = &createdBy
+	}
+
+	note := &models.EpicNote{
+		EpicID:    epic.ID,
+		Content:   content,
+		CreatedBy: createdByPtr,
+	}
+
+	if err := noteRepo.Create(ctx, note); err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	// Retrieve the note to get the timestamp
+	note, err = noteRepo.GetByID(ctx, note.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created note: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(note)
+	}
+
+	fmt.Printf("Note added to %s\n\n", epic.Key)
+	printEpicNote(note)
+
+	return nil
+}
+
+// runEpicNoteList handles the epic note list command
+func runEpicNoteList(cmd *cobra.Command, args []string) error {
+	epicKey := args[0]
+
+	// Get database connection
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+	epicRepo := repository.NewEpicRepository(repoDb)
+	noteRepo := repository.NewEpicNoteRepository(repoDb)
+
+	epic, err := epicRepo.GetByKey(ctx, epicKey)
+	if err != nil {
+		return fmt.Errorf("epic %s not found", epicKey)
+	}
+
+	notes, err := noteRepo.ListByEpicID(ctx, epic.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(notes)
+	}
+
+	if len(notes) == 0 {
+		fmt.Printf("No notes found for epic %s\n", epic.Key)
+		return nil
+	}
+
+	fmt.Printf("Epic %s: %s (%d notes)\n\n", epic.Key, epic.Title, len(notes))
+	for _, note := range notes {
+		printEpicNote(note)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// runEpicNoteDelete handles the epic note delete command
+func runEpicNoteDelete(cmd *cobra.Command, args []string) error {
+	noteID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid note id %q: must be a number", args[0])
+	}
+
+	// Get database connection
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	noteRepo := repository.NewEpicNoteRepository(repoDb)
+	if err := noteRepo.Delete(context.Background(), noteID); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"id":      noteID,
+			"deleted": true,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Note %d deleted", noteID))
+	return nil
+}
+
+// printEpicNote prints a single epic note in human-readable form
+func printEpicNote(note *models.EpicNote) {
+	creator := "unknown"
+	if note.CreatedBy != nil {
+		creator = *note.CreatedBy
+	}
+	fmt.Printf("#%d %s (%s)\n", note.ID, note.CreatedAt.Format("2006-01-02 15:04"), creator)
+	fmt.Println(note.Content)
+}
+
+func init() {
+	epicCmd.AddCommand(epicNoteCmd)
+
+	epicNoteCmd.AddCommand(epicNoteAddCmd)
+	epicNoteCmd.AddCommand(epicNoteListCmd)
+	epicNoteCmd.AddCommand(epicNoteDeleteCmd)
+
+	epicNoteAddCmd.Flags().StringP("created-by", "c", "", "Creator name (optional)")
+}
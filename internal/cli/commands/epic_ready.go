@@ -0,0 +1,400 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// Feature states reported by epic ready
+const (
+	readyFeatureReady   = "ready"   // Has tasks that can start now
+	readyFeatureActive  = "active"  // Work in progress, nothing else can start
+	readyFeatureBlocked = "blocked" // Open tasks, none of them startable or in progress
+	readyFeatureDone    = "done"    // Every task completed or archived
+	readyFeatureEmpty   = "empty"   // No tasks yet
+)
+
+// epicReadyCmd analyzes which work in an epic can start now
+var epicReadyCmd = &cobra.Command{
+	Use:         "ready <epic-key>",
+	Short:       "Show which features and tasks can start now",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Analyze an epic for work that can start immediately: todo tasks whose
+dependencies are all completed or archived, the same rule 'shark task next'
+uses. Every other open todo or blocked task is listed with what blocks it.
+
+Parallelism estimates how many agents the epic can keep busy:
+  ready now      tasks that can start immediately
+  max parallel   the most open tasks that could run at once as dependencies clear
+  critical path  the longest chain of open tasks that must run one after another
+  factor         open tasks divided by the critical path: the average number
+                 of agents that stay busy until the epic is done
+
+Examples:
+  shark epic ready E05
+  shark epic ready E05 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEpicReady,
+}
+
+func init() {
+	epicCmd.AddCommand(epicReadyCmd)
+}
+
+// EpicReadyJSON is the output of shark epic ready
+type EpicReadyJSON struct {
+	Epic        string               `json:"epic"`
+	Title       string               `json:"title"`
+	Ready       []*EpicReadyTask     `json:"ready"`
+	Blocked     []*EpicReadyTask     `json:"blocked"`
+	Features    []*EpicReadyFeature  `json:"features"`
+	Parallelism EpicReadyParallelism `json:"parallelism"`
+}
+
+// EpicReadyTask is a task that can start now, or a blocked task with its blockers
+type EpicReadyTask struct {
+	Key       string             `json:"key"`
+	Title     string             `json:"title"`
+	Feature   string             `json:"feature"`
+	Status    models.TaskStatus  `json:"status"`
+	Priority  int                `json:"priority"`
+	AgentType *string            `json:"agent_type"`
+	BlockedBy []EpicReadyBlocker `json:"blocked_by,omitempty"`
+	Reason    *string            `json:"blocked_reason,omitempty"`
+}
+
+// EpicReadyBlocker is an incomplete dependency of a blocked task
+type EpicReadyBlocker struct {
+	Key    string `json:"key"`
+	Status string `json:"status"` // Task status, or "not found"
+}
+
+// EpicReadyFeature counts a feature's tasks by readiness
+type EpicReadyFeature struct {
+	Key        string `json:"key"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	Ready      int    `json:"ready"`
+	Blocked    int    `json:"blocked"`
+	InProgress int    `json:"in_progress"`
+	Done       int    `json:"done"`
+}
+
+// EpicReadyParallelism estimates how many agents can work on the epic at once
+type EpicReadyParallelism struct {
+	ReadyNow     int     `json:"ready_now"`
+	InProgress   int     `json:"in_progress"`
+	OpenTasks    int     `json:"open_tasks"`
+	MaxParallel  int     `json:"max_parallel"`
+	CriticalPath int     `json:"critical_path"`
+	Factor       float64 `json:"factor"`
+}
+
+// runEpicReady executes the epic ready command
+func runEpicReady(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	epicKey := NormalizeKey(args[0])
+	if !IsEpicKey(epicKey) {
+		return InvalidEpicKeyError(args[0])
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	epic, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, epicKey)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("Epic %s does not exist", epicKey)).
+			WithHint("Use 'shark epic list' to see available epics")
+	}
+
+	features, err := repository.NewFeatureRepository(repoDb).ListByEpic(ctx, epic.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list features: %w", err)
+	}
+	taskRepo := repository.NewTaskRepository(repoDb)
+	tasks, err := taskRepo.ListByEpic(ctx, epic.Key)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	deps, err := loadTaskDependencies(ctx, repoDb, taskRepo, tasks)
+	if err != nil {
+		return err
+	}
+
+	report := analyzeEpicReadiness(epic, features, tasks, deps)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(report)
+	}
+	printEpicReadiness(report)
+	return nil
+}
+
+// loadTaskDependencies returns the dependencies of each open task, by task key,
+// from both the depends_on column and depends_on relationships
+func loadTaskDependencies(ctx context.Context, repoDb *repository.DB, taskRepo *repository.TaskRepository, tasks []*models.Task) (map[string][]EpicReadyBlocker, error) {
+	relRepo := repository.NewTaskRelationshipRepository(repoDb)
+
+	keys := map[string][]string{}
+	var lookup []string
+	for _, task := range tasks {
+		if isTaskDone(task.Status) {
+			continue
+		}
+		if task.DependsOn != nil && *task.DependsOn != "" {
+			var depKeys []string
+			if err := json.Unmarshal([]byte(*task.DependsOn), &depKeys); err == nil {
+				keys[task.Key] = append(keys[task.Key], depKeys...)
+				lookup = append(lookup, depKeys...)
+			}
+		}
+		rels, err := relRepo.GetOutgoing(ctx, task.ID, []string{string(models.RelationshipDependsOn)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependencies of %s: %w", task.Key, err)
+		}
+		for _, rel := range rels {
+			dep, err := taskRepo.GetByID(ctx, rel.ToTaskID)
+			if err != nil {
+				continue
+			}
+			keys[task.Key] = append(keys[task.Key], dep.Key)
+			lookup = append(lookup, dep.Key)
+		}
+	}
+
+	found, err := taskRepo.GetByKeys(ctx, lookup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	deps := make(map[string][]EpicReadyBlocker, len(keys))
+	for key, depKeys := range keys {
+		seen := map[string]bool{}
+		for _, depKey := range depKeys {
+			if seen[depKey] {
+				continue
+			}
+			seen[depKey] = true
+			blocker := EpicReadyBlocker{Key: depKey, Status: "not found"}
+			if dep, ok := found[depKey]; ok {
+				blocker.Status = string(dep.Status)
+			}
+			deps[key] = append(deps[key], blocker)
+		}
+	}
+	return deps, nil
+}
+
+// analyzeEpicReadiness classifies an epic's open tasks and estimates its
+// parallelism. deps holds each task's dependencies by task key.
+func analyzeEpicReadiness(epic *models.Epic, features []*models.Feature, tasks []*models.Task, deps map[string][]EpicReadyBlocker) *EpicReadyJSON {
+	report := &EpicReadyJSON{
+		Epic:     epic.Key,
+		Title:    epic.Title,
+		Ready:    []*EpicReadyTask{},
+		Blocked:  []*EpicReadyTask{},
+		Features: []*EpicReadyFeature{},
+	}
+
+	featureKeys := map[int64]string{}
+	byFeature := map[int64]*EpicReadyFeature{}
+	for _, feature := range features {
+		featureKeys[feature.ID] = feature.Key
+		summary := &EpicReadyFeature{Key: feature.Key, Title: feature.Title}
+		byFeature[feature.ID] = summary
+		report.Features = append(report.Features, summary)
+	}
+
+	open := map[string]*models.Task{}
+	for _, task := range tasks {
+		summary := byFeature[task.FeatureID]
+		if summary == nil {
+			continue
+		}
+		if isTaskDone(task.Status) {
+			summary.Done++
+			continue
+		}
+		open[task.Key] = task
+
+		if task.Status != models.TaskStatusTodo && task.Status != models.TaskStatusBlocked {
+			summary.InProgress++
+			continue
+		}
+
+		var blockers []EpicReadyBlocker
+		for _, dep := range deps[task.Key] {
+			if !isTaskDone(models.TaskStatus(dep.Status)) {
+				blockers = append(blockers, dep)
+			}
+		}
+		entry := &EpicReadyTask{
+			Key:       task.Key,
+			Title:     task.Title,
+			Feature:   featureKeys[task.FeatureID],
+			Status:    task.Status,
+			Priority:  task.Priority,
+			AgentType: task.AgentType,
+			BlockedBy: blockers,
+		}
+		if task.Status == models.TaskStatusTodo && len(blockers) == 0 {
+			summary.Ready++
+			report.Ready = append(report.Ready, entry)
+			continue
+		}
+		if task.Status == models.TaskStatusBlocked {
+			entry.Reason = task.BlockedReason
+		}
+		summary.Blocked++
+		report.Blocked = append(report.Blocked, entry)
+	}
+
+	for _, summary := range report.Features {
+		switch {
+		case summary.Ready > 0:
+			summary.State = readyFeatureReady
+		case summary.InProgress > 0:
+			summary.State = readyFeatureActive
+		case summary.Blocked > 0:
+			summary.State = readyFeatureBlocked
+		case summary.Done > 0:
+			summary.State = readyFeatureDone
+		default:
+			summary.State = readyFeatureEmpty
+		}
+	}
+
+	report.Parallelism = estimateParallelism(open, deps)
+	report.Parallelism.ReadyNow = len(report.Ready)
+	for _, summary := range report.Features {
+		report.Parallelism.InProgress += summary.InProgress
+	}
+	return report
+}
+
+// estimateParallelism layers the open tasks by dependency depth: a task with
+// no open dependencies in the epic is at depth 1, and every other task is one
+// deeper than its deepest open dependency. The widest layer bounds how many
+// tasks can run at once and the number of layers is the critical path.
+func estimateParallelism(open map[string]*models.Task, deps map[string][]EpicReadyBlocker) EpicReadyParallelism {
+	depth := map[string]int{}
+	var depthOf func(key string, visiting map[string]bool) int
+	depthOf = func(key string, visiting map[string]bool) int {
+		if d, ok := depth[key]; ok {
+			return d
+		}
+		visiting[key] = true
+		d := 1
+		for _, dep := range deps[key] {
+			// Dependencies outside the epic or in a cycle add no depth
+			if _, ok := open[dep.Key]; !ok || visiting[dep.Key] {
+				continue
+			}
+			if depDepth := depthOf(dep.Key, visiting) + 1; depDepth > d {
+				d = depDepth
+			}
+		}
+		delete(visiting, key)
+		depth[key] = d
+		return d
+	}
+
+	widths := map[int]int{}
+	result := EpicReadyParallelism{OpenTasks: len(open)}
+	for key := range open {
+		d := depthOf(key, map[string]bool{})
+		widths[d]++
+		if d > result.CriticalPath {
+			result.CriticalPath = d
+		}
+		if widths[d] > result.MaxParallel {
+			result.MaxParallel = widths[d]
+		}
+	}
+	if result.CriticalPath > 0 {
+		result.Factor = math.Round(float64(result.OpenTasks)/float64(result.CriticalPath)*10) / 10
+	}
+	return result
+}
+
+// isTaskDone reports whether a task no longer blocks its dependents
+func isTaskDone(status models.TaskStatus) bool {
+	return status == models.TaskStatusCompleted || status == models.TaskStatusArchived
+}
+
+// printEpicReadiness prints the readiness report as tables
+func printEpicReadiness(report *EpicReadyJSON) {
+	fmt.Printf("Epic %s: %s\n\n", report.Epic, report.Title)
+
+	fmt.Printf("Ready to start (%d):\n", len(report.Ready))
+	if len(report.Ready) > 0 {
+		rows := make([][]string, 0, len(report.Ready))
+		for _, task := range report.Ready {
+			agent := ""
+			if task.AgentType != nil {
+				agent = *task.AgentType
+			}
+			rows = append(rows, []string{task.Key, task.Feature, strconv.Itoa(task.Priority), agent, task.Title})
+		}
+		cli.OutputTable([]string{"Task", "Feature", "Priority", "Agent", "Title"}, rows)
+	} else {
+		fmt.Println()
+	}
+
+	if len(report.Blocked) > 0 {
+		fmt.Printf("Blocked (%d):\n", len(report.Blocked))
+		rows := make([][]string, 0, len(report.Blocked))
+		for _, task := range report.Blocked {
+			rows = append(rows, []string{task.Key, task.Feature, formatReadyBlockers(task)})
+		}
+		cli.OutputTable([]string{"Task", "Feature", "Blocked By"}, rows)
+	}
+
+	if len(report.Features) > 0 {
+		fmt.Println("Features:")
+		rows := make([][]string, 0, len(report.Features))
+		for _, feature := range report.Features {
+			rows = append(rows, []string{
+				feature.Key, feature.State,
+				strconv.Itoa(feature.Ready), strconv.Itoa(feature.Blocked),
+				strconv.Itoa(feature.InProgress), strconv.Itoa(feature.Done),
+			})
+		}
+		cli.OutputTable([]string{"Feature", "State", "Ready", "Blocked", "In Progress", "Done"}, rows)
+	}
+
+	p := report.Parallelism
+	fmt.Println("Parallelism:")
+	fmt.Printf("  Ready now:     %d (%d in progress)\n", p.ReadyNow, p.InProgress)
+	fmt.Printf("  Max parallel:  %d of %d open tasks\n", p.MaxParallel, p.OpenTasks)
+	fmt.Printf("  Critical path: %d\n", p.CriticalPath)
+	fmt.Printf("  Factor:        %.1f\n", p.Factor)
+}
+
+// formatReadyBlockers describes what blocks a task
+func formatReadyBlockers(task *EpicReadyTask) string {
+	parts := make([]string, 0, len(task.BlockedBy)+1)
+	for _, blocker := range task.BlockedBy {
+		parts = append(parts, fmt.Sprintf("%s (%s)", blocker.Key, blocker.Status))
+	}
+	if task.Reason != nil && *task.Reason != "" {
+		parts = append(parts, *task.Reason)
+	} else if len(parts) == 0 {
+		parts = append(parts, "status "+string(task.Status))
+	}
+	return strings.Join(parts, ", ")
+}
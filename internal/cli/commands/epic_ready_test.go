@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeEpicReadiness(t *testing.T) {
+	epic := &models.Epic{Key: "E05", Title: "Payments"}
+	features := []*models.Feature{
+		{ID: 1, Key: "E05-F01", Title: "Checkout"},
+		{ID: 2, Key: "E05-F02", Title: "Refunds"},
+		{ID: 3, Key: "E05-F03", Title: "Invoices"},
+	}
+	reason := "Waiting on the payments API"
+	tasks := []*models.Task{
+		{FeatureID: 1, Key: "T-E05-F01-001", Status: models.TaskStatusCompleted},
+		{FeatureID: 1, Key: "T-E05-F01-002", Status: models.TaskStatusTodo, Priority: 2},
+		{FeatureID: 1, Key: "T-E05-F01-003", Status: models.TaskStatusTodo},
+		{FeatureID: 1, Key: "T-E05-F01-004", Status: models.TaskStatusInProgress},
+		{FeatureID: 2, Key: "T-E05-F02-001", Status: models.TaskStatusBlocked, BlockedReason: &reason},
+	}
+	deps := map[string][]EpicReadyBlocker{
+		"T-E05-F01-002": {{Key: "T-E05-F01-001", Status: "completed"}},
+		"T-E05-F01-003": {{Key: "T-E05-F01-002", Status: "todo"}, {Key: "T-E04-F01-009", Status: "not found"}},
+	}
+
+	report := analyzeEpicReadiness(epic, features, tasks, deps)
+
+	require.Len(t, report.Ready, 1)
+	assert.Equal(t, "T-E05-F01-002", report.Ready[0].Key)
+	assert.Equal(t, "E05-F01", report.Ready[0].Feature)
+	assert.Empty(t, report.Ready[0].BlockedBy, "completed dependencies don't block")
+
+	require.Len(t, report.Blocked, 2)
+	assert.Equal(t, "T-E05-F01-003", report.Blocked[0].Key)
+	assert.Len(t, report.Blocked[0].BlockedBy, 2)
+	assert.Equal(t, "T-E05-F02-001", report.Blocked[1].Key)
+	assert.Equal(t, &reason, report.Blocked[1].Reason)
+	assert.Equal(t, "T-E05-F01-002 (todo), T-E04-F01-009 (not found)", formatReadyBlockers(report.Blocked[0]))
+
+	states := map[string]string{}
+	for _, feature := range report.Features {
+		states[feature.Key] = feature.State
+	}
+	assert.Equal(t, map[string]string{"E05-F01": "ready", "E05-F02": "blocked", "E05-F03": "empty"}, states)
+	assert.Equal(t, 1, report.Features[0].Done)
+	assert.Equal(t, 1, report.Features[0].InProgress)
+
+	// Open tasks 002, 004, and F02-001 can run together; 003 must wait for 002
+	assert.Equal(t, EpicReadyParallelism{
+		ReadyNow:     1,
+		InProgress:   1,
+		OpenTasks:    4,
+		MaxParallel:  3,
+		CriticalPath: 2,
+		Factor:       2,
+	}, report.Parallelism)
+}
+
+func TestEstimateParallelism_Cycle(t *testing.T) {
+	open := map[string]*models.Task{"A": {Key: "A"}, "B": {Key: "B"}}
+	deps := map[string][]EpicReadyBlocker{
+		"A": {{Key: "B", Status: "todo"}},
+		"B": {{Key: "A", Status: "todo"}},
+	}
+
+	result := estimateParallelism(open, deps)
+	assert.Equal(t, 2, result.OpenTasks)
+	assert.Equal(t, 2, result.CriticalPath, "a cycle doesn't recurse forever")
+}
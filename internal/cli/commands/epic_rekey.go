@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// planEpicRekey plans an epic key change along with the features and tasks
+// keyed under it. The epic's directory is renamed if it is named for the epic.
+func planEpicRekey(ctx context.Context, repoDb *repository.DB, projectRoot string, epic *models.Epic, newKey string) (*repository.RenumberPlan, error) {
+	epicRepo := repository.NewEpicRepository(repoDb)
+	resolver := pathresolver.NewPathResolver(epicRepo, repository.NewFeatureRepository(repoDb), nil, projectRoot)
+	epicDir, err := entityDir(projectRoot, epic.FilePath, func() (string, error) {
+		return resolver.ResolveEpicPath(ctx, epic.Key)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve epic directory: %w", err)
+	}
+	plan, err := repository.NewRenumberRepository(repoDb).PlanEpicRekey(ctx, epic, newKey, filepath.ToSlash(epicDir))
+	if err != nil {
+		return nil, err
+	}
+	plan.AddAbsoluteMoves(projectRoot)
+	return plan, nil
+}
+
+// applyEpicRekey renames the plan's files, applies it to the database in one
+// transaction, and rewrites frontmatter keys in the renamed files. Files are
+// put back if the database changes fail. Returns the files whose frontmatter
+// could not be updated.
+func applyEpicRekey(ctx context.Context, repoDb *repository.DB, projectRoot string, plan *repository.RenumberPlan) ([]string, error) {
+	undo, err := moveEntityFiles(projectRoot, plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename files (nothing changed): %w", err)
+	}
+
+	if err := repository.NewRenumberRepository(repoDb).Apply(ctx, plan); err != nil {
+		undo()
+		return nil, fmt.Errorf("failed to change the key of %s (files restored): %w", plan.Changes[0].OldKey, err)
+	}
+
+	var frontmatterErrors []string
+	for _, change := range plan.Changes {
+		if change.NewFilePath == nil || !strings.HasSuffix(*change.NewFilePath, ".md") {
+			continue
+		}
+		if err := rewriteFrontmatterKeys(resolveProjectPath(projectRoot, *change.NewFilePath), plan.RewriteName); err != nil {
+			frontmatterErrors = append(frontmatterErrors, fmt.Sprintf("%s: %v", *change.NewFilePath, err))
+		}
+	}
+
+	// The epic's own change is audited with the rest of the update
+	for _, change := range plan.Changes[1:] {
+		recordAudit(ctx, repoDb, &models.AuditEntry{
+			EntityType: change.EntityType,
+			EntityKey:  change.NewKey,
+			Action:     models.AuditActionUpdate,
+			Summary:    fmt.Sprintf("Rekeyed from %s with epic %s", change.OldKey, plan.Changes[0].NewKey),
+			Changes:    map[string]models.AuditChange{"key": {Old: change.OldKey, New: change.NewKey}},
+		})
+	}
+
+	return frontmatterErrors, nil
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/spf13/cobra"
+)
+
+// epicStatusCmd shows status of all epics
+var epicStatusCmd = &cobra.Command{
+	Use:         "status [epic-key]",
+	Short:       "Show epic status summary",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Display a status summary of all epics (or one epic): health, progress,
+feature counts, a task status breakdown, and the blocked tasks of each epic.
+
+Health uses the same rules as 'shark status'. By default:
+  healthy   ≥75% of tasks completed and no blocked tasks
+  warning   25-74% completed, or 1-3 blocked tasks
+  critical  <25% completed, or more than 3 blocked tasks
+
+Teams can tune the rules under health: in .shark.yaml.
+
+Examples:
+  shark epic status                  Show all epics
+  shark epic status E05              Show a single epic
+  shark epic status --label=security Only count tasks labeled 'security'
+  shark epic status --json           Output as JSON`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEpicStatus,
+}
+
+func init() {
+	epicCmd.AddCommand(epicStatusCmd)
+
+	addLabelFilterFlag(epicStatusCmd)
+	addWeightedFlag(epicStatusCmd)
+}
+
+// runEpicStatus executes the epic status command
+func runEpicStatus(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(5 * time.Second)
+	defer cancel()
+
+	var epicKey string
+	if len(args) == 1 {
+		epicKey = NormalizeKey(args[0])
+		if !IsEpicKey(epicKey) {
+			return InvalidEpicKeyError(args[0])
+		}
+	}
+
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	health, err := projectHealthRules(ctx, repoDb)
+	if err != nil {
+		return err
+	}
+
+	service := status.NewStatusService(repoDb)
+	report, err := service.GetEpicStatus(ctx, &status.StatusRequest{
+		EpicKey:  epicKey,
+		Labels:   labels,
+		Weighted: useWeightedProgress(cmd),
+		Health:   health,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get epic status: %w", err)
+	}
+
+	if epicKey != "" && len(report.Epics) == 0 {
+		return fmt.Errorf("epic %s not found", epicKey)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(report)
+	}
+
+	fmt.Print(status.FormatEpicStatus(report, cli.GlobalConfig.NoColor))
+	return nil
+}
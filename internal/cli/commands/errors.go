@@ -54,6 +54,11 @@ Valid syntax:
     E07-F01-001                     # Without 'T-' prefix
     e07-f01-001                     # Case insensitive
 
+  Shorthand (must match a single task):
+    003, F01-003                    # Task number, in the focused epic first
+    build-login                     # Task slug, or the start of one
+    login                           # Alias from 'shark alias add'
+
 Note: Epic and feature must be two-digit (E01-E99, F01-F99).
       Task number must be three-digit (001-999).`, key)
 }
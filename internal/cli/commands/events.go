@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/events"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// eventsCmd is the parent command for the changes feed
+var eventsCmd = &cobra.Command{
+	Use:         "events",
+	Short:       "Read the feed of changes to epics, features, and tasks",
+	GroupID:     "status",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Every change to an epic, feature, or task is recorded as an event with a
+sequence number that only increases, whichever command, server, or tool made
+it. Read the events with 'shark events tail', or stream them over HTTP from
+/api/v1/events on the status server.
+
+Examples:
+  shark events tail
+  shark events tail --since=120 --follow --json`,
+}
+
+// eventsTailCmd prints recent events
+var eventsTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Print recent changes, optionally following new ones",
+	Long: `Print the last --limit events, or every event after --since. With --follow,
+keep printing new events as they happen until interrupted.
+
+With --json each event is printed as one JSON object per line. Pass the seq
+of the last event seen as --since to resume without missing or repeating any.
+
+Examples:
+  shark events tail                          Last 20 changes
+  shark events tail --follow                 Then keep printing new ones
+  shark events tail --since=120 --json       Every change after event 120
+  shark events tail --type=task --key=E05    Only E05's tasks`,
+	Args: cobra.NoArgs,
+	RunE: runEventsTail,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(eventsCmd)
+	eventsCmd.AddCommand(eventsTailCmd)
+
+	eventsTailCmd.Flags().Int64("since", -1, "Print every event after this seq (default: the last --limit events)")
+	eventsTailCmd.Flags().Int("limit", 20, "Number of recent events to print without --since")
+	eventsTailCmd.Flags().BoolP("follow", "f", false, "Keep printing new events until interrupted")
+	eventsTailCmd.Flags().Duration("interval", events.DefaultPollInterval, "How often --follow checks for new events")
+	eventsTailCmd.Flags().String("type", "", "Only events of this type: epic, feature, or task")
+	eventsTailCmd.Flags().String("key", "", "Only events of this epic, feature, or task, including an epic's features and tasks and a feature's tasks")
+}
+
+// runEventsTail handles the events tail command
+func runEventsTail(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetInt64("since")
+	limit, _ := cmd.Flags().GetInt("limit")
+	follow, _ := cmd.Flags().GetBool("follow")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	entityType, _ := cmd.Flags().GetString("type")
+	keyFlag, _ := cmd.Flags().GetString("key")
+
+	if err := events.ValidateEntityType(entityType); err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+	if limit <= 0 {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "--limit must be positive")
+	}
+	if follow && interval <= 0 {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "--interval must be positive")
+	}
+	filter := repository.EventFilter{EntityType: entityType}
+	if keyFlag != "" {
+		filter.Key = NormalizeKey(keyFlag)
+		if !IsEpicKey(filter.Key) && !IsFeatureKey(filter.Key) {
+			key, err := ResolveTaskKey(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			filter.Key = key
+		}
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+	eventRepo := repository.NewEventRepository(repoDb)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var batch []*models.Event
+	if since < 0 {
+		if batch, err = eventRepo.ListLatest(ctx, limit, filter); err != nil {
+			return err
+		}
+		if since, err = eventRepo.LatestSeq(ctx); err != nil {
+			return err
+		}
+		printEvents(batch)
+	}
+
+	for {
+		for {
+			if batch, err = eventRepo.ListSince(ctx, since, 500, filter); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			printEvents(batch)
+			if len(batch) > 0 {
+				since = batch[len(batch)-1].Seq
+			}
+			if len(batch) < 500 {
+				break
+			}
+		}
+		if !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// printEvents prints events, one per line
+func printEvents(batch []*models.Event) {
+	for _, event := range batch {
+		if cli.GlobalConfig.JSON {
+			data, err := json.Marshal(event)
+			if err == nil {
+				fmt.Println(string(data))
+			}
+			continue
+		}
+
+		detail := ""
+		switch {
+		case event.PreviousStatus != nil && event.Status != nil:
+			detail = fmt.Sprintf(" %s → %s", *event.PreviousStatus, *event.Status)
+		case event.Status != nil && event.Action == models.EventActionCreated:
+			detail = " " + *event.Status
+		}
+		fmt.Printf("%-6d %s  %-7s %-16s %s%s\n", event.Seq, event.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			event.EntityType, event.EntityKey, event.Action, detail)
+	}
+}
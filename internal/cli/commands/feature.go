@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,11 +13,11 @@ import (
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/config"
-	"github.com/jwwelbor/shark-task-manager/internal/db"
 	"github.com/jwwelbor/shark-task-manager/internal/fileops"
 	"github.com/jwwelbor/shark-task-manager/internal/formatters"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/progress"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
 	"github.com/jwwelbor/shark-task-manager/internal/status"
 	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
@@ -60,15 +61,63 @@ type FeatureWithTaskCount struct {
 
 // FeatureListItemJSON is the enhanced JSON structure for feature list with health and progress info
 type FeatureListItemJSON struct {
-	Key            string      `json:"key"`
-	Title          string      `json:"title"`
-	EpicID         int64       `json:"epic_id"`
-	Status         string      `json:"status"`
-	StatusOverride bool        `json:"status_override"`
-	Health         string      `json:"health"`
-	Progress       interface{} `json:"progress"`
-	Notes          string      `json:"notes"`
-	TaskCount      int         `json:"task_count"`
+	Key            string                   `json:"key"`
+	Title          string                   `json:"title"`
+	EpicID         int64                    `json:"epic_id"`
+	Status         string                   `json:"status"`
+	StatusOverride bool                     `json:"status_override"`
+	Health         string                   `json:"health"`
+	Progress       *FeatureListProgressJSON `json:"progress"`
+	Notes          string                   `json:"notes"`
+	TaskCount      int                      `json:"task_count"`
+	Labels         []string                 `json:"labels,omitempty"`
+}
+
+// FeatureListProgressJSON is a feature's progress in feature list. Without a
+// workflow config only pct is set.
+type FeatureListProgressJSON struct {
+	WeightedPct     *float64 `json:"weighted_pct,omitempty"`
+	CompletionPct   *float64 `json:"completion_pct,omitempty"`
+	WeightedRatio   string   `json:"weighted_ratio,omitempty"`
+	CompletionRatio string   `json:"completion_ratio,omitempty"`
+	TotalTasks      *int     `json:"total_tasks,omitempty"`
+	Pct             *float64 `json:"pct,omitempty"`
+	EstimatePct     *float64 `json:"estimate_pct,omitempty"`
+	EstimateRatio   string   `json:"estimate_ratio,omitempty"`
+}
+
+// FeatureListJSON is the JSON output of feature list
+type FeatureListJSON struct {
+	Results []FeatureListItemJSON `json:"results"`
+	Count   int                   `json:"count"`
+}
+
+// FeatureGetJSON is the JSON output of feature get
+type FeatureGetJSON struct {
+	ID               int64                  `json:"id"`
+	EpicID           int64                  `json:"epic_id"`
+	EpicKey          string                 `json:"epic_key"`
+	Key              string                 `json:"key"`
+	Title            string                 `json:"title"`
+	Description      *string                `json:"description"`
+	Status           models.FeatureStatus   `json:"status"`
+	StatusSource     string                 `json:"status_source"`
+	StatusOverride   bool                   `json:"status_override"`
+	Slug             *string                `json:"slug"`
+	ExecutionOrder   *int                   `json:"execution_order"`
+	ProgressPct      float64                `json:"progress_pct"`
+	Path             string                 `json:"path"`
+	Filename         string                 `json:"filename"`
+	FilePath         *string                `json:"file_path"`
+	Labels           []string               `json:"labels"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+	Tasks            []*models.Task         `json:"tasks"`
+	StatusBreakdown  []workflow.StatusCount `json:"status_breakdown"`
+	RelatedDocuments []*models.Document     `json:"related_documents"`
+	Progress         *status.ProgressInfo   `json:"progress"`
+	WorkSummary      *status.WorkSummary    `json:"work_summary"`
+	ActionItems      *status.ActionItems    `json:"action_items"`
 }
 
 // featureCmd represents the feature command group
@@ -86,8 +135,9 @@ Examples:
 
 // featureListCmd lists features
 var featureListCmd = &cobra.Command{
-	Use:   "list [EPIC]",
-	Short: "List features",
+	Use:         "list [EPIC]",
+	Short:       "List features",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List features with optional filtering by epic.
 
 By default, completed features are hidden. Use --show-all to include them.
@@ -103,14 +153,16 @@ Examples:
   shark feature list --json       Output as JSON
   shark feature list --status=active  Filter by status
   shark feature list --status=completed  List only completed features
-  shark feature list --sort-by=progress  Sort by progress`,
+  shark feature list --sort-by=progress  Sort by progress
+  shark feature list --epic=E05 --status=active --sort-by=progress`,
 	RunE: runFeatureList,
 }
 
 // featureGetCmd gets a specific feature
 var featureGetCmd = &cobra.Command{
-	Use:   "get <feature-key>",
-	Short: "Get feature details",
+	Use:         "get <feature-key>",
+	Short:       "Get feature details",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display detailed information about a specific feature including all tasks and progress.
 
 Supports multiple key formats:
@@ -137,6 +189,15 @@ var featureCreateCmd = &cobra.Command{
 
 The feature key is automatically assigned as the next available F## number within the epic.
 By default, the feature file is created at docs/plan/{epic-key}/{feature-key}/feature.md.
+With --from-file, an existing markdown file is linked as the feature file instead, unchanged;
+the title comes from its first heading and the description from its first paragraph unless
+given, and a file already claimed by another feature or epic needs --force, as with --file.
+With --scaffold (or feature_scaffold.enabled in .sharkconfig.json), the feature folder also
+gets a tasks/ subfolder and starter docs rendered from shark-templates/feature-<name>.md,
+which are recorded as documents linked to the feature.
+With --if-not-exists, an existing feature with the --key, or without --key one in the epic
+with the same title (ignoring case), is returned instead of failing or creating a duplicate,
+so retries are safe. JSON output has "created": false for an existing feature.
 
 Positional Arguments:
   EPIC    Optional epic key (E##) - can also be specified with --epic flag
@@ -151,8 +212,23 @@ Examples:
   shark feature create --epic=E01 "OAuth Login Integration"
   shark feature create --epic=E01 "OAuth Login" --description="Add OAuth 2.0 support"
   shark feature create --epic=E01 --file="docs/specs/auth.md" "OAuth Login"
-  shark feature create --epic=E01 --file="docs/specs/auth.md" --force "OAuth Login"`,
-	Args: cobra.RangeArgs(1, 2),
+  shark feature create --epic=E01 --file="docs/specs/auth.md" --force "OAuth Login"
+
+  # Safe to retry: returns the existing feature if there is one
+  shark feature create E01 "OAuth Login" --if-not-exists --json
+
+  # Link an existing spec as the feature file; the title comes from its first heading
+  shark feature create E01 --from-file="docs/specs/auth.md"
+  shark feature create E01 "OAuth Login" --from-file="docs/specs/auth.md" --force
+
+  # Scaffold the feature folder: feature.md, tasks/, design.md and testing.md
+  shark feature create E01 "OAuth Login" --scaffold
+  shark feature create E01 "OAuth Login" --scaffold-docs=design
+
+  # Create a feature for each entry in the epic document's "## Features" section
+  shark feature create E01 --from-epic-doc --dry-run
+  shark feature create E01 --from-epic-doc`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: runFeatureCreate,
 }
 
@@ -165,33 +241,50 @@ var featureCompleteCmd = &cobra.Command{
 Without --force, shows a warning summary if any tasks are incomplete and fails.
 With --force, completes all tasks regardless of status.
 
+Use --dry-run to preview the effect and get a confirmation token. When
+require_confirmation_tokens is enabled in .sharkconfig.json, the token must be
+passed with --confirm to execute.
+
 Supports multiple key formats (numeric, full, or slugged).
 
 Examples:
   shark feature complete E04-F02                   Complete feature by full key
   shark feature complete F02                       Complete feature by numeric key
   shark feature complete F02-user-auth             Complete feature by slugged key
-  shark feature complete E04-F02 --force           Force complete all tasks`,
+  shark feature complete E04-F02 --force           Force complete all tasks
+  shark feature complete E04-F02 --dry-run         Preview and print a confirmation token
+  shark feature complete E04-F02 --force --confirm=<token>`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFeatureComplete,
 }
 
 // featureDeleteCmd deletes a feature
 var featureDeleteCmd = &cobra.Command{
-	Use:   "delete <feature-key>",
-	Short: "Delete a feature",
-	Long: `Delete a feature from the database (and all its tasks via CASCADE).
+	Use:         "delete <feature-key>",
+	Short:       "Delete a feature",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Move a feature and all its tasks to the trash.
+
+Trashed features and tasks are hidden from lists and status until they are
+brought back with 'shark trash restore'. 'shark trash empty' deletes them for good.
+
+With --permanent the feature is deleted from the database immediately, along
+with all its tasks (via CASCADE). If the feature has tasks, --permanent also
+requires --force to confirm the cascade deletion.
 
-WARNING: This action cannot be undone. All tasks under this feature will also be deleted.
-If the feature has tasks, you must use --force to confirm the cascade deletion.
+Use --dry-run to preview the effect and get a confirmation token. When
+require_confirmation_tokens is enabled in .sharkconfig.json, the token must be
+passed with --confirm to execute.
 
 Supports multiple key formats (numeric, full, or slugged).
 
 Examples:
-  shark feature delete E04-F02                     Delete feature with no tasks
+  shark feature delete E04-F02                     Move feature and its tasks to the trash
   shark feature delete F02                         Delete feature by numeric key
   shark feature delete F02-user-auth               Delete feature by slugged key
-  shark feature delete E04-F02 --force             Force delete feature with tasks`,
+  shark feature delete E04-F02 --permanent --force Permanently delete feature with tasks
+  shark feature delete E04-F02 --permanent --dry-run
+  shark feature delete E04-F02 --permanent --force --confirm=<token>`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFeatureDelete,
 }
@@ -200,17 +293,22 @@ Examples:
 var featureUpdateCmd = &cobra.Command{
 	Use:   "update <feature-key>",
 	Short: "Update a feature",
-	Long: `Update a feature's properties such as title, description, status, execution order, or file path.
+	Long: `Update a feature's properties such as title, description, status, key, execution order, or file path.
 
 Supports multiple key formats (numeric, full, or slugged).
 
+A new file path must be relative to the project root. If it is already claimed by
+another epic, feature, or task the update fails; use --force to reassign it (the
+database is backed up first).
+
 Examples:
   shark feature update E04-F02 --title "New Title"
   shark feature update F02 --description "New description"
   shark feature update F02-user-auth --status active
   shark feature update E04-F02 --execution-order 2
-  shark feature update E04-F02 --filename "docs/specs/feature.md"
-  shark feature update E04-F02 --path "docs/custom"`,
+  shark feature update E04-F02 --key E04-F10
+  shark feature update E04-F02 --file "docs/specs/feature.md"
+  shark feature update E04-F02 --file "docs/specs/shared.md" --force`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFeatureUpdate,
 }
@@ -240,6 +338,9 @@ func init() {
 	featureListCmd.Flags().String("status", "", "Filter by status: draft, active, completed, archived")
 	featureListCmd.Flags().String("sort-by", "", "Sort by: key, progress, status (default: key)")
 	featureListCmd.Flags().Bool("show-all", false, "Show all features including completed (by default, completed features are hidden)")
+	addWeightedFlag(featureListCmd)
+	addWeightedFlag(featureGetCmd)
+	addLabelFilterFlag(featureListCmd)
 
 	// Add flags for create command
 	featureCreateCmd.Flags().StringVar(&featureCreateEpic, "epic", "", "Epic key (e.g., E01) - can also be specified as first positional argument")
@@ -248,6 +349,13 @@ func init() {
 	featureCreateCmd.Flags().StringVar(&featureCreateKey, "key", "", "Custom key for the feature (e.g., auth, F00). If not provided, auto-generates next F## number")
 	featureCreateCmd.Flags().BoolVar(&featureCreateForce, "force", false, "Force reassignment if file already claimed by another feature or epic")
 	featureCreateCmd.Flags().String("status", "draft", "Status: draft, active, completed, archived (default: draft)")
+	addIfNotExistsFlag(featureCreateCmd, "feature", "epic")
+	featureCreateCmd.Flags().Bool("from-epic-doc", false, "Create a feature for each entry in the epic document's \"## Features\" section")
+	featureCreateCmd.Flags().Bool("dry-run", false, "With --from-epic-doc, show the features that would be created without creating them")
+	featureCreateCmd.Flags().String("from-file", "", "Link an existing markdown file as the feature file, taking the title from its first heading")
+	featureCreateCmd.Flags().Bool("scaffold", false, "Also create the tasks/ folder and starter docs (default from feature_scaffold.enabled in config)")
+	featureCreateCmd.Flags().StringSlice("scaffold-docs", nil, "Starter docs to scaffold, from shark-templates/feature-<name>.md (default: design,testing; implies --scaffold)")
+	addLabelEditFlags(featureCreateCmd, false)
 
 	// File path flags: --file is primary, --filename and --path are hidden aliases
 	featureCreateCmd.Flags().String("file", "", "Full file path (e.g., docs/custom/feature.md)")
@@ -260,9 +368,12 @@ func init() {
 
 	// Add flags for complete command
 	featureCompleteCmd.Flags().Bool("force", false, "Force completion of all tasks regardless of status")
+	addConfirmationFlags(featureCompleteCmd)
 
 	// Add flags for delete command
-	featureDeleteCmd.Flags().Bool("force", false, "Force deletion even if feature has tasks")
+	featureDeleteCmd.Flags().Bool("force", false, "Force permanent deletion even if feature has tasks")
+	featureDeleteCmd.Flags().Bool("permanent", false, "Delete permanently instead of moving to the trash")
+	addConfirmationFlags(featureDeleteCmd)
 
 	// Add flags for update command
 	featureUpdateCmd.Flags().String("title", "", "New title for the feature")
@@ -271,6 +382,7 @@ func init() {
 	featureUpdateCmd.Flags().Int("execution-order", -1, "New execution order (-1 = no change)")
 	featureUpdateCmd.Flags().String("key", "", "New key for the feature (must be unique, cannot contain spaces)")
 	featureUpdateCmd.Flags().Bool("force", false, "Force reassignment if file already claimed")
+	addLabelEditFlags(featureUpdateCmd, true)
 
 	// File path flags: --file is primary, --filename and --path are hidden aliases
 	featureUpdateCmd.Flags().String("file", "", "New file path (e.g., docs/custom/feature.md)")
@@ -282,21 +394,26 @@ func init() {
 
 // runFeatureList executes the feature list command
 func runFeatureList(cmd *cobra.Command, args []string) error {
+	applyFocus(cmd, args)
+
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Parse positional arguments first
 	positionalEpic, err := ParseFeatureListArgs(args)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 	}
 
 	// Get flags
 	epicFilter, _ := cmd.Flags().GetString("epic")
 	statusFilter, _ := cmd.Flags().GetString("status")
 	sortBy, _ := cmd.Flags().GetString("sort-by")
+	labelFilter, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+	}
 
 	// Positional argument takes priority over flag
 	if positionalEpic != nil {
@@ -307,26 +424,21 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 	if statusFilter != "" {
 		validatedStatus, err := ParseFeatureStatus(statusFilter)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 		statusFilter = validatedStatus
 	}
 
 	// Validate sort-by option
 	if sortBy != "" && sortBy != "key" && sortBy != "progress" && sortBy != "status" {
-		cli.Error(fmt.Sprintf("Error: Invalid sort-by '%s'. Must be one of: key, progress, status", sortBy))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid sort-by '%s'. Must be one of: key, progress, status", sortBy))
 	}
 
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -341,9 +453,7 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 		// Get epic by key
 		epic, err := epicRepo.GetByKey(ctx, epicFilter)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Epic %s does not exist", epicFilter))
-			cli.Info("Use 'shark epic list' to see available epics")
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Epic %s does not exist", epicFilter), "Use 'shark epic list' to see available epics")
 		}
 
 		// Use combined filter if status is specified
@@ -355,50 +465,53 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 		}
 
 		if err != nil {
-			cli.Error("Error: Database error. Run with --verbose for details.")
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Failed to list features: %v\n", err)
-			}
-			os.Exit(2)
+			slog.Error("Failed to list features", "error", err)
+			cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 		}
 	} else if statusFilter != "" {
 		// Use status filter only
 		status := models.FeatureStatus(statusFilter)
 		features, err = featureRepo.ListByStatus(ctx, status)
 		if err != nil {
-			cli.Error("Error: Database error. Run with --verbose for details.")
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Failed to list features: %v\n", err)
-			}
-			os.Exit(2)
+			slog.Error("Failed to list features", "error", err)
+			cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 		}
 	} else {
 		// Get all features
 		features, err = featureRepo.List(ctx)
 		if err != nil {
-			cli.Error("Error: Database error. Run with --verbose for details.")
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Failed to list features: %v\n", err)
+			slog.Error("Failed to list features", "error", err)
+			cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
+		}
+	}
+
+	// Attach labels for output and filter by label if requested
+	if err := attachFeatureLabels(ctx, repoDb, features); err != nil {
+		slog.Error("Failed to load labels", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
+	}
+	if len(labelFilter) > 0 {
+		labeled := []*models.Feature{}
+		for _, feature := range features {
+			if repository.HasAllLabels(feature.Labels, labelFilter) {
+				labeled = append(labeled, feature)
 			}
-			os.Exit(2)
 		}
+		features = labeled
 	}
 
 	// Handle empty results
 	if len(features) == 0 {
 		message := "No features found"
-		if epicFilter != "" {
+		if epicFilter != "" && statusFilter != "" {
+			message = fmt.Sprintf("No features found for epic %s with status %s", epicFilter, statusFilter)
+		} else if epicFilter != "" {
 			message = fmt.Sprintf("No features found for epic %s", epicFilter)
-		}
-		if statusFilter != "" {
+		} else if statusFilter != "" {
 			message = fmt.Sprintf("No features found with status %s", statusFilter)
 		}
 		if cli.GlobalConfig.JSON {
-			result := map[string]interface{}{
-				"results": []interface{}{},
-				"count":   0,
-			}
-			return cli.OutputJSON(result)
+			return cli.OutputJSON(&FeatureListJSON{Results: []FeatureListItemJSON{}})
 		}
 		cli.Info(message)
 		return nil
@@ -409,26 +522,22 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 	for _, feature := range features {
 		// Update feature progress (in case it's stale)
 		if err := featureRepo.UpdateProgress(ctx, feature.ID); err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to update progress for feature %s: %v\n", feature.Key, err)
-			}
+			slog.Warn("Failed to update progress for feature", "feature", feature.Key, "error", err)
 		}
 
 		// Get updated feature
+		labels := feature.Labels
 		feature, err = featureRepo.GetByID(ctx, feature.ID)
 		if err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to get feature %s: %v\n", feature.Key, err)
-			}
+			slog.Warn("Failed to get feature", "feature", feature.Key, "error", err)
 			continue
 		}
+		feature.Labels = labels
 
 		// Get task count using repository method
 		taskCount, err := featureRepo.GetTaskCount(ctx, feature.ID)
 		if err != nil {
-			if cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to get task count for feature %s: %v\n", feature.Key, err)
-			}
+			slog.Warn("Failed to get task count for feature", "feature", feature.Key, "error", err)
 			taskCount = 0
 		}
 
@@ -449,6 +558,19 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 	showAll, _ := cmd.Flags().GetBool("show-all")
 	featuresWithTaskCount = filterFeaturesByCompletedStatus(featuresWithTaskCount, showAll, statusFilter)
 
+	// Weight progress by task estimates if asked to, so sorting follows it too
+	var estimateTotals map[int64]progress.EstimateTotals
+	if useWeightedProgress(cmd) {
+		estimateTotals, err = repository.NewTaskEstimateRepository(repoDb).EstimateTotalsByFeature(ctx)
+		if err != nil {
+			slog.Error("Failed to load task estimates", "error", err)
+			cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
+		}
+		for _, feature := range featuresWithTaskCount {
+			feature.ProgressPct = estimateTotals[feature.ID].WeightedPct()
+		}
+	}
+
 	// Apply sorting
 	sortFeatures(featuresWithTaskCount, sortBy)
 
@@ -461,12 +583,12 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 
 		// Load workflow config
 		configPath, err := cli.GetConfigPath()
-		if err != nil && cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to get config path: %v\n", err)
+		if err != nil {
+			slog.Warn("Failed to get config path", "error", err)
 		}
 		cfg, err := config.LoadWorkflowConfig(configPath)
-		if err != nil && cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", err)
+		if err != nil {
+			slog.Warn("Failed to load config", "error", err)
 		}
 
 		// Batch fetch status breakdowns for all features to avoid N+1 query
@@ -475,8 +597,8 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 			featureIDs[i] = feature.ID
 		}
 		statusBreakdownBatch, err := taskRepo.GetStatusBreakdownMapBatch(ctx, featureIDs)
-		if err != nil && cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to batch fetch status breakdowns: %v\n", err)
+		if err != nil {
+			slog.Warn("Failed to batch fetch status breakdowns", "error", err)
 		}
 		if statusBreakdownBatch == nil {
 			statusBreakdownBatch = make(map[int64]map[models.TaskStatus]int)
@@ -499,20 +621,23 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 			health := calculateHealthIndicator(statusCounts, cfg)
 
 			// Calculate progress
-			var progressInfo interface{}
+			var progressInfo *FeatureListProgressJSON
 			if cfg != nil {
 				progress := status.CalculateProgress(statusCounts, cfg)
-				progressInfo = map[string]interface{}{
-					"weighted_pct":     progress.WeightedPct,
-					"completion_pct":   progress.CompletionPct,
-					"weighted_ratio":   progress.WeightedRatio,
-					"completion_ratio": progress.CompletionRatio,
-					"total_tasks":      progress.TotalTasks,
+				progressInfo = &FeatureListProgressJSON{
+					WeightedPct:     &progress.WeightedPct,
+					CompletionPct:   &progress.CompletionPct,
+					WeightedRatio:   progress.WeightedRatio,
+					CompletionRatio: progress.CompletionRatio,
+					TotalTasks:      &progress.TotalTasks,
 				}
 			} else {
-				progressInfo = map[string]interface{}{
-					"pct": feature.ProgressPct,
-				}
+				progressInfo = &FeatureListProgressJSON{Pct: &feature.ProgressPct}
+			}
+			if estimateTotals != nil {
+				estimatePct := estimateTotals[feature.ID].WeightedPct()
+				progressInfo.EstimatePct = &estimatePct
+				progressInfo.EstimateRatio = estimateTotals[feature.ID].Ratio()
 			}
 
 			// Generate notes
@@ -528,25 +653,25 @@ func runFeatureList(cmd *cobra.Command, args []string) error {
 				Progress:       progressInfo,
 				Notes:          notes,
 				TaskCount:      feature.TaskCount,
+				Labels:         feature.Labels,
 			})
 		}
 
-		result := map[string]interface{}{
-			"results": enhancedResults,
-			"count":   len(enhancedResults),
-		}
-		return cli.OutputJSON(result)
+		return cli.OutputJSON(&FeatureListJSON{Results: enhancedResults, Count: len(enhancedResults)})
 	}
 
 	// Output as table
-	renderFeatureListTable(featuresWithTaskCount, epicFilter, ctx, repoDb)
+	if estimateTotals != nil {
+		noteWeightedProgress(ctx, repoDb)
+	}
+	renderFeatureListTable(featuresWithTaskCount, epicFilter, estimateTotals, ctx, repoDb)
 	return nil
 }
 
 // runFeatureGet executes the feature get command
 func runFeatureGet(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	featureKey := args[0]
@@ -554,16 +679,13 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
 	// Get project root for WorkflowService
-	projectRoot, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
 		projectRoot = ""
 	}
@@ -580,9 +702,7 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 	// Get feature by key
 	feature, err := featureRepo.GetByKey(ctx, featureKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Feature %s does not exist", featureKey))
-		cli.Info("Use 'shark feature list' to see available features")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Feature %s does not exist", featureKey), "Use 'shark feature list' to see available features")
 	}
 
 	// Resolve feature path using PathResolver
@@ -592,46 +712,39 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 		absPath, err := pathResolver.ResolveFeaturePath(ctx, feature.Key)
 		if err == nil {
 			resolvedPath = getRelativePathFeature(absPath, projectRoot)
-		} else if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to resolve feature path: %v\n", err)
+		} else {
+			slog.Warn("Failed to resolve feature path", "error", err)
 		}
 	}
 
 	// Update feature progress
 	if err := featureRepo.UpdateProgress(ctx, feature.ID); err != nil {
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to update progress for feature %s: %v\n", feature.Key, err)
-		}
+		slog.Warn("Failed to update progress for feature", "feature", feature.Key, "error", err)
 	}
 
 	// Get updated feature
 	feature, err = featureRepo.GetByID(ctx, feature.ID)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Failed to get feature: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Failed to get feature", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
+	}
+
+	if err := attachFeatureLabels(ctx, repoDb, []*models.Feature{feature}); err != nil {
+		slog.Warn("Failed to fetch labels", "error", err)
 	}
 
 	// Get tasks for this feature
 	tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Failed to list tasks: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Failed to list tasks", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 
 	// Get task status breakdown from repository
 	statusBreakdown, err := taskRepo.GetStatusBreakdown(ctx, feature.ID)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Failed to get status breakdown: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Failed to get status breakdown", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 
 	// Extract directory path and filename
@@ -643,8 +756,8 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 
 	// Get related documents
 	relatedDocs, err := documentRepo.ListForFeature(ctx, feature.ID)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch related documents: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to fetch related documents", "error", err)
 	}
 	if relatedDocs == nil {
 		relatedDocs = []*models.Document{}
@@ -656,17 +769,25 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 		statusSource = "manual"
 	}
 
+	// Get parent epic key
+	var epicKey string
+	if epic, err := epicRepo.GetByID(ctx, feature.EpicID); err == nil {
+		epicKey = epic.Key
+	} else {
+		slog.Warn("Failed to get parent epic", "error", err)
+	}
+
 	// Load workflow config for status calculations
 	configPath, err := cli.GetConfigPath()
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to get config path: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to get config path", "error", err)
 	}
 
 	var workflowCfg *config.WorkflowConfig
 	if configPath != "" {
 		workflowCfg, err = config.LoadWorkflowConfig(configPath)
-		if err != nil && cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to load workflow config for status calculations: %v\n", err)
+		if err != nil {
+			slog.Warn("Failed to load workflow config for status calculations", "error", err)
 		}
 	}
 
@@ -691,6 +812,18 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Weight progress by task estimates if asked to
+	weighted := useWeightedProgress(cmd)
+	if weighted {
+		estimateTotals, err := repository.NewTaskEstimateRepository(repoDb).EstimateTotalsByFeature(ctx)
+		if err != nil {
+			return err
+		}
+		estimatePct := estimateTotals[feature.ID].WeightedPct()
+		progressInfo.EstimatePct = &estimatePct
+		progressInfo.EstimateRatio = estimateTotals[feature.ID].Ratio()
+	}
+
 	// Calculate work summary using status package
 	var workSummary *status.WorkSummary
 	if workflowCfg != nil {
@@ -720,37 +853,45 @@ func runFeatureGet(cmd *cobra.Command, args []string) error {
 
 	// Output as JSON if requested
 	if cli.GlobalConfig.JSON {
-		result := map[string]interface{}{
-			"id":                feature.ID,
-			"epic_id":           feature.EpicID,
-			"key":               feature.Key,
-			"title":             feature.Title,
-			"description":       feature.Description,
-			"status":            feature.Status,
-			"status_source":     statusSource,
-			"status_override":   feature.StatusOverride,
-			"progress_pct":      feature.ProgressPct,
-			"path":              dirPath,
-			"filename":          filename,
-			"created_at":        feature.CreatedAt,
-			"updated_at":        feature.UpdatedAt,
-			"tasks":             tasks,
-			"status_breakdown":  statusBreakdown,
-			"related_documents": relatedDocs,
-			"progress":          progressInfo,
-			"work_summary":      workSummary,
-			"action_items":      actionItems,
+		result := &FeatureGetJSON{
+			ID:               feature.ID,
+			EpicID:           feature.EpicID,
+			EpicKey:          epicKey,
+			Key:              feature.Key,
+			Title:            feature.Title,
+			Description:      feature.Description,
+			Status:           feature.Status,
+			StatusSource:     statusSource,
+			StatusOverride:   feature.StatusOverride,
+			Slug:             feature.Slug,
+			ExecutionOrder:   feature.ExecutionOrder,
+			ProgressPct:      feature.ProgressPct,
+			Path:             dirPath,
+			Filename:         filename,
+			FilePath:         feature.FilePath,
+			Labels:           feature.Labels,
+			CreatedAt:        feature.CreatedAt,
+			UpdatedAt:        feature.UpdatedAt,
+			Tasks:            tasks,
+			StatusBreakdown:  statusBreakdown,
+			RelatedDocuments: relatedDocs,
+			Progress:         progressInfo,
+			WorkSummary:      workSummary,
+			ActionItems:      actionItems,
 		}
 		return cli.OutputJSON(result)
 	}
 
 	// Output as formatted text
+	if weighted {
+		noteWeightedProgress(ctx, repoDb)
+	}
 	renderFeatureDetails(feature, tasks, statusBreakdown, dirPath, filename, relatedDocs, workflowService, progressInfo, workSummary, actionItems)
 	return nil
 }
 
 // renderFeatureListTable renders features as a table
-func renderFeatureListTable(features []FeatureWithTaskCount, epicFilter string, ctx context.Context, repoDb *repository.DB) {
+func renderFeatureListTable(features []FeatureWithTaskCount, epicFilter string, estimateTotals map[int64]progress.EstimateTotals, ctx context.Context, repoDb *repository.DB) {
 	// Create table data with reordered columns (removed Notes, Health next to Status)
 	tableData := pterm.TableData{
 		{"Key", "Title", "Progress", "Status", "Health"},
@@ -765,8 +906,8 @@ func renderFeatureListTable(features []FeatureWithTaskCount, epicFilter string,
 		featureIDs[i] = feature.ID
 	}
 	statusBreakdownBatch, err := taskRepo.GetStatusBreakdownMapBatch(ctx, featureIDs)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to batch fetch status breakdowns: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to batch fetch status breakdowns", "error", err)
 	}
 	if statusBreakdownBatch == nil {
 		statusBreakdownBatch = make(map[int64]map[models.TaskStatus]int)
@@ -774,16 +915,16 @@ func renderFeatureListTable(features []FeatureWithTaskCount, epicFilter string,
 
 	// Load config once for all features
 	configPath, cfgErr := cli.GetConfigPath()
-	if cfgErr != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to get config path: %v\n", cfgErr)
+	if cfgErr != nil {
+		slog.Warn("Failed to get config path", "error", cfgErr)
 	}
 	cfg, cfgErr := config.LoadWorkflowConfig(configPath)
-	if cfgErr != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", cfgErr)
+	if cfgErr != nil {
+		slog.Warn("Failed to load config", "error", cfgErr)
 	}
 
 	// Get project root for WorkflowService
-	projectRoot, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
 		projectRoot = ""
 	}
@@ -813,7 +954,10 @@ func renderFeatureListTable(features []FeatureWithTaskCount, epicFilter string,
 
 		// Calculate progress with weighted ratio
 		var progressDisplay string
-		if cfg != nil {
+		if estimateTotals != nil {
+			totals := estimateTotals[feature.ID]
+			progressDisplay = fmt.Sprintf("%.0f%% (%s est)", totals.WeightedPct(), totals.Ratio())
+		} else if cfg != nil {
 			progress := status.CalculateProgress(statusCounts, cfg)
 			progressDisplay = fmt.Sprintf("%.0f%% (%s)", progress.WeightedPct, progress.WeightedRatio)
 		} else {
@@ -946,6 +1090,9 @@ func renderFeatureDetails(feature *models.Feature, tasks []*models.Task, statusB
 	progressDisplay := fmt.Sprintf("%.1f%%", feature.ProgressPct)
 	if progressInfo != nil {
 		progressDisplay = fmt.Sprintf("%.1f%%", progressInfo.WeightedPct)
+		if progressInfo.EstimatePct != nil {
+			progressDisplay = fmt.Sprintf("%.1f%% (by estimate)", *progressInfo.EstimatePct)
+		}
 	}
 
 	info := [][]string{
@@ -963,6 +1110,10 @@ func renderFeatureDetails(feature *models.Feature, tasks []*models.Task, statusB
 		info = append(info, []string{"Filename", filename})
 	}
 
+	if len(feature.Labels) > 0 {
+		info = append(info, []string{"Labels", strings.Join(feature.Labels, ", ")})
+	}
+
 	if feature.Description != nil && *feature.Description != "" {
 		info = append(info, []string{"Description", *feature.Description})
 	}
@@ -1009,6 +1160,9 @@ func renderFeatureDetails(feature *models.Feature, tasks []*models.Task, statusB
 			{"Weighted Progress", fmt.Sprintf("%.1f%%", progressInfo.WeightedPct), progressInfo.WeightedRatio},
 			{"Completion", fmt.Sprintf("%.1f%%", progressInfo.CompletionPct), progressInfo.CompletionRatio},
 		}
+		if progressInfo.EstimatePct != nil {
+			progressData = append(progressData, []string{"Estimate Progress", fmt.Sprintf("%.1f%%", *progressInfo.EstimatePct), progressInfo.EstimateRatio})
+		}
 		_ = pterm.DefaultTable.WithHasHeader().WithData(progressData).Render()
 	}
 
@@ -1048,8 +1202,8 @@ func renderFeatureDetails(feature *models.Feature, tasks []*models.Task, statusB
 				pterm.DefaultBox.WithTitle("⏳ Awaiting Approval").Println(fmt.Sprintf("%d tasks", len(actionItems.AwaitingApproval)))
 				for _, item := range actionItems.AwaitingApproval {
 					ageStr := ""
-					if item.AgeDays != nil {
-						ageStr = fmt.Sprintf(" (%d days)", *item.AgeDays)
+					if item.Age != nil {
+						ageStr = fmt.Sprintf(" (waiting %s)", *item.Age)
 					}
 					fmt.Printf("  - %s: %s%s\n", item.TaskKey, item.Title, ageStr)
 				}
@@ -1064,7 +1218,11 @@ func renderFeatureDetails(feature *models.Feature, tasks []*models.Task, statusB
 					if item.BlockedReason != nil && *item.BlockedReason != "" {
 						reasonStr = fmt.Sprintf(" - %s", *item.BlockedReason)
 					}
-					fmt.Printf("  - %s: %s%s\n", item.TaskKey, item.Title, reasonStr)
+					ageStr := ""
+					if item.Age != nil {
+						ageStr = fmt.Sprintf(" (blocked %s)", *item.Age)
+					}
+					fmt.Printf("  - %s: %s%s%s\n", item.TaskKey, item.Title, ageStr, reasonStr)
 				}
 			}
 
@@ -1186,17 +1344,54 @@ type FeatureTemplateData struct {
 	Date        string
 }
 
+// renderFeatureTemplate renders shark-templates/feature.md with the given data
+func renderFeatureTemplate(data FeatureTemplateData) ([]byte, error) {
+	templateContent, err := os.ReadFile(projectTemplatePath("feature.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature template: %w", err)
+	}
+
+	tmpl, err := template.New("feature").Parse(string(templateContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feature template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render feature template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 // runFeatureCreate executes the feature create command
 func runFeatureCreate(cmd *cobra.Command, args []string) error {
+	if fromEpicDoc, _ := cmd.Flags().GetBool("from-epic-doc"); fromEpicDoc {
+		return runFeatureCreateFromEpicDoc(cmd, args)
+	}
+
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Parse arguments - supports both positional and flag-based syntax
 	var featureTitle string
+	fromFile, _ := cmd.Flags().GetString("from-file")
 	positionalEpic, positionalTitle, err := ParseFeatureCreateArgs(args)
 
-	if err == nil && positionalEpic != nil && positionalTitle != nil {
+	if fromFile != "" {
+		// --from-file: the title may come from the file's first heading
+		if cmd.Flags().Changed("file") || cmd.Flags().Changed("filename") || cmd.Flags().Changed("path") {
+			cli.Fail(cli.ErrCodeInvalidArgument, "Error: --file cannot be used with --from-file",
+				"Use --from-file to link an existing file, or --file to create a new one")
+		}
+		if cmd.Flags().Changed("scaffold") || cmd.Flags().Changed("scaffold-docs") {
+			cli.Fail(cli.ErrCodeInvalidArgument, "Error: --scaffold cannot be used with --from-file")
+		}
+		featureCreateEpic, featureTitle, err = parseFeatureFromFileArgs(args, featureCreateEpic)
+		if err != nil {
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err))
+		}
+	} else if err == nil && positionalEpic != nil && positionalTitle != nil {
 		// Positional syntax: shark feature create E07 "Feature Title"
 		featureTitle = *positionalTitle
 		// Positional epic takes priority over flag (if both provided, use positional)
@@ -1209,27 +1404,34 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 		featureTitle = args[0]
 	} else {
 		// Invalid syntax - show error
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		fmt.Println("\nValid syntaxes:")
-		fmt.Println("  shark feature create E07 \"Feature Title\"           (recommended)")
-		fmt.Println("  shark feature create --epic=E07 \"Feature Title\"     (legacy)")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err),
+			"Valid syntaxes:\n  shark feature create E07 \"Feature Title\"           (recommended)\n  shark feature create --epic=E07 \"Feature Title\"     (legacy)")
 	}
 
 	// Validate epic key format
 	if !isValidEpicKey(featureCreateEpic) {
-		cli.Error("Error: Invalid epic key format. Must be E## (e.g., E01, E02)")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, "Error: Invalid epic key format. Must be E## (e.g., E01, E02)")
+	}
+
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+	}
+
+	scaffoldEnabled, scaffoldDocs, err := featureScaffoldOptions(cmd)
+	if err != nil {
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err))
+	}
+	if fromFile != "" {
+		// A linked spec rarely sits in a folder of its own
+		scaffoldEnabled = false
 	}
 
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1240,18 +1442,16 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 	// Verify epic exists in database
 	epic, err := epicRepo.GetByKey(ctx, featureCreateEpic)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Epic %s not found in database", featureCreateEpic))
-		cli.Info("Use 'shark epic list' to see available epics")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Epic %s not found in database", featureCreateEpic), "Use 'shark epic list' to see available epics")
 	}
 
 	// Get feature key (custom or auto-generated)
+	ifNotExists, _ := cmd.Flags().GetBool(ifNotExistsFlag)
 	var nextKey string
 	if featureCreateKey != "" {
 		// Validate custom key using shared validator: no spaces allowed
 		if err := ValidateNoSpaces(featureCreateKey, "feature"); err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 
 		// For custom keys, construct full key as E##-<custom-key>
@@ -1267,19 +1467,57 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 			nextKey = fmt.Sprintf("%s-%s", featureCreateEpic, featureCreateKey)
 		}
 
-		// Check if key already exists
+		// Check if key already exists (--if-not-exists returns it below)
 		existing, err := featureRepo.GetByKey(ctx, nextKey)
-		if err == nil && existing != nil {
-			cli.Error(fmt.Sprintf("Error: Feature with key '%s' already exists", nextKey))
-			os.Exit(1)
+		if err == nil && existing != nil && !ifNotExists {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Feature with key '%s' already exists", nextKey),
+				"Use --if-not-exists to return the existing feature instead")
 		}
 	} else {
 		// Auto-generate next feature key (now includes epic prefix)
 		var err error
-		nextKey, err = getNextFeatureKey(ctx, featureRepo, epic.ID, epic.Key)
+		nextKey, err = featureRepo.NextKey(ctx, epic.ID, epic.Key)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to generate feature key: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to generate feature key: %v", err))
+		}
+	}
+
+	// Get project root
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to find project root: %v", err))
+	}
+
+	// Read the title and description of an existing feature spec
+	if fromFile != "" {
+		absPath, relPath, err := taskcreation.ValidateCustomFilename(fromFile, projectRoot)
+		if err != nil {
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid filename: %v", err))
+		}
+		fileTitle, fileDescription, err := readFeatureSourceFile(absPath, relPath)
+		if err != nil {
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err))
+		}
+		if featureTitle == "" {
+			featureTitle = fileTitle
+		}
+		if featureTitle == "" {
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: '%s' has no heading to take the title from", relPath),
+				fmt.Sprintf("Pass the title as an argument: shark feature create %s \"Feature Title\" --from-file=%s", featureCreateEpic, relPath))
+		}
+		if featureCreateDescription == "" {
+			featureCreateDescription = fileDescription
+		}
+	}
+
+	// With --if-not-exists, a retried create returns the feature it made
+	if ifNotExists {
+		existing, err := findExistingFeature(ctx, featureRepo, epic.ID, featureCreateKey != "", nextKey, featureTitle)
+		if err != nil {
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err))
+		}
+		if existing != nil {
+			return outputExistingEntity("feature", existing.Key, existing.Title, existing.FilePath, projectRoot, existing)
 		}
 	}
 
@@ -1287,13 +1525,6 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 	slug := utils.GenerateSlug(featureTitle)
 	featureSlug := fmt.Sprintf("%s-%s", nextKey, slug)
 
-	// Get project root (current working directory)
-	projectRoot, err := os.Getwd()
-	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to get working directory: %v", err))
-		os.Exit(1)
-	}
-
 	// Use the nextKey which is already in full format (E##-F## or E##-<custom>)
 	featureKey := nextKey
 
@@ -1307,6 +1538,10 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 	var featureFilePath string
 	var customFilePath *string
 
+	// Previous owners of a force-reassigned file, journaled for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	var reassignedFrom []string
+
 	// Try all three flag aliases: --file, --filename, --path (last one wins)
 	file, _ := cmd.Flags().GetString("file")
 	filename, _ := cmd.Flags().GetString("filename")
@@ -1314,7 +1549,9 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 
 	// Determine which flag was provided (priority: path > filename > file)
 	var customFile string
-	if path != "" {
+	if fromFile != "" {
+		customFile = fromFile
+	} else if path != "" {
 		customFile = path
 	} else if filename != "" {
 		customFile = filename
@@ -1326,42 +1563,27 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 		// Validate custom filename
 		absPath, relPath, err := taskcreation.ValidateCustomFilename(customFile, projectRoot)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Invalid filename: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid filename: %v", err))
 		}
 
-		// Check for collision with existing features
-		existingFeature, err := featureRepo.GetByFilePath(ctx, relPath)
-		if err == nil && existingFeature != nil {
-			if !featureCreateForce {
-				cli.Error(fmt.Sprintf("Error: file '%s' is already claimed by feature %s ('%s'). Use --force to reassign",
-					relPath, existingFeature.Key, existingFeature.Title))
-				os.Exit(1)
-			}
+		// Check for collision with the files every epic, feature, and task claims
+		collision, err := claimedFileCollision(ctx, repoDb, relPath)
+		if err != nil {
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to check for file collision: %v", err))
 		}
-
-		// Check for collision with existing epics (cross-entity collision)
-		existingEpic, err := epicRepo.GetByFilePath(ctx, relPath)
-		if err == nil && existingEpic != nil {
-			if !featureCreateForce {
-				cli.Error(fmt.Sprintf("Error: file '%s' is already claimed by epic %s ('%s'). Use --force to reassign",
-					relPath, existingEpic.Key, existingEpic.Title))
-				os.Exit(1)
-			}
+		if collision != nil && !featureCreateForce {
+			cli.Fail(cli.ErrCodeFailure, "Error: "+claimedFileError(collision))
 		}
 
-		// Create backup before force reassignment (if any collision exists)
-		if (existingFeature != nil || existingEpic != nil) && featureCreateForce {
+		if collision != nil {
+			// Create backup before force reassignment
 			dbPath, canBackup, err := cli.GetDatabasePathForBackup()
 			if err != nil {
-				cli.Error(fmt.Sprintf("Error: failed to get database path for backup: %v", err))
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
 			}
 			if canBackup {
 				if _, err := backupDatabaseOnForceFeature(featureCreateForce, dbPath, "force file reassignment"); err != nil {
-					cli.Error(fmt.Sprintf("Error: %v", err))
-					cli.Info("Aborting operation to prevent data loss")
-					os.Exit(2)
+					cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err), "Aborting operation to prevent data loss")
 				}
 			} else {
 				// Cloud database - backup is handled by cloud provider
@@ -1369,32 +1591,16 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 					cli.Info("Using cloud database - backup handled by provider")
 				}
 			}
-		}
-
-		// Force reassignment: clear the old feature's file path
-		if existingFeature != nil && featureCreateForce {
-			if err := featureRepo.UpdateFilePath(ctx, existingFeature.Key, nil); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to clear old feature's file path: %v", err))
-				os.Exit(1)
-			}
-		}
 
-		// Force reassignment: clear the old epic's file path
-		if existingEpic != nil && featureCreateForce {
-			// Force reassignment: clear the old epic's file path
-			if err := epicRepo.UpdateFilePath(ctx, existingEpic.Key, nil); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to clear old epic's file path: %v", err))
-				os.Exit(1)
+			// Force reassignment: clear the old owner's file path
+			entityType, ownerKey, _ := collision.owner()
+			reassignedFrom = append(reassignedFrom, ownerKey)
+			if err := releaseFileClaim(ctx, repoDb, journal, collision); err != nil {
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to clear old %s's file path: %v", entityType, err))
 			}
 		}
 
-		// Create parent directories if they don't exist
-		dirPath := filepath.Dir(absPath)
-		if err := os.MkdirAll(dirPath, 0755); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to create directory structure: %v", err))
-			os.Exit(1)
-		}
-
+		// The file writer creates parent directories
 		featureFilePath = absPath
 		customFilePath = &relPath
 	} else {
@@ -1403,8 +1609,7 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 		pathResolver := pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot)
 		epicPath, err := pathResolver.ResolveEpicPath(ctx, epic.Key)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to resolve epic directory: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to resolve epic directory: %v", err))
 		}
 
 		// Extract directory from epic.md path (remove filename)
@@ -1413,14 +1618,10 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 		// Validate that the epic directory exists
 		fileInfo, err := os.Stat(epicDir)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Epic directory does not exist: %s", epicDir))
-			cli.Info("Run 'shark init' to create the directory structure")
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Epic directory does not exist: %s", epicDir), "Run 'shark init' to create the directory structure")
 		}
 		if !fileInfo.IsDir() {
-			cli.Error(fmt.Sprintf("Error: Expected directory but found file at: %s", epicDir))
-			cli.Info("Please remove or rename the file to resolve the conflict")
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Expected directory but found file at: %s", epicDir), "Please remove or rename the file to resolve the conflict")
 		}
 
 		// Create feature directory
@@ -1428,33 +1629,17 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 
 		// Check if feature already exists
 		if _, err := os.Stat(featureDir); err == nil {
-			cli.Error(fmt.Sprintf("Error: Feature directory already exists: %s", featureDir))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Feature directory already exists: %s", featureDir))
 		}
 
-		// Create feature directory
-		if err := os.MkdirAll(featureDir, 0755); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to create feature directory: %v", err))
-			os.Exit(1)
-		}
-
-		// Set both featureFilePath and customFilePath
+		// The file writer creates the feature directory. Set both featureFilePath and customFilePath
 		featureFilePath = fmt.Sprintf("%s/feature.md", featureDir)
 		relPath := featureFilePath // This is already a relative path from project root
 		customFilePath = &relPath
 	}
 
-	// Read feature template
-	templatePath := "shark-templates/feature.md"
-	templateContent, err := os.ReadFile(templatePath)
-	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to read feature template: %v", err))
-		cli.Info("Make sure you've run 'shark init' to create templates")
-		os.Exit(1)
-	}
-
-	// Prepare template data
-	data := FeatureTemplateData{
+	// Render feature template
+	templateData := FeatureTemplateData{
 		EpicKey:     featureCreateEpic,
 		FeatureKey:  nextKey,
 		FeatureSlug: featureSlug,
@@ -1463,36 +1648,29 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 		FilePath:    featureFilePath,
 		Date:        time.Now().Format("2006-01-02"),
 	}
-
-	// Parse and execute template
-	tmpl, err := template.New("feature").Parse(string(templateContent))
-	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to parse feature template: %v", err))
-		os.Exit(1)
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to render feature template: %v", err))
-		os.Exit(1)
-	}
-
-	// Write feature file using unified file writer
-	writer := fileops.NewEntityFileWriter()
-	writeResult, err := writer.WriteEntityFile(fileops.WriteOptions{
-		Content:        buf.Bytes(),
-		ProjectRoot:    projectRoot,
-		FilePath:       featureFilePath,
-		Verbose:        cli.GlobalConfig.Verbose,
-		EntityType:     "feature",
-		UseAtomicWrite: false, // Keep existing behavior (no O_EXCL)
-		Logger: func(message string) {
-			cli.Info(message)
-		},
-	})
-	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+	// Write feature file using unified file writer; an existing spec is linked as-is
+	writeResult := &fileops.WriteResult{Linked: true, AbsolutePath: featureFilePath}
+	if fromFile == "" {
+		content, err := renderFeatureTemplate(templateData)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err), "Make sure you've run 'shark init' to create templates")
+		}
+
+		writer := fileops.NewEntityFileWriter()
+		writeResult, err = writer.WriteEntityFile(fileops.WriteOptions{
+			Content:        content,
+			ProjectRoot:    projectRoot,
+			FilePath:       featureFilePath,
+			Verbose:        cli.GlobalConfig.Verbose,
+			EntityType:     "feature",
+			UseAtomicWrite: true, // Agents creating the same feature at once can't both write it
+			Logger: func(message string) {
+				cli.Info(message)
+			},
+		})
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+		}
 	}
 
 	// Capture whether file was linked to existing content
@@ -1505,8 +1683,7 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 	}
 	statusStr, err = ParseFeatureStatus(statusStr)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 	}
 	status := models.FeatureStatus(statusStr)
 
@@ -1523,11 +1700,34 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := featureRepo.Create(ctx, feature); err != nil {
-		// Rollback: delete the created file
-		os.Remove(featureFilePath)
-		cli.Error(fmt.Sprintf("Error: Failed to create feature in database: %v", err))
-		cli.Info("Rolled back file creation")
-		os.Exit(1)
+		// Rollback: delete the created file, unless it was an existing file we linked to
+		if writeResult.Written {
+			os.Remove(writeResult.AbsolutePath)
+			cli.Info("Rolled back file creation")
+		}
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to create feature in database: %v", err))
+	}
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityFeature, EntityKey: feature.Key, Action: models.AuditActionCreate, Summary: feature.Title})
+
+	if len(reassignedFrom) > 0 {
+		journal.setOnUndo("features", feature.ID, "file_path", nil)
+		journal.record(ctx, "file reassign", feature.Key, fmt.Sprintf("Reassigned %s from %s to feature %s", *customFilePath, strings.Join(reassignedFrom, ", "), feature.Key))
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityFeature, EntityKey: feature.Key, Action: models.AuditActionFileReassign,
+			Summary: fmt.Sprintf("Took %s from %s", *customFilePath, strings.Join(reassignedFrom, ", "))})
+	}
+
+	if len(labels) > 0 {
+		if err := repository.NewLabelRepository(repoDb).AddFeatureLabels(ctx, feature.ID, labels); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Feature %s created but labels could not be added: %v", featureKey, err))
+		}
+	}
+
+	var scaffold *FeatureScaffold
+	if scaffoldEnabled {
+		scaffold, err = scaffoldFeature(ctx, repoDb, projectRoot, feature, templateData, scaffoldDocs)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Feature %s created but could not be scaffolded: %v", featureKey, err))
+		}
 	}
 
 	// Success output
@@ -1535,6 +1735,12 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 		// JSON output with enhanced messaging
 		requiredSections := cli.GetRequiredSectionsForEntityType("feature")
 		jsonOutput := cli.FormatEntityCreationJSON("feature", featureKey, featureTitle, featureFilePath, projectRoot, requiredSections)
+		if len(labels) > 0 {
+			jsonOutput["labels"] = labels
+		}
+		if scaffold != nil {
+			jsonOutput["scaffold"] = scaffold
+		}
 		return cli.OutputJSON(jsonOutput)
 	}
 
@@ -1542,54 +1748,31 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 	requiredSections := cli.GetRequiredSectionsForEntityType("feature")
 	message := cli.FormatEntityCreationMessage("feature", featureKey, featureTitle, featureFilePath, projectRoot, fileWasLinked, requiredSections)
 	fmt.Print(message)
+	if scaffold != nil {
+		printFeatureScaffold(scaffold)
+	}
 
 	return nil
 }
 
-// getNextFeatureKey determines the next available feature key (E##-F##) for an epic
-// If epicKey is empty, it will attempt to extract from existing features
-func getNextFeatureKey(ctx context.Context, featureRepo *repository.FeatureRepository, epicID int64, epicKey ...string) (string, error) {
-	// Get all features for this epic
-	features, err := featureRepo.ListByEpic(ctx, epicID)
-	if err != nil {
-		return "", fmt.Errorf("failed to list features: %w", err)
-	}
-
-	// Find the maximum feature number and extract epic key from existing features
-	maxNum := 0
-	extractedEpicKey := ""
-	for _, feature := range features {
-		// Feature key format in DB is E##-F##, extract both parts
-		var epicNum, featureNum int
-		if _, err := fmt.Sscanf(feature.Key, "E%d-F%d", &epicNum, &featureNum); err == nil {
-			if extractedEpicKey == "" {
-				extractedEpicKey = fmt.Sprintf("E%02d", epicNum)
-			}
-			if featureNum > maxNum {
-				maxNum = featureNum
-			}
+// findExistingFeature returns the feature a create would duplicate: the
+// feature with the custom key, or without one the epic's feature with the same
+// title. Returns nil if none.
+func findExistingFeature(ctx context.Context, featureRepo *repository.FeatureRepository, epicID int64, customKey bool, key, title string) (*models.Feature, error) {
+	if customKey {
+		existing, err := featureRepo.GetByKey(ctx, key)
+		if err == nil && existing != nil {
+			return existing, nil
 		}
+		return nil, nil
 	}
-
-	// Determine which epic key to use: provided parameter or extracted from features
-	finalEpicKey := extractedEpicKey
-	if len(epicKey) > 0 && epicKey[0] != "" {
-		finalEpicKey = epicKey[0]
-	}
-
-	// If still no epic key, we can't proceed
-	if finalEpicKey == "" {
-		return "", fmt.Errorf("unable to determine epic key - no existing features and no epic key provided")
-	}
-
-	// Return full feature key with epic prefix
-	return fmt.Sprintf("%s-F%02d", finalEpicKey, maxNum+1), nil
+	return featureRepo.GetByTitle(ctx, epicID, title)
 }
 
 // runFeatureComplete executes the feature complete command
 func runFeatureComplete(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	featureKey := args[0]
@@ -1598,11 +1781,8 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1613,25 +1793,30 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 	// Get feature by key
 	feature, err := featureRepo.GetByKey(ctx, featureKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Feature %s does not exist", featureKey))
-		cli.Info("Use 'shark feature list' to see available features")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Feature %s does not exist", featureKey), "Use 'shark feature list' to see available features")
 	}
 
 	// Get all tasks in feature
 	tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to list tasks: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to list tasks: %v", err))
 	}
 
 	// If no tasks, set feature status to completed and inform user
 	if len(tasks) == 0 {
+		if isDryRun(cmd) {
+			return printDestructiveDryRun(&DestructiveOperation{
+				Operation: "feature complete",
+				Key:       feature.Key,
+				Summary:   fmt.Sprintf("Would mark feature %s completed (no tasks)", feature.Key),
+				Affected:  []string{},
+			})
+		}
+
 		// Set feature status to completed even with no tasks
 		feature.Status = models.FeatureStatusCompleted
 		if err := featureRepo.Update(ctx, feature); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update feature status: %v", err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to update feature status: %v", err))
 		}
 
 		if cli.GlobalConfig.JSON {
@@ -1651,8 +1836,7 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 	// Get status breakdown using new workflow-aware method
 	statusBreakdownSlice, err := taskRepo.GetStatusBreakdown(ctx, feature.ID)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to get task status: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to get task status: %v", err))
 	}
 
 	// Convert to map for efficient lookup
@@ -1676,6 +1860,22 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 
 	hasIncomplete := len(incompleteTasks) > 0
 
+	// Describe the force completion for --dry-run and confirmation tokens
+	forceAffected := make([]string, 0, len(incompleteTasks))
+	for _, task := range incompleteTasks {
+		forceAffected = append(forceAffected, fmt.Sprintf("%s:%s", task.Key, task.Status))
+	}
+	op := &DestructiveOperation{
+		Operation: "feature complete",
+		Key:       feature.Key,
+		Summary:   fmt.Sprintf("Would force %d incomplete task(s) in feature %s to completed", len(forceAffected), feature.Key),
+		Affected:  forceAffected,
+	}
+
+	if isDryRun(cmd) {
+		return printDestructiveDryRun(op)
+	}
+
 	// Show warning if incomplete tasks exist and no --force
 	if hasIncomplete && !force {
 		// Build status breakdown summary
@@ -1733,21 +1933,22 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 			return cli.OutputJSON(result)
 		}
 
-		os.Exit(3)
+		cli.Exit(cli.ExitInvalidState)
 	}
 
 	// Create backup before force completing tasks
 	if force && hasIncomplete {
+		if err := verifyConfirmationToken(cmd, op); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+		}
+
 		dbPath, canBackup, err := cli.GetDatabasePathForBackup()
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: failed to get database path for backup: %v", err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
 		}
 		if canBackup {
 			if _, err := backupDatabaseOnForceFeature(force, dbPath, "force complete feature"); err != nil {
-				cli.Error(fmt.Sprintf("Error: %v", err))
-				cli.Info("Aborting operation to prevent data loss")
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: %v", err), "Aborting operation to prevent data loss")
 			}
 		} else {
 			// Cloud database - backup is handled by cloud provider
@@ -1757,6 +1958,13 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Snapshot statuses changed by a forced completion for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	if force && hasIncomplete {
+		journal.captureColumns(ctx, "tasks", incompleteTaskIDs(tasks), "status", "completed_at")
+		journal.captureColumns(ctx, "features", []int64{feature.ID}, "status", "progress_pct")
+	}
+
 	// Complete all tasks in a transaction
 	agent := getAgentIdentifier("")
 	numCompleted := 0
@@ -1770,8 +1978,7 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 
 		// Mark as completed
 		if err := taskRepo.UpdateStatusForced(ctx, task.ID, models.TaskStatusCompleted, &agent, nil, nil, nil, true); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to complete task %s: %v", task.Key, err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to complete task %s: %v", task.Key, err))
 		}
 		numCompleted++
 		affectedTaskKeys = append(affectedTaskKeys, task.Key)
@@ -1779,15 +1986,19 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 
 	// Update feature progress (which now auto-completes at 100%)
 	if err := featureRepo.UpdateProgress(ctx, feature.ID); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to update feature progress: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to update feature progress: %v", err))
 	}
 
 	// Fetch updated feature to get the new status
 	feature, err = featureRepo.GetByKey(ctx, featureKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to fetch updated feature: %v", err))
-		os.Exit(2)
+		cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to fetch updated feature: %v", err))
+	}
+
+	if force && hasIncomplete {
+		summary := fmt.Sprintf("Force completed %d task(s) in feature %s", numCompleted, feature.Key)
+		journal.record(ctx, "feature complete", feature.Key, summary)
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityFeature, EntityKey: feature.Key, Action: models.AuditActionForce, Summary: summary})
 	}
 
 	// Output results
@@ -1838,20 +2049,18 @@ func runFeatureComplete(cmd *cobra.Command, args []string) error {
 
 // runFeatureDelete executes the feature delete command
 func runFeatureDelete(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	featureKey := args[0]
 	force, _ := cmd.Flags().GetBool("force")
+	permanent, _ := cmd.Flags().GetBool("permanent")
 
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1862,39 +2071,78 @@ func runFeatureDelete(cmd *cobra.Command, args []string) error {
 	// Get feature by key to verify it exists
 	feature, err := featureRepo.GetByKey(ctx, featureKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Feature %s does not exist", featureKey))
-		cli.Info("Use 'shark feature list' to see available features")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Feature %s does not exist", featureKey), "Use 'shark feature list' to see available features")
 	}
 
 	// Check for child tasks
 	tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to check for tasks: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to check for tasks: %v", err))
+	}
+
+	// Describe the cascade for --dry-run and confirmation tokens
+	affected := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		affected = append(affected, task.Key)
+	}
+	op := &DestructiveOperation{
+		Operation: "feature delete",
+		Key:       feature.Key,
+		Summary:   fmt.Sprintf("Would delete feature %s and cascade delete %d task(s)", feature.Key, len(tasks)),
+		Affected:  affected,
+	}
+	if !permanent {
+		op.Summary = fmt.Sprintf("Would move feature %s and %d task(s) to the trash", feature.Key, len(tasks))
+	}
+
+	if isDryRun(cmd) {
+		return printDestructiveDryRun(op)
+	}
+
+	if !permanent {
+		// Snapshot deleted_at of the feature and its tasks for 'shark undo'
+		journal := newUndoJournal(repoDb)
+		journal.captureColumns(ctx, "features", []int64{feature.ID}, "deleted_at")
+		taskIDs := make([]int64, 0, len(tasks))
+		for _, task := range tasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+		journal.captureColumns(ctx, "tasks", taskIDs, "deleted_at")
+
+		trashed, err := repository.NewTrashRepository(repoDb).TrashFeature(ctx, feature.ID)
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to move feature to the trash: %v", err))
+		}
+		summary := fmt.Sprintf("Moved feature %s with %d task(s) to the trash", feature.Key, trashed)
+		journal.record(ctx, "feature delete", feature.Key, summary)
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityFeature, EntityKey: feature.Key, Action: models.AuditActionDelete, Summary: summary})
+
+		cli.Success(fmt.Sprintf("Feature %s and %d task(s) moved to the trash", feature.Key, trashed))
+		cli.Info(fmt.Sprintf("Restore them with 'shark trash restore %s'", feature.Key))
+		return nil
 	}
 
 	// If there are tasks, require --force flag
 	if len(tasks) > 0 && !force {
-		cli.Error(fmt.Sprintf("Error: Feature %s has %d task(s)", featureKey, len(tasks)))
 		cli.Warning("This will CASCADE DELETE all tasks and their history")
-		cli.Info(fmt.Sprintf("Use --force to confirm deletion: shark feature delete %s --force", featureKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Error: Feature %s has %d task(s)", featureKey, len(tasks)),
+			fmt.Sprintf("Use --force to confirm deletion: shark feature delete %s --permanent --force", featureKey))
 	}
 
 	// Create backup before cascade delete (when feature has tasks)
 	if len(tasks) > 0 {
+		if err := verifyConfirmationToken(cmd, op); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+		}
+
 		dbPath, canBackup, err := cli.GetDatabasePathForBackup()
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: failed to get database path for backup: %v", err))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: failed to get database path for backup: %v", err))
 		}
 		if canBackup {
-			backupPath, err := db.BackupDatabase(dbPath)
+			backupPath, err := createDatabaseBackup(dbPath, fmt.Sprintf("cascade delete feature %s", feature.Key))
 			if err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to create backup before deletion: %v", err))
-				cli.Info("Aborting deletion to prevent data loss")
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Error: Failed to create backup before deletion: %v", err), "Aborting deletion to prevent data loss")
 			}
 			if !cli.GlobalConfig.JSON {
 				cli.Info(fmt.Sprintf("Database backup created: %s", backupPath))
@@ -1907,11 +2155,17 @@ func runFeatureDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Snapshot the feature and its cascade for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	journal.captureDelete(ctx, "features", feature.ID)
+
 	// Delete feature from database (CASCADE will handle tasks)
 	if err := featureRepo.Delete(ctx, feature.ID); err != nil {
-		cli.Error(fmt.Sprintf("Error: Failed to delete feature: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to delete feature: %v", err))
 	}
+	summary := fmt.Sprintf("Deleted feature %s with %d task(s)", feature.Key, len(tasks))
+	journal.record(ctx, "feature delete", feature.Key, summary)
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityFeature, EntityKey: feature.Key, Action: models.AuditActionDelete, Summary: summary})
 
 	cli.Success(fmt.Sprintf("Feature %s deleted successfully", featureKey))
 	if len(tasks) > 0 {
@@ -1922,7 +2176,7 @@ func runFeatureDelete(cmd *cobra.Command, args []string) error {
 
 // runFeatureUpdate executes the feature update command
 func runFeatureUpdate(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	featureKey := args[0]
@@ -1930,11 +2184,8 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 	// Get database connection (cloud-aware)
 	repoDb, err := cli.GetDB(ctx)
 	if err != nil {
-		cli.Error("Error: Database error. Run with --verbose for details.")
-		if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Database error: %v\n", err)
-		}
-		os.Exit(2)
+		slog.Error("Database error", "error", err)
+		cli.Fail(cli.ErrCodeDatabase, "Error: Database error. Run with --verbose for details.")
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
@@ -1944,10 +2195,9 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 	// Get feature by key to verify it exists
 	feature, err := featureRepo.GetByKey(ctx, featureKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: Feature %s does not exist", featureKey))
-		cli.Info("Use 'shark feature list' to see available features")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: Feature %s does not exist", featureKey), "Use 'shark feature list' to see available features")
 	}
+	auditBefore := auditFields(feature)
 
 	// Track if any changes were made
 	changed := false
@@ -1981,26 +2231,24 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 		if strings.ToLower(statusFlag) == "auto" {
 			// Clear status override and recalculate status
 			if err := featureRepo.SetStatusOverride(ctx, feature.ID, false); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to clear status override: %v", err))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to clear status override: %v", err))
 			}
 
 			// Load workflow config
 			configPath, err := cli.GetConfigPath()
-			if err != nil && cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to get config path: %v\n", err)
+			if err != nil {
+				slog.Warn("Failed to get config path", "error", err)
 			}
 			cfg, err := config.LoadWorkflowConfig(configPath)
-			if err != nil && cli.GlobalConfig.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", err)
+			if err != nil {
+				slog.Warn("Failed to load config", "error", err)
 			}
 
 			// Recalculate status from tasks
 			calcService := status.NewCalculationService(repoDb, cfg)
 			result, err := calcService.RecalculateFeatureStatus(ctx, feature.ID)
 			if err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to recalculate status: %v", err))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to recalculate status: %v", err))
 			}
 
 			cli.Success(fmt.Sprintf("Feature %s status recalculated: %s (calculated from tasks)", feature.Key, result.NewStatus))
@@ -2010,14 +2258,12 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 		// Regular status update - set override and apply status
 		validatedStatus, err := ParseFeatureStatus(statusFlag)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 
 		// Set override to true for manual status
 		if err := featureRepo.SetStatusOverride(ctx, feature.ID, true); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to set status override: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to set status override: %v", err))
 		}
 
 		feature.Status = models.FeatureStatus(validatedStatus)
@@ -2026,8 +2272,7 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 		// Cascade status to child tasks if --force is used and status is completed
 		if force && feature.Status == models.FeatureStatusCompleted {
 			if err := featureRepo.CascadeStatusToTasks(ctx, feature.ID, models.TaskStatusCompleted); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to cascade status to tasks: %v", err))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to cascade status to tasks: %v", err))
 			}
 		}
 	}
@@ -2039,60 +2284,92 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 		changed = true
 	}
 
+	// Handle filename update separately
+	// Try all three flag aliases: --file, --filename, --path (last one wins)
+	file, _ := cmd.Flags().GetString("file")
+	filename, _ := cmd.Flags().GetString("filename")
+	path, _ := cmd.Flags().GetString("path")
+
+	// Determine which flag was provided (priority: path > filename > file)
+	var customFile string
+	if path != "" {
+		customFile = path
+	} else if filename != "" {
+		customFile = filename
+	} else if file != "" {
+		customFile = file
+	}
+
+	// Validate the path and resolve collisions before changing anything
+	// (--force reassigns a file claimed by another entity, with a backup)
+	var relPath string
+	if customFile != "" {
+		relPath, err = AssignFileForUpdate(ctx, repoDb, customFile, force, func(c *FileCollision) bool {
+			return c.Feature != nil && c.Feature.ID == feature.ID
+		})
+		if err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+		}
+	}
+
+	// Validate labels before changing anything
+	addLabels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+	}
+	removeLabels, err := labelsFromFlag(cmd, "remove-label")
+	if err != nil {
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+	}
+
 	// Apply core field updates if any changed
 	if changed {
 		if err := featureRepo.Update(ctx, feature); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update feature: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update feature: %v", err))
 		}
 	}
 
 	// Handle key update separately (requires unique validation)
+	// Use the canonical key: the argument may be a numeric or slugged form
+	currentKey := feature.Key
 	newKey, _ := cmd.Flags().GetString("key")
 	if newKey != "" {
 		// Validate new key using shared validator: no spaces allowed
 		if err := ValidateNoSpaces(newKey, "feature"); err != nil {
-			cli.Error(fmt.Sprintf("Error: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 		}
 
 		// Check if new key already exists (and is different from current key)
-		if newKey != featureKey {
+		if newKey != currentKey {
 			existing, err := featureRepo.GetByKey(ctx, newKey)
 			if err == nil && existing != nil {
-				cli.Error(fmt.Sprintf("Error: Feature with key '%s' already exists", newKey))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Feature with key '%s' already exists", newKey))
 			}
 
 			// Update the key
-			if err := featureRepo.UpdateKey(ctx, featureKey, newKey); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to update feature key: %v", err))
-				os.Exit(1)
+			if err := featureRepo.UpdateKey(ctx, currentKey, newKey); err != nil {
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update feature key: %v", err))
 			}
+			currentKey = newKey
 			changed = true
 		}
 	}
 
-	// Handle filename update separately
-	// Try all three flag aliases: --file, --filename, --path (last one wins)
-	file, _ := cmd.Flags().GetString("file")
-	filename, _ := cmd.Flags().GetString("filename")
-	path, _ := cmd.Flags().GetString("path")
-
-	// Determine which flag was provided (priority: path > filename > file)
-	var customFile string
-	if path != "" {
-		customFile = path
-	} else if filename != "" {
-		customFile = filename
-	} else if file != "" {
-		customFile = file
+	if relPath != "" {
+		if err := featureRepo.UpdateFilePath(ctx, currentKey, &relPath); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update feature file path: %v", err))
+		}
+		changed = true
 	}
 
-	if customFile != "" {
-		if err := featureRepo.UpdateFilePath(ctx, featureKey, &customFile); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update feature file path: %v", err))
-			os.Exit(1)
+	// Update labels if provided
+	if len(addLabels) > 0 || len(removeLabels) > 0 {
+		labelRepo := repository.NewLabelRepository(repoDb)
+		if err := labelRepo.RemoveFeatureLabels(ctx, feature.ID, removeLabels); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to remove labels: %v", err))
+		}
+		if err := labelRepo.AddFeatureLabels(ctx, feature.ID, addLabels); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to add labels: %v", err))
 		}
 		changed = true
 	}
@@ -2102,6 +2379,10 @@ func runFeatureUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	cli.Success(fmt.Sprintf("Feature %s updated successfully", featureKey))
+	if updated, err := featureRepo.GetByID(ctx, feature.ID); err == nil {
+		recordAuditUpdate(ctx, repoDb, models.AuditEntityFeature, currentKey, auditBefore, updated)
+	}
+
+	cli.Success(fmt.Sprintf("Feature %s updated successfully", currentKey))
 	return nil
 }
@@ -11,8 +11,9 @@ import (
 
 // featureCriteriaCmd shows aggregated criteria for a feature
 var featureCriteriaCmd = &cobra.Command{
-	Use:   "criteria <feature-key>",
-	Short: "Show aggregated acceptance criteria for a feature",
+	Use:         "criteria <feature-key>",
+	Short:       "Show aggregated acceptance criteria for a feature",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Show aggregated acceptance criteria across all tasks in a feature.
 
 Displays:
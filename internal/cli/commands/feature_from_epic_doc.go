@@ -0,0 +1,280 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/fileops"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// epicDocFeatureResult describes one feature planned in an epic document
+type epicDocFeatureResult struct {
+	Key         string           `json:"key,omitempty"`
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	FilePath    string           `json:"file_path,omitempty"`
+	Reason      string           `json:"reason,omitempty"`
+	Scaffold    *FeatureScaffold `json:"scaffold,omitempty"`
+}
+
+// runFeatureCreateFromEpicDoc creates a feature for each entry in the
+// "## Features" section of an epic's document. Features whose title already
+// exists in the epic are skipped, so the command can be re-run after the
+// document is extended.
+func runFeatureCreateFromEpicDoc(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	epicKey := featureCreateEpic
+	switch len(args) {
+	case 0:
+	case 1:
+		epicKey = args[0]
+	default:
+		return fmt.Errorf("--from-epic-doc takes only an epic key (titles come from the epic document)")
+	}
+	if epicKey == "" {
+		return fmt.Errorf("an epic key is required: shark feature create E01 --from-epic-doc")
+	}
+	if !isValidEpicKey(epicKey) {
+		return fmt.Errorf("invalid epic key format %q. Must be E## (e.g., E01, E02)", epicKey)
+	}
+	if featureCreateKey != "" || cmd.Flags().Changed("file") || cmd.Flags().Changed("filename") || cmd.Flags().Changed("path") || cmd.Flags().Changed("from-file") {
+		return fmt.Errorf("--key, --file, and --from-file cannot be used with --from-epic-doc")
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	statusStr, _ := cmd.Flags().GetString("status")
+	if statusStr == "" {
+		statusStr = "draft"
+	}
+	statusStr, err := ParseFeatureStatus(statusStr)
+	if err != nil {
+		return err
+	}
+
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+
+	scaffoldEnabled, scaffoldDocs, err := featureScaffoldOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+
+	epic, err := epicRepo.GetByKey(ctx, epicKey)
+	if err != nil {
+		return fmt.Errorf("epic %s not found", epicKey)
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	pathResolver := pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot)
+	epicPath, err := pathResolver.ResolveEpicPath(ctx, epic.Key)
+	if err != nil {
+		return fmt.Errorf("failed to resolve epic document: %w", err)
+	}
+	epicContent, err := os.ReadFile(epicPath)
+	if err != nil {
+		return fmt.Errorf("failed to read epic document: %w", err)
+	}
+
+	stubs := parser.ParseEpicFeatures(string(epicContent))
+	if len(stubs) == 0 {
+		return fmt.Errorf("no features found under a \"## Features\" heading in %s", relativeToRoot(projectRoot, epicPath))
+	}
+
+	existing, err := featureRepo.ListByEpic(ctx, epic.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list features for epic %s: %w", epic.Key, err)
+	}
+	existingByTitle := make(map[string]string, len(existing))
+	for _, feature := range existing {
+		existingByTitle[strings.ToLower(feature.Title)] = feature.Key
+	}
+
+	created := make([]epicDocFeatureResult, 0)
+	skipped := make([]epicDocFeatureResult, 0)
+	epicDir := filepath.Dir(epicPath)
+
+	for _, stub := range stubs {
+		result := epicDocFeatureResult{Title: stub.Title, Description: stub.Description}
+
+		titleKey := strings.ToLower(stub.Title)
+		if key, ok := existingByTitle[titleKey]; ok {
+			result.Key = key
+			result.Reason = fmt.Sprintf("already exists as %s", key)
+			skipped = append(skipped, result)
+			continue
+		}
+		existingByTitle[titleKey] = ""
+
+		if dryRun {
+			created = append(created, result)
+			continue
+		}
+
+		feature, filePath, err := createFeatureFromStub(ctx, featureRepo, epic, epicDir, projectRoot, stub, models.FeatureStatus(statusStr))
+		if err != nil {
+			return fmt.Errorf("failed to create feature %q: %w", stub.Title, err)
+		}
+		existingByTitle[titleKey] = feature.Key
+
+		if len(labels) > 0 {
+			if err := repository.NewLabelRepository(repoDb).AddFeatureLabels(ctx, feature.ID, labels); err != nil {
+				return fmt.Errorf("feature %s created but labels could not be added: %w", feature.Key, err)
+			}
+		}
+
+		if scaffoldEnabled {
+			data := featureTemplateDataFor(epic.Key, feature, filepath.Base(filepath.Dir(filePath)))
+			result.Scaffold, err = scaffoldFeature(ctx, repoDb, projectRoot, feature, data, scaffoldDocs)
+			if err != nil {
+				return fmt.Errorf("feature %s created but could not be scaffolded: %w", feature.Key, err)
+			}
+		}
+
+		result.Key = feature.Key
+		result.FilePath = filePath
+		created = append(created, result)
+	}
+
+	source := relativeToRoot(projectRoot, epicPath)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"epic":    epic.Key,
+			"source":  source,
+			"dry_run": dryRun,
+			"created": created,
+			"skipped": skipped,
+		})
+	}
+
+	if len(created) > 0 {
+		rows := make([][]string, 0, len(created))
+		for _, result := range created {
+			key := result.Key
+			if key == "" {
+				key = "(new)"
+			}
+			rows = append(rows, []string{key, result.Title, result.FilePath})
+		}
+		cli.OutputTable([]string{"Key", "Title", "File"}, rows)
+	}
+	for _, result := range skipped {
+		cli.Info("Skipped %q: %s", result.Title, result.Reason)
+	}
+
+	switch {
+	case dryRun:
+		cli.Info("Dry run: %d feature(s) would be created in %s from %s", len(created), epic.Key, source)
+	case len(created) == 0:
+		cli.Info("All features in %s already exist in %s", source, epic.Key)
+	default:
+		cli.Success(fmt.Sprintf("Created %d feature(s) in %s from %s", len(created), epic.Key, source))
+	}
+	return nil
+}
+
+// createFeatureFromStub creates the directory, feature.md, and database entry
+// for a feature parsed from an epic document. Returns the feature and its file
+// path relative to the project root.
+func createFeatureFromStub(ctx context.Context, featureRepo *repository.FeatureRepository, epic *models.Epic, epicDir, projectRoot string, stub parser.EpicFeatureStub, status models.FeatureStatus) (*models.Feature, string, error) {
+	featureKey, err := featureRepo.NextKey(ctx, epic.ID, epic.Key)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate feature key: %w", err)
+	}
+	featureSlug := fmt.Sprintf("%s-%s", featureKey, utils.GenerateSlug(stub.Title))
+
+	featureDir := filepath.Join(epicDir, featureSlug)
+	if _, err := os.Stat(featureDir); err == nil {
+		return nil, "", fmt.Errorf("feature directory already exists: %s", featureDir)
+	}
+	featureFilePath := filepath.Join(featureDir, "feature.md")
+	relPath := relativeToRoot(projectRoot, featureFilePath)
+
+	content, err := renderFeatureTemplate(FeatureTemplateData{
+		EpicKey:     epic.Key,
+		FeatureKey:  featureKey,
+		FeatureSlug: featureSlug,
+		Title:       stub.Title,
+		Description: stub.Description,
+		FilePath:    featureFilePath,
+		Date:        time.Now().Format("2006-01-02"),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	writer := fileops.NewEntityFileWriter()
+	writeResult, err := writer.WriteEntityFile(fileops.WriteOptions{
+		Content:        content,
+		ProjectRoot:    projectRoot,
+		FilePath:       featureFilePath,
+		Verbose:        cli.GlobalConfig.Verbose,
+		EntityType:     "feature",
+		UseAtomicWrite: true,
+		Logger: func(message string) {
+			cli.Info(message)
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	feature := &models.Feature{
+		EpicID:      epic.ID,
+		Key:         featureKey,
+		Title:       stub.Title,
+		Status:      status,
+		ProgressPct: 0.0,
+		FilePath:    &relPath,
+	}
+	if stub.Description != "" {
+		feature.Description = &stub.Description
+	}
+
+	if err := featureRepo.Create(ctx, feature); err != nil {
+		// Rollback: delete the created file, unless it was an existing file we linked to
+		if writeResult.Written {
+			os.Remove(writeResult.AbsolutePath)
+		}
+		return nil, "", fmt.Errorf("failed to create feature in database: %w", err)
+	}
+	return feature, relPath, nil
+}
+
+// relativeToRoot returns path relative to the project root, falling back to
+// the path itself when it is outside the root
+func relativeToRoot(projectRoot, path string) string {
+	rel, err := filepath.Rel(projectRoot, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+)
+
+// parseFeatureFromFileArgs parses the arguments of feature create --from-file:
+// an epic key (positional or --epic) and an optional title that replaces the
+// one from the file's first heading.
+func parseFeatureFromFileArgs(args []string, epicFlag string) (epicKey, title string, err error) {
+	epicKey = epicFlag
+	switch len(args) {
+	case 0:
+	case 1:
+		if epicFlag != "" {
+			title = args[0]
+		} else {
+			epicKey = NormalizeKey(args[0])
+			if !IsEpicKey(epicKey) {
+				return "", "", InvalidEpicKeyError(args[0])
+			}
+		}
+	default:
+		positionalEpic, positionalTitle, err := ParseFeatureCreateArgs(args)
+		if err != nil {
+			return "", "", err
+		}
+		epicKey, title = *positionalEpic, *positionalTitle
+	}
+	if epicKey == "" {
+		return "", "", fmt.Errorf("an epic key is required: shark feature create E01 --from-file=docs/specs/auth.md")
+	}
+	return epicKey, title, nil
+}
+
+// readFeatureSourceFile reads the title (the first heading) and description
+// (the first paragraph) of an existing feature spec
+func readFeatureSourceFile(absPath, relPath string) (title, description string, err error) {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", "", fmt.Errorf("file '%s' does not exist; use --file to create a new feature file", relPath)
+		}
+		return "", "", fmt.Errorf("failed to read '%s': %w", relPath, err)
+	}
+	content := string(data)
+	return strings.TrimSpace(parser.ExtractTitleFromMarkdown(content)), parser.ExtractDescriptionFromMarkdown(content), nil
+}
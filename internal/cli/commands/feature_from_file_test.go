@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFeatureFromFileArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		epicFlag  string
+		wantEpic  string
+		wantTitle string
+		wantErr   bool
+	}{
+		{name: "positional epic", args: []string{"e05"}, wantEpic: "E05"},
+		{name: "positional epic and title", args: []string{"E05", "Auth"}, wantEpic: "E05", wantTitle: "Auth"},
+		{name: "epic flag", epicFlag: "E05", wantEpic: "E05"},
+		{name: "epic flag and title", args: []string{"Auth"}, epicFlag: "E05", wantEpic: "E05", wantTitle: "Auth"},
+		{name: "no epic", wantErr: true},
+		{name: "title without epic", args: []string{"Auth"}, wantErr: true},
+		{name: "too many", args: []string{"E05", "Auth", "extra"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epic, title, err := parseFeatureFromFileArgs(tt.args, tt.epicFlag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if epic != tt.wantEpic || title != tt.wantTitle {
+				t.Errorf("Expected %q %q, got %q %q", tt.wantEpic, tt.wantTitle, epic, title)
+			}
+		})
+	}
+}
+
+func TestReadFeatureSourceFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.md")
+	content := "---\nstatus: draft\n---\n\n# Auth Overhaul\n\nReplace session cookies with tokens.\n\n## Details\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	title, description, err := readFeatureSourceFile(path, "docs/specs/auth.md")
+	if err != nil {
+		t.Fatalf("readFeatureSourceFile failed: %v", err)
+	}
+	if title != "Auth Overhaul" {
+		t.Errorf("Expected title from the first heading, got %q", title)
+	}
+	if description != "Replace session cookies with tokens." {
+		t.Errorf("Expected description from the first paragraph, got %q", description)
+	}
+
+	if _, _, err := readFeatureSourceFile(filepath.Join(dir, "missing.md"), "docs/specs/missing.md"); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
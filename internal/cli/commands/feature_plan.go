@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// featurePlanCmd proposes and creates tasks for a feature from a markdown spec
+var featurePlanCmd = &cobra.Command{
+	Use:   "plan <feature-key>",
+	Short: "Propose tasks for a feature from a markdown spec",
+	Long: `Break a markdown spec (a PRD or design doc) into tasks, preview them, and
+create them on confirmation.
+
+Each heading that describes work becomes a task, with the text below it as the
+description. A heading with two or more work sub-headings ("## Requirements")
+is a grouping and its sub-headings become tasks. Sections such as Overview,
+Goals, and Open Questions are skipped.
+
+Acceptance criteria come from "Acceptance Criteria" sub-headings or labels and
+from checkbox items, and are added to the task ('shark task criteria'). A
+"Depends on:" line names earlier tasks by title or number (#2), or existing
+tasks by key. The agent type is guessed from keywords (frontend, backend,
+testing, devops); --agent is used when nothing matches.
+
+A spec with no work headings but an acceptance criteria list yields one task
+per criterion.
+
+Tasks whose title already exists in the feature are skipped, so the command can
+be re-run after the spec is extended. With --json, tasks are only created with
+--yes.
+
+Examples:
+  shark feature plan E07-F01 --from=docs/prd/login.md
+  shark feature plan E07-F01 --from=prd.md --dry-run
+  shark feature plan E07-F01 --from=prd.md --agent=backend --chain --yes
+  shark feature plan E07-F01 --from=prd.md --yes --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFeaturePlan,
+}
+
+func init() {
+	featureCmd.AddCommand(featurePlanCmd)
+
+	featurePlanCmd.Flags().String("from", "", "Markdown spec to plan from (required)")
+	featurePlanCmd.Flags().String("agent", "general", "Agent type for tasks whose type can't be inferred")
+	featurePlanCmd.Flags().Int("priority", 5, "Priority of the created tasks (1-10; default 5, or the task.default_priority setting)")
+	featurePlanCmd.Flags().Bool("chain", false, "Make each task depend on the one before it")
+	featurePlanCmd.Flags().Bool("dry-run", false, "Preview the proposed tasks without creating them")
+	featurePlanCmd.Flags().BoolP("yes", "y", false, "Create the tasks without asking for confirmation")
+	_ = featurePlanCmd.MarkFlagRequired("from")
+}
+
+// plannedTask is a task proposed by shark feature plan
+type plannedTask struct {
+	Number      int      `json:"number"`
+	Key         string   `json:"key,omitempty"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	AgentType   string   `json:"agent_type"`
+	DependsOn   []string `json:"depends_on"` // Task keys, or "#n" for proposed tasks not yet created
+	Criteria    []string `json:"criteria"`
+	Skipped     string   `json:"skipped,omitempty"` // Why the task wasn't created
+}
+
+// runFeaturePlan handles the feature plan command
+func runFeaturePlan(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(60 * time.Second)
+	defer cancel()
+
+	from, _ := cmd.Flags().GetString("from")
+	defaultAgent, _ := cmd.Flags().GetString("agent")
+	priority, _ := cmd.Flags().GetInt("priority")
+	chain, _ := cmd.Flags().GetBool("chain")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	content, err := os.ReadFile(from)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	stubs := parser.ParseTaskPlan(string(content))
+	if len(stubs) == 0 {
+		return fmt.Errorf("no tasks found in %s: add a heading per task or an \"Acceptance Criteria\" list", from)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	if !cmd.Flags().Changed("priority") {
+		priority = defaultTaskPriority(ctx, repoDb, priority)
+	}
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	taskRepo := repository.NewTaskRepository(repoDb)
+
+	featureKey := NormalizeKey(args[0])
+	feature, err := featureRepo.GetByKey(ctx, featureKey)
+	if err != nil {
+		return fmt.Errorf("feature %s not found", featureKey)
+	}
+	epic, err := epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return fmt.Errorf("failed to get epic: %w", err)
+	}
+
+	existing, err := taskRepo.ListByFeature(ctx, feature.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks for feature %s: %w", feature.Key, err)
+	}
+	existingByTitle := make(map[string]string, len(existing))
+	for _, task := range existing {
+		existingByTitle[strings.ToLower(task.Title)] = task.Key
+	}
+
+	plan := buildFeaturePlan(stubs, defaultAgent, existingByTitle)
+
+	create := yes
+	if !dryRun && !yes && !cli.GlobalConfig.JSON {
+		printFeaturePlan(plan)
+		toCreate := countPlannedTasks(plan)
+		if toCreate == 0 {
+			cli.Info("All tasks in %s already exist in %s", from, feature.Key)
+			return nil
+		}
+		var response string
+		fmt.Printf("\nCreate %d task(s) in %s? (yes/no): ", toCreate, feature.Key)
+		_, _ = fmt.Scanln(&response)
+		if !strings.EqualFold(response, "yes") && !strings.EqualFold(response, "y") {
+			fmt.Println("Plan cancelled")
+			return nil
+		}
+		create = true
+	}
+	if dryRun {
+		create = false
+	}
+
+	if create {
+		projectRoot, err := cli.FindProjectRoot()
+		if err != nil {
+			return fmt.Errorf("failed to find project root: %w", err)
+		}
+		keygen := taskcreation.NewKeyGenerator(taskRepo, featureRepo)
+		validator := taskcreation.NewValidator(epicRepo, featureRepo, taskRepo)
+		loader := templates.NewLoader("")
+		registry := templates.NewRegistry(filepath.Join(projectRoot, templates.DefaultProjectTemplateDir))
+		renderer := templates.NewRendererWithRegistry(loader, registry)
+		creator := taskcreation.NewCreator(repoDb, keygen, validator, renderer, taskRepo, repository.NewTaskHistoryRepository(repoDb), epicRepo, featureRepo, projectRoot, nil)
+
+		if err := createFeaturePlan(ctx, repoDb, creator, epic, feature, plan, priority, chain); err != nil {
+			return err
+		}
+		triggerStatusCascade(ctx, repoDb, feature.ID)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"feature": feature.Key,
+			"source":  from,
+			"dry_run": !create,
+			"tasks":   plan,
+		})
+	}
+
+	if !create {
+		printFeaturePlan(plan)
+		cli.Info("Dry run: %d task(s) would be created in %s from %s", countPlannedTasks(plan), feature.Key, from)
+		return nil
+	}
+
+	printFeaturePlan(plan)
+	cli.Success(fmt.Sprintf("Created %d task(s) in %s from %s", countPlannedTasks(plan), feature.Key, from))
+	return nil
+}
+
+// buildFeaturePlan turns parsed stubs into planned tasks. Tasks whose title
+// already exists in the feature are marked skipped with the existing key, and
+// dependencies on them point at that key.
+func buildFeaturePlan(stubs []parser.TaskPlanStub, defaultAgent string, existingByTitle map[string]string) []*plannedTask {
+	plan := make([]*plannedTask, len(stubs))
+	for i, stub := range stubs {
+		task := &plannedTask{
+			Number:      i + 1,
+			Title:       stub.Title,
+			Description: stub.Description,
+			AgentType:   stub.AgentType,
+			DependsOn:   []string{},
+			Criteria:    stub.Criteria,
+		}
+		if task.AgentType == "" {
+			task.AgentType = defaultAgent
+		}
+		if task.Criteria == nil {
+			task.Criteria = []string{}
+		}
+		if key, ok := existingByTitle[strings.ToLower(stub.Title)]; ok {
+			task.Key = key
+			task.Skipped = fmt.Sprintf("already exists as %s", key)
+		}
+		plan[i] = task
+	}
+
+	for i, stub := range stubs {
+		for _, index := range stub.DependsOn {
+			plan[i].DependsOn = append(plan[i].DependsOn, plannedTaskRef(plan[index]))
+		}
+		plan[i].DependsOn = append(plan[i].DependsOn, stub.DependsKeys...)
+	}
+	return plan
+}
+
+// plannedTaskRef is a task's key, or "#n" if it hasn't been created yet
+func plannedTaskRef(task *plannedTask) string {
+	if task.Key != "" {
+		return task.Key
+	}
+	return "#" + strconv.Itoa(task.Number)
+}
+
+// createFeaturePlan creates the planned tasks in order, resolving references
+// to earlier planned tasks to their new keys, and adds their criteria
+func createFeaturePlan(ctx context.Context, repoDb *repository.DB, creator *taskcreation.Creator, epic *models.Epic, feature *models.Feature, plan []*plannedTask, priority int, chain bool) error {
+	criteriaRepo := repository.NewTaskCriteriaRepository(repoDb)
+	keyByRef := make(map[string]string, len(plan))
+
+	for _, task := range plan {
+		if task.Skipped != "" {
+			continue
+		}
+
+		dependsOn := make([]string, 0, len(task.DependsOn))
+		for _, ref := range task.DependsOn {
+			if key, ok := keyByRef[ref]; ok {
+				ref = key
+			}
+			dependsOn = append(dependsOn, ref)
+		}
+
+		result, err := creator.CreateTask(ctx, taskcreation.CreateTaskInput{
+			EpicKey:     epic.Key,
+			FeatureKey:  feature.Key,
+			Title:       task.Title,
+			Description: task.Description,
+			AgentType:   task.AgentType,
+			Priority:    priority,
+			DependsOn:   strings.Join(dependsOn, ","),
+			Chain:       chain,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create task %d (%q): %w", task.Number, task.Title, err)
+		}
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityTask, EntityKey: result.Task.Key, Action: models.AuditActionCreate, Summary: result.Task.Title})
+
+		keyByRef["#"+strconv.Itoa(task.Number)] = result.Task.Key
+		task.Key = result.Task.Key
+		task.DependsOn = dependsOn
+
+		for _, criterion := range task.Criteria {
+			if err := criteriaRepo.Create(ctx, &models.TaskCriteria{TaskID: result.Task.ID, Criterion: criterion, Status: models.CriteriaStatusPending}); err != nil {
+				return fmt.Errorf("task %s created but criteria could not be added: %w", result.Task.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// printFeaturePlan prints planned tasks as a table
+func printFeaturePlan(plan []*plannedTask) {
+	rows := make([][]string, len(plan))
+	for i, task := range plan {
+		key := task.Key
+		if key == "" {
+			key = "(new)"
+		}
+		dependsOn := "-"
+		if len(task.DependsOn) > 0 {
+			dependsOn = strings.Join(task.DependsOn, ", ")
+		}
+		status := "create"
+		if task.Skipped != "" {
+			status = "skip: " + task.Skipped
+		}
+		rows[i] = []string{strconv.Itoa(task.Number), key, truncateCell(task.Title, 45), task.AgentType, dependsOn, strconv.Itoa(len(task.Criteria)), status}
+	}
+	cli.OutputTable([]string{"#", "Key", "Title", "Agent", "Depends On", "Criteria", "Action"}, rows)
+}
+
+// countPlannedTasks counts the tasks a plan would create
+func countPlannedTasks(plan []*plannedTask) int {
+	count := 0
+	for _, task := range plan {
+		if task.Skipped == "" {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFeaturePlan(t *testing.T) {
+	stubs := []parser.TaskPlanStub{
+		{Title: "Users table", AgentType: "backend"},
+		{Title: "Login API", AgentType: "backend", DependsOn: []int{0}, Criteria: []string{"Returns 401"}},
+		{Title: "Write docs", DependsOn: []int{1}, DependsKeys: []string{"T-E01-F01-009"}},
+	}
+	existing := map[string]string{"users table": "T-E01-F01-001"}
+
+	plan := buildFeaturePlan(stubs, "general", existing)
+	require.Len(t, plan, 3)
+
+	assert.Equal(t, "T-E01-F01-001", plan[0].Key)
+	assert.Equal(t, "already exists as T-E01-F01-001", plan[0].Skipped)
+	assert.Equal(t, []string{}, plan[0].Criteria)
+
+	assert.Equal(t, []string{"T-E01-F01-001"}, plan[1].DependsOn, "dependencies on existing tasks use their key")
+	assert.Equal(t, []string{"Returns 401"}, plan[1].Criteria)
+	assert.Empty(t, plan[1].Skipped)
+
+	assert.Equal(t, "general", plan[2].AgentType, "uninferred agent types use the default")
+	assert.Equal(t, []string{"#2", "T-E01-F01-009"}, plan[2].DependsOn)
+
+	assert.Equal(t, 2, countPlannedTasks(plan))
+}
@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/fileops"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// featureRenameCmd gives a feature a new title and renames its files to match
+var featureRenameCmd = &cobra.Command{
+	Use:   "rename <feature-key> <new-title>",
+	Short: "Change a feature's title and rename its directory to match",
+	Long: `Change a feature's title and regenerate its slug. The feature's directory
+(E05-F01-old-title) is renamed for the new slug (E05-F01-new-title), or its file
+if it has no directory of its own, and the stored file paths of the feature, its
+tasks, and linked documents follow. The title and feature_key in the feature
+file's frontmatter are updated.
+
+Keys don't change. Directories and files that aren't named for the feature key,
+such as a custom --file path, stay where they are. The database changes are made
+in a single transaction, and renamed files are put back if it fails.
+
+Run it with the current title to rename files left stale by
+'shark feature update --title'.
+
+Examples:
+  shark feature rename E05-F01 "Single sign-on"
+  shark feature rename E05-F01 "Single sign-on" --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFeatureRename,
+}
+
+func init() {
+	featureCmd.AddCommand(featureRenameCmd)
+}
+
+// runFeatureRename handles the feature rename command
+func runFeatureRename(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(time.Minute)
+	defer cancel()
+
+	featureKey := NormalizeKey(args[0])
+	title := strings.TrimSpace(args[1])
+	if title == "" {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "feature title cannot be empty")
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	epicRepo := repository.NewEpicRepository(repoDb)
+
+	feature, err := featureRepo.GetByKey(ctx, featureKey)
+	if err != nil {
+		return fmt.Errorf("feature %s not found: %w", featureKey, err)
+	}
+
+	from, to, err := featureRenamePaths(projectRoot, feature, title, func() (string, error) {
+		return pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot).ResolveFeaturePath(ctx, feature.Key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve feature directory: %w", err)
+	}
+	if title == feature.Title && from == to {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"key": feature.Key, "title": title, "changed": false})
+		}
+		cli.Info(fmt.Sprintf("Feature %s is already named %q", feature.Key, title))
+		return nil
+	}
+
+	renumberRepo := repository.NewRenumberRepository(repoDb)
+	plan, err := renumberRepo.PlanFeatureRename(ctx, feature, title, from, to)
+	if err != nil {
+		return err
+	}
+
+	writer := fileops.NewEntityFileWriter()
+	renamed := false
+	if from != to {
+		renamed, err = writer.RenameEntityPath(projectRoot, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to rename files (nothing changed): %w", err)
+		}
+	}
+
+	if err := renumberRepo.Apply(ctx, plan); err != nil {
+		if renamed {
+			if _, undoErr := writer.RenameEntityPath(projectRoot, to, from); undoErr != nil {
+				cli.Warning(fmt.Sprintf("Failed to restore %s: %v", from, undoErr))
+			}
+		}
+		return fmt.Errorf("failed to rename %s (files restored): %w", feature.Key, err)
+	}
+
+	change := plan.Changes[0]
+	var frontmatterErrors []string
+	if change.NewFilePath != nil && strings.HasSuffix(*change.NewFilePath, ".md") {
+		err := rewriteFeatureFrontmatter(resolveProjectPath(projectRoot, *change.NewFilePath), title, featureSlugKey(from), featureSlugKey(to))
+		if err != nil {
+			frontmatterErrors = append(frontmatterErrors, fmt.Sprintf("%s: %v", *change.NewFilePath, err))
+		}
+	}
+
+	changes := map[string]models.AuditChange{"title": {Old: feature.Title, New: title}}
+	if change.OldFilePath != nil && *change.NewFilePath != *change.OldFilePath {
+		changes["file_path"] = models.AuditChange{Old: *change.OldFilePath, New: *change.NewFilePath}
+	}
+	recordAudit(ctx, repoDb, &models.AuditEntry{
+		EntityType: models.AuditEntityFeature,
+		EntityKey:  feature.Key,
+		Action:     models.AuditActionUpdate,
+		Summary:    fmt.Sprintf("Renamed from %q", feature.Title),
+		Changes:    changes,
+	})
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"key":                feature.Key,
+			"old_title":          feature.Title,
+			"title":              title,
+			"changed":            true,
+			"moved":              plan.PathMoves(),
+			"changes":            plan.Changes,
+			"frontmatter_errors": frontmatterErrors,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Renamed feature %s to %q", feature.Key, title))
+	if renamed {
+		cli.Info(fmt.Sprintf("Moved %s to %s (%d task file path(s) updated)", from, to, len(plan.Changes)-1))
+	}
+	for _, message := range frontmatterErrors {
+		cli.Warning(fmt.Sprintf("Failed to update frontmatter in %s", message))
+	}
+	return nil
+}
+
+// featureRenamePaths returns the feature's directory, or its file if it has no
+// directory of its own, and where it goes for the new title. Both are "" when
+// neither is named for the feature key.
+func featureRenamePaths(projectRoot string, feature *models.Feature, title string, resolve func() (string, error)) (from, to string, err error) {
+	name := feature.Key
+	if slug := utils.GenerateSlug(title); slug != "" {
+		name += "-" + slug
+	}
+
+	dir, err := entityDir(projectRoot, feature.FilePath, resolve)
+	if err != nil {
+		return "", "", err
+	}
+	dir = filepath.ToSlash(dir)
+	if isFeatureKeyName(path.Base(dir), feature.Key) {
+		return dir, path.Join(path.Dir(dir), name), nil
+	}
+
+	if feature.FilePath == nil || filepath.IsAbs(*feature.FilePath) {
+		return "", "", nil
+	}
+	file := filepath.ToSlash(*feature.FilePath)
+	if base := path.Base(file); strings.HasSuffix(base, ".md") && isFeatureKeyName(strings.TrimSuffix(base, ".md"), feature.Key) {
+		return file, path.Join(path.Dir(file), name+".md"), nil
+	}
+	return "", "", nil
+}
+
+// isFeatureKeyName reports whether a file or directory name is the feature key,
+// optionally followed by a slug
+func isFeatureKeyName(name, key string) bool {
+	return name == key || strings.HasPrefix(name, key+"-")
+}
+
+// featureSlugKey returns the slugged feature key a renamed path is named for
+// ("E05-F01-login" for .../E05-F01-login or .../E05-F01-login.md)
+func featureSlugKey(p string) string {
+	return strings.TrimSuffix(path.Base(p), ".md")
+}
+
+var frontmatterTitleLine = regexp.MustCompile(`^(\s*title:\s*)(.*?)(\s*)$`)
+
+// rewriteFeatureFrontmatter sets the title in a feature file's frontmatter and
+// replaces the old slugged key in its *_key fields. Missing files and files
+// without frontmatter are ignored.
+func rewriteFeatureFrontmatter(filePath, title, oldSlugKey, newSlugKey string) error {
+	if oldSlugKey != newSlugKey {
+		err := rewriteFrontmatterKeys(filePath, func(value string) string {
+			if value == oldSlugKey {
+				return newSlugKey
+			}
+			return value
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	content, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(content), "\n")
+	if len(lines) == 0 || lines[0] != "---" {
+		return nil
+	}
+	for i := 1; i < len(lines) && lines[i] != "---"; i++ {
+		match := frontmatterTitleLine.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		value := yamlTitle(title)
+		if value == match[2] {
+			return nil
+		}
+		lines[i] = match[1] + value + match[3]
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), info.Mode().Perm())
+	}
+	return nil
+}
+
+// yamlTitle quotes a title if YAML would otherwise read it differently
+func yamlTitle(title string) string {
+	if strings.ContainsAny(title[:1], "-?:,[]{}#&*!|>'\"%@`") || strings.Contains(title, ": ") || strings.Contains(title, " #") {
+		return strconv.Quote(title)
+	}
+	return title
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureRenamePaths(t *testing.T) {
+	root := t.TempDir()
+	strPtr := func(s string) *string { return &s }
+	noResolve := func() (string, error) { return "", nil }
+
+	tests := []struct {
+		name     string
+		filePath string
+		from, to string
+	}{
+		{"feature directory", "docs/plan/E05-auth/E05-F01-login/feature.md", "docs/plan/E05-auth/E05-F01-login", "docs/plan/E05-auth/E05-F01-single-sign-on"},
+		{"directory without a slug", "docs/plan/E05-auth/E05-F01/feature.md", "docs/plan/E05-auth/E05-F01", "docs/plan/E05-auth/E05-F01-single-sign-on"},
+		{"feature file in the epic directory", "docs/plan/E05-auth/E05-F01-login.md", "docs/plan/E05-auth/E05-F01-login.md", "docs/plan/E05-auth/E05-F01-single-sign-on.md"},
+		{"custom file path", "docs/specs/login.md", "", ""},
+		{"longer key with the same prefix", "docs/plan/E05-auth/E05-F011-other/feature.md", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feature := &models.Feature{Key: "E05-F01", Title: "Login", FilePath: strPtr(tt.filePath)}
+			from, to, err := featureRenamePaths(root, feature, "Single Sign-On", noResolve)
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+}
+
+func TestRewriteFeatureFrontmatter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "feature.md")
+	content := "---\nfeature_key: E05-F01-login\nepic_key: E05\ntitle: Login\n---\n\n# Login\n\n**Feature Key**: E05-F01-login\n"
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	require.NoError(t, rewriteFeatureFrontmatter(file, "SSO: single sign-on", "E05-F01-login", "E05-F01-sso-single-sign-on"))
+
+	updated, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nfeature_key: E05-F01-sso-single-sign-on\nepic_key: E05\ntitle: \"SSO: single sign-on\"\n---\n\n# Login\n\n**Feature Key**: E05-F01-login\n", string(updated))
+
+	assert.NoError(t, rewriteFeatureFrontmatter(filepath.Join(t.TempDir(), "missing.md"), "Title", "a", "b"))
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// featureResequenceCmd renumbers a feature's tasks contiguously
+var featureResequenceCmd = &cobra.Command{
+	Use:   "resequence <feature-key>",
+	Short: "Renumber a feature's tasks contiguously after deletions",
+	Long: `Renumber the tasks of a feature whose numbers have gaps after deletions
+(001, 003, 004) so they run contiguously (001, 002, 003), keeping their order.
+Trashed and archived tasks keep their place, since they can still be restored.
+
+Task depends_on lists, the audit log, undo journal, and search index follow the
+new keys. Task files named for their keys are renamed and the key in their
+frontmatter updated. The database changes are made in a single transaction, and
+renamed files are put back if it fails.
+
+Old keys are kept as aliases, so task commands still accept them, and they are
+never handed out to new tasks. A key that a renumbered task holds now finds
+that task: after the example above, T-E05-F01-004 still finds its task (now
+T-E05-F01-003), but the task that was T-E05-F01-003 is only found as
+T-E05-F01-002. New tasks continue after the highest old key (005).
+
+Use 'shark admin renumber' to renumber every epic, feature, and task.
+
+Examples:
+  shark feature resequence E05-F01 --dry-run
+  shark feature resequence E05-F01`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFeatureResequence,
+}
+
+func init() {
+	featureCmd.AddCommand(featureResequenceCmd)
+
+	featureResequenceCmd.Flags().Bool("dry-run", false, "Show the new task keys without changing anything")
+}
+
+// runFeatureResequence handles the feature resequence command
+func runFeatureResequence(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(time.Minute)
+	defer cancel()
+
+	featureKey := NormalizeKey(args[0])
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	feature, err := repository.NewFeatureRepository(repoDb).GetByKey(ctx, featureKey)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("feature %s not found", featureKey)).
+			WithHint("Use 'shark feature list' to see available features")
+	}
+
+	renumberRepo := repository.NewRenumberRepository(repoDb)
+	plan, err := renumberRepo.PlanFeatureResequence(ctx, feature)
+	if err != nil {
+		return fmt.Errorf("failed to plan resequence: %w", err)
+	}
+
+	if len(plan.Changes) == 0 {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"feature": feature.Key, "changes": []*repository.KeyChange{}})
+		}
+		cli.Success(fmt.Sprintf("Tasks of %s are already numbered contiguously; nothing to resequence", feature.Key))
+		return nil
+	}
+
+	if dryRun {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"feature": feature.Key, "dry_run": true, "changes": plan.Changes})
+		}
+		displayRenumberPlan(plan)
+		fmt.Println()
+		cli.Info("Dry run: nothing changed. Re-run without --dry-run to resequence")
+		return nil
+	}
+
+	renames := planRenumberRenames(plan)
+	done, err := applyRenumberRenames(projectRoot, renames)
+	if err != nil {
+		undoRenumberRenames(projectRoot, done)
+		return fmt.Errorf("failed to rename files (nothing changed): %w", err)
+	}
+
+	if err := renumberRepo.Apply(ctx, plan); err != nil {
+		undoRenumberRenames(projectRoot, done)
+		return fmt.Errorf("failed to resequence %s (files restored): %w", feature.Key, err)
+	}
+
+	var frontmatterErrors []string
+	for _, change := range plan.Changes {
+		if change.NewFilePath == nil || !strings.HasSuffix(*change.NewFilePath, ".md") {
+			continue
+		}
+		if err := rewriteFrontmatterKeys(resolveProjectPath(projectRoot, *change.NewFilePath), plan.MapKey); err != nil {
+			frontmatterErrors = append(frontmatterErrors, fmt.Sprintf("%s: %v", *change.NewFilePath, err))
+		}
+	}
+
+	for _, change := range plan.Changes {
+		recordAudit(ctx, repoDb, &models.AuditEntry{
+			EntityType: models.AuditEntityTask,
+			EntityKey:  change.NewKey,
+			Action:     models.AuditActionUpdate,
+			Summary:    fmt.Sprintf("Resequenced from %s", change.OldKey),
+			Changes:    map[string]models.AuditChange{"key": {Old: change.OldKey, New: change.NewKey}},
+		})
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"feature":            feature.Key,
+			"changes":            plan.Changes,
+			"files_renamed":      len(done),
+			"frontmatter_errors": frontmatterErrors,
+		})
+	}
+
+	displayRenumberPlan(plan)
+	cli.Success(fmt.Sprintf("Resequenced %d task(s) of %s and renamed %d file(s)", len(plan.Changes), feature.Key, len(done)))
+	cli.Info("Old keys still resolve and won't be given to new tasks")
+	for _, message := range frontmatterErrors {
+		cli.Warning(fmt.Sprintf("Failed to update frontmatter in %s", message))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/fileops"
+	init_pkg "github.com/jwwelbor/shark-task-manager/internal/init"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// featureScaffoldDocTitles names the built-in starter documents. Other names
+// work too if the project has a shark-templates/feature-<name>.md template.
+var featureScaffoldDocTitles = map[string]string{
+	"design":  "Design",
+	"testing": "Test Plan",
+}
+
+// FeatureScaffold lists what scaffolding created for a feature
+type FeatureScaffold struct {
+	TasksDir  string   `json:"tasks_dir"`
+	Documents []string `json:"documents"`
+}
+
+// featureScaffoldDocName matches starter document names; they become file names
+var featureScaffoldDocName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// featureScaffoldOptions resolves --scaffold and --scaffold-docs, falling back
+// to the feature_scaffold section of the config
+func featureScaffoldOptions(cmd *cobra.Command) (bool, []string, error) {
+	var cfg *config.Config
+	if configPath, err := cli.GetConfigPath(); err == nil {
+		cfg, _ = config.NewManager(configPath).Load()
+	}
+
+	enabled := cfg.IsFeatureScaffoldEnabled()
+	if cmd.Flags().Changed("scaffold") {
+		enabled, _ = cmd.Flags().GetBool("scaffold")
+	}
+	docs := cfg.GetFeatureScaffoldDocs()
+	if cmd.Flags().Changed("scaffold-docs") {
+		docs, _ = cmd.Flags().GetStringSlice("scaffold-docs")
+		enabled = true
+	}
+
+	names := make([]string, 0, len(docs))
+	for _, name := range docs {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !featureScaffoldDocName.MatchString(name) {
+			return false, nil, fmt.Errorf("invalid starter document name %q (use letters, digits and dashes)", name)
+		}
+		names = append(names, name)
+	}
+	return enabled, names, nil
+}
+
+// scaffoldFeature creates the tasks/ folder and the starter documents next to
+// a feature's file, and links the documents to the feature. Features without a
+// folder of their own (a custom --file) are not scaffolded.
+func scaffoldFeature(ctx context.Context, repoDb *repository.DB, projectRoot string, feature *models.Feature, data FeatureTemplateData, docs []string) (*FeatureScaffold, error) {
+	if feature.FilePath == nil {
+		return nil, fmt.Errorf("feature %s has no file to scaffold around", feature.Key)
+	}
+	featureDir := filepath.Dir(resolveProjectPath(projectRoot, *feature.FilePath))
+	if !strings.Contains(filepath.Base(featureDir), feature.Key) {
+		return nil, fmt.Errorf("feature %s has no folder of its own (%s); scaffold only works for features in their own folder", feature.Key, relativeToRoot(projectRoot, featureDir))
+	}
+
+	tasksDir := filepath.Join(featureDir, "tasks")
+	if err := os.MkdirAll(tasksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create tasks folder: %w", err)
+	}
+	scaffold := &FeatureScaffold{TasksDir: relativeToRoot(projectRoot, tasksDir), Documents: []string{}}
+
+	docRepo := repository.NewDocumentRepository(repoDb)
+	writer := fileops.NewEntityFileWriter()
+	for _, name := range docs {
+		content, err := renderFeatureDocTemplate(name, data)
+		if err != nil {
+			return scaffold, err
+		}
+
+		result, err := writer.WriteEntityFile(fileops.WriteOptions{
+			Content:        content,
+			ProjectRoot:    projectRoot,
+			FilePath:       filepath.Join(featureDir, name+".md"),
+			Verbose:        cli.GlobalConfig.Verbose,
+			EntityType:     "document",
+			UseAtomicWrite: true,
+			Logger: func(message string) {
+				cli.Info(message)
+			},
+		})
+		if err != nil {
+			return scaffold, fmt.Errorf("failed to write %s.md: %w", name, err)
+		}
+
+		relPath := filepath.ToSlash(result.RelativePath)
+		doc, err := docRepo.CreateOrGet(ctx, featureDocTitle(feature.Title, name), relPath)
+		if err != nil {
+			return scaffold, fmt.Errorf("failed to record %s: %w", relPath, err)
+		}
+		if err := docRepo.LinkToFeature(ctx, feature.ID, doc.ID); err != nil {
+			return scaffold, fmt.Errorf("failed to link %s to %s: %w", relPath, feature.Key, err)
+		}
+		scaffold.Documents = append(scaffold.Documents, relPath)
+	}
+	return scaffold, nil
+}
+
+// renderFeatureDocTemplate renders shark-templates/feature-<name>.md, falling
+// back to the built-in template for projects initialized before it existed
+func renderFeatureDocTemplate(name string, data FeatureTemplateData) ([]byte, error) {
+	fileName := "feature-" + name + ".md"
+	templateContent, err := os.ReadFile(projectTemplatePath(fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		templateContent, err = init_pkg.DefaultTemplate(fileName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no template for starter document %q (add shark-templates/%s): %w", name, fileName, err)
+	}
+
+	tmpl, err := template.New(fileName).Parse(string(templateContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", fileName, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// featureDocTitle returns the document title for a starter document, such as
+// "OAuth Login Design"
+func featureDocTitle(featureTitle, name string) string {
+	docTitle, ok := featureScaffoldDocTitles[name]
+	if !ok {
+		docTitle = strings.ToUpper(name[:1]) + strings.ReplaceAll(name[1:], "-", " ")
+	}
+	return featureTitle + " " + docTitle
+}
+
+// featureTemplateDataFor rebuilds the template data for a created feature
+func featureTemplateDataFor(epicKey string, feature *models.Feature, featureSlug string) FeatureTemplateData {
+	data := FeatureTemplateData{
+		EpicKey:     epicKey,
+		FeatureKey:  feature.Key,
+		FeatureSlug: featureSlug,
+		Title:       feature.Title,
+		Date:        time.Now().Format("2006-01-02"),
+	}
+	if feature.Description != nil {
+		data.Description = *feature.Description
+	}
+	if feature.FilePath != nil {
+		data.FilePath = *feature.FilePath
+	}
+	return data
+}
+
+// printFeatureScaffold lists the scaffolded folder and documents
+func printFeatureScaffold(scaffold *FeatureScaffold) {
+	cli.Info("Scaffolded tasks folder: %s", scaffold.TasksDir)
+	for _, doc := range scaffold.Documents {
+		cli.Info("Scaffolded document: %s", doc)
+	}
+}
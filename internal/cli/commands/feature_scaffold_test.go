@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffoldFeature(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	ctx := context.Background()
+	projectRoot := t.TempDir()
+
+	epic := &models.Epic{Key: "E06", Title: "Accounts", Status: models.EpicStatusActive, Priority: models.PriorityMedium}
+	require.NoError(t, repository.NewEpicRepository(database).Create(ctx, epic))
+
+	filePath := "docs/plan/E06-accounts/E06-F01-oauth-login/feature.md"
+	feature := &models.Feature{EpicID: epic.ID, Key: "E06-F01", Title: "OAuth Login", Status: models.FeatureStatusDraft, FilePath: &filePath}
+	require.NoError(t, repository.NewFeatureRepository(database).Create(ctx, feature))
+
+	data := featureTemplateDataFor(epic.Key, feature, "E06-F01-oauth-login")
+	scaffold, err := scaffoldFeature(ctx, database, projectRoot, feature, data, []string{"design", "testing"})
+	require.NoError(t, err)
+
+	featureDir := "docs/plan/E06-accounts/E06-F01-oauth-login"
+	assert.Equal(t, featureDir+"/tasks", scaffold.TasksDir)
+	assert.DirExists(t, filepath.Join(projectRoot, featureDir, "tasks"))
+	assert.Equal(t, []string{featureDir + "/design.md", featureDir + "/testing.md"}, scaffold.Documents)
+
+	design, err := os.ReadFile(filepath.Join(projectRoot, featureDir, "design.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(design), "feature_key: E06-F01-oauth-login")
+	assert.Contains(t, string(design), "OAuth Login")
+
+	docs, err := repository.NewDocumentRepository(database).ListForFeature(ctx, feature.ID)
+	require.NoError(t, err)
+	titles := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		titles = append(titles, doc.Title)
+	}
+	assert.ElementsMatch(t, []string{"OAuth Login Design", "OAuth Login Test Plan"}, titles)
+
+	// Unknown starter docs need a project template
+	_, err = scaffoldFeature(ctx, database, projectRoot, feature, data, []string{"runbook"})
+	assert.ErrorContains(t, err, "shark-templates/feature-runbook.md")
+
+	// Features without their own folder are not scaffolded
+	shared := "docs/specs/auth.md"
+	feature.FilePath = &shared
+	_, err = scaffoldFeature(ctx, database, projectRoot, feature, data, nil)
+	assert.ErrorContains(t, err, "no folder of its own")
+}
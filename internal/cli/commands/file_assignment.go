@@ -8,7 +8,11 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/db"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
 )
 
 // EpicRepoInterface defines methods needed from EpicRepository for file collision detection
@@ -153,6 +157,127 @@ func HandleFileReassignment(
 	return nil
 }
 
+// claimedFileCollision looks filePath up in the file claims registry and
+// returns the epic, feature, or task that claims it, or nil if it is free
+func claimedFileCollision(ctx context.Context, repoDb *repository.DB, filePath string) (*FileCollision, error) {
+	claim, err := repository.NewFileClaimRepository(repoDb).GetByPath(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if claim == nil {
+		return nil, nil
+	}
+
+	collision := &FileCollision{FilePath: filePath}
+	switch claim.EntityType {
+	case "epic":
+		collision.Epic, err = repository.NewEpicRepository(repoDb).GetByID(ctx, claim.EntityID)
+	case "feature":
+		collision.Feature, err = repository.NewFeatureRepository(repoDb).GetByID(ctx, claim.EntityID)
+	case "task":
+		collision.Task, err = repository.NewTaskRepository(repoDb).GetByID(ctx, claim.EntityID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s claiming %s: %w", claim.EntityType, claim.EntityKey, filePath, err)
+	}
+	return collision, nil
+}
+
+// owner returns the type, key, and title of the entity claiming the file
+func (c *FileCollision) owner() (entityType, key, title string) {
+	switch {
+	case c.Epic != nil:
+		return "epic", c.Epic.Key, c.Epic.Title
+	case c.Feature != nil:
+		return "feature", c.Feature.Key, c.Feature.Title
+	case c.Task != nil:
+		return "task", c.Task.Key, c.Task.Title
+	}
+	return "", "", ""
+}
+
+// claimedFileError describes a collision for a create or update without --force
+func claimedFileError(c *FileCollision) string {
+	entityType, key, title := c.owner()
+	return fmt.Sprintf("file '%s' is already claimed by %s %s ('%s'). Use --force to reassign", c.FilePath, entityType, key, title)
+}
+
+// releaseFileClaim clears the file path of the entity claiming the file, so
+// another can claim it. With a journal, the old path is kept for 'shark undo'.
+func releaseFileClaim(ctx context.Context, repoDb *repository.DB, journal *undoJournal, c *FileCollision) error {
+	capture := func(table string, id int64) {
+		if journal != nil {
+			journal.captureColumns(ctx, table, []int64{id}, "file_path")
+		}
+	}
+	switch {
+	case c.Epic != nil:
+		capture("epics", c.Epic.ID)
+		return repository.NewEpicRepository(repoDb).UpdateFilePath(ctx, c.Epic.Key, nil)
+	case c.Feature != nil:
+		capture("features", c.Feature.ID)
+		return repository.NewFeatureRepository(repoDb).UpdateFilePath(ctx, c.Feature.Key, nil)
+	case c.Task != nil:
+		capture("tasks", c.Task.ID)
+		return repository.NewTaskRepository(repoDb).UpdateFilePath(ctx, c.Task.Key, nil)
+	}
+	return nil
+}
+
+// AssignFileForUpdate validates a new file path for an epic or feature update and
+// resolves collisions with the same semantics as create: a path claimed by another
+// entity is an error unless force is set, in which case the database is backed up
+// and the other entity's claim is cleared.
+// isSelf reports whether a collision is with the entity being updated.
+// Returns the cleaned project-relative path to store.
+func AssignFileForUpdate(ctx context.Context, repoDb *repository.DB, customFile string, force bool, isSelf func(*FileCollision) bool) (string, error) {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return "", fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	_, relPath, err := taskcreation.ValidateCustomFilename(customFile, projectRoot)
+	if err != nil {
+		return "", fmt.Errorf("invalid filename: %w", err)
+	}
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	taskRepo := repository.NewTaskRepository(repoDb)
+
+	collision, err := claimedFileCollision(ctx, repoDb, relPath)
+	if err != nil {
+		return "", err
+	}
+	if collision == nil || isSelf(collision) {
+		return relPath, nil
+	}
+
+	// Reuse the create-time error message for unforced collisions
+	if !force {
+		return "", HandleFileReassignment(ctx, collision, false, epicRepo, featureRepo, taskRepo)
+	}
+
+	// Back up before taking the file away from another entity
+	dbPath, canBackup, err := cli.GetDatabasePathForBackup()
+	if err != nil {
+		return "", fmt.Errorf("failed to get database path for backup: %w", err)
+	}
+	if canBackup {
+		if _, err := CreateBackupIfForce(true, dbPath, "force file reassignment"); err != nil {
+			return "", err
+		}
+	} else if cli.GlobalConfig.Verbose {
+		cli.Info("Using cloud database - backup handled by provider")
+	}
+
+	if err := releaseFileClaim(ctx, repoDb, nil, collision); err != nil {
+		return "", fmt.Errorf("failed to clear previous file assignment: %w", err)
+	}
+
+	return relPath, nil
+}
+
 // CreateBackupIfForce creates a timestamped database backup when force=true
 // Returns empty string if force=false
 // Returns backup path on success, error on failure
@@ -193,20 +318,36 @@ func CreateBackupIfForce(force bool, dbPath string, operation string) (string, e
 		return "", fmt.Errorf("failed to create backup: sync failed: %w", err)
 	}
 
+	// Record what triggered the backup and apply retention
+	if err := db.WriteBackupMetadata(backupFilename, dbPath, operation); err != nil && cli.GlobalConfig.Verbose {
+		cli.Warning(fmt.Sprintf("Failed to write backup metadata: %v", err))
+	}
+	pruneDatabaseBackups(dbPath)
+
 	return backupFilename, nil
 }
 
-// GetAbsoluteFilePath converts a relative file path to an absolute path
-// Uses current working directory as the project root
+// GetAbsoluteFilePath converts a project-relative file path to an absolute
+// path, so it resolves the same from any subdirectory of the project
 func GetAbsoluteFilePath(relativePath string) (string, error) {
 	if filepath.IsAbs(relativePath) {
 		return relativePath, nil
 	}
 
-	wd, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
-		return "", fmt.Errorf("failed to get working directory: %w", err)
+		return "", fmt.Errorf("failed to find project root: %w", err)
 	}
 
-	return filepath.Join(wd, relativePath), nil
+	return filepath.Join(projectRoot, relativePath), nil
+}
+
+// projectTemplatePath returns the path of a file in the project's
+// shark-templates directory
+func projectTemplatePath(name string) string {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return filepath.Join("shark-templates", name)
+	}
+	return filepath.Join(projectRoot, "shark-templates", name)
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
 )
 
 // FileAssignmentEpicRepo provides minimal interface for file assignment testing
@@ -715,3 +716,58 @@ func TestGetAbsoluteFilePath(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignFileForUpdate_InvalidPath(t *testing.T) {
+	// Invalid paths are rejected before any database access
+	notSelf := func(*FileCollision) bool { return false }
+
+	for _, path := range []string{"/tmp/absolute.md", "../outside.md", "docs/spec.txt"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := AssignFileForUpdate(context.Background(), nil, path, false, notSelf)
+			if err == nil {
+				t.Fatalf("Expected error for %q", path)
+			}
+			if !strings.Contains(err.Error(), "invalid filename") {
+				t.Errorf("Expected invalid filename error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestClaimedFileCollision_ReleaseClaim(t *testing.T) {
+	ctx := context.Background()
+	repoDb := setupTestDB(t)
+	defer repoDb.Close()
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	epic := &models.Epic{Key: "E01", Title: "Auth", Status: models.EpicStatusActive, Priority: models.PriorityHigh}
+	if err := epicRepo.Create(ctx, epic); err != nil {
+		t.Fatalf("Failed to create epic: %v", err)
+	}
+	path := "docs/roadmap/auth.md"
+	if err := epicRepo.UpdateFilePath(ctx, epic.Key, &path); err != nil {
+		t.Fatalf("Failed to set file path: %v", err)
+	}
+
+	collision, err := claimedFileCollision(ctx, repoDb, path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if collision == nil || collision.Epic == nil || collision.Epic.Key != "E01" {
+		t.Fatalf("Expected collision with epic E01, got: %+v", collision)
+	}
+	if msg := claimedFileError(collision); !strings.Contains(msg, "epic E01 ('Auth')") {
+		t.Errorf("Expected error naming the epic, got: %s", msg)
+	}
+
+	if err := releaseFileClaim(ctx, repoDb, nil, collision); err != nil {
+		t.Fatalf("Failed to release claim: %v", err)
+	}
+	collision, err = claimedFileCollision(ctx, repoDb, path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if collision != nil {
+		t.Errorf("Expected file to be free after release, got: %+v", collision)
+	}
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// fileCmd groups commands about the files epics, features, and tasks own
+var fileCmd = &cobra.Command{
+	Use:     "file",
+	Short:   "Inspect which epics, features, and tasks own which files",
+	GroupID: "details",
+	Long: `Inspect the files claimed by epics, features, and tasks.
+
+A file belongs to at most one epic, feature, or task. Creating or updating
+one with a file another already claims fails unless --force is given.`,
+}
+
+// fileClaimsCmd lists file claims
+var fileClaimsCmd = &cobra.Command{
+	Use:         "claims [path]",
+	Short:       "Show which epic, feature, or task claims a file",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Show the epic, feature, or task that claims a file. Given a directory, show
+the claims on every file under it. Without a path, show every claim.
+
+Paths are relative to the current directory and may point anywhere in the
+project.
+
+Examples:
+  shark file claims docs/plan/E04-auth/epic.md
+  shark file claims docs/plan
+  shark file claims --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFileClaims,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(fileCmd)
+	fileCmd.AddCommand(fileClaimsCmd)
+}
+
+func runFileClaims(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	// File paths are stored project-relative, though some older entities
+	// have absolute paths, so look a path up in both forms
+	path := ""
+	lookups := []string{""}
+	if len(args) == 1 {
+		var absPath string
+		if path, absPath, err = projectRelativePath(args[0]); err != nil {
+			return err
+		}
+		lookups = []string{path, absPath}
+	}
+
+	claimRepo := repository.NewFileClaimRepository(repoDb)
+	claims := []*models.FileClaim{}
+	for _, lookup := range lookups {
+		found, err := claimRepo.List(ctx, lookup)
+		if err != nil {
+			return err
+		}
+		claims = append(claims, found...)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(claims)
+	}
+	if len(claims) == 0 {
+		if path == "" {
+			fmt.Println("No files are claimed")
+		} else {
+			fmt.Printf("No claims on %s\n", path)
+		}
+		return nil
+	}
+
+	rows := make([][]string, len(claims))
+	for i, claim := range claims {
+		rows[i] = []string{claim.Path, claim.EntityType, claim.EntityKey, truncateCell(claim.EntityTitle, 50), claim.ClaimedAt.Format("2006-01-02 15:04")}
+	}
+	cli.OutputTable([]string{"Path", "Type", "Key", "Title", "Claimed"}, rows)
+	return nil
+}
+
+// projectRelativePath turns a path given on the command line into the
+// project-relative form file paths are stored in, and its absolute form
+func projectRelativePath(path string) (relPath, absPath string, err error) {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find project root: %w", err)
+	}
+	absPath, err = filepath.Abs(path)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	relPath, err = filepath.Rel(projectRoot, absPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("path %q is outside the project root %s", path, projectRoot)
+	}
+	return filepath.ToSlash(relPath), absPath, nil
+}
@@ -30,6 +30,12 @@ func TestE2E_FilePathFlagStandardization_E07F19(t *testing.T) {
 
 	ctx := context.Background()
 
+	// Release the files earlier runs claimed in the shared test database
+	for _, table := range []string{"tasks", "features", "epics"} {
+		_, _ = testDB.Exec(fmt.Sprintf(`UPDATE %s SET file_path = NULL
+			WHERE file_path LIKE 'docs/custom/%%' OR file_path LIKE 'docs/epics/%%' OR file_path LIKE 'docs/features/%%'`, table))
+	}
+
 	// Create repositories
 	epicRepo := repository.NewEpicRepository(database)
 	featureRepo := repository.NewFeatureRepository(database)
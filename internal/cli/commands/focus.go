@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// focusCmd scopes list, status, and next commands to one epic
+var focusCmd = &cobra.Command{
+	Use:         "focus [epic-key]",
+	Short:       "Scope list, status, and next commands to one epic",
+	GroupID:     "essentials",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Focus the project on an epic. Until the focus is cleared, these commands
+default to the focused epic when no epic, feature, or positional key is given:
+
+  shark task list      shark feature list
+  shark task next      shark status
+
+The focus is kept per project, for you alone, in ~/.shark/workspaces.yaml
+($SHARK_HOME overrides the directory). With no arguments the current focus is
+shown.
+
+Examples:
+  shark focus E05            Focus on epic E05
+  shark focus                Show the current focus
+  shark focus --clear        Go back to all epics
+  shark task list E04        An explicit epic still wins`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFocus,
+}
+
+// focusedCommandFlags are the flags that, when set, mean a command already
+// has its scope and the focus doesn't apply
+var focusedCommandFlags = []string{"epic", "feature", "standalone"}
+
+func init() {
+	cli.RootCmd.AddCommand(focusCmd)
+
+	focusCmd.Flags().Bool("clear", false, "Clear the focus")
+}
+
+// FocusJSON is the JSON output of shark focus
+type FocusJSON struct {
+	ProjectRoot string  `json:"project_root"`
+	Epic        *string `json:"epic"`
+}
+
+// runFocus handles the focus command
+func runFocus(cmd *cobra.Command, args []string) error {
+	clear, _ := cmd.Flags().GetBool("clear")
+	if clear && len(args) > 0 {
+		return fmt.Errorf("give an epic key or --clear, not both")
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+	registry, registryPath, err := loadWorkspaceRegistry()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case clear:
+		registry.SetFocus(projectRoot, "")
+		if err := registry.Save(registryPath); err != nil {
+			return err
+		}
+	case len(args) == 1:
+		epicKey, err := focusEpicKey(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		registry.SetFocus(projectRoot, epicKey)
+		if err := registry.Save(registryPath); err != nil {
+			return err
+		}
+	}
+
+	result := FocusJSON{ProjectRoot: projectRoot}
+	if epicKey := registry.FocusedEpic(projectRoot); epicKey != "" {
+		result.Epic = &epicKey
+	}
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+
+	switch {
+	case result.Epic != nil && len(args) == 1:
+		cli.Success(fmt.Sprintf("Focused on %s; list, status, and next commands now default to it", *result.Epic))
+	case result.Epic != nil:
+		cli.Info("Focused on %s ('shark focus --clear' to go back to all epics)", *result.Epic)
+	case clear:
+		cli.Success("Focus cleared")
+	default:
+		cli.Info("No focus set (see 'shark focus <epic-key>')")
+	}
+	return nil
+}
+
+// focusEpicKey normalizes an epic key and checks that the epic exists
+func focusEpicKey(cmd *cobra.Command, key string) (string, error) {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	key = NormalizeKey(key)
+	if !IsEpicKey(key) {
+		return "", InvalidEpicKeyError(key)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return "", fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	epic, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, key)
+	if err != nil {
+		return "", cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("epic %s not found", key)).WithHint("Use 'shark epic list' to see available epics")
+	}
+	return epic.Key, nil
+}
+
+// applyFocus sets cmd's --epic flag to the focused epic unless the command was
+// given its own scope, and returns the epic it applied. Focus problems never
+// fail the command: it just runs unfocused.
+func applyFocus(cmd *cobra.Command, args []string) string {
+	if len(args) > 0 {
+		return ""
+	}
+	for _, name := range focusedCommandFlags {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return ""
+		}
+	}
+
+	epicKey := focusedEpic()
+	if epicKey == "" || cmd.Flags().Set("epic", epicKey) != nil {
+		return ""
+	}
+
+	if !cli.GlobalConfig.JSON {
+		cli.Info("Focused on %s ('shark focus --clear' to show all epics)", epicKey)
+	}
+	return epicKey
+}
+
+// focusedEpic returns the epic the current project is focused on, or ""
+func focusedEpic() string {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return ""
+	}
+	registry, _, err := loadWorkspaceRegistry()
+	if err != nil {
+		return ""
+	}
+	return registry.FocusedEpic(projectRoot)
+}
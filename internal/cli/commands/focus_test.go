@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFocus(t *testing.T) {
+	t.Setenv(workspace.HomeEnv, t.TempDir())
+	originalConfig := *cli.GlobalConfig
+	defer func() { *cli.GlobalConfig = originalConfig }()
+	cli.GlobalConfig.ProjectRoot = "/src/api"
+	cli.GlobalConfig.JSON = true
+
+	newListCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "list"}
+		cmd.Flags().String("epic", "", "")
+		cmd.Flags().String("feature", "", "")
+		return cmd
+	}
+
+	// No focus: nothing changes
+	cmd := newListCmd()
+	assert.Empty(t, applyFocus(cmd, nil))
+
+	registry, path, err := loadWorkspaceRegistry()
+	require.NoError(t, err)
+	registry.SetFocus("/src/api", "E05")
+	require.NoError(t, registry.Save(path))
+
+	cmd = newListCmd()
+	assert.Equal(t, "E05", applyFocus(cmd, nil))
+	epic, _ := cmd.Flags().GetString("epic")
+	assert.Equal(t, "E05", epic)
+
+	// An explicit scope wins over the focus
+	assert.Empty(t, applyFocus(newListCmd(), []string{"E04"}))
+	cmd = newListCmd()
+	require.NoError(t, cmd.Flags().Set("feature", "E04-F01"))
+	assert.Empty(t, applyFocus(cmd, nil))
+	epic, _ = cmd.Flags().GetString("epic")
+	assert.Empty(t, epic)
+
+	// The focus belongs to one project
+	cli.GlobalConfig.ProjectRoot = "/src/web"
+	assert.Empty(t, applyFocus(newListCmd(), nil))
+}
@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/progress"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// forecastCmd estimates when an epic will be completed
+var forecastCmd = &cobra.Command{
+	Use:         "forecast <epic-key>",
+	Short:       "Forecast when an epic will be completed",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
+	Long: `Estimate a completion date range for an epic from how long tasks have taken
+and the dependencies between its open tasks.
+
+Cycle time is measured from task history: the time from a task's first move
+into a development status to its completion, averaged over the tasks
+completed in the --since window. Every open task is assumed to take that
+long; tasks in progress only count the time they haven't spent yet.
+
+Tasks that depend on each other run one after another, so the longest chain
+of open dependencies (the critical path) sets the pace. Those are the tasks
+that most affect the forecast: a delay to any of them delays the epic.
+With --agents, the forecast also accounts for the total work being shared
+between that many agents.
+
+The range runs from the average cycle time less one standard deviation to
+the average plus one. Times are wall-clock, like the history they come from.
+
+Examples:
+  shark forecast E05
+  shark forecast E05 --agents=3
+  shark forecast E05 --since=180d --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runForecast,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(forecastCmd)
+
+	forecastCmd.Flags().String("since", "90d", "Measure cycle time from tasks completed since: a duration ago (30d) or a date (YYYY-MM-DD)")
+	forecastCmd.Flags().Int("agents", 0, "Agents working on the epic at once (default: as many as dependencies allow)")
+}
+
+// EpicForecastJSON is the output of shark forecast
+type EpicForecastJSON struct {
+	Epic          string                  `json:"epic"`
+	Title         string                  `json:"title"`
+	Since         time.Time               `json:"since"`
+	OpenTasks     int                     `json:"open_tasks"`
+	InProgress    int                     `json:"in_progress"`
+	CycleTime     progress.CycleTime      `json:"cycle_time"`
+	Agents        int                     `json:"agents"`
+	LimitedBy     string                  `json:"limited_by"`
+	Earliest      time.Time               `json:"earliest"`
+	Expected      time.Time               `json:"expected"`
+	Latest        time.Time               `json:"latest"`
+	ExpectedHours float64                 `json:"expected_hours"`
+	CriticalPath  []*EpicForecastPathTask `json:"critical_path"`
+}
+
+// EpicForecastPathTask is a task on the critical path of an epic
+type EpicForecastPathTask struct {
+	Key            string            `json:"key"`
+	Title          string            `json:"title"`
+	Status         models.TaskStatus `json:"status"`
+	RemainingHours float64           `json:"remaining_hours"`
+}
+
+// runForecast executes the forecast command
+func runForecast(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	sinceStr, _ := cmd.Flags().GetString("since")
+	agents, _ := cmd.Flags().GetInt("agents")
+	if agents < 0 {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "--agents must be 0 or more")
+	}
+
+	epicKey := NormalizeKey(args[0])
+	if !IsEpicKey(epicKey) {
+		return InvalidEpicKeyError(args[0])
+	}
+
+	now := time.Now()
+	since, err := parseAuditSince(sinceStr, now)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	epic, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, epicKey)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("Epic %s does not exist", epicKey)).
+			WithHint("Use 'shark epic list' to see available epics")
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	tasks, err := taskRepo.ListByEpic(ctx, epic.Key)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	deps, err := loadTaskDependencies(ctx, repoDb, taskRepo, tasks)
+	if err != nil {
+		return err
+	}
+
+	configPath, _ := cli.GetConfigPath()
+	workflow := config.GetWorkflowOrDefault(configPath)
+	hours, err := repository.NewStatsRepository(repoDb).CycleTimes(ctx, repository.CycleTimeFilter{
+		Since:         since,
+		StartStatuses: statusesOrDefault(workflow.GetStatusesByPhase("development"), models.TaskStatusInProgress),
+		DoneStatuses:  statusesOrDefault(workflow.GetStatusesByPhase("done"), models.TaskStatusCompleted),
+	})
+	if err != nil {
+		return err
+	}
+	cycle := progress.NewCycleTime(hours)
+
+	forecastTasks, byKey := forecastOpenTasks(tasks, deps, now)
+	if len(forecastTasks) > 0 && cycle.Samples == 0 {
+		return cli.NewError(cli.ErrCodeInvalidState, fmt.Sprintf("No tasks were completed since %s to measure cycle time", since.Local().Format("2006-01-02"))).
+			WithHint("Use a longer window, e.g. --since=365d")
+	}
+
+	forecast := progress.BuildForecast(forecastTasks, cycle, agents, now)
+	report := &EpicForecastJSON{
+		Epic:          epic.Key,
+		Title:         epic.Title,
+		Since:         since,
+		OpenTasks:     forecast.OpenTasks,
+		CycleTime:     forecast.CycleTime,
+		Agents:        forecast.Agents,
+		LimitedBy:     forecast.LimitedBy,
+		Earliest:      forecast.Earliest,
+		Expected:      forecast.Expected,
+		Latest:        forecast.Latest,
+		ExpectedHours: forecast.ExpectedHours,
+		CriticalPath:  []*EpicForecastPathTask{},
+	}
+	for _, task := range byKey {
+		if task.Status != models.TaskStatusTodo && task.Status != models.TaskStatusBlocked {
+			report.InProgress++
+		}
+	}
+	for _, step := range forecast.CriticalPath {
+		task := byKey[step.Key]
+		report.CriticalPath = append(report.CriticalPath, &EpicForecastPathTask{
+			Key:            task.Key,
+			Title:          task.Title,
+			Status:         task.Status,
+			RemainingHours: step.RemainingHours,
+		})
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(report)
+	}
+	printEpicForecast(report, now)
+	return nil
+}
+
+// forecastOpenTasks converts an epic's open tasks for forecasting and returns
+// them by key. deps holds each task's dependencies by task key; tasks past
+// todo or blocked count the time since they were started as already spent.
+func forecastOpenTasks(tasks []*models.Task, deps map[string][]EpicReadyBlocker, now time.Time) ([]progress.ForecastTask, map[string]*models.Task) {
+	open := []progress.ForecastTask{}
+	byKey := map[string]*models.Task{}
+	for _, task := range tasks {
+		if isTaskDone(task.Status) {
+			continue
+		}
+		byKey[task.Key] = task
+
+		forecastTask := progress.ForecastTask{Key: task.Key}
+		for _, dep := range deps[task.Key] {
+			if !isTaskDone(models.TaskStatus(dep.Status)) {
+				forecastTask.DependsOn = append(forecastTask.DependsOn, dep.Key)
+			}
+		}
+		started := task.Status != models.TaskStatusTodo && task.Status != models.TaskStatusBlocked
+		if started && task.StartedAt.Valid && task.StartedAt.Time.Before(now) {
+			forecastTask.ElapsedHours = now.Sub(task.StartedAt.Time).Hours()
+		}
+		open = append(open, forecastTask)
+	}
+	return open, byKey
+}
+
+// statusesOrDefault returns statuses, or fallback for workflows without phases
+func statusesOrDefault(statuses []string, fallback models.TaskStatus) []string {
+	if len(statuses) == 0 {
+		return []string{string(fallback)}
+	}
+	return statuses
+}
+
+// printEpicForecast prints the forecast as a summary and a critical path table
+func printEpicForecast(report *EpicForecastJSON, now time.Time) {
+	fmt.Printf("Forecast: %s - %s\n\n", report.Epic, report.Title)
+
+	if report.OpenTasks == 0 {
+		fmt.Println("Every task is completed or archived; nothing left to forecast.")
+		return
+	}
+
+	cycle := report.CycleTime
+	fmt.Printf("Open tasks:  %d (%d in progress)\n", report.OpenTasks, report.InProgress)
+	fmt.Printf("Cycle time:  %s average, ± %s (%d task(s) completed since %s)\n",
+		formatStatsHours(cycle.MeanHours), formatStatsHours(cycle.StdDevHours), cycle.Samples, report.Since.Local().Format("2006-01-02"))
+	if report.Agents > 0 {
+		fmt.Printf("Agents:      %d (limited by %s)\n", report.Agents, report.LimitedBy)
+	} else {
+		fmt.Println("Agents:      as many as dependencies allow")
+	}
+	fmt.Printf("Completion:  %s to %s, most likely %s (%s)\n",
+		report.Earliest.Local().Format("2006-01-02"),
+		report.Latest.Local().Format("2006-01-02"),
+		report.Expected.Local().Format("2006-01-02"),
+		formatForecastDays(report.Expected.Sub(now)))
+
+	fmt.Printf("\nCritical path (%d task(s)):\n", len(report.CriticalPath))
+	rows := make([][]string, 0, len(report.CriticalPath))
+	for i, task := range report.CriticalPath {
+		rows = append(rows, []string{strconv.Itoa(i + 1), task.Key, string(task.Status), formatStatsHours(task.RemainingHours), task.Title})
+	}
+	cli.OutputTable([]string{"#", "Task", "Status", "Remaining", "Title"}, rows)
+}
+
+// formatForecastDays describes how far away a forecast date is
+func formatForecastDays(d time.Duration) string {
+	days := int(math.Ceil(d.Hours() / 24))
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "in 1 day"
+	default:
+		return fmt.Sprintf("in %d days", days)
+	}
+}
@@ -7,9 +7,10 @@ import (
 
 // getCmd represents the unified get command
 var getCmd = &cobra.Command{
-	Use:     "get <KEY>",
-	Short:   "Get epic, feature, or task details",
-	GroupID: "essentials",
+	Use:         "get <KEY>",
+	Short:       "Get epic, feature, or task details",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "essentials",
 	Long: `Smart get command that dispatches to the appropriate subcommand based on arguments.
 
 Positional Arguments:
@@ -25,9 +25,10 @@ var (
 )
 
 var historyCmd = &cobra.Command{
-	Use:     "history [EPIC] [FEATURE]",
-	Short:   "View project-wide task history",
-	GroupID: "status",
+	Use:         "history [EPIC] [FEATURE]",
+	Short:       "View project-wide task history",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
 	Long: `View project-wide task activity log with optional filtering.
 
 Displays recent status changes, agent assignments, and task transitions across all tasks in the project.
@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
 	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +29,11 @@ type IdeaRepository interface {
 	Update(ctx context.Context, idea *models.Idea) error
 	Delete(ctx context.Context, id int64) error
 	MarkAsConverted(ctx context.Context, ideaID int64, convertedToType, convertedToKey string) error
+	ConvertToEpic(ctx context.Context, ideaID int64, epic *models.Epic) error
+	ConvertToFeature(ctx context.Context, ideaID int64, feature *models.Feature) error
+	ConvertToTask(ctx context.Context, ideaID int64, task *models.Task) error
 	GetNextSequenceForDate(ctx context.Context, dateStr string) (int, error)
+	UpdateFilePath(ctx context.Context, ideaKey string, newFilePath *string) error
 }
 
 // ideaCmd represents the idea command group
@@ -41,13 +50,18 @@ Examples:
   shark idea create "New Feature"    Create a new idea
   shark idea get I-2026-01-01-01     Get idea details
   shark idea update I-2026-01-01-01  Update an idea
-  shark idea delete I-2026-01-01-01  Delete an idea`,
+  shark idea delete I-2026-01-01-01  Delete an idea
+  shark idea merge I-A I-B           Merge duplicate ideas into I-A
+  shark idea split I-A "x" "y"       Split an idea into new ideas
+  shark idea export --mine           Save ideas to your personal idea store
+  shark idea import --mine           Load ideas from your personal idea store`,
 }
 
 // ideaListCmd lists ideas
 var ideaListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List ideas",
+	Use:         "list",
+	Short:       "List ideas",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List ideas with optional filtering by status and priority.
 
 By default, archived ideas are hidden unless --status=archived is specified.
@@ -56,14 +70,20 @@ Examples:
   shark idea list                     List all non-archived ideas
   shark idea list --status=new        List only new ideas
   shark idea list --priority=5        List ideas with priority 5
-  shark idea list --json              Output as JSON`,
+  shark idea list --rank              Highest-ranked ideas first
+  shark idea list --json              Output as JSON
+
+With --rank, ideas are sorted by a score from 0 to 100 weighing priority (60%),
+age (20%, full weight at 90 days), and the number of open ideas that depend on
+the idea (20%, full weight at 3).`,
 	RunE: runIdeaList,
 }
 
 // ideaGetCmd gets a specific idea
 var ideaGetCmd = &cobra.Command{
-	Use:   "get <idea-key>",
-	Short: "Get idea details",
+	Use:         "get <idea-key>",
+	Short:       "Get idea details",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display detailed information about a specific idea.
 
 Examples:
@@ -79,12 +99,21 @@ var ideaCreateCmd = &cobra.Command{
 	Short: "Create a new idea",
 	Long: `Create a new idea with auto-generated key (I-YYYY-MM-DD-xx format).
 
-All properties can be set on creation using flags.
+All properties can be set on creation using flags. With --with-file, a markdown
+file is also created from shark-templates/idea.md at
+docs/plan/ideas/{idea-key}-{slug}.md. When the idea is converted, the file moves
+into the new epic, feature, or task's folder.
+
+With --if-not-exists, an existing idea with the same title (ignoring case) is
+returned instead of creating a duplicate, so retries are safe. JSON output
+includes "created": false for an existing idea.
 
 Examples:
   shark idea create "New feature idea"
+  shark idea create "New feature idea" --if-not-exists --json
   shark idea create "Backend optimization" --description="Improve query performance" --priority=8
-  shark idea create "UI redesign" --status=on_hold --notes="Waiting for design review"`,
+  shark idea create "UI redesign" --status=on_hold --notes="Waiting for design review"
+  shark idea create "Offline mode" --with-file`,
 	Args: cobra.ExactArgs(1),
 	RunE: runIdeaCreate,
 }
@@ -107,8 +136,9 @@ Examples:
 
 // ideaDeleteCmd deletes an idea
 var ideaDeleteCmd = &cobra.Command{
-	Use:   "delete <idea-key>",
-	Short: "Delete an idea",
+	Use:         "delete <idea-key>",
+	Short:       "Delete an idea",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
 	Long: `Delete an idea (soft delete by default, archives the idea).
 
 By default, ideas are archived (soft delete). Use --hard flag for permanent deletion.
@@ -129,11 +159,21 @@ var ideaConvertCmd = &cobra.Command{
 	Long: `Convert a lightweight idea into a structured entity (epic, feature, task).
 
 Once converted, the idea status changes to 'converted' and a new entity is created.
+Both happen in one transaction, so a failed conversion changes nothing. If the
+idea has a file (see 'shark idea create --with-file'), the file moves into the
+new entity's folder and becomes its document; otherwise the document is created
+from the entity's template, as 'shark epic/feature/task create' would.
+
+The idea's notes become a note on the new epic or task (features have no
+notes, so they are appended to the feature's description), and its related
+docs are linked to the new entity as documents. Use --status and --priority
+to override the defaults, and --dry-run to preview the conversion.
 
 Examples:
   shark idea convert I-2026-01-01-01 epic
-  shark idea convert I-2026-01-01-01 feature --epic=E10
-  shark idea convert I-2026-01-01-01 task --epic=E10 --feature=E10-F02`,
+  shark idea convert I-2026-01-01-01 epic --dry-run
+  shark idea convert I-2026-01-01-01 feature --epic=E10 --status=active
+  shark idea convert I-2026-01-01-01 task --epic=E10 --feature=E10-F02 --priority=2`,
 }
 
 // ideaConvertEpicCmd converts an idea to an epic
@@ -142,11 +182,15 @@ var ideaConvertEpicCmd = &cobra.Command{
 	Short: "Convert idea to epic",
 	Long: `Convert an idea to a new epic.
 
-The idea's title and description are copied to the epic.
-A new epic key is auto-generated (E##).
+The idea's title and description are copied to the epic, its notes become
+an epic note, and its related docs are linked as documents.
+A new epic key is auto-generated (E##). The epic starts as draft with medium
+priority unless --status or --priority says otherwise.
 
 Examples:
   shark idea convert I-2026-01-01-01 epic
+  shark idea convert I-2026-01-01-01 epic --priority=high --status=active
+  shark idea convert I-2026-01-01-01 epic --dry-run
   shark idea convert I-2026-01-01-01 epic --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runIdeaConvertEpic,
@@ -158,11 +202,15 @@ var ideaConvertFeatureCmd = &cobra.Command{
 	Short: "Convert idea to feature",
 	Long: `Convert an idea to a feature in a specified epic.
 
-The idea's title and description are copied to the feature.
-Requires --epic flag to specify the target epic.
+The idea's title and description are copied to the feature, its notes are
+appended to the description, and its related docs are linked as documents.
+Requires --epic flag to specify the target epic. The feature starts as draft
+unless --status says otherwise.
 
 Examples:
   shark idea convert I-2026-01-01-01 feature --epic=E10
+  shark idea convert I-2026-01-01-01 feature --epic=E10 --status=active
+  shark idea convert I-2026-01-01-01 feature --epic=E10 --dry-run
   shark idea convert I-2026-01-01-01 feature --epic=E10 --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runIdeaConvertFeature,
@@ -174,11 +222,16 @@ var ideaConvertTaskCmd = &cobra.Command{
 	Short: "Convert idea to task",
 	Long: `Convert an idea to a task in a specified epic and feature.
 
-The idea's title, description, and priority are copied to the task.
-Requires --epic and --feature flags to specify the target location.
+The idea's title, description, and priority are copied to the task, its
+notes become a task note, and its related docs are linked as documents.
+Requires --epic and --feature flags to specify the target location. The task
+starts as todo with the idea's priority unless --status or --priority says
+otherwise.
 
 Examples:
   shark idea convert I-2026-01-01-01 task --epic=E10 --feature=E10-F02
+  shark idea convert I-2026-01-01-01 task --epic=E10 --feature=E10-F02 --priority=2
+  shark idea convert I-2026-01-01-01 task --epic=E10 --feature=E10-F02 --dry-run
   shark idea convert I-2026-01-01-01 task --epic=E10 --feature=E10-F02 --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runIdeaConvertTask,
@@ -186,17 +239,22 @@ Examples:
 
 // Command flags
 var (
-	ideaStatus         string
-	ideaPriority       int
-	ideaDescription    string
-	ideaNotes          string
-	ideaRelatedDocs    []string
-	ideaDependencies   []string
-	ideaOrder          int
-	ideaForce          bool
-	ideaHard           bool
-	ideaConvertEpic    string
-	ideaConvertFeature string
+	ideaStatus          string
+	ideaPriority        int
+	ideaDescription     string
+	ideaNotes           string
+	ideaRelatedDocs     []string
+	ideaDependencies    []string
+	ideaOrder           int
+	ideaForce           bool
+	ideaHard            bool
+	ideaConvertEpic     string
+	ideaConvertFeature  string
+	ideaConvertStatus   string
+	ideaConvertPriority string
+	ideaConvertDryRun   bool
+	ideaWithFile        bool
+	ideaRank            bool
 )
 
 func init() {
@@ -215,17 +273,27 @@ func init() {
 	ideaConvertCmd.AddCommand(ideaConvertTaskCmd)
 
 	// Convert command flags
+	ideaConvertEpicCmd.Flags().StringVar(&ideaConvertStatus, "status", "", "Epic status (default draft)")
+	ideaConvertEpicCmd.Flags().StringVar(&ideaConvertPriority, "priority", "", "Epic priority: high, medium, or low (default medium)")
+	ideaConvertEpicCmd.Flags().BoolVar(&ideaConvertDryRun, "dry-run", false, "Preview the epic without creating it")
+
+	ideaConvertFeatureCmd.Flags().StringVar(&ideaConvertStatus, "status", "", "Feature status (default draft)")
+	ideaConvertFeatureCmd.Flags().BoolVar(&ideaConvertDryRun, "dry-run", false, "Preview the feature without creating it")
 	ideaConvertFeatureCmd.Flags().StringVar(&ideaConvertEpic, "epic", "", "Target epic key (required)")
 	_ = ideaConvertFeatureCmd.MarkFlagRequired("epic")
 
 	ideaConvertTaskCmd.Flags().StringVar(&ideaConvertEpic, "epic", "", "Target epic key (required)")
 	ideaConvertTaskCmd.Flags().StringVar(&ideaConvertFeature, "feature", "", "Target feature key (required)")
 	_ = ideaConvertTaskCmd.MarkFlagRequired("epic")
+	ideaConvertTaskCmd.Flags().StringVar(&ideaConvertStatus, "status", "", "Task status (default todo)")
+	ideaConvertTaskCmd.Flags().StringVar(&ideaConvertPriority, "priority", "", "Task priority 1-10 (default the idea's priority, or 5)")
+	ideaConvertTaskCmd.Flags().BoolVar(&ideaConvertDryRun, "dry-run", false, "Preview the task without creating it")
 	_ = ideaConvertTaskCmd.MarkFlagRequired("feature")
 
 	// List command flags
 	ideaListCmd.Flags().StringVar(&ideaStatus, "status", "", "Filter by status (new, on_hold, converted, archived)")
 	ideaListCmd.Flags().IntVar(&ideaPriority, "priority", 0, "Filter by priority (1-10)")
+	ideaListCmd.Flags().BoolVar(&ideaRank, "rank", false, "Sort by a weighted score of priority, age, and dependents")
 
 	// Create command flags
 	ideaCreateCmd.Flags().StringVar(&ideaDescription, "description", "", "Idea description")
@@ -235,6 +303,8 @@ func init() {
 	ideaCreateCmd.Flags().StringSliceVar(&ideaRelatedDocs, "related-docs", []string{}, "Related document paths")
 	ideaCreateCmd.Flags().StringSliceVar(&ideaDependencies, "depends-on", []string{}, "Dependent idea keys")
 	ideaCreateCmd.Flags().StringVar(&ideaStatus, "status", "new", "Initial status (new, on_hold, converted, archived)")
+	ideaCreateCmd.Flags().BoolVar(&ideaWithFile, "with-file", false, "Also create a markdown file for the idea from shark-templates/idea.md")
+	addIfNotExistsFlag(ideaCreateCmd, "idea", "")
 
 	// Update command flags
 	ideaUpdateCmd.Flags().StringVar(&ideaStatus, "status", "", "Update status")
@@ -267,7 +337,11 @@ func runIdeaList(cmd *cobra.Command, args []string) error {
 	// Build filter
 	var filter *repository.IdeaFilter
 	if ideaStatus != "" {
-		status := models.IdeaStatus(ideaStatus)
+		validated, err := ParseIdeaStatus(ideaStatus)
+		if err != nil {
+			return err
+		}
+		status := models.IdeaStatus(validated)
 		filter = &repository.IdeaFilter{Status: &status}
 	}
 
@@ -299,6 +373,10 @@ func runIdeaList(cmd *cobra.Command, args []string) error {
 		ideas = filtered
 	}
 
+	if ideaRank {
+		return outputRankedIdeas(ctx, repo, ideas, filter != nil)
+	}
+
 	// Output
 	if cli.GlobalConfig.JSON {
 		return cli.OutputJSON(ideas)
@@ -377,6 +455,9 @@ func runIdeaGet(cmd *cobra.Command, args []string) error {
 	if idea.Dependencies != nil && *idea.Dependencies != "" {
 		fmt.Printf("Dependencies: %s\n", *idea.Dependencies)
 	}
+	if idea.FilePath != nil && *idea.FilePath != "" {
+		fmt.Printf("File: %s\n", *idea.FilePath)
+	}
 
 	// Display conversion information if idea was converted
 	if idea.Status == models.IdeaStatusConverted {
@@ -394,6 +475,13 @@ func runIdeaGet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// IdeaCreateJSON is the idea create output: the idea, and whether it was
+// created or, with --if-not-exists, already existed
+type IdeaCreateJSON struct {
+	*models.Idea
+	Created bool `json:"created"`
+}
+
 // runIdeaCreate handles the idea create command
 func runIdeaCreate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
@@ -408,6 +496,20 @@ func runIdeaCreate(cmd *cobra.Command, args []string) error {
 
 	repo := repository.NewIdeaRepository(repoDb)
 
+	if ifNotExists, _ := cmd.Flags().GetBool(ifNotExistsFlag); ifNotExists {
+		existing, err := repo.GetByTitle(ctx, title)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if cli.GlobalConfig.JSON {
+				return cli.OutputJSON(IdeaCreateJSON{Idea: existing, Created: false})
+			}
+			cli.Info(fmt.Sprintf("Found existing idea %s: %s (nothing created)", existing.Key, existing.Title))
+			return nil
+		}
+	}
+
 	// Generate idea key
 	ideaKey, err := generateIdeaKey(ctx, repo)
 	if err != nil {
@@ -415,9 +517,11 @@ func runIdeaCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build idea with default status if not provided
-	status := ideaStatus
-	if status == "" {
-		status = "new"
+	status := "new"
+	if ideaStatus != "" {
+		if status, err = ParseIdeaStatus(ideaStatus); err != nil {
+			return err
+		}
 	}
 
 	idea := &models.Idea{
@@ -461,17 +565,38 @@ func runIdeaCreate(cmd *cobra.Command, args []string) error {
 		idea.Dependencies = &depsStr
 	}
 
+	// Write the idea file before the database entry so a failed write leaves nothing behind
+	var projectRoot string
+	if ideaWithFile {
+		projectRoot, err = cli.FindProjectRoot()
+		if err != nil {
+			return fmt.Errorf("failed to find project root: %w", err)
+		}
+		filePath, err := writeIdeaFile(projectRoot, idea)
+		if err != nil {
+			return fmt.Errorf("failed to create idea file: %w", err)
+		}
+		idea.FilePath = &filePath
+	}
+
 	// Create idea
 	if err := repo.Create(ctx, idea); err != nil {
+		if idea.FilePath != nil {
+			// Clean up file on DB error
+			_ = os.Remove(filepath.Join(projectRoot, *idea.FilePath))
+		}
 		return fmt.Errorf("failed to create idea: %w", err)
 	}
 
 	// Output
 	if cli.GlobalConfig.JSON {
-		return cli.OutputJSON(idea)
+		return cli.OutputJSON(IdeaCreateJSON{Idea: idea, Created: true})
 	}
 
 	cli.Success(fmt.Sprintf("Created idea %s: %s", idea.Key, idea.Title))
+	if idea.FilePath != nil {
+		cli.Info("File: %s", *idea.FilePath)
+	}
 	return nil
 }
 
@@ -504,7 +629,11 @@ func runIdeaUpdate(cmd *cobra.Command, args []string) error {
 		idea.Description = &ideaDescription
 	}
 	if cmd.Flags().Changed("status") {
-		idea.Status = models.IdeaStatus(ideaStatus)
+		status, err := ParseIdeaStatus(ideaStatus)
+		if err != nil {
+			return err
+		}
+		idea.Status = models.IdeaStatus(status)
 	}
 	if cmd.Flags().Changed("priority") {
 		idea.Priority = &ideaPriority
@@ -622,51 +751,44 @@ func generateIdeaKey(ctx context.Context, repo IdeaRepository) (string, error) {
 	return fmt.Sprintf("%s-%02d", baseKey, nextSeq), nil
 }
 
+// checkIdeaConvertible returns an error if an idea has already been converted
+func checkIdeaConvertible(idea *models.Idea) error {
+	if idea.Status != models.IdeaStatusConverted {
+		return nil
+	}
+	convertedInfo := ""
+	if idea.ConvertedToType != nil && idea.ConvertedToKey != nil {
+		convertedInfo = fmt.Sprintf(" to %s %s", *idea.ConvertedToType, *idea.ConvertedToKey)
+	}
+	return fmt.Errorf("idea %s is already converted%s", idea.Key, convertedInfo)
+}
+
 // convertIdeaToEpic converts an idea to an epic (for testing)
-func convertIdeaToEpic(ctx context.Context, ideaRepo IdeaRepository, epicRepo interface {
-	Create(context.Context, *models.Epic) error
-	GetByKey(context.Context, string) (*models.Epic, error)
-}, ideaKey string) (string, error) {
-	return convertIdeaToEpicWithKey(ctx, ideaRepo, epicRepo, ideaKey, "E15")
+func convertIdeaToEpic(ctx context.Context, ideaRepo IdeaRepository, ideaKey string) (string, error) {
+	return convertIdeaToEpicWithKey(ctx, ideaRepo, ideaKey, "E15", nil, ideaConvertOverrides{})
 }
 
-// convertIdeaToEpicWithKey converts an idea to an epic with a specified key
-func convertIdeaToEpicWithKey(ctx context.Context, ideaRepo IdeaRepository, epicRepo interface {
-	Create(context.Context, *models.Epic) error
-}, ideaKey, epicKey string) (string, error) {
+// convertIdeaToEpicWithKey converts an idea to an epic with a specified key.
+// filePath is the epic's markdown file, if one was written for it.
+func convertIdeaToEpicWithKey(ctx context.Context, ideaRepo IdeaRepository, ideaKey, epicKey string, filePath *string, overrides ideaConvertOverrides) (string, error) {
 	// Get the idea
 	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to get idea: %w", err)
 	}
-
-	// Check if already converted
-	if idea.Status == models.IdeaStatusConverted {
-		convertedInfo := ""
-		if idea.ConvertedToType != nil && idea.ConvertedToKey != nil {
-			convertedInfo = fmt.Sprintf(" to %s %s", *idea.ConvertedToType, *idea.ConvertedToKey)
-		}
-		return "", fmt.Errorf("idea %s is already converted%s", idea.Key, convertedInfo)
+	if err := checkIdeaConvertible(idea); err != nil {
+		return "", err
 	}
 
 	// Create epic from idea
-	epic := &models.Epic{
-		Key:           epicKey,
-		Title:         idea.Title,
-		Description:   idea.Description,
-		Status:        "draft",
-		Priority:      models.PriorityMedium,
-		BusinessValue: priorityPtr(models.PriorityMedium),
-	}
-
-	// Create the epic
-	if err := epicRepo.Create(ctx, epic); err != nil {
-		return "", fmt.Errorf("failed to create epic: %w", err)
+	epic, err := newIdeaEpic(idea, epicKey, filePath, overrides)
+	if err != nil {
+		return "", err
 	}
 
-	// Mark idea as converted
-	if err := ideaRepo.MarkAsConverted(ctx, idea.ID, "epic", epic.Key); err != nil {
-		return "", fmt.Errorf("failed to mark idea as converted: %w", err)
+	// Create the epic and mark the idea converted together
+	if err := ideaRepo.ConvertToEpic(ctx, idea.ID, epic); err != nil {
+		return "", fmt.Errorf("failed to convert idea to epic: %w", err)
 	}
 
 	return epic.Key, nil
@@ -675,8 +797,6 @@ func convertIdeaToEpicWithKey(ctx context.Context, ideaRepo IdeaRepository, epic
 // convertIdeaToFeature converts an idea to a feature (for testing)
 func convertIdeaToFeature(ctx context.Context, ideaRepo IdeaRepository, epicRepo interface {
 	GetByKey(context.Context, string) (*models.Epic, error)
-}, featureRepo interface {
-	Create(context.Context, *models.Feature) error
 }, ideaKey, epicKey string) (string, error) {
 	// Validate epic exists
 	epic, err := epicRepo.GetByKey(ctx, epicKey)
@@ -684,45 +804,30 @@ func convertIdeaToFeature(ctx context.Context, ideaRepo IdeaRepository, epicRepo
 		return "", fmt.Errorf("failed to get epic: %w", err)
 	}
 
-	return convertIdeaToFeatureWithKey(ctx, ideaRepo, epic, featureRepo, ideaKey, "E10-F03")
+	return convertIdeaToFeatureWithKey(ctx, ideaRepo, epic, ideaKey, "E10-F03", nil, ideaConvertOverrides{})
 }
 
-// convertIdeaToFeatureWithKey converts an idea to a feature with a specified key
-func convertIdeaToFeatureWithKey(ctx context.Context, ideaRepo IdeaRepository, epic *models.Epic, featureRepo interface {
-	Create(context.Context, *models.Feature) error
-}, ideaKey, featureKey string) (string, error) {
+// convertIdeaToFeatureWithKey converts an idea to a feature with a specified
+// key. filePath is the feature's markdown file, if one was written for it.
+func convertIdeaToFeatureWithKey(ctx context.Context, ideaRepo IdeaRepository, epic *models.Epic, ideaKey, featureKey string, filePath *string, overrides ideaConvertOverrides) (string, error) {
 	// Get the idea
 	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to get idea: %w", err)
 	}
-
-	// Check if already converted
-	if idea.Status == models.IdeaStatusConverted {
-		convertedInfo := ""
-		if idea.ConvertedToType != nil && idea.ConvertedToKey != nil {
-			convertedInfo = fmt.Sprintf(" to %s %s", *idea.ConvertedToType, *idea.ConvertedToKey)
-		}
-		return "", fmt.Errorf("idea %s is already converted%s", idea.Key, convertedInfo)
+	if err := checkIdeaConvertible(idea); err != nil {
+		return "", err
 	}
 
 	// Create feature from idea
-	feature := &models.Feature{
-		EpicID:      epic.ID,
-		Key:         featureKey,
-		Title:       idea.Title,
-		Description: idea.Description,
-		Status:      "draft",
-	}
-
-	// Create the feature
-	if err := featureRepo.Create(ctx, feature); err != nil {
-		return "", fmt.Errorf("failed to create feature: %w", err)
+	feature, err := newIdeaFeature(idea, epic, featureKey, filePath, overrides)
+	if err != nil {
+		return "", err
 	}
 
-	// Mark idea as converted
-	if err := ideaRepo.MarkAsConverted(ctx, idea.ID, "feature", feature.Key); err != nil {
-		return "", fmt.Errorf("failed to mark idea as converted: %w", err)
+	// Create the feature and mark the idea converted together
+	if err := ideaRepo.ConvertToFeature(ctx, idea.ID, feature); err != nil {
+		return "", fmt.Errorf("failed to convert idea to feature: %w", err)
 	}
 
 	return feature.Key, nil
@@ -733,8 +838,6 @@ func convertIdeaToTask(ctx context.Context, ideaRepo IdeaRepository, epicRepo in
 	GetByKey(context.Context, string) (*models.Epic, error)
 }, featureRepo interface {
 	GetByKey(context.Context, string) (*models.Feature, error)
-}, taskRepo interface {
-	Create(context.Context, *models.Task) error
 }, ideaKey, epicKey, featureKey string) (string, error) {
 	// Validate epic exists
 	epic, err := epicRepo.GetByKey(ctx, epicKey)
@@ -753,95 +856,42 @@ func convertIdeaToTask(ctx context.Context, ideaRepo IdeaRepository, epicRepo in
 		return "", fmt.Errorf("feature %s does not belong to epic %s", feature.Key, epic.Key)
 	}
 
-	// Get the idea
-	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to get idea: %w", err)
-	}
+	return convertIdeaToTaskWithKey(ctx, ideaRepo, feature, ideaKey, "T-E10-F02-005", nil, ideaConvertOverrides{})
+}
 
-	// Check if already converted
-	if idea.Status == models.IdeaStatusConverted {
-		convertedInfo := ""
-		if idea.ConvertedToType != nil && idea.ConvertedToKey != nil {
-			convertedInfo = fmt.Sprintf(" to %s %s", *idea.ConvertedToType, *idea.ConvertedToKey)
-		}
-		return "", fmt.Errorf("idea %s is already converted%s", idea.Key, convertedInfo)
-	}
+// ideaTaskAgentType is the agent type of tasks converted from ideas
+const ideaTaskAgentType = "general"
 
-	// Create task from idea
-	agentType := "general"
-	task := &models.Task{
-		FeatureID:   feature.ID,
-		Key:         "T-E10-F02-005", // Hardcoded for test compatibility
-		Title:       idea.Title,
-		Description: idea.Description,
-		Status:      "todo",
-		AgentType:   &agentType,
-		Priority:    5, // default priority
-	}
-
-	// Use idea priority if provided
+// ideaTaskPriority returns the priority of a task converted from idea: the
+// idea's priority, or the default of 5
+func ideaTaskPriority(idea *models.Idea) int {
 	if idea.Priority != nil {
-		task.Priority = *idea.Priority
+		return *idea.Priority
 	}
-
-	// Create the task
-	if err := taskRepo.Create(ctx, task); err != nil {
-		return "", fmt.Errorf("failed to create task: %w", err)
-	}
-
-	// Mark idea as converted
-	if err := ideaRepo.MarkAsConverted(ctx, idea.ID, "task", task.Key); err != nil {
-		return "", fmt.Errorf("failed to mark idea as converted: %w", err)
-	}
-
-	return task.Key, nil
+	return 5
 }
 
-// convertIdeaToTaskWithKey converts an idea to a task with a specified key
-func convertIdeaToTaskWithKey(ctx context.Context, ideaRepo IdeaRepository, epic *models.Epic, feature *models.Feature, taskRepo interface {
-	Create(context.Context, *models.Task) error
-}, ideaKey, taskKey string) (string, error) {
+// convertIdeaToTaskWithKey converts an idea to a task with a specified key.
+// filePath is the task's markdown file, if one was written for it.
+func convertIdeaToTaskWithKey(ctx context.Context, ideaRepo IdeaRepository, feature *models.Feature, ideaKey, taskKey string, filePath *string, overrides ideaConvertOverrides) (string, error) {
 	// Get the idea
 	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to get idea: %w", err)
 	}
-
-	// Check if already converted
-	if idea.Status == models.IdeaStatusConverted {
-		convertedInfo := ""
-		if idea.ConvertedToType != nil && idea.ConvertedToKey != nil {
-			convertedInfo = fmt.Sprintf(" to %s %s", *idea.ConvertedToType, *idea.ConvertedToKey)
-		}
-		return "", fmt.Errorf("idea %s is already converted%s", idea.Key, convertedInfo)
+	if err := checkIdeaConvertible(idea); err != nil {
+		return "", err
 	}
 
 	// Create task from idea
-	agentType := "general"
-	task := &models.Task{
-		FeatureID:   feature.ID,
-		Key:         taskKey,
-		Title:       idea.Title,
-		Description: idea.Description,
-		Status:      "todo",
-		AgentType:   &agentType,
-		Priority:    5, // default priority
-	}
-
-	// Use idea priority if provided
-	if idea.Priority != nil {
-		task.Priority = *idea.Priority
-	}
-
-	// Create the task
-	if err := taskRepo.Create(ctx, task); err != nil {
-		return "", fmt.Errorf("failed to create task: %w", err)
+	task, err := newIdeaTask(idea, feature, taskKey, filePath, overrides)
+	if err != nil {
+		return "", err
 	}
 
-	// Mark idea as converted
-	if err := ideaRepo.MarkAsConverted(ctx, idea.ID, "task", task.Key); err != nil {
-		return "", fmt.Errorf("failed to mark idea as converted: %w", err)
+	// Create the task and mark the idea converted together
+	if err := ideaRepo.ConvertToTask(ctx, idea.ID, task); err != nil {
+		return "", fmt.Errorf("failed to convert idea to task: %w", err)
 	}
 
 	return task.Key, nil
@@ -867,29 +917,75 @@ func runIdeaConvertEpic(cmd *cobra.Command, args []string) error {
 	ideaRepo := repository.NewIdeaRepository(repoDb)
 	epicRepo := repository.NewEpicRepository(repoDb)
 
-	// Generate next epic key
-	nextKey, err := getNextEpicKey(ctx, epicRepo)
+	// Get idea up front for its file path
+	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
+	if err != nil {
+		return fmt.Errorf("failed to get idea: %w", err)
+	}
+	if err := checkIdeaConvertible(idea); err != nil {
+		return err
+	}
+
+	// Generate next epic key; a dry run only looks it up, leaving it free
+	var nextKey string
+	if ideaConvertDryRun {
+		nextKey, err = epicRepo.PeekNextKey(ctx)
+	} else {
+		nextKey, err = epicRepo.NextKey(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate epic key: %w", err)
 	}
 
-	// Convert idea to epic
-	newKey, err := convertIdeaToEpicWithKey(ctx, ideaRepo, epicRepo, ideaKey, nextKey)
+	// The epic's file: docs/plan/{epic-key}-{slug}/epic.md
+	target := fmt.Sprintf("docs/plan/%s-%s/epic.md", nextKey, utils.GenerateSlug(idea.Title))
+
+	// Build the epic up front so bad overrides fail before any file is written
+	overrides := ideaConvertOverrides{Status: ideaConvertStatus, Priority: ideaConvertPriority}
+	epic, err := newIdeaEpic(idea, nextKey, &target, overrides)
 	if err != nil {
 		return err
 	}
+	if ideaConvertDryRun {
+		return outputIdeaConversionPreview(idea, "epic", nextKey, epic, target, []string{
+			fmt.Sprintf("Status: %s", epic.Status),
+			fmt.Sprintf("Priority: %s", epic.Priority),
+		})
+	}
 
-	// Output
-	if cli.GlobalConfig.JSON {
-		return cli.OutputJSON(map[string]interface{}{
-			"idea_key":     ideaKey,
-			"converted_to": newKey,
-			"type":         "epic",
+	created, err := writeConvertedIdeaEntityFile(idea, target, "epic", func() ([]byte, error) {
+		return renderEpicTemplate(templates.EpicTemplateData{
+			EpicKey:     nextKey,
+			EpicSlug:    nextKey,
+			Title:       idea.Title,
+			Description: ideaDescriptionText(idea),
+			FilePath:    target,
+			Date:        time.Now().Format("2006-01-02"),
 		})
+	})
+	if err != nil {
+		return err
 	}
 
-	cli.Success(fmt.Sprintf("Idea %s converted to epic %s", ideaKey, newKey))
-	return nil
+	// Convert idea to epic
+	newKey, err := convertIdeaToEpicWithKey(ctx, ideaRepo, ideaKey, nextKey, created.FilePath(), overrides)
+	if err != nil {
+		created.Remove()
+		return err
+	}
+
+	// Move the idea's file to the epic's file
+	filePath := moveConvertedIdeaFile(ctx, ideaRepo, idea, func(projectRoot string) (ideaFileMove, error) {
+		return ideaFileMove{
+			Target:      target,
+			KeyField:    "epic_key",
+			EntityKey:   newKey,
+			SetFilePath: epicRepo.UpdateFilePath,
+		}, nil
+	})
+
+	return outputIdeaConversion(ideaKey, newKey, "epic", created, filePath, nil,
+		fmt.Sprintf("Idea %s converted to epic %s", ideaKey, newKey))
 }
 
 // runIdeaConvertFeature handles converting an idea to a feature
@@ -908,36 +1004,108 @@ func runIdeaConvertFeature(cmd *cobra.Command, args []string) error {
 	epicRepo := repository.NewEpicRepository(repoDb)
 	featureRepo := repository.NewFeatureRepository(repoDb)
 
+	// Get idea up front for its file path
+	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
+	if err != nil {
+		return fmt.Errorf("failed to get idea: %w", err)
+	}
+	if err := checkIdeaConvertible(idea); err != nil {
+		return err
+	}
+
 	// Get epic first to generate feature key
 	epic, err := epicRepo.GetByKey(ctx, ideaConvertEpic)
 	if err != nil {
 		return fmt.Errorf("failed to get epic: %w", err)
 	}
 
-	// Generate next feature key
-	nextKey, err := getNextFeatureKey(ctx, featureRepo, epic.ID, epic.Key)
+	// Generate next feature key; a dry run only looks it up, leaving it free
+	var nextKey string
+	if ideaConvertDryRun {
+		nextKey, err = featureRepo.PeekNextKey(ctx, epic.ID, epic.Key)
+	} else {
+		nextKey, err = featureRepo.NextKey(ctx, epic.ID, epic.Key)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate feature key: %w", err)
 	}
 
-	// Convert idea to feature
-	newKey, err := convertIdeaToFeatureWithKey(ctx, ideaRepo, epic, featureRepo, ideaKey, nextKey)
+	// The feature's file: {epic-dir}/{feature-key}-{slug}/feature.md
+	featureSlug := fmt.Sprintf("%s-%s", nextKey, utils.GenerateSlug(idea.Title))
+	var target string
+	var targetErr error
+	if projectRoot, err := cli.FindProjectRoot(); err == nil {
+		epicDir, err := entityDir(projectRoot, epic.FilePath, func() (string, error) {
+			return pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot).ResolveEpicPath(ctx, epic.Key)
+		})
+		if err != nil {
+			targetErr = fmt.Errorf("failed to resolve epic directory: %w", err)
+		}
+		target = filepath.ToSlash(filepath.Join(epicDir, featureSlug, "feature.md"))
+	} else {
+		targetErr = err
+	}
+
+	// Build the feature up front so bad overrides fail before any file is written
+	overrides := ideaConvertOverrides{Status: ideaConvertStatus, Priority: ideaConvertPriority}
+	feature, err := newIdeaFeature(idea, epic, nextKey, nil, overrides)
 	if err != nil {
 		return err
 	}
+	if ideaConvertDryRun {
+		previewPath := ""
+		if targetErr == nil {
+			previewPath = target
+			feature.FilePath = &previewPath
+		}
+		return outputIdeaConversionPreview(idea, "feature", nextKey, feature, previewPath, []string{
+			fmt.Sprintf("Epic: %s", epic.Key),
+			fmt.Sprintf("Status: %s", feature.Status),
+		})
+	}
 
-	// Output
-	if cli.GlobalConfig.JSON {
-		return cli.OutputJSON(map[string]interface{}{
-			"idea_key":     ideaKey,
-			"converted_to": newKey,
-			"type":         "feature",
-			"epic":         ideaConvertEpic,
+	var created *convertedIdeaFile
+	if targetErr == nil {
+		created, err = writeConvertedIdeaEntityFile(idea, target, "feature", func() ([]byte, error) {
+			return renderFeatureTemplate(FeatureTemplateData{
+				EpicKey:     epic.Key,
+				FeatureKey:  nextKey,
+				FeatureSlug: featureSlug,
+				Title:       idea.Title,
+				Description: ideaDescriptionText(idea),
+				FilePath:    target,
+				Date:        time.Now().Format("2006-01-02"),
+			})
 		})
+		if err != nil {
+			return err
+		}
+	} else if idea.FilePath == nil || *idea.FilePath == "" {
+		return targetErr
 	}
 
-	cli.Success(fmt.Sprintf("Idea %s converted to feature %s in epic %s", ideaKey, newKey, ideaConvertEpic))
-	return nil
+	// Convert idea to feature
+	newKey, err := convertIdeaToFeatureWithKey(ctx, ideaRepo, epic, ideaKey, nextKey, created.FilePath(), overrides)
+	if err != nil {
+		created.Remove()
+		return err
+	}
+
+	// Move the idea's file to the feature's file
+	filePath := moveConvertedIdeaFile(ctx, ideaRepo, idea, func(projectRoot string) (ideaFileMove, error) {
+		if targetErr != nil {
+			return ideaFileMove{}, targetErr
+		}
+		return ideaFileMove{
+			Target:      target,
+			KeyField:    "feature_key",
+			EntityKey:   newKey,
+			SetFilePath: featureRepo.UpdateFilePath,
+		}, nil
+	})
+
+	return outputIdeaConversion(ideaKey, newKey, "feature", created, filePath, map[string]interface{}{"epic": ideaConvertEpic},
+		fmt.Sprintf("Idea %s converted to feature %s in epic %s", ideaKey, newKey, ideaConvertEpic))
 }
 
 // runIdeaConvertTask handles converting an idea to a task
@@ -957,6 +1125,15 @@ func runIdeaConvertTask(cmd *cobra.Command, args []string) error {
 	featureRepo := repository.NewFeatureRepository(repoDb)
 	taskRepo := repository.NewTaskRepository(repoDb)
 
+	// Get idea up front for its file path
+	idea, err := ideaRepo.GetByKey(ctx, ideaKey)
+	if err != nil {
+		return fmt.Errorf("failed to get idea: %w", err)
+	}
+	if err := checkIdeaConvertible(idea); err != nil {
+		return err
+	}
+
 	// Get epic and feature to validate
 	epic, err := epicRepo.GetByKey(ctx, ideaConvertEpic)
 	if err != nil {
@@ -973,30 +1150,134 @@ func runIdeaConvertTask(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("feature %s does not belong to epic %s", feature.Key, epic.Key)
 	}
 
-	// Generate task key using KeyGenerator
+	// Generate task key using KeyGenerator; a dry run only looks it up,
+	// leaving it free
 	kg := taskcreation.NewKeyGenerator(taskRepo, featureRepo)
-	taskKey, err := kg.GenerateTaskKey(ctx, epic.Key, feature.Key)
+	var taskKey string
+	if ideaConvertDryRun {
+		taskKey, err = kg.PeekTaskKey(ctx, epic.Key, feature.Key)
+	} else {
+		taskKey, err = kg.GenerateTaskKey(ctx, epic.Key, feature.Key)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate task key: %w", err)
 	}
 
+	// The task's file: {feature-dir}/tasks/{task-key}.md
+	var target string
+	var targetErr error
+	projectRoot, err := cli.FindProjectRoot()
+	if err == nil {
+		featureDir, err := entityDir(projectRoot, feature.FilePath, func() (string, error) {
+			return pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot).ResolveFeaturePath(ctx, feature.Key)
+		})
+		if err != nil {
+			targetErr = fmt.Errorf("failed to resolve feature directory: %w", err)
+		}
+		target = filepath.ToSlash(filepath.Join(featureDir, "tasks", taskKey+".md"))
+	} else {
+		targetErr = err
+	}
+
+	// Build the task up front so bad overrides fail before any file is written
+	overrides := ideaConvertOverrides{Status: ideaConvertStatus, Priority: ideaConvertPriority}
+	task, err := newIdeaTask(idea, feature, taskKey, nil, overrides)
+	if err != nil {
+		return err
+	}
+	if ideaConvertDryRun {
+		previewPath := ""
+		if targetErr == nil {
+			previewPath = target
+			task.FilePath = &previewPath
+		}
+		return outputIdeaConversionPreview(idea, "task", taskKey, task, previewPath, []string{
+			fmt.Sprintf("Feature: %s/%s", epic.Key, feature.Key),
+			fmt.Sprintf("Status: %s", task.Status),
+			fmt.Sprintf("Priority: %d", task.Priority),
+		})
+	}
+
+	var created *convertedIdeaFile
+	if targetErr == nil {
+		created, err = writeConvertedIdeaEntityFile(idea, target, "task", func() ([]byte, error) {
+			registry := templates.NewRegistry(filepath.Join(projectRoot, templates.DefaultProjectTemplateDir))
+			renderer := templates.NewRendererWithRegistry(templates.NewLoader(""), registry)
+			markdown, err := renderer.Render(ideaTaskAgentType, templates.TemplateData{
+				Key:         taskKey,
+				Title:       idea.Title,
+				Description: ideaDescriptionText(idea),
+				Epic:        epic.Key,
+				Feature:     feature.Key,
+				AgentType:   ideaTaskAgentType,
+				Priority:    task.Priority,
+				CreatedAt:   time.Now().UTC(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to render template: %w", err)
+			}
+			return []byte(markdown), nil
+		})
+		if err != nil {
+			return err
+		}
+	} else if idea.FilePath == nil || *idea.FilePath == "" {
+		return targetErr
+	}
+
 	// Convert idea to task
-	newKey, err := convertIdeaToTaskWithKey(ctx, ideaRepo, epic, feature, taskRepo, ideaKey, taskKey)
+	newKey, err := convertIdeaToTaskWithKey(ctx, ideaRepo, feature, ideaKey, taskKey, created.FilePath(), overrides)
 	if err != nil {
+		created.Remove()
 		return err
 	}
 
-	// Output
+	// Move the idea's file to the task's file
+	filePath := moveConvertedIdeaFile(ctx, ideaRepo, idea, func(projectRoot string) (ideaFileMove, error) {
+		if targetErr != nil {
+			return ideaFileMove{}, targetErr
+		}
+		return ideaFileMove{
+			Target:      target,
+			KeyField:    "task_key",
+			EntityKey:   newKey,
+			SetFilePath: taskRepo.UpdateFilePath,
+		}, nil
+	})
+
+	return outputIdeaConversion(ideaKey, newKey, "task", created, filePath,
+		map[string]interface{}{"epic": ideaConvertEpic, "feature": ideaConvertFeature},
+		fmt.Sprintf("Idea %s converted to task %s in %s/%s", ideaKey, newKey, ideaConvertEpic, ideaConvertFeature))
+}
+
+// outputIdeaConversion reports a conversion. created is the entity's new file,
+// if one was written; movedPath is where the idea's own file went, if anywhere.
+func outputIdeaConversion(ideaKey, newKey, entityType string, created *convertedIdeaFile, movedPath string, extra map[string]interface{}, message string) error {
+	filePath := movedPath
+	if created.FilePath() != nil {
+		filePath = *created.FilePath()
+	}
+
 	if cli.GlobalConfig.JSON {
-		return cli.OutputJSON(map[string]interface{}{
+		result := map[string]interface{}{
 			"idea_key":     ideaKey,
 			"converted_to": newKey,
-			"type":         "task",
-			"epic":         ideaConvertEpic,
-			"feature":      ideaConvertFeature,
-		})
+			"type":         entityType,
+		}
+		for k, v := range extra {
+			result[k] = v
+		}
+		if filePath != "" {
+			result["file_path"] = filePath
+		}
+		return cli.OutputJSON(result)
 	}
 
-	cli.Success(fmt.Sprintf("Idea %s converted to task %s in %s/%s", ideaKey, newKey, ideaConvertEpic, ideaConvertFeature))
+	cli.Success(message)
+	if movedPath != "" {
+		cli.Info("Moved idea file to %s", movedPath)
+	} else if filePath != "" {
+		cli.Info("File: %s", filePath)
+	}
 	return nil
 }
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// ideaConvertOverrides are the --status and --priority given to 'idea convert'.
+// Empty values keep the defaults.
+type ideaConvertOverrides struct {
+	Status   string
+	Priority string // high, medium, or low for epics; 1-10 for tasks
+}
+
+// newIdeaEpic builds the epic an idea converts to
+func newIdeaEpic(idea *models.Idea, key string, filePath *string, overrides ideaConvertOverrides) (*models.Epic, error) {
+	epic := &models.Epic{
+		Key:           key,
+		Title:         idea.Title,
+		Description:   idea.Description,
+		Status:        models.EpicStatusDraft,
+		Priority:      models.PriorityMedium,
+		BusinessValue: priorityPtr(models.PriorityMedium),
+		FilePath:      filePath,
+	}
+	if overrides.Status != "" {
+		if err := models.ValidateEpicStatus(overrides.Status); err != nil {
+			return nil, err
+		}
+		epic.Status = models.EpicStatus(overrides.Status)
+	}
+	if overrides.Priority != "" {
+		if err := models.ValidatePriority(overrides.Priority); err != nil {
+			return nil, err
+		}
+		epic.Priority = models.Priority(overrides.Priority)
+	}
+	return epic, nil
+}
+
+// newIdeaFeature builds the feature an idea converts to. Features have no
+// priority, so only the status can be overridden.
+func newIdeaFeature(idea *models.Idea, epic *models.Epic, key string, filePath *string, overrides ideaConvertOverrides) (*models.Feature, error) {
+	feature := &models.Feature{
+		EpicID:      epic.ID,
+		Key:         key,
+		Title:       idea.Title,
+		Description: idea.Description,
+		Status:      models.FeatureStatusDraft,
+		FilePath:    filePath,
+	}
+	if overrides.Priority != "" {
+		return nil, fmt.Errorf("features have no priority; --priority applies to epics and tasks")
+	}
+	if overrides.Status != "" {
+		if err := models.ValidateFeatureStatus(overrides.Status); err != nil {
+			return nil, err
+		}
+		feature.Status = models.FeatureStatus(overrides.Status)
+	}
+	return feature, nil
+}
+
+// newIdeaTask builds the task an idea converts to
+func newIdeaTask(idea *models.Idea, feature *models.Feature, key string, filePath *string, overrides ideaConvertOverrides) (*models.Task, error) {
+	agentType := ideaTaskAgentType
+	task := &models.Task{
+		FeatureID:   feature.ID,
+		Key:         key,
+		Title:       idea.Title,
+		Description: idea.Description,
+		Status:      "todo",
+		AgentType:   &agentType,
+		Priority:    ideaTaskPriority(idea),
+		FilePath:    filePath,
+	}
+	if overrides.Status != "" {
+		if err := models.ValidateTaskStatus(overrides.Status); err != nil {
+			return nil, err
+		}
+		task.Status = models.TaskStatus(overrides.Status)
+	}
+	if overrides.Priority != "" {
+		priority, err := strconv.Atoi(overrides.Priority)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task priority %q: must be a number from 1 to 10", overrides.Priority)
+		}
+		if err := models.ValidateTaskPriority(priority); err != nil {
+			return nil, err
+		}
+		task.Priority = priority
+	}
+	return task, nil
+}
+
+// IdeaConversionPreview is what 'idea convert --dry-run' reports
+type IdeaConversionPreview struct {
+	IdeaKey     string      `json:"idea_key"`
+	Type        string      `json:"type"`
+	DryRun      bool        `json:"dry_run"`
+	Entity      interface{} `json:"entity"`                 // The epic, feature, or task that would be created
+	FilePath    string      `json:"file_path,omitempty"`    // The entity's file
+	MovesFile   bool        `json:"moves_idea_file"`        // The idea's own file would move to file_path
+	Note        string      `json:"note,omitempty"`         // The note the idea's notes would become
+	RelatedDocs []string    `json:"related_docs,omitempty"` // Documents that would be linked
+}
+
+// outputIdeaConversionPreview reports the entity an idea would convert to
+// without creating it
+func outputIdeaConversionPreview(idea *models.Idea, entityType, key string, entity interface{}, filePath string, details []string) error {
+	preview := &IdeaConversionPreview{
+		IdeaKey:   idea.Key,
+		Type:      entityType,
+		DryRun:    true,
+		Entity:    entity,
+		FilePath:  filePath,
+		MovesFile: idea.FilePath != nil && *idea.FilePath != "",
+	}
+	if idea.Notes != nil && strings.TrimSpace(*idea.Notes) != "" {
+		preview.Note = repository.IdeaNotesContent(idea.Key, *idea.Notes)
+	}
+	if idea.RelatedDocs != nil {
+		preview.RelatedDocs = repository.IdeaRelatedDocPaths(*idea.RelatedDocs)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(preview)
+	}
+
+	fmt.Printf("%s %s: %s\n", entityType, key, idea.Title)
+	for _, detail := range details {
+		fmt.Printf("  %s\n", detail)
+	}
+	if filePath != "" {
+		if preview.MovesFile {
+			fmt.Printf("  File: %s (moved from %s)\n", filePath, *idea.FilePath)
+		} else {
+			fmt.Printf("  File: %s\n", filePath)
+		}
+	}
+	if preview.Note != "" {
+		where := "Note"
+		if entityType == "feature" {
+			where = "Appended to description"
+		}
+		fmt.Printf("  %s: %s\n", where, strings.ReplaceAll(preview.Note, "\n", "\n    "))
+	}
+	if len(preview.RelatedDocs) > 0 {
+		fmt.Printf("  Documents: %s\n", strings.Join(preview.RelatedDocs, ", "))
+	}
+	cli.Info("Dry run: idea %s would be converted to %s %s", idea.Key, entityType, key)
+	return nil
+}
@@ -33,25 +33,17 @@ func TestConvertIdeaToEpic_Success(t *testing.T) {
 			}
 			return nil, fmt.Errorf("idea not found")
 		},
-		MarkAsConvertedFunc: func(ctx context.Context, ideaID int64, convertedToType, convertedToKey string) error {
-			markedIdeaID = ideaID
-			markedType = convertedToType
-			markedKey = convertedToKey
-			return nil
-		},
-	}
-
-	mockEpicRepo := &MockEpicRepository{
-		CreateFunc: func(ctx context.Context, epic *models.Epic) error {
+		ConvertToEpicFunc: func(ctx context.Context, ideaID int64, epic *models.Epic) error {
 			createdEpic = epic
 			epic.ID = 1
-			epic.Key = "E15"
+			markedIdeaID = ideaID
+			markedType = "epic"
+			markedKey = epic.Key
 			return nil
 		},
 	}
 
-	// Call conversion function (will be implemented)
-	newKey, err := convertIdeaToEpic(ctx, mockIdeaRepo, mockEpicRepo, "I-2026-01-01-01")
+	newKey, err := convertIdeaToEpic(ctx, mockIdeaRepo, "I-2026-01-01-01")
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -109,9 +101,7 @@ func TestConvertIdeaToEpic_AlreadyConverted(t *testing.T) {
 		},
 	}
 
-	mockEpicRepo := &MockEpicRepository{}
-
-	_, err := convertIdeaToEpic(ctx, mockIdeaRepo, mockEpicRepo, "I-2026-01-01-01")
+	_, err := convertIdeaToEpic(ctx, mockIdeaRepo, "I-2026-01-01-01")
 
 	if err == nil {
 		t.Fatal("Expected error for already-converted idea, got none")
@@ -128,9 +118,7 @@ func TestConvertIdeaToEpic_IdeaNotFound(t *testing.T) {
 		},
 	}
 
-	mockEpicRepo := &MockEpicRepository{}
-
-	_, err := convertIdeaToEpic(ctx, mockIdeaRepo, mockEpicRepo, "I-9999-99-99-99")
+	_, err := convertIdeaToEpic(ctx, mockIdeaRepo, "I-9999-99-99-99")
 
 	if err == nil {
 		t.Fatal("Expected error for non-existent idea, got none")
@@ -158,10 +146,12 @@ func TestConvertIdeaToFeature_Success(t *testing.T) {
 				Status:      models.IdeaStatusNew,
 			}, nil
 		},
-		MarkAsConvertedFunc: func(ctx context.Context, ideaID int64, convertedToType, convertedToKey string) error {
+		ConvertToFeatureFunc: func(ctx context.Context, ideaID int64, feature *models.Feature) error {
+			createdFeature = feature
+			feature.ID = 1
 			markedIdeaID = ideaID
-			markedType = convertedToType
-			markedKey = convertedToKey
+			markedType = "feature"
+			markedKey = feature.Key
 			return nil
 		},
 	}
@@ -179,16 +169,7 @@ func TestConvertIdeaToFeature_Success(t *testing.T) {
 		},
 	}
 
-	mockFeatureRepo := &MockFeatureRepository{
-		CreateFunc: func(ctx context.Context, feature *models.Feature) error {
-			createdFeature = feature
-			feature.ID = 1
-			feature.Key = "E10-F03"
-			return nil
-		},
-	}
-
-	newKey, err := convertIdeaToFeature(ctx, mockIdeaRepo, mockEpicRepo, mockFeatureRepo, "I-2026-01-01-01", "E10")
+	newKey, err := convertIdeaToFeature(ctx, mockIdeaRepo, mockEpicRepo, "I-2026-01-01-01", "E10")
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -245,9 +226,7 @@ func TestConvertIdeaToFeature_EpicNotFound(t *testing.T) {
 		},
 	}
 
-	mockFeatureRepo := &MockFeatureRepository{}
-
-	_, err := convertIdeaToFeature(ctx, mockIdeaRepo, mockEpicRepo, mockFeatureRepo, "I-2026-01-01-01", "E99")
+	_, err := convertIdeaToFeature(ctx, mockIdeaRepo, mockEpicRepo, "I-2026-01-01-01", "E99")
 
 	if err == nil {
 		t.Fatal("Expected error for non-existent epic, got none")
@@ -277,10 +256,12 @@ func TestConvertIdeaToTask_Success(t *testing.T) {
 				Status:      models.IdeaStatusNew,
 			}, nil
 		},
-		MarkAsConvertedFunc: func(ctx context.Context, ideaID int64, convertedToType, convertedToKey string) error {
+		ConvertToTaskFunc: func(ctx context.Context, ideaID int64, task *models.Task) error {
+			createdTask = task
+			task.ID = 1
 			markedIdeaID = ideaID
-			markedType = convertedToType
-			markedKey = convertedToKey
+			markedType = "task"
+			markedKey = task.Key
 			return nil
 		},
 	}
@@ -302,16 +283,7 @@ func TestConvertIdeaToTask_Success(t *testing.T) {
 		},
 	}
 
-	mockTaskRepo := &MockTaskRepository{
-		CreateFunc: func(ctx context.Context, task *models.Task) error {
-			createdTask = task
-			task.ID = 1
-			task.Key = "T-E10-F02-005"
-			return nil
-		},
-	}
-
-	newKey, err := convertIdeaToTask(ctx, mockIdeaRepo, mockEpicRepo, mockFeatureRepo, mockTaskRepo, "I-2026-01-01-01", "E10", "E10-F02")
+	newKey, err := convertIdeaToTask(ctx, mockIdeaRepo, mockEpicRepo, mockFeatureRepo, "I-2026-01-01-01", "E10", "E10-F02")
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -379,11 +351,75 @@ func TestConvertIdeaToTask_FeatureNotInEpic(t *testing.T) {
 		},
 	}
 
-	mockTaskRepo := &MockTaskRepository{}
-
-	_, err := convertIdeaToTask(ctx, mockIdeaRepo, mockEpicRepo, mockFeatureRepo, mockTaskRepo, "I-2026-01-01-01", "E10", "E05-F01")
+	_, err := convertIdeaToTask(ctx, mockIdeaRepo, mockEpicRepo, mockFeatureRepo, "I-2026-01-01-01", "E10", "E05-F01")
 
 	if err == nil {
 		t.Fatal("Expected error for feature not in epic, got none")
 	}
 }
+
+// TestConvertIdeaToEpic_ConversionFails tests that a failed conversion is reported
+func TestConvertIdeaToEpic_ConversionFails(t *testing.T) {
+	ctx := context.Background()
+
+	mockIdeaRepo := &MockIdeaRepository{
+		GetByKeyFunc: func(ctx context.Context, key string) (*models.Idea, error) {
+			return &models.Idea{ID: 1, Key: key, Title: "Test Idea", CreatedDate: time.Now(), Status: models.IdeaStatusNew}, nil
+		},
+		ConvertToEpicFunc: func(ctx context.Context, ideaID int64, epic *models.Epic) error {
+			return fmt.Errorf("UNIQUE constraint failed: epics.key")
+		},
+	}
+
+	_, err := convertIdeaToEpicWithKey(ctx, mockIdeaRepo, "I-2026-01-01-01", "E15", stringPtr("docs/plan/E15-test-idea/epic.md"), ideaConvertOverrides{})
+	if err == nil {
+		t.Fatal("Expected error when the conversion fails, got none")
+	}
+}
+
+// TestNewIdeaEntities_Overrides tests --status and --priority at conversion time
+func TestNewIdeaEntities_Overrides(t *testing.T) {
+	priority := 8
+	idea := &models.Idea{Key: "I-2026-01-01-01", Title: "Idea", Priority: &priority}
+	epic := &models.Epic{ID: 10, Key: "E10"}
+	feature := &models.Feature{ID: 5, EpicID: 10, Key: "E10-F02"}
+
+	newEpic, err := newIdeaEpic(idea, "E15", nil, ideaConvertOverrides{Status: "active", Priority: "high"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if newEpic.Status != models.EpicStatusActive || newEpic.Priority != models.PriorityHigh {
+		t.Errorf("Expected active/high epic, got %s/%s", newEpic.Status, newEpic.Priority)
+	}
+
+	newTask, err := newIdeaTask(idea, feature, "T-E10-F02-005", nil, ideaConvertOverrides{Status: "ready_for_development", Priority: "2"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if newTask.Status != "ready_for_development" || newTask.Priority != 2 {
+		t.Errorf("Expected ready_for_development/2 task, got %s/%d", newTask.Status, newTask.Priority)
+	}
+
+	// Defaults when nothing is overridden
+	newTask, err = newIdeaTask(idea, feature, "T-E10-F02-005", nil, ideaConvertOverrides{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if newTask.Status != "todo" || newTask.Priority != 8 {
+		t.Errorf("Expected todo/8 task, got %s/%d", newTask.Status, newTask.Priority)
+	}
+
+	// Invalid overrides are rejected
+	if _, err := newIdeaEpic(idea, "E15", nil, ideaConvertOverrides{Priority: "urgent"}); err == nil {
+		t.Error("Expected error for invalid epic priority")
+	}
+	if _, err := newIdeaFeature(idea, epic, "E10-F03", nil, ideaConvertOverrides{Status: "bogus"}); err == nil {
+		t.Error("Expected error for invalid feature status")
+	}
+	if _, err := newIdeaFeature(idea, epic, "E10-F03", nil, ideaConvertOverrides{Priority: "high"}); err == nil {
+		t.Error("Expected error for feature priority")
+	}
+	if _, err := newIdeaTask(idea, feature, "T-E10-F02-005", nil, ideaConvertOverrides{Priority: "11"}); err == nil {
+		t.Error("Expected error for out of range task priority")
+	}
+}
@@ -0,0 +1,424 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// ideaExportVersion is the current idea export file format version
+const ideaExportVersion = 1
+
+// defaultPersonalIdeaFile is the file name used in the personal idea store when none is given
+const defaultPersonalIdeaFile = "ideas.json"
+
+// IdeaExportFile is the on-disk format for exported ideas
+type IdeaExportFile struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Ideas      []*models.Idea `json:"ideas"`
+}
+
+// IdeaImportResult summarizes the outcome of an import
+type IdeaImportResult struct {
+	Imported []string          `json:"imported"`
+	Skipped  []string          `json:"skipped"`
+	Renamed  map[string]string `json:"renamed"` // original key -> new key
+}
+
+// ideaExportCmd exports ideas to a JSON file
+var ideaExportCmd = &cobra.Command{
+	Use:         "export [file]",
+	Short:       "Export ideas to a JSON file",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Export ideas to a JSON file so they can be moved between projects.
+
+With --mine, the file is written to your personal idea store in the user
+config directory (e.g. ~/.config/shark/ideas.json) instead of the project,
+merging with any ideas already there. Combine with --remove to take the
+ideas out of the shared project database entirely.
+
+Without a file argument (and without --mine), the export is written to stdout.
+Archived and converted ideas are excluded unless --status is given.
+
+Examples:
+  shark idea export ideas.json                  Export to a file
+  shark idea export --mine                      Export to the personal store
+  shark idea export --mine ideas.json --remove  Move ideas to the personal store
+  shark idea export --status=on_hold > parked.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIdeaExport,
+}
+
+// ideaImportCmd imports ideas from a JSON file
+var ideaImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import ideas from a JSON file",
+	Long: `Import ideas previously written by 'shark idea export'.
+
+Ideas whose key and title already exist in the project are skipped. Ideas
+whose key is taken by a different idea are assigned the next free key for
+the same date, and dependencies between imported ideas are updated to match.
+
+With --mine, ideas are read from your personal idea store.
+
+Examples:
+  shark idea import ideas.json
+  shark idea import --mine
+  shark idea import --mine ideas.json --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIdeaImport,
+}
+
+var (
+	ideaPersonal bool
+	ideaRemove   bool
+)
+
+func init() {
+	ideaCmd.AddCommand(ideaExportCmd)
+	ideaCmd.AddCommand(ideaImportCmd)
+
+	ideaExportCmd.Flags().BoolVar(&ideaPersonal, "mine", false, "Write to the personal idea store in the user config directory")
+	ideaExportCmd.Flags().StringVar(&ideaStatus, "status", "", "Only export ideas with this status (new, on_hold, converted, archived)")
+	ideaExportCmd.Flags().BoolVar(&ideaRemove, "remove", false, "Delete exported ideas from the project database after export")
+
+	ideaImportCmd.Flags().BoolVar(&ideaPersonal, "mine", false, "Read from the personal idea store in the user config directory")
+}
+
+// runIdeaExport handles the idea export command
+func runIdeaExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var file string
+	if len(args) == 1 {
+		file = args[0]
+	}
+
+	path, err := resolveIdeaFilePath(file, ideaPersonal)
+	if err != nil {
+		return err
+	}
+	if ideaRemove && path == "" {
+		return fmt.Errorf("--remove requires an output file or --mine")
+	}
+
+	// Get database connection (cloud-aware)
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	repo := repository.NewIdeaRepository(repoDb)
+
+	ideas, err := selectIdeasForExport(ctx, repo, ideaStatus)
+	if err != nil {
+		return err
+	}
+
+	export := &IdeaExportFile{
+		Version:    ideaExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Ideas:      ideas,
+	}
+
+	if path == "" {
+		return writeIdeaExport(os.Stdout, export)
+	}
+
+	// Merge with an existing personal store rather than overwriting it
+	if ideaPersonal {
+		existing, err := readIdeaExportFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if existing != nil {
+			export.Ideas = mergeIdeas(existing.Ideas, ideas)
+		}
+	}
+
+	if err := saveIdeaExportFile(path, export); err != nil {
+		return err
+	}
+
+	if ideaRemove {
+		for _, idea := range ideas {
+			if err := repo.Delete(ctx, idea.ID); err != nil {
+				return fmt.Errorf("exported to %s but failed to remove idea %s: %w", path, idea.Key, err)
+			}
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		keys := make([]string, len(ideas))
+		for i, idea := range ideas {
+			keys[i] = idea.Key
+		}
+		return cli.OutputJSON(map[string]interface{}{
+			"path":     path,
+			"exported": keys,
+			"count":    len(ideas),
+			"removed":  ideaRemove,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Exported %d idea(s) to %s", len(ideas), path))
+	if ideaRemove {
+		cli.Info(fmt.Sprintf("Removed %d idea(s) from the project database", len(ideas)))
+	}
+	return nil
+}
+
+// runIdeaImport handles the idea import command
+func runIdeaImport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var file string
+	if len(args) == 1 {
+		file = args[0]
+	}
+
+	path, err := resolveIdeaFilePath(file, ideaPersonal)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("an input file or --mine is required")
+	}
+
+	export, err := readIdeaExportFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("idea file not found: %s", path)
+		}
+		return err
+	}
+
+	// Get database connection (cloud-aware)
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	repo := repository.NewIdeaRepository(repoDb)
+
+	result, err := importIdeas(ctx, repo, export.Ideas)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+
+	cli.Success(fmt.Sprintf("Imported %d idea(s) from %s", len(result.Imported), path))
+	for oldKey, newKey := range result.Renamed {
+		cli.Info(fmt.Sprintf("%s imported as %s (key already in use)", oldKey, newKey))
+	}
+	if len(result.Skipped) > 0 {
+		cli.Info(fmt.Sprintf("Skipped %d idea(s) already in this project", len(result.Skipped)))
+	}
+	return nil
+}
+
+// personalIdeaStoreDir returns the directory of the personal idea store
+func personalIdeaStoreDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "shark"), nil
+}
+
+// resolveIdeaFilePath resolves the export/import file path.
+// With personal set, relative names are resolved inside the personal idea store.
+// Returns an empty path when neither a file nor the personal store is requested.
+func resolveIdeaFilePath(file string, personal bool) (string, error) {
+	if !personal {
+		return file, nil
+	}
+
+	dir, err := personalIdeaStoreDir()
+	if err != nil {
+		return "", err
+	}
+	if file == "" {
+		return filepath.Join(dir, defaultPersonalIdeaFile), nil
+	}
+	if filepath.IsAbs(file) {
+		return file, nil
+	}
+	return filepath.Join(dir, file), nil
+}
+
+// selectIdeasForExport lists ideas to export. Without a status filter,
+// archived and converted ideas are excluded since they belong to this project.
+func selectIdeasForExport(ctx context.Context, repo IdeaRepository, status string) ([]*models.Idea, error) {
+	var filter *repository.IdeaFilter
+	if status != "" {
+		if err := models.ValidateIdeaStatus(status); err != nil {
+			return nil, err
+		}
+		s := models.IdeaStatus(status)
+		filter = &repository.IdeaFilter{Status: &s}
+	}
+
+	ideas, err := repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	selected := []*models.Idea{}
+	for _, idea := range ideas {
+		if status == "" && (idea.Status == models.IdeaStatusArchived || idea.Status == models.IdeaStatusConverted) {
+			continue
+		}
+		selected = append(selected, idea)
+	}
+	return selected, nil
+}
+
+// mergeIdeas appends incoming ideas to existing ones, dropping incoming
+// ideas that are already present with the same key and title
+func mergeIdeas(existing, incoming []*models.Idea) []*models.Idea {
+	seen := make(map[string]bool, len(existing))
+	for _, idea := range existing {
+		seen[idea.Key+"\x00"+idea.Title] = true
+	}
+
+	merged := append([]*models.Idea{}, existing...)
+	for _, idea := range incoming {
+		if seen[idea.Key+"\x00"+idea.Title] {
+			continue
+		}
+		merged = append(merged, idea)
+	}
+	return merged
+}
+
+// importIdeas creates the given ideas in the repository, skipping duplicates
+// and re-keying ideas whose key is already taken by a different idea
+func importIdeas(ctx context.Context, repo IdeaRepository, ideas []*models.Idea) (*IdeaImportResult, error) {
+	result := &IdeaImportResult{
+		Imported: []string{},
+		Skipped:  []string{},
+		Renamed:  map[string]string{},
+	}
+
+	var created []*models.Idea
+	for _, src := range ideas {
+		idea := *src
+		idea.ID = 0
+		idea.ConvertedToType = nil
+		idea.ConvertedToKey = nil
+		idea.ConvertedAt = nil
+
+		if existing, err := repo.GetByKey(ctx, idea.Key); err == nil && existing != nil {
+			if existing.Title == idea.Title {
+				result.Skipped = append(result.Skipped, idea.Key)
+				continue
+			}
+
+			dateStr := idea.CreatedDate.Format("2006-01-02")
+			seq, err := repo.GetNextSequenceForDate(ctx, dateStr)
+			if err != nil {
+				return result, fmt.Errorf("failed to allocate key for idea %s: %w", idea.Key, err)
+			}
+			newKey := fmt.Sprintf("I-%s-%02d", dateStr, seq)
+			result.Renamed[idea.Key] = newKey
+			idea.Key = newKey
+		}
+
+		if err := repo.Create(ctx, &idea); err != nil {
+			return result, fmt.Errorf("failed to import idea %s: %w", src.Key, err)
+		}
+		result.Imported = append(result.Imported, idea.Key)
+		created = append(created, &idea)
+	}
+
+	// Point dependencies at the new keys of re-keyed ideas
+	if len(result.Renamed) > 0 {
+		for _, idea := range created {
+			if idea.Dependencies == nil {
+				continue
+			}
+			var deps []string
+			if err := json.Unmarshal([]byte(*idea.Dependencies), &deps); err != nil {
+				continue
+			}
+			changed := false
+			for i, dep := range deps {
+				if newKey, ok := result.Renamed[dep]; ok {
+					deps[i] = newKey
+					changed = true
+				}
+			}
+			if !changed {
+				continue
+			}
+			data, err := json.Marshal(deps)
+			if err != nil {
+				return result, fmt.Errorf("failed to marshal dependencies: %w", err)
+			}
+			depsStr := string(data)
+			idea.Dependencies = &depsStr
+			if err := repo.Update(ctx, idea); err != nil {
+				return result, fmt.Errorf("failed to update dependencies for idea %s: %w", idea.Key, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// readIdeaExportFile reads and decodes an idea export file
+func readIdeaExportFile(path string) (*IdeaExportFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var export IdeaExportFile
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse idea file %s: %w", path, err)
+	}
+	if export.Version > ideaExportVersion {
+		return nil, fmt.Errorf("idea file %s has unsupported version %d (max %d)", path, export.Version, ideaExportVersion)
+	}
+	return &export, nil
+}
+
+// saveIdeaExportFile writes an idea export file, creating parent directories
+func saveIdeaExportFile(path string, export *IdeaExportFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return writeIdeaExport(f, export)
+}
+
+// writeIdeaExport encodes an idea export as indented JSON
+func writeIdeaExport(w *os.File, export *IdeaExportFile) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return fmt.Errorf("failed to write idea export: %w", err)
+	}
+	return nil
+}
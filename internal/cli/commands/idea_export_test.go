@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectIdeasForExport(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockIdeaRepository{
+		ListFunc: func(ctx context.Context, filter *repository.IdeaFilter) ([]*models.Idea, error) {
+			return []*models.Idea{
+				{Key: "I-2026-01-01-01", Title: "New", Status: models.IdeaStatusNew},
+				{Key: "I-2026-01-01-02", Title: "Parked", Status: models.IdeaStatusOnHold},
+				{Key: "I-2026-01-01-03", Title: "Done", Status: models.IdeaStatusConverted},
+				{Key: "I-2026-01-01-04", Title: "Old", Status: models.IdeaStatusArchived},
+			}, nil
+		},
+	}
+
+	ideas, err := selectIdeasForExport(ctx, repo, "")
+	require.NoError(t, err)
+	require.Len(t, ideas, 2)
+	assert.Equal(t, "I-2026-01-01-01", ideas[0].Key)
+	assert.Equal(t, "I-2026-01-01-02", ideas[1].Key)
+
+	_, err = selectIdeasForExport(ctx, repo, "bogus")
+	assert.Error(t, err)
+}
+
+func TestImportIdeas(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	stored := map[string]*models.Idea{
+		"I-2026-01-01-01": {Key: "I-2026-01-01-01", Title: "Same idea"},
+		"I-2026-01-01-02": {Key: "I-2026-01-01-02", Title: "Someone else's idea"},
+	}
+	var updated []*models.Idea
+
+	repo := &MockIdeaRepository{
+		GetByKeyFunc: func(ctx context.Context, key string) (*models.Idea, error) {
+			if idea, ok := stored[key]; ok {
+				return idea, nil
+			}
+			return nil, fmt.Errorf("idea not found with key %q", key)
+		},
+		GetNextSequenceForDateFunc: func(ctx context.Context, dateStr string) (int, error) {
+			return len(stored) + 1, nil
+		},
+		CreateFunc: func(ctx context.Context, idea *models.Idea) error {
+			stored[idea.Key] = idea
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, idea *models.Idea) error {
+			updated = append(updated, idea)
+			return nil
+		},
+	}
+
+	deps := `["I-2026-01-01-02"]`
+	convertedType := "epic"
+	incoming := []*models.Idea{
+		{ID: 7, Key: "I-2026-01-01-01", Title: "Same idea", CreatedDate: created, Status: models.IdeaStatusNew},
+		{ID: 8, Key: "I-2026-01-01-02", Title: "My idea", CreatedDate: created, Status: models.IdeaStatusNew, ConvertedToType: &convertedType},
+		{ID: 9, Key: "I-2026-01-01-05", Title: "Depends on mine", CreatedDate: created, Status: models.IdeaStatusNew, Dependencies: &deps},
+	}
+
+	result, err := importIdeas(ctx, repo, incoming)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"I-2026-01-01-01"}, result.Skipped)
+	assert.Equal(t, map[string]string{"I-2026-01-01-02": "I-2026-01-01-03"}, result.Renamed)
+	assert.Equal(t, []string{"I-2026-01-01-03", "I-2026-01-01-05"}, result.Imported)
+
+	renamed := stored["I-2026-01-01-03"]
+	require.NotNil(t, renamed)
+	assert.Equal(t, "My idea", renamed.Title)
+	assert.Nil(t, renamed.ConvertedToType)
+	assert.Equal(t, int64(8), incoming[1].ID, "source ideas must not be mutated")
+
+	require.Len(t, updated, 1)
+	assert.Equal(t, "I-2026-01-01-05", updated[0].Key)
+	assert.Equal(t, `["I-2026-01-01-03"]`, *updated[0].Dependencies)
+}
+
+func TestMergeIdeas(t *testing.T) {
+	existing := []*models.Idea{{Key: "I-2026-01-01-01", Title: "A"}}
+	incoming := []*models.Idea{
+		{Key: "I-2026-01-01-01", Title: "A"},
+		{Key: "I-2026-01-01-01", Title: "B"},
+	}
+
+	merged := mergeIdeas(existing, incoming)
+	require.Len(t, merged, 2)
+	assert.Equal(t, "B", merged[1].Title)
+}
+
+func TestIdeaExportFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "ideas.json")
+	export := &IdeaExportFile{
+		Version:    ideaExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Ideas:      []*models.Idea{{Key: "I-2026-01-01-01", Title: "Portable idea", Status: models.IdeaStatusNew}},
+	}
+
+	require.NoError(t, saveIdeaExportFile(path, export))
+
+	loaded, err := readIdeaExportFile(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Ideas, 1)
+	assert.Equal(t, "Portable idea", loaded.Ideas[0].Title)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99, "ideas": []}`), 0644))
+	_, err = readIdeaExportFile(path)
+	assert.Error(t, err)
+}
+
+func TestResolveIdeaFilePath(t *testing.T) {
+	path, err := resolveIdeaFilePath("out.json", false)
+	require.NoError(t, err)
+	assert.Equal(t, "out.json", path)
+
+	path, err = resolveIdeaFilePath("", false)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir, err := personalIdeaStoreDir()
+	require.NoError(t, err)
+
+	path, err = resolveIdeaFilePath("", true)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, defaultPersonalIdeaFile), path)
+
+	path, err = resolveIdeaFilePath("work.json", true)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "work.json"), path)
+}
@@ -0,0 +1,295 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/fileops"
+	init_pkg "github.com/jwwelbor/shark-task-manager/internal/init"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+)
+
+// ideaFileDir is where idea files are created, relative to the project root
+const ideaFileDir = "docs/plan/ideas"
+
+// IdeaTemplateData holds data for idea template rendering
+type IdeaTemplateData struct {
+	IdeaKey     string
+	Title       string
+	Description string
+	Notes       string
+	FilePath    string
+	Date        string
+}
+
+// renderIdeaTemplate renders shark-templates/idea.md with the given data,
+// falling back to the built-in template for projects that don't have one yet
+func renderIdeaTemplate(data IdeaTemplateData) ([]byte, error) {
+	templateContent, err := os.ReadFile(projectTemplatePath("idea.md"))
+	if errors.Is(err, os.ErrNotExist) {
+		templateContent, err = init_pkg.DefaultTemplate("idea.md")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idea template: %w", err)
+	}
+
+	tmpl, err := template.New("idea").Parse(string(templateContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse idea template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render idea template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ideaFilePath returns the default file path for an idea, relative to the
+// project root: docs/plan/ideas/{idea-key}-{slug}.md
+func ideaFilePath(idea *models.Idea) string {
+	return fmt.Sprintf("%s/%s-%s.md", ideaFileDir, idea.Key, utils.GenerateSlug(idea.Title))
+}
+
+// writeIdeaFile renders the idea template into the idea's default file and
+// returns the file path relative to the project root
+func writeIdeaFile(projectRoot string, idea *models.Idea) (string, error) {
+	relPath := ideaFilePath(idea)
+	absPath := filepath.Join(projectRoot, relPath)
+
+	data := IdeaTemplateData{
+		IdeaKey:  idea.Key,
+		Title:    idea.Title,
+		FilePath: relPath,
+		Date:     idea.CreatedDate.Format("2006-01-02"),
+	}
+	if idea.Description != nil {
+		data.Description = *idea.Description
+	}
+	if idea.Notes != nil {
+		data.Notes = *idea.Notes
+	}
+
+	content, err := renderIdeaTemplate(data)
+	if err != nil {
+		return "", err
+	}
+
+	writer := fileops.NewEntityFileWriter()
+	if _, err := writer.WriteEntityFile(fileops.WriteOptions{
+		Content:     content,
+		ProjectRoot: projectRoot,
+		FilePath:    absPath,
+		Verbose:     cli.GlobalConfig.Verbose,
+		EntityType:  "idea",
+		Logger: func(message string) {
+			cli.Info(message)
+		},
+	}); err != nil {
+		return "", err
+	}
+
+	return relPath, nil
+}
+
+// ideaFileMove describes where a converted idea's file goes
+type ideaFileMove struct {
+	Target      string // Destination relative to the project root
+	KeyField    string // Frontmatter field that receives the entity key (epic_key, feature_key, task_key)
+	EntityKey   string
+	SetFilePath func(ctx context.Context, key string, path *string) error
+}
+
+// carryOverIdeaFile moves a converted idea's file to the new entity's location,
+// stamps the entity key into its frontmatter, records the path on the entity,
+// and clears the idea's file_path. Returns the new path, or "" when the idea
+// has no file. The idea's file is left in place if anything fails.
+func carryOverIdeaFile(ctx context.Context, ideaRepo IdeaRepository, idea *models.Idea, projectRoot string, move ideaFileMove) (string, error) {
+	if idea.FilePath == nil || *idea.FilePath == "" {
+		return "", nil
+	}
+
+	source := *idea.FilePath
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(projectRoot, source)
+	}
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to read idea file: %w", err)
+	}
+
+	// A file whose frontmatter can't be parsed is carried over unchanged
+	if updated, err := parser.UpdateFrontmatterField(string(content), move.KeyField, move.EntityKey); err == nil {
+		content = []byte(updated)
+	}
+
+	target := filepath.Join(projectRoot, move.Target)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", move.Target, err)
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("%s already exists", move.Target)
+		}
+		return "", fmt.Errorf("failed to create %s: %w", move.Target, err)
+	}
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(target)
+		return "", fmt.Errorf("failed to write %s: %w", move.Target, err)
+	}
+
+	relPath := filepath.ToSlash(move.Target)
+	if err := move.SetFilePath(ctx, move.EntityKey, &relPath); err != nil {
+		_ = os.Remove(target)
+		return "", fmt.Errorf("failed to record file path on %s: %w", move.EntityKey, err)
+	}
+
+	if err := os.Remove(source); err != nil {
+		return relPath, fmt.Errorf("copied idea file to %s but failed to remove %s: %w", relPath, *idea.FilePath, err)
+	}
+	if err := ideaRepo.UpdateFilePath(ctx, idea.Key, nil); err != nil {
+		return relPath, fmt.Errorf("failed to clear idea file path: %w", err)
+	}
+
+	return relPath, nil
+}
+
+// entityDir returns the directory holding an entity's file, relative to the
+// project root. Recorded paths may be relative or absolute; resolve is used
+// when the entity has no recorded path.
+func entityDir(projectRoot string, filePath *string, resolve func() (string, error)) (string, error) {
+	path := ""
+	if filePath != nil && *filePath != "" {
+		path = *filePath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, path)
+		}
+	} else {
+		resolved, err := resolve()
+		if err != nil {
+			return "", err
+		}
+		path = resolved
+	}
+
+	dir := relativeToRoot(projectRoot, filepath.Dir(path))
+	if filepath.IsAbs(dir) || strings.HasPrefix(dir, "..") {
+		return "", fmt.Errorf("%s is outside the project root", dir)
+	}
+	return dir, nil
+}
+
+// moveConvertedIdeaFile carries a converted idea's file over to the new entity.
+// The conversion has already succeeded by then, so problems are reported as a
+// warning rather than an error. plan computes the move once the project root
+// is known. Returns the entity's new file path, or "" if nothing was moved.
+func moveConvertedIdeaFile(ctx context.Context, ideaRepo IdeaRepository, idea *models.Idea, plan func(projectRoot string) (ideaFileMove, error)) string {
+	if idea.FilePath == nil || *idea.FilePath == "" {
+		return ""
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		cli.Warning(fmt.Sprintf("Idea file %s was not moved: %v", *idea.FilePath, err))
+		return ""
+	}
+	move, err := plan(projectRoot)
+	if err != nil {
+		cli.Warning(fmt.Sprintf("Idea file %s was not moved: %v", *idea.FilePath, err))
+		return ""
+	}
+
+	filePath, err := carryOverIdeaFile(ctx, ideaRepo, idea, projectRoot, move)
+	if err != nil {
+		cli.Warning(fmt.Sprintf("Idea file %s was not fully moved: %v", *idea.FilePath, err))
+	}
+	return filePath
+}
+
+// convertedIdeaFile is the markdown file written for an entity converted from
+// an idea that had no file of its own
+type convertedIdeaFile struct {
+	relPath string // Relative to the project root
+	absPath string // Set only if the file was newly written rather than linked
+}
+
+// FilePath returns the file's path relative to the project root, or nil if
+// no file was written
+func (f *convertedIdeaFile) FilePath() *string {
+	if f == nil {
+		return nil
+	}
+	return &f.relPath
+}
+
+// Remove deletes the file after a failed conversion, unless it already
+// existed and was only linked
+func (f *convertedIdeaFile) Remove() {
+	if f != nil && f.absPath != "" {
+		_ = os.Remove(f.absPath)
+	}
+}
+
+// writeConvertedIdeaEntityFile writes the file of an entity converted from
+// idea at relPath, rendered by render from the entity's template, the way the
+// entity's create command does. An idea with a file of its own keeps it
+// instead (see moveConvertedIdeaFile), so nothing is written and nil is returned.
+func writeConvertedIdeaEntityFile(idea *models.Idea, relPath, entityType string, render func() ([]byte, error)) (*convertedIdeaFile, error) {
+	if idea.FilePath != nil && *idea.FilePath != "" {
+		return nil, nil
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find project root: %w", err)
+	}
+	content, err := render()
+	if err != nil {
+		return nil, fmt.Errorf("%w (run 'shark init' to create templates)", err)
+	}
+
+	writer := fileops.NewEntityFileWriter()
+	result, err := writer.WriteEntityFile(fileops.WriteOptions{
+		Content:         content,
+		ProjectRoot:     projectRoot,
+		FilePath:        relPath,
+		Verbose:         cli.GlobalConfig.Verbose,
+		EntityType:      entityType,
+		UseAtomicWrite:  true,
+		CreateIfMissing: true, // A default path, as in task create
+		Logger: func(message string) {
+			cli.Info(message)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s file: %w", entityType, err)
+	}
+
+	file := &convertedIdeaFile{relPath: relPath}
+	if result.Written {
+		file.absPath = result.AbsolutePath
+	}
+	return file, nil
+}
+
+// ideaDescriptionText returns an idea's description, or "" if it has none
+func ideaDescriptionText(idea *models.Idea) string {
+	if idea.Description == nil {
+		return ""
+	}
+	return *idea.Description
+}
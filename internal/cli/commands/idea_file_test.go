@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+func TestIdeaFilePath(t *testing.T) {
+	idea := &models.Idea{Key: "I-2026-01-01-01", Title: "Offline Mode for Mobile"}
+
+	got := ideaFilePath(idea)
+	want := "docs/plan/ideas/I-2026-01-01-01-offline-mode-for-mobile.md"
+	if got != want {
+		t.Errorf("ideaFilePath() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderIdeaTemplate_BuiltInFallback(t *testing.T) {
+	// The test's working directory has no shark-templates/idea.md
+	content, err := renderIdeaTemplate(IdeaTemplateData{
+		IdeaKey:     "I-2026-01-01-01",
+		Title:       "Offline mode",
+		Description: "Work without a network",
+		Date:        "2026-01-01",
+	})
+	if err != nil {
+		t.Fatalf("renderIdeaTemplate() error = %v", err)
+	}
+
+	for _, want := range []string{"idea_key: I-2026-01-01-01", "# Offline mode", "Work without a network"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("rendered template missing %q", want)
+		}
+	}
+}
+
+// newIdeaWithFile creates an idea file in a temp project root
+func newIdeaWithFile(t *testing.T) (*models.Idea, string) {
+	t.Helper()
+	root := t.TempDir()
+	filePath := "docs/plan/ideas/I-2026-01-01-01-offline-mode.md"
+	abs := filepath.Join(root, filePath)
+	if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nidea_key: I-2026-01-01-01\ntitle: Offline mode\n---\n\n# Offline mode\n\nWritten notes.\n"
+	if err := os.WriteFile(abs, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return &models.Idea{
+		ID:          1,
+		Key:         "I-2026-01-01-01",
+		Title:       "Offline mode",
+		CreatedDate: time.Now(),
+		FilePath:    &filePath,
+		Status:      models.IdeaStatusConverted,
+	}, root
+}
+
+func TestCarryOverIdeaFile(t *testing.T) {
+	ctx := context.Background()
+	idea, root := newIdeaWithFile(t)
+
+	var clearedKey string
+	ideaRepo := &MockIdeaRepository{
+		UpdateFilePathFunc: func(ctx context.Context, ideaKey string, newFilePath *string) error {
+			if newFilePath == nil {
+				clearedKey = ideaKey
+			}
+			return nil
+		},
+	}
+	var recordedKey, recordedPath string
+	move := ideaFileMove{
+		Target:    "docs/plan/E07-offline-mode/epic.md",
+		KeyField:  "epic_key",
+		EntityKey: "E07",
+		SetFilePath: func(ctx context.Context, key string, path *string) error {
+			recordedKey, recordedPath = key, *path
+			return nil
+		},
+	}
+
+	got, err := carryOverIdeaFile(ctx, ideaRepo, idea, root, move)
+	if err != nil {
+		t.Fatalf("carryOverIdeaFile() error = %v", err)
+	}
+	if got != move.Target {
+		t.Errorf("carryOverIdeaFile() = %q, want %q", got, move.Target)
+	}
+	if recordedKey != "E07" || recordedPath != move.Target {
+		t.Errorf("entity file path recorded as %s=%q", recordedKey, recordedPath)
+	}
+	if clearedKey != idea.Key {
+		t.Errorf("idea file path not cleared")
+	}
+
+	if _, err := os.Stat(filepath.Join(root, *idea.FilePath)); !os.IsNotExist(err) {
+		t.Errorf("idea file should be moved, stat err = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(root, move.Target))
+	if err != nil {
+		t.Fatalf("moved file not found: %v", err)
+	}
+	for _, want := range []string{"epic_key: E07", "idea_key: I-2026-01-01-01", "Written notes."} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("moved file missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestCarryOverIdeaFile_TargetExists(t *testing.T) {
+	ctx := context.Background()
+	idea, root := newIdeaWithFile(t)
+
+	target := "docs/plan/E07-offline-mode/epic.md"
+	if err := os.MkdirAll(filepath.Join(root, filepath.Dir(target)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, target), []byte("existing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	move := ideaFileMove{
+		Target:    target,
+		KeyField:  "epic_key",
+		EntityKey: "E07",
+		SetFilePath: func(ctx context.Context, key string, path *string) error {
+			t.Error("entity file path should not be recorded")
+			return nil
+		},
+	}
+
+	if _, err := carryOverIdeaFile(ctx, &MockIdeaRepository{}, idea, root, move); err == nil {
+		t.Fatal("expected error when target exists")
+	}
+	if _, err := os.Stat(filepath.Join(root, *idea.FilePath)); err != nil {
+		t.Errorf("idea file should stay in place: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(root, target)); string(content) != "existing" {
+		t.Errorf("existing target was overwritten")
+	}
+}
+
+func TestCarryOverIdeaFile_RecordFailure(t *testing.T) {
+	ctx := context.Background()
+	idea, root := newIdeaWithFile(t)
+
+	move := ideaFileMove{
+		Target:    "docs/plan/E07-offline-mode/epic.md",
+		KeyField:  "epic_key",
+		EntityKey: "E07",
+		SetFilePath: func(ctx context.Context, key string, path *string) error {
+			return errors.New("database is locked")
+		},
+	}
+
+	if _, err := carryOverIdeaFile(ctx, &MockIdeaRepository{}, idea, root, move); err == nil {
+		t.Fatal("expected error when the entity path can't be recorded")
+	}
+	if _, err := os.Stat(filepath.Join(root, move.Target)); !os.IsNotExist(err) {
+		t.Errorf("copied file should be removed, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, *idea.FilePath)); err != nil {
+		t.Errorf("idea file should stay in place: %v", err)
+	}
+}
+
+func TestCarryOverIdeaFile_NoFile(t *testing.T) {
+	idea := &models.Idea{Key: "I-2026-01-01-01", Title: "No file"}
+	move := ideaFileMove{
+		SetFilePath: func(ctx context.Context, key string, path *string) error {
+			t.Error("nothing should be recorded for an idea without a file")
+			return nil
+		},
+	}
+
+	got, err := carryOverIdeaFile(context.Background(), &MockIdeaRepository{}, idea, t.TempDir(), move)
+	if err != nil || got != "" {
+		t.Errorf("carryOverIdeaFile() = %q, %v; want \"\", nil", got, err)
+	}
+}
@@ -0,0 +1,350 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// ideaMergeCmd combines duplicate ideas into one
+var ideaMergeCmd = &cobra.Command{
+	Use:   "merge <idea-key> <idea-key>... [--into=<idea-key>]",
+	Short: "Combine duplicate ideas into one",
+	Long: `Merge duplicate ideas into one of them (--into, default: the first key).
+
+The other ideas' descriptions and notes are appended to the merged idea, their
+dependencies and related documents are added to its own, and it takes the
+highest priority among them. The other ideas are archived. Ideas that depended
+on an archived idea now depend on the merged idea instead. Every idea involved
+gets a note recording the merge.
+
+Examples:
+  shark idea merge I-2026-01-01-01 I-2026-01-03-02
+  shark idea merge I-2026-01-01-01 I-2026-01-03-02 I-2026-01-04-01 --into=I-2026-01-03-02`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runIdeaMerge,
+}
+
+// ideaSplitCmd breaks an idea into several new ones
+var ideaSplitCmd = &cobra.Command{
+	Use:   "split <idea-key> <title> <title>...",
+	Short: "Split an idea into new ideas",
+	Long: `Create a new idea for each title, linked back to the original by a note on
+both sides. New ideas start as new and copy the original's priority,
+dependencies, and related documents. The original is archived unless --keep
+is given.
+
+Examples:
+  shark idea split I-2026-01-01-01 "Offline reads" "Offline writes"
+  shark idea split I-2026-01-01-01 "Plugin API" --keep`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runIdeaSplit,
+}
+
+// Merge and split flags
+var (
+	ideaMergeInto string
+	ideaSplitKeep bool
+)
+
+func init() {
+	ideaCmd.AddCommand(ideaMergeCmd)
+	ideaCmd.AddCommand(ideaSplitCmd)
+
+	ideaMergeCmd.Flags().StringVar(&ideaMergeInto, "into", "", "Idea to keep (default: the first key)")
+	ideaSplitCmd.Flags().BoolVar(&ideaSplitKeep, "keep", false, "Keep the original idea open instead of archiving it")
+}
+
+// ideaMergeResult is the JSON output of idea merge
+type ideaMergeResult struct {
+	Idea       *models.Idea `json:"idea"`
+	Merged     []string     `json:"merged"`
+	Redirected []string     `json:"redirected"` // Ideas whose dependencies now point at the merged idea
+}
+
+// ideaSplitResult is the JSON output of idea split
+type ideaSplitResult struct {
+	Original *models.Idea   `json:"original"`
+	Ideas    []*models.Idea `json:"ideas"`
+}
+
+// runIdeaMerge handles the idea merge command
+func runIdeaMerge(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	intoKey := ideaMergeInto
+	if intoKey == "" {
+		intoKey = args[0]
+	}
+
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	repo := repository.NewIdeaRepository(repoDb)
+
+	var target *models.Idea
+	var sources []*models.Idea
+	seen := map[string]bool{}
+	for _, key := range args {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		idea, err := repo.GetByKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get idea %s: %w", key, err)
+		}
+		if idea.Key == intoKey {
+			target = idea
+		} else {
+			sources = append(sources, idea)
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("--into %s must be one of the ideas being merged", intoKey)
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("at least two different ideas are required to merge")
+	}
+
+	result, err := mergeDuplicateIdeas(ctx, repo, target, sources, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+	cli.Success(fmt.Sprintf("Merged %s into %s", strings.Join(result.Merged, ", "), target.Key))
+	if len(result.Redirected) > 0 {
+		cli.Info("Dependencies updated: %s", strings.Join(result.Redirected, ", "))
+	}
+	return nil
+}
+
+// runIdeaSplit handles the idea split command
+func runIdeaSplit(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	titles := make([]string, 0, len(args)-1)
+	for _, title := range args[1:] {
+		if title = strings.TrimSpace(title); title == "" {
+			return fmt.Errorf("idea titles cannot be empty")
+		}
+		titles = append(titles, title)
+	}
+
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	repo := repository.NewIdeaRepository(repoDb)
+
+	original, err := repo.GetByKey(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get idea %s: %w", args[0], err)
+	}
+
+	ideas, err := splitIdea(ctx, repo, original, titles, ideaSplitKeep, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(ideaSplitResult{Original: original, Ideas: ideas})
+	}
+	cli.Success(fmt.Sprintf("Split %s into %d idea(s)", original.Key, len(ideas)))
+	for _, idea := range ideas {
+		fmt.Printf("  %s: %s\n", idea.Key, idea.Title)
+	}
+	return nil
+}
+
+// mergeDuplicateIdeas folds sources into target, archives the sources, and points
+// dependencies on a source at target instead
+func mergeDuplicateIdeas(ctx context.Context, repo IdeaRepository, target *models.Idea, sources []*models.Idea, now time.Time) (*ideaMergeResult, error) {
+	for _, idea := range append([]*models.Idea{target}, sources...) {
+		if idea.Status == models.IdeaStatusConverted {
+			return nil, fmt.Errorf("idea %s was already converted and cannot be merged", idea.Key)
+		}
+	}
+
+	date := now.Format("2006-01-02")
+	result := &ideaMergeResult{Idea: target, Merged: []string{}, Redirected: []string{}}
+	merged := map[string]bool{}
+	for _, source := range sources {
+		merged[source.Key] = true
+	}
+
+	deps := ideaJSONList(target.Dependencies)
+	docs := ideaJSONList(target.RelatedDocs)
+	for _, source := range sources {
+		heading := fmt.Sprintf("From %s (%s):", source.Key, source.Title)
+		if source.Description != nil && strings.TrimSpace(*source.Description) != "" {
+			target.Description = appendIdeaText(target.Description, heading+"\n"+strings.TrimSpace(*source.Description), "\n\n")
+		}
+		if source.Notes != nil && strings.TrimSpace(*source.Notes) != "" {
+			target.Notes = appendIdeaText(target.Notes, heading+"\n"+strings.TrimSpace(*source.Notes), "\n")
+		}
+		deps = appendUnique(deps, ideaJSONList(source.Dependencies)...)
+		docs = appendUnique(docs, ideaJSONList(source.RelatedDocs)...)
+		if source.Priority != nil && (target.Priority == nil || *source.Priority < *target.Priority) {
+			priority := *source.Priority
+			target.Priority = &priority
+		}
+		target.Notes = appendIdeaText(target.Notes, fmt.Sprintf("[%s] Merged %s (%s) into this idea", date, source.Key, source.Title), "\n")
+		result.Merged = append(result.Merged, source.Key)
+	}
+
+	// An idea can't depend on itself or on the ideas folded into it
+	kept := deps[:0]
+	for _, dep := range deps {
+		if dep != target.Key && !merged[dep] {
+			kept = append(kept, dep)
+		}
+	}
+	target.Dependencies = ideaJSONString(kept)
+	target.RelatedDocs = ideaJSONString(docs)
+
+	if err := repo.Update(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to update idea %s: %w", target.Key, err)
+	}
+	for _, source := range sources {
+		source.Status = models.IdeaStatusArchived
+		source.Notes = appendIdeaText(source.Notes, fmt.Sprintf("[%s] Merged into %s", date, target.Key), "\n")
+		if err := repo.Update(ctx, source); err != nil {
+			return nil, fmt.Errorf("failed to archive idea %s: %w", source.Key, err)
+		}
+	}
+
+	all, err := repo.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ideas: %w", err)
+	}
+	for _, idea := range all {
+		if idea.Key == target.Key || merged[idea.Key] {
+			continue
+		}
+		deps := ideaJSONList(idea.Dependencies)
+		redirected := false
+		updated := []string{}
+		for _, dep := range deps {
+			if merged[dep] {
+				dep = target.Key
+				redirected = true
+			}
+			updated = appendUnique(updated, dep)
+		}
+		if !redirected {
+			continue
+		}
+		idea.Dependencies = ideaJSONString(updated)
+		if err := repo.Update(ctx, idea); err != nil {
+			return nil, fmt.Errorf("failed to update dependencies of idea %s: %w", idea.Key, err)
+		}
+		result.Redirected = append(result.Redirected, idea.Key)
+	}
+
+	return result, nil
+}
+
+// splitIdea creates an idea for each title, linked back to original by notes,
+// and archives the original unless keep is set
+func splitIdea(ctx context.Context, repo IdeaRepository, original *models.Idea, titles []string, keep bool, now time.Time) ([]*models.Idea, error) {
+	if original.Status == models.IdeaStatusConverted || original.Status == models.IdeaStatusArchived {
+		return nil, fmt.Errorf("idea %s is %s and cannot be split", original.Key, original.Status)
+	}
+
+	date := now.Format("2006-01-02")
+	ideas := make([]*models.Idea, 0, len(titles))
+	for _, title := range titles {
+		key, err := generateIdeaKey(ctx, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate idea key: %w", err)
+		}
+		notes := fmt.Sprintf("[%s] Split from %s (%s)", date, original.Key, original.Title)
+		idea := &models.Idea{
+			Key:          key,
+			Title:        title,
+			CreatedDate:  now,
+			Status:       models.IdeaStatusNew,
+			Priority:     original.Priority,
+			Notes:        &notes,
+			Dependencies: original.Dependencies,
+			RelatedDocs:  original.RelatedDocs,
+		}
+		if err := repo.Create(ctx, idea); err != nil {
+			return nil, fmt.Errorf("failed to create idea %q: %w", title, err)
+		}
+		ideas = append(ideas, idea)
+	}
+
+	keys := make([]string, 0, len(ideas))
+	for _, idea := range ideas {
+		keys = append(keys, idea.Key)
+	}
+	original.Notes = appendIdeaText(original.Notes, fmt.Sprintf("[%s] Split into %s", date, strings.Join(keys, ", ")), "\n")
+	if !keep {
+		original.Status = models.IdeaStatusArchived
+	}
+	if err := repo.Update(ctx, original); err != nil {
+		return nil, fmt.Errorf("failed to update idea %s: %w", original.Key, err)
+	}
+	return ideas, nil
+}
+
+// appendIdeaText appends text to an optional idea field
+func appendIdeaText(field *string, text, separator string) *string {
+	if field != nil && strings.TrimSpace(*field) != "" {
+		text = strings.TrimRight(*field, "\n") + separator + text
+	}
+	return &text
+}
+
+// ideaJSONList parses an idea's JSON array field; malformed values are empty
+func ideaJSONList(field *string) []string {
+	var values []string
+	if field != nil && *field != "" {
+		_ = json.Unmarshal([]byte(*field), &values)
+	}
+	return values
+}
+
+// ideaJSONString encodes a JSON array field, or nil when there are no values
+func ideaJSONString(values []string) *string {
+	if len(values) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(values)
+	encoded := string(data)
+	return &encoded
+}
+
+// appendUnique appends the values not already in list
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeDuplicateIdeas(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	strPtr := func(s string) *string { return &s }
+	low, high := 6, 2
+
+	target := &models.Idea{
+		Key: "I-2026-01-01-01", Title: "Offline mode", Status: models.IdeaStatusNew,
+		Description:  strPtr("Work without a network"),
+		Priority:     &low,
+		Dependencies: strPtr(`["I-2026-01-01-05"]`),
+	}
+	source := &models.Idea{
+		Key: "I-2026-01-03-02", Title: "Airplane mode", Status: models.IdeaStatusNew,
+		Description:  strPtr("Queue changes while offline"),
+		Notes:        strPtr("Asked for by three customers"),
+		Priority:     &high,
+		Dependencies: strPtr(`["I-2026-01-01-05", "I-2026-01-02-01", "I-2026-01-01-01"]`),
+		RelatedDocs:  strPtr(`["docs/offline.md"]`),
+	}
+	dependent := &models.Idea{
+		Key: "I-2026-01-04-01", Title: "Sync conflicts", Status: models.IdeaStatusNew,
+		Dependencies: strPtr(`["I-2026-01-03-02", "I-2026-01-01-01"]`),
+	}
+
+	updated := map[string]*models.Idea{}
+	repo := &MockIdeaRepository{
+		UpdateFunc: func(ctx context.Context, idea *models.Idea) error {
+			updated[idea.Key] = idea
+			return nil
+		},
+		ListFunc: func(ctx context.Context, filter *repository.IdeaFilter) ([]*models.Idea, error) {
+			return []*models.Idea{target, source, dependent}, nil
+		},
+	}
+
+	result, err := mergeDuplicateIdeas(context.Background(), repo, target, []*models.Idea{source}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"I-2026-01-03-02"}, result.Merged)
+	assert.Equal(t, []string{"I-2026-01-04-01"}, result.Redirected)
+
+	assert.Equal(t, "Work without a network\n\nFrom I-2026-01-03-02 (Airplane mode):\nQueue changes while offline", *target.Description)
+	assert.Equal(t, "From I-2026-01-03-02 (Airplane mode):\nAsked for by three customers\n[2026-03-04] Merged I-2026-01-03-02 (Airplane mode) into this idea", *target.Notes)
+	assert.Equal(t, 2, *target.Priority)
+	assert.JSONEq(t, `["I-2026-01-01-05", "I-2026-01-02-01"]`, *target.Dependencies)
+	assert.JSONEq(t, `["docs/offline.md"]`, *target.RelatedDocs)
+
+	assert.Equal(t, models.IdeaStatusArchived, source.Status)
+	assert.Contains(t, *source.Notes, "[2026-03-04] Merged into I-2026-01-01-01")
+	assert.JSONEq(t, `["I-2026-01-01-01"]`, *dependent.Dependencies)
+	assert.Len(t, updated, 3)
+
+	converted := &models.Idea{Key: "I-2026-01-05-01", Status: models.IdeaStatusConverted}
+	_, err = mergeDuplicateIdeas(context.Background(), repo, target, []*models.Idea{converted}, now)
+	assert.ErrorContains(t, err, "already converted")
+}
+
+func TestSplitIdea(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	priority := 3
+	deps := `["I-2026-01-01-05"]`
+	original := &models.Idea{Key: "I-2026-01-01-01", Title: "Offline mode", Status: models.IdeaStatusNew, Priority: &priority, Dependencies: &deps}
+
+	sequence := 0
+	var created []*models.Idea
+	repo := &MockIdeaRepository{
+		GetNextSequenceForDateFunc: func(ctx context.Context, dateStr string) (int, error) {
+			sequence++
+			return sequence, nil
+		},
+		CreateFunc: func(ctx context.Context, idea *models.Idea) error {
+			created = append(created, idea)
+			return nil
+		},
+	}
+
+	ideas, err := splitIdea(context.Background(), repo, original, []string{"Offline reads", "Offline writes"}, false, now)
+	require.NoError(t, err)
+	require.Len(t, ideas, 2)
+	assert.Equal(t, created, ideas)
+
+	for _, idea := range ideas {
+		assert.Equal(t, models.IdeaStatusNew, idea.Status)
+		assert.Equal(t, 3, *idea.Priority)
+		assert.Equal(t, deps, *idea.Dependencies)
+		assert.Equal(t, "[2026-03-04] Split from I-2026-01-01-01 (Offline mode)", *idea.Notes)
+	}
+	assert.Equal(t, "Offline writes", ideas[1].Title)
+	assert.NotEqual(t, ideas[0].Key, ideas[1].Key)
+
+	assert.Equal(t, models.IdeaStatusArchived, original.Status)
+	assert.Equal(t, "[2026-03-04] Split into "+ideas[0].Key+", "+ideas[1].Key, *original.Notes)
+
+	_, err = splitIdea(context.Background(), repo, original, []string{"Again"}, false, now)
+	assert.ErrorContains(t, err, "cannot be split")
+}
@@ -0,0 +1,457 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// Weights of the idea rank score components; they sum to 1
+const (
+	ideaRankPriorityWeight   = 0.6
+	ideaRankAgeWeight        = 0.2
+	ideaRankDependentsWeight = 0.2
+
+	// ideaRankMaxAgeDays is the age at which an idea earns the full age score
+	ideaRankMaxAgeDays = 90
+	// ideaRankMaxDependents is the dependent count that earns the full dependents score
+	ideaRankMaxDependents = 3
+)
+
+// ideaTriageCmd walks through open ideas to set priority, status, and notes
+var ideaTriageCmd = &cobra.Command{
+	Use:   "triage [idea-key...]",
+	Short: "Set priority, status, and notes on open ideas quickly",
+	Long: `Walk through open ideas (status new or on_hold) one at a time and set
+their priority, status, and notes. Ideas without a priority come first, then
+the oldest. Pass idea keys to triage only those ideas.
+
+At each prompt, enter any combination of:
+  1-10           set the priority (1 is highest)
+  new, hold      set the status (n and h also work)
+  archive        archive the idea (a also works)
+  : text         append text to the idea's notes
+Press Enter to skip an idea and q to stop.
+
+With --priority, --status, or --notes, no prompts are shown: the changes are
+applied to every idea key given.
+
+Examples:
+  shark idea triage
+  shark idea triage I-2026-01-01-01 I-2026-01-02-01
+  shark idea triage I-2026-01-01-01 --priority=2 --status=on_hold
+  shark idea triage I-2026-01-01-01 --notes="Revisit after the beta"
+
+Prompt input examples:
+  3 hold: waiting on design review
+  a: superseded by I-2026-01-04-02`,
+	RunE: runIdeaTriage,
+}
+
+// Triage flags
+var (
+	ideaTriagePriority int
+	ideaTriageStatus   string
+	ideaTriageNotes    string
+)
+
+func init() {
+	ideaCmd.AddCommand(ideaTriageCmd)
+
+	ideaTriageCmd.Flags().IntVar(&ideaTriagePriority, "priority", 0, "Set priority (1-10) without prompting")
+	ideaTriageCmd.Flags().StringVar(&ideaTriageStatus, "status", "", "Set status (new, on_hold, archived) without prompting")
+	ideaTriageCmd.Flags().StringVar(&ideaTriageNotes, "notes", "", "Append to notes without prompting")
+}
+
+// triageEdit is one set of changes for an idea, from a prompt or from flags
+type triageEdit struct {
+	Priority *int
+	Status   *models.IdeaStatus
+	Note     string
+	Quit     bool
+}
+
+// empty reports whether the edit changes nothing
+func (e triageEdit) empty() bool {
+	return e.Priority == nil && e.Status == nil && e.Note == ""
+}
+
+// apply applies the edit to the idea, appending the note to any existing notes
+func (e triageEdit) apply(idea *models.Idea) {
+	if e.Priority != nil {
+		priority := *e.Priority
+		idea.Priority = &priority
+	}
+	if e.Status != nil {
+		idea.Status = *e.Status
+	}
+	if e.Note != "" {
+		notes := e.Note
+		if idea.Notes != nil && strings.TrimSpace(*idea.Notes) != "" {
+			notes = strings.TrimRight(*idea.Notes, "\n") + "\n" + e.Note
+		}
+		idea.Notes = &notes
+	}
+}
+
+// triageStatusAliases maps prompt words to the statuses triage may set
+var triageStatusAliases = map[string]models.IdeaStatus{
+	"n":        models.IdeaStatusNew,
+	"new":      models.IdeaStatusNew,
+	"h":        models.IdeaStatusOnHold,
+	"hold":     models.IdeaStatusOnHold,
+	"on_hold":  models.IdeaStatusOnHold,
+	"a":        models.IdeaStatusArchived,
+	"archive":  models.IdeaStatusArchived,
+	"archived": models.IdeaStatusArchived,
+}
+
+// parseTriageInput parses one prompt line, e.g. "3 hold: waiting on design"
+func parseTriageInput(line string) (triageEdit, error) {
+	var edit triageEdit
+
+	commands, note, _ := strings.Cut(line, ":")
+	edit.Note = strings.TrimSpace(note)
+
+	for _, word := range strings.Fields(strings.ToLower(commands)) {
+		if word == "q" || word == "quit" {
+			edit.Quit = true
+			continue
+		}
+		if n, err := strconv.Atoi(word); err == nil {
+			if n < 1 || n > 10 {
+				return triageEdit{}, fmt.Errorf("priority must be between 1 and 10, got %d", n)
+			}
+			if edit.Priority != nil {
+				return triageEdit{}, fmt.Errorf("more than one priority given")
+			}
+			edit.Priority = &n
+			continue
+		}
+		status, ok := triageStatusAliases[word]
+		if !ok {
+			return triageEdit{}, fmt.Errorf("unrecognized input %q", word)
+		}
+		if edit.Status != nil {
+			return triageEdit{}, fmt.Errorf("more than one status given")
+		}
+		edit.Status = &status
+	}
+
+	return edit, nil
+}
+
+// triageFlagEdit builds the edit given by the --priority, --status, and
+// --notes flags, and reports whether any of them was set
+func triageFlagEdit(cmd *cobra.Command) (triageEdit, bool, error) {
+	var edit triageEdit
+	if cmd.Flags().Changed("priority") {
+		if ideaTriagePriority < 1 || ideaTriagePriority > 10 {
+			return edit, false, fmt.Errorf("priority must be between 1 and 10, got %d", ideaTriagePriority)
+		}
+		priority := ideaTriagePriority
+		edit.Priority = &priority
+	}
+	if cmd.Flags().Changed("status") {
+		status := models.IdeaStatus(ideaTriageStatus)
+		if status == models.IdeaStatusConverted {
+			return edit, false, fmt.Errorf("ideas cannot be marked converted by triage; use 'shark idea convert'")
+		}
+		if err := models.ValidateIdeaStatus(ideaTriageStatus); err != nil {
+			return edit, false, err
+		}
+		edit.Status = &status
+	}
+	if cmd.Flags().Changed("notes") {
+		edit.Note = strings.TrimSpace(ideaTriageNotes)
+	}
+	return edit, !edit.empty(), nil
+}
+
+// triageResult lists the ideas changed and skipped by a triage session
+type triageResult struct {
+	Updated []string `json:"updated"`
+	Skipped []string `json:"skipped"`
+}
+
+// runIdeaTriage handles the idea triage command
+func runIdeaTriage(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	edit, nonInteractive, err := triageFlagEdit(cmd)
+	if err != nil {
+		return err
+	}
+	if nonInteractive && len(args) == 0 {
+		return fmt.Errorf("idea keys are required with --priority, --status, or --notes")
+	}
+
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	repo := repository.NewIdeaRepository(repoDb)
+
+	var ideas []*models.Idea
+	if len(args) > 0 {
+		for _, key := range args {
+			idea, err := repo.GetByKey(ctx, key)
+			if err != nil {
+				return fmt.Errorf("failed to get idea %s: %w", key, err)
+			}
+			ideas = append(ideas, idea)
+		}
+	} else {
+		all, err := repo.List(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list ideas: %w", err)
+		}
+		ideas = triageQueue(all)
+	}
+
+	var result *triageResult
+	if nonInteractive {
+		result, err = applyTriageEdit(ctx, repo, ideas, edit)
+	} else {
+		result, err = triageIdeas(ctx, repo, ideas, os.Stdin, os.Stdout, time.Now())
+	}
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+	if nonInteractive {
+		cli.Success(fmt.Sprintf("Updated %d idea(s)", len(result.Updated)))
+	} else {
+		cli.Success(fmt.Sprintf("Triaged %d idea(s), skipped %d", len(result.Updated), len(result.Skipped)))
+	}
+	return nil
+}
+
+// triageQueue returns the open ideas in triage order: unprioritized ideas
+// first, then oldest first
+func triageQueue(ideas []*models.Idea) []*models.Idea {
+	queue := []*models.Idea{}
+	for _, idea := range ideas {
+		if idea.Status == models.IdeaStatusNew || idea.Status == models.IdeaStatusOnHold {
+			queue = append(queue, idea)
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		if (queue[i].Priority == nil) != (queue[j].Priority == nil) {
+			return queue[i].Priority == nil
+		}
+		return queue[i].CreatedDate.Before(queue[j].CreatedDate)
+	})
+	return queue
+}
+
+// applyTriageEdit applies the same edit to every idea
+func applyTriageEdit(ctx context.Context, repo IdeaRepository, ideas []*models.Idea, edit triageEdit) (*triageResult, error) {
+	result := &triageResult{Updated: []string{}, Skipped: []string{}}
+	for _, idea := range ideas {
+		edit.apply(idea)
+		if err := repo.Update(ctx, idea); err != nil {
+			return result, fmt.Errorf("failed to update idea %s: %w", idea.Key, err)
+		}
+		result.Updated = append(result.Updated, idea.Key)
+	}
+	return result, nil
+}
+
+// triageIdeas prompts for each idea in turn and saves the changes entered.
+// Invalid input re-prompts for the same idea; q or end of input stops early.
+func triageIdeas(ctx context.Context, repo IdeaRepository, ideas []*models.Idea, in io.Reader, out io.Writer, now time.Time) (*triageResult, error) {
+	result := &triageResult{Updated: []string{}, Skipped: []string{}}
+	if len(ideas) == 0 {
+		fmt.Fprintln(out, "No ideas to triage")
+		return result, nil
+	}
+
+	fmt.Fprintln(out, "Enter a priority (1-10), a status (new, hold, archive), and/or ': note'.")
+	fmt.Fprintln(out, "Press Enter to skip, q to quit.")
+
+	reader := bufio.NewReader(in)
+	for i, idea := range ideas {
+		printTriageIdea(out, idea, i+1, len(ideas), now)
+
+		for {
+			fmt.Fprint(out, "> ")
+			line, err := reader.ReadString('\n')
+			if err != nil && (err != io.EOF || line == "") {
+				if err == io.EOF {
+					fmt.Fprintln(out)
+					return result, nil
+				}
+				return result, err
+			}
+
+			edit, err := parseTriageInput(line)
+			if err != nil {
+				fmt.Fprintf(out, "  %v\n", err)
+				continue
+			}
+
+			if !edit.empty() {
+				edit.apply(idea)
+				if err := repo.Update(ctx, idea); err != nil {
+					return result, fmt.Errorf("failed to update idea %s: %w", idea.Key, err)
+				}
+				result.Updated = append(result.Updated, idea.Key)
+			} else if !edit.Quit {
+				result.Skipped = append(result.Skipped, idea.Key)
+			}
+			if edit.Quit {
+				return result, nil
+			}
+			break
+		}
+	}
+	return result, nil
+}
+
+// printTriageIdea shows an idea at the triage prompt
+func printTriageIdea(out io.Writer, idea *models.Idea, n, total int, now time.Time) {
+	priority := "-"
+	if idea.Priority != nil {
+		priority = strconv.Itoa(*idea.Priority)
+	}
+
+	fmt.Fprintf(out, "\n[%d/%d] %s  %s\n", n, total, idea.Key, idea.Title)
+	fmt.Fprintf(out, "  Status: %s  Priority: %s  Age: %s\n", idea.Status, priority, utils.HumanizeDuration(now.Sub(idea.CreatedDate)))
+	if idea.Description != nil && *idea.Description != "" {
+		fmt.Fprintf(out, "  %s\n", truncateCell(strings.Join(strings.Fields(*idea.Description), " "), 200))
+	}
+	if idea.Notes != nil && *idea.Notes != "" {
+		fmt.Fprintf(out, "  Notes: %s\n", truncateCell(strings.Join(strings.Fields(*idea.Notes), " "), 200))
+	}
+}
+
+// rankedIdea is an idea with its rank score, for idea list --rank
+type rankedIdea struct {
+	*models.Idea
+	Score      float64 `json:"rank_score"`
+	Dependents int     `json:"dependents"`
+}
+
+// rankIdeas scores the ideas and sorts them highest score first. Dependents
+// are counted across all given ideas, so pass the unfiltered list as all.
+func rankIdeas(ideas, all []*models.Idea, now time.Time) []*rankedIdea {
+	dependents := ideaDependentCounts(all)
+
+	ranked := make([]*rankedIdea, len(ideas))
+	for i, idea := range ideas {
+		ranked[i] = &rankedIdea{
+			Idea:       idea,
+			Dependents: dependents[idea.Key],
+			Score:      ideaRankScore(idea, dependents[idea.Key], now),
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].CreatedDate.Before(ranked[j].CreatedDate)
+	})
+	return ranked
+}
+
+// ideaDependentCounts counts, for each idea key, the open ideas that depend on it
+func ideaDependentCounts(ideas []*models.Idea) map[string]int {
+	counts := make(map[string]int)
+	for _, idea := range ideas {
+		if idea.Dependencies == nil || idea.Status == models.IdeaStatusArchived || idea.Status == models.IdeaStatusConverted {
+			continue
+		}
+		var keys []string
+		if err := json.Unmarshal([]byte(*idea.Dependencies), &keys); err != nil {
+			continue
+		}
+		for _, key := range keys {
+			counts[key]++
+		}
+	}
+	return counts
+}
+
+// ideaRankScore scores an idea from 0 to 100 as a weighted sum of its
+// priority (1 is highest, unset counts as the middle), its age (capped at
+// ideaRankMaxAgeDays), and how many ideas depend on it (capped at
+// ideaRankMaxDependents)
+func ideaRankScore(idea *models.Idea, dependents int, now time.Time) float64 {
+	priority := 0.5
+	if idea.Priority != nil {
+		priority = float64(10-*idea.Priority) / 9
+	}
+
+	ageDays := now.Sub(idea.CreatedDate).Hours() / 24
+	age := math.Min(math.Max(ageDays, 0)/ideaRankMaxAgeDays, 1)
+
+	deps := math.Min(float64(dependents)/ideaRankMaxDependents, 1)
+
+	score := ideaRankPriorityWeight*priority + ideaRankAgeWeight*age + ideaRankDependentsWeight*deps
+	return math.Round(score*1000) / 10
+}
+
+// outputRankedIdeas prints ideas highest rank first. When the list was
+// filtered by status, all ideas are loaded so dependents are counted in full.
+func outputRankedIdeas(ctx context.Context, repo IdeaRepository, ideas []*models.Idea, filtered bool) error {
+	all := ideas
+	if filtered {
+		var err error
+		all, err = repo.List(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list ideas: %w", err)
+		}
+	}
+
+	ranked := rankIdeas(ideas, all, time.Now())
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(ranked)
+	}
+
+	if len(ranked) == 0 {
+		fmt.Println("No ideas found")
+		return nil
+	}
+
+	headers := []string{"Key", "Title", "Status", "Priority", "Created", "Dependents", "Score"}
+	rows := make([][]string, len(ranked))
+	for i, idea := range ranked {
+		priority := "-"
+		if idea.Priority != nil {
+			priority = strconv.Itoa(*idea.Priority)
+		}
+		rows[i] = []string{
+			idea.Key,
+			idea.Title,
+			string(idea.Status),
+			priority,
+			idea.CreatedDate.Format("2006-01-02"),
+			strconv.Itoa(idea.Dependents),
+			strconv.FormatFloat(idea.Score, 'f', 1, 64),
+		}
+	}
+
+	cli.OutputTable(headers, rows)
+	return nil
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTriageInput(t *testing.T) {
+	edit, err := parseTriageInput("3 hold: waiting on design: v2\n")
+	require.NoError(t, err)
+	require.NotNil(t, edit.Priority)
+	assert.Equal(t, 3, *edit.Priority)
+	require.NotNil(t, edit.Status)
+	assert.Equal(t, models.IdeaStatusOnHold, *edit.Status)
+	assert.Equal(t, "waiting on design: v2", edit.Note)
+
+	edit, err = parseTriageInput("\n")
+	require.NoError(t, err)
+	assert.True(t, edit.empty())
+	assert.False(t, edit.Quit)
+
+	edit, err = parseTriageInput("A q")
+	require.NoError(t, err)
+	assert.Equal(t, models.IdeaStatusArchived, *edit.Status)
+	assert.True(t, edit.Quit)
+
+	for _, bad := range []string{"11", "0", "3 4", "hold new", "convert", "later"} {
+		_, err := parseTriageInput(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestTriageEditApplyAppendsNotes(t *testing.T) {
+	notes := "First thought"
+	idea := &models.Idea{Key: "I-2026-01-01-01", Notes: &notes}
+
+	triageEdit{Note: "Second thought"}.apply(idea)
+	assert.Equal(t, "First thought\nSecond thought", *idea.Notes)
+
+	empty := &models.Idea{Key: "I-2026-01-01-02"}
+	triageEdit{Note: "Only thought"}.apply(empty)
+	assert.Equal(t, "Only thought", *empty.Notes)
+}
+
+func TestTriageQueue(t *testing.T) {
+	now := time.Now()
+	priority := 2
+	ideas := []*models.Idea{
+		{Key: "I-2026-01-03-01", Status: models.IdeaStatusNew, CreatedDate: now},
+		{Key: "I-2026-01-01-01", Status: models.IdeaStatusNew, Priority: &priority, CreatedDate: now.Add(-48 * time.Hour)},
+		{Key: "I-2026-01-02-01", Status: models.IdeaStatusOnHold, CreatedDate: now.Add(-24 * time.Hour)},
+		{Key: "I-2026-01-02-02", Status: models.IdeaStatusArchived, CreatedDate: now.Add(-24 * time.Hour)},
+		{Key: "I-2026-01-02-03", Status: models.IdeaStatusConverted, CreatedDate: now.Add(-24 * time.Hour)},
+	}
+
+	var keys []string
+	for _, idea := range triageQueue(ideas) {
+		keys = append(keys, idea.Key)
+	}
+	assert.Equal(t, []string{"I-2026-01-02-01", "I-2026-01-03-01", "I-2026-01-01-01"}, keys)
+}
+
+func TestTriageIdeas(t *testing.T) {
+	now := time.Now()
+	ideas := []*models.Idea{
+		{Key: "I-2026-01-01-01", Title: "Offline mode", Status: models.IdeaStatusNew, CreatedDate: now},
+		{Key: "I-2026-01-01-02", Title: "Dark theme", Status: models.IdeaStatusNew, CreatedDate: now},
+		{Key: "I-2026-01-01-03", Title: "Plugin API", Status: models.IdeaStatusNew, CreatedDate: now},
+		{Key: "I-2026-01-01-04", Title: "Never reached", Status: models.IdeaStatusNew, CreatedDate: now},
+	}
+
+	var updated []string
+	repo := &MockIdeaRepository{
+		UpdateFunc: func(ctx context.Context, idea *models.Idea) error {
+			updated = append(updated, idea.Key)
+			return nil
+		},
+	}
+
+	// Invalid input re-prompts the first idea; the second is skipped; the
+	// third is archived before quitting
+	input := strings.NewReader("12\n2: sync first\n\na q\n")
+	var out bytes.Buffer
+
+	result, err := triageIdeas(context.Background(), repo, ideas, input, &out, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"I-2026-01-01-01", "I-2026-01-01-03"}, result.Updated)
+	assert.Equal(t, []string{"I-2026-01-01-02"}, result.Skipped)
+	assert.Equal(t, result.Updated, updated)
+
+	assert.Equal(t, 2, *ideas[0].Priority)
+	assert.Equal(t, "sync first", *ideas[0].Notes)
+	assert.Equal(t, models.IdeaStatusArchived, ideas[2].Status)
+	assert.Contains(t, out.String(), "priority must be between 1 and 10")
+	assert.NotContains(t, out.String(), "Never reached")
+}
+
+func TestTriageIdeasStopsAtEndOfInput(t *testing.T) {
+	now := time.Now()
+	ideas := []*models.Idea{
+		{Key: "I-2026-01-01-01", Title: "Offline mode", Status: models.IdeaStatusNew, CreatedDate: now},
+		{Key: "I-2026-01-01-02", Title: "Dark theme", Status: models.IdeaStatusNew, CreatedDate: now},
+	}
+
+	result, err := triageIdeas(context.Background(), &MockIdeaRepository{}, ideas, strings.NewReader("5"), &bytes.Buffer{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"I-2026-01-01-01"}, result.Updated)
+	assert.Empty(t, result.Skipped)
+}
+
+func TestRankIdeas(t *testing.T) {
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	high, low := 1, 10
+	deps := `["I-2026-03-01-01"]`
+
+	ideas := []*models.Idea{
+		{Key: "I-2026-03-31-01", Status: models.IdeaStatusNew, Priority: &low, CreatedDate: now.AddDate(0, 0, -1)},
+		{Key: "I-2026-03-01-01", Status: models.IdeaStatusNew, CreatedDate: now.AddDate(0, 0, -31)},
+		{Key: "I-2026-03-30-01", Status: models.IdeaStatusNew, Priority: &high, CreatedDate: now.AddDate(0, 0, -2)},
+		{Key: "I-2026-03-30-02", Status: models.IdeaStatusNew, Dependencies: &deps, CreatedDate: now},
+		{Key: "I-2026-03-30-03", Status: models.IdeaStatusArchived, Dependencies: &deps, CreatedDate: now},
+	}
+
+	ranked := rankIdeas(ideas[:4], ideas, now)
+
+	var keys []string
+	for _, idea := range ranked {
+		keys = append(keys, idea.Key)
+	}
+	assert.Equal(t, []string{"I-2026-03-30-01", "I-2026-03-01-01", "I-2026-03-30-02", "I-2026-03-31-01"}, keys)
+
+	// Archived ideas don't count as dependents
+	assert.Equal(t, 1, ranked[1].Dependents)
+	assert.Equal(t, 60.4, ranked[0].Score)
+}
+
+func TestIdeaRankScore(t *testing.T) {
+	now := time.Now()
+	high := 1
+
+	assert.Equal(t, 30.0, ideaRankScore(&models.Idea{CreatedDate: now}, 0, now), "unset priority scores the middle")
+	assert.Equal(t, 100.0, ideaRankScore(&models.Idea{Priority: &high, CreatedDate: now.AddDate(-1, 0, 0)}, 5, now), "components are capped")
+}
@@ -1,14 +1,12 @@
 package commands
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
-	"github.com/jwwelbor/shark-task-manager/internal/db"
 	init_pkg "github.com/jwwelbor/shark-task-manager/internal/init"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +23,8 @@ var initCmd = &cobra.Command{
 	Use:     "init",
 	Short:   "Initialize Shark CLI infrastructure",
 	GroupID: "setup",
+	// init creates a project in the working directory, never the current workspace
+	Annotations: map[string]string{cli.SkipWorkspaceAnnotation: "true"},
 	Long: `Initialize Shark CLI infrastructure by creating database schema,
 folder structure, configuration file, and task templates.
 
@@ -98,7 +98,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// This protects against unexpected issues
 	if _, err := os.Stat(dbPath); err == nil {
 		// Database exists, create backup
-		backupPath, err := db.BackupDatabase(dbPath)
+		backupPath, err := createDatabaseBackup(dbPath, "init")
 		if err != nil {
 			cli.Warning(fmt.Sprintf("Failed to create backup: %v", err))
 			// Continue anyway - init should be safe
@@ -121,7 +121,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	initializer := init_pkg.NewInitializer()
 
 	// Run initialization with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := cli.CommandContext(10 * time.Second)
 	defer cancel()
 
 	result, err := initializer.Initialize(ctx, opts)
@@ -210,7 +210,7 @@ func displayInitSuccess(result *init_pkg.InitResult) {
 	fmt.Println("Next steps:")
 	fmt.Println("1. Edit .sharkconfig.json to set default epic and agent")
 	fmt.Println("2. Create tasks with: shark task create \"Task title\" --epic=E01 --feature=F01 --agent=backend")
-	fmt.Println("3. Import existing tasks with: shark sync")
+	fmt.Println("3. Adopt existing plan files with: shark scan --adopt")
 
 	// Only show profile message if config was created
 	if result.ConfigCreated {
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// labelCmd is the parent command for label operations
+var labelCmd = &cobra.Command{
+	Use:     "label",
+	Short:   "List labels used on tasks and features",
+	GroupID: "details",
+	Long: `Labels tag tasks and features (e.g. "security", "tech-debt", "frontend").
+
+Attach labels with --label on create/update and remove them with --remove-label:
+  shark task create E07 F01 "Harden login" --label=security
+  shark task update E07-F01-001 --label=tech-debt --remove-label=security
+  shark feature update E07-F01 --label=frontend
+
+Filter by label with --label on task list, task next, feature list, and status.
+Repeating --label requires all of the given labels.`,
+}
+
+// labelListCmd lists labels with usage counts
+var labelListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List labels with usage counts",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List all labels with the number of tasks and features using each.
+
+Examples:
+  shark label list
+  shark label list --json`,
+	Args: cobra.NoArgs,
+	RunE: runLabelList,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(labelCmd)
+	labelCmd.AddCommand(labelListCmd)
+}
+
+// runLabelList handles the label list command
+func runLabelList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	labels, err := repository.NewLabelRepository(repoDb).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(labels)
+	}
+
+	if len(labels) == 0 {
+		cli.Info("No labels found")
+		return nil
+	}
+
+	headers := []string{"Label", "Tasks", "Features"}
+	rows := make([][]string, len(labels))
+	for i, label := range labels {
+		rows[i] = []string{label.Name, strconv.Itoa(label.Tasks), strconv.Itoa(label.Features)}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// addLabelFilterFlag registers --label as a filter on a list-style command
+func addLabelFilterFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("label", nil, "Filter by label (repeatable or comma-separated; all must match)")
+}
+
+// addLabelEditFlags registers --label (and --remove-label for updates) on a create/update command
+func addLabelEditFlags(cmd *cobra.Command, withRemove bool) {
+	cmd.Flags().StringSlice("label", nil, "Add label (repeatable or comma-separated)")
+	if withRemove {
+		cmd.Flags().StringSlice("remove-label", nil, "Remove label (repeatable or comma-separated)")
+	}
+}
+
+// labelsFromFlag reads and normalizes a label flag
+func labelsFromFlag(cmd *cobra.Command, name string) ([]string, error) {
+	if cmd.Flags().Lookup(name) == nil {
+		return nil, nil
+	}
+	values, _ := cmd.Flags().GetStringSlice(name)
+	labels, err := models.NormalizeLabels(values)
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %w", name, err)
+	}
+	return labels, nil
+}
+
+// attachTaskLabels fills in the Labels field of each task
+func attachTaskLabels(ctx context.Context, repoDb *repository.DB, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	labels, err := repository.NewLabelRepository(repoDb).GetTaskLabels(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.Labels = labels[task.ID]
+	}
+	return nil
+}
+
+// attachFeatureLabels fills in the Labels field of each feature
+func attachFeatureLabels(ctx context.Context, repoDb *repository.DB, features []*models.Feature) error {
+	if len(features) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(features))
+	for i, feature := range features {
+		ids[i] = feature.ID
+	}
+	labels, err := repository.NewLabelRepository(repoDb).GetFeatureLabels(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, feature := range features {
+		feature.Labels = labels[feature.ID]
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/reporting"
+)
+
+// projectLinkFormat returns the configured link format for file references
+func projectLinkFormat() reporting.LinkFormat {
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return reporting.LinkFormatPlain
+	}
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil {
+		return reporting.LinkFormatPlain
+	}
+	return reporting.ParseLinkFormat(cfg.GetLinkFormat())
+}
+
+// entityFileLink returns a clickable link to an entity's markdown file, or ""
+// when links are disabled. relPath is relative to projectRoot.
+func entityFileLink(projectRoot, relPath string) string {
+	format := projectLinkFormat()
+	if format == reporting.LinkFormatPlain || relPath == "" {
+		return ""
+	}
+	if !filepath.IsAbs(relPath) && projectRoot != "" {
+		relPath = filepath.Join(projectRoot, relPath)
+	}
+	return reporting.FormatFileLink(format, relPath, 0)
+}
@@ -7,9 +7,10 @@ import (
 
 // listCmd represents the unified list command
 var listCmd = &cobra.Command{
-	Use:     "list [EPIC] [FEATURE]",
-	Short:   "List epics, features, or tasks",
-	GroupID: "essentials",
+	Use:         "list [EPIC] [FEATURE]",
+	Short:       "List epics, features, or tasks",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "essentials",
 	Long: `Smart list command that dispatches to the appropriate subcommand based on arguments.
 
 Positional Arguments:
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// addListPagingFlags registers --limit, --offset, and --page on a list command
+func addListPagingFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("limit", 0, "Show at most this many rows (default: list.page_size in .sharkconfig.json, else all)")
+	cmd.Flags().Int("offset", 0, "Skip this many rows")
+	cmd.Flags().Int("page", 0, "Show this page of --limit rows, starting from 1")
+}
+
+// listConfig loads the project config for list defaults; a missing or
+// unreadable config gives the built-in defaults
+func listConfig() *config.Config {
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return nil
+	}
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// listOptions builds a list's sort and page from --sort-by, --limit, --offset,
+// and --page, falling back to defaultSort and pageSize for flags not given
+func listOptions(cmd *cobra.Command, defaultSort string, pageSize int) (repository.ListOptions, error) {
+	sortBy := defaultSort
+	if cmd.Flags().Changed("sort-by") {
+		sortBy, _ = cmd.Flags().GetString("sort-by")
+	}
+	field, desc, err := repository.ParseSort(sortBy)
+	if err != nil {
+		return repository.ListOptions{}, err
+	}
+	opts := repository.ListOptions{SortBy: field, Desc: desc, Limit: pageSize}
+
+	if cmd.Flags().Changed("limit") {
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+	}
+	opts.Offset, _ = cmd.Flags().GetInt("offset")
+	page, _ := cmd.Flags().GetInt("page")
+	if opts.Limit < 0 || opts.Offset < 0 || page < 0 {
+		return repository.ListOptions{}, fmt.Errorf("--limit, --offset, and --page must not be negative")
+	}
+	if cmd.Flags().Changed("page") {
+		if cmd.Flags().Changed("offset") {
+			return repository.ListOptions{}, fmt.Errorf("--page and --offset cannot be used together")
+		}
+		if opts.Limit == 0 || page == 0 {
+			return repository.ListOptions{}, fmt.Errorf("--page needs a page size: give --limit or set list.page_size in .sharkconfig.json, and count pages from 1")
+		}
+		opts.Offset = (page - 1) * opts.Limit
+	}
+	return opts, nil
+}
+
+// isPaged reports whether opts shows only part of a list
+func isPaged(opts repository.ListOptions) bool {
+	return opts.Limit > 0 || opts.Offset > 0
+}
+
+// pageSlice returns the part of a list sorted in memory that opts selects
+func pageSlice[T any](rows []T, opts repository.ListOptions) []T {
+	if opts.Offset >= len(rows) {
+		return rows[:0]
+	}
+	rows = rows[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(rows) {
+		rows = rows[:opts.Limit]
+	}
+	return rows
+}
+
+// notePage tells human readers which rows of a paged list they are seeing and
+// how to get the next page
+func notePage(opts repository.ListOptions, shown, total int, noun string) {
+	if cli.GlobalConfig.JSON || !isPaged(opts) {
+		return
+	}
+	if shown == 0 {
+		cli.Info("No %s at offset %d of %d", noun, opts.Offset, total)
+		return
+	}
+	last := opts.Offset + shown
+	if last < total {
+		cli.Info("Showing %s %d-%d of %d; use --offset %d for more", noun, opts.Offset+1, last, total, last)
+	} else {
+		cli.Info("Showing %s %d-%d of %d", noun, opts.Offset+1, last, total)
+	}
+}
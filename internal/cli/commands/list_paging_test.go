@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+func newListPagingTestCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().String("sort-by", "", "")
+	addListPagingFlags(cmd)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags(%v) failed: %v", args, err)
+	}
+	return cmd
+}
+
+// TestListOptions tests how --sort-by, --limit, --offset, and --page combine with the config defaults
+func TestListOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		defaultSort string
+		pageSize    int
+		want        repository.ListOptions
+		wantErr     bool
+	}{
+		{name: "defaults", defaultSort: "updated_at:desc", pageSize: 25,
+			want: repository.ListOptions{SortBy: "updated_at", Desc: true, Limit: 25}},
+		{name: "flags override config", args: []string{"--sort-by=key", "--limit=0"}, defaultSort: "updated_at:desc", pageSize: 25,
+			want: repository.ListOptions{SortBy: "key"}},
+		{name: "page of configured size", args: []string{"--page=3"}, pageSize: 20,
+			want: repository.ListOptions{Limit: 20, Offset: 40}},
+		{name: "page without a size", args: []string{"--page=2"}, wantErr: true},
+		{name: "page and offset", args: []string{"--page=2", "--offset=5", "--limit=10"}, wantErr: true},
+		{name: "negative limit", args: []string{"--limit=-1"}, wantErr: true},
+		{name: "bad direction", args: []string{"--sort-by=key:up"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := listOptions(newListPagingTestCmd(t, tt.args...), tt.defaultSort, tt.pageSize)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("listOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPageSlice tests paging a list sorted in memory
+func TestPageSlice(t *testing.T) {
+	rows := []int{1, 2, 3, 4, 5}
+	if got := pageSlice(rows, repository.ListOptions{Limit: 2, Offset: 2}); len(got) != 2 || got[0] != 3 {
+		t.Errorf("pageSlice() = %v, want [3 4]", got)
+	}
+	if got := pageSlice(rows, repository.ListOptions{Offset: 9}); len(got) != 0 {
+		t.Errorf("pageSlice() past the end = %v, want empty", got)
+	}
+}
@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/lsp"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/spf13/cobra"
+)
+
+// lspCmd runs the JSON-RPC server for editor integrations
+var lspCmd = &cobra.Command{
+	Use:     "lsp",
+	Short:   "Run a JSON-RPC server over stdio for editor integrations",
+	GroupID: "setup",
+	Long: `Run a language-server-style JSON-RPC 2.0 server on stdin/stdout.
+
+Editor plugins (VS Code, Neovim, ...) can start this process to show task status
+inline in markdown files. Messages use LSP framing (Content-Length headers).
+
+Standard LSP methods:
+  textDocument/hover        Status of the epic/feature/task key under the cursor
+  textDocument/codeAction   "Start task" on task keys that can be started
+  workspace/executeCommand  shark.startTask [task-key]
+
+Shark methods:
+  shark/resolveKey  {textDocument, position} or {text, character} -> key and entity
+  shark/status      {key} -> entity with status and progress
+  shark/filePath    {key} -> path and file:// URI of the entity's markdown file
+
+Examples:
+  shark lsp
+  shark lsp --db /path/to/shark-tasks.db`,
+	Args: cobra.NoArgs,
+	RunE: runLSP,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(lspCmd)
+}
+
+// runLSP executes the lsp command
+func runLSP(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	repoDb, err := cli.GetDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	backend := newLSPBackend(repoDb, projectRoot)
+
+	// stdout carries the protocol; diagnostics go to stderr
+	slog.Info("Serving language server on stdio")
+	return lsp.NewServer(backend).Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// lspBackend answers editor queries from the database
+type lspBackend struct {
+	db           *repository.DB
+	epicRepo     *repository.EpicRepository
+	featureRepo  *repository.FeatureRepository
+	taskRepo     *repository.TaskRepository
+	pathResolver *pathresolver.PathResolver
+	workflow     *config.WorkflowConfig
+}
+
+// newLSPBackend creates a backend using the project's workflow config
+func newLSPBackend(repoDb *repository.DB, projectRoot string) *lspBackend {
+	var workflow *config.WorkflowConfig
+	if configPath, err := cli.GetConfigPath(); err == nil {
+		workflow, _ = config.LoadWorkflowConfig(configPath)
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	if workflow != nil {
+		taskRepo = repository.NewTaskRepositoryWithWorkflow(repoDb, workflow)
+	}
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+
+	return &lspBackend{
+		db:           repoDb,
+		epicRepo:     epicRepo,
+		featureRepo:  featureRepo,
+		taskRepo:     taskRepo,
+		pathResolver: pathresolver.NewPathResolver(epicRepo, featureRepo, taskRepo, projectRoot),
+		workflow:     workflow,
+	}
+}
+
+// Lookup implements lsp.Backend
+func (b *lspBackend) Lookup(ctx context.Context, entityType, key string) (*lsp.EntityInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	switch entityType {
+	case lsp.EntityEpic:
+		epic, err := b.epicRepo.GetByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		progress, _ := b.epicRepo.CalculateProgress(ctx, epic.ID)
+		path, _ := b.pathResolver.ResolveEpicPath(ctx, epic.Key)
+		return &lsp.EntityInfo{Type: entityType, Key: epic.Key, Title: epic.Title, Status: string(epic.Status), ProgressPct: &progress, FilePath: path}, nil
+
+	case lsp.EntityFeature:
+		feature, err := b.featureRepo.GetByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		progress := feature.ProgressPct
+		path, _ := b.pathResolver.ResolveFeaturePath(ctx, feature.Key)
+		return &lsp.EntityInfo{Type: entityType, Key: feature.Key, Title: feature.Title, Status: string(feature.Status), ProgressPct: &progress, FilePath: path}, nil
+
+	case lsp.EntityTask:
+		task, err := b.taskRepo.GetByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return b.taskInfo(ctx, task), nil
+	}
+
+	return nil, fmt.Errorf("unknown entity type %q", entityType)
+}
+
+// taskInfo converts a task to editor-facing info
+func (b *lspBackend) taskInfo(ctx context.Context, task *models.Task) *lsp.EntityInfo {
+	path, _ := b.pathResolver.ResolveTaskPath(ctx, task.Key)
+	return &lsp.EntityInfo{Type: lsp.EntityTask, Key: task.Key, Title: task.Title, Status: string(task.Status), FilePath: path}
+}
+
+// CanStart implements lsp.Backend
+func (b *lspBackend) CanStart(taskStatus string) bool {
+	if b.workflow == nil {
+		return taskStatus == string(models.TaskStatusTodo)
+	}
+	return config.ValidateTransition(b.workflow, taskStatus, string(models.TaskStatusInProgress)) == nil
+}
+
+// StartTask implements lsp.Backend. Mirrors 'shark task start' without console output,
+// since stdout carries the protocol.
+func (b *lspBackend) StartTask(ctx context.Context, key string) (*lsp.EntityInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	taskKey, err := NormalizeTaskKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := b.taskRepo.GetByKey(ctx, taskKey)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %s", taskKey)
+	}
+
+	updated, _, err := b.taskRepo.UpdateStatusWithAction(ctx, task.Key, string(models.TaskStatusInProgress))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task status: %w", err)
+	}
+
+	agent := getAgentIdentifier("")
+	session := &models.WorkSession{TaskID: task.ID, AgentID: &agent, StartedAt: time.Now()}
+	if err := repository.NewWorkSessionRepository(b.db).Create(ctx, session); err != nil {
+		slog.Warn("Failed to create work session", "error", err)
+	}
+
+	if _, err := status.NewCalculationService(b.db, b.workflow).CascadeFromFeatureID(ctx, task.FeatureID); err != nil {
+		slog.Warn("Status cascade failed", "error", err)
+	}
+
+	return b.taskInfo(ctx, updated), nil
+}
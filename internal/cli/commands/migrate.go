@@ -21,7 +21,10 @@ the database as new features are added.`,
   shark migrate backfill-slugs --dry-run
 
   # Verbose output with detailed logging
-  shark migrate backfill-slugs --verbose`,
+  shark migrate backfill-slugs --verbose
+
+  # Find rows with invalid status, priority, or agent values
+  shark migrate check-enums`,
 }
 
 func init() {
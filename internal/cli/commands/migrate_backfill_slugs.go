@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"database/sql"
 	"encoding/json"
 	"fmt"
 
@@ -59,7 +58,7 @@ func runBackfillSlugs(cmd *cobra.Command, args []string) error {
 	}
 
 	// Open database
-	database, err := sql.Open("sqlite3", dbPath)
+	database, err := db.OpenSQLite(dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var checkEnumsCmd = &cobra.Command{
+	Use:         "check-enums",
+	Short:       "Find rows with invalid status, priority, or agent values",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Scan epics, features, tasks, and ideas for enum columns holding values that
+validation rejects: unknown epic, feature, and idea statuses, task statuses not
+defined by the configured workflow, invalid epic priorities and business
+values, and blank task agent types.
+
+Rows written before validation was enforced, or edited directly in the
+database, can hold such values. Nothing is changed; fix each row with the
+matching update command, for example 'shark epic update E07 --status=active'.
+
+Exits with a non-zero status when any invalid value is found.`,
+	Example: `  # List invalid values
+  shark migrate check-enums
+
+  # Get JSON output for automation
+  shark migrate check-enums --json`,
+	RunE: runCheckEnums,
+}
+
+func init() {
+	migrateCmd.AddCommand(checkEnumsCmd)
+}
+
+func runCheckEnums(cmd *cobra.Command, args []string) error {
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+	taskStatuses := workflow.NewService(projectRoot).GetAllStatuses()
+
+	invalid, err := db.FindInvalidEnumValues(repoDb.DB, taskStatuses)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		if err := cli.OutputJSON(map[string]interface{}{
+			"invalid": invalid,
+			"count":   len(invalid),
+		}); err != nil {
+			return err
+		}
+	} else if len(invalid) == 0 {
+		cli.Success("No invalid enum values found")
+	} else {
+		headers := []string{"Table", "Key", "Column", "Value", "Allowed"}
+		rows := make([][]string, len(invalid))
+		for i, row := range invalid {
+			value := "(null)"
+			if row.Value != nil {
+				value = fmt.Sprintf("%q", *row.Value)
+			}
+			allowed := strings.Join(row.Allowed, ", ")
+			if allowed == "" {
+				allowed = "any non-blank value"
+			}
+			rows[i] = []string{row.Table, row.Key, row.Column, value, allowed}
+		}
+		cli.OutputTable(headers, rows)
+	}
+
+	if len(invalid) > 0 {
+		// The findings are the report; usage text would only bury them
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %d invalid enum value(s)", len(invalid))
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/hooks"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// fireMilestoneHooks runs the .shark.yaml hooks for any milestone a feature or
+// its epic reached through a change to the feature's tasks. Hook failures are
+// reported but never fail the command: the change has already been made.
+func fireMilestoneHooks(ctx context.Context, dbWrapper *repository.DB, featureID int64) {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return
+	}
+	configured, err := hooks.Load(projectRoot)
+	if err != nil {
+		cli.Warning(err.Error())
+		return
+	}
+	if len(configured) == 0 {
+		return
+	}
+
+	events, err := hooks.Check(ctx, dbWrapper, configured, featureID)
+	if err != nil {
+		cli.Warning(fmt.Sprintf("Milestone hooks failed: %v", err))
+		return
+	}
+	for _, event := range events {
+		slog.Debug("Milestone reached", "event", event.Event, "entity", event.EntityKey, "threshold", event.Threshold)
+	}
+	for _, err := range hooks.NewRunner(configured, projectRoot).Fire(ctx, events) {
+		cli.Warning(fmt.Sprintf("Hook failed: %v", err))
+	}
+}
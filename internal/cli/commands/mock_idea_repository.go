@@ -16,7 +16,11 @@ type MockIdeaRepository struct {
 	UpdateFunc                 func(ctx context.Context, idea *models.Idea) error
 	DeleteFunc                 func(ctx context.Context, id int64) error
 	MarkAsConvertedFunc        func(ctx context.Context, ideaID int64, convertedToType, convertedToKey string) error
+	ConvertToEpicFunc          func(ctx context.Context, ideaID int64, epic *models.Epic) error
+	ConvertToFeatureFunc       func(ctx context.Context, ideaID int64, feature *models.Feature) error
+	ConvertToTaskFunc          func(ctx context.Context, ideaID int64, task *models.Task) error
 	GetNextSequenceForDateFunc func(ctx context.Context, dateStr string) (int, error)
+	UpdateFilePathFunc         func(ctx context.Context, ideaKey string, newFilePath *string) error
 }
 
 // Create mocks the Create method
@@ -75,6 +79,30 @@ func (m *MockIdeaRepository) MarkAsConverted(ctx context.Context, ideaID int64,
 	return nil
 }
 
+// ConvertToEpic mocks the ConvertToEpic method
+func (m *MockIdeaRepository) ConvertToEpic(ctx context.Context, ideaID int64, epic *models.Epic) error {
+	if m.ConvertToEpicFunc != nil {
+		return m.ConvertToEpicFunc(ctx, ideaID, epic)
+	}
+	return nil
+}
+
+// ConvertToFeature mocks the ConvertToFeature method
+func (m *MockIdeaRepository) ConvertToFeature(ctx context.Context, ideaID int64, feature *models.Feature) error {
+	if m.ConvertToFeatureFunc != nil {
+		return m.ConvertToFeatureFunc(ctx, ideaID, feature)
+	}
+	return nil
+}
+
+// ConvertToTask mocks the ConvertToTask method
+func (m *MockIdeaRepository) ConvertToTask(ctx context.Context, ideaID int64, task *models.Task) error {
+	if m.ConvertToTaskFunc != nil {
+		return m.ConvertToTaskFunc(ctx, ideaID, task)
+	}
+	return nil
+}
+
 // GetNextSequenceForDate mocks the GetNextSequenceForDate method
 func (m *MockIdeaRepository) GetNextSequenceForDate(ctx context.Context, dateStr string) (int, error) {
 	if m.GetNextSequenceForDateFunc != nil {
@@ -82,3 +110,11 @@ func (m *MockIdeaRepository) GetNextSequenceForDate(ctx context.Context, dateStr
 	}
 	return 1, nil
 }
+
+// UpdateFilePath mocks the UpdateFilePath method
+func (m *MockIdeaRepository) UpdateFilePath(ctx context.Context, ideaKey string, newFilePath *string) error {
+	if m.UpdateFilePathFunc != nil {
+		return m.UpdateFilePathFunc(ctx, ideaKey, newFilePath)
+	}
+	return nil
+}
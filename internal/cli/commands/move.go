@@ -0,0 +1,356 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskMoveCmd moves a task to another feature
+var taskMoveCmd = &cobra.Command{
+	Use:   "move <task-key> --to-feature=<feature-key>",
+	Short: "Move a task to another feature",
+	Long: `Move a task to another feature. The task gets the next key in the target
+feature (T-E05-F01-003 may become T-E06-F02-004), and its markdown file moves to
+the target feature's tasks directory with its frontmatter keys updated.
+
+Standalone tasks (T-BKL-###) are promoted into a feature the same way, and
+--to-feature=BKL moves a task back to the backlog.
+
+Task dependencies that point at the task, audit and journal entries, and the
+search index follow the new key. Status history, notes, criteria, and links
+stay with the task. The database changes are made in a single transaction,
+and moved files are put back if it fails.
+
+Examples:
+  shark task move T-E05-F01-003 --to-feature=E06-F02
+  shark task move E05-F01-003 --to-feature=E06-F02 --json
+  shark task move T-BKL-004 --to-feature=E06-F02`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskMove,
+}
+
+// featureMoveCmd moves a feature, with its tasks, to another epic
+var featureMoveCmd = &cobra.Command{
+	Use:   "move <feature-key> --to-epic=<epic-key>",
+	Short: "Move a feature and its tasks to another epic",
+	Long: `Move a feature to another epic. The feature gets the next feature key in the
+target epic (E05-F01 may become E06-F03) and its tasks keep their numbers under
+the new key (T-E05-F01-002 becomes T-E06-F03-002). The feature's directory
+moves into the target epic's directory, and task files and frontmatter keys
+are renamed to match.
+
+Task dependencies, audit and journal entries, linked document paths, and the
+search index follow the new keys. Status history stays with the tasks. The
+database changes are made in a single transaction, and moved files are put
+back if it fails.
+
+Examples:
+  shark feature move E05-F01 --to-epic=E06
+  shark feature move E05-F01 --to-epic=E06 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFeatureMove,
+}
+
+func init() {
+	taskCmd.AddCommand(taskMoveCmd)
+	taskMoveCmd.Flags().String("to-feature", "", "Feature to move the task to (e.g. E06-F02)")
+	_ = taskMoveCmd.MarkFlagRequired("to-feature")
+
+	featureCmd.AddCommand(featureMoveCmd)
+	featureMoveCmd.Flags().String("to-epic", "", "Epic to move the feature to (e.g. E06)")
+	_ = featureMoveCmd.MarkFlagRequired("to-epic")
+}
+
+// runTaskMove handles the task move command
+func runTaskMove(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(time.Minute)
+	defer cancel()
+
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	toFeature, _ := cmd.Flags().GetString("to-feature")
+	featureKey := NormalizeKey(toFeature)
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	epicRepo := repository.NewEpicRepository(repoDb)
+
+	task, err := taskRepo.GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found: %w", taskKey, err)
+	}
+	oldFeature, err := featureRepo.GetByID(ctx, task.FeatureID)
+	if err != nil {
+		return fmt.Errorf("failed to get feature of %s: %w", task.Key, err)
+	}
+	oldEpic, err := epicRepo.GetByID(ctx, oldFeature.EpicID)
+	if err != nil {
+		return fmt.Errorf("failed to get epic of %s: %w", task.Key, err)
+	}
+	feature, err := featureRepo.GetByKey(ctx, featureKey)
+	if err != nil {
+		return fmt.Errorf("feature %s not found: %w", featureKey, err)
+	}
+	epic, err := epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return fmt.Errorf("failed to get epic of %s: %w", feature.Key, err)
+	}
+
+	// Standalone tasks live directly in the backlog directory
+	tasksDir := models.BacklogDir
+	if feature.Key != models.BacklogKey {
+		featureDir, err := entityDir(projectRoot, feature.FilePath, func() (string, error) {
+			return pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot).ResolveFeaturePath(ctx, feature.Key)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resolve feature directory: %w", err)
+		}
+		tasksDir = path.Join(filepath.ToSlash(featureDir), "tasks")
+	}
+
+	renumberRepo := repository.NewRenumberRepository(repoDb)
+	plan, err := renumberRepo.PlanTaskMove(ctx, task, feature, tasksDir)
+	if err != nil {
+		return err
+	}
+
+	frontmatter := plan.WithParentKeys(map[string]string{oldFeature.Key: feature.Key, oldEpic.Key: epic.Key})
+	frontmatterErrors, err := applyMovePlan(ctx, repoDb, renumberRepo, projectRoot, plan, frontmatter.RewriteName)
+	if err != nil {
+		return err
+	}
+
+	triggerStatusCascade(ctx, repoDb, oldFeature.ID)
+	triggerStatusCascade(ctx, repoDb, feature.ID)
+
+	change := plan.Changes[0]
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"old_key":            change.OldKey,
+			"new_key":            change.NewKey,
+			"feature":            feature.Key,
+			"file_path":          change.NewFilePath,
+			"frontmatter_errors": frontmatterErrors,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Moved task %s to feature %s as %s", change.OldKey, feature.Key, change.NewKey))
+	if change.NewFilePath != nil && *change.NewFilePath != *change.OldFilePath {
+		cli.Info(fmt.Sprintf("Moved file to %s", *change.NewFilePath))
+	}
+	for _, message := range frontmatterErrors {
+		cli.Warning(fmt.Sprintf("Failed to update frontmatter in %s", message))
+	}
+	return nil
+}
+
+// runFeatureMove handles the feature move command
+func runFeatureMove(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(time.Minute)
+	defer cancel()
+
+	featureKey := NormalizeKey(args[0])
+	toEpic, _ := cmd.Flags().GetString("to-epic")
+	epicKey := NormalizeKey(toEpic)
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	epicRepo := repository.NewEpicRepository(repoDb)
+
+	feature, err := featureRepo.GetByKey(ctx, featureKey)
+	if err != nil {
+		return fmt.Errorf("feature %s not found: %w", featureKey, err)
+	}
+	oldEpic, err := epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return fmt.Errorf("failed to get epic of %s: %w", feature.Key, err)
+	}
+	epic, err := epicRepo.GetByKey(ctx, epicKey)
+	if err != nil {
+		return fmt.Errorf("epic %s not found: %w", epicKey, err)
+	}
+
+	resolver := pathresolver.NewPathResolver(epicRepo, featureRepo, nil, projectRoot)
+	featureDir, err := entityDir(projectRoot, feature.FilePath, func() (string, error) {
+		return resolver.ResolveFeaturePath(ctx, feature.Key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve feature directory: %w", err)
+	}
+	epicDir, err := entityDir(projectRoot, epic.FilePath, func() (string, error) {
+		return resolver.ResolveEpicPath(ctx, epic.Key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve epic directory: %w", err)
+	}
+
+	// Only a directory named for the feature moves; a feature file kept
+	// directly in the epic directory moves on its own
+	from := filepath.ToSlash(featureDir)
+	if !strings.HasPrefix(path.Base(from), feature.Key) {
+		from = ""
+		if feature.FilePath != nil {
+			from = *feature.FilePath
+		}
+	}
+
+	renumberRepo := repository.NewRenumberRepository(repoDb)
+	plan, err := renumberRepo.PlanFeatureMove(ctx, feature, epic, from, filepath.ToSlash(epicDir))
+	if err != nil {
+		return err
+	}
+
+	frontmatter := plan.WithParentKeys(map[string]string{oldEpic.Key: epic.Key})
+	frontmatterErrors, err := applyMovePlan(ctx, repoDb, renumberRepo, projectRoot, plan, frontmatter.RewriteName)
+	if err != nil {
+		return err
+	}
+
+	triggerStatusCascade(ctx, repoDb, feature.ID)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"old_key":            plan.Changes[0].OldKey,
+			"new_key":            plan.Changes[0].NewKey,
+			"epic":               epic.Key,
+			"changes":            plan.Changes,
+			"frontmatter_errors": frontmatterErrors,
+		})
+	}
+
+	displayRenumberPlan(plan)
+	cli.Success(fmt.Sprintf("Moved feature %s to epic %s as %s with %d tasks", plan.Changes[0].OldKey, epic.Key, plan.Changes[0].NewKey, len(plan.Changes)-1))
+	for _, message := range frontmatterErrors {
+		cli.Warning(fmt.Sprintf("Failed to update frontmatter in %s", message))
+	}
+	return nil
+}
+
+// applyMovePlan moves files on disk, applies the plan to the database, and then
+// rewrites frontmatter keys in the moved files. Files are put back if the
+// database changes fail. Returns the files whose frontmatter could not be updated.
+func applyMovePlan(ctx context.Context, repoDb *repository.DB, renumberRepo *repository.RenumberRepository, projectRoot string, plan *repository.RenumberPlan, rewriteKey func(string) string) ([]string, error) {
+	undo, err := moveEntityFiles(projectRoot, plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move files (nothing changed): %w", err)
+	}
+
+	if err := renumberRepo.Apply(ctx, plan); err != nil {
+		undo()
+		return nil, fmt.Errorf("failed to move %s (files restored): %w", plan.Changes[0].OldKey, err)
+	}
+
+	var frontmatterErrors []string
+	for _, change := range plan.Changes {
+		if change.NewFilePath == nil || !strings.HasSuffix(*change.NewFilePath, ".md") {
+			continue
+		}
+		if err := rewriteFrontmatterKeys(resolveProjectPath(projectRoot, *change.NewFilePath), rewriteKey); err != nil {
+			frontmatterErrors = append(frontmatterErrors, fmt.Sprintf("%s: %v", *change.NewFilePath, err))
+		}
+	}
+
+	for _, change := range plan.Changes {
+		recordAudit(ctx, repoDb, &models.AuditEntry{
+			EntityType: change.EntityType,
+			EntityKey:  change.NewKey,
+			Action:     models.AuditActionUpdate,
+			Summary:    fmt.Sprintf("Moved from %s", change.OldKey),
+			Changes:    map[string]models.AuditChange{"key": {Old: change.OldKey, New: change.NewKey}},
+		})
+	}
+
+	return frontmatterErrors, nil
+}
+
+// moveEntityFiles moves the plan's files and directories to their new parent
+// directories, then renames the key-named files below them. Paths that don't
+// exist are skipped. Returns a function that puts everything back.
+func moveEntityFiles(projectRoot string, plan *repository.RenumberPlan) (func(), error) {
+	var moved []repository.PathMove
+	undoMoves := func() {
+		for i := len(moved) - 1; i >= 0; i-- {
+			if err := os.Rename(resolveProjectPath(projectRoot, moved[i].To), resolveProjectPath(projectRoot, moved[i].From)); err != nil {
+				cli.Warning(fmt.Sprintf("Failed to restore %s: %v", moved[i].From, err))
+			}
+		}
+	}
+
+	for _, move := range plan.PathMoves() {
+		from := resolveProjectPath(projectRoot, move.From)
+		to := resolveProjectPath(projectRoot, move.To)
+		if _, err := os.Stat(from); os.IsNotExist(err) {
+			continue
+		}
+		if _, err := os.Stat(to); err == nil {
+			undoMoves()
+			return nil, fmt.Errorf("cannot move %s: %s already exists", move.From, move.To)
+		}
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			undoMoves()
+			return nil, err
+		}
+		if err := os.Rename(from, to); err != nil {
+			undoMoves()
+			return nil, err
+		}
+		moved = append(moved, move)
+	}
+
+	// Files below a moved directory still have their old key names. Stored
+	// paths may be relative or absolute, so renames use absolute paths to keep
+	// them in depth order.
+	renames := &repository.RenumberPlan{}
+	for _, change := range plan.Changes {
+		if change.OldFilePath == nil || change.NewFilePath == nil {
+			continue
+		}
+		movedPath := filepath.ToSlash(resolveProjectPath(projectRoot, plan.MovedPath(*change.OldFilePath)))
+		newPath := filepath.ToSlash(resolveProjectPath(projectRoot, *change.NewFilePath))
+		renames.Changes = append(renames.Changes, &repository.KeyChange{OldFilePath: &movedPath, NewFilePath: &newPath})
+	}
+	done, err := applyRenumberRenames(projectRoot, planRenumberRenames(renames))
+	undo := func() {
+		undoRenumberRenames(projectRoot, done)
+		undoMoves()
+	}
+	if err != nil {
+		undo()
+		return nil, err
+	}
+	return undo, nil
+}
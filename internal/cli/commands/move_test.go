@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveEntityFiles_Feature(t *testing.T) {
+	dbWrapper := setupTestDB(t)
+	defer dbWrapper.Close()
+
+	ctx := context.Background()
+	strPtr := func(s string) *string { return &s }
+
+	e05 := &models.Epic{Key: "E05", Title: "Auth", Status: models.EpicStatusActive, Priority: models.PriorityMedium}
+	e06 := &models.Epic{Key: "E06", Title: "Payments", Status: models.EpicStatusActive, Priority: models.PriorityMedium}
+	require.NoError(t, repository.NewEpicRepository(dbWrapper).Create(ctx, e05))
+	require.NoError(t, repository.NewEpicRepository(dbWrapper).Create(ctx, e06))
+
+	feature := &models.Feature{EpicID: e05.ID, Key: "E05-F01", Title: "Login", Status: models.FeatureStatusActive,
+		FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/feature.md")}
+	require.NoError(t, repository.NewFeatureRepository(dbWrapper).Create(ctx, feature))
+	task := &models.Task{FeatureID: feature.ID, Key: "T-E05-F01-002", Title: "Form", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/tasks/T-E05-F01-002.md")}
+	require.NoError(t, repository.NewTaskRepository(dbWrapper).Create(ctx, task))
+
+	root := t.TempDir()
+	files := map[string]string{
+		*feature.FilePath: "---\nfeature_key: E05-F01\nepic_key: E05\n---\n# Login\n",
+		*task.FilePath:    "---\ntask_key: T-E05-F01-002\nfeature_key: E05-F01\nepic_key: E05\n---\n# Form\n",
+		"docs/plan/E05-auth/E05-F01-login/prd.md": "# PRD\n",
+	}
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs/plan/E06-payments"), 0755))
+
+	renumberRepo := repository.NewRenumberRepository(dbWrapper)
+	plan, err := renumberRepo.PlanFeatureMove(ctx, feature, e06, "docs/plan/E05-auth/E05-F01-login", "docs/plan/E06-payments")
+	require.NoError(t, err)
+
+	undo, err := moveEntityFiles(root, plan)
+	require.NoError(t, err)
+	newDir := filepath.Join(root, "docs/plan/E06-payments/E06-F01-login")
+	assert.FileExists(t, filepath.Join(newDir, "feature.md"))
+	assert.FileExists(t, filepath.Join(newDir, "prd.md"))
+	assert.FileExists(t, filepath.Join(newDir, "tasks/T-E06-F01-002.md"))
+	assert.NoDirExists(t, filepath.Join(root, "docs/plan/E05-auth/E05-F01-login"))
+
+	undo()
+	for path := range files {
+		assert.FileExists(t, filepath.Join(root, path))
+	}
+
+	frontmatterErrors, err := applyMovePlan(ctx, dbWrapper, renumberRepo, root, plan, plan.WithParentKeys(map[string]string{"E05": "E06"}).RewriteName)
+	require.NoError(t, err)
+	assert.Empty(t, frontmatterErrors)
+
+	content, err := os.ReadFile(filepath.Join(newDir, "tasks/T-E06-F01-002.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntask_key: T-E06-F01-002\nfeature_key: E06-F01\nepic_key: E06\n---\n# Form\n", string(content))
+
+	moved, err := repository.NewTaskRepository(dbWrapper).GetByKey(ctx, "T-E06-F01-002")
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, moved.ID)
+}
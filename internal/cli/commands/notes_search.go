@@ -21,8 +21,9 @@ var notesCmd = &cobra.Command{
 
 // notesSearchCmd searches notes across all tasks
 var notesSearchCmd = &cobra.Command{
-	Use:   "search <query>",
-	Short: "Search note content across all tasks",
+	Use:         "search <query>",
+	Short:       "Search note content across all tasks",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Search for notes containing the specified query across all tasks.
 
 The search is case-insensitive and supports filtering by epic, feature, note type, and time period.
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// addWeightedFlag registers --weighted on a command that shows progress
+func addWeightedFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("weighted", false, "Weight progress by task estimates (default: weighted_progress in .sharkconfig.json)")
+}
+
+// useWeightedProgress reports whether to weight progress by task estimates:
+// --weighted when given, else weighted_progress in .sharkconfig.json
+func useWeightedProgress(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("weighted"); flag != nil && flag.Changed {
+		weighted, _ := cmd.Flags().GetBool("weighted")
+		return weighted
+	}
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return false
+	}
+	cfg, err := config.NewManager(configPath).Load()
+	if err != nil {
+		return false
+	}
+	return cfg.WeightedProgress
+}
+
+// noteWeightedProgress tells human readers that progress is weighted, since it
+// can differ a lot from task counts, and warns when estimates in a second unit
+// were left out. repoDb may be nil to skip the unit check.
+func noteWeightedProgress(ctx context.Context, repoDb *repository.DB) {
+	if cli.GlobalConfig.JSON {
+		return
+	}
+	cli.Info("Progress is weighted by task estimates")
+	if repoDb == nil {
+		return
+	}
+	unit, ignored, err := repository.NewTaskEstimateRepository(repoDb).EstimateUnit(ctx)
+	if err != nil || ignored == 0 {
+		return
+	}
+	other := models.EstimateUnitHours
+	if unit == models.EstimateUnitHours {
+		other = models.EstimateUnitPoints
+	}
+	cli.Warning(fmt.Sprintf("%d task estimate(s) in %s were left out: weighted progress counts %s, the unit most estimates use. Re-estimate them with 'shark task update <key> --estimate'.", ignored, other, unit))
+}
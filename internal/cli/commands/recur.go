@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// recurCmd groups commands for recurring tasks
+var recurCmd = &cobra.Command{
+	Use:     "recur",
+	Short:   "Run and list recurring tasks",
+	GroupID: "status",
+	Long: `Create due occurrences of recurring tasks.
+
+A task becomes recurring with 'shark task recur <task-key> --every=7d'. The
+task is archived and kept as a template; 'shark recur run' copies it into a
+fresh todo task whenever its rule comes due.
+
+Examples:
+  shark recur list
+  shark recur run
+  shark recur run --dry-run`,
+}
+
+// recurRunCmd instantiates due occurrences
+var recurRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create tasks for recurrences that are due",
+	Long: `Create a fresh todo task for every recurring task that is due.
+
+Each occurrence copies the template's title, description, agent type,
+priority, and labels into a new task in the same feature. The next run is
+scheduled one interval later; runs missed while 'shark recur run' was not
+called are skipped, so a late run creates one occurrence, not a backlog.
+
+Safe to call from cron; it does nothing when no recurrence is due.
+
+Examples:
+  shark recur run
+  shark recur run --dry-run
+  shark recur run --json
+
+Cron (every hour):
+  0 * * * * cd /path/to/project && shark recur run`,
+	Args: cobra.NoArgs,
+	RunE: runRecurRun,
+}
+
+// recurListCmd lists recurring tasks
+var recurListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List recurring tasks",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List recurring tasks with their rule, next run, and most recent occurrence.
+
+Examples:
+  shark recur list
+  shark recur list --json`,
+	Args: cobra.NoArgs,
+	RunE: runRecurList,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(recurCmd)
+	recurCmd.AddCommand(recurRunCmd)
+	recurCmd.AddCommand(recurListCmd)
+
+	recurRunCmd.Flags().Bool("dry-run", false, "Show which occurrences are due without creating them")
+}
+
+// recurOccurrence reports the outcome of one due recurrence
+type recurOccurrence struct {
+	Template  string    `json:"template"`
+	Title     string    `json:"title"`
+	Created   string    `json:"created,omitempty"`
+	NextRunAt time.Time `json:"next_run_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// runRecurRun handles the recur run command
+func runRecurRun(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(60 * time.Second)
+	defer cancel()
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	recurRepo := repository.NewTaskRecurrenceRepository(repoDb)
+	now := time.Now()
+	due, err := recurRepo.ListDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	taskRepo := repository.NewTaskRepository(repoDb)
+	labelRepo := repository.NewLabelRepository(repoDb)
+
+	keygen := taskcreation.NewKeyGenerator(taskRepo, featureRepo)
+	validator := taskcreation.NewValidator(epicRepo, featureRepo, taskRepo)
+	loader := templates.NewLoader("")
+	registry := templates.NewRegistry(filepath.Join(projectRoot, templates.DefaultProjectTemplateDir))
+	renderer := templates.NewRendererWithRegistry(loader, registry)
+	creator := taskcreation.NewCreator(repoDb, keygen, validator, renderer, taskRepo, repository.NewTaskHistoryRepository(repoDb), epicRepo, featureRepo, projectRoot, nil)
+
+	occurrences := make([]*recurOccurrence, 0, len(due))
+	failed := 0
+	for _, rec := range due {
+		occurrence := &recurOccurrence{Template: rec.TaskKey}
+		occurrences = append(occurrences, occurrence)
+
+		if occurrence.NextRunAt, err = rec.NextAfter(now); err != nil {
+			occurrence.Error = err.Error()
+			failed++
+			continue
+		}
+
+		created, err := createRecurOccurrence(ctx, creator, taskRepo, featureRepo, epicRepo, labelRepo, rec, occurrence, dryRun)
+		if err != nil {
+			occurrence.Error = err.Error()
+			failed++
+			continue
+		}
+		if created == nil {
+			continue
+		}
+
+		occurrence.Created = created.Key
+		if err := recurRepo.RecordRun(ctx, rec.TaskID, now, occurrence.NextRunAt, created.Key); err != nil {
+			occurrence.Error = err.Error()
+			failed++
+			continue
+		}
+		triggerStatusCascade(ctx, repoDb, created.FeatureID)
+	}
+
+	if cli.GlobalConfig.JSON {
+		if err := cli.OutputJSON(map[string]interface{}{
+			"dry_run":     dryRun,
+			"occurrences": occurrences,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printRecurOccurrences(occurrences, dryRun)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d due recurrence(s) failed", failed, len(due))
+	}
+	return nil
+}
+
+// createRecurOccurrence copies a recurring task's template into a new task.
+// In a dry run nothing is created and the returned task is nil.
+func createRecurOccurrence(ctx context.Context, creator *taskcreation.Creator, taskRepo *repository.TaskRepository, featureRepo *repository.FeatureRepository, epicRepo *repository.EpicRepository, labelRepo *repository.LabelRepository, rec *models.TaskRecurrence, occurrence *recurOccurrence, dryRun bool) (*models.Task, error) {
+	template, err := taskRepo.GetByID(ctx, rec.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template task: %w", err)
+	}
+	occurrence.Title = template.Title
+	if dryRun {
+		return nil, nil
+	}
+
+	feature, err := featureRepo.GetByID(ctx, template.FeatureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature: %w", err)
+	}
+	epic, err := epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epic: %w", err)
+	}
+
+	input := taskcreation.CreateTaskInput{
+		EpicKey:    epic.Key,
+		FeatureKey: feature.Key,
+		Title:      template.Title,
+		Priority:   template.Priority,
+	}
+	if template.Description != nil {
+		input.Description = *template.Description
+	}
+	if template.AgentType != nil {
+		input.AgentType = *template.AgentType
+	}
+
+	result, err := creator.CreateTask(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	labels, err := labelRepo.GetTaskLabels(ctx, []int64{template.ID})
+	if err == nil && len(labels[template.ID]) > 0 {
+		if err := labelRepo.AddTaskLabels(ctx, result.Task.ID, labels[template.ID]); err != nil {
+			return result.Task, fmt.Errorf("task %s created but labels could not be copied: %w", result.Task.Key, err)
+		}
+	}
+
+	return result.Task, nil
+}
+
+// printRecurOccurrences prints the outcome of a recur run
+func printRecurOccurrences(occurrences []*recurOccurrence, dryRun bool) {
+	if len(occurrences) == 0 {
+		fmt.Println("No recurring tasks are due")
+		return
+	}
+
+	headers := []string{"Template", "Title", "Created", "Next Run"}
+	rows := make([][]string, len(occurrences))
+	for i, o := range occurrences {
+		created := o.Created
+		switch {
+		case o.Error != "":
+			created = "error: " + o.Error
+		case dryRun:
+			created = "(dry run)"
+		}
+		rows[i] = []string{o.Template, o.Title, created, o.NextRunAt.Local().Format("2006-01-02 15:04")}
+	}
+	cli.OutputTable(headers, rows)
+}
+
+// runRecurList handles the recur list command
+func runRecurList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	recurrences, err := repository.NewTaskRecurrenceRepository(repoDb).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(recurrences)
+	}
+
+	if len(recurrences) == 0 {
+		fmt.Println("No recurring tasks")
+		return nil
+	}
+
+	headers := []string{"Template", "Every", "Next Run", "Last Occurrence"}
+	rows := make([][]string, len(recurrences))
+	for i, rec := range recurrences {
+		last := "-"
+		if rec.LastTaskKey != nil {
+			last = *rec.LastTaskKey
+		}
+		rows[i] = []string{rec.TaskKey, rec.Rule, rec.NextRunAt.Local().Format("2006-01-02 15:04"), last}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
@@ -1,9 +1,7 @@
 package commands
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -60,8 +58,9 @@ Examples:
 
 // relatedDocsListCmd lists documents for a parent entity
 var relatedDocsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List related documents",
+	Use:         "list",
+	Short:       "List related documents",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List all documents linked to an epic, feature, or task.
 
 Requires exactly one of --epic, --feature, or --task flags.
@@ -100,7 +99,7 @@ func runRelatedDocsAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Get database connection
@@ -219,7 +218,7 @@ func runRelatedDocsDelete(cmd *cobra.Command, args []string) error {
 	task, _ := cmd.Flags().GetString("task")
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Get database connection
@@ -362,7 +361,7 @@ func runRelatedDocsListList(cmd *cobra.Command, args []string) error {
 	jsonOutput, _ := cmd.Flags().GetBool("json")
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Get database connection
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/progress"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// burndownBarWidth is the width of the ASCII burndown bars
+const burndownBarWidth = 40
+
+// reportCmd is the parent command for progress reports
+var reportCmd = &cobra.Command{
+	Use:         "report",
+	Short:       "Progress reports",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
+	Long: `Reports on project progress and remaining work.
+
+Burndown reports are built from recorded progress history; record it with
+'shark snapshot' before running them.`,
+}
+
+// reportBurndownCmd renders a burndown chart for an epic or feature
+var reportBurndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Show a burndown chart for an epic or feature",
+	Long: `Render remaining work over time from recorded progress snapshots,
+with the average daily progress rate and a projected completion date.
+
+Pass --target to check whether the projection meets a target date.
+
+Examples:
+  shark report burndown --epic=E05
+  shark report burndown --epic=E05 --target=2026-03-31
+  shark report burndown --feature=E05-F02 --since=2026-02-01
+  shark report burndown --epic=E05 --json`,
+	Args: cobra.NoArgs,
+	RunE: runReportBurndown,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportBurndownCmd)
+
+	reportBurndownCmd.Flags().String("epic", "", "Epic key")
+	reportBurndownCmd.Flags().String("feature", "", "Feature key")
+	reportBurndownCmd.Flags().String("since", "", "Only include snapshots on or after this date (YYYY-MM-DD)")
+	reportBurndownCmd.Flags().String("target", "", "Target completion date (YYYY-MM-DD)")
+}
+
+// runReportBurndown handles the report burndown command
+func runReportBurndown(cmd *cobra.Command, args []string) error {
+	epicKey, _ := cmd.Flags().GetString("epic")
+	featureKey, _ := cmd.Flags().GetString("feature")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	targetStr, _ := cmd.Flags().GetString("target")
+
+	if (epicKey == "") == (featureKey == "") {
+		return fmt.Errorf("specify exactly one of --epic or --feature")
+	}
+
+	var since, target *time.Time
+	if sinceStr != "" {
+		t, err := time.ParseInLocation("2006-01-02", sinceStr, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", sinceStr)
+		}
+		since = &t
+	}
+	if targetStr != "" {
+		t, err := time.ParseInLocation("2006-01-02", targetStr, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --target date %q: expected YYYY-MM-DD", targetStr)
+		}
+		target = &t
+	}
+
+	// Get database connection
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+
+	var entityType, key, title string
+	var entityID int64
+	if epicKey != "" {
+		epic, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, epicKey)
+		if err != nil {
+			return fmt.Errorf("epic %s not found", epicKey)
+		}
+		entityType, entityID, key, title = models.SnapshotEntityEpic, epic.ID, epic.Key, epic.Title
+	} else {
+		feature, err := repository.NewFeatureRepository(repoDb).GetByKey(ctx, featureKey)
+		if err != nil {
+			return fmt.Errorf("feature %s not found", featureKey)
+		}
+		entityType, entityID, key, title = models.SnapshotEntityFeature, feature.ID, feature.Key, feature.Title
+	}
+
+	snapshots, err := repository.NewProgressSnapshotRepository(repoDb).ListByEntity(ctx, entityType, entityID, since)
+	if err != nil {
+		return fmt.Errorf("failed to get progress history: %w", err)
+	}
+
+	samples := make([]progress.Sample, len(snapshots))
+	for i, s := range snapshots {
+		samples[i] = progress.Sample{
+			RecordedAt:     s.RecordedAt.Local(),
+			ProgressPct:    s.ProgressPct,
+			TotalTasks:     s.TotalTasks,
+			CompletedTasks: s.CompletedTasks,
+		}
+	}
+	burndown := progress.BuildBurndown(samples, target)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"entity_type": entityType,
+			"key":         key,
+			"title":       title,
+			"burndown":    burndown,
+		})
+	}
+
+	fmt.Printf("Burndown: %s - %s\n\n", key, title)
+
+	if len(burndown.Points) == 0 {
+		fmt.Println("No progress snapshots recorded yet.")
+		fmt.Println("Run 'shark snapshot' periodically to build progress history.")
+		return nil
+	}
+
+	fmt.Print(renderBurndownChart(burndown.Points))
+	fmt.Println()
+	fmt.Printf("Daily rate: %+.1f%%/day\n", burndown.DailyRate)
+
+	if burndown.ProjectedCompletion != nil {
+		fmt.Printf("Projected completion: %s\n", burndown.ProjectedCompletion.Format("2006-01-02"))
+	} else {
+		fmt.Println("Projected completion: unknown (no forward progress)")
+	}
+
+	if target != nil {
+		fmt.Printf("Target date: %s", target.Format("2006-01-02"))
+		switch {
+		case burndown.OnTrack == nil:
+			fmt.Println()
+		case *burndown.OnTrack:
+			fmt.Println(" (on track)")
+		default:
+			fmt.Println(" (behind)")
+		}
+	}
+
+	return nil
+}
+
+// renderBurndownChart renders burndown points as ASCII bars of remaining work
+func renderBurndownChart(points []progress.BurndownPoint) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-10s  %-*s  %6s  %s\n", "Date", burndownBarWidth, "Remaining", "Done", "Tasks")
+	for _, p := range points {
+		filled := int(p.RemainingPct/100.0*burndownBarWidth + 0.5)
+		if filled < 0 {
+			filled = 0
+		}
+		if filled > burndownBarWidth {
+			filled = burndownBarWidth
+		}
+		bar := strings.Repeat("#", filled) + strings.Repeat(".", burndownBarWidth-filled)
+		fmt.Fprintf(&b, "%-10s  %s  %5.1f%%  %d/%d\n", p.Date, bar, p.ProgressPct, p.CompletedTasks, p.TotalTasks)
+	}
+	return b.String()
+}
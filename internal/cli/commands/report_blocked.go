@@ -0,0 +1,444 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// escalationNotePrefix starts the note added to a task by --escalate. A task
+// with such a note since it was blocked is not escalated again.
+const escalationNotePrefix = "Escalated:"
+
+// reportBlockedCmd lists tasks that have been blocked longer than a threshold
+var reportBlockedCmd = &cobra.Command{
+	Use:         "blocked",
+	Short:       "List tasks blocked longer than a threshold",
+	Annotations: map[string]string{cli.WritingFlagsAnnotation: "escalate"},
+	Long: `List tasks that have been blocked longer than --older-than, oldest first,
+with their blocked reason, the open tasks that depend on them (directly or
+through other tasks), and the impact on each feature and epic.
+
+--escalate raises each listed task's priority by one level (1 is highest) and
+adds an escalation note. A task is escalated once per blocked period: tasks
+with an escalation note since they were blocked are skipped. Use
+--escalate-with to apply only one of the two; without the note, each run
+raises priority again.
+
+Examples:
+  shark report blocked
+  shark report blocked --older-than=7d --epic=E05
+  shark report blocked --older-than=48h --escalate
+  shark report blocked --escalate --escalate-with=note --json`,
+	Args: cobra.NoArgs,
+	RunE: runReportBlocked,
+}
+
+func init() {
+	reportCmd.AddCommand(reportBlockedCmd)
+
+	reportBlockedCmd.Flags().String("older-than", "48h", "Only include tasks blocked at least this long (e.g. 12h, 2d, 1w)")
+	reportBlockedCmd.Flags().String("epic", "", "Only include tasks in this epic")
+	reportBlockedCmd.Flags().Bool("escalate", false, "Raise priority and add an escalation note to each listed task")
+	reportBlockedCmd.Flags().StringSlice("escalate-with", []string{"priority", "note"}, "Escalation actions: priority, note")
+}
+
+// BlockedTaskReport is a task blocked longer than the report threshold
+type BlockedTaskReport struct {
+	Key           string     `json:"key"`
+	Title         string     `json:"title"`
+	FeatureKey    string     `json:"feature_key"`
+	EpicKey       string     `json:"epic_key"`
+	Priority      int        `json:"priority"`
+	BlockedAt     *time.Time `json:"blocked_at,omitempty"`
+	BlockedHours  float64    `json:"blocked_hours"`
+	BlockedReason *string    `json:"blocked_reason,omitempty"`
+	Affected      []string   `json:"affected_tasks"` // Open tasks that depend on this task, directly or transitively
+	Escalated     bool       `json:"escalated"`
+
+	task *models.Task
+}
+
+// BlockedImpact summarizes aged blocked tasks for a feature or epic
+type BlockedImpact struct {
+	Key           string `json:"key"`
+	Title         string `json:"title"`
+	BlockedTasks  int    `json:"blocked_tasks"`  // Tasks blocked longer than the threshold
+	AffectedTasks int    `json:"affected_tasks"` // Open tasks waiting on them
+	OpenTasks     int    `json:"open_tasks"`
+}
+
+// BlockedAgingReport is the result of shark report blocked
+type BlockedAgingReport struct {
+	OlderThan string               `json:"older_than"`
+	Tasks     []*BlockedTaskReport `json:"tasks"`
+	Features  []*BlockedImpact     `json:"features"`
+	Epics     []*BlockedImpact     `json:"epics"`
+}
+
+// runReportBlocked handles the report blocked command
+func runReportBlocked(cmd *cobra.Command, args []string) error {
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	epicKey, _ := cmd.Flags().GetString("epic")
+	escalate, _ := cmd.Flags().GetBool("escalate")
+	escalateWith, _ := cmd.Flags().GetStringSlice("escalate-with")
+
+	threshold, err := models.ParseRecurrenceRule(olderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than %q: use a number followed by h, d, or w (e.g. 48h)", olderThan)
+	}
+	bumpPriority, addNote := false, false
+	for _, action := range escalateWith {
+		switch strings.TrimSpace(action) {
+		case "priority":
+			bumpPriority = true
+		case "note":
+			addNote = true
+		default:
+			return fmt.Errorf("invalid --escalate-with %q: must be priority or note", action)
+		}
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+
+	epicRepo := repository.NewEpicRepository(repoDb)
+	if epicKey != "" {
+		epicKey = NormalizeKey(epicKey)
+		if _, err := epicRepo.GetByKey(ctx, epicKey); err != nil {
+			return fmt.Errorf("epic %s not found", epicKey)
+		}
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	tasks, err := taskRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	features, err := repository.NewFeatureRepository(repoDb).List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list features: %w", err)
+	}
+	epics, err := epicRepo.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list epics: %w", err)
+	}
+
+	report := buildBlockedAgingReport(tasks, features, epics, epicKey, threshold, time.Now())
+	report.OlderThan = olderThan
+
+	if escalate {
+		escalateBlockedTasks(ctx, repoDb, report.Tasks, olderThan, bumpPriority, addNote)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(report)
+	}
+
+	if len(report.Tasks) == 0 {
+		cli.Info(fmt.Sprintf("No tasks blocked longer than %s", olderThan))
+		return nil
+	}
+
+	headers := []string{"Task", "Title", "Blocked For", "Priority", "Reason", "Affected"}
+	rows := make([][]string, len(report.Tasks))
+	for i, t := range report.Tasks {
+		reason := "-"
+		if t.BlockedReason != nil && *t.BlockedReason != "" {
+			reason = truncateCell(*t.BlockedReason, 40)
+		}
+		affected := "-"
+		if len(t.Affected) > 0 {
+			affected = fmt.Sprintf("%d (%s)", len(t.Affected), truncateCell(strings.Join(t.Affected, ", "), 40))
+		}
+		priority := strconv.Itoa(t.Priority)
+		if t.Escalated {
+			priority += " (escalated)"
+		}
+		rows[i] = []string{
+			t.Key,
+			truncateCell(t.Title, 35),
+			utils.HumanizeDuration(time.Duration(t.BlockedHours * float64(time.Hour))),
+			priority,
+			reason,
+			affected,
+		}
+	}
+	cli.OutputTable(headers, rows)
+
+	impactHeaders := []string{"Key", "Title", "Blocked", "Affected", "Open Tasks"}
+	for _, section := range []struct {
+		name    string
+		impacts []*BlockedImpact
+	}{{"Feature impact", report.Features}, {"Epic impact", report.Epics}} {
+		fmt.Printf("\n%s:\n", section.name)
+		rows := make([][]string, len(section.impacts))
+		for i, impact := range section.impacts {
+			rows[i] = []string{
+				impact.Key,
+				truncateCell(impact.Title, 35),
+				strconv.Itoa(impact.BlockedTasks),
+				strconv.Itoa(impact.AffectedTasks),
+				strconv.Itoa(impact.OpenTasks),
+			}
+		}
+		cli.OutputTable(impactHeaders, rows)
+	}
+
+	return nil
+}
+
+// buildBlockedAgingReport finds tasks blocked at least threshold before now,
+// oldest first, with the open tasks that depend on them and the impact on
+// their features and epics. A non-empty epicKey limits the report to that epic.
+// Tasks without a blocked_at time are aged from their last update.
+func buildBlockedAgingReport(tasks []*models.Task, features []*models.Feature, epics []*models.Epic, epicKey string, threshold time.Duration, now time.Time) *BlockedAgingReport {
+	featureByID := make(map[int64]*models.Feature, len(features))
+	for _, f := range features {
+		featureByID[f.ID] = f
+	}
+	epicByID := make(map[int64]*models.Epic, len(epics))
+	for _, e := range epics {
+		epicByID[e.ID] = e
+	}
+
+	// Open tasks that list each task key in depends_on
+	dependents := make(map[string][]string)
+	for _, t := range tasks {
+		if !isOpenTask(t) || t.DependsOn == nil || *t.DependsOn == "" {
+			continue
+		}
+		var deps []string
+		if err := json.Unmarshal([]byte(*t.DependsOn), &deps); err != nil {
+			continue
+		}
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], t.Key)
+		}
+	}
+
+	report := &BlockedAgingReport{Tasks: []*BlockedTaskReport{}, Features: []*BlockedImpact{}, Epics: []*BlockedImpact{}}
+	featureImpact := make(map[int64]*BlockedImpact)
+	epicImpact := make(map[int64]*BlockedImpact)
+	affectedByFeature := make(map[int64]map[string]bool)
+	affectedByEpic := make(map[int64]map[string]bool)
+
+	for _, t := range tasks {
+		if t.Status != models.TaskStatusBlocked {
+			continue
+		}
+		feature := featureByID[t.FeatureID]
+		if feature == nil {
+			continue
+		}
+		epic := epicByID[feature.EpicID]
+		if epic == nil || (epicKey != "" && epic.Key != epicKey) {
+			continue
+		}
+
+		blockedAt := t.UpdatedAt
+		if t.BlockedAt.Valid {
+			blockedAt = t.BlockedAt.Time
+		}
+		age := now.Sub(blockedAt)
+		if age < threshold {
+			continue
+		}
+
+		entry := &BlockedTaskReport{
+			Key:           t.Key,
+			Title:         t.Title,
+			FeatureKey:    feature.Key,
+			EpicKey:       epic.Key,
+			Priority:      t.Priority,
+			BlockedHours:  float64(int(age.Hours()*10)) / 10,
+			BlockedReason: t.BlockedReason,
+			Affected:      transitiveDependents(t.Key, dependents),
+			task:          t,
+		}
+		if t.BlockedAt.Valid {
+			blockedTime := t.BlockedAt.Time
+			entry.BlockedAt = &blockedTime
+		}
+		report.Tasks = append(report.Tasks, entry)
+
+		if featureImpact[feature.ID] == nil {
+			featureImpact[feature.ID] = &BlockedImpact{Key: feature.Key, Title: feature.Title}
+			affectedByFeature[feature.ID] = make(map[string]bool)
+			report.Features = append(report.Features, featureImpact[feature.ID])
+		}
+		if epicImpact[epic.ID] == nil {
+			epicImpact[epic.ID] = &BlockedImpact{Key: epic.Key, Title: epic.Title}
+			affectedByEpic[epic.ID] = make(map[string]bool)
+			report.Epics = append(report.Epics, epicImpact[epic.ID])
+		}
+		featureImpact[feature.ID].BlockedTasks++
+		epicImpact[epic.ID].BlockedTasks++
+		for _, key := range entry.Affected {
+			affectedByFeature[feature.ID][key] = true
+			affectedByEpic[epic.ID][key] = true
+		}
+	}
+
+	for _, t := range tasks {
+		if !isOpenTask(t) {
+			continue
+		}
+		if impact := featureImpact[t.FeatureID]; impact != nil {
+			impact.OpenTasks++
+		}
+		if feature := featureByID[t.FeatureID]; feature != nil {
+			if impact := epicImpact[feature.EpicID]; impact != nil {
+				impact.OpenTasks++
+			}
+		}
+	}
+	for id, impact := range featureImpact {
+		impact.AffectedTasks = len(affectedByFeature[id])
+	}
+	for id, impact := range epicImpact {
+		impact.AffectedTasks = len(affectedByEpic[id])
+	}
+
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		if report.Tasks[i].BlockedHours != report.Tasks[j].BlockedHours {
+			return report.Tasks[i].BlockedHours > report.Tasks[j].BlockedHours
+		}
+		return report.Tasks[i].Key < report.Tasks[j].Key
+	})
+	sortImpacts := func(impacts []*BlockedImpact) {
+		sort.SliceStable(impacts, func(i, j int) bool {
+			if impacts[i].AffectedTasks != impacts[j].AffectedTasks {
+				return impacts[i].AffectedTasks > impacts[j].AffectedTasks
+			}
+			return impacts[i].Key < impacts[j].Key
+		})
+	}
+	sortImpacts(report.Features)
+	sortImpacts(report.Epics)
+
+	return report
+}
+
+// transitiveDependents returns every task reachable from key through the
+// dependents map, sorted by key
+func transitiveDependents(key string, dependents map[string][]string) []string {
+	seen := map[string]bool{key: true}
+	queue := []string{key}
+	affected := []string{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current] {
+			if seen[dependent] {
+				continue
+			}
+			seen[dependent] = true
+			affected = append(affected, dependent)
+			queue = append(queue, dependent)
+		}
+	}
+	sort.Strings(affected)
+	return affected
+}
+
+// truncateCell shortens s to max characters for table output
+func truncateCell(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
+
+// isOpenTask reports whether a task still has work remaining
+func isOpenTask(t *models.Task) bool {
+	return t.Status != models.TaskStatusCompleted && t.Status != models.TaskStatusArchived
+}
+
+// escalateBlockedTasks raises the priority of and/or adds an escalation note to
+// each reported task not already escalated since it was blocked. Failures are
+// reported as warnings so one task doesn't stop the rest.
+func escalateBlockedTasks(ctx context.Context, repoDb *repository.DB, entries []*BlockedTaskReport, olderThan string, bumpPriority, addNote bool) {
+	taskRepo := repository.NewTaskRepository(repoDb)
+	noteRepo := repository.NewTaskNoteRepository(repoDb)
+	agent := getAgentIdentifier("")
+
+	for _, entry := range entries {
+		task := entry.task
+		escalated, err := escalatedSinceBlocked(ctx, noteRepo, task)
+		if err != nil {
+			cli.Warning(fmt.Sprintf("Failed to check escalation of %s: %v", task.Key, err))
+			continue
+		}
+		if escalated {
+			continue
+		}
+
+		changed := false
+		if bumpPriority && task.Priority > 1 {
+			auditBefore := auditFields(task)
+			task.Priority--
+			if err := taskRepo.Update(ctx, task); err != nil {
+				if errors.Is(err, repository.ErrVersionConflict) {
+					cli.Warning(fmt.Sprintf("Skipped escalating %s: it was modified concurrently", task.Key))
+				} else {
+					cli.Warning(fmt.Sprintf("Failed to raise priority of %s: %v", task.Key, err))
+				}
+				task.Priority++
+				continue
+			}
+			recordAuditUpdate(ctx, repoDb, models.AuditEntityTask, task.Key, auditBefore, task)
+			entry.Priority = task.Priority
+			changed = true
+		}
+
+		if addNote {
+			content := fmt.Sprintf("%s blocked for %s (threshold %s)", escalationNotePrefix, utils.HumanizeDuration(time.Duration(entry.BlockedHours*float64(time.Hour))), olderThan)
+			if len(entry.Affected) > 0 {
+				content += fmt.Sprintf(", holding up %d task(s): %s", len(entry.Affected), strings.Join(entry.Affected, ", "))
+			}
+			note := &models.TaskNote{TaskID: task.ID, NoteType: models.NoteTypeBlocker, Content: content, CreatedBy: &agent}
+			if err := noteRepo.Create(ctx, note); err != nil {
+				cli.Warning(fmt.Sprintf("Failed to add escalation note to %s: %v", task.Key, err))
+			} else {
+				changed = true
+			}
+		}
+
+		entry.Escalated = changed
+	}
+}
+
+// escalatedSinceBlocked reports whether a task has an escalation note from its
+// current blocked period
+func escalatedSinceBlocked(ctx context.Context, noteRepo *repository.TaskNoteRepository, task *models.Task) (bool, error) {
+	notes, err := noteRepo.GetByTaskIDAndType(ctx, task.ID, []string{string(models.NoteTypeBlocker)})
+	if err != nil {
+		return false, err
+	}
+	for _, note := range notes {
+		if !strings.HasPrefix(note.Content, escalationNotePrefix) {
+			continue
+		}
+		if !task.BlockedAt.Valid || !note.CreatedAt.Before(task.BlockedAt.Time) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
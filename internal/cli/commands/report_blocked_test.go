@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBlockedAgingReport(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	dependsOn := func(keys string) *string { return &keys }
+	blocked := func(id, featureID int64, key string, age time.Duration) *models.Task {
+		return &models.Task{ID: id, FeatureID: featureID, Key: key, Status: models.TaskStatusBlocked, Priority: 5,
+			BlockedAt: sql.NullTime{Time: now.Add(-age), Valid: true}}
+	}
+
+	epics := []*models.Epic{{ID: 1, Key: "E01", Title: "Auth"}, {ID: 2, Key: "E02", Title: "Billing"}}
+	features := []*models.Feature{{ID: 10, EpicID: 1, Key: "E01-F01", Title: "Login"}, {ID: 20, EpicID: 2, Key: "E02-F01", Title: "Invoices"}}
+	tasks := []*models.Task{
+		blocked(1, 10, "T-E01-F01-001", 72*time.Hour),
+		{ID: 2, FeatureID: 10, Key: "T-E01-F01-002", Status: models.TaskStatusTodo, DependsOn: dependsOn(`["T-E01-F01-001"]`)},
+		{ID: 3, FeatureID: 10, Key: "T-E01-F01-003", Status: models.TaskStatusTodo, DependsOn: dependsOn(`["T-E01-F01-002"]`)},
+		{ID: 4, FeatureID: 10, Key: "T-E01-F01-004", Status: models.TaskStatusCompleted, DependsOn: dependsOn(`["T-E01-F01-001"]`)},
+		blocked(5, 10, "T-E01-F01-005", 12*time.Hour),
+		blocked(6, 20, "T-E02-F01-001", 96*time.Hour),
+	}
+
+	report := buildBlockedAgingReport(tasks, features, epics, "", 48*time.Hour, now)
+
+	require.Len(t, report.Tasks, 2, "tasks blocked under the threshold are left out")
+	assert.Equal(t, "T-E02-F01-001", report.Tasks[0].Key, "oldest first")
+	assert.Equal(t, 96.0, report.Tasks[0].BlockedHours)
+	assert.Empty(t, report.Tasks[0].Affected)
+
+	first := report.Tasks[1]
+	assert.Equal(t, "T-E01-F01-001", first.Key)
+	assert.Equal(t, "E01-F01", first.FeatureKey)
+	assert.Equal(t, "E01", first.EpicKey)
+	assert.Equal(t, []string{"T-E01-F01-002", "T-E01-F01-003"}, first.Affected, "includes transitive dependents but not completed tasks")
+
+	require.Len(t, report.Features, 2)
+	assert.Equal(t, &BlockedImpact{Key: "E01-F01", Title: "Login", BlockedTasks: 1, AffectedTasks: 2, OpenTasks: 4}, report.Features[0])
+	assert.Equal(t, &BlockedImpact{Key: "E02-F01", Title: "Invoices", BlockedTasks: 1, AffectedTasks: 0, OpenTasks: 1}, report.Features[1])
+	require.Len(t, report.Epics, 2)
+	assert.Equal(t, "E01", report.Epics[0].Key)
+
+	report = buildBlockedAgingReport(tasks, features, epics, "E02", 48*time.Hour, now)
+	require.Len(t, report.Tasks, 1)
+	assert.Equal(t, "T-E02-F01-001", report.Tasks[0].Key)
+}
+
+func TestTransitiveDependents(t *testing.T) {
+	dependents := map[string][]string{
+		"A": {"B", "C"},
+		"B": {"D"},
+		"D": {"A"}, // Cycles don't loop forever
+	}
+	assert.Equal(t, []string{"B", "C", "D"}, transitiveDependents("A", dependents))
+	assert.Equal(t, []string{}, transitiveDependents("C", dependents))
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// reportCapacityCmd sums remaining estimates by agent type and epic
+var reportCapacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Show remaining estimated work by agent type and epic",
+	Long: `Sum the remaining effort of open tasks (anything not completed or archived),
+grouped by agent type and epic, with totals per agent type.
+
+Remaining effort is each task's estimate less its actual effort so far.
+Points and hours are summed separately. Tasks without an estimate are counted
+as unestimated so gaps in planning stay visible.
+
+Examples:
+  shark report capacity
+  shark report capacity --agent-type=backend
+  shark report capacity --epic=E05 --json`,
+	Args: cobra.NoArgs,
+	RunE: runReportCapacity,
+}
+
+func init() {
+	reportCmd.AddCommand(reportCapacityCmd)
+
+	reportCapacityCmd.Flags().String("agent-type", "", "Only include tasks for this agent type")
+	reportCapacityCmd.Flags().String("epic", "", "Only include tasks in this epic")
+}
+
+// runReportCapacity handles the report capacity command
+func runReportCapacity(cmd *cobra.Command, args []string) error {
+	agentType, _ := cmd.Flags().GetString("agent-type")
+	epicKey, _ := cmd.Flags().GetString("epic")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+
+	filter := repository.CapacityFilter{}
+	if agentType != "" {
+		filter.AgentType = &agentType
+	}
+	if epicKey != "" {
+		if _, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, epicKey); err != nil {
+			return fmt.Errorf("epic %s not found", epicKey)
+		}
+		filter.EpicKey = &epicKey
+	}
+
+	rows, err := repository.NewTaskEstimateRepository(repoDb).Capacity(ctx, filter)
+	if err != nil {
+		return err
+	}
+	totals := capacityByAgentType(rows)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"by_epic":       rows,
+			"by_agent_type": totals,
+		})
+	}
+
+	if len(rows) == 0 {
+		cli.Info("No open tasks found")
+		return nil
+	}
+
+	headers := []string{"Agent Type", "Epic", "Open Tasks", "Unestimated", "Points", "Hours"}
+	tableRows := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		tableRows = append(tableRows, capacityTableRow(row, row.EpicKey))
+	}
+	cli.OutputTable(headers, tableRows)
+
+	fmt.Println("\nTotals by agent type:")
+	tableRows = make([][]string, 0, len(totals))
+	for _, row := range totals {
+		tableRows = append(tableRows, capacityTableRow(row, "all"))
+	}
+	cli.OutputTable(headers, tableRows)
+
+	return nil
+}
+
+// capacityByAgentType totals capacity rows per agent type, keeping the rows'
+// order. The totals have no epic.
+func capacityByAgentType(rows []*repository.CapacityRow) []*repository.CapacityRow {
+	totals := []*repository.CapacityRow{}
+	byAgent := make(map[string]*repository.CapacityRow)
+	for _, row := range rows {
+		total, ok := byAgent[row.AgentType]
+		if !ok {
+			total = &repository.CapacityRow{AgentType: row.AgentType}
+			byAgent[row.AgentType] = total
+			totals = append(totals, total)
+		}
+		total.OpenTasks += row.OpenTasks
+		total.UnestimatedTasks += row.UnestimatedTasks
+		total.RemainingPoints += row.RemainingPoints
+		total.RemainingHours += row.RemainingHours
+	}
+	return totals
+}
+
+// capacityTableRow formats a capacity row for table output
+func capacityTableRow(row *repository.CapacityRow, epic string) []string {
+	agentType := row.AgentType
+	if agentType == "" {
+		agentType = "(none)"
+	}
+	return []string{
+		agentType,
+		epic,
+		strconv.Itoa(row.OpenTasks),
+		strconv.Itoa(row.UnestimatedTasks),
+		strconv.FormatFloat(row.RemainingPoints, 'f', -1, 64),
+		strconv.FormatFloat(row.RemainingHours, 'f', -1, 64),
+	}
+}
@@ -0,0 +1,384 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// Report export formats
+const (
+	reportFormatMarkdown = "markdown"
+	reportFormatCSV      = "csv"
+)
+
+// reportExportCmd writes a progress report for stakeholders
+var reportExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a progress report as Markdown or CSV",
+	Long: `Write a human-readable progress report to share outside the CLI: an epic
+summary, a feature table with progress, tasks completed in the period, and
+blocked tasks with their reasons.
+
+The period starts at --since (default: 7 days ago). Without --epic, every
+epic is included. Without --output, the report is written to stdout. The
+format defaults to csv for a .csv output file and markdown otherwise.
+
+Examples:
+  shark report export --epic=E05
+  shark report export --epic=E05 --format=markdown --output=report.md
+  shark report export --epic=E05 --output=report.csv
+  shark report export --since=2026-03-01 | pbcopy`,
+	Args: cobra.NoArgs,
+	RunE: runReportExport,
+}
+
+func init() {
+	reportCmd.AddCommand(reportExportCmd)
+
+	reportExportCmd.Flags().String("epic", "", "Only report on this epic")
+	reportExportCmd.Flags().String("format", "", "Output format: markdown or csv")
+	reportExportCmd.Flags().StringP("output", "o", "", "Write the report to this file instead of stdout")
+	reportExportCmd.Flags().String("since", "", "Start of the reporting period (YYYY-MM-DD, default: 7 days ago)")
+}
+
+// progressReport is the content of an exported report
+type progressReport struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Since       time.Time     `json:"since"`
+	Epics       []*reportEpic `json:"epics"`
+}
+
+// reportEpic summarizes one epic in an exported report
+type reportEpic struct {
+	Key            string           `json:"key"`
+	Title          string           `json:"title"`
+	Status         string           `json:"status"`
+	ProgressPct    float64          `json:"progress_pct"`
+	TasksTotal     int              `json:"tasks_total"`
+	TasksCompleted int              `json:"tasks_completed"`
+	TasksBlocked   int              `json:"tasks_blocked"`
+	Features       []*reportFeature `json:"features"`
+	Completed      []*reportTask    `json:"completed"`
+	Blocked        []*reportTask    `json:"blocked"`
+}
+
+// reportFeature is one row of a report's feature table
+type reportFeature struct {
+	Key             string  `json:"key"`
+	Title           string  `json:"title"`
+	Status          string  `json:"status"`
+	ProgressPct     float64 `json:"progress_pct"`
+	TasksTotal      int     `json:"tasks_total"`
+	TasksCompleted  int     `json:"tasks_completed"`
+	TasksInProgress int     `json:"tasks_in_progress"`
+	TasksBlocked    int     `json:"tasks_blocked"`
+}
+
+// reportTask is a completed or blocked task in a report
+type reportTask struct {
+	Key           string     `json:"key"`
+	Title         string     `json:"title"`
+	Feature       string     `json:"feature"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	BlockedAt     *time.Time `json:"blocked_at,omitempty"`
+	BlockedReason string     `json:"blocked_reason,omitempty"`
+}
+
+// runReportExport handles the report export command
+func runReportExport(cmd *cobra.Command, args []string) error {
+	epicKey, _ := cmd.Flags().GetString("epic")
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	sinceStr, _ := cmd.Flags().GetString("since")
+
+	if format == "" {
+		format = reportFormatMarkdown
+		if strings.EqualFold(filepath.Ext(output), ".csv") {
+			format = reportFormatCSV
+		}
+	}
+	if format != reportFormatMarkdown && format != reportFormatCSV {
+		return fmt.Errorf("invalid format %q: use %s or %s", format, reportFormatMarkdown, reportFormatCSV)
+	}
+
+	now := time.Now()
+	since := startOfDay(now).AddDate(0, 0, -7)
+	if sinceStr != "" {
+		t, err := time.ParseInLocation("2006-01-02", sinceStr, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", sinceStr)
+		}
+		since = t
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	report, err := buildProgressReport(ctx, repoDb, epicKey, since, now)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if format == reportFormatCSV {
+		err = writeReportCSV(&buf, report)
+	} else {
+		err = writeReportMarkdown(&buf, report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(report)
+		}
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"path":   output,
+			"format": format,
+			"epics":  len(report.Epics),
+		})
+	}
+	cli.Success(fmt.Sprintf("Wrote %s report to %s", format, output))
+	return nil
+}
+
+// startOfDay returns midnight at the start of t's day
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// buildProgressReport gathers the report for one epic, or all epics when
+// epicKey is empty
+func buildProgressReport(ctx context.Context, repoDb *repository.DB, epicKey string, since, now time.Time) (*progressReport, error) {
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	taskRepo := repository.NewTaskRepository(repoDb)
+
+	var epics []*models.Epic
+	if epicKey != "" {
+		epic, err := epicRepo.GetByKey(ctx, epicKey)
+		if err != nil {
+			return nil, fmt.Errorf("epic %s not found", epicKey)
+		}
+		epics = []*models.Epic{epic}
+	} else {
+		all, err := epicRepo.List(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list epics: %w", err)
+		}
+		epics = all
+	}
+
+	report := &progressReport{GeneratedAt: now, Since: since, Epics: []*reportEpic{}}
+	for _, epic := range epics {
+		features, err := featureRepo.ListByEpic(ctx, epic.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list features for %s: %w", epic.Key, err)
+		}
+
+		entry := &reportEpic{
+			Key:       epic.Key,
+			Title:     epic.Title,
+			Status:    string(epic.Status),
+			Features:  []*reportFeature{},
+			Completed: []*reportTask{},
+			Blocked:   []*reportTask{},
+		}
+		for _, feature := range features {
+			tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tasks for %s: %w", feature.Key, err)
+			}
+			entry.addFeature(feature, tasks, since)
+		}
+		if entry.TasksTotal > 0 {
+			entry.ProgressPct = float64(entry.TasksCompleted) / float64(entry.TasksTotal) * 100
+		}
+		report.Epics = append(report.Epics, entry)
+	}
+	return report, nil
+}
+
+// addFeature adds a feature's row and its completed and blocked tasks to the epic
+func (e *reportEpic) addFeature(feature *models.Feature, tasks []*models.Task, since time.Time) {
+	row := &reportFeature{
+		Key:        feature.Key,
+		Title:      feature.Title,
+		Status:     string(feature.Status),
+		TasksTotal: len(tasks),
+	}
+	for _, task := range tasks {
+		switch task.Status {
+		case models.TaskStatusCompleted:
+			row.TasksCompleted++
+			if task.CompletedAt.Valid && !task.CompletedAt.Time.Before(since) {
+				completedAt := task.CompletedAt.Time
+				e.Completed = append(e.Completed, &reportTask{Key: task.Key, Title: task.Title, Feature: feature.Key, CompletedAt: &completedAt})
+			}
+		case models.TaskStatusInProgress:
+			row.TasksInProgress++
+		case models.TaskStatusBlocked:
+			row.TasksBlocked++
+			blocked := &reportTask{Key: task.Key, Title: task.Title, Feature: feature.Key}
+			if task.BlockedAt.Valid {
+				blockedAt := task.BlockedAt.Time
+				blocked.BlockedAt = &blockedAt
+			}
+			if task.BlockedReason != nil {
+				blocked.BlockedReason = *task.BlockedReason
+			}
+			e.Blocked = append(e.Blocked, blocked)
+		}
+	}
+	if row.TasksTotal > 0 {
+		row.ProgressPct = float64(row.TasksCompleted) / float64(row.TasksTotal) * 100
+	}
+
+	e.Features = append(e.Features, row)
+	e.TasksTotal += row.TasksTotal
+	e.TasksCompleted += row.TasksCompleted
+	e.TasksBlocked += row.TasksBlocked
+}
+
+// writeReportMarkdown renders the report as Markdown
+func writeReportMarkdown(w io.Writer, report *progressReport) error {
+	var sb strings.Builder
+	since := report.Since.Format("2006-01-02")
+
+	if len(report.Epics) == 1 {
+		fmt.Fprintf(&sb, "# Progress Report: %s %s\n\n", report.Epics[0].Key, report.Epics[0].Title)
+	} else {
+		sb.WriteString("# Progress Report\n\n")
+	}
+	fmt.Fprintf(&sb, "_Generated %s. Completed tasks since %s._\n", report.GeneratedAt.Format("2006-01-02 15:04"), since)
+
+	if len(report.Epics) == 0 {
+		sb.WriteString("\nNo epics found.\n")
+	}
+
+	for _, epic := range report.Epics {
+		fmt.Fprintf(&sb, "\n## %s: %s\n\n", epic.Key, markdownEscape(epic.Title))
+		fmt.Fprintf(&sb, "**Status:** %s | **Progress:** %.0f%% (%d/%d tasks) | **Blocked:** %d\n",
+			epic.Status, epic.ProgressPct, epic.TasksCompleted, epic.TasksTotal, epic.TasksBlocked)
+
+		sb.WriteString("\n### Features\n\n")
+		if len(epic.Features) == 0 {
+			sb.WriteString("_No features._\n")
+		} else {
+			sb.WriteString("| Feature | Title | Status | Progress | Tasks | In Progress | Blocked |\n")
+			sb.WriteString("|---------|-------|--------|----------|-------|-------------|---------|\n")
+			for _, f := range epic.Features {
+				fmt.Fprintf(&sb, "| %s | %s | %s | %.0f%% | %d/%d | %d | %d |\n",
+					f.Key, markdownEscape(f.Title), f.Status, f.ProgressPct, f.TasksCompleted, f.TasksTotal, f.TasksInProgress, f.TasksBlocked)
+			}
+		}
+
+		fmt.Fprintf(&sb, "\n### Completed Since %s\n\n", since)
+		if len(epic.Completed) == 0 {
+			sb.WriteString("_None._\n")
+		}
+		for _, t := range epic.Completed {
+			fmt.Fprintf(&sb, "- **%s** %s (%s), %s\n", t.Key, markdownEscape(t.Title), t.Feature, t.CompletedAt.Local().Format("2006-01-02"))
+		}
+
+		sb.WriteString("\n### Blocked\n\n")
+		if len(epic.Blocked) == 0 {
+			sb.WriteString("_None._\n")
+		}
+		for _, t := range epic.Blocked {
+			fmt.Fprintf(&sb, "- **%s** %s (%s)", t.Key, markdownEscape(t.Title), t.Feature)
+			if t.BlockedReason != "" {
+				fmt.Fprintf(&sb, ": %s", markdownEscape(t.BlockedReason))
+			}
+			if t.BlockedAt != nil {
+				fmt.Fprintf(&sb, " _(blocked for %s)_", utils.HumanizeDuration(report.GeneratedAt.Sub(*t.BlockedAt)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownEscape keeps free text from breaking Markdown tables and lines
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// writeReportCSV renders the report as one CSV table, with a section column
+// (epic, feature, completed, blocked) so it can be filtered in a spreadsheet
+func writeReportCSV(w io.Writer, report *progressReport) error {
+	cw := csv.NewWriter(w)
+	header := []string{"section", "epic", "feature", "key", "title", "status", "progress_pct", "tasks_completed", "tasks_total", "tasks_blocked", "date", "blocked_reason"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	pct := func(p float64) string { return strconv.FormatFloat(p, 'f', 1, 64) }
+	for _, epic := range report.Epics {
+		rows := [][]string{{
+			"epic", epic.Key, "", epic.Key, epic.Title, epic.Status, pct(epic.ProgressPct),
+			strconv.Itoa(epic.TasksCompleted), strconv.Itoa(epic.TasksTotal), strconv.Itoa(epic.TasksBlocked), "", "",
+		}}
+		for _, f := range epic.Features {
+			rows = append(rows, []string{
+				"feature", epic.Key, f.Key, f.Key, f.Title, f.Status, pct(f.ProgressPct),
+				strconv.Itoa(f.TasksCompleted), strconv.Itoa(f.TasksTotal), strconv.Itoa(f.TasksBlocked), "", "",
+			})
+		}
+		for _, t := range epic.Completed {
+			rows = append(rows, []string{
+				"completed", epic.Key, t.Feature, t.Key, t.Title, string(models.TaskStatusCompleted), "", "", "", "",
+				t.CompletedAt.Local().Format("2006-01-02"), "",
+			})
+		}
+		for _, t := range epic.Blocked {
+			blockedAt := ""
+			if t.BlockedAt != nil {
+				blockedAt = t.BlockedAt.Local().Format("2006-01-02")
+			}
+			rows = append(rows, []string{
+				"blocked", epic.Key, t.Feature, t.Key, t.Title, string(models.TaskStatusBlocked), "", "", "", "",
+				blockedAt, t.BlockedReason,
+			})
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
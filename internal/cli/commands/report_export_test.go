@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleProgressReport builds a one-epic report with a completed task inside
+// the period, one before it, and a blocked task
+func sampleProgressReport() *progressReport {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	since := time.Date(2026, 3, 3, 0, 0, 0, 0, time.Local)
+	reason := "Waiting on API | v2"
+
+	tasks := []*models.Task{
+		{Key: "T-E05-F01-001", Title: "Add model", Status: models.TaskStatusCompleted, CompletedAt: sql.NullTime{Time: now.AddDate(0, 0, -2), Valid: true}},
+		{Key: "T-E05-F01-002", Title: "Old work", Status: models.TaskStatusCompleted, CompletedAt: sql.NullTime{Time: now.AddDate(0, 0, -30), Valid: true}},
+		{Key: "T-E05-F01-003", Title: "Wire API", Status: models.TaskStatusBlocked, BlockedReason: &reason, BlockedAt: sql.NullTime{Time: now.AddDate(0, 0, -3), Valid: true}},
+		{Key: "T-E05-F01-004", Title: "Write docs", Status: models.TaskStatusTodo},
+	}
+
+	epic := &reportEpic{Key: "E05", Title: "Task CLI", Status: "active", Features: []*reportFeature{}, Completed: []*reportTask{}, Blocked: []*reportTask{}}
+	epic.addFeature(&models.Feature{Key: "E05-F01", Title: "Reports", Status: models.FeatureStatusActive}, tasks, since)
+	epic.ProgressPct = 50
+	return &progressReport{GeneratedAt: now, Since: since, Epics: []*reportEpic{epic}}
+}
+
+func TestReportEpicAddFeature(t *testing.T) {
+	report := sampleProgressReport()
+	epic := report.Epics[0]
+
+	require.Len(t, epic.Features, 1)
+	feature := epic.Features[0]
+	assert.Equal(t, 4, feature.TasksTotal)
+	assert.Equal(t, 2, feature.TasksCompleted)
+	assert.Equal(t, 1, feature.TasksBlocked)
+	assert.Equal(t, 50.0, feature.ProgressPct)
+
+	require.Len(t, epic.Completed, 1, "only tasks completed in the period")
+	assert.Equal(t, "T-E05-F01-001", epic.Completed[0].Key)
+	require.Len(t, epic.Blocked, 1)
+	assert.Equal(t, "Waiting on API | v2", epic.Blocked[0].BlockedReason)
+	assert.Equal(t, 4, epic.TasksTotal)
+}
+
+func TestWriteReportMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeReportMarkdown(&buf, sampleProgressReport()))
+	out := buf.String()
+
+	assert.Contains(t, out, "# Progress Report: E05 Task CLI")
+	assert.Contains(t, out, "**Status:** active | **Progress:** 50% (2/4 tasks) | **Blocked:** 1")
+	assert.Contains(t, out, "| E05-F01 | Reports | active | 50% | 2/4 | 0 | 1 |")
+	assert.Contains(t, out, "### Completed Since 2026-03-03")
+	assert.Contains(t, out, "- **T-E05-F01-001** Add model (E05-F01), 2026-03-08")
+	assert.NotContains(t, out, "Old work")
+	assert.Contains(t, out, `- **T-E05-F01-003** Wire API (E05-F01): Waiting on API \| v2`)
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeReportCSV(&buf, sampleProgressReport()))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5) // header, epic, feature, completed, blocked
+
+	assert.Equal(t, "section", records[0][0])
+	assert.Equal(t, []string{"epic", "E05", "", "E05", "Task CLI", "active", "50.0", "2", "4", "1", "", ""}, records[1])
+	assert.Equal(t, "feature", records[2][0])
+	assert.Equal(t, []string{"completed", "E05", "E05-F01", "T-E05-F01-001", "Add model", "completed", "", "", "", "", "2026-03-08", ""}, records[3])
+	assert.Equal(t, "Waiting on API | v2", records[4][11])
+}
@@ -0,0 +1,552 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// defaultReviewDiffLines caps the linked commit diffs shown by review next
+const defaultReviewDiffLines = 300
+
+// reviewCmd is the review queue for human approvers
+var reviewCmd = &cobra.Command{
+	Use:     "review",
+	Short:   "Work through tasks waiting for review",
+	GroupID: "essentials",
+	Long: `Work through the tasks waiting for a human to review them.
+
+The queue holds tasks in the workflow's review statuses, other than those an
+agent is responsible for: ready_for_review in the default workflow. Use
+--status to review other statuses.`,
+}
+
+// reviewListCmd shows the review queue
+var reviewListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "Show tasks waiting for review, grouped by feature",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Show the tasks waiting for review, grouped by feature, with how long each has
+waited since it entered its status. Features whose oldest task has waited
+longest come first.
+
+Examples:
+  shark review list
+  shark review list --epic=E04
+  shark review list --status=ready_for_code_review --json`,
+	Args: cobra.NoArgs,
+	RunE: runReviewList,
+}
+
+// reviewNextCmd reviews the task that has waited longest
+var reviewNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Review the task that has waited longest",
+	Long: `Show the task that has waited longest for review and act on it.
+
+The task's brief (see 'shark task brief') is shown with its notes and the git
+commits whose messages mention its key, with their diffs. Then enter one of:
+  a [: note]     approve the task (completed in the default workflow)
+  r: reason      reject it, sending it back with the reason recorded
+  o: reason      reopen it for more work, like 'shark task reopen'
+Press Enter to leave it in the queue.
+
+With --json, the task is shown as JSON and no action is offered.
+
+Examples:
+  shark review next
+  shark review next --feature=E04-F02
+  shark review next --max-diff-lines=0
+
+Prompt input examples:
+  a: looks good
+  r: login fails when the password has a space`,
+	Args: cobra.NoArgs,
+	RunE: runReviewNext,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(reviewCmd)
+	reviewCmd.AddCommand(reviewListCmd, reviewNextCmd)
+
+	for _, cmd := range []*cobra.Command{reviewListCmd, reviewNextCmd} {
+		cmd.Flags().String("epic", "", "Only review tasks in this epic")
+		cmd.Flags().String("feature", "", "Only review tasks in this feature")
+		cmd.Flags().StringSlice("status", nil, "Statuses to review (default: the workflow's review statuses)")
+	}
+	reviewNextCmd.Flags().Int("max-diff-lines", defaultReviewDiffLines, "Lines of linked commit diffs to show (0 for none)")
+	reviewNextCmd.Flags().String("agent", "", "Reviewer recorded in the task history (default: $USER)")
+}
+
+// ReviewFeature is one feature's tasks in the review queue
+type ReviewFeature struct {
+	FeatureKey   string        `json:"feature_key"`
+	FeatureTitle string        `json:"feature_title"`
+	EpicKey      string        `json:"epic_key"`
+	Tasks        []*ReviewTask `json:"tasks"`
+}
+
+// ReviewTask is a task in the review queue
+type ReviewTask struct {
+	Key          string    `json:"key"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	Priority     int       `json:"priority"`
+	AgentType    string    `json:"agent_type,omitempty"`
+	WaitingSince time.Time `json:"waiting_since"`
+	WaitingHours float64   `json:"waiting_hours"`
+
+	task *models.Task
+}
+
+// reviewStatuses returns the statuses the review queue holds: the given ones,
+// else the workflow's review-phase statuses that agents aren't responsible for
+func reviewStatuses(workflow *config.WorkflowConfig, given []string) []string {
+	if len(given) > 0 {
+		return given
+	}
+	statuses := []string{}
+	for _, status := range workflow.GetStatusesByPhase("review") {
+		if meta, _ := workflow.GetStatusMetadata(status); meta.Responsibility != "agent" {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// loadReviewQueue returns the review queue grouped by feature. Tasks are
+// oldest first within a feature, and features with the oldest task first.
+func loadReviewQueue(ctx context.Context, cmd *cobra.Command, repoDb *repository.DB, workflow *config.WorkflowConfig, now time.Time) ([]*ReviewFeature, error) {
+	epicKey, _ := cmd.Flags().GetString("epic")
+	featureKey, _ := cmd.Flags().GetString("feature")
+	given, _ := cmd.Flags().GetStringSlice("status")
+	if epicKey != "" {
+		epicKey = NormalizeKey(epicKey)
+	}
+	if featureKey != "" {
+		featureKey = NormalizeKey(featureKey)
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	tasks, err := taskRepo.ListByStatuses(ctx, reviewStatuses(workflow, given), epicKey, featureKey)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	entered, err := taskRepo.StatusEnteredAt(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	epicRepo := repository.NewEpicRepository(repoDb)
+	groups := map[int64]*ReviewFeature{}
+	queue := []*ReviewFeature{}
+	for _, task := range tasks {
+		group, ok := groups[task.FeatureID]
+		if !ok {
+			feature, err := featureRepo.GetByID(ctx, task.FeatureID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get feature of %s: %w", task.Key, err)
+			}
+			group = &ReviewFeature{FeatureKey: feature.Key, FeatureTitle: feature.Title, Tasks: []*ReviewTask{}}
+			if epic, err := epicRepo.GetByID(ctx, feature.EpicID); err == nil {
+				group.EpicKey = epic.Key
+			}
+			groups[task.FeatureID] = group
+			queue = append(queue, group)
+		}
+
+		since, ok := entered[task.ID]
+		if !ok {
+			since = task.UpdatedAt
+		}
+		group.Tasks = append(group.Tasks, &ReviewTask{
+			Key:          task.Key,
+			Title:        task.Title,
+			Status:       string(task.Status),
+			Priority:     task.Priority,
+			AgentType:    derefString(task.AgentType),
+			WaitingSince: since,
+			WaitingHours: roundHours(now.Sub(since)),
+			task:         task,
+		})
+	}
+
+	for _, group := range queue {
+		sort.SliceStable(group.Tasks, func(i, j int) bool {
+			return group.Tasks[i].WaitingSince.Before(group.Tasks[j].WaitingSince)
+		})
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].Tasks[0].WaitingSince.Before(queue[j].Tasks[0].WaitingSince)
+	})
+	return queue, nil
+}
+
+// roundHours converts a duration to hours with one decimal place
+func roundHours(d time.Duration) float64 {
+	return float64(int64(d.Hours()*10+0.5)) / 10
+}
+
+// oldestReviewTask returns the task that has waited longest, or nil
+func oldestReviewTask(queue []*ReviewFeature) *ReviewTask {
+	var oldest *ReviewTask
+	for _, group := range queue {
+		if oldest == nil || group.Tasks[0].WaitingSince.Before(oldest.WaitingSince) {
+			oldest = group.Tasks[0]
+		}
+	}
+	return oldest
+}
+
+// reviewWorkflow returns the workflow in effect for the project
+func reviewWorkflow() *config.WorkflowConfig {
+	configPath, _ := cli.GetConfigPath()
+	return config.GetWorkflowOrDefault(configPath)
+}
+
+// runReviewList handles the review list command
+func runReviewList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	now := time.Now()
+	queue, err := loadReviewQueue(ctx, cmd, repoDb, reviewWorkflow(), now)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(queue)
+	}
+	if len(queue) == 0 {
+		fmt.Println("Nothing is waiting for review")
+		return nil
+	}
+
+	total := 0
+	for _, group := range queue {
+		total += len(group.Tasks)
+		fmt.Printf("\n%s %s (%d)\n", group.FeatureKey, group.FeatureTitle, len(group.Tasks))
+		rows := make([][]string, len(group.Tasks))
+		for i, task := range group.Tasks {
+			rows[i] = []string{task.Key, truncateCell(task.Title, 60), task.Status, fmt.Sprintf("%d", task.Priority), utils.HumanizeDuration(now.Sub(task.WaitingSince))}
+		}
+		cli.OutputTable([]string{"Key", "Title", "Status", "Priority", "Waiting"}, rows)
+	}
+	fmt.Printf("%d task(s) waiting for review. Run 'shark review next' to review the oldest.\n", total)
+	return nil
+}
+
+// reviewAction is what the reviewer chose to do with a task
+type reviewAction string
+
+const (
+	reviewApprove reviewAction = "approve"
+	reviewReject  reviewAction = "reject"
+	reviewReopen  reviewAction = "reopen"
+)
+
+// reviewActionDone describes a task after each action
+var reviewActionDone = map[reviewAction]string{
+	reviewApprove: "approved",
+	reviewReject:  "rejected",
+	reviewReopen:  "reopened",
+}
+
+// reviewActionAliases maps prompt words to review actions
+var reviewActionAliases = map[string]reviewAction{
+	"a":       reviewApprove,
+	"approve": reviewApprove,
+	"r":       reviewReject,
+	"reject":  reviewReject,
+	"o":       reviewReopen,
+	"reopen":  reviewReopen,
+}
+
+// parseReviewInput parses one prompt line, e.g. "r: tests fail". An empty
+// line returns an empty action.
+func parseReviewInput(line string) (reviewAction, string, error) {
+	word, text, _ := strings.Cut(line, ":")
+	word = strings.ToLower(strings.TrimSpace(word))
+	text = strings.TrimSpace(text)
+	if word == "" {
+		if text != "" {
+			return "", "", fmt.Errorf("start with a, r, or o")
+		}
+		return "", "", nil
+	}
+	action, ok := reviewActionAliases[word]
+	if !ok {
+		return "", "", fmt.Errorf("unrecognized input %q: enter a, r, or o", word)
+	}
+	if action != reviewApprove && text == "" {
+		return "", "", fmt.Errorf("%s needs a reason, e.g. '%s: tests fail'", action, word)
+	}
+	return action, text, nil
+}
+
+// ReviewTargets are the statuses each review action moves a task to; empty
+// when the workflow offers none
+type ReviewTargets struct {
+	Approve string `json:"approve,omitempty"`
+	Reject  string `json:"reject,omitempty"`
+	Reopen  string `json:"reopen,omitempty"`
+}
+
+// reviewTargets picks the status each action moves a task in status to.
+// Approving prefers a done status, else the first forward move; rejecting
+// takes the first backward move; reopening a development status like
+// 'shark task reopen', else the rejection status.
+func reviewTargets(workflow *config.WorkflowConfig, status string) ReviewTargets {
+	var targets ReviewTargets
+	for _, next := range workflow.StatusFlow[status] {
+		meta, _ := workflow.GetStatusMetadata(next)
+		backward, _ := workflow.IsBackwardTransition(status, next)
+		switch {
+		case backward:
+			if targets.Reject == "" {
+				targets.Reject = next
+			}
+		case meta.Phase == "done":
+			if targets.Approve == "" || !isDonePhase(workflow, targets.Approve) {
+				targets.Approve = next
+			}
+		case meta.Phase != "any" && meta.Phase != "blocked" && targets.Approve == "":
+			targets.Approve = next
+		}
+	}
+	for _, next := range workflow.StatusFlow[status] {
+		for _, target := range reopenTargetStatuses {
+			if next == target && targets.Reopen == "" {
+				targets.Reopen = next
+			}
+		}
+	}
+	if targets.Reopen == "" {
+		targets.Reopen = targets.Reject
+	}
+	return targets
+}
+
+// isDonePhase reports whether status is in the workflow's done phase
+func isDonePhase(workflow *config.WorkflowConfig, status string) bool {
+	meta, _ := workflow.GetStatusMetadata(status)
+	return meta.Phase == "done"
+}
+
+// ReviewNext is the task shown by review next
+type ReviewNext struct {
+	*TaskBrief
+	WaitingSince time.Time          `json:"waiting_since"`
+	Queued       int                `json:"queued"` // Tasks waiting for review, this one included
+	Notes        []*models.TaskNote `json:"notes"`
+	Commits      []*ReviewCommit    `json:"commits"`
+	Actions      ReviewTargets      `json:"actions"`
+}
+
+// runReviewNext handles the review next command
+func runReviewNext(cmd *cobra.Command, args []string) error {
+	// The prompt waits on the reviewer, so only database work is timed
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	workflow := reviewWorkflow()
+	queue, err := loadReviewQueue(ctx, cmd, repoDb, workflow, time.Now())
+	if err != nil {
+		return err
+	}
+	oldest := oldestReviewTask(queue)
+	if oldest == nil {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(nil)
+		}
+		fmt.Println("Nothing is waiting for review")
+		return nil
+	}
+	queued := 0
+	for _, group := range queue {
+		queued += len(group.Tasks)
+	}
+
+	projectRoot, _ := cli.FindProjectRoot()
+	maxDiffLines, _ := cmd.Flags().GetInt("max-diff-lines")
+	next, err := buildReviewNext(ctx, repoDb, workflow, projectRoot, oldest, maxDiffLines)
+	if err != nil {
+		return err
+	}
+	next.Queued = queued
+	cancel()
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(next)
+	}
+
+	fmt.Print(formatReviewNext(next, time.Now()))
+
+	agentFlag, _ := cmd.Flags().GetString("agent")
+	agent := getAgentIdentifier(agentFlag)
+	return promptReviewAction(repoDb, workflow, next, agent, os.Stdin, os.Stdout)
+}
+
+// buildReviewNext gathers what a reviewer needs to judge a task
+func buildReviewNext(ctx context.Context, repoDb *repository.DB, workflow *config.WorkflowConfig, projectRoot string, item *ReviewTask, maxDiffLines int) (*ReviewNext, error) {
+	brief, err := buildTaskBrief(ctx, repoDb, projectRoot, item.task)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := repository.NewTaskNoteRepository(repoDb).GetByTaskID(ctx, item.task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	if notes == nil {
+		notes = []*models.TaskNote{}
+	}
+
+	next := &ReviewNext{
+		TaskBrief:    brief,
+		WaitingSince: item.WaitingSince,
+		Notes:        notes,
+		Commits:      []*ReviewCommit{},
+		Actions:      reviewTargets(workflow, string(item.task.Status)),
+	}
+	if projectRoot != "" {
+		if commits := linkedCommits(ctx, projectRoot, item.task.Key, maxDiffLines); commits != nil {
+			next.Commits = commits
+		}
+	}
+	return next, nil
+}
+
+// formatReviewNext renders the task under review as Markdown
+func formatReviewNext(next *ReviewNext, now time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Waiting for review for %s (%d in the queue)\n\n", utils.HumanizeDuration(now.Sub(next.WaitingSince)), next.Queued)
+	sb.WriteString(formatTaskBrief(next.TaskBrief))
+
+	if len(next.Notes) > 0 {
+		sb.WriteString("\n## Notes\n\n")
+		for _, note := range next.Notes {
+			fmt.Fprintf(&sb, "- %s [%s]", note.CreatedAt.Local().Format("2006-01-02 15:04"), note.NoteType)
+			if note.CreatedBy != nil && *note.CreatedBy != "" {
+				fmt.Fprintf(&sb, " %s", *note.CreatedBy)
+			}
+			fmt.Fprintf(&sb, ": %s\n", strings.Join(strings.Fields(note.Content), " "))
+		}
+	}
+
+	sb.WriteString(formatReviewCommits(next.Commits))
+	return sb.String()
+}
+
+// promptReviewAction asks what to do with the task and does it. Invalid
+// input re-prompts; Enter or end of input leaves the task in the queue.
+func promptReviewAction(repoDb *repository.DB, workflow *config.WorkflowConfig, next *ReviewNext, agent string, in io.Reader, out io.Writer) error {
+	task := next.Task
+	choices := []string{}
+	for _, c := range []struct {
+		key, action, target string
+	}{
+		{"a", "approve", next.Actions.Approve},
+		{"r: reason", "reject", next.Actions.Reject},
+		{"o: reason", "reopen", next.Actions.Reopen},
+	} {
+		if c.target != "" {
+			choices = append(choices, fmt.Sprintf("%s = %s (→ %s)", c.key, c.action, c.target))
+		}
+	}
+	if len(choices) == 0 {
+		fmt.Fprintf(out, "\nThe workflow allows no moves from %s.\n", task.Status)
+		return nil
+	}
+	fmt.Fprintf(out, "\n%s. Press Enter to leave %s in the queue.\n", strings.Join(choices, ", "), task.Key)
+
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprint(out, "> ")
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				fmt.Fprintln(out)
+				return nil
+			}
+			return err
+		}
+
+		action, text, err := parseReviewInput(line)
+		if err != nil {
+			fmt.Fprintf(out, "  %v\n", err)
+			continue
+		}
+		if action == "" {
+			cli.Info(fmt.Sprintf("Left %s in the review queue", task.Key))
+			return nil
+		}
+
+		target := map[reviewAction]string{reviewApprove: next.Actions.Approve, reviewReject: next.Actions.Reject, reviewReopen: next.Actions.Reopen}[action]
+		if target == "" {
+			fmt.Fprintf(out, "  the workflow doesn't allow a task in %s to be %s\n", task.Status, reviewActionDone[action])
+			continue
+		}
+		return applyReviewAction(repoDb, workflow, task, action, target, text, agent)
+	}
+}
+
+// applyReviewAction moves the task to target, recording the reviewer's note
+// or reason
+func applyReviewAction(repoDb *repository.DB, workflow *config.WorkflowConfig, task *models.Task, action reviewAction, target, text, agent string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	var notes, reason *string
+	if text != "" {
+		if action == reviewApprove {
+			notes = &text
+		} else {
+			reason = &text
+		}
+	}
+
+	repo := repository.NewTaskRepositoryWithWorkflow(repoDb, workflow)
+	err := repo.UpdateStatusIfVersion(ctx, task.ID, task.Version, models.TaskStatus(target), &agent, notes, reason, nil, false)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		exitVersionConflict(task.Key, err)
+	}
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to %s %s: %v", action, task.Key, err))
+	}
+
+	cli.Success(fmt.Sprintf("Task %s %s (%s → %s)", task.Key, reviewActionDone[action], task.Status, target))
+	triggerStatusCascade(ctx, repoDb, task.FeatureID)
+	return nil
+}
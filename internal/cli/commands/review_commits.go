@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReviewCommit is a git commit whose message mentions the task under review
+type ReviewCommit struct {
+	Hash      string    `json:"hash"`
+	Author    string    `json:"author"`
+	Date      time.Time `json:"date"`
+	Subject   string    `json:"subject"`
+	Diff      string    `json:"diff,omitempty"`      // Stat and patch, cut off at --max-diff-lines
+	Truncated bool      `json:"truncated,omitempty"` // Diff was cut off
+}
+
+// taskCommitPattern matches a task key in a commit message, with or without
+// the T- prefix, e.g. "T-E04-F02-001" or "E04-F02-001: fix login"
+func taskCommitPattern(taskKey string) string {
+	short := strings.TrimPrefix(taskKey, "T-")
+	return "(^|[^0-9A-Za-z-])(T-)?" + regexp.QuoteMeta(short) + "([^0-9]|$)"
+}
+
+// linkedCommits returns the commits in the project's git repository whose
+// messages mention taskKey, newest first, with their diffs sharing a budget of
+// maxDiffLines lines (0 leaves diffs out). Projects that aren't git
+// repositories, or machines without git, have no linked commits.
+func linkedCommits(ctx context.Context, projectRoot, taskKey string, maxDiffLines int) []*ReviewCommit {
+	out, err := exec.CommandContext(ctx, "git", "-C", projectRoot, "log",
+		"--extended-regexp", "--regexp-ignore-case", "--grep", taskCommitPattern(taskKey),
+		"--format=%H%x1f%an%x1f%aI%x1f%s").Output()
+	if err != nil {
+		return nil
+	}
+
+	commits := []*ReviewCommit{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, &ReviewCommit{Hash: fields[0], Author: fields[1], Date: date, Subject: fields[3]})
+	}
+
+	budget := maxDiffLines
+	for _, commit := range commits {
+		if budget <= 0 {
+			commit.Truncated = maxDiffLines > 0
+			continue
+		}
+		diff, err := exec.CommandContext(ctx, "git", "-C", projectRoot, "show", "--format=", "--stat", "--patch", commit.Hash).Output()
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(strings.TrimRight(string(diff), "\n"), "\n")
+		if len(lines) > budget {
+			lines = lines[:budget]
+			commit.Truncated = true
+		}
+		budget -= len(lines)
+		commit.Diff = strings.Join(lines, "\n")
+	}
+	return commits
+}
+
+// formatReviewCommits renders linked commits as a Markdown section
+func formatReviewCommits(commits []*ReviewCommit) string {
+	if len(commits) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n## Commits\n")
+	for _, commit := range commits {
+		fmt.Fprintf(&sb, "\n### %s %s\n\n", shortHash(commit.Hash), commit.Subject)
+		fmt.Fprintf(&sb, "%s, %s\n", commit.Author, commit.Date.Local().Format("2006-01-02 15:04"))
+		if commit.Diff != "" {
+			fmt.Fprintf(&sb, "\n```diff\n%s\n```\n", commit.Diff)
+		}
+		if commit.Truncated {
+			fmt.Fprintf(&sb, "\n_Diff cut off; see `git show %s`._\n", shortHash(commit.Hash))
+		}
+	}
+	return sb.String()
+}
+
+// shortHash abbreviates a commit hash for display
+func shortHash(hash string) string {
+	if len(hash) > 10 {
+		return hash[:10]
+	}
+	return hash
+}
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReviewInput(t *testing.T) {
+	tests := []struct {
+		line    string
+		action  reviewAction
+		text    string
+		wantErr bool
+	}{
+		{line: "\n"},
+		{line: "a\n", action: reviewApprove},
+		{line: "Approve: looks good\n", action: reviewApprove, text: "looks good"},
+		{line: "r: tests fail\n", action: reviewReject, text: "tests fail"},
+		{line: "o:  needs docs \n", action: reviewReopen, text: "needs docs"},
+		{line: "r\n", wantErr: true},
+		{line: "o:\n", wantErr: true},
+		{line: "x: what\n", wantErr: true},
+		{line: ": a reason\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		action, text, err := parseReviewInput(tt.line)
+		if tt.wantErr {
+			assert.Error(t, err, tt.line)
+			continue
+		}
+		require.NoError(t, err, tt.line)
+		assert.Equal(t, tt.action, action, tt.line)
+		assert.Equal(t, tt.text, text, tt.line)
+	}
+}
+
+func TestReviewTargets(t *testing.T) {
+	workflow := config.DefaultWorkflow()
+	assert.Equal(t, []string{"ready_for_review"}, reviewStatuses(workflow, nil))
+	assert.Equal(t, []string{"ready_for_qa"}, reviewStatuses(workflow, []string{"ready_for_qa"}))
+
+	targets := reviewTargets(workflow, "ready_for_review")
+	assert.Equal(t, "completed", targets.Approve)
+	assert.Equal(t, "in_progress", targets.Reject)
+	assert.Equal(t, "in_progress", targets.Reopen)
+
+	custom := &config.WorkflowConfig{
+		StatusFlow: map[string][]string{
+			"todo":        {"in_progress"},
+			"in_progress": {"code_review"},
+			"code_review": {"blocked", "in_progress", "qa"},
+			"qa":          {"done", "code_review"},
+			"done":        {},
+			"blocked":     {"in_progress"},
+		},
+		StatusMetadata: map[string]config.StatusMetadata{
+			"todo":        {Phase: "planning"},
+			"in_progress": {Phase: "development"},
+			"code_review": {Phase: "review", Responsibility: "human"},
+			"qa":          {Phase: "qa"},
+			"done":        {Phase: "done"},
+			"blocked":     {Phase: "any"},
+		},
+	}
+	targets = reviewTargets(custom, "code_review")
+	assert.Equal(t, "qa", targets.Approve, "no done status is reachable, so the first forward move")
+	assert.Equal(t, "in_progress", targets.Reject)
+	assert.Equal(t, "in_progress", targets.Reopen)
+
+	targets = reviewTargets(custom, "qa")
+	assert.Equal(t, "done", targets.Approve)
+	assert.Equal(t, "code_review", targets.Reject)
+	assert.Equal(t, "code_review", targets.Reopen, "falls back to the rejection status")
+}
+
+func TestTaskCommitPattern(t *testing.T) {
+	pattern := regexp.MustCompile("(?i)" + taskCommitPattern("T-E04-F02-001"))
+
+	assert.True(t, pattern.MatchString("T-E04-F02-001: add login form"))
+	assert.True(t, pattern.MatchString("Fix session expiry (e04-f02-001)"))
+	assert.True(t, pattern.MatchString("[E04-F02-001] tests"))
+	assert.False(t, pattern.MatchString("T-E04-F02-0012: other task"))
+	assert.False(t, pattern.MatchString("T-E04-F02-002: other task"))
+	assert.False(t, pattern.MatchString("XE04-F02-001"))
+}
+
+func TestReviewQueue(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	ctx := context.Background()
+	epic := &models.Epic{Key: "E07", Title: "Accounts", Status: models.EpicStatusActive, Priority: models.PriorityHigh}
+	require.NoError(t, repository.NewEpicRepository(database).Create(ctx, epic))
+	featureRepo := repository.NewFeatureRepository(database)
+	login := &models.Feature{EpicID: epic.ID, Key: "E07-F01", Title: "Login", Status: models.FeatureStatusActive}
+	require.NoError(t, featureRepo.Create(ctx, login))
+	signup := &models.Feature{EpicID: epic.ID, Key: "E07-F02", Title: "Signup", Status: models.FeatureStatusActive}
+	require.NoError(t, featureRepo.Create(ctx, signup))
+
+	taskRepo := repository.NewTaskRepository(database)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	newTask := func(feature *models.Feature, key string, status models.TaskStatus, enteredAgo time.Duration) *models.Task {
+		task := &models.Task{FeatureID: feature.ID, Key: key, Title: key, Status: status, Priority: 5}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		_, err := database.ExecContext(ctx,
+			"INSERT INTO task_history (task_id, old_status, new_status, timestamp) VALUES (?, 'in_progress', ?, ?)",
+			task.ID, string(status), now.Add(-enteredAgo).Format("2006-01-02 15:04:05"))
+		require.NoError(t, err)
+		return task
+	}
+	newTask(login, "T-E07-F01-001", models.TaskStatusReadyForReview, 2*time.Hour)
+	newTask(login, "T-E07-F01-002", models.TaskStatusReadyForReview, 30*time.Hour)
+	newTask(login, "T-E07-F01-003", models.TaskStatusInProgress, 90*time.Hour)
+	oldest := newTask(signup, "T-E07-F02-001", models.TaskStatusReadyForReview, 50*time.Hour)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("epic", "", "")
+	cmd.Flags().String("feature", "", "")
+	cmd.Flags().StringSlice("status", nil, "")
+
+	workflow := config.DefaultWorkflow()
+	queue, err := loadReviewQueue(ctx, cmd, database, workflow, now)
+	require.NoError(t, err)
+	require.Len(t, queue, 2)
+	assert.Equal(t, "E07-F02", queue[0].FeatureKey, "feature with the oldest task first")
+	assert.Equal(t, "E07", queue[0].EpicKey)
+	require.Len(t, queue[1].Tasks, 2)
+	assert.Equal(t, "T-E07-F01-002", queue[1].Tasks[0].Key, "oldest first within a feature")
+	assert.Equal(t, 30.0, queue[1].Tasks[0].WaitingHours)
+	assert.Equal(t, "T-E07-F02-001", oldestReviewTask(queue).Key)
+
+	require.NoError(t, cmd.Flags().Set("feature", "e07-f01"))
+	filtered, err := loadReviewQueue(ctx, cmd, database, workflow, now)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "E07-F01", filtered[0].FeatureKey)
+
+	next, err := buildReviewNext(ctx, database, workflow, "", oldestReviewTask(queue), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", next.Actions.Approve)
+	assert.Empty(t, next.Commits)
+
+	var out bytes.Buffer
+	input := strings.NewReader("x\nr\nr: missing validation\n")
+	require.NoError(t, promptReviewAction(database, workflow, next, "reviewer", input, &out))
+	assert.Contains(t, out.String(), "a = approve (→ completed)")
+	assert.Contains(t, out.String(), "unrecognized input")
+	assert.Contains(t, out.String(), "reject needs a reason")
+
+	rejected, err := taskRepo.GetByID(ctx, oldest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusInProgress, rejected.Status)
+
+	history, err := repository.NewTaskHistoryRepository(database).ListByTask(ctx, oldest.ID)
+	require.NoError(t, err)
+	var reasons []string
+	for _, h := range history {
+		if h.RejectionReason != nil {
+			reasons = append(reasons, *h.RejectionReason)
+		}
+	}
+	assert.Equal(t, []string{"missing validation"}, reasons)
+}
+
+func TestPromptReviewAction_LeaveInQueue(t *testing.T) {
+	task := &models.Task{Key: "T-E07-F01-001", Status: models.TaskStatusReadyForReview}
+	next := &ReviewNext{TaskBrief: &TaskBrief{Task: task}, Actions: ReviewTargets{Approve: "completed"}}
+
+	var out bytes.Buffer
+	require.NoError(t, promptReviewAction(nil, config.DefaultWorkflow(), next, "reviewer", strings.NewReader("o: more\n\n"), &out))
+	assert.Contains(t, out.String(), "doesn't allow a task in ready_for_review to be reopened")
+	assert.NotContains(t, out.String(), "r: reason")
+}
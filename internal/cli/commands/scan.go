@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/scan"
+	"github.com/spf13/cobra"
+)
+
+// ScanJSON is the JSON output of shark scan
+type ScanJSON struct {
+	Files     []*scan.File `json:"files"`
+	Adoptable int          `json:"adoptable"` // Files that can be adopted as suggested
+	Adopted   int          `json:"adopted"`   // Files adopted with --adopt
+}
+
+// scanCmd finds untracked markdown files under the plan directories
+var scanCmd = &cobra.Command{
+	Use:         "scan",
+	Short:       "Find plan files that no epic, feature, or task tracks",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true", cli.WritingFlagsAnnotation: "adopt"},
+	GroupID:     "setup",
+	Long: `Find markdown files under docs/plan that no epic, feature, task, document,
+or idea records, and suggest what each one is from its path and name:
+
+  epic       epic.md in an epic folder (E05-payments/epic.md)
+  feature    feature.md in a feature folder (E05-F02-refunds/feature.md)
+  task       a file named after a task key (T-E05-F02-003.md), or any file in
+             a feature's tasks folder, numbered after the feature's last task
+  document   any other file in an epic or feature folder, linked to it
+
+With --adopt, records are created for every file that can be adopted, pointing
+at the existing files: epics and features as drafts, tasks in the workflow's
+first status. Titles come from the frontmatter, the first heading, or the
+name. Files are never changed.
+
+A file can't be adopted when its key is already taken, or when its epic or
+feature neither exists nor is adopted with it.
+
+Examples:
+  shark scan
+  shark scan --adopt
+  shark scan --dir=docs/plan --dir=docs/backlog --json`,
+	Args: cobra.NoArgs,
+	RunE: runScan,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().StringSlice("dir", []string{scan.DefaultDir}, "Directory to scan, relative to the project root (repeatable)")
+	scanCmd.Flags().Bool("adopt", false, "Create records for the files that can be adopted")
+}
+
+// runScan handles the scan command
+func runScan(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(60 * time.Second)
+	defer cancel()
+
+	dirs, _ := cmd.Flags().GetStringSlice("dir")
+	adopt, _ := cmd.Flags().GetBool("adopt")
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	scanner := scan.NewScanner(repoDb, projectRoot)
+	files, err := scanner.Scan(ctx, dirs)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	result := &ScanJSON{Files: files}
+	for _, file := range files {
+		if file.Adoptable() {
+			result.Adoptable++
+		}
+	}
+
+	if adopt && result.Adoptable > 0 {
+		result.Adopted = scanner.Adopt(ctx, files)
+		for _, file := range files {
+			if !file.Adopted || file.Kind == scan.KindDocument {
+				continue
+			}
+			recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: file.Kind, EntityKey: file.Key, Action: models.AuditActionCreate,
+				Summary: fmt.Sprintf("Adopted %s", file.Path)})
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+
+	if len(files) == 0 {
+		cli.Success("Every markdown file is tracked")
+		return nil
+	}
+
+	headers := []string{"File", "Suggested", "Title", "Note"}
+	rows := make([][]string, len(files))
+	for i, file := range files {
+		note := file.Reason
+		switch {
+		case file.Adopted:
+			note = "adopted"
+		case file.Error != "":
+			note = "failed: " + file.Error
+		case file.Problem != "":
+			note = file.Problem
+		}
+		rows[i] = []string{file.Path, describeScanSuggestion(file), file.Title, note}
+	}
+	cli.OutputTable(headers, rows)
+
+	switch {
+	case adopt:
+		cli.Success(fmt.Sprintf("Adopted %d of %d untracked files", result.Adopted, len(files)))
+		if result.Adopted < result.Adoptable {
+			cli.Warning(fmt.Sprintf("%d files failed to be adopted", result.Adoptable-result.Adopted))
+		}
+	case result.Adoptable > 0:
+		cli.Info("%d of %d untracked files can be adopted; run 'shark scan --adopt' to create their records", result.Adoptable, len(files))
+	default:
+		cli.Info("%d untracked files; none can be adopted as suggested", len(files))
+	}
+	return nil
+}
+
+// describeScanSuggestion describes the record suggested for a file
+func describeScanSuggestion(file *scan.File) string {
+	switch {
+	case file.Kind == scan.KindDocument && file.Parent != "":
+		return "document of " + file.Parent
+	case file.Kind == scan.KindTask && file.Key == "":
+		return "new task in " + file.Parent
+	case file.Key != "":
+		return file.Kind + " " + file.Key
+	}
+	return file.Kind
+}
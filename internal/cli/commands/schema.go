@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/jsonschema"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/spf13/cobra"
+)
+
+// schemaBaseURL prefixes the $id of every output schema
+const schemaBaseURL = "https://github.com/jwwelbor/shark-task-manager/schemas/"
+
+// outputSchema describes the --json output of one command. Bump Version when a
+// change can break consumers: a field removed, renamed, or given a new type.
+// Adding a field is not a breaking change.
+type outputSchema struct {
+	Command     string
+	Version     int
+	Description string
+	Value       interface{} // Zero value of the type the command marshals
+}
+
+// outputSchemas lists the commands whose JSON output has a published schema
+var outputSchemas = []outputSchema{
+	{"task list", 1, "Tasks matching the filters", []*models.Task{}},
+	{"task get", 1, "A task with its dependencies, documents, review history, and attachments", TaskGetJSON{}},
+	{"task next", 1, "The next available task (also with --claim and --lease)", NextTaskJSON{}},
+	{"task next --count", 1, "Several available tasks, with --count or when tasks can run in parallel", NextTaskListJSON{}},
+	{"epic list", 1, "Epics with their progress", EpicListJSON{}},
+	{"epic get", 1, "An epic with its features, documents, and status rollups", EpicGetJSON{}},
+	{"epic clone", 1, "The features and tasks copied into a new epic", EpicCloneJSON{}},
+	{"epic ready", 1, "Tasks that can start now, blocked tasks, and estimated parallelism", EpicReadyJSON{}},
+	{"feature list", 1, "Features with their health and progress", FeatureListJSON{}},
+	{"feature get", 1, "A feature with its tasks, progress, and action items", FeatureGetJSON{}},
+	{"status", 1, "The project dashboard", status.StatusDashboard{}},
+	{"forecast", 1, "An epic's forecast completion dates and critical path", EpicForecastJSON{}},
+}
+
+// schemaCmd prints JSON Schemas for command output
+var schemaCmd = &cobra.Command{
+	Use:         "schema [command]",
+	Short:       "Show JSON Schemas for --json output",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "details",
+	Long: `Print the JSON Schema (draft 2020-12) of a command's --json output.
+
+Schemas are generated from the types the commands marshal, so they always match
+the installed version of shark. Each schema carries a version in its $id and in
+x-schema-version; the version changes only when a field is removed, renamed, or
+changes type. New fields may appear without a version change.
+
+Without a command, lists the commands that have a schema.
+
+Examples:
+  shark schema
+  shark schema task get
+  shark schema "task next --count"`,
+	RunE: runSchema,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(schemaCmd)
+}
+
+// runSchema executes the schema command
+func runSchema(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return listOutputSchemas()
+	}
+
+	name := strings.Join(args, " ")
+	entry := findOutputSchema(name)
+	if entry == nil {
+		return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("no schema for %q", name)).WithHint("Use 'shark schema' to list commands with a schema")
+	}
+	return cli.OutputJSON(entry.document())
+}
+
+// listOutputSchemas prints the commands that have a schema
+func listOutputSchemas() error {
+	if cli.GlobalConfig.JSON {
+		list := make([]map[string]interface{}, 0, len(outputSchemas))
+		for _, entry := range outputSchemas {
+			list = append(list, map[string]interface{}{
+				"command":     entry.Command,
+				"version":     entry.Version,
+				"id":          entry.id(),
+				"description": entry.Description,
+			})
+		}
+		return cli.OutputJSON(list)
+	}
+
+	rows := make([][]string, 0, len(outputSchemas))
+	for _, entry := range outputSchemas {
+		rows = append(rows, []string{entry.Command, strconv.Itoa(entry.Version), entry.Description})
+	}
+	cli.OutputTable([]string{"Command", "Version", "Output"}, rows)
+	return nil
+}
+
+// findOutputSchema looks up a command's schema; the leading "shark" and the
+// --json flag are optional
+func findOutputSchema(name string) *outputSchema {
+	fields := strings.Fields(name)
+	if len(fields) > 0 && fields[0] == "shark" {
+		fields = fields[1:]
+	}
+	kept := fields[:0]
+	for _, field := range fields {
+		if field != "--json" {
+			kept = append(kept, field)
+		}
+	}
+	name = strings.Join(kept, " ")
+
+	for i := range outputSchemas {
+		if outputSchemas[i].Command == name {
+			return &outputSchemas[i]
+		}
+	}
+	return nil
+}
+
+// id returns the schema's $id, which includes its version
+func (s *outputSchema) id() string {
+	return fmt.Sprintf("%s%s/v%d.json", schemaBaseURL, strings.NewReplacer(" --", "-", " ", "-").Replace(s.Command), s.Version)
+}
+
+// document generates the full schema document
+func (s *outputSchema) document() *jsonschema.Schema {
+	schema := jsonschema.For(s.Value)
+	schema.Schema = jsonschema.Draft
+	schema.ID = s.id()
+	schema.Title = "shark " + s.Command + " --json"
+	schema.Description = s.Description
+	schema.Version = s.Version
+	return schema
+}
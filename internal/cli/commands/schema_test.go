@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishedSchemaFields pins the top-level fields of each published schema
+// version. A test failure here means a field was removed or renamed: bump the
+// schema's version in outputSchemas and record its new fields. New fields can
+// be added to the current version's list.
+var publishedSchemaFields = map[string][]string{
+	"task get v1":          {"attachments", "blocked_by", "blocks", "checklist", "dependency_status", "filename", "path", "readiness", "rejection_history", "related_documents", "task"},
+	"task next v1":         {"agent_type", "assigned_agent", "claimed", "dependencies", "dependency_status", "execution_order", "file_path", "key", "labels", "lease", "priority", "status", "title"},
+	"task next --count v1": {"count", "message", "tasks"},
+	"epic list v1":         {"count", "results"},
+	"epic get v1":          {"approval_backlog_count", "business_value", "created_at", "description", "feature_status_rollup", "features", "file_path", "filename", "id", "impediments", "key", "notes", "path", "priority", "progress_pct", "related_documents", "slug", "status", "status_source", "task_status_rollup", "title", "updated_at"},
+	"epic clone v1":        {"dry_run", "epic", "features", "source", "title"},
+	"epic ready v1":        {"blocked", "epic", "features", "parallelism", "ready", "title"},
+	"feature list v1":      {"count", "results"},
+	"feature get v1":       {"action_items", "created_at", "description", "epic_id", "epic_key", "execution_order", "file_path", "filename", "id", "key", "labels", "path", "progress", "progress_pct", "related_documents", "slug", "status", "status_breakdown", "status_override", "status_source", "tasks", "title", "updated_at", "work_summary"},
+	"status v1":            {"active_tasks", "blocked_tasks", "epics", "filter", "leased_tasks", "quota_warnings", "recent_completions", "summary"},
+	"forecast v1":          {"agents", "critical_path", "cycle_time", "earliest", "epic", "expected", "expected_hours", "in_progress", "latest", "limited_by", "open_tasks", "since", "title"},
+}
+
+func TestOutputSchemas_Published(t *testing.T) {
+	seen := map[string]bool{}
+	for _, entry := range outputSchemas {
+		require.False(t, seen[entry.Command], "duplicate schema for %s", entry.Command)
+		seen[entry.Command] = true
+
+		doc := entry.document()
+		assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", doc.Schema)
+		assert.Equal(t, entry.Version, doc.Version)
+
+		if entry.Command == "task list" {
+			assert.Equal(t, []string{"array", "null"}, doc.Type)
+			assert.Contains(t, doc.Items.Properties, "key")
+			continue
+		}
+
+		published := publishedSchemaFields[entry.Command+" v"+strconv.Itoa(entry.Version)]
+		require.NotNil(t, published, "record the fields of %s v%d in publishedSchemaFields", entry.Command, entry.Version)
+		fields := make([]string, 0, len(doc.Properties))
+		for field := range doc.Properties {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		assert.Subset(t, fields, published, "%s dropped a field: bump its schema version", entry.Command)
+	}
+}
+
+func TestOutputSchemas_MatchOutput(t *testing.T) {
+	// Every field the command actually emits is described by the schema
+	filePath := "docs/task.md"
+	output := nextTaskJSON(&models.Task{Key: "T-E01-F01-001", Title: "Task", FilePath: &filePath}, nil)
+	output.Claimed = true
+	output.Status = models.TaskStatusInProgress
+
+	data, err := json.Marshal(output)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	schema := findOutputSchema("task next").document()
+	for field := range fields {
+		assert.Contains(t, schema.Properties, field)
+	}
+	assert.Contains(t, schema.Required, "key")
+	assert.NotContains(t, schema.Required, "claimed")
+}
+
+func TestFindOutputSchema(t *testing.T) {
+	assert.Equal(t, "task get", findOutputSchema("task get").Command)
+	assert.Equal(t, "task get", findOutputSchema("shark task get --json").Command)
+	assert.Equal(t, "task next --count", findOutputSchema("task next --count").Command)
+	assert.Nil(t, findOutputSchema("task frobnicate"))
+
+	assert.Equal(t, "https://github.com/jwwelbor/shark-task-manager/schemas/task-next-count/v1.json", findOutputSchema("task next --count").id())
+}
@@ -1,9 +1,7 @@
 package commands
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -13,9 +11,10 @@ import (
 
 // searchCmd is the parent command for search operations
 var searchCmd = &cobra.Command{
-	Use:     "search",
-	Short:   "Search tasks by various criteria",
-	GroupID: "details",
+	Use:         "search",
+	Short:       "Search tasks by various criteria",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "details",
 	Long: `Search for tasks using completion metadata like files changed.
 
 Supports partial filename matching. Results are ordered by completion date (most recent first).
@@ -31,7 +30,7 @@ Examples:
 // runSearchFile handles the file search command
 func runSearchFile(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Get file parameter
@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/reload"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+// serveCmd runs an API server for programmatic integrations
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	Short:   "Run an API server for programmatic integrations",
+	GroupID: "setup",
+	Long: `Run a server that exposes the project's epics, features, tasks, and ideas
+to orchestrators that prefer typed RPC over running shark commands, and to
+people who'd rather follow progress in a browser.
+
+--grpc serves the EpicService, FeatureService, TaskService, and IdeaService
+defined in proto/shark/v1/shark.proto. Changes made over gRPC go through the
+same repositories as the CLI, so task history, the audit log, and status
+cascades behave the same. TaskService.WatchTaskStatus streams status changes
+made by any client or CLI using the database.
+
+Clients authenticate with "authorization: Bearer <token>" metadata, using the
+token from the "server" section of .sharkconfig.json:
+
+  "server": {
+    "grpc_addr": ":50051",
+    "http_addr": ":8080",
+    "auth_token_file": "~/.config/shark/server-token"
+  }
+
+auth_token can hold the token directly, but a file outside the project keeps
+it out of version control. Clients can also send an API key from
+'shark apikey create' in place of the token; keys with read scope can't call
+RPCs that change data. The server refuses to start without a token or an API
+key unless --no-auth is given.
+
+--http serves a web UI at /ui/ that lists epics, features, and tasks, shows a
+status dashboard, and moves tasks to the statuses the workflow allows. It uses
+a JSON API under /api/ that scripts can call too; each endpoint behaves like
+the matching RPC and takes the same "Authorization: Bearer <token>" header.
+The UI asks for the token or API key once and keeps it in the browser.
+--grpc and --http can be combined.
+
+Epic and feature lookups and progress calculations are cached for --cache-ttl.
+Changes made through the server invalidate the cache immediately; changes made
+by CLI commands or other processes show up once the TTL expires. Use
+--cache-ttl=0 to turn caching off.
+
+The server watches .sharkconfig.json and .shark.yaml and reloads the workflow,
+health rules, and hooks (commands and webhooks) when either changes, without a
+restart. Each changed setting is logged with its old and new value; a file that
+fails to load is logged and its settings are kept as they were. The auth token,
+addresses, and cache TTL are read only at startup.
+
+Each call is logged with its method, status code, and duration. Logging is off
+unless --log-level or --verbose is given; add --log-format=json for one JSON
+object per line and --log-file to append to a file instead of stderr.
+
+Examples:
+  shark serve --grpc
+  shark serve --grpc --addr=127.0.0.1:6000
+  shark serve --grpc --no-auth --addr=127.0.0.1:50051
+  shark serve --grpc --log-level=info --log-format=json --log-file=shark-server.log
+  shark serve --http --http-addr=127.0.0.1:8080
+  shark serve --grpc --http`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().Bool("grpc", false, "Serve the gRPC API")
+	serveCmd.Flags().Bool("http", false, "Serve the web UI and JSON API")
+	serveCmd.Flags().String("addr", "", "gRPC listen address (default: server.grpc_addr from config, or :50051)")
+	serveCmd.Flags().String("http-addr", "", "HTTP listen address (default: server.http_addr from config, or :8080)")
+	serveCmd.Flags().Bool("no-auth", false, "Accept calls without an auth token")
+	serveCmd.Flags().Duration("cache-ttl", repository.DefaultCacheTTL, "How long to cache epic, feature, and progress reads (0 disables)")
+}
+
+// runServe handles the serve command
+func runServe(cmd *cobra.Command, args []string) error {
+	useGRPC, _ := cmd.Flags().GetBool("grpc")
+	useHTTP, _ := cmd.Flags().GetBool("http")
+	addr, _ := cmd.Flags().GetString("addr")
+	httpAddr, _ := cmd.Flags().GetString("http-addr")
+	noAuth, _ := cmd.Flags().GetBool("no-auth")
+	cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
+
+	if !useGRPC && !useHTTP {
+		return fmt.Errorf("choose what to serve: --grpc, --http, or both")
+	}
+
+	var cfg *config.Config
+	configPath, err := cli.GetConfigPath()
+	if err == nil {
+		if loaded, err := config.NewManager(configPath).Load(); err == nil {
+			cfg = loaded
+		}
+	}
+
+	token, err := cfg.GetServerAuthToken()
+	if err != nil {
+		return err
+	}
+	if addr == "" {
+		addr = cfg.GetGRPCAddr()
+	}
+	if httpAddr == "" {
+		httpAddr = cfg.GetHTTPAddr()
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	activeKeys := 0
+	if noAuth {
+		token = ""
+	} else {
+		activeKeys, err = repository.NewAPIKeyRepository(repoDb).CountActive(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if token == "" && activeKeys == 0 {
+			return fmt.Errorf("no server auth token or API key configured: set server.auth_token_file (or server.auth_token) in .sharkconfig.json, create a key with 'shark apikey create', or pass --no-auth")
+		}
+	}
+	if cacheTTL > 0 {
+		repoDb.EnableCache(cacheTTL)
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	if configPath == "" {
+		configPath = filepath.Join(projectRoot, ".sharkconfig.json")
+	}
+	settings, err := reload.New(cmd.Context(), repoDb, configPath, projectRoot, slog.Default().With("component", "config"))
+	if err != nil {
+		cli.Warning(fmt.Sprintf("Using default settings where the config failed to load: %v", err))
+	}
+	rpcServer := rpc.NewServer(repoDb, settings.Current().Workflow, projectRoot)
+	rpcServer.UseSettings(settings.Current())
+	settings.OnReload(rpcServer.UseSettings)
+	if !noAuth {
+		rpcServer.EnableAPIKeys()
+	}
+
+	// Listen on every address before serving, so a taken port fails cleanly
+	var grpcListener, httpListener net.Listener
+	if useGRPC {
+		if grpcListener, err = net.Listen("tcp", addr); err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+	}
+	if useHTTP {
+		if httpListener, err = net.Listen("tcp", httpAddr); err != nil {
+			if grpcListener != nil {
+				_ = grpcListener.Close()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", httpAddr, err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Pick up changes to .sharkconfig.json and .shark.yaml without a restart
+	go func() {
+		if err := settings.Watch(ctx, reload.DefaultDebounce); err != nil {
+			slog.Warn("Not reloading config on change", "component", "config", "error", err)
+		}
+	}()
+
+	if noAuth {
+		cli.Warning("Serving without authentication: any client that can reach the address can change tasks")
+	}
+
+	errs := make(chan error, 2)
+	if grpcListener != nil {
+		server := rpc.NewGRPCServer(rpcServer, token)
+		go func() {
+			<-ctx.Done()
+			server.GracefulStop()
+		}()
+		go func() {
+			if err := server.Serve(grpcListener); err != nil {
+				errs <- fmt.Errorf("gRPC server failed: %w", err)
+				return
+			}
+			errs <- nil
+		}()
+		cli.Info("Serving gRPC on %s (Ctrl+C to stop)", grpcListener.Addr())
+		slog.Info("Serving gRPC", "component", "rpc", "addr", grpcListener.Addr().String(), "auth", !noAuth, "api_keys", activeKeys)
+	}
+	if httpListener != nil {
+		server := &http.Server{Handler: rpc.NewHTTPHandler(rpcServer, token), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+		go func() {
+			if err := server.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("HTTP server failed: %w", err)
+				return
+			}
+			errs <- nil
+		}()
+		cli.Info("Serving the web UI at http://%s/ui/ (Ctrl+C to stop)", httpListener.Addr())
+		slog.Info("Serving HTTP", "component", "rpc", "addr", httpListener.Addr().String(), "auth", !noAuth, "api_keys", activeKeys)
+	}
+
+	// Stop everything when either server stops, and wait for both
+	servers := 0
+	if grpcListener != nil {
+		servers++
+	}
+	if httpListener != nil {
+		servers++
+	}
+	var serveErr error
+	for i := 0; i < servers; i++ {
+		if err := <-errs; err != nil && serveErr == nil {
+			serveErr = err
+		}
+		stop()
+	}
+	return serveErr
+}
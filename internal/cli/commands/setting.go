@@ -0,0 +1,302 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// Where the value of a listed setting comes from
+const (
+	settingSourceDatabase = "database"
+	settingSourceDefault  = "default"
+)
+
+// settingCmd is the parent command for project settings
+var settingCmd = &cobra.Command{
+	Use:     "setting",
+	Short:   "Manage project settings stored in the database",
+	GroupID: "setup",
+	Long: `Project settings are stored in the database, so they travel with it rather
+than with a local config file, and the whole team shares them.
+
+Precedence, highest first:
+  1. Command-line flags (e.g. task create --priority)
+  2. Settings stored with 'shark setting set'
+  3. .shark.yaml (health rules)
+  4. Built-in defaults
+
+Run 'shark setting list' to see every setting with its type and description.
+
+Examples:
+  shark setting list
+  shark setting set task.default_priority 3
+  shark setting set health.blocked.critical 5
+  shark setting get task.default_priority
+  shark setting unset task.default_priority`,
+}
+
+// settingGetCmd shows one setting
+var settingGetCmd = &cobra.Command{
+	Use:         "get <key>",
+	Short:       "Show a setting's value",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Show the value of a setting and whether it is stored in the database or is
+the default.
+
+Examples:
+  shark setting get task.default_priority
+  shark setting get health.progress.warning --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSettingGet,
+}
+
+// settingSetCmd stores a setting
+var settingSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Store a setting in the database",
+	Long: `Store a setting in the database, replacing its previous value. The value is
+checked against the setting's type and range.
+
+Examples:
+  shark setting set task.default_priority 3
+  shark setting set epic.default_priority high
+  shark setting set health.stale.warning 14`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSettingSet,
+}
+
+// settingUnsetCmd removes a setting
+var settingUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting so its default applies",
+	Long: `Remove a setting from the database, so .shark.yaml or the built-in default
+applies again.
+
+Examples:
+  shark setting unset task.default_priority`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSettingUnset,
+}
+
+// settingListCmd lists settings
+var settingListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List every setting with its value",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List every known setting with its value, where the value comes from, and
+what it does. Settings without a value and without a default fall back to
+.shark.yaml or the built-in rules.
+
+Examples:
+  shark setting list
+  shark setting list --json`,
+	Args: cobra.NoArgs,
+	RunE: runSettingList,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(settingCmd)
+	settingCmd.AddCommand(settingGetCmd)
+	settingCmd.AddCommand(settingSetCmd)
+	settingCmd.AddCommand(settingUnsetCmd)
+	settingCmd.AddCommand(settingListCmd)
+}
+
+// SettingJSON is a setting with its definition, as output by shark setting
+type SettingJSON struct {
+	models.SettingDefinition
+	Value     string     `json:"value"`  // Stored value, or the default
+	Source    string     `json:"source"` // "database" or "default"
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// newSettingJSON combines a setting's definition with its stored value, if any
+func newSettingJSON(def models.SettingDefinition, stored *models.Setting) *SettingJSON {
+	out := &SettingJSON{SettingDefinition: def, Value: def.Default, Source: settingSourceDefault}
+	if stored != nil {
+		out.Value = stored.Value
+		out.Source = settingSourceDatabase
+		out.UpdatedAt = &stored.UpdatedAt
+	}
+	return out
+}
+
+// settingError converts setting validation errors to CLI errors
+func settingError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrUnknownSetting):
+		return cli.NewError(cli.ErrCodeNotFound, err.Error()).WithHint("Use 'shark setting list' to see available settings")
+	case errors.Is(err, models.ErrInvalidSettingValue):
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+	return err
+}
+
+// runSettingGet handles the setting get command
+func runSettingGet(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	def, err := models.LookupSetting(args[0])
+	if err != nil {
+		return settingError(err)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	stored, err := repository.NewSettingRepository(repoDb).Get(ctx, def.Key)
+	if err != nil {
+		return err
+	}
+	out := newSettingJSON(def, stored)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(out)
+	}
+	if out.Value == "" {
+		fmt.Printf("%s is not set\n", def.Key)
+		return nil
+	}
+	fmt.Printf("%s = %s (%s)\n", def.Key, out.Value, out.Source)
+	return nil
+}
+
+// runSettingSet handles the setting set command
+func runSettingSet(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	def, err := models.LookupSetting(args[0])
+	if err != nil {
+		return settingError(err)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	stored, err := repository.NewSettingRepository(repoDb).Set(ctx, def.Key, args[1])
+	if err != nil {
+		return settingError(err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(newSettingJSON(def, stored))
+	}
+	cli.Success(fmt.Sprintf("%s set to %s", def.Key, stored.Value))
+	return nil
+}
+
+// runSettingUnset handles the setting unset command
+func runSettingUnset(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	def, err := models.LookupSetting(args[0])
+	if err != nil {
+		return settingError(err)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	removed, err := repository.NewSettingRepository(repoDb).Unset(ctx, def.Key)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{"key": def.Key, "removed": removed})
+	}
+	if !removed {
+		cli.Info(fmt.Sprintf("%s was not set", def.Key))
+		return nil
+	}
+	cli.Success(fmt.Sprintf("%s unset", def.Key))
+	return nil
+}
+
+// runSettingList handles the setting list command
+func runSettingList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	stored, err := repository.NewSettingRepository(repoDb).List(ctx)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*models.Setting, len(stored))
+	for _, setting := range stored {
+		byKey[setting.Key] = setting
+	}
+
+	defs := models.SettingDefinitions()
+	settings := make([]*SettingJSON, 0, len(defs))
+	for _, def := range defs {
+		settings = append(settings, newSettingJSON(def, byKey[def.Key]))
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(settings)
+	}
+
+	rows := make([][]string, 0, len(settings))
+	for _, setting := range settings {
+		value, source := setting.Value, setting.Source
+		if value == "" {
+			value, source = "-", ""
+		}
+		rows = append(rows, []string{setting.Key, value, source, setting.Description})
+	}
+	cli.OutputTable([]string{"Key", "Value", "Source", "Description"}, rows)
+	return nil
+}
+
+// defaultTaskPriority returns the task.default_priority setting, or fallback
+// when it isn't set or can't be read
+func defaultTaskPriority(ctx context.Context, repoDb *repository.DB, fallback int) int {
+	priority, ok, err := repository.NewSettingRepository(repoDb).GetInt(ctx, models.SettingTaskDefaultPriority)
+	if err != nil {
+		slog.Warn("Failed to read setting", "key", models.SettingTaskDefaultPriority, "error", err)
+	}
+	if !ok {
+		return fallback
+	}
+	return priority
+}
+
+// defaultEpicPriority returns the epic.default_priority setting, or fallback
+// when it isn't set or can't be read
+func defaultEpicPriority(ctx context.Context, repoDb *repository.DB, fallback string) string {
+	priority, ok, err := repository.NewSettingRepository(repoDb).GetString(ctx, models.SettingEpicDefaultPriority)
+	if err != nil {
+		slog.Warn("Failed to read setting", "key", models.SettingEpicDefaultPriority, "error", err)
+	}
+	if !ok {
+		return fallback
+	}
+	return priority
+}
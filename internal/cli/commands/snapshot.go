@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// snapshotCmd records progress snapshots for epics and features
+var snapshotCmd = &cobra.Command{
+	Use:     "snapshot",
+	Short:   "Record epic and feature progress snapshots",
+	GroupID: "status",
+	Long: `Record the current completion percentage of every epic and feature.
+
+Snapshots accumulate into a progress history used by 'shark report burndown'.
+Run it periodically (e.g. from cron or CI), or pass --interval to keep
+recording in the foreground until interrupted.
+
+Examples:
+  shark snapshot                    Snapshot all epics and features
+  shark snapshot --epic=E05         Snapshot one epic and its features
+  shark snapshot --interval=6h      Record every 6 hours until Ctrl+C
+  shark snapshot --json             Output recorded snapshots as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshot,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(snapshotCmd)
+
+	snapshotCmd.Flags().String("epic", "", "Only snapshot this epic and its features")
+	snapshotCmd.Flags().Duration("interval", 0, "Keep running and record snapshots at this interval (e.g. 1h, 24h)")
+}
+
+// runSnapshot handles the snapshot command
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	epicKey, _ := cmd.Flags().GetString("epic")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	// Get database connection
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+
+	snapshots, err := recordProgressSnapshots(ctx, repoDb, epicKey)
+	if err != nil {
+		return err
+	}
+
+	if interval <= 0 {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(snapshots)
+		}
+		printSnapshotSummary(snapshots)
+		return nil
+	}
+
+	if interval < time.Minute {
+		return fmt.Errorf("--interval must be at least 1m")
+	}
+
+	printSnapshotSummary(snapshots)
+	cli.Info(fmt.Sprintf("Recording every %s (Ctrl+C to stop)", interval))
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigCh:
+			return nil
+		case <-ticker.C:
+			snapshots, err := recordProgressSnapshots(ctx, repoDb, epicKey)
+			if err != nil {
+				cli.Warning(fmt.Sprintf("Snapshot failed: %v", err))
+				continue
+			}
+			printSnapshotSummary(snapshots)
+		}
+	}
+}
+
+// recordProgressSnapshots records a snapshot for each epic (or the given epic) and its features
+func recordProgressSnapshots(ctx context.Context, repoDb *repository.DB, epicKey string) ([]*models.ProgressSnapshot, error) {
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	snapshotRepo := repository.NewProgressSnapshotRepository(repoDb)
+
+	var epics []*models.Epic
+	if epicKey != "" {
+		epic, err := epicRepo.GetByKey(ctx, epicKey)
+		if err != nil {
+			return nil, fmt.Errorf("epic %s not found", epicKey)
+		}
+		epics = []*models.Epic{epic}
+	} else {
+		var err error
+		epics, err = epicRepo.List(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list epics: %w", err)
+		}
+	}
+
+	recordedAt := time.Now().UTC()
+	snapshots := []*models.ProgressSnapshot{}
+
+	record := func(entityType string, id int64, key string, progressPct float64) error {
+		total, completed, err := snapshotRepo.GetTaskCounts(ctx, entityType, id)
+		if err != nil {
+			return err
+		}
+		snapshot := &models.ProgressSnapshot{
+			EntityType:     entityType,
+			EntityID:       id,
+			EntityKey:      key,
+			ProgressPct:    progressPct,
+			TotalTasks:     total,
+			CompletedTasks: completed,
+			RecordedAt:     recordedAt,
+		}
+		if err := snapshotRepo.Create(ctx, snapshot); err != nil {
+			return fmt.Errorf("failed to record snapshot for %s: %w", key, err)
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	}
+
+	for _, epic := range epics {
+		features, err := featureRepo.ListByEpic(ctx, epic.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list features for epic %s: %w", epic.Key, err)
+		}
+		for _, feature := range features {
+			featureProgress, err := featureRepo.CalculateProgress(ctx, feature.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate progress for feature %s: %w", feature.Key, err)
+			}
+			if err := record(models.SnapshotEntityFeature, feature.ID, feature.Key, featureProgress); err != nil {
+				return nil, err
+			}
+		}
+
+		epicProgress, err := epicRepo.CalculateProgress(ctx, epic.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate progress for epic %s: %w", epic.Key, err)
+		}
+		if err := record(models.SnapshotEntityEpic, epic.ID, epic.Key, epicProgress); err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshots, nil
+}
+
+// printSnapshotSummary prints a one-line summary of recorded snapshots
+func printSnapshotSummary(snapshots []*models.ProgressSnapshot) {
+	epics, features := 0, 0
+	for _, s := range snapshots {
+		if s.EntityType == models.SnapshotEntityEpic {
+			epics++
+		} else {
+			features++
+		}
+	}
+	cli.Success(fmt.Sprintf("Recorded progress for %d epic(s) and %d feature(s) at %s",
+		epics, features, time.Now().Format("2006-01-02 15:04")))
+}
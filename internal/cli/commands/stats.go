@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// statsCmd summarizes throughput
+var statsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show throughput statistics",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
+	Long: `Summarize how work has been flowing: tasks completed per day and per week,
+average time from review to approval, rejection rate, the busiest agent types,
+and each epic's velocity.
+
+An approval is a move from a review-phase status to a done-phase status in the
+workflow. Time to approval runs from the task's last move into review. The
+rejection rate is rejections as a share of approvals and rejections.
+
+--since takes a duration (12h, 7d, 2w) or a date (YYYY-MM-DD or RFC3339) and
+defaults to 30 days.
+
+Examples:
+  shark stats
+  shark stats --since=2w
+  shark stats --epic=E05 --since=2026-03-01 --json`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().String("since", "30d", "Start of the period: a duration ago (7d) or a date (YYYY-MM-DD)")
+	statsCmd.Flags().String("epic", "", "Only include tasks in this epic")
+}
+
+// runStats executes the stats command
+func runStats(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	sinceStr, _ := cmd.Flags().GetString("since")
+	epicKey, _ := cmd.Flags().GetString("epic")
+
+	now := time.Now()
+	since, err := parseAuditSince(sinceStr, now)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	configPath, _ := cli.GetConfigPath()
+	workflow := config.GetWorkflowOrDefault(configPath)
+	filter := repository.StatsFilter{
+		Since:          since,
+		ReviewStatuses: workflow.GetStatusesByPhase("review"),
+		DoneStatuses:   workflow.GetStatusesByPhase("done"),
+	}
+	if epicKey != "" {
+		epicKey = NormalizeKey(epicKey)
+		if _, err := repository.NewEpicRepository(repoDb).GetByKey(ctx, epicKey); err != nil {
+			return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("epic %s not found", epicKey)).WithHint("Use 'shark epic list' to see available epics")
+		}
+		filter.EpicKey = &epicKey
+	}
+
+	stats, err := repository.NewStatsRepository(repoDb).Throughput(ctx, filter, now)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(stats)
+	}
+
+	displayStats(stats)
+	return nil
+}
+
+// displayStats prints throughput statistics as tables
+func displayStats(stats *repository.ThroughputStats) {
+	fmt.Printf("Throughput since %s\n\n", stats.Since.Local().Format("2006-01-02 15:04"))
+
+	fmt.Printf("Completed: %d task(s)\n", stats.Completed)
+	review := stats.Review
+	fmt.Printf("Reviews:   %d approved, %d rejected (%.0f%% rejection rate)\n", review.Approvals, review.Rejections, review.RejectionRate*100)
+	if review.AvgHoursToApproval != nil {
+		fmt.Printf("Average time to approval: %s\n", formatStatsHours(*review.AvgHoursToApproval))
+	}
+
+	if len(stats.PerWeek) > 0 {
+		fmt.Println("\nCompleted per week:")
+		cli.OutputTable([]string{"Week Of", "Completed"}, completionRows(stats.PerWeek))
+		fmt.Println("\nCompleted per day:")
+		cli.OutputTable([]string{"Day", "Completed"}, completionRows(stats.PerDay))
+	}
+
+	if len(stats.AgentTypes) > 0 {
+		fmt.Println("\nBusiest agent types:")
+		rows := make([][]string, len(stats.AgentTypes))
+		for i, row := range stats.AgentTypes {
+			agentType := row.AgentType
+			if agentType == "" {
+				agentType = "(none)"
+			}
+			rows[i] = []string{agentType, strconv.Itoa(row.Completed), strconv.Itoa(row.StatusChanges)}
+		}
+		cli.OutputTable([]string{"Agent Type", "Completed", "Status Changes"}, rows)
+	}
+
+	if len(stats.Epics) > 0 {
+		fmt.Println("\nEpic velocity:")
+		rows := make([][]string, len(stats.Epics))
+		for i, row := range stats.Epics {
+			rows[i] = []string{row.EpicKey, row.EpicTitle, strconv.Itoa(row.Completed), strconv.FormatFloat(row.PerWeek, 'f', 1, 64), strconv.Itoa(row.Remaining)}
+		}
+		cli.OutputTable([]string{"Epic", "Title", "Completed", "Per Week", "Remaining"}, rows)
+	}
+}
+
+// completionRows formats completion counts for a table
+func completionRows(counts []*repository.CompletionCount) [][]string {
+	rows := make([][]string, len(counts))
+	for i, count := range counts {
+		rows[i] = []string{count.Period, strconv.Itoa(count.Completed)}
+	}
+	return rows
+}
+
+// formatStatsHours formats a number of hours, switching to days past two days
+func formatStatsHours(hours float64) string {
+	if hours >= 48 {
+		return fmt.Sprintf("%.1f days", hours/24)
+	}
+	return fmt.Sprintf("%.1f hours", hours)
+}
@@ -4,18 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
 	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 // statusCmd represents the status command
 var statusCmd = &cobra.Command{
-	Use:     "status [EPIC] [FEATURE]",
-	Short:   "Show project status dashboard",
-	GroupID: "status",
+	Use:         "status [EPIC] [FEATURE]",
+	Short:       "Show project status dashboard",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "status",
 	Long: `Display a comprehensive status dashboard showing project progress, active tasks, and blocked items.
 
 Positional Arguments:
@@ -30,7 +35,20 @@ Examples:
   shark status E05-F02               Show status for feature E05-F02 (combined format)
   shark status --epic=E05            Flag syntax (still supported)
   shark status --recent=7d           Include recent completions (7 days)
-  shark status --json                Output as JSON`,
+  shark status --label=security      Only count tasks labeled 'security'
+  shark status --detail=feature      Add per-feature health, blocked counts, and agents
+  shark status --weighted            Weight progress by task estimates
+  shark status --history --json      Add burnup data per day (recent window, default 7d)
+  shark status --by-agent            Add the tasks held by each agent (see 'shark agent')
+  shark status --all-workspaces      Progress and blocked counts of every registered workspace
+  shark status --json                Output as JSON
+
+Quota warnings are shown when an epic or feature has too many open tasks or
+the database grows too large. Configure the soft limits in .sharkconfig.json:
+  "quotas": {"max_open_tasks_per_epic": 200, "max_open_tasks_per_feature": 50, "max_database_size_mb": 100}
+
+In-progress tasks are shown against the WIP limit of each agent type that has one:
+  "wip_limits": {"backend": 2, "frontend": 3}`,
 	RunE: runStatus,
 }
 
@@ -42,12 +60,23 @@ func init() {
 	statusCmd.Flags().String("epic", "", "Filter by epic key")
 	statusCmd.Flags().String("recent", "", "Recent completion window (24h, 7d, 30d, 90d)")
 	statusCmd.Flags().Bool("include-archived", false, "Include archived epics/features")
+	statusCmd.Flags().String("detail", status.DetailEpic, "Detail level: epic, or feature to add per-feature health under each epic")
+	addLabelFilterFlag(statusCmd)
+	addWeightedFlag(statusCmd)
+	statusCmd.Flags().Bool("history", false, "Add tasks completed per day and cumulative completed vs total over the recent window (default 7d)")
+	statusCmd.Flags().Bool("by-agent", false, "Add in-progress, blocked, and completed tasks per agent, registered or named in assigned_agent")
+	statusCmd.Flags().Bool("all-workspaces", false, "Summarize every registered workspace")
 }
 
 // runStatus executes the status command
 func runStatus(cmd *cobra.Command, args []string) error {
+	if allWorkspaces, _ := cmd.Flags().GetBool("all-workspaces"); allWorkspaces {
+		return runStatusAllWorkspaces(cmd, args)
+	}
+	applyFocus(cmd, args)
+
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := cli.CommandContext(5 * time.Second)
 	defer cancel()
 
 	// Parse positional arguments first
@@ -60,6 +89,13 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	epicKeyFlag, _ := cmd.Flags().GetString("epic")
 	recentWindow, _ := cmd.Flags().GetString("recent")
 	includeArchived, _ := cmd.Flags().GetBool("include-archived")
+	detail, _ := cmd.Flags().GetString("detail")
+	history, _ := cmd.Flags().GetBool("history")
+	byAgent, _ := cmd.Flags().GetBool("by-agent")
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
 
 	// Positional argument takes priority over flag
 	epicKey := epicKeyFlag
@@ -85,6 +121,18 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		EpicKey:         epicKey,
 		RecentWindow:    recentWindow,
 		IncludeArchived: includeArchived,
+		Labels:          labels,
+		Detail:          detail,
+		Weighted:        useWeightedProgress(cmd),
+		History:         history,
+		ByAgent:         byAgent,
+	}
+	quotas := projectQuotaLimits()
+	req.Quotas = &quotas
+	req.DatabaseSizeBytes = localDatabaseSize()
+	req.WIPLimits = projectWIPLimits()
+	if req.Health, err = projectHealthRules(ctx, repoDb); err != nil {
+		return err
 	}
 
 	// Get dashboard
@@ -99,9 +147,46 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Rich terminal output
+	if req.Weighted {
+		noteWeightedProgress(ctx, repoDb)
+	}
 	return outputStatusTerminal(dashboard)
 }
 
+// projectQuotaLimits returns the soft limits from .sharkconfig.json (defaults if unavailable)
+func projectQuotaLimits() config.QuotaLimits {
+	var cfg *config.Config
+	if configPath, err := cli.GetConfigPath(); err == nil {
+		if loaded, err := config.NewManager(configPath).Load(); err == nil {
+			cfg = loaded
+		}
+	}
+	return cfg.GetQuotaLimits()
+}
+
+// projectHealthRules returns the health rules from the project's .shark.yaml
+// and health.* settings, or nil for the defaults
+func projectHealthRules(ctx context.Context, repoDb *repository.DB) (*workspace.HealthRules, error) {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		projectRoot = ""
+	}
+	return status.LoadProjectHealthRules(ctx, projectRoot, repoDb)
+}
+
+// localDatabaseSize returns the size of the local database file, or 0 for cloud databases
+func localDatabaseSize() int64 {
+	dbPath, isLocal, err := cli.GetDatabasePathForBackup()
+	if err != nil || !isLocal {
+		return 0
+	}
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
 // outputStatusJSON outputs the dashboard as JSON
 func outputStatusJSON(dashboard *status.StatusDashboard) error {
 	data, err := json.MarshalIndent(dashboard, "", "  ")
@@ -56,6 +56,20 @@ func ParseFeatureStatus(status string) (string, error) {
 	return normalized, nil
 }
 
+// ParseIdeaStatus parses and validates an idea status value.
+// Input is case-insensitive and normalized to lowercase.
+// Returns the normalized status value or an error if invalid.
+func ParseIdeaStatus(status string) (string, error) {
+	normalized := strings.TrimSpace(strings.ToLower(status))
+	if normalized == "" {
+		return "", fmt.Errorf("idea status cannot be empty")
+	}
+	if err := models.ValidateIdeaStatus(normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
 // ParseTaskStatus parses and validates a task status value.
 // Input is case-insensitive and normalized to lowercase.
 // Supports both old workflow (todo, in_progress, etc.) and new workflow (draft, in_development, etc.).
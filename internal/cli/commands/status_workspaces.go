@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/spf13/cobra"
+)
+
+// AllWorkspacesStatusJSON is the JSON output of status --all-workspaces
+type AllWorkspacesStatusJSON struct {
+	Workspaces map[string]*WorkspaceStatusJSON `json:"workspaces"`
+}
+
+// WorkspaceStatusJSON is the status of one workspace. Error is set instead of
+// the summary when its database couldn't be read.
+type WorkspaceStatusJSON struct {
+	Root    string                 `json:"root"`
+	Summary *status.ProjectSummary `json:"summary,omitempty"`
+	Epics   []*status.EpicSummary  `json:"epics,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// runStatusAllWorkspaces shows a combined summary of every registered workspace
+func runStatusAllWorkspaces(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 || cmd.Flags().Changed("epic") || cmd.Flags().Changed("detail") || cmd.Flags().Changed("recent") {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "--all-workspaces can't be combined with an epic, --detail, or --recent").
+			WithHint("Run 'shark status --workspace=<name>' for the details of one workspace")
+	}
+	includeArchived, _ := cmd.Flags().GetBool("include-archived")
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+
+	registry, _, err := loadWorkspaceRegistry()
+	if err != nil {
+		return err
+	}
+	if len(registry.Workspaces) == 0 {
+		return cli.NewError(cli.ErrCodeNotFound, "no workspaces registered").
+			WithHint("Register projects with 'shark workspace add <name> <root>'")
+	}
+
+	req := &status.StatusRequest{
+		IncludeArchived: includeArchived,
+		Labels:          labels,
+		Weighted:        useWeightedProgress(cmd),
+	}
+	result := &AllWorkspacesStatusJSON{Workspaces: make(map[string]*WorkspaceStatusJSON, len(registry.Workspaces))}
+	for _, ws := range registry.Workspaces {
+		entry := &WorkspaceStatusJSON{Root: ws.Root}
+		dashboard, err := workspaceDashboard(ws.Root, req)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Summary = dashboard.Summary
+			entry.Epics = dashboard.Epics
+		}
+		result.Workspaces[ws.Name] = entry
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(result)
+	}
+
+	if req.Weighted {
+		noteWeightedProgress(cmd.Context(), nil)
+	}
+	headers := []string{"Workspace", "Progress", "Epics", "Tasks", "In Progress", "Blocked"}
+	rows := [][]string{}
+	var totalTasks, totalCompleted, totalInProgress, totalBlocked int
+	var progressSum float64
+	for _, ws := range registry.Workspaces {
+		entry := result.Workspaces[ws.Name]
+		if entry.Error != "" {
+			rows = append(rows, []string{ws.Name, "error: " + entry.Error, "", "", "", ""})
+			continue
+		}
+		summary := entry.Summary
+		rows = append(rows, []string{
+			ws.Name,
+			fmt.Sprintf("%.0f%%", summary.OverallProgress),
+			fmt.Sprintf("%d", summary.Epics.Total),
+			fmt.Sprintf("%d/%d", summary.Tasks.Completed, summary.Tasks.Total),
+			fmt.Sprintf("%d", summary.Tasks.InProgress),
+			fmt.Sprintf("%d", summary.BlockedCount),
+		})
+		totalTasks += summary.Tasks.Total
+		totalCompleted += summary.Tasks.Completed
+		totalInProgress += summary.Tasks.InProgress
+		totalBlocked += summary.BlockedCount
+		progressSum += summary.OverallProgress * float64(summary.Tasks.Total)
+	}
+
+	// Overall progress weighs each workspace by its task count
+	totalProgress := 0.0
+	if totalTasks > 0 {
+		totalProgress = progressSum / float64(totalTasks)
+	}
+	rows = append(rows, []string{
+		"Total",
+		fmt.Sprintf("%.0f%%", totalProgress),
+		"",
+		fmt.Sprintf("%d/%d", totalCompleted, totalTasks),
+		fmt.Sprintf("%d", totalInProgress),
+		fmt.Sprintf("%d", totalBlocked),
+	})
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// workspaceDashboard builds the status dashboard of the workspace at root,
+// rating health with the rules in the workspace's own .shark.yaml and settings
+func workspaceDashboard(root string, base *status.StatusRequest) (*status.StatusDashboard, error) {
+	ctx, cancel := cli.CommandContext(5 * time.Second)
+	defer cancel()
+
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("root %s does not exist", root)
+	}
+	repoDb, err := cli.OpenProjectDB(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	defer repoDb.Close()
+
+	req := *base
+	if req.Health, err = status.LoadProjectHealthRules(ctx, root, repoDb); err != nil {
+		return nil, err
+	}
+	return status.NewStatusService(repoDb).GetDashboard(ctx, &req)
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"time"
@@ -186,7 +185,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 	defer engine.Close()
 
 	// Run sync with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := cli.CommandContext(5 * time.Minute)
 	defer cancel()
 
 	syncReport, err := engine.Sync(ctx, opts)
@@ -221,7 +220,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if !syncQuiet {
 		// Use color output if terminal supports it
 		useColor := isTerminal()
-		fmt.Println(reporting.FormatCLI(scanReport, useColor))
+		fmt.Println(reporting.FormatCLIWithLinks(scanReport, useColor, projectLinkFormat()))
 	} else {
 		// Quiet mode: only show errors
 		if len(syncReport.Errors) > 0 {
@@ -3,7 +3,9 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -23,6 +25,50 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// TaskGetJSON is the JSON output of task get
+type TaskGetJSON struct {
+	Task             *models.Task                        `json:"task"`
+	Path             string                              `json:"path"`
+	Filename         string                              `json:"filename"`
+	DependencyStatus map[string]string                   `json:"dependency_status"`
+	RelatedDocuments []*models.Document                  `json:"related_documents"`
+	BlockedBy        []string                            `json:"blocked_by"`
+	Blocks           []string                            `json:"blocks"`
+	RejectionHistory []*repository.RejectionHistoryEntry `json:"rejection_history"`
+	Checklist        []*models.TaskChecklistItem         `json:"checklist"`
+	Readiness        *TaskReadiness                      `json:"readiness"`
+	Attachments      []*models.TaskAttachment            `json:"attachments"`
+}
+
+// NextTaskJSON is the JSON output of task next for a single task
+type NextTaskJSON struct {
+	Key              string            `json:"key"`
+	Title            string            `json:"title"`
+	FilePath         *string           `json:"file_path"`
+	Dependencies     *string           `json:"dependencies"`
+	DependencyStatus map[string]string `json:"dependency_status"`
+	Priority         int               `json:"priority"`
+	AgentType        *string           `json:"agent_type"`
+	ExecutionOrder   *int              `json:"execution_order"`
+	Labels           []string          `json:"labels"`
+
+	// Set by task next --claim
+	Claimed       bool              `json:"claimed,omitempty"`
+	Status        models.TaskStatus `json:"status,omitempty"`
+	AssignedAgent *string           `json:"assigned_agent,omitempty"`
+
+	// Set by task next --lease
+	Lease *models.TaskLease `json:"lease,omitempty"`
+}
+
+// NextTaskListJSON is the JSON output of task next when several tasks are
+// returned (with --count or parallel tasks)
+type NextTaskListJSON struct {
+	Message string          `json:"message"`
+	Count   int             `json:"count"`
+	Tasks   []*NextTaskJSON `json:"tasks"`
+}
+
 // getRelativePathTask converts an absolute path to relative path from project root
 func getRelativePathTask(absPath string, projectRoot string) string {
 	relPath, err := filepath.Rel(projectRoot, absPath)
@@ -38,16 +84,18 @@ func getRelativePathTask(absPath string, projectRoot string) string {
 func triggerStatusCascade(ctx context.Context, dbWrapper *repository.DB, featureID int64) {
 	// Load workflow config
 	configPath, err := cli.GetConfigPath()
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to get config path: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to get config path", "error", err)
 	}
 	cfg, err := config.LoadWorkflowConfig(configPath)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to load config: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to load config", "error", err)
 	}
 
 	calcService := status.NewCalculationService(dbWrapper, cfg)
 	results, err := calcService.CascadeFromFeatureID(ctx, featureID)
+	// Milestone hooks depend on task progress, not on the cascade succeeding
+	defer fireMilestoneHooks(ctx, dbWrapper, featureID)
 	if err != nil {
 		cli.Warning(fmt.Sprintf("Status cascade failed: %v", err))
 		return
@@ -78,11 +126,15 @@ Examples:
 
 // taskListCmd lists tasks
 var taskListCmd = &cobra.Command{
-	Use:   "list [EPIC] [FEATURE]",
-	Short: "List tasks",
+	Use:         "list [EPIC] [FEATURE]",
+	Short:       "List tasks",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List tasks with optional filtering by status, epic, feature, or agent.
 
 By default, completed tasks are hidden. Use --show-all to include them.
+Tasks are listed in execution order unless --sort-by or list.task_sort in
+.sharkconfig.json says otherwise. --limit, --offset, and --page show one page
+of a long list; list.page_size in .sharkconfig.json sets the default --limit.
 
 Positional Arguments:
   EPIC      Optional epic key (E##) to filter by epic (e.g., E04)
@@ -97,14 +149,22 @@ Examples:
   shark task list --status=todo        List tasks with status 'todo'
   shark task list --status=completed   List only completed tasks
   shark task list --epic=E04           Flag syntax (still supported)
+  shark task list --label=security     List tasks labeled 'security'
+  shark task list --grep=OAuth         Search titles and descriptions
+  shark task list --grep=OAuth --include-files --show-all
+                                       Also search task files, including completed tasks
+  shark task list --sort-by=updated_at:desc --limit=20
+                                       The 20 most recently updated tasks
+  shark task list --limit=50 --page=2  Tasks 51-100
   shark task list --json               Output as JSON`,
 	RunE: runTaskList,
 }
 
 // taskGetCmd gets a specific task
 var taskGetCmd = &cobra.Command{
-	Use:   "get <task-key>",
-	Short: "Get task details",
+	Use:         "get <task-key>",
+	Short:       "Get task details",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display detailed information about a specific task.
 
 Supports multiple key formats:
@@ -127,9 +187,20 @@ var taskCreateCmd = &cobra.Command{
 	Long: `Create a new task with automatic key generation and file creation.
 
 The --agent flag is optional and accepts any string value. If not provided, defaults to "general".
-The --template flag allows using a custom task template file.
+The --template flag selects a named template from the template registry (see 'shark template list')
+or a path to a custom template file. Use --var key=value (repeatable) to pass user-defined variables,
+available in templates as {{.Vars.key}}.
 The --file flag allows specifying a custom file path (relative to project root, must end in .md).
 The --create flag creates the file if it doesn't exist (when using --file).
+The --chain flag makes the task depend on the previous task in execution order within the
+feature; without --order the task is placed after the last task. Use it when scripting a
+strictly sequential plan.
+The --standalone flag creates a small chore that doesn't fit an epic or feature. It goes in
+the implicit backlog (created on first use) with a T-BKL-### key, and shows up in list,
+status, and next like any other task.
+The --if-not-exists flag makes retries safe: if a task with the --key, or without --key one
+in the feature with the same title (ignoring case), exists, it is returned and nothing is
+created. JSON output has "created": false for an existing task.
 
 Positional Arguments:
   EPIC      Optional epic key (E##) - can also be specified with --epic flag
@@ -151,7 +222,23 @@ Examples:
   shark task create "Build Login" --epic=E01 --feature=F02 --agent=frontend
   shark task create "User Service" --epic=E01 --feature=F02 --agent=backend --priority=5
   shark task create "Database task" --epic=E01 --feature=F02 --agent=database-admin
-  shark task create "Custom task" --epic=E01 --feature=F02 --template=./my-template.md`,
+  shark task create "Custom task" --epic=E01 --feature=F02 --template=./my-template.md
+  shark task create "Harden login" --epic=E01 --feature=F02 --label=security,backend
+  shark task create "Add rate limiting" --epic=E01 --feature=F02 --agent=backend --estimate=5pts
+
+  # Named templates with variables
+  shark task create E01 F02 "Fix login crash" --template=bugfix --var severity=high --var component=auth
+
+  # Safe to retry: returns the existing task if there is one
+  shark task create E01 F02 "Build Login" --if-not-exists --json
+
+  # Standalone task in the backlog (T-BKL-###); promote it later with 'shark task move'
+  shark task create "Fix typo in README" --standalone
+
+  # Sequential plan: each task depends on the one before it
+  shark task create E01 F02 "Design schema" --chain
+  shark task create E01 F02 "Write migration" --chain
+  shark task create E01 F02 "Add repository" --chain`,
 	Args: cobra.RangeArgs(1, 3),
 	RunE: runTaskCreate,
 }
@@ -165,6 +252,11 @@ var taskStartCmd = &cobra.Command{
 Use --force to bypass status transition validation. This allows starting a task
 from any status (not just 'todo'). Use with caution as this is an administrative override.
 
+If .sharkconfig.json sets a WIP limit for the task's agent type and that many tasks
+of the type are already in progress, the task is not started; use --ignore-wip-limit
+to start it anyway:
+  "wip_limits": {"backend": 2, "frontend": 3}
+
 Supports multiple key formats (numeric, full, or slugged).
 
 Examples:
@@ -198,8 +290,12 @@ var taskApproveCmd = &cobra.Command{
 	Short: "Approve task for completion",
 	Long: `Approve a task that is ready for review and mark it as completed.
 
-Use --force to bypass status transition validation. This allows approving a task
-from any status (not just 'ready_for_review'). Use with caution as this is an administrative override.`,
+A task with acceptance criteria that are pending, in progress, or failed can't
+be approved until they are verified ('shark task ac verify').
+
+Use --force to bypass status transition validation and unverified criteria.
+This allows approving a task from any status (not just 'ready_for_review').
+Use with caution as this is an administrative override.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTaskApprove,
 }
@@ -242,29 +338,58 @@ from any status (not just 'ready_for_review'). Use with caution as this is an ad
 
 // taskNextCmd finds the next available task
 var taskNextCmd = &cobra.Command{
-	Use:   "next",
-	Short: "Get next available task",
-	Long: `Find the next available task based on dependencies, priority, and agent type.
+	Use:         "next",
+	Short:       "Get next available task",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true", cli.WritingFlagsAnnotation: "claim,lease"},
+	Long: `Find the next available task based on dependencies, execution order, priority, and agent type.
+
+A task is available when it is in todo status and every task it depends on is
+completed or archived. Candidates are ordered by execution order (unordered tasks
+last), then priority, then creation time. Without --count, all tasks sharing the
+lowest execution order are returned so they can be worked in parallel.
+
+--claim atomically starts the returned task (todo → in_progress) and assigns it to
+the current agent. If another agent claims it first, the next candidate is tried.
+Tasks whose agent type is at its WIP limit (wip_limits in .sharkconfig.json) are
+skipped unless --ignore-wip-limit is given.
+
+--lease reserves the returned task for this agent instance without starting it.
+Until the lease expires, other agents' 'task next' skips the task, so agents
+polling at the same time get distinct tasks; the holder sees it again and can
+renew the lease by leasing again. A lease that expires before the task is
+started lapses. --agent-id names the agent instance (default: $USER); --agent
+still filters by agent type.
 
 Examples:
   shark task next                     Get next task
-  shark task next --agent=frontend    Get next frontend task`,
+  shark task next --agent=frontend    Get next frontend task
+  shark task next --label=tech-debt   Get next task labeled 'tech-debt'
+  shark task next --count=5           List the next five available tasks
+  shark task next --claim --json      Start the next task and return it
+  shark task next --agent=backend --lease=5m --agent-id=backend-2
+                                      Reserve the next backend task for backend-2`,
 	RunE: runTaskNext,
 }
 
 // taskDeleteCmd deletes a task
 var taskDeleteCmd = &cobra.Command{
-	Use:   "delete <task-key>",
-	Short: "Delete a task",
-	Long: `Delete a task from the database (and its history via CASCADE).
+	Use:         "delete <task-key>",
+	Short:       "Delete a task",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Move a task to the trash.
+
+Trashed tasks are hidden from lists and status until they are brought back
+with 'shark trash restore'. 'shark trash empty' deletes them for good.
 
-WARNING: This action cannot be undone. Task history will also be deleted.
+With --permanent the task is deleted from the database immediately, along with
+its history (via CASCADE).
 
 Supports multiple key formats (numeric, full, or slugged).
 
 Examples:
-  shark task delete T-E04-F01-001                  Delete task by full key
-  shark task delete T-E04-F01-001-user-auth        Delete task by slugged key`,
+  shark task delete T-E04-F01-001                  Move task to the trash
+  shark task delete T-E04-F01-001-user-auth        Delete task by slugged key
+  shark task delete T-E04-F01-001 --permanent      Delete task permanently`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTaskDelete,
 }
@@ -278,6 +403,11 @@ var taskUpdateCmd = &cobra.Command{
 For backward status transitions (e.g., moving from review back to development), you must provide
 a --reason flag to explain why the task is being sent back, unless --force is used.
 
+If another agent changes the task while the update runs, the update is refused
+with exit code 4 instead of overwriting their change. Use --retry=N to re-read the
+task and reapply the changes up to N times, or --expect-version (from
+'shark task get --json') to only update the version you read.
+
 Supports multiple key formats (numeric, full, or slugged).
 
 Examples:
@@ -287,7 +417,11 @@ Examples:
   shark task update T-E04-F01-001 --agent backend
   shark task update T-E04-F01-001 --filename "docs/tasks/custom.md"
   shark task update T-E04-F01-001 --depends-on "T-E04-F01-002,T-E04-F01-003"
-  shark task update T-E04-F01-001 --status in_development --reason "Missing error handling"`,
+  shark task update T-E04-F01-001 --status in_development --reason "Missing error handling"
+  shark task update T-E04-F01-001 --label security --remove-label tech-debt
+  shark task update T-E04-F01-001 --estimate 3h --actual-effort 1.5h
+  shark task update T-E04-F01-001 --priority 2 --retry 3
+  shark task update T-E04-F01-001 --priority 2 --expect-version 7`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTaskUpdate,
 }
@@ -304,10 +438,15 @@ workflow. It can transition to any valid status according to the workflow rules.
 Use --force to bypass workflow validation. This allows transitioning to any status
 regardless of workflow rules. Use with caution as this is an administrative override.
 
+If another agent changes the task at the same time, the command exits with code 4.
+Use --retry=N to re-read the task and try the transition again, or --expect-version
+to only change the version you read.
+
 Examples:
   shark task set-status T-E04-F01-001 in_progress
   shark task set-status T-E04-F01-001 ready_for_review --notes "Completed implementation"
-  shark task set-status T-E04-F01-001 blocked --notes "Waiting for API" --force`,
+  shark task set-status T-E04-F01-001 blocked --notes "Waiting for API" --force
+  shark task set-status T-E04-F01-001 in_progress --expect-version 4`,
 	Args: cobra.ExactArgs(2),
 	RunE: runTaskSetStatus,
 }
@@ -338,15 +477,16 @@ func enrichTasksWithOrchestratorActions(ctx context.Context, repo *repository.Ta
 
 // runTaskList executes the task list command
 func runTaskList(cmd *cobra.Command, args []string) error {
+	applyFocus(cmd, args)
+
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Parse positional arguments first
 	positionalEpic, positionalFeature, err := ParseTaskListArgs(args)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
 	}
 
 	// Get database connection
@@ -369,11 +509,28 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	blocked, _ := cmd.Flags().GetBool("blocked")
 	withActions, _ := cmd.Flags().GetBool("with-actions")
 	hasRejections, _ := cmd.Flags().GetBool("has-rejections")
+	standalone, _ := cmd.Flags().GetBool("standalone")
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+	grepPattern, _ := cmd.Flags().GetString("grep")
+	includeFiles, _ := cmd.Flags().GetBool("include-files")
+	grepContext, _ := cmd.Flags().GetInt("context")
+	if grepPattern == "" && (includeFiles || cmd.Flags().Changed("context")) {
+		cli.Fail(cli.ErrCodeFailure, "Error: --include-files and --context require --grep")
+	}
+	if grepContext < 0 {
+		cli.Fail(cli.ErrCodeFailure, "Error: --context must not be negative")
+	}
 
 	// Positional arguments take priority over flags
 	if positionalEpic != nil {
 		epicKey = *positionalEpic
 	}
+	if standalone {
+		epicKey = models.BacklogKey
+	}
 	if positionalFeature != nil {
 		featureKey = *positionalFeature
 	}
@@ -385,101 +542,117 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build filters
-	var status *models.TaskStatus
-	var agentType *string
-	var maxPriority *int
+	showAll, _ := cmd.Flags().GetBool("show-all")
+	filter := repository.TaskListFilter{
+		Blocked:       blocked,
+		HasRejections: hasRejections,
+		// Completed tasks are hidden by default (unless --show-all or explicit status filter)
+		HideCompleted: !showAll && statusStr == "",
+		Labels:        labels,
+	}
 
-	// Parse status filter (can be multiple, comma-separated)
-	var tasks []*models.Task
+	// Parse status filter
 	if statusStr != "" {
 		// For simplicity, handle single status first
 		// TODO: Support multiple statuses in a future enhancement
 		s := models.TaskStatus(statusStr)
-		status = &s
+		filter.Status = &s
+	}
+	if epicKey != "" {
+		filter.EpicKey = &epicKey
 	}
 
 	// Parse agent type filter
 	if agentStr != "" {
-		agentType = &agentStr
+		filter.AgentType = &agentStr
 	}
 
 	// Handle priority filter
 	if priorityMax > 0 {
-		maxPriority = &priorityMax
+		filter.MaxPriority = &priorityMax
 	} else if priorityMin > 0 {
 		// If only min is specified, max = 10 (highest priority number)
 		max := 10
-		maxPriority = &max
-	}
-
-	// Query tasks based on filters
-	if epicKey != "" || status != nil || agentType != nil || maxPriority != nil {
-		var epicKeyPtr *string
-		if epicKey != "" {
-			epicKeyPtr = &epicKey
-		}
-		tasks, err = repo.FilterCombined(ctx, status, epicKeyPtr, agentType, maxPriority)
-	} else {
-		tasks, err = repo.List(ctx)
-	}
-
-	if err != nil {
-		return fmt.Errorf("failed to list tasks: %w", err)
+		filter.MaxPriority = &max
 	}
 
 	// Filter by feature if requested
 	if featureKey != "" {
-		filteredTasks := []*models.Task{}
-		// Get feature ID from the feature key
-		featureRepo := repository.NewFeatureRepository(repoDb)
-		feature, err := featureRepo.GetByKey(ctx, featureKey)
+		feature, err := repository.NewFeatureRepository(repoDb).GetByKey(ctx, featureKey)
 		if err != nil {
 			return fmt.Errorf("failed to find feature: %w", err)
 		}
-		if feature != nil {
-			for _, task := range tasks {
-				if task.FeatureID == feature.ID {
-					filteredTasks = append(filteredTasks, task)
-				}
-			}
-			tasks = filteredTasks
-		} else {
-			// Feature doesn't exist - return empty list
-			tasks = []*models.Task{}
-		}
+		filter.FeatureID = &feature.ID
 	}
 
-	// Filter by blocked status if requested
-	if blocked {
-		filteredTasks := []*models.Task{}
-		for _, task := range tasks {
-			if task.Status == models.TaskStatusBlocked {
-				filteredTasks = append(filteredTasks, task)
-			}
-		}
-		tasks = filteredTasks
+	// Sort and page: --sort-by, --limit, --offset, and --page, else the config's defaults
+	cfg := listConfig()
+	opts, err := listOptions(cmd, cfg.GetTaskListSort(), cfg.GetListPageSize())
+	if err != nil {
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err))
+	}
+	if _, ok := repository.TaskSortFields[opts.SortBy]; opts.SortBy != "" && !ok {
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: Invalid sort-by '%s'. Must be one of: key, title, status, priority, execution_order, created_at, updated_at", opts.SortBy))
 	}
 
-	// Filter by rejections if requested
-	if hasRejections {
-		filteredTasks := []*models.Task{}
-		for _, task := range tasks {
-			if task.RejectionCount > 0 {
-				filteredTasks = append(filteredTasks, task)
-			}
+	// --grep pages its matches, so it needs every task
+	query := opts
+	if grepPattern != "" {
+		query.Limit, query.Offset = 0, 0
+	}
+	tasks, err := repo.ListFiltered(ctx, filter, query)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	total := len(tasks)
+	if grepPattern == "" && isPaged(opts) {
+		if total, err = repo.CountFiltered(ctx, filter); err != nil {
+			return fmt.Errorf("failed to count tasks: %w", err)
 		}
-		tasks = filteredTasks
 	}
 
-	// Filter out completed tasks by default (unless --show-all or explicit status filter)
-	showAll, _ := cmd.Flags().GetBool("show-all")
-	tasks = filterTasksByCompletedStatus(tasks, showAll, statusStr)
+	// Attach labels for output
+	if err := attachTaskLabels(ctx, repoDb, tasks); err != nil {
+		return fmt.Errorf("failed to load labels: %w", err)
+	}
 
 	// Enrich tasks with orchestrator actions if requested
 	if withActions && len(tasks) > 0 {
 		enrichTasksWithOrchestratorActions(ctx, repo, tasks)
 	}
 
+	// Search titles, descriptions, and task files if requested
+	if grepPattern != "" {
+		var resolvePath func(context.Context, *models.Task) (string, string, error)
+		if includeFiles {
+			if projectRoot, err := cli.FindProjectRoot(); err == nil {
+				pathResolver := pathresolver.NewPathResolver(
+					repository.NewEpicRepository(repoDb), repository.NewFeatureRepository(repoDb), repo, projectRoot)
+				resolvePath = func(ctx context.Context, task *models.Task) (string, string, error) {
+					if task.FilePath != nil && filepath.IsAbs(*task.FilePath) {
+						return *task.FilePath, getRelativePathTask(*task.FilePath, projectRoot), nil
+					}
+					absPath, err := pathResolver.ResolveTaskPath(ctx, task.Key)
+					return absPath, getRelativePathTask(absPath, projectRoot), err
+				}
+			}
+		}
+		results := grepTasks(ctx, tasks, grepPattern, grepContext, includeFiles, resolvePath)
+		matches := len(results)
+		results = pageSlice(results, opts)
+
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(results)
+		}
+		if matches == 0 {
+			cli.Info("No tasks found matching %q", grepPattern)
+			return nil
+		}
+		fmt.Print(formatTaskGrepResults(results))
+		notePage(opts, len(results), matches, "matching tasks")
+		return nil
+	}
+
 	// Output results
 	// TODO: Support multiple output formats (markdown, yaml, csv)
 	// See docs/future-enhancements/output-formats.md for implementation plan
@@ -490,12 +663,16 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 
 	// Human-readable table output
 	if len(tasks) == 0 {
+		if total > 0 {
+			notePage(opts, 0, total, "tasks")
+			return nil
+		}
 		cli.Info("No tasks found")
 		return nil
 	}
 
 	// Get project root for WorkflowService
-	projectRoot, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
 		projectRoot = ""
 	}
@@ -505,6 +682,7 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	config := formatters.DefaultTaskTableConfig()
 	config.ColorEnabled = !cli.GlobalConfig.NoColor
 	_ = formatters.RenderTaskTable(tasks, workflowService, config)
+	notePage(opts, len(tasks), total, "tasks")
 
 	// Show action summaries if --with-actions flag is set
 	// Note: This will display orchestrator actions once T-E07-F21-006 adds OrchestratorAction field to Task model
@@ -547,11 +725,11 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 // runTaskGet executes the task get command
 func runTaskGet(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -574,12 +752,23 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := taskRepo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
+	}
+
+	if err := attachTaskLabels(ctx, repoDb, []*models.Task{task}); err != nil {
+		slog.Warn("Failed to fetch labels", "error", err)
+	}
+	if err := attachTaskEstimate(ctx, repoDb, task); err != nil {
+		slog.Warn("Failed to fetch estimate", "error", err)
+	}
+	if lease, err := taskRepo.GetActiveLease(ctx, task.ID); err == nil {
+		task.Lease = lease
+	} else {
+		slog.Warn("Failed to fetch lease", "error", err)
 	}
 
 	// Get project root for path resolution
-	projectRoot, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
 		projectRoot = ""
 	}
@@ -591,8 +780,8 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 		absPath, err := pathResolver.ResolveTaskPath(ctx, task.Key)
 		if err == nil {
 			resolvedPath = getRelativePathTask(absPath, projectRoot)
-		} else if cli.GlobalConfig.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to resolve task path: %v\n", err)
+		} else {
+			slog.Warn("Failed to resolve task path", "error", err)
 		}
 	}
 
@@ -621,8 +810,8 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 
 	// Get related documents
 	relatedDocs, err := documentRepo.ListForTask(ctx, task.ID)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch related documents: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to fetch related documents", "error", err)
 	}
 	if relatedDocs == nil {
 		relatedDocs = []*models.Document{}
@@ -631,8 +820,8 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 	// Get blocking relationships
 	// Blocked-by: incoming "blocks" relationships (tasks that block this task)
 	blockedByRels, err := relationshipRepo.GetIncoming(ctx, task.ID, []string{"blocks"})
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch blocked-by relationships: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to fetch blocked-by relationships", "error", err)
 	}
 	blockedByKeys := []string{}
 	for _, rel := range blockedByRels {
@@ -644,8 +833,8 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 
 	// Blocks: outgoing "blocks" relationships (tasks this task blocks)
 	blocksRels, err := relationshipRepo.GetOutgoing(ctx, task.ID, []string{"blocks"})
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch blocks relationships: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to fetch blocks relationships", "error", err)
 	}
 	blocksKeys := []string{}
 	for _, rel := range blocksRels {
@@ -658,25 +847,49 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 	// Get rejection history from task_notes table (includes document paths)
 	noteRepo := repository.NewTaskNoteRepository(repoDb)
 	rejectionHistory, err := noteRepo.GetRejectionHistory(ctx, task.ID)
-	if err != nil && cli.GlobalConfig.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch rejection history: %v\n", err)
+	if err != nil {
+		slog.Warn("Failed to fetch rejection history", "error", err)
 	}
 	if rejectionHistory == nil {
 		rejectionHistory = make([]*repository.RejectionHistoryEntry, 0)
 	}
 
+	// Get checklist items and readiness (criteria + checklist completion)
+	checklist, err := repository.NewTaskChecklistRepository(repoDb).ListByTaskID(ctx, task.ID)
+	if err != nil {
+		slog.Warn("Failed to fetch checklist", "error", err)
+	}
+	if checklist == nil {
+		checklist = []*models.TaskChecklistItem{}
+	}
+	readiness, err := loadTaskReadiness(ctx, repoDb, task.ID)
+	if err != nil {
+		slog.Warn("Failed to calculate readiness", "error", err)
+	}
+
+	attachments, err := repository.NewTaskAttachmentRepository(repoDb).ListByTaskID(ctx, task.ID)
+	if err != nil {
+		slog.Warn("Failed to fetch attachments", "error", err)
+	}
+	if attachments == nil {
+		attachments = []*models.TaskAttachment{}
+	}
+
 	// Output results
 	if cli.GlobalConfig.JSON {
 		// Create enhanced output with dependency status, related docs, and blocking relationships
-		output := map[string]interface{}{
-			"task":              task,
-			"path":              dirPath,
-			"filename":          filename,
-			"dependency_status": dependencyStatus,
-			"related_documents": relatedDocs,
-			"blocked_by":        blockedByKeys,
-			"blocks":            blocksKeys,
-			"rejection_history": rejectionHistory,
+		output := &TaskGetJSON{
+			Task:             task,
+			Path:             dirPath,
+			Filename:         filename,
+			DependencyStatus: dependencyStatus,
+			RelatedDocuments: relatedDocs,
+			BlockedBy:        blockedByKeys,
+			Blocks:           blocksKeys,
+			RejectionHistory: rejectionHistory,
+			Checklist:        checklist,
+			Readiness:        readiness,
+			Attachments:      attachments,
 		}
 		return cli.OutputJSON(output)
 	}
@@ -699,6 +912,10 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Filename: %s\n", filename)
 	}
 
+	if link := entityFileLink(projectRoot, resolvedPath); link != "" {
+		fmt.Printf("Link: %s\n", link)
+	}
+
 	if task.Description != nil {
 		fmt.Printf("Description: %s\n", *task.Description)
 	}
@@ -711,6 +928,16 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Assigned Agent: %s\n", *task.AssignedAgent)
 	}
 
+	if task.Lease != nil {
+		fmt.Printf("Leased By: %s (until %s)\n", task.Lease.Agent, task.Lease.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	if len(task.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", strings.Join(task.Labels, ", "))
+	}
+
+	printTaskEstimate(task.Estimate)
+
 	if task.BlockedReason != nil {
 		fmt.Printf("Blocked Reason: %s\n", *task.BlockedReason)
 	}
@@ -750,6 +977,29 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Display checklist and readiness
+	if len(checklist) > 0 {
+		fmt.Println("\nChecklist:")
+		for _, item := range checklist {
+			mark := " "
+			if item.Done {
+				mark = "x"
+			}
+			fmt.Printf("  [%s] %d. %s\n", mark, item.ID, item.Content)
+		}
+	}
+	if readiness != nil && (readiness.CriteriaTotal > 0 || readiness.ChecklistTotal > 0) {
+		fmt.Printf("\n%s\n", formatTaskReadiness(readiness))
+	}
+
+	// Display attachments
+	if len(attachments) > 0 {
+		fmt.Println("\nAttachments:")
+		for _, attachment := range attachments {
+			fmt.Printf("  - %s (%s, sha256 %s)\n", attachment.FilePath, formatBytes(attachment.SizeBytes), shortChecksum(attachment.SHA256))
+		}
+	}
+
 	// Display related documents
 	if len(relatedDocs) > 0 {
 		fmt.Println("\nRelated Documents:")
@@ -828,10 +1078,44 @@ func runTaskGet(cmd *cobra.Command, args []string) error {
 
 // runTaskNext executes the task next command
 func runTaskNext(cmd *cobra.Command, args []string) error {
+	applyFocus(cmd, args)
+
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
+	// Get filter flags
+	agentStr, _ := cmd.Flags().GetString("agent")
+	epicKey, _ := cmd.Flags().GetString("epic")
+	count, _ := cmd.Flags().GetInt("count")
+	claim, _ := cmd.Flags().GetBool("claim")
+	lease, _ := cmd.Flags().GetDuration("lease")
+	agentIDFlag, _ := cmd.Flags().GetString("agent-id")
+	agentID := getAgentIdentifier(agentIDFlag)
+
+	if count < 0 {
+		return fmt.Errorf("--count must be a positive number")
+	}
+	if claim && count > 1 {
+		return fmt.Errorf("--claim starts a single task and cannot be combined with --count greater than 1")
+	}
+	if cmd.Flags().Changed("lease") {
+		if lease <= 0 {
+			return fmt.Errorf("--lease must be a positive duration such as 5m")
+		}
+		if claim {
+			return fmt.Errorf("--lease reserves a task without starting it and cannot be combined with --claim")
+		}
+		if count > 1 {
+			return fmt.Errorf("--lease reserves a single task and cannot be combined with --count greater than 1")
+		}
+	}
+
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+
 	// Get database connection
 	repoDb, err := cli.GetDB(cmd.Context())
 	if err != nil {
@@ -839,53 +1123,61 @@ func runTaskNext(cmd *cobra.Command, args []string) error {
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
-	// Create repositories
-	dbWrapper := repoDb
-	repo := repository.NewTaskRepository(dbWrapper)
-	relRepo := repository.NewTaskRelationshipRepository(dbWrapper)
-
-	// Get filter flags
-	agentStr, _ := cmd.Flags().GetString("agent")
-	epicKey, _ := cmd.Flags().GetString("epic")
-
-	// Build filter for todo status
-	todoStatus := models.TaskStatusTodo
-	var agentType *string
-	var epicKeyPtr *string
-
-	if agentStr != "" {
-		agentType = &agentStr
+	// Load workflow config so --claim validates the transition like 'task start'
+	var workflow *config.WorkflowConfig
+	if configPath, err := cli.GetConfigPath(); err == nil {
+		workflow, _ = config.LoadWorkflowConfig(configPath)
 	}
-
-	if epicKey != "" {
-		epicKeyPtr = &epicKey
+	repo := repository.NewTaskRepository(repoDb)
+	if workflow != nil {
+		repo = repository.NewTaskRepositoryWithWorkflow(repoDb, workflow)
 	}
 
-	// Get all todo tasks matching filters
-	tasks, err := repo.FilterCombined(ctx, &todoStatus, epicKeyPtr, agentType, nil)
+	// Todo tasks whose dependencies are all completed, resolved in SQL and
+	// ordered by execution_order, then priority
+	availableTasks, err := repo.ListAvailable(ctx, repository.AvailableTaskFilter{
+		EpicKey:     epicKey,
+		AgentType:   agentStr,
+		Labels:      labels,
+		LeaseHolder: agentID,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to query tasks: %w", err)
 	}
 
-	// Filter out tasks with incomplete dependencies
-	var availableTasks []*models.Task
-	for _, task := range tasks {
-		if isTaskAvailable(ctx, task, repo, relRepo) {
-			availableTasks = append(availableTasks, task)
+	if claim {
+		// Skip agent types at their WIP limit. The claim itself checks the
+		// limit again, atomically, in case other agents started tasks since.
+		if ignoreWIPLimit, _ := cmd.Flags().GetBool("ignore-wip-limit"); !ignoreWIPLimit {
+			limits := projectWIPLimits()
+			repo.SetWIPLimits(limits)
+			var limited []string
+			availableTasks, limited, err = withoutWIPLimited(ctx, repo, limits, availableTasks)
+			if err != nil {
+				return fmt.Errorf("failed to check WIP limits: %w", err)
+			}
+			if len(availableTasks) == 0 && len(limited) > 0 {
+				failWIPLimited(limited)
+			}
 		}
+		return claimNextTask(ctx, repoDb, repo, availableTasks, agentID)
+	}
+	if lease > 0 {
+		return leaseNextTask(ctx, repoDb, repo, availableTasks, agentID, lease)
 	}
 
-	if len(availableTasks) == 0 {
-		if cli.GlobalConfig.JSON {
-			return cli.OutputJSON(map[string]string{"message": "No available tasks found"})
+	// Select next task(s): the top --count candidates, or the tasks sharing
+	// the lowest execution_order
+	var nextTasks []*models.Task
+	if count > 0 {
+		nextTasks = availableTasks
+		if len(nextTasks) > count {
+			nextTasks = nextTasks[:count]
 		}
-		cli.Info("No available tasks found")
-		return nil
+	} else {
+		nextTasks = selectNextTasks(availableTasks)
 	}
 
-	// Select next task(s) based on execution_order and priority
-	nextTasks := selectNextTasks(availableTasks)
-
 	if len(nextTasks) == 0 {
 		if cli.GlobalConfig.JSON {
 			return cli.OutputJSON(map[string]string{"message": "No available tasks found"})
@@ -894,48 +1186,33 @@ func runTaskNext(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Output result - if multiple tasks, output array; if single task, output object
-	if len(nextTasks) > 1 {
-		// Multiple tasks with same order (parallel work possible)
-		if cli.GlobalConfig.JSON {
-			taskOutputs := []map[string]interface{}{}
-			for _, task := range nextTasks {
-				dependencyStatus := map[string]string{}
-				if task.DependsOn != nil && *task.DependsOn != "" {
-					var deps []string
-					if err := json.Unmarshal([]byte(*task.DependsOn), &deps); err == nil {
-						for _, depKey := range deps {
-							depTask, err := repo.GetByKey(ctx, depKey)
-							if err == nil {
-								dependencyStatus[depKey] = string(depTask.Status)
-							}
-						}
-					}
-				}
+	if err := attachTaskLabels(ctx, repoDb, nextTasks); err != nil {
+		return fmt.Errorf("failed to load labels: %w", err)
+	}
+	dependencyStatus := loadDependencyStatuses(ctx, repo, nextTasks)
 
-				taskOutputs = append(taskOutputs, map[string]interface{}{
-					"key":               task.Key,
-					"title":             task.Title,
-					"file_path":         task.FilePath,
-					"dependencies":      task.DependsOn,
-					"dependency_status": dependencyStatus,
-					"priority":          task.Priority,
-					"agent_type":        task.AgentType,
-					"execution_order":   task.ExecutionOrder,
-				})
-			}
+	// Output result - with --count or multiple tasks, output a list; otherwise a single task
+	if count > 0 || len(nextTasks) > 1 {
+		message := "Multiple tasks available for parallel execution"
+		if count > 0 {
+			message = fmt.Sprintf("Next %d available task(s)", len(nextTasks))
+		}
 
-			output := map[string]interface{}{
-				"message": "Multiple tasks available for parallel execution",
-				"count":   len(nextTasks),
-				"tasks":   taskOutputs,
+		if cli.GlobalConfig.JSON {
+			output := &NextTaskListJSON{Message: message, Count: len(nextTasks), Tasks: []*NextTaskJSON{}}
+			for _, task := range nextTasks {
+				output.Tasks = append(output.Tasks, nextTaskJSON(task, dependencyStatus[task.Key]))
 			}
 			return cli.OutputJSON(output)
 		}
 
 		// Human-readable output for multiple tasks
-		fmt.Printf("Multiple tasks available for parallel execution (%d tasks with order=%v):\n\n",
-			len(nextTasks), nextTasks[0].ExecutionOrder)
+		if count > 0 {
+			fmt.Printf("%s:\n\n", message)
+		} else {
+			fmt.Printf("Multiple tasks available for parallel execution (%d tasks with order=%v):\n\n",
+				len(nextTasks), nextTasks[0].ExecutionOrder)
+		}
 		for i, task := range nextTasks {
 			fmt.Printf("%d. %s: %s\n", i+1, task.Key, task.Title)
 			fmt.Printf("   Priority: %d\n", task.Priority)
@@ -959,50 +1236,194 @@ func runTaskNext(cmd *cobra.Command, args []string) error {
 
 	// Output result
 	if cli.GlobalConfig.JSON {
-		// Include dependency status
-		dependencyStatus := map[string]string{}
-		if nextTask.DependsOn != nil && *nextTask.DependsOn != "" {
-			var deps []string
-			if err := json.Unmarshal([]byte(*nextTask.DependsOn), &deps); err == nil {
-				for _, depKey := range deps {
-					depTask, err := repo.GetByKey(ctx, depKey)
-					if err == nil {
-						dependencyStatus[depKey] = string(depTask.Status)
-					}
-				}
-			}
+		return cli.OutputJSON(nextTaskJSON(nextTask, dependencyStatus[nextTask.Key]))
+	}
+
+	printNextTask(nextTask)
+	return nil
+}
+
+// claimNextTask atomically starts the first available task. If another agent
+// claims a candidate first, the next candidate is tried.
+func claimNextTask(ctx context.Context, repoDb *repository.DB, repo *repository.TaskRepository, candidates []*models.Task, agent string) error {
+	var claimed *models.Task
+	limited := make(map[string]string)
+	for _, candidate := range candidates {
+		ok, err := repo.ClaimTask(ctx, candidate.ID, candidate.Status, models.TaskStatusInProgress, &agent)
+		var wipErr *repository.WIPLimitError
+		if errors.As(err, &wipErr) {
+			// Another agent took the last slot; try a task for another agent type
+			limited[wipErr.AgentType] = fmt.Sprintf("%s %d/%d", wipErr.AgentType, wipErr.InProgress, wipErr.Limit)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim task %s: %w", candidate.Key, err)
 		}
+		if ok {
+			claimed = candidate
+			break
+		}
+	}
 
-		output := map[string]interface{}{
-			"key":               nextTask.Key,
-			"title":             nextTask.Title,
-			"file_path":         nextTask.FilePath,
-			"dependencies":      nextTask.DependsOn,
-			"dependency_status": dependencyStatus,
-			"priority":          nextTask.Priority,
-			"agent_type":        nextTask.AgentType,
-			"execution_order":   nextTask.ExecutionOrder,
+	if claimed == nil && len(limited) > 0 {
+		failWIPLimited(sortedWIPUsage(limited))
+	}
+	if claimed == nil {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]string{"message": "No available tasks found"})
 		}
+		cli.Info("No available tasks found")
+		return nil
+	}
+
+	recordRegisteredAgent(ctx, repoDb, agent, claimed.ID)
+	task, err := repo.GetByID(ctx, claimed.ID)
+	if err != nil {
+		return fmt.Errorf("task %s was claimed but could not be reloaded: %w", claimed.Key, err)
+	}
+	if err := attachTaskLabels(ctx, repoDb, []*models.Task{task}); err != nil {
+		slog.Warn("Failed to fetch labels", "error", err)
+	}
+
+	// Record the work session as 'task start' does
+	session := &models.WorkSession{
+		TaskID:    task.ID,
+		AgentID:   &agent,
+		StartedAt: time.Now(),
+	}
+	if err := repository.NewWorkSessionRepository(repoDb).Create(ctx, session); err != nil {
+		cli.Warning(fmt.Sprintf("Failed to create work session: %v", err))
+	}
+
+	if !cli.GlobalConfig.JSON {
+		cli.Success(fmt.Sprintf("Claimed task %s", task.Key))
+	}
+
+	// Trigger cascading status updates for parent feature and epic
+	triggerStatusCascade(ctx, repoDb, task.FeatureID)
+
+	if cli.GlobalConfig.JSON {
+		output := nextTaskJSON(task, loadDependencyStatuses(ctx, repo, []*models.Task{task})[task.Key])
+		output.Claimed = true
+		output.Status = task.Status
+		output.AssignedAgent = task.AssignedAgent
 		return cli.OutputJSON(output)
 	}
 
-	// Human-readable output
-	fmt.Printf("Next Task: %s\n", nextTask.Key)
-	fmt.Printf("Title: %s\n", nextTask.Title)
-	fmt.Printf("Priority: %d\n", nextTask.Priority)
-	if nextTask.ExecutionOrder != nil {
-		fmt.Printf("Order: %d\n", *nextTask.ExecutionOrder)
+	printNextTask(task)
+	fmt.Printf("Status: %s\n", task.Status)
+	return nil
+}
+
+// leaseNextTask atomically leases the first available task to agent. If another
+// agent leases or claims a candidate first, the next candidate is tried.
+func leaseNextTask(ctx context.Context, repoDb *repository.DB, repo *repository.TaskRepository, candidates []*models.Task, agent string, ttl time.Duration) error {
+	var leased *models.Task
+	for _, candidate := range candidates {
+		lease, err := repo.LeaseTask(ctx, candidate.ID, candidate.Status, agent, ttl)
+		if err != nil {
+			return fmt.Errorf("failed to lease task %s: %w", candidate.Key, err)
+		}
+		if lease != nil {
+			lease.TaskKey = candidate.Key
+			candidate.Lease = lease
+			leased = candidate
+			break
+		}
 	}
-	if nextTask.AgentType != nil {
-		fmt.Printf("Agent Type: %s\n", *nextTask.AgentType)
+
+	if leased == nil {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]string{"message": "No available tasks found"})
+		}
+		cli.Info("No available tasks found")
+		return nil
 	}
-	if nextTask.FilePath != nil {
-		fmt.Printf("File Path: %s\n", *nextTask.FilePath)
+
+	if err := attachTaskLabels(ctx, repoDb, []*models.Task{leased}); err != nil {
+		slog.Warn("Failed to fetch labels", "error", err)
 	}
 
+	if cli.GlobalConfig.JSON {
+		output := nextTaskJSON(leased, loadDependencyStatuses(ctx, repo, []*models.Task{leased})[leased.Key])
+		output.Lease = leased.Lease
+		return cli.OutputJSON(output)
+	}
+
+	cli.Success(fmt.Sprintf("Leased task %s to %s until %s", leased.Key, agent, leased.Lease.ExpiresAt.Local().Format("15:04:05")))
+	printNextTask(leased)
 	return nil
 }
 
+// loadDependencyStatuses returns the status of each depends_on key, per task key
+func loadDependencyStatuses(ctx context.Context, repo *repository.TaskRepository, tasks []*models.Task) map[string]map[string]string {
+	depsByTask := make(map[string][]string, len(tasks))
+	var allDeps []string
+	for _, task := range tasks {
+		if task.DependsOn == nil || *task.DependsOn == "" {
+			continue
+		}
+		var deps []string
+		if err := json.Unmarshal([]byte(*task.DependsOn), &deps); err == nil {
+			depsByTask[task.Key] = deps
+			allDeps = append(allDeps, deps...)
+		}
+	}
+
+	depTasks := map[string]*models.Task{}
+	if len(allDeps) > 0 {
+		if found, err := repo.GetByKeys(ctx, allDeps); err == nil {
+			depTasks = found
+		}
+	}
+
+	result := make(map[string]map[string]string, len(tasks))
+	for _, task := range tasks {
+		status := map[string]string{}
+		for _, depKey := range depsByTask[task.Key] {
+			if depTask, ok := depTasks[depKey]; ok {
+				status[depKey] = string(depTask.Status)
+			}
+		}
+		result[task.Key] = status
+	}
+	return result
+}
+
+// nextTaskJSON builds the JSON representation of a task returned by task next
+func nextTaskJSON(task *models.Task, dependencyStatus map[string]string) *NextTaskJSON {
+	if dependencyStatus == nil {
+		dependencyStatus = map[string]string{}
+	}
+	return &NextTaskJSON{
+		Key:              task.Key,
+		Title:            task.Title,
+		FilePath:         task.FilePath,
+		Dependencies:     task.DependsOn,
+		DependencyStatus: dependencyStatus,
+		Priority:         task.Priority,
+		AgentType:        task.AgentType,
+		ExecutionOrder:   task.ExecutionOrder,
+		Labels:           task.Labels,
+	}
+}
+
+// printNextTask prints a single task returned by task next
+func printNextTask(task *models.Task) {
+	fmt.Printf("Next Task: %s\n", task.Key)
+	fmt.Printf("Title: %s\n", task.Title)
+	fmt.Printf("Priority: %d\n", task.Priority)
+	if task.ExecutionOrder != nil {
+		fmt.Printf("Order: %d\n", *task.ExecutionOrder)
+	}
+	if task.AgentType != nil {
+		fmt.Printf("Agent Type: %s\n", *task.AgentType)
+	}
+	if task.FilePath != nil {
+		fmt.Printf("File Path: %s\n", *task.FilePath)
+	}
+}
+
 // selectNextTasks selects the next task(s) to work on based on order and priority
 // Returns all tasks with the lowest execution_order value (or highest priority if no order)
 // Sorting logic:
@@ -1173,15 +1594,26 @@ func filterTasksByCompletedStatus(tasks []*models.Task, showAll bool, statusFilt
 // runTaskCreate executes the task create command
 func runTaskCreate(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Parse positional arguments (supports multiple syntaxes)
 	var title, epicKey, featureKey string
 	positionalEpic, positionalFeature, positionalTitle, err := ParseTaskCreateArgs(args)
+	standalone, _ := cmd.Flags().GetBool("standalone")
 
-	// If positional parsing succeeds, use those values
-	if err == nil && positionalEpic != nil && positionalFeature != nil && positionalTitle != nil {
+	if standalone {
+		// Standalone syntax: shark task create "Task Title" --standalone
+		epicFlag, _ := cmd.Flags().GetString("epic")
+		featureFlag, _ := cmd.Flags().GetString("feature")
+		if len(args) != 1 || epicFlag != "" || featureFlag != "" {
+			cli.Fail(cli.ErrCodeInvalidArgument, "Error: --standalone takes only a title, without an epic or feature",
+				"Example:\n  shark task create \"Fix typo in README\" --standalone")
+		}
+		title = args[0]
+		epicKey = models.BacklogKey
+		featureKey = models.BacklogKey
+	} else if err == nil && positionalEpic != nil && positionalFeature != nil && positionalTitle != nil {
 		// Positional syntax: shark task create E07 F20 "Task Title" or shark task create E07-F20 "Task Title"
 		title = *positionalTitle
 
@@ -1214,22 +1646,14 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		featureKey, _ = cmd.Flags().GetString("feature")
 	} else {
 		// Invalid syntax - show error
-		cli.Error(fmt.Sprintf("Error: %v", err))
-		fmt.Println("\nValid syntaxes:")
-		fmt.Println("  shark task create E07 F20 \"Task Title\"")
-		fmt.Println("  shark task create E07-F20 \"Task Title\"")
-		fmt.Println("  shark task create \"Task Title\" --epic=E07 --feature=F20")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Error: %v", err),
+			"Valid syntaxes:\n  shark task create E07 F20 \"Task Title\"\n  shark task create E07-F20 \"Task Title\"\n  shark task create \"Task Title\" --epic=E07 --feature=F20")
 	}
 
 	// Validate required fields
 	if epicKey == "" || featureKey == "" || title == "" {
-		cli.Error("Error: Missing required arguments. Epic, feature, and title are all required.")
-		fmt.Println("\nExamples:")
-		fmt.Println("  shark task create E07 F20 \"Build Login\"")
-		fmt.Println("  shark task create E07-F20 \"Build Login\"")
-		fmt.Println("  shark task create \"Build Login\" --epic=E01 --feature=F02")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, "Error: Missing required arguments. Epic, feature, and title are all required.",
+			"Examples:\n  shark task create E07 F20 \"Build Login\"\n  shark task create E07-F20 \"Build Login\"\n  shark task create \"Build Login\" --epic=E01 --feature=F02")
 	}
 
 	// Get optional flags
@@ -1243,6 +1667,7 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 	if order != 0 {
 		executionOrder = order
 	}
+	chain, _ := cmd.Flags().GetBool("chain")
 	customKey, _ := cmd.Flags().GetString("key")
 	force, _ := cmd.Flags().GetBool("force")
 
@@ -1263,10 +1688,27 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 
 	create, _ := cmd.Flags().GetBool("create")
 
+	// Template selection and user-defined template variables
+	templateName, _ := cmd.Flags().GetString("template")
+	varPairs, _ := cmd.Flags().GetStringArray("var")
+	templateVars, err := templates.ParseVars(varPairs)
+	if err != nil {
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: %v", err))
+	}
+
+	labels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+
+	estimate, err := estimateFromFlags(cmd, nil)
+	if err != nil {
+		return err
+	}
+
 	// Validate custom key if provided
 	if customKey != "" && containsSpace(customKey) {
-		cli.Error("Error: Task key cannot contain spaces")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, "Error: Task key cannot contain spaces")
 	}
 
 	// Get database connection
@@ -1276,11 +1718,14 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 	}
 	// Note: Database will be closed automatically by PersistentPostRunE hook
 
-	// Get project root (current working directory)
-	projectRoot, err := os.Getwd()
+	if !cmd.Flags().Changed("priority") {
+		priority = defaultTaskPriority(ctx, repoDb, priority)
+	}
+
+	// Get project root
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
-		cli.Error(fmt.Sprintf("Failed to get working directory: %s", err.Error()))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Failed to find project root: %s", err.Error()))
 	}
 
 	// Create repositories
@@ -1290,11 +1735,19 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 	taskRepo := repository.NewTaskRepository(repoDb)
 	historyRepo := repository.NewTaskHistoryRepository(repoDb)
 
+	// Standalone tasks go in the backlog, which is created on first use
+	if standalone {
+		if _, err := featureRepo.GetOrCreateBacklog(ctx); err != nil {
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Failed to create task: %s", err.Error()))
+		}
+	}
+
 	// Create task creation components
 	keygen := taskcreation.NewKeyGenerator(taskRepo, featureRepo)
 	validator := taskcreation.NewValidator(epicRepo, featureRepo, taskRepo)
 	loader := templates.NewLoader("")
-	renderer := templates.NewRenderer(loader)
+	registry := templates.NewRegistry(filepath.Join(projectRoot, templates.DefaultProjectTemplateDir))
+	renderer := templates.NewRendererWithRegistry(loader, registry)
 	// Pass nil for workflowService - Creator will create one automatically from projectRoot
 	creator := taskcreation.NewCreator(repoDb, keygen, validator, renderer, taskRepo, historyRepo, epicRepo, featureRepo, projectRoot, nil)
 
@@ -1308,16 +1761,65 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		Priority:       priority,
 		DependsOn:      dependsOn,
 		ExecutionOrder: executionOrder,
+		Chain:          chain,
 		CustomKey:      customKey,
 		Filename:       filename,
 		Force:          force,
 		Create:         create,
+		Template:       templateName,
+		Vars:           templateVars,
+	}
+
+	// With --if-not-exists, a retried create returns the task it made
+	if ifNotExists, _ := cmd.Flags().GetBool(ifNotExistsFlag); ifNotExists {
+		existing, err := creator.FindExisting(ctx, input)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return outputExistingEntity("task", existing.Key, existing.Title, existing.FilePath, projectRoot, existing)
+		}
+	}
+
+	// With --force, the creator takes the file from the epic, feature, or task
+	// that claims it; journal the owner's file path for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	var previousOwner *models.FileClaim
+	if force && filename != "" {
+		if _, relPath, err := taskcreation.ValidateCustomFilename(filename, projectRoot); err == nil {
+			if owner, err := repository.NewFileClaimRepository(repoDb).GetByPath(ctx, relPath); err == nil && owner != nil {
+				previousOwner = owner
+				journal.captureColumns(ctx, owner.EntityType+"s", []int64{owner.EntityID}, "file_path")
+			}
+		}
 	}
 
 	result, err := creator.CreateTask(ctx, input)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Failed to create task: %s", err.Error()))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Failed to create task: %s", err.Error()))
+	}
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityTask, EntityKey: result.Task.Key, Action: models.AuditActionCreate, Summary: result.Task.Title})
+
+	if previousOwner != nil {
+		journal.setOnUndo("tasks", result.Task.ID, "file_path", nil)
+		journal.record(ctx, "file reassign", result.Task.Key, fmt.Sprintf("Reassigned %s from %s to task %s", previousOwner.Path, previousOwner.EntityKey, result.Task.Key))
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: models.AuditEntityTask, EntityKey: result.Task.Key, Action: models.AuditActionFileReassign,
+			Summary: fmt.Sprintf("Took %s from %s", previousOwner.Path, previousOwner.EntityKey)})
+	}
+
+	if len(labels) > 0 {
+		if err := repository.NewLabelRepository(repoDb).AddTaskLabels(ctx, result.Task.ID, labels); err != nil {
+			return fmt.Errorf("task %s created but labels could not be added: %w", result.Task.Key, err)
+		}
+		result.Task.Labels = labels
+	}
+
+	if estimate != nil {
+		estimate.TaskID = result.Task.ID
+		if err := repository.NewTaskEstimateRepository(repoDb).Set(ctx, estimate); err != nil {
+			return fmt.Errorf("task %s created but estimate could not be saved: %w", result.Task.Key, err)
+		}
+		result.Task.Estimate = estimate
 	}
 
 	// Output result
@@ -1344,11 +1846,11 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 // runTaskStart executes the task start command
 func runTaskStart(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -1384,8 +1886,7 @@ func runTaskStart(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Get force flag
@@ -1399,15 +1900,30 @@ func runTaskStart(cmd *cobra.Command, args []string) error {
 		cli.Warning("Warning: Task has incomplete dependencies but proceeding with start.")
 	}
 
+	// Refuse to start past the agent type's WIP limit
+	if ignoreWIPLimit, _ := cmd.Flags().GetBool("ignore-wip-limit"); !ignoreWIPLimit {
+		repo.SetWIPLimits(projectWIPLimits())
+	}
+
 	// Get agent identifier
 	agentFlag, _ := cmd.Flags().GetString("agent")
 	agent := getAgentIdentifier(agentFlag)
 
 	// Update status and get orchestrator action for in_progress status
 	updatedTask, orchestratorAction, err := repo.UpdateStatusWithAction(ctx, taskKey, string(models.TaskStatusInProgress))
+	if errors.Is(err, repository.ErrVersionConflict) {
+		exitVersionConflict(taskKey, err)
+	}
+	var wipErr *repository.WIPLimitError
+	if errors.As(err, &wipErr) {
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Cannot start %s: %v", taskKey, wipErr), wipLimitHint)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update task status: %w", err)
 	}
+	if recordRegisteredAgent(ctx, dbWrapper, agent, task.ID) {
+		updatedTask.AssignedAgent = &agent
+	}
 
 	// Create work session
 	sessionRepo := repository.NewWorkSessionRepository(dbWrapper)
@@ -1423,6 +1939,7 @@ func runTaskStart(cmd *cobra.Command, args []string) error {
 
 	if force {
 		cli.Warning(fmt.Sprintf("Task %s force-started from %s status", taskKey, task.Status))
+		recordForcedTransition(ctx, dbWrapper, task, models.TaskStatusInProgress, agent)
 	}
 
 	// Output result
@@ -1469,11 +1986,11 @@ func runTaskStart(cmd *cobra.Command, args []string) error {
 // runTaskComplete executes the task complete command
 func runTaskComplete(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -1509,13 +2026,23 @@ func runTaskComplete(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Get force flag
 	force, _ := cmd.Flags().GetBool("force")
 
+	// Unverified acceptance criteria block approval unless forced
+	if !force {
+		summary, err := repository.NewTaskCriteriaRepository(dbWrapper).GetSummaryByTaskID(ctx, task.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check acceptance criteria: %w", err)
+		}
+		if unverified := summary.UnverifiedCount(); unverified > 0 {
+			cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Cannot approve %s: %d of %d acceptance criteria are not verified", taskKey, unverified, summary.TotalCount), fmt.Sprintf("Review them with 'shark task ac list %s' and sign off with 'shark task ac verify %s <criterion-id>', or use --force", taskKey, taskKey))
+		}
+	}
+
 	// Note: Workflow validation now handled by repository layer, not CLI
 
 	// Get agent identifier and optional notes
@@ -1531,11 +2058,10 @@ func runTaskComplete(cmd *cobra.Command, args []string) error {
 	updatedTask, orchestratorAction, err := repo.UpdateStatusWithAction(ctx, taskKey, string(models.TaskStatusReadyForReview))
 	if err != nil {
 		// Display error with workflow suggestion
-		cli.Error(fmt.Sprintf("Failed to update task status: %s", err.Error()))
 		if !force {
-			cli.Info("Use --force to bypass workflow validation")
+			cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to update task status: %s", err.Error()), "Use --force to bypass workflow validation")
 		}
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to update task status: %s", err.Error()))
 	}
 
 	// End active work session
@@ -1611,6 +2137,7 @@ func runTaskComplete(cmd *cobra.Command, args []string) error {
 
 	if force {
 		cli.Warning(fmt.Sprintf("Task %s force-completed from %s status", taskKey, task.Status))
+		recordForcedTransition(ctx, dbWrapper, task, models.TaskStatusReadyForReview, agent)
 	}
 
 	// Return JSON output if requested
@@ -1664,11 +2191,11 @@ func runTaskComplete(cmd *cobra.Command, args []string) error {
 // runTaskApprove executes the task approve command
 func runTaskApprove(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -1704,8 +2231,7 @@ func runTaskApprove(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Get force flag
@@ -1735,22 +2261,18 @@ func runTaskApprove(cmd *cobra.Command, args []string) error {
 	if reasonDocFlag != "" {
 		// Validate document path format
 		if err := ValidateRejectionReasonDocPath(reasonDocFlag); err != nil {
-			cli.Error(fmt.Sprintf("Invalid document path: %s", err.Error()))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Invalid document path: %s", err.Error()))
 		}
 
 		// Check if document file exists
 		projectRoot, err := cli.FindProjectRoot()
 		if err != nil {
-			cli.Error(fmt.Sprintf("Failed to find project root: %s", err.Error()))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Failed to find project root: %s", err.Error()))
 		}
 
 		fullPath := filepath.Join(projectRoot, reasonDocFlag)
 		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			cli.Error(fmt.Sprintf("Document not found: %s", reasonDocFlag))
-			cli.Info(fmt.Sprintf("Looked for file at: %s", fullPath))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Document not found: %s", reasonDocFlag), fmt.Sprintf("Looked for file at: %s", fullPath))
 		}
 
 		// Convert to pointer for passing to repository
@@ -1758,17 +2280,20 @@ func runTaskApprove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update status (repository handles workflow validation)
-	if err := repo.UpdateStatusForced(ctx, task.ID, models.TaskStatusCompleted, &agent, notes, rejectionReason, documentPath, force); err != nil {
+	if err := repo.UpdateStatusIfVersion(ctx, task.ID, task.Version, models.TaskStatusCompleted, &agent, notes, rejectionReason, documentPath, force); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			exitVersionConflict(taskKey, err)
+		}
 		// Display error with workflow suggestion
-		cli.Error(fmt.Sprintf("Failed to update task status: %s", err.Error()))
 		if !force {
-			cli.Info("Use --force to bypass workflow validation")
+			cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to update task status: %s", err.Error()), "Use --force to bypass workflow validation")
 		}
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to update task status: %s", err.Error()))
 	}
 
 	if force {
 		cli.Warning(fmt.Sprintf("Task %s force-approved from %s status", taskKey, task.Status))
+		recordForcedTransition(ctx, dbWrapper, task, models.TaskStatusCompleted, agent)
 	}
 
 	cli.Success(fmt.Sprintf("Task %s approved and completed.", taskKey))
@@ -1779,11 +2304,27 @@ func runTaskApprove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// getAgentIdentifier returns the agent identifier from flag, environment variable, or default
+// recordRegisteredAgent records a registered agent as the holder of a task it
+// started or claimed, and marks the agent seen. It reports whether the agent
+// is registered; failures only warn, the task has already changed.
+func recordRegisteredAgent(ctx context.Context, repoDb *repository.DB, agent string, taskID int64) bool {
+	assigned, err := repository.NewAgentRepository(repoDb).AssignTask(ctx, agent, taskID)
+	if err != nil {
+		cli.Warning(fmt.Sprintf("Failed to record agent %s: %v", agent, err))
+		return false
+	}
+	return assigned
+}
+
+// getAgentIdentifier returns the agent identifier from flag, environment variable, or default.
+// Registered agents set SHARK_AGENT to the name they registered with.
 func getAgentIdentifier(agentFlag string) string {
 	if agentFlag != "" {
 		return agentFlag
 	}
+	if agent := os.Getenv("SHARK_AGENT"); agent != "" {
+		return agent
+	}
 	if user := os.Getenv("USER"); user != "" {
 		return user
 	}
@@ -1793,11 +2334,11 @@ func getAgentIdentifier(agentFlag string) string {
 // runTaskBlock executes the task block command
 func runTaskBlock(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -1805,8 +2346,7 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 	// Get required reason flag
 	reason, _ := cmd.Flags().GetString("reason")
 	if reason == "" {
-		cli.Error("Error: --reason is required when blocking a task. Explain why the task cannot proceed.")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeInvalidArgument, "Error: --reason is required when blocking a task. Explain why the task cannot proceed.")
 	}
 
 	// Get database connection
@@ -1822,8 +2362,7 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Get force flag
@@ -1843,10 +2382,7 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 				}
 			}
 			if !canBlock {
-				cli.Error(fmt.Sprintf("Invalid state transition from %s to blocked.", task.Status))
-				cli.Info(fmt.Sprintf("Workflow does not allow blocking from status '%s'", task.Status))
-				cli.Info("Use --force to bypass this validation")
-				os.Exit(3)
+				cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Invalid state transition from %s to blocked.", task.Status), fmt.Sprintf("Workflow does not allow blocking from status '%s'", task.Status), "Use --force to bypass this validation")
 			}
 		}
 	}
@@ -1872,6 +2408,7 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 
 	if force {
 		cli.Warning(fmt.Sprintf("Task %s force-blocked from %s status", taskKey, task.Status))
+		recordForcedTransition(ctx, dbWrapper, task, models.TaskStatusBlocked, agent)
 	}
 
 	cli.Success(fmt.Sprintf("Task %s blocked. Reason: %s", taskKey, reason))
@@ -1885,11 +2422,11 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 // runTaskUnblock executes the task unblock command
 func runTaskUnblock(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -1908,8 +2445,7 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Get force flag
@@ -1917,9 +2453,7 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 
 	// Validate current status is "blocked" unless forcing
 	if !force && task.Status != models.TaskStatusBlocked {
-		cli.Error(fmt.Sprintf("Invalid state transition from %s to todo. Task must be in 'blocked' status.", task.Status))
-		cli.Info("Use --force to bypass this validation")
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Invalid state transition from %s to todo. Task must be in 'blocked' status.", task.Status), "Use --force to bypass this validation")
 	}
 
 	// Get agent identifier
@@ -1933,6 +2467,7 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 
 	if force {
 		cli.Warning(fmt.Sprintf("Task %s force-unblocked from %s status", taskKey, task.Status))
+		recordForcedTransition(ctx, dbWrapper, task, models.TaskStatusTodo, agent)
 	}
 
 	cli.Success(fmt.Sprintf("Task %s unblocked and returned to todo queue", taskKey))
@@ -1943,14 +2478,18 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// reopenTargetStatuses are the statuses a reopened task may go back to: a
+// development or refinement status
+var reopenTargetStatuses = []string{"in_development", "in_progress", "ready_for_development", "ready_for_refinement", "in_refinement"}
+
 // runTaskReopen executes the task reopen command
 func runTaskReopen(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -1969,8 +2508,7 @@ func runTaskReopen(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Get force flag
@@ -1983,10 +2521,8 @@ func runTaskReopen(cmd *cobra.Command, args []string) error {
 		if workflow != nil && workflow.StatusFlow != nil {
 			allowedTransitions := workflow.StatusFlow[string(task.Status)]
 			canReopen := false
-			// Reopen typically means going back to a development/refinement status
-			reopenTargets := []string{"in_development", "in_progress", "ready_for_development", "ready_for_refinement", "in_refinement"}
 			for _, nextStatus := range allowedTransitions {
-				for _, target := range reopenTargets {
+				for _, target := range reopenTargetStatuses {
 					if nextStatus == target {
 						canReopen = true
 						break
@@ -1997,11 +2533,7 @@ func runTaskReopen(cmd *cobra.Command, args []string) error {
 				}
 			}
 			if !canReopen {
-				cli.Error(fmt.Sprintf("Invalid state transition from %s.", task.Status))
-				cli.Info(fmt.Sprintf("Workflow does not allow reopening from status '%s'", task.Status))
-				cli.Info(fmt.Sprintf("Allowed transitions from '%s': %v", task.Status, allowedTransitions))
-				cli.Info("Use --force to bypass this validation")
-				os.Exit(3)
+				cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Invalid state transition from %s.", task.Status), fmt.Sprintf("Workflow does not allow reopening from status '%s'", task.Status), fmt.Sprintf("Allowed transitions from '%s': %v", task.Status, allowedTransitions), "Use --force to bypass this validation")
 			}
 		}
 	}
@@ -2028,22 +2560,18 @@ func runTaskReopen(cmd *cobra.Command, args []string) error {
 	if reasonDocFlag != "" {
 		// Validate document path format
 		if err := ValidateRejectionReasonDocPath(reasonDocFlag); err != nil {
-			cli.Error(fmt.Sprintf("Invalid document path: %s", err.Error()))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Invalid document path: %s", err.Error()))
 		}
 
 		// Check if document file exists
 		projectRoot, err := cli.FindProjectRoot()
 		if err != nil {
-			cli.Error(fmt.Sprintf("Failed to find project root: %s", err.Error()))
-			os.Exit(2)
+			cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Failed to find project root: %s", err.Error()))
 		}
 
 		fullPath := filepath.Join(projectRoot, reasonDocFlag)
 		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			cli.Error(fmt.Sprintf("Document not found: %s", reasonDocFlag))
-			cli.Info(fmt.Sprintf("Looked for file at: %s", fullPath))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Document not found: %s", reasonDocFlag), fmt.Sprintf("Looked for file at: %s", fullPath))
 		}
 
 		// Convert to pointer for passing to repository
@@ -2057,6 +2585,7 @@ func runTaskReopen(cmd *cobra.Command, args []string) error {
 
 	if force {
 		cli.Warning(fmt.Sprintf("Task %s force-reopened from %s status", taskKey, task.Status))
+		recordForcedTransition(ctx, dbWrapper, task, models.TaskStatusInProgress, agent)
 	}
 
 	cli.Success(fmt.Sprintf("Task %s reopened for rework.", taskKey))
@@ -2069,11 +2598,11 @@ func runTaskReopen(cmd *cobra.Command, args []string) error {
 
 // runTaskDelete executes the task delete command
 func runTaskDelete(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -2092,18 +2621,40 @@ func runTaskDelete(cmd *cobra.Command, args []string) error {
 	// Get task by key to verify it exists
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Capture feature ID before deletion for cascade
 	featureID := task.FeatureID
 
+	if permanent, _ := cmd.Flags().GetBool("permanent"); !permanent {
+		journal := newUndoJournal(dbWrapper)
+		journal.captureColumns(ctx, "tasks", []int64{task.ID}, "deleted_at")
+
+		if err := repository.NewTrashRepository(dbWrapper).TrashTask(ctx, task.ID); err != nil {
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Failed to move task to the trash: %v", err))
+		}
+		summary := fmt.Sprintf("Moved task %s (%s) to the trash", task.Key, task.Title)
+		journal.record(ctx, "task delete", task.Key, summary)
+		recordAudit(ctx, dbWrapper, &models.AuditEntry{EntityType: models.AuditEntityTask, EntityKey: task.Key, Action: models.AuditActionDelete, Summary: summary})
+
+		cli.Success(fmt.Sprintf("Task %s moved to the trash", taskKey))
+		cli.Info(fmt.Sprintf("Restore it with 'shark trash restore %s'", task.Key))
+		triggerStatusCascade(ctx, dbWrapper, featureID)
+		return nil
+	}
+
+	// Snapshot the task and its history, notes, and links for 'shark undo'
+	journal := newUndoJournal(dbWrapper)
+	journal.captureDelete(ctx, "tasks", task.ID)
+
 	// Delete task from database (CASCADE will handle history)
 	if err := repo.Delete(ctx, task.ID); err != nil {
-		cli.Error(fmt.Sprintf("Failed to delete task: %v", err))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Failed to delete task: %v", err))
 	}
+	summary := fmt.Sprintf("Deleted task %s (%s)", task.Key, task.Title)
+	journal.record(ctx, "task delete", task.Key, summary)
+	recordAudit(ctx, dbWrapper, &models.AuditEntry{EntityType: models.AuditEntityTask, EntityKey: task.Key, Action: models.AuditActionDelete, Summary: summary})
 
 	cli.Success(fmt.Sprintf("Task %s deleted successfully", taskKey))
 
@@ -2131,6 +2682,7 @@ func init() {
 	taskCmd.AddCommand(taskNextCmd)
 	taskCmd.AddCommand(taskNextStatusCmd)
 	taskCmd.AddCommand(taskDeleteCmd)
+	taskDeleteCmd.Flags().Bool("permanent", false, "Delete permanently instead of moving to the trash")
 	taskCmd.AddCommand(taskUpdateCmd)
 	taskCmd.AddCommand(taskSetStatusCmd)
 
@@ -2145,19 +2697,32 @@ func init() {
 	taskListCmd.Flags().Bool("show-all", false, "Show all tasks including completed (by default, completed tasks are hidden)")
 	taskListCmd.Flags().Bool("with-actions", false, "Include orchestrator actions with each task (for batch orchestrator polling)")
 	taskListCmd.Flags().Bool("has-rejections", false, "Filter tasks that have rejections")
+	taskListCmd.Flags().Bool("standalone", false, "Show only standalone tasks (T-BKL-###)")
+	taskListCmd.Flags().String("grep", "", "Show only tasks whose title or description contains this text (case-insensitive), with the matching lines")
+	taskListCmd.Flags().Bool("include-files", false, "With --grep, also search each task's markdown file")
+	taskListCmd.Flags().IntP("context", "C", 1, "With --grep, lines of context to show around each match")
+	taskListCmd.Flags().String("sort-by", "", "Sort by: key, title, status, priority, execution_order, created_at, updated_at; add :desc to reverse (default: list.task_sort in .sharkconfig.json, else execution order)")
+	addListPagingFlags(taskListCmd)
+	addLabelFilterFlag(taskListCmd)
 
 	// Add flags for create command
 	taskCreateCmd.Flags().StringP("epic", "e", "", "Epic key (e.g., E01) - can also be specified as first positional argument")
 	taskCreateCmd.Flags().StringP("feature", "f", "", "Feature key (e.g., F02 or E01-F02) - can also be specified as second positional argument")
 	taskCreateCmd.Flags().StringP("agent", "a", "", "Agent type (optional, accepts any string)")
 	taskCreateCmd.Flags().StringP("description", "d", "", "Detailed description (optional)")
-	taskCreateCmd.Flags().IntP("priority", "p", 5, "Priority (1=highest, 10=lowest, default 5)")
+	taskCreateCmd.Flags().IntP("priority", "p", 5, "Priority (1=highest, 10=lowest; default 5, or the task.default_priority setting)")
 	taskCreateCmd.Flags().String("depends-on", "", "Comma-separated dependency task keys (optional)")
 	taskCreateCmd.Flags().Int("execution-order", 0, "Execution order (optional, 0 = not set)")
 	taskCreateCmd.Flags().Int("order", 0, "Execution order (alias for --execution-order)")
+	taskCreateCmd.Flags().Bool("chain", false, "Depend on the previous task in execution order within the feature")
+	taskCreateCmd.Flags().Bool("standalone", false, "Create a standalone task in the backlog (T-BKL-###) instead of a feature")
 	taskCreateCmd.Flags().String("key", "", "Custom key for the task (e.g., T-E01-F01-custom). If not provided, auto-generates next sequence number")
 	taskCreateCmd.Flags().Bool("force", false, "Force reassignment if file already claimed by another task")
 	taskCreateCmd.Flags().Bool("create", false, "Create file if it doesn't exist when using --file flag")
+	taskCreateCmd.Flags().String("template", "", "Named template (see 'shark template list') or path to a template file")
+	taskCreateCmd.Flags().StringArray("var", nil, "Template variable as key=value (repeatable)")
+	addLabelEditFlags(taskCreateCmd, false)
+	addEstimateFlags(taskCreateCmd)
 
 	// Note: --epic and --feature flags are no longer required since they can be specified positionally
 
@@ -2167,15 +2732,23 @@ func init() {
 	taskCreateCmd.Flags().String("path", "", "Alias for --file")
 	_ = taskCreateCmd.Flags().MarkHidden("filename")
 	_ = taskCreateCmd.Flags().MarkHidden("path")
+	addIfNotExistsFlag(taskCreateCmd, "task", "feature")
 
 	// Add flags for next command
 	taskNextCmd.Flags().StringP("agent", "a", "", "Agent type to match")
 	taskNextCmd.Flags().StringP("epic", "e", "", "Filter by epic key")
+	addLabelFilterFlag(taskNextCmd)
+	taskNextCmd.Flags().Int("count", 0, "Return up to N available tasks in order")
+	taskNextCmd.Flags().Bool("claim", false, "Atomically start the returned task and assign it to the current agent")
+	taskNextCmd.Flags().Bool("ignore-wip-limit", false, "With --claim, also claim tasks whose agent type is at its WIP limit")
+	taskNextCmd.Flags().Duration("lease", 0, "Reserve the returned task for this agent for a duration (e.g. 5m) without starting it")
+	taskNextCmd.Flags().String("agent-id", "", "Agent instance that claims or leases tasks (defaults to SHARK_AGENT, else USER env var)")
 
 	// Add flags for state transition commands
-	taskStartCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to USER env var)")
+	taskStartCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to SHARK_AGENT, else USER env var)")
 	taskStartCmd.Flags().Bool("force", false, "Force status change bypassing validation (use with caution)")
-	taskCompleteCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to USER env var)")
+	taskStartCmd.Flags().Bool("ignore-wip-limit", false, "Start even if the task's agent type is at its WIP limit")
+	taskCompleteCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to SHARK_AGENT, else USER env var)")
 	taskCompleteCmd.Flags().StringP("notes", "n", "", "Completion notes")
 	taskCompleteCmd.Flags().Bool("force", false, "Force status change bypassing validation (use with caution)")
 
@@ -2187,7 +2760,7 @@ func init() {
 	taskCompleteCmd.Flags().Bool("verified", false, "Mark task as verified")
 	taskCompleteCmd.Flags().String("agent-id", "", "Agent execution ID for traceability")
 	taskCompleteCmd.Flags().Int("time-spent", 0, "Time spent in minutes")
-	taskApproveCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to USER env var)")
+	taskApproveCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to SHARK_AGENT, else USER env var)")
 	taskApproveCmd.Flags().StringP("notes", "n", "", "Approval notes")
 	taskApproveCmd.Flags().String("rejection-reason", "", "Reason for rejection or feedback on the task")
 	taskApproveCmd.Flags().String("reason-doc", "", "Path to document containing rejection reason (relative to project root)")
@@ -2195,11 +2768,11 @@ func init() {
 
 	// Add flags for exception handling commands
 	taskBlockCmd.Flags().StringP("reason", "r", "", "Reason for blocking (required)")
-	taskBlockCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to USER env var)")
+	taskBlockCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to SHARK_AGENT, else USER env var)")
 	taskBlockCmd.Flags().Bool("force", false, "Force status change bypassing validation (use with caution)")
-	taskUnblockCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to USER env var)")
+	taskUnblockCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to SHARK_AGENT, else USER env var)")
 	taskUnblockCmd.Flags().Bool("force", false, "Force status change bypassing validation (use with caution)")
-	taskReopenCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to USER env var)")
+	taskReopenCmd.Flags().StringP("agent", "", "", "Agent identifier (defaults to SHARK_AGENT, else USER env var)")
 	taskReopenCmd.Flags().StringP("notes", "n", "", "Rework notes")
 	taskReopenCmd.Flags().String("rejection-reason", "", "Reason for rejection or sending task back")
 	taskReopenCmd.Flags().String("reason-doc", "", "Path to document containing rejection reason (relative to project root)")
@@ -2218,19 +2791,25 @@ func init() {
 	taskUpdateCmd.Flags().Bool("force", false, "Force reassignment if file already claimed or bypass workflow validation for status changes")
 	taskUpdateCmd.Flags().String("reason", "", "Reason for backward status transitions (required unless --force is used)")
 	taskUpdateCmd.Flags().String("reason-doc", "", "Path to document containing rejection reason (relative to project root)")
+	taskUpdateCmd.Flags().Int("retry", 0, "Re-read the task and reapply the changes up to this many times if another agent changed it")
+	taskUpdateCmd.Flags().Int("expect-version", 0, "Only update if the task is still at this version (from 'task get --json')")
+	addLabelEditFlags(taskUpdateCmd, true)
+	addEstimateFlags(taskUpdateCmd)
 
 	// Add flags for set-status command
 	taskSetStatusCmd.Flags().Bool("force", false, "Force status change bypassing workflow validation (use with caution)")
 	taskSetStatusCmd.Flags().String("notes", "", "Notes to record with this status transition")
+	taskSetStatusCmd.Flags().Int("retry", 0, "Re-read the task and retry up to this many times if another agent changed it")
+	taskSetStatusCmd.Flags().Int("expect-version", 0, "Only change status if the task is still at this version (from 'task get --json')")
 }
 
 // runTaskUpdate executes the task update command
 func runTaskUpdate(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -2248,79 +2827,87 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 	// Get task by key to verify it exists
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
+	auditBefore := auditFields(task)
 
-	// Track if any changes were made
-	changed := false
-
-	// Update title if provided
-	title, _ := cmd.Flags().GetString("title")
-	if title != "" {
-		task.Title = title
-		changed = true
+	expectVersion, _ := cmd.Flags().GetInt("expect-version")
+	retries, _ := cmd.Flags().GetInt("retry")
+	if expectVersion > 0 && task.Version != expectVersion {
+		exitVersionConflict(taskKey, &repository.VersionConflictError{TaskID: task.ID, Expected: expectVersion, Actual: task.Version})
 	}
 
-	// Update description if provided
-	description, _ := cmd.Flags().GetString("description")
-	if description != "" {
-		task.Description = &description
-		changed = true
+	// Track if any changes were made
+	changed, err := applyTaskUpdateFlags(cmd, task)
+	if err != nil {
+		return err
 	}
 
-	// Update priority if provided
-	priority, _ := cmd.Flags().GetInt("priority")
-	if priority != -1 {
-		task.Priority = priority
-		changed = true
+	// Validate labels before changing anything
+	addLabels, err := labelsFromFlag(cmd, "label")
+	if err != nil {
+		return err
+	}
+	removeLabels, err := labelsFromFlag(cmd, "remove-label")
+	if err != nil {
+		return err
 	}
 
-	// Update agent type if provided
-	agent, _ := cmd.Flags().GetString("agent")
-	if agent != "" {
-		task.AgentType = &agent
-		changed = true
+	// Validate estimate flags against the current estimate
+	estimateRepo := repository.NewTaskEstimateRepository(repoDb)
+	currentEstimate, err := estimateRepo.GetByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	estimate, err := estimateFromFlags(cmd, currentEstimate)
+	if err != nil {
+		return err
 	}
 
-	// Update dependencies if provided
-	dependsOn, _ := cmd.Flags().GetString("depends-on")
-	if dependsOn != "" {
-		// Parse dependencies - split by comma and trim whitespace
-		deps := []string{}
-		for _, part := range strings.Split(dependsOn, ",") {
-			if trimmed := strings.TrimSpace(part); trimmed != "" {
-				deps = append(deps, trimmed)
+	// Apply core field updates if any changed. If another agent changed the
+	// task since it was read, re-read it and reapply the flags (with --retry).
+	if changed {
+		for attempt := 0; ; attempt++ {
+			err := repo.Update(ctx, task)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, repository.ErrVersionConflict) {
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update task: %v", err))
+			}
+			if attempt >= retries || expectVersion > 0 {
+				exitVersionConflict(taskKey, err)
+			}
+
+			if task, err = repo.GetByKey(ctx, taskKey); err != nil {
+				return fmt.Errorf("failed to re-read task: %w", err)
+			}
+			auditBefore = auditFields(task)
+			if _, err := applyTaskUpdateFlags(cmd, task); err != nil {
+				return err
 			}
 		}
-		depsJSON, err := json.Marshal(deps)
-		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to marshal dependencies: %v", err))
-			os.Exit(1)
-		}
-		depsStr := string(depsJSON)
-		task.DependsOn = &depsStr
-		changed = true
 	}
 
-	// Update execution order if provided
-	order, _ := cmd.Flags().GetInt("order")
-	if order != -1 {
-		if order == 0 {
-			// 0 means clear the execution order
-			task.ExecutionOrder = nil
-		} else {
-			task.ExecutionOrder = &order
+	// Update labels if provided
+	if len(addLabels) > 0 || len(removeLabels) > 0 {
+		labelRepo := repository.NewLabelRepository(repoDb)
+		if err := labelRepo.RemoveTaskLabels(ctx, task.ID, removeLabels); err != nil {
+			return err
+		}
+		if err := labelRepo.AddTaskLabels(ctx, task.ID, addLabels); err != nil {
+			return err
 		}
 		changed = true
 	}
 
-	// Apply core field updates if any changed
-	if changed {
-		if err := repo.Update(ctx, task); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update task: %v", err))
-			os.Exit(1)
+	// Update estimate and actual effort if provided
+	if estimate != nil {
+		estimate.TaskID = task.ID
+		if err := estimateRepo.Set(ctx, estimate); err != nil {
+			return err
 		}
+		changed = true
 	}
 
 	// Handle key update separately (requires unique validation)
@@ -2328,22 +2915,19 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 	if newKey != "" {
 		// Validate new key: no spaces allowed
 		if containsSpace(newKey) {
-			cli.Error("Error: Task key cannot contain spaces")
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, "Error: Task key cannot contain spaces")
 		}
 
 		// Check if new key already exists (and is different from current key)
 		if newKey != taskKey {
 			existing, err := repo.GetByKey(ctx, newKey)
 			if err == nil && existing != nil {
-				cli.Error(fmt.Sprintf("Error: Task with key '%s' already exists", newKey))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Task with key '%s' already exists", newKey))
 			}
 
 			// Update the key
 			if err := repo.UpdateKey(ctx, taskKey, newKey); err != nil {
-				cli.Error(fmt.Sprintf("Error: Failed to update task key: %v", err))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update task key: %v", err))
 			}
 			changed = true
 		}
@@ -2367,8 +2951,7 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 
 	if customFile != "" {
 		if err := repo.UpdateFilePath(ctx, taskKey, &customFile); err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update task file path: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to update task file path: %v", err))
 		}
 		changed = true
 	}
@@ -2383,8 +2966,7 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 		}
 		workflow, err := config.LoadWorkflowConfig(configPath)
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to load workflow config: %v", err))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeFailure, fmt.Sprintf("Error: Failed to load workflow config: %v", err))
 		}
 
 		// Get force flag and reason flag
@@ -2397,22 +2979,18 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 		if reasonDocFlag != "" {
 			// Validate document path format
 			if err := ValidateRejectionReasonDocPath(reasonDocFlag); err != nil {
-				cli.Error(fmt.Sprintf("Invalid document path: %s", err.Error()))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Invalid document path: %s", err.Error()))
 			}
 
 			// Check if document file exists
 			projectRoot, err := cli.FindProjectRoot()
 			if err != nil {
-				cli.Error(fmt.Sprintf("Failed to find project root: %s", err.Error()))
-				os.Exit(2)
+				cli.Fail(cli.ErrCodeDatabase, fmt.Sprintf("Failed to find project root: %s", err.Error()))
 			}
 
 			fullPath := filepath.Join(projectRoot, reasonDocFlag)
 			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-				cli.Error(fmt.Sprintf("Document not found: %s", reasonDocFlag))
-				cli.Info(fmt.Sprintf("Looked for file at: %s", fullPath))
-				os.Exit(1)
+				cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Document not found: %s", reasonDocFlag), fmt.Sprintf("Looked for file at: %s", fullPath))
 			}
 
 			// Convert to pointer for passing to repository
@@ -2421,10 +2999,7 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 
 		// Validate that backward transitions have a reason (unless --force is used)
 		if err := validation.ValidateReasonForStatusTransition(status, string(task.Status), reason, force, workflow); err != nil {
-			cli.Error(fmt.Sprintf("Error: %s", err.Error()))
-			cli.Info("Use --reason to provide a reason, or use --force to bypass this requirement")
-			cli.Info(fmt.Sprintf("Example: shark task update %s --status %s --reason \"Reason for transition\"", taskKey, status))
-			os.Exit(3) // Exit code 3 for invalid state
+			cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Error: %s", err.Error()), "Use --reason to provide a reason, or use --force to bypass this requirement", fmt.Sprintf("Example: shark task update %s --status %s --reason \"Reason for transition\"", taskKey, status)) // Exit code 3 for invalid state
 		}
 
 		// Create repository with workflow support
@@ -2447,21 +3022,29 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 
 		// Update status with workflow validation (unless forcing)
 		err = workflowRepo.UpdateStatusForced(ctx, task.ID, newStatus, nil, nil, rejectionReasonPtr, documentPath, force)
+		for attempt := 0; errors.Is(err, repository.ErrVersionConflict); attempt++ {
+			if attempt >= retries || expectVersion > 0 {
+				exitVersionConflict(taskKey, err)
+			}
+			err = workflowRepo.UpdateStatusForced(ctx, task.ID, newStatus, nil, nil, rejectionReasonPtr, documentPath, force)
+		}
 		if err != nil {
-			cli.Error(fmt.Sprintf("Error: Failed to update task status: %s", err.Error()))
-
 			// If this is a validation error, suggest using --force
+			var hints []string
 			if !force && (strings.Contains(err.Error(), "invalid status transition") || strings.Contains(err.Error(), "transition")) {
-				cli.Info("Use --force to bypass workflow validation")
+				hints = append(hints, "Use --force to bypass workflow validation")
 			}
 
-			os.Exit(3) // Exit code 3 for invalid state
+			cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Error: Failed to update task status: %s", err.Error()), hints...)
 		}
 
 		// Display warning if force was used
 		if force && !cli.GlobalConfig.JSON {
 			cli.Warning(fmt.Sprintf("⚠️  Forced transition from %s to %s (bypassed workflow validation)", task.Status, newStatus))
 		}
+		if force {
+			recordForcedTransition(ctx, repoDb, task, newStatus, "")
+		}
 
 		changed = true
 	}
@@ -2471,18 +3054,101 @@ func runTaskUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if updated, err := repo.GetByID(ctx, task.ID); err == nil {
+		recordAuditUpdate(ctx, repoDb, models.AuditEntityTask, updated.Key, auditBefore, updated)
+	}
+
 	cli.Success(fmt.Sprintf("Task %s updated successfully", taskKey))
 	return nil
 }
 
+// applyTaskUpdateFlags applies the field flags of 'task update' to task and
+// reports whether anything changed
+func applyTaskUpdateFlags(cmd *cobra.Command, task *models.Task) (bool, error) {
+	changed := false
+
+	// Update title if provided
+	title, _ := cmd.Flags().GetString("title")
+	if title != "" {
+		task.Title = title
+		changed = true
+	}
+
+	// Update description if provided
+	description, _ := cmd.Flags().GetString("description")
+	if description != "" {
+		task.Description = &description
+		changed = true
+	}
+
+	// Update priority if provided
+	priority, _ := cmd.Flags().GetInt("priority")
+	if priority != -1 {
+		task.Priority = priority
+		changed = true
+	}
+
+	// Update agent type if provided
+	agent, _ := cmd.Flags().GetString("agent")
+	if agent != "" {
+		task.AgentType = &agent
+		changed = true
+	}
+
+	// Update dependencies if provided
+	dependsOn, _ := cmd.Flags().GetString("depends-on")
+	if dependsOn != "" {
+		// Parse dependencies - split by comma and trim whitespace
+		deps := []string{}
+		for _, part := range strings.Split(dependsOn, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				deps = append(deps, trimmed)
+			}
+		}
+		depsJSON, err := json.Marshal(deps)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal dependencies: %w", err)
+		}
+		depsStr := string(depsJSON)
+		task.DependsOn = &depsStr
+		changed = true
+	}
+
+	// Update execution order if provided
+	order, _ := cmd.Flags().GetInt("order")
+	if order != -1 {
+		if order == 0 {
+			// 0 means clear the execution order
+			task.ExecutionOrder = nil
+		} else {
+			task.ExecutionOrder = &order
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// exitVersionConflict reports that another agent changed a task while a
+// command was updating it, and exits with code 4 so orchestrators can tell
+// races apart from other failures
+func exitVersionConflict(taskKey string, err error) {
+	message := fmt.Sprintf("Error: task %s was changed by another agent", taskKey)
+	var conflict *repository.VersionConflictError
+	if errors.As(err, &conflict) {
+		message = fmt.Sprintf("Error: task %s was changed by another agent (expected version %d, found %d)", taskKey, conflict.Expected, conflict.Actual)
+	}
+	cli.Fail(cli.ErrCodeConflict, message, fmt.Sprintf("Run 'shark task get %s' to see the latest version, then try again", taskKey))
+}
+
 // runTaskSetStatus executes the set-status command
 func runTaskSetStatus(cmd *cobra.Command, args []string) error {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	// Normalize task key to support short format (E##-F##-###) and case insensitivity
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -2491,6 +3157,8 @@ func runTaskSetStatus(cmd *cobra.Command, args []string) error {
 	// Get flags
 	force, _ := cmd.Flags().GetBool("force")
 	notes, _ := cmd.Flags().GetString("notes")
+	retries, _ := cmd.Flags().GetInt("retry")
+	expectVersion, _ := cmd.Flags().GetInt("expect-version")
 
 	// Get database connection
 	repoDb, err := cli.GetDB(cmd.Context())
@@ -2523,8 +3191,7 @@ func runTaskSetStatus(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task not found: %s", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task not found: %s", taskKey))
 	}
 
 	// Convert status string to TaskStatus
@@ -2536,24 +3203,39 @@ func runTaskSetStatus(cmd *cobra.Command, args []string) error {
 		notesPtr = &notes
 	}
 
-	// Update status with workflow validation (unless forcing)
-	err = repo.UpdateStatusForced(ctx, task.ID, taskStatus, nil, notesPtr, nil, nil, force)
+	// Update status with workflow validation (unless forcing). The write fails
+	// if the task changed since it was read; with --retry, re-read and try again.
+	version := task.Version
+	if expectVersion > 0 {
+		version = expectVersion
+	}
+	err = repo.UpdateStatusIfVersion(ctx, task.ID, version, taskStatus, nil, notesPtr, nil, nil, force)
+	for attempt := 0; errors.Is(err, repository.ErrVersionConflict); attempt++ {
+		if attempt >= retries || expectVersion > 0 {
+			exitVersionConflict(taskKey, err)
+		}
+		if task, err = repo.GetByKey(ctx, taskKey); err != nil {
+			return fmt.Errorf("failed to re-read task: %w", err)
+		}
+		err = repo.UpdateStatusIfVersion(ctx, task.ID, task.Version, taskStatus, nil, notesPtr, nil, nil, force)
+	}
 	if err != nil {
-		// Extract validation error message if available
-		cli.Error(fmt.Sprintf("Failed to update task status: %s", err.Error()))
-
 		// If this is a validation error, suggest using --force
+		var hints []string
 		if !force && (strings.Contains(err.Error(), "invalid status transition") || strings.Contains(err.Error(), "transition")) {
-			cli.Info("Use --force to bypass workflow validation")
+			hints = append(hints, "Use --force to bypass workflow validation")
 		}
 
-		os.Exit(3) // Exit code 3 for invalid state
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to update task status: %s", err.Error()), hints...)
 	}
 
 	// Display warning if force was used
 	if force && !cli.GlobalConfig.JSON {
 		cli.Warning(fmt.Sprintf("⚠️  Forced transition from %s to %s (bypassed workflow validation)", task.Status, newStatus))
 	}
+	if force {
+		recordForcedTransition(ctx, repoDb, task, taskStatus, "")
+	}
 
 	// Output result
 	if cli.GlobalConfig.JSON {
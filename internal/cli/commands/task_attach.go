@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskAttachCmd attaches files to a task
+var taskAttachCmd = &cobra.Command{
+	Use:         "attach <task-key> [--file=<path>]...",
+	Short:       "Attach files such as screenshots or logs to a task",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true", cli.WritingFlagsAnnotation: "file"},
+	Long: `Copy files into the task's attachments directory and record them on the task.
+
+Attachments are stored next to the feature, in
+docs/plan/<epic>/<feature>/attachments/<task-key>/, and recorded with their size
+and SHA-256 checksum. A file with the same name as an existing attachment is
+saved under a numbered name instead of replacing it. 'shark task get' lists a
+task's attachments.
+
+With no --file the task's attachments are listed.
+
+Examples:
+  shark task attach E07-F01-001 --file=error.log
+  shark task attach E07-F01-001 --file=before.png --file=after.png --note="Button misaligned"
+  shark task attach E07-F01-001 --file=junit.xml --agent=qa-agent
+  shark task attach E07-F01-001 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskAttach,
+}
+
+func init() {
+	taskCmd.AddCommand(taskAttachCmd)
+
+	taskAttachCmd.Flags().StringArray("file", nil, "File to attach (repeatable)")
+	taskAttachCmd.Flags().String("agent", "", "Agent or person attaching the files")
+	taskAttachCmd.Flags().String("note", "", "Short note describing the attachments")
+}
+
+// taskAttachDir is the directory, relative to the feature directory, that holds
+// attachments; each task gets its own subdirectory
+const taskAttachDir = "attachments"
+
+// runTaskAttach handles the task attach command
+func runTaskAttach(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task key: %w", err)
+	}
+
+	files, _ := cmd.Flags().GetStringArray("file")
+	agent, _ := cmd.Flags().GetString("agent")
+	note, _ := cmd.Flags().GetString("note")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	task, err := taskRepo.GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found", taskKey)
+	}
+
+	attachmentRepo := repository.NewTaskAttachmentRepository(repoDb)
+
+	if len(files) == 0 {
+		attachments, err := attachmentRepo.ListByTaskID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{
+				"task_key":    task.Key,
+				"attachments": attachments,
+			})
+		}
+		printTaskAttachments(attachments)
+		return nil
+	}
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	// Attachments live beside the feature's documents, whatever its layout
+	featureRepo := repository.NewFeatureRepository(repoDb)
+	feature, err := featureRepo.GetByID(ctx, task.FeatureID)
+	if err != nil {
+		return fmt.Errorf("failed to get feature of %s: %w", task.Key, err)
+	}
+	var featurePath string
+	if feature.FilePath != nil && filepath.IsAbs(*feature.FilePath) {
+		// Features created by path store it absolute
+		featurePath = *feature.FilePath
+	} else {
+		resolver := pathresolver.NewPathResolver(repository.NewEpicRepository(repoDb), featureRepo, taskRepo, projectRoot)
+		featurePath, err = resolver.ResolveFeaturePath(ctx, feature.Key)
+		if err != nil {
+			return fmt.Errorf("failed to resolve feature directory for %s: %w", task.Key, err)
+		}
+	}
+	destDir := filepath.Join(filepath.Dir(featurePath), taskAttachDir, task.Key)
+
+	attachments := make([]*models.TaskAttachment, 0, len(files))
+	for _, file := range files {
+		attachment, err := copyAttachment(file, destDir, projectRoot)
+		if err != nil {
+			return err
+		}
+		attachment.TaskID = task.ID
+		if agent != "" {
+			attachment.AttachedBy = &agent
+		}
+		if note != "" {
+			attachment.Note = &note
+		}
+		if err := attachmentRepo.Create(ctx, attachment); err != nil {
+			return err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"task_key":    task.Key,
+			"attachments": attachments,
+		})
+	}
+
+	for _, attachment := range attachments {
+		cli.Success(fmt.Sprintf("Attached %s to %s (%s)", attachment.FilePath, task.Key, formatBytes(attachment.SizeBytes)))
+	}
+	return nil
+}
+
+// copyAttachment copies src into destDir and returns the attachment record
+// without its task. An existing file of the same name is never overwritten:
+// the copy is saved as name-2.ext, name-3.ext, and so on. FilePath is relative
+// to projectRoot.
+func copyAttachment(src, destDir, projectRoot string) (*models.TaskAttachment, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory; only files can be attached", src)
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	name := filepath.Base(src)
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	var out *os.File
+	for n := 1; ; n++ {
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d%s", stem, n, ext)
+		}
+		out, err = os.OpenFile(filepath.Join(destDir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create attachment %s: %w", name, err)
+		}
+	}
+	destPath := out.Name()
+
+	// Sniff the content type from the first bytes when the extension is unknown
+	head := make([]byte, 512)
+	headLen, err := io.ReadFull(in, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		out.Close()
+		os.Remove(destPath)
+		return nil, fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	head = head[:headLen]
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), io.MultiReader(bytes.NewReader(head), in))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(destPath)
+		return nil, fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	relPath, err := filepath.Rel(projectRoot, destPath)
+	if err != nil {
+		relPath = destPath
+	}
+
+	return &models.TaskAttachment{
+		Filename:    name,
+		FilePath:    filepath.ToSlash(relPath),
+		SizeBytes:   size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		ContentType: &contentType,
+	}, nil
+}
+
+// printTaskAttachments prints a task's attachments as a table
+func printTaskAttachments(attachments []*models.TaskAttachment) {
+	if len(attachments) == 0 {
+		fmt.Println("No attachments")
+		return
+	}
+	headers := []string{"ID", "File", "Size", "SHA-256", "Attached"}
+	rows := make([][]string, len(attachments))
+	for i, attachment := range attachments {
+		rows[i] = []string{
+			strconv.FormatInt(attachment.ID, 10),
+			attachment.FilePath,
+			formatBytes(attachment.SizeBytes),
+			shortChecksum(attachment.SHA256),
+			attachment.CreatedAt.Local().Format("2006-01-02 15:04"),
+		}
+	}
+	cli.OutputTable(headers, rows)
+}
+
+// shortChecksum abbreviates a checksum for table output
+func shortChecksum(sum string) string {
+	if len(sum) > 12 {
+		return sum[:12]
+	}
+	return sum
+}
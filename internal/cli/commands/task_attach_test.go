@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyAttachment(t *testing.T) {
+	projectRoot := t.TempDir()
+	src := filepath.Join(t.TempDir(), "error.log")
+	require.NoError(t, os.WriteFile(src, []byte("panic: boom\n"), 0644))
+	destDir := filepath.Join(projectRoot, "docs", "plan", "E07-auth", "E07-F01-login", taskAttachDir, "T-E07-F01-001")
+
+	first, err := copyAttachment(src, destDir, projectRoot)
+	require.NoError(t, err)
+	assert.Equal(t, "error.log", first.Filename)
+	assert.Equal(t, "docs/plan/E07-auth/E07-F01-login/attachments/T-E07-F01-001/error.log", first.FilePath)
+	assert.Equal(t, int64(12), first.SizeBytes)
+	assert.Equal(t, "b1df42db35d65a0bc7fdfecd4d705985c028336e5517baaaf5cfcd8b1c143b7f", first.SHA256)
+	assert.NotEmpty(t, *first.ContentType)
+
+	copied, err := os.ReadFile(filepath.Join(projectRoot, first.FilePath))
+	require.NoError(t, err)
+	assert.Equal(t, "panic: boom\n", string(copied))
+
+	// A second attachment with the same name doesn't replace the first
+	second, err := copyAttachment(src, destDir, projectRoot)
+	require.NoError(t, err)
+	assert.Equal(t, "error-2.log", second.Filename)
+	assert.Equal(t, first.SHA256, second.SHA256)
+
+	_, err = copyAttachment(filepath.Dir(src), destDir, projectRoot)
+	assert.ErrorContains(t, err, "is a directory")
+	_, err = copyAttachment(filepath.Join(projectRoot, "missing.png"), destDir, projectRoot)
+	assert.Error(t, err)
+}
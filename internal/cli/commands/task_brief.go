@@ -0,0 +1,317 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskBriefCmd assembles everything an agent needs to work on a task
+var taskBriefCmd = &cobra.Command{
+	Use:         "brief <task-key>",
+	Short:       "Assemble a task's full working context for an agent",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Assemble the full working context for a task in one document, so an agent
+doesn't have to run get, criteria, deps, notes, and doc commands separately:
+
+  - The task's details and the markdown content of its file
+  - Acceptance criteria and their verification status
+  - The parent feature's and epic's descriptions
+  - Each dependency's title, status, and completion notes
+  - Earlier rejections with their reasons
+  - Documents linked to the task, its feature, and its epic
+
+The brief is Markdown, ready to paste into a prompt. Use --json for the same
+content as structured data.
+
+Examples:
+  shark task brief T-E05-F01-003
+  shark task brief e05-f01-003 > brief.md
+  shark task brief T-E05-F01-003 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskBrief,
+}
+
+func init() {
+	taskCmd.AddCommand(taskBriefCmd)
+}
+
+// TaskBrief is the working context assembled by shark task brief
+type TaskBrief struct {
+	Task         *models.Task           `json:"task"`
+	Content      string                 `json:"content,omitempty"` // Task file markdown, without frontmatter
+	Feature      *BriefParent           `json:"feature"`
+	Epic         *BriefParent           `json:"epic"`
+	Criteria     []*models.TaskCriteria `json:"criteria"`
+	Dependencies []*BriefDependency     `json:"dependencies"`
+	Rejections   []*BriefRejection      `json:"rejections"`
+	Documents    []*BriefDocument       `json:"documents"`
+}
+
+// BriefParent is the task's feature or epic in a brief
+type BriefParent struct {
+	Key         string `json:"key"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Description string `json:"description,omitempty"`
+}
+
+// BriefDependency summarizes a task the briefed task depends on
+type BriefDependency struct {
+	Key             string `json:"key"`
+	Title           string `json:"title,omitempty"`
+	Status          string `json:"status,omitempty"` // Empty if the dependency doesn't exist
+	CompletionNotes string `json:"completion_notes,omitempty"`
+}
+
+// BriefRejection is an earlier rejection of the task
+type BriefRejection struct {
+	RejectedAt time.Time `json:"rejected_at"`
+	RejectedBy string    `json:"rejected_by,omitempty"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+}
+
+// BriefDocument is a document linked to the task or one of its parents
+type BriefDocument struct {
+	Title    string `json:"title"`
+	FilePath string `json:"file_path"`
+	LinkedTo string `json:"linked_to"` // Key of the task, feature, or epic
+}
+
+// runTaskBrief handles the task brief command
+func runTaskBrief(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	task, err := repository.NewTaskRepository(repoDb).GetByKey(ctx, taskKey)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("task %s not found", taskKey)).WithHint("Use 'shark task list' to see available tasks")
+	}
+
+	projectRoot, _ := cli.FindProjectRoot()
+	brief, err := buildTaskBrief(ctx, repoDb, projectRoot, task)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(brief)
+	}
+	fmt.Print(formatTaskBrief(brief))
+	return nil
+}
+
+// buildTaskBrief gathers a task's working context. A missing task file is
+// left out rather than failing the brief.
+func buildTaskBrief(ctx context.Context, repoDb *repository.DB, projectRoot string, task *models.Task) (*TaskBrief, error) {
+	taskRepo := repository.NewTaskRepository(repoDb)
+	docRepo := repository.NewDocumentRepository(repoDb)
+
+	feature, err := repository.NewFeatureRepository(repoDb).GetByID(ctx, task.FeatureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature of %s: %w", task.Key, err)
+	}
+	epic, err := repository.NewEpicRepository(repoDb).GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epic of %s: %w", task.Key, err)
+	}
+
+	brief := &TaskBrief{
+		Task:         task,
+		Feature:      &BriefParent{Key: feature.Key, Title: feature.Title, Status: string(feature.Status), Description: derefString(feature.Description)},
+		Epic:         &BriefParent{Key: epic.Key, Title: epic.Title, Status: string(epic.Status), Description: derefString(epic.Description)},
+		Dependencies: []*BriefDependency{},
+		Rejections:   []*BriefRejection{},
+		Documents:    []*BriefDocument{},
+	}
+
+	if task.FilePath != nil && *task.FilePath != "" && projectRoot != "" {
+		if data, err := os.ReadFile(resolveProjectPath(projectRoot, *task.FilePath)); err == nil {
+			content := string(data)
+			if fm, err := parser.ParseFrontmatter(content); err == nil {
+				content = parser.GetContentAfterFrontmatter(content, fm)
+			}
+			brief.Content = strings.TrimSpace(content)
+		}
+	}
+
+	if brief.Criteria, err = repository.NewTaskCriteriaRepository(repoDb).GetByTaskID(ctx, task.ID); err != nil {
+		return nil, fmt.Errorf("failed to get criteria: %w", err)
+	}
+	if brief.Criteria == nil {
+		brief.Criteria = []*models.TaskCriteria{}
+	}
+
+	if task.DependsOn != nil && *task.DependsOn != "" {
+		var deps []string
+		if err := json.Unmarshal([]byte(*task.DependsOn), &deps); err == nil {
+			for _, depKey := range deps {
+				dep := &BriefDependency{Key: depKey}
+				if depTask, err := taskRepo.GetByKey(ctx, depKey); err == nil {
+					dep.Title = depTask.Title
+					dep.Status = string(depTask.Status)
+					dep.CompletionNotes = derefString(depTask.CompletionNotes)
+				}
+				brief.Dependencies = append(brief.Dependencies, dep)
+			}
+		}
+	}
+
+	rejections, err := repository.NewTaskHistoryRepository(repoDb).GetRejectionHistoryForTask(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rejection history: %w", err)
+	}
+	for _, record := range rejections {
+		brief.Rejections = append(brief.Rejections, &BriefRejection{
+			RejectedAt: record.Timestamp,
+			RejectedBy: derefString(record.Agent),
+			FromStatus: derefString(record.OldStatus),
+			ToStatus:   record.NewStatus,
+			Reason:     derefString(record.RejectionReason),
+		})
+	}
+
+	linked := []struct {
+		key  string
+		list func() ([]*models.Document, error)
+	}{
+		{task.Key, func() ([]*models.Document, error) { return docRepo.ListForTask(ctx, task.ID) }},
+		{feature.Key, func() ([]*models.Document, error) { return docRepo.ListForFeature(ctx, feature.ID) }},
+		{epic.Key, func() ([]*models.Document, error) { return docRepo.ListForEpic(ctx, epic.ID) }},
+	}
+	for _, l := range linked {
+		docs, err := l.list()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents for %s: %w", l.key, err)
+		}
+		for _, doc := range docs {
+			brief.Documents = append(brief.Documents, &BriefDocument{Title: doc.Title, FilePath: doc.FilePath, LinkedTo: l.key})
+		}
+	}
+
+	return brief, nil
+}
+
+// formatTaskBrief renders a brief as Markdown
+func formatTaskBrief(brief *TaskBrief) string {
+	var sb strings.Builder
+	task := brief.Task
+
+	fmt.Fprintf(&sb, "# %s: %s\n\n", task.Key, task.Title)
+	fmt.Fprintf(&sb, "- **Status:** %s\n", task.Status)
+	fmt.Fprintf(&sb, "- **Priority:** %d\n", task.Priority)
+	if task.AgentType != nil && *task.AgentType != "" {
+		fmt.Fprintf(&sb, "- **Agent type:** %s\n", *task.AgentType)
+	}
+	fmt.Fprintf(&sb, "- **Feature:** %s %s\n", brief.Feature.Key, brief.Feature.Title)
+	fmt.Fprintf(&sb, "- **Epic:** %s %s\n", brief.Epic.Key, brief.Epic.Title)
+	if task.FilePath != nil && *task.FilePath != "" {
+		fmt.Fprintf(&sb, "- **File:** %s\n", *task.FilePath)
+	}
+
+	sb.WriteString("\n## Task\n\n")
+	switch {
+	case brief.Content != "":
+		sb.WriteString(brief.Content + "\n")
+	case task.Description != nil && *task.Description != "":
+		sb.WriteString(strings.TrimSpace(*task.Description) + "\n")
+	default:
+		sb.WriteString("_No description._\n")
+	}
+
+	if len(brief.Criteria) > 0 {
+		sb.WriteString("\n## Acceptance Criteria\n\n")
+		for _, c := range brief.Criteria {
+			check := " "
+			if c.Status == models.CriteriaStatusComplete {
+				check = "x"
+			}
+			fmt.Fprintf(&sb, "- [%s] %s", check, c.Criterion)
+			if c.Status != models.CriteriaStatusComplete && c.Status != models.CriteriaStatusPending {
+				fmt.Fprintf(&sb, " _(%s)_", c.Status)
+			}
+			if c.VerificationNotes != nil && *c.VerificationNotes != "" {
+				fmt.Fprintf(&sb, ": %s", *c.VerificationNotes)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	for _, parent := range []struct {
+		kind   string
+		parent *BriefParent
+	}{{"Feature", brief.Feature}, {"Epic", brief.Epic}} {
+		if parent.parent.Description == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s: %s %s\n\n%s\n", parent.kind, parent.parent.Key, parent.parent.Title, strings.TrimSpace(parent.parent.Description))
+	}
+
+	if len(brief.Dependencies) > 0 {
+		sb.WriteString("\n## Dependencies\n\n")
+		for _, dep := range brief.Dependencies {
+			if dep.Status == "" {
+				fmt.Fprintf(&sb, "- **%s** (not found)\n", dep.Key)
+				continue
+			}
+			fmt.Fprintf(&sb, "- **%s** %s (%s)", dep.Key, dep.Title, dep.Status)
+			if dep.CompletionNotes != "" {
+				fmt.Fprintf(&sb, ": %s", strings.Join(strings.Fields(dep.CompletionNotes), " "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(brief.Rejections) > 0 {
+		sb.WriteString("\n## Previous Rejections\n\n")
+		for _, r := range brief.Rejections {
+			fmt.Fprintf(&sb, "- %s", r.RejectedAt.Local().Format("2006-01-02 15:04"))
+			if r.RejectedBy != "" {
+				fmt.Fprintf(&sb, " by %s", r.RejectedBy)
+			}
+			if r.FromStatus != "" {
+				fmt.Fprintf(&sb, " (%s → %s)", r.FromStatus, r.ToStatus)
+			}
+			fmt.Fprintf(&sb, ": %s\n", r.Reason)
+		}
+	}
+
+	if len(brief.Documents) > 0 {
+		sb.WriteString("\n## Documents\n\n")
+		for _, doc := range brief.Documents {
+			fmt.Fprintf(&sb, "- %s: %s (linked to %s)\n", doc.Title, doc.FilePath, doc.LinkedTo)
+		}
+	}
+
+	return sb.String()
+}
+
+// derefString returns the value of s, or "" if it is nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
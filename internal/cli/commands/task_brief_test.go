@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTaskBrief(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	ctx := context.Background()
+	strPtr := func(s string) *string { return &s }
+
+	epic := &models.Epic{Key: "E05", Title: "Payments", Description: strPtr("Take card payments."), Status: models.EpicStatusActive, Priority: models.PriorityHigh}
+	require.NoError(t, repository.NewEpicRepository(database).Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E05-F01", Title: "Checkout", Description: strPtr("One-page checkout."), Status: models.FeatureStatusActive}
+	require.NoError(t, repository.NewFeatureRepository(database).Create(ctx, feature))
+
+	taskRepo := repository.NewTaskRepository(database)
+	dep := &models.Task{FeatureID: feature.ID, Key: "T-E05-F01-001", Title: "Cart API", Status: models.TaskStatusCompleted, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, dep))
+	_, err := database.ExecContext(ctx, "UPDATE tasks SET completion_notes = ? WHERE id = ?", "Added\nPOST /cart", dep.ID)
+	require.NoError(t, err)
+
+	projectRoot := t.TempDir()
+	taskFile := "docs/T-E05-F01-002.md"
+	require.NoError(t, os.MkdirAll(filepath.Join(projectRoot, "docs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectRoot, taskFile), []byte("---\ntask_key: T-E05-F01-002\n---\n\n# Build the checkout form\n"), 0644))
+
+	task := &models.Task{
+		FeatureID: feature.ID,
+		Key:       "T-E05-F01-002",
+		Title:     "Checkout form",
+		Status:    models.TaskStatusInProgress,
+		Priority:  3,
+		AgentType: strPtr("frontend"),
+		DependsOn: strPtr(`["T-E05-F01-001"]`),
+		FilePath:  &taskFile,
+	}
+	require.NoError(t, taskRepo.Create(ctx, task))
+
+	require.NoError(t, repository.NewTaskCriteriaRepository(database).Create(ctx, &models.TaskCriteria{TaskID: task.ID, Criterion: "Validates card number", Status: models.CriteriaStatusComplete}))
+	require.NoError(t, repository.NewTaskHistoryRepository(database).Create(ctx, &models.TaskHistory{
+		TaskID:          task.ID,
+		OldStatus:       strPtr("ready_for_review"),
+		NewStatus:       "in_progress",
+		Agent:           strPtr("reviewer"),
+		RejectionReason: strPtr("Missing error states"),
+	}))
+
+	docRepo := repository.NewDocumentRepository(database)
+	doc, err := docRepo.CreateOrGet(ctx, "Payment API", "docs/payment-api.md")
+	require.NoError(t, err)
+	require.NoError(t, docRepo.LinkToTask(ctx, task.ID, doc.ID))
+
+	brief, err := buildTaskBrief(ctx, database, projectRoot, task)
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Build the checkout form", brief.Content)
+	assert.Equal(t, "One-page checkout.", brief.Feature.Description)
+	assert.Equal(t, "Take card payments.", brief.Epic.Description)
+	assert.Len(t, brief.Criteria, 1)
+	require.Len(t, brief.Dependencies, 1)
+	assert.Equal(t, "completed", brief.Dependencies[0].Status)
+	require.Len(t, brief.Rejections, 1)
+	assert.Equal(t, "Missing error states", brief.Rejections[0].Reason)
+	require.Len(t, brief.Documents, 1)
+	assert.Equal(t, "T-E05-F01-002", brief.Documents[0].LinkedTo)
+
+	markdown := formatTaskBrief(brief)
+	assert.Contains(t, markdown, "# T-E05-F01-002: Checkout form\n")
+	assert.Contains(t, markdown, "## Task\n\n# Build the checkout form\n")
+	assert.Contains(t, markdown, "- [x] Validates card number\n")
+	assert.Contains(t, markdown, "## Feature: E05-F01 Checkout\n\nOne-page checkout.\n")
+	assert.Contains(t, markdown, "- **T-E05-F01-001** Cart API (completed): Added POST /cart\n")
+	assert.Contains(t, markdown, "by reviewer (ready_for_review → in_progress): Missing error states\n")
+	assert.Contains(t, markdown, "- Payment API: docs/payment-api.md (linked to T-E05-F01-002)\n")
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskCheckCmd manages the working checklist of a task
+var taskCheckCmd = &cobra.Command{
+	Use:   "check <task-key>",
+	Short: "Manage a task's working checklist",
+	Long: `Add, tick off, and list checklist items on a task.
+
+Checklist items are informal working steps ("write tests", "update docs").
+They are separate from acceptance criria ('shark task criteria'), but both
+count toward the readiness shown by 'shark task get'.
+
+With no flags the checklist is listed.
+
+Examples:
+  shark task check E07-F01-001 --add "write tests"
+  shark task check E07-F01-001 --done 3
+  shark task check E07-F01-001 --undo 3
+  shark task check E07-F01-001 --remove 3
+  shark task check E07-F01-001 --list --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskCheck,
+}
+
+func init() {
+	taskCmd.AddCommand(taskCheckCmd)
+
+	taskCheckCmd.Flags().String("add", "", "Add a checklist item with this text")
+	taskCheckCmd.Flags().Int64("done", 0, "Mark the checklist item with this ID as done")
+	taskCheckCmd.Flags().Int64("undo", 0, "Mark the checklist item with this ID as not done")
+	taskCheckCmd.Flags().Int64("remove", 0, "Remove the checklist item with this ID")
+	taskCheckCmd.Flags().Bool("list", false, "List checklist items (default when no other flag is given)")
+	taskCheckCmd.MarkFlagsMutuallyExclusive("add", "done", "undo", "remove")
+}
+
+// TaskReadiness combines acceptance criteria and checklist progress into a
+// single indicator of how close a task is to being ready for completion
+type TaskReadiness struct {
+	Ready             bool    `json:"ready"`
+	ReadinessPct      float64 `json:"readiness_pct"`
+	CriteriaTotal     int     `json:"criteria_total"`
+	CriteriaComplete  int     `json:"criteria_complete"`
+	ChecklistTotal    int     `json:"checklist_total"`
+	ChecklistComplete int     `json:"checklist_complete"`
+}
+
+// computeTaskReadiness builds the readiness indicator. Criteria marked "na"
+// count as complete; a task with no criteria and no checklist is ready.
+func computeTaskReadiness(criteria *repository.CriteriaSummary, checklist *repository.ChecklistSummary) *TaskReadiness {
+	r := &TaskReadiness{}
+	if criteria != nil {
+		r.CriteriaTotal = criteria.TotalCount
+		r.CriteriaComplete = criteria.CompleteCount + criteria.NACount
+	}
+	if checklist != nil {
+		r.ChecklistTotal = checklist.Total
+		r.ChecklistComplete = checklist.Done
+	}
+
+	total := r.CriteriaTotal + r.ChecklistTotal
+	complete := r.CriteriaComplete + r.ChecklistComplete
+	r.Ready = complete == total
+	r.ReadinessPct = 100.0
+	if total > 0 {
+		r.ReadinessPct = float64(complete) / float64(total) * 100.0
+	}
+	return r
+}
+
+// loadTaskReadiness reads the criteria and checklist summaries of a task
+func loadTaskReadiness(ctx context.Context, repoDb *repository.DB, taskID int64) (*TaskReadiness, error) {
+	criteria, err := repository.NewTaskCriteriaRepository(repoDb).GetSummaryByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	checklist, err := repository.NewTaskChecklistRepository(repoDb).GetSummaryByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return computeTaskReadiness(criteria, checklist), nil
+}
+
+// runTaskCheck handles the task check command
+func runTaskCheck(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task key: %w", err)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	task, err := repository.NewTaskRepository(repoDb).GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found", taskKey)
+	}
+
+	checklistRepo := repository.NewTaskChecklistRepository(repoDb)
+
+	addText, _ := cmd.Flags().GetString("add")
+	doneID, _ := cmd.Flags().GetInt64("done")
+	undoID, _ := cmd.Flags().GetInt64("undo")
+	removeID, _ := cmd.Flags().GetInt64("remove")
+
+	var message string
+	switch {
+	case cmd.Flags().Changed("add"):
+		item := &models.TaskChecklistItem{TaskID: task.ID, Content: addText}
+		if err := checklistRepo.Create(ctx, item); err != nil {
+			return err
+		}
+		message = fmt.Sprintf("Added checklist item %d to %s", item.ID, task.Key)
+	case cmd.Flags().Changed("done"):
+		if err := checklistRepo.SetDone(ctx, task.ID, doneID, true); err != nil {
+			return err
+		}
+		message = fmt.Sprintf("Checked off item %d on %s", doneID, task.Key)
+	case cmd.Flags().Changed("undo"):
+		if err := checklistRepo.SetDone(ctx, task.ID, undoID, false); err != nil {
+			return err
+		}
+		message = fmt.Sprintf("Unchecked item %d on %s", undoID, task.Key)
+	case cmd.Flags().Changed("remove"):
+		if err := checklistRepo.Delete(ctx, task.ID, removeID); err != nil {
+			return err
+		}
+		message = fmt.Sprintf("Removed checklist item %d from %s", removeID, task.Key)
+	}
+
+	items, err := checklistRepo.ListByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	readiness, err := loadTaskReadiness(ctx, repoDb, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"task_key":  task.Key,
+			"checklist": items,
+			"readiness": readiness,
+		})
+	}
+
+	if message != "" {
+		cli.Success(message)
+	}
+	printTaskChecklist(items, readiness)
+	return nil
+}
+
+// printTaskChecklist prints checklist items and the readiness line
+func printTaskChecklist(items []*models.TaskChecklistItem, readiness *TaskReadiness) {
+	if len(items) == 0 {
+		fmt.Println("No checklist items")
+	} else {
+		headers := []string{"ID", "Done", "Item"}
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			mark := " "
+			if item.Done {
+				mark = "✓"
+			}
+			rows[i] = []string{strconv.FormatInt(item.ID, 10), mark, item.Content}
+		}
+		cli.OutputTable(headers, rows)
+	}
+	fmt.Println(formatTaskReadiness(readiness))
+}
+
+// formatTaskReadiness renders the readiness indicator as a single line
+func formatTaskReadiness(r *TaskReadiness) string {
+	state := "not ready"
+	if r.Ready {
+		state = "ready"
+	}
+	return fmt.Sprintf("Readiness: %.0f%% %s (criteria %d/%d, checklist %d/%d)",
+		r.ReadinessPct, state, r.CriteriaComplete, r.CriteriaTotal, r.ChecklistComplete, r.ChecklistTotal)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTaskReadiness(t *testing.T) {
+	t.Run("no criteria or checklist is ready", func(t *testing.T) {
+		r := computeTaskReadiness(&repository.CriteriaSummary{}, &repository.ChecklistSummary{})
+		assert.True(t, r.Ready)
+		assert.Equal(t, 100.0, r.ReadinessPct)
+	})
+
+	t.Run("counts criteria and checklist together", func(t *testing.T) {
+		r := computeTaskReadiness(
+			&repository.CriteriaSummary{TotalCount: 3, CompleteCount: 1, NACount: 1},
+			&repository.ChecklistSummary{Total: 1, Done: 0},
+		)
+		assert.False(t, r.Ready)
+		assert.Equal(t, 50.0, r.ReadinessPct)
+		assert.Equal(t, 2, r.CriteriaComplete)
+		assert.Equal(t, 0, r.ChecklistComplete)
+	})
+
+	t.Run("all complete is ready", func(t *testing.T) {
+		r := computeTaskReadiness(nil, &repository.ChecklistSummary{Total: 2, Done: 2})
+		assert.True(t, r.Ready)
+		assert.Equal(t, 100.0, r.ReadinessPct)
+	})
+}
+
+func TestFormatTaskReadiness(t *testing.T) {
+	r := &TaskReadiness{ReadinessPct: 50, CriteriaTotal: 2, CriteriaComplete: 1, ChecklistTotal: 2, ChecklistComplete: 1}
+	assert.Equal(t, "Readiness: 50% not ready (criteria 1/2, checklist 1/2)", formatTaskReadiness(r))
+}
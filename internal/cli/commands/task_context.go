@@ -1,11 +1,8 @@
 package commands
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -54,11 +51,12 @@ Examples:
 
 // taskContextGetCmd gets task context
 var taskContextGetCmd = &cobra.Command{
-	Use:   "get <task-key>",
-	Short: "Get task context data",
-	Long:  `Display the current context data for a task.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTaskContextGet,
+	Use:         "get <task-key>",
+	Short:       "Get task context data",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long:        `Display the current context data for a task.`,
+	Args:        cobra.ExactArgs(1),
+	RunE:        runTaskContextGet,
 }
 
 // taskContextClearCmd clears task context
@@ -86,7 +84,7 @@ func init() {
 
 // runTaskContextSet sets or updates a context field
 func runTaskContextSet(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	taskKey := args[0]
@@ -106,9 +104,7 @@ func runTaskContextSet(cmd *cobra.Command, args []string) error {
 	}
 
 	if !validFields[field] {
-		cli.Error(fmt.Sprintf("Invalid context field: %s", field))
-		cli.Info("Supported fields: current_step, completed_steps, remaining_steps, implementation_decisions, open_questions, blockers, acceptance_criteria_status, related_tasks")
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Invalid context field: %s", field), "Supported fields: current_step, completed_steps, remaining_steps, implementation_decisions, open_questions, blockers, acceptance_criteria_status, related_tasks")
 	}
 
 	// Get database connection
@@ -124,9 +120,7 @@ func runTaskContextSet(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task %s not found", taskKey))
-		cli.Info("Use 'shark task list' to see available tasks")
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task %s not found", taskKey), "Use 'shark task list' to see available tasks")
 	}
 
 	// Parse existing context data or create new
@@ -142,8 +136,7 @@ func runTaskContextSet(cmd *cobra.Command, args []string) error {
 
 	// Update the specified field
 	if err := updateContextField(contextData, field, value); err != nil {
-		cli.Error(fmt.Sprintf("Failed to update field: %v", err))
-		os.Exit(3)
+		cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("Failed to update field: %v", err))
 	}
 
 	// Validate and convert back to JSON
@@ -247,7 +240,7 @@ func updateContextField(cd *models.ContextData, field, value string) error {
 
 // runTaskContextGet retrieves and displays task context
 func runTaskContextGet(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	taskKey := args[0]
@@ -265,8 +258,7 @@ func runTaskContextGet(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task %s not found", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task %s not found", taskKey))
 	}
 
 	// Parse context data
@@ -368,7 +360,7 @@ func runTaskContextGet(cmd *cobra.Command, args []string) error {
 
 // runTaskContextClear clears task context data
 func runTaskContextClear(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	taskKey := args[0]
@@ -386,8 +378,7 @@ func runTaskContextClear(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := repo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task %s not found", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task %s not found", taskKey))
 	}
 
 	// Clear context data
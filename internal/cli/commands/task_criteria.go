@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -13,9 +14,27 @@ import (
 
 // taskCriteriaCmd is the parent command for criteria operations
 var taskCriteriaCmd = &cobra.Command{
-	Use:   "criteria",
-	Short: "Manage task acceptance criteria",
-	Long:  `Import, view, and manage acceptance criteria for tasks.`,
+	Use:     "criteria",
+	Aliases: []string{"ac"},
+	Short:   "Manage task acceptance criteria",
+	Long: `Add, import, view, and verify acceptance criteria for tasks.
+
+Criteria give reviewers a concrete checklist to sign off. 'shark task approve'
+refuses to complete a task while any criterion is pending, in progress, or
+failed, unless --force is given.`,
+}
+
+// taskCriteriaAddCmd adds a criterion to a task
+var taskCriteriaAddCmd = &cobra.Command{
+	Use:   "add <task-key> <criterion>",
+	Short: "Add an acceptance criterion to a task",
+	Long: `Add an acceptance criterion to a task. New criteria start as pending.
+
+Examples:
+  shark task ac add T-E10-F04-001 "Login fails with a clear message for bad passwords"
+  shark task criteria add T-E10-F04-001 "p95 latency under 200ms" --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTaskCriteriaAdd,
 }
 
 // taskCriteriaImportCmd imports criteria from task markdown file
@@ -37,8 +56,9 @@ Examples:
 
 // taskCriteriaListCmd lists criteria for a task
 var taskCriteriaListCmd = &cobra.Command{
-	Use:   "list <task-key>",
-	Short: "List acceptance criteria for a task",
+	Use:         "list <task-key>",
+	Short:       "List acceptance criteria for a task",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List all acceptance criteria for a task with status summary.
 
 Displays:
@@ -56,15 +76,17 @@ Examples:
 
 // taskCriteriaCheckCmd marks a criterion as complete
 var taskCriteriaCheckCmd = &cobra.Command{
-	Use:   "check <task-key> <criterion-id>",
-	Short: "Mark a criterion as complete",
+	Use:     "check <task-key> <criterion-id>",
+	Aliases: []string{"verify"},
+	Short:   "Mark a criterion as complete",
 	Long: `Mark an acceptance criterion as complete (verified).
 
-Updates status to 'complete' and sets verified_at timestamp.
-Optional --note parameter adds verification notes.
+Updates status to 'complete' and records who verified it (--agent, default
+$USER) and when. Optional --note parameter adds verification notes.
 
 Examples:
   shark task criteria check T-E10-F04-001 5
+  shark task ac verify T-E10-F04-001 5 --agent=reviewer
   shark task criteria check T-E10-F04-001 5 --note "Verified with unit tests"
   shark task criteria check T-E10-F04-001 5 --json`,
 	Args: cobra.ExactArgs(2),
@@ -115,7 +137,7 @@ func runTaskCriteriaImport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Parse criteria from file
-	criteria, err := taskfile.ParseCriteriaFromFile(*task.FilePath)
+	criteria, err := taskfile.ParseCriteriaFromFile(resolveProjectPath(repoDb.ProjectRoot(), *task.FilePath))
 	if err != nil {
 		return fmt.Errorf("failed to parse criteria from file: %w", err)
 	}
@@ -160,6 +182,53 @@ func runTaskCriteriaImport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runTaskCriteriaAdd handles the task criteria add command
+func runTaskCriteriaAdd(cmd *cobra.Command, args []string) error {
+	taskKey := args[0]
+	text := strings.TrimSpace(args[1])
+	if text == "" {
+		return fmt.Errorf("criterion text cannot be empty")
+	}
+
+	// Get database connection
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+	taskRepo := repository.NewTaskRepository(repoDb)
+	criteriaRepo := repository.NewTaskCriteriaRepository(repoDb)
+
+	// Get task by key
+	task, err := taskRepo.GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found", taskKey)
+	}
+
+	criterion := &models.TaskCriteria{
+		TaskID:    task.ID,
+		Criterion: text,
+		Status:    models.CriteriaStatusPending,
+	}
+	if err := criteriaRepo.Create(ctx, criterion); err != nil {
+		return fmt.Errorf("failed to add criterion: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"task_key":     task.Key,
+			"criterion_id": criterion.ID,
+			"criterion":    criterion.Criterion,
+			"status":       criterion.Status,
+		})
+	}
+
+	fmt.Printf("Added criterion %d to %s: %s\n", criterion.ID, task.Key, criterion.Criterion)
+	return nil
+}
+
 // runTaskCriteriaList handles the task criteria list command
 func runTaskCriteriaList(cmd *cobra.Command, args []string) error {
 	taskKey := args[0]
@@ -266,6 +335,9 @@ func runTaskCriteriaList(cmd *cobra.Command, args []string) error {
 		icon := getCriteriaStatusIcon(criterion.Status)
 		fmt.Printf("  [%d] %s %s\n", criterion.ID, icon, criterion.Criterion)
 
+		if criterion.VerifiedBy != nil && *criterion.VerifiedBy != "" {
+			fmt.Printf("      Verified by: %s\n", *criterion.VerifiedBy)
+		}
 		if criterion.VerificationNotes != nil && *criterion.VerificationNotes != "" {
 			fmt.Printf("      Note: %s\n", *criterion.VerificationNotes)
 		}
@@ -287,6 +359,8 @@ func runTaskCriteriaCheck(cmd *cobra.Command, args []string) error {
 	if note != "" {
 		notePtr = &note
 	}
+	agentFlag, _ := cmd.Flags().GetString("agent")
+	agent := getAgentIdentifier(agentFlag)
 
 	// Get database connection
 	repoDb, err := cli.GetDB(cmd.Context())
@@ -316,7 +390,7 @@ func runTaskCriteriaCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update status to complete
-	err = criteriaRepo.UpdateStatus(ctx, criterionID, models.CriteriaStatusComplete, notePtr)
+	err = criteriaRepo.UpdateStatusBy(ctx, criterionID, models.CriteriaStatusComplete, &agent, notePtr)
 	if err != nil {
 		return fmt.Errorf("failed to update criterion status: %w", err)
 	}
@@ -332,6 +406,7 @@ func runTaskCriteriaCheck(cmd *cobra.Command, args []string) error {
 			"task_key":       taskKey,
 			"criterion_id":   criterionID,
 			"status":         "complete",
+			"verified_by":    agent,
 			"total_count":    summary.TotalCount,
 			"complete_count": summary.CompleteCount,
 			"completion_pct": summary.CompletionPct,
@@ -360,6 +435,8 @@ func runTaskCriteriaFail(cmd *cobra.Command, args []string) error {
 	if note == "" {
 		return fmt.Errorf("--note flag is required for failed criteria")
 	}
+	agentFlag, _ := cmd.Flags().GetString("agent")
+	agent := getAgentIdentifier(agentFlag)
 
 	// Get database connection
 	repoDb, err := cli.GetDB(cmd.Context())
@@ -389,7 +466,7 @@ func runTaskCriteriaFail(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update status to failed
-	err = criteriaRepo.UpdateStatus(ctx, criterionID, models.CriteriaStatusFailed, &note)
+	err = criteriaRepo.UpdateStatusBy(ctx, criterionID, models.CriteriaStatusFailed, &agent, &note)
 	if err != nil {
 		return fmt.Errorf("failed to update criterion status: %w", err)
 	}
@@ -405,6 +482,7 @@ func runTaskCriteriaFail(cmd *cobra.Command, args []string) error {
 			"task_key":       taskKey,
 			"criterion_id":   criterionID,
 			"status":         "failed",
+			"verified_by":    agent,
 			"note":           note,
 			"total_count":    summary.TotalCount,
 			"failed_count":   summary.FailedCount,
@@ -446,6 +524,7 @@ func init() {
 	taskCmd.AddCommand(taskCriteriaCmd)
 
 	// Add subcommands to criteria command
+	taskCriteriaCmd.AddCommand(taskCriteriaAddCmd)
 	taskCriteriaCmd.AddCommand(taskCriteriaImportCmd)
 	taskCriteriaCmd.AddCommand(taskCriteriaListCmd)
 	taskCriteriaCmd.AddCommand(taskCriteriaCheckCmd)
@@ -453,8 +532,10 @@ func init() {
 
 	// Flags for check command
 	taskCriteriaCheckCmd.Flags().StringP("note", "n", "", "Verification notes (optional)")
+	taskCriteriaCheckCmd.Flags().String("agent", "", "Reviewer recorded as verifier (defaults to SHARK_AGENT, else USER env var)")
 
 	// Flags for fail command
 	taskCriteriaFailCmd.Flags().StringP("note", "n", "", "Failure reason (required)")
+	taskCriteriaFailCmd.Flags().String("agent", "", "Reviewer recorded as verifier (defaults to SHARK_AGENT, else USER env var)")
 	_ = taskCriteriaFailCmd.MarkFlagRequired("note")
 }
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskDepCmd groups commands that edit a task's depends_on list
+var taskDepCmd = &cobra.Command{
+	Use:   "dep",
+	Short: "Add, remove, or list a task's dependencies",
+	Long: `Edit a task's depends_on list without writing JSON by hand.
+
+A task cannot depend on itself, dependencies must exist, and a dependency that
+would create a cycle is rejected. Each change reads and rewrites the list in a
+single transaction.
+
+Examples:
+  shark task dep add T-E04-F01-003 --on T-E04-F01-001
+  shark task dep add T-E04-F01-003 --on T-E04-F01-001,T-E04-F01-002
+  shark task dep rm T-E04-F01-003 --on T-E04-F01-001
+  shark task dep list T-E04-F01-003`,
+}
+
+// taskDepAddCmd adds dependencies to a task
+var taskDepAddCmd = &cobra.Command{
+	Use:   "add <task-key> --on <task-key>[,<task-key>...]",
+	Short: "Make a task depend on other tasks",
+	Long: `Add one or more tasks to a task's depends_on list. Dependencies that are
+already present are left as they are.
+
+Examples:
+  shark task dep add T-E04-F01-003 --on T-E04-F01-001
+  shark task dep add T-E04-F01-003 --on T-E04-F01-001,T-E04-F01-002 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskDepAdd,
+}
+
+// taskDepRmCmd removes dependencies from a task
+var taskDepRmCmd = &cobra.Command{
+	Use:     "rm <task-key> --on <task-key>[,<task-key>...]",
+	Aliases: []string{"remove"},
+	Short:   "Remove dependencies from a task",
+	Long: `Remove one or more tasks from a task's depends_on list.
+
+Examples:
+  shark task dep rm T-E04-F01-003 --on T-E04-F01-001
+  shark task dep rm T-E04-F01-003 --on T-E04-F01-001,T-E04-F01-002`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskDepRm,
+}
+
+// taskDepListCmd lists a task's dependencies
+var taskDepListCmd = &cobra.Command{
+	Use:         "list <task-key>",
+	Short:       "List a task's dependencies and their status",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List the tasks in a task's depends_on list with their title and status.
+
+Examples:
+  shark task dep list T-E04-F01-003
+  shark task dep list T-E04-F01-003 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskDepList,
+}
+
+func init() {
+	taskCmd.AddCommand(taskDepCmd)
+	taskDepCmd.AddCommand(taskDepAddCmd)
+	taskDepCmd.AddCommand(taskDepRmCmd)
+	taskDepCmd.AddCommand(taskDepListCmd)
+
+	taskDepAddCmd.Flags().StringSlice("on", nil, "Task keys to depend on (comma-separated)")
+	_ = taskDepAddCmd.MarkFlagRequired("on")
+	taskDepRmCmd.Flags().StringSlice("on", nil, "Task keys to stop depending on (comma-separated)")
+	_ = taskDepRmCmd.MarkFlagRequired("on")
+}
+
+// depOnKeys reads and normalizes the --on flag
+func depOnKeys(cmd *cobra.Command) ([]string, error) {
+	refs, _ := cmd.Flags().GetStringSlice("on")
+	keys := []string{}
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		key, err := ResolveTaskKey(cmd, ref)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("--on requires at least one task key")
+	}
+	return keys, nil
+}
+
+// runTaskDepAdd handles the task dep add command
+func runTaskDepAdd(cmd *cobra.Command, args []string) error {
+	return runTaskDepChange(cmd, args, true)
+}
+
+// runTaskDepRm handles the task dep rm command
+func runTaskDepRm(cmd *cobra.Command, args []string) error {
+	return runTaskDepChange(cmd, args, false)
+}
+
+// runTaskDepChange adds or removes each --on key in turn. Each change is
+// atomic; an error stops at the failing key and keeps earlier changes.
+func runTaskDepChange(cmd *cobra.Command, args []string, add bool) error {
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	onKeys, err := depOnKeys(cmd)
+	if err != nil {
+		return err
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+	taskRepo := repository.NewTaskRepository(repoDb)
+
+	var deps []string
+	for _, onKey := range onKeys {
+		if add {
+			deps, err = taskRepo.AddTaskDependency(ctx, taskKey, onKey)
+		} else {
+			deps, err = taskRepo.RemoveTaskDependency(ctx, taskKey, onKey)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"task_key":   taskKey,
+			"depends_on": deps,
+		})
+	}
+
+	if add {
+		cli.Success(fmt.Sprintf("%s now depends on %s", taskKey, strings.Join(onKeys, ", ")))
+	} else {
+		cli.Success(fmt.Sprintf("%s no longer depends on %s", taskKey, strings.Join(onKeys, ", ")))
+	}
+	if len(deps) == 0 {
+		cli.Info("%s has no dependencies", taskKey)
+	} else {
+		cli.Info("Dependencies: %s", strings.Join(deps, ", "))
+	}
+	return nil
+}
+
+// runTaskDepList handles the task dep list command
+func runTaskDepList(cmd *cobra.Command, args []string) error {
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	ctx := context.Background()
+	taskRepo := repository.NewTaskRepository(repoDb)
+
+	task, err := taskRepo.GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found", taskKey)
+	}
+
+	deps := []string{}
+	if task.DependsOn != nil && *task.DependsOn != "" {
+		if err := json.Unmarshal([]byte(*task.DependsOn), &deps); err != nil {
+			return fmt.Errorf("task %s has invalid depends_on JSON: %w", taskKey, err)
+		}
+	}
+
+	found, err := taskRepo.GetByKeys(ctx, deps)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	type dependencyInfo struct {
+		Key    string `json:"key"`
+		Title  string `json:"title"`
+		Status string `json:"status"`
+	}
+	infos := make([]dependencyInfo, len(deps))
+	for i, key := range deps {
+		infos[i] = dependencyInfo{Key: key, Status: "not found"}
+		if dep, ok := found[key]; ok {
+			infos[i].Title = dep.Title
+			infos[i].Status = string(dep.Status)
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"task_key":     taskKey,
+			"dependencies": infos,
+		})
+	}
+
+	if len(infos) == 0 {
+		fmt.Printf("%s has no dependencies\n", taskKey)
+		return nil
+	}
+
+	rows := make([][]string, len(infos))
+	for i, info := range infos {
+		rows[i] = []string{getStatusIcon(info.Status), info.Key, truncateCell(info.Title, 50), info.Status}
+	}
+	cli.OutputTable([]string{"", "Key", "Title", "Status"}, rows)
+	return nil
+}
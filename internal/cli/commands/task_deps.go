@@ -34,8 +34,9 @@ type RelationshipRepositoryInterface interface {
 
 // taskDepsCmd shows all relationships for a task
 var taskDepsCmd = &cobra.Command{
-	Use:   "deps <task-key>",
-	Short: "Show all relationships for a task",
+	Use:         "deps <task-key>",
+	Short:       "Show all relationships for a task",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Show all relationships for a task (incoming and outgoing).
 
 Shows dependencies, blocks, related tasks, and other relationships.
@@ -53,8 +54,9 @@ Examples:
 
 // taskBlockedByCmd shows what blocks this task
 var taskBlockedByCmd = &cobra.Command{
-	Use:   "blocked-by <task-key>",
-	Short: "Show what blocks this task (incoming dependencies)",
+	Use:         "blocked-by <task-key>",
+	Short:       "Show what blocks this task (incoming dependencies)",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Show all tasks that this task depends on (incoming dependencies).
 
 Examples:
@@ -66,8 +68,9 @@ Examples:
 
 // taskBlocksCmd shows what this task blocks
 var taskBlocksCmd = &cobra.Command{
-	Use:   "blocks <task-key>",
-	Short: "Show what this task blocks (outgoing blockers)",
+	Use:         "blocks <task-key>",
+	Short:       "Show what this task blocks (outgoing blockers)",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Show all tasks that depend on this task completing (outgoing blockers).
 
 Examples:
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// addEstimateFlags registers --estimate and --actual-effort on a create/update command
+func addEstimateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("estimate", "", "Estimated effort: a number of points (5, 5pts) or hours (3h)")
+	cmd.Flags().String("actual-effort", "", "Actual effort spent, in the estimate's unit (e.g. 2, 2pts, 1.5h)")
+}
+
+// estimateFromFlags applies --estimate and --actual-effort to a task's current
+// estimate (nil if it has none). Returns nil when neither flag was given.
+func estimateFromFlags(cmd *cobra.Command, current *models.TaskEstimate) (*models.TaskEstimate, error) {
+	estimateFlag, _ := cmd.Flags().GetString("estimate")
+	actualFlag, _ := cmd.Flags().GetString("actual-effort")
+	if estimateFlag == "" && actualFlag == "" {
+		return nil, nil
+	}
+	return applyEffortFlags(current, estimateFlag, actualFlag)
+}
+
+// applyEffortFlags returns current updated with the given effort values.
+// Values without a unit use the current unit, or points for a new estimate.
+// Estimate and actual effort always share a unit, so switching units requires
+// setting every value the task already has.
+func applyEffortFlags(current *models.TaskEstimate, estimateFlag, actualFlag string) (*models.TaskEstimate, error) {
+	next := &models.TaskEstimate{Unit: models.EstimateUnitPoints}
+	if current != nil {
+		copied := *current
+		next = &copied
+	}
+
+	var unit models.EstimateUnit
+	if estimateFlag != "" {
+		value, u, err := models.ParseEffort(estimateFlag)
+		if err != nil {
+			return nil, fmt.Errorf("--estimate: %w", err)
+		}
+		next.Estimate = &value
+		unit = u
+	}
+	if actualFlag != "" {
+		value, u, err := models.ParseEffort(actualFlag)
+		if err != nil {
+			return nil, fmt.Errorf("--actual-effort: %w", err)
+		}
+		if unit != "" && u != "" && u != unit {
+			return nil, fmt.Errorf("--estimate and --actual-effort must use the same unit")
+		}
+		next.ActualEffort = &value
+		if u != "" {
+			unit = u
+		}
+	}
+
+	if unit != "" && unit != next.Unit {
+		if next.Estimate != nil && estimateFlag == "" {
+			return nil, fmt.Errorf("estimate is in %s; set --estimate in %s too to switch units", next.Unit, unit)
+		}
+		if next.ActualEffort != nil && actualFlag == "" {
+			return nil, fmt.Errorf("actual effort is in %s; set --actual-effort in %s too to switch units", next.Unit, unit)
+		}
+		next.Unit = unit
+	}
+
+	return next, nil
+}
+
+// attachTaskEstimate fills in the Estimate field of a task
+func attachTaskEstimate(ctx context.Context, repoDb *repository.DB, task *models.Task) error {
+	estimate, err := repository.NewTaskEstimateRepository(repoDb).GetByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	task.Estimate = estimate
+	return nil
+}
+
+// printTaskEstimate prints a task's estimate and actual effort, if any
+func printTaskEstimate(estimate *models.TaskEstimate) {
+	if estimate == nil {
+		return
+	}
+	if estimate.Estimate != nil {
+		fmt.Printf("Estimate: %s\n", models.FormatEffort(*estimate.Estimate, estimate.Unit))
+	}
+	if estimate.ActualEffort != nil {
+		fmt.Printf("Actual Effort: %s\n", models.FormatEffort(*estimate.ActualEffort, estimate.Unit))
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEffortFlags(t *testing.T) {
+	five, two := 5.0, 2.0
+
+	t.Run("new estimate defaults to points", func(t *testing.T) {
+		estimate, err := applyEffortFlags(nil, "5", "")
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, *estimate.Estimate)
+		assert.Nil(t, estimate.ActualEffort)
+		assert.Equal(t, models.EstimateUnitPoints, estimate.Unit)
+	})
+
+	t.Run("unit suffix sets the unit", func(t *testing.T) {
+		estimate, err := applyEffortFlags(nil, "3h", "1")
+		require.NoError(t, err)
+		assert.Equal(t, models.EstimateUnitHours, estimate.Unit)
+		assert.Equal(t, 1.0, *estimate.ActualEffort)
+	})
+
+	t.Run("bare actual effort uses the current unit", func(t *testing.T) {
+		current := &models.TaskEstimate{TaskID: 1, Estimate: &five, Unit: models.EstimateUnitHours}
+		estimate, err := applyEffortFlags(current, "", "2")
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, *estimate.Estimate)
+		assert.Equal(t, 2.0, *estimate.ActualEffort)
+		assert.Equal(t, models.EstimateUnitHours, estimate.Unit)
+		assert.Nil(t, current.ActualEffort, "current estimate must not be modified")
+	})
+
+	t.Run("switching units requires every value", func(t *testing.T) {
+		current := &models.TaskEstimate{TaskID: 1, Estimate: &five, ActualEffort: &two, Unit: models.EstimateUnitPoints}
+		_, err := applyEffortFlags(current, "4h", "")
+		assert.Error(t, err)
+
+		estimate, err := applyEffortFlags(current, "4h", "1h")
+		require.NoError(t, err)
+		assert.Equal(t, models.EstimateUnitHours, estimate.Unit)
+	})
+
+	t.Run("mixed units are rejected", func(t *testing.T) {
+		_, err := applyEffortFlags(nil, "5pts", "2h")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		_, err := applyEffortFlags(nil, "lots", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestCapacityByAgentType(t *testing.T) {
+	rows := []*repository.CapacityRow{
+		{AgentType: "backend", EpicKey: "E01", OpenTasks: 3, UnestimatedTasks: 1, RemainingPoints: 8},
+		{AgentType: "backend", EpicKey: "E02", OpenTasks: 2, RemainingPoints: 5, RemainingHours: 4},
+		{AgentType: "frontend", EpicKey: "E01", OpenTasks: 1, RemainingHours: 6},
+	}
+
+	totals := capacityByAgentType(rows)
+	require.Len(t, totals, 2)
+	assert.Equal(t, "backend", totals[0].AgentType)
+	assert.Equal(t, 5, totals[0].OpenTasks)
+	assert.Equal(t, 1, totals[0].UnestimatedTasks)
+	assert.Equal(t, 13.0, totals[0].RemainingPoints)
+	assert.Equal(t, 4.0, totals[0].RemainingHours)
+	assert.Equal(t, "frontend", totals[1].AgentType)
+	assert.Empty(t, totals[1].EpicKey)
+
+	assert.Empty(t, capacityByAgentType(nil))
+}
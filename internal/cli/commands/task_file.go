@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/pathresolver"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/view"
+	"github.com/spf13/cobra"
+)
+
+// taskFileCmd prints the path of a task's markdown file
+var taskFileCmd = &cobra.Command{
+	Use:         "file <task-key>",
+	Short:       "Print the path of a task's markdown file",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Print the absolute path of a task's markdown file, resolved the way
+'shark task get' resolves it, so scripts don't have to parse the get output.
+With --cat, print the file's contents instead.
+
+The path is printed even if the file doesn't exist yet; --json reports whether
+it does.
+
+Examples:
+  shark task file T-E04-F02-001
+  shark task file E04-F02-001 --cat
+  $EDITOR "$(shark task file T-E04-F02-001)"
+  shark task file T-E04-F02-001 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskFile,
+}
+
+// taskEditCmd opens a task's markdown file in an editor
+var taskEditCmd = &cobra.Command{
+	Use:   "edit <task-key>",
+	Short: "Open a task's markdown file in your editor",
+	Long: `Open a task's markdown file in $VISUAL, else $EDITOR, else vi, and wait
+for the editor to exit. The editor may include arguments, e.g.
+EDITOR="code --wait".
+
+Examples:
+  shark task edit T-E04-F02-001
+  EDITOR=nano shark task edit E04-F02-001`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskEdit,
+}
+
+func init() {
+	taskCmd.AddCommand(taskFileCmd)
+	taskCmd.AddCommand(taskEditCmd)
+
+	taskFileCmd.Flags().Bool("cat", false, "Print the file's contents instead of its path")
+}
+
+// TaskFileJSON is the output of shark task file --json
+type TaskFileJSON struct {
+	Key          string `json:"key"`
+	Path         string `json:"path"`          // Absolute path
+	RelativePath string `json:"relative_path"` // Relative to the project root
+	Exists       bool   `json:"exists"`
+	Content      string `json:"content,omitempty"` // With --cat
+}
+
+// resolveTaskFile returns a task's key and the absolute path of its file
+func resolveTaskFile(ctx context.Context, cmd *cobra.Command, keyArg string) (key, path, projectRoot string, err error) {
+	taskKey, err := ResolveTaskKey(cmd, keyArg)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid task key: %w", err)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err = cli.FindProjectRoot()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	task, err := taskRepo.GetByKey(ctx, taskKey)
+	if err != nil {
+		return "", "", "", cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("task %s not found", taskKey))
+	}
+
+	resolver := pathresolver.NewPathResolver(repository.NewEpicRepository(repoDb), repository.NewFeatureRepository(repoDb), taskRepo, projectRoot)
+	path, err = resolver.ResolveTaskPath(ctx, task.Key)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to resolve task file: %w", err)
+	}
+	return task.Key, path, projectRoot, nil
+}
+
+// runTaskFile handles the task file command
+func runTaskFile(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	key, path, projectRoot, err := resolveTaskFile(ctx, cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	out := TaskFileJSON{Key: key, Path: path, RelativePath: getRelativePathTask(path, projectRoot)}
+	_, statErr := os.Stat(path)
+	out.Exists = statErr == nil
+
+	if showContent, _ := cmd.Flags().GetBool("cat"); showContent {
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("task %s has no file at %s", key, out.RelativePath))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read task file: %w", err)
+		}
+		if !cli.GlobalConfig.JSON {
+			fmt.Print(string(content))
+			return nil
+		}
+		out.Content = string(content)
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(out)
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// runTaskEdit handles the task edit command
+func runTaskEdit(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	key, path, projectRoot, err := resolveTaskFile(ctx, cmd, args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("task %s has no file at %s", key, getRelativePathTask(path, projectRoot)))
+	}
+
+	// The editor runs as long as the user needs, not within the lookup timeout
+	return view.NewService(nil, nil, nil).LaunchEditor(context.Background(), path, view.Editor())
+}
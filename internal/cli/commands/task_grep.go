@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// TaskGrepMatch is a line of a task that matched --grep, with the lines around it
+type TaskGrepMatch struct {
+	Field  string   `json:"field"`          // title, description, or file
+	Path   string   `json:"path,omitempty"` // Task file, relative to the project root (field "file" only)
+	Line   int      `json:"line"`
+	Text   string   `json:"text"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// TaskGrepResult is a task listed by task list --grep with its matches
+type TaskGrepResult struct {
+	*models.Task
+	Matches []TaskGrepMatch `json:"matches"`
+}
+
+// grepLines returns the lines of content containing pattern (case-insensitive),
+// each with up to contextLines lines before and after it
+func grepLines(field, content, pattern string, contextLines int) []TaskGrepMatch {
+	if content == "" || pattern == "" {
+		return nil
+	}
+	needle := strings.ToLower(pattern)
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var matches []TaskGrepMatch
+	for i, line := range lines {
+		if !strings.Contains(strings.ToLower(line), needle) {
+			continue
+		}
+		start := max(0, i-contextLines)
+		end := min(len(lines), i+contextLines+1)
+		matches = append(matches, TaskGrepMatch{
+			Field:  field,
+			Line:   i + 1,
+			Text:   line,
+			Before: append([]string(nil), lines[start:i]...),
+			After:  append([]string(nil), lines[i+1:end]...),
+		})
+	}
+	return matches
+}
+
+// grepTasks returns the tasks whose title or description (and, with
+// includeFiles, task file) contain pattern. resolvePath returns the task
+// file's absolute and display paths; files that can't be read are skipped.
+func grepTasks(ctx context.Context, tasks []*models.Task, pattern string, contextLines int, includeFiles bool, resolvePath func(context.Context, *models.Task) (string, string, error)) []*TaskGrepResult {
+	results := make([]*TaskGrepResult, 0)
+	for _, task := range tasks {
+		matches := grepLines("title", task.Title, pattern, 0)
+		if task.Description != nil {
+			matches = append(matches, grepLines("description", *task.Description, pattern, contextLines)...)
+		}
+
+		if includeFiles && resolvePath != nil {
+			absPath, displayPath, err := resolvePath(ctx, task)
+			if err == nil {
+				var content []byte
+				content, err = os.ReadFile(absPath)
+				if err == nil {
+					fileMatches := grepLines("file", string(content), pattern, contextLines)
+					for i := range fileMatches {
+						fileMatches[i].Path = displayPath
+					}
+					matches = append(matches, fileMatches...)
+				}
+			}
+			if err != nil && !os.IsNotExist(err) {
+				slog.Warn("Failed to read task file", "task", task.Key, "error", err)
+			}
+		}
+
+		if len(matches) > 0 {
+			results = append(results, &TaskGrepResult{Task: task, Matches: matches})
+		}
+	}
+	return results
+}
+
+// formatTaskGrepResults formats grep results like grep -n: "source:line:" for
+// matching lines and "source-line-" for context, with "--" between
+// non-adjacent groups
+func formatTaskGrepResults(results []*TaskGrepResult) string {
+	var sb strings.Builder
+	for i, result := range results {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s: %s [%s]\n", result.Key, result.Title, result.Status)
+
+		source, last := "", 0
+		for j, match := range result.Matches {
+			label := match.Field
+			if match.Path != "" {
+				label = match.Path
+			}
+			if label != source {
+				source, last = label, 0
+			}
+
+			first := match.Line - len(match.Before)
+			if last > 0 && first > last+1 {
+				sb.WriteString("  --\n")
+			}
+			for k, line := range match.Before {
+				if n := first + k; n > last {
+					fmt.Fprintf(&sb, "  %s-%d- %s\n", label, n, line)
+				}
+			}
+			fmt.Fprintf(&sb, "  %s:%d: %s\n", label, match.Line, match.Text)
+			last = match.Line
+
+			// Stop the trailing context where the next match of this source begins
+			next := 0
+			if j+1 < len(result.Matches) && sameGrepSource(result.Matches[j+1], match) {
+				next = result.Matches[j+1].Line
+			}
+			for k, line := range match.After {
+				n := match.Line + 1 + k
+				if next > 0 && n >= next {
+					break
+				}
+				fmt.Fprintf(&sb, "  %s-%d- %s\n", label, n, line)
+				last = n
+			}
+		}
+	}
+	return sb.String()
+}
+
+// sameGrepSource reports whether two matches come from the same text
+func sameGrepSource(a, b TaskGrepMatch) bool {
+	return a.Field == b.Field && a.Path == b.Path
+}
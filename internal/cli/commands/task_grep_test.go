@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+func TestGrepLines(t *testing.T) {
+	content := "intro\nset up oauth client\nstore tokens\nrefresh OAuth tokens\noutro"
+	matches := grepLines("description", content, "OAuth", 1)
+
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Line != 2 || matches[0].Text != "set up oauth client" {
+		t.Errorf("unexpected first match %+v", matches[0])
+	}
+	if len(matches[0].Before) != 1 || matches[0].Before[0] != "intro" {
+		t.Errorf("expected one line of context before, got %v", matches[0].Before)
+	}
+	if matches[1].Line != 4 || len(matches[1].After) != 1 || matches[1].After[0] != "outro" {
+		t.Errorf("unexpected second match %+v", matches[1])
+	}
+
+	if got := grepLines("description", content, "saml", 1); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", got)
+	}
+}
+
+func TestGrepTasks(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "T-E01-F01-002.md")
+	if err := os.WriteFile(filePath, []byte("# Task\n\nUse the OAuth device flow.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	description := "Log in with OAuth"
+	tasks := []*models.Task{
+		{Key: "T-E01-F01-001", Title: "Login page", Description: &description},
+		{Key: "T-E01-F01-002", Title: "CLI login"},
+		{Key: "T-E01-F01-003", Title: "Logout"},
+	}
+	resolvePath := func(ctx context.Context, task *models.Task) (string, string, error) {
+		return filepath.Join(dir, task.Key+".md"), "docs/" + task.Key + ".md", nil
+	}
+
+	results := grepTasks(context.Background(), tasks, "oauth", 1, false, resolvePath)
+	if len(results) != 1 || results[0].Key != "T-E01-F01-001" {
+		t.Fatalf("expected only the task with a matching description, got %v", results)
+	}
+
+	results = grepTasks(context.Background(), tasks, "oauth", 1, true, resolvePath)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 tasks with --include-files, got %d", len(results))
+	}
+	match := results[1].Matches[0]
+	if match.Field != "file" || match.Path != "docs/T-E01-F01-002.md" || match.Line != 3 {
+		t.Errorf("unexpected file match %+v", match)
+	}
+}
+
+func TestFormatTaskGrepResults(t *testing.T) {
+	content := "a\noauth one\nb\noauth two\nc\nd\ne\noauth three"
+	results := []*TaskGrepResult{{
+		Task:    &models.Task{Key: "T-E01-F01-001", Title: "Login", Status: models.TaskStatusTodo},
+		Matches: grepLines("description", content, "oauth", 1),
+	}}
+
+	want := strings.Join([]string{
+		"T-E01-F01-001: Login [todo]",
+		"  description-1- a",
+		"  description:2: oauth one",
+		"  description-3- b",
+		"  description:4: oauth two",
+		"  description-5- c",
+		"  --",
+		"  description-7- e",
+		"  description:8: oauth three",
+		"",
+	}, "\n")
+	if got := formatTaskGrepResults(results); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/formatters"
@@ -17,15 +18,22 @@ var taskHistoryFormat string
 
 // taskHistoryCmd shows the history of a task
 var taskHistoryCmd = &cobra.Command{
-	Use:   "history <task-key>",
-	Short: "Show task history",
+	Use:         "history <task-key>",
+	Short:       "Show task history",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Display the complete lifecycle history of a task showing all status transitions.
 
-Shows all status changes with timestamps, agents, and notes in chronological order.
+Shows all status changes with timestamps, agents, and notes in chronological order,
+how long the task spent in each status before moving on, and which transitions
+were forced past workflow validation.
+
+--since takes a duration (12h, 7d, 2w) or a date (YYYY-MM-DD or RFC3339).
+--limit keeps the most recent transitions.
 
 Examples:
   shark task history T-E04-F01-001
   shark task history T-E04-F01-001 --json
+  shark task history T-E04-F01-001 --since=7d --limit=5
   shark task history T-E04-F01-001 --format=csv
   shark task history T-E04-F01-001 --format=json`,
 	Args: cobra.ExactArgs(1),
@@ -35,6 +43,7 @@ Examples:
 // HistoryOutput represents the JSON output structure for task history
 type HistoryOutput struct {
 	TaskKey string         `json:"task_key"`
+	Total   int            `json:"total"` // Transitions before --since and --limit
 	History []HistoryEntry `json:"history"`
 }
 
@@ -47,12 +56,41 @@ type HistoryEntry struct {
 	Agent           *string `json:"agent,omitempty"`
 	Notes           *string `json:"notes,omitempty"`
 	RejectionReason *string `json:"rejection_reason,omitempty"`
+	Forced          bool    `json:"forced"`
+
+	// Time since the previous transition, i.e. how long the task was in
+	// old_status. Not set for the first transition.
+	Duration        string `json:"duration,omitempty"`
+	DurationSeconds *int64 `json:"duration_seconds,omitempty"`
+}
+
+// timedTransition is a history record with the time since the previous one
+type timedTransition struct {
+	*models.TaskHistory
+	Elapsed *time.Duration
 }
 
 func runTaskHistory(cmd *cobra.Command, args []string) error {
-	taskKey := args[0]
 	ctx := context.Background()
 
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, fmt.Sprintf("invalid task key: %v", err))
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return cli.NewError(cli.ErrCodeInvalidArgument, "--limit must be zero or more")
+	}
+	var since *time.Time
+	if sinceStr, _ := cmd.Flags().GetString("since"); sinceStr != "" {
+		t, err := parseAuditSince(sinceStr, time.Now())
+		if err != nil {
+			return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+		}
+		since = &t
+	}
+
 	// Get database connection
 	repoDb, err := cli.GetDB(cmd.Context())
 	if err != nil {
@@ -72,22 +110,27 @@ func runTaskHistory(cmd *cobra.Command, args []string) error {
 
 	// Check if task exists (empty history could mean no task or no transitions)
 	if len(histories) == 0 {
-		// Verify task exists
 		taskRepo := repository.NewTaskRepository(dbConn)
-		_, err := taskRepo.GetByKey(ctx, taskKey)
-		if err != nil {
-			return fmt.Errorf("task not found: %s", taskKey)
+		if _, err := taskRepo.GetByKey(ctx, taskKey); err != nil {
+			return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("task not found: %s", taskKey))
 		}
+	}
 
-		// Task exists but has no history
+	transitions := timeTransitions(histories, since, limit)
+	if len(transitions) == 0 {
 		if cli.GlobalConfig.JSON {
 			output := HistoryOutput{
 				TaskKey: taskKey,
+				Total:   len(histories),
 				History: []HistoryEntry{},
 			}
 			return cli.OutputJSON(output)
 		}
 
+		if len(histories) > 0 {
+			cli.Info("No history for task %s matches the filters (%d transitions in total)", taskKey, len(histories))
+			return nil
+		}
 		cli.Info("No history found for task %s", taskKey)
 		return nil
 	}
@@ -95,24 +138,55 @@ func runTaskHistory(cmd *cobra.Command, args []string) error {
 	// Format output based on --format flag or --json flag
 	switch taskHistoryFormat {
 	case "csv":
-		return outputHistoryCSV(taskKey, histories)
+		return outputHistoryCSV(taskKey, transitionRecords(transitions))
 	case "json":
-		return outputHistoryJSONExport(taskKey, histories)
+		return outputHistoryJSONExport(taskKey, transitionRecords(transitions))
 	case "":
 		// Default behavior: use --json flag or table
 		if cli.GlobalConfig.JSON {
-			return outputHistoryJSON(taskKey, histories)
+			return outputHistoryJSON(taskKey, transitions, len(histories))
 		}
-		return outputHistoryTable(taskKey, histories)
+		return outputHistoryTable(taskKey, transitions, len(histories))
 	default:
 		return fmt.Errorf("unsupported format: %s (supported formats: csv, json)", taskHistoryFormat)
 	}
 }
 
-func outputHistoryJSON(taskKey string, histories []*models.TaskHistory) error {
-	entries := make([]HistoryEntry, len(histories))
-
+// timeTransitions pairs each record of a chronological history with the time
+// since the previous record, then applies --since and --limit. Durations are
+// computed first, so filtering doesn't lose them.
+func timeTransitions(histories []*models.TaskHistory, since *time.Time, limit int) []timedTransition {
+	transitions := make([]timedTransition, 0, len(histories))
 	for i, h := range histories {
+		transition := timedTransition{TaskHistory: h}
+		if i > 0 {
+			elapsed := h.Timestamp.Sub(histories[i-1].Timestamp)
+			transition.Elapsed = &elapsed
+		}
+		if since != nil && h.Timestamp.Before(*since) {
+			continue
+		}
+		transitions = append(transitions, transition)
+	}
+	if limit > 0 && len(transitions) > limit {
+		transitions = transitions[len(transitions)-limit:]
+	}
+	return transitions
+}
+
+// transitionRecords returns the history records of timed transitions
+func transitionRecords(transitions []timedTransition) []*models.TaskHistory {
+	records := make([]*models.TaskHistory, len(transitions))
+	for i, transition := range transitions {
+		records[i] = transition.TaskHistory
+	}
+	return records
+}
+
+func outputHistoryJSON(taskKey string, transitions []timedTransition, total int) error {
+	entries := make([]HistoryEntry, len(transitions))
+
+	for i, h := range transitions {
 		entries[i] = HistoryEntry{
 			Timestamp:       h.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
 			RelativeAge:     utils.FormatRelativeTime(h.Timestamp),
@@ -121,24 +195,31 @@ func outputHistoryJSON(taskKey string, histories []*models.TaskHistory) error {
 			Agent:           h.Agent,
 			Notes:           h.Notes,
 			RejectionReason: h.RejectionReason,
+			Forced:          h.Forced,
+		}
+		if h.Elapsed != nil {
+			seconds := int64(h.Elapsed.Seconds())
+			entries[i].Duration = formatDuration(*h.Elapsed)
+			entries[i].DurationSeconds = &seconds
 		}
 	}
 
 	output := HistoryOutput{
 		TaskKey: taskKey,
+		Total:   total,
 		History: entries,
 	}
 
 	return cli.OutputJSON(output)
 }
 
-func outputHistoryTable(taskKey string, histories []*models.TaskHistory) error {
+func outputHistoryTable(taskKey string, transitions []timedTransition, total int) error {
 	// Print title
 	cli.Title(fmt.Sprintf("Task History: %s", taskKey))
 	fmt.Println()
 
 	// Print timeline
-	for i, h := range histories {
+	for i, h := range transitions {
 		// Timeline marker
 		if i == 0 {
 			fmt.Print(pterm.LightCyan("┌─"))
@@ -160,12 +241,19 @@ func outputHistoryTable(taskKey string, histories []*models.TaskHistory) error {
 			statusLine = fmt.Sprintf("created as %s", formatStatus(h.NewStatus))
 		}
 
-		// Print main line
+		// Print main line, with how long the task was in its previous status
+		if h.Elapsed != nil && h.OldStatus != nil {
+			relativeAge = fmt.Sprintf("%s, after %s in %s", relativeAge, formatDuration(*h.Elapsed), *h.OldStatus)
+		}
 		fmt.Printf(" %s %s (%s)\n",
 			pterm.LightCyan(timestamp),
 			statusLine,
 			pterm.Gray(relativeAge))
 
+		if h.Forced {
+			fmt.Println(pterm.LightCyan("│  ") + pterm.LightRed("Forced: workflow validation bypassed"))
+		}
+
 		// Print agent if present
 		if h.Agent != nil && *h.Agent != "" {
 			fmt.Printf(pterm.LightCyan("│  ")+"Agent: %s\n", pterm.LightYellow(*h.Agent))
@@ -182,7 +270,7 @@ func outputHistoryTable(taskKey string, histories []*models.TaskHistory) error {
 		}
 
 		// Add spacing between entries
-		if i < len(histories)-1 {
+		if i < len(transitions)-1 {
 			fmt.Println(pterm.LightCyan("│"))
 		}
 	}
@@ -191,6 +279,9 @@ func outputHistoryTable(taskKey string, histories []*models.TaskHistory) error {
 	fmt.Println(pterm.LightCyan("└─"))
 
 	fmt.Println()
+	if len(transitions) < total {
+		cli.Info("Showing %d of %d transitions", len(transitions), total)
+	}
 	return nil
 }
 
@@ -243,5 +334,7 @@ func outputHistoryJSONExport(taskKey string, histories []*models.TaskHistory) er
 func init() {
 	// Register history command
 	taskHistoryCmd.Flags().StringVar(&taskHistoryFormat, "format", "", "Output format (csv, json)")
+	taskHistoryCmd.Flags().Int("limit", 0, "Show only the most recent N transitions (0 = all)")
+	taskHistoryCmd.Flags().String("since", "", "Show transitions since a duration ago (7d) or a date (YYYY-MM-DD)")
 	taskCmd.AddCommand(taskHistoryCmd)
 }
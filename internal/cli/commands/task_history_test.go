@@ -195,3 +195,40 @@ func TestFormatHistoryTableWithRejectionReason(t *testing.T) {
 		t.Logf("Empty reason correctly skipped")
 	}
 }
+
+func TestTimeTransitions(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	histories := []*models.TaskHistory{
+		{NewStatus: "todo", Timestamp: start},
+		{OldStatus: strPtr("todo"), NewStatus: "in_progress", Timestamp: start.Add(2 * time.Hour)},
+		{OldStatus: strPtr("in_progress"), NewStatus: "ready_for_review", Timestamp: start.Add(5 * time.Hour)},
+		{OldStatus: strPtr("ready_for_review"), NewStatus: "completed", Timestamp: start.Add(6 * time.Hour), Forced: true},
+	}
+
+	all := timeTransitions(histories, nil, 0)
+	if len(all) != 4 {
+		t.Fatalf("expected 4 transitions, got %d", len(all))
+	}
+	if all[0].Elapsed != nil {
+		t.Error("first transition should have no duration")
+	}
+	if *all[2].Elapsed != 3*time.Hour {
+		t.Errorf("expected 3h in in_progress, got %v", *all[2].Elapsed)
+	}
+
+	// --limit keeps the most recent transitions
+	limited := timeTransitions(histories, nil, 2)
+	if len(limited) != 2 || limited[0].NewStatus != "ready_for_review" || !limited[1].Forced {
+		t.Errorf("unexpected limited transitions: %+v", limited)
+	}
+
+	// --since drops older transitions but keeps durations computed from them
+	since := start.Add(90 * time.Minute)
+	recent := timeTransitions(histories, &since, 0)
+	if len(recent) != 3 {
+		t.Fatalf("expected 3 transitions since %v, got %d", since, len(recent))
+	}
+	if *recent[0].Elapsed != 2*time.Hour {
+		t.Errorf("expected 2h in todo, got %v", *recent[0].Elapsed)
+	}
+}
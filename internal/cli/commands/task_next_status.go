@@ -8,7 +8,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/config"
@@ -71,10 +70,10 @@ type NextStatusResult struct {
 }
 
 func runTaskNextStatus(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
-	taskKey, err := NormalizeTaskKey(args[0])
+	taskKey, err := ResolveTaskKey(cmd, args[0])
 	if err != nil {
 		return fmt.Errorf("invalid task key: %w", err)
 	}
@@ -103,16 +102,13 @@ func runTaskNextStatus(cmd *cobra.Command, args []string) error {
 	if reasonDocFlag != "" {
 		// Validate document path format
 		if err := ValidateRejectionReasonDocPath(reasonDocFlag); err != nil {
-			cli.Error(fmt.Sprintf("Invalid document path: %s", err.Error()))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Invalid document path: %s", err.Error()))
 		}
 
 		// Check if document file exists
 		fullPath := filepath.Join(projectRoot, reasonDocFlag)
 		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			cli.Error(fmt.Sprintf("Document not found: %s", reasonDocFlag))
-			cli.Info(fmt.Sprintf("Looked for file at: %s", fullPath))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Document not found: %s", reasonDocFlag), fmt.Sprintf("Looked for file at: %s", fullPath))
 		}
 
 		// Convert to pointer for passing to repository
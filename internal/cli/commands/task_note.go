@@ -47,8 +47,9 @@ Examples:
 
 // taskNotesCmd lists notes for a task
 var taskNotesCmd = &cobra.Command{
-	Use:   "notes <task-key>",
-	Short: "List notes for a task",
+	Use:         "notes <task-key>",
+	Short:       "List notes for a task",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `List all notes for a task, optionally filtered by type.
 
 Examples:
@@ -62,8 +63,9 @@ Examples:
 
 // taskTimelineCmd shows task timeline
 var taskTimelineCmd = &cobra.Command{
-	Use:   "timeline <task-key>",
-	Short: "Show task timeline with status changes and notes",
+	Use:         "timeline <task-key>",
+	Short:       "Show task timeline with status changes and notes",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `Show a unified chronological timeline of status changes and notes for a task.
 
 This command interleaves task status changes from task_history with notes from task_notes
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskRecurCmd sets or clears the recurrence rule of a task
+var taskRecurCmd = &cobra.Command{
+	Use:   "recur <task-key>",
+	Short: "Make a task recur on a schedule",
+	Long: `Turn a task into a recurring template.
+
+The task is archived and kept as a template. Each time the rule comes due,
+'shark recur run' creates a fresh todo task in the same feature with the
+template's title, description, agent type, priority, and labels.
+
+Rules are a number followed by h (hours), d (days), or w (weeks). The first
+occurrence is due at --start (default: now).
+
+With no flags the current rule is shown.
+
+Examples:
+  shark task recur E07-F01-004 --every=7d
+  shark task recur E07-F01-004 --every=2w --start=2026-01-05
+  shark task recur E07-F01-004 --stop
+  shark task recur E07-F01-004 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskRecur,
+}
+
+func init() {
+	taskCmd.AddCommand(taskRecurCmd)
+
+	taskRecurCmd.Flags().String("every", "", "Recurrence interval, e.g. 12h, 7d, 2w")
+	taskRecurCmd.Flags().String("start", "", "When the first occurrence is due (YYYY-MM-DD or RFC 3339; default: now)")
+	taskRecurCmd.Flags().Bool("stop", false, "Stop the task from recurring (the template stays archived)")
+	taskRecurCmd.MarkFlagsMutuallyExclusive("every", "stop")
+	taskRecurCmd.MarkFlagsMutuallyExclusive("start", "stop")
+}
+
+// runTaskRecur handles the task recur command
+func runTaskRecur(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	taskKey, err := ResolveTaskKey(cmd, args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task key: %w", err)
+	}
+
+	every, _ := cmd.Flags().GetString("every")
+	start, _ := cmd.Flags().GetString("start")
+	stop, _ := cmd.Flags().GetBool("stop")
+
+	if start != "" && every == "" {
+		return fmt.Errorf("--start requires --every")
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	task, err := repository.NewTaskRepository(repoDb).GetByKey(ctx, taskKey)
+	if err != nil {
+		return fmt.Errorf("task %s not found", taskKey)
+	}
+
+	recurRepo := repository.NewTaskRecurrenceRepository(repoDb)
+
+	var message string
+	switch {
+	case stop:
+		if err := recurRepo.Delete(ctx, task.ID); err != nil {
+			return fmt.Errorf("task %s does not recur", task.Key)
+		}
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"task_key": task.Key, "recurring": false})
+		}
+		cli.Success(fmt.Sprintf("%s no longer recurs (the template stays archived)", task.Key))
+		return nil
+	case every != "":
+		nextRunAt := time.Now()
+		if start != "" {
+			if nextRunAt, err = parseRecurStart(start); err != nil {
+				return err
+			}
+		}
+		rec := &models.TaskRecurrence{TaskID: task.ID, Rule: every, NextRunAt: nextRunAt}
+		if err := recurRepo.Set(ctx, rec); err != nil {
+			return err
+		}
+		message = fmt.Sprintf("%s now recurs every %s and is archived as the template", task.Key, every)
+	}
+
+	rec, err := recurRepo.GetByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"task_key": task.Key, "recurring": false})
+		}
+		fmt.Printf("%s does not recur. Use --every to schedule it.\n", task.Key)
+		return nil
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(rec)
+	}
+
+	if message != "" {
+		cli.Success(message)
+	}
+	printTaskRecurrence(rec)
+	return nil
+}
+
+// parseRecurStart parses --start as a date (local midnight) or an RFC 3339 time
+func parseRecurStart(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --start %q: use YYYY-MM-DD or RFC 3339 (2026-01-05T09:00:00Z)", value)
+}
+
+// printTaskRecurrence prints a task's recurrence rule and schedule
+func printTaskRecurrence(rec *models.TaskRecurrence) {
+	fmt.Printf("Task: %s\n", rec.TaskKey)
+	fmt.Printf("Every: %s\n", rec.Rule)
+	fmt.Printf("Next run: %s\n", rec.NextRunAt.Local().Format("2006-01-02 15:04"))
+	if rec.LastRunAt != nil {
+		last := rec.LastRunAt.Local().Format("2006-01-02 15:04")
+		if rec.LastTaskKey != nil {
+			last += " (" + *rec.LastTaskKey + ")"
+		}
+		fmt.Printf("Last run: %s\n", last)
+	}
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurStart(t *testing.T) {
+	got, err := parseRecurStart("2026-01-05")
+	if err != nil {
+		t.Fatalf("parseRecurStart(date) error = %v", err)
+	}
+	if want := time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("parseRecurStart(date) = %v, want %v", got, want)
+	}
+
+	got, err = parseRecurStart("2026-01-05T09:30:00Z")
+	if err != nil {
+		t.Fatalf("parseRecurStart(RFC 3339) error = %v", err)
+	}
+	if want := time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("parseRecurStart(RFC 3339) = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"next monday", "05/01/2026", ""} {
+		if _, err := parseRecurStart(bad); err == nil {
+			t.Errorf("parseRecurStart(%q) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/keys"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// taskReorderCmd rewrites the execution order of a feature's tasks
+var taskReorderCmd = &cobra.Command{
+	Use:   "reorder --feature=<feature-key> <task>...",
+	Short: "Set the execution order of a feature's tasks",
+	Long: `Rewrite execution_order for every task in a feature in one step.
+
+List all of the feature's tasks in the order they should run; they are numbered
+1..n. The list must contain each task in the feature exactly once, and nothing
+changes if it doesn't.
+
+Tasks can be given as full keys (T-E05-F01-003), short keys (E05-F01-003), or
+just the task number within the feature (T-003, 003, or 3).
+
+With --interactive, the current order is shown and the new order is entered as
+a list of positions.
+
+Examples:
+  shark task reorder --feature=E05-F01 T-003 T-001 T-002
+  shark task reorder --feature=E05-F01 3 1 2 --json
+  shark task reorder --feature=E05-F01 --interactive`,
+	RunE: runTaskReorder,
+}
+
+func init() {
+	taskCmd.AddCommand(taskReorderCmd)
+
+	taskReorderCmd.Flags().String("feature", "", "Feature whose tasks are reordered (required)")
+	taskReorderCmd.Flags().BoolP("interactive", "i", false, "Show the current order and prompt for the new one")
+	_ = taskReorderCmd.MarkFlagRequired("feature")
+}
+
+// runTaskReorder handles reordering a feature's tasks
+func runTaskReorder(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	featureKey, _ := cmd.Flags().GetString("feature")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+
+	if interactive && len(args) > 0 {
+		return fmt.Errorf("give either a task list or --interactive, not both")
+	}
+	if interactive && cli.GlobalConfig.JSON {
+		return fmt.Errorf("--interactive cannot be used with --json")
+	}
+	if !interactive && len(args) == 0 {
+		return fmt.Errorf("list the feature's tasks in their new order, or use --interactive")
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+
+	feature, err := repository.NewFeatureRepository(repoDb).GetByKey(ctx, featureKey)
+	if err != nil {
+		return fmt.Errorf("feature %s not found", featureKey)
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("feature %s has no tasks", feature.Key)
+	}
+
+	var ordered []*models.Task
+	if interactive {
+		ordered, err = promptForTaskOrder(tasks)
+		if err != nil {
+			return fmt.Errorf("no changes made: %w", err)
+		}
+	} else {
+		ordered, err = resolveTaskOrder(tasks, args)
+		if err != nil {
+			return err
+		}
+	}
+
+	taskIDs := make([]int64, len(ordered))
+	for i, task := range ordered {
+		taskIDs[i] = task.ID
+	}
+	if err := taskRepo.ReorderFeatureTasks(ctx, feature.ID, taskIDs); err != nil {
+		return fmt.Errorf("failed to reorder tasks: %w", err)
+	}
+
+	if cli.GlobalConfig.JSON {
+		order := make([]map[string]interface{}, len(ordered))
+		for i, task := range ordered {
+			order[i] = map[string]interface{}{
+				"execution_order": i + 1,
+				"key":             task.Key,
+				"title":           task.Title,
+				"status":          task.Status,
+			}
+		}
+		return cli.OutputJSON(map[string]interface{}{
+			"feature": feature.Key,
+			"tasks":   order,
+		})
+	}
+
+	rows := make([][]string, len(ordered))
+	for i, task := range ordered {
+		rows[i] = []string{strconv.Itoa(i + 1), task.Key, task.Title, string(task.Status)}
+	}
+	cli.OutputTable([]string{"Order", "Key", "Title", "Status"}, rows)
+	cli.Success(fmt.Sprintf("Reordered %d tasks in %s", len(ordered), feature.Key))
+	return nil
+}
+
+// resolveTaskOrder maps task references to the feature's tasks, checking that
+// every task is listed exactly once
+func resolveTaskOrder(tasks []*models.Task, refs []string) ([]*models.Task, error) {
+	ordered := make([]*models.Task, 0, len(refs))
+	seen := make(map[int64]bool, len(refs))
+
+	for _, ref := range refs {
+		task := matchFeatureTask(tasks, ref)
+		if task == nil {
+			return nil, fmt.Errorf("task %q is not in this feature", ref)
+		}
+		if seen[task.ID] {
+			return nil, fmt.Errorf("task %s is listed more than once", task.Key)
+		}
+		seen[task.ID] = true
+		ordered = append(ordered, task)
+	}
+
+	var missing []string
+	for _, task := range tasks {
+		if !seen[task.ID] {
+			missing = append(missing, task.Key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("every task in the feature must be listed; missing: %s", strings.Join(missing, ", "))
+	}
+
+	return ordered, nil
+}
+
+// matchFeatureTask finds the task a reference points to: a full or short task
+// key, or a task number within the feature (T-003, 003, 3)
+func matchFeatureTask(tasks []*models.Task, ref string) *models.Task {
+	ref = strings.ToUpper(strings.TrimSpace(ref))
+	if normalized, err := keys.NormalizeTaskKey(ref); err == nil {
+		ref = normalized
+	}
+	for _, task := range tasks {
+		if strings.EqualFold(task.Key, ref) {
+			return task
+		}
+	}
+
+	num, err := keys.ParseTaskNumber(strings.TrimPrefix(ref, "T-"))
+	if err != nil {
+		return nil
+	}
+	for _, task := range tasks {
+		idx := strings.LastIndex(task.Key, "-")
+		if taskNum, err := keys.ParseTaskNumber(task.Key[idx+1:]); err == nil && taskNum == num {
+			return task
+		}
+	}
+	return nil
+}
+
+// promptForTaskOrder shows the current order and reads the new one as a list
+// of positions (e.g. "3 1 2")
+func promptForTaskOrder(tasks []*models.Task) ([]*models.Task, error) {
+	fmt.Println("\nCurrent order:")
+	for i, task := range tasks {
+		fmt.Printf("  %d) %s  %s [%s]\n", i+1, task.Key, task.Title, task.Status)
+	}
+	fmt.Println()
+	fmt.Printf("Enter the new order as positions 1-%d separated by spaces, or Ctrl+C to cancel: ", len(tasks))
+
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && (err != io.EOF || input == "") {
+		return nil, err
+	}
+
+	refs := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t' || r == '\n' || r == '\r'
+	})
+	ordered := make([]*models.Task, 0, len(refs))
+	for _, ref := range refs {
+		pos, err := strconv.Atoi(ref)
+		if err != nil || pos < 1 || pos > len(tasks) {
+			return nil, fmt.Errorf("invalid position %q (must be 1-%d)", ref, len(tasks))
+		}
+		ordered = append(ordered, tasks[pos-1])
+	}
+
+	return resolveTaskOrder(tasks, taskKeys(ordered))
+}
+
+// taskKeys returns the keys of tasks, in order
+func taskKeys(tasks []*models.Task) []string {
+	result := make([]string, len(tasks))
+	for i, task := range tasks {
+		result[i] = task.Key
+	}
+	return result
+}
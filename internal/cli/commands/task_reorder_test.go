@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTaskOrder(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: 1, Key: "T-E05-F01-001"},
+		{ID: 2, Key: "T-E05-F01-002"},
+		{ID: 3, Key: "T-E05-F01-003"},
+	}
+
+	tests := []struct {
+		name    string
+		refs    []string
+		want    []string
+		wantErr string
+	}{
+		{name: "task numbers", refs: []string{"T-003", "T-001", "T-002"}, want: []string{"T-E05-F01-003", "T-E05-F01-001", "T-E05-F01-002"}},
+		{name: "mixed key forms", refs: []string{"e05-f01-002", "T-E05-F01-003", "1"}, want: []string{"T-E05-F01-002", "T-E05-F01-003", "T-E05-F01-001"}},
+		{name: "missing task", refs: []string{"1", "2"}, wantErr: "missing: T-E05-F01-003"},
+		{name: "duplicate task", refs: []string{"1", "T-001", "2"}, wantErr: "listed more than once"},
+		{name: "task from another feature", refs: []string{"1", "2", "T-E05-F02-003"}, wantErr: "not in this feature"},
+		{name: "unknown number", refs: []string{"1", "2", "4"}, wantErr: "not in this feature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTaskOrder(tasks, tt.refs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, taskKeys(got))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// Reprioritize strategies
+const (
+	reprioritizeSpread          = "spread"
+	reprioritizeDependencyOrder = "dependency-order"
+)
+
+// taskReprioritizeCmd reassigns priorities across a feature's open tasks
+var taskReprioritizeCmd = &cobra.Command{
+	Use:   "reprioritize --feature=<feature-key>",
+	Short: "Reassign priorities across a feature's open tasks",
+	Long: `Reassign the priorities of a feature's open tasks when they have drifted
+(for example, everything at priority 5).
+
+Tasks are ranked by dependency depth (a task that depends on another open task
+in the feature is one level deeper), then execution_order, then their current
+priority. Completed and archived tasks are left alone.
+
+Strategies:
+  spread            Spread the ranked tasks evenly across priorities 1-10 (default)
+  dependency-order  Priority is dependency depth + 1: tasks with no open
+                    dependencies get 1, their dependents 2, and so on (max 10)
+
+All changes are made in one transaction. A before/after table is printed;
+--dry-run prints it without changing anything.
+
+Examples:
+  shark task reprioritize --feature=E05-F01
+  shark task reprioritize --feature=E05-F01 --strategy=dependency-order
+  shark task reprioritize --feature=E05-F01 --dry-run --json`,
+	Args: cobra.NoArgs,
+	RunE: runTaskReprioritize,
+}
+
+func init() {
+	taskCmd.AddCommand(taskReprioritizeCmd)
+
+	taskReprioritizeCmd.Flags().String("feature", "", "Feature whose tasks are reprioritized (required)")
+	taskReprioritizeCmd.Flags().String("strategy", reprioritizeSpread, "Strategy: spread or dependency-order")
+	taskReprioritizeCmd.Flags().Bool("dry-run", false, "Show the new priorities without saving them")
+	_ = taskReprioritizeCmd.MarkFlagRequired("feature")
+}
+
+// priorityChange is one task's priority before and after reprioritizing
+type priorityChange struct {
+	Task   *models.Task `json:"-"`
+	Key    string       `json:"key"`
+	Title  string       `json:"title"`
+	Depth  int          `json:"depth"`
+	Before int          `json:"before"`
+	After  int          `json:"after"`
+}
+
+// runTaskReprioritize handles reprioritizing a feature's tasks
+func runTaskReprioritize(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	featureKey, _ := cmd.Flags().GetString("feature")
+	strategy, _ := cmd.Flags().GetString("strategy")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if strategy != reprioritizeSpread && strategy != reprioritizeDependencyOrder {
+		return fmt.Errorf("invalid strategy %q: use %s or %s", strategy, reprioritizeSpread, reprioritizeDependencyOrder)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	feature, err := repository.NewFeatureRepository(repoDb).GetByKey(ctx, featureKey)
+	if err != nil {
+		return fmt.Errorf("feature %s not found", featureKey)
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	tasks, err := taskRepo.ListByFeature(ctx, feature.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	changes, err := planReprioritize(tasks, strategy)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("feature %s has no open tasks", feature.Key)
+	}
+
+	priorities := make(map[int64]int)
+	for _, change := range changes {
+		if change.After != change.Before {
+			priorities[change.Task.ID] = change.After
+		}
+	}
+	if !dryRun && len(priorities) > 0 {
+		if err := taskRepo.SetFeaturePriorities(ctx, feature.ID, priorities); err != nil {
+			return fmt.Errorf("failed to reprioritize tasks: %w", err)
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"feature":  feature.Key,
+			"strategy": strategy,
+			"dry_run":  dryRun,
+			"changed":  len(priorities),
+			"tasks":    changes,
+		})
+	}
+
+	rows := make([][]string, len(changes))
+	for i, change := range changes {
+		after := strconv.Itoa(change.After)
+		if change.After != change.Before {
+			after += " *"
+		}
+		rows[i] = []string{change.Key, truncateCell(change.Title, 50), strconv.Itoa(change.Depth), strconv.Itoa(change.Before), after}
+	}
+	cli.OutputTable([]string{"Key", "Title", "Depth", "Before", "After"}, rows)
+
+	if dryRun {
+		cli.Info("Dry run: %d of %d priorities would change", len(priorities), len(changes))
+		return nil
+	}
+	cli.Success(fmt.Sprintf("Reprioritized %d of %d open tasks in %s", len(priorities), len(changes), feature.Key))
+	return nil
+}
+
+// planReprioritize ranks a feature's open tasks by dependency depth, then by
+// their current order (execution_order, then priority), and assigns new
+// priorities using strategy
+func planReprioritize(tasks []*models.Task, strategy string) ([]*priorityChange, error) {
+	open := make(map[string]*models.Task)
+	var ranked []*models.Task
+	for _, task := range tasks {
+		if isOpenTask(task) {
+			open[task.Key] = task
+			ranked = append(ranked, task)
+		}
+	}
+
+	depths := make(map[string]int, len(ranked))
+	for _, task := range ranked {
+		if _, err := dependencyDepth(task, open, depths, map[string]bool{}); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if depths[a.Key] != depths[b.Key] {
+			return depths[a.Key] < depths[b.Key]
+		}
+		if (a.ExecutionOrder == nil) != (b.ExecutionOrder == nil) {
+			return a.ExecutionOrder != nil
+		}
+		if a.ExecutionOrder != nil && *a.ExecutionOrder != *b.ExecutionOrder {
+			return *a.ExecutionOrder < *b.ExecutionOrder
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Key < b.Key
+	})
+
+	changes := make([]*priorityChange, len(ranked))
+	for i, task := range ranked {
+		var after int
+		switch strategy {
+		case reprioritizeDependencyOrder:
+			after = min(depths[task.Key]+1, 10)
+		default:
+			after = 1
+			if len(ranked) > 1 {
+				after = 1 + i*9/(len(ranked)-1)
+			}
+		}
+		changes[i] = &priorityChange{
+			Task:   task,
+			Key:    task.Key,
+			Title:  task.Title,
+			Depth:  depths[task.Key],
+			Before: task.Priority,
+			After:  after,
+		}
+	}
+	return changes, nil
+}
+
+// dependencyDepth returns how many levels of open, same-feature dependencies
+// sit below task. Dependencies on closed tasks or other features don't count.
+func dependencyDepth(task *models.Task, open map[string]*models.Task, depths map[string]int, visiting map[string]bool) (int, error) {
+	if depth, ok := depths[task.Key]; ok {
+		return depth, nil
+	}
+	if visiting[task.Key] {
+		return 0, fmt.Errorf("dependency cycle involving %s", task.Key)
+	}
+	visiting[task.Key] = true
+
+	depth := 0
+	if task.DependsOn != nil && *task.DependsOn != "" {
+		var deps []string
+		if err := json.Unmarshal([]byte(*task.DependsOn), &deps); err == nil {
+			for _, depKey := range deps {
+				dep, ok := open[depKey]
+				if !ok {
+					continue
+				}
+				depDepth, err := dependencyDepth(dep, open, depths, visiting)
+				if err != nil {
+					return 0, err
+				}
+				depth = max(depth, depDepth+1)
+			}
+		}
+	}
+
+	delete(visiting, task.Key)
+	depths[task.Key] = depth
+	return depth, nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanReprioritize(t *testing.T) {
+	deps := func(keys string) *string { return &keys }
+	order := func(n int) *int { return &n }
+
+	// 003 depends on 001; 004 depends on 003; 002 is independent but ordered
+	// after 001; 005 is completed and left alone
+	tasks := []*models.Task{
+		{ID: 1, Key: "T-E05-F01-001", Status: models.TaskStatusTodo, Priority: 5, ExecutionOrder: order(1)},
+		{ID: 2, Key: "T-E05-F01-002", Status: models.TaskStatusTodo, Priority: 5, ExecutionOrder: order(2)},
+		{ID: 3, Key: "T-E05-F01-003", Status: models.TaskStatusInProgress, Priority: 5, DependsOn: deps(`["T-E05-F01-001"]`)},
+		{ID: 4, Key: "T-E05-F01-004", Status: models.TaskStatusTodo, Priority: 5, DependsOn: deps(`["T-E05-F01-003","T-E05-F01-005","T-E09-F01-001"]`)},
+		{ID: 5, Key: "T-E05-F01-005", Status: models.TaskStatusCompleted, Priority: 5},
+	}
+
+	t.Run("spread", func(t *testing.T) {
+		changes, err := planReprioritize(tasks, reprioritizeSpread)
+		require.NoError(t, err)
+		require.Len(t, changes, 4)
+
+		var keys []string
+		var after, depth []int
+		for _, c := range changes {
+			keys = append(keys, c.Key)
+			after = append(after, c.After)
+			depth = append(depth, c.Depth)
+		}
+		assert.Equal(t, []string{"T-E05-F01-001", "T-E05-F01-002", "T-E05-F01-003", "T-E05-F01-004"}, keys)
+		assert.Equal(t, []int{0, 0, 1, 2}, depth)
+		assert.Equal(t, []int{1, 4, 7, 10}, after)
+		assert.Equal(t, 5, changes[0].Before)
+	})
+
+	t.Run("dependency order", func(t *testing.T) {
+		changes, err := planReprioritize(tasks, reprioritizeDependencyOrder)
+		require.NoError(t, err)
+
+		var after []int
+		for _, c := range changes {
+			after = append(after, c.After)
+		}
+		assert.Equal(t, []int{1, 1, 2, 3}, after)
+	})
+
+	t.Run("single task", func(t *testing.T) {
+		changes, err := planReprioritize(tasks[:1], reprioritizeSpread)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, 1, changes[0].After)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		cyclic := []*models.Task{
+			{ID: 1, Key: "T-E05-F01-001", Status: models.TaskStatusTodo, Priority: 5, DependsOn: deps(`["T-E05-F01-002"]`)},
+			{ID: 2, Key: "T-E05-F01-002", Status: models.TaskStatusTodo, Priority: 5, DependsOn: deps(`["T-E05-F01-001"]`)},
+		}
+		_, err := planReprioritize(cyclic, reprioritizeSpread)
+		assert.ErrorContains(t, err, "dependency cycle")
+	})
+}
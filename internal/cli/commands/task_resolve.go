@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// taskNumberPattern matches a task number with an optional feature:
+	// 003, 3, F01-003
+	taskNumberPattern = regexp.MustCompile(`^(?i)(F[0-9]{2}-)?([0-9]{1,3})$`)
+	taskSlugPattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// maxAmbiguousCandidates bounds the task keys listed in an ambiguity error
+const maxAmbiguousCandidates = 10
+
+// ResolveTaskKey turns what the user typed for a task into its key. Besides
+// full and short task keys it accepts, looked up in the database:
+//
+//   - a key the task had before shark feature resequence, while no task holds it
+//   - an alias from shark alias add (login)
+//   - a task number, with or without its feature (003, F01-003)
+//   - a task slug, or the start of one (build-login)
+//
+// Task numbers and slugs are matched in the focused epic first, then in the
+// whole project. A shorthand matching several tasks is an error listing them.
+func ResolveTaskKey(cmd *cobra.Command, input string) (string, error) {
+	if key, err := NormalizeTaskKey(input); err == nil {
+		return resolveOldTaskKey(cmd, key), nil
+	}
+
+	value := strings.ToLower(strings.TrimSpace(input))
+	number := taskNumberPattern.FindStringSubmatch(value)
+	_, aliasErr := models.NormalizeTaskAlias(value)
+	if number == nil && aliasErr != nil && !taskSlugPattern.MatchString(value) {
+		return "", InvalidTaskKeyError(input)
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return "", fmt.Errorf("failed to get database: %w", err)
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if aliasErr == nil {
+		key, err := repository.NewTaskAliasRepository(repoDb).GetTaskKey(ctx, value)
+		if err != nil || key != "" {
+			return key, err
+		}
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	find := func(epicKey string) ([]string, error) {
+		if number != nil {
+			n, _ := strconv.Atoi(number[2])
+			suffix := fmt.Sprintf("-%03d", n)
+			if number[1] != "" {
+				suffix = "-" + strings.ToUpper(number[1]) + suffix[1:]
+			}
+			return taskRepo.FindKeysBySuffix(ctx, suffix, epicKey)
+		}
+		if taskSlugPattern.MatchString(value) {
+			return taskRepo.FindKeysBySlug(ctx, value, epicKey)
+		}
+		return nil, nil
+	}
+
+	var candidates []string
+	if epicKey := focusedEpic(); epicKey != "" {
+		if candidates, err = find(epicKey); err != nil {
+			return "", err
+		}
+	}
+	if len(candidates) == 0 {
+		if candidates, err = find(""); err != nil {
+			return "", err
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("no task matches %q", input)).
+			WithHint("Use a task key (T-E07-F01-001), a task number, a slug, or an alias from 'shark alias list'")
+	case 1:
+		return candidates[0], nil
+	}
+	return "", ambiguousTaskKeyError(input, candidates)
+}
+
+// resolveOldTaskKey returns the current key of the task that had key before it
+// was resequenced, or key itself. Lookup failures leave key to the command,
+// which reports it as not found.
+func resolveOldTaskKey(cmd *cobra.Command, key string) string {
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return key
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if current, err := repository.NewTaskAliasRepository(repoDb).GetTaskKeyByOldKey(ctx, key); err == nil && current != "" {
+		return current
+	}
+	return key
+}
+
+// ambiguousTaskKeyError reports a shorthand that matches several tasks
+func ambiguousTaskKeyError(input string, candidates []string) error {
+	listed := candidates
+	if len(listed) > maxAmbiguousCandidates {
+		listed = listed[:maxAmbiguousCandidates]
+	}
+	message := fmt.Sprintf("%q matches %d tasks: %s", input, len(candidates), strings.Join(listed, ", "))
+	if len(candidates) > len(listed) {
+		message += ", ..."
+	}
+	return cli.NewError(cli.ErrCodeInvalidArgument, message).
+		WithHint("Use the full task key, or 'shark focus <epic>' to match within one epic")
+}
@@ -1,10 +1,8 @@
 package commands
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -52,7 +50,7 @@ type ResumeContext struct {
 
 // runTaskResume retrieves and displays comprehensive task context
 func runTaskResume(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	taskKey := args[0]
@@ -73,8 +71,7 @@ func runTaskResume(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := taskRepo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task %s not found", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task %s not found", taskKey))
 	}
 
 	// Build resume context
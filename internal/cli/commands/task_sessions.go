@@ -1,10 +1,7 @@
 package commands
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -14,8 +11,9 @@ import (
 
 // taskSessionsCmd displays all work sessions for a task
 var taskSessionsCmd = &cobra.Command{
-	Use:   "sessions <task-key>",
-	Short: "View all work sessions for a task",
+	Use:         "sessions <task-key>",
+	Short:       "View all work sessions for a task",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
 	Long: `View all work sessions for a task with durations and outcomes.
 
 Shows:
@@ -39,7 +37,7 @@ func init() {
 
 // runTaskSessions displays work sessions for a task
 func runTaskSessions(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	taskKey := args[0]
@@ -59,8 +57,7 @@ func runTaskSessions(cmd *cobra.Command, args []string) error {
 	// Get task by key
 	task, err := taskRepo.GetByKey(ctx, taskKey)
 	if err != nil {
-		cli.Error(fmt.Sprintf("Task %s not found", taskKey))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Task %s not found", taskKey))
 	}
 
 	// Get work sessions
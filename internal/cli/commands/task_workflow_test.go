@@ -348,17 +348,25 @@ func TestAgentIdentification(t *testing.T) {
 	tests := []struct {
 		name       string
 		flagValue  string
+		envValue   string
 		expectedID string
 	}{
 		{
 			name:       "flag_provided",
 			flagValue:  "custom-agent",
+			envValue:   "registered-agent",
 			expectedID: "custom-agent",
 		},
+		{
+			name:       "shark_agent_env",
+			envValue:   "registered-agent",
+			expectedID: "registered-agent",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SHARK_AGENT", tt.envValue)
 			result := getAgentIdentifier(tt.flagValue)
 			if result != tt.expectedID {
 				t.Errorf("Expected agent ID '%s', got '%s'", tt.expectedID, result)
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// templateCmd is the parent command for task and epic template operations
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Short:   "Manage task and epic templates",
+	GroupID: "setup",
+	Long: `List and validate the templates available to 'shark task create --template'
+and 'shark epic create --template'.
+
+Task templates are resolved from two sources:
+  - Project templates: shark-templates/tasks/<name>.md (take precedence)
+  - Built-in agent templates: frontend, backend, api, testing, devops, general
+
+Epic templates are project templates: shark-templates/epics/<name>.md
+
+Task templates have access to these variables:
+  {{.Key}} {{.Title}} {{.Description}} {{.AgentType}} {{.Priority}} {{.CreatedAt}}
+  {{.Epic}} {{.EpicTitle}} {{.Feature}} {{.FeatureTitle}} {{.FeatureSlug}} {{.DependsOn}}
+  {{.Vars.<name>}}  user-defined variables passed via --var name=value
+
+Epic templates have access to these variables:
+  {{.EpicKey}} {{.EpicSlug}} {{.Title}} {{.Description}} {{.FilePath}} {{.Date}}
+  {{.Vars.<name>}}  user-defined variables passed via --var name=value`,
+}
+
+// templateListCmd lists available templates
+var templateListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List available task and epic templates",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List all task and epic templates in the registry with their source.
+
+Examples:
+  shark template list
+  shark template list --kind=epic
+  shark template list --json`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateList,
+}
+
+// templateValidateCmd validates templates
+var templateValidateCmd = &cobra.Command{
+	Use:         "validate [name]",
+	Short:       "Validate task and epic templates",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Parse and render templates against sample data to catch syntax errors
+and references to unknown fields. Validates all templates when no name is given;
+a named template is looked up among task templates unless --kind=epic is given.
+
+Examples:
+  shark template validate
+  shark template validate bugfix
+  shark template validate initiative --kind=epic
+  shark template validate ./my-template.md`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplateValidate,
+}
+
+// TemplateValidationResult holds the validation outcome for a single template
+type TemplateValidationResult struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// newProjectTemplateRegistries creates the registries of the given kind (both
+// when kind is empty) rooted at the project's template directories
+func newProjectTemplateRegistries(kind string) ([]*templates.Registry, error) {
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	var registries []*templates.Registry
+	if kind == "" || kind == templates.KindTask {
+		registries = append(registries, templates.NewRegistry(filepath.Join(projectRoot, templates.DefaultProjectTemplateDir)))
+	}
+	if kind == "" || kind == templates.KindEpic {
+		registries = append(registries, templates.NewEpicRegistry(filepath.Join(projectRoot, templates.DefaultProjectEpicTemplateDir)))
+	}
+	if len(registries) == 0 {
+		return nil, cli.NewError(cli.ErrCodeInvalidArgument, fmt.Sprintf("invalid --kind %q: must be task or epic", kind))
+	}
+	return registries, nil
+}
+
+// runTemplateList handles the template list command
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	kind, _ := cmd.Flags().GetString("kind")
+	registries, err := newProjectTemplateRegistries(kind)
+	if err != nil {
+		return err
+	}
+
+	var infos []templates.TemplateInfo
+	for _, registry := range registries {
+		kindInfos, err := registry.List()
+		if err != nil {
+			return fmt.Errorf("failed to list templates: %w", err)
+		}
+		infos = append(infos, kindInfos...)
+	}
+	if infos == nil {
+		infos = []templates.TemplateInfo{}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"results": infos,
+			"count":   len(infos),
+		})
+	}
+
+	rows := make([][]string, 0, len(infos))
+	for _, info := range infos {
+		rows = append(rows, []string{info.Name, info.Kind, info.Source, info.Path})
+	}
+	cli.OutputTable([]string{"Name", "Kind", "Source", "Path"}, rows)
+	return nil
+}
+
+// runTemplateValidate handles the template validate command
+func runTemplateValidate(cmd *cobra.Command, args []string) error {
+	kind, _ := cmd.Flags().GetString("kind")
+	if len(args) == 1 && kind == "" {
+		kind = templates.KindTask
+	}
+	registries, err := newProjectTemplateRegistries(kind)
+	if err != nil {
+		return err
+	}
+
+	var results []TemplateValidationResult
+	for _, registry := range registries {
+		var names []string
+		if len(args) == 1 {
+			names = []string{args[0]}
+		} else {
+			infos, err := registry.List()
+			if err != nil {
+				return fmt.Errorf("failed to list templates: %w", err)
+			}
+			for _, info := range infos {
+				names = append(names, info.Name)
+			}
+		}
+		results = append(results, validateTemplates(registry, names)...)
+	}
+
+	invalid := 0
+	for _, result := range results {
+		if !result.Valid {
+			invalid++
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		if err := cli.OutputJSON(map[string]interface{}{
+			"results": results,
+			"valid":   invalid == 0,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range results {
+			if result.Valid {
+				cli.Success(fmt.Sprintf("%s %s: valid", result.Kind, result.Name))
+			} else {
+				cli.Error(fmt.Sprintf("%s %s: %s", result.Kind, result.Name, result.Error))
+			}
+		}
+	}
+
+	if invalid > 0 {
+		cli.Exit(cli.ExitFailure)
+	}
+	return nil
+}
+
+// validateTemplates validates each named template and collects the results
+func validateTemplates(registry *templates.Registry, names []string) []TemplateValidationResult {
+	results := make([]TemplateValidationResult, 0, len(names))
+	for _, name := range names {
+		result := TemplateValidationResult{Name: name, Kind: registry.Kind(), Valid: true}
+		if err := registry.Validate(name); err != nil {
+			result.Valid = false
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func init() {
+	cli.RootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateValidateCmd)
+
+	templateListCmd.Flags().String("kind", "", "Only list templates of this kind: task or epic")
+	templateValidateCmd.Flags().String("kind", "", "Only validate templates of this kind: task or epic")
+}
@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/keys"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// trashCmd represents the trash command group
+var trashCmd = &cobra.Command{
+	Use:     "trash",
+	Short:   "Manage deleted features and tasks",
+	GroupID: "setup",
+	Long: `List, restore, or permanently delete trashed features and tasks.
+
+'shark feature delete' and 'shark task delete' move entities to the trash
+instead of removing them. Trashed entities are hidden from lists, status, and
+reports, but keep their keys until the trash is emptied.`,
+}
+
+// trashListCmd lists trashed features and tasks
+var trashListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List trashed features and tasks",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List trashed features and tasks, most recently deleted first.
+
+Examples:
+  shark trash list
+  shark trash list --json`,
+	Args: cobra.NoArgs,
+	RunE: runTrashList,
+}
+
+// trashRestoreCmd restores a trashed feature or task
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <key>",
+	Short: "Restore a trashed feature or task",
+	Long: `Take a feature or task out of the trash.
+
+Restoring a feature also restores the tasks that were trashed with it. Tasks
+deleted on their own before the feature stay in the trash. A task can't be
+restored while its feature is in the trash.
+
+Examples:
+  shark trash restore E04-F02
+  shark trash restore T-E04-F02-003
+  shark trash restore e04-f02-003`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrashRestore,
+}
+
+// trashEmptyCmd permanently deletes everything in the trash
+var trashEmptyCmd = &cobra.Command{
+	Use:         "empty",
+	Short:       "Permanently delete everything in the trash",
+	Annotations: map[string]string{cli.DestructiveAnnotation: "true"},
+	Long: `Permanently delete all trashed features and tasks, with their history,
+notes, and other dependent rows.
+
+A database backup is created first. Use --dry-run to preview the effect and get
+a confirmation token. When require_confirmation_tokens is enabled in
+.sharkconfig.json, the token must be passed with --confirm to execute.
+
+Examples:
+  shark trash empty --dry-run
+  shark trash empty
+  shark trash empty --confirm=<token>`,
+	Args: cobra.NoArgs,
+	RunE: runTrashEmpty,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+
+	addConfirmationFlags(trashEmptyCmd)
+}
+
+// runTrashList executes the trash list command
+func runTrashList(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	items, err := repository.NewTrashRepository(repoDb).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(items)
+	}
+
+	if len(items) == 0 {
+		cli.Info("The trash is empty")
+		return nil
+	}
+
+	headers := []string{"Type", "Key", "Title", "Parent", "Deleted"}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = []string{
+			item.Type,
+			item.Key,
+			item.Title,
+			item.ParentKey,
+			item.DeletedAt.Local().Format("2006-01-02 15:04:05"),
+		}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// runTrashRestore executes the trash restore command
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	trashRepo := repository.NewTrashRepository(repoDb)
+	item, err := trashRepo.GetByKey(ctx, trashKey(args[0]))
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Error: %s is not in the trash", args[0]), "Use 'shark trash list' to see trashed features and tasks")
+	}
+
+	tasks, err := trashRepo.Restore(ctx, item)
+	if err != nil {
+		return err
+	}
+	recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: item.Type, EntityKey: item.Key, Action: models.AuditActionRestore, Summary: "Restored from the trash"})
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"restored":       item,
+			"restored_tasks": tasks,
+		})
+	}
+
+	if item.Type == models.TrashTypeFeature {
+		cli.Success(fmt.Sprintf("Feature %s and %d task(s) restored", item.Key, tasks))
+	} else {
+		cli.Success(fmt.Sprintf("Task %s restored", item.Key))
+		if task, err := repository.NewTaskRepository(repoDb).GetByID(ctx, item.ID); err == nil {
+			triggerStatusCascade(ctx, repoDb, task.FeatureID)
+		}
+	}
+	return nil
+}
+
+// runTrashEmpty executes the trash empty command
+func runTrashEmpty(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	trashRepo := repository.NewTrashRepository(repoDb)
+	items, err := trashRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		cli.Info("The trash is empty")
+		return nil
+	}
+
+	// Tasks trashed with their feature are snapshotted with the feature's cascade
+	trashedFeatures := make(map[string]bool)
+	var featureIDs []int64
+	affected := make([]string, 0, len(items))
+	for _, item := range items {
+		affected = append(affected, item.Key)
+		if item.Type == models.TrashTypeFeature {
+			trashedFeatures[item.Key] = true
+			featureIDs = append(featureIDs, item.ID)
+		}
+	}
+	var taskIDs []int64
+	for _, item := range items {
+		if item.Type == models.TrashTypeTask && !trashedFeatures[item.ParentKey] {
+			taskIDs = append(taskIDs, item.ID)
+		}
+	}
+
+	op := &DestructiveOperation{
+		Operation: "trash",
+		Key:       "empty",
+		Summary:   fmt.Sprintf("Would permanently delete %d trashed feature(s) and task(s)", len(items)),
+		Affected:  affected,
+	}
+
+	if isDryRun(cmd) {
+		return printDestructiveDryRun(op)
+	}
+
+	if err := verifyConfirmationToken(cmd, op); err != nil {
+		return err
+	}
+
+	dbPath, canBackup, err := cli.GetDatabasePathForBackup()
+	if err != nil {
+		return fmt.Errorf("failed to get database path for backup: %w", err)
+	}
+	if canBackup {
+		backupPath, err := createDatabaseBackup(dbPath, "trash empty")
+		if err != nil {
+			return fmt.Errorf("failed to create backup before emptying the trash: %w", err)
+		}
+		if !cli.GlobalConfig.JSON {
+			cli.Info(fmt.Sprintf("Database backup created: %s", backupPath))
+		}
+	}
+
+	// Snapshot the trashed rows and their cascades for 'shark undo'
+	journal := newUndoJournal(repoDb)
+	journal.captureDelete(ctx, "features", featureIDs...)
+	journal.captureDelete(ctx, "tasks", taskIDs...)
+
+	features, tasks, err := trashRepo.Empty(ctx)
+	if err != nil {
+		return err
+	}
+	journal.record(ctx, "trash", "empty", fmt.Sprintf("Emptied the trash: %d feature(s), %d task(s)", features, tasks))
+	for _, item := range items {
+		recordAudit(ctx, repoDb, &models.AuditEntry{EntityType: item.Type, EntityKey: item.Key, Action: models.AuditActionDelete, Summary: "Permanently deleted from the trash"})
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"deleted_features": features,
+			"deleted_tasks":    tasks,
+		})
+	}
+
+	cli.Success(fmt.Sprintf("Trash emptied: %d feature(s) and %d task(s) permanently deleted", features, tasks))
+	return nil
+}
+
+// trashKey converts a restore argument to the key stored in the trash.
+// Task keys may be short or slugged; feature keys may be slugged.
+func trashKey(arg string) string {
+	if taskKey, err := keys.NormalizeTaskKey(arg); err == nil {
+		return taskKey[:len("T-E00-F00-000")]
+	}
+	key := keys.Normalize(arg)
+	if len(key) >= len("E00-F00") && keys.IsFeatureKey(key[:len("E00-F00")]) {
+		return key[:len("E00-F00")]
+	}
+	return key
+}
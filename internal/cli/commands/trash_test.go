@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashKey(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"T-E04-F02-003", "T-E04-F02-003"},
+		{"e04-f02-003", "T-E04-F02-003"},
+		{"T-E04-F02-003-login-form", "T-E04-F02-003"},
+		{"E04-F02", "E04-F02"},
+		{"e04-f02-user-auth", "E04-F02"},
+		{"unknown", "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			assert.Equal(t, tt.want, trashKey(tt.arg))
+		})
+	}
+}
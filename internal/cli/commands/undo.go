@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// undoCmd reverts the most recent destructive operation
+var undoCmd = &cobra.Command{
+	Use:     "undo",
+	Short:   "Undo the most recent destructive operation",
+	GroupID: "setup",
+	Long: `Revert the most recent destructive operation recorded in the operation journal.
+
+Journaled operations:
+  epic delete, feature delete, task delete   Deleted rows (and cascaded rows) are restored with their original IDs;
+                                             trashed features and tasks are taken out of the trash
+  trash empty                                Emptied features and tasks are restored to the trash
+  epic complete --force, feature complete --force
+                                             Task, feature, and epic statuses are restored
+  --force file reassignment on create        The file is handed back to its previous owner
+
+Each run undoes one operation; run it again to undo the one before. Only the
+most recent operations are kept (` + strconv.Itoa(repository.MaxJournalEntries) + `). Markdown files are not touched.
+
+Examples:
+  shark undo --list          Show journaled operations
+  shark undo --dry-run       Show what would be undone
+  shark undo                 Undo the most recent operation`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(undoCmd)
+
+	undoCmd.Flags().Bool("list", false, "List journaled operations, newest first")
+	undoCmd.Flags().Bool("dry-run", false, "Show the operation that would be undone without changing anything")
+}
+
+// runUndo executes the undo command
+func runUndo(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	journalRepo := repository.NewOperationJournalRepository(repoDb)
+
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		return listUndoJournal(ctx, journalRepo)
+	}
+
+	entry, err := journalRepo.GetLatestUndoable(ctx)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		if cli.GlobalConfig.JSON {
+			return cli.OutputJSON(map[string]interface{}{"undone": false, "message": "Nothing to undo"})
+		}
+		cli.Info("Nothing to undo")
+		return nil
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		if err := journalRepo.Undo(ctx, entry); err != nil {
+			return fmt.Errorf("failed to undo %s %s: %w (later changes may conflict; 'shark db restore' can restore a backup instead)",
+				entry.Operation, entry.EntityKey, err)
+		}
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"undone":    !dryRun,
+			"dry_run":   dryRun,
+			"operation": entry,
+			"rows":      snapshotRowCount(entry.Snapshot),
+		})
+	}
+
+	if dryRun {
+		fmt.Printf("Would undo: %s %s (%s)\n", entry.Operation, entry.EntityKey, entry.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+		if entry.Summary != "" {
+			fmt.Printf("  %s\n", entry.Summary)
+		}
+		fmt.Printf("  Restores %d row(s)\n", snapshotRowCount(entry.Snapshot))
+		return nil
+	}
+
+	cli.Success(fmt.Sprintf("Undid %s %s (%d row(s) restored)", entry.Operation, entry.EntityKey, snapshotRowCount(entry.Snapshot)))
+	return nil
+}
+
+// listUndoJournal prints the journaled operations
+func listUndoJournal(ctx context.Context, journalRepo *repository.OperationJournalRepository) error {
+	entries, err := journalRepo.List(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		if entries == nil {
+			entries = []*models.OperationJournalEntry{}
+		}
+		return cli.OutputJSON(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No journaled operations")
+		return nil
+	}
+
+	headers := []string{"ID", "When", "Operation", "Key", "Summary", "Undone"}
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		undone := ""
+		if e.UndoneAt != nil {
+			undone = e.UndoneAt.Local().Format("2006-01-02 15:04:05")
+		}
+		rows[i] = []string{
+			strconv.FormatInt(e.ID, 10),
+			e.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			e.Operation,
+			e.EntityKey,
+			e.Summary,
+			undone,
+		}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// snapshotRowCount counts the rows a snapshot restores
+func snapshotRowCount(snapshot *models.JournalSnapshot) int {
+	if snapshot == nil {
+		return 0
+	}
+	count := 0
+	for _, set := range snapshot.Deleted {
+		count += len(set.Rows)
+	}
+	for _, set := range snapshot.Updated {
+		count += len(set.Rows)
+	}
+	return count
+}
+
+// undoJournal collects row snapshots before a destructive operation and records
+// them afterwards so 'shark undo' can revert it. Capture failures only warn: the
+// operation still proceeds and remains protected by database backups.
+type undoJournal struct {
+	repo     *repository.OperationJournalRepository
+	snapshot *models.JournalSnapshot
+	failed   bool
+}
+
+// newUndoJournal creates an empty undo journal
+func newUndoJournal(repoDb *repository.DB) *undoJournal {
+	return &undoJournal{
+		repo:     repository.NewOperationJournalRepository(repoDb),
+		snapshot: &models.JournalSnapshot{},
+	}
+}
+
+// captureDelete snapshots rows of table that are about to be deleted, with their cascades
+func (j *undoJournal) captureDelete(ctx context.Context, table string, ids ...int64) {
+	if j.failed || len(ids) == 0 {
+		return
+	}
+	snapshot, err := j.repo.CaptureCascade(ctx, table, ids)
+	if err != nil {
+		j.fail(err)
+		return
+	}
+	j.snapshot.Deleted = append(j.snapshot.Deleted, snapshot.Deleted...)
+	j.snapshot.Updated = append(j.snapshot.Updated, snapshot.Updated...)
+}
+
+// captureColumns snapshots columns of rows of table that are about to be updated
+func (j *undoJournal) captureColumns(ctx context.Context, table string, ids []int64, columns ...string) {
+	if j.failed || len(ids) == 0 {
+		return
+	}
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	rows, err := j.repo.CaptureColumns(ctx, table, "id", values, columns)
+	if err != nil {
+		j.fail(err)
+		return
+	}
+	if len(rows.Rows) > 0 {
+		j.snapshot.Updated = append(j.snapshot.Updated, *rows)
+	}
+}
+
+// setOnUndo writes value to a column of a row when the operation is undone,
+// e.g. to clear a file path that the operation assigned
+func (j *undoJournal) setOnUndo(table string, id int64, column string, value interface{}) {
+	j.snapshot.Updated = append(j.snapshot.Updated, models.JournalRows{
+		Table:   table,
+		Columns: []string{column},
+		Rows:    []map[string]interface{}{{models.JournalRowIDKey: id, column: value}},
+	})
+}
+
+// record stores the journal entry once the operation has succeeded
+func (j *undoJournal) record(ctx context.Context, operation, key, summary string) {
+	if j.failed {
+		return
+	}
+	entry := &models.OperationJournalEntry{
+		Operation: operation,
+		EntityKey: key,
+		Summary:   summary,
+		Snapshot:  j.snapshot,
+	}
+	if err := j.repo.Record(ctx, entry); err != nil {
+		j.fail(err)
+		return
+	}
+	slog.Debug("Recorded operation in the undo journal", "operation", entry.ID)
+}
+
+// fail disables the journal and warns that undo is unavailable
+func (j *undoJournal) fail(err error) {
+	j.failed = true
+	message := fmt.Sprintf("Undo will not be available for this operation: %v", err)
+	if cli.GlobalConfig.JSON {
+		fmt.Fprintln(os.Stderr, "Warning: "+message)
+		return
+	}
+	cli.Warning(message)
+}
+
+// incompleteTaskIDs returns the IDs of tasks that are not completed
+func incompleteTaskIDs(tasks []*models.Task) []int64 {
+	var ids []int64
+	for _, task := range tasks {
+		if task.Status != models.TaskStatusCompleted {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}
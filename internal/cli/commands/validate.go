@@ -1,10 +1,8 @@
 package commands
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
@@ -14,9 +12,10 @@ import (
 
 // validateCmd represents the validate command
 var validateCmd = &cobra.Command{
-	Use:     "validate",
-	Short:   "Validate database integrity",
-	GroupID: "setup",
+	Use:         "validate",
+	Short:       "Validate database integrity",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "setup",
 	Long: `Validate database integrity by checking file paths and relationships.
 
 This command checks:
@@ -64,9 +63,10 @@ func runValidate(cmd *cobra.Command, args []string) error {
 
 	// Create validator
 	validator := validation.NewValidator(repoAdapter)
+	validator.SetProjectRoot(repoDb.ProjectRoot())
 
 	// Run validation
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
 	defer cancel()
 
 	if validateVerbose {
@@ -2,10 +2,10 @@ package commands
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/utils"
 	"github.com/spf13/cobra"
@@ -25,9 +25,9 @@ func ValidateCustomPath(cmd *cobra.Command, flagName string) (*PathValidationRes
 		return nil, nil // Not provided, not an error
 	}
 
-	projectRoot, err := os.Getwd()
+	projectRoot, err := cli.FindProjectRoot()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get working directory: %w", err)
+		return nil, fmt.Errorf("failed to find project root: %w", err)
 	}
 
 	absPath, relPath, err := utils.ValidateFolderPath(customPath, projectRoot)
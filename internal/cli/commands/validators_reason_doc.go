@@ -8,6 +8,12 @@ import (
 
 // ValidateRejectionReasonDocPath validates a document path for rejection reason linking
 func ValidateRejectionReasonDocPath(docPath string) error {
+	return ValidateDocumentPath(docPath)
+}
+
+// ValidateDocumentPath validates a document path for linking to epics, features,
+// and tasks: it must be non-empty, relative, and stay inside the project
+func ValidateDocumentPath(docPath string) error {
 	// Empty path check
 	if docPath == "" {
 		return fmt.Errorf("document path cannot be empty")
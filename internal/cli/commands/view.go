@@ -14,9 +14,10 @@ import (
 
 // viewCmd represents the view command
 var viewCmd = &cobra.Command{
-	Use:     "view <KEY>",
-	Short:   "View epic, feature, or task specification in external viewer",
-	GroupID: "essentials",
+	Use:         "view <KEY>",
+	Short:       "View epic, feature, or task specification in external viewer",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	GroupID:     "essentials",
 	Long: `View specification files in an external viewer (glow, nano, cat, etc.)
 
 The viewer can be configured in .sharkconfig.json:
@@ -94,6 +95,8 @@ func runView(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get file path: %w", err)
 	}
 
+	filePath = resolveProjectPath(projectRoot, filePath)
+
 	// Launch viewer
 	viewerCmd := cfg.GetViewer()
 	if err := viewService.LaunchViewer(ctx, filePath, viewerCmd); err != nil {
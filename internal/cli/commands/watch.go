@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// watchCmd keeps the database in step with task files edited directly
+var watchCmd = &cobra.Command{
+	Use:     "watch",
+	Short:   "Update the database when task files are edited directly",
+	GroupID: "setup",
+	Long: `Watch task markdown files and apply edits made outside the CLI, so agents
+that change files directly keep the database consistent.
+
+When a file's frontmatter status marker changes, the task moves to that status
+if the workflow allows the transition; otherwise the change is reported as
+drift. When a checklist item is checked, the matching acceptance criterion is
+marked complete (and created if it doesn't exist); unchecking it moves a
+complete criterion back to pending.
+
+Only edits made while watching are applied. Each file is compared with its
+previous content, not with the database, so status markers that were never
+updated don't undo status changes made through the CLI.
+
+With --report-only, changes are printed as drift and the database is left
+alone. With --json, each result is printed as one JSON object per line.
+
+Examples:
+  shark watch
+  shark watch --report-only
+  shark watch --debounce=1s --json`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().Bool("report-only", false, "Report changes as drift without updating the database")
+	watchCmd.Flags().Duration("debounce", 300*time.Millisecond, "Wait this long after a file's last write before reading it")
+	watchCmd.Flags().String("agent", "", "Agent recorded in task history for status changes (default: $USER)")
+}
+
+// runWatch handles the watch command
+func runWatch(cmd *cobra.Command, args []string) error {
+	reportOnly, _ := cmd.Flags().GetBool("report-only")
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+	agent, _ := cmd.Flags().GetString("agent")
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	projectRoot, err := cli.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	taskRepo := repository.NewTaskRepository(repoDb)
+	criteriaRepo := repository.NewTaskCriteriaRepository(repoDb)
+	reconciler := watch.NewReconciler(taskRepo, criteriaRepo, getAgentIdentifier(agent), reportOnly)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tracked, err := trackTaskFiles(ctx, taskRepo, reconciler, projectRoot)
+	if err != nil {
+		return err
+	}
+	if tracked == 0 {
+		return fmt.Errorf("no task files to watch: tasks have no file paths on disk")
+	}
+
+	if !cli.GlobalConfig.JSON {
+		mode := "applying changes"
+		if reportOnly {
+			mode = "reporting drift only"
+		}
+		cli.Info("Watching %d task files, %s (Ctrl+C to stop)", tracked, mode)
+	}
+
+	return watch.Watch(ctx, reconciler.Dirs(), debounce, func(path string) {
+		// A file the reconciler doesn't know may belong to a task created
+		// since watching started
+		if !reconciler.Tracks(path) {
+			if _, err := trackTaskFiles(ctx, taskRepo, reconciler, projectRoot); err != nil {
+				cli.Warning(err.Error())
+			}
+			return
+		}
+
+		results, err := reconciler.Reconcile(ctx, path)
+		if err != nil {
+			cli.Warning(err.Error())
+		}
+		for _, result := range results {
+			printWatchResult(result)
+		}
+	})
+}
+
+// trackTaskFiles starts tracking every task file on disk that isn't tracked
+// yet, returning the number of files now tracked
+func trackTaskFiles(ctx context.Context, taskRepo *repository.TaskRepository, reconciler *watch.Reconciler, projectRoot string) (int, error) {
+	tasks, err := taskRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	tracked := 0
+	for _, task := range tasks {
+		if task.FilePath == nil || *task.FilePath == "" {
+			continue
+		}
+		path := *task.FilePath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectRoot, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if !reconciler.Tracks(path) {
+			reconciler.Track(path, task.Key)
+		}
+		tracked++
+	}
+	return tracked, nil
+}
+
+// printWatchResult prints one reconciled change
+func printWatchResult(result watch.Result) {
+	if cli.GlobalConfig.JSON {
+		data, err := json.Marshal(result)
+		if err == nil {
+			fmt.Println(string(data))
+		}
+		return
+	}
+
+	subject := result.Field
+	if result.Field == watch.FieldCriterion {
+		subject = fmt.Sprintf("criterion %q", result.Item)
+	}
+	from := result.From
+	if from == "" {
+		from = "(new)"
+	}
+
+	message := fmt.Sprintf("%s %s: %s → %s", result.TaskKey, subject, from, result.To)
+	if result.Action == watch.ActionDrift {
+		cli.Warning(fmt.Sprintf("Drift: %s (%s)", message, result.Reason))
+		return
+	}
+	cli.Success(fmt.Sprintf("%s (%s)", message, result.Action))
+}
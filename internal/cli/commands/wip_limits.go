@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// projectWIPLimits returns the WIP limits per agent type from .sharkconfig.json
+// (none if unavailable)
+func projectWIPLimits() map[string]int {
+	var cfg *config.Config
+	if configPath, err := cli.GetConfigPath(); err == nil {
+		if loaded, err := config.NewManager(configPath).Load(); err == nil {
+			cfg = loaded
+		}
+	}
+	return cfg.GetWIPLimits()
+}
+
+// wipLimitHint is the hint shown when a WIP limit stops a task from starting
+const wipLimitHint = "Finish or block an in-progress task first, or use --ignore-wip-limit"
+
+// failWIPLimited exits because every task that could be claimed is for an
+// agent type at its WIP limit; usage lists those agent types (e.g. "backend 2/2")
+func failWIPLimited(usage []string) {
+	cli.Fail(cli.ErrCodeInvalidState, fmt.Sprintf("No task can be claimed: available tasks are for agent types at their WIP limit (%s)", strings.Join(usage, ", ")), wipLimitHint)
+}
+
+// withoutWIPLimited drops the candidates whose agent type is at its WIP limit,
+// and returns those agent types with their usage (e.g. "backend 2/2"). It
+// reads the counts outside any transaction, to explain an empty result;
+// TaskRepository.SetWIPLimits enforces the limits.
+func withoutWIPLimited(ctx context.Context, repo *repository.TaskRepository, limits map[string]int, candidates []*models.Task) ([]*models.Task, []string, error) {
+	if len(limits) == 0 {
+		return candidates, nil, nil
+	}
+	counts, err := repo.CountInProgressByAgentType(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kept := make([]*models.Task, 0, len(candidates))
+	limited := make(map[string]string)
+	for _, task := range candidates {
+		if task.AgentType != nil {
+			agentType := *task.AgentType
+			if limit, ok := limits[agentType]; ok && counts[agentType] >= limit {
+				limited[agentType] = fmt.Sprintf("%s %d/%d", agentType, counts[agentType], limit)
+				continue
+			}
+		}
+		kept = append(kept, task)
+	}
+
+	return kept, sortedWIPUsage(limited), nil
+}
+
+// sortedWIPUsage returns the usage strings of limited, keyed by agent type, in
+// order
+func sortedWIPUsage(limited map[string]string) []string {
+	usage := make([]string, 0, len(limited))
+	for _, u := range limited {
+		usage = append(usage, u)
+	}
+	sort.Strings(usage)
+	return usage
+}
@@ -2,11 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli"
 	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
 	"github.com/spf13/cobra"
 )
 
@@ -17,18 +19,25 @@ var workflowCmd = &cobra.Command{
 	GroupID: "setup",
 	Long: `Workflow configuration operations including listing, validation, and migration.
 
-The workflow system allows customizing task status transitions via .sharkconfig.json.
+The workflow system allows customizing task statuses and their transitions.
+A workflow stored in the database with 'shark workflow import' takes precedence
+over the status_flow in .sharkconfig.json; without either, the default
+workflow applies.
 
 Examples:
-  shark workflow list      Display configured workflow
-  shark workflow validate  Validate workflow configuration`,
+  shark workflow list                  Display configured workflow
+  shark workflow validate              Validate workflow configuration
+  shark workflow transitions           List allowed status transitions
+  shark workflow import workflow.json  Store a workflow in the database`,
 }
 
 // workflowListCmd displays the configured workflow
 var workflowListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "Display configured workflow",
-	Long: `Display the configured status workflow from .sharkconfig.json.
+	Use:         "list",
+	Short:       "Display configured workflow",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Display the status workflow in use: the one stored in the database, else the
+one in .sharkconfig.json.
 
 Shows all statuses and their valid transitions, highlighting special statuses
 (_start_ and _complete_). If no custom workflow is configured, displays the
@@ -42,9 +51,11 @@ Examples:
 
 // workflowValidateCmd validates the workflow configuration
 var workflowValidateCmd = &cobra.Command{
-	Use:   "validate",
-	Short: "Validate workflow configuration",
-	Long: `Validate the workflow configuration in .sharkconfig.json for correctness.
+	Use:         "validate",
+	Short:       "Validate workflow configuration",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `Validate the workflow in use (stored in the database, else in .sharkconfig.json)
+for correctness.
 
 Checks all validation rules:
 - Required special statuses (_start_, _complete_) are defined
@@ -71,9 +82,6 @@ func init() {
 
 // runWorkflowList implements the workflow list command
 func runWorkflowList(cmd *cobra.Command, args []string) error {
-	ctx := cmd.Context()
-	_ = ctx // Context available for future use
-
 	// Get config path using centralized helper
 	configPath, err := cli.GetConfigPath()
 	if err != nil {
@@ -81,17 +89,14 @@ func runWorkflowList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load workflow config
-	workflow, err := config.LoadWorkflowConfig(configPath)
+	workflow, source, err := loadWorkflowWithSource(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load workflow config: %w", err)
 	}
 
 	// If no custom workflow, use default
-	if workflow == nil {
-		workflow = config.DefaultWorkflow()
-		if !cli.GlobalConfig.JSON {
-			cli.Warning("No custom workflow configured in .sharkconfig.json, using default workflow")
-		}
+	if source == workflowSourceDefault && !cli.GlobalConfig.JSON {
+		cli.Warning("No custom workflow configured in .sharkconfig.json, using default workflow")
 	}
 
 	// Output as JSON if requested
@@ -100,9 +105,56 @@ func runWorkflowList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Human-readable output
+	if source == workflowSourceDatabase {
+		fmt.Printf("Using the workflow stored in the database\n\n")
+	}
 	return displayWorkflowHumanReadable(workflow)
 }
 
+// loadWorkflowWithSource loads the workflow in use and where it comes from:
+// the project database, .sharkconfig.json, or the default workflow. A stored
+// workflow is returned even if invalid, so validate can report it.
+func loadWorkflowWithSource(configPath string) (*config.WorkflowConfig, string, error) {
+	if workflow := storedWorkflow(configPath); workflow != nil {
+		return workflow, workflowSourceDatabase, nil
+	}
+
+	workflow, err := config.LoadWorkflowConfig(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if workflow == nil {
+		return config.DefaultWorkflow(), workflowSourceDefault, nil
+	}
+	return workflow, workflowSourceConfig, nil
+}
+
+// storedWorkflow returns the workflow stored in the database of the project
+// configPath belongs to, or nil if there is none. List and validate also work
+// without a database, so none is created.
+func storedWorkflow(configPath string) *config.WorkflowConfig {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	database, err := cli.OpenProjectDB(ctx, filepath.Dir(configPath))
+	if err != nil {
+		return nil
+	}
+	defer database.Close()
+
+	stored, err := repository.NewWorkflowRepository(database).Get(ctx)
+	if err != nil {
+		if !cli.GlobalConfig.JSON {
+			cli.Warning(err.Error())
+		}
+		return nil
+	}
+	if stored == nil {
+		return nil
+	}
+	return stored.Workflow
+}
+
 // displayWorkflowHumanReadable displays the workflow in a human-readable format
 func displayWorkflowHumanReadable(workflow *config.WorkflowConfig) error {
 	// Display header
@@ -174,9 +226,6 @@ func displayWorkflowHumanReadable(workflow *config.WorkflowConfig) error {
 
 // runWorkflowValidate implements the workflow validate command
 func runWorkflowValidate(cmd *cobra.Command, args []string) error {
-	ctx := cmd.Context()
-	_ = ctx // Context available for future use
-
 	// Get config path using centralized helper
 	configPath, err := cli.GetConfigPath()
 	if err != nil {
@@ -184,16 +233,18 @@ func runWorkflowValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load workflow config
-	workflow, err := config.LoadWorkflowConfig(configPath)
+	workflow, source, err := loadWorkflowWithSource(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load workflow config: %w", err)
 	}
 
 	// If no custom workflow, validate default
-	if workflow == nil {
-		workflow = config.DefaultWorkflow()
-		if !cli.GlobalConfig.JSON {
+	if !cli.GlobalConfig.JSON {
+		switch source {
+		case workflowSourceDefault:
 			cli.Warning("No custom workflow configured in .sharkconfig.json, validating default workflow")
+		case workflowSourceDatabase:
+			cli.Info("Validating the workflow stored in the database")
 		}
 	}
 
@@ -204,6 +255,7 @@ func runWorkflowValidate(cmd *cobra.Command, args []string) error {
 	result := map[string]interface{}{
 		"valid":       validationErr == nil,
 		"config_path": configPath,
+		"source":      source,
 	}
 
 	if validationErr == nil {
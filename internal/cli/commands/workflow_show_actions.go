@@ -2,7 +2,6 @@ package commands
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -15,8 +14,9 @@ import (
 
 // workflowShowActionsCmd displays all orchestrator actions in the workflow
 var workflowShowActionsCmd = &cobra.Command{
-	Use:   "show-actions",
-	Short: "Display workflow orchestrator actions",
+	Use:         "show-actions",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Short:       "Display workflow orchestrator actions",
 	Long: `Display all orchestrator actions defined in the workflow configuration.
 
 Shows actions grouped by workflow phase with agent types and skills.
@@ -119,8 +119,7 @@ func runWorkflowShowActions(cmd *cobra.Command, args []string) error {
 			"archive":         true,
 		}
 		if !validTypes[showActionsActionType] {
-			cli.Error(fmt.Sprintf("Invalid action type '%s'. Valid types: spawn_agent, pause, wait_for_triage, archive", showActionsActionType))
-			os.Exit(1)
+			cli.Fail(cli.ErrCodeInvalidArgument, fmt.Sprintf("Invalid action type '%s'. Valid types: spawn_agent, pause, wait_for_triage, archive", showActionsActionType))
 		}
 	}
 
@@ -129,8 +128,7 @@ func runWorkflowShowActions(cmd *cobra.Command, args []string) error {
 
 	// Check if status was requested but not found
 	if showActionsStatus != "" && len(display.WorkflowActions) == 0 {
-		cli.Error(fmt.Sprintf("Status '%s' not found in workflow configuration", showActionsStatus))
-		os.Exit(1)
+		cli.Fail(cli.ErrCodeNotFound, fmt.Sprintf("Status '%s' not found in workflow configuration", showActionsStatus))
 	}
 
 	// Output as JSON if requested
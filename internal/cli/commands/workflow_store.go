@@ -0,0 +1,327 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// Where the workflow in use comes from
+const (
+	workflowSourceDatabase = "database"
+	workflowSourceConfig   = "config"
+	workflowSourceDefault  = "default"
+)
+
+// workflowImportCmd stores a workflow in the database
+var workflowImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Store a workflow in the database",
+	Long: `Store a custom workflow in the database, so the whole team shares it and it
+takes precedence over the status_flow in .sharkconfig.json.
+
+The file uses the .sharkconfig.json format: status_flow, status_metadata,
+special_statuses, require_rejection_reason, and auto_activate. Other fields are
+ignored, so a .sharkconfig.json can be imported as is. The workflow is validated
+first (see 'shark workflow validate').
+
+Tasks in a status the new workflow doesn't define could not move anywhere, so
+the import is refused while there are any, unless --force is given. Move them
+first, or add their status to the workflow.
+
+Examples:
+  shark workflow import review-workflow.json
+  shark workflow import .sharkconfig.json
+  shark workflow import review-workflow.json --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkflowImport,
+}
+
+// workflowResetCmd removes the stored workflow
+var workflowResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Remove the workflow stored in the database",
+	Long: `Remove the workflow stored in the database, so the status_flow in
+.sharkconfig.json, or the default workflow, applies again.
+
+Like import, reset is refused while tasks are in a status the workflow that
+would apply doesn't define, unless --force is given.
+
+Examples:
+  shark workflow reset`,
+	Args: cobra.NoArgs,
+	RunE: runWorkflowReset,
+}
+
+// workflowTransitionsCmd lists the statuses a status can move to
+var workflowTransitionsCmd = &cobra.Command{
+	Use:         "transitions [status|task-key]",
+	Short:       "List allowed status transitions",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Long: `List the statuses each status can move to in the workflow in use. Given a
+status, or a task key to use the task's current status, only that status's
+transitions are listed.
+
+Backward transitions, such as a review sending a task back to development,
+need a --reason when the workflow requires rejection reasons.
+
+Examples:
+  shark workflow transitions
+  shark workflow transitions in_code_review
+  shark workflow transitions T-E07-F01-003 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorkflowTransitions,
+}
+
+func init() {
+	workflowCmd.AddCommand(workflowImportCmd)
+	workflowCmd.AddCommand(workflowResetCmd)
+	workflowCmd.AddCommand(workflowTransitionsCmd)
+
+	workflowImportCmd.Flags().Bool("force", false, "Store the workflow even if tasks are in statuses it doesn't define")
+	workflowResetCmd.Flags().Bool("force", false, "Remove the workflow even if tasks are in statuses the fallback doesn't define")
+}
+
+// WorkflowTransitionJSON is a status and the statuses it can move to
+type WorkflowTransitionJSON struct {
+	Status      string   `json:"status"`
+	Phase       string   `json:"phase,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Next        []string `json:"next"`
+	Backward    []string `json:"backward"` // Next statuses that need a rejection reason
+	Terminal    bool     `json:"terminal"`
+}
+
+// workflowSource reports where the workflow in use comes from. The database
+// must be open, so a stored workflow is already in use.
+func workflowSource(configPath string) (string, error) {
+	if config.StoredWorkflowInUse() != nil {
+		return workflowSourceDatabase, nil
+	}
+	workflow, err := config.LoadWorkflowConfig(configPath)
+	if err != nil {
+		return "", err
+	}
+	if workflow == nil {
+		return workflowSourceDefault, nil
+	}
+	return workflowSourceConfig, nil
+}
+
+// checkWorkflowStatuses fails if tasks are in statuses the workflow doesn't
+// define, unless force is set, in which case it only warns
+func checkWorkflowStatuses(ctx context.Context, workflowRepo *repository.WorkflowRepository, workflow *config.WorkflowConfig, force bool) error {
+	missing, err := workflowRepo.StatusesNotIn(ctx, workflow)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	statuses := make([]string, 0, len(missing))
+	for status := range missing {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	counts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		counts = append(counts, fmt.Sprintf("%s (%d)", status, missing[status]))
+	}
+
+	message := fmt.Sprintf("tasks are in statuses the workflow doesn't define: %s", strings.Join(counts, ", "))
+	if force {
+		cli.Warning(message)
+		return nil
+	}
+	return cli.NewError(cli.ErrCodeConflict, message).
+		WithHint("Move those tasks first, add their statuses to the workflow, or use --force")
+}
+
+// runWorkflowImport handles the workflow import command
+func runWorkflowImport(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read workflow file: %w", err)
+	}
+	workflow, err := config.ParseWorkflowJSON(data)
+	if err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, err.Error())
+	}
+	if len(workflow.StatusFlow) == 0 {
+		return cli.NewError(cli.ErrCodeInvalidArgument, fmt.Sprintf("%s has no status_flow", args[0]))
+	}
+	if err := config.ValidateWorkflow(workflow); err != nil {
+		return cli.NewError(cli.ErrCodeInvalidArgument, fmt.Sprintf("invalid workflow: %v", err))
+	}
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	force, _ := cmd.Flags().GetBool("force")
+	workflowRepo := repository.NewWorkflowRepository(repoDb)
+	if err := checkWorkflowStatuses(ctx, workflowRepo, workflow, force); err != nil {
+		return err
+	}
+	if err := workflowRepo.Save(ctx, workflow); err != nil {
+		return err
+	}
+	config.UseStoredWorkflow(workflow)
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{
+			"source":   workflowSourceDatabase,
+			"statuses": workflow.Statuses(),
+		})
+	}
+	cli.Success(fmt.Sprintf("Stored a workflow with %d statuses in the database", len(workflow.StatusFlow)))
+	cli.Info("It takes precedence over the status_flow in .sharkconfig.json; 'shark workflow reset' removes it")
+	return nil
+}
+
+// runWorkflowReset handles the workflow reset command
+func runWorkflowReset(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	// Find the workflow that applies once the stored one is gone
+	config.UseStoredWorkflow(nil)
+	fallback := config.GetWorkflowOrDefault(configPath)
+	source, err := workflowSource(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load workflow config: %w", err)
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	workflowRepo := repository.NewWorkflowRepository(repoDb)
+	if err := checkWorkflowStatuses(ctx, workflowRepo, fallback, force); err != nil {
+		return err
+	}
+	removed, err := workflowRepo.Delete(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]interface{}{"removed": removed, "source": source})
+	}
+	if !removed {
+		cli.Info("No workflow is stored in the database")
+		return nil
+	}
+	cli.Success(fmt.Sprintf("Removed the stored workflow; the %s workflow applies", source))
+	return nil
+}
+
+// runWorkflowTransitions handles the workflow transitions command
+func runWorkflowTransitions(cmd *cobra.Command, args []string) error {
+	ctx, cancel := cli.CommandContext(cli.DefaultCommandTimeout)
+	defer cancel()
+
+	repoDb, err := cli.GetDB(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get database: %w", err)
+	}
+	// Note: Database will be closed automatically by PersistentPostRunE hook
+
+	configPath, err := cli.GetConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+	workflow := config.GetWorkflowOrDefault(configPath)
+
+	statuses := workflow.Statuses()
+	if len(args) == 1 {
+		status := strings.ToLower(strings.TrimSpace(args[0]))
+		if !workflow.HasStatus(status) {
+			taskKey, err := ResolveTaskKey(cmd, args[0])
+			if err != nil {
+				return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("%q is not a status in the workflow or a task key", args[0])).
+					WithHint("Run 'shark workflow transitions' to see every status")
+			}
+			task, err := repository.NewTaskRepository(repoDb).GetByKey(ctx, taskKey)
+			if err != nil {
+				return cli.NewError(cli.ErrCodeNotFound, fmt.Sprintf("task %s not found", taskKey))
+			}
+			status = string(task.Status)
+			if !workflow.HasStatus(status) {
+				return cli.NewError(cli.ErrCodeInvalidState, fmt.Sprintf("task %s is %s, which the workflow doesn't define", task.Key, status)).
+					WithHint("Move it with 'shark task set-status --force'")
+			}
+		}
+		statuses = []string{status}
+	}
+
+	transitions := workflowTransitions(workflow, statuses)
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(transitions)
+	}
+
+	rows := make([][]string, 0, len(transitions))
+	for _, transition := range transitions {
+		next := "(terminal)"
+		if !transition.Terminal {
+			names := make([]string, 0, len(transition.Next))
+			for _, status := range transition.Next {
+				if slices.Contains(transition.Backward, status) {
+					status += " (reason)"
+				}
+				names = append(names, status)
+			}
+			next = strings.Join(names, ", ")
+		}
+		rows = append(rows, []string{transition.Status, transition.Phase, next})
+	}
+	cli.OutputTable([]string{"Status", "Phase", "Can move to"}, rows)
+	if workflow.RequireRejectionReason {
+		fmt.Println("\n(reason): backward transition, needs --reason")
+	}
+	return nil
+}
+
+// workflowTransitions returns the transitions out of each status
+func workflowTransitions(workflow *config.WorkflowConfig, statuses []string) []WorkflowTransitionJSON {
+	transitions := make([]WorkflowTransitionJSON, 0, len(statuses))
+	for _, status := range statuses {
+		meta, _ := workflow.GetStatusMetadata(status)
+		transition := WorkflowTransitionJSON{
+			Status:      status,
+			Phase:       meta.Phase,
+			Description: meta.Description,
+			Next:        append([]string{}, workflow.StatusFlow[status]...),
+			Backward:    []string{},
+		}
+		transition.Terminal = len(transition.Next) == 0
+		if workflow.RequireRejectionReason {
+			for _, next := range transition.Next {
+				if backward, err := workflow.IsBackwardTransition(status, next); err == nil && backward {
+					transition.Backward = append(transition.Backward, next)
+				}
+			}
+		}
+		transitions = append(transitions, transition)
+	}
+	return transitions
+}
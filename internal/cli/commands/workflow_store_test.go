@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowTransitions(t *testing.T) {
+	workflow, err := config.ParseWorkflowJSON([]byte(`{
+		"status_flow": {
+			"todo": ["in_development"],
+			"in_development": ["in_code_review"],
+			"in_code_review": ["in_development", "ready_for_qa"],
+			"ready_for_qa": ["done"],
+			"done": []
+		},
+		"status_metadata": {
+			"todo": {"phase": "planning"},
+			"in_development": {"phase": "development"},
+			"in_code_review": {"phase": "review", "description": "Peer review"},
+			"ready_for_qa": {"phase": "qa"},
+			"done": {"phase": "done"}
+		},
+		"special_statuses": {"_start_": ["todo"], "_complete_": ["done"]},
+		"require_rejection_reason": true
+	}`))
+	require.NoError(t, err)
+	require.NoError(t, config.ValidateWorkflow(workflow))
+
+	transitions := workflowTransitions(workflow, []string{"in_code_review", "done"})
+	require.Len(t, transitions, 2)
+
+	review := transitions[0]
+	assert.Equal(t, "review", review.Phase)
+	assert.Equal(t, "Peer review", review.Description)
+	assert.Equal(t, []string{"in_development", "ready_for_qa"}, review.Next)
+	assert.Equal(t, []string{"in_development"}, review.Backward, "moving back to development needs a reason")
+	assert.False(t, review.Terminal)
+
+	assert.True(t, transitions[1].Terminal)
+	assert.Empty(t, transitions[1].Next)
+
+	workflow.RequireRejectionReason = false
+	transitions = workflowTransitions(workflow, []string{"in_code_review"})
+	assert.Empty(t, transitions[0].Backward, "no reasons are needed when the workflow doesn't require them")
+}
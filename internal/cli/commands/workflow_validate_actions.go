@@ -2,7 +2,6 @@ package commands
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -13,8 +12,9 @@ import (
 
 // workflowValidateActionsCmd validates all orchestrator actions in the workflow
 var workflowValidateActionsCmd = &cobra.Command{
-	Use:   "validate-actions",
-	Short: "Validate workflow orchestrator actions",
+	Use:         "validate-actions",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Short:       "Validate workflow orchestrator actions",
 	Long: `Validate that all orchestrator actions in the workflow configuration are properly defined.
 
 This command checks:
@@ -108,7 +108,7 @@ func runWorkflowValidateActions(cmd *cobra.Command, args []string) error {
 
 	// Determine exit code
 	if !report.Valid {
-		os.Exit(1)
+		cli.Exit(cli.ExitFailure)
 	}
 
 	return nil
@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jwwelbor/shark-task-manager/internal/cli"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// workspaceCmd represents the workspace command group
+var workspaceCmd = &cobra.Command{
+	Use:     "workspace",
+	Short:   "Manage registered workspaces",
+	GroupID: "setup",
+	Long: `Register shark projects by name so commands find the right project root and
+database without --db or --project-root.
+
+Workspaces are stored in ~/.shark/workspaces.yaml ($SHARK_HOME overrides the
+directory). Commands resolve the project in this order:
+  1. --project-root (or SHARK_PROJECT_ROOT)
+  2. --workspace <name>
+  3. The nearest .shark.yaml, .sharkconfig.json, or shark-tasks.db walking up
+     from the working directory
+  4. The current workspace (set with 'shark workspace use')
+
+A .shark.yaml marks a project root and can name a database other than
+shark-tasks.db:
+
+  name: api
+  db: data/tasks.db
+
+Examples:
+  shark workspace add api                     Register the current project as "api"
+  shark workspace add web ~/src/web           Register another project
+  shark workspace list                        List workspaces
+  shark workspace use api                     Use "api" outside any project
+  shark task list --workspace=web             Run one command in "web"`,
+	Annotations: map[string]string{cli.SkipWorkspaceAnnotation: "true"},
+}
+
+// workspaceAddCmd registers a workspace
+var workspaceAddCmd = &cobra.Command{
+	Use:   "add <name> [path]",
+	Short: "Register a project as a workspace",
+	Long: `Register a project root under a name. The path defaults to the current
+project root. A .shark.yaml naming the workspace is written to the root if it
+has none.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runWorkspaceAdd,
+}
+
+// workspaceListCmd lists workspaces
+var workspaceListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List registered workspaces",
+	Annotations: map[string]string{cli.ReadOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runWorkspaceList,
+}
+
+// workspaceUseCmd sets the current workspace
+var workspaceUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the workspace used outside any project",
+	Long: `Set the current workspace. Commands run outside any project use its root
+and database. --clear unsets it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorkspaceUse,
+}
+
+// workspaceRemoveCmd unregisters a workspace
+var workspaceRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister a workspace",
+	Long:  `Unregister a workspace. The project and its .shark.yaml are left in place.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorkspaceRemove,
+}
+
+func init() {
+	cli.RootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceAddCmd)
+	workspaceCmd.AddCommand(workspaceListCmd)
+	workspaceCmd.AddCommand(workspaceUseCmd)
+	workspaceCmd.AddCommand(workspaceRemoveCmd)
+
+	workspaceAddCmd.Flags().Bool("use", false, "Also make it the current workspace")
+	workspaceUseCmd.Flags().Bool("clear", false, "Unset the current workspace")
+}
+
+// loadWorkspaceRegistry loads the registry and returns it with its path
+func loadWorkspaceRegistry() (*workspace.Registry, string, error) {
+	path, err := workspace.RegistryPath()
+	if err != nil {
+		return nil, "", err
+	}
+	registry, err := workspace.LoadRegistry(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return registry, path, nil
+}
+
+// runWorkspaceAdd handles workspace add
+func runWorkspaceAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	use, _ := cmd.Flags().GetBool("use")
+
+	var root string
+	if len(args) == 2 {
+		root = args[1]
+	} else {
+		projectRoot, err := cli.FindProjectRoot()
+		if err != nil {
+			return err
+		}
+		root = projectRoot
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("invalid path %q: %w", root, err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", root)
+	}
+
+	registry, registryPath, err := loadWorkspaceRegistry()
+	if err != nil {
+		return err
+	}
+	ws := workspace.Workspace{Name: name, Root: root}
+	if err := registry.Add(ws); err != nil {
+		return err
+	}
+	if use {
+		_ = registry.Use(name)
+	}
+
+	project, err := workspace.LoadProjectFile(root)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		if err := workspace.WriteProjectFile(root, &workspace.ProjectFile{Name: name}); err != nil {
+			return err
+		}
+	}
+
+	if err := registry.Save(registryPath); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(ws)
+	}
+	cli.Success(fmt.Sprintf("Registered workspace %s at %s", name, root))
+	if use {
+		cli.Info("Current workspace: %s", name)
+	}
+	return nil
+}
+
+// runWorkspaceList handles workspace list
+func runWorkspaceList(cmd *cobra.Command, args []string) error {
+	registry, _, err := loadWorkspaceRegistry()
+	if err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		if registry.Workspaces == nil {
+			registry.Workspaces = []workspace.Workspace{}
+		}
+		return cli.OutputJSON(registry)
+	}
+
+	if len(registry.Workspaces) == 0 {
+		cli.Info("No workspaces registered (see 'shark workspace add')")
+		return nil
+	}
+
+	headers := []string{"Name", "Root", "Current"}
+	rows := make([][]string, len(registry.Workspaces))
+	current := registry.CurrentWorkspace()
+	for i, ws := range registry.Workspaces {
+		marker := ""
+		if current != nil && current.Name == ws.Name {
+			marker = "*"
+		}
+		rows[i] = []string{ws.Name, ws.Root, marker}
+	}
+	cli.OutputTable(headers, rows)
+	return nil
+}
+
+// runWorkspaceUse handles workspace use
+func runWorkspaceUse(cmd *cobra.Command, args []string) error {
+	clearCurrent, _ := cmd.Flags().GetBool("clear")
+	if clearCurrent == (len(args) == 1) {
+		return fmt.Errorf("give a workspace name or --clear")
+	}
+
+	registry, registryPath, err := loadWorkspaceRegistry()
+	if err != nil {
+		return err
+	}
+	if clearCurrent {
+		registry.Current = ""
+	} else if err := registry.Use(args[0]); err != nil {
+		return err
+	}
+	if err := registry.Save(registryPath); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]string{"current": registry.Current})
+	}
+	if clearCurrent {
+		cli.Success("Cleared the current workspace")
+	} else {
+		cli.Success(fmt.Sprintf("Current workspace: %s (%s)", registry.Current, registry.CurrentWorkspace().Root))
+	}
+	return nil
+}
+
+// runWorkspaceRemove handles workspace remove
+func runWorkspaceRemove(cmd *cobra.Command, args []string) error {
+	registry, registryPath, err := loadWorkspaceRegistry()
+	if err != nil {
+		return err
+	}
+	if err := registry.Remove(args[0]); err != nil {
+		return err
+	}
+	if err := registry.Save(registryPath); err != nil {
+		return err
+	}
+
+	if cli.GlobalConfig.JSON {
+		return cli.OutputJSON(map[string]string{"removed": args[0]})
+	}
+	cli.Success(fmt.Sprintf("Removed workspace %s", args[0]))
+	return nil
+}
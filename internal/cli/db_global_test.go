@@ -172,3 +172,32 @@ func TestCloseDB_SafeToCallMultipleTimes(t *testing.T) {
 		t.Errorf("Expected no error on second close, got: %v", err)
 	}
 }
+
+func TestOpenProjectDB(t *testing.T) {
+	root := t.TempDir()
+	configContent := `{"database": {"backend": "local", "url": "./tasks.db"}}`
+	if err := os.WriteFile(filepath.Join(root, ".sharkconfig.json"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := OpenProjectDB(ctx, root); err == nil {
+		t.Fatal("Expected an error for a project without a database")
+	}
+	if _, err := os.Stat(filepath.Join(root, "tasks.db")); !os.IsNotExist(err) {
+		t.Fatal("Expected no database to be created")
+	}
+
+	// A relative url is relative to the project root, not the working directory
+	created, err := openProjectDB(ctx, root, false)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	_ = created.Close()
+
+	opened, err := OpenProjectDB(ctx, root)
+	if err != nil {
+		t.Fatalf("Expected the database to open, got: %v", err)
+	}
+	_ = opened.Close()
+}
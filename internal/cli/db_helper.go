@@ -2,12 +2,16 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/credentials"
 	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 )
 
 // GetDatabaseConfig reads database configuration from .sharkconfig.json
@@ -28,7 +32,7 @@ func GetDatabaseConfig(configPath string) (config.DatabaseConfig, error) {
 		// No config data - fall back to local database
 		return config.DatabaseConfig{
 			Backend: "sqlite",
-			URL:     filepath.Join(configDir, "shark-tasks.db"),
+			URL:     defaultDatabasePath(configDir),
 		}, nil
 	}
 
@@ -37,7 +41,7 @@ func GetDatabaseConfig(configPath string) (config.DatabaseConfig, error) {
 		// No database section - fall back to local database
 		return config.DatabaseConfig{
 			Backend: "sqlite",
-			URL:     filepath.Join(configDir, "shark-tasks.db"),
+			URL:     defaultDatabasePath(configDir),
 		}, nil
 	}
 
@@ -66,17 +70,56 @@ func GetDatabaseConfig(configPath string) (config.DatabaseConfig, error) {
 		dbConfig.EmbeddedReplica = embeddedReplica
 	}
 
+	if journalMode, ok := dbConfigMap["journal_mode"].(string); ok {
+		dbConfig.JournalMode = journalMode
+	}
+
+	if synchronous, ok := dbConfigMap["synchronous"].(string); ok {
+		dbConfig.Synchronous = synchronous
+	}
+
+	// JSON numbers decode as float64
+	if busyTimeout, ok := dbConfigMap["busy_timeout_ms"].(float64); ok {
+		dbConfig.BusyTimeoutMs = int(busyTimeout)
+	}
+
+	if cacheSize, ok := dbConfigMap["cache_size_kb"].(float64); ok {
+		dbConfig.CacheSizeKB = int(cacheSize)
+	}
+
+	if maxOpen, ok := dbConfigMap["max_open_conns"].(float64); ok {
+		dbConfig.MaxOpenConns = int(maxOpen)
+	}
+
+	if maxIdle, ok := dbConfigMap["max_idle_conns"].(float64); ok {
+		dbConfig.MaxIdleConns = int(maxIdle)
+	}
+
+	if maxRetries, ok := dbConfigMap["max_retries"].(float64); ok {
+		retries := int(maxRetries)
+		dbConfig.MaxRetries = &retries
+	}
+
 	// Fall back to local if backend/URL not specified
 	if dbConfig.Backend == "" {
 		dbConfig.Backend = "sqlite"
 	}
 	if dbConfig.URL == "" {
-		dbConfig.URL = filepath.Join(configDir, "shark-tasks.db")
+		dbConfig.URL = defaultDatabasePath(configDir)
 	}
 
 	return dbConfig, nil
 }
 
+// defaultDatabasePath is the local database used when .sharkconfig.json sets
+// none: the db from the project's .shark.yaml, or shark-tasks.db
+func defaultDatabasePath(projectRoot string) string {
+	if dbPath := workspace.DatabasePath(projectRoot); dbPath != "" {
+		return dbPath
+	}
+	return filepath.Join(projectRoot, "shark-tasks.db")
+}
+
 // InitializeDatabaseFromConfig initializes a database connection using config from .sharkconfig.json
 // This is the cloud-aware replacement for db.InitDB()
 func InitializeDatabaseFromConfig(ctx context.Context, configPath string) (db.Database, error) {
@@ -91,21 +134,13 @@ func InitializeDatabaseFromConfig(ctx context.Context, configPath string) (db.Da
 		return nil, fmt.Errorf("invalid database config: %w", err)
 	}
 
-	// Load auth token if Turso and auth_token_file is specified
-	if dbConfig.Backend == "turso" && dbConfig.AuthTokenFile != "" {
-		authToken, err := db.LoadAuthToken(dbConfig.AuthTokenFile)
+	// Build the Turso connection string with its auth token
+	if dbConfig.Backend == "turso" {
+		authToken, _, err := TursoAuthToken(dbConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load auth token: %w", err)
 		}
-
-		// Build connection string with auth token
 		dbConfig.URL = db.BuildTursoConnectionString(dbConfig.URL, authToken)
-	} else if dbConfig.Backend == "turso" {
-		// Try environment variable
-		authToken := os.Getenv("TURSO_AUTH_TOKEN")
-		if authToken != "" {
-			dbConfig.URL = db.BuildTursoConnectionString(dbConfig.URL, authToken)
-		}
 	}
 
 	// Initialize database using driver registry
@@ -117,6 +152,33 @@ func InitializeDatabaseFromConfig(ctx context.Context, configPath string) (db.Da
 	return database, nil
 }
 
+// TursoAuthToken returns the auth token for a Turso database and where it came
+// from: auth_token_file ("file"), else TURSO_AUTH_TOKEN ("env"), else the
+// encrypted credentials store of shark auth login ("credentials"). Both are ""
+// if there is no token.
+func TursoAuthToken(dbConfig config.DatabaseConfig) (token, source string, err error) {
+	if dbConfig.AuthTokenFile != "" {
+		token, err := db.LoadAuthToken(dbConfig.AuthTokenFile)
+		return token, "file", err
+	}
+	if token := strings.TrimSpace(os.Getenv("TURSO_AUTH_TOKEN")); token != "" {
+		return token, "env", nil
+	}
+
+	store, err := credentials.DefaultStore()
+	if err != nil {
+		return "", "", nil
+	}
+	token, err = store.Load(dbConfig.URL)
+	if errors.Is(err, credentials.ErrNotFound) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "credentials", err
+	}
+	return token, "credentials", nil
+}
+
 // GetDatabasePathForBackup returns the database file path for backup operations.
 // This function works with the new cloud-aware architecture by reading the database
 // configuration and determining if the database can be backed up locally.
@@ -6,6 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/credentials"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 )
 
 func TestInitializeDatabase_LocalBackend(t *testing.T) {
@@ -155,6 +159,7 @@ func TestGetDatabaseConfig_ParsesConfigCorrectly(t *testing.T) {
 			"backend":         "turso",
 			"url":             expectedURL,
 			"auth_token_file": expectedTokenFile,
+			"max_retries":     0,
 		},
 	}
 
@@ -186,6 +191,10 @@ func TestGetDatabaseConfig_ParsesConfigCorrectly(t *testing.T) {
 	if dbConfig.AuthTokenFile != expectedTokenFile {
 		t.Errorf("expected auth_token_file %s, got: %s", expectedTokenFile, dbConfig.AuthTokenFile)
 	}
+
+	if dbConfig.MaxRetries == nil || *dbConfig.MaxRetries != 0 {
+		t.Errorf("expected max_retries 0, got: %v", dbConfig.MaxRetries)
+	}
 }
 
 func TestGetDatabaseConfig_FallbackToLocalDB(t *testing.T) {
@@ -223,3 +232,40 @@ func TestGetDatabaseConfig_FallbackToLocalDB(t *testing.T) {
 		t.Error("expected default URL, got empty string")
 	}
 }
+
+func TestTursoAuthToken_Precedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(workspace.HomeEnv, home)
+	t.Setenv(credentials.KeyEnv, "")
+	t.Setenv("TURSO_AUTH_TOKEN", "")
+
+	dbConfig := config.DatabaseConfig{Backend: "turso", URL: "libsql://mydb.turso.io"}
+	token, source, err := TursoAuthToken(dbConfig)
+	if err != nil || token != "" || source != "" {
+		t.Fatalf("expected no token, got %q from %q (%v)", token, source, err)
+	}
+
+	if err := credentials.NewStore(home).Save(dbConfig.URL, "stored-token"); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	token, source, err = TursoAuthToken(dbConfig)
+	if err != nil || token != "stored-token" || source != "credentials" {
+		t.Errorf("expected the stored token, got %q from %q (%v)", token, source, err)
+	}
+
+	t.Setenv("TURSO_AUTH_TOKEN", "env-token")
+	token, source, _ = TursoAuthToken(dbConfig)
+	if token != "env-token" || source != "env" {
+		t.Errorf("expected TURSO_AUTH_TOKEN to win over stored credentials, got %q from %q", token, source)
+	}
+
+	tokenFile := filepath.Join(home, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	dbConfig.AuthTokenFile = tokenFile
+	token, source, _ = TursoAuthToken(dbConfig)
+	if token != "file-token" || source != "file" {
+		t.Errorf("expected auth_token_file to win, got %q from %q", token, source)
+	}
+}
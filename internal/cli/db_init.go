@@ -2,9 +2,12 @@ package cli
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"os"
 	"path/filepath"
 
+	"github.com/jwwelbor/shark-task-manager/internal/config"
 	"github.com/jwwelbor/shark-task-manager/internal/db"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
 )
@@ -18,7 +21,38 @@ func initDatabase(ctx context.Context) (*repository.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to find project root: %w", err)
 	}
+	database, err := openProjectDB(ctx, projectRoot, false)
+	if err != nil {
+		return nil, err
+	}
+	UseStoredWorkflow(ctx, database)
+	return database, nil
+}
+
+// UseStoredWorkflow makes the workflow stored in the project database, if any,
+// the one every command uses instead of the status_flow in .sharkconfig.json.
+// A stored workflow that can't be loaded or is invalid is ignored with a
+// warning.
+func UseStoredWorkflow(ctx context.Context, database *repository.DB) {
+	workflow, err := repository.NewWorkflowRepository(database).Load(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Using the workflow in .sharkconfig.json. Fix it with 'shark workflow import' or remove it with 'shark workflow reset'.\n")
+	}
+	config.UseStoredWorkflow(workflow)
+}
+
+// OpenProjectDB opens the database of the project at projectRoot the way
+// GetDB opens the current project's, e.g. for commands that read several
+// workspaces. Unlike GetDB it fails rather than create a local database that
+// doesn't exist yet. The caller must close it.
+func OpenProjectDB(ctx context.Context, projectRoot string) (*repository.DB, error) {
+	return openProjectDB(ctx, projectRoot, true)
+}
 
+// openProjectDB opens the database of the project at projectRoot, failing if
+// mustExist and it is a local database that doesn't exist
+func openProjectDB(ctx context.Context, projectRoot string, mustExist bool) (*repository.DB, error) {
 	// Get config path
 	configPath := filepath.Join(projectRoot, ".sharkconfig.json")
 
@@ -35,14 +69,31 @@ func initDatabase(ctx context.Context) (*repository.DB, error) {
 		dbPath := dbConfig.URL
 		if dbPath == "" {
 			dbPath = filepath.Join(projectRoot, "shark-tasks.db")
+		} else if !filepath.IsAbs(dbPath) {
+			// "./shark-tasks.db" in the config is relative to the project
+			dbPath = filepath.Join(projectRoot, dbPath)
+		}
+		if mustExist {
+			if _, err := os.Stat(dbPath); err != nil {
+				return nil, fmt.Errorf("no database at %s", dbPath)
+			}
+		}
+
+		if err := db.ConfigureEncryption(dbPath); err != nil {
+			return nil, err
 		}
 
-		database, err := db.InitDB(dbPath)
+		database, err := db.InitDBWithOptions(dbPath, db.InitOptions{
+			VerifySchema: GlobalConfig.VerifySchema,
+			Tuning:       databaseTuning(dbConfig),
+			ReadOnly:     IsReadOnly(),
+			ProjectRoot:  projectRoot,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize database: %w", err)
 		}
 
-		return repository.NewDB(database), nil
+		return newRepositoryDB(database, dbConfig, projectRoot), nil
 	}
 
 	// For Turso cloud, use the new driver system
@@ -64,11 +115,47 @@ func initDatabase(ctx context.Context) (*repository.DB, error) {
 		return nil, fmt.Errorf("failed to get sql.DB from Turso driver: %w", err)
 	}
 
+	// Turso connections can't be opened query_only, so read-only clients rely
+	// on CheckAccess; a read-only auth token enforces it on the server
+	if IsReadOnly() {
+		return newRepositoryDB(sqlDB, dbConfig, projectRoot), nil
+	}
+
 	// Apply schema and migrations to the Turso database
 	// This ensures tables like 'ideas' are created on cloud databases
 	if err := db.ApplySchemaAndMigrations(sqlDB); err != nil {
 		return nil, fmt.Errorf("failed to apply schema and migrations: %w", err)
 	}
+	if err := db.RelativizeFilePaths(sqlDB, projectRoot); err != nil {
+		return nil, fmt.Errorf("failed to apply schema and migrations: %w", err)
+	}
+
+	return newRepositoryDB(sqlDB, dbConfig, projectRoot), nil
+}
 
-	return repository.NewDB(sqlDB), nil
+// newRepositoryDB wraps an open database of the project at projectRoot for the
+// repositories, retrying transient errors as many times as
+// database.max_retries allows
+func newRepositoryDB(sqlDB *sql.DB, dbConfig config.DatabaseConfig, projectRoot string) *repository.DB {
+	database := repository.NewDB(sqlDB)
+	database.SetProjectRoot(projectRoot)
+	if dbConfig.MaxRetries != nil {
+		policy := repository.DefaultRetryPolicy
+		policy.MaxRetries = max(*dbConfig.MaxRetries, 0)
+		database.SetRetryPolicy(policy)
+	}
+	return database
+}
+
+// databaseTuning reads SQLite tuning from the database config, with the
+// --db-busy-timeout and --db-max-open-conns flags taking precedence
+func databaseTuning(dbConfig config.DatabaseConfig) db.Tuning {
+	tuning := db.TuningFromConfig(dbConfig)
+	if GlobalConfig.DBBusyTimeoutMs > 0 {
+		tuning.BusyTimeoutMs = GlobalConfig.DBBusyTimeoutMs
+	}
+	if GlobalConfig.DBMaxOpenConns > 0 {
+		tuning.MaxOpenConns = GlobalConfig.DBMaxOpenConns
+	}
+	return tuning
 }
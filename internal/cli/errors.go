@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/pterm/pterm"
+)
+
+// Exit codes returned by every shark command. They are part of the CLI
+// contract: scripts and orchestrators can rely on them not changing.
+const (
+	ExitSuccess      = 0
+	ExitFailure      = 1 // Not found, invalid arguments, and other failures
+	ExitDatabase     = 2 // The database could not be opened, read, or written
+	ExitInvalidState = 3 // The entity's state doesn't allow the operation (e.g. a status transition)
+	ExitConflict     = 4 // Another agent changed the entity at the same time; re-read and retry
+)
+
+// Error codes in the JSON error envelope. Several codes can share an exit code;
+// the error code tells them apart.
+const (
+	ErrCodeFailure         = "error"
+	ErrCodeNotFound        = "not_found"
+	ErrCodeInvalidArgument = "invalid_argument"
+	ErrCodeDatabase        = "database_error"
+	ErrCodeInvalidState    = "invalid_state"
+	ErrCodeConflict        = "conflict"
+	ErrCodePermission      = "permission_denied"
+)
+
+// errorExitCodes maps each error code to its exit code
+var errorExitCodes = map[string]int{
+	ErrCodeFailure:         ExitFailure,
+	ErrCodeNotFound:        ExitFailure,
+	ErrCodeInvalidArgument: ExitFailure,
+	ErrCodeDatabase:        ExitDatabase,
+	ErrCodeInvalidState:    ExitInvalidState,
+	ErrCodeConflict:        ExitConflict,
+	ErrCodePermission:      ExitFailure,
+}
+
+// CommandError is a command failure with a stable code, a message, and an
+// optional hint about what to do next
+type CommandError struct {
+	Code    string
+	Message string
+	Hint    string
+	Err     error // Underlying error, if any
+}
+
+// NewError creates a CommandError with one of the ErrCode* codes
+func NewError(code, message string) *CommandError {
+	return &CommandError{Code: code, Message: message}
+}
+
+// WithHint sets the error's hint
+func (e *CommandError) WithHint(hint string) *CommandError {
+	e.Hint = hint
+	return e
+}
+
+// Error implements the error interface
+func (e *CommandError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit code for the error's code
+func (e *CommandError) ExitCode() int {
+	if code, ok := errorExitCodes[e.Code]; ok {
+		return code
+	}
+	return ExitFailure
+}
+
+// errorEnvelope is the JSON object written to stderr for a failed command in --json mode
+type errorEnvelope struct {
+	Error errorBody `json:"error"`
+}
+
+// errorBody is the content of an errorEnvelope
+type errorBody struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Hint     string `json:"hint,omitempty"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// errWriter receives error output (replaced in tests)
+var errWriter io.Writer = os.Stderr
+
+// osExit ends the process (replaced in tests)
+var osExit = os.Exit
+
+// AsCommandError classifies any error as a CommandError. Errors that aren't
+// CommandErrors are classified by what they wrap: version conflicts, missing
+// rows, and "not found" messages get their own codes, everything else is a
+// general failure.
+func AsCommandError(err error) *CommandError {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr
+	}
+
+	result := &CommandError{Code: ErrCodeFailure, Message: err.Error(), Err: err}
+	var conflict *repository.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		result.Code = ErrCodeConflict
+	case errors.Is(err, sql.ErrNoRows), strings.Contains(strings.ToLower(err.Error()), "not found"):
+		result.Code = ErrCodeNotFound
+	}
+	return result
+}
+
+// ReportError writes a failed command's error to stderr and returns its exit
+// code. In --json mode the error is a single JSON object:
+//
+//	{"error": {"code": "not_found", "message": "...", "hint": "...", "exit_code": 1}}
+//
+// Otherwise it is printed as an error message followed by the hint, using the
+// --log-format like other status messages.
+func ReportError(err error) int {
+	cmdErr := AsCommandError(err)
+	if GlobalConfig.JSON {
+		writeErrorEnvelope(errWriter, cmdErr)
+		return cmdErr.ExitCode()
+	}
+
+	message := cmdErr.Message
+	if !strings.HasPrefix(message, "Error") {
+		message = "Error: " + message
+	}
+	if !writeStructuredLog(LogLevelError, message) {
+		if GlobalConfig.NoColor {
+			fmt.Fprintln(errWriter, "✗", message)
+		} else {
+			pterm.Error.WithWriter(errWriter).Println(message)
+		}
+	}
+	if cmdErr.Hint != "" && !writeStructuredLog(LogLevelInfo, cmdErr.Hint) {
+		if GlobalConfig.NoColor {
+			fmt.Fprintln(errWriter, "ℹ", cmdErr.Hint)
+		} else {
+			pterm.Info.WithWriter(errWriter).Println(cmdErr.Hint)
+		}
+	}
+	return cmdErr.ExitCode()
+}
+
+// writeErrorEnvelope writes the JSON error envelope
+func writeErrorEnvelope(w io.Writer, err *CommandError) {
+	data, _ := json.Marshal(errorEnvelope{Error: errorBody{
+		Code:     err.Code,
+		Message:  strings.TrimPrefix(err.Message, "Error: "),
+		Hint:     err.Hint,
+		ExitCode: err.ExitCode(),
+	}})
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// Fail reports an error with one of the ErrCode* codes and exits with the
+// matching exit code. Hints are printed after the message, one per line.
+func Fail(code, message string, hints ...string) {
+	Exit(ReportError(&CommandError{Code: code, Message: message, Hint: strings.Join(hints, "\n")}))
+}
+
+// Exit ends the process with one of the Exit* codes. Use it when the command
+// has already reported the problem itself, such as a validation report.
+func Exit(code int) {
+	_ = CloseDB()
+	osExit(code)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+func TestAsCommandError(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantCode string
+		wantExit int
+	}{
+		{NewError(ErrCodeInvalidState, "task is blocked"), ErrCodeInvalidState, ExitInvalidState},
+		{fmt.Errorf("wrapped: %w", NewError(ErrCodeDatabase, "locked")), ErrCodeDatabase, ExitDatabase},
+		{&repository.VersionConflictError{TaskID: 1, Expected: 1, Actual: 2}, ErrCodeConflict, ExitConflict},
+		{fmt.Errorf("failed to get task: %w", sql.ErrNoRows), ErrCodeNotFound, ExitFailure},
+		{errors.New("feature E01-F09 not found"), ErrCodeNotFound, ExitFailure},
+		{errors.New("something broke"), ErrCodeFailure, ExitFailure},
+	}
+
+	for _, tt := range tests {
+		cmdErr := AsCommandError(tt.err)
+		if cmdErr.Code != tt.wantCode {
+			t.Errorf("AsCommandError(%v).Code = %q, want %q", tt.err, cmdErr.Code, tt.wantCode)
+		}
+		if cmdErr.ExitCode() != tt.wantExit {
+			t.Errorf("AsCommandError(%v).ExitCode() = %d, want %d", tt.err, cmdErr.ExitCode(), tt.wantExit)
+		}
+	}
+}
+
+func TestReportError(t *testing.T) {
+	origJSON, origNoColor, origFormat := GlobalConfig.JSON, GlobalConfig.NoColor, GlobalConfig.LogFormat
+	origErrWriter := errWriter
+	defer func() {
+		GlobalConfig.JSON, GlobalConfig.NoColor, GlobalConfig.LogFormat = origJSON, origNoColor, origFormat
+		errWriter = origErrWriter
+	}()
+	GlobalConfig.LogFormat = LogFormatText
+	GlobalConfig.NoColor = true
+
+	err := NewError(ErrCodeNotFound, "Error: Task T-E01-F01-009 not found").WithHint("Use 'shark task list' to see available tasks")
+
+	var buf bytes.Buffer
+	errWriter = &buf
+	GlobalConfig.JSON = true
+	if code := ReportError(err); code != ExitFailure {
+		t.Errorf("ReportError returned %d, want %d", code, ExitFailure)
+	}
+	want := `{"error":{"code":"not_found","message":"Task T-E01-F01-009 not found","hint":"Use 'shark task list' to see available tasks","exit_code":1}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("JSON output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	GlobalConfig.JSON = false
+	ReportError(errors.New("disk full"))
+	if want := "✗ Error: disk full\n"; buf.String() != want {
+		t.Errorf("text output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFail(t *testing.T) {
+	origJSON, origErrWriter, origExit := GlobalConfig.JSON, errWriter, osExit
+	defer func() {
+		GlobalConfig.JSON, errWriter, osExit = origJSON, origErrWriter, origExit
+	}()
+
+	var buf bytes.Buffer
+	errWriter = &buf
+	GlobalConfig.JSON = true
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	Fail(ErrCodeConflict, "Error: task T-E01-F01-001 was changed by another agent")
+	if exitCode != ExitConflict {
+		t.Errorf("Fail exited with %d, want %d", exitCode, ExitConflict)
+	}
+	want := `{"error":{"code":"conflict","message":"task T-E01-F01-001 was changed by another agent","exit_code":4}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("JSON output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log formats for status messages (Success, Warning, Info, Error)
+const (
+	LogFormatText  = "text"  // Decorated pterm output (default)
+	LogFormatPlain = "plain" // "LEVEL: message" lines on stderr, no emoji or ANSI
+	LogFormatJSON  = "json"  // One JSON object per line on stderr
+)
+
+// Log levels written by the plain and json log formats
+const (
+	LogLevelSuccess = "success"
+	LogLevelInfo    = "info"
+	LogLevelWarning = "warning"
+	LogLevelError   = "error"
+)
+
+// logWriter receives structured status messages. Always stderr in production so
+// log lines never interleave with JSON payloads on stdout.
+var logWriter io.Writer = os.Stderr
+
+// logMu serializes writes so concurrent messages never interleave mid-line
+var logMu sync.Mutex
+
+// logNow returns the timestamp for structured log lines (replaced in tests)
+var logNow = time.Now
+
+// logEntry is the shape of a json log line
+type logEntry struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// ValidateLogFormat checks a --log-format value
+func ValidateLogFormat(format string) error {
+	switch format {
+	case "", LogFormatText, LogFormatPlain, LogFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid --log-format %q: must be text, plain, or json", format)
+}
+
+// structuredLogging reports whether status messages use a plain or json writer
+func structuredLogging() bool {
+	return GlobalConfig.LogFormat == LogFormatPlain || GlobalConfig.LogFormat == LogFormatJSON
+}
+
+// formatLogLine renders a status message for the plain or json log format
+func formatLogLine(format, level, message string) string {
+	if format == LogFormatJSON {
+		data, err := json.Marshal(logEntry{
+			Time:    logNow().UTC().Format(time.RFC3339),
+			Level:   level,
+			Message: message,
+		})
+		if err == nil {
+			return string(data) + "\n"
+		}
+	}
+	// Keep one line per message so prefixes stay stable for parsers
+	message = strings.ReplaceAll(strings.TrimRight(message, "\n"), "\n", " ")
+	return strings.ToUpper(level) + ": " + message + "\n"
+}
+
+// writeStructuredLog writes a status message with the configured log format.
+// Returns false when the default decorated output should be used instead.
+func writeStructuredLog(level, message string) bool {
+	if !structuredLogging() {
+		return false
+	}
+
+	logMu.Lock()
+	defer logMu.Unlock()
+	_, _ = io.WriteString(logWriter, formatLogLine(GlobalConfig.LogFormat, level, message))
+	return true
+}
+
+// Diagnostic logging. Status messages above are for the user; diagnostics are
+// slog records about what shark is doing (failed lookups, SQL, RPCs) and are
+// off unless --verbose or --log-level asks for them.
+
+// LogLevelOff disables diagnostic logging (the default without --verbose)
+const LogLevelOff = "off"
+
+// Repeats of one diagnostic message beyond logRateBurst per logRateInterval
+// are dropped, so a hot loop can't flood the log
+const (
+	logRateBurst    = 20
+	logRateInterval = time.Second
+)
+
+// slogLevelOff is above every slog level, so nothing is enabled
+const slogLevelOff = slog.Level(100)
+
+// logFile is the open --log-file, closed by CloseLogger
+var logFile *os.File
+
+// ParseLogLevel parses a --log-level value: debug, info, warn, error, or off
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", LogLevelWarning:
+		return slog.LevelWarn, nil
+	case LogLevelError:
+		return slog.LevelError, nil
+	case LogLevelOff, "none":
+		return slogLevelOff, nil
+	}
+	return 0, fmt.Errorf("invalid --log-level %q: must be debug, info, warn, error, or off", level)
+}
+
+// InitLogger builds the diagnostic logger from --log-level, --log-format, and
+// --log-file and makes it the slog default. Without --log-level, --verbose
+// logs everything and otherwise nothing is logged.
+func InitLogger() error {
+	level := slogLevelOff
+	if GlobalConfig.Verbose {
+		level = slog.LevelDebug
+	}
+	if GlobalConfig.LogLevel != "" {
+		parsed, err := ParseLogLevel(GlobalConfig.LogLevel)
+		if err != nil {
+			return err
+		}
+		level = parsed
+	}
+
+	out := logWriter
+	if GlobalConfig.LogFile != "" {
+		file, err := os.OpenFile(GlobalConfig.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		CloseLogger()
+		logFile = file
+		out = file
+	}
+
+	handler := newLogHandler(out, GlobalConfig.LogFormat, level)
+	slog.SetDefault(slog.New(newRateLimitHandler(handler, logRateBurst, logRateInterval)))
+	return nil
+}
+
+// CloseLogger closes the --log-file, if one is open
+func CloseLogger() {
+	if logFile != nil {
+		_ = logFile.Close()
+		logFile = nil
+	}
+}
+
+// newLogHandler returns a JSON handler for --log-format=json and a key=value
+// text handler otherwise. JSON records use the same time, level, and message
+// keys as json status lines, so one parser reads both.
+func newLogHandler(w io.Writer, format string, level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format != LogFormatJSON {
+		return slog.NewTextHandler(w, opts)
+	}
+	opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) > 0 {
+			return a
+		}
+		switch a.Key {
+		case slog.TimeKey:
+			return slog.String(slog.TimeKey, a.Value.Time().UTC().Format(time.RFC3339))
+		case slog.LevelKey:
+			levelName := strings.ToLower(a.Value.String())
+			if levelName == "warn" {
+				levelName = LogLevelWarning
+			}
+			return slog.String(slog.LevelKey, levelName)
+		case slog.MessageKey:
+			return slog.Attr{Key: "message", Value: a.Value}
+		}
+		return a
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+// rateLimitHandler drops repeats of a message beyond a burst per interval. The
+// next record of that message to get through carries a "suppressed" count.
+type rateLimitHandler struct {
+	next    slog.Handler
+	limiter *logLimiter // Shared by handlers derived with WithAttrs/WithGroup
+}
+
+// logLimiter counts records per level and message in fixed windows
+type logLimiter struct {
+	mu       sync.Mutex
+	burst    int
+	interval time.Duration
+	now      func() time.Time
+	windows  map[string]*logWindow
+}
+
+type logWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+func newRateLimitHandler(next slog.Handler, burst int, interval time.Duration) *rateLimitHandler {
+	return &rateLimitHandler{
+		next: next,
+		limiter: &logLimiter{
+			burst:    burst,
+			interval: interval,
+			now:      time.Now,
+			windows:  make(map[string]*logWindow),
+		},
+	}
+}
+
+func (h *rateLimitHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *rateLimitHandler) Handle(ctx context.Context, record slog.Record) error {
+	ok, suppressed := h.limiter.allow(record.Level.String() + "\x00" + record.Message)
+	if !ok {
+		return nil
+	}
+	if suppressed > 0 {
+		record = record.Clone()
+		record.AddAttrs(slog.Int("suppressed", suppressed))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *rateLimitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &rateLimitHandler{next: h.next.WithAttrs(attrs), limiter: h.limiter}
+}
+
+func (h *rateLimitHandler) WithGroup(name string) slog.Handler {
+	return &rateLimitHandler{next: h.next.WithGroup(name), limiter: h.limiter}
+}
+
+// allow reports whether a record with key may be logged, and how many records
+// with that key were dropped in the previous window
+func (l *logLimiter) allow(key string) (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	window := l.windows[key]
+	suppressed := 0
+	if window == nil || now.Sub(window.start) >= l.interval {
+		if window != nil {
+			suppressed = window.suppressed
+		}
+		window = &logWindow{start: now}
+		l.windows[key] = window
+	}
+	if window.count >= l.burst {
+		window.suppressed++
+		return false, 0
+	}
+	window.count++
+	return true, suppressed
+}
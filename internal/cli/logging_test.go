@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateLogFormat(t *testing.T) {
+	for _, format := range []string{"", "text", "plain", "json"} {
+		if err := ValidateLogFormat(format); err != nil {
+			t.Errorf("ValidateLogFormat(%q) returned error: %v", format, err)
+		}
+	}
+	if err := ValidateLogFormat("xml"); err == nil {
+		t.Error("ValidateLogFormat(\"xml\") should return an error")
+	}
+}
+
+func TestStructuredLogging(t *testing.T) {
+	origFormat, origWriter, origNow := GlobalConfig.LogFormat, logWriter, logNow
+	defer func() {
+		GlobalConfig.LogFormat, logWriter, logNow = origFormat, origWriter, origNow
+	}()
+	logNow = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	tests := []struct {
+		format string
+		log    func()
+		want   string
+	}{
+		{LogFormatPlain, func() { Success("Task started") }, "SUCCESS: Task started\n"},
+		{LogFormatPlain, func() { Info("Found %d tasks", 3) }, "INFO: Found 3 tasks\n"},
+		{LogFormatPlain, func() { Warning("line one\nline two") }, "WARNING: line one line two\n"},
+		{LogFormatJSON, func() { Error("boom") }, `{"time":"2026-01-02T03:04:05Z","level":"error","message":"boom"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		logWriter = &buf
+		GlobalConfig.LogFormat = tt.format
+		tt.log()
+		if buf.String() != tt.want {
+			t.Errorf("format %s: got %q, want %q", tt.format, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		"warn":    slog.LevelWarn,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+		"off":     slogLevelOff,
+	}
+	for value, want := range tests {
+		got, err := ParseLogLevel(value)
+		if err != nil || got != want {
+			t.Errorf("ParseLogLevel(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseLogLevel("loud"); err == nil {
+		t.Error("ParseLogLevel(\"loud\") should return an error")
+	}
+}
+
+func TestLogHandler_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(newLogHandler(&buf, LogFormatJSON, slog.LevelInfo))
+	logger.Debug("hidden")
+	logger.Warn("Status cascade failed", "feature", "E01-F01")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["level"] != "warning" || record["message"] != "Status cascade failed" || record["feature"] != "E01-F01" {
+		t.Errorf("unexpected record: %v", record)
+	}
+	if _, err := time.Parse(time.RFC3339, record["time"].(string)); err != nil {
+		t.Errorf("time is not RFC3339: %v", record["time"])
+	}
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := newRateLimitHandler(newLogHandler(&buf, LogFormatText, slog.LevelDebug), 2, time.Second)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	handler.limiter.now = func() time.Time { return now }
+	logger := slog.New(handler).With("component", "db")
+
+	for i := 0; i < 5; i++ {
+		logger.Debug("sql")
+	}
+	slog.New(handler).Debug("other")
+	if got := strings.Count(buf.String(), "msg=sql"); got != 2 {
+		t.Errorf("expected 2 records within the burst, got %d:\n%s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "msg=other") {
+		t.Error("other messages should not be limited")
+	}
+
+	buf.Reset()
+	now = now.Add(time.Second)
+	logger.Debug("sql")
+	if !strings.Contains(buf.String(), "suppressed=3") {
+		t.Errorf("expected suppressed count in the next window, got %q", buf.String())
+	}
+}
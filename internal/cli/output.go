@@ -53,6 +53,7 @@ func FormatEntityCreationJSON(entityType, entityKey, entityTitle, filePath, proj
 
 	// Basic fields
 	result["status"] = "created"
+	result["created"] = true
 	result["entity_type"] = entityType
 	result["key"] = entityKey
 	result["title"] = entityTitle
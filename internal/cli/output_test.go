@@ -137,6 +137,7 @@ func TestFormatEntityCreationJSON(t *testing.T) {
 			requiredSections: []string{"Implementation Plan", "Acceptance Criteria", "Test Plan"},
 			wantFields: map[string]interface{}{
 				"status":           "created",
+				"created":          true,
 				"entity_type":      "task",
 				"key":              "T-E07-F01-001",
 				"title":            "Implement JWT validation",
@@ -154,6 +155,7 @@ func TestFormatEntityCreationJSON(t *testing.T) {
 			requiredSections: []string{"Vision & Goals"},
 			wantFields: map[string]interface{}{
 				"status":           "created",
+				"created":          true,
 				"entity_type":      "epic",
 				"key":              "E07",
 				"file_state":       "placeholder",
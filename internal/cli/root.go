@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -13,13 +15,36 @@ import (
 
 // Config holds the global CLI configuration
 type Config struct {
-	JSON       bool
-	NoColor    bool
-	Verbose    bool
-	ConfigFile string
-	DBPath     string
+	JSON         bool
+	NoColor      bool
+	Verbose      bool
+	ConfigFile   string
+	DBPath       string
+	LogFormat    string // Status message format: text (default), plain, or json
+	LogLevel     string // Diagnostic log level; empty means debug with --verbose, else off
+	LogFile      string // Diagnostic log file; empty means stderr
+	VerifySchema bool   // Apply the full schema and migrations even if the database is current
+	ProjectRoot  string // Explicit project root; overrides discovery and the working directory
+	Workspace    string // Registered workspace whose root is the project root
+
+	DBBusyTimeoutMs int // Overrides database.busy_timeout_ms when set
+	DBMaxOpenConns  int // Overrides database.max_open_conns when set
+
+	Timeout time.Duration // Time limit for each command; see CommandTimeout
+
+	ReadOnly bool   // Reject commands that write and open the database read-only
+	Role     string // reader, contributor, or admin (default); see CheckAccess
 }
 
+// ProjectRootEnv is the environment variable that sets the project root when
+// --project-root is not given
+const ProjectRootEnv = "SHARK_PROJECT_ROOT"
+
+// SkipWorkspaceAnnotation marks commands (and their subcommands) that ignore
+// the current workspace, such as init, which creates a project in the working
+// directory
+const SkipWorkspaceAnnotation = "shark_skip_workspace"
+
 // GlobalConfig is the shared configuration instance
 var GlobalConfig = &Config{}
 
@@ -33,12 +58,36 @@ in multi-agent software development projects.
 It provides a SQLite-backed database for tracking project state with commands
 optimized for both human developers and AI agents.`,
 	Version: "dev", // Will be set by SetVersion() from build-time injection
+	// Errors are written by ReportError, so --json can report them as JSON
+	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Switch to an explicit project root before any path is resolved
+		if err := applyProjectRoot(cmd); err != nil {
+			return err
+		}
+
 		// Initialize configuration
 		if err := initConfig(); err != nil {
 			return fmt.Errorf("failed to initialize config: %w", err)
 		}
 
+		if err := ValidateLogFormat(GlobalConfig.LogFormat); err != nil {
+			return err
+		}
+		if err := InitLogger(); err != nil {
+			return err
+		}
+
+		// Usage text would follow the JSON error envelope on stderr
+		if GlobalConfig.JSON {
+			cmd.SilenceUsage = true
+		}
+
+		if err := CheckAccess(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
 		// Disable color output if requested
 		if GlobalConfig.NoColor {
 			pterm.DisableColor()
@@ -59,6 +108,7 @@ optimized for both human developers and AI agents.`,
 				pterm.Warning.Printf("Failed to close database: %v\n", err)
 			}
 		}
+		CloseLogger()
 		return nil
 	},
 }
@@ -69,8 +119,12 @@ func SetVersion(version string) {
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
-func Execute() error {
-	return RootCmd.Execute()
+// Errors are reported with ReportError; the returned exit code is one of the Exit* codes.
+func Execute() int {
+	if err := RootCmd.Execute(); err != nil {
+		return ReportError(err)
+	}
+	return ExitSuccess
 }
 
 func init() {
@@ -94,12 +148,32 @@ func init() {
 		},
 	)
 
+	// Flag errors are invalid arguments, whichever command they come from
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &CommandError{
+			Code:    ErrCodeInvalidArgument,
+			Message: err.Error(),
+			Hint:    fmt.Sprintf("Run '%s --help' for usage", cmd.CommandPath()),
+			Err:     err,
+		}
+	})
+
 	// Global flags available to all commands
 	RootCmd.PersistentFlags().BoolVar(&GlobalConfig.JSON, "json", false, "Output in JSON format (machine-readable)")
 	RootCmd.PersistentFlags().BoolVar(&GlobalConfig.NoColor, "no-color", false, "Disable colored output")
 	RootCmd.PersistentFlags().BoolVarP(&GlobalConfig.Verbose, "verbose", "v", false, "Enable verbose/debug output")
 	RootCmd.PersistentFlags().StringVar(&GlobalConfig.ConfigFile, "config", "", "Config file path (default: .sharkconfig.json)")
 	RootCmd.PersistentFlags().StringVar(&GlobalConfig.DBPath, "db", "shark-tasks.db", "Database file path")
+	RootCmd.PersistentFlags().StringVar(&GlobalConfig.ProjectRoot, "project-root", "", "Project root directory (default: discovered from the working directory; env: "+ProjectRootEnv+")")
+	RootCmd.PersistentFlags().StringVar(&GlobalConfig.Workspace, "workspace", "", "Run in a registered workspace (default: the nearest project, else the current workspace)")
+	RootCmd.PersistentFlags().BoolVar(&GlobalConfig.VerifySchema, "verify-schema", false, "Re-apply the database schema and migrations even if the database is up to date")
+	RootCmd.PersistentFlags().IntVar(&GlobalConfig.DBBusyTimeoutMs, "db-busy-timeout", 0, "Milliseconds to wait on a locked database before failing (default: database.busy_timeout_ms or 5000)")
+	RootCmd.PersistentFlags().IntVar(&GlobalConfig.DBMaxOpenConns, "db-max-open-conns", 0, "Maximum open database connections (default: database.max_open_conns or unlimited)")
+	RootCmd.PersistentFlags().DurationVar(&GlobalConfig.Timeout, "timeout", 0, "Time limit for each command, e.g. 45s or 2m (default: timeout in config, else 30s, or longer for bulk commands like sync and scan)")
+	RootCmd.PersistentFlags().StringVar(&GlobalConfig.LogFormat, "log-format", LogFormatText, "Status message and diagnostic log format: text, plain, or json (plain/json write to stderr)")
+	RootCmd.PersistentFlags().StringVar(&GlobalConfig.LogLevel, "log-level", "", "Diagnostic log level: debug, info, warn, error, or off (default: debug with --verbose, else off)")
+	RootCmd.PersistentFlags().StringVar(&GlobalConfig.LogFile, "log-file", "", "Append diagnostic logs to this file instead of stderr")
+	RootCmd.PersistentFlags().BoolVar(&GlobalConfig.ReadOnly, "read-only", false, "Open the database read-only and reject commands that change it (default: read_only in config; env: PM_READ_ONLY)")
 
 	// Bind flags to viper for config file support
 	if err := viper.BindPFlag("json", RootCmd.PersistentFlags().Lookup("json")); err != nil {
@@ -118,77 +192,151 @@ func init() {
 
 // FindProjectRoot walks up the directory tree to find the project root.
 // It looks for markers with different priorities:
-// 1. .sharkconfig.json (STRONGEST - always preferred)
-// 2. shark-tasks.db (STRONG - used if no .sharkconfig.json found)
-// 3. .git/ directory (WEAK - used if no stronger markers found)
+// 1. .shark.yaml (STRONGEST - an explicit workspace marker)
+// 2. .sharkconfig.json (STRONG - used if no .shark.yaml found)
+// 3. shark-tasks.db (MEDIUM - used if no config found)
+// 4. .git/ directory (WEAK - used if no stronger markers found)
 //
 // The search goes all the way to the filesystem root and returns the BEST marker found,
-// preferring .sharkconfig.json over everything else. This ensures that if .sharkconfig.json
+// preferring .shark.yaml and .sharkconfig.json over everything else. This ensures that if .sharkconfig.json
 // exists in the project root but shark-tasks.db exists in a subdirectory (like /docs),
 // we correctly identify the project root.
 //
 // Returns the project root directory, or current directory if no markers found.
+// An explicit root set with --project-root is returned as-is.
 func FindProjectRoot() (string, error) {
+	if GlobalConfig.ProjectRoot != "" {
+		return GlobalConfig.ProjectRoot, nil
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("failed to get working directory: %w", err)
 	}
+	if root := workspace.FindRoot(wd); root != "" {
+		return root, nil
+	}
 
-	// Track the best marker found during search
-	var foundConfig string // .sharkconfig.json (highest priority)
-	var foundDB string     // shark-tasks.db (medium priority)
-	var foundGit string    // .git directory (lowest priority)
-
-	currentDir := wd
+	// No markers found, use working directory
+	return wd, nil
+}
 
-	// Search from current directory up to filesystem root
-	for {
-		// Check for .sharkconfig.json (highest priority)
-		// Take the first (closest) one found
-		if foundConfig == "" {
-			if _, err := os.Stat(filepath.Join(currentDir, ".sharkconfig.json")); err == nil {
-				foundConfig = currentDir
-			}
+// applyProjectRoot makes the --project-root directory (or SHARK_PROJECT_ROOT)
+// the working directory, so every path resolved relative to the project root
+// or the working directory uses it. Relative --config and --db paths are kept
+// relative to the directory shark was started from.
+//
+// Without an explicit root, the --workspace root is used, then the current
+// workspace's root when shark is run outside any project.
+func applyProjectRoot(cmd *cobra.Command) error {
+	root := GlobalConfig.ProjectRoot
+	if root == "" {
+		root = os.Getenv(ProjectRootEnv)
+	}
+	if root == "" && !skipsWorkspace(cmd) {
+		var err error
+		if root, err = workspaceRoot(); err != nil {
+			return err
 		}
+	}
+	if root == "" {
+		return nil
+	}
 
-		// Check for shark-tasks.db (medium priority)
-		if foundDB == "" {
-			if _, err := os.Stat(filepath.Join(currentDir, "shark-tasks.db")); err == nil {
-				foundDB = currentDir
-			}
-		}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("invalid project root %q: %w", root, err)
+	}
+	info, err := os.Stat(absRoot)
+	if err != nil {
+		return fmt.Errorf("project root %s does not exist", absRoot)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("project root %s is not a directory", absRoot)
+	}
 
-		// Check for .git directory (lowest priority)
-		if foundGit == "" {
-			if _, err := os.Stat(filepath.Join(currentDir, ".git")); err == nil {
-				foundGit = currentDir
-			}
+	if GlobalConfig.ConfigFile != "" && !filepath.IsAbs(GlobalConfig.ConfigFile) {
+		if GlobalConfig.ConfigFile, err = filepath.Abs(GlobalConfig.ConfigFile); err != nil {
+			return err
+		}
+	}
+	if GlobalConfig.DBPath != "shark-tasks.db" && !filepath.IsAbs(GlobalConfig.DBPath) {
+		if GlobalConfig.DBPath, err = filepath.Abs(GlobalConfig.DBPath); err != nil {
+			return err
 		}
+	}
 
-		// Move up one directory
-		parentDir := filepath.Dir(currentDir)
+	if err := os.Chdir(absRoot); err != nil {
+		return fmt.Errorf("failed to change to project root: %w", err)
+	}
+	GlobalConfig.ProjectRoot = absRoot
+	return nil
+}
 
-		// If we've reached the filesystem root, stop searching
-		if parentDir == currentDir {
-			break
+// workspaceRoot returns the root of the --workspace workspace, or of the
+// current workspace when the working directory is not inside a project.
+// Returns "" when neither applies.
+func workspaceRoot() (string, error) {
+	if GlobalConfig.Workspace == "" {
+		wd, err := os.Getwd()
+		if err != nil || insideProject(wd) {
+			return "", nil
 		}
+	}
 
-		currentDir = parentDir
+	registryPath, err := workspace.RegistryPath()
+	if err != nil {
+		return "", err
+	}
+	registry, err := workspace.LoadRegistry(registryPath)
+	if err != nil {
+		if GlobalConfig.Workspace == "" {
+			// A broken registry shouldn't stop commands that never asked for it
+			return "", nil
+		}
+		return "", err
 	}
 
-	// Return the best marker found, in priority order
-	if foundConfig != "" {
-		return foundConfig, nil
+	if GlobalConfig.Workspace == "" {
+		if current := registry.CurrentWorkspace(); current != nil {
+			return current.Root, nil
+		}
+		return "", nil
 	}
-	if foundDB != "" {
-		return foundDB, nil
+
+	ws := registry.Get(GlobalConfig.Workspace)
+	if ws == nil {
+		return "", fmt.Errorf("workspace %q not found (see 'shark workspace list')", GlobalConfig.Workspace)
 	}
-	if foundGit != "" {
-		return foundGit, nil
+	return ws.Root, nil
+}
+
+// insideProject reports whether dir or any parent holds a shark project
+// marker. A bare .git directory doesn't count.
+func insideProject(dir string) bool {
+	for {
+		for _, marker := range []string{workspace.ProjectFileName, ".sharkconfig.json", "shark-tasks.db"} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
 	}
+}
 
-	// No markers found, use working directory
-	return wd, nil
+// skipsWorkspace reports whether cmd or a parent is annotated to run in the
+// working directory regardless of the current workspace
+func skipsWorkspace(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[SkipWorkspaceAnnotation] == "true" {
+			return true
+		}
+	}
+	return false
 }
 
 // GetConfigPath returns the absolute path to .sharkconfig.json.
@@ -269,6 +417,25 @@ func initConfig() error {
 		GlobalConfig.DBPath = viper.GetString("db")
 	}
 
+	// --timeout takes precedence over the config
+	if GlobalConfig.Timeout < 0 {
+		return fmt.Errorf("invalid --timeout %s: must not be negative", GlobalConfig.Timeout)
+	}
+	if GlobalConfig.Timeout == 0 {
+		timeout, err := ParseTimeout(viper.Get("timeout"))
+		if err != nil {
+			return err
+		}
+		GlobalConfig.Timeout = timeout
+	}
+
+	// --read-only can only tighten access set in the config
+	GlobalConfig.ReadOnly = GlobalConfig.ReadOnly || viper.GetBool("read_only")
+	GlobalConfig.Role = viper.GetString("role")
+	if err := ValidateRole(GlobalConfig.Role); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -300,6 +467,9 @@ func OutputTable(headers []string, rows [][]string) {
 
 // Success prints a success message
 func Success(message string) {
+	if writeStructuredLog(LogLevelSuccess, message) {
+		return
+	}
 	if !GlobalConfig.NoColor {
 		pterm.Success.Println(message)
 	} else {
@@ -309,6 +479,9 @@ func Success(message string) {
 
 // Error prints an error message
 func Error(message string) {
+	if writeStructuredLog(LogLevelError, message) {
+		return
+	}
 	if !GlobalConfig.NoColor {
 		pterm.Error.Println(message)
 	} else {
@@ -318,6 +491,9 @@ func Error(message string) {
 
 // Warning prints a warning message
 func Warning(message string) {
+	if writeStructuredLog(LogLevelWarning, message) {
+		return
+	}
 	if !GlobalConfig.NoColor {
 		pterm.Warning.Println(message)
 	} else {
@@ -328,6 +504,9 @@ func Warning(message string) {
 // Info prints an info message
 func Info(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
+	if writeStructuredLog(LogLevelInfo, message) {
+		return
+	}
 	if !GlobalConfig.NoColor {
 		pterm.Info.Println(message)
 	} else {
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -418,3 +420,134 @@ func TestFindProjectRoot_NestedGitWithoutParentConfig(t *testing.T) {
 		t.Errorf("FindProjectRoot() = %v, want %v (should use .git as fallback)", root, nestedRepo)
 	}
 }
+
+func TestApplyProjectRoot(t *testing.T) {
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	originalConfig := *GlobalConfig
+	t.Cleanup(func() {
+		_ = os.Chdir(originalWd)
+		*GlobalConfig = originalConfig
+	})
+
+	// Resolve symlinks so paths compare equal on systems with a symlinked temp dir
+	projectDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		GlobalConfig.ProjectRoot = projectDir
+		GlobalConfig.ConfigFile = "custom.json"
+		if err := applyProjectRoot(RootCmd); err != nil {
+			t.Fatalf("applyProjectRoot() returned error: %v", err)
+		}
+
+		wd, _ := os.Getwd()
+		if wd != projectDir {
+			t.Errorf("Expected working directory %s, got %s", projectDir, wd)
+		}
+		root, err := FindProjectRoot()
+		if err != nil || root != projectDir {
+			t.Errorf("Expected FindProjectRoot() = %s, got %s (err %v)", projectDir, root, err)
+		}
+		if want := filepath.Join(originalWd, "custom.json"); GlobalConfig.ConfigFile != want {
+			t.Errorf("Expected relative --config to stay relative to the start directory (%s), got %s", want, GlobalConfig.ConfigFile)
+		}
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		_ = os.Chdir(originalWd)
+		*GlobalConfig = originalConfig
+		t.Setenv(ProjectRootEnv, projectDir)
+
+		if err := applyProjectRoot(RootCmd); err != nil {
+			t.Fatalf("applyProjectRoot() returned error: %v", err)
+		}
+		if GlobalConfig.ProjectRoot != projectDir {
+			t.Errorf("Expected project root %s from %s, got %s", projectDir, ProjectRootEnv, GlobalConfig.ProjectRoot)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		*GlobalConfig = originalConfig
+		GlobalConfig.ProjectRoot = filepath.Join(projectDir, "missing")
+		if err := applyProjectRoot(RootCmd); err == nil {
+			t.Error("Expected an error for a missing project root")
+		}
+	})
+
+	t.Run("workspace", func(t *testing.T) {
+		_ = os.Chdir(originalWd)
+		*GlobalConfig = originalConfig
+		t.Setenv(ProjectRootEnv, "")
+		t.Setenv(workspace.HomeEnv, t.TempDir())
+
+		registryPath, _ := workspace.RegistryPath()
+		registry := &workspace.Registry{}
+		if err := registry.Add(workspace.Workspace{Name: "api", Root: projectDir}); err != nil {
+			t.Fatalf("Add() returned error: %v", err)
+		}
+		if err := registry.Save(registryPath); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		GlobalConfig.Workspace = "api"
+		if err := applyProjectRoot(RootCmd); err != nil {
+			t.Fatalf("applyProjectRoot() returned error: %v", err)
+		}
+		if GlobalConfig.ProjectRoot != projectDir {
+			t.Errorf("Expected project root %s from --workspace, got %s", projectDir, GlobalConfig.ProjectRoot)
+		}
+
+		*GlobalConfig = originalConfig
+		GlobalConfig.Workspace = "web"
+		if err := applyProjectRoot(RootCmd); err == nil {
+			t.Error("Expected an error for an unknown workspace")
+		}
+	})
+
+	t.Run("current workspace outside a project", func(t *testing.T) {
+		*GlobalConfig = originalConfig
+		t.Setenv(ProjectRootEnv, "")
+		t.Setenv(workspace.HomeEnv, t.TempDir())
+
+		registryPath, _ := workspace.RegistryPath()
+		registry := &workspace.Registry{}
+		_ = registry.Add(workspace.Workspace{Name: "api", Root: projectDir})
+		_ = registry.Use("api")
+		if err := registry.Save(registryPath); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		// Outside any project, the current workspace applies
+		outside, _ := filepath.EvalSymlinks(t.TempDir())
+		_ = os.Chdir(outside)
+		if insideProject(outside) {
+			t.Skip("temp dir is inside a project")
+		}
+		if err := applyProjectRoot(RootCmd); err != nil {
+			t.Fatalf("applyProjectRoot() returned error: %v", err)
+		}
+		if GlobalConfig.ProjectRoot != projectDir {
+			t.Errorf("Expected project root %s from the current workspace, got %s", projectDir, GlobalConfig.ProjectRoot)
+		}
+
+		// Inside a project, the project wins
+		*GlobalConfig = originalConfig
+		inside, _ := filepath.EvalSymlinks(t.TempDir())
+		_ = os.WriteFile(filepath.Join(inside, workspace.ProjectFileName), []byte("name: web\n"), 0644)
+		_ = os.Chdir(inside)
+		if err := applyProjectRoot(RootCmd); err != nil {
+			t.Fatalf("applyProjectRoot() returned error: %v", err)
+		}
+		if GlobalConfig.ProjectRoot != "" {
+			t.Errorf("Expected no project root override inside a project, got %s", GlobalConfig.ProjectRoot)
+		}
+		if root, _ := FindProjectRoot(); root != inside {
+			t.Errorf("Expected FindProjectRoot() = %s, got %s", inside, root)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCommandTimeout bounds most commands when neither --timeout nor
+// timeout in .sharkconfig.json is set
+const DefaultCommandTimeout = 30 * time.Second
+
+// CommandTimeout returns how long a command may run: --timeout, else timeout
+// in the config (env: PM_TIMEOUT), else the command's own default
+func CommandTimeout(fallback time.Duration) time.Duration {
+	if GlobalConfig.Timeout > 0 {
+		return GlobalConfig.Timeout
+	}
+	return fallback
+}
+
+// CommandContext returns a context that expires after CommandTimeout(fallback)
+func CommandContext(fallback time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), CommandTimeout(fallback))
+}
+
+// ParseTimeout parses a timeout from the config: a duration such as "45s" or
+// "2m", or a number of seconds. Zero means the default.
+func ParseTimeout(value interface{}) (time.Duration, error) {
+	var timeout time.Duration
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case time.Duration:
+		timeout = v
+	case float64:
+		timeout = time.Duration(v * float64(time.Second))
+	case int:
+		timeout = time.Duration(v) * time.Second
+	case int64:
+		timeout = time.Duration(v) * time.Second
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0, nil
+		}
+		if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+			timeout = time.Duration(seconds * float64(time.Second))
+			break
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timeout %q: use a duration such as 45s or 2m", v)
+		}
+		timeout = d
+	default:
+		return 0, fmt.Errorf("invalid timeout %v: use a duration such as 45s or 2m", value)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout %v: must not be negative", value)
+	}
+	return timeout, nil
+}
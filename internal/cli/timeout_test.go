@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeout(t *testing.T) {
+	for value, want := range map[interface{}]time.Duration{
+		nil:     0,
+		"":      0,
+		"45s":   45 * time.Second,
+		"2m":    2 * time.Minute,
+		"90":    90 * time.Second,
+		"1.5":   1500 * time.Millisecond,
+		60.0:    time.Minute,
+		int(10): 10 * time.Second,
+	} {
+		got, err := ParseTimeout(value)
+		require.NoError(t, err, "%v", value)
+		assert.Equal(t, want, got, "%v", value)
+	}
+
+	for _, value := range []interface{}{"soon", "-5s", -1.0, true} {
+		_, err := ParseTimeout(value)
+		assert.Error(t, err, "%v", value)
+	}
+}
+
+func TestCommandTimeout(t *testing.T) {
+	saved := *GlobalConfig
+	t.Cleanup(func() { *GlobalConfig = saved })
+
+	GlobalConfig.Timeout = 0
+	assert.Equal(t, DefaultCommandTimeout, CommandTimeout(DefaultCommandTimeout))
+	assert.Equal(t, 5*time.Minute, CommandTimeout(5*time.Minute), "commands keep their own default")
+
+	GlobalConfig.Timeout = 2 * time.Second
+	assert.Equal(t, 2*time.Second, CommandTimeout(5*time.Minute), "--timeout applies to every command")
+
+	ctx, cancel := CommandContext(DefaultCommandTimeout)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), deadline, time.Second)
+}
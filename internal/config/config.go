@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 )
@@ -20,10 +21,19 @@ type Config struct {
 	DefaultEpic            *string                `json:"default_epic,omitempty"`
 	DefaultAgent           *string                `json:"default_agent,omitempty"`
 	JSONOutput             *bool                  `json:"json_output,omitempty"`
-	InteractiveMode        *bool                  `json:"interactive_mode,omitempty"`         // Enable interactive prompts (default: false for automation)
-	RequireRejectionReason bool                   `json:"require_rejection_reason,omitempty"` // NEW: Require rejection reason for backward transitions (default: false)
-	Viewer                 *string                `json:"viewer,omitempty"`                   // External viewer command for spec files (glow, nano, bat, less, cat, etc). Default: "cat"
-	RawData                map[string]interface{} `json:"-"`                                  // Store raw config data to preserve unknown fields
+	InteractiveMode        *bool                  `json:"interactive_mode,omitempty"`            // Enable interactive prompts (default: false for automation)
+	RequireRejectionReason bool                   `json:"require_rejection_reason,omitempty"`    // NEW: Require rejection reason for backward transitions (default: false)
+	Viewer                 *string                `json:"viewer,omitempty"`                      // External viewer command for spec files (glow, nano, bat, less, cat, etc). Default: "cat"
+	RequireConfirmTokens   bool                   `json:"require_confirmation_tokens,omitempty"` // Require a dry-run confirmation token for cascade deletes and force completions (default: false)
+	BackupRetention        *int                   `json:"backup_retention,omitempty"`            // Number of database backups to keep (default: 10, 0 = keep all)
+	Quotas                 *QuotaConfig           `json:"quotas,omitempty"`                      // Soft limits that trigger archival suggestions in status output
+	WIPLimits              map[string]int         `json:"wip_limits,omitempty"`                  // Maximum in_progress tasks per agent type, enforced by task start and task next --claim
+	LinkFormat             *string                `json:"link_format,omitempty"`                 // How file references are printed in human output: "plain" (default), "file", or "vscode"
+	Server                 *ServerConfig          `json:"server,omitempty"`                      // Settings for shark serve
+	FeatureScaffold        *FeatureScaffoldConfig `json:"feature_scaffold,omitempty"`            // Defaults for shark feature create --scaffold
+	WeightedProgress       bool                   `json:"weighted_progress,omitempty"`           // Weight epic and feature progress by task estimates (default: false; --weighted overrides)
+	List                   *ListConfig            `json:"list,omitempty"`                        // Default sort order and page size for task list and epic list
+	RawData                map[string]interface{} `json:"-"`                                     // Store raw config data to preserve unknown fields
 
 	// statusMetadata holds status metadata for work breakdown calculations
 	// Internal field for testing and programmatic access
@@ -47,6 +57,28 @@ type DatabaseConfig struct {
 	// EmbeddedReplica enables offline mode with local replica that syncs to cloud
 	// Only valid for turso backend
 	EmbeddedReplica bool `json:"embedded_replica,omitempty"`
+
+	// JournalMode sets PRAGMA journal_mode for local databases (default "WAL")
+	JournalMode string `json:"journal_mode,omitempty"`
+
+	// BusyTimeoutMs is how long a connection waits on a locked database
+	// before failing with SQLITE_BUSY (default 5000)
+	BusyTimeoutMs int `json:"busy_timeout_ms,omitempty"`
+
+	// Synchronous sets PRAGMA synchronous: OFF, NORMAL, FULL, or EXTRA (default "NORMAL")
+	Synchronous string `json:"synchronous,omitempty"`
+
+	// CacheSizeKB is the page cache size per connection (default 64000)
+	CacheSizeKB int `json:"cache_size_kb,omitempty"`
+
+	// MaxOpenConns and MaxIdleConns limit the connection pool (0 = no limit)
+	MaxOpenConns int `json:"max_open_conns,omitempty"`
+	MaxIdleConns int `json:"max_idle_conns,omitempty"`
+
+	// MaxRetries is how many times a statement that fails with a transient
+	// error (SQLITE_BUSY, a dropped Turso connection) is retried with backoff
+	// (nil = default 3, 0 = never)
+	MaxRetries *int `json:"max_retries,omitempty"`
 }
 
 // Validate checks if the DatabaseConfig is valid
@@ -90,6 +122,63 @@ func (dc *DatabaseConfig) Validate() error {
 	return nil
 }
 
+// QuotaConfig holds soft limits on project size.
+// Exceeding a limit never blocks a command; it only produces a warning with a
+// suggested archival command. A limit of 0 disables that check.
+type QuotaConfig struct {
+	MaxOpenTasksPerEpic    *int `json:"max_open_tasks_per_epic,omitempty"`    // Default: 200
+	MaxOpenTasksPerFeature *int `json:"max_open_tasks_per_feature,omitempty"` // Default: 50
+	MaxDatabaseSizeMB      *int `json:"max_database_size_mb,omitempty"`       // Default: 100
+}
+
+// ServerConfig holds settings for the API served by shark serve.
+// Clients authenticate with the token as "authorization: Bearer <token>".
+type ServerConfig struct {
+	GRPCAddr      string `json:"grpc_addr,omitempty"`       // Listen address for --grpc (default ":50051")
+	HTTPAddr      string `json:"http_addr,omitempty"`       // Listen address for --http (default ":8080")
+	AuthToken     string `json:"auth_token,omitempty"`      // Token clients must send
+	AuthTokenFile string `json:"auth_token_file,omitempty"` // File containing the token; takes precedence over auth_token
+}
+
+// FeatureScaffoldConfig sets the defaults for scaffolding new features: a
+// tasks/ folder and starter documents next to feature.md
+type FeatureScaffoldConfig struct {
+	Enabled bool     `json:"enabled"`        // Scaffold without --scaffold (default: false)
+	Docs    []string `json:"docs,omitempty"` // Starter documents to create (default: design, testing)
+}
+
+// ListConfig sets the defaults of task list and epic list. A sort is a field
+// name, optionally followed by ":asc" or ":desc", as given to --sort-by.
+type ListConfig struct {
+	TaskSort string `json:"task_sort,omitempty"` // Default sort for task list, e.g. "updated_at:desc" (default: execution order)
+	EpicSort string `json:"epic_sort,omitempty"` // Default sort for epic list (default: key)
+	PageSize int    `json:"page_size,omitempty"` // Default --limit for task list and epic list (default: 0, no limit)
+}
+
+// DefaultFeatureScaffoldDocs are the starter documents a scaffolded feature
+// gets when none are configured
+var DefaultFeatureScaffoldDocs = []string{"design", "testing"}
+
+// DefaultGRPCAddr is the address shark serve --grpc listens on when none is configured
+const DefaultGRPCAddr = ":50051"
+
+// DefaultHTTPAddr is the address shark serve --http listens on when none is configured
+const DefaultHTTPAddr = ":8080"
+
+// QuotaLimits are the effective soft limits after applying defaults
+type QuotaLimits struct {
+	MaxOpenTasksPerEpic    int
+	MaxOpenTasksPerFeature int
+	MaxDatabaseSizeMB      int
+}
+
+// Default soft limits used when quotas are not configured
+const (
+	DefaultMaxOpenTasksPerEpic    = 200
+	DefaultMaxOpenTasksPerFeature = 50
+	DefaultMaxDatabaseSizeMB      = 100
+)
+
 // DetectBackend automatically detects the backend type from a database URL
 // Returns "turso" for libsql:// or https:// URLs, "local" for file paths
 func DetectBackend(url string) string {
@@ -134,6 +223,151 @@ func (c *Config) IsRequireRejectionReasonEnabled() bool {
 	return c.RequireRejectionReason
 }
 
+// IsConfirmationTokenRequired returns true if destructive operations (cascade deletes,
+// force completions) must be confirmed with a token printed by a dry-run.
+// Defaults to false for backward compatibility
+func (c *Config) IsConfirmationTokenRequired() bool {
+	if c == nil {
+		return false
+	}
+	return c.RequireConfirmTokens
+}
+
+// GetBackupRetention returns how many database backups to keep when pruning.
+// Defaults to 10; a value of 0 or less disables pruning
+func (c *Config) GetBackupRetention() int {
+	if c == nil || c.BackupRetention == nil {
+		return 10
+	}
+	return *c.BackupRetention
+}
+
+// GetQuotaLimits returns the soft limits on project size, using defaults for unset values
+func (c *Config) GetQuotaLimits() QuotaLimits {
+	limits := QuotaLimits{
+		MaxOpenTasksPerEpic:    DefaultMaxOpenTasksPerEpic,
+		MaxOpenTasksPerFeature: DefaultMaxOpenTasksPerFeature,
+		MaxDatabaseSizeMB:      DefaultMaxDatabaseSizeMB,
+	}
+	if c == nil || c.Quotas == nil {
+		return limits
+	}
+
+	if c.Quotas.MaxOpenTasksPerEpic != nil {
+		limits.MaxOpenTasksPerEpic = *c.Quotas.MaxOpenTasksPerEpic
+	}
+	if c.Quotas.MaxOpenTasksPerFeature != nil {
+		limits.MaxOpenTasksPerFeature = *c.Quotas.MaxOpenTasksPerFeature
+	}
+	if c.Quotas.MaxDatabaseSizeMB != nil {
+		limits.MaxDatabaseSizeMB = *c.Quotas.MaxDatabaseSizeMB
+	}
+	return limits
+}
+
+// GetWIPLimits returns the maximum in_progress tasks per agent type. Agent
+// types without a positive limit are not limited and are left out.
+func (c *Config) GetWIPLimits() map[string]int {
+	limits := make(map[string]int)
+	if c == nil {
+		return limits
+	}
+	for agentType, limit := range c.WIPLimits {
+		if limit > 0 {
+			limits[agentType] = limit
+		}
+	}
+	return limits
+}
+
+// GetGRPCAddr returns the address shark serve --grpc listens on
+func (c *Config) GetGRPCAddr() string {
+	if c == nil || c.Server == nil || c.Server.GRPCAddr == "" {
+		return DefaultGRPCAddr
+	}
+	return c.Server.GRPCAddr
+}
+
+// GetHTTPAddr returns the address shark serve --http listens on
+func (c *Config) GetHTTPAddr() string {
+	if c == nil || c.Server == nil || c.Server.HTTPAddr == "" {
+		return DefaultHTTPAddr
+	}
+	return c.Server.HTTPAddr
+}
+
+// GetServerAuthToken returns the token API clients must send, read from
+// auth_token_file if set, otherwise auth_token. Returns "" if neither is set.
+func (c *Config) GetServerAuthToken() (string, error) {
+	if c == nil || c.Server == nil {
+		return "", nil
+	}
+	if c.Server.AuthTokenFile != "" {
+		data, err := os.ReadFile(c.Server.AuthTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read server auth token from %s: %w", c.Server.AuthTokenFile, err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("server auth token file %s is empty", c.Server.AuthTokenFile)
+		}
+		return token, nil
+	}
+	return strings.TrimSpace(c.Server.AuthToken), nil
+}
+
+// IsFeatureScaffoldEnabled returns true if new features are scaffolded by default.
+// Defaults to false (only feature.md is written)
+func (c *Config) IsFeatureScaffoldEnabled() bool {
+	if c == nil || c.FeatureScaffold == nil {
+		return false
+	}
+	return c.FeatureScaffold.Enabled
+}
+
+// GetFeatureScaffoldDocs returns the starter documents a scaffolded feature gets.
+// Defaults to DefaultFeatureScaffoldDocs; an empty list in the config means none
+func (c *Config) GetFeatureScaffoldDocs() []string {
+	if c == nil || c.FeatureScaffold == nil || c.FeatureScaffold.Docs == nil {
+		return DefaultFeatureScaffoldDocs
+	}
+	return c.FeatureScaffold.Docs
+}
+
+// GetTaskListSort returns the default sort for task list, or "" for execution order
+func (c *Config) GetTaskListSort() string {
+	if c == nil || c.List == nil {
+		return ""
+	}
+	return c.List.TaskSort
+}
+
+// GetEpicListSort returns the default sort for epic list, or "" for key order
+func (c *Config) GetEpicListSort() string {
+	if c == nil || c.List == nil {
+		return ""
+	}
+	return c.List.EpicSort
+}
+
+// GetListPageSize returns how many rows task list and epic list show when no
+// --limit is given. Defaults to 0, which shows every row
+func (c *Config) GetListPageSize() int {
+	if c == nil || c.List == nil || c.List.PageSize < 0 {
+		return 0
+	}
+	return c.List.PageSize
+}
+
+// GetLinkFormat returns how file references are printed in human-readable output.
+// Defaults to "plain"; "file" prints file:// URIs and "vscode" prints vscode://file links
+func (c *Config) GetLinkFormat() string {
+	if c == nil || c.LinkFormat == nil || *c.LinkFormat == "" {
+		return "plain"
+	}
+	return *c.LinkFormat
+}
+
 // GetViewer returns the configured viewer command or default "cat"
 // The viewer is used by the shark view command to open specification files
 // Examples: "glow", "nano", "bat", "less", "cat"
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 )
@@ -55,7 +55,7 @@ func (m *Manager) Load() (*Config, error) {
 		parsedTime, err := time.Parse(time.RFC3339, lastSyncStr)
 		if err != nil {
 			// Invalid timestamp - log error and treat as nil
-			log.Printf("Warning: Invalid last_sync_time format in config: %v", err)
+			slog.Warn("Invalid last_sync_time format in config", "error", err)
 			config.LastSyncTime = nil
 		} else {
 			config.LastSyncTime = &parsedTime
@@ -83,6 +83,43 @@ func (m *Manager) Load() (*Config, error) {
 		config.RequireRejectionReason = requireRejection
 	}
 
+	if requireTokens, ok := rawData["require_confirmation_tokens"].(bool); ok {
+		config.RequireConfirmTokens = requireTokens
+	}
+
+	if weighted, ok := rawData["weighted_progress"].(bool); ok {
+		config.WeightedProgress = weighted
+	}
+
+	if retention, ok := rawData["backup_retention"].(float64); ok {
+		keep := int(retention)
+		config.BackupRetention = &keep
+	}
+
+	if linkFormat, ok := rawData["link_format"].(string); ok {
+		config.LinkFormat = &linkFormat
+	}
+
+	if quotas, ok := rawData["quotas"].(map[string]interface{}); ok {
+		config.Quotas = parseQuotaConfig(quotas)
+	}
+
+	if wipLimits, ok := rawData["wip_limits"].(map[string]interface{}); ok {
+		config.WIPLimits = parseWIPLimits(wipLimits)
+	}
+
+	if server, ok := rawData["server"].(map[string]interface{}); ok {
+		config.Server = parseServerConfig(server)
+	}
+
+	if scaffold, ok := rawData["feature_scaffold"].(map[string]interface{}); ok {
+		config.FeatureScaffold = parseFeatureScaffoldConfig(scaffold)
+	}
+
+	if list, ok := rawData["list"].(map[string]interface{}); ok {
+		config.List = parseListConfig(list)
+	}
+
 	m.config = config
 	return config, nil
 }
@@ -158,3 +195,82 @@ func (m *Manager) GetActionService() (ActionService, error) {
 	}
 	return m.actionService, nil
 }
+
+// parseQuotaConfig parses the "quotas" section of the config file
+func parseQuotaConfig(raw map[string]interface{}) *QuotaConfig {
+	quotas := &QuotaConfig{}
+	intField := func(name string) *int {
+		if v, ok := raw[name].(float64); ok {
+			n := int(v)
+			return &n
+		}
+		return nil
+	}
+
+	quotas.MaxOpenTasksPerEpic = intField("max_open_tasks_per_epic")
+	quotas.MaxOpenTasksPerFeature = intField("max_open_tasks_per_feature")
+	quotas.MaxDatabaseSizeMB = intField("max_database_size_mb")
+	return quotas
+}
+
+// parseWIPLimits parses the "wip_limits" section of the config file: agent
+// types mapped to their maximum number of in_progress tasks
+func parseWIPLimits(raw map[string]interface{}) map[string]int {
+	limits := make(map[string]int, len(raw))
+	for agentType, value := range raw {
+		if v, ok := value.(float64); ok {
+			limits[agentType] = int(v)
+		}
+	}
+	return limits
+}
+
+// parseServerConfig parses the "server" section of the config file
+func parseServerConfig(raw map[string]interface{}) *ServerConfig {
+	server := &ServerConfig{}
+	if addr, ok := raw["grpc_addr"].(string); ok {
+		server.GRPCAddr = addr
+	}
+	if addr, ok := raw["http_addr"].(string); ok {
+		server.HTTPAddr = addr
+	}
+	if token, ok := raw["auth_token"].(string); ok {
+		server.AuthToken = token
+	}
+	if tokenFile, ok := raw["auth_token_file"].(string); ok {
+		server.AuthTokenFile = tokenFile
+	}
+	return server
+}
+
+// parseFeatureScaffoldConfig parses the "feature_scaffold" section of the config file
+func parseFeatureScaffoldConfig(raw map[string]interface{}) *FeatureScaffoldConfig {
+	scaffold := &FeatureScaffoldConfig{}
+	if enabled, ok := raw["enabled"].(bool); ok {
+		scaffold.Enabled = enabled
+	}
+	if docs, ok := raw["docs"].([]interface{}); ok {
+		scaffold.Docs = make([]string, 0, len(docs))
+		for _, doc := range docs {
+			if name, ok := doc.(string); ok {
+				scaffold.Docs = append(scaffold.Docs, name)
+			}
+		}
+	}
+	return scaffold
+}
+
+// parseListConfig parses the list section of the config
+func parseListConfig(raw map[string]interface{}) *ListConfig {
+	list := &ListConfig{}
+	if sort, ok := raw["task_sort"].(string); ok {
+		list.TaskSort = sort
+	}
+	if sort, ok := raw["epic_sort"].(string); ok {
+		list.EpicSort = sort
+	}
+	if size, ok := raw["page_size"].(float64); ok {
+		list.PageSize = int(size)
+	}
+	return list
+}
@@ -92,6 +92,108 @@ func TestLoadConfig_MissingLastSyncTime(t *testing.T) {
 	}
 }
 
+// TestLoadConfig_RequireConfirmationTokens tests parsing of require_confirmation_tokens
+func TestLoadConfig_RequireConfirmationTokens(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".sharkconfig.json")
+
+	if err := os.WriteFile(configPath, []byte(`{"require_confirmation_tokens": true}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if !config.IsConfirmationTokenRequired() {
+		t.Error("IsConfirmationTokenRequired() = false, want true")
+	}
+
+	var nilConfig *Config
+	if nilConfig.IsConfirmationTokenRequired() {
+		t.Error("nil config should not require confirmation tokens")
+	}
+}
+
+// TestLoadConfig_Quotas tests parsing of quota limits and their defaults
+func TestLoadConfig_Quotas(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".sharkconfig.json")
+
+	if err := os.WriteFile(configPath, []byte(`{"quotas": {"max_open_tasks_per_epic": 50, "max_database_size_mb": 0}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	limits := config.GetQuotaLimits()
+	want := QuotaLimits{
+		MaxOpenTasksPerEpic:    50,
+		MaxOpenTasksPerFeature: DefaultMaxOpenTasksPerFeature,
+		MaxDatabaseSizeMB:      0,
+	}
+	if limits != want {
+		t.Errorf("GetQuotaLimits() = %+v, want %+v", limits, want)
+	}
+
+	var nilConfig *Config
+	if nilConfig.GetQuotaLimits().MaxOpenTasksPerEpic != DefaultMaxOpenTasksPerEpic {
+		t.Error("nil config should use default quota limits")
+	}
+}
+
+// TestLoadConfig_WIPLimits tests parsing of per-agent-type WIP limits
+func TestLoadConfig_WIPLimits(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".sharkconfig.json")
+
+	if err := os.WriteFile(configPath, []byte(`{"wip_limits": {"backend": 2, "frontend": 0, "qa": "three"}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	limits := config.GetWIPLimits()
+	if len(limits) != 1 || limits["backend"] != 2 {
+		t.Errorf("GetWIPLimits() = %v, want only backend: 2", limits)
+	}
+
+	var nilConfig *Config
+	if len(nilConfig.GetWIPLimits()) != 0 {
+		t.Error("nil config should have no WIP limits")
+	}
+}
+
+func TestLoadConfig_LinkFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".sharkconfig.json")
+
+	if err := os.WriteFile(configPath, []byte(`{"link_format": "vscode"}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if got := config.GetLinkFormat(); got != "vscode" {
+		t.Errorf("GetLinkFormat() = %q, want %q", got, "vscode")
+	}
+
+	var nilConfig *Config
+	if got := nilConfig.GetLinkFormat(); got != "plain" {
+		t.Errorf("nil config GetLinkFormat() = %q, want %q", got, "plain")
+	}
+}
+
 // TestLoadConfig_InvalidTimestamp tests loading config with invalid timestamp
 func TestLoadConfig_InvalidTimestamp(t *testing.T) {
 	tests := []struct {
@@ -678,3 +780,110 @@ func TestManager_GetActionService_Caching(t *testing.T) {
 		t.Error("expected same service instance on multiple calls")
 	}
 }
+
+// TestLoadConfig_Server tests parsing of the server section and token file precedence
+func TestLoadConfig_Server(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ".sharkconfig.json")
+	tokenPath := filepath.Join(tempDir, "token")
+
+	if err := os.WriteFile(tokenPath, []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte(`{"server": {"grpc_addr": "127.0.0.1:6000", "http_addr": "127.0.0.1:6080", "auth_token": "inline-token"}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if got := config.GetGRPCAddr(); got != "127.0.0.1:6000" {
+		t.Errorf("GetGRPCAddr() = %q, want %q", got, "127.0.0.1:6000")
+	}
+	if got := config.GetHTTPAddr(); got != "127.0.0.1:6080" {
+		t.Errorf("GetHTTPAddr() = %q, want %q", got, "127.0.0.1:6080")
+	}
+	if token, err := config.GetServerAuthToken(); err != nil || token != "inline-token" {
+		t.Errorf("GetServerAuthToken() = %q, %v, want %q", token, err, "inline-token")
+	}
+
+	config.Server.AuthTokenFile = tokenPath
+	if token, err := config.GetServerAuthToken(); err != nil || token != "file-token" {
+		t.Errorf("GetServerAuthToken() with file = %q, %v, want %q", token, err, "file-token")
+	}
+
+	config.Server.AuthTokenFile = filepath.Join(tempDir, "missing")
+	if _, err := config.GetServerAuthToken(); err == nil {
+		t.Error("GetServerAuthToken() with missing file should fail")
+	}
+
+	var nilConfig *Config
+	if got := nilConfig.GetGRPCAddr(); got != DefaultGRPCAddr {
+		t.Errorf("nil config GetGRPCAddr() = %q, want %q", got, DefaultGRPCAddr)
+	}
+	if got := nilConfig.GetHTTPAddr(); got != DefaultHTTPAddr {
+		t.Errorf("nil config GetHTTPAddr() = %q, want %q", got, DefaultHTTPAddr)
+	}
+	if token, err := nilConfig.GetServerAuthToken(); err != nil || token != "" {
+		t.Errorf("nil config GetServerAuthToken() = %q, %v, want empty", token, err)
+	}
+}
+
+// TestLoadConfig_FeatureScaffold tests parsing of the feature_scaffold section and its defaults
+func TestLoadConfig_FeatureScaffold(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".sharkconfig.json")
+	if err := os.WriteFile(configPath, []byte(`{"feature_scaffold": {"enabled": true, "docs": ["design"]}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !config.IsFeatureScaffoldEnabled() {
+		t.Error("IsFeatureScaffoldEnabled() = false, want true")
+	}
+	if got := config.GetFeatureScaffoldDocs(); len(got) != 1 || got[0] != "design" {
+		t.Errorf("GetFeatureScaffoldDocs() = %v, want [design]", got)
+	}
+
+	var nilConfig *Config
+	if nilConfig.IsFeatureScaffoldEnabled() {
+		t.Error("nil config IsFeatureScaffoldEnabled() = true, want false")
+	}
+	if got := nilConfig.GetFeatureScaffoldDocs(); len(got) != len(DefaultFeatureScaffoldDocs) {
+		t.Errorf("nil config GetFeatureScaffoldDocs() = %v, want %v", got, DefaultFeatureScaffoldDocs)
+	}
+}
+
+// TestLoadConfig_List tests parsing of the list section and its defaults
+func TestLoadConfig_List(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".sharkconfig.json")
+	if err := os.WriteFile(configPath, []byte(`{"list": {"task_sort": "updated_at:desc", "epic_sort": "progress", "page_size": 50}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := NewManager(configPath).Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if got := config.GetTaskListSort(); got != "updated_at:desc" {
+		t.Errorf("GetTaskListSort() = %q, want updated_at:desc", got)
+	}
+	if got := config.GetEpicListSort(); got != "progress" {
+		t.Errorf("GetEpicListSort() = %q, want progress", got)
+	}
+	if got := config.GetListPageSize(); got != 50 {
+		t.Errorf("GetListPageSize() = %d, want 50", got)
+	}
+
+	var nilConfig *Config
+	if got := nilConfig.GetTaskListSort(); got != "" {
+		t.Errorf("nil config GetTaskListSort() = %q, want empty", got)
+	}
+	if got := nilConfig.GetListPageSize(); got != 0 {
+		t.Errorf("nil config GetListPageSize() = %d, want 0", got)
+	}
+}
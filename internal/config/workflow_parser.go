@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"sync"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
 )
 
 var (
@@ -12,9 +14,13 @@ var (
 	workflowCache     *WorkflowConfig
 	workflowCacheLock sync.RWMutex
 	workflowCachePath string
+
+	// Workflow stored in the project database; takes precedence over the file
+	storedWorkflow *WorkflowConfig
 )
 
-// LoadWorkflowConfig loads workflow configuration from .sharkconfig.json
+// LoadWorkflowConfig loads workflow configuration from .sharkconfig.json, or
+// returns the workflow stored in the project database (see UseStoredWorkflow)
 //
 // Returns:
 // - WorkflowConfig: parsed workflow configuration
@@ -33,6 +39,10 @@ var (
 func LoadWorkflowConfig(configPath string) (*WorkflowConfig, error) {
 	// Check cache first (fast path)
 	workflowCacheLock.RLock()
+	if storedWorkflow != nil {
+		defer workflowCacheLock.RUnlock()
+		return storedWorkflow, nil
+	}
 	if workflowCache != nil && workflowCachePath == configPath {
 		defer workflowCacheLock.RUnlock()
 		return workflowCache, nil
@@ -72,6 +82,15 @@ func LoadWorkflowConfig(configPath string) (*WorkflowConfig, error) {
 	// Check if status_flow section exists
 	_, hasStatusFlow := rawConfig["status_flow"]
 	if !hasStatusFlow {
+		// auto_activate can be set without a custom status flow
+		if autoActivate, ok := rawConfig["auto_activate"].(bool); ok && !autoActivate {
+			workflow := DefaultWorkflow()
+			workflow.AutoActivate = &autoActivate
+			workflowCache = workflow
+			workflowCachePath = configPath
+			return workflow, nil
+		}
+
 		// No workflow config defined - return nil, no error
 		// Caller will use default workflow
 		return nil, nil
@@ -85,6 +104,7 @@ func LoadWorkflowConfig(configPath string) (*WorkflowConfig, error) {
 		"status_metadata":          rawConfig["status_metadata"],
 		"special_statuses":         rawConfig["special_statuses"],
 		"require_rejection_reason": rawConfig["require_rejection_reason"],
+		"auto_activate":            rawConfig["auto_activate"],
 	}
 
 	workflowJSON, err := json.Marshal(workflowData)
@@ -92,8 +112,37 @@ func LoadWorkflowConfig(configPath string) (*WorkflowConfig, error) {
 		return nil, fmt.Errorf("failed to marshal workflow data: %w", err)
 	}
 
+	workflow, err := ParseWorkflowJSON(workflowJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the parsed config
+	workflowCache = workflow
+	workflowCachePath = configPath
+	models.RegisterTaskStatuses(workflow.Statuses())
+
+	return workflow, nil
+}
+
+// ReloadWorkflowConfig is LoadWorkflowConfig reading configPath again instead
+// of returning the cached workflow, for servers that reload the config when
+// it changes. A workflow set with UseStoredWorkflow still takes precedence.
+func ReloadWorkflowConfig(configPath string) (*WorkflowConfig, error) {
+	workflowCacheLock.Lock()
+	workflowCache = nil
+	workflowCachePath = ""
+	workflowCacheLock.Unlock()
+	return LoadWorkflowConfig(configPath)
+}
+
+// ParseWorkflowJSON parses a workflow in the .sharkconfig.json format
+// (status_flow, status_metadata, special_statuses, ...), filling in the
+// version and empty maps. Other fields are ignored, so a whole .sharkconfig.json
+// can be parsed. The workflow is not validated; see ValidateWorkflow.
+func ParseWorkflowJSON(data []byte) (*WorkflowConfig, error) {
 	var workflow WorkflowConfig
-	if err := json.Unmarshal(workflowJSON, &workflow); err != nil {
+	if err := json.Unmarshal(data, &workflow); err != nil {
 		return nil, fmt.Errorf("failed to parse workflow config: %w", err)
 	}
 
@@ -118,13 +167,28 @@ func LoadWorkflowConfig(configPath string) (*WorkflowConfig, error) {
 		return nil, fmt.Errorf("unsupported workflow config version %s (supported: 1.0). Upgrade Shark to use this config", workflow.Version)
 	}
 
-	// Cache the parsed config
-	workflowCache = &workflow
-	workflowCachePath = configPath
-
 	return &workflow, nil
 }
 
+// UseStoredWorkflow makes LoadWorkflowConfig return a workflow stored in the
+// project database instead of the one in .sharkconfig.json, whatever the config
+// path. nil goes back to the config file.
+func UseStoredWorkflow(workflow *WorkflowConfig) {
+	workflowCacheLock.Lock()
+	defer workflowCacheLock.Unlock()
+	storedWorkflow = workflow
+	if workflow != nil {
+		models.RegisterTaskStatuses(workflow.Statuses())
+	}
+}
+
+// StoredWorkflowInUse returns the workflow set with UseStoredWorkflow, or nil
+func StoredWorkflowInUse() *WorkflowConfig {
+	workflowCacheLock.RLock()
+	defer workflowCacheLock.RUnlock()
+	return storedWorkflow
+}
+
 // ClearWorkflowCache clears the in-memory workflow config cache
 // Used for testing or when config file changes
 func ClearWorkflowCache() {
@@ -132,6 +196,8 @@ func ClearWorkflowCache() {
 	defer workflowCacheLock.Unlock()
 	workflowCache = nil
 	workflowCachePath = ""
+	storedWorkflow = nil
+	models.RegisterTaskStatuses(nil)
 }
 
 // GetWorkflowOrDefault loads workflow config or returns default if not configured
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 )
 
 // WorkflowConfig defines the structure for configurable status workflows in .sharkconfig.json
@@ -81,6 +82,18 @@ type WorkflowConfig struct {
 	// Default: true (enabled)
 	// Stored in config as "require_rejection_reason": true/false
 	RequireRejectionReason bool `json:"require_rejection_reason"`
+
+	// AutoActivate promotes a draft feature and its draft epic to active when one of
+	// their tasks enters a development-phase status (e.g. in_progress). The promotion
+	// happens in the same transaction as the task's status change.
+	// Default: true (enabled); use AutoActivateEnabled to read it
+	// Stored in config as "auto_activate": true/false
+	AutoActivate *bool `json:"auto_activate,omitempty"`
+}
+
+// AutoActivateEnabled reports whether auto-activation is on (the default when unset)
+func (w *WorkflowConfig) AutoActivateEnabled() bool {
+	return w.AutoActivate == nil || *w.AutoActivate
 }
 
 // StatusMetadata provides UI and agent-targeting metadata for a status
@@ -137,6 +150,22 @@ const (
 // Default version for workflow configs
 const DefaultWorkflowVersion = "1.0"
 
+// Statuses returns the workflow's statuses, sorted
+func (w *WorkflowConfig) Statuses() []string {
+	statuses := make([]string, 0, len(w.StatusFlow))
+	for status := range w.StatusFlow {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// HasStatus reports whether status is defined in the workflow
+func (w *WorkflowConfig) HasStatus(status string) bool {
+	_, ok := w.StatusFlow[status]
+	return ok
+}
+
 // GetStatusMetadata returns metadata for a given status
 // Returns empty metadata if status not found
 func (w *WorkflowConfig) GetStatusMetadata(status string) (StatusMetadata, bool) {
@@ -173,6 +202,20 @@ func (w *WorkflowConfig) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// ActivatesParents reports whether moving a task into status should promote its
+// draft feature and epic to active: AutoActivate is enabled and the status is
+// in_progress or belongs to the development phase.
+func (w *WorkflowConfig) ActivatesParents(status string) bool {
+	if !w.AutoActivateEnabled() {
+		return false
+	}
+	if status == "in_progress" {
+		return true
+	}
+	meta, found := w.GetStatusMetadata(status)
+	return found && meta.Phase == "development"
+}
+
 // GetStatusesByAgentType returns all statuses that include the given agent type
 // Returns empty slice if no statuses match
 func (w *WorkflowConfig) GetStatusesByAgentType(agentType string) []string {
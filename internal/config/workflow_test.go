@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
 )
 
 // Test workflow schema defaults
@@ -525,6 +527,54 @@ func TestWorkflowCache(t *testing.T) {
 	}
 }
 
+func TestUseStoredWorkflow(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".sharkconfig.json")
+	if err := os.WriteFile(configPath, []byte(`{"status_flow": {"todo": ["done"], "done": []}}`), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	defer ClearWorkflowCache()
+
+	stored, err := ParseWorkflowJSON([]byte(`{
+		"status_flow": {
+			"todo": ["in_code_review"],
+			"in_code_review": ["todo", "done"],
+			"done": []
+		},
+		"special_statuses": {"_start_": ["todo"], "_complete_": ["done"]}
+	}`))
+	if err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	if stored.Version != DefaultWorkflowVersion {
+		t.Errorf("expected version %s by default, got %s", DefaultWorkflowVersion, stored.Version)
+	}
+
+	UseStoredWorkflow(stored)
+	workflow, err := LoadWorkflowConfig(configPath)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if workflow != stored {
+		t.Error("expected the stored workflow to take precedence over the config file")
+	}
+	if err := models.ValidateTaskStatus("in_code_review"); err != nil {
+		t.Errorf("expected statuses of the stored workflow to validate: %v", err)
+	}
+
+	ClearWorkflowCache()
+	if StoredWorkflowInUse() != nil {
+		t.Error("expected ClearWorkflowCache to drop the stored workflow")
+	}
+	workflow, err = LoadWorkflowConfig(configPath)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if workflow.HasStatus("in_code_review") {
+		t.Error("expected the config file workflow once the stored one is gone")
+	}
+}
+
 // Test loading actual .sharkconfig.json from project root
 // This test reproduces the parsing error: json: cannot unmarshal object into Go struct field
 func TestLoadActualSharkConfig(t *testing.T) {
@@ -961,3 +1011,91 @@ func TestWorkflowConfig_RequireRejectionReason_JSON(t *testing.T) {
 		})
 	}
 }
+
+// Test auto_activate defaults to enabled and can be turned off with or without a status_flow
+func TestLoadWorkflowConfig_AutoActivate(t *testing.T) {
+	if !DefaultWorkflow().AutoActivateEnabled() {
+		t.Error("expected auto-activation enabled in default workflow")
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantNil     bool
+		wantEnabled bool
+	}{
+		{
+			name:    "no status_flow, unset",
+			content: `{"default_agent": "backend"}`,
+			wantNil: true,
+		},
+		{
+			name:        "no status_flow, disabled",
+			content:     `{"auto_activate": false}`,
+			wantEnabled: false,
+		},
+		{
+			name:        "status_flow, unset",
+			content:     `{"status_flow": {"todo": ["in_progress"], "in_progress": []}}`,
+			wantEnabled: true,
+		},
+		{
+			name:        "status_flow, disabled",
+			content:     `{"status_flow": {"todo": ["in_progress"], "in_progress": []}, "auto_activate": false}`,
+			wantEnabled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".sharkconfig.json")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+			ClearWorkflowCache()
+
+			workflow, err := LoadWorkflowConfig(configPath)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tt.wantNil {
+				if workflow != nil {
+					t.Error("expected nil workflow")
+				}
+				return
+			}
+			if workflow == nil {
+				t.Fatal("expected workflow, got nil")
+			}
+			if workflow.AutoActivateEnabled() != tt.wantEnabled {
+				t.Errorf("AutoActivateEnabled() = %v, want %v", workflow.AutoActivateEnabled(), tt.wantEnabled)
+			}
+		})
+	}
+}
+
+// Test which statuses promote parents
+func TestWorkflowConfig_ActivatesParents(t *testing.T) {
+	workflow := &WorkflowConfig{
+		StatusMetadata: map[string]StatusMetadata{
+			"in_development": {Phase: "development"},
+			"ready_for_qa":   {Phase: "qa"},
+		},
+	}
+
+	if !workflow.ActivatesParents("in_progress") {
+		t.Error("expected in_progress to activate parents")
+	}
+	if !workflow.ActivatesParents("in_development") {
+		t.Error("expected development-phase status to activate parents")
+	}
+	if workflow.ActivatesParents("ready_for_qa") || workflow.ActivatesParents("todo") {
+		t.Error("expected non-development statuses not to activate parents")
+	}
+
+	disabled := false
+	workflow.AutoActivate = &disabled
+	if workflow.ActivatesParents("in_progress") {
+		t.Error("expected no activation when auto_activate is false")
+	}
+}
@@ -0,0 +1,350 @@
+// Package credentials stores remote database auth tokens outside any project,
+// encrypted, so they don't sit in plaintext in .sharkconfig.json or the shell.
+//
+// Tokens live in ~/.shark/credentials.json, one per database, sealed with
+// AES-256-GCM. The key is kept apart from them: $SHARK_CREDENTIALS_KEY (e.g.
+// from a CI secret), else the OS keychain, created there on first use. Only
+// when there is no keychain does the key go in ~/.shark/credentials.key,
+// readable only by its owner.
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+)
+
+// FileName is the credentials file in the shark home directory
+const FileName = "credentials.json"
+
+// KeyFileName is the key file in the shark home directory
+const KeyFileName = "credentials.key"
+
+// KeyEnv holds a base64-encoded 32-byte key, used instead of the key file
+const KeyEnv = "SHARK_CREDENTIALS_KEY"
+
+// keySize is the AES-256 key size in bytes
+const keySize = 32
+
+// ErrNotFound is returned for a database with no stored token
+var ErrNotFound = errors.New("no stored credentials")
+
+// Entry describes a stored token without revealing it
+type Entry struct {
+	Database string    `json:"database"` // Normalized database URL
+	SavedAt  time.Time `json:"saved_at"`
+}
+
+// KeySource says where a Store's key is kept
+type KeySource string
+
+// Key sources, in the order they are tried
+const (
+	KeySourceEnv      KeySource = "env"      // KeyEnv
+	KeySourceKeychain KeySource = "keychain" // The OS keychain
+	KeySourceFile     KeySource = "file"     // The key file
+)
+
+// Store is a credentials file and the key that seals it
+type Store struct {
+	path     string
+	keyPath  string
+	keychain keychain
+}
+
+// sealedToken is a token as written to the credentials file
+type sealedToken struct {
+	Nonce      string    `json:"nonce"`
+	Ciphertext string    `json:"ciphertext"`
+	SavedAt    time.Time `json:"saved_at"`
+}
+
+// file is the content of the credentials file
+type file struct {
+	Version int                     `json:"version"`
+	Tokens  map[string]*sealedToken `json:"tokens"`
+}
+
+// NewStore returns the store in dir
+func NewStore(dir string) *Store {
+	keyPath := filepath.Join(dir, KeyFileName)
+	account := keyPath
+	if abs, err := filepath.Abs(keyPath); err == nil {
+		account = abs
+	}
+	return &Store{path: filepath.Join(dir, FileName), keyPath: keyPath, keychain: osKeychain{account: account}}
+}
+
+// DefaultStore returns the store in the shark home directory
+func DefaultStore() (*Store, error) {
+	home, err := workspace.Home()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(home), nil
+}
+
+// Path returns the path of the credentials file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// KeyPath returns the path of the key file, used when there is no OS keychain
+func (s *Store) KeyPath() string {
+	return s.keyPath
+}
+
+// KeySource returns where the store's key is kept. It fails if there is no
+// key yet.
+func (s *Store) KeySource() (KeySource, error) {
+	_, source, err := s.key(false)
+	return source, err
+}
+
+// NormalizeDatabase returns the name a database's token is stored under: its
+// host and path, so libsql:// and https:// URLs of one database share a token
+// and any authToken query parameter is dropped
+func NormalizeDatabase(databaseURL string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(databaseURL))
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("invalid database URL %q: expected libsql://<host> or https://<host>", databaseURL)
+	}
+	return strings.ToLower(parsed.Host) + strings.TrimRight(parsed.Path, "/"), nil
+}
+
+// Save encrypts token and stores it for the database, replacing any token
+// stored for it before
+func (s *Store) Save(databaseURL, token string) error {
+	database, err := NormalizeDatabase(databaseURL)
+	if err != nil {
+		return err
+	}
+	gcm, err := s.cipher(true)
+	if err != nil {
+		return err
+	}
+	f, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	// The database is authenticated with the token, so a token can't be moved
+	// to another database's entry
+	ciphertext := gcm.Seal(nil, nonce, []byte(token), []byte(database))
+	f.Tokens[database] = &sealedToken{
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+		SavedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+	return s.write(f)
+}
+
+// Load returns the token stored for the database, or ErrNotFound
+func (s *Store) Load(databaseURL string) (string, error) {
+	database, err := NormalizeDatabase(databaseURL)
+	if err != nil {
+		return "", err
+	}
+	f, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	sealed, ok := f.Tokens[database]
+	if !ok {
+		return "", fmt.Errorf("%w for %s", ErrNotFound, database)
+	}
+
+	gcm, err := s.cipher(false)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(sealed.Nonce)
+	if err != nil || len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("credentials for %s are corrupt; run shark auth login again", database)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(sealed.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("credentials for %s are corrupt; run shark auth login again", database)
+	}
+	token, err := gcm.Open(nil, nonce, ciphertext, []byte(database))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt credentials for %s: wrong key (check %s) or corrupt file", database, KeyEnv)
+	}
+	return string(token), nil
+}
+
+// Delete removes the token stored for the database. It reports whether there
+// was one.
+func (s *Store) Delete(databaseURL string) (bool, error) {
+	database, err := NormalizeDatabase(databaseURL)
+	if err != nil {
+		return false, err
+	}
+	f, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := f.Tokens[database]; !ok {
+		return false, nil
+	}
+	delete(f.Tokens, database)
+	return true, s.write(f)
+}
+
+// List returns the databases with a stored token, sorted
+func (s *Store) List() ([]Entry, error) {
+	f, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(f.Tokens))
+	for database, sealed := range f.Tokens {
+		entries = append(entries, Entry{Database: database, SavedAt: sealed.SavedAt})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Database < entries[j].Database })
+	return entries, nil
+}
+
+// read loads the credentials file. A missing file has no tokens.
+func (s *Store) read() (*file, error) {
+	f := &file{Version: 1, Tokens: map[string]*sealedToken{}}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials %s: %w", s.path, err)
+	}
+	if f.Tokens == nil {
+		f.Tokens = map[string]*sealedToken{}
+	}
+	return f, nil
+}
+
+// write saves the credentials file atomically, readable only by its owner
+func (s *Store) write(f *file) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+// cipher returns the AES-GCM cipher for the store's key, creating the key
+// file if create is set and there is no key yet
+func (s *Store) cipher(create bool) (cipher.AEAD, error) {
+	key, _, err := s.key(create)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// key returns the key from KeyEnv, else the OS keychain, else the key file,
+// and where it came from. With create set and no key anywhere, it makes one
+// and stores it in the keychain, or the key file if there is no keychain.
+func (s *Store) key(create bool) ([]byte, KeySource, error) {
+	if encoded := strings.TrimSpace(os.Getenv(KeyEnv)); encoded != "" {
+		key, err := decodeKey(encoded, KeyEnv)
+		return key, KeySourceEnv, err
+	}
+
+	encoded, err := s.keychain.Lookup()
+	hasKeychain := !errors.Is(err, errNoKeychain)
+	if err != nil && hasKeychain {
+		return nil, "", fmt.Errorf("failed to read credentials key from the OS keychain: %w", err)
+	}
+	if encoded != "" {
+		key, err := decodeKey(encoded, "the OS keychain (service "+KeychainService+")")
+		return key, KeySourceKeychain, err
+	}
+
+	// A key file from before the keychain was used, or from a machine without one
+	data, err := os.ReadFile(s.keyPath)
+	if err == nil {
+		key, err := decodeKey(strings.TrimSpace(string(data)), s.keyPath)
+		return key, KeySourceFile, err
+	}
+	if !os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("failed to read credentials key: %w", err)
+	}
+	if !create {
+		return nil, "", fmt.Errorf("no credentials key: set %s, or restore it to the OS keychain or %s", KeyEnv, s.keyPath)
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", fmt.Errorf("failed to generate credentials key: %w", err)
+	}
+	if hasKeychain {
+		if err := s.keychain.Store(base64.StdEncoding.EncodeToString(key)); err == nil {
+			// Read it back: a key stored by a concurrent login wins
+			return s.key(false)
+		}
+	}
+	return s.createKeyFile(key)
+}
+
+// createKeyFile writes key to the key file, readable only by its owner. A key
+// file written by a concurrent login wins, and is read back.
+func (s *Store) createKeyFile(key []byte) ([]byte, KeySource, error) {
+	if err := os.MkdirAll(filepath.Dir(s.keyPath), 0700); err != nil {
+		return nil, "", fmt.Errorf("failed to create %s: %w", filepath.Dir(s.keyPath), err)
+	}
+	out, err := os.OpenFile(s.keyPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		return s.key(false)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create credentials key: %w", err)
+	}
+	defer out.Close()
+	if _, err := out.WriteString(base64.StdEncoding.EncodeToString(key) + "\n"); err != nil {
+		return nil, "", fmt.Errorf("failed to write credentials key: %w", err)
+	}
+	return key, KeySourceFile, nil
+}
+
+// decodeKey decodes a base64 key read from source
+func decodeKey(encoded, source string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("invalid credentials key in %s: expected %d base64-encoded bytes", source, keySize)
+	}
+	return key, nil
+}
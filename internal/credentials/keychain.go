@@ -0,0 +1,94 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeychainService is the OS keychain service the credentials key is stored
+// under, the same one database encryption keys use
+const KeychainService = "shark-task-manager"
+
+// errNoKeychain is returned when there is no OS keychain shark can use
+var errNoKeychain = errors.New("no OS keychain available")
+
+// keychain holds the credentials key in an OS keychain
+type keychain interface {
+	// Lookup returns the stored key, "" if there is none, or errNoKeychain
+	Lookup() (string, error)
+	// Store saves the key, replacing any stored before
+	Store(key string) error
+}
+
+// osKeychain is the macOS Keychain via 'security', or the Secret Service via
+// 'secret-tool' on Linux, as for database encryption keys. The key is stored
+// under the key file's path, so each shark home directory has its own.
+type osKeychain struct {
+	account string
+}
+
+// Lookup implements keychain
+func (k osKeychain) Lookup() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", KeychainService, "-a", k.account, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", KeychainService, "credentials", k.account)
+	default:
+		return "", errNoKeychain
+	}
+	if cmd.Err != nil {
+		// Keychain tool not installed
+		return "", errNoKeychain
+	}
+
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && keychainItemMissing(exitErr) {
+		return "", nil
+	}
+	if err != nil {
+		// Installed but unusable, e.g. no Secret Service running
+		return "", errNoKeychain
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// Store implements keychain
+func (k osKeychain) Store(key string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "add-generic-password", "-U", "-s", KeychainService, "-a", k.account, "-l", "shark credentials key", "-w", key)
+	case "linux":
+		// secret-tool reads the secret from standard input
+		cmd = exec.Command("secret-tool", "store", "--label=shark credentials key", "service", KeychainService, "credentials", k.account)
+		cmd.Stdin = strings.NewReader(key)
+	default:
+		return errNoKeychain
+	}
+	if cmd.Err != nil {
+		return errNoKeychain
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", errNoKeychain, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// keychainItemMissing reports whether a failed lookup means the keychain works
+// but holds no key: 'security' exits 44, and 'secret-tool' exits 1 without an
+// error message
+func keychainItemMissing(err *exec.ExitError) bool {
+	switch runtime.GOOS {
+	case "darwin":
+		return err.ExitCode() == 44
+	case "linux":
+		return err.ExitCode() == 1 && len(strings.TrimSpace(string(err.Stderr))) == 0
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBackupRetention is the number of backups kept when pruning is not configured
+const DefaultBackupRetention = 10
+
+// backupMetadataSuffix is appended to a backup path to form its metadata sidecar
+const backupMetadataSuffix = ".meta.json"
+
+// sqliteHeader is the magic string at the start of every SQLite database file
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// BackupInfo describes a database backup and why it was created
+type BackupInfo struct {
+	Path         string    `json:"path"`
+	DatabasePath string    `json:"database_path"`
+	CreatedAt    time.Time `json:"created_at"`
+	Trigger      string    `json:"trigger"` // Operation that caused the backup (e.g. "manual", "cascade delete epic E05")
+	SizeBytes    int64     `json:"size_bytes"`
+}
+
+// BackupVerification is the result of checking a backup's integrity
+type BackupVerification struct {
+	BackupInfo
+	OK     bool     `json:"ok"`
+	Errors []string `json:"errors,omitempty"` // integrity_check messages or the reason the backup could not be opened
+}
+
+// BackupMetadataPath returns the path of the metadata sidecar for a backup file
+func BackupMetadataPath(backupPath string) string {
+	return backupPath + backupMetadataSuffix
+}
+
+// BackupDatabaseWithTrigger creates a timestamped backup like BackupDatabase and
+// records the triggering operation in a metadata file stored alongside it
+func BackupDatabaseWithTrigger(dbPath, trigger string) (string, error) {
+	backupPath, err := BackupDatabase(dbPath)
+	if err != nil {
+		return "", err
+	}
+
+	if err := WriteBackupMetadata(backupPath, dbPath, trigger); err != nil {
+		return backupPath, err
+	}
+
+	return backupPath, nil
+}
+
+// WriteBackupMetadata writes the metadata sidecar for an existing backup file
+func WriteBackupMetadata(backupPath, dbPath, trigger string) error {
+	stat, err := os.Stat(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat backup: %w", err)
+	}
+
+	info := BackupInfo{
+		Path:         backupPath,
+		DatabasePath: dbPath,
+		CreatedAt:    time.Now().UTC(),
+		Trigger:      trigger,
+		SizeBytes:    stat.Size(),
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup metadata: %w", err)
+	}
+
+	if err := os.WriteFile(BackupMetadataPath(backupPath), data, 0644); err != nil {
+		return fmt.Errorf("failed to write backup metadata: %w", err)
+	}
+
+	return nil
+}
+
+// ListBackups returns the backups of a database, newest first.
+// Both backup naming schemes are recognized:
+//   - <name>_YYYYMMDD_HHMMSS_backup<ext> (BackupDatabase)
+//   - <db-file>.<operation>-YYYYMMDD-HHMMSS.backup (force operations)
+//
+// Backups without a metadata sidecar use the file modification time and an "unknown" trigger.
+func ListBackups(dbPath string) ([]BackupInfo, error) {
+	dir := filepath.Dir(dbPath)
+	baseName := filepath.Base(dbPath)
+	ext := filepath.Ext(baseName)
+	nameWithoutExt := strings.TrimSuffix(baseName, ext)
+
+	patterns := []string{
+		filepath.Join(dir, nameWithoutExt+"_*_backup"+ext),
+		dbPath + ".*.backup",
+	}
+
+	var backups []BackupInfo
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", err)
+		}
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			info, err := readBackupInfo(path, dbPath)
+			if err != nil {
+				return nil, err
+			}
+			backups = append(backups, info)
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// readBackupInfo loads metadata for a backup file, falling back to file info
+func readBackupInfo(path, dbPath string) (BackupInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to stat backup %s: %w", path, err)
+	}
+
+	info := BackupInfo{
+		Path:         path,
+		DatabasePath: dbPath,
+		CreatedAt:    stat.ModTime().UTC(),
+		Trigger:      "unknown",
+		SizeBytes:    stat.Size(),
+	}
+
+	data, err := os.ReadFile(BackupMetadataPath(path))
+	if err != nil {
+		return info, nil
+	}
+
+	var meta BackupInfo
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return info, nil
+	}
+	if !meta.CreatedAt.IsZero() {
+		info.CreatedAt = meta.CreatedAt
+	}
+	if meta.Trigger != "" {
+		info.Trigger = meta.Trigger
+	}
+
+	return info, nil
+}
+
+// PruneBackups deletes all but the keep most recent backups of a database,
+// including their WAL copies and metadata. keep <= 0 disables pruning.
+// Returns the paths of the deleted backups.
+func PruneBackups(dbPath string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	backups, err := ListBackups(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) <= keep {
+		return nil, nil
+	}
+
+	var removed []string
+	for _, backup := range backups[keep:] {
+		if err := removeBackup(backup.Path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, backup.Path)
+	}
+
+	return removed, nil
+}
+
+// VerifyBackups verifies every backup of a database, newest first
+func VerifyBackups(dbPath string) ([]BackupVerification, error) {
+	backups, err := ListBackups(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BackupVerification, len(backups))
+	for i, backup := range backups {
+		results[i] = VerifyBackup(backup)
+	}
+	return results, nil
+}
+
+// VerifyBackup opens a backup read-only and runs PRAGMA integrity_check on it.
+// The backup file is never modified.
+func VerifyBackup(info BackupInfo) BackupVerification {
+	result := BackupVerification{BackupInfo: info}
+
+	key, err := backupKey(info.Path)
+	if err != nil {
+		result.Errors = []string{err.Error()}
+		return result
+	}
+
+	messages, err := integrityCheck(info.Path, key)
+	if err != nil {
+		result.Errors = []string{err.Error()}
+		return result
+	}
+
+	if len(messages) == 1 && messages[0] == "ok" {
+		result.OK = true
+		return result
+	}
+	result.Errors = messages
+	return result
+}
+
+// integrityCheck runs PRAGMA integrity_check on a database file opened read-only
+// and returns its messages ("ok" when the database is intact)
+func integrityCheck(path, key string) ([]string, error) {
+	// A backup without a WAL copy is opened immutable; otherwise SQLite would
+	// create -wal and -shm files next to it
+	dsn := "file:" + path + "?mode=ro"
+	if _, err := os.Stat(path + "-wal"); os.IsNotExist(err) {
+		dsn += "&immutable=1"
+	}
+
+	conn, err := openSQLiteWithKey(dsn, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.Query("PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []string
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			return nil, fmt.Errorf("failed to read integrity check result: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+
+	return messages, nil
+}
+
+// removeBackup deletes a backup file with its WAL copies and metadata sidecar.
+// WAL copies made by older versions (<backup>.db-wal) are removed too.
+func removeBackup(backupPath string) error {
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove backup %s: %w", backupPath, err)
+	}
+	legacy := backupPath + filepath.Ext(backupPath)
+	for _, extra := range []string{backupPath + "-wal", backupPath + "-shm", legacy + "-wal", legacy + "-shm", BackupMetadataPath(backupPath)} {
+		if err := os.Remove(extra); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", extra, err)
+		}
+	}
+	return nil
+}
+
+// RestoreDatabase replaces the database with a backup.
+// The current database is backed up first (trigger "pre-restore") so the
+// restore itself can be undone; the path of that safety backup is returned.
+// The database must not be open while restoring.
+func RestoreDatabase(dbPath, backupPath string) (string, error) {
+	if _, err := backupKey(backupPath); err != nil {
+		return "", err
+	}
+
+	var safetyBackup string
+	if _, err := os.Stat(dbPath); err == nil {
+		safetyBackup, err = BackupDatabaseWithTrigger(dbPath, "pre-restore")
+		if err != nil {
+			return "", fmt.Errorf("failed to back up current database before restore: %w", err)
+		}
+	}
+
+	// Stale WAL files from the current database would be replayed on top of the restored file
+	for _, walFile := range []string{dbPath + "-wal", dbPath + "-shm"} {
+		if err := os.Remove(walFile); err != nil && !os.IsNotExist(err) {
+			return safetyBackup, fmt.Errorf("failed to remove %s: %w", walFile, err)
+		}
+	}
+
+	if err := copyFile(backupPath, dbPath); err != nil {
+		return safetyBackup, fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	// Restore the WAL captured with the backup, if any
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if _, err := os.Stat(backupPath + suffix); err == nil {
+			if err := copyFile(backupPath+suffix, dbPath+suffix); err != nil {
+				return safetyBackup, fmt.Errorf("failed to restore %s file: %w", suffix, err)
+			}
+		}
+	}
+
+	return safetyBackup, nil
+}
+
+// backupKey checks that a backup is a SQLite database and returns the key needed
+// to open it: the configured encryption key for encrypted backups, empty otherwise
+func backupKey(path string) (string, error) {
+	encrypted, err := IsEncrypted(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup: %w", err)
+	}
+	if !encrypted {
+		return "", checkSQLiteFile(path)
+	}
+	key := encryptionKey
+	if key == "" {
+		key = os.Getenv(EncryptionKeyEnv)
+	}
+	if key == "" {
+		return "", fmt.Errorf("%s is not a SQLite database, or is encrypted and %s is not set", path, EncryptionKeyEnv)
+	}
+	return key, nil
+}
+
+// checkSQLiteFile verifies that a file exists and has a SQLite header
+func checkSQLiteFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer f.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	if _, err := f.Read(header); err != nil || !bytes.Equal(header, sqliteHeader) {
+		return fmt.Errorf("%s is not a SQLite database", path)
+	}
+
+	return nil
+}
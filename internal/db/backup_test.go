@@ -0,0 +1,165 @@
+package db
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeBackup creates a backup file with metadata recording the given creation time
+func writeFakeBackup(t *testing.T, path string, createdAt time.Time, trigger string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, sqliteHeader, 0644))
+	data, err := json.Marshal(BackupInfo{Path: path, CreatedAt: createdAt, Trigger: trigger})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(BackupMetadataPath(path), data, 0644))
+}
+
+func TestBackupDatabaseWithTrigger_WritesMetadata(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shark-tasks.db")
+	database, err := InitDB(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	backupPath, err := BackupDatabaseWithTrigger(dbPath, "cascade delete epic E05")
+	require.NoError(t, err)
+	assert.FileExists(t, BackupMetadataPath(backupPath))
+
+	backups, err := ListBackups(dbPath)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, backupPath, backups[0].Path)
+	assert.Equal(t, "cascade delete epic E05", backups[0].Trigger)
+	assert.Positive(t, backups[0].SizeBytes)
+}
+
+func TestListBackups_BothNamingSchemes(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "shark-tasks.db")
+	now := time.Now().UTC()
+
+	writeFakeBackup(t, filepath.Join(dir, "shark-tasks_20260101_100000_backup.db"), now.Add(-2*time.Hour), "init")
+	writeFakeBackup(t, dbPath+".force-complete-epic-20260101-110000.backup", now.Add(-time.Hour), "force complete epic")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other_20260101_100000_backup.db"), sqliteHeader, 0644))
+
+	backups, err := ListBackups(dbPath)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "force complete epic", backups[0].Trigger, "newest first")
+	assert.Equal(t, "init", backups[1].Trigger)
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "shark-tasks.db")
+	now := time.Now().UTC()
+
+	var paths []string
+	for i := 0; i < 4; i++ {
+		path := filepath.Join(dir, "shark-tasks_2026010"+string(rune('1'+i))+"_100000_backup.db")
+		writeFakeBackup(t, path, now.Add(time.Duration(i)*time.Hour), "manual")
+		paths = append(paths, path)
+	}
+	require.NoError(t, os.WriteFile(paths[0]+"-wal", []byte("wal"), 0644))
+	require.NoError(t, os.WriteFile(paths[1]+".db-wal", []byte("wal"), 0644)) // Older WAL copy naming
+
+	removed, err := PruneBackups(dbPath, 2)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, paths[:2], removed)
+
+	assert.NoFileExists(t, paths[0])
+	assert.NoFileExists(t, paths[0]+"-wal")
+	assert.NoFileExists(t, BackupMetadataPath(paths[0]))
+	assert.NoFileExists(t, paths[1]+".db-wal")
+	assert.FileExists(t, paths[2])
+	assert.FileExists(t, paths[3])
+
+	removed, err = PruneBackups(dbPath, 0)
+	require.NoError(t, err)
+	assert.Empty(t, removed, "keep <= 0 disables pruning")
+}
+
+func TestRestoreDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shark-tasks.db")
+	database, err := InitDB(dbPath)
+	require.NoError(t, err)
+	_, err = database.Exec(`INSERT INTO epics (key, title, status, priority) VALUES ('E01', 'Before', 'active', 'high')`)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	backupPath, err := BackupDatabaseWithTrigger(dbPath, "manual")
+	require.NoError(t, err)
+
+	// Change the database after the backup
+	database, err = InitDB(dbPath)
+	require.NoError(t, err)
+	_, err = database.Exec(`UPDATE epics SET title = 'After' WHERE key = 'E01'`)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	// Ensure the safety backup gets a distinct timestamped name
+	time.Sleep(1100 * time.Millisecond)
+
+	safetyBackup, err := RestoreDatabase(dbPath, backupPath)
+	require.NoError(t, err)
+	assert.FileExists(t, safetyBackup)
+
+	database, err = InitDB(dbPath)
+	require.NoError(t, err)
+	defer database.Close()
+
+	var title string
+	require.NoError(t, database.QueryRow(`SELECT title FROM epics WHERE key = 'E01'`).Scan(&title))
+	assert.Equal(t, "Before", title)
+}
+
+func TestRestoreDatabase_RejectsNonSQLite(t *testing.T) {
+	dir := t.TempDir()
+	bogus := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(bogus, []byte("not a database"), 0644))
+
+	_, err := RestoreDatabase(filepath.Join(dir, "shark-tasks.db"), bogus)
+	assert.Error(t, err)
+}
+
+func TestVerifyBackups(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "shark-tasks.db")
+	database, err := InitDB(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	good, err := BackupDatabaseWithTrigger(dbPath, "manual")
+	require.NoError(t, err)
+
+	// A file with a SQLite header but no valid pages, and a file that is not SQLite at all
+	truncated := dbPath + ".force-delete-epic-20260101-100000.backup"
+	writeFakeBackup(t, truncated, time.Now().UTC().Add(-time.Hour), "cascade delete epic E01")
+	bogus := dbPath + ".force-delete-epic-20260101-090000.backup"
+	writeFakeBackup(t, bogus, time.Now().UTC().Add(-2*time.Hour), "cascade delete epic E02")
+	require.NoError(t, os.WriteFile(bogus, []byte("not a database"), 0644))
+
+	results, err := VerifyBackups(dbPath)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	byPath := make(map[string]BackupVerification)
+	for _, r := range results {
+		byPath[r.Path] = r
+	}
+	assert.True(t, byPath[good].OK)
+	assert.Empty(t, byPath[good].Errors)
+	assert.False(t, byPath[truncated].OK)
+	assert.NotEmpty(t, byPath[truncated].Errors)
+	assert.False(t, byPath[bogus].OK)
+	assert.NotEmpty(t, byPath[bogus].Errors)
+
+	// Verification leaves the backup untouched
+	assert.NoFileExists(t, good+"-wal")
+	assert.NoFileExists(t, good+"-shm")
+}
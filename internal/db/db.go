@@ -6,17 +6,78 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// SchemaVersion identifies the schema produced by createSchema and
+// runMigrations. It is stamped into each database (PRAGMA user_version) after
+// the schema is applied, and databases already at this version skip schema
+// validation on open. Bump it whenever createSchema or runMigrations changes.
+const SchemaVersion = 20
+
+// InitOptions controls how InitDBWithOptions prepares a database
+type InitOptions struct {
+	// VerifySchema applies the full schema and migrations even when the
+	// database is already stamped with SchemaVersion
+	VerifySchema bool
+
+	// Tuning sets SQLite pragmas and connection pool limits
+	Tuning Tuning
+
+	// ReadOnly opens every connection with query_only set, so writes fail.
+	// The schema is not upgraded: an outdated database is an error.
+	ReadOnly bool
+
+	// ProjectRoot is the project the database belongs to. When set, file
+	// paths under it are made relative when migrations run (see
+	// RelativizeFilePaths).
+	ProjectRoot string
+}
+
+// schemaCheck records a database file's state when its schema was last
+// confirmed current, so repeated opens in one process skip the version query
+type schemaCheck struct {
+	modTime time.Time
+	size    int64
+}
+
+var (
+	schemaChecksMu sync.Mutex
+	schemaChecks   = make(map[string]schemaCheck)
+)
+
 // InitDB initializes the SQLite database with complete schema
 func InitDB(filepath string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", filepath+"?_foreign_keys=on")
+	return InitDBWithOptions(filepath, InitOptions{})
+}
+
+// InitDBWithOptions initializes the SQLite database, applying the schema and
+// migrations only when the database is not stamped with SchemaVersion (or
+// when opts.VerifySchema is set)
+func InitDBWithOptions(filepath string, opts InitOptions) (*sql.DB, error) {
+	if err := opts.Tuning.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid database tuning: %w", err)
+	}
+	tuning := opts.Tuning.WithDefaults()
+
+	dsn := filepath + "?" + tuning.dsnParams()
+	if opts.ReadOnly {
+		dsn += "&_query_only=true"
+	}
+	db, err := OpenSQLite(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	if tuning.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(tuning.MaxOpenConns)
+	}
+	if tuning.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(tuning.MaxIdleConns)
+	}
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
@@ -24,10 +85,18 @@ func InitDB(filepath string) (*sql.DB, error) {
 	}
 
 	// Configure SQLite for optimal performance and data integrity
-	if err := configureSQLite(db); err != nil {
+	if err := configureSQLite(db, tuning); err != nil {
 		return nil, fmt.Errorf("failed to configure SQLite: %w", err)
 	}
 
+	if !opts.VerifySchema && schemaIsCurrent(db, filepath) {
+		return db, nil
+	}
+	if opts.ReadOnly {
+		db.Close()
+		return nil, fmt.Errorf("database schema is out of date and can't be upgraded read-only; run shark once without --read-only")
+	}
+
 	// Create all tables, indexes, and triggers
 	if err := createSchema(db); err != nil {
 		return nil, fmt.Errorf("failed to create schema: %w", err)
@@ -37,23 +106,57 @@ func InitDB(filepath string) (*sql.DB, error) {
 	if err := runMigrations(db); err != nil {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
+	if opts.ProjectRoot != "" {
+		if err := RelativizeFilePaths(db, opts.ProjectRoot); err != nil {
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d;", SchemaVersion)); err != nil {
+		return nil, fmt.Errorf("failed to record schema version: %w", err)
+	}
+	recordSchemaCheck(filepath)
 
 	return db, nil
 }
 
+// schemaIsCurrent reports whether the database is stamped with SchemaVersion
+// or later.
+// The result is cached per file until the file's modification time or size
+// changes.
+func schemaIsCurrent(db *sql.DB, path string) bool {
+	if info, err := os.Stat(path); err == nil {
+		schemaChecksMu.Lock()
+		check, ok := schemaChecks[path]
+		schemaChecksMu.Unlock()
+		if ok && check.modTime.Equal(info.ModTime()) && check.size == info.Size() {
+			return true
+		}
+	}
+
+	// Databases stamped by a newer shark already have this schema
+	var version int
+	if err := db.QueryRow("PRAGMA user_version;").Scan(&version); err != nil || version < SchemaVersion {
+		return false
+	}
+	recordSchemaCheck(path)
+	return true
+}
+
+// recordSchemaCheck caches that the database file's schema is current
+func recordSchemaCheck(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	schemaChecksMu.Lock()
+	schemaChecks[path] = schemaCheck{modTime: info.ModTime(), size: info.Size()}
+	schemaChecksMu.Unlock()
+}
+
 // configureSQLite sets SQLite PRAGMA settings for optimal operation
-func configureSQLite(db *sql.DB) error {
-	pragmas := []string{
-		"PRAGMA foreign_keys = ON;",       // Enable foreign key constraints
-		"PRAGMA journal_mode = WAL;",      // Use Write-Ahead Logging for better concurrency
-		"PRAGMA busy_timeout = 5000;",     // 5 second timeout for locks
-		"PRAGMA synchronous = NORMAL;",    // Balance safety and performance
-		"PRAGMA cache_size = -64000;",     // 64MB cache
-		"PRAGMA temp_store = MEMORY;",     // Store temp tables in memory
-		"PRAGMA mmap_size = 30000000000;", // Use memory-mapped I/O
-	}
-
-	for _, pragma := range pragmas {
+func configureSQLite(db *sql.DB, tuning Tuning) error {
+	for _, pragma := range tuning.pragmas() {
 		if _, err := db.Exec(pragma); err != nil {
 			return fmt.Errorf("failed to execute %q: %w", pragma, err)
 		}
@@ -340,6 +443,7 @@ CREATE TABLE IF NOT EXISTS ideas (
     notes TEXT,
     related_docs TEXT,                                 -- JSON array of document paths
     dependencies TEXT,                                 -- JSON array of idea keys
+    file_path TEXT,                                    -- Optional markdown file for the idea
     status TEXT NOT NULL CHECK (status IN ('new', 'on_hold', 'converted', 'archived')) DEFAULT 'new',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -620,6 +724,110 @@ func runMigrations(db *sql.DB) error {
 		return fmt.Errorf("failed to migrate task_notes note_type constraint: %w", err)
 	}
 
+	// Run epic_notes table migration for planning annotations on epics
+	if err := migrateEpicNotes(db); err != nil {
+		return fmt.Errorf("failed to migrate epic_notes: %w", err)
+	}
+
+	// Add progress_snapshots table for progress history and burndown reports
+	if err := migrateProgressSnapshots(db); err != nil {
+		return fmt.Errorf("failed to migrate progress_snapshots: %w", err)
+	}
+
+	// Add key_sequences table for concurrent-safe epic/feature/idea key generation
+	if err := migrateKeySequences(db); err != nil {
+		return fmt.Errorf("failed to migrate key_sequences: %w", err)
+	}
+
+	// Add task_checklist_items table for per-task working checklists
+	if err := migrateTaskChecklistItems(db); err != nil {
+		return fmt.Errorf("failed to migrate task_checklist_items: %w", err)
+	}
+
+	// Add labels and task/feature junction tables for tagging
+	if err := migrateLabels(db); err != nil {
+		return fmt.Errorf("failed to migrate labels: %w", err)
+	}
+
+	// Add operation_journal table used by 'shark undo'
+	if err := migrateOperationJournal(db); err != nil {
+		return fmt.Errorf("failed to migrate operation_journal: %w", err)
+	}
+
+	// Add file_path column to ideas for optional idea files
+	if err := migrateIdeasFilePath(db); err != nil {
+		return fmt.Errorf("failed to migrate ideas file_path: %w", err)
+	}
+
+	// Add task_recurrences table for recurring tasks
+	if err := migrateTaskRecurrences(db); err != nil {
+		return fmt.Errorf("failed to migrate task_recurrences: %w", err)
+	}
+
+	if err := migrateTaskEstimates(db); err != nil {
+		return fmt.Errorf("failed to migrate task_estimates: %w", err)
+	}
+
+	if err := migrateSoftDeleteColumns(db); err != nil {
+		return fmt.Errorf("failed to migrate deleted_at columns: %w", err)
+	}
+
+	if err := migrateAuditLog(db); err != nil {
+		return fmt.Errorf("failed to migrate audit_log: %w", err)
+	}
+
+	if err := migrateTaskVersionColumn(db); err != nil {
+		return fmt.Errorf("failed to migrate task version column: %w", err)
+	}
+
+	if err := migrateCriteriaVerifiedBy(db); err != nil {
+		return fmt.Errorf("failed to migrate task_criteria verified_by: %w", err)
+	}
+
+	if err := migrateTaskLeases(db); err != nil {
+		return fmt.Errorf("failed to migrate task_leases: %w", err)
+	}
+
+	if err := migrateTaskAttachments(db); err != nil {
+		return fmt.Errorf("failed to migrate task_attachments: %w", err)
+	}
+
+	if err := migrateHookMilestones(db); err != nil {
+		return fmt.Errorf("failed to migrate hook_milestones: %w", err)
+	}
+
+	if err := migrateTaskAliases(db); err != nil {
+		return fmt.Errorf("failed to migrate task_aliases: %w", err)
+	}
+
+	if err := migrateAPIKeys(db); err != nil {
+		return fmt.Errorf("failed to migrate api_keys: %w", err)
+	}
+
+	if err := migrateEvents(db); err != nil {
+		return fmt.Errorf("failed to migrate events: %w", err)
+	}
+
+	if err := migrateSettings(db); err != nil {
+		return fmt.Errorf("failed to migrate settings: %w", err)
+	}
+
+	if err := migrateWorkflowConfig(db); err != nil {
+		return fmt.Errorf("failed to migrate workflow_config: %w", err)
+	}
+
+	if err := migrateFileClaims(db); err != nil {
+		return fmt.Errorf("failed to migrate file_claims: %w", err)
+	}
+
+	if err := migrateAgents(db); err != nil {
+		return fmt.Errorf("failed to migrate agents: %w", err)
+	}
+
+	if err := migrateTaskKeyAliases(db); err != nil {
+		return fmt.Errorf("failed to migrate task_key_aliases: %w", err)
+	}
+
 	return nil
 }
 
@@ -930,7 +1138,7 @@ func BackupDatabase(dbPath string) (string, error) {
 	for _, walFile := range walFiles {
 		if _, err := os.Stat(walFile); err == nil {
 			// WAL file exists, copy it
-			walBackupPath := backupPath + filepath.Ext(walFile)
+			walBackupPath := backupPath + strings.TrimPrefix(walFile, dbPath)
 			if err := copyFile(walFile, walBackupPath); err != nil {
 				// Log warning but don't fail the backup
 				fmt.Fprintf(os.Stderr, "Warning: Failed to backup WAL file %s: %v\n", walFile, err)
@@ -1381,3 +1589,825 @@ func migrateTaskNotesNoteTypeConstraint(db *sql.DB) error {
 
 	return nil
 }
+
+// migrateEpicNotes adds the epic_notes table for lightweight planning annotations on epics.
+// Notes are kept separate from the epic description so planning context accumulates over time.
+func migrateEpicNotes(db *sql.DB) error {
+	// Check if epic_notes table exists
+	var tableExists int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='epic_notes'
+	`).Scan(&tableExists)
+	if err != nil {
+		return fmt.Errorf("failed to check epic_notes table: %w", err)
+	}
+
+	if tableExists == 0 {
+		_, err := db.Exec(`
+			CREATE TABLE epic_notes (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				epic_id INTEGER NOT NULL,
+				content TEXT NOT NULL,
+				created_by TEXT,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (epic_id) REFERENCES epics(id) ON DELETE CASCADE
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create epic_notes table: %w", err)
+		}
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_epic_notes_epic_id ON epic_notes(epic_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_epic_notes_created_at ON epic_notes(created_at);`,
+	}
+	for _, idx := range indexes {
+		if _, err := db.Exec(idx); err != nil {
+			return fmt.Errorf("failed to create epic_notes index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrateProgressSnapshots adds the progress_snapshots table, which records per-epic
+// and per-feature progress over time for burndown reporting.
+func migrateProgressSnapshots(db *sql.DB) error {
+	// Check if progress_snapshots table exists
+	var tableExists int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='progress_snapshots'
+	`).Scan(&tableExists)
+	if err != nil {
+		return fmt.Errorf("failed to check progress_snapshots table: %w", err)
+	}
+
+	if tableExists == 0 {
+		_, err := db.Exec(`
+			CREATE TABLE progress_snapshots (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				entity_type TEXT NOT NULL CHECK (entity_type IN ('epic', 'feature')),
+				entity_id INTEGER NOT NULL,
+				entity_key TEXT NOT NULL,
+				progress_pct REAL NOT NULL DEFAULT 0.0 CHECK (progress_pct >= 0.0 AND progress_pct <= 100.0),
+				total_tasks INTEGER NOT NULL DEFAULT 0,
+				completed_tasks INTEGER NOT NULL DEFAULT 0,
+				recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create progress_snapshots table: %w", err)
+		}
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_progress_snapshots_entity ON progress_snapshots(entity_type, entity_id, recorded_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_progress_snapshots_recorded_at ON progress_snapshots(recorded_at);`,
+	}
+	for _, idx := range indexes {
+		if _, err := db.Exec(idx); err != nil {
+			return fmt.Errorf("failed to create progress_snapshots index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrateKeySequences adds the key_sequences table, which hands out epic, feature,
+// and idea key numbers atomically so concurrent creates never pick the same key
+func migrateKeySequences(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS key_sequences (
+			scope TEXT PRIMARY KEY,
+			last_value INTEGER NOT NULL DEFAULT 0,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create key_sequences table: %w", err)
+	}
+
+	return nil
+}
+
+// migrateTaskChecklistItems adds the task_checklist_items table, which holds the
+// informal working checklist of a task (separate from acceptance criteria).
+func migrateTaskChecklistItems(db *sql.DB) error {
+	// Check if task_checklist_items table exists
+	var tableExists int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='task_checklist_items'
+	`).Scan(&tableExists)
+	if err != nil {
+		return fmt.Errorf("failed to check task_checklist_items table: %w", err)
+	}
+
+	if tableExists == 0 {
+		_, err := db.Exec(`
+			CREATE TABLE task_checklist_items (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				task_id INTEGER NOT NULL,
+				content TEXT NOT NULL,
+				done BOOLEAN NOT NULL DEFAULT 0,
+				completed_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create task_checklist_items table: %w", err)
+		}
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task_id ON task_checklist_items(task_id);`); err != nil {
+		return fmt.Errorf("failed to create task_checklist_items index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateLabels adds the labels table and the task_labels / feature_labels junction
+// tables used to tag tasks and features (e.g. "security", "tech-debt")
+func migrateLabels(db *sql.DB) error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS labels (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE TABLE IF NOT EXISTS task_labels (
+			task_id INTEGER NOT NULL,
+			label_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (task_id, label_id),
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+			FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+		);`,
+		`CREATE TABLE IF NOT EXISTS feature_labels (
+			feature_id INTEGER NOT NULL,
+			label_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (feature_id, label_id),
+			FOREIGN KEY (feature_id) REFERENCES features(id) ON DELETE CASCADE,
+			FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+		);`,
+	}
+	for _, table := range tables {
+		if _, err := db.Exec(table); err != nil {
+			return fmt.Errorf("failed to create label table: %w", err)
+		}
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels(label_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_feature_labels_label_id ON feature_labels(label_id);`,
+	}
+	for _, idx := range indexes {
+		if _, err := db.Exec(idx); err != nil {
+			return fmt.Errorf("failed to create label index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrateOperationJournal adds the operation_journal table, which records destructive
+// operations (deletes, force completes, force file reassignments) together with the
+// row snapshots needed to revert them with 'shark undo'
+func migrateOperationJournal(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS operation_journal (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			operation TEXT NOT NULL,
+			entity_key TEXT NOT NULL,
+			summary TEXT NOT NULL DEFAULT '',
+			snapshot TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			undone_at TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create operation_journal table: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_operation_journal_undone_at ON operation_journal(undone_at);`); err != nil {
+		return fmt.Errorf("failed to create operation_journal index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateIdeasFilePath adds the file_path column to the ideas table so ideas
+// can have an optional markdown file (shark idea create --with-file)
+func migrateIdeasFilePath(db *sql.DB) error {
+	var columnExists int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('ideas') WHERE name = 'file_path'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check ideas schema for file_path: %w", err)
+	}
+
+	if columnExists == 0 {
+		if _, err := db.Exec(`ALTER TABLE ideas ADD COLUMN file_path TEXT;`); err != nil {
+			return fmt.Errorf("failed to add file_path column to ideas: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrateTaskRecurrences adds the task_recurrences table. A task with a
+// recurrence is an archived template; 'shark recur run' copies it into a new
+// todo task whenever next_run_at has passed.
+func migrateTaskRecurrences(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_recurrences (
+			task_id INTEGER PRIMARY KEY,
+			rule TEXT NOT NULL,
+			next_run_at TIMESTAMP NOT NULL,
+			last_run_at TIMESTAMP,
+			last_task_key TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create task_recurrences table: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_task_recurrences_next_run_at ON task_recurrences(next_run_at);`); err != nil {
+		return fmt.Errorf("failed to create task_recurrences index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateTaskEstimates adds the task_estimates table holding each task's
+// estimate and actual effort, both measured in the row's unit
+func migrateTaskEstimates(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_estimates (
+			task_id INTEGER PRIMARY KEY,
+			estimate REAL CHECK (estimate IS NULL OR estimate >= 0),
+			actual_effort REAL CHECK (actual_effort IS NULL OR actual_effort >= 0),
+			unit TEXT NOT NULL DEFAULT 'points' CHECK (unit IN ('points', 'hours')),
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create task_estimates table: %w", err)
+	}
+
+	return nil
+}
+
+// migrateSoftDeleteColumns adds deleted_at to features and tasks. Rows with a
+// deleted_at are in the trash: hidden from normal queries until restored or
+// permanently removed by 'shark trash empty'.
+func migrateSoftDeleteColumns(db *sql.DB) error {
+	for _, table := range []string{"features", "tasks"} {
+		var columnExists int
+		if err := db.QueryRow(`
+			SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'deleted_at'
+		`, table).Scan(&columnExists); err != nil {
+			return fmt.Errorf("failed to check %s schema for deleted_at: %w", table, err)
+		}
+
+		if columnExists == 0 {
+			if _, err := db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN deleted_at TIMESTAMP NULL;`, table)); err != nil {
+				return fmt.Errorf("failed to add deleted_at to %s: %w", table, err)
+			}
+		}
+		if _, err := db.Exec(fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_deleted_at ON %s(deleted_at);`, table, table)); err != nil {
+			return fmt.Errorf("failed to create %s deleted_at index: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateAuditLog adds the audit_log table recording creations, updates,
+// deletions, file reassignments, and forced operations for 'shark audit'
+func migrateAuditLog(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entity_type TEXT NOT NULL,
+			entity_key TEXT NOT NULL,
+			action TEXT NOT NULL,
+			changes TEXT,
+			summary TEXT NOT NULL DEFAULT '',
+			actor TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create audit_log table: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_audit_log_entity_key ON audit_log(entity_key);`); err != nil {
+		return fmt.Errorf("failed to create audit_log entity index: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);`); err != nil {
+		return fmt.Errorf("failed to create audit_log created_at index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateCriteriaVerifiedBy adds verified_by to task_criteria, recording which
+// reviewer signed off on (or failed) each acceptance criterion
+func migrateCriteriaVerifiedBy(db *sql.DB) error {
+	var columnExists int
+	if err := db.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('task_criteria') WHERE name = 'verified_by'
+	`).Scan(&columnExists); err != nil {
+		return fmt.Errorf("failed to check task_criteria schema for verified_by: %w", err)
+	}
+
+	if columnExists == 0 {
+		if _, err := db.Exec(`ALTER TABLE task_criteria ADD COLUMN verified_by TEXT;`); err != nil {
+			return fmt.Errorf("failed to add verified_by to task_criteria: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// migrateTaskLeases adds the task_leases table. 'shark task next --lease' reserves
+// a todo task for one agent until expires_at, so agents polling at the same time
+// are handed different tasks.
+func migrateTaskLeases(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_leases (
+			task_id INTEGER PRIMARY KEY,
+			agent TEXT NOT NULL,
+			leased_at TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create task_leases table: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_task_leases_expires_at ON task_leases(expires_at);`); err != nil {
+		return fmt.Errorf("failed to create task_leases index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateTaskAttachments adds the task_attachments table. 'shark task attach'
+// copies a file next to the task's feature and records it here with its size
+// and checksum so the copy can be verified later.
+func migrateTaskAttachments(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_attachments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id INTEGER NOT NULL,
+			filename TEXT NOT NULL,
+			file_path TEXT NOT NULL,
+			size_bytes INTEGER NOT NULL,
+			sha256 TEXT NOT NULL,
+			content_type TEXT,
+			attached_by TEXT,
+			note TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create task_attachments table: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments(task_id);`); err != nil {
+		return fmt.Errorf("failed to create task_attachments index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateHookMilestones adds the hook_milestones table. It records which
+// progress thresholds an epic or feature has reached and which epics have
+// blocked tasks, so .shark.yaml hooks fire once per crossing.
+func migrateHookMilestones(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS hook_milestones (
+			entity_type TEXT NOT NULL,
+			entity_id INTEGER NOT NULL,
+			milestone TEXT NOT NULL,
+			reached_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (entity_type, entity_id, milestone)
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create hook_milestones table: %w", err)
+	}
+	return nil
+}
+
+// migrateTaskAliases adds the task_aliases table of short names for tasks.
+// Aliases point at the task's ID, so they follow it when it is moved and
+// renumbered.
+func migrateTaskAliases(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_aliases (
+			alias TEXT PRIMARY KEY,
+			task_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create task_aliases table: %w", err)
+	}
+	return nil
+}
+
+// migrateTaskKeyAliases adds the task_key_aliases table of keys tasks had
+// before 'shark feature resequence' renumbered them, so old keys in notes,
+// commits, and scripts still resolve to the task.
+func migrateTaskKeyAliases(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_key_aliases (
+			old_key TEXT PRIMARY KEY,
+			task_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_task_key_aliases_task_id ON task_key_aliases(task_id);
+	`); err != nil {
+		return fmt.Errorf("failed to create task_key_aliases table: %w", err)
+	}
+	return nil
+}
+
+// migrateAPIKeys adds the api_keys table used to authenticate API clients.
+// Only a SHA-256 hash of each key is stored; the key itself is shown once,
+// when it is created.
+func migrateAPIKeys(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			key_hash TEXT NOT NULL UNIQUE,
+			prefix TEXT NOT NULL,
+			scope TEXT NOT NULL CHECK (scope IN ('read', 'write')),
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			last_used_at TIMESTAMP,
+			revoked_at TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create api_keys table: %w", err)
+	}
+	return nil
+}
+
+// migrateAgents adds the agents table, the registry of agents that work on
+// tasks (shark agent register). Tasks point at a registered agent by name
+// through assigned_agent, so tasks held by unregistered agents keep working.
+func migrateAgents(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS agents (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			agent_type TEXT,
+			capabilities TEXT NOT NULL DEFAULT '[]',
+			registered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			last_seen TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create agents table: %w", err)
+	}
+	return nil
+}
+
+// migrateSettings adds the settings table of project settings that travel
+// with the database (shark setting). Values are stored as text and validated
+// against the known settings when they are set.
+func migrateSettings(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create settings table: %w", err)
+	}
+	return nil
+}
+
+// migrateWorkflowConfig adds the workflow_config table, which holds at most
+// one workflow (status flow and metadata, as JSON) that takes precedence over
+// the one in .sharkconfig.json
+func migrateWorkflowConfig(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS workflow_config (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			config TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create workflow_config table: %w", err)
+	}
+	return nil
+}
+
+// migrateFileClaims adds the file_claims table, one row per file claimed by an
+// epic, feature, or task. The unique path makes claiming atomic: triggers keep
+// the table in step with every write to file_path, whichever code path makes
+// it, so a second entity claiming a claimed file fails the write with
+// "UNIQUE constraint failed: file_claims.path". Trashed features and tasks
+// release their files. Existing claims are backfilled; where two
+// entities already share a file, only one holds the claim: an epic over a
+// feature over a task, else the oldest.
+func migrateFileClaims(db *sql.DB) error {
+	var tableExists int
+	if err := db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'file_claims'
+	`).Scan(&tableExists); err != nil {
+		return fmt.Errorf("failed to check for file_claims table: %w", err)
+	}
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS file_claims (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			path TEXT NOT NULL UNIQUE,
+			entity_type TEXT NOT NULL CHECK (entity_type IN ('epic', 'feature', 'task')),
+			entity_id INTEGER NOT NULL,
+			claimed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+			UNIQUE (entity_type, entity_id)
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create file_claims table: %w", err)
+	}
+
+	entities := []struct {
+		table, entityType, active string
+	}{
+		{"epics", "epic", ""},
+		{"features", "feature", " AND NEW.deleted_at IS NULL"},
+		{"tasks", "task", " AND NEW.deleted_at IS NULL"},
+	}
+	for _, e := range entities {
+		claim := fmt.Sprintf(`NEW.file_path IS NOT NULL AND NEW.file_path != ''%s`, e.active)
+		changed := `OLD.file_path IS NOT NEW.file_path`
+		if e.active != "" {
+			changed += ` OR OLD.deleted_at IS NOT NEW.deleted_at`
+		}
+		triggers := []string{fmt.Sprintf(`
+CREATE TRIGGER IF NOT EXISTS %[1]s_file_claims_insert
+AFTER INSERT ON %[1]s
+FOR EACH ROW
+WHEN %[3]s
+BEGIN
+    INSERT INTO file_claims (path, entity_type, entity_id) VALUES (NEW.file_path, '%[2]s', NEW.id);
+END;`, e.table, e.entityType, claim), fmt.Sprintf(`
+CREATE TRIGGER IF NOT EXISTS %[1]s_file_claims_update
+AFTER UPDATE ON %[1]s
+FOR EACH ROW
+WHEN %[4]s
+BEGIN
+    DELETE FROM file_claims WHERE entity_type = '%[2]s' AND entity_id = OLD.id;
+    INSERT INTO file_claims (path, entity_type, entity_id)
+    SELECT NEW.file_path, '%[2]s', NEW.id WHERE %[3]s;
+END;`, e.table, e.entityType, claim, changed), fmt.Sprintf(`
+CREATE TRIGGER IF NOT EXISTS %[1]s_file_claims_delete
+AFTER DELETE ON %[1]s
+FOR EACH ROW
+BEGIN
+    DELETE FROM file_claims WHERE entity_type = '%[2]s' AND entity_id = OLD.id;
+END;`, e.table, e.entityType)}
+		for _, trigger := range triggers {
+			if _, err := db.Exec(trigger); err != nil {
+				return fmt.Errorf("failed to create file_claims trigger on %s: %w", e.table, err)
+			}
+		}
+	}
+
+	if tableExists > 0 {
+		return nil
+	}
+	for _, e := range entities {
+		if _, err := db.Exec(fmt.Sprintf(`
+			INSERT OR IGNORE INTO file_claims (path, entity_type, entity_id, claimed_at)
+			SELECT file_path, '%s', id, created_at FROM %s
+			WHERE file_path IS NOT NULL AND file_path != ''%s
+			ORDER BY created_at, id
+		`, e.entityType, e.table, strings.ReplaceAll(e.active, "NEW.", ""))); err != nil {
+			return fmt.Errorf("failed to backfill file claims from %s: %w", e.table, err)
+		}
+	}
+	return nil
+}
+
+// RelativizeFilePaths rewrites the absolute file paths of epics, features,
+// and tasks under projectRoot relative to it, the form the repositories store,
+// so an older row and a newer one naming the same file share one claim. The
+// file_claims triggers move the claims along. A row whose relative path is
+// already claimed by another entity keeps its absolute path, leaving the
+// duplicate for 'shark doctor' to report.
+func RelativizeFilePaths(db *sql.DB, projectRoot string) error {
+	root, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project root: %w", err)
+	}
+
+	for _, table := range []string{"epics", "features", "tasks"} {
+		rows, err := db.Query(fmt.Sprintf(`SELECT id, file_path FROM %s WHERE file_path LIKE ?`, table), root+string(filepath.Separator)+"%")
+		if err != nil {
+			return fmt.Errorf("failed to read file paths from %s: %w", table, err)
+		}
+		paths := make(map[int64]string)
+		for rows.Next() {
+			var id int64
+			var path string
+			if err := rows.Scan(&id, &path); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan file path from %s: %w", table, err)
+			}
+			rel, err := filepath.Rel(root, filepath.Clean(path))
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			paths[id] = filepath.ToSlash(rel)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read file paths from %s: %w", table, err)
+		}
+
+		for id, rel := range paths {
+			_, err := db.Exec(fmt.Sprintf(`UPDATE %s SET file_path = ? WHERE id = ?`, table), rel, id)
+			if err != nil && !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+				return fmt.Errorf("failed to relativize file path in %s: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// migrateEvents adds the events table, a changes feed of epics, features, and
+// tasks with a monotonically increasing sequence number. Triggers write the
+// events, so every change is recorded whichever code path makes it. Updates
+// only count when a column a client would react to changes: not updated_at
+// and version bumps, or feature progress recalculations.
+func migrateEvents(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS events (
+			seq INTEGER PRIMARY KEY AUTOINCREMENT,
+			entity_type TEXT NOT NULL,
+			entity_key TEXT NOT NULL,
+			parent_key TEXT,
+			action TEXT NOT NULL,
+			status TEXT,
+			previous_status TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return fmt.Errorf("failed to create events table: %w", err)
+	}
+
+	triggers := []string{`
+CREATE TRIGGER IF NOT EXISTS tasks_events_insert
+AFTER INSERT ON tasks
+FOR EACH ROW
+BEGIN
+    INSERT INTO events (entity_type, entity_key, parent_key, action, status)
+    VALUES ('task', NEW.key, (SELECT key FROM features WHERE id = NEW.feature_id), 'created', NEW.status);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS tasks_events_update
+AFTER UPDATE ON tasks
+FOR EACH ROW
+WHEN OLD.key IS NOT NEW.key OR OLD.feature_id IS NOT NEW.feature_id OR OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description OR OLD.status IS NOT NEW.status
+    OR OLD.agent_type IS NOT NEW.agent_type OR OLD.priority IS NOT NEW.priority
+    OR OLD.depends_on IS NOT NEW.depends_on OR OLD.assigned_agent IS NOT NEW.assigned_agent
+    OR OLD.blocked_reason IS NOT NEW.blocked_reason OR OLD.execution_order IS NOT NEW.execution_order
+    OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO events (entity_type, entity_key, parent_key, action, status, previous_status)
+    VALUES ('task', NEW.key, (SELECT key FROM features WHERE id = NEW.feature_id),
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'deleted'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restored'
+            WHEN OLD.status IS NOT NEW.status THEN 'status_changed'
+            ELSE 'updated'
+        END,
+        NEW.status, CASE WHEN OLD.status IS NOT NEW.status THEN OLD.status END);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS tasks_events_delete
+AFTER DELETE ON tasks
+FOR EACH ROW
+BEGIN
+    INSERT INTO events (entity_type, entity_key, parent_key, action, status)
+    VALUES ('task', OLD.key, (SELECT key FROM features WHERE id = OLD.feature_id),
+        CASE WHEN OLD.deleted_at IS NULL THEN 'deleted' ELSE 'purged' END, OLD.status);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS features_events_insert
+AFTER INSERT ON features
+FOR EACH ROW
+BEGIN
+    INSERT INTO events (entity_type, entity_key, parent_key, action, status)
+    VALUES ('feature', NEW.key, (SELECT key FROM epics WHERE id = NEW.epic_id), 'created', NEW.status);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS features_events_update
+AFTER UPDATE ON features
+FOR EACH ROW
+WHEN OLD.key IS NOT NEW.key OR OLD.epic_id IS NOT NEW.epic_id OR OLD.title IS NOT NEW.title
+    OR OLD.description IS NOT NEW.description OR OLD.status IS NOT NEW.status
+    OR OLD.execution_order IS NOT NEW.execution_order OR OLD.deleted_at IS NOT NEW.deleted_at
+BEGIN
+    INSERT INTO events (entity_type, entity_key, parent_key, action, status, previous_status)
+    VALUES ('feature', NEW.key, (SELECT key FROM epics WHERE id = NEW.epic_id),
+        CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'deleted'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restored'
+            WHEN OLD.status IS NOT NEW.status THEN 'status_changed'
+            ELSE 'updated'
+        END,
+        NEW.status, CASE WHEN OLD.status IS NOT NEW.status THEN OLD.status END);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS features_events_delete
+AFTER DELETE ON features
+FOR EACH ROW
+BEGIN
+    INSERT INTO events (entity_type, entity_key, parent_key, action, status)
+    VALUES ('feature', OLD.key, (SELECT key FROM epics WHERE id = OLD.epic_id),
+        CASE WHEN OLD.deleted_at IS NULL THEN 'deleted' ELSE 'purged' END, OLD.status);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS epics_events_insert
+AFTER INSERT ON epics
+FOR EACH ROW
+BEGIN
+    INSERT INTO events (entity_type, entity_key, action, status)
+    VALUES ('epic', NEW.key, 'created', NEW.status);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS epics_events_update
+AFTER UPDATE ON epics
+FOR EACH ROW
+WHEN OLD.key IS NOT NEW.key OR OLD.title IS NOT NEW.title OR OLD.description IS NOT NEW.description
+    OR OLD.status IS NOT NEW.status OR OLD.priority IS NOT NEW.priority
+    OR OLD.business_value IS NOT NEW.business_value
+BEGIN
+    INSERT INTO events (entity_type, entity_key, action, status, previous_status)
+    VALUES ('epic', NEW.key, CASE WHEN OLD.status IS NOT NEW.status THEN 'status_changed' ELSE 'updated' END,
+        NEW.status, CASE WHEN OLD.status IS NOT NEW.status THEN OLD.status END);
+END;`, `
+CREATE TRIGGER IF NOT EXISTS epics_events_delete
+AFTER DELETE ON epics
+FOR EACH ROW
+BEGIN
+    INSERT INTO events (entity_type, entity_key, action, status)
+    VALUES ('epic', OLD.key, 'deleted', OLD.status);
+END;`,
+	}
+	for _, trigger := range triggers {
+		if _, err := db.Exec(trigger); err != nil {
+			return fmt.Errorf("failed to create events trigger: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateTaskVersionColumn adds the version column used for optimistic locking
+// of tasks. The tasks_updated_at trigger is replaced so that every write to a
+// task, from any code path, increments its version.
+func migrateTaskVersionColumn(db *sql.DB) error {
+	var columnExists int
+	if err := db.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('tasks') WHERE name = 'version'
+	`).Scan(&columnExists); err != nil {
+		return fmt.Errorf("failed to check tasks schema for version: %w", err)
+	}
+
+	if columnExists == 0 {
+		if _, err := db.Exec(`ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;`); err != nil {
+			return fmt.Errorf("failed to add version to tasks: %w", err)
+		}
+	}
+
+	var triggerSQL string
+	err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = 'tasks_updated_at'`).Scan(&triggerSQL)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to read tasks_updated_at trigger: %w", err)
+	}
+	if strings.Contains(triggerSQL, "version") {
+		return nil
+	}
+
+	if _, err := db.Exec(`DROP TRIGGER IF EXISTS tasks_updated_at;`); err != nil {
+		return fmt.Errorf("failed to drop tasks_updated_at trigger: %w", err)
+	}
+	if _, err := db.Exec(`
+CREATE TRIGGER tasks_updated_at
+AFTER UPDATE ON tasks
+FOR EACH ROW
+BEGIN
+    UPDATE tasks SET updated_at = CURRENT_TIMESTAMP, version = OLD.version + 1 WHERE id = NEW.id;
+END;
+	`); err != nil {
+		return fmt.Errorf("failed to create tasks_updated_at trigger: %w", err)
+	}
+
+	return nil
+}
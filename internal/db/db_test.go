@@ -140,3 +140,37 @@ func TestMigration_TaskNotesNoteTypeConstraint(t *testing.T) {
 	// Verify CHECK constraint syntax
 	assert.Contains(t, tableSchema, "CHECK (note_type IN", "task_notes should have CHECK constraint on note_type")
 }
+
+func TestRelativizeFilePaths(t *testing.T) {
+	db, err := InitDB(t.TempDir() + "/test.db")
+	require.NoError(t, err)
+	defer db.Close()
+
+	root := t.TempDir()
+	_, err = db.Exec(`INSERT INTO epics (id, key, title, status, priority, file_path) VALUES (1, 'E01', 'Epic', 'active', 'high', 'docs/plan/E01/epic.md')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO features (id, epic_id, key, title, status, file_path) VALUES (1, 1, 'E01-F01', 'Feature', 'active', ?)`,
+		root+"/docs/plan/E01/E01-F01/feature.md")
+	require.NoError(t, err)
+	// An older task holds the epic's file by its absolute path
+	_, err = db.Exec(`INSERT INTO tasks (id, feature_id, key, title, status, priority, file_path) VALUES (1, 1, 'T-E01-F01-001', 'Task', 'todo', 5, ?)`,
+		root+"/docs/plan/E01/epic.md")
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO tasks (id, feature_id, key, title, status, priority, file_path) VALUES (2, 1, 'T-E01-F01-002', 'Elsewhere', 'todo', 5, '/elsewhere/T-E01-F01-002.md')`)
+	require.NoError(t, err)
+
+	require.NoError(t, RelativizeFilePaths(db, root))
+
+	var featurePath, claimedBy string
+	require.NoError(t, db.QueryRow(`SELECT file_path FROM features WHERE id = 1`).Scan(&featurePath))
+	assert.Equal(t, "docs/plan/E01/E01-F01/feature.md", featurePath)
+	require.NoError(t, db.QueryRow(`SELECT entity_type FROM file_claims WHERE path = ?`, featurePath).Scan(&claimedBy))
+	assert.Equal(t, "feature", claimedBy)
+
+	// The duplicate keeps its absolute path; paths outside the root are untouched
+	var taskPath, otherPath string
+	require.NoError(t, db.QueryRow(`SELECT file_path FROM tasks WHERE id = 1`).Scan(&taskPath))
+	assert.Equal(t, root+"/docs/plan/E01/epic.md", taskPath)
+	require.NoError(t, db.QueryRow(`SELECT file_path FROM tasks WHERE id = 2`).Scan(&otherPath))
+	assert.Equal(t, "/elsewhere/T-E01-F01-002.md", otherPath)
+}
@@ -0,0 +1,234 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// EncryptionKeyEnv is the environment variable holding the database encryption key
+const EncryptionKeyEnv = "SHARK_DB_KEY"
+
+// KeychainService is the service name encryption keys are stored under in the OS keychain
+const KeychainService = "shark-task-manager"
+
+// encryptionKeyParam is the DSN parameter that carries the key to the SQLCipher driver.
+// It is stripped before the DSN reaches SQLite.
+const encryptionKeyParam = "_shark_key"
+
+// ErrEncryptionUnsupported is returned when an encryption key is used with a build
+// that is not linked against SQLCipher
+var ErrEncryptionUnsupported = errors.New("this build of shark does not support database encryption (rebuild with 'make shark-sqlcipher')")
+
+// encryptionKey is the key used by OpenSQLite; empty means the database is not encrypted
+var encryptionKey string
+
+// SetEncryptionKey sets the key used to open local databases. An empty key opens
+// databases without encryption.
+func SetEncryptionKey(key string) {
+	encryptionKey = key
+}
+
+// EncryptionKey returns the key set with SetEncryptionKey
+func EncryptionKey() string {
+	return encryptionKey
+}
+
+// ResolveEncryptionKey looks up the encryption key for a database: the
+// SHARK_DB_KEY environment variable first, then the OS keychain (macOS
+// Keychain via 'security', or the Secret Service via 'secret-tool' on Linux)
+// under service "shark-task-manager" and the database's absolute path.
+// Returns an empty string when no key is configured.
+func ResolveEncryptionKey(dbPath string) string {
+	if key := os.Getenv(EncryptionKeyEnv); key != "" {
+		return key
+	}
+	return keychainEncryptionKey(dbPath)
+}
+
+// ConfigureEncryption sets the key used to open a local database. Encrypted
+// databases get their key from ResolveEncryptionKey, new databases are created
+// encrypted when SHARK_DB_KEY is set, and plain databases are opened without a
+// key (use EncryptDatabase to encrypt them).
+func ConfigureEncryption(dbPath string) error {
+	encrypted, err := IsEncrypted(dbPath)
+	switch {
+	case os.IsNotExist(err):
+		SetEncryptionKey(os.Getenv(EncryptionKeyEnv))
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read database: %w", err)
+	case !encrypted:
+		SetEncryptionKey("")
+		return nil
+	}
+
+	key := ResolveEncryptionKey(dbPath)
+	if key == "" {
+		return fmt.Errorf("database %s is encrypted: set %s or store the key in the OS keychain (service %q, account: the database path)",
+			dbPath, EncryptionKeyEnv, KeychainService)
+	}
+	SetEncryptionKey(key)
+	return nil
+}
+
+// keychainEncryptionKey reads the key for a database from the OS keychain, if available
+func keychainEncryptionKey(dbPath string) string {
+	absPath, err := filepath.Abs(dbPath)
+	if err != nil {
+		return ""
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", KeychainService, "-a", absPath, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", KeychainService, "database", absPath)
+	default:
+		return ""
+	}
+	if cmd.Err != nil {
+		// Keychain tool not installed
+		return ""
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(out), "\r\n")
+}
+
+// OpenSQLite opens a local SQLite database, using the key set with
+// SetEncryptionKey when there is one
+func OpenSQLite(dsn string) (*sql.DB, error) {
+	return openSQLiteWithKey(dsn, encryptionKey)
+}
+
+// openSQLiteWithKey opens a local SQLite database with an explicit key
+// (empty for an unencrypted database)
+func openSQLiteWithKey(dsn, key string) (*sql.DB, error) {
+	if key == "" {
+		return sql.Open("sqlite3", dsn)
+	}
+	if !EncryptionSupported {
+		return nil, ErrEncryptionUnsupported
+	}
+
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return sql.Open(sqlcipherDriverName, dsn+separator+encryptionKeyParam+"="+url.QueryEscape(key))
+}
+
+// splitEncryptionKey removes the key parameter from a DSN and returns it separately
+func splitEncryptionKey(dsn string) (string, string) {
+	base, query, found := strings.Cut(dsn, "?")
+	if !found {
+		return dsn, ""
+	}
+
+	var key string
+	var kept []string
+	for _, param := range strings.Split(query, "&") {
+		if value, ok := strings.CutPrefix(param, encryptionKeyParam+"="); ok {
+			key, _ = url.QueryUnescape(value)
+			continue
+		}
+		kept = append(kept, param)
+	}
+
+	if len(kept) == 0 {
+		return base, key
+	}
+	return base + "?" + strings.Join(kept, "&"), key
+}
+
+// IsEncrypted reports whether a database file is encrypted, i.e. exists but
+// does not start with the plain SQLite header. Empty files are not encrypted.
+func IsEncrypted(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	n, _ := f.Read(header)
+	if n == 0 {
+		return false, nil
+	}
+	return !bytes.Equal(header[:n], sqliteHeader), nil
+}
+
+// EncryptDatabase converts a plain database to an encrypted one in place.
+// The database must not be open; callers should back it up first.
+func EncryptDatabase(dbPath, key string) error {
+	if key == "" {
+		return fmt.Errorf("an encryption key is required")
+	}
+	encrypted, err := IsEncrypted(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database: %w", err)
+	}
+	if encrypted {
+		return fmt.Errorf("%s is already encrypted", dbPath)
+	}
+	return convertDatabase(dbPath, "", key)
+}
+
+// DecryptDatabase converts an encrypted database to a plain one in place.
+// The database must not be open; callers should back it up first.
+func DecryptDatabase(dbPath, key string) error {
+	if key == "" {
+		return fmt.Errorf("the database's encryption key is required")
+	}
+	encrypted, err := IsEncrypted(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database: %w", err)
+	}
+	if !encrypted {
+		return fmt.Errorf("%s is not encrypted", dbPath)
+	}
+	return convertDatabase(dbPath, key, "")
+}
+
+// convertDatabase re-keys a database by exporting it to a new file and
+// replacing the original, so a failure leaves the original untouched
+func convertDatabase(dbPath, fromKey, toKey string) error {
+	if !EncryptionSupported {
+		return ErrEncryptionUnsupported
+	}
+
+	tmpPath := dbPath + ".converting"
+	_ = os.Remove(tmpPath)
+
+	if err := exportDatabase(dbPath, fromKey, tmpPath, toKey); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	// The source WAL was checkpointed during export; stale WAL files would
+	// be replayed against the converted file
+	for _, walFile := range []string{dbPath + "-wal", dbPath + "-shm"} {
+		if err := os.Remove(walFile); err != nil && !os.IsNotExist(err) {
+			_ = os.Remove(tmpPath)
+			return fmt.Errorf("failed to remove %s: %w", walFile, err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !sqlcipher
+
+package db
+
+// EncryptionSupported reports whether this build can open encrypted databases
+const EncryptionSupported = false
+
+// sqlcipherDriverName is unused without the sqlcipher tag
+const sqlcipherDriverName = ""
+
+// exportDatabase requires SQLCipher
+func exportDatabase(srcPath, srcKey, dstPath, dstKey string) error {
+	return ErrEncryptionUnsupported
+}
@@ -0,0 +1,114 @@
+//go:build sqlcipher
+
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// EncryptionSupported reports whether this build can open encrypted databases.
+//
+// Builds with the sqlcipher tag must link go-sqlite3 against SQLCipher instead
+// of the bundled SQLite (see 'make shark-sqlcipher').
+const EncryptionSupported = true
+
+// sqlcipherDriverName is the database/sql driver that applies the encryption key
+const sqlcipherDriverName = "sqlite3_sqlcipher"
+
+func init() {
+	sql.Register(sqlcipherDriverName, &sqlcipherDriver{})
+}
+
+// sqlcipherDriver opens SQLite connections and keys them with PRAGMA key
+// before any other statement runs, so every pooled connection is keyed
+type sqlcipherDriver struct {
+	sqlite3.SQLiteDriver
+}
+
+// Open opens a connection and applies the key carried in the DSN
+func (d *sqlcipherDriver) Open(dsn string) (driver.Conn, error) {
+	dsn, key := splitEncryptionKey(dsn)
+
+	conn, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	sqliteConn := conn.(*sqlite3.SQLiteConn)
+
+	if key != "" {
+		if _, err := sqliteConn.Exec("PRAGMA key = "+quoteSQLString(key), nil); err != nil {
+			sqliteConn.Close()
+			return nil, fmt.Errorf("failed to apply encryption key: %w", err)
+		}
+	}
+
+	if err := checkCipherConn(sqliteConn); err != nil {
+		sqliteConn.Close()
+		return nil, err
+	}
+
+	return sqliteConn, nil
+}
+
+// checkCipherConn verifies that SQLCipher is linked and the key opens the database
+func checkCipherConn(conn *sqlite3.SQLiteConn) error {
+	rows, err := conn.Query("PRAGMA cipher_version", nil)
+	if err != nil {
+		return fmt.Errorf("failed to query cipher version: %w", err)
+	}
+	dest := make([]driver.Value, 1)
+	err = rows.Next(dest)
+	rows.Close()
+	if err == io.EOF {
+		return fmt.Errorf("shark was built with the sqlcipher tag but is not linked against SQLCipher")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query cipher version: %w", err)
+	}
+
+	// With a wrong key, the first read of the schema fails
+	if _, err := conn.Exec("SELECT count(*) FROM sqlite_master", nil); err != nil {
+		return fmt.Errorf("failed to open encrypted database (wrong key?): %w", err)
+	}
+	return nil
+}
+
+// exportDatabase copies a database into a new file with a different key using
+// sqlcipher_export. An empty key means unencrypted.
+func exportDatabase(srcPath, srcKey, dstPath, dstKey string) error {
+	src, err := openSQLiteWithKey(srcPath, srcKey)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer src.Close()
+
+	// ATTACH is per connection, so keep everything on one
+	src.SetMaxOpenConns(1)
+
+	if _, err := src.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+
+	if _, err := src.Exec("ATTACH DATABASE ? AS export KEY ?", dstPath, dstKey); err != nil {
+		return fmt.Errorf("failed to create converted database: %w", err)
+	}
+	if _, err := src.Exec("SELECT sqlcipher_export('export')"); err != nil {
+		return fmt.Errorf("failed to export database: %w", err)
+	}
+	if _, err := src.Exec("DETACH DATABASE export"); err != nil {
+		return fmt.Errorf("failed to finish export: %w", err)
+	}
+
+	return nil
+}
+
+// quoteSQLString quotes a value as a SQL string literal
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitEncryptionKey(t *testing.T) {
+	dsn, key := splitEncryptionKey("shark-tasks.db?_foreign_keys=on&_shark_key=p%40ss%26word")
+	assert.Equal(t, "shark-tasks.db?_foreign_keys=on", dsn)
+	assert.Equal(t, "p@ss&word", key)
+
+	dsn, key = splitEncryptionKey("shark-tasks.db?_shark_key=secret")
+	assert.Equal(t, "shark-tasks.db", dsn)
+	assert.Equal(t, "secret", key)
+
+	dsn, key = splitEncryptionKey("shark-tasks.db")
+	assert.Equal(t, "shark-tasks.db", dsn)
+	assert.Empty(t, key)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "plain.db")
+	database, err := InitDB(plain)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	encrypted := filepath.Join(dir, "encrypted.db")
+	require.NoError(t, os.WriteFile(encrypted, []byte("0123456789abcdef random page bytes"), 0600))
+
+	empty := filepath.Join(dir, "empty.db")
+	require.NoError(t, os.WriteFile(empty, nil, 0600))
+
+	got, err := IsEncrypted(plain)
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	got, err = IsEncrypted(encrypted)
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = IsEncrypted(empty)
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	_, err = IsEncrypted(filepath.Join(dir, "missing.db"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestConfigureEncryption(t *testing.T) {
+	defer SetEncryptionKey("")
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "plain.db")
+	database, err := InitDB(plain)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	encrypted := filepath.Join(dir, "encrypted.db")
+	require.NoError(t, os.WriteFile(encrypted, []byte("0123456789abcdef random page bytes"), 0600))
+
+	// Plain databases are opened without a key even when one is configured
+	t.Setenv(EncryptionKeyEnv, "secret")
+	require.NoError(t, ConfigureEncryption(plain))
+	assert.Empty(t, EncryptionKey())
+
+	// Encrypted and new databases use the configured key
+	require.NoError(t, ConfigureEncryption(encrypted))
+	assert.Equal(t, "secret", EncryptionKey())
+	require.NoError(t, ConfigureEncryption(filepath.Join(dir, "new.db")))
+	assert.Equal(t, "secret", EncryptionKey())
+
+	// Encrypted databases without a key fail with a hint
+	t.Setenv(EncryptionKeyEnv, "")
+	SetEncryptionKey("")
+	if keychainEncryptionKey(encrypted) == "" {
+		err = ConfigureEncryption(encrypted)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), EncryptionKeyEnv)
+	}
+}
+
+func TestEncryption_UnsupportedBuild(t *testing.T) {
+	if EncryptionSupported {
+		t.Skip("built with SQLCipher")
+	}
+
+	_, err := openSQLiteWithKey(filepath.Join(t.TempDir(), "shark-tasks.db"), "secret")
+	assert.ErrorIs(t, err, ErrEncryptionUnsupported)
+
+	dbPath := filepath.Join(t.TempDir(), "shark-tasks.db")
+	database, err := InitDB(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+	assert.ErrorIs(t, EncryptDatabase(dbPath, "secret"), ErrEncryptionUnsupported)
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// InvalidEnumValue is a row whose enum column holds a value that validation
+// now rejects. Allowed is empty for agent_type, which only has to be non-blank.
+type InvalidEnumValue struct {
+	Table   string   `json:"table"`
+	Key     string   `json:"key"`
+	Column  string   `json:"column"`
+	Value   *string  `json:"value"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// enumColumnCheck describes one enum column to check
+type enumColumnCheck struct {
+	table    string
+	column   string
+	allowed  []string
+	nullable bool
+}
+
+// FindInvalidEnumValues lists epics, features, tasks, and ideas whose status,
+// priority, business value, or agent type would fail validation. The status
+// CHECK constraints were dropped so workflows can define their own task
+// statuses, so rows written before validation was enforced everywhere may hold
+// typos. taskStatuses are the statuses of the configured workflow.
+func FindInvalidEnumValues(db *sql.DB, taskStatuses []string) ([]InvalidEnumValue, error) {
+	checks := []enumColumnCheck{
+		{table: "epics", column: "status", allowed: models.EnumValues(models.EpicStatuses)},
+		{table: "epics", column: "priority", allowed: models.EnumValues(models.Priorities)},
+		{table: "epics", column: "business_value", allowed: models.EnumValues(models.Priorities), nullable: true},
+		{table: "features", column: "status", allowed: models.EnumValues(models.FeatureStatuses)},
+		{table: "tasks", column: "status", allowed: taskStatuses},
+		{table: "ideas", column: "status", allowed: models.EnumValues(models.IdeaStatuses)},
+	}
+
+	invalid := []InvalidEnumValue{}
+	for _, check := range checks {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(check.allowed)), ", ")
+		where := fmt.Sprintf("%s NOT IN (%s)", check.column, placeholders)
+		if check.nullable {
+			where = fmt.Sprintf("%s IS NOT NULL AND %s", check.column, where)
+		} else {
+			where = fmt.Sprintf("(%s IS NULL OR %s)", check.column, where)
+		}
+		args := make([]interface{}, len(check.allowed))
+		for i, value := range check.allowed {
+			args[i] = value
+		}
+
+		rows, err := findEnumRows(db, fmt.Sprintf("SELECT key, %s FROM %s WHERE %s ORDER BY key", check.column, check.table, where), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s.%s: %w", check.table, check.column, err)
+		}
+		for _, row := range rows {
+			row.Table, row.Column, row.Allowed = check.table, check.column, check.allowed
+			invalid = append(invalid, row)
+		}
+	}
+
+	rows, err := findEnumRows(db, `SELECT key, agent_type FROM tasks WHERE agent_type IS NOT NULL AND TRIM(agent_type) = '' ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tasks.agent_type: %w", err)
+	}
+	for _, row := range rows {
+		row.Table, row.Column = "tasks", "agent_type"
+		invalid = append(invalid, row)
+	}
+
+	return invalid, nil
+}
+
+// findEnumRows runs a query selecting key and value columns
+func findEnumRows(db *sql.DB, query string, args ...interface{}) ([]InvalidEnumValue, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []InvalidEnumValue
+	for rows.Next() {
+		var row InvalidEnumValue
+		if err := rows.Scan(&row.Key, &row.Value); err != nil {
+			return nil, err
+		}
+		found = append(found, row)
+	}
+	return found, rows.Err()
+}
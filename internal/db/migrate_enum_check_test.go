@@ -0,0 +1,50 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInvalidEnumValues(t *testing.T) {
+	database, err := InitDB(filepath.Join(t.TempDir(), "shark-tasks.db"))
+	require.NoError(t, err)
+	defer database.Close()
+
+	_, err = database.Exec(`
+		INSERT INTO epics (id, key, title, status, priority, business_value) VALUES
+			(1, 'E01', 'Valid', 'active', 'high', NULL),
+			(2, 'E02', 'Typo', 'actve', 'medium', 'low');
+		INSERT INTO features (id, epic_id, key, title, status) VALUES
+			(1, 1, 'E01-F01', 'Valid', 'draft'),
+			(2, 1, 'E01-F02', 'Bogus', 'bogus');
+		INSERT INTO tasks (feature_id, key, title, status, priority, agent_type) VALUES
+			(1, 'T-E01-F01-001', 'Valid', 'todo', 5, 'backend'),
+			(1, 'T-E01-F01-002', 'Unknown status', 'doing', 5, NULL),
+			(1, 'T-E01-F01-003', 'Blank agent', 'todo', 5, '  ');
+	`)
+	require.NoError(t, err)
+
+	invalid, err := FindInvalidEnumValues(database, []string{"todo", "in_progress", "completed"})
+	require.NoError(t, err)
+
+	found := map[string]string{}
+	for _, row := range invalid {
+		require.NotNil(t, row.Value)
+		found[row.Table+"."+row.Column+" "+row.Key] = *row.Value
+	}
+	assert.Equal(t, map[string]string{
+		"epics.status E02":               "actve",
+		"features.status E01-F02":        "bogus",
+		"tasks.status T-E01-F01-002":     "doing",
+		"tasks.agent_type T-E01-F01-003": "  ",
+	}, found)
+
+	for _, row := range invalid {
+		if row.Table == "epics" && row.Column == "status" {
+			assert.Equal(t, []string{"draft", "active", "completed", "archived"}, row.Allowed)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// indexExists reports whether the named index exists
+func indexExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = ?`, name).Scan(&count)
+	require.NoError(t, err)
+	return count == 1
+}
+
+func TestInitDB_StampsSchemaVersion(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := InitDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var version int
+	require.NoError(t, db.QueryRow("PRAGMA user_version;").Scan(&version))
+	assert.Equal(t, SchemaVersion, version)
+}
+
+func TestInitDB_SkipsSchemaWhenCurrent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := InitDB(dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("DROP INDEX idx_tasks_status")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// Current databases are opened without re-applying the schema
+	db, err = InitDB(dbPath)
+	require.NoError(t, err)
+	assert.False(t, indexExists(t, db, "idx_tasks_status"), "schema should not be re-applied")
+	require.NoError(t, db.Close())
+
+	// --verify-schema re-applies it
+	db, err = InitDBWithOptions(dbPath, InitOptions{VerifySchema: true})
+	require.NoError(t, err)
+	defer db.Close()
+	assert.True(t, indexExists(t, db, "idx_tasks_status"), "schema should be re-applied")
+}
+
+func TestInitDB_AppliesSchemaWhenStale(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := InitDB(dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("DROP INDEX idx_tasks_status")
+	require.NoError(t, err)
+	_, err = db.Exec("PRAGMA user_version = 0")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = InitDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	assert.True(t, indexExists(t, db, "idx_tasks_status"), "stale databases should be migrated")
+
+	var version int
+	require.NoError(t, db.QueryRow("PRAGMA user_version;").Scan(&version))
+	assert.Equal(t, SchemaVersion, version)
+}
+
+func TestInitDBWithOptions_ReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := InitDB(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = InitDBWithOptions(dbPath, InitOptions{ReadOnly: true, Tuning: Tuning{MaxOpenConns: 2}})
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM epics").Scan(&count))
+	_, err = db.Exec(`INSERT INTO epics (key, title, status, priority) VALUES ('E01', 'Epic', 'draft', 'high')`)
+	assert.Error(t, err, "writes should fail on every connection")
+	require.NoError(t, db.Close())
+
+	// A stale schema can't be upgraded read-only
+	db, err = InitDB(dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("PRAGMA user_version = 0")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	_, err = InitDBWithOptions(dbPath, InitOptions{ReadOnly: true})
+	assert.ErrorContains(t, err, "out of date")
+}
+
+// BenchmarkInitDB_Current measures opening an up-to-date database, the path
+// every CLI command takes
+func BenchmarkInitDB_Current(b *testing.B) {
+	benchmarkInitDB(b, InitOptions{})
+}
+
+// BenchmarkInitDB_VerifySchema measures opening a database with full schema
+// validation (--verify-schema)
+func BenchmarkInitDB_VerifySchema(b *testing.B) {
+	benchmarkInitDB(b, InitOptions{VerifySchema: true})
+}
+
+func benchmarkInitDB(b *testing.B, opts InitOptions) {
+	dbPath := filepath.Join(b.TempDir(), "bench.db")
+	db, err := InitDB(dbPath)
+	if err != nil {
+		b.Fatal(err)
+	}
+	db.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		db, err := InitDBWithOptions(dbPath, opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		db.Close()
+	}
+}
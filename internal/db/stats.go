@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+)
+
+// StatsPragmas are the settings reported by CollectStats, in display order
+var StatsPragmas = []string{
+	"journal_mode",
+	"busy_timeout",
+	"synchronous",
+	"cache_size",
+	"page_size",
+	"page_count",
+	"freelist_count",
+	"wal_autocheckpoint",
+	"foreign_keys",
+	"locking_mode",
+}
+
+// DatabaseStats describes the settings and size of an open database, for
+// diagnosing lock contention (SQLITE_BUSY) and slow queries
+type DatabaseStats struct {
+	Path         string            `json:"path"`
+	SizeBytes    int64             `json:"size_bytes"`
+	WALSizeBytes int64             `json:"wal_size_bytes"`
+	Pragmas      map[string]string `json:"pragmas"`
+	Pool         PoolStats         `json:"pool"`
+	Tables       []TableStats      `json:"tables"`
+	Indexes      []IndexStats      `json:"indexes"`
+	Analyzed     bool              `json:"analyzed"` // Whether sqlite_stat1 exists (ANALYZE has been run)
+}
+
+// PoolStats summarizes database/sql connection pool usage for this process
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"` // 0 = unlimited
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+}
+
+// TableStats is the row count of a table
+type TableStats struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// IndexStats describes an index and, once ANALYZE has been run, SQLite's
+// estimate of its selectivity from sqlite_stat1
+type IndexStats struct {
+	Name   string `json:"name"`
+	Table  string `json:"table"`
+	Unique bool   `json:"unique"`
+	Stat   string `json:"stat,omitempty"` // "<rows> <rows per key>..." as stored in sqlite_stat1
+}
+
+// CollectStats reads pragma settings, file sizes, connection pool usage, table
+// row counts, and index statistics. With analyze, ANALYZE is run first so
+// index statistics are current.
+func CollectStats(ctx context.Context, db *sql.DB, path string, analyze bool) (*DatabaseStats, error) {
+	if analyze {
+		if _, err := db.ExecContext(ctx, "ANALYZE"); err != nil {
+			return nil, fmt.Errorf("failed to analyze database: %w", err)
+		}
+	}
+
+	stats := &DatabaseStats{
+		Path:    path,
+		Pragmas: make(map[string]string, len(StatsPragmas)),
+		Tables:  []TableStats{},
+		Indexes: []IndexStats{},
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		stats.SizeBytes = info.Size()
+	}
+	if info, err := os.Stat(path + "-wal"); err == nil {
+		stats.WALSizeBytes = info.Size()
+	}
+
+	for _, name := range StatsPragmas {
+		var value string
+		if err := db.QueryRowContext(ctx, "PRAGMA "+name).Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to read PRAGMA %s: %w", name, err)
+		}
+		stats.Pragmas[name] = value
+	}
+
+	pool := db.Stats()
+	stats.Pool = PoolStats{
+		MaxOpenConnections: pool.MaxOpenConnections,
+		OpenConnections:    pool.OpenConnections,
+		InUse:              pool.InUse,
+		Idle:               pool.Idle,
+		WaitCount:          pool.WaitCount,
+		WaitDurationMs:     pool.WaitDuration.Milliseconds(),
+	}
+
+	tables, err := queryStrings(ctx, db, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	for _, table := range tables {
+		var rows int64
+		if err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, table)).Scan(&rows); err != nil {
+			return nil, fmt.Errorf("failed to count rows in %s: %w", table, err)
+		}
+		stats.Tables = append(stats.Tables, TableStats{Name: table, Rows: rows})
+	}
+
+	var statTables int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_stat1'").Scan(&statTables); err != nil {
+		return nil, fmt.Errorf("failed to check for sqlite_stat1: %w", err)
+	}
+	stats.Analyzed = statTables > 0
+
+	query := `
+		SELECT m.name, m.tbl_name, m.sql IS NULL AND m.name LIKE 'sqlite_autoindex_%', COALESCE(m.sql, '') LIKE 'CREATE UNIQUE%', ''
+		FROM sqlite_master m
+		WHERE m.type = 'index'
+		ORDER BY m.tbl_name, m.name
+	`
+	if stats.Analyzed {
+		query = `
+			SELECT m.name, m.tbl_name, m.sql IS NULL AND m.name LIKE 'sqlite_autoindex_%', COALESCE(m.sql, '') LIKE 'CREATE UNIQUE%', COALESCE(s.stat, '')
+			FROM sqlite_master m
+			LEFT JOIN sqlite_stat1 s ON s.idx = m.name
+			WHERE m.type = 'index'
+			ORDER BY m.tbl_name, m.name
+		`
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var index IndexStats
+		var autoIndex, unique bool
+		if err := rows.Scan(&index.Name, &index.Table, &autoIndex, &unique, &index.Stat); err != nil {
+			return nil, fmt.Errorf("failed to scan index: %w", err)
+		}
+		// Automatic indexes back UNIQUE and PRIMARY KEY constraints
+		index.Unique = autoIndex || unique
+		stats.Indexes = append(stats.Indexes, index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating indexes: %w", err)
+	}
+
+	return stats, nil
+}
+
+// queryStrings returns the first column of every row of a query
+func queryStrings(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
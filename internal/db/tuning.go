@@ -0,0 +1,114 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+)
+
+// Default SQLite tuning, used for any setting left unset
+const (
+	DefaultJournalMode   = "WAL"
+	DefaultBusyTimeoutMs = 5000
+	DefaultSynchronous   = "NORMAL"
+	DefaultCacheSizeKB   = 64000
+)
+
+var (
+	validJournalModes = []string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}
+	validSynchronous  = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+)
+
+// Tuning holds SQLite connection settings. Zero values fall back to the
+// defaults above; zero pool limits leave database/sql's defaults in place.
+type Tuning struct {
+	JournalMode   string // PRAGMA journal_mode (default WAL)
+	BusyTimeoutMs int    // How long a connection waits on a lock before SQLITE_BUSY
+	Synchronous   string // PRAGMA synchronous (default NORMAL)
+	CacheSizeKB   int    // Page cache size per connection
+	MaxOpenConns  int    // Connection pool limit (0 = unlimited)
+	MaxIdleConns  int    // Idle connections kept open (0 = database/sql default)
+}
+
+// TuningFromConfig reads tuning from the database section of .sharkconfig.json
+func TuningFromConfig(dc config.DatabaseConfig) Tuning {
+	return Tuning{
+		JournalMode:   dc.JournalMode,
+		BusyTimeoutMs: dc.BusyTimeoutMs,
+		Synchronous:   dc.Synchronous,
+		CacheSizeKB:   dc.CacheSizeKB,
+		MaxOpenConns:  dc.MaxOpenConns,
+		MaxIdleConns:  dc.MaxIdleConns,
+	}
+}
+
+// WithDefaults returns the tuning with unset settings filled in
+func (t Tuning) WithDefaults() Tuning {
+	if t.JournalMode == "" {
+		t.JournalMode = DefaultJournalMode
+	}
+	if t.BusyTimeoutMs == 0 {
+		t.BusyTimeoutMs = DefaultBusyTimeoutMs
+	}
+	if t.Synchronous == "" {
+		t.Synchronous = DefaultSynchronous
+	}
+	if t.CacheSizeKB == 0 {
+		t.CacheSizeKB = DefaultCacheSizeKB
+	}
+	t.JournalMode = strings.ToUpper(t.JournalMode)
+	t.Synchronous = strings.ToUpper(t.Synchronous)
+	return t
+}
+
+// Validate checks the tuning settings
+func (t Tuning) Validate() error {
+	t = t.WithDefaults()
+	if !containsString(validJournalModes, t.JournalMode) {
+		return fmt.Errorf("invalid journal_mode %q: must be one of %s", t.JournalMode, strings.Join(validJournalModes, ", "))
+	}
+	if !containsString(validSynchronous, t.Synchronous) {
+		return fmt.Errorf("invalid synchronous %q: must be one of %s", t.Synchronous, strings.Join(validSynchronous, ", "))
+	}
+	if t.BusyTimeoutMs < 0 {
+		return fmt.Errorf("invalid busy_timeout_ms %d: must not be negative", t.BusyTimeoutMs)
+	}
+	if t.CacheSizeKB < 0 {
+		return fmt.Errorf("invalid cache_size_kb %d: must not be negative", t.CacheSizeKB)
+	}
+	if t.MaxOpenConns < 0 || t.MaxIdleConns < 0 {
+		return fmt.Errorf("invalid connection pool limits: must not be negative")
+	}
+	return nil
+}
+
+// dsnParams returns connection string parameters applied to every pooled
+// connection as it opens. Only settings that don't touch the database file
+// belong here: encrypted databases are keyed after the connection opens.
+func (t Tuning) dsnParams() string {
+	return fmt.Sprintf("_foreign_keys=on&_busy_timeout=%d", t.BusyTimeoutMs)
+}
+
+// pragmas returns the PRAGMA statements applied when the database is opened
+func (t Tuning) pragmas() []string {
+	return []string{
+		"PRAGMA foreign_keys = ON;",                               // Enable foreign key constraints
+		fmt.Sprintf("PRAGMA journal_mode = %s;", t.JournalMode),   // WAL allows readers alongside a writer
+		fmt.Sprintf("PRAGMA busy_timeout = %d;", t.BusyTimeoutMs), // Wait for locks instead of failing
+		fmt.Sprintf("PRAGMA synchronous = %s;", t.Synchronous),    // Balance safety and performance
+		fmt.Sprintf("PRAGMA cache_size = -%d;", t.CacheSizeKB),    // Negative values are KiB
+		"PRAGMA temp_store = MEMORY;",                             // Store temp tables in memory
+		"PRAGMA mmap_size = 30000000000;",                         // Use memory-mapped I/O
+	}
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
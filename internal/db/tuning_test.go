@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuning_WithDefaults(t *testing.T) {
+	tuning := Tuning{Synchronous: "full", MaxOpenConns: 4}.WithDefaults()
+
+	assert.Equal(t, DefaultJournalMode, tuning.JournalMode)
+	assert.Equal(t, DefaultBusyTimeoutMs, tuning.BusyTimeoutMs)
+	assert.Equal(t, "FULL", tuning.Synchronous)
+	assert.Equal(t, DefaultCacheSizeKB, tuning.CacheSizeKB)
+	assert.Equal(t, 4, tuning.MaxOpenConns)
+}
+
+func TestTuning_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tuning  Tuning
+		wantErr bool
+	}{
+		{"zero value uses defaults", Tuning{}, false},
+		{"lowercase modes", Tuning{JournalMode: "delete", Synchronous: "extra"}, false},
+		{"unknown journal mode", Tuning{JournalMode: "fast"}, true},
+		{"unknown synchronous", Tuning{Synchronous: "sometimes"}, true},
+		{"negative busy timeout", Tuning{BusyTimeoutMs: -1}, true},
+		{"negative pool limit", Tuning{MaxIdleConns: -2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tuning.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInitDBWithOptions_AppliesTuning(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shark-tasks.db")
+	database, err := InitDBWithOptions(dbPath, InitOptions{Tuning: Tuning{BusyTimeoutMs: 1234, Synchronous: "FULL", MaxOpenConns: 3}})
+	require.NoError(t, err)
+	defer database.Close()
+
+	stats, err := CollectStats(context.Background(), database, dbPath, false)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", stats.Pragmas["busy_timeout"])
+	assert.Equal(t, "2", stats.Pragmas["synchronous"], "FULL is reported as 2")
+	assert.Equal(t, "wal", stats.Pragmas["journal_mode"])
+	assert.Equal(t, 3, stats.Pool.MaxOpenConnections)
+}
+
+func TestInitDBWithOptions_RejectsInvalidTuning(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shark-tasks.db")
+	_, err := InitDBWithOptions(dbPath, InitOptions{Tuning: Tuning{JournalMode: "fast"}})
+	assert.ErrorContains(t, err, "journal_mode")
+}
+
+func TestCollectStats_Analyze(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shark-tasks.db")
+	database, err := InitDB(dbPath)
+	require.NoError(t, err)
+	defer database.Close()
+
+	ctx := context.Background()
+	stats, err := CollectStats(ctx, database, dbPath, false)
+	require.NoError(t, err)
+	assert.False(t, stats.Analyzed)
+	assert.Positive(t, stats.SizeBytes)
+
+	tables := make(map[string]bool)
+	for _, table := range stats.Tables {
+		tables[table.Name] = true
+	}
+	assert.True(t, tables["tasks"])
+
+	_, err = database.Exec(`INSERT INTO epics (key, title, status, priority) VALUES ('E01', 'Epic', 'active', 'high')`)
+	require.NoError(t, err)
+
+	stats, err = CollectStats(ctx, database, dbPath, true)
+	require.NoError(t, err)
+	assert.True(t, stats.Analyzed)
+	for _, index := range stats.Indexes {
+		if index.Name == "idx_epics_key" {
+			assert.True(t, index.Unique)
+			assert.Equal(t, "1 1", index.Stat)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package events serves the changes feed, the events recorded for every change
+// to an epic, feature, or task, as a stream of server-sent events.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// DefaultPollInterval is how often the stream checks for new events
+const DefaultPollInterval = time.Second
+
+// keepAliveInterval is how often an idle stream sends a comment, so proxies
+// don't close it
+const keepAliveInterval = 15 * time.Second
+
+// batchSize bounds the events read per poll
+const batchSize = 500
+
+// Source reads the changes feed; *repository.EventRepository implements it
+type Source interface {
+	ListSince(ctx context.Context, since int64, limit int, filter repository.EventFilter) ([]*models.Event, error)
+	LatestSeq(ctx context.Context) (int64, error)
+}
+
+// NewHTTPHandler serves GET /api/v1/events as server-sent events. Each event
+// has the seq as its id, the entity type as its event name, and the Event as
+// JSON data. The stream starts after the since query parameter, else after
+// the Last-Event-ID header of a reconnecting client, else with the next new
+// event. The type and key query parameters filter the feed as EventFilter does.
+func NewHTTPHandler(source Source, pollInterval time.Duration) http.Handler {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	h := &httpHandler{source: source, pollInterval: pollInterval}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/events", h.serveEvents)
+	return mux
+}
+
+type httpHandler struct {
+	source       Source
+	pollInterval time.Duration
+}
+
+// serveEvents handles GET /api/v1/events
+func (h *httpHandler) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	since, err := h.startSeq(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+	lastWrite := time.Now()
+	for {
+		events, err := h.source.ListSince(r.Context(), since, batchSize, filter)
+		if err != nil {
+			if r.Context().Err() == nil {
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", strings.ReplaceAll(err.Error(), "\n", " "))
+				flusher.Flush()
+			}
+			return
+		}
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.EntityType, data)
+			since = event.Seq
+		}
+		if len(events) > 0 {
+			flusher.Flush()
+			lastWrite = time.Now()
+			if len(events) == batchSize {
+				continue
+			}
+		} else if time.Since(lastWrite) >= keepAliveInterval {
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+			lastWrite = time.Now()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startSeq returns the seq the stream starts after
+func (h *httpHandler) startSeq(r *http.Request) (int64, error) {
+	value, name := r.URL.Query().Get("since"), "since"
+	if value == "" {
+		value, name = r.Header.Get("Last-Event-ID"), "Last-Event-ID"
+	}
+	if value == "" {
+		return h.source.LatestSeq(r.Context())
+	}
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("invalid %s: %s (expected an event seq)", name, value)
+	}
+	return seq, nil
+}
+
+// filterFromQuery builds an EventFilter from the type and key query parameters
+func filterFromQuery(r *http.Request) (repository.EventFilter, error) {
+	query := r.URL.Query()
+	filter := repository.EventFilter{
+		EntityType: strings.ToLower(strings.TrimSpace(query.Get("type"))),
+		Key:        strings.ToUpper(strings.TrimSpace(query.Get("key"))),
+	}
+	if err := ValidateEntityType(filter.EntityType); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// ValidateEntityType checks an event entity type filter; empty means all
+func ValidateEntityType(entityType string) error {
+	switch entityType {
+	case "", models.EventEntityEpic, models.EventEntityFeature, models.EventEntityTask:
+		return nil
+	}
+	return fmt.Errorf("invalid type: %s (expected epic, feature, or task)", entityType)
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// fakeSource serves a fixed list of events
+type fakeSource struct {
+	events     []*models.Event
+	lastFilter repository.EventFilter
+}
+
+func (f *fakeSource) ListSince(ctx context.Context, since int64, limit int, filter repository.EventFilter) ([]*models.Event, error) {
+	f.lastFilter = filter
+	var result []*models.Event
+	for _, event := range f.events {
+		if event.Seq > since && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSource) LatestSeq(ctx context.Context) (int64, error) {
+	return int64(len(f.events)), nil
+}
+
+func newFakeSource() *fakeSource {
+	status := "in_progress"
+	return &fakeSource{events: []*models.Event{
+		{Seq: 1, EntityType: "task", EntityKey: "T-E01-F01-001", Action: "created"},
+		{Seq: 2, EntityType: "task", EntityKey: "T-E01-F01-001", Action: "status_changed", Status: &status},
+	}}
+}
+
+// stream serves the request until its context times out and returns the body
+func stream(t *testing.T, handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(req.Context(), 50*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req.WithContext(ctx))
+	return rec
+}
+
+func TestHTTPHandler_StreamsEvents(t *testing.T) {
+	source := newFakeSource()
+	handler := NewHTTPHandler(source, 10*time.Millisecond)
+
+	rec := stream(t, handler, httptest.NewRequest(http.MethodGet, "/api/v1/events?since=0&type=task&key=e01", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "id: 1\nevent: task\ndata: {") || !strings.Contains(body, `"action":"status_changed"`) {
+		t.Errorf("unexpected stream:\n%s", body)
+	}
+	if source.lastFilter.EntityType != "task" || source.lastFilter.Key != "E01" {
+		t.Errorf("filter = %+v", source.lastFilter)
+	}
+}
+
+func TestHTTPHandler_Resume(t *testing.T) {
+	handler := NewHTTPHandler(newFakeSource(), 10*time.Millisecond)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	body := stream(t, handler, req).Body.String()
+	if strings.Contains(body, "id: 1\n") || !strings.Contains(body, "id: 2\n") {
+		t.Errorf("expected only events after 1:\n%s", body)
+	}
+
+	// Without since or Last-Event-ID only new events are sent
+	body = stream(t, handler, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)).Body.String()
+	if strings.Contains(body, "id:") {
+		t.Errorf("expected no past events:\n%s", body)
+	}
+}
+
+func TestHTTPHandler_InvalidQuery(t *testing.T) {
+	handler := NewHTTPHandler(newFakeSource(), 10*time.Millisecond)
+	for _, query := range []string{"since=abc", "since=-1", "type=idea"} {
+		rec := stream(t, handler, httptest.NewRequest(http.MethodGet, "/api/v1/events?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
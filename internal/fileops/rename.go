@@ -0,0 +1,36 @@
+package fileops
+
+import (
+	"fmt"
+	"os"
+)
+
+// RenameEntityPath renames an entity's file or directory, creating the
+// target's parent directories. Paths are absolute or relative to projectRoot.
+// Returns false without changing anything if from doesn't exist, and an error
+// if to already exists.
+func (w *EntityFileWriter) RenameEntityPath(projectRoot, from, to string) (bool, error) {
+	fromAbs, _, _ := w.resolvePaths(from, projectRoot)
+	toAbs, _, _ := w.resolvePaths(to, projectRoot)
+
+	exists, err := w.checkFileExists(fromAbs)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", from, err)
+	}
+	if !exists {
+		return false, nil
+	}
+	if exists, err := w.checkFileExists(toAbs); err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", to, err)
+	} else if exists {
+		return false, fmt.Errorf("cannot rename %s: %s already exists", from, to)
+	}
+
+	if err := w.ensureParentDir(toAbs); err != nil {
+		return false, fmt.Errorf("failed to create directory for %s: %w", to, err)
+	}
+	if err := os.Rename(fromAbs, toAbs); err != nil {
+		return false, fmt.Errorf("failed to rename %s to %s: %w", from, to, err)
+	}
+	return true, nil
+}
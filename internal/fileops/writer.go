@@ -131,7 +131,7 @@ func (w *EntityFileWriter) WriteEntityFile(opts WriteOptions) (*WriteResult, err
 			return nil, fmt.Errorf("failed to write %s file: %w", opts.EntityType, err)
 		}
 	} else {
-		// Simple write (backward compatible with epic/feature behavior)
+		// Simple write; a concurrent writer may overwrite the file
 		if err := os.WriteFile(absPath, opts.Content, 0644); err != nil {
 			return nil, fmt.Errorf("failed to write %s file: %w", opts.EntityType, err)
 		}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -320,6 +321,44 @@ func TestAtomicWriteRaceCondition(t *testing.T) {
 	assert.False(t, result.Written)
 }
 
+// TestAtomicWriteConcurrentWriters tests that only one of several concurrent
+// writers creates a new file, as when agents create the same epic at once
+func TestAtomicWriteConcurrentWriters(t *testing.T) {
+	tmpDir := t.TempDir()
+	const writers = 8
+
+	var wg sync.WaitGroup
+	results := make([]*WriteResult, writers)
+	errs := make([]error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = NewEntityFileWriter().WriteEntityFile(WriteOptions{
+				Content:        []byte(strings.Repeat("x", i+1)),
+				ProjectRoot:    tmpDir,
+				FilePath:       "docs/plan/E01-auth/epic.md",
+				UseAtomicWrite: true,
+				EntityType:     "epic",
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	written := 0
+	for i := 0; i < writers; i++ {
+		if errs[i] == nil && results[i].Written {
+			written++
+		}
+	}
+	assert.Equal(t, 1, written, "exactly one writer should create the file")
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "docs/plan/E01-auth/epic.md"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, content)
+	assert.Equal(t, strings.Repeat("x", len(content)), string(content), "file should hold one writer's content")
+}
+
 // TestEmptyFilePath tests error handling for empty file path
 func TestEmptyFilePath(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -591,3 +630,26 @@ func TestSyncFailure(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []byte("content"), data)
 }
+
+// TestRenameEntityPath tests renaming a directory, a missing source, and an existing target
+func TestRenameEntityPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "plan", "E01-F01-old", "tasks"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "plan", "E01-F01-old", "feature.md"), []byte("x"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "plan", "E01-F02-taken"), 0755))
+
+	writer := NewEntityFileWriter()
+	renamed, err := writer.RenameEntityPath(tmpDir, "plan/E01-F01-old", "plan/E01-F01-new")
+	require.NoError(t, err)
+	assert.True(t, renamed)
+	assert.FileExists(t, filepath.Join(tmpDir, "plan", "E01-F01-new", "feature.md"))
+	assert.NoDirExists(t, filepath.Join(tmpDir, "plan", "E01-F01-old"))
+
+	renamed, err = writer.RenameEntityPath(tmpDir, "plan/E01-F01-old", "plan/E01-F01-other")
+	require.NoError(t, err)
+	assert.False(t, renamed, "a missing source is skipped")
+
+	_, err = writer.RenameEntityPath(tmpDir, filepath.Join(tmpDir, "plan", "E01-F01-new"), "plan/E01-F02-taken")
+	assert.Error(t, err)
+	assert.DirExists(t, filepath.Join(tmpDir, "plan", "E01-F01-new"), "nothing changes when the target exists")
+}
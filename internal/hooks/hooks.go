@@ -0,0 +1,262 @@
+// Package hooks runs the commands and webhooks configured in .shark.yaml when
+// an epic or feature crosses a progress threshold or the last blocked task in
+// an epic is cleared.
+//
+// Milestones are recorded in the database as they are reached, so each hook
+// fires once per crossing no matter how many changes follow. A milestone that
+// is lost again (progress drops below the threshold, or a task in the epic is
+// blocked again) is cleared and can fire a second time.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+)
+
+// Hook events
+const (
+	EventProgress  = "progress"  // An epic or feature reached a progress threshold
+	EventUnblocked = "unblocked" // The last blocked task in an epic was unblocked
+)
+
+// Entity types an event can be about
+const (
+	EntityEpic    = "epic"
+	EntityFeature = "feature"
+)
+
+// blockedMilestone is recorded while an epic has blocked tasks
+const blockedMilestone = "blocked"
+
+// Event describes a milestone that was reached. It is the JSON body sent to
+// webhooks; commands receive the same fields as SHARK_* environment variables.
+type Event struct {
+	Event       string    `json:"event"`
+	EntityType  string    `json:"entity_type"`
+	EntityKey   string    `json:"entity_key"`
+	EntityTitle string    `json:"entity_title"`
+	EpicKey     string    `json:"epic_key"`
+	ProgressPct float64   `json:"progress_pct"`
+	Threshold   int       `json:"threshold,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// Load reads the hooks configured in root's .shark.yaml. A missing file or
+// one without hooks returns none.
+func Load(root string) ([]workspace.Hook, error) {
+	project, err := workspace.LoadProjectFile(root)
+	if err != nil || project == nil {
+		return nil, err
+	}
+	for i, hook := range project.Hooks {
+		if err := Validate(hook); err != nil {
+			return nil, fmt.Errorf("invalid hook %d in %s: %w", i+1, workspace.ProjectFileName, err)
+		}
+	}
+	return project.Hooks, nil
+}
+
+// Validate checks that a hook names a known event and something to run
+func Validate(hook workspace.Hook) error {
+	switch hook.Event {
+	case EventProgress:
+		if hook.Threshold < 1 || hook.Threshold > 100 {
+			return fmt.Errorf("progress hooks need a threshold between 1 and 100, got %d", hook.Threshold)
+		}
+		if hook.Scope != "" && hook.Scope != EntityEpic && hook.Scope != EntityFeature {
+			return fmt.Errorf("invalid scope %q: must be epic or feature", hook.Scope)
+		}
+	case EventUnblocked:
+		if hook.Threshold != 0 || hook.Scope != "" {
+			return fmt.Errorf("unblocked hooks take no threshold or scope")
+		}
+	default:
+		return fmt.Errorf("invalid event %q: must be progress or unblocked", hook.Event)
+	}
+
+	if (hook.Run == "") == (hook.Webhook == "") {
+		return fmt.Errorf("a hook needs exactly one of run or webhook")
+	}
+	if hook.Webhook != "" {
+		u, err := url.Parse(hook.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook URL %q", hook.Webhook)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether hook should fire for event
+func Matches(hook workspace.Hook, event Event) bool {
+	if hook.Event != event.Event {
+		return false
+	}
+	if event.Event == EventProgress {
+		return hook.Threshold == event.Threshold && (hook.Scope == "" || hook.Scope == event.EntityType)
+	}
+	return true
+}
+
+// Check looks at a feature and its epic after a change to one of the
+// feature's tasks, records the milestones they have reached or lost, and
+// returns an event for each milestone newly reached that a hook listens for.
+func Check(ctx context.Context, db *repository.DB, hooks []workspace.Hook, featureID int64) ([]Event, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	featureRepo := repository.NewFeatureRepository(db)
+	epicRepo := repository.NewEpicRepository(db)
+	milestoneRepo := repository.NewHookMilestoneRepository(db)
+	now := time.Now().UTC()
+
+	feature, err := featureRepo.GetByID(ctx, featureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature: %w", err)
+	}
+	epic, err := epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epic: %w", err)
+	}
+
+	featureProgress, err := featureRepo.CalculateProgress(ctx, feature.ID)
+	if err != nil {
+		return nil, err
+	}
+	epicProgress, err := epicProgress(ctx, featureRepo, epic.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, entity := range []struct {
+		entityType string
+		id         int64
+		key, title string
+		progress   float64
+	}{
+		{EntityFeature, feature.ID, feature.Key, feature.Title, featureProgress},
+		{EntityEpic, epic.ID, epic.Key, epic.Title, epicProgress},
+	} {
+		reached, err := milestoneRepo.List(ctx, entity.entityType, entity.id)
+		if err != nil {
+			return nil, err
+		}
+		progress := math.Round(entity.progress*100) / 100
+		for _, threshold := range thresholds(hooks, entity.entityType) {
+			milestone := fmt.Sprintf("%s:%d", EventProgress, threshold)
+			switch {
+			case progress >= float64(threshold) && !reached[milestone]:
+				if err := milestoneRepo.Record(ctx, entity.entityType, entity.id, milestone); err != nil {
+					return nil, err
+				}
+				events = append(events, Event{
+					Event:       EventProgress,
+					EntityType:  entity.entityType,
+					EntityKey:   entity.key,
+					EntityTitle: entity.title,
+					EpicKey:     epic.Key,
+					ProgressPct: progress,
+					Threshold:   threshold,
+					OccurredAt:  now,
+				})
+			case progress < float64(threshold) && reached[milestone]:
+				if err := milestoneRepo.Clear(ctx, entity.entityType, entity.id, milestone); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if !listensFor(hooks, EventUnblocked) {
+		return events, nil
+	}
+	blocked, err := repository.NewTaskRepository(db).ListBlockedTasksByEpic(ctx, epic.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked tasks: %w", err)
+	}
+	reached, err := milestoneRepo.List(ctx, EntityEpic, epic.ID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(blocked) > 0 && !reached[blockedMilestone]:
+		if err := milestoneRepo.Record(ctx, EntityEpic, epic.ID, blockedMilestone); err != nil {
+			return nil, err
+		}
+	case len(blocked) == 0 && reached[blockedMilestone]:
+		if err := milestoneRepo.Clear(ctx, EntityEpic, epic.ID, blockedMilestone); err != nil {
+			return nil, err
+		}
+		events = append(events, Event{
+			Event:       EventUnblocked,
+			EntityType:  EntityEpic,
+			EntityKey:   epic.Key,
+			EntityTitle: epic.Title,
+			EpicKey:     epic.Key,
+			ProgressPct: math.Round(epicProgress*100) / 100,
+			OccurredAt:  now,
+		})
+	}
+
+	return events, nil
+}
+
+// epicProgress averages the live progress of an epic's features. Completed
+// and archived features count as done, as in EpicRepository.CalculateProgress,
+// which reads the stored feature progress instead.
+func epicProgress(ctx context.Context, featureRepo *repository.FeatureRepository, epicID int64) (float64, error) {
+	features, err := featureRepo.ListByEpic(ctx, epicID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list features: %w", err)
+	}
+	if len(features) == 0 {
+		return 0, nil
+	}
+
+	var total float64
+	for _, feature := range features {
+		if feature.Status == models.FeatureStatusCompleted || feature.Status == models.FeatureStatusArchived {
+			total += 100
+			continue
+		}
+		progress, err := featureRepo.CalculateProgress(ctx, feature.ID)
+		if err != nil {
+			return 0, err
+		}
+		total += progress
+	}
+	return total / float64(len(features)), nil
+}
+
+// thresholds returns the distinct progress thresholds hooks listen for on an
+// entity type, in configuration order
+func thresholds(hooks []workspace.Hook, entityType string) []int {
+	var values []int
+	seen := map[int]bool{}
+	for _, hook := range hooks {
+		if hook.Event != EventProgress || (hook.Scope != "" && hook.Scope != entityType) || seen[hook.Threshold] {
+			continue
+		}
+		seen[hook.Threshold] = true
+		values = append(values, hook.Threshold)
+	}
+	return values
+}
+
+// listensFor reports whether any hook listens for event
+func listensFor(hooks []workspace.Hook, event string) bool {
+	for _, hook := range hooks {
+		if hook.Event == event {
+			return true
+		}
+	}
+	return false
+}
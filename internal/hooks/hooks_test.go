@@ -0,0 +1,167 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestDB(t *testing.T) *repository.DB {
+	sqlDB, err := db.InitDB(filepath.Join(t.TempDir(), "shark-tasks.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	_, err = sqlDB.Exec(`
+		INSERT INTO epics (id, key, title, status, priority) VALUES (1, 'E01', 'Payments', 'active', 'high');
+		INSERT INTO features (id, epic_id, key, title, status) VALUES
+			(1, 1, 'E01-F01', 'Checkout', 'active'),
+			(2, 1, 'E01-F02', 'Refunds', 'draft');
+		INSERT INTO tasks (feature_id, key, title, status, priority) VALUES
+			(1, 'T-E01-F01-001', 'Cart', 'todo', 5),
+			(1, 'T-E01-F01-002', 'Pay', 'blocked', 5);
+	`)
+	require.NoError(t, err)
+	return &repository.DB{DB: sqlDB}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	testDB := setupTestDB(t)
+	configured := []workspace.Hook{
+		{Event: EventProgress, Threshold: 50, Run: "true"},
+		{Event: EventProgress, Threshold: 100, Scope: EntityFeature, Run: "true"},
+		{Event: EventUnblocked, Run: "true"},
+	}
+	setStatus := func(key, status string) {
+		_, err := testDB.Exec(`UPDATE tasks SET status = ? WHERE key = ?`, status, key)
+		require.NoError(t, err)
+	}
+	check := func() []Event {
+		events, err := Check(ctx, testDB, configured, 1)
+		require.NoError(t, err)
+		return events
+	}
+	type fired struct {
+		key       string
+		event     string
+		threshold int
+	}
+	summarize := func(events []Event) []fired {
+		result := []fired{}
+		for _, event := range events {
+			result = append(result, fired{event.EntityKey, event.Event, event.Threshold})
+		}
+		return result
+	}
+
+	assert.Empty(t, check(), "nothing reached yet; the blocked task is recorded")
+
+	setStatus("T-E01-F01-001", "completed")
+	assert.Equal(t, []fired{{"E01-F01", EventProgress, 50}}, summarize(check()))
+	assert.Empty(t, check(), "a milestone fires once")
+
+	setStatus("T-E01-F01-002", "completed")
+	events := check()
+	assert.Equal(t, []fired{
+		{"E01-F01", EventProgress, 100},
+		{"E01", EventProgress, 50},
+		{"E01", EventUnblocked, 0},
+	}, summarize(events))
+	assert.Equal(t, "E01", events[0].EpicKey)
+	assert.Equal(t, 100.0, events[0].ProgressPct)
+
+	// Dropping below a threshold clears it, so crossing it again fires again
+	setStatus("T-E01-F01-001", "todo")
+	assert.Empty(t, check())
+	setStatus("T-E01-F01-001", "completed")
+	assert.Equal(t, []fired{{"E01-F01", EventProgress, 100}, {"E01", EventProgress, 50}}, summarize(check()))
+
+	events, err := Check(ctx, testDB, nil, 1)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(workspace.Hook{Event: EventProgress, Threshold: 50, Run: "echo"}))
+	assert.NoError(t, Validate(workspace.Hook{Event: EventUnblocked, Webhook: "https://example.com/hook"}))
+
+	assert.ErrorContains(t, Validate(workspace.Hook{Event: "done", Run: "echo"}), "invalid event")
+	assert.ErrorContains(t, Validate(workspace.Hook{Event: EventProgress, Run: "echo"}), "threshold")
+	assert.ErrorContains(t, Validate(workspace.Hook{Event: EventProgress, Threshold: 50, Scope: "task", Run: "echo"}), "scope")
+	assert.ErrorContains(t, Validate(workspace.Hook{Event: EventUnblocked}), "exactly one")
+	assert.ErrorContains(t, Validate(workspace.Hook{Event: EventUnblocked, Run: "echo", Webhook: "https://example.com"}), "exactly one")
+	assert.ErrorContains(t, Validate(workspace.Hook{Event: EventUnblocked, Webhook: "example.com"}), "webhook URL")
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	configured, err := Load(root)
+	require.NoError(t, err)
+	assert.Empty(t, configured)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, workspace.ProjectFileName), []byte(`
+hooks:
+  - event: progress
+    threshold: 100
+    scope: epic
+    run: ./announce.sh
+  - event: unblocked
+    webhook: https://example.com/hook
+`), 0644))
+	configured, err = Load(root)
+	require.NoError(t, err)
+	assert.Equal(t, []workspace.Hook{
+		{Event: EventProgress, Threshold: 100, Scope: EntityEpic, Run: "./announce.sh"},
+		{Event: EventUnblocked, Webhook: "https://example.com/hook"},
+	}, configured)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, workspace.ProjectFileName), []byte("hooks:\n  - event: progress\n    run: x\n"), 0644))
+	_, err = Load(root)
+	assert.ErrorContains(t, err, "invalid hook 1")
+}
+
+func TestRunnerFire(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	runner := NewRunner([]workspace.Hook{
+		{Event: EventProgress, Threshold: 100, Run: `echo "$SHARK_ENTITY_KEY reached $SHARK_THRESHOLD% ($SHARK_EVENT)"`},
+		{Event: EventProgress, Threshold: 50, Run: "echo wrong threshold"},
+		{Event: EventProgress, Threshold: 100, Scope: EntityEpic, Run: "echo wrong scope"},
+		{Event: EventProgress, Threshold: 100, Webhook: server.URL},
+		{Event: EventProgress, Threshold: 100, Webhook: failing.URL},
+		{Event: EventProgress, Threshold: 100, Run: "exit 3"},
+	}, t.TempDir())
+	var output bytes.Buffer
+	runner.Output = &output
+
+	errs := runner.Fire(context.Background(), []Event{{
+		Event: EventProgress, EntityType: EntityFeature, EntityKey: "E01-F01", EpicKey: "E01", ProgressPct: 100, Threshold: 100,
+	}})
+
+	assert.Equal(t, "E01-F01 reached 100% (progress)\n", output.String())
+	assert.Equal(t, "E01-F01", received.EntityKey)
+	assert.Equal(t, 100, received.Threshold)
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], "500")
+	assert.ErrorContains(t, errs[1], "exit 3")
+}
@@ -0,0 +1,113 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+)
+
+// DefaultTimeout bounds each hook command or webhook call
+const DefaultTimeout = 30 * time.Second
+
+// Runner fires hooks for events
+type Runner struct {
+	Hooks   []workspace.Hook
+	Root    string       // Directory commands run in
+	Output  io.Writer    // Receives command output; default os.Stderr so JSON output stays clean
+	Client  *http.Client // Webhook client; default has DefaultTimeout
+	Timeout time.Duration
+}
+
+// NewRunner creates a Runner for hooks, running commands in root
+func NewRunner(hooks []workspace.Hook, root string) *Runner {
+	return &Runner{
+		Hooks:   hooks,
+		Root:    root,
+		Output:  os.Stderr,
+		Client:  &http.Client{Timeout: DefaultTimeout},
+		Timeout: DefaultTimeout,
+	}
+}
+
+// Fire runs every hook matching each event, in configuration order. A failing
+// hook doesn't stop the others; all failures are returned.
+func (r *Runner) Fire(ctx context.Context, events []Event) []error {
+	var errs []error
+	for _, event := range events {
+		for _, hook := range r.Hooks {
+			if !Matches(hook, event) {
+				continue
+			}
+			var err error
+			if hook.Run != "" {
+				err = r.runCommand(ctx, hook.Run, event)
+			} else {
+				err = r.callWebhook(ctx, hook.Webhook, event)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s hook for %s: %w", event.Event, event.EntityKey, err))
+			}
+		}
+	}
+	return errs
+}
+
+// runCommand runs a hook command through the shell with the event in its
+// environment
+func (r *Runner) runCommand(ctx context.Context, command string, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = r.Root
+	cmd.Stdout = r.Output
+	cmd.Stderr = r.Output
+	cmd.Env = append(os.Environ(),
+		"SHARK_EVENT="+event.Event,
+		"SHARK_ENTITY_TYPE="+event.EntityType,
+		"SHARK_ENTITY_KEY="+event.EntityKey,
+		"SHARK_ENTITY_TITLE="+event.EntityTitle,
+		"SHARK_EPIC_KEY="+event.EpicKey,
+		"SHARK_PROGRESS="+strconv.FormatFloat(event.ProgressPct, 'f', -1, 64),
+		"SHARK_THRESHOLD="+strconv.Itoa(event.Threshold),
+	)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command %q failed: %w", command, err)
+	}
+	return nil
+}
+
+// callWebhook POSTs the event as JSON. Any non-2xx response is an error.
+func (r *Runner) callWebhook(ctx context.Context, webhook string, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s failed: %w", webhook, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s", webhook, resp.Status)
+	}
+	return nil
+}
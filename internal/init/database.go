@@ -2,7 +2,6 @@ package init
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,6 +15,11 @@ import (
 // Returns true if database was created, false if already existed
 // Returns error if database exists with data (user must manually delete it)
 func (i *Initializer) createDatabase(ctx context.Context, dbPath string) (bool, error) {
+	// New databases are created encrypted when SHARK_DB_KEY is set
+	if err := db.ConfigureEncryption(dbPath); err != nil {
+		return false, err
+	}
+
 	// Check if database already exists
 	if _, err := os.Stat(dbPath); err == nil {
 		// Database file exists, check if it contains any data
@@ -55,7 +59,7 @@ func (i *Initializer) createDatabase(ctx context.Context, dbPath string) (bool,
 // databaseHasData checks if database contains any data
 func (i *Initializer) databaseHasData(dbPath string) (bool, error) {
 	// Open database without initializing schema
-	database, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
+	database, err := db.OpenSQLite(dbPath + "?_foreign_keys=on")
 	if err != nil {
 		return false, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -59,3 +59,13 @@ func (i *Initializer) copyTemplates(force bool) (int, error) {
 
 	return count, err
 }
+
+// DefaultTemplate returns the built-in template with the given file name (e.g.
+// "idea.md"), for projects initialized before the template existed
+func DefaultTemplate(name string) ([]byte, error) {
+	data, err := embeddedTemplates.ReadFile("shark-templates/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("no built-in template %s: %w", name, err)
+	}
+	return data, nil
+}
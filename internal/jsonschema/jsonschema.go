@@ -0,0 +1,169 @@
+// Package jsonschema generates JSON Schema documents from the Go types that
+// commands marshal for --json output, so the schemas cannot drift from the
+// payloads they describe.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Version              int                `json:"x-schema-version,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"` // string, or []string when nullable
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// For returns the schema of the JSON encoding of v's type
+func For(v interface{}) *Schema {
+	return forType(reflect.TypeOf(v), map[reflect.Type]bool{})
+}
+
+// forType builds the schema for t. seen guards against recursive types,
+// which are described as any value.
+func forType(t reflect.Type, seen map[reflect.Type]bool) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface &&
+		(t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType)) {
+		// Custom encodings can produce anything
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(forType(t.Elem(), seen))
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nullable(&Schema{Type: "string", Format: "byte"})
+		}
+		return nullable(&Schema{Type: "array", Items: forType(t.Elem(), seen)})
+	case reflect.Array:
+		return &Schema{Type: "array", Items: forType(t.Elem(), seen)}
+	case reflect.Map:
+		return nullable(&Schema{Type: "object", AdditionalProperties: forType(t.Elem(), seen)})
+	case reflect.Struct:
+		if seen[t] {
+			return &Schema{}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addFields(schema, t, seen)
+		return schema
+	}
+	// Channels, funcs, and complex numbers cannot be marshaled
+	return &Schema{}
+}
+
+// addFields adds the JSON properties of struct type t to schema. Embedded
+// structs without a JSON name are flattened, as encoding/json does; fields of
+// the outer struct win over promoted ones.
+func addFields(schema *Schema, t reflect.Type, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				promoted := &Schema{Properties: map[string]*Schema{}}
+				addFields(promoted, embedded, seen)
+				for _, key := range promoted.Required {
+					if _, ok := schema.Properties[key]; !ok {
+						schema.Required = append(schema.Required, key)
+					}
+				}
+				for key, prop := range promoted.Properties {
+					if _, ok := schema.Properties[key]; !ok {
+						schema.Properties[key] = prop
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if _, ok := schema.Properties[name]; ok {
+			// Replace a promoted field of the same name
+			schema.Required = remove(schema.Required, name)
+		}
+		schema.Properties[name] = forType(field.Type, seen)
+		if !hasOption(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// nullable allows null in addition to the schema's type
+func nullable(schema *Schema) *Schema {
+	if typ, ok := schema.Type.(string); ok {
+		schema.Type = []string{typ, "null"}
+	}
+	return schema
+}
+
+func hasOption(opts, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, value string) []string {
+	out := values[:0]
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
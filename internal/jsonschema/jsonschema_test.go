@@ -0,0 +1,79 @@
+package jsonschema
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type testNode struct {
+	Name     string      `json:"name"`
+	Children []*testNode `json:"children"`
+}
+
+type testPayload struct {
+	*testBase
+	Title     string          `json:"title,omitempty"`
+	Score     float64         `json:"score"`
+	Done      bool            `json:"done"`
+	Note      *string         `json:"note,omitempty"`
+	Tags      []string        `json:"tags"`
+	Counts    map[string]int  `json:"counts"`
+	CreatedAt time.Time       `json:"created_at"`
+	StartedAt sql.NullTime    `json:"started_at,omitempty"`
+	Raw       json.RawMessage `json:"raw,omitempty"`
+	Extra     interface{}     `json:"extra"`
+	Node      testNode        `json:"node"`
+	Hidden    string          `json:"-"`
+	Untagged  string
+	private   string            //nolint:unused
+	Nested    map[string][]bool `json:"nested,omitempty"`
+}
+
+func TestFor(t *testing.T) {
+	schema := For(testPayload{})
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"id", "score", "done", "tags", "counts", "created_at", "extra", "node", "Untagged"}, schema.Required)
+
+	props := schema.Properties
+	assert.Equal(t, "integer", props["id"].Type)
+	assert.Equal(t, "string", props["title"].Type, "outer field replaces the promoted one")
+	assert.Equal(t, "number", props["score"].Type)
+	assert.Equal(t, "boolean", props["done"].Type)
+	assert.Equal(t, []string{"string", "null"}, props["note"].Type)
+	assert.Equal(t, []string{"array", "null"}, props["tags"].Type)
+	assert.Equal(t, "string", props["tags"].Items.Type)
+	assert.Equal(t, "integer", props["counts"].AdditionalProperties.Type)
+	assert.Equal(t, "date-time", props["created_at"].Format)
+	assert.Equal(t, "boolean", props["started_at"].Properties["Valid"].Type)
+	assert.Equal(t, &Schema{}, props["raw"], "custom marshalers accept any value")
+	assert.Equal(t, &Schema{}, props["extra"])
+	assert.Equal(t, &Schema{}, props["node"].Properties["children"].Items, "recursive types accept any value")
+	assert.Equal(t, []string{"array", "null"}, props["nested"].AdditionalProperties.Type)
+	assert.NotContains(t, props, "Hidden")
+	assert.NotContains(t, props, "private")
+	assert.Contains(t, props, "Untagged")
+}
+
+func TestFor_Marshals(t *testing.T) {
+	data, err := json.Marshal(For([]*testBase{}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": ["array", "null"],
+		"items": {
+			"type": ["object", "null"],
+			"properties": {"id": {"type": "integer"}, "title": {"type": "string"}},
+			"required": ["id", "title"]
+		}
+	}`, string(data))
+}
@@ -14,6 +14,9 @@ var (
 	// shortTaskKeyPattern matches task keys without the T- prefix (E##-F##-###)
 	// This enables users to use "E01-F02-001" instead of "T-E01-F02-001"
 	shortTaskKeyPattern = regexp.MustCompile(`^E\d{2}-F\d{2}-\d{3}$`)
+	// backlogTaskKeyPattern matches standalone task keys (T-BKL-###), with or
+	// without the T- prefix and with an optional slug
+	backlogTaskKeyPattern = regexp.MustCompile(`^(T-)?BKL-\d{3}(-|$)`)
 )
 
 // Normalize converts a key to canonical uppercase format.
@@ -75,8 +78,12 @@ func ParseFeatureKey(s string) (epic, feature string, err error) {
 	return epic, feature, nil
 }
 
-// IsTaskKey validates if a string is a valid task key format (T-E##-F##-###)
+// IsTaskKey validates if a string is a valid task key format (T-E##-F##-###,
+// or T-BKL-### for standalone tasks)
 func IsTaskKey(s string) bool {
+	if strings.HasPrefix(s, "T-BKL-") {
+		return backlogTaskKeyPattern.MatchString(s)
+	}
 	// Task key format: T-E##-F##-###
 	if len(s) < 13 {
 		return false
@@ -119,7 +126,8 @@ func IsShortTaskKey(s string) bool {
 }
 
 // NormalizeTaskKey converts a task key to canonical format with T- prefix.
-// Accepts both full format (T-E##-F##-###) and short format (E##-F##-###).
+// Accepts both full format (T-E##-F##-###) and short format (E##-F##-###),
+// and the same forms of standalone task keys (T-BKL-###, BKL-###).
 // This enables users to type shorter commands while maintaining backward compatibility.
 //
 // Examples:
@@ -128,6 +136,7 @@ func IsShortTaskKey(s string) bool {
 //	e01-f02-001 → T-E01-F02-001 (add prefix, uppercase)
 //	E01-F02-001 → T-E01-F02-001 (add prefix)
 //	e01-f02-001-task-name → T-E01-F02-001-TASK-NAME (slugged, add prefix)
+//	bkl-004 → T-BKL-004 (standalone, add prefix)
 func NormalizeTaskKey(input string) (string, error) {
 	if input == "" {
 		return "", fmt.Errorf("empty task key")
@@ -143,8 +152,8 @@ func NormalizeTaskKey(input string) (string, error) {
 		return "", fmt.Errorf("invalid task key format: %q", input)
 	}
 
-	// Check if it matches short format (E##-F##-###)
-	if IsShortTaskKey(normalized) {
+	// Check if it matches short format (E##-F##-### or BKL-###)
+	if IsShortTaskKey(normalized) || backlogTaskKeyPattern.MatchString(normalized) {
 		return "T-" + normalized, nil
 	}
 
@@ -141,6 +141,9 @@ func TestIsTaskKey(t *testing.T) {
 	}{
 		{"valid traditional", "T-E04-F01-001", true},
 		{"valid with slug", "T-E04-F01-001-IMPLEMENT-AUTH", true},
+		{"valid standalone", "T-BKL-007", true},
+		{"valid standalone with slug", "T-BKL-007-FIX-TYPO", true},
+		{"invalid standalone number", "T-BKL-07", false},
 		{"invalid no T prefix", "E04-F01-001", false},
 		{"invalid wrong format", "T-E4-F01-001", false},
 		{"invalid too short", "T-E04-F01", false},
@@ -192,6 +195,8 @@ func TestNormalizeTaskKey(t *testing.T) {
 		{"short format", "E01-F02-001", "T-E01-F02-001", false},
 		{"lowercase short", "e01-f02-001", "T-E01-F02-001", false},
 		{"slugged short", "E01-F02-001-task-name", "T-E01-F02-001-TASK-NAME", false},
+		{"standalone", "T-BKL-003", "T-BKL-003", false},
+		{"standalone short", "bkl-003", "T-BKL-003", false},
 		{"invalid format", "INVALID", "", true},
 		{"empty", "", "", true},
 	}
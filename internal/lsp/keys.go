@@ -0,0 +1,59 @@
+package lsp
+
+import (
+	"regexp"
+	"unicode/utf16"
+)
+
+// Entity types returned by the server
+const (
+	EntityEpic    = "epic"
+	EntityFeature = "feature"
+	EntityTask    = "task"
+)
+
+// entityKeyPattern matches task, feature, and epic keys; longer forms are listed first
+// so T-E01-F02-003 is not matched as the epic E01
+var entityKeyPattern = regexp.MustCompile(`\bT-E\d{2}-F\d{2}-\d{3}\b|\bE\d{2}-F\d{2}\b|\bE\d{2}\b`)
+
+// KeyMatch is an entity key found in a line of text
+type KeyMatch struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Start int    `json:"start"` // UTF-16 offset of the first character
+	End   int    `json:"end"`   // UTF-16 offset after the last character
+}
+
+// KeyAt returns the entity key under the cursor, or nil if there is none.
+// character is a UTF-16 code unit offset, as used by LSP positions; a cursor
+// just after the last character of a key still counts as on the key.
+func KeyAt(line string, character int) *KeyMatch {
+	for _, loc := range entityKeyPattern.FindAllStringIndex(line, -1) {
+		start := utf16Len(line[:loc[0]])
+		end := start + utf16Len(line[loc[0]:loc[1]])
+		if character < start || character > end {
+			continue
+		}
+
+		key := line[loc[0]:loc[1]]
+		return &KeyMatch{Key: key, Type: keyType(key), Start: start, End: end}
+	}
+	return nil
+}
+
+// keyType classifies a key matched by entityKeyPattern
+func keyType(key string) string {
+	switch {
+	case key[0] == 'T':
+		return EntityTask
+	case len(key) > 3:
+		return EntityFeature
+	default:
+		return EntityEpic
+	}
+}
+
+// utf16Len returns the length of s in UTF-16 code units
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
@@ -0,0 +1,48 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyAt(t *testing.T) {
+	line := "Depends on T-E01-F02-003 and E04-F01 (see E07)"
+
+	tests := []struct {
+		name      string
+		character int
+		wantKey   string
+		wantType  string
+	}{
+		{"task key start", 11, "T-E01-F02-003", EntityTask},
+		{"inside task key on epic part", 14, "T-E01-F02-003", EntityTask},
+		{"cursor just after task key", 24, "T-E01-F02-003", EntityTask},
+		{"feature key", 31, "E04-F01", EntityFeature},
+		{"epic key", 43, "E07", EntityEpic},
+		{"no key", 3, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := KeyAt(line, tt.character)
+			if tt.wantKey == "" {
+				assert.Nil(t, match)
+				return
+			}
+			require.NotNil(t, match)
+			assert.Equal(t, tt.wantKey, match.Key)
+			assert.Equal(t, tt.wantType, match.Type)
+		})
+	}
+}
+
+func TestKeyAt_UTF16Offsets(t *testing.T) {
+	// The emoji takes two UTF-16 code units, shifting the key start to 3
+	match := KeyAt("🦈 E05", 3)
+	require.NotNil(t, match)
+	assert.Equal(t, "E05", match.Key)
+	assert.Equal(t, 3, match.Start)
+	assert.Equal(t, 6, match.End)
+}
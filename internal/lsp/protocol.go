@@ -0,0 +1,106 @@
+// Package lsp implements a small language-server-style JSON-RPC 2.0 server over
+// stdio so editor plugins can show shark task status inline in markdown files.
+//
+// Messages use the Language Server Protocol framing (a Content-Length header
+// followed by a JSON body), so existing LSP client libraries can talk to it.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Request is an incoming JSON-RPC request or notification (notifications have no ID)
+type Request struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+// IsNotification reports whether the request expects no response
+func (r *Request) IsNotification() bool {
+	return r.ID == nil
+}
+
+// Response is an outgoing JSON-RPC response
+type Response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+// MarshalJSON omits result when the response carries an error, as required by JSON-RPC
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Error != nil {
+		return json.Marshal(struct {
+			JSONRPC string           `json:"jsonrpc"`
+			ID      *json.RawMessage `json:"id"`
+			Error   *ResponseError   `json:"error"`
+		}{r.JSONRPC, r.ID, r.Error})
+	}
+	type plain Response
+	return json.Marshal(plain(r))
+}
+
+// ResponseError is a JSON-RPC error object
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// ReadMessage reads one Content-Length framed message body
+func ReadMessage(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	lengthStr := header.Get("Content-Length")
+	if lengthStr == "" {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(lengthStr))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header %q", lengthStr)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+	return body, nil
+}
+
+// WriteMessage writes a value as a Content-Length framed JSON message
+func WriteMessage(w io.Writer, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,470 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CommandStartTask is the workspace command offered as a code action on task keys
+const CommandStartTask = "shark.startTask"
+
+// EntityInfo describes an epic, feature, or task referenced in a document
+type EntityInfo struct {
+	Type        string   `json:"type"`
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	ProgressPct *float64 `json:"progress_pct,omitempty"` // Epics and features only
+	FilePath    string   `json:"file_path,omitempty"`    // Absolute path of the entity's markdown file
+}
+
+// Backend answers queries and executes commands on behalf of the server
+type Backend interface {
+	// Lookup returns the entity with the given key, or an error if it does not exist
+	Lookup(ctx context.Context, entityType, key string) (*EntityInfo, error)
+	// StartTask moves a task to in_progress and returns its updated state
+	StartTask(ctx context.Context, key string) (*EntityInfo, error)
+	// CanStart reports whether a task in the given status can be started
+	CanStart(status string) bool
+}
+
+// Server is a JSON-RPC server exposing shark queries to editors.
+//
+// Standard LSP methods: initialize, shutdown, exit, textDocument/didOpen,
+// textDocument/didChange, textDocument/didClose, textDocument/hover,
+// textDocument/codeAction, workspace/executeCommand.
+//
+// Shark methods: shark/resolveKey, shark/status, shark/filePath.
+type Server struct {
+	backend Backend
+
+	mu        sync.Mutex
+	documents map[string]string // Open document text by URI
+	shutdown  bool
+}
+
+// NewServer creates a new Server
+func NewServer(backend Backend) *Server {
+	return &Server{
+		backend:   backend,
+		documents: make(map[string]string),
+	}
+}
+
+// errExit is returned by a handler to stop serving
+var errExit = errors.New("exit")
+
+// Serve reads requests from in and writes responses to out until the client
+// sends "exit" or closes the input stream
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	for {
+		body, err := ReadMessage(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		resp, err := s.handleMessage(ctx, body)
+		if errors.Is(err, errExit) {
+			return nil
+		}
+		if resp != nil {
+			if err := WriteMessage(out, resp); err != nil {
+				return fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+	}
+}
+
+// handleMessage decodes and dispatches one message, returning the response to send (nil for notifications)
+func (s *Server) handleMessage(ctx context.Context, body []byte) (*Response, error) {
+	var req Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return &Response{JSONRPC: "2.0", Error: &ResponseError{Code: CodeParseError, Message: err.Error()}}, nil
+	}
+	if req.Method == "" {
+		return &Response{JSONRPC: "2.0", ID: req.ID, Error: &ResponseError{Code: CodeInvalidRequest, Message: "missing method"}}, nil
+	}
+
+	result, err := s.dispatch(ctx, &req)
+	if errors.Is(err, errExit) {
+		return nil, err
+	}
+	if req.IsNotification() {
+		return nil, nil
+	}
+
+	resp := &Response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rpcErr *ResponseError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &ResponseError{Code: CodeInternalError, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = rpcErr
+	}
+	return resp, nil
+}
+
+// dispatch routes a request to its handler
+func (s *Server) dispatch(ctx context.Context, req *Request) (interface{}, error) {
+	s.mu.Lock()
+	shutdown := s.shutdown
+	s.mu.Unlock()
+	if shutdown && req.Method != "exit" {
+		return nil, &ResponseError{Code: CodeInvalidRequest, Message: "server is shutting down"}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		s.mu.Unlock()
+		return nil, nil
+	case "exit":
+		return nil, errExit
+	case "textDocument/didOpen":
+		return nil, s.didOpen(req.Params)
+	case "textDocument/didChange":
+		return nil, s.didChange(req.Params)
+	case "textDocument/didClose":
+		return nil, s.didClose(req.Params)
+	case "textDocument/hover":
+		return s.hover(ctx, req.Params)
+	case "textDocument/codeAction":
+		return s.codeAction(ctx, req.Params)
+	case "workspace/executeCommand":
+		return s.executeCommand(ctx, req.Params)
+	case "shark/resolveKey":
+		return s.resolveKey(ctx, req.Params)
+	case "shark/status":
+		return s.status(ctx, req.Params)
+	case "shark/filePath":
+		return s.filePath(ctx, req.Params)
+	default:
+		return nil, &ResponseError{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+// initialize returns the server capabilities
+func (s *Server) initialize() interface{} {
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync":   1, // Full document sync
+			"hoverProvider":      true,
+			"codeActionProvider": true,
+			"executeCommandProvider": map[string]interface{}{
+				"commands": []string{CommandStartTask},
+			},
+		},
+		"serverInfo": map[string]string{"name": "shark"},
+	}
+}
+
+// Protocol parameter types (subset of the LSP specification)
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+type keyParams struct {
+	Key string `json:"key"`
+}
+
+// decodeParams unmarshals request params, reporting failures as invalid params
+func decodeParams(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 {
+		return &ResponseError{Code: CodeInvalidParams, Message: "missing params"}
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &ResponseError{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) didOpen(raw json.RawMessage) error {
+	var params struct {
+		TextDocument struct {
+			URI  string `json:"uri"`
+			Text string `json:"text"`
+		} `json:"textDocument"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.documents[params.TextDocument.URI] = params.TextDocument.Text
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Server) didChange(raw json.RawMessage) error {
+	var params struct {
+		TextDocument   textDocumentIdentifier `json:"textDocument"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return err
+	}
+	// Full sync: the last change holds the complete document
+	if n := len(params.ContentChanges); n > 0 {
+		s.mu.Lock()
+		s.documents[params.TextDocument.URI] = params.ContentChanges[n-1].Text
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Server) didClose(raw json.RawMessage) error {
+	var params struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.documents, params.TextDocument.URI)
+	s.mu.Unlock()
+	return nil
+}
+
+// lineAt returns a line of a document, reading it from disk if the editor has not opened it
+func (s *Server) lineAt(uri string, line int) (string, error) {
+	s.mu.Lock()
+	text, ok := s.documents[uri]
+	s.mu.Unlock()
+
+	if !ok {
+		path, err := uriToPath(uri)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read document: %w", err)
+		}
+		text = string(data)
+	}
+
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return "", nil
+	}
+	return strings.TrimSuffix(lines[line], "\r"), nil
+}
+
+// uriToPath converts a file:// URI to a filesystem path
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported document URI: %s", uri)
+	}
+	return u.Path, nil
+}
+
+// entityAt looks up the entity whose key is under the cursor; returns nil if there is none
+func (s *Server) entityAt(ctx context.Context, params textDocumentPositionParams) (*EntityInfo, *KeyMatch, error) {
+	line, err := s.lineAt(params.TextDocument.URI, params.Position.Line)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	match := KeyAt(line, params.Position.Character)
+	if match == nil {
+		return nil, nil, nil
+	}
+
+	info, err := s.backend.Lookup(ctx, match.Type, match.Key)
+	if err != nil {
+		// Keys that look valid but do not exist are not errors for the editor
+		return nil, match, nil
+	}
+	return info, match, nil
+}
+
+// hover shows the status of the entity under the cursor
+func (s *Server) hover(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var params textDocumentPositionParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	info, match, err := s.entityAt(ctx, params)
+	if err != nil || info == nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"contents": map[string]string{
+			"kind":  "markdown",
+			"value": FormatHover(info),
+		},
+		"range": lspRange{
+			Start: position{Line: params.Position.Line, Character: match.Start},
+			End:   position{Line: params.Position.Line, Character: match.End},
+		},
+	}, nil
+}
+
+// FormatHover renders entity details as markdown for hover popups
+func FormatHover(info *EntityInfo) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s** %s\n\n", info.Key, info.Title))
+	sb.WriteString(fmt.Sprintf("%s · status: `%s`", info.Type, info.Status))
+	if info.ProgressPct != nil {
+		sb.WriteString(fmt.Sprintf(" · progress: %.0f%%", *info.ProgressPct))
+	}
+	return sb.String()
+}
+
+// codeAction offers "start task" on task keys that can be started
+func (s *Server) codeAction(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var params struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+		Range        lspRange               `json:"range"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	info, _, err := s.entityAt(ctx, textDocumentPositionParams{TextDocument: params.TextDocument, Position: params.Range.Start})
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []interface{}{}
+	if info != nil && info.Type == EntityTask && s.backend.CanStart(info.Status) {
+		title := fmt.Sprintf("Start task %s", info.Key)
+		actions = append(actions, map[string]interface{}{
+			"title": title,
+			"kind":  "quickfix",
+			"command": map[string]interface{}{
+				"title":     title,
+				"command":   CommandStartTask,
+				"arguments": []string{info.Key},
+			},
+		})
+	}
+	return actions, nil
+}
+
+// executeCommand runs a command offered by a code action
+func (s *Server) executeCommand(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var params struct {
+		Command   string   `json:"command"`
+		Arguments []string `json:"arguments"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	switch params.Command {
+	case CommandStartTask:
+		if len(params.Arguments) != 1 {
+			return nil, &ResponseError{Code: CodeInvalidParams, Message: "shark.startTask expects one task key"}
+		}
+		return s.backend.StartTask(ctx, params.Arguments[0])
+	default:
+		return nil, &ResponseError{Code: CodeInvalidParams, Message: fmt.Sprintf("unknown command: %s", params.Command)}
+	}
+}
+
+// resolveKey returns the key under the cursor and its entity (nil entity if not found).
+// Accepts either LSP position params or {"text": "...", "character": N} for a single line.
+func (s *Server) resolveKey(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var params struct {
+		textDocumentPositionParams
+		Text      *string `json:"text"`
+		Character int     `json:"character"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	var match *KeyMatch
+	var info *EntityInfo
+	if params.Text != nil {
+		match = KeyAt(*params.Text, params.Character)
+		if match != nil {
+			info, _ = s.backend.Lookup(ctx, match.Type, match.Key)
+		}
+	} else {
+		var err error
+		info, match, err = s.entityAt(ctx, params.textDocumentPositionParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if match == nil {
+		return nil, nil
+	}
+	return map[string]interface{}{
+		"match":  match,
+		"entity": info,
+	}, nil
+}
+
+// lookupKey resolves a bare key from params
+func (s *Server) lookupKey(ctx context.Context, raw json.RawMessage) (*EntityInfo, error) {
+	var params keyParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	match := KeyAt(params.Key, 0)
+	if match == nil || match.Key != params.Key {
+		return nil, &ResponseError{Code: CodeInvalidParams, Message: fmt.Sprintf("not an epic, feature, or task key: %q", params.Key)}
+	}
+	return s.backend.Lookup(ctx, match.Type, match.Key)
+}
+
+// status returns the entity for a key
+func (s *Server) status(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	return s.lookupKey(ctx, raw)
+}
+
+// filePath returns the markdown file path for a key, for "open file" actions
+func (s *Server) filePath(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	info, err := s.lookupKey(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"key":  info.Key,
+		"path": info.FilePath,
+		"uri":  (&url.URL{Scheme: "file", Path: info.FilePath}).String(),
+	}, nil
+}
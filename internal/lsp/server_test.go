@@ -0,0 +1,141 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend serves entities from a map
+type fakeBackend struct {
+	entities map[string]*EntityInfo
+	started  []string
+}
+
+func (f *fakeBackend) Lookup(ctx context.Context, entityType, key string) (*EntityInfo, error) {
+	if info, ok := f.entities[key]; ok && info.Type == entityType {
+		return info, nil
+	}
+	return nil, fmt.Errorf("%s %s not found", entityType, key)
+}
+
+func (f *fakeBackend) StartTask(ctx context.Context, key string) (*EntityInfo, error) {
+	f.started = append(f.started, key)
+	info := *f.entities[key]
+	info.Status = "in_progress"
+	return &info, nil
+}
+
+func (f *fakeBackend) CanStart(status string) bool {
+	return status == "todo"
+}
+
+// runSession sends requests through Serve and returns the decoded responses
+func runSession(t *testing.T, backend Backend, requests ...map[string]interface{}) []map[string]interface{} {
+	t.Helper()
+
+	var in bytes.Buffer
+	for _, req := range requests {
+		req["jsonrpc"] = "2.0"
+		require.NoError(t, WriteMessage(&in, req))
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, NewServer(backend).Serve(context.Background(), &in, &out))
+
+	var responses []map[string]interface{}
+	reader := bufio.NewReader(&out)
+	for {
+		body, err := ReadMessage(reader)
+		if err != nil {
+			break
+		}
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{entities: map[string]*EntityInfo{
+		"T-E01-F01-001": {Type: EntityTask, Key: "T-E01-F01-001", Title: "Write parser", Status: "todo", FilePath: "/project/docs/plan/T-E01-F01-001.md"},
+		"E01":           {Type: EntityEpic, Key: "E01", Title: "Platform", Status: "active"},
+	}}
+}
+
+func TestServer_HoverAndCodeAction(t *testing.T) {
+	backend := newFakeBackend()
+	doc := "# Notes\n\nNext up: T-E01-F01-001 then E01 wrap-up\n"
+
+	responses := runSession(t, backend,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		map[string]interface{}{"method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": "file:///project/notes.md", "text": doc},
+		}},
+		map[string]interface{}{"id": 2, "method": "textDocument/hover", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": "file:///project/notes.md"},
+			"position":     map[string]interface{}{"line": 2, "character": 12},
+		}},
+		map[string]interface{}{"id": 3, "method": "textDocument/codeAction", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": "file:///project/notes.md"},
+			"range": map[string]interface{}{
+				"start": map[string]interface{}{"line": 2, "character": 12},
+				"end":   map[string]interface{}{"line": 2, "character": 12},
+			},
+		}},
+		map[string]interface{}{"id": 4, "method": "workspace/executeCommand", "params": map[string]interface{}{
+			"command": CommandStartTask, "arguments": []string{"T-E01-F01-001"},
+		}},
+		map[string]interface{}{"id": 5, "method": "shutdown"},
+		map[string]interface{}{"method": "exit"},
+	)
+
+	require.Len(t, responses, 5, "notifications must not produce responses")
+
+	caps := responses[0]["result"].(map[string]interface{})["capabilities"].(map[string]interface{})
+	assert.Equal(t, true, caps["hoverProvider"])
+
+	hover := responses[1]["result"].(map[string]interface{})
+	contents := hover["contents"].(map[string]interface{})["value"].(string)
+	assert.Contains(t, contents, "**T-E01-F01-001** Write parser")
+	assert.Contains(t, contents, "status: `todo`")
+
+	actions := responses[2]["result"].([]interface{})
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Start task T-E01-F01-001", actions[0].(map[string]interface{})["title"])
+
+	started := responses[3]["result"].(map[string]interface{})
+	assert.Equal(t, "in_progress", started["status"])
+	assert.Equal(t, []string{"T-E01-F01-001"}, backend.started)
+}
+
+func TestServer_SharkMethods(t *testing.T) {
+	responses := runSession(t, newFakeBackend(),
+		map[string]interface{}{"id": 1, "method": "shark/resolveKey", "params": map[string]interface{}{"text": "see E01", "character": 5}},
+		map[string]interface{}{"id": 2, "method": "shark/status", "params": map[string]interface{}{"key": "E01"}},
+		map[string]interface{}{"id": 3, "method": "shark/filePath", "params": map[string]interface{}{"key": "T-E01-F01-001"}},
+		map[string]interface{}{"id": 4, "method": "shark/status", "params": map[string]interface{}{"key": "not-a-key"}},
+		map[string]interface{}{"id": 5, "method": "bogus/method"},
+	)
+	require.Len(t, responses, 5)
+
+	resolved := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, "E01", resolved["match"].(map[string]interface{})["key"])
+	assert.Equal(t, "Platform", resolved["entity"].(map[string]interface{})["title"])
+
+	assert.Equal(t, "active", responses[1]["result"].(map[string]interface{})["status"])
+
+	path := responses[2]["result"].(map[string]interface{})
+	assert.Equal(t, "file:///project/docs/plan/T-E01-F01-001.md", path["uri"])
+
+	assert.Equal(t, float64(CodeInvalidParams), responses[3]["error"].(map[string]interface{})["code"])
+	assert.NotContains(t, responses[3], "result")
+	assert.Equal(t, float64(CodeMethodNotFound), responses[4]["error"].(map[string]interface{})["code"])
+}
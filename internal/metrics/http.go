@@ -0,0 +1,142 @@
+// Package metrics exports project health as Prometheus metrics, so existing
+// Grafana setups can chart it without a custom exporter.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+)
+
+// ContentType is the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// TotalsSource counts tasks, completions, and rejections; *repository.StatsRepository implements it
+type TotalsSource interface {
+	Totals(ctx context.Context, doneStatuses []string) (*repository.ProjectTotals, error)
+}
+
+// NewHTTPHandler serves GET /metrics in the Prometheus text format:
+//
+//	shark_tasks{status}                  gauge    tasks outside the trash, by status
+//	shark_tasks_blocked                  gauge    blocked tasks
+//	shark_progress_percent               gauge    overall progress
+//	shark_epic_progress_percent{epic}    gauge    progress of each epic that isn't archived
+//	shark_task_completions_total         counter  moves into a done status
+//	shark_task_rejections_total          counter  status changes with a rejection reason
+//
+// doneStatuses returns the workflow's done-phase statuses; it is called on
+// each scrape so a reloaded workflow applies. defaults returns the base
+// dashboard request (e.g. health rules); nil for none.
+func NewHTTPHandler(dashboards status.DashboardProvider, totals TotalsSource, doneStatuses func() []string, defaults func() *status.StatusRequest) http.Handler {
+	h := &httpHandler{dashboards: dashboards, totals: totals, doneStatuses: doneStatuses, defaults: defaults}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", h.serveMetrics)
+	return mux
+}
+
+type httpHandler struct {
+	dashboards   status.DashboardProvider
+	totals       TotalsSource
+	doneStatuses func() []string
+	defaults     func() *status.StatusRequest
+}
+
+// serveMetrics handles GET /metrics
+func (h *httpHandler) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	req := &status.StatusRequest{}
+	if h.defaults != nil {
+		if base := h.defaults(); base != nil {
+			req = base
+		}
+	}
+	dashboard, err := h.dashboards.GetDashboard(r.Context(), req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get dashboard: %v", err), http.StatusInternalServerError)
+		return
+	}
+	var doneStatuses []string
+	if h.doneStatuses != nil {
+		doneStatuses = h.doneStatuses()
+	}
+	totals, err := h.totals.Totals(r.Context(), doneStatuses)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to count tasks: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	_ = Write(w, dashboard, totals)
+}
+
+// Write writes the metrics for a dashboard and totals in the Prometheus text format
+func Write(w io.Writer, dashboard *status.StatusDashboard, totals *repository.ProjectTotals) error {
+	var sb strings.Builder
+
+	statuses := make([]string, 0, len(totals.TasksByStatus))
+	for taskStatus := range totals.TasksByStatus {
+		statuses = append(statuses, taskStatus)
+	}
+	sort.Strings(statuses)
+	writeHeader(&sb, "shark_tasks", "gauge", "Tasks outside the trash, by status.")
+	for _, taskStatus := range statuses {
+		writeSample(&sb, "shark_tasks", [][2]string{{"status", taskStatus}}, float64(totals.TasksByStatus[taskStatus]))
+	}
+
+	blocked, progress := 0, 0.0
+	if dashboard.Summary != nil {
+		blocked, progress = dashboard.Summary.BlockedCount, dashboard.Summary.OverallProgress
+	}
+	writeHeader(&sb, "shark_tasks_blocked", "gauge", "Blocked tasks.")
+	writeSample(&sb, "shark_tasks_blocked", nil, float64(blocked))
+	writeHeader(&sb, "shark_progress_percent", "gauge", "Overall progress of the project, 0-100.")
+	writeSample(&sb, "shark_progress_percent", nil, progress)
+
+	writeHeader(&sb, "shark_epic_progress_percent", "gauge", "Progress of each epic, 0-100.")
+	for _, epic := range dashboard.Epics {
+		writeSample(&sb, "shark_epic_progress_percent", [][2]string{{"epic", epic.Key}}, epic.ProgressPercent)
+	}
+
+	writeHeader(&sb, "shark_task_completions_total", "counter", "Task moves into a done status.")
+	writeSample(&sb, "shark_task_completions_total", nil, float64(totals.Completions))
+	writeHeader(&sb, "shark_task_rejections_total", "counter", "Task status changes with a rejection reason.")
+	writeSample(&sb, "shark_task_rejections_total", nil, float64(totals.Rejections))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric
+func writeHeader(sb *strings.Builder, name, metricType, help string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// writeSample writes one sample line with its labels
+func writeSample(sb *strings.Builder, name string, labels [][2]string, value float64) {
+	sb.WriteString(name)
+	if len(labels) > 0 {
+		sb.WriteString("{")
+		for i, label := range labels {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			fmt.Fprintf(sb, "%s=\"%s\"", label[0], escapeLabelValue(label[1]))
+		}
+		sb.WriteString("}")
+	}
+	sb.WriteString(" ")
+	sb.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	sb.WriteString("\n")
+}
+
+// escapeLabelValue escapes backslashes, double quotes, and newlines in a label value
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
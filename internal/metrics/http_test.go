@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+)
+
+// fakeDashboardProvider records the last request and returns a fixed dashboard
+type fakeDashboardProvider struct {
+	lastRequest *status.StatusRequest
+	dashboard   *status.StatusDashboard
+}
+
+func (f *fakeDashboardProvider) GetDashboard(ctx context.Context, req *status.StatusRequest) (*status.StatusDashboard, error) {
+	f.lastRequest = req
+	return f.dashboard, nil
+}
+
+// fakeTotals returns fixed totals and records the done statuses it was asked about
+type fakeTotals struct {
+	doneStatuses []string
+	totals       *repository.ProjectTotals
+	err          error
+}
+
+func (f *fakeTotals) Totals(ctx context.Context, doneStatuses []string) (*repository.ProjectTotals, error) {
+	f.doneStatuses = doneStatuses
+	return f.totals, f.err
+}
+
+func TestHTTPHandler_Metrics(t *testing.T) {
+	provider := &fakeDashboardProvider{dashboard: &status.StatusDashboard{
+		Summary: &status.ProjectSummary{OverallProgress: 62.5, BlockedCount: 2},
+		Epics: []*status.EpicSummary{
+			{Key: "E01", ProgressPercent: 100},
+			{Key: "E02", ProgressPercent: 25},
+		},
+	}}
+	totals := &fakeTotals{totals: &repository.ProjectTotals{
+		TasksByStatus: map[string]int{"todo": 3, "completed": 5, "in_qa": 1},
+		Completions:   7,
+		Rejections:    2,
+	}}
+	rules := &workspace.HealthRules{}
+	doneStatuses := func() []string { return []string{"completed", "archived"} }
+	handler := NewHTTPHandler(provider, totals, doneStatuses, func() *status.StatusRequest {
+		return &status.StatusRequest{Health: rules}
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("expected %q, got %q", ContentType, ct)
+	}
+	if provider.lastRequest == nil || provider.lastRequest.Health != rules {
+		t.Error("expected the dashboard to be built from the default request")
+	}
+	if len(totals.doneStatuses) != 2 {
+		t.Errorf("expected the done statuses to be passed on, got %v", totals.doneStatuses)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE shark_tasks gauge\n",
+		"shark_tasks{status=\"completed\"} 5\nshark_tasks{status=\"in_qa\"} 1\nshark_tasks{status=\"todo\"} 3\n",
+		"shark_tasks_blocked 2\n",
+		"shark_progress_percent 62.5\n",
+		"shark_epic_progress_percent{epic=\"E01\"} 100\nshark_epic_progress_percent{epic=\"E02\"} 25\n",
+		"# TYPE shark_task_completions_total counter\nshark_task_completions_total 7\n",
+		"shark_task_rejections_total 2\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestHTTPHandler_MetricsError(t *testing.T) {
+	provider := &fakeDashboardProvider{dashboard: &status.StatusDashboard{}}
+	handler := NewHTTPHandler(provider, &fakeTotals{err: errors.New("database is locked")}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if got := escapeLabelValue("a\\b\"c\nd"); got != `a\\b\"c\nd` {
+		t.Errorf("unexpected escaped value %q", got)
+	}
+}
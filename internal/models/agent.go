@@ -0,0 +1,59 @@
+package models
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var agentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.@-]{0,63}$`)
+
+// Agent is a registered agent. Tasks it holds carry its name in
+// assigned_agent.
+type Agent struct {
+	ID           int64     `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	AgentType    *string   `json:"agent_type,omitempty" db:"agent_type"`
+	Capabilities []string  `json:"capabilities" db:"capabilities"` // Stored as a JSON array
+	RegisteredAt time.Time `json:"registered_at" db:"registered_at"`
+	LastSeen     time.Time `json:"last_seen" db:"last_seen"`
+}
+
+// AgentWorkload is the work held by one agent: a registered agent, or a
+// name found in assigned_agent of an in-progress task
+type AgentWorkload struct {
+	Agent      string     `json:"agent"`
+	AgentType  *string    `json:"agent_type,omitempty"`
+	Registered bool       `json:"registered"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"` // Registered agents only
+	InProgress int        `json:"in_progress"`
+	Blocked    int        `json:"blocked"`
+	Completed  int        `json:"completed"`
+	Tasks      []string   `json:"tasks"` // Keys of the in-progress tasks
+}
+
+// ValidateAgentName validates the name an agent registers under
+func ValidateAgentName(name string) error {
+	if !agentNamePattern.MatchString(name) {
+		return ErrInvalidAgentName
+	}
+	return nil
+}
+
+// NormalizeCapabilities trims, lowercases, deduplicates, and sorts
+// capabilities, dropping empty ones
+func NormalizeCapabilities(capabilities []string) []string {
+	seen := make(map[string]bool, len(capabilities))
+	normalized := []string{}
+	for _, capability := range capabilities {
+		capability = strings.ToLower(strings.TrimSpace(capability))
+		if capability == "" || seen[capability] {
+			continue
+		}
+		seen[capability] = true
+		normalized = append(normalized, capability)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// APIKeyScope limits what an API key may do
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead  APIKeyScope = "read"  // Read-only calls
+	APIKeyScopeWrite APIKeyScope = "write" // Every call, including ones that change data
+)
+
+// APIKeyScopes are the allowed API key scopes
+var APIKeyScopes = []APIKeyScope{APIKeyScopeRead, APIKeyScopeWrite}
+
+// APIKeyPrefix starts every API key, so a leaked key is easy to recognize
+const APIKeyPrefix = "shark_"
+
+// apiKeyPrefixLength is how much of a key is kept in clear text to tell keys apart
+const apiKeyPrefixLength = len(APIKeyPrefix) + 8
+
+var apiKeyNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,49}$`)
+
+// APIKey is a credential for the API server. Only a hash of the key is
+// stored; the key itself is returned once, when it is created.
+type APIKey struct {
+	ID         int64       `json:"id" db:"id"`
+	Name       string      `json:"name" db:"name"`
+	KeyHash    string      `json:"-" db:"key_hash"`
+	Prefix     string      `json:"prefix" db:"prefix"`
+	Scope      APIKeyScope `json:"scope" db:"scope"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time  `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time  `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// CanWrite reports whether the key may call endpoints that change data
+func (k *APIKey) CanWrite() bool {
+	return k.Scope == APIKeyScopeWrite
+}
+
+// ValidateAPIKeyScope validates an API key scope
+func ValidateAPIKeyScope(scope string) error {
+	return validateEnum(ErrInvalidAPIKeyScope, scope, APIKeyScopes)
+}
+
+// NormalizeAPIKeyName trims and lowercases an API key name and validates it
+func NormalizeAPIKeyName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !apiKeyNamePattern.MatchString(name) {
+		return "", ErrInvalidAPIKeyName
+	}
+	return name, nil
+}
+
+// GenerateAPIKey returns a new random API key
+func GenerateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return APIKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// HashAPIKey returns the SHA-256 hash an API key is stored and looked up by.
+// Keys are long and random, so a fast unsalted hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyDisplayPrefix returns the start of a key, kept to tell keys apart
+func APIKeyDisplayPrefix(key string) string {
+	if len(key) <= apiKeyPrefixLength {
+		return key
+	}
+	return key[:apiKeyPrefixLength]
+}
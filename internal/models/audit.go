@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Audited entity types
+const (
+	AuditEntityEpic    = "epic"
+	AuditEntityFeature = "feature"
+	AuditEntityTask    = "task"
+)
+
+// Audit actions
+const (
+	AuditActionCreate       = "create"
+	AuditActionUpdate       = "update"
+	AuditActionDelete       = "delete"
+	AuditActionRestore      = "restore"
+	AuditActionFileReassign = "file_reassign"
+	AuditActionForce        = "force"
+)
+
+// AuditEntry records a change to an epic, feature, or task. Unlike task
+// history, which covers status transitions, the audit log covers creations,
+// field updates, deletions, file reassignments, and forced operations.
+type AuditEntry struct {
+	ID         int64                  `json:"id" db:"id"`
+	EntityType string                 `json:"entity_type" db:"entity_type"` // AuditEntityEpic, AuditEntityFeature, or AuditEntityTask
+	EntityKey  string                 `json:"entity_key" db:"entity_key"`
+	Action     string                 `json:"action" db:"action"`
+	Changes    map[string]AuditChange `json:"changes,omitempty" db:"changes"` // Changed fields, for updates
+	Summary    string                 `json:"summary" db:"summary"`
+	Actor      string                 `json:"actor" db:"actor"` // Agent or user that made the change
+	CreatedAt  time.Time              `json:"created_at" db:"created_at"`
+}
+
+// AuditChange is the old and new value of a changed field
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Validate validates the AuditEntry fields
+func (e *AuditEntry) Validate() error {
+	if e.EntityType == "" || e.EntityKey == "" || e.Action == "" {
+		return ErrInvalidAuditEntry
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package models
+
+// BacklogKey is the key of the implicit epic and feature that hold standalone
+// tasks. Standalone tasks are keyed T-BKL-###.
+const BacklogKey = "BKL"
+
+// BacklogDir is where standalone task files are created, relative to the
+// project root
+const BacklogDir = "docs/plan/backlog"
+
+// BacklogTitle is the title of the backlog epic and feature
+const BacklogTitle = "Backlog"
@@ -13,6 +13,14 @@ type Document struct {
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 }
 
+// DocumentWithLinks is a document with the keys of the entities it is linked to
+type DocumentWithLinks struct {
+	Document
+	Epics    []string `json:"epics"`
+	Features []string `json:"features"`
+	Tasks    []string `json:"tasks"`
+}
+
 // NotFoundError represents an entity not found error
 type NotFoundError struct {
 	Entity string
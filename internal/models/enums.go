@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Allowed values of the fixed enums. Validation errors list them, so an
+// invalid value can be corrected from the message alone. Task statuses are
+// not listed here: they come from the workflow configuration.
+var (
+	EpicStatuses    = []EpicStatus{EpicStatusDraft, EpicStatusActive, EpicStatusCompleted, EpicStatusArchived}
+	FeatureStatuses = []FeatureStatus{FeatureStatusDraft, FeatureStatusActive, FeatureStatusCompleted, FeatureStatusArchived}
+	Priorities      = []Priority{PriorityHigh, PriorityMedium, PriorityLow}
+	IdeaStatuses    = []IdeaStatus{IdeaStatusNew, IdeaStatusOnHold, IdeaStatusConverted, IdeaStatusArchived}
+)
+
+// EnumValues returns the allowed values of an enum as strings
+func EnumValues[T ~string](allowed []T) []string {
+	values := make([]string, len(allowed))
+	for i, value := range allowed {
+		values[i] = string(value)
+	}
+	return values
+}
+
+// validateEnum checks value against allowed. The error wraps sentinel and
+// names the rejected value and every allowed value.
+func validateEnum[T ~string](sentinel error, value string, allowed []T) error {
+	for _, candidate := range allowed {
+		if string(candidate) == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q: must be one of %s", sentinel, value, strings.Join(EnumValues(allowed), ", "))
+}
+
+// ValidateEpicStatus validates the epic status enum
+func ValidateEpicStatus(status string) error {
+	return validateEnum(ErrInvalidEpicStatus, status, EpicStatuses)
+}
+
+// ValidateFeatureStatus validates the feature status enum
+func ValidateFeatureStatus(status string) error {
+	return validateEnum(ErrInvalidFeatureStatus, status, FeatureStatuses)
+}
+
+// ValidatePriority validates the priority level (for Epic and other entities)
+func ValidatePriority(priority string) error {
+	return validateEnum(ErrInvalidPriorityLevel, priority, Priorities)
+}
+
+// ValidateIdeaStatus validates an idea status value
+func ValidateIdeaStatus(status string) error {
+	return validateEnum(ErrInvalidIdeaStatus, status, IdeaStatuses)
+}
+
+// ValidateTaskPriority validates a task or idea priority (1 = highest, 10 = lowest)
+func ValidateTaskPriority(priority int) error {
+	if priority < 1 || priority > 10 {
+		return fmt.Errorf("%w, got %d", ErrInvalidPriority, priority)
+	}
+	return nil
+}
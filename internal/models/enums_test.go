@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		valid    string
+		sentinel error
+		allowed  string
+	}{
+		{"epic status", ValidateEpicStatus, "active", ErrInvalidEpicStatus, "draft, active, completed, archived"},
+		{"feature status", ValidateFeatureStatus, "completed", ErrInvalidFeatureStatus, "draft, active, completed, archived"},
+		{"priority", ValidatePriority, "low", ErrInvalidPriorityLevel, "high, medium, low"},
+		{"idea status", ValidateIdeaStatus, "on_hold", ErrInvalidIdeaStatus, "new, on_hold, converted, archived"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.validate(tt.valid))
+
+			err := tt.validate("bogus")
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.sentinel))
+			assert.Contains(t, err.Error(), `"bogus"`)
+			assert.Contains(t, err.Error(), tt.allowed)
+		})
+	}
+}
+
+func TestValidateTaskPriority(t *testing.T) {
+	assert.NoError(t, ValidateTaskPriority(1))
+	assert.NoError(t, ValidateTaskPriority(10))
+
+	err := ValidateTaskPriority(11)
+	assert.ErrorIs(t, err, ErrInvalidPriority)
+	assert.Contains(t, err.Error(), "got 11")
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// EpicNote represents a lightweight planning annotation attached to an epic.
+// Unlike the epic description, notes are append-only and shown chronologically.
+type EpicNote struct {
+	ID        int64     `json:"id" db:"id"`
+	EpicID    int64     `json:"epic_id" db:"epic_id"`
+	Content   string    `json:"content" db:"content"`
+	CreatedBy *string   `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the EpicNote fields
+func (en *EpicNote) Validate() error {
+	if en.EpicID == 0 {
+		return ErrInvalidEpicID
+	}
+	if en.Content == "" {
+		return ErrEmptyContent
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Event entity types
+const (
+	EventEntityEpic    = "epic"
+	EventEntityFeature = "feature"
+	EventEntityTask    = "task"
+)
+
+// Event actions
+const (
+	EventActionCreated       = "created"
+	EventActionUpdated       = "updated"
+	EventActionStatusChanged = "status_changed"
+	EventActionDeleted       = "deleted"  // Deleted, or moved to the trash
+	EventActionRestored      = "restored" // Restored from the trash
+	EventActionPurged        = "purged"   // Removed for good from the trash
+)
+
+// Event is an entry in the changes feed: a change to an epic, feature, or
+// task. Seq increases with every event and is never reused, so a client can
+// resume the feed after the last seq it saw.
+type Event struct {
+	Seq            int64     `json:"seq" db:"seq"`
+	EntityType     string    `json:"entity_type" db:"entity_type"`
+	EntityKey      string    `json:"entity_key" db:"entity_key"`
+	ParentKey      *string   `json:"parent_key,omitempty" db:"parent_key"` // Feature of a task, epic of a feature
+	Action         string    `json:"action" db:"action"`
+	Status         *string   `json:"status,omitempty" db:"status"`
+	PreviousStatus *string   `json:"previous_status,omitempty" db:"previous_status"` // Only for status_changed
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
@@ -27,6 +27,7 @@ type Feature struct {
 	FilePath       *string       `json:"file_path,omitempty" db:"file_path"`
 	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time     `json:"updated_at" db:"updated_at"`
+	Labels         []string      `json:"labels,omitempty" db:"-"` // Labels attached via feature_labels (populated by commands that display them)
 }
 
 // IsAutoStatus returns true if status is automatically derived from tasks
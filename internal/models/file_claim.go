@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// FileClaim records that an epic, feature, or task owns a file. A file has at
+// most one claim and an entity claims at most one file.
+type FileClaim struct {
+	Path        string    `json:"path" db:"path"`
+	EntityType  string    `json:"entity_type" db:"entity_type"` // "epic", "feature", or "task"
+	EntityID    int64     `json:"entity_id" db:"entity_id"`
+	EntityKey   string    `json:"entity_key" db:"entity_key"`
+	EntityTitle string    `json:"entity_title" db:"entity_title"`
+	ClaimedAt   time.Time `json:"claimed_at" db:"claimed_at"`
+}
@@ -28,6 +28,7 @@ type Idea struct {
 	Notes        *string    `json:"notes,omitempty" db:"notes"`               // Additional notes
 	RelatedDocs  *string    `json:"related_docs,omitempty" db:"related_docs"` // JSON array of document paths
 	Dependencies *string    `json:"dependencies,omitempty" db:"dependencies"` // JSON array of idea keys
+	FilePath     *string    `json:"file_path,omitempty" db:"file_path"`       // Optional markdown file
 	Status       IdeaStatus `json:"status" db:"status"`
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
@@ -57,8 +58,8 @@ func (i *Idea) Validate() error {
 
 	// Validate priority if provided
 	if i.Priority != nil {
-		if *i.Priority < 1 || *i.Priority > 10 {
-			return ErrInvalidPriority
+		if err := ValidateTaskPriority(*i.Priority); err != nil {
+			return err
 		}
 	}
 
@@ -97,19 +98,3 @@ func ValidateIdeaKey(key string) error {
 
 	return nil
 }
-
-// ValidateIdeaStatus validates the idea status enum
-func ValidateIdeaStatus(status string) error {
-	validStatuses := map[string]bool{
-		"new":       true,
-		"on_hold":   true,
-		"converted": true,
-		"archived":  true,
-	}
-
-	if !validStatuses[status] {
-		return fmt.Errorf("invalid idea status %q: must be one of new, on_hold, converted, archived", status)
-	}
-
-	return nil
-}
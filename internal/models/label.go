@@ -0,0 +1,51 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxLabelLength is the maximum length of a label name
+const maxLabelLength = 50
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:/-]*$`)
+
+// Label is a tag that can be attached to tasks and features (e.g. "security", "tech-debt")
+type Label struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NormalizeLabel trims and lowercases a label name and validates it
+func NormalizeLabel(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) == 0 || len(name) > maxLabelLength || !labelPattern.MatchString(name) {
+		return "", ErrInvalidLabel
+	}
+	return name, nil
+}
+
+// NormalizeLabels normalizes label names from repeatable or comma-separated
+// flag values, dropping empty entries and duplicates while keeping order
+func NormalizeLabels(values []string) ([]string, error) {
+	labels := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			name, err := NormalizeLabel(part)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[name] {
+				seen[name] = true
+				labels = append(labels, name)
+			}
+		}
+	}
+	return labels, nil
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLabels(t *testing.T) {
+	labels, err := NormalizeLabels([]string{" Security ", "tech-debt,frontend", "security", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"security", "tech-debt", "frontend"}, labels)
+
+	for _, invalid := range []string{"has space", "-leading", "emoji✓"} {
+		_, err := NormalizeLabel(invalid)
+		assert.ErrorIs(t, err, ErrInvalidLabel, invalid)
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// OperationJournalEntry records a destructive operation (delete, force complete,
+// force file reassignment) with the row snapshots needed to undo it
+type OperationJournalEntry struct {
+	ID        int64            `json:"id" db:"id"`
+	Operation string           `json:"operation" db:"operation"`   // e.g. "epic delete"
+	EntityKey string           `json:"entity_key" db:"entity_key"` // Target entity key
+	Summary   string           `json:"summary" db:"summary"`       // Human-readable description of the effect
+	Snapshot  *JournalSnapshot `json:"-" db:"snapshot"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	UndoneAt  *time.Time       `json:"undone_at,omitempty" db:"undone_at"`
+}
+
+// JournalSnapshot holds the row images used to revert an operation
+type JournalSnapshot struct {
+	Deleted []JournalRows `json:"deleted,omitempty"` // Rows removed by the operation, re-inserted on undo
+	Updated []JournalRows `json:"updated,omitempty"` // Column values written back on undo
+}
+
+// JournalRows holds rows of one table. Each row is keyed by column name and
+// carries its rowid under JournalRowIDKey.
+type JournalRows struct {
+	Table   string                   `json:"table"`
+	Columns []string                 `json:"columns,omitempty"` // Columns restored for updated rows (all when empty)
+	Rows    []map[string]interface{} `json:"rows"`
+}
+
+// JournalRowIDKey is the key holding a snapshot row's rowid
+const JournalRowIDKey = "_rowid_"
+
+// Validate validates the OperationJournalEntry fields
+func (e *OperationJournalEntry) Validate() error {
+	if e.Operation == "" || e.EntityKey == "" {
+		return ErrEmptyOperation
+	}
+	if e.Snapshot == nil || (len(e.Snapshot.Deleted) == 0 && len(e.Snapshot.Updated) == 0) {
+		return ErrEmptySnapshot
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// Snapshot entity types
+const (
+	SnapshotEntityEpic    = "epic"
+	SnapshotEntityFeature = "feature"
+)
+
+// ProgressSnapshot records the progress of an epic or feature at a point in time.
+// Snapshots accumulate to form progress history (e.g. for burndown reports).
+type ProgressSnapshot struct {
+	ID             int64     `json:"id" db:"id"`
+	EntityType     string    `json:"entity_type" db:"entity_type"`
+	EntityID       int64     `json:"entity_id" db:"entity_id"`
+	EntityKey      string    `json:"entity_key" db:"entity_key"`
+	ProgressPct    float64   `json:"progress_pct" db:"progress_pct"`
+	TotalTasks     int       `json:"total_tasks" db:"total_tasks"`
+	CompletedTasks int       `json:"completed_tasks" db:"completed_tasks"`
+	RecordedAt     time.Time `json:"recorded_at" db:"recorded_at"`
+}
+
+// Validate validates the ProgressSnapshot fields
+func (ps *ProgressSnapshot) Validate() error {
+	if ps.EntityType != SnapshotEntityEpic && ps.EntityType != SnapshotEntityFeature {
+		return ErrInvalidSnapshotEntity
+	}
+	if ps.EntityID <= 0 {
+		return ErrInvalidSnapshotEntity
+	}
+	if ps.ProgressPct < 0.0 || ps.ProgressPct > 100.0 {
+		return ErrInvalidProgressPct
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SettingType is the type of a project setting's value
+type SettingType string
+
+const (
+	SettingTypeInt    SettingType = "int"
+	SettingTypeFloat  SettingType = "float"
+	SettingTypeString SettingType = "string"
+)
+
+// Project settings stored in the database
+const (
+	SettingTaskDefaultPriority    = "task.default_priority"
+	SettingEpicDefaultPriority    = "epic.default_priority"
+	SettingHealthProgressWarning  = "health.progress.warning"
+	SettingHealthProgressCritical = "health.progress.critical"
+	SettingHealthProgressWeight   = "health.progress.weight"
+	SettingHealthBlockedWarning   = "health.blocked.warning"
+	SettingHealthBlockedCritical  = "health.blocked.critical"
+	SettingHealthBlockedWeight    = "health.blocked.weight"
+	SettingHealthStaleWarning     = "health.stale.warning"
+	SettingHealthStaleCritical    = "health.stale.critical"
+	SettingHealthStaleWeight      = "health.stale.weight"
+	SettingHealthWarningScore     = "health.warning_score"
+	SettingHealthCriticalScore    = "health.critical_score"
+)
+
+// SettingDefinition describes a project setting: its type, the values it
+// accepts, and the value used when it isn't set
+type SettingDefinition struct {
+	Key         string      `json:"key"`
+	Type        SettingType `json:"type"`
+	Description string      `json:"description"`
+	Default     string      `json:"default,omitempty"` // Empty when the default comes from elsewhere, e.g. .shark.yaml
+	Min         *float64    `json:"min,omitempty"`     // Numeric settings only
+	Max         *float64    `json:"max,omitempty"`
+	Values      []string    `json:"values,omitempty"` // Allowed values of a string setting
+}
+
+// Setting is the value of a project setting stored in the database
+type Setting struct {
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func settingBound(v float64) *float64 { return &v }
+
+// settingDefinitions are the known project settings, in display order
+var settingDefinitions = []SettingDefinition{
+	{Key: SettingTaskDefaultPriority, Type: SettingTypeInt, Description: "Priority of new tasks when --priority isn't given (1 = highest, 10 = lowest)", Default: "5", Min: settingBound(1), Max: settingBound(10)},
+	{Key: SettingEpicDefaultPriority, Type: SettingTypeString, Description: "Priority of new epics when --priority isn't given", Default: string(PriorityMedium), Values: []string{string(PriorityHigh), string(PriorityMedium), string(PriorityLow)}},
+	{Key: SettingHealthProgressWarning, Type: SettingTypeFloat, Description: "Health is warning below this percent of tasks completed", Min: settingBound(0), Max: settingBound(100)},
+	{Key: SettingHealthProgressCritical, Type: SettingTypeFloat, Description: "Health is critical below this percent of tasks completed", Min: settingBound(0), Max: settingBound(100)},
+	{Key: SettingHealthProgressWeight, Type: SettingTypeFloat, Description: "Weight of the progress health rule (0 turns it off)", Min: settingBound(0)},
+	{Key: SettingHealthBlockedWarning, Type: SettingTypeFloat, Description: "Health is warning above this many blocked tasks", Min: settingBound(0)},
+	{Key: SettingHealthBlockedCritical, Type: SettingTypeFloat, Description: "Health is critical above this many blocked tasks", Min: settingBound(0)},
+	{Key: SettingHealthBlockedWeight, Type: SettingTypeFloat, Description: "Weight of the blocked health rule (0 turns it off)", Min: settingBound(0)},
+	{Key: SettingHealthStaleWarning, Type: SettingTypeFloat, Description: "Health is warning after this many days without a change", Min: settingBound(0)},
+	{Key: SettingHealthStaleCritical, Type: SettingTypeFloat, Description: "Health is critical after this many days without a change", Min: settingBound(0)},
+	{Key: SettingHealthStaleWeight, Type: SettingTypeFloat, Description: "Weight of the stale health rule (0 turns it off)", Min: settingBound(0)},
+	{Key: SettingHealthWarningScore, Type: SettingTypeFloat, Description: "Total health score at which health is warning"},
+	{Key: SettingHealthCriticalScore, Type: SettingTypeFloat, Description: "Total health score at which health is critical"},
+}
+
+// SettingDefinitions returns the known project settings, in display order
+func SettingDefinitions() []SettingDefinition {
+	return append([]SettingDefinition(nil), settingDefinitions...)
+}
+
+// LookupSetting returns the definition of a project setting
+func LookupSetting(key string) (SettingDefinition, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, def := range settingDefinitions {
+		if def.Key == key {
+			return def, nil
+		}
+	}
+	return SettingDefinition{}, fmt.Errorf("%w: %s", ErrUnknownSetting, key)
+}
+
+// Normalize validates a value for the setting and returns it in canonical form
+func (d SettingDefinition) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch d.Type {
+	case SettingTypeInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be a whole number, got %q", ErrInvalidSettingValue, d.Key, value)
+		}
+		if err := d.checkRange(float64(n)); err != nil {
+			return "", err
+		}
+		return strconv.Itoa(n), nil
+	case SettingTypeFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("%w: %s must be a number, got %q", ErrInvalidSettingValue, d.Key, value)
+		}
+		if err := d.checkRange(f); err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	default:
+		if len(d.Values) == 0 {
+			return value, nil
+		}
+		lower := strings.ToLower(value)
+		for _, allowed := range d.Values {
+			if lower == allowed {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("%w: %s must be one of %s, got %q", ErrInvalidSettingValue, d.Key, strings.Join(d.Values, ", "), value)
+	}
+}
+
+// checkRange checks a numeric value against the setting's bounds
+func (d SettingDefinition) checkRange(v float64) error {
+	if (d.Min != nil && v < *d.Min) || (d.Max != nil && v > *d.Max) {
+		switch {
+		case d.Min != nil && d.Max != nil:
+			return fmt.Errorf("%w: %s must be between %g and %g, got %g", ErrInvalidSettingValue, d.Key, *d.Min, *d.Max, v)
+		case d.Min != nil:
+			return fmt.Errorf("%w: %s must be at least %g, got %g", ErrInvalidSettingValue, d.Key, *d.Min, v)
+		default:
+			return fmt.Errorf("%w: %s must be at most %g, got %g", ErrInvalidSettingValue, d.Key, *d.Max, v)
+		}
+	}
+	return nil
+}
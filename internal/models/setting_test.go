@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingDefinition_Normalize(t *testing.T) {
+	priority, err := LookupSetting(" TASK.default_priority")
+	require.NoError(t, err)
+
+	value, err := priority.Normalize(" 03 ")
+	require.NoError(t, err)
+	assert.Equal(t, "3", value)
+	for _, invalid := range []string{"0", "11", "high", "2.5"} {
+		_, err := priority.Normalize(invalid)
+		assert.ErrorIs(t, err, ErrInvalidSettingValue, invalid)
+	}
+
+	stale, err := LookupSetting(SettingHealthStaleWarning)
+	require.NoError(t, err)
+	value, err = stale.Normalize("7.50")
+	require.NoError(t, err)
+	assert.Equal(t, "7.5", value)
+	_, err = stale.Normalize("-1")
+	assert.ErrorIs(t, err, ErrInvalidSettingValue)
+
+	epicPriority, err := LookupSetting(SettingEpicDefaultPriority)
+	require.NoError(t, err)
+	value, err = epicPriority.Normalize("High")
+	require.NoError(t, err)
+	assert.Equal(t, "high", value)
+	_, err = epicPriority.Normalize("urgent")
+	assert.ErrorIs(t, err, ErrInvalidSettingValue)
+
+	_, err = LookupSetting("task.colour")
+	assert.ErrorIs(t, err, ErrUnknownSetting)
+}
@@ -53,6 +53,7 @@ type Task struct {
 	CompletedAt    sql.NullTime `json:"completed_at,omitempty" db:"completed_at"`
 	BlockedAt      sql.NullTime `json:"blocked_at,omitempty" db:"blocked_at"`
 	UpdatedAt      time.Time    `json:"updated_at" db:"updated_at"`
+	Version        int          `json:"version" db:"version"` // Incremented on every write; used for optimistic locking
 
 	// Completion metadata fields
 	CompletedBy        *string             `json:"completed_by,omitempty" db:"completed_by"`
@@ -68,6 +69,15 @@ type Task struct {
 	// Rejection metadata fields
 	RejectionCount  int        `json:"rejection_count" db:"-"`             // Derived from task_notes, not stored
 	LastRejectionAt *time.Time `json:"last_rejection_at,omitempty" db:"-"` // Derived from task_notes, not stored
+
+	// Labels attached via task_labels (populated by commands that display them)
+	Labels []string `json:"labels,omitempty" db:"-"`
+
+	// Estimate from task_estimates (populated by commands that display it)
+	Estimate *TaskEstimate `json:"estimate,omitempty" db:"-"`
+
+	// Active lease from task_leases (populated by commands that display it)
+	Lease *TaskLease `json:"lease,omitempty" db:"-"`
 }
 
 // Validate validates the Task fields
@@ -86,8 +96,8 @@ func (t *Task) Validate() error {
 			return err
 		}
 	}
-	if t.Priority < 1 || t.Priority > 10 {
-		return ErrInvalidPriority
+	if err := ValidateTaskPriority(t.Priority); err != nil {
+		return err
 	}
 	if t.DependsOn != nil {
 		if err := ValidateDependsOn(*t.DependsOn); err != nil {
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/keys"
+)
+
+// maxTaskAliasLength bounds alias names so they stay easy to type
+const maxTaskAliasLength = 50
+
+var (
+	taskAliasPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	// taskNumberShorthandPattern matches the F##-### shorthand for a task
+	// number, which an alias would otherwise hide
+	taskNumberShorthandPattern = regexp.MustCompile(`^f[0-9]{2}-[0-9]+$`)
+)
+
+// TaskAlias is a short name for a task (e.g. "login" for T-E05-F01-003) that
+// task commands accept in place of its key
+type TaskAlias struct {
+	Alias     string    `json:"alias" db:"alias"`
+	TaskID    int64     `json:"task_id" db:"task_id"`
+	TaskKey   string    `json:"task_key" db:"task_key"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NormalizeTaskAlias trims and lowercases an alias name and validates it. An
+// alias can't look like a task key or task number, which it would shadow.
+func NormalizeTaskAlias(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) == 0 || len(name) > maxTaskAliasLength || !taskAliasPattern.MatchString(name) {
+		return "", ErrInvalidTaskAlias
+	}
+	if _, err := keys.NormalizeTaskKey(name); err == nil || taskNumberShorthandPattern.MatchString(name) {
+		return "", ErrInvalidTaskAlias
+	}
+	return name, nil
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTaskAlias(t *testing.T) {
+	alias, err := NormalizeTaskAlias("  Login_Form ")
+	require.NoError(t, err)
+	assert.Equal(t, "login_form", alias)
+
+	for _, invalid := range []string{"", "3d", "my alias", "e05-f01-003", "t-bkl-004", "f01-003"} {
+		_, err := NormalizeTaskAlias(invalid)
+		assert.ErrorIs(t, err, ErrInvalidTaskAlias, invalid)
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskAttachment represents a file attached to a task, such as a screenshot or
+// a log captured when a test failed. The file is copied into the project and
+// FilePath is relative to the project root.
+type TaskAttachment struct {
+	ID          int64     `json:"id" db:"id"`
+	TaskID      int64     `json:"task_id" db:"task_id"`
+	Filename    string    `json:"filename" db:"filename"`
+	FilePath    string    `json:"file_path" db:"file_path"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	SHA256      string    `json:"sha256" db:"sha256"`
+	ContentType *string   `json:"content_type,omitempty" db:"content_type"`
+	AttachedBy  *string   `json:"attached_by,omitempty" db:"attached_by"`
+	Note        *string   `json:"note,omitempty" db:"note"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the TaskAttachment fields
+func (a *TaskAttachment) Validate() error {
+	if a.TaskID == 0 {
+		return ErrInvalidTaskID
+	}
+	if a.Filename == "" || a.FilePath == "" || a.SHA256 == "" {
+		return ErrInvalidAttachment
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskChecklistItem represents a working checklist item on a task.
+// Unlike acceptance criteria, checklist items are informal steps the
+// implementer tracks while working (e.g. "write tests").
+type TaskChecklistItem struct {
+	ID          int64      `json:"id" db:"id"`
+	TaskID      int64      `json:"task_id" db:"task_id"`
+	Content     string     `json:"content" db:"content"`
+	Done        bool       `json:"done" db:"done"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the TaskChecklistItem fields
+func (i *TaskChecklistItem) Validate() error {
+	if i.TaskID == 0 {
+		return ErrInvalidTaskID
+	}
+	if i.Content == "" {
+		return ErrEmptyContent
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ type TaskCriteria struct {
 	Criterion         string         `json:"criterion" db:"criterion"`
 	Status            CriteriaStatus `json:"status" db:"status"`
 	VerifiedAt        *time.Time     `json:"verified_at,omitempty" db:"verified_at"`
+	VerifiedBy        *string        `json:"verified_by,omitempty" db:"verified_by"` // Agent that marked it complete or failed
 	VerificationNotes *string        `json:"verification_notes,omitempty" db:"verification_notes"`
 	CreatedAt         time.Time      `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EstimateUnit is the unit a task's estimate and actual effort are measured in
+type EstimateUnit string
+
+const (
+	EstimateUnitPoints EstimateUnit = "points"
+	EstimateUnitHours  EstimateUnit = "hours"
+)
+
+// TaskEstimate holds the planned and actual effort for a task. Estimate and
+// ActualEffort share one unit so they can be compared.
+type TaskEstimate struct {
+	TaskID       int64        `json:"-" db:"task_id"`
+	Estimate     *float64     `json:"estimate,omitempty" db:"estimate"`
+	ActualEffort *float64     `json:"actual_effort,omitempty" db:"actual_effort"`
+	Unit         EstimateUnit `json:"unit" db:"unit"`
+	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// ParseEffort parses an effort value such as "5", "5pts", "3h", or "1.5hours".
+// The returned unit is empty when the value has no suffix, leaving the choice
+// to the caller.
+func ParseEffort(value string) (float64, EstimateUnit, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+
+	var unit EstimateUnit
+	for _, suffix := range []struct {
+		text string
+		unit EstimateUnit
+	}{
+		{"points", EstimateUnitPoints},
+		{"pts", EstimateUnitPoints},
+		{"pt", EstimateUnitPoints},
+		{"p", EstimateUnitPoints},
+		{"hours", EstimateUnitHours},
+		{"hrs", EstimateUnitHours},
+		{"h", EstimateUnitHours},
+	} {
+		if strings.HasSuffix(s, suffix.text) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix.text))
+			unit = suffix.unit
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, "", fmt.Errorf("invalid effort %q: use a non-negative number, optionally followed by pts or h (e.g. 3, 5pts, 2.5h)", value)
+	}
+	return n, unit, nil
+}
+
+// Validate validates the TaskEstimate fields
+func (e *TaskEstimate) Validate() error {
+	if e.TaskID == 0 {
+		return ErrInvalidTaskID
+	}
+	if e.Unit != EstimateUnitPoints && e.Unit != EstimateUnitHours {
+		return fmt.Errorf("invalid estimate unit %q: must be points or hours", e.Unit)
+	}
+	if e.Estimate != nil && *e.Estimate < 0 {
+		return fmt.Errorf("estimate cannot be negative")
+	}
+	if e.ActualEffort != nil && *e.ActualEffort < 0 {
+		return fmt.Errorf("actual effort cannot be negative")
+	}
+	return nil
+}
+
+// FormatEffort renders an effort value with its unit, e.g. "5 points" or "2.5 hours"
+func FormatEffort(value float64, unit EstimateUnit) string {
+	return strconv.FormatFloat(value, 'f', -1, 64) + " " + string(unit)
+}
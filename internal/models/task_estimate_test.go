@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEffort(t *testing.T) {
+	tests := []struct {
+		input string
+		value float64
+		unit  EstimateUnit
+	}{
+		{"5", 5, ""},
+		{"5pts", 5, EstimateUnitPoints},
+		{"8 points", 8, EstimateUnitPoints},
+		{"3p", 3, EstimateUnitPoints},
+		{"2.5h", 2.5, EstimateUnitHours},
+		{"4 hours", 4, EstimateUnitHours},
+		{"0", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			value, unit, err := ParseEffort(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.unit, unit)
+		})
+	}
+
+	for _, input := range []string{"", "h", "-1", "3d", "lots"} {
+		_, _, err := ParseEffort(input)
+		assert.Error(t, err, "expected %q to be rejected", input)
+	}
+}
+
+func TestFormatEffort(t *testing.T) {
+	assert.Equal(t, "5 points", FormatEffort(5, EstimateUnitPoints))
+	assert.Equal(t, "2.5 hours", FormatEffort(2.5, EstimateUnitHours))
+}
@@ -11,6 +11,7 @@ type TaskHistory struct {
 	Agent           *string   `json:"agent,omitempty" db:"agent"`
 	Notes           *string   `json:"notes,omitempty" db:"notes"`
 	RejectionReason *string   `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	Forced          bool      `json:"forced" db:"forced"` // Transition bypassed workflow validation
 	Timestamp       time.Time `json:"timestamp" db:"timestamp"`
 }
 
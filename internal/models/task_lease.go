@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TaskLease reserves a todo task for one agent instance. 'shark task next'
+// skips tasks leased by other agents until the lease expires, so agents
+// polling at the same time get distinct tasks. A lease that expires before
+// the task is started simply lapses.
+type TaskLease struct {
+	TaskID    int64     `json:"-" db:"task_id"`
+	TaskKey   string    `json:"task_key,omitempty" db:"-"`
+	Agent     string    `json:"agent" db:"agent"`
+	LeasedAt  time.Time `json:"leased_at" db:"leased_at"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskRecurrence turns a task into an archived template that 'shark recur run'
+// copies into a fresh todo task each time the rule comes due
+type TaskRecurrence struct {
+	TaskID      int64      `json:"task_id" db:"task_id"`
+	TaskKey     string     `json:"task_key" db:"-"` // Joined from tasks, not stored
+	Rule        string     `json:"rule" db:"rule"`  // Interval such as "7d", "2w", or "12h"
+	NextRunAt   time.Time  `json:"next_run_at" db:"next_run_at"`
+	LastRunAt   *time.Time `json:"last_run_at,omitempty" db:"last_run_at"`
+	LastTaskKey *string    `json:"last_task_key,omitempty" db:"last_task_key"` // Most recent occurrence
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ParseRecurrenceRule parses a recurrence interval: a positive whole number
+// followed by h (hours), d (days), or w (weeks), e.g. "7d"
+func ParseRecurrenceRule(rule string) (time.Duration, error) {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	if len(rule) < 2 {
+		return 0, fmt.Errorf("invalid recurrence %q: use a number followed by h, d, or w (e.g. 7d)", rule)
+	}
+
+	n, err := strconv.Atoi(rule[:len(rule)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid recurrence %q: use a number followed by h, d, or w (e.g. 7d)", rule)
+	}
+
+	switch rule[len(rule)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid recurrence %q: use a number followed by h, d, or w (e.g. 7d)", rule)
+}
+
+// Validate validates the TaskRecurrence fields
+func (r *TaskRecurrence) Validate() error {
+	if r.TaskID == 0 {
+		return ErrInvalidTaskID
+	}
+	if _, err := ParseRecurrenceRule(r.Rule); err != nil {
+		return err
+	}
+	if r.NextRunAt.IsZero() {
+		return fmt.Errorf("next run time is required")
+	}
+	return nil
+}
+
+// NextAfter returns the first scheduled run after now. Missed runs are
+// skipped rather than replayed, so a recurrence that was not run for a while
+// produces one occurrence, not a backlog.
+func (r *TaskRecurrence) NextAfter(now time.Time) (time.Time, error) {
+	interval, err := ParseRecurrenceRule(r.Rule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := r.NextRunAt
+	if !next.After(now) {
+		missed := now.Sub(next) / interval
+		next = next.Add((missed + 1) * interval)
+	}
+	return next, nil
+}
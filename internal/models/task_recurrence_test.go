@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"12h", 12 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{" 1D ", 24 * time.Hour, false},
+		{"0d", 0, true},
+		{"-1d", 0, true},
+		{"7", 0, true},
+		{"d", 0, true},
+		{"7m", 0, true},
+		{"weekly", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseRecurrenceRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRecurrenceRule(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRecurrenceRule(%q) = %v, want %v", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskRecurrence_NextAfter(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	rec := &TaskRecurrence{TaskID: 1, Rule: "7d", NextRunAt: start}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"not yet due", start.Add(-time.Hour), start},
+		{"exactly due", start, start.AddDate(0, 0, 7)},
+		{"due", start.Add(time.Hour), start.AddDate(0, 0, 7)},
+		{"missed runs are skipped", start.AddDate(0, 0, 22), start.AddDate(0, 0, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rec.NextAfter(tt.now)
+			if err != nil {
+				t.Fatalf("NextAfter() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
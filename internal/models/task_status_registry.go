@@ -0,0 +1,29 @@
+package models
+
+import "sync"
+
+var (
+	registeredStatusesLock sync.RWMutex
+	registeredStatuses     map[string]bool
+)
+
+// RegisterTaskStatuses makes ValidateTaskStatus accept the statuses of the
+// project's workflow, replacing any registered before. The config package
+// calls it whenever it loads a custom workflow.
+func RegisterTaskStatuses(statuses []string) {
+	registered := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		registered[status] = true
+	}
+
+	registeredStatusesLock.Lock()
+	defer registeredStatusesLock.Unlock()
+	registeredStatuses = registered
+}
+
+// isRegisteredTaskStatus reports whether status belongs to the registered workflow
+func isRegisteredTaskStatus(status string) bool {
+	registeredStatusesLock.RLock()
+	defer registeredStatusesLock.RUnlock()
+	return registeredStatuses[status]
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Trashed entity types
+const (
+	TrashTypeFeature = "feature"
+	TrashTypeTask    = "task"
+)
+
+// TrashedItem is a soft-deleted feature or task. Trashed entities keep their
+// rows (and keys) until the trash is emptied, but are hidden from normal queries.
+type TrashedItem struct {
+	Type      string    `json:"type"` // TrashTypeFeature or TrashTypeTask
+	ID        int64     `json:"-"`
+	Key       string    `json:"key"`
+	Title     string    `json:"title"`
+	ParentKey string    `json:"parent_key"` // Epic key for features, feature key for tasks
+	DeletedAt time.Time `json:"deleted_at"`
+}
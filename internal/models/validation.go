@@ -12,9 +12,9 @@ import (
 var (
 	ErrInvalidEpicKey       = errors.New("invalid epic key format: must match ^E\\d{2}$")
 	ErrInvalidFeatureKey    = errors.New("invalid feature key format: must match ^E\\d{2}-F\\d{2}$")
-	ErrInvalidTaskKey       = errors.New("invalid task key format: must match ^T-E\\d{2}-F\\d{2}-\\d{3}$")
-	ErrInvalidEpicStatus    = errors.New("invalid epic status: must be draft, active, completed, or archived")
-	ErrInvalidFeatureStatus = errors.New("invalid feature status: must be draft, active, completed, or archived")
+	ErrInvalidTaskKey       = errors.New("invalid task key format: must match ^T-E\\d{2}-F\\d{2}-\\d{3}$ or ^T-BKL-\\d{3}$")
+	ErrInvalidEpicStatus    = errors.New("invalid epic status")
+	ErrInvalidFeatureStatus = errors.New("invalid feature status")
 	// ErrInvalidTaskStatus is deprecated - error messages are now generated dynamically based on workflow config
 	ErrInvalidTaskStatus       = errors.New("invalid task status")
 	ErrInvalidAgentType        = errors.New("invalid agent type: cannot be empty or whitespace-only")
@@ -25,6 +25,7 @@ var (
 	ErrEmptyNewStatus          = errors.New("new_status cannot be empty")
 	ErrInvalidNoteType         = errors.New("invalid note type: must be comment, decision, blocker, solution, reference, implementation, testing, future, question, or rejection")
 	ErrInvalidTaskID           = errors.New("task_id must be greater than 0")
+	ErrInvalidEpicID           = errors.New("epic_id must be greater than 0")
 	ErrEmptyContent            = errors.New("content cannot be empty")
 	ErrInvalidCriteriaStatus   = errors.New("invalid criteria status: must be pending, in_progress, complete, failed, or na")
 	ErrEmptyCriterion          = errors.New("criterion cannot be empty")
@@ -35,13 +36,27 @@ var (
 	ErrInvalidTimestamp        = errors.New("invalid timestamp: cannot be zero value")
 	ErrEmptyKey                = errors.New("key cannot be empty")
 	ErrInvalidJSON             = errors.New("invalid JSON format")
+	ErrInvalidSnapshotEntity   = errors.New("invalid snapshot entity: must be an epic or feature with id greater than 0")
+	ErrInvalidLabel            = errors.New("invalid label: must be 1-50 lowercase letters, digits, or . _ : / - and start with a letter or digit")
+	ErrEmptyOperation          = errors.New("journal entry requires an operation and entity key")
+	ErrEmptySnapshot           = errors.New("journal entry requires a snapshot with at least one row set")
+	ErrInvalidAuditEntry       = errors.New("audit entry requires an entity type, entity key, and action")
+	ErrInvalidAttachment       = errors.New("attachment requires a filename, file path, and sha256 checksum")
+	ErrInvalidPriorityLevel    = errors.New("invalid priority")
+	ErrInvalidIdeaStatus       = errors.New("invalid idea status")
+	ErrInvalidTaskAlias        = errors.New("invalid task alias: must be 1-50 lowercase letters, digits, _ or -, start with a letter, and not look like a task key")
+	ErrInvalidAPIKeyScope      = errors.New("invalid API key scope")
+	ErrInvalidAPIKeyName       = errors.New("invalid API key name: must be 1-50 lowercase letters, digits, _, . or -, starting with a letter or digit")
+	ErrInvalidAgentName        = errors.New("invalid agent name: must be 1-64 letters, digits, _, ., @ or -, starting with a letter or digit")
+	ErrUnknownSetting          = errors.New("unknown setting")
+	ErrInvalidSettingValue     = errors.New("invalid setting value")
 )
 
 // Key format regex patterns
 var (
 	epicKeyPattern    = regexp.MustCompile(`^E\d{2}$`)
 	featureKeyPattern = regexp.MustCompile(`^E\d{2}-F\d{2}$`)
-	taskKeyPattern    = regexp.MustCompile(`^T-E\d{2}-F\d{2}-\d{3}$`)
+	taskKeyPattern    = regexp.MustCompile(`^T-(?:E\d{2}-F\d{2}|BKL)-\d{3}$`)
 )
 
 // ValidateEpicKey validates the epic key format
@@ -68,34 +83,6 @@ func ValidateTaskKey(key string) error {
 	return nil
 }
 
-// ValidateEpicStatus validates the epic status enum
-func ValidateEpicStatus(status string) error {
-	validStatuses := map[string]bool{
-		"draft":     true,
-		"active":    true,
-		"completed": true,
-		"archived":  true,
-	}
-	if !validStatuses[status] {
-		return fmt.Errorf("%w: got %q", ErrInvalidEpicStatus, status)
-	}
-	return nil
-}
-
-// ValidateFeatureStatus validates the feature status enum
-func ValidateFeatureStatus(status string) error {
-	validStatuses := map[string]bool{
-		"draft":     true,
-		"active":    true,
-		"completed": true,
-		"archived":  true,
-	}
-	if !validStatuses[status] {
-		return fmt.Errorf("%w: got %q", ErrInvalidFeatureStatus, status)
-	}
-	return nil
-}
-
 // ValidateTaskStatus validates the task status enum
 // DEPRECATED: This function uses hardcoded statuses and will be removed in a future version.
 // Use ValidateTaskStatusWithWorkflow instead for config-driven validation.
@@ -108,7 +95,9 @@ func ValidateTaskStatus(status string) error {
 }
 
 // ValidateTaskStatusWithWorkflow validates a task status against a workflow config.
-// If workflow is nil, uses the default workflow.
+// workflow is anything with a HasStatus(string) bool method, such as
+// *config.WorkflowConfig. If workflow is nil, the default and extended statuses
+// and those registered with RegisterTaskStatuses are accepted.
 // This is the config-driven replacement for ValidateTaskStatus.
 func ValidateTaskStatusWithWorkflow(status string, workflow interface{}) error {
 	// Import here to avoid circular dependency - we'll handle this properly
@@ -153,6 +142,14 @@ func ValidateTaskStatusWithWorkflow(status string, workflow interface{}) error {
 		return nil
 	}
 
+	// Statuses of a custom workflow
+	if w, ok := workflow.(interface{ HasStatus(string) bool }); ok && w.HasStatus(status) {
+		return nil
+	}
+	if isRegisteredTaskStatus(status) {
+		return nil
+	}
+
 	// If workflow is provided, we could validate against it
 	// For now, return error with helpful message
 	return fmt.Errorf("invalid task status %q: not found in default or extended workflow. "+
@@ -176,19 +173,6 @@ func ValidateAgentType(agentType string) error {
 	return nil
 }
 
-// ValidatePriority validates the priority level (for Epic and other entities)
-func ValidatePriority(priority string) error {
-	validPriorities := map[string]bool{
-		"high":   true,
-		"medium": true,
-		"low":    true,
-	}
-	if !validPriorities[priority] {
-		return fmt.Errorf("invalid priority: must be high, medium, or low, got %q", priority)
-	}
-	return nil
-}
-
 // ValidateDependsOn validates the JSON format of the depends_on field
 func ValidateDependsOn(dependsOn string) error {
 	if dependsOn == "" || dependsOn == "null" {
@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Match "## Features" (any case, optional trailing text such as "## Features (MVP)")
+	featuresHeadingPattern = regexp.MustCompile(`(?i)^##\s+features\b`)
+
+	// Match any markdown heading and capture its level and text
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+
+	// Match top-level bullet or numbered list items: "- item", "* item", "1. item"
+	listItemPattern = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(.+)$`)
+
+	// Match a leading checkbox: "[ ] item", "[x] item"
+	checkboxPrefixPattern = regexp.MustCompile(`^\[[ xX]\]\s+`)
+
+	// Match a leading feature key: "F01:", "F01 -", "E05-F01:"
+	featureKeyPrefixPattern = regexp.MustCompile(`^(?:E\d{2}-)?F\d{2}\s*(?:[:.\-–—]\s*|\s+)`)
+
+	// Match a leading bold title: "**Title** rest" or "__Title__ rest"
+	boldTitlePattern = regexp.MustCompile(`^(?:\*\*|__)(.+?)(?:\*\*|__)\s*(.*)$`)
+
+	// Match markdown links: [text](url)
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+)
+
+// EpicFeatureStub is a feature planned in an epic document's "## Features" section
+type EpicFeatureStub struct {
+	Title       string
+	Description string
+}
+
+// ParseEpicFeatures extracts planned features from the "## Features" section of
+// an epic document. The section runs until the next level-1 or level-2 heading.
+//
+// When the section contains "###" headings, each heading is a feature and the
+// text below it is its description. Otherwise each top-level list item is a
+// feature, split into title and description on ":" or " - " (a bold title,
+// "**Title** description", is split after the bold text). Leading feature keys
+// ("F01:"), checkboxes, and link markup are removed.
+func ParseEpicFeatures(content string) []EpicFeatureStub {
+	section := epicFeaturesSection(content)
+
+	for _, line := range section {
+		if match := headingPattern.FindStringSubmatch(line); match != nil && len(match[1]) >= 3 {
+			return parseFeatureHeadings(section)
+		}
+	}
+	return parseFeatureListItems(section)
+}
+
+// epicFeaturesSection returns the lines of the "## Features" section, excluding
+// the heading and any fenced code blocks
+func epicFeaturesSection(content string) []string {
+	var section []string
+	inSection := false
+	inFence := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if !inSection {
+			inSection = featuresHeadingPattern.MatchString(trimmed)
+			continue
+		}
+		if match := headingPattern.FindStringSubmatch(trimmed); match != nil && len(match[1]) <= 2 {
+			break
+		}
+		section = append(section, line)
+	}
+
+	return section
+}
+
+// parseFeatureHeadings treats each "###" heading as a feature and the
+// paragraph text under it as the description
+func parseFeatureHeadings(section []string) []EpicFeatureStub {
+	stubs := make([]EpicFeatureStub, 0)
+	var current *EpicFeatureStub
+	var description []string
+
+	flush := func() {
+		if current != nil && current.Title != "" {
+			current.Description = strings.Join(description, " ")
+			stubs = append(stubs, *current)
+		}
+		current = nil
+		description = nil
+	}
+
+	for _, line := range section {
+		trimmed := strings.TrimSpace(line)
+		if match := headingPattern.FindStringSubmatch(trimmed); match != nil {
+			if len(match[1]) == 3 {
+				flush()
+				title, _ := splitFeatureText(match[2])
+				current = &EpicFeatureStub{Title: title}
+			}
+			continue
+		}
+		if current == nil || trimmed == "" {
+			continue
+		}
+		description = append(description, cleanFeatureText(trimmed))
+	}
+	flush()
+
+	return stubs
+}
+
+// parseFeatureListItems treats each unindented list item as a feature. Nested
+// items and continuation lines are ignored.
+func parseFeatureListItems(section []string) []EpicFeatureStub {
+	stubs := make([]EpicFeatureStub, 0)
+
+	for _, line := range section {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		match := listItemPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		title, description := splitFeatureText(match[1])
+		if title == "" {
+			continue
+		}
+		stubs = append(stubs, EpicFeatureStub{Title: title, Description: description})
+	}
+
+	return stubs
+}
+
+// splitFeatureText splits a list item or heading into title and description
+func splitFeatureText(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	text = checkboxPrefixPattern.ReplaceAllString(text, "")
+	text = featureKeyPrefixPattern.ReplaceAllString(text, "")
+
+	if match := boldTitlePattern.FindStringSubmatch(text); match != nil {
+		title := strings.TrimRight(cleanFeatureText(match[1]), ":")
+		description := strings.TrimLeft(match[2], ":-–— ")
+		return strings.TrimSpace(title), cleanFeatureText(description)
+	}
+
+	text = cleanFeatureText(text)
+	for _, separator := range []string{": ", " - ", " – ", " — "} {
+		if title, description, found := strings.Cut(text, separator); found {
+			return strings.TrimSpace(title), strings.TrimSpace(description)
+		}
+	}
+	return text, ""
+}
+
+// cleanFeatureText removes link and emphasis markup
+func cleanFeatureText(text string) string {
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+	return strings.TrimSpace(text)
+}
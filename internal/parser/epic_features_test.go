@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEpicFeatures(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []EpicFeatureStub
+	}{
+		{
+			name: "bullet list with descriptions",
+			content: `# Epic: Identity
+
+## Goals
+
+- Not a feature
+
+## Features
+
+- User Login: Email and password sign-in
+- **OAuth Providers** - Google and GitHub
+* Password Reset
+1. F04: Session Management — Idle timeouts
+  - nested detail is ignored
+
+## Non-Goals
+
+- Not a feature either
+`,
+			want: []EpicFeatureStub{
+				{Title: "User Login", Description: "Email and password sign-in"},
+				{Title: "OAuth Providers", Description: "Google and GitHub"},
+				{Title: "Password Reset"},
+				{Title: "Session Management", Description: "Idle timeouts"},
+			},
+		},
+		{
+			name: "headings with paragraphs",
+			content: `## Features (MVP)
+
+### F01: Audit Log
+
+Record every change.
+Keep it for 90 days.
+
+- detail bullets are part of the description
+
+### [Export](docs/export.md)
+
+# Appendix
+`,
+			want: []EpicFeatureStub{
+				{Title: "Audit Log", Description: "Record every change. Keep it for 90 days. - detail bullets are part of the description"},
+				{Title: "Export"},
+			},
+		},
+		{
+			name: "checkboxes and links",
+			content: `## features
+- [ ] [Billing](billing.md): Invoices
+- [x] ` + "`Usage`" + ` Metering
+`,
+			want: []EpicFeatureStub{
+				{Title: "Billing", Description: "Invoices"},
+				{Title: "Usage Metering"},
+			},
+		},
+		{
+			name:    "code fences are skipped",
+			content: "## Features\n```\n- not a feature\n```\n- Real Feature\n",
+			want:    []EpicFeatureStub{{Title: "Real Feature"}},
+		},
+		{
+			name:    "no features section",
+			content: "# Epic\n\n- Something\n",
+			want:    []EpicFeatureStub{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseEpicFeatures(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEpicFeatures() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Match a leading task number or key: "1.", "Task 2:", "T-E01-F01-003:"
+	taskPrefixPattern = regexp.MustCompile(`(?i)^(?:(?:task|step|phase)\s+\d+\s*[:.\-–—]\s*|\d+[.)]\s+|T-E\d{2}-F\d{2}-\d{3}\s*[:.\-–—]\s*)`)
+
+	// Match an "Acceptance Criteria" label line: "Acceptance Criteria:", "**AC:**"
+	criteriaLabelPattern = regexp.MustCompile(`(?i)^(?:\*\*|__)?(?:acceptance criteria|acceptance|ac|definition of done|done when)(?:\*\*|__)?\s*:?\s*(?:\*\*|__)?\s*:?$`)
+
+	// Match a dependency line: "Depends on: API endpoints, #2", "**After:** Login form"
+	dependsOnPattern = regexp.MustCompile(`(?i)^(?:[-*+]\s+)?(?:\*\*|__)?(?:depends on|dependencies|after|blocked by)(?:\*\*|__)?\s*:\s*(?:\*\*|__)?\s*(.+)$`)
+
+	// Match a checkbox list item: "- [ ] criterion"
+	checkboxItemPattern = regexp.MustCompile(`^[-*+]\s+\[[ xX]\]\s+(.+)$`)
+
+	// Match a task key reference in a dependency line
+	taskKeyPattern = regexp.MustCompile(`(?i)^T-E\d{2}-F\d{2}-\d{3}$`)
+
+	// Split text into lower-case words for keyword matching
+	wordPattern = regexp.MustCompile(`[a-z0-9]+`)
+)
+
+// planSkippedSections are headings that describe a spec rather than work to do
+var planSkippedSections = []string{
+	"overview", "introduction", "background", "summary", "context", "motivation",
+	"problem", "goals", "non-goals", "non goals", "out of scope", "scope",
+	"references", "open questions", "questions", "appendix", "glossary",
+	"table of contents", "risks", "assumptions", "success metrics", "metrics",
+	"notes", "revision history", "changelog", "user stories", "personas",
+}
+
+// planCriteriaSections are headings whose list items are acceptance criteria
+var planCriteriaSections = []string{
+	"acceptance criteria", "acceptance", "ac", "definition of done", "success criteria", "done when",
+}
+
+// agentTypeKeywords maps agent types to words that suggest them, in tie-break order
+var agentTypeKeywords = []struct {
+	AgentType string
+	Words     []string
+}{
+	{"frontend", []string{"ui", "ux", "page", "pages", "screen", "screens", "component", "components", "form", "forms", "button", "css", "react", "vue", "view", "views", "frontend", "layout", "modal", "dashboard", "style", "styling"}},
+	{"backend", []string{"api", "endpoint", "endpoints", "database", "db", "schema", "migration", "migrations", "service", "services", "server", "backend", "query", "queries", "repository", "model", "models", "webhook", "job", "worker"}},
+	{"testing", []string{"test", "tests", "testing", "qa", "e2e", "coverage", "regression", "integration"}},
+	{"devops", []string{"deploy", "deployment", "ci", "cd", "pipeline", "docker", "kubernetes", "k8s", "infrastructure", "infra", "monitoring", "alerting", "terraform", "release"}},
+}
+
+// TaskPlanStub is a task proposed from a markdown spec
+type TaskPlanStub struct {
+	Title       string
+	Description string
+	AgentType   string   // Inferred from keywords; empty when nothing matched
+	Criteria    []string // Acceptance criteria
+	DependsOn   []int    // Indexes of earlier stubs this task depends on
+	DependsKeys []string // Existing task keys this task depends on
+}
+
+// planHeading is a node in a spec's heading tree
+type planHeading struct {
+	Level    int
+	Title    string
+	Body     []string // Lines before the first child heading
+	Children []*planHeading
+}
+
+// ParseTaskPlan proposes tasks from a markdown spec such as a PRD.
+//
+// Each heading that describes work becomes a task, with the text below it as
+// the description. A heading with two or more work sub-headings is a grouping
+// ("## Requirements") and its sub-headings become tasks instead. Sections such
+// as Overview, Goals, and Open Questions are skipped.
+//
+// Acceptance criteria come from "Acceptance Criteria" sub-headings or labels
+// and from checkbox items. A "Depends on:" line names earlier tasks by title
+// or number (#2), or existing tasks by key. The agent type is inferred from
+// keywords in the title and description.
+//
+// A spec with no work headings but an acceptance criteria list yields one
+// task per criterion.
+func ParseTaskPlan(content string) []TaskPlanStub {
+	root := parsePlanHeadings(content)
+
+	var nodes []*planHeading
+	collectPlanTasks(root, &nodes)
+	if len(nodes) == 0 {
+		return planStubsFromCriteria(root)
+	}
+
+	stubs := make([]TaskPlanStub, 0, len(nodes))
+	var dependencyRefs [][]string
+	for _, node := range nodes {
+		stub, refs := planStubFromHeading(node)
+		stubs = append(stubs, stub)
+		dependencyRefs = append(dependencyRefs, refs)
+	}
+	resolvePlanDependencies(stubs, dependencyRefs)
+	return stubs
+}
+
+// parsePlanHeadings builds the heading tree of a markdown document, ignoring
+// headings inside fenced code blocks
+func parsePlanHeadings(content string) *planHeading {
+	root := &planHeading{Level: 0}
+	stack := []*planHeading{root}
+	inFence := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		current := stack[len(stack)-1]
+
+		match := headingPattern.FindStringSubmatch(trimmed)
+		if inFence || match == nil {
+			if len(current.Children) == 0 {
+				current.Body = append(current.Body, line)
+			}
+			continue
+		}
+
+		node := &planHeading{Level: len(match[1]), Title: cleanFeatureText(match[2])}
+		for len(stack) > 1 && stack[len(stack)-1].Level >= node.Level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, node)
+		stack = append(stack, node)
+	}
+
+	return root
+}
+
+// collectPlanTasks appends the headings under node that become tasks
+func collectPlanTasks(node *planHeading, tasks *[]*planHeading) {
+	work := planWorkChildren(node)
+	for _, child := range work {
+		if len(planWorkChildren(child)) >= 2 {
+			collectPlanTasks(child, tasks)
+		} else {
+			*tasks = append(*tasks, child)
+		}
+	}
+}
+
+// planWorkChildren returns the sub-headings of node that describe work
+func planWorkChildren(node *planHeading) []*planHeading {
+	var work []*planHeading
+	for _, child := range node.Children {
+		if matchesPlanSection(child.Title, planSkippedSections) || matchesPlanSection(child.Title, planCriteriaSections) {
+			continue
+		}
+		work = append(work, child)
+	}
+	// A single top-level heading is the document title, not a task
+	if node.Level == 0 && len(work) == 1 && work[0].Level == 1 {
+		return planWorkChildren(work[0])
+	}
+	return work
+}
+
+// matchesPlanSection reports whether a heading title names one of sections
+func matchesPlanSection(title string, sections []string) bool {
+	title = strings.ToLower(strings.TrimRight(strings.TrimSpace(title), ":"))
+	for _, section := range sections {
+		if title == section || strings.HasPrefix(title, section+" ") || strings.HasPrefix(title, section+":") || strings.HasPrefix(title, section+" (") {
+			return true
+		}
+	}
+	return false
+}
+
+// planStubFromHeading turns a task heading into a stub, returning its
+// unresolved dependency references
+func planStubFromHeading(node *planHeading) (TaskPlanStub, []string) {
+	title := taskPrefixPattern.ReplaceAllString(node.Title, "")
+	title, _ = splitFeatureText(title)
+	stub := TaskPlanStub{Title: title, Criteria: []string{}}
+
+	description, criteria, refs := parsePlanBody(node.Body)
+	stub.Criteria = append(stub.Criteria, criteria...)
+
+	// Sub-headings of a task: criteria sections add criteria, others add detail
+	var details []string
+	var walk func(children []*planHeading)
+	walk = func(children []*planHeading) {
+		for _, child := range children {
+			childDescription, childCriteria, childRefs := parsePlanBody(child.Body)
+			refs = append(refs, childRefs...)
+			if matchesPlanSection(child.Title, planCriteriaSections) {
+				stub.Criteria = append(stub.Criteria, planListItems(child.Body)...)
+			} else {
+				stub.Criteria = append(stub.Criteria, childCriteria...)
+				if childDescription != "" {
+					details = append(details, childDescription)
+				}
+			}
+			walk(child.Children)
+		}
+	}
+	walk(node.Children)
+
+	if len(details) > 0 {
+		description = strings.TrimSpace(description + "\n\n" + strings.Join(details, "\n\n"))
+	}
+	stub.Description = description
+	stub.AgentType = InferAgentType(stub.Title, stub.Description)
+	return stub, refs
+}
+
+// parsePlanBody splits a section body into description text, acceptance
+// criteria (checkbox items and items after a criteria label), and dependency
+// references
+func parsePlanBody(lines []string) (string, []string, []string) {
+	var description []string
+	var criteria []string
+	var refs []string
+	inCriteria := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if !inCriteria {
+				description = append(description, "")
+			}
+			continue
+		}
+		if match := dependsOnPattern.FindStringSubmatch(trimmed); match != nil {
+			for _, ref := range strings.Split(match[1], ",") {
+				if ref = strings.Trim(cleanFeatureText(ref), " ."); ref != "" {
+					refs = append(refs, ref)
+				}
+			}
+			continue
+		}
+		if criteriaLabelPattern.MatchString(trimmed) {
+			inCriteria = true
+			continue
+		}
+		if match := checkboxItemPattern.FindStringSubmatch(trimmed); match != nil {
+			criteria = append(criteria, cleanFeatureText(match[1]))
+			continue
+		}
+		if inCriteria {
+			if match := listItemPattern.FindStringSubmatch(trimmed); match != nil {
+				criteria = append(criteria, cleanFeatureText(match[1]))
+				continue
+			}
+			inCriteria = false
+		}
+		description = append(description, line)
+	}
+
+	return strings.TrimSpace(strings.Join(description, "\n")), criteria, refs
+}
+
+// planListItems returns the text of the list items in lines
+func planListItems(lines []string) []string {
+	var items []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if match := checkboxItemPattern.FindStringSubmatch(trimmed); match != nil {
+			items = append(items, cleanFeatureText(match[1]))
+		} else if match := listItemPattern.FindStringSubmatch(trimmed); match != nil {
+			items = append(items, cleanFeatureText(match[1]))
+		}
+	}
+	return items
+}
+
+// planStubsFromCriteria makes one task per acceptance criterion, for specs
+// that are just a criteria list
+func planStubsFromCriteria(root *planHeading) []TaskPlanStub {
+	var items []string
+	var walk func(node *planHeading)
+	walk = func(node *planHeading) {
+		if node.Level > 0 && matchesPlanSection(node.Title, planCriteriaSections) {
+			items = append(items, planListItems(node.Body)...)
+		} else {
+			_, criteria, _ := parsePlanBody(node.Body)
+			items = append(items, criteria...)
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	stubs := make([]TaskPlanStub, 0, len(items))
+	for _, item := range items {
+		title, description := splitFeatureText(item)
+		stubs = append(stubs, TaskPlanStub{
+			Title:       title,
+			Description: description,
+			AgentType:   InferAgentType(title, description),
+			Criteria:    []string{item},
+		})
+	}
+	return stubs
+}
+
+// resolvePlanDependencies resolves dependency references to earlier stubs (by
+// title or 1-based number) or existing task keys. References to later tasks
+// and unknown references are dropped.
+func resolvePlanDependencies(stubs []TaskPlanStub, refs [][]string) {
+	byTitle := make(map[string]int, len(stubs))
+	for i, stub := range stubs {
+		if _, exists := byTitle[strings.ToLower(stub.Title)]; !exists {
+			byTitle[strings.ToLower(stub.Title)] = i
+		}
+	}
+
+	for i := range stubs {
+		seen := make(map[int]bool)
+		for _, ref := range refs[i] {
+			if taskKeyPattern.MatchString(ref) {
+				stubs[i].DependsKeys = append(stubs[i].DependsKeys, strings.ToUpper(ref))
+				continue
+			}
+			index := -1
+			if n, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+				index = n - 1
+			} else if j, ok := byTitle[strings.ToLower(ref)]; ok {
+				index = j
+			}
+			if index >= 0 && index < i && !seen[index] {
+				seen[index] = true
+				stubs[i].DependsOn = append(stubs[i].DependsOn, index)
+			}
+		}
+	}
+}
+
+// InferAgentType guesses an agent type (frontend, backend, testing, devops)
+// from keywords in a task's title and description. Title words count three
+// times as much. Returns "" when no keyword matches.
+func InferAgentType(title, description string) string {
+	scores := make(map[string]int, len(agentTypeKeywords))
+	count := func(text string, weight int) {
+		for _, word := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+			for _, kw := range agentTypeKeywords {
+				for _, w := range kw.Words {
+					if word == w {
+						scores[kw.AgentType] += weight
+					}
+				}
+			}
+		}
+	}
+	count(title, 3)
+	count(description, 1)
+
+	best, bestScore := "", 0
+	for _, kw := range agentTypeKeywords {
+		if scores[kw.AgentType] > bestScore {
+			best, bestScore = kw.AgentType, scores[kw.AgentType]
+		}
+	}
+	return best
+}
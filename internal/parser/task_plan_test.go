@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaskPlan_Headings(t *testing.T) {
+	content := `# PRD: User Login
+
+## Overview
+
+Users sign in with email and password.
+
+## Requirements
+
+### 1. Users table migration
+
+Add a users table with email and password hash.
+
+### Login API endpoint
+
+POST /api/login returns a session token.
+
+**Depends on:** Users table migration
+
+#### Acceptance Criteria
+
+- Returns 401 for a wrong password
+- Rate limits to 5 attempts per minute
+
+### Login form
+
+A React page with email and password fields.
+
+- [ ] Shows an error on failed login
+- [ ] Redirects to the dashboard on success
+
+Depends on: #2
+
+### E2E tests for login
+
+Cover the happy path and lockout.
+
+Depends on: Login form, Login API endpoint, T-E01-F01-001
+
+## Open Questions
+
+- Do we need SSO?
+`
+	stubs := ParseTaskPlan(content)
+	require.Len(t, stubs, 4)
+
+	assert.Equal(t, "Users table migration", stubs[0].Title)
+	assert.Equal(t, "Add a users table with email and password hash.", stubs[0].Description)
+	assert.Equal(t, "backend", stubs[0].AgentType)
+	assert.Empty(t, stubs[0].DependsOn)
+
+	assert.Equal(t, "Login API endpoint", stubs[1].Title)
+	assert.Equal(t, "POST /api/login returns a session token.", stubs[1].Description)
+	assert.Equal(t, []string{"Returns 401 for a wrong password", "Rate limits to 5 attempts per minute"}, stubs[1].Criteria)
+	assert.Equal(t, []int{0}, stubs[1].DependsOn)
+	assert.Equal(t, "backend", stubs[1].AgentType)
+
+	assert.Equal(t, "Login form", stubs[2].Title)
+	assert.Equal(t, []string{"Shows an error on failed login", "Redirects to the dashboard on success"}, stubs[2].Criteria)
+	assert.Equal(t, []int{1}, stubs[2].DependsOn)
+	assert.Equal(t, "frontend", stubs[2].AgentType)
+
+	assert.Equal(t, "E2E tests for login", stubs[3].Title)
+	assert.Equal(t, []int{2, 1}, stubs[3].DependsOn)
+	assert.Equal(t, []string{"T-E01-F01-001"}, stubs[3].DependsKeys)
+	assert.Equal(t, "testing", stubs[3].AgentType)
+}
+
+func TestParseTaskPlan_TopLevelSections(t *testing.T) {
+	content := `## Background
+
+Why we need this.
+
+## Deploy pipeline
+
+Build and push the Docker image.
+
+Acceptance Criteria:
+- Image is tagged with the commit SHA
+- Pipeline fails on test failures
+
+Notes after the list.
+
+## Write docs
+`
+	stubs := ParseTaskPlan(content)
+	require.Len(t, stubs, 2)
+	assert.Equal(t, "Deploy pipeline", stubs[0].Title)
+	assert.Equal(t, "devops", stubs[0].AgentType)
+	assert.Equal(t, []string{"Image is tagged with the commit SHA", "Pipeline fails on test failures"}, stubs[0].Criteria)
+	assert.Equal(t, "Build and push the Docker image.\n\nNotes after the list.", stubs[0].Description)
+	assert.Equal(t, "Write docs", stubs[1].Title)
+	assert.Equal(t, "", stubs[1].AgentType)
+}
+
+func TestParseTaskPlan_CriteriaOnly(t *testing.T) {
+	content := `# Password reset
+
+## Acceptance Criteria
+
+- Reset email: sent within a minute
+- [ ] Reset link expires after 24 hours
+`
+	stubs := ParseTaskPlan(content)
+	require.Len(t, stubs, 2)
+	assert.Equal(t, "Reset email", stubs[0].Title)
+	assert.Equal(t, "sent within a minute", stubs[0].Description)
+	assert.Equal(t, "Reset link expires after 24 hours", stubs[1].Title)
+}
+
+func TestParseTaskPlan_IgnoresCodeBlocks(t *testing.T) {
+	content := "## Build parser\n\n```\n## not a heading\n```\n\n## Test parser\n"
+	stubs := ParseTaskPlan(content)
+	require.Len(t, stubs, 2)
+	assert.Equal(t, "Build parser", stubs[0].Title)
+	assert.Contains(t, stubs[0].Description, "## not a heading")
+}
+
+func TestParseTaskPlan_Empty(t *testing.T) {
+	assert.Empty(t, ParseTaskPlan("Just some prose.\n"))
+}
+
+func TestInferAgentType(t *testing.T) {
+	assert.Equal(t, "frontend", InferAgentType("Settings page", ""))
+	assert.Equal(t, "backend", InferAgentType("Add API endpoint", "returns JSON"))
+	assert.Equal(t, "testing", InferAgentType("Integration tests", "for the API"))
+	assert.Equal(t, "", InferAgentType("Write docs", "explain the feature"))
+}
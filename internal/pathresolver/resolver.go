@@ -61,7 +61,7 @@ func (pr *PathResolver) ResolveEpicPath(ctx context.Context, epicKey string) (st
 
 	// Precedence 1: Explicit file_path
 	if epic.FilePath != nil && *epic.FilePath != "" {
-		return filepath.Join(pr.projectRoot, *epic.FilePath), nil
+		return pr.projectPath(*epic.FilePath), nil
 	}
 
 	// Precedence 2: Default path (docs/plan/{epic-key}/epic.md)
@@ -85,7 +85,7 @@ func (pr *PathResolver) ResolveFeaturePath(ctx context.Context, featureKey strin
 
 	// Precedence 1: Explicit file_path
 	if feature.FilePath != nil && *feature.FilePath != "" {
-		return filepath.Join(pr.projectRoot, *feature.FilePath), nil
+		return pr.projectPath(*feature.FilePath), nil
 	}
 
 	// Precedence 2: Default path (docs/plan/{epic-key}/{feature-key}/prd.md)
@@ -125,7 +125,7 @@ func (pr *PathResolver) ResolveTaskPath(ctx context.Context, taskKey string) (st
 
 	// Precedence 1: Explicit file_path
 	if task.FilePath != nil && *task.FilePath != "" {
-		return filepath.Join(pr.projectRoot, *task.FilePath), nil
+		return pr.projectPath(*task.FilePath), nil
 	}
 
 	// Precedence 2: Default path based on feature's location
@@ -173,3 +173,12 @@ func (pr *PathResolver) ResolveTaskPath(ctx context.Context, taskKey string) (st
 
 	return filepath.Join(pr.projectRoot, taskPath), nil
 }
+
+// projectPath makes a stored file path absolute. Stored paths are relative to
+// the project root, but older ones may already be absolute.
+func (pr *PathResolver) projectPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(pr.projectRoot, path)
+}
@@ -330,6 +330,26 @@ func TestResolveTaskPath_ExplicitFilePath(t *testing.T) {
 	}
 }
 
+func TestResolveTaskPath_AbsoluteFilePath(t *testing.T) {
+	ctx := context.Background()
+	taskPath := filepath.Join(t.TempDir(), "docs", "my-task.md")
+
+	mockTaskRepo := &MockTaskRepository{
+		GetByKeyFunc: func(ctx context.Context, key string) (*models.Task, error) {
+			return &models.Task{ID: 1, FeatureID: 1, Key: "T-E01-F01-001", Title: "Test Task", FilePath: &taskPath}, nil
+		},
+	}
+
+	resolver := NewPathResolver(&MockEpicRepository{}, &MockFeatureRepository{}, mockTaskRepo, "/project")
+	path, err := resolver.ResolveTaskPath(ctx, "T-E01-F01-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != taskPath {
+		t.Errorf("expected absolute file path %s to be used as is, got %s", taskPath, path)
+	}
+}
+
 // TestPathPrecedence tests that path precedence is correctly followed
 func TestPathPrecedence_EpicWithAllOptions(t *testing.T) {
 	ctx := context.Background()
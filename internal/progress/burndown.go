@@ -0,0 +1,99 @@
+package progress
+
+import (
+	"math"
+	"time"
+)
+
+// Sample is a single progress measurement at a point in time
+type Sample struct {
+	RecordedAt     time.Time
+	ProgressPct    float64
+	TotalTasks     int
+	CompletedTasks int
+}
+
+// BurndownPoint is one day of a burndown chart
+type BurndownPoint struct {
+	Date           string  `json:"date"` // YYYY-MM-DD
+	ProgressPct    float64 `json:"progress_pct"`
+	RemainingPct   float64 `json:"remaining_pct"`
+	TotalTasks     int     `json:"total_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	RemainingTasks int     `json:"remaining_tasks"`
+}
+
+// Burndown summarizes progress history and projects a completion date
+type Burndown struct {
+	Points              []BurndownPoint `json:"points"`
+	DailyRate           float64         `json:"daily_rate"`                     // Average progress gained per day (percentage points)
+	ProjectedCompletion *time.Time      `json:"projected_completion,omitempty"` // nil when progress is flat or regressing
+	TargetDate          *time.Time      `json:"target_date,omitempty"`
+	OnTrack             *bool           `json:"on_track,omitempty"` // nil when there is no target or projection
+}
+
+// BuildBurndown builds a burndown from chronologically ordered samples.
+// Samples are bucketed by calendar day, keeping the last sample of each day.
+// The daily rate is the average progress gained per day between the first
+// and last sample; the projected completion date extrapolates that rate
+// linearly to 100%.
+func BuildBurndown(samples []Sample, target *time.Time) *Burndown {
+	burndown := &Burndown{
+		Points:     []BurndownPoint{},
+		TargetDate: target,
+	}
+	if len(samples) == 0 {
+		return burndown
+	}
+
+	for _, s := range samples {
+		point := BurndownPoint{
+			Date:           s.RecordedAt.Format("2006-01-02"),
+			ProgressPct:    round1(s.ProgressPct),
+			RemainingPct:   round1(100.0 - s.ProgressPct),
+			TotalTasks:     s.TotalTasks,
+			CompletedTasks: s.CompletedTasks,
+			RemainingTasks: s.TotalTasks - s.CompletedTasks,
+		}
+		if n := len(burndown.Points); n > 0 && burndown.Points[n-1].Date == point.Date {
+			burndown.Points[n-1] = point
+			continue
+		}
+		burndown.Points = append(burndown.Points, point)
+	}
+
+	first := samples[0]
+	last := samples[len(samples)-1]
+
+	if last.ProgressPct >= 100.0 {
+		completedAt := last.RecordedAt
+		burndown.ProjectedCompletion = &completedAt
+	} else {
+		days := last.RecordedAt.Sub(first.RecordedAt).Hours() / 24
+		if days > 0 {
+			burndown.DailyRate = round1((last.ProgressPct - first.ProgressPct) / days)
+		}
+		if burndown.DailyRate > 0 {
+			remainingDays := (100.0 - last.ProgressPct) / burndown.DailyRate
+			projected := last.RecordedAt.Add(time.Duration(remainingDays * 24 * float64(time.Hour)))
+			burndown.ProjectedCompletion = &projected
+		}
+	}
+
+	if target != nil && burndown.ProjectedCompletion != nil {
+		onTrack := !burndown.ProjectedCompletion.After(endOfDay(*target))
+		burndown.OnTrack = &onTrack
+	}
+
+	return burndown
+}
+
+// round1 rounds to one decimal place
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// endOfDay returns the last instant of the given day
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
@@ -0,0 +1,62 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBurndown_Empty(t *testing.T) {
+	burndown := BuildBurndown(nil, nil)
+
+	assert.Empty(t, burndown.Points)
+	assert.Nil(t, burndown.ProjectedCompletion)
+	assert.Nil(t, burndown.OnTrack)
+}
+
+func TestBuildBurndown_BucketsByDayAndProjects(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	samples := []Sample{
+		{RecordedAt: day(1, 9), ProgressPct: 0, TotalTasks: 10},
+		{RecordedAt: day(1, 17), ProgressPct: 5, TotalTasks: 10},
+		{RecordedAt: day(3, 9), ProgressPct: 20, TotalTasks: 10, CompletedTasks: 2},
+		{RecordedAt: day(5, 9), ProgressPct: 40, TotalTasks: 10, CompletedTasks: 4},
+	}
+
+	target := day(20, 0)
+	burndown := BuildBurndown(samples, &target)
+
+	require.Len(t, burndown.Points, 3)
+	assert.Equal(t, "2026-03-01", burndown.Points[0].Date)
+	assert.Equal(t, 5.0, burndown.Points[0].ProgressPct)
+	assert.Equal(t, 95.0, burndown.Points[0].RemainingPct)
+	assert.Equal(t, 6, burndown.Points[2].RemainingTasks)
+
+	// 40 points over 4 days = 10/day; 60 remaining = 6 days after Mar 5
+	assert.Equal(t, 10.0, burndown.DailyRate)
+	require.NotNil(t, burndown.ProjectedCompletion)
+	assert.Equal(t, day(11, 9), *burndown.ProjectedCompletion)
+	require.NotNil(t, burndown.OnTrack)
+	assert.True(t, *burndown.OnTrack)
+
+	early := day(8, 0)
+	burndown = BuildBurndown(samples, &early)
+	require.NotNil(t, burndown.OnTrack)
+	assert.False(t, *burndown.OnTrack)
+}
+
+func TestBuildBurndown_FlatProgressHasNoProjection(t *testing.T) {
+	samples := []Sample{
+		{RecordedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), ProgressPct: 30},
+		{RecordedAt: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), ProgressPct: 30},
+	}
+	target := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	burndown := BuildBurndown(samples, &target)
+
+	assert.Equal(t, 0.0, burndown.DailyRate)
+	assert.Nil(t, burndown.ProjectedCompletion)
+	assert.Nil(t, burndown.OnTrack)
+}
@@ -19,6 +19,9 @@ type ProgressInfo struct {
 	WeightedRatio   string  // "3.4/5" (weighted tasks complete)
 	CompletionRatio string  // "2/5" (completed tasks / total)
 	TotalTasks      int     // Total task count
+
+	EstimatePct   *float64 `json:",omitempty"` // Progress weighted by task estimates (only with --weighted)
+	EstimateRatio string   `json:",omitempty"` // "8.0/15.0" (completed estimate / total estimate)
 }
 
 // CalculateProgress calculates weighted and completion progress from status counts
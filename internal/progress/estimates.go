@@ -0,0 +1,45 @@
+package progress
+
+import "fmt"
+
+// EstimateTotals are the task counts and estimate sums behind progress
+// weighted by task estimates. The sums are aggregated in SQL. Done counts and
+// sums weigh each task by its status's progress weight, so a completed task
+// counts fully and a task in review partly. Estimates are all in one unit.
+type EstimateTotals struct {
+	Tasks                   int
+	CompletedTasks          float64 // Tasks weighted by status progress weight
+	EstimatedTasks          int
+	CompletedEstimatedTasks float64 // Estimated tasks weighted by status progress weight
+	Estimate                float64
+	CompletedEstimate       float64 // Estimates weighted by status progress weight
+}
+
+// WeightedPct returns completed estimate / total estimate as a percentage.
+// Tasks without an estimate weigh as much as the average estimated task, so
+// a partly estimated feature isn't skewed; with no estimates (or only zero
+// estimates) every task weighs the same, as in unweighted progress.
+func (t EstimateTotals) WeightedPct() float64 {
+	completed, total := t.weights()
+	if total == 0 {
+		return 0
+	}
+	return completed / total * 100.0
+}
+
+// Ratio returns the completed and total weight, e.g. "8.0/15.0"
+func (t EstimateTotals) Ratio() string {
+	completed, total := t.weights()
+	return fmt.Sprintf("%.1f/%.1f", completed, total)
+}
+
+// weights returns the completed and total weight of the tasks
+func (t EstimateTotals) weights() (completed, total float64) {
+	if t.EstimatedTasks == 0 || t.Estimate <= 0 {
+		return t.CompletedTasks, float64(t.Tasks)
+	}
+	average := t.Estimate / float64(t.EstimatedTasks)
+	total = t.Estimate + float64(t.Tasks-t.EstimatedTasks)*average
+	completed = t.CompletedEstimate + (t.CompletedTasks-t.CompletedEstimatedTasks)*average
+	return completed, total
+}
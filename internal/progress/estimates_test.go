@@ -0,0 +1,42 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTotals_WeightedPct(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals EstimateTotals
+		want   float64
+	}{
+		{"no tasks", EstimateTotals{}, 0},
+		{"no estimates falls back to task counts", EstimateTotals{Tasks: 4, CompletedTasks: 1}, 25},
+		{"zero estimates fall back to task counts", EstimateTotals{Tasks: 2, CompletedTasks: 1, EstimatedTasks: 2}, 50},
+		{
+			"completed estimate over total estimate",
+			EstimateTotals{Tasks: 2, CompletedTasks: 1, EstimatedTasks: 2, CompletedEstimatedTasks: 1, Estimate: 10, CompletedEstimate: 8},
+			80,
+		},
+		{
+			// Two tasks are estimated at 10 in all; the completed, unestimated
+			// one weighs their average of 5
+			"unestimated tasks weigh the average estimate",
+			EstimateTotals{Tasks: 3, CompletedTasks: 1, EstimatedTasks: 2, Estimate: 10},
+			100.0 / 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.totals.WeightedPct(), 0.001)
+		})
+	}
+}
+
+func TestEstimateTotals_Ratio(t *testing.T) {
+	totals := EstimateTotals{Tasks: 3, CompletedTasks: 1, EstimatedTasks: 2, CompletedEstimatedTasks: 1, Estimate: 10, CompletedEstimate: 8}
+	assert.Equal(t, "8.0/15.0", totals.Ratio())
+	assert.Equal(t, "1.0/4.0", EstimateTotals{Tasks: 4, CompletedTasks: 1}.Ratio(), "task counts without estimates")
+}
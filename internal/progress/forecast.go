@@ -0,0 +1,169 @@
+package progress
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Forecast bounds reported by BuildForecast
+const (
+	ForecastLimitedByDependencies = "dependencies" // The critical path sets the pace
+	ForecastLimitedByCapacity     = "capacity"     // There is more work than the agents can run in parallel
+)
+
+// CycleTime summarizes how long tasks took from start to completion
+type CycleTime struct {
+	Samples     int     `json:"samples"`
+	MeanHours   float64 `json:"mean_hours"`
+	StdDevHours float64 `json:"stddev_hours"`
+}
+
+// NewCycleTime summarizes cycle times given in hours
+func NewCycleTime(hours []float64) CycleTime {
+	cycle := CycleTime{Samples: len(hours)}
+	if len(hours) == 0 {
+		return cycle
+	}
+
+	var sum float64
+	for _, h := range hours {
+		sum += h
+	}
+	mean := sum / float64(len(hours))
+
+	var squares float64
+	for _, h := range hours {
+		squares += (h - mean) * (h - mean)
+	}
+	cycle.MeanHours = round1(mean)
+	cycle.StdDevHours = round1(math.Sqrt(squares / float64(len(hours))))
+	return cycle
+}
+
+// ForecastTask is an open task to forecast
+type ForecastTask struct {
+	Key          string
+	DependsOn    []string // Keys of the tasks it waits for; finished or unknown tasks are ignored
+	ElapsedHours float64  // Time already spent on a task in progress
+}
+
+// ForecastPathTask is a task on the critical path
+type ForecastPathTask struct {
+	Key            string  `json:"key"`
+	RemainingHours float64 `json:"remaining_hours"`
+}
+
+// Forecast estimates when a set of open tasks will be finished
+type Forecast struct {
+	OpenTasks     int                `json:"open_tasks"`
+	CycleTime     CycleTime          `json:"cycle_time"`
+	Agents        int                `json:"agents"` // 0 when unlimited
+	LimitedBy     string             `json:"limited_by"`
+	Earliest      time.Time          `json:"earliest"`
+	Expected      time.Time          `json:"expected"`
+	Latest        time.Time          `json:"latest"`
+	ExpectedHours float64            `json:"expected_hours"`
+	CriticalPath  []ForecastPathTask `json:"critical_path"`
+}
+
+// BuildForecast estimates when tasks will be finished if each takes the
+// average cycle time. Tasks that depend on each other run one after another,
+// so the longest chain of dependencies (the critical path) sets the pace;
+// with agents > 0, the total work shared between that many agents can set it
+// instead. Tasks in progress only count the part of the cycle time not yet
+// spent. The earliest and latest dates use the cycle time one standard
+// deviation below and above the average.
+func BuildForecast(tasks []ForecastTask, cycle CycleTime, agents int, now time.Time) *Forecast {
+	forecast := &Forecast{
+		OpenTasks:    len(tasks),
+		CycleTime:    cycle,
+		Agents:       agents,
+		LimitedBy:    ForecastLimitedByDependencies,
+		CriticalPath: []ForecastPathTask{},
+	}
+
+	open := make(map[string]ForecastTask, len(tasks))
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		open[task.Key] = task
+		keys = append(keys, task.Key)
+	}
+	sort.Strings(keys)
+
+	at := func(hours float64) time.Time {
+		return now.Add(time.Duration(hours * float64(time.Hour)))
+	}
+
+	low := math.Max(cycle.MeanHours-cycle.StdDevHours, 0)
+	lowHours, _, _ := scheduleForecast(open, keys, low, agents)
+	expectedHours, limitedBy, path := scheduleForecast(open, keys, cycle.MeanHours, agents)
+	highHours, _, _ := scheduleForecast(open, keys, cycle.MeanHours+cycle.StdDevHours, agents)
+
+	forecast.Earliest = at(lowHours)
+	forecast.Expected = at(expectedHours)
+	forecast.Latest = at(highHours)
+	forecast.ExpectedHours = round1(expectedHours)
+	forecast.LimitedBy = limitedBy
+	for _, step := range path {
+		forecast.CriticalPath = append(forecast.CriticalPath, ForecastPathTask{
+			Key:            step.Key,
+			RemainingHours: round1(remainingHours(step, cycle.MeanHours)),
+		})
+	}
+	return forecast
+}
+
+// scheduleForecast returns how many hours the open tasks take when each one
+// takes cycleHours, what limits that, and the critical path from its first
+// task to its last. keys are the open task keys in a stable order.
+func scheduleForecast(open map[string]ForecastTask, keys []string, cycleHours float64, agents int) (float64, string, []ForecastTask) {
+	finish := map[string]float64{}
+	prev := map[string]string{}
+	var finishOf func(key string, visiting map[string]bool) float64
+	finishOf = func(key string, visiting map[string]bool) float64 {
+		if f, ok := finish[key]; ok {
+			return f
+		}
+		visiting[key] = true
+		var start float64
+		for _, dep := range open[key].DependsOn {
+			// Dependencies that aren't open or are in a cycle don't delay the task
+			if _, ok := open[dep]; !ok || visiting[dep] {
+				continue
+			}
+			if f := finishOf(dep, visiting); f > start || (f == start && prev[key] == "") {
+				start = f
+				prev[key] = dep
+			}
+		}
+		delete(visiting, key)
+		finish[key] = start + remainingHours(open[key], cycleHours)
+		return finish[key]
+	}
+
+	var span, total float64
+	last := ""
+	for _, key := range keys {
+		f := finishOf(key, map[string]bool{})
+		if last == "" || f > span {
+			span, last = f, key
+		}
+		total += remainingHours(open[key], cycleHours)
+	}
+
+	var path []ForecastTask
+	for key := last; key != ""; key = prev[key] {
+		path = append([]ForecastTask{open[key]}, path...)
+	}
+
+	if agents > 0 && total/float64(agents) > span {
+		return total / float64(agents), ForecastLimitedByCapacity, path
+	}
+	return span, ForecastLimitedByDependencies, path
+}
+
+// remainingHours is the part of the cycle time a task hasn't spent yet
+func remainingHours(task ForecastTask, cycleHours float64) float64 {
+	return math.Max(cycleHours-task.ElapsedHours, 0)
+}
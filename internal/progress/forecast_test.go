@@ -0,0 +1,65 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCycleTime(t *testing.T) {
+	assert.Equal(t, CycleTime{}, NewCycleTime(nil))
+
+	cycle := NewCycleTime([]float64{4, 8, 12})
+	assert.Equal(t, 3, cycle.Samples)
+	assert.Equal(t, 8.0, cycle.MeanHours)
+	assert.Equal(t, 3.3, cycle.StdDevHours)
+}
+
+func TestBuildForecast_FollowsCriticalPath(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	cycle := CycleTime{Samples: 10, MeanHours: 10, StdDevHours: 5}
+	tasks := []ForecastTask{
+		{Key: "T-E01-F01-001", ElapsedHours: 4},                      // In progress: 6h left
+		{Key: "T-E01-F01-002", DependsOn: []string{"T-E01-F01-001"}}, // 16h
+		{Key: "T-E01-F01-003", DependsOn: []string{"T-E01-F01-002", "T-E01-F01-004", "T-E09-F01-001"}},
+		{Key: "T-E01-F01-004"},
+		{Key: "T-E01-F01-005"},
+	}
+
+	forecast := BuildForecast(tasks, cycle, 0, now)
+
+	assert.Equal(t, 5, forecast.OpenTasks)
+	assert.Equal(t, ForecastLimitedByDependencies, forecast.LimitedBy)
+	assert.Equal(t, 26.0, forecast.ExpectedHours)
+	assert.Equal(t, now.Add(26*time.Hour), forecast.Expected)
+	assert.Equal(t, now.Add(11*time.Hour), forecast.Earliest, "5h cycle: the started task is already past it")
+	assert.Equal(t, now.Add(41*time.Hour), forecast.Latest)
+
+	require.Len(t, forecast.CriticalPath, 3)
+	assert.Equal(t, "T-E01-F01-001", forecast.CriticalPath[0].Key)
+	assert.Equal(t, 6.0, forecast.CriticalPath[0].RemainingHours)
+	assert.Equal(t, "T-E01-F01-002", forecast.CriticalPath[1].Key)
+	assert.Equal(t, "T-E01-F01-003", forecast.CriticalPath[2].Key)
+}
+
+func TestBuildForecast_LimitedByAgents(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	cycle := CycleTime{Samples: 4, MeanHours: 8}
+	tasks := []ForecastTask{{Key: "A"}, {Key: "B"}, {Key: "C"}, {Key: "D", DependsOn: []string{"E"}}, {Key: "E", DependsOn: []string{"D"}}}
+
+	forecast := BuildForecast(tasks, cycle, 2, now)
+
+	assert.Equal(t, ForecastLimitedByCapacity, forecast.LimitedBy)
+	assert.Equal(t, 20.0, forecast.ExpectedHours, "40h of work shared by 2 agents")
+	assert.Len(t, forecast.CriticalPath, 2, "a dependency cycle is followed once")
+}
+
+func TestBuildForecast_NothingOpen(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	forecast := BuildForecast(nil, CycleTime{}, 0, now)
+
+	assert.Equal(t, now, forecast.Expected)
+	assert.Empty(t, forecast.CriticalPath)
+}
@@ -0,0 +1,260 @@
+// Package reload keeps the project settings of a long-running server current:
+// the workflow from .sharkconfig.json (or the project database), and the hooks
+// and health rules from .shark.yaml. A Reloader watches both files and, when
+// one changes, loads the settings again, logs what changed, and hands the new
+// settings to the parts of the server that use them.
+//
+// A file that fails to load keeps the settings it held: a typo in .shark.yaml
+// is logged and doesn't turn the server's hooks off.
+package reload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/hooks"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/jwwelbor/shark-task-manager/internal/watch"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDebounce is how long a config file must be quiet before it is reloaded
+const DefaultDebounce = 250 * time.Millisecond
+
+// Settings are the project settings a server can reload without restarting
+type Settings struct {
+	Workflow *config.WorkflowConfig // Never nil; the default workflow if none is configured
+	Health   *workspace.HealthRules // nil for the default health rules
+	Hooks    []workspace.Hook
+}
+
+// Change is one setting that differs between two Settings
+type Change struct {
+	Setting string // Dotted path, e.g. workflow.status_flow.todo or health.progress.warning
+	From    string // Empty when the setting was added
+	To      string // Empty when the setting was removed
+}
+
+// Load reads the settings of the project at projectRoot. A part that fails to
+// load keeps its value from previous, or its default when previous is nil, and
+// its error is returned along with the settings.
+func Load(ctx context.Context, db *repository.DB, configPath, projectRoot string, previous *Settings) (*Settings, error) {
+	settings := &Settings{Workflow: config.DefaultWorkflow()}
+	if previous != nil {
+		*settings = *previous
+	}
+
+	var errs []error
+	if workflow, err := config.ReloadWorkflowConfig(configPath); err != nil {
+		errs = append(errs, err)
+	} else if workflow != nil {
+		settings.Workflow = workflow
+	} else {
+		settings.Workflow = config.DefaultWorkflow()
+	}
+
+	if health, err := status.LoadProjectHealthRules(ctx, projectRoot, db); err != nil {
+		errs = append(errs, err)
+	} else {
+		settings.Health = health
+	}
+
+	if configured, err := hooks.Load(projectRoot); err != nil {
+		errs = append(errs, err)
+	} else {
+		settings.Hooks = configured
+	}
+
+	return settings, errors.Join(errs...)
+}
+
+// Diff lists the settings that differ between before and after, sorted by
+// setting
+func Diff(before, after *Settings) []Change {
+	old, next := flattenSettings(before), flattenSettings(after)
+
+	var changes []Change
+	for setting, from := range old {
+		if to := next[setting]; to != from {
+			changes = append(changes, Change{Setting: setting, From: from, To: to})
+		}
+	}
+	for setting, to := range next {
+		if _, ok := old[setting]; !ok {
+			changes = append(changes, Change{Setting: setting, To: to})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Setting < changes[j].Setting })
+	return changes
+}
+
+// Reloader loads a project's settings and keeps them current
+type Reloader struct {
+	db          *repository.DB
+	configPath  string
+	projectRoot string
+	logger      *slog.Logger
+
+	mu        sync.Mutex
+	current   *Settings
+	listeners []func(*Settings)
+}
+
+// New loads the settings of the project at projectRoot. Parts that fail to
+// load use their defaults, and their error is returned with a Reloader that
+// can still be used.
+func New(ctx context.Context, db *repository.DB, configPath, projectRoot string, logger *slog.Logger) (*Reloader, error) {
+	settings, err := Load(ctx, db, configPath, projectRoot, nil)
+	return &Reloader{db: db, configPath: configPath, projectRoot: projectRoot, logger: logger, current: settings}, err
+}
+
+// Current returns the settings in use
+func (r *Reloader) Current() *Settings {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// OnReload registers fn to be called with the new settings after each reload
+// that changes them
+func (r *Reloader) OnReload(fn func(*Settings)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// Reload loads the settings again, logs each change, and passes the new
+// settings to the OnReload listeners if anything changed. It returns the
+// changes made; a load error is logged and returned with the changes that
+// could still be applied.
+func (r *Reloader) Reload(ctx context.Context) ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings, err := Load(ctx, r.db, r.configPath, r.projectRoot, r.current)
+	if err != nil {
+		r.logger.Warn("Failed to reload some project settings; keeping their current values", "error", err)
+	}
+
+	changes := Diff(r.current, settings)
+	if len(changes) == 0 {
+		r.logger.Debug("Project settings reloaded; nothing changed")
+		return nil, err
+	}
+	for _, change := range changes {
+		r.logger.Info("Setting changed", "setting", change.Setting, "from", change.From, "to", change.To)
+	}
+	r.logger.Info("Project settings reloaded", "changes", len(changes))
+
+	r.current = settings
+	for _, fn := range r.listeners {
+		fn(settings)
+	}
+	return changes, err
+}
+
+// Watch reloads the settings whenever .sharkconfig.json or .shark.yaml changes,
+// once the file has been quiet for debounce. Watch returns when ctx is
+// cancelled.
+func (r *Reloader) Watch(ctx context.Context, debounce time.Duration) error {
+	paths := []string{r.configPath, filepath.Join(r.projectRoot, workspace.ProjectFileName)}
+	return watch.WatchFiles(ctx, paths, debounce, func(path string) {
+		r.logger.Debug("Config file changed", "path", path)
+		_, _ = r.Reload(ctx)
+	})
+}
+
+// flattenSettings describes settings as a map from dotted setting path to
+// value, so two sets of settings can be compared field by field
+func flattenSettings(settings *Settings) map[string]string {
+	out := make(map[string]string)
+	if settings == nil {
+		return out
+	}
+
+	if settings.Workflow != nil {
+		if data, err := json.Marshal(settings.Workflow); err == nil {
+			var fields interface{}
+			if json.Unmarshal(data, &fields) == nil {
+				flatten("workflow", fields, out)
+			}
+		}
+	}
+
+	if settings.Health != nil {
+		if data, err := yaml.Marshal(settings.Health); err == nil {
+			var fields interface{}
+			if yaml.Unmarshal(data, &fields) == nil {
+				flatten("health", fields, out)
+			}
+		}
+	}
+
+	for i, hook := range settings.Hooks {
+		out[fmt.Sprintf("hooks.%d", i+1)] = describeHook(hook)
+	}
+	return out
+}
+
+// flatten adds value to out under prefix, descending into maps and lists of
+// maps. Lists of plain values are kept whole.
+func flatten(prefix string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, field := range v {
+			flatten(prefix+"."+key, field, out)
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for i, item := range v {
+			if _, ok := item.(map[string]interface{}); ok {
+				flatten(fmt.Sprintf("%s.%d", prefix, i+1), item, out)
+			} else {
+				items = append(items, fmt.Sprint(item))
+			}
+		}
+		if len(items) == len(v) {
+			out[prefix] = "[" + strings.Join(items, ", ") + "]"
+		}
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// describeHook summarizes a hook for the reload log. Webhook URLs are shown
+// without their query string, which may hold a secret.
+func describeHook(hook workspace.Hook) string {
+	var when string
+	switch hook.Event {
+	case hooks.EventProgress:
+		when = fmt.Sprintf("progress %d%%", hook.Threshold)
+		if hook.Scope != "" {
+			when += " (" + hook.Scope + ")"
+		}
+	default:
+		when = hook.Event
+	}
+
+	if hook.Webhook != "" {
+		target := hook.Webhook
+		if u, err := url.Parse(hook.Webhook); err == nil {
+			u.RawQuery, u.Fragment, u.User = "", "", nil
+			target = u.String()
+		}
+		return when + ": webhook " + target
+	}
+	return when + ": run " + hook.Run
+}
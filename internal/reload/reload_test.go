@@ -0,0 +1,113 @@
+package reload
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProject(t *testing.T) (*repository.DB, string) {
+	root := t.TempDir()
+	sqlDB, err := db.InitDB(filepath.Join(root, "shark-tasks.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	t.Cleanup(config.ClearWorkflowCache)
+	return &repository.DB{DB: sqlDB}, root
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDiff(t *testing.T) {
+	warning, critical := 50.0, 20.0
+	before := &Settings{
+		Workflow: config.DefaultWorkflow(),
+		Health:   &workspace.HealthRules{Progress: &workspace.HealthRule{Warning: &warning}},
+		Hooks:    []workspace.Hook{{Event: "progress", Threshold: 50, Webhook: "https://example.com/hook?token=secret"}},
+	}
+	after := &Settings{
+		Workflow: config.DefaultWorkflow(),
+		Health:   &workspace.HealthRules{Progress: &workspace.HealthRule{Warning: &warning, Critical: &critical}},
+	}
+	autoActivate := false
+	after.Workflow.AutoActivate = &autoActivate
+
+	assert.Empty(t, Diff(before, before))
+	assert.Equal(t, []Change{
+		{Setting: "health.progress.critical", To: "20"},
+		{Setting: "hooks.1", From: "progress 50%: webhook https://example.com/hook"},
+		{Setting: "workflow.auto_activate", To: "false"},
+	}, Diff(before, after))
+}
+
+func TestReloader(t *testing.T) {
+	database, root := setupProject(t)
+	configPath := filepath.Join(root, ".sharkconfig.json")
+	projectPath := filepath.Join(root, workspace.ProjectFileName)
+	writeFile(t, configPath, `{}`)
+	writeFile(t, projectPath, "health:\n  blocked:\n    warning: 1\n")
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	reloader, err := New(context.Background(), database, configPath, root, logger)
+	require.NoError(t, err)
+	require.NotNil(t, reloader.Current().Health)
+	assert.Equal(t, 1.0, *reloader.Current().Health.Blocked.Warning)
+	assert.Empty(t, reloader.Current().Hooks)
+
+	reloaded := make(chan *Settings, 10)
+	reloader.OnReload(func(settings *Settings) { reloaded <- settings })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- reloader.Watch(ctx, 20*time.Millisecond)
+	}()
+
+	// Give the watcher time to register the directory
+	time.Sleep(100 * time.Millisecond)
+	writeFile(t, projectPath, "health:\n  blocked:\n    warning: 2\nhooks:\n  - event: unblocked\n    run: echo done\n")
+	writeFile(t, configPath, `{"auto_activate": false}`)
+
+	var settings *Settings
+	for settings == nil || settings.Workflow.AutoActivateEnabled() {
+		select {
+		case settings = <-reloaded:
+		case <-ctx.Done():
+			t.Fatal("settings were not reloaded")
+		}
+	}
+	assert.Equal(t, 2.0, *settings.Health.Blocked.Warning)
+	require.Len(t, settings.Hooks, 1)
+	assert.Equal(t, "echo done", settings.Hooks[0].Run)
+	assert.Same(t, settings, reloader.Current())
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Contains(t, logs.String(), "setting=health.blocked.warning from=1 to=2")
+	assert.Contains(t, logs.String(), `setting=hooks.1 from="" to="unblocked: run echo done"`)
+	assert.Contains(t, logs.String(), "setting=workflow.auto_activate from=\"\" to=false")
+
+	// A file that fails to load keeps its settings; the other file still applies
+	writeFile(t, projectPath, "hooks:\n  - event: sometimes\n    run: echo never\n")
+	writeFile(t, configPath, `{}`)
+	changes, err := reloader.Reload(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid hook 1")
+	assert.Len(t, reloader.Current().Hooks, 1)
+	assert.True(t, reloader.Current().Workflow.AutoActivateEnabled())
+	assert.NotEmpty(t, changes)
+}
@@ -21,6 +21,12 @@ const (
 
 // FormatCLI formats the scan report for CLI output
 func FormatCLI(report *ScanReport, useColor bool) string {
+	return FormatCLIWithLinks(report, useColor, LinkFormatPlain)
+}
+
+// FormatCLIWithLinks formats the scan report for CLI output, printing file
+// references of errors and warnings in the given link format
+func FormatCLIWithLinks(report *ScanReport, useColor bool, links LinkFormat) string {
 	var sb strings.Builder
 
 	// Helper functions for coloring
@@ -116,12 +122,8 @@ func FormatCLI(report *ScanReport, useColor bool) string {
 				sb.WriteString(fmt.Sprintf("%s (%d):\n", typeName, len(entries)))
 
 				for _, entry := range entries {
-					sb.WriteString(fmt.Sprintf("  %s: %s",
-						colorize("ERROR", colorRed), entry.FilePath))
-					if entry.LineNumber != nil {
-						sb.WriteString(fmt.Sprintf(":%d", *entry.LineNumber))
-					}
-					sb.WriteString("\n")
+					sb.WriteString(fmt.Sprintf("  %s: %s\n",
+						colorize("ERROR", colorRed), entryLink(entry, links)))
 					sb.WriteString(fmt.Sprintf("    %s\n", entry.Reason))
 					sb.WriteString(fmt.Sprintf("    Suggestion: %s\n", entry.SuggestedFix))
 					sb.WriteString("\n")
@@ -140,12 +142,8 @@ func FormatCLI(report *ScanReport, useColor bool) string {
 				sb.WriteString(fmt.Sprintf("%s (%d):\n", typeName, len(entries)))
 
 				for _, entry := range entries {
-					sb.WriteString(fmt.Sprintf("  %s: %s",
-						colorize("WARNING", colorYellow), entry.FilePath))
-					if entry.LineNumber != nil {
-						sb.WriteString(fmt.Sprintf(":%d", *entry.LineNumber))
-					}
-					sb.WriteString("\n")
+					sb.WriteString(fmt.Sprintf("  %s: %s\n",
+						colorize("WARNING", colorYellow), entryLink(entry, links)))
 					sb.WriteString(fmt.Sprintf("    %s\n", entry.Reason))
 					sb.WriteString(fmt.Sprintf("    Suggestion: %s\n", entry.SuggestedFix))
 					sb.WriteString("\n")
@@ -236,3 +234,12 @@ func formatErrorTypeName(errorType string) string {
 		return cases.Title(language.English).String(strings.ReplaceAll(errorType, "_", " "))
 	}
 }
+
+// entryLink formats the file reference of a skipped file entry
+func entryLink(entry SkippedFileEntry, links LinkFormat) string {
+	line := 0
+	if entry.LineNumber != nil {
+		line = *entry.LineNumber
+	}
+	return FormatFileLink(links, entry.FilePath, line)
+}
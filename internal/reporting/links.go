@@ -0,0 +1,68 @@
+package reporting
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// LinkFormat controls how file references are printed in human-readable output
+type LinkFormat string
+
+const (
+	LinkFormatPlain  LinkFormat = "plain"  // Path as-is (default)
+	LinkFormatFile   LinkFormat = "file"   // file:///abs/path
+	LinkFormatVSCode LinkFormat = "vscode" // vscode://file/abs/path:line
+)
+
+// ParseLinkFormat converts a config value to a LinkFormat.
+// Empty and unknown values fall back to plain paths.
+func ParseLinkFormat(s string) LinkFormat {
+	switch LinkFormat(strings.ToLower(strings.TrimSpace(s))) {
+	case LinkFormatFile:
+		return LinkFormatFile
+	case LinkFormatVSCode:
+		return LinkFormatVSCode
+	default:
+		return LinkFormatPlain
+	}
+}
+
+// FormatFileLink renders a file reference in the given format.
+// line is 1-based; 0 omits it. Relative paths are resolved against the working
+// directory for link formats, since editors need absolute paths. file:// URIs
+// have no line syntax, so the line is dropped for that format.
+func FormatFileLink(format LinkFormat, path string, line int) string {
+	if path == "" {
+		return ""
+	}
+
+	if format == LinkFormatPlain || format == "" {
+		if line > 0 {
+			return fmt.Sprintf("%s:%d", path, line)
+		}
+		return path
+	}
+
+	absPath := path
+	if abs, err := filepath.Abs(path); err == nil {
+		absPath = abs
+	}
+	slashPath := filepath.ToSlash(absPath)
+	if !strings.HasPrefix(slashPath, "/") {
+		// Windows drive paths (C:/...) need a leading slash in URIs
+		slashPath = "/" + slashPath
+	}
+
+	switch format {
+	case LinkFormatVSCode:
+		link := "vscode://file" + (&url.URL{Path: slashPath}).EscapedPath()
+		if line > 0 {
+			link += fmt.Sprintf(":%d", line)
+		}
+		return link
+	default:
+		return (&url.URL{Scheme: "file", Path: slashPath}).String()
+	}
+}
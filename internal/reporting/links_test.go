@@ -0,0 +1,38 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkFormat(t *testing.T) {
+	assert.Equal(t, LinkFormatPlain, ParseLinkFormat(""))
+	assert.Equal(t, LinkFormatPlain, ParseLinkFormat("bogus"))
+	assert.Equal(t, LinkFormatFile, ParseLinkFormat("file"))
+	assert.Equal(t, LinkFormatVSCode, ParseLinkFormat("VSCode"))
+}
+
+func TestFormatFileLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		format LinkFormat
+		path   string
+		line   int
+		want   string
+	}{
+		{"plain", LinkFormatPlain, "docs/plan/E01/epic.md", 0, "docs/plan/E01/epic.md"},
+		{"plain with line", LinkFormatPlain, "docs/plan/E01/epic.md", 12, "docs/plan/E01/epic.md:12"},
+		{"file uri", LinkFormatFile, "/repo/docs/plan/E01/epic.md", 12, "file:///repo/docs/plan/E01/epic.md"},
+		{"vscode", LinkFormatVSCode, "/repo/docs/plan/E01/epic.md", 0, "vscode://file/repo/docs/plan/E01/epic.md"},
+		{"vscode with line", LinkFormatVSCode, "/repo/docs/plan/E01/epic.md", 7, "vscode://file/repo/docs/plan/E01/epic.md:7"},
+		{"escapes spaces", LinkFormatFile, "/repo/my docs/task.md", 0, "file:///repo/my%20docs/task.md"},
+		{"empty path", LinkFormatVSCode, "", 3, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatFileLink(tt.format, tt.path, tt.line))
+		})
+	}
+}
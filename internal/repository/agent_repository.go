@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ErrAgentNotFound is returned for an agent that isn't registered
+var ErrAgentNotFound = errors.New("agent not registered")
+
+// agentColumns are the agents columns read by scanAgents
+const agentColumns = `id, name, agent_type, capabilities, registered_at, last_seen`
+
+// AgentRepository handles the registry of agents that work on tasks
+type AgentRepository struct {
+	db *DB
+}
+
+// NewAgentRepository creates a new AgentRepository
+func NewAgentRepository(db *DB) *AgentRepository {
+	return &AgentRepository{db: db}
+}
+
+// Register adds an agent, or updates the type and capabilities of one already
+// registered under the same name. Either way the agent is marked seen now.
+func (r *AgentRepository) Register(ctx context.Context, agent *models.Agent) error {
+	if err := models.ValidateAgentName(agent.Name); err != nil {
+		return err
+	}
+	if agent.AgentType != nil {
+		if err := models.ValidateAgentType(*agent.AgentType); err != nil {
+			return err
+		}
+	}
+	agent.Capabilities = models.NormalizeCapabilities(agent.Capabilities)
+	capabilities, err := json.Marshal(agent.Capabilities)
+	if err != nil {
+		return fmt.Errorf("failed to encode capabilities: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO agents (name, agent_type, capabilities)
+		VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET
+			agent_type = excluded.agent_type,
+			capabilities = excluded.capabilities,
+			last_seen = CURRENT_TIMESTAMP
+		RETURNING id, registered_at, last_seen
+	`, agent.Name, agent.AgentType, string(capabilities)).Scan(&agent.ID, &agent.RegisteredAt, &agent.LastSeen)
+	if err != nil {
+		return fmt.Errorf("failed to register agent: %w", err)
+	}
+	return nil
+}
+
+// Get returns the agent registered under name, or ErrAgentNotFound
+func (r *AgentRepository) Get(ctx context.Context, name string) (*models.Agent, error) {
+	agents, err := r.query(ctx, `SELECT `+agentColumns+` FROM agents WHERE name = ?`, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, name)
+	}
+	return agents[0], nil
+}
+
+// List returns every registered agent, ordered by name
+func (r *AgentRepository) List(ctx context.Context) ([]*models.Agent, error) {
+	return r.query(ctx, `SELECT `+agentColumns+` FROM agents ORDER BY name`)
+}
+
+// Heartbeat marks a registered agent seen now
+func (r *AgentRepository) Heartbeat(ctx context.Context, name string) error {
+	// Bypass DB.ExecContext: a heartbeat doesn't change anything the read cache holds
+	result, err := r.db.DB.ExecContext(ctx, `UPDATE agents SET last_seen = CURRENT_TIMESTAMP WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, name)
+	}
+	return nil
+}
+
+// AssignTask records a registered agent as the holder of a task and marks the
+// agent seen. It reports false, changing nothing, when name isn't registered.
+func (r *AgentRepository) AssignTask(ctx context.Context, name string, taskID int64) (bool, error) {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `UPDATE agents SET last_seen = CURRENT_TIMESTAMP WHERE name = ?`, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to record agent as seen: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tasks SET assigned_agent = ? WHERE id = ?`, name, taskID); err != nil {
+		return false, fmt.Errorf("failed to assign task: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// Workloads returns the tasks held by each agent, ordered by agent name: every
+// registered agent, and any other name in assigned_agent of an in-progress or
+// blocked task. Completed counts every task the agent completed.
+func (r *AgentRepository) Workloads(ctx context.Context) ([]*models.AgentWorkload, error) {
+	agents, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*models.AgentWorkload, len(agents))
+	for _, agent := range agents {
+		lastSeen := agent.LastSeen
+		byName[agent.Name] = &models.AgentWorkload{
+			Agent:      agent.Name,
+			AgentType:  agent.AgentType,
+			Registered: true,
+			LastSeen:   &lastSeen,
+			Tasks:      []string{},
+		}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT assigned_agent, key, status
+		FROM tasks
+		WHERE assigned_agent IS NOT NULL AND assigned_agent != '' AND deleted_at IS NULL
+			AND status IN (?, ?, ?)
+		ORDER BY key
+	`, models.TaskStatusInProgress, models.TaskStatusBlocked, models.TaskStatusCompleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agent workloads: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, key string
+		var status models.TaskStatus
+		if err := rows.Scan(&name, &key, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan agent workload: %w", err)
+		}
+		workload, ok := byName[name]
+		if !ok {
+			workload = &models.AgentWorkload{Agent: name, Tasks: []string{}}
+			byName[name] = workload
+		}
+		switch status {
+		case models.TaskStatusInProgress:
+			workload.InProgress++
+			workload.Tasks = append(workload.Tasks, key)
+		case models.TaskStatusBlocked:
+			workload.Blocked++
+		case models.TaskStatusCompleted:
+			workload.Completed++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate agent workloads: %w", err)
+	}
+
+	workloads := make([]*models.AgentWorkload, 0, len(byName))
+	for _, workload := range byName {
+		if workload.Registered || workload.InProgress > 0 || workload.Blocked > 0 {
+			workloads = append(workloads, workload)
+		}
+	}
+	sort.Slice(workloads, func(i, j int) bool { return workloads[i].Agent < workloads[j].Agent })
+	return workloads, nil
+}
+
+// query runs an agents query selecting agentColumns and scans the agents
+func (r *AgentRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.Agent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agents: %w", err)
+	}
+	defer rows.Close()
+
+	agents := []*models.Agent{}
+	for rows.Next() {
+		agent := &models.Agent{}
+		var agentType sql.NullString
+		var capabilities string
+		if err := rows.Scan(&agent.ID, &agent.Name, &agentType, &capabilities, &agent.RegisteredAt, &agent.LastSeen); err != nil {
+			return nil, fmt.Errorf("failed to scan agent: %w", err)
+		}
+		if agentType.Valid {
+			agent.AgentType = &agentType.String
+		}
+		if err := json.Unmarshal([]byte(capabilities), &agent.Capabilities); err != nil || agent.Capabilities == nil {
+			agent.Capabilities = []string{}
+		}
+		agents = append(agents, agent)
+	}
+	return agents, rows.Err()
+}
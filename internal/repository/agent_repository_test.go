@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	repo := NewAgentRepository(db)
+
+	backend := "backend"
+	agent := &models.Agent{Name: "worker-1", AgentType: &backend, Capabilities: []string{"Go", " sql", "go", ""}}
+	require.NoError(t, repo.Register(ctx, agent))
+	assert.NotZero(t, agent.ID)
+	assert.Equal(t, []string{"go", "sql"}, agent.Capabilities)
+
+	assert.ErrorIs(t, repo.Register(ctx, &models.Agent{Name: "-bad name"}), models.ErrInvalidAgentName)
+
+	// Registering again updates the agent in place
+	require.NoError(t, repo.Register(ctx, &models.Agent{Name: "worker-1", Capabilities: []string{"docs"}}))
+	found, err := repo.Get(ctx, "worker-1")
+	require.NoError(t, err)
+	assert.Equal(t, agent.ID, found.ID)
+	assert.Nil(t, found.AgentType)
+	assert.Equal(t, []string{"docs"}, found.Capabilities)
+
+	_, err = repo.Get(ctx, "worker-2")
+	assert.ErrorIs(t, err, ErrAgentNotFound)
+	require.NoError(t, repo.Heartbeat(ctx, "worker-1"))
+	assert.ErrorIs(t, repo.Heartbeat(ctx, "worker-2"), ErrAgentNotFound)
+
+	agents, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	assert.Equal(t, "worker-1", agents[0].Name)
+}
+
+func TestAgentRepository_AssignTaskAndWorkloads(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+
+	taskID := createTestTask(t, db) // T-E01-F01-001
+	repo := NewAgentRepository(db)
+	require.NoError(t, repo.Register(ctx, &models.Agent{Name: "worker-1"}))
+	require.NoError(t, repo.Register(ctx, &models.Agent{Name: "idle"}))
+
+	assigned, err := repo.AssignTask(ctx, "someone", taskID)
+	require.NoError(t, err)
+	assert.False(t, assigned, "unregistered agents are not recorded")
+
+	assigned, err = repo.AssignTask(ctx, "worker-1", taskID)
+	require.NoError(t, err)
+	assert.True(t, assigned)
+	task, err := NewTaskRepository(db).GetByID(ctx, taskID)
+	require.NoError(t, err)
+	require.NotNil(t, task.AssignedAgent)
+	assert.Equal(t, "worker-1", *task.AssignedAgent)
+
+	// An unregistered agent holding an in-progress task is listed too
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO tasks (feature_id, key, title, status, priority, assigned_agent)
+		SELECT feature_id, 'T-E01-F01-002', 'Other', 'in_progress', 5, 'someone' FROM tasks WHERE id = ?
+	`, taskID)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `UPDATE tasks SET status = 'in_progress' WHERE id = ?`, taskID)
+	require.NoError(t, err)
+
+	workloads, err := repo.Workloads(ctx)
+	require.NoError(t, err)
+	require.Len(t, workloads, 3)
+	assert.Equal(t, "idle", workloads[0].Agent)
+	assert.Zero(t, workloads[0].InProgress)
+	assert.Equal(t, "someone", workloads[1].Agent)
+	assert.False(t, workloads[1].Registered)
+	assert.Nil(t, workloads[1].LastSeen)
+	assert.Equal(t, "worker-1", workloads[2].Agent)
+	assert.True(t, workloads[2].Registered)
+	assert.Equal(t, 1, workloads[2].InProgress)
+	assert.Equal(t, []string{"T-E01-F01-001"}, workloads[2].Tasks)
+}
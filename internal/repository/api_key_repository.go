@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ErrInvalidAPIKey is returned for a key that doesn't exist or was revoked
+var ErrInvalidAPIKey = errors.New("invalid or revoked API key")
+
+// apiKeyColumns are the api_keys columns read by scanAPIKeys
+const apiKeyColumns = `id, name, key_hash, prefix, scope, created_at, last_used_at, revoked_at`
+
+// APIKeyRepository handles the keys API clients authenticate with
+type APIKeyRepository struct {
+	db *DB
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository
+func NewAPIKeyRepository(db *DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create generates a key with a normalized, unused name and returns it with
+// the key itself, which is not stored and can't be shown again
+func (r *APIKeyRepository) Create(ctx context.Context, name string, scope models.APIKeyScope) (*models.APIKey, string, error) {
+	if err := models.ValidateAPIKeyScope(string(scope)); err != nil {
+		return nil, "", err
+	}
+	secret, err := models.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &models.APIKey{
+		Name:    name,
+		KeyHash: models.HashAPIKey(secret),
+		Prefix:  models.APIKeyDisplayPrefix(secret),
+		Scope:   scope,
+	}
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO api_keys (name, key_hash, prefix, scope)
+		VALUES (?, ?, ?, ?)
+		RETURNING id, created_at
+	`, key.Name, key.KeyHash, key.Prefix, key.Scope).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, "", fmt.Errorf("API key %q already exists", name)
+		}
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	// The insert bypasses DB.ExecContext; drop the cached CountActive
+	r.db.Cache().Invalidate()
+	return key, secret, nil
+}
+
+// Authenticate returns the active key matching secret and records that it was
+// used. It returns ErrInvalidAPIKey for an unknown or revoked key.
+func (r *APIKeyRepository) Authenticate(ctx context.Context, secret string) (*models.APIKey, error) {
+	keys, err := r.query(ctx, `
+		SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE key_hash = ? AND revoked_at IS NULL
+	`, models.HashAPIKey(secret))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Bypass DB.ExecContext: a use doesn't change anything the read cache holds
+	if _, err := r.db.DB.ExecContext(ctx, `UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = ?`, keys[0].ID); err != nil {
+		return nil, fmt.Errorf("failed to record API key use: %w", err)
+	}
+	return keys[0], nil
+}
+
+// List returns every key, revoked ones included, ordered by name
+func (r *APIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	return r.query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY name`)
+}
+
+// CountActive returns how many keys have not been revoked. The count is
+// cached with the DB's other reads; Create and Revoke invalidate it.
+func (r *APIKeyRepository) CountActive(ctx context.Context) (int, error) {
+	return CachedRead(r.db.cache, "api-keys:active", func() (int, error) {
+		var count int
+		if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL`).Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to count API keys: %w", err)
+		}
+		return count, nil
+	})
+}
+
+// Revoke stops a key from authenticating. The key stays listed.
+func (r *APIKeyRepository) Revoke(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE name = ? AND revoked_at IS NULL
+	`, name)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("API key %q not found or already revoked", name)
+	}
+	return nil
+}
+
+// query runs an api_keys query selecting apiKeyColumns and scans the keys
+func (r *APIKeyRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key := &models.APIKey{}
+		var lastUsedAt, revokedAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.Name, &key.KeyHash, &key.Prefix, &key.Scope, &key.CreatedAt, &lastUsedAt, &revokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		if revokedAt.Valid {
+			key.RevokedAt = &revokedAt.Time
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	repo := NewAPIKeyRepository(db)
+
+	key, secret, err := repo.Create(ctx, "ci", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, models.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.NotContains(t, key.KeyHash, secret, "only the hash is stored")
+
+	_, _, err = repo.Create(ctx, "ci", models.APIKeyScopeWrite)
+	assert.Error(t, err, "names are unique")
+	_, _, err = repo.Create(ctx, "admin", models.APIKeyScope("admin"))
+	assert.ErrorIs(t, err, models.ErrInvalidAPIKeyScope)
+
+	found, err := repo.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", found.Name)
+	assert.False(t, found.CanWrite())
+
+	_, err = repo.Authenticate(ctx, secret+"x")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	keys, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt, "authenticating records the use")
+	assert.Nil(t, keys[0].RevokedAt)
+
+	count, err := repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, repo.Revoke(ctx, "ci"))
+	assert.Error(t, repo.Revoke(ctx, "ci"), "already revoked")
+	_, err = repo.Authenticate(ctx, secret)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	count, err = repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestAPIKeyRepository_CountActiveCached(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	db.EnableCache(time.Hour)
+	repo := NewAPIKeyRepository(db)
+
+	count, err := repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Creating and revoking keys invalidate the cached count
+	_, _, err = repo.Create(ctx, "ci", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	count, err = repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, repo.Revoke(ctx, "ci"))
+	count, err = repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// AuditLogRepository records and queries the audit log
+type AuditLogRepository struct {
+	db *DB
+}
+
+// NewAuditLogRepository creates a new AuditLogRepository
+func NewAuditLogRepository(db *DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// AuditFilter narrows an audit log query
+type AuditFilter struct {
+	EntityKey *string // Matches the entity and everything under it (E05 matches E05-F01 and T-E05-F01-001)
+	Action    *string
+	Actor     *string
+	Since     *time.Time
+	Limit     int // 0 means no limit
+}
+
+// Record stores an audit entry
+func (r *AuditLogRepository) Record(ctx context.Context, entry *models.AuditEntry) error {
+	if err := entry.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	var changes sql.NullString
+	if len(entry.Changes) > 0 {
+		data, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit changes: %w", err)
+		}
+		changes = sql.NullString{String: string(data), Valid: true}
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_log (entity_type, entity_key, action, changes, summary, actor)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, entry.EntityType, entry.EntityKey, entry.Action, changes, entry.Summary, entry.Actor)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	entry.ID = id
+
+	return nil
+}
+
+// List returns audit entries matching the filter, newest first
+func (r *AuditLogRepository) List(ctx context.Context, filter AuditFilter) ([]*models.AuditEntry, error) {
+	query := `
+		SELECT id, entity_type, entity_key, action, changes, summary, actor, created_at
+		FROM audit_log
+		WHERE 1 = 1
+	`
+	var args []interface{}
+	if filter.EntityKey != nil {
+		query += " AND (entity_key = ? OR entity_key LIKE ? OR entity_key LIKE ?)"
+		args = append(args, *filter.EntityKey, *filter.EntityKey+"-%", "T-"+*filter.EntityKey+"-%")
+	}
+	if filter.Action != nil {
+		query += " AND action = ?"
+		args = append(args, *filter.Action)
+	}
+	if filter.Actor != nil {
+		query += " AND actor = ?"
+		args = append(args, *filter.Actor)
+	}
+	if filter.Since != nil {
+		query += " AND created_at >= ?"
+		args = append(args, filter.Since.UTC().Format("2006-01-02 15:04:05"))
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditEntry{}
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+		var changes sql.NullString
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.EntityType,
+			&entry.EntityKey,
+			&entry.Action,
+			&changes,
+			&entry.Summary,
+			&entry.Actor,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if changes.Valid {
+			if err := json.Unmarshal([]byte(changes.String), &entry.Changes); err != nil {
+				return nil, fmt.Errorf("failed to decode changes of audit entry %d: %w", entry.ID, err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit log: %w", err)
+	}
+
+	return entries, nil
+}
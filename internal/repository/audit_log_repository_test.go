@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRepository_RecordAndList(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+	repo := NewAuditLogRepository(db)
+
+	entries := []*models.AuditEntry{
+		{EntityType: models.AuditEntityEpic, EntityKey: "E05", Action: models.AuditActionCreate, Actor: "alice"},
+		{EntityType: models.AuditEntityFeature, EntityKey: "E05-F01", Action: models.AuditActionCreate, Actor: "alice"},
+		{
+			EntityType: models.AuditEntityTask,
+			EntityKey:  "T-E05-F01-001",
+			Action:     models.AuditActionUpdate,
+			Changes:    map[string]models.AuditChange{"title": {Old: "Old", New: "New"}},
+			Actor:      "backend-agent",
+		},
+		{EntityType: models.AuditEntityEpic, EntityKey: "E50", Action: models.AuditActionDelete, Actor: "alice"},
+	}
+	for _, entry := range entries {
+		require.NoError(t, repo.Record(ctx, entry))
+	}
+	assert.Error(t, repo.Record(ctx, &models.AuditEntry{EntityKey: "E05"}))
+
+	all, err := repo.List(ctx, AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, "E50", all[0].EntityKey, "newest first")
+
+	// An entity key matches everything under it, but not a longer key
+	epicKey := "E05"
+	scoped, err := repo.List(ctx, AuditFilter{EntityKey: &epicKey})
+	require.NoError(t, err)
+	require.Len(t, scoped, 3)
+	assert.Equal(t, "T-E05-F01-001", scoped[0].EntityKey)
+	assert.Equal(t, models.AuditChange{Old: "Old", New: "New"}, scoped[0].Changes["title"])
+	assert.Nil(t, scoped[1].Changes)
+
+	actor := "alice"
+	action := models.AuditActionCreate
+	filtered, err := repo.List(ctx, AuditFilter{Actor: &actor, Action: &action, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "E05-F01", filtered[0].EntityKey)
+
+	future := time.Now().Add(time.Hour)
+	recent, err := repo.List(ctx, AuditFilter{Since: &future})
+	require.NoError(t, err)
+	assert.Empty(t, recent)
+	past := time.Now().Add(-time.Hour)
+	recent, err = repo.List(ctx, AuditFilter{Since: &past})
+	require.NoError(t, err)
+	assert.Len(t, recent, 4)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached reads are served by the long-running
+// server modes before they are read from the database again
+const DefaultCacheTTL = 5 * time.Second
+
+// ReadCache holds the results of repository reads for a short time. Long-running
+// servers enable it with DB.EnableCache so repeated requests skip the queries;
+// short-lived CLI commands leave it off.
+//
+// Every write through the same DB invalidates the whole cache, so a process
+// always reads its own writes. Transactions invalidate it when they commit,
+// so rows read while one is open aren't served after it. Writes made by other
+// processes are picked up once the TTL expires.
+type ReadCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	entries    map[string]cacheEntry
+	generation uint64 // Incremented by Invalidate
+	now        func() time.Time
+}
+
+// cacheEntry is one cached read
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewReadCache creates a ReadCache whose entries expire after ttl
+func NewReadCache(ttl time.Duration) *ReadCache {
+	return &ReadCache{ttl: ttl, entries: make(map[string]cacheEntry), now: time.Now}
+}
+
+// Invalidate drops every cached read. A nil cache is ignored.
+func (c *ReadCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry)
+	c.generation++
+}
+
+// Len returns the number of cached reads, including expired ones not yet replaced
+func (c *ReadCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// lookup returns a cached value, or the current generation if there is none
+func (c *ReadCache) lookup(key string) (interface{}, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && c.now().Before(entry.expires) {
+		return entry.value, true, c.generation
+	}
+	return nil, false, c.generation
+}
+
+// store caches a value read during generation. Values read before the last
+// Invalidate may predate a write, so they are dropped.
+func (c *ReadCache) store(key string, value interface{}, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.entries[key] = cacheEntry{value: value, expires: c.now().Add(c.ttl)}
+	}
+}
+
+// CachedRead returns the cached value for key, or calls load and caches its
+// result. Errors are not cached. With a nil cache it just calls load.
+//
+// Cached values are shared between callers, so load should return values that
+// callers don't modify, or callers must copy them.
+func CachedRead[T any](c *ReadCache, key string, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+	cached, ok, generation := c.lookup(key)
+	if ok {
+		return cached.(T), nil
+	}
+	value, err := load()
+	if err == nil {
+		c.store(key, value, generation)
+	}
+	return value, err
+}
+
+// EnableCache turns on read caching for repositories using this DB
+func (db *DB) EnableCache(ttl time.Duration) {
+	db.cache = NewReadCache(ttl)
+}
+
+// Cache returns the DB's read cache, or nil if caching is off
+func (db *DB) Cache() *ReadCache {
+	if db == nil {
+		return nil
+	}
+	return db.cache
+}
+
+// ExecContext runs a statement, retrying transient errors, and invalidates
+// the read cache. The statement is logged at debug level. Claiming a file
+// another entity claims fails with ErrFileClaimed.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer db.cache.Invalidate()
+	var result sql.Result
+	err := db.withRetry(ctx, "exec", query, func() error {
+		var err error
+		start := time.Now()
+		result, err = db.DB.ExecContext(ctx, query, args...)
+		db.logQuery(ctx, "exec", query, start, err)
+		return err
+	})
+	return result, fileClaimConflict(err)
+}
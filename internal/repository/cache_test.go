@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCache(t *testing.T) {
+	cache := NewReadCache(time.Minute)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	loads := 0
+	load := func() (int, error) {
+		loads++
+		return loads, nil
+	}
+
+	value, err := CachedRead(cache, "k", load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+	value, _ = CachedRead(cache, "k", load)
+	assert.Equal(t, 1, value, "second read should be cached")
+
+	now = now.Add(time.Minute)
+	value, _ = CachedRead(cache, "k", load)
+	assert.Equal(t, 2, value, "expired entries should be reloaded")
+
+	cache.Invalidate()
+	value, _ = CachedRead(cache, "k", load)
+	assert.Equal(t, 3, value, "invalidated entries should be reloaded")
+
+	// A read that overlaps an invalidation may predate a write, so it isn't cached
+	cache.Invalidate()
+	value, _ = CachedRead(cache, "k", func() (int, error) {
+		cache.Invalidate()
+		return 99, nil
+	})
+	assert.Equal(t, 99, value)
+	assert.Equal(t, 0, cache.Len())
+
+	// A nil cache always loads
+	var disabled *ReadCache
+	value, _ = CachedRead(disabled, "k", load)
+	assert.Equal(t, 4, value)
+}
+
+func TestDB_EnableCache(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+	database.EnableCache(time.Minute)
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+
+	epic := &models.Epic{Key: "E01", Title: "Auth", Status: models.EpicStatusActive, Priority: models.PriorityHigh}
+	require.NoError(t, epicRepo.Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Login", Status: models.FeatureStatusActive}
+	require.NoError(t, featureRepo.Create(ctx, feature))
+
+	got, err := epicRepo.GetByKey(ctx, "E01")
+	require.NoError(t, err)
+	got.Title = "Changed by caller"
+	_, err = featureRepo.GetByKey(ctx, "E01-F01")
+	require.NoError(t, err)
+	assert.Equal(t, 2, database.Cache().Len())
+
+	again, err := epicRepo.GetByKey(ctx, "E01")
+	require.NoError(t, err)
+	assert.Equal(t, "Auth", again.Title, "callers should get a copy of the cached epic")
+
+	// Writes outside the repositories still invalidate the cache
+	_, err = database.ExecContext(ctx, "UPDATE epics SET title = 'Authentication' WHERE id = ?", epic.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, database.Cache().Len())
+
+	again, err = epicRepo.GetByKey(ctx, "E01")
+	require.NoError(t, err)
+	assert.Equal(t, "Authentication", again.Title)
+}
+
+func TestDB_CacheInvalidatedOnCommit(t *testing.T) {
+	testDB, err := db.InitDB(filepath.Join(t.TempDir(), "shark-tasks.db"))
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+	database.EnableCache(time.Minute)
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	epic := &models.Epic{Key: "E01", Title: "Auth", Status: models.EpicStatusActive, Priority: models.PriorityHigh}
+	require.NoError(t, epicRepo.Create(ctx, epic))
+
+	tx, err := database.BeginTxContext(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+	_, err = tx.ExecContext(ctx, "UPDATE epics SET title = 'Authentication' WHERE id = ?", epic.ID)
+	require.NoError(t, err)
+
+	// A read while the transaction is open sees, and caches, the old title
+	during, err := epicRepo.GetByKey(ctx, "E01")
+	require.NoError(t, err)
+	assert.Equal(t, "Auth", during.Title)
+	assert.Equal(t, 1, database.Cache().Len())
+
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 0, database.Cache().Len())
+
+	after, err := epicRepo.GetByKey(ctx, "E01")
+	require.NoError(t, err)
+	assert.Equal(t, "Authentication", after.Title, "a read after commit should see the committed title")
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/jwwelbor/shark-task-manager/internal/models"
 )
@@ -328,3 +330,50 @@ func (r *DocumentRepository) ListForTask(ctx context.Context, taskID int64) ([]*
 
 	return docs, nil
 }
+
+// ListAll returns every document with the keys of the epics, features, and tasks it is linked to
+func (r *DocumentRepository) ListAll(ctx context.Context) ([]*models.DocumentWithLinks, error) {
+	query := `
+		SELECT d.id, d.title, d.file_path, d.created_at,
+			(SELECT GROUP_CONCAT(e.key) FROM epic_documents ed JOIN epics e ON e.id = ed.epic_id WHERE ed.document_id = d.id),
+			(SELECT GROUP_CONCAT(f.key) FROM feature_documents fd JOIN features f ON f.id = fd.feature_id WHERE fd.document_id = d.id AND f.deleted_at IS NULL),
+			(SELECT GROUP_CONCAT(t.key) FROM task_documents td JOIN tasks t ON t.id = td.task_id WHERE td.document_id = d.id AND t.deleted_at IS NULL)
+		FROM documents d
+		ORDER BY d.title, d.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*models.DocumentWithLinks
+	for rows.Next() {
+		doc := &models.DocumentWithLinks{}
+		var epics, features, tasks sql.NullString
+		if err := rows.Scan(&doc.ID, &doc.Title, &doc.FilePath, &doc.CreatedAt, &epics, &features, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+		doc.Epics = splitKeyList(epics)
+		doc.Features = splitKeyList(features)
+		doc.Tasks = splitKeyList(tasks)
+		docs = append(docs, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating documents: %w", err)
+	}
+
+	return docs, nil
+}
+
+// splitKeyList splits a GROUP_CONCAT result into sorted keys
+func splitKeyList(value sql.NullString) []string {
+	if !value.Valid || value.String == "" {
+		return []string{}
+	}
+	keys := strings.Split(value.String, ",")
+	sort.Strings(keys)
+	return keys
+}
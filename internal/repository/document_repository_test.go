@@ -538,3 +538,55 @@ func TestGetByTitleNotFound(t *testing.T) {
 		t.Error("Expected error for non-existent document title")
 	}
 }
+
+// TestListAllDocuments lists documents with the keys they are linked to
+func TestListAllDocuments(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskID := createTestTask(t, db)
+	task, err := NewTaskRepository(db).GetByID(ctx, taskID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	feature, err := NewFeatureRepository(db).GetByID(ctx, task.FeatureID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+
+	docRepo := NewDocumentRepository(db)
+	prd, _ := docRepo.CreateOrGet(ctx, "PRD", "docs/prd.md")
+	unlinked, _ := docRepo.CreateOrGet(ctx, "Archive", "docs/archive.md")
+	if err := docRepo.LinkToEpic(ctx, feature.EpicID, prd.ID); err != nil {
+		t.Fatalf("LinkToEpic failed: %v", err)
+	}
+	if err := docRepo.LinkToTask(ctx, taskID, prd.ID); err != nil {
+		t.Fatalf("LinkToTask failed: %v", err)
+	}
+
+	docs, err := docRepo.ListAll(ctx)
+	if err != nil {
+		t.Fatalf("ListAll failed: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("Expected 2 documents, got %d", len(docs))
+	}
+
+	// Ordered by title
+	if docs[0].ID != unlinked.ID || len(docs[0].Epics)+len(docs[0].Features)+len(docs[0].Tasks) != 0 {
+		t.Errorf("Expected unlinked Archive document first, got %+v", docs[0])
+	}
+	if docs[1].ID != prd.ID {
+		t.Fatalf("Expected PRD second, got %+v", docs[1])
+	}
+	if len(docs[1].Epics) != 1 || docs[1].Epics[0] != "E01" {
+		t.Errorf("Expected PRD linked to E01, got %v", docs[1].Epics)
+	}
+	if len(docs[1].Tasks) != 1 || docs[1].Tasks[0] != "T-E01-F01-001" {
+		t.Errorf("Expected PRD linked to T-E01-F01-001, got %v", docs[1].Tasks)
+	}
+	if len(docs[1].Features) != 0 {
+		t.Errorf("Expected no feature links, got %v", docs[1].Features)
+	}
+}
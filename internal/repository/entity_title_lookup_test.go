@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityGetByTitle(t *testing.T) {
+	ctx := context.Background()
+	database := setupCriteriaTestDB(t)
+	defer database.Close()
+
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+	taskRepo := NewTaskRepository(database)
+	ideaRepo := NewIdeaRepository(database)
+
+	e01 := &models.Epic{Key: "E01", Title: "Identity", Status: "active", Priority: "high"}
+	e02 := &models.Epic{Key: "E02", Title: "Billing", Status: "active", Priority: "high"}
+	require.NoError(t, epicRepo.Create(ctx, e01))
+	require.NoError(t, epicRepo.Create(ctx, e02))
+	f0101 := &models.Feature{EpicID: e01.ID, Key: "E01-F01", Title: "Login", Status: "active"}
+	require.NoError(t, featureRepo.Create(ctx, f0101))
+	task := &models.Task{FeatureID: f0101.ID, Key: "T-E01-F01-001", Title: "Build form", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, task))
+	idea := &models.Idea{Key: "I-2026-10-17-01", Title: "Dark mode", CreatedDate: time.Now(), Status: models.IdeaStatusNew}
+	require.NoError(t, ideaRepo.Create(ctx, idea))
+
+	epic, err := epicRepo.GetByTitle(ctx, " identity ")
+	require.NoError(t, err)
+	require.NotNil(t, epic, "titles match ignoring case and surrounding whitespace")
+	assert.Equal(t, "E01", epic.Key)
+
+	feature, err := featureRepo.GetByTitle(ctx, e01.ID, "LOGIN")
+	require.NoError(t, err)
+	require.NotNil(t, feature)
+	assert.Equal(t, "E01-F01", feature.Key)
+	feature, err = featureRepo.GetByTitle(ctx, e02.ID, "Login")
+	require.NoError(t, err)
+	assert.Nil(t, feature, "feature titles only match within the epic")
+
+	found, err := taskRepo.GetByTitle(ctx, f0101.ID, "build form")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, task.ID, found.ID)
+	found, err = taskRepo.GetByTitle(ctx, f0101.ID, "Build form twice")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	// Trashed tasks don't count
+	_, err = database.ExecContext(ctx, `UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, task.ID)
+	require.NoError(t, err)
+	found, err = taskRepo.GetByTitle(ctx, f0101.ID, "Build form")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	existingIdea, err := ideaRepo.GetByTitle(ctx, "dark mode")
+	require.NoError(t, err)
+	require.NotNil(t, existingIdea)
+	assert.Equal(t, idea.Key, existingIdea.Key)
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// EpicNoteRepository handles CRUD operations for epic planning notes
+type EpicNoteRepository struct {
+	db *DB
+}
+
+// NewEpicNoteRepository creates a new EpicNoteRepository
+func NewEpicNoteRepository(db *DB) *EpicNoteRepository {
+	return &EpicNoteRepository{db: db}
+}
+
+// Create creates a new epic note
+func (r *EpicNoteRepository) Create(ctx context.Context, note *models.EpicNote) error {
+	if err := note.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	query := `
+		INSERT INTO epic_notes (epic_id, content, created_by)
+		VALUES (?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		note.EpicID,
+		note.Content,
+		note.CreatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create epic note: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	note.ID = id
+	return nil
+}
+
+// GetByID retrieves an epic note by its ID
+func (r *EpicNoteRepository) GetByID(ctx context.Context, id int64) (*models.EpicNote, error) {
+	query := `
+		SELECT id, epic_id, content, created_by, created_at
+		FROM epic_notes
+		WHERE id = ?
+	`
+
+	note := &models.EpicNote{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&note.ID,
+		&note.EpicID,
+		&note.Content,
+		&note.CreatedBy,
+		&note.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("epic note not found with id %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epic note: %w", err)
+	}
+
+	return note, nil
+}
+
+// ListByEpicID retrieves all notes for an epic in chronological order
+func (r *EpicNoteRepository) ListByEpicID(ctx context.Context, epicID int64) ([]*models.EpicNote, error) {
+	query := `
+		SELECT id, epic_id, content, created_by, created_at
+		FROM epic_notes
+		WHERE epic_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epic notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []*models.EpicNote{}
+	for rows.Next() {
+		note := &models.EpicNote{}
+		err := rows.Scan(
+			&note.ID,
+			&note.EpicID,
+			&note.Content,
+			&note.CreatedBy,
+			&note.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan epic note: %w", err)
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating epic notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// Delete deletes an epic note by ID
+func (r *EpicNoteRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM epic_notes WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete epic note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("epic note not found with id %d", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEpicNoteTest(t *testing.T) (*DB, int64) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+
+	epic := &models.Epic{
+		Key:      "E01",
+		Title:    "Test Epic",
+		Status:   "active",
+		Priority: "high",
+	}
+	require.NoError(t, NewEpicRepository(database).Create(context.Background(), epic))
+
+	return database, epic.ID
+}
+
+func TestEpicNoteRepository_CreateAndList(t *testing.T) {
+	database, epicID := setupEpicNoteTest(t)
+	defer database.Close()
+
+	repo := NewEpicNoteRepository(database)
+	ctx := context.Background()
+
+	author := "alice"
+	first := &models.EpicNote{EpicID: epicID, Content: "Descoped reporting to v2", CreatedBy: &author}
+	require.NoError(t, repo.Create(ctx, first))
+	assert.NotZero(t, first.ID)
+
+	second := &models.EpicNote{EpicID: epicID, Content: "Agreed on OAuth provider"}
+	require.NoError(t, repo.Create(ctx, second))
+
+	notes, err := repo.ListByEpicID(ctx, epicID)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, "Descoped reporting to v2", notes[0].Content)
+	assert.Equal(t, "alice", *notes[0].CreatedBy)
+	assert.Equal(t, "Agreed on OAuth provider", notes[1].Content)
+	assert.Nil(t, notes[1].CreatedBy)
+}
+
+func TestEpicNoteRepository_CreateValidation(t *testing.T) {
+	database, epicID := setupEpicNoteTest(t)
+	defer database.Close()
+
+	repo := NewEpicNoteRepository(database)
+	ctx := context.Background()
+
+	err := repo.Create(ctx, &models.EpicNote{EpicID: epicID, Content: ""})
+	assert.ErrorIs(t, err, models.ErrEmptyContent)
+
+	err = repo.Create(ctx, &models.EpicNote{Content: "orphan"})
+	assert.ErrorIs(t, err, models.ErrInvalidEpicID)
+}
+
+func TestEpicNoteRepository_Delete(t *testing.T) {
+	database, epicID := setupEpicNoteTest(t)
+	defer database.Close()
+
+	repo := NewEpicNoteRepository(database)
+	ctx := context.Background()
+
+	note := &models.EpicNote{EpicID: epicID, Content: "Temporary"}
+	require.NoError(t, repo.Create(ctx, note))
+
+	require.NoError(t, repo.Delete(ctx, note.ID))
+	_, err := repo.GetByID(ctx, note.ID)
+	assert.Error(t, err)
+
+	assert.Error(t, repo.Delete(ctx, note.ID))
+}
+
+func TestEpicNoteRepository_CascadeOnEpicDelete(t *testing.T) {
+	database, epicID := setupEpicNoteTest(t)
+	defer database.Close()
+
+	repo := NewEpicNoteRepository(database)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &models.EpicNote{EpicID: epicID, Content: "Will be removed"}))
+	require.NoError(t, NewEpicRepository(database).Delete(ctx, epicID))
+
+	notes, err := repo.ListByEpicID(ctx, epicID)
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+}
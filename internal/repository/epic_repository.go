@@ -20,8 +20,42 @@ func NewEpicRepository(db *DB) *EpicRepository {
 	return &EpicRepository{db: db}
 }
 
+// epicKeyMaxQuery returns the highest epic number in use
+const epicKeyMaxQuery = `
+	SELECT COALESCE(MAX(CAST(SUBSTR(key, 2) AS INTEGER)), 0)
+	FROM epics
+	WHERE key GLOB 'E[0-9]*'
+`
+
+// NextKey reserves and returns the next available epic key (E##).
+// Safe to call concurrently: each call returns a distinct key.
+func (r *EpicRepository) NextKey(ctx context.Context) (string, error) {
+	next, err := reserveKeySequence(ctx, r.db, "epic", epicKeyMaxQuery)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("E%02d", next), nil
+}
+
+// PeekNextKey returns the key NextKey would return next without reserving it
+func (r *EpicRepository) PeekNextKey(ctx context.Context) (string, error) {
+	next, err := peekKeySequence(ctx, r.db, "epic", epicKeyMaxQuery)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("E%02d", next), nil
+}
+
 // Create creates a new epic
 func (r *EpicRepository) Create(ctx context.Context, epic *models.Epic) error {
+	return insertEpic(ctx, r.db, epic, r.db.projectPathPtr(epic.FilePath))
+}
+
+// insertEpic validates and inserts an epic on db or a transaction. filePath
+// is the epic's file path as stored (see DB.ProjectPath).
+func insertEpic(ctx context.Context, db execer, epic *models.Epic, filePath *string) error {
 	if err := epic.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -35,7 +69,7 @@ func (r *EpicRepository) Create(ctx context.Context, epic *models.Epic) error {
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		epic.Key,
 		epic.Title,
 		epic.Description,
@@ -43,7 +77,7 @@ func (r *EpicRepository) Create(ctx context.Context, epic *models.Epic) error {
 		epic.Priority,
 		epic.BusinessValue,
 		epic.Slug,
-		epic.FilePath,
+		filePath,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create epic: %w", err)
@@ -94,7 +128,21 @@ func (r *EpicRepository) GetByID(ctx context.Context, id int64) (*models.Epic, e
 
 // GetByKey retrieves an epic by its key, supporting both numeric (E04) and slugged (E04-epic-name) formats.
 // It tries numeric lookup first for performance, then falls back to slug-based lookup if the key contains a hyphen.
+// Results are served from the read cache when it is enabled.
 func (r *EpicRepository) GetByKey(ctx context.Context, key string) (*models.Epic, error) {
+	epic, err := CachedRead(r.db.cache, "epic:"+key, func() (*models.Epic, error) {
+		return r.getByKey(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers may modify the epic, so the cached one is copied
+	result := *epic
+	return &result, nil
+}
+
+// getByKey looks up an epic by key in the database
+func (r *EpicRepository) getByKey(ctx context.Context, key string) (*models.Epic, error) {
 	// Try direct numeric key lookup first (e.g., "E04")
 	query := `
 		SELECT id, key, title, description, status, priority, business_value,
@@ -218,7 +266,7 @@ func (r *EpicRepository) GetByFilePath(ctx context.Context, filePath string) (*m
 	`
 
 	epic := &models.Epic{}
-	err := r.db.QueryRowContext(ctx, query, filePath).Scan(
+	err := r.db.QueryRowContext(ctx, query, r.db.ProjectPath(filePath)).Scan(
 		&epic.ID,
 		&epic.Key,
 		&epic.Title,
@@ -242,8 +290,43 @@ func (r *EpicRepository) GetByFilePath(ctx context.Context, filePath string) (*m
 	return epic, nil
 }
 
+// GetByTitle retrieves the epic with a title, ignoring case and surrounding
+// whitespace, so a retried create can find the epic it made. Returns nil if
+// there is none.
+func (r *EpicRepository) GetByTitle(ctx context.Context, title string) (*models.Epic, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT key FROM epics
+		WHERE LOWER(TRIM(title)) = LOWER(TRIM(?))
+		ORDER BY id LIMIT 1
+	`, title).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil // Not found is not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get epic by title: %w", err)
+	}
+	return r.GetByKey(ctx, key)
+}
+
 // List retrieves all epics, optionally filtered by status
 func (r *EpicRepository) List(ctx context.Context, status *models.EpicStatus) ([]*models.Epic, error) {
+	return r.ListPage(ctx, status, ListOptions{})
+}
+
+// EpicSortFields are the fields ListPage can sort epics by
+var EpicSortFields = map[string]string{
+	"key":        "key",
+	"title":      "title",
+	"status":     "CASE status WHEN 'draft' THEN 1 WHEN 'active' THEN 2 WHEN 'completed' THEN 3 WHEN 'archived' THEN 4 ELSE 5 END",
+	"priority":   "CASE priority WHEN 'high' THEN 1 WHEN 'medium' THEN 2 WHEN 'low' THEN 3 ELSE 4 END",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// ListPage retrieves a page of epics, optionally filtered by status, sorted by
+// one of EpicSortFields (newest first by default)
+func (r *EpicRepository) ListPage(ctx context.Context, status *models.EpicStatus, opts ListOptions) ([]*models.Epic, error) {
 	query := `
 		SELECT id, key, title, description, status, priority, business_value,
 		       slug, file_path, created_at, updated_at
@@ -256,7 +339,13 @@ func (r *EpicRepository) List(ctx context.Context, status *models.EpicStatus) ([
 		args = append(args, *status)
 	}
 
-	query += " ORDER BY created_at DESC"
+	order, err := opts.orderSQL(EpicSortFields, "created_at DESC", "key ASC")
+	if err != nil {
+		return nil, err
+	}
+	page, pageArgs := opts.pageSQL()
+	query += order + page
+	args = append(args, pageArgs...)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -293,6 +382,22 @@ func (r *EpicRepository) List(ctx context.Context, status *models.EpicStatus) ([
 	return epics, nil
 }
 
+// Count returns how many epics List would return
+func (r *EpicRepository) Count(ctx context.Context, status *models.EpicStatus) (int, error) {
+	query := "SELECT COUNT(*) FROM epics"
+	args := []interface{}{}
+	if status != nil {
+		query += " WHERE status = ?"
+		args = append(args, *status)
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count epics: %w", err)
+	}
+	return count, nil
+}
+
 // Update updates an existing epic
 func (r *EpicRepository) Update(ctx context.Context, epic *models.Epic) error {
 	if err := epic.Validate(); err != nil {
@@ -356,7 +461,7 @@ func (r *EpicRepository) UpdateFilePath(ctx context.Context, epicKey string, new
 		WHERE key = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, newFilePath, epicKey)
+	result, err := r.db.ExecContext(ctx, query, r.db.projectPathPtr(newFilePath), epicKey)
 	if err != nil {
 		return fmt.Errorf("update epic file path: %w", err)
 	}
@@ -378,6 +483,13 @@ func (r *EpicRepository) UpdateFilePath(ctx context.Context, epicKey string, new
 //   - If feature status = "completed" OR "archived" → 100% (regardless of tasks)
 //   - Otherwise → use feature's progress_pct field (calculated from tasks)
 func (r *EpicRepository) CalculateProgress(ctx context.Context, epicID int64) (float64, error) {
+	return CachedRead(r.db.cache, fmt.Sprintf("epic-progress:%d", epicID), func() (float64, error) {
+		return r.calculateProgress(ctx, epicID)
+	})
+}
+
+// calculateProgress calculates an epic's progress in the database
+func (r *EpicRepository) calculateProgress(ctx context.Context, epicID int64) (float64, error) {
 	query := `
 		SELECT
 		    COALESCE(SUM(
@@ -388,7 +500,7 @@ func (r *EpicRepository) CalculateProgress(ctx context.Context, epicID int64) (f
 		    ), 0) as total_progress,
 		    COUNT(*) as feature_count
 		FROM features f
-		WHERE f.epic_id = ?
+		WHERE f.epic_id = ? AND f.deleted_at IS NULL
 	`
 
 	var totalProgress float64
@@ -509,7 +621,7 @@ func (r *EpicRepository) GetFeatureStatusBreakdown(ctx context.Context, epicID i
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM features
-		WHERE epic_id = ?
+		WHERE epic_id = ? AND deleted_at IS NULL
 		GROUP BY status
 	`
 
@@ -547,6 +659,10 @@ func (r *EpicRepository) GetFeatureStatusBreakdownByKey(ctx context.Context, epi
 
 // UpdateStatus updates the status of an epic
 func (r *EpicRepository) UpdateStatus(ctx context.Context, epicID int64, status models.EpicStatus) error {
+	if err := models.ValidateEpicStatus(string(status)); err != nil {
+		return err
+	}
+
 	query := `UPDATE epics SET status = ? WHERE id = ?`
 
 	result, err := r.db.ExecContext(ctx, query, status, epicID)
@@ -577,6 +693,10 @@ func (r *EpicRepository) UpdateStatusByKey(ctx context.Context, epicKey string,
 // CascadeStatusToFeaturesAndTasks updates the status of all child features and their tasks
 // Used when --force is specified to override workflow validation
 func (r *EpicRepository) CascadeStatusToFeaturesAndTasks(ctx context.Context, epicID int64, targetFeatureStatus models.FeatureStatus, targetTaskStatus models.TaskStatus) error {
+	if err := models.ValidateFeatureStatus(string(targetFeatureStatus)); err != nil {
+		return err
+	}
+
 	tx, err := r.db.BeginTxContext(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -584,7 +704,7 @@ func (r *EpicRepository) CascadeStatusToFeaturesAndTasks(ctx context.Context, ep
 	defer func() { _ = tx.Rollback() }()
 
 	// First update all features
-	featureQuery := `UPDATE features SET status = ? WHERE epic_id = ?`
+	featureQuery := `UPDATE features SET status = ? WHERE epic_id = ? AND deleted_at IS NULL`
 
 	_, err = tx.ExecContext(ctx, featureQuery, targetFeatureStatus, epicID)
 	if err != nil {
@@ -595,7 +715,7 @@ func (r *EpicRepository) CascadeStatusToFeaturesAndTasks(ctx context.Context, ep
 	taskQuery := `
 		UPDATE tasks
 		SET status = ?
-		WHERE feature_id IN (SELECT id FROM features WHERE epic_id = ?)
+		WHERE deleted_at IS NULL AND feature_id IN (SELECT id FROM features WHERE epic_id = ?)
 	`
 
 	_, err = tx.ExecContext(ctx, taskQuery, targetTaskStatus, epicID)
@@ -630,7 +750,7 @@ func (r *EpicRepository) GetFeatureStatusRollup(ctx context.Context, epicID int6
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM features
-		WHERE epic_id = ?
+		WHERE epic_id = ? AND deleted_at IS NULL
 		GROUP BY status
 	`
 
@@ -665,7 +785,7 @@ func (r *EpicRepository) GetTaskStatusRollup(ctx context.Context, epicID int64)
 		SELECT t.status, COUNT(*) as count
 		FROM tasks t
 		JOIN features f ON t.feature_id = f.id
-		WHERE f.epic_id = ?
+		WHERE f.epic_id = ? AND t.deleted_at IS NULL
 		GROUP BY t.status
 	`
 
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// EventFilter narrows the changes feed. Zero values match everything.
+type EventFilter struct {
+	EntityType string // epic, feature, or task
+	Key        string // Events of this key, or of its children (an epic's features, a feature's tasks)
+}
+
+// EventRepository reads the changes feed written by the events triggers
+type EventRepository struct {
+	db *DB
+}
+
+// NewEventRepository creates a new EventRepository
+func NewEventRepository(db *DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// ListSince returns up to limit events after seq since, oldest first
+func (r *EventRepository) ListSince(ctx context.Context, since int64, limit int, filter EventFilter) ([]*models.Event, error) {
+	where, args := filter.where()
+	args = append([]interface{}{since}, args...)
+	args = append(args, limit)
+	return r.query(ctx, `
+		SELECT seq, entity_type, entity_key, parent_key, action, status, previous_status, created_at
+		FROM events
+		WHERE seq > ?`+where+`
+		ORDER BY seq
+		LIMIT ?
+	`, args...)
+}
+
+// ListLatest returns the last limit events, oldest first
+func (r *EventRepository) ListLatest(ctx context.Context, limit int, filter EventFilter) ([]*models.Event, error) {
+	where, args := filter.where()
+	args = append(args, limit)
+	return r.query(ctx, `
+		SELECT * FROM (
+			SELECT seq, entity_type, entity_key, parent_key, action, status, previous_status, created_at
+			FROM events
+			WHERE 1 = 1`+where+`
+			ORDER BY seq DESC
+			LIMIT ?
+		) ORDER BY seq
+	`, args...)
+}
+
+// LatestSeq returns the seq of the last event, or 0 if there are none
+func (r *EventRepository) LatestSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(seq), 0) FROM events`).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("failed to get latest event: %w", err)
+	}
+	return seq, nil
+}
+
+// where returns the SQL conditions of the filter, each starting with AND
+func (f EventFilter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if f.EntityType != "" {
+		conditions = append(conditions, " AND entity_type = ?")
+		args = append(args, f.EntityType)
+	}
+	if f.Key != "" {
+		// An epic's tasks have feature keys starting with the epic key
+		conditions = append(conditions, " AND (entity_key = ? OR parent_key = ? OR parent_key LIKE ? || '-%')")
+		args = append(args, f.Key, f.Key, f.Key)
+	}
+	return strings.Join(conditions, ""), args
+}
+
+// query runs an events query and scans the events
+func (r *EventRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.Event, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.Event{}
+	for rows.Next() {
+		event := &models.Event{}
+		if err := rows.Scan(&event.Seq, &event.EntityType, &event.EntityKey, &event.ParentKey, &event.Action, &event.Status, &event.PreviousStatus, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
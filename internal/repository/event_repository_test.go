@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	createTestTask(t, db)
+	repo := NewEventRepository(db)
+
+	events, err := repo.ListSince(ctx, 0, 100, EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 3, "creating an epic, feature, and task")
+	assert.Equal(t, "E01", events[0].EntityKey)
+	assert.Equal(t, "T-E01-F01-001", events[2].EntityKey)
+	require.NotNil(t, events[2].ParentKey)
+	assert.Equal(t, "E01-F01", *events[2].ParentKey)
+	assert.Equal(t, models.EventActionCreated, events[2].Action)
+
+	latest, err := repo.LatestSeq(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, events[2].Seq, latest)
+
+	// Progress recalculations aren't changes a client reacts to
+	_, err = db.ExecContext(ctx, `UPDATE features SET progress_pct = 50 WHERE key = 'E01-F01'`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `UPDATE tasks SET status = 'in_progress' WHERE key = 'T-E01-F01-001'`)
+	require.NoError(t, err)
+
+	events, err = repo.ListSince(ctx, latest, 100, EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1, "the updated_at trigger doesn't add an event")
+	assert.Equal(t, models.EventActionStatusChanged, events[0].Action)
+	assert.Equal(t, "in_progress", *events[0].Status)
+	assert.Equal(t, "todo", *events[0].PreviousStatus)
+
+	_, err = db.ExecContext(ctx, `UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE key = 'T-E01-F01-001'`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `DELETE FROM tasks WHERE key = 'T-E01-F01-001'`)
+	require.NoError(t, err)
+
+	events, err = repo.ListLatest(ctx, 2, EventFilter{Key: "E01", EntityType: models.EventEntityTask})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.EventActionDeleted, events[0].Action)
+	assert.Equal(t, models.EventActionPurged, events[1].Action)
+
+	events, err = repo.ListSince(ctx, 0, 100, EventFilter{Key: "E02"})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -5,10 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/jwwelbor/shark-task-manager/internal/config"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/progress"
 	"github.com/jwwelbor/shark-task-manager/internal/slug"
@@ -24,8 +22,51 @@ func NewFeatureRepository(db *DB) *FeatureRepository {
 	return &FeatureRepository{db: db}
 }
 
+// featureKeyMaxQuery returns the highest feature number in use in an epic
+const featureKeyMaxQuery = `
+	SELECT COALESCE(MAX(CAST(SUBSTR(key, INSTR(key, '-F') + 2) AS INTEGER)), 0)
+	FROM features
+	WHERE epic_id = ? AND key GLOB 'E*-F[0-9]*'
+`
+
+// NextKey reserves and returns the next available feature key (E##-F##) for an epic.
+// Safe to call concurrently: each call returns a distinct key.
+func (r *FeatureRepository) NextKey(ctx context.Context, epicID int64, epicKey string) (string, error) {
+	if epicKey == "" {
+		return "", fmt.Errorf("epic key is required to generate a feature key")
+	}
+
+	next, err := reserveKeySequence(ctx, r.db, fmt.Sprintf("feature:%d", epicID), featureKeyMaxQuery, epicID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-F%02d", epicKey, next), nil
+}
+
+// PeekNextKey returns the key NextKey would return next for an epic without
+// reserving it
+func (r *FeatureRepository) PeekNextKey(ctx context.Context, epicID int64, epicKey string) (string, error) {
+	if epicKey == "" {
+		return "", fmt.Errorf("epic key is required to generate a feature key")
+	}
+
+	next, err := peekKeySequence(ctx, r.db, fmt.Sprintf("feature:%d", epicID), featureKeyMaxQuery, epicID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-F%02d", epicKey, next), nil
+}
+
 // Create creates a new feature
 func (r *FeatureRepository) Create(ctx context.Context, feature *models.Feature) error {
+	return insertFeature(ctx, r.db, feature, r.db.projectPathPtr(feature.FilePath))
+}
+
+// insertFeature validates and inserts a feature on db or a transaction.
+// filePath is the feature's file path as stored (see DB.ProjectPath).
+func insertFeature(ctx context.Context, db execer, feature *models.Feature, filePath *string) error {
 	if err := feature.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -39,7 +80,7 @@ func (r *FeatureRepository) Create(ctx context.Context, feature *models.Feature)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		feature.EpicID,
 		feature.Key,
 		feature.Title,
@@ -49,7 +90,7 @@ func (r *FeatureRepository) Create(ctx context.Context, feature *models.Feature)
 		feature.StatusOverride,
 		feature.ProgressPct,
 		feature.ExecutionOrder,
-		feature.FilePath,
+		filePath,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create feature: %w", err)
@@ -70,7 +111,7 @@ func (r *FeatureRepository) GetByID(ctx context.Context, id int64) (*models.Feat
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	feature := &models.Feature{}
@@ -110,7 +151,22 @@ func (r *FeatureRepository) GetByID(ctx context.Context, id int64) (*models.Feat
 // 1. Exact match on key column
 // 2. Pattern match for numeric key (key LIKE '%F11')
 // 3. Pattern match for slugged key (key || '-' || slug matches input)
+//
+// Results are served from the read cache when it is enabled.
 func (r *FeatureRepository) GetByKey(ctx context.Context, key string) (*models.Feature, error) {
+	feature, err := CachedRead(r.db.cache, "feature:"+key, func() (*models.Feature, error) {
+		return r.getByKey(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers may modify the feature, so the cached one is copied
+	result := *feature
+	return &result, nil
+}
+
+// getByKey looks up a feature by key in the database
+func (r *FeatureRepository) getByKey(ctx context.Context, key string) (*models.Feature, error) {
 	// Normalize key to uppercase for comparison
 	normalizedKey := strings.ToUpper(key)
 
@@ -154,7 +210,7 @@ func (r *FeatureRepository) getByExactKey(ctx context.Context, key string) (*mod
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE key = ?
+		WHERE key = ? AND deleted_at IS NULL
 	`
 
 	feature := &models.Feature{}
@@ -184,7 +240,7 @@ func (r *FeatureRepository) getByNumericKey(ctx context.Context, numericKey stri
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE key LIKE ?
+		WHERE key LIKE ? AND deleted_at IS NULL
 	`
 
 	// Match pattern: any epic prefix followed by the numeric key
@@ -245,7 +301,7 @@ func (r *FeatureRepository) getBySluggedKey(ctx context.Context, sluggedKey stri
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE key LIKE ? AND slug = ?
+		WHERE key LIKE ? AND slug = ? AND deleted_at IS NULL
 	`
 
 	pattern := "%-" + numericPart
@@ -277,11 +333,11 @@ func (r *FeatureRepository) GetByFilePath(ctx context.Context, filePath string)
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE file_path = ?
+		WHERE file_path = ? AND deleted_at IS NULL
 	`
 
 	feature := &models.Feature{}
-	err := r.db.QueryRowContext(ctx, query, filePath).Scan(
+	err := r.db.QueryRowContext(ctx, query, r.db.ProjectPath(filePath)).Scan(
 		&feature.ID,
 		&feature.EpicID,
 		&feature.Key,
@@ -307,13 +363,32 @@ func (r *FeatureRepository) GetByFilePath(ctx context.Context, filePath string)
 	return feature, nil
 }
 
+// GetByTitle retrieves the feature of an epic with a title, ignoring case and
+// surrounding whitespace, so a retried create can find the feature it made.
+// Returns nil if there is none.
+func (r *FeatureRepository) GetByTitle(ctx context.Context, epicID int64, title string) (*models.Feature, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT key FROM features
+		WHERE epic_id = ? AND LOWER(TRIM(title)) = LOWER(TRIM(?)) AND deleted_at IS NULL
+		ORDER BY id LIMIT 1
+	`, epicID, title).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil // Not found is not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get feature by title: %w", err)
+	}
+	return r.GetByKey(ctx, key)
+}
+
 // ListByEpic retrieves all features for an epic
 func (r *FeatureRepository) ListByEpic(ctx context.Context, epicID int64) ([]*models.Feature, error) {
 	query := `
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE epic_id = ?
+		WHERE epic_id = ? AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, created_at
 	`
 
@@ -360,6 +435,7 @@ func (r *FeatureRepository) List(ctx context.Context) ([]*models.Feature, error)
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
+		WHERE deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, created_at
 	`
 
@@ -432,7 +508,7 @@ func (r *FeatureRepository) Update(ctx context.Context, feature *models.Feature)
 	// If cascade is needed, get all features BEFORE updating, then resequence ALL features
 	if needsCascade {
 		// Get all features in the same epic (before any updates)
-		allFeatures, err := r.listByEpicInTx(ctx, tx, feature.EpicID)
+		allFeatures, err := r.listByEpicInTx(ctx, tx.Tx, feature.EpicID)
 		if err != nil {
 			return fmt.Errorf("failed to list features for cascade: %w", err)
 		}
@@ -526,7 +602,7 @@ func (r *FeatureRepository) listByEpicInTx(ctx context.Context, tx *sql.Tx, epic
 		SELECT id, epic_id, key, title, slug, description, status, progress_pct, execution_order,
 		       created_at, updated_at, file_path
 		FROM features
-		WHERE epic_id = ?
+		WHERE epic_id = ? AND deleted_at IS NULL
 		ORDER BY execution_order ASC
 	`
 
@@ -594,7 +670,7 @@ func (r *FeatureRepository) UpdateFilePath(ctx context.Context, featureKey strin
 		WHERE key = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, newFilePath, featureKey)
+	result, err := r.db.ExecContext(ctx, query, r.db.projectPathPtr(newFilePath), featureKey)
 	if err != nil {
 		return fmt.Errorf("update feature file path: %w", err)
 	}
@@ -613,11 +689,18 @@ func (r *FeatureRepository) UpdateFilePath(ctx context.Context, featureKey strin
 // CalculateProgress calculates the weighted progress of a feature based on task status weights
 // Uses workflow config to apply progress weights to each task status
 func (r *FeatureRepository) CalculateProgress(ctx context.Context, featureID int64) (float64, error) {
+	return CachedRead(r.db.cache, fmt.Sprintf("feature-progress:%d", featureID), func() (float64, error) {
+		return r.calculateProgress(ctx, featureID)
+	})
+}
+
+// calculateProgress calculates a feature's weighted progress in the database
+func (r *FeatureRepository) calculateProgress(ctx context.Context, featureID int64) (float64, error) {
 	// Get task status breakdown
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 		GROUP BY status
 	`
 
@@ -646,24 +729,8 @@ func (r *FeatureRepository) CalculateProgress(ctx context.Context, featureID int
 		return 0.0, nil
 	}
 
-	// Load workflow config for weighted progress calculation
-	cwd, err := os.Getwd()
-	if err != nil {
-		cwd = ""
-	}
-
-	var cfg *config.WorkflowConfig
-	if cwd != "" {
-		configPath := cwd + "/.sharkconfig.json"
-		cfg, err = config.LoadWorkflowConfig(configPath)
-		if err != nil {
-			// If config load fails, use default weights (completion-based)
-			cfg = nil
-		}
-	}
-
 	// Calculate weighted progress using progress package
-	progressInfo := progress.CalculateProgress(statusCounts, cfg)
+	progressInfo := progress.CalculateProgress(statusCounts, r.db.projectWorkflow())
 	return progressInfo.WeightedPct, nil
 }
 
@@ -724,7 +791,7 @@ func (r *FeatureRepository) ListByStatus(ctx context.Context, status models.Feat
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE status = ?
+		WHERE status = ? AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, created_at
 	`
 
@@ -771,7 +838,7 @@ func (r *FeatureRepository) ListByEpicAndStatus(ctx context.Context, epicID int6
 		SELECT id, epic_id, key, title, slug, description, status, COALESCE(status_override, 0) as status_override, progress_pct,
 		       execution_order, file_path, created_at, updated_at
 		FROM features
-		WHERE epic_id = ? AND status = ?
+		WHERE epic_id = ? AND status = ? AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, created_at
 	`
 
@@ -814,7 +881,7 @@ func (r *FeatureRepository) ListByEpicAndStatus(ctx context.Context, epicID int6
 
 // GetTaskCount returns the total number of tasks for a feature
 func (r *FeatureRepository) GetTaskCount(ctx context.Context, featureID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM tasks WHERE feature_id = ?`
+	query := `SELECT COUNT(*) FROM tasks WHERE feature_id = ? AND deleted_at IS NULL`
 
 	var count int
 	err := r.db.QueryRowContext(ctx, query, featureID).Scan(&count)
@@ -880,6 +947,32 @@ func (r *FeatureRepository) CreateIfNotExists(ctx context.Context, feature *mode
 	return feature, true, nil
 }
 
+// GetOrCreateBacklog returns the implicit backlog feature that holds
+// standalone tasks, creating it and its backlog epic on first use. Both use
+// models.BacklogKey, which bypasses key validation, and the feature's status
+// is overridden so it stays active when all of its tasks are done.
+func (r *FeatureRepository) GetOrCreateBacklog(ctx context.Context) (*models.Feature, error) {
+	description := "Standalone tasks that aren't part of an epic or feature"
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO epics (key, title, slug, description, status, priority)
+		VALUES (?, ?, 'backlog', ?, ?, ?)
+	`, models.BacklogKey, models.BacklogTitle, description, models.EpicStatusActive, models.PriorityLow); err != nil {
+		return nil, fmt.Errorf("failed to create backlog epic: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO features (epic_id, key, title, slug, description, status, status_override)
+		SELECT id, ?, ?, 'backlog', ?, ?, 1 FROM epics WHERE key = ?
+	`, models.BacklogKey, models.BacklogTitle, description, models.FeatureStatusActive, models.BacklogKey); err != nil {
+		return nil, fmt.Errorf("failed to create backlog feature: %w", err)
+	}
+
+	feature, err := r.GetByKey(ctx, models.BacklogKey)
+	if err != nil {
+		return nil, fmt.Errorf("backlog feature %s not found (restore it from the trash if it was deleted): %w", models.BacklogKey, err)
+	}
+	return feature, nil
+}
+
 // UpdateKey updates the key of a feature
 func (r *FeatureRepository) UpdateKey(ctx context.Context, oldKey string, newKey string) error {
 	// Validate new key doesn't already exist
@@ -920,7 +1013,7 @@ func (r *FeatureRepository) GetTaskStatusBreakdown(ctx context.Context, featureI
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 		GROUP BY status
 	`
 
@@ -989,6 +1082,10 @@ func (r *FeatureRepository) SetStatusOverrideByKey(ctx context.Context, featureK
 // UpdateStatusIfNotOverridden updates the status only if status_override is false
 // Returns true if the status was updated, false if skipped due to override
 func (r *FeatureRepository) UpdateStatusIfNotOverridden(ctx context.Context, featureID int64, newStatus models.FeatureStatus) (bool, error) {
+	if err := models.ValidateFeatureStatus(string(newStatus)); err != nil {
+		return false, err
+	}
+
 	query := `
 		UPDATE features
 		SET status = ?
@@ -1020,7 +1117,7 @@ func (r *FeatureRepository) UpdateStatusIfNotOverriddenByKey(ctx context.Context
 // CascadeStatusToTasks updates the status of all child tasks to match a target task status
 // Used when --force is specified to override workflow validation
 func (r *FeatureRepository) CascadeStatusToTasks(ctx context.Context, featureID int64, targetTaskStatus models.TaskStatus) error {
-	query := `UPDATE tasks SET status = ? WHERE feature_id = ?`
+	query := `UPDATE tasks SET status = ? WHERE feature_id = ? AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query, targetTaskStatus, featureID)
 	if err != nil {
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ErrFileClaimed is returned when a write gives an epic, feature, or task a
+// file another one already claims
+var ErrFileClaimed = errors.New("file is already claimed by another epic, feature, or task")
+
+// FileClaimRepository reads the file_claims table. Claims are written by
+// triggers on the epics, features, and tasks tables whenever a file_path
+// changes, so there is nothing to write here. The repositories store file
+// paths in DB.ProjectPath form, so claims on the same file always match.
+type FileClaimRepository struct {
+	db *DB
+}
+
+// NewFileClaimRepository creates a new FileClaimRepository
+func NewFileClaimRepository(db *DB) *FileClaimRepository {
+	return &FileClaimRepository{db: db}
+}
+
+// fileClaimSelect selects claims with their entity's key and title
+const fileClaimSelect = `
+	SELECT c.path, c.entity_type, c.entity_id,
+	       COALESCE(e.key, f.key, t.key, ''), COALESCE(e.title, f.title, t.title, ''),
+	       c.claimed_at
+	FROM file_claims c
+	LEFT JOIN epics e ON c.entity_type = 'epic' AND e.id = c.entity_id
+	LEFT JOIN features f ON c.entity_type = 'feature' AND f.id = c.entity_id
+	LEFT JOIN tasks t ON c.entity_type = 'task' AND t.id = c.entity_id
+`
+
+// GetByPath returns the claim on path, which may be absolute or relative to
+// the project root, or nil if the file is unclaimed
+func (r *FileClaimRepository) GetByPath(ctx context.Context, path string) (*models.FileClaim, error) {
+	claims, err := r.queryClaims(ctx, fileClaimSelect+" WHERE c.path = ?", r.db.ProjectPath(path))
+	if err != nil {
+		return nil, err
+	}
+	if len(claims) == 0 {
+		return nil, nil
+	}
+	return claims[0], nil
+}
+
+// List returns the claims on path and on every file under it when it is a
+// directory, ordered by path. An empty path lists every claim.
+func (r *FileClaimRepository) List(ctx context.Context, path string) ([]*models.FileClaim, error) {
+	path = strings.TrimSuffix(r.db.ProjectPath(path), "/")
+	if path == "" || path == "." {
+		return r.queryClaims(ctx, fileClaimSelect+" ORDER BY c.path")
+	}
+	prefix := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(path) + "/%"
+	return r.queryClaims(ctx, fileClaimSelect+` WHERE c.path = ? OR c.path LIKE ? ESCAPE '\' ORDER BY c.path`, path, prefix)
+}
+
+// queryClaims runs a fileClaimSelect query
+func (r *FileClaimRepository) queryClaims(ctx context.Context, query string, args ...interface{}) ([]*models.FileClaim, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []*models.FileClaim{}
+	for rows.Next() {
+		claim := &models.FileClaim{}
+		if err := rows.Scan(&claim.Path, &claim.EntityType, &claim.EntityID, &claim.EntityKey, &claim.EntityTitle, &claim.ClaimedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan file claim: %w", err)
+		}
+		claims = append(claims, claim)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating file claims: %w", err)
+	}
+	return claims, nil
+}
+
+// fileClaimConflict turns the constraint error a write gets when it claims a
+// claimed file into ErrFileClaimed, keeping the driver's message
+func fileClaimConflict(err error) error {
+	if err != nil && strings.Contains(err.Error(), "file_claims.path") && !errors.Is(err, ErrFileClaimed) {
+		return fmt.Errorf("%w (%v)", ErrFileClaimed, err)
+	}
+	return err
+}
+
+// IsFileClaimed reports whether err comes from claiming a claimed file,
+// including writes made inside a transaction
+func IsFileClaimed(err error) bool {
+	return err != nil && (errors.Is(err, ErrFileClaimed) || strings.Contains(err.Error(), "file_claims.path"))
+}
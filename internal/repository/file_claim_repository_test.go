@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileClaimRepository_TracksFilePaths(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	epicRepo := NewEpicRepository(db)
+	repo := NewFileClaimRepository(db)
+
+	epicPath := "docs/plan/E01/epic.md"
+	require.NoError(t, epicRepo.UpdateFilePath(ctx, "E01", &epicPath))
+
+	claim, err := repo.GetByPath(ctx, epicPath)
+	require.NoError(t, err)
+	require.NotNil(t, claim)
+	assert.Equal(t, "epic", claim.EntityType)
+	assert.Equal(t, "E01", claim.EntityKey)
+	assert.Equal(t, "Test Epic", claim.EntityTitle)
+
+	// Another entity can't claim the same file
+	err = taskRepo.UpdateFilePath(ctx, task.Key, &epicPath)
+	assert.ErrorIs(t, err, ErrFileClaimed)
+	assert.True(t, IsFileClaimed(err))
+
+	// Moving a file releases the old path
+	taskPath := "docs/plan/E01/tasks/T-001.md"
+	require.NoError(t, taskRepo.UpdateFilePath(ctx, task.Key, &taskPath))
+	movedPath := "docs/plan/E01/tasks/renamed.md"
+	require.NoError(t, taskRepo.UpdateFilePath(ctx, task.Key, &movedPath))
+	claim, err = repo.GetByPath(ctx, taskPath)
+	require.NoError(t, err)
+	assert.Nil(t, claim)
+
+	// Directories list every claim under them, and % or _ match literally
+	claims, err := repo.List(ctx, "docs/plan/E01/")
+	require.NoError(t, err)
+	require.Len(t, claims, 2)
+	assert.Equal(t, epicPath, claims[0].Path)
+	assert.Equal(t, movedPath, claims[1].Path)
+	claims, err = repo.List(ctx, "docs/plan/E0_")
+	require.NoError(t, err)
+	assert.Empty(t, claims)
+
+	// Trashing a task frees its file; restoring it claims the file again
+	require.NoError(t, NewTrashRepository(db).TrashTask(ctx, taskID))
+	claim, err = repo.GetByPath(ctx, movedPath)
+	require.NoError(t, err)
+	assert.Nil(t, claim)
+
+	_, err = db.ExecContext(ctx, `UPDATE tasks SET deleted_at = NULL WHERE id = ?`, taskID)
+	require.NoError(t, err)
+	claim, err = repo.GetByPath(ctx, movedPath)
+	require.NoError(t, err)
+	require.NotNil(t, claim)
+	assert.Equal(t, task.Key, claim.EntityKey)
+
+	// Deleting an entity removes its claim
+	require.NoError(t, taskRepo.Delete(ctx, taskID))
+	claims, err = repo.List(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, claims, 1)
+	assert.Equal(t, "epic", claims[0].EntityType)
+}
+
+func TestFileClaimRepository_AbsoluteAndRelativePathsCollide(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	root := t.TempDir()
+	db.SetProjectRoot(root)
+
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	repo := NewFileClaimRepository(db)
+
+	// The epic claims the file by its relative path
+	epicPath := "docs/plan/E01/epic.md"
+	require.NoError(t, NewEpicRepository(db).UpdateFilePath(ctx, "E01", &epicPath))
+
+	// The task names the same file by its absolute path
+	absPath := filepath.Join(root, "docs", "plan", "E01", "epic.md")
+	err = taskRepo.UpdateFilePath(ctx, task.Key, &absPath)
+	assert.ErrorIs(t, err, ErrFileClaimed)
+
+	claim, err := repo.GetByPath(ctx, absPath)
+	require.NoError(t, err)
+	require.NotNil(t, claim)
+	assert.Equal(t, "epic", claim.EntityType)
+
+	// Absolute paths under the root are stored relative to it
+	taskPath := filepath.Join(root, "docs", "plan", "E01", "tasks", "T-001.md")
+	require.NoError(t, taskRepo.UpdateFilePath(ctx, task.Key, &taskPath))
+	task, err = taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/plan/E01/tasks/T-001.md", *task.FilePath)
+
+	found, err := taskRepo.GetByFilePath(ctx, taskPath)
+	require.NoError(t, err)
+	assert.Equal(t, task.Key, found.Key)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// HookMilestoneRepository records the milestones an epic or feature has
+// reached, so hooks fire when a milestone is crossed rather than on every
+// change. Milestones are opaque names such as "progress:50".
+type HookMilestoneRepository struct {
+	db *DB
+}
+
+// NewHookMilestoneRepository creates a new HookMilestoneRepository
+func NewHookMilestoneRepository(db *DB) *HookMilestoneRepository {
+	return &HookMilestoneRepository{db: db}
+}
+
+// List returns the milestones recorded for an entity
+func (r *HookMilestoneRepository) List(ctx context.Context, entityType string, entityID int64) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT milestone FROM hook_milestones WHERE entity_type = ? AND entity_id = ?
+	`, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hook milestones: %w", err)
+	}
+	defer rows.Close()
+
+	milestones := map[string]bool{}
+	for rows.Next() {
+		var milestone string
+		if err := rows.Scan(&milestone); err != nil {
+			return nil, fmt.Errorf("failed to scan hook milestone: %w", err)
+		}
+		milestones[milestone] = true
+	}
+	return milestones, rows.Err()
+}
+
+// Record marks a milestone as reached. Recording it again is a no-op.
+func (r *HookMilestoneRepository) Record(ctx context.Context, entityType string, entityID int64, milestone string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO hook_milestones (entity_type, entity_id, milestone) VALUES (?, ?, ?)
+	`, entityType, entityID, milestone)
+	if err != nil {
+		return fmt.Errorf("failed to record hook milestone: %w", err)
+	}
+	return nil
+}
+
+// Clear removes a milestone so it can be reached again
+func (r *HookMilestoneRepository) Clear(ctx context.Context, entityType string, entityID int64, milestone string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM hook_milestones WHERE entity_type = ? AND entity_id = ? AND milestone = ?
+	`, entityType, entityID, milestone)
+	if err != nil {
+		return fmt.Errorf("failed to clear hook milestone: %w", err)
+	}
+	return nil
+}
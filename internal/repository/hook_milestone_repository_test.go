@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookMilestoneRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewHookMilestoneRepository(setupCriteriaTestDB(t))
+
+	require.NoError(t, repo.Record(ctx, "epic", 1, "progress:50"))
+	require.NoError(t, repo.Record(ctx, "epic", 1, "progress:50"), "recording twice is a no-op")
+	require.NoError(t, repo.Record(ctx, "epic", 1, "blocked"))
+	require.NoError(t, repo.Record(ctx, "feature", 1, "progress:100"))
+
+	milestones, err := repo.List(ctx, "epic", 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"progress:50": true, "blocked": true}, milestones)
+
+	require.NoError(t, repo.Clear(ctx, "epic", 1, "blocked"))
+	milestones, err = repo.List(ctx, "epic", 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"progress:50": true}, milestones)
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ConvertToEpic creates epic from an idea and marks the idea converted in a
+// single transaction, so neither happens without the other
+func (r *IdeaRepository) ConvertToEpic(ctx context.Context, ideaID int64, epic *models.Epic) error {
+	return r.convert(ctx, ideaID, "epic", func(tx *sql.Tx) (string, int64, error) {
+		if err := insertEpic(ctx, tx, epic, r.db.projectPathPtr(epic.FilePath)); err != nil {
+			return "", 0, err
+		}
+		return epic.Key, epic.ID, nil
+	})
+}
+
+// ConvertToFeature creates feature from an idea and marks the idea converted
+// in a single transaction
+func (r *IdeaRepository) ConvertToFeature(ctx context.Context, ideaID int64, feature *models.Feature) error {
+	return r.convert(ctx, ideaID, "feature", func(tx *sql.Tx) (string, int64, error) {
+		if err := insertFeature(ctx, tx, feature, r.db.projectPathPtr(feature.FilePath)); err != nil {
+			return "", 0, err
+		}
+		return feature.Key, feature.ID, nil
+	})
+}
+
+// ConvertToTask creates task from an idea, records its creation in the task
+// history, and marks the idea converted in a single transaction
+func (r *IdeaRepository) ConvertToTask(ctx context.Context, ideaID int64, task *models.Task) error {
+	if err := task.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return r.convert(ctx, ideaID, "task", func(tx *sql.Tx) (string, int64, error) {
+		if err := insertTask(ctx, tx, task, r.db.projectPathPtr(task.FilePath)); err != nil {
+			return "", 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO task_history (task_id, old_status, new_status, notes)
+			VALUES (?, NULL, ?, ?)
+		`, task.ID, task.Status, "Task created from idea"); err != nil {
+			return "", 0, fmt.Errorf("failed to create history record: %w", err)
+		}
+		return task.Key, task.ID, nil
+	})
+}
+
+// convert runs create, carries the idea's notes and related docs over to the
+// entity it returns the key and id of, and marks the idea converted, rolling
+// everything back if any step fails. Ideas that are already converted are
+// refused.
+func (r *IdeaRepository) convert(ctx context.Context, ideaID int64, entityType string, create func(tx *sql.Tx) (string, int64, error)) error {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var ideaKey, status string
+	var convertedType, convertedKey, notes, relatedDocs sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT key, status, converted_to_type, converted_to_key, notes, related_docs FROM ideas WHERE id = ?
+	`, ideaID).Scan(&ideaKey, &status, &convertedType, &convertedKey, &notes, &relatedDocs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("idea not found with id %d", ideaID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get idea: %w", err)
+	}
+	if status == string(models.IdeaStatusConverted) {
+		if convertedType.Valid && convertedKey.Valid {
+			return fmt.Errorf("idea %s is already converted to %s %s", ideaKey, convertedType.String, convertedKey.String)
+		}
+		return fmt.Errorf("idea %s is already converted", ideaKey)
+	}
+
+	entityKey, entityID, err := create(tx.Tx)
+	if err != nil {
+		return err
+	}
+	if err := carryIdeaNotes(ctx, tx.Tx, ideaKey, entityType, entityID, notes.String); err != nil {
+		return err
+	}
+	if err := linkIdeaRelatedDocs(ctx, tx.Tx, entityType, entityID, relatedDocs.String); err != nil {
+		return err
+	}
+	if err := markIdeaConverted(ctx, tx, ideaID, entityType, entityKey); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// IdeaNotesContent is the note an idea's notes become on the entity it is
+// converted to
+func IdeaNotesContent(ideaKey, notes string) string {
+	return fmt.Sprintf("Notes from idea %s:\n%s", ideaKey, strings.TrimSpace(notes))
+}
+
+// carryIdeaNotes adds an idea's notes to the entity converted from it: as an
+// epic or task note, or appended to a feature's description, since features
+// have no notes
+func carryIdeaNotes(ctx context.Context, tx *sql.Tx, ideaKey, entityType string, entityID int64, notes string) error {
+	if strings.TrimSpace(notes) == "" {
+		return nil
+	}
+	content := IdeaNotesContent(ideaKey, notes)
+
+	var err error
+	switch entityType {
+	case "epic":
+		_, err = tx.ExecContext(ctx, `INSERT INTO epic_notes (epic_id, content) VALUES (?, ?)`, entityID, content)
+	case "feature":
+		_, err = tx.ExecContext(ctx, `
+			UPDATE features
+			SET description = CASE WHEN COALESCE(description, '') = '' THEN ? ELSE description || char(10) || char(10) || ? END
+			WHERE id = ?
+		`, content, content, entityID)
+	case "task":
+		_, err = tx.ExecContext(ctx, `INSERT INTO task_notes (task_id, note_type, content) VALUES (?, ?, ?)`,
+			entityID, models.NoteTypeComment, content)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to carry idea notes to %s: %w", entityType, err)
+	}
+	return nil
+}
+
+// IdeaRelatedDocPaths returns the document paths in an idea's related_docs
+func IdeaRelatedDocPaths(relatedDocs string) []string {
+	var paths []string
+	if strings.TrimSpace(relatedDocs) == "" || json.Unmarshal([]byte(relatedDocs), &paths) != nil {
+		return nil
+	}
+	docs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			docs = append(docs, path)
+		}
+	}
+	return docs
+}
+
+// linkIdeaRelatedDocs links each of an idea's related docs to the entity
+// converted from it, titled by file name like 'shark related-docs add' would
+func linkIdeaRelatedDocs(ctx context.Context, tx *sql.Tx, entityType string, entityID int64, relatedDocs string) error {
+	for _, path := range IdeaRelatedDocPaths(relatedDocs) {
+		title := filepath.Base(path)
+
+		var documentID int64
+		err := tx.QueryRowContext(ctx, `SELECT id FROM documents WHERE title = ? AND file_path = ?`, title, path).Scan(&documentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			result, insertErr := tx.ExecContext(ctx, `INSERT INTO documents (title, file_path) VALUES (?, ?)`, title, path)
+			if insertErr != nil {
+				return fmt.Errorf("failed to create document %s: %w", path, insertErr)
+			}
+			documentID, err = result.LastInsertId()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get document %s: %w", path, err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT OR IGNORE INTO %s_documents (%s_id, document_id) VALUES (?, ?)`, entityType, entityType),
+			entityID, documentID); err != nil {
+			return fmt.Errorf("failed to link document %s to %s: %w", path, entityType, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createConversionTestIdea(t *testing.T, db *DB, key string) *models.Idea {
+	idea := &models.Idea{Key: key, Title: "Convert me", CreatedDate: time.Now(), Status: models.IdeaStatusNew}
+	require.NoError(t, NewIdeaRepository(db).Create(context.Background(), idea))
+	return idea
+}
+
+func TestIdeaRepository_ConvertToEpic(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewIdeaRepository(db)
+	idea := createConversionTestIdea(t, db, "I-2026-01-01-01")
+
+	epic := &models.Epic{Key: "E05", Title: idea.Title, Status: "draft", Priority: models.PriorityMedium}
+	require.NoError(t, repo.ConvertToEpic(ctx, idea.ID, epic))
+	assert.NotZero(t, epic.ID)
+
+	converted, err := repo.GetByID(ctx, idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IdeaStatusConverted, converted.Status)
+	require.NotNil(t, converted.ConvertedToKey)
+	assert.Equal(t, "E05", *converted.ConvertedToKey)
+
+	// A second conversion is refused and creates nothing
+	again := &models.Epic{Key: "E06", Title: idea.Title, Status: "draft", Priority: models.PriorityMedium}
+	err = repo.ConvertToEpic(ctx, idea.ID, again)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already converted to epic E05")
+	_, err = NewEpicRepository(db).GetByKey(ctx, "E06")
+	assert.Error(t, err)
+}
+
+func TestIdeaRepository_ConvertRollsBack(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewIdeaRepository(db)
+	taskID := createTestTask(t, db) // E01, E01-F01, T-E01-F01-001
+	task, err := NewTaskRepository(db).GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	// The epic key is taken, so the idea must stay unconverted
+	idea := createConversionTestIdea(t, db, "I-2026-01-01-01")
+	err = repo.ConvertToEpic(ctx, idea.ID, &models.Epic{Key: "E01", Title: idea.Title, Status: "draft", Priority: models.PriorityMedium})
+	require.Error(t, err)
+
+	unchanged, err := repo.GetByID(ctx, idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IdeaStatusNew, unchanged.Status)
+	assert.Nil(t, unchanged.ConvertedToKey)
+
+	// A task conversion creates the task with its history in the same transaction
+	newTask := &models.Task{FeatureID: task.FeatureID, Key: "T-E01-F01-002", Title: idea.Title, Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, repo.ConvertToTask(ctx, idea.ID, newTask))
+
+	var historyCount int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM task_history WHERE task_id = ?`, newTask.ID).Scan(&historyCount))
+	assert.Equal(t, 1, historyCount)
+
+	converted, err := repo.GetByID(ctx, idea.ID)
+	require.NoError(t, err)
+	require.NotNil(t, converted.ConvertedToType)
+	assert.Equal(t, "task", *converted.ConvertedToType)
+}
+
+func TestIdeaRepository_ConvertCarriesNotesAndDocs(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewIdeaRepository(db)
+	taskID := createTestTask(t, db) // E01, E01-F01, T-E01-F01-001
+	task, err := NewTaskRepository(db).GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	newIdea := func(key string) *models.Idea {
+		notes := "Talk to support first"
+		docs := `["docs/research/survey.md", "docs/spec.md"]`
+		idea := &models.Idea{Key: key, Title: "Convert me", CreatedDate: time.Now(), Status: models.IdeaStatusNew, Notes: &notes, RelatedDocs: &docs}
+		require.NoError(t, repo.Create(ctx, idea))
+		return idea
+	}
+	countDocs := func(entityType string, entityID int64) int {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM `+entityType+`_documents WHERE `+entityType+`_id = ?`, entityID).Scan(&count))
+		return count
+	}
+
+	// Epic: notes become an epic note
+	epic := &models.Epic{Key: "E05", Title: "Convert me", Status: "draft", Priority: models.PriorityMedium}
+	require.NoError(t, repo.ConvertToEpic(ctx, newIdea("I-2026-01-01-01").ID, epic))
+	var content string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT content FROM epic_notes WHERE epic_id = ?`, epic.ID).Scan(&content))
+	assert.Equal(t, "Notes from idea I-2026-01-01-01:\nTalk to support first", content)
+	assert.Equal(t, 2, countDocs("epic", epic.ID))
+
+	// Feature: notes are appended to the description
+	description := "Feature description"
+	feature := &models.Feature{EpicID: epic.ID, Key: "E05-F01", Title: "Convert me", Description: &description, Status: "draft"}
+	require.NoError(t, repo.ConvertToFeature(ctx, newIdea("I-2026-01-01-02").ID, feature))
+	saved, err := NewFeatureRepository(db).GetByID(ctx, feature.ID)
+	require.NoError(t, err)
+	require.NotNil(t, saved.Description)
+	assert.Equal(t, "Feature description\n\nNotes from idea I-2026-01-01-02:\nTalk to support first", *saved.Description)
+	assert.Equal(t, 2, countDocs("feature", feature.ID))
+
+	// Task: notes become a comment, and existing documents are reused
+	newTask := &models.Task{FeatureID: task.FeatureID, Key: "T-E01-F01-002", Title: "Convert me", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, repo.ConvertToTask(ctx, newIdea("I-2026-01-01-03").ID, newTask))
+	var noteType string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT note_type, content FROM task_notes WHERE task_id = ?`, newTask.ID).Scan(&noteType, &content))
+	assert.Equal(t, string(models.NoteTypeComment), noteType)
+	assert.Contains(t, content, "Talk to support first")
+	assert.Equal(t, 2, countDocs("task", newTask.ID))
+
+	var documents int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM documents`).Scan(&documents))
+	assert.Equal(t, 2, documents)
+}
@@ -33,9 +33,9 @@ func (r *IdeaRepository) Create(ctx context.Context, idea *models.Idea) error {
 	query := `
 		INSERT INTO ideas (
 			key, title, description, created_date, priority, display_order,
-			notes, related_docs, dependencies, status
+			notes, related_docs, dependencies, file_path, status
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -48,6 +48,7 @@ func (r *IdeaRepository) Create(ctx context.Context, idea *models.Idea) error {
 		idea.Notes,
 		idea.RelatedDocs,
 		idea.Dependencies,
+		idea.FilePath,
 		idea.Status,
 	)
 	if err != nil {
@@ -67,7 +68,7 @@ func (r *IdeaRepository) Create(ctx context.Context, idea *models.Idea) error {
 func (r *IdeaRepository) GetByID(ctx context.Context, id int64) (*models.Idea, error) {
 	query := `
 		SELECT id, key, title, description, created_date, priority, display_order,
-		       notes, related_docs, dependencies, status, created_at, updated_at,
+		       notes, related_docs, dependencies, file_path, status, created_at, updated_at,
 		       converted_to_type, converted_to_key, converted_at
 		FROM ideas
 		WHERE id = ?
@@ -85,6 +86,7 @@ func (r *IdeaRepository) GetByID(ctx context.Context, id int64) (*models.Idea, e
 		&idea.Notes,
 		&idea.RelatedDocs,
 		&idea.Dependencies,
+		&idea.FilePath,
 		&idea.Status,
 		&idea.CreatedAt,
 		&idea.UpdatedAt,
@@ -107,7 +109,7 @@ func (r *IdeaRepository) GetByID(ctx context.Context, id int64) (*models.Idea, e
 func (r *IdeaRepository) GetByKey(ctx context.Context, key string) (*models.Idea, error) {
 	query := `
 		SELECT id, key, title, description, created_date, priority, display_order,
-		       notes, related_docs, dependencies, status, created_at, updated_at,
+		       notes, related_docs, dependencies, file_path, status, created_at, updated_at,
 		       converted_to_type, converted_to_key, converted_at
 		FROM ideas
 		WHERE key = ?
@@ -125,6 +127,7 @@ func (r *IdeaRepository) GetByKey(ctx context.Context, key string) (*models.Idea
 		&idea.Notes,
 		&idea.RelatedDocs,
 		&idea.Dependencies,
+		&idea.FilePath,
 		&idea.Status,
 		&idea.CreatedAt,
 		&idea.UpdatedAt,
@@ -143,11 +146,30 @@ func (r *IdeaRepository) GetByKey(ctx context.Context, key string) (*models.Idea
 	return idea, nil
 }
 
+// GetByTitle retrieves the idea with a title, ignoring case and surrounding
+// whitespace, so a retried create can find the idea it made. Returns nil if
+// there is none.
+func (r *IdeaRepository) GetByTitle(ctx context.Context, title string) (*models.Idea, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT key FROM ideas
+		WHERE LOWER(TRIM(title)) = LOWER(TRIM(?))
+		ORDER BY id LIMIT 1
+	`, title).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil // Not found is not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get idea by title: %w", err)
+	}
+	return r.GetByKey(ctx, key)
+}
+
 // List retrieves all ideas, optionally filtered by status
 func (r *IdeaRepository) List(ctx context.Context, filter *IdeaFilter) ([]*models.Idea, error) {
 	query := `
 		SELECT id, key, title, description, created_date, priority, display_order,
-		       notes, related_docs, dependencies, status, created_at, updated_at,
+		       notes, related_docs, dependencies, file_path, status, created_at, updated_at,
 		       converted_to_type, converted_to_key, converted_at
 		FROM ideas
 	`
@@ -181,6 +203,7 @@ func (r *IdeaRepository) List(ctx context.Context, filter *IdeaFilter) ([]*model
 			&idea.Notes,
 			&idea.RelatedDocs,
 			&idea.Dependencies,
+			&idea.FilePath,
 			&idea.Status,
 			&idea.CreatedAt,
 			&idea.UpdatedAt,
@@ -210,7 +233,7 @@ func (r *IdeaRepository) Update(ctx context.Context, idea *models.Idea) error {
 	query := `
 		UPDATE ideas
 		SET title = ?, description = ?, priority = ?, display_order = ?,
-		    notes = ?, related_docs = ?, dependencies = ?, status = ?
+		    notes = ?, related_docs = ?, dependencies = ?, file_path = ?, status = ?
 		WHERE id = ?
 	`
 
@@ -222,6 +245,7 @@ func (r *IdeaRepository) Update(ctx context.Context, idea *models.Idea) error {
 		idea.Notes,
 		idea.RelatedDocs,
 		idea.Dependencies,
+		idea.FilePath,
 		idea.Status,
 		idea.ID,
 	)
@@ -264,6 +288,11 @@ func (r *IdeaRepository) Delete(ctx context.Context, id int64) error {
 
 // MarkAsConverted updates an idea's conversion tracking fields
 func (r *IdeaRepository) MarkAsConverted(ctx context.Context, ideaID int64, convertedToType, convertedToKey string) error {
+	return markIdeaConverted(ctx, r.db, ideaID, convertedToType, convertedToKey)
+}
+
+// markIdeaConverted marks an idea converted on db or a transaction
+func markIdeaConverted(ctx context.Context, db execer, ideaID int64, convertedToType, convertedToKey string) error {
 	query := `
 		UPDATE ideas
 		SET status = 'converted',
@@ -273,7 +302,7 @@ func (r *IdeaRepository) MarkAsConverted(ctx context.Context, ideaID int64, conv
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, convertedToType, convertedToKey, ideaID)
+	result, err := db.ExecContext(ctx, query, convertedToType, convertedToKey, ideaID)
 	if err != nil {
 		return fmt.Errorf("failed to mark idea as converted: %w", err)
 	}
@@ -290,23 +319,38 @@ func (r *IdeaRepository) MarkAsConverted(ctx context.Context, ideaID int64, conv
 	return nil
 }
 
-// GetNextSequenceForDate returns the next sequence number for a given date
-// This is optimized to query the database directly instead of loading all ideas
+// UpdateFilePath updates or clears the file path of an idea
+func (r *IdeaRepository) UpdateFilePath(ctx context.Context, ideaKey string, newFilePath *string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE ideas SET file_path = ? WHERE key = ?`, newFilePath, ideaKey)
+	if err != nil {
+		return fmt.Errorf("failed to update idea file path: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("idea not found with key %q", ideaKey)
+	}
+
+	return nil
+}
+
+// GetNextSequenceForDate reserves and returns the next sequence number for a given date.
+// Safe to call concurrently: each call returns a distinct number.
 func (r *IdeaRepository) GetNextSequenceForDate(ctx context.Context, dateStr string) (int, error) {
-	query := `
+	pattern := fmt.Sprintf("I-%s-%%", dateStr)
+	nextSeq, err := reserveKeySequence(ctx, r.db, "idea:"+dateStr, `
 		SELECT COALESCE(MAX(CAST(SUBSTR(key, 14) AS INTEGER)), 0)
 		FROM ideas
 		WHERE key LIKE ?
-	`
-
-	var maxSeq int
-	pattern := fmt.Sprintf("I-%s-%%", dateStr)
-	err := r.db.QueryRowContext(ctx, query, pattern).Scan(&maxSeq)
+	`, pattern)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get next sequence: %w", err)
 	}
 
-	nextSeq := maxSeq + 1
 	if nextSeq > 99 {
 		return 0, fmt.Errorf("maximum ideas for date %s reached (99)", dateStr)
 	}
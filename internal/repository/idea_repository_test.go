@@ -326,6 +326,56 @@ func TestIdeaRepository_Update(t *testing.T) {
 	}
 }
 
+// TestIdeaRepository_UpdateFilePath tests setting and clearing an idea's file path
+func TestIdeaRepository_UpdateFilePath(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	dbWrapper := NewDB(database)
+	repo := NewIdeaRepository(dbWrapper)
+
+	// Clean up existing test data
+	_, _ = database.ExecContext(ctx, "DELETE FROM ideas WHERE key = 'I-2026-01-04-02'")
+
+	filePath := "docs/plan/ideas/I-2026-01-04-02-with-file.md"
+	idea := &models.Idea{
+		Key:         "I-2026-01-04-02",
+		Title:       "With File",
+		CreatedDate: time.Now(),
+		FilePath:    &filePath,
+		Status:      models.IdeaStatusNew,
+	}
+
+	err := repo.Create(ctx, idea)
+	if err != nil {
+		t.Fatalf("Failed to create idea: %v", err)
+	}
+	defer func() { _, _ = database.ExecContext(ctx, "DELETE FROM ideas WHERE id = ?", idea.ID) }()
+
+	retrieved, err := repo.GetByKey(ctx, idea.Key)
+	if err != nil {
+		t.Fatalf("Failed to get idea: %v", err)
+	}
+	if retrieved.FilePath == nil || *retrieved.FilePath != filePath {
+		t.Fatalf("Expected file path %q, got %v", filePath, retrieved.FilePath)
+	}
+
+	if err := repo.UpdateFilePath(ctx, idea.Key, nil); err != nil {
+		t.Fatalf("Failed to clear file path: %v", err)
+	}
+
+	retrieved, err = repo.GetByKey(ctx, idea.Key)
+	if err != nil {
+		t.Fatalf("Failed to get idea: %v", err)
+	}
+	if retrieved.FilePath != nil {
+		t.Errorf("Expected file path to be cleared, got %q", *retrieved.FilePath)
+	}
+
+	if err := repo.UpdateFilePath(ctx, "I-2099-01-01-01", &filePath); err == nil {
+		t.Error("Expected error for non-existent idea")
+	}
+}
+
 // TestIdeaRepository_Delete tests deleting an idea
 func TestIdeaRepository_Delete(t *testing.T) {
 	ctx := context.Background()
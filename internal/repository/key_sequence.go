@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// reserveKeySequence atomically reserves the next number in a key sequence.
+//
+// maxQuery is a scalar query returning the highest number already used by
+// existing rows in the scope; it seeds the sequence the first time a scope is
+// used and keeps it ahead of keys created outside the sequence (imports, manual keys).
+// The read and increment happen in a single statement, so concurrent callers
+// always receive distinct numbers. Numbers are not reused if a create fails.
+func reserveKeySequence(ctx context.Context, db *DB, scope, maxQuery string, args ...interface{}) (int, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO key_sequences (scope, last_value, updated_at)
+		VALUES (?, (%s) + 1, CURRENT_TIMESTAMP)
+		ON CONFLICT(scope) DO UPDATE SET
+			last_value = MAX(key_sequences.last_value, excluded.last_value - 1) + 1,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING last_value
+	`, maxQuery)
+
+	queryArgs := append([]interface{}{scope}, args...)
+
+	var next int
+	if err := db.QueryRowContext(ctx, query, queryArgs...).Scan(&next); err != nil {
+		return 0, fmt.Errorf("failed to reserve %s key: %w", scope, err)
+	}
+
+	return next, nil
+}
+
+// peekKeySequence returns the number reserveKeySequence would hand out next in
+// scope without reserving it, for previews. A concurrent create may take it first.
+func peekKeySequence(ctx context.Context, db *DB, scope, maxQuery string, args ...interface{}) (int, error) {
+	query := fmt.Sprintf(`
+		SELECT MAX(
+			COALESCE((SELECT last_value FROM key_sequences WHERE scope = ?), 0),
+			(%s)
+		) + 1
+	`, maxQuery)
+
+	queryArgs := append([]interface{}{scope}, args...)
+
+	var next int
+	if err := db.QueryRowContext(ctx, query, queryArgs...).Scan(&next); err != nil {
+		return 0, fmt.Errorf("failed to look up next %s key: %w", scope, err)
+	}
+
+	return next, nil
+}
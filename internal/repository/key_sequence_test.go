@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpicRepository_NextKey_SeedsFromExistingEpics(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+
+	require.NoError(t, epicRepo.Create(ctx, &models.Epic{Key: "E07", Title: "Existing", Status: "active", Priority: "high"}))
+
+	// Peeking doesn't reserve the key
+	key, err := epicRepo.PeekNextKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "E08", key)
+
+	key, err = epicRepo.NextKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "E08", key)
+
+	// Reserved keys are not handed out twice even before the epic is created
+	key, err = epicRepo.PeekNextKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "E09", key)
+	key, err = epicRepo.NextKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "E09", key)
+
+	// Keys created outside the sequence push it forward
+	require.NoError(t, epicRepo.Create(ctx, &models.Epic{Key: "E20", Title: "Manual", Status: "active", Priority: "high"}))
+	key, err = epicRepo.NextKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "E21", key)
+}
+
+func TestFeatureRepository_NextKey_PerEpic(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+
+	epic1 := &models.Epic{Key: "E01", Title: "One", Status: "active", Priority: "high"}
+	epic2 := &models.Epic{Key: "E02", Title: "Two", Status: "active", Priority: "high"}
+	require.NoError(t, epicRepo.Create(ctx, epic1))
+	require.NoError(t, epicRepo.Create(ctx, epic2))
+	require.NoError(t, featureRepo.Create(ctx, &models.Feature{EpicID: epic1.ID, Key: "E01-F03", Title: "Existing", Status: "active"}))
+
+	key, err := featureRepo.NextKey(ctx, epic1.ID, epic1.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "E01-F04", key)
+
+	key, err = featureRepo.PeekNextKey(ctx, epic1.ID, epic1.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "E01-F05", key)
+
+	key, err = featureRepo.NextKey(ctx, epic2.ID, epic2.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "E02-F01", key)
+
+	_, err = featureRepo.NextKey(ctx, epic2.ID, "")
+	assert.Error(t, err)
+}
+
+func TestNextKey_ConcurrentCallersGetDistinctKeys(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "keys.db")
+	testDB, err := db.InitDB(dbPath)
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	ideaRepo := NewIdeaRepository(database)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*2)
+	epicKeys := make(chan string, workers)
+	ideaSeqs := make(chan int, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			key, err := epicRepo.NextKey(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			epicKeys <- key
+			if err := epicRepo.Create(ctx, &models.Epic{Key: key, Title: fmt.Sprintf("Epic %d", i), Status: "active", Priority: "high"}); err != nil {
+				errs <- err
+			}
+
+			seq, err := ideaRepo.GetNextSequenceForDate(ctx, "2026-10-16")
+			if err != nil {
+				errs <- err
+				return
+			}
+			ideaSeqs <- seq
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	close(epicKeys)
+	close(ideaSeqs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	seenKeys := make(map[string]bool)
+	for key := range epicKeys {
+		assert.False(t, seenKeys[key], "duplicate epic key %s", key)
+		seenKeys[key] = true
+	}
+	assert.Len(t, seenKeys, workers)
+
+	seenSeqs := make(map[int]bool)
+	for seq := range ideaSeqs {
+		assert.False(t, seenSeqs[seq], "duplicate idea sequence %d", seq)
+		seenSeqs[seq] = true
+	}
+	assert.Len(t, seenSeqs, workers)
+}
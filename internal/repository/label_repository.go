@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// labelTarget identifies a junction table linking labels to an entity type
+type labelTarget struct {
+	table  string // e.g. "task_labels"
+	column string // e.g. "task_id"
+}
+
+var (
+	taskLabelTarget    = labelTarget{table: "task_labels", column: "task_id"}
+	featureLabelTarget = labelTarget{table: "feature_labels", column: "feature_id"}
+)
+
+// LabelCount is a label with the number of tasks and features using it
+type LabelCount struct {
+	Name     string `json:"name"`
+	Tasks    int    `json:"tasks"`
+	Features int    `json:"features"`
+}
+
+// LabelRepository handles labels and their task/feature assignments
+type LabelRepository struct {
+	db *DB
+}
+
+// NewLabelRepository creates a new LabelRepository
+func NewLabelRepository(db *DB) *LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+// AddTaskLabels attaches labels to a task, creating labels that do not exist yet
+func (r *LabelRepository) AddTaskLabels(ctx context.Context, taskID int64, names []string) error {
+	return r.addLabels(ctx, taskLabelTarget, taskID, names)
+}
+
+// RemoveTaskLabels detaches labels from a task
+func (r *LabelRepository) RemoveTaskLabels(ctx context.Context, taskID int64, names []string) error {
+	return r.removeLabels(ctx, taskLabelTarget, taskID, names)
+}
+
+// GetTaskLabels returns the sorted labels of each task, keyed by task ID
+func (r *LabelRepository) GetTaskLabels(ctx context.Context, taskIDs []int64) (map[int64][]string, error) {
+	return r.getLabels(ctx, taskLabelTarget, taskIDs)
+}
+
+// AddFeatureLabels attaches labels to a feature, creating labels that do not exist yet
+func (r *LabelRepository) AddFeatureLabels(ctx context.Context, featureID int64, names []string) error {
+	return r.addLabels(ctx, featureLabelTarget, featureID, names)
+}
+
+// RemoveFeatureLabels detaches labels from a feature
+func (r *LabelRepository) RemoveFeatureLabels(ctx context.Context, featureID int64, names []string) error {
+	return r.removeLabels(ctx, featureLabelTarget, featureID, names)
+}
+
+// GetFeatureLabels returns the sorted labels of each feature, keyed by feature ID
+func (r *LabelRepository) GetFeatureLabels(ctx context.Context, featureIDs []int64) (map[int64][]string, error) {
+	return r.getLabels(ctx, featureLabelTarget, featureIDs)
+}
+
+// List returns all labels with usage counts, ordered by name
+func (r *LabelRepository) List(ctx context.Context) ([]*LabelCount, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.name,
+			(SELECT COUNT(*) FROM task_labels tl WHERE tl.label_id = l.id),
+			(SELECT COUNT(*) FROM feature_labels fl WHERE fl.label_id = l.id)
+		FROM labels l
+		ORDER BY l.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := []*LabelCount{}
+	for rows.Next() {
+		label := &LabelCount{}
+		if err := rows.Scan(&label.Name, &label.Tasks, &label.Features); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// addLabels creates missing labels and links them to the entity in one transaction
+func (r *LabelRepository) addLabels(ctx context.Context, target labelTarget, entityID int64, names []string) error {
+	names, err := models.NormalizeLabels(names)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, name := range names {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO labels (name) VALUES (?) ON CONFLICT(name) DO NOTHING`, name); err != nil {
+			return fmt.Errorf("failed to create label %q: %w", name, err)
+		}
+		query := fmt.Sprintf(`
+			INSERT INTO %s (%s, label_id)
+			SELECT ?, id FROM labels WHERE name = ?
+			ON CONFLICT DO NOTHING
+		`, target.table, target.column)
+		if _, err := tx.ExecContext(ctx, query, entityID, name); err != nil {
+			return fmt.Errorf("failed to add label %q: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// removeLabels unlinks labels from the entity; unknown labels are ignored
+func (r *LabelRepository) removeLabels(ctx context.Context, target labelTarget, entityID int64, names []string) error {
+	names, err := models.NormalizeLabels(names)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE %s = ? AND label_id IN (SELECT id FROM labels WHERE name IN (%s))
+	`, target.table, target.column, placeholders(len(names)))
+
+	args := []interface{}{entityID}
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to remove labels: %w", err)
+	}
+	return nil
+}
+
+// getLabels loads the labels of several entities in a single query
+func (r *LabelRepository) getLabels(ctx context.Context, target labelTarget, ids []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT j.%s, l.name
+		FROM %s j
+		JOIN labels l ON l.id = j.label_id
+		WHERE j.%s IN (%s)
+	`, target.column, target.table, target.column, placeholders(len(ids)))
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		result[id] = append(result[id], name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labels: %w", err)
+	}
+
+	for _, names := range result {
+		sort.Strings(names)
+	}
+	return result, nil
+}
+
+// TaskLabelFilterSQL returns a condition matching tasks (aliased as taskAlias)
+// that carry every one of the given labels, for use in WHERE or JOIN clauses
+func TaskLabelFilterSQL(taskAlias string, labels []string) (string, []interface{}) {
+	condition := fmt.Sprintf(`%s.id IN (
+		SELECT tl.task_id FROM task_labels tl
+		JOIN labels l ON l.id = tl.label_id
+		WHERE l.name IN (%s)
+		GROUP BY tl.task_id
+		HAVING COUNT(DISTINCT l.id) = ?
+	)`, taskAlias, placeholders(len(labels)))
+
+	args := make([]interface{}, 0, len(labels)+1)
+	for _, label := range labels {
+		args = append(args, label)
+	}
+	args = append(args, len(labels))
+	return condition, args
+}
+
+// HasAllLabels reports whether have contains every label in want
+func HasAllLabels(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholders returns n comma-separated SQL placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelRepository_TaskLabels(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewLabelRepository(db)
+
+	require.NoError(t, repo.AddTaskLabels(ctx, taskID, []string{"Security", "tech-debt,frontend"}))
+	// Adding an existing label is a no-op
+	require.NoError(t, repo.AddTaskLabels(ctx, taskID, []string{"security"}))
+
+	labels, err := repo.GetTaskLabels(ctx, []int64{taskID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "security", "tech-debt"}, labels[taskID])
+
+	require.NoError(t, repo.RemoveTaskLabels(ctx, taskID, []string{"frontend", "unknown"}))
+	labels, err = repo.GetTaskLabels(ctx, []int64{taskID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"security", "tech-debt"}, labels[taskID])
+
+	assert.Error(t, repo.AddTaskLabels(ctx, taskID, []string{"has space"}))
+}
+
+func TestLabelRepository_FeatureLabelsAndList(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewLabelRepository(db)
+
+	task, err := NewTaskRepository(db).GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddFeatureLabels(ctx, task.FeatureID, []string{"frontend"}))
+	require.NoError(t, repo.AddTaskLabels(ctx, taskID, []string{"frontend", "security"}))
+
+	labels, err := repo.GetFeatureLabels(ctx, []int64{task.FeatureID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, labels[task.FeatureID])
+
+	counts, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+	assert.Equal(t, LabelCount{Name: "frontend", Tasks: 1, Features: 1}, *counts[0])
+	assert.Equal(t, LabelCount{Name: "security", Tasks: 1, Features: 0}, *counts[1])
+}
+
+func TestTaskLabelFilterSQL(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewLabelRepository(db)
+	require.NoError(t, repo.AddTaskLabels(ctx, taskID, []string{"security", "frontend"}))
+
+	count := func(labels ...string) int {
+		condition, args := TaskLabelFilterSQL("t", labels)
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks t WHERE "+condition, args...).Scan(&n))
+		return n
+	}
+
+	assert.Equal(t, 1, count("security"))
+	assert.Equal(t, 1, count("security", "frontend"))
+	assert.Equal(t, 0, count("security", "backend"))
+}
+
+func TestHasAllLabels(t *testing.T) {
+	assert.True(t, HasAllLabels([]string{"a", "b"}, []string{"b"}))
+	assert.True(t, HasAllLabels([]string{"a"}, nil))
+	assert.False(t, HasAllLabels([]string{"a"}, []string{"a", "b"}))
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ListOptions orders and pages a list query
+type ListOptions struct {
+	SortBy string // A field the list can be sorted by; empty for the list's default order
+	Desc   bool   // Sort descending
+	Limit  int    // Maximum rows to return; 0 for all
+	Offset int    // Rows to skip
+}
+
+// ParseSort parses a sort given as "field" or "field:asc" or "field:desc"
+func ParseSort(spec string) (field string, desc bool, err error) {
+	field, direction, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	switch strings.ToLower(direction) {
+	case "", "asc":
+		return field, false, nil
+	case "desc":
+		return field, true, nil
+	default:
+		return "", false, fmt.Errorf("invalid sort direction %q in %q: must be asc or desc", direction, spec)
+	}
+}
+
+// orderSQL returns the ORDER BY clause for opts. fields maps each field the
+// list can be sorted by to its SQL expression; defaultOrder is used when no
+// field is given, and tieBreak keeps the order stable between pages.
+func (o ListOptions) orderSQL(fields map[string]string, defaultOrder, tieBreak string) (string, error) {
+	if o.SortBy == "" {
+		return "\nORDER BY " + defaultOrder, nil
+	}
+	expr, ok := fields[o.SortBy]
+	if !ok {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("invalid sort field %q: must be one of %s", o.SortBy, strings.Join(names, ", "))
+	}
+	direction := "ASC"
+	if o.Desc {
+		direction = "DESC"
+	}
+	if strings.HasPrefix(tieBreak, expr+" ") {
+		return fmt.Sprintf("\nORDER BY %s %s", expr, direction), nil
+	}
+	return fmt.Sprintf("\nORDER BY %s %s, %s", expr, direction, tieBreak), nil
+}
+
+// pageSQL returns the LIMIT and OFFSET clause for opts, if it pages at all
+func (o ListOptions) pageSQL() (string, []interface{}) {
+	if o.Limit <= 0 && o.Offset <= 0 {
+		return "", nil
+	}
+	limit := o.Limit
+	if limit <= 0 {
+		limit = -1 // SQLite needs a LIMIT for an OFFSET; -1 means no limit
+	}
+	offset := o.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	return "\nLIMIT ? OFFSET ?", []interface{}{limit, offset}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	field, desc, err := ParseSort("updated_at:desc")
+	require.NoError(t, err)
+	assert.Equal(t, "updated_at", field)
+	assert.True(t, desc)
+
+	field, desc, err = ParseSort("priority")
+	require.NoError(t, err)
+	assert.Equal(t, "priority", field)
+	assert.False(t, desc)
+
+	_, _, err = ParseSort("priority:sideways")
+	assert.Error(t, err)
+}
+
+func TestTaskRepository_ListFiltered_SortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskID := createTestTask(t, db) // T-E01-F01-001, priority 5
+	repo := NewTaskRepository(db)
+	first, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	for i, priority := range []int{2, 8, 1, 4} {
+		status := models.TaskStatusTodo
+		if i == 1 {
+			status = models.TaskStatusCompleted
+		}
+		require.NoError(t, repo.Create(ctx, &models.Task{
+			FeatureID: first.FeatureID,
+			Key:       fmt.Sprintf("T-E01-F01-%03d", i+2),
+			Title:     fmt.Sprintf("Task %d", i+2),
+			Status:    status,
+			Priority:  priority,
+		}))
+	}
+
+	keys := func(tasks []*models.Task) []string {
+		result := make([]string, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.Key
+		}
+		return result
+	}
+
+	// Sorted by priority, highest first, one page at a time
+	opts := ListOptions{SortBy: "priority", Desc: true, Limit: 2}
+	tasks, err := repo.ListFiltered(ctx, TaskListFilter{}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-003", "T-E01-F01-001"}, keys(tasks))
+
+	opts.Offset = 4
+	tasks, err = repo.ListFiltered(ctx, TaskListFilter{}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-004"}, keys(tasks))
+
+	// An offset without a limit skips rows
+	tasks, err = repo.ListFiltered(ctx, TaskListFilter{}, ListOptions{SortBy: "key", Offset: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-004", "T-E01-F01-005"}, keys(tasks))
+
+	// Filters apply before paging, and the count ignores the page
+	filter := TaskListFilter{HideCompleted: true}
+	tasks, err = repo.ListFiltered(ctx, filter, ListOptions{SortBy: "priority", Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-004", "T-E01-F01-002", "T-E01-F01-005"}, keys(tasks))
+	count, err := repo.CountFiltered(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	// Unknown sort fields are rejected
+	_, err = repo.ListFiltered(ctx, TaskListFilter{}, ListOptions{SortBy: "depends_on"})
+	assert.ErrorContains(t, err, "invalid sort field")
+}
+
+func TestEpicRepository_ListPage(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewEpicRepository(db)
+	for _, epic := range []*models.Epic{
+		{Key: "E03", Title: "Three", Status: models.EpicStatusDraft, Priority: models.PriorityLow},
+		{Key: "E01", Title: "One", Status: models.EpicStatusCompleted, Priority: models.PriorityHigh},
+		{Key: "E02", Title: "Two", Status: models.EpicStatusActive, Priority: models.PriorityMedium},
+	} {
+		require.NoError(t, repo.Create(ctx, epic))
+	}
+
+	epics, err := repo.ListPage(ctx, nil, ListOptions{SortBy: "key", Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, epics, 2)
+	assert.Equal(t, "E02", epics[0].Key)
+	assert.Equal(t, "E03", epics[1].Key)
+
+	// Status sorts in workflow order, not alphabetically
+	epics, err = repo.ListPage(ctx, nil, ListOptions{SortBy: "status"})
+	require.NoError(t, err)
+	require.Len(t, epics, 3)
+	assert.Equal(t, []string{"E03", "E02", "E01"}, []string{epics[0].Key, epics[1].Key, epics[2].Key})
+
+	count, err := repo.Count(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/slug"
+)
+
+// PlanTaskMove plans moving a task to another feature. The task gets the next
+// key in the target feature. If the task has a file and toDir is set, the file
+// moves into toDir (the target feature's tasks directory).
+func (r *RenumberRepository) PlanTaskMove(ctx context.Context, task *models.Task, feature *models.Feature, toDir string) (*RenumberPlan, error) {
+	if task.FeatureID == feature.ID {
+		return nil, fmt.Errorf("task %s is already in feature %s", task.Key, feature.Key)
+	}
+
+	next, err := NewTaskRepository(r.db).PeekNextKeyNumber(ctx, feature.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find next task number in %s: %w", feature.Key, err)
+	}
+	if next > 999 {
+		return nil, fmt.Errorf("feature %s has reached maximum task count (999)", feature.Key)
+	}
+
+	newKey := fmt.Sprintf("T-%s-%03d", feature.Key, next)
+	plan := &RenumberPlan{
+		keyMap:    map[string]string{task.Key: newKey},
+		pathMoves: make(map[string]string),
+	}
+	change := &KeyChange{
+		EntityType:  RenumberEntityTask,
+		ID:          task.ID,
+		NewParentID: feature.ID,
+		OldKey:      task.Key,
+		NewKey:      newKey,
+		OldFilePath: nonEmpty(task.FilePath),
+	}
+	if change.OldFilePath != nil && toDir != "" {
+		plan.pathMoves[*change.OldFilePath] = path.Join(toDir, plan.RewriteName(path.Base(*change.OldFilePath)))
+	}
+	plan.Changes = []*KeyChange{change}
+	plan.rewriteFilePaths()
+
+	return plan, nil
+}
+
+// PlanFeatureMove plans moving a feature, with all of its tasks, to another
+// epic. The feature gets the next feature key in the target epic and its tasks
+// keep their numbers under it. If from is set, the feature's directory (or its
+// file, if it has no directory of its own) moves into toDir (the target epic's
+// directory) along with the files below it.
+func (r *RenumberRepository) PlanFeatureMove(ctx context.Context, feature *models.Feature, epic *models.Epic, from, toDir string) (*RenumberPlan, error) {
+	if feature.EpicID == epic.ID {
+		return nil, fmt.Errorf("feature %s is already in epic %s", feature.Key, epic.Key)
+	}
+	if feature.Key == models.BacklogKey || epic.Key == models.BacklogKey {
+		return nil, fmt.Errorf("the backlog can't be moved or hold other features; move standalone tasks with 'shark task move'")
+	}
+
+	newKey, err := NewFeatureRepository(r.db).NextKey(ctx, epic.ID, epic.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RenumberPlan{
+		keyMap:    map[string]string{feature.Key: newKey},
+		pathMoves: make(map[string]string),
+	}
+	plan.Changes = append(plan.Changes, &KeyChange{
+		EntityType:  RenumberEntityFeature,
+		ID:          feature.ID,
+		NewParentID: epic.ID,
+		OldKey:      feature.Key,
+		NewKey:      newKey,
+		OldFilePath: nonEmpty(feature.FilePath),
+	})
+
+	// Tasks stay in the feature, including trashed and archived ones since
+	// they still hold their keys
+	rows, err := r.db.QueryContext(ctx, "SELECT id, key, file_path FROM tasks WHERE feature_id = ? ORDER BY id", feature.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks of %s: %w", feature.Key, err)
+	}
+	defer rows.Close()
+
+	taskPrefix := "T-" + feature.Key + "-"
+	for rows.Next() {
+		var id int64
+		var key string
+		var filePath sql.NullString
+		if err := rows.Scan(&id, &key, &filePath); err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		if !strings.HasPrefix(key, taskPrefix) {
+			continue
+		}
+		newTaskKey := "T-" + newKey + "-" + strings.TrimPrefix(key, taskPrefix)
+		plan.keyMap[key] = newTaskKey
+		plan.Changes = append(plan.Changes, &KeyChange{
+			EntityType:  RenumberEntityTask,
+			ID:          id,
+			OldKey:      key,
+			NewKey:      newTaskKey,
+			OldFilePath: nonEmpty(&filePath.String),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load tasks of %s: %w", feature.Key, err)
+	}
+
+	if from != "" && toDir != "" {
+		plan.pathMoves[from] = path.Join(toDir, plan.RewriteName(path.Base(from)))
+	}
+	plan.rewriteFilePaths()
+
+	return plan, nil
+}
+
+// PlanFeatureRename plans giving a feature a new title. Keys stay the same. If
+// from is set, the feature's directory (or its file, if it has no directory of
+// its own) is renamed to "to" and the file paths of the feature, its tasks, and
+// linked documents below it follow.
+func (r *RenumberRepository) PlanFeatureRename(ctx context.Context, feature *models.Feature, title, from, to string) (*RenumberPlan, error) {
+	if feature.Key == models.BacklogKey {
+		return nil, fmt.Errorf("the backlog can't be renamed")
+	}
+
+	plan := &RenumberPlan{
+		keyMap:    make(map[string]string),
+		pathMoves: make(map[string]string),
+		retitle:   &renumberRetitle{id: feature.ID, title: title, slug: slug.Generate(title)},
+	}
+	plan.Changes = append(plan.Changes, &KeyChange{
+		EntityType:  RenumberEntityFeature,
+		ID:          feature.ID,
+		OldKey:      feature.Key,
+		NewKey:      feature.Key,
+		OldFilePath: nonEmpty(feature.FilePath),
+	})
+
+	if from != "" && to != "" && from != to {
+		plan.pathMoves[from] = to
+
+		// Only tasks with files below the renamed directory change
+		rows, err := r.db.QueryContext(ctx, "SELECT id, key, file_path FROM tasks WHERE feature_id = ? AND file_path IS NOT NULL ORDER BY id", feature.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tasks of %s: %w", feature.Key, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			var key, filePath string
+			if err := rows.Scan(&id, &key, &filePath); err != nil {
+				return nil, fmt.Errorf("failed to scan task: %w", err)
+			}
+			if plan.MovedPath(filePath) == filePath {
+				continue
+			}
+			plan.Changes = append(plan.Changes, &KeyChange{
+				EntityType:  RenumberEntityTask,
+				ID:          id,
+				OldKey:      key,
+				NewKey:      key,
+				OldFilePath: nonEmpty(&filePath),
+			})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to load tasks of %s: %w", feature.Key, err)
+		}
+	}
+	plan.rewriteFilePaths()
+
+	return plan, nil
+}
+
+// PlanEpicRekey plans giving an epic a new key. Features keyed under the old
+// key (E05-F01) and their tasks (T-E05-F01-001) move to the new prefix, and
+// key-named files and directories follow. If from is set, the epic's directory
+// is renamed along with it.
+func (r *RenumberRepository) PlanEpicRekey(ctx context.Context, epic *models.Epic, newKey, from string) (*RenumberPlan, error) {
+	if epic.Key == models.BacklogKey || newKey == models.BacklogKey {
+		return nil, fmt.Errorf("the backlog epic's key can't be changed")
+	}
+	if epic.Key == newKey {
+		return nil, fmt.Errorf("epic %s already has that key", epic.Key)
+	}
+
+	plan := &RenumberPlan{
+		keyMap:    map[string]string{epic.Key: newKey},
+		pathMoves: make(map[string]string),
+	}
+	plan.Changes = append(plan.Changes, &KeyChange{
+		EntityType:  RenumberEntityEpic,
+		ID:          epic.ID,
+		OldKey:      epic.Key,
+		NewKey:      newKey,
+		OldFilePath: nonEmpty(epic.FilePath),
+	})
+
+	// Trashed features and tasks still hold their keys, so they follow too
+	featurePrefix := epic.Key + "-"
+	featureRows, err := r.loadRekeyRows(ctx, "SELECT id, key, file_path FROM features WHERE epic_id = ? ORDER BY id", epic.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load features of %s: %w", epic.Key, err)
+	}
+	for _, feature := range featureRows {
+		if !strings.HasPrefix(feature.key, featurePrefix) {
+			continue
+		}
+		newFeatureKey := newKey + "-" + strings.TrimPrefix(feature.key, featurePrefix)
+		if err := models.ValidateFeatureKey(newFeatureKey); err != nil {
+			return nil, fmt.Errorf("epic %s has features, so its new key must look like E01: %w", epic.Key, err)
+		}
+		plan.Changes = appendKeyChange(plan.Changes, plan, RenumberEntityFeature, feature, newFeatureKey)
+
+		taskPrefix := "T-" + feature.key + "-"
+		taskRows, err := r.loadRekeyRows(ctx, "SELECT id, key, file_path FROM tasks WHERE feature_id = ? ORDER BY id", feature.id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tasks of %s: %w", feature.key, err)
+		}
+		for _, task := range taskRows {
+			if !strings.HasPrefix(task.key, taskPrefix) {
+				continue
+			}
+			newTaskKey := "T-" + newFeatureKey + "-" + strings.TrimPrefix(task.key, taskPrefix)
+			plan.Changes = appendKeyChange(plan.Changes, plan, RenumberEntityTask, task, newTaskKey)
+		}
+	}
+
+	if from != "" {
+		if renamed := plan.RewriteName(path.Base(from)); renamed != path.Base(from) {
+			plan.pathMoves[from] = path.Join(path.Dir(from), renamed)
+		}
+	}
+	plan.rewriteFilePaths()
+
+	return plan, nil
+}
+
+// PlanFeatureResequence plans renumbering a feature's tasks so their numbers
+// run contiguously (001..n) after deletions, keeping their current order.
+// Trashed and archived tasks keep their place, since they still hold their
+// keys. Task files named for their keys follow, and the old keys are recorded
+// so they still resolve to the renumbered tasks.
+func (r *RenumberRepository) PlanFeatureResequence(ctx context.Context, feature *models.Feature) (*RenumberPlan, error) {
+	rows, err := r.loadRekeyRows(ctx, "SELECT id, key, file_path FROM tasks WHERE feature_id = ?", feature.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks of %s: %w", feature.Key, err)
+	}
+
+	// Tasks with keys that don't follow the feature's numbering are left alone
+	taskPrefix := "T-" + feature.Key + "-"
+	var tasks []*renumberRow
+	for _, row := range rows {
+		number := strings.TrimPrefix(row.key, taskPrefix)
+		if number == row.key || len(number) != 3 {
+			continue
+		}
+		if row.number, err = strconv.Atoi(number); err != nil {
+			continue
+		}
+		tasks = append(tasks, row)
+	}
+
+	plan := &RenumberPlan{keyMap: make(map[string]string), keepOldKeys: true}
+	var pending []*KeyChange
+	for i, task := range sortRenumberRows(tasks) {
+		pending = appendKeyChange(pending, plan, RenumberEntityTask, task, fmt.Sprintf("%s%03d", taskPrefix, i+1))
+	}
+	// Tasks already in place are left out of the plan
+	for _, change := range pending {
+		if change.OldKey != change.NewKey {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+	plan.rewriteFilePaths()
+
+	return plan, nil
+}
+
+// loadRekeyRows loads the id, key, and file path of the rows a query returns
+func (r *RenumberRepository) loadRekeyRows(ctx context.Context, query string, args ...interface{}) ([]*renumberRow, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*renumberRow
+	for rows.Next() {
+		var row renumberRow
+		var filePath sql.NullString
+		if err := rows.Scan(&row.id, &row.key, &filePath); err != nil {
+			return nil, err
+		}
+		row.filePath = nonEmpty(&filePath.String)
+		result = append(result, &row)
+	}
+	return result, rows.Err()
+}
+
+// AddAbsoluteMoves also applies the plan's path moves to absolute file paths
+// under projectRoot, since stored paths may be relative or absolute
+func (p *RenumberPlan) AddAbsoluteMoves(projectRoot string) {
+	root := filepath.ToSlash(projectRoot)
+	for _, move := range p.PathMoves() {
+		if !path.IsAbs(move.From) {
+			p.pathMoves[path.Join(root, move.From)] = path.Join(root, move.To)
+		}
+	}
+	p.rewriteFilePaths()
+}
+
+// rewriteFilePaths sets each change's new file path once the key map and
+// path moves are complete
+func (p *RenumberPlan) rewriteFilePaths() {
+	for _, change := range p.Changes {
+		if change.OldFilePath != nil {
+			newPath := p.RewritePath(*change.OldFilePath)
+			change.NewFilePath = &newPath
+		}
+	}
+}
+
+// nonEmpty returns nil for a nil or empty string
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// WithParentKeys returns a copy of the plan whose key map also maps a moved
+// entity's old parent keys to the new ones. Its RewriteName updates the
+// epic_key and feature_key frontmatter of moved files; it is not meant to be
+// applied.
+func (p *RenumberPlan) WithParentKeys(parents map[string]string) *RenumberPlan {
+	keyMap := make(map[string]string, len(p.keyMap)+len(parents))
+	for oldKey, newKey := range parents {
+		keyMap[oldKey] = newKey
+	}
+	for oldKey, newKey := range p.keyMap {
+		keyMap[oldKey] = newKey
+	}
+	return &RenumberPlan{keyMap: keyMap, pathMoves: p.pathMoves}
+}
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenumberRepository_Move(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+	taskRepo := NewTaskRepository(database)
+
+	strPtr := func(s string) *string { return &s }
+
+	e05 := &models.Epic{Key: "E05", Title: "Auth", Status: "active", Priority: "high", FilePath: strPtr("docs/plan/E05-auth/epic.md")}
+	e06 := &models.Epic{Key: "E06", Title: "Payments", Status: "active", Priority: "high", FilePath: strPtr("docs/plan/E06-payments/epic.md")}
+	require.NoError(t, epicRepo.Create(ctx, e05))
+	require.NoError(t, epicRepo.Create(ctx, e06))
+
+	f0501 := &models.Feature{EpicID: e05.ID, Key: "E05-F01", Title: "Login", Status: "active", FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/feature.md")}
+	f0602 := &models.Feature{EpicID: e06.ID, Key: "E06-F02", Title: "Checkout", Status: "active", FilePath: strPtr("docs/plan/E06-payments/E06-F02-checkout/feature.md")}
+	require.NoError(t, featureRepo.Create(ctx, f0501))
+	require.NoError(t, featureRepo.Create(ctx, f0602))
+
+	t1 := &models.Task{FeatureID: f0501.ID, Key: "T-E05-F01-001", Title: "Form", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/tasks/T-E05-F01-001.md")}
+	require.NoError(t, taskRepo.Create(ctx, t1))
+	t3 := &models.Task{FeatureID: f0501.ID, Key: "T-E05-F01-003", Title: "Session", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/tasks/T-E05-F01-003.md"), DependsOn: strPtr(`["T-E05-F01-001"]`)}
+	require.NoError(t, taskRepo.Create(ctx, t3))
+	existing := &models.Task{FeatureID: f0602.ID, Key: "T-E06-F02-004", Title: "Cart", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, existing))
+	// Create only accepts dependencies within the same feature
+	_, err = database.ExecContext(ctx, `UPDATE tasks SET depends_on = '["T-E05-F01-003"]' WHERE id = ?`, existing.ID)
+	require.NoError(t, err)
+
+	renumberRepo := NewRenumberRepository(database)
+
+	// Move a task: it takes the next key in the target feature
+	plan, err := renumberRepo.PlanTaskMove(ctx, t3, f0602, "docs/plan/E06-payments/E06-F02-checkout/tasks")
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, "T-E06-F02-005", plan.Changes[0].NewKey)
+	assert.Equal(t, "docs/plan/E06-payments/E06-F02-checkout/tasks/T-E06-F02-005.md", *plan.Changes[0].NewFilePath)
+	require.NoError(t, renumberRepo.Apply(ctx, plan))
+
+	moved, err := taskRepo.GetByKey(ctx, "T-E06-F02-005")
+	require.NoError(t, err)
+	assert.Equal(t, t3.ID, moved.ID)
+	assert.Equal(t, f0602.ID, moved.FeatureID)
+	require.NotNil(t, moved.DependsOn)
+	assert.JSONEq(t, `["T-E05-F01-001"]`, *moved.DependsOn)
+
+	dependent, err := taskRepo.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["T-E06-F02-005"]`, *dependent.DependsOn)
+
+	_, err = renumberRepo.PlanTaskMove(ctx, moved, f0602, "")
+	assert.Error(t, err, "moving a task to its own feature should fail")
+
+	// Move a feature: it takes the next feature key in the target epic and its
+	// tasks keep their numbers
+	plan, err = renumberRepo.PlanFeatureMove(ctx, f0501, e06, "docs/plan/E05-auth/E05-F01-login", "docs/plan/E06-payments")
+	require.NoError(t, err)
+	assert.Equal(t, []PathMove{{From: "docs/plan/E05-auth/E05-F01-login", To: "docs/plan/E06-payments/E06-F03-login"}}, plan.PathMoves())
+	assert.Equal(t, "docs/plan/E06-payments/E06-F03-login/tasks/T-E05-F01-001.md", plan.MovedPath(*t1.FilePath))
+	require.NoError(t, renumberRepo.Apply(ctx, plan))
+
+	feature, err := featureRepo.GetByKey(ctx, "E06-F03")
+	require.NoError(t, err)
+	assert.Equal(t, f0501.ID, feature.ID)
+	assert.Equal(t, e06.ID, feature.EpicID)
+	assert.Equal(t, "docs/plan/E06-payments/E06-F03-login/feature.md", *feature.FilePath)
+
+	task, err := taskRepo.GetByKey(ctx, "T-E06-F03-001")
+	require.NoError(t, err)
+	assert.Equal(t, t1.ID, task.ID)
+	assert.Equal(t, "docs/plan/E06-payments/E06-F03-login/tasks/T-E06-F03-001.md", *task.FilePath)
+
+	moved, err = taskRepo.GetByID(ctx, t3.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["T-E06-F03-001"]`, *moved.DependsOn)
+
+	// Frontmatter rewriting also maps the old parent keys
+	rewrite := plan.WithParentKeys(map[string]string{"E05": "E06"})
+	assert.Equal(t, "E06", rewrite.RewriteName("E05"))
+	assert.Equal(t, "E06-F03", rewrite.RewriteName("E05-F01"))
+	assert.Equal(t, "T-E06-F03-001", rewrite.RewriteName("T-E05-F01-001"))
+}
+
+func TestRenumberRepository_PlanFeatureRename(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+	taskRepo := NewTaskRepository(database)
+
+	strPtr := func(s string) *string { return &s }
+
+	epic := &models.Epic{Key: "E05", Title: "Auth", Status: "active", Priority: "high", FilePath: strPtr("docs/plan/E05-auth/epic.md")}
+	require.NoError(t, epicRepo.Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E05-F01", Title: "Login", Status: "active", FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/feature.md")}
+	require.NoError(t, featureRepo.Create(ctx, feature))
+
+	withFile := &models.Task{FeatureID: feature.ID, Key: "T-E05-F01-001", Title: "Form", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/tasks/T-E05-F01-001.md")}
+	require.NoError(t, taskRepo.Create(ctx, withFile))
+	elsewhere := &models.Task{FeatureID: feature.ID, Key: "T-E05-F01-002", Title: "Notes", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/notes/session.md")}
+	require.NoError(t, taskRepo.Create(ctx, elsewhere))
+
+	renumberRepo := NewRenumberRepository(database)
+	plan, err := renumberRepo.PlanFeatureRename(ctx, feature, "Sign-in & SSO", "docs/plan/E05-auth/E05-F01-login", "docs/plan/E05-auth/E05-F01-sign-in-sso")
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2, "tasks with files outside the feature directory don't change")
+	for _, change := range plan.Changes {
+		assert.Equal(t, change.OldKey, change.NewKey)
+	}
+	require.NoError(t, renumberRepo.Apply(ctx, plan))
+
+	renamed, err := featureRepo.GetByKey(ctx, "E05-F01")
+	require.NoError(t, err)
+	assert.Equal(t, "Sign-in & SSO", renamed.Title)
+	require.NotNil(t, renamed.Slug)
+	assert.Equal(t, "sign-in-sso", *renamed.Slug)
+	assert.Equal(t, "docs/plan/E05-auth/E05-F01-sign-in-sso/feature.md", *renamed.FilePath)
+
+	task, err := taskRepo.GetByKey(ctx, "T-E05-F01-001")
+	require.NoError(t, err)
+	assert.Equal(t, "docs/plan/E05-auth/E05-F01-sign-in-sso/tasks/T-E05-F01-001.md", *task.FilePath)
+	task, err = taskRepo.GetByKey(ctx, "T-E05-F01-002")
+	require.NoError(t, err)
+	assert.Equal(t, "docs/notes/session.md", *task.FilePath)
+
+	// The slugged key follows the new title
+	bySlug, err := featureRepo.GetByKey(ctx, "E05-F01-sign-in-sso")
+	require.NoError(t, err)
+	assert.Equal(t, feature.ID, bySlug.ID)
+
+	backlog := &models.Feature{Key: models.BacklogKey}
+	_, err = renumberRepo.PlanFeatureRename(ctx, backlog, "Other", "", "")
+	assert.Error(t, err)
+}
+
+func TestRenumberRepository_PlanEpicRekey(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+	taskRepo := NewTaskRepository(database)
+
+	strPtr := func(s string) *string { return &s }
+
+	e05 := &models.Epic{Key: "E05", Title: "Auth", Status: "active", Priority: "high", FilePath: strPtr("docs/plan/E05-auth/epic.md")}
+	e06 := &models.Epic{Key: "E06", Title: "Payments", Status: "active", Priority: "high"}
+	require.NoError(t, epicRepo.Create(ctx, e05))
+	require.NoError(t, epicRepo.Create(ctx, e06))
+
+	login := &models.Feature{EpicID: e05.ID, Key: "E05-F01", Title: "Login", Status: "active", FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/feature.md")}
+	checkout := &models.Feature{EpicID: e06.ID, Key: "E06-F01", Title: "Checkout", Status: "active"}
+	require.NoError(t, featureRepo.Create(ctx, login))
+	require.NoError(t, featureRepo.Create(ctx, checkout))
+
+	form := &models.Task{FeatureID: login.ID, Key: "T-E05-F01-001", Title: "Form", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/tasks/T-E05-F01-001.md")}
+	require.NoError(t, taskRepo.Create(ctx, form))
+	session := &models.Task{FeatureID: login.ID, Key: "T-E05-F01-002", Title: "Session", Status: models.TaskStatusTodo, Priority: 5,
+		DependsOn: strPtr(`["T-E05-F01-001"]`)}
+	require.NoError(t, taskRepo.Create(ctx, session))
+	cart := &models.Task{FeatureID: checkout.ID, Key: "T-E06-F01-001", Title: "Cart", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, cart))
+	_, err = database.ExecContext(ctx, `UPDATE tasks SET depends_on = '["T-E05-F01-002"]' WHERE id = ?`, cart.ID)
+	require.NoError(t, err)
+
+	renumberRepo := NewRenumberRepository(database)
+	plan, err := renumberRepo.PlanEpicRekey(ctx, e05, "E09", "docs/plan/E05-auth")
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 4, "the epic, its feature, and the feature's tasks")
+	assert.Equal(t, []PathMove{{From: "docs/plan/E05-auth", To: "docs/plan/E09-auth"}}, plan.PathMoves())
+	assert.Equal(t, "docs/plan/E09-auth/E09-F01-login/tasks/T-E09-F01-001.md", *plan.Changes[2].NewFilePath)
+	require.NoError(t, renumberRepo.Apply(ctx, plan))
+
+	epic, err := epicRepo.GetByKey(ctx, "E09")
+	require.NoError(t, err)
+	assert.Equal(t, e05.ID, epic.ID)
+	assert.Equal(t, "docs/plan/E09-auth/epic.md", *epic.FilePath)
+
+	feature, err := featureRepo.GetByKey(ctx, "E09-F01")
+	require.NoError(t, err)
+	assert.Equal(t, login.ID, feature.ID)
+	assert.Equal(t, "docs/plan/E09-auth/E09-F01-login/feature.md", *feature.FilePath)
+
+	task, err := taskRepo.GetByKey(ctx, "T-E09-F01-002")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["T-E09-F01-001"]`, *task.DependsOn)
+	task, err = taskRepo.GetByID(ctx, cart.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["T-E09-F01-002"]`, *task.DependsOn, "dependencies in other epics follow")
+
+	_, err = renumberRepo.PlanEpicRekey(ctx, epic, "E09", "")
+	assert.Error(t, err)
+	_, err = renumberRepo.PlanEpicRekey(ctx, epic, "auth", "")
+	assert.Error(t, err, "feature keys must stay E##-F##")
+}
+
+func TestRenumberRepository_PlanFeatureResequence(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+	taskRepo := NewTaskRepository(database)
+	aliasRepo := NewTaskAliasRepository(database)
+
+	strPtr := func(s string) *string { return &s }
+
+	epic := &models.Epic{Key: "E05", Title: "Auth", Status: "active", Priority: "high"}
+	require.NoError(t, epicRepo.Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E05-F01", Title: "Login", Status: "active"}
+	require.NoError(t, featureRepo.Create(ctx, feature))
+
+	// 002 was deleted, leaving 001, 003, 005
+	tasks := map[string]*models.Task{}
+	for _, key := range []string{"T-E05-F01-001", "T-E05-F01-003", "T-E05-F01-005"} {
+		task := &models.Task{FeatureID: feature.ID, Key: key, Title: key, Status: models.TaskStatusTodo, Priority: 5,
+			FilePath: strPtr("docs/plan/E05-auth/E05-F01-login/tasks/" + key + ".md")}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		tasks[key] = task
+	}
+	_, err = database.ExecContext(ctx, `UPDATE tasks SET depends_on = '["T-E05-F01-001","T-E05-F01-005"]' WHERE id = ?`, tasks["T-E05-F01-003"].ID)
+	require.NoError(t, err)
+
+	renumberRepo := NewRenumberRepository(database)
+	plan, err := renumberRepo.PlanFeatureResequence(ctx, feature)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2, "the task already in place is left out")
+	assert.Equal(t, "T-E05-F01-002", plan.Changes[0].NewKey)
+	assert.Equal(t, "T-E05-F01-003", plan.Changes[1].NewKey)
+	assert.Equal(t, "docs/plan/E05-auth/E05-F01-login/tasks/T-E05-F01-003.md", *plan.Changes[1].NewFilePath)
+	require.NoError(t, renumberRepo.Apply(ctx, plan))
+
+	task, err := taskRepo.GetByID(ctx, tasks["T-E05-F01-005"].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "T-E05-F01-003", task.Key)
+	task, err = taskRepo.GetByID(ctx, tasks["T-E05-F01-003"].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "T-E05-F01-002", task.Key)
+	assert.JSONEq(t, `["T-E05-F01-001","T-E05-F01-003"]`, *task.DependsOn)
+
+	// Old keys are never handed out again
+	maxSeq, err := taskRepo.GetMaxSequenceForFeature(ctx, "E05-F01")
+	require.NoError(t, err)
+	assert.Equal(t, 5, maxSeq)
+	next, err := taskRepo.NextKeyNumber(ctx, feature.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 6, next)
+
+	// An old key resolves while no task holds it
+	key, err := aliasRepo.GetTaskKeyByOldKey(ctx, "T-E05-F01-005")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E05-F01-003", key)
+	key, err = aliasRepo.GetTaskKeyByOldKey(ctx, "T-E05-F01-003")
+	require.NoError(t, err)
+	assert.Empty(t, key, "a key another task holds is not an alias")
+
+	newTask := &models.Task{FeatureID: feature.ID, Key: "T-E05-F01-006", Title: "New", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, newTask))
+	key, err = aliasRepo.GetTaskKeyByOldKey(ctx, "T-E05-F01-005")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E05-F01-003", key, "old keys still resolve after new tasks are created")
+}
@@ -0,0 +1,503 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// MaxJournalEntries is the number of journal entries kept; older entries are pruned when recording
+const MaxJournalEntries = 50
+
+// journalQueryChunk bounds the number of values bound in a single IN clause
+const journalQueryChunk = 500
+
+// journalTimeFormat matches the format the SQLite driver uses when writing time.Time values
+const journalTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// OperationJournalRepository records destructive operations and reverts them
+type OperationJournalRepository struct {
+	db *DB
+}
+
+// NewOperationJournalRepository creates a new OperationJournalRepository
+func NewOperationJournalRepository(db *DB) *OperationJournalRepository {
+	return &OperationJournalRepository{db: db}
+}
+
+// foreignKeyRef describes a foreign key from a child table to a parent table
+type foreignKeyRef struct {
+	childTable   string
+	childColumn  string
+	parentColumn string
+	onDelete     string
+}
+
+// CaptureCascade snapshots the rows of table with the given ids, every row that
+// ON DELETE CASCADE would remove along with them, and the columns that ON DELETE
+// SET NULL would clear. Child tables are discovered from the schema's foreign keys,
+// so new tables are covered without changes here.
+func (r *OperationJournalRepository) CaptureCascade(ctx context.Context, table string, ids []int64) (*models.JournalSnapshot, error) {
+	fks, err := r.foreignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type pending struct {
+		table  string
+		column string
+		values []interface{}
+	}
+
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	queue := []pending{{table: table, column: "rowid", values: values}}
+	seen := make(map[string]map[int64]bool)
+	snapshot := &models.JournalSnapshot{}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		rows, err := r.selectRows(ctx, p.table, p.column, p.values)
+		if err != nil {
+			return nil, err
+		}
+		rows = unseenRows(seen, p.table, rows)
+		if len(rows) == 0 {
+			continue
+		}
+		snapshot.Deleted = append(snapshot.Deleted, models.JournalRows{Table: p.table, Rows: rows})
+
+		for _, fk := range fks[p.table] {
+			refValues := distinctColumnValues(rows, fk.parentColumn)
+			if len(refValues) == 0 {
+				continue
+			}
+			switch strings.ToUpper(fk.onDelete) {
+			case "CASCADE":
+				queue = append(queue, pending{table: fk.childTable, column: fk.childColumn, values: refValues})
+			case "SET NULL":
+				children, err := r.selectRows(ctx, fk.childTable, fk.childColumn, refValues)
+				if err != nil {
+					return nil, err
+				}
+				if len(children) > 0 {
+					snapshot.Updated = append(snapshot.Updated, models.JournalRows{
+						Table:   fk.childTable,
+						Columns: []string{fk.childColumn},
+						Rows:    children,
+					})
+				}
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// CaptureColumns snapshots the given columns of the rows of table where column
+// matches one of values, for operations that update rows rather than delete them
+func (r *OperationJournalRepository) CaptureColumns(ctx context.Context, table, column string, values []interface{}, columns []string) (*models.JournalRows, error) {
+	rows, err := r.selectRows(ctx, table, column, values)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(columns)+1)
+	keep[models.JournalRowIDKey] = true
+	for _, c := range columns {
+		keep[c] = true
+	}
+	for _, row := range rows {
+		for c := range row {
+			if !keep[c] {
+				delete(row, c)
+			}
+		}
+	}
+
+	return &models.JournalRows{Table: table, Columns: columns, Rows: rows}, nil
+}
+
+// Record stores a journal entry and prunes entries beyond MaxJournalEntries
+func (r *OperationJournalRepository) Record(ctx context.Context, entry *models.OperationJournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	data, err := json.Marshal(entry.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal snapshot: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO operation_journal (operation, entity_key, summary, snapshot)
+		VALUES (?, ?, ?, ?)
+	`, entry.Operation, entry.EntityKey, entry.Summary, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to record operation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	entry.ID = id
+
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM operation_journal
+		WHERE id NOT IN (SELECT id FROM operation_journal ORDER BY id DESC LIMIT ?)
+	`, MaxJournalEntries); err != nil {
+		return fmt.Errorf("failed to prune operation journal: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the most recent journal entries, newest first
+func (r *OperationJournalRepository) List(ctx context.Context, limit int) ([]*models.OperationJournalEntry, error) {
+	if limit <= 0 {
+		limit = MaxJournalEntries
+	}
+	return r.queryEntries(ctx, `
+		SELECT id, operation, entity_key, summary, snapshot, created_at, undone_at
+		FROM operation_journal
+		ORDER BY id DESC
+		LIMIT ?
+	`, limit)
+}
+
+// GetLatestUndoable returns the most recent entry that has not been undone,
+// or nil if there is nothing to undo
+func (r *OperationJournalRepository) GetLatestUndoable(ctx context.Context) (*models.OperationJournalEntry, error) {
+	entries, err := r.queryEntries(ctx, `
+		SELECT id, operation, entity_key, summary, snapshot, created_at, undone_at
+		FROM operation_journal
+		WHERE undone_at IS NULL
+		ORDER BY id DESC
+		LIMIT 1
+	`)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// Undo reverts a journal entry in a single transaction: deleted rows are
+// re-inserted with their original ids and updated columns are written back.
+// The entry is then marked as undone.
+func (r *OperationJournalRepository) Undo(ctx context.Context, entry *models.OperationJournalEntry) error {
+	if entry.UndoneAt != nil {
+		return fmt.Errorf("operation %d (%s %s) was already undone", entry.ID, entry.Operation, entry.EntityKey)
+	}
+	if entry.Snapshot == nil {
+		return fmt.Errorf("operation %d has no snapshot to restore", entry.ID)
+	}
+
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Rows are restored parent-first, but deferring the checks also covers
+	// references between rows of the same table
+	if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+		return fmt.Errorf("failed to defer foreign key checks: %w", err)
+	}
+
+	for _, set := range entry.Snapshot.Deleted {
+		for _, row := range set.Rows {
+			if err := insertJournalRow(ctx, tx.Tx, set.Table, row); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Columns are written back latest change first, so a file the operation
+	// moved is released by its new owner before the old one claims it again
+	for i := len(entry.Snapshot.Updated) - 1; i >= 0; i-- {
+		set := entry.Snapshot.Updated[i]
+		for _, row := range set.Rows {
+			if err := updateJournalRow(ctx, tx.Tx, set.Table, set.Columns, row); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE operation_journal SET undone_at = ? WHERE id = ?", time.Now().UTC(), entry.ID); err != nil {
+		return fmt.Errorf("failed to mark operation as undone: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit undo: %w", err)
+	}
+
+	now := time.Now().UTC()
+	entry.UndoneAt = &now
+	return nil
+}
+
+// foreignKeys returns the foreign keys of the schema, keyed by parent table
+func (r *OperationJournalRepository) foreignKeys(ctx context.Context) (map[string][]foreignKeyRef, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT m.name, p."table", p."from", COALESCE(p."to", 'rowid'), p.on_delete
+		FROM sqlite_master m
+		JOIN pragma_foreign_key_list(m.name) p
+		WHERE m.type = 'table'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	fks := make(map[string][]foreignKeyRef)
+	for rows.Next() {
+		var parent string
+		var fk foreignKeyRef
+		if err := rows.Scan(&fk.childTable, &parent, &fk.childColumn, &fk.parentColumn, &fk.onDelete); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		fks[parent] = append(fks[parent], fk)
+	}
+	return fks, rows.Err()
+}
+
+// selectRows returns the rows of table where column matches one of values,
+// with their rowid and JSON-friendly values
+func (r *OperationJournalRepository) selectRows(ctx context.Context, table, column string, values []interface{}) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+
+	for start := 0; start < len(values); start += journalQueryChunk {
+		end := start + journalQueryChunk
+		if end > len(values) {
+			end = len(values)
+		}
+		chunk := values[start:end]
+
+		query := fmt.Sprintf(`SELECT rowid AS %s, * FROM %s WHERE %s IN (%s)`,
+			models.JournalRowIDKey, quoteIdent(table), quoteIdent(column), placeholders(len(chunk)))
+		rows, err := r.db.QueryContext(ctx, query, chunk...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", table, err)
+		}
+
+		scanned, err := scanJournalRows(rows)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", table, err)
+		}
+		result = append(result, scanned...)
+	}
+
+	return result, nil
+}
+
+// queryEntries runs a journal query and scans the entries
+func (r *OperationJournalRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]*models.OperationJournalEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query operation journal: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.OperationJournalEntry
+	for rows.Next() {
+		entry := &models.OperationJournalEntry{}
+		var snapshot string
+		var undoneAt sql.NullTime
+		if err := rows.Scan(&entry.ID, &entry.Operation, &entry.EntityKey, &entry.Summary, &snapshot, &entry.CreatedAt, &undoneAt); err != nil {
+			return nil, fmt.Errorf("failed to scan journal entry: %w", err)
+		}
+		if undoneAt.Valid {
+			entry.UndoneAt = &undoneAt.Time
+		}
+
+		// Decode numbers as json.Number so integer ids survive the round trip
+		decoder := json.NewDecoder(bytes.NewReader([]byte(snapshot)))
+		decoder.UseNumber()
+		entry.Snapshot = &models.JournalSnapshot{}
+		if err := decoder.Decode(entry.Snapshot); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of journal entry %d: %w", entry.ID, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// scanJournalRows scans rows into column maps with JSON-friendly values
+func scanJournalRows(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			switch v := values[i].(type) {
+			case time.Time:
+				row[column] = v.Format(journalTimeFormat)
+			case []byte:
+				row[column] = string(v)
+			default:
+				row[column] = v
+			}
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// unseenRows drops rows of table already captured and marks the rest as seen
+func unseenRows(seen map[string]map[int64]bool, table string, rows []map[string]interface{}) []map[string]interface{} {
+	if seen[table] == nil {
+		seen[table] = make(map[int64]bool)
+	}
+	var result []map[string]interface{}
+	for _, row := range rows {
+		rowID, ok := journalRowID(row)
+		if !ok || seen[table][rowID] {
+			continue
+		}
+		seen[table][rowID] = true
+		result = append(result, row)
+	}
+	return result
+}
+
+// distinctColumnValues returns the distinct non-null values of column across rows
+func distinctColumnValues(rows []map[string]interface{}, column string) []interface{} {
+	if column == "rowid" {
+		column = models.JournalRowIDKey
+	}
+	seen := make(map[interface{}]bool)
+	var values []interface{}
+	for _, row := range rows {
+		v, ok := row[column]
+		if !ok || v == nil || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	return values
+}
+
+// insertJournalRow re-inserts a deleted row with its original column values
+func insertJournalRow(ctx context.Context, tx *sql.Tx, table string, row map[string]interface{}) error {
+	columns := journalRowColumns(row, nil)
+	args := make([]interface{}, len(columns))
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quoteIdent(c)
+		args[i] = journalValue(row[c])
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(table), strings.Join(quoted, ", "), placeholders(len(columns)))
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to restore row in %s: %w", table, err)
+	}
+	return nil
+}
+
+// updateJournalRow writes the snapshot values of columns back to a row
+func updateJournalRow(ctx context.Context, tx *sql.Tx, table string, columns []string, row map[string]interface{}) error {
+	rowID, ok := journalRowID(row)
+	if !ok {
+		return fmt.Errorf("snapshot row in %s has no rowid", table)
+	}
+
+	columns = journalRowColumns(row, columns)
+	if len(columns) == 0 {
+		return nil
+	}
+	sets := make([]string, len(columns))
+	args := make([]interface{}, 0, len(columns)+1)
+	for i, c := range columns {
+		sets[i] = quoteIdent(c) + " = ?"
+		args = append(args, journalValue(row[c]))
+	}
+	args = append(args, rowID)
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE rowid = ?", quoteIdent(table), strings.Join(sets, ", "))
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to restore row in %s: %w", table, err)
+	}
+	return nil
+}
+
+// journalRowColumns returns the sorted columns of row to restore, limited to
+// only when given, excluding the rowid key
+func journalRowColumns(row map[string]interface{}, only []string) []string {
+	var columns []string
+	if len(only) > 0 {
+		for _, c := range only {
+			if _, ok := row[c]; ok {
+				columns = append(columns, c)
+			}
+		}
+	} else {
+		for c := range row {
+			if c != models.JournalRowIDKey {
+				columns = append(columns, c)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// journalRowID returns the rowid stored in a snapshot row
+func journalRowID(row map[string]interface{}) (int64, bool) {
+	switch v := row[models.JournalRowIDKey].(type) {
+	case int64:
+		return v, true
+	case json.Number:
+		id, err := v.Int64()
+		return id, err == nil
+	}
+	return 0, false
+}
+
+// journalValue converts a decoded snapshot value into a bind argument
+func journalValue(v interface{}) interface{} {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+		return n.String()
+	}
+	return v
+}
+
+// quoteIdent quotes a SQLite identifier
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
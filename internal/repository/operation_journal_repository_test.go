@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationJournalRepository_UndoCascadeDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	feature, err := NewFeatureRepository(db).GetByID(ctx, task.FeatureID)
+	require.NoError(t, err)
+
+	require.NoError(t, taskRepo.UpdateStatus(ctx, taskID, models.TaskStatusInProgress, nil, nil))
+	require.NoError(t, NewTaskChecklistRepository(db).Create(ctx, &models.TaskChecklistItem{TaskID: taskID, Content: "write tests"}))
+	require.NoError(t, NewLabelRepository(db).AddTaskLabels(ctx, taskID, []string{"security"}))
+
+	repo := NewOperationJournalRepository(db)
+	snapshot, err := repo.CaptureCascade(ctx, "epics", []int64{feature.EpicID})
+	require.NoError(t, err)
+
+	tables := map[string]int{}
+	for _, set := range snapshot.Deleted {
+		tables[set.Table] += len(set.Rows)
+	}
+	assert.Equal(t, 1, tables["epics"])
+	assert.Equal(t, 1, tables["features"])
+	assert.Equal(t, 1, tables["tasks"])
+	assert.Equal(t, 1, tables["task_history"])
+	assert.Equal(t, 1, tables["task_checklist_items"])
+	assert.Equal(t, 1, tables["task_labels"])
+
+	require.NoError(t, NewEpicRepository(db).Delete(ctx, feature.EpicID))
+	entry := &models.OperationJournalEntry{Operation: "epic delete", EntityKey: "E01", Summary: "Deleted epic E01", Snapshot: snapshot}
+	require.NoError(t, repo.Record(ctx, entry))
+
+	latest, err := repo.GetLatestUndoable(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, entry.ID, latest.ID)
+
+	require.NoError(t, repo.Undo(ctx, latest))
+
+	restored, err := taskRepo.GetByKey(ctx, "T-E01-F01-001")
+	require.NoError(t, err)
+	assert.Equal(t, taskID, restored.ID)
+	assert.Equal(t, models.TaskStatusInProgress, restored.Status)
+	assert.Equal(t, task.CreatedAt.Unix(), restored.CreatedAt.Unix())
+
+	labels, err := NewLabelRepository(db).GetTaskLabels(ctx, []int64{taskID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"security"}, labels[taskID])
+
+	items, err := NewTaskChecklistRepository(db).ListByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	history, err := NewTaskHistoryRepository(db).ListByTask(ctx, taskID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	// Nothing left to undo, and an entry cannot be undone twice
+	latest, err = repo.GetLatestUndoable(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+	assert.Error(t, repo.Undo(ctx, entry))
+}
+
+func TestOperationJournalRepository_UndoUpdatedColumns(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	repo := NewOperationJournalRepository(db)
+
+	rows, err := repo.CaptureColumns(ctx, "tasks", "id", []interface{}{taskID}, []string{"status", "completed_at"})
+	require.NoError(t, err)
+	require.Len(t, rows.Rows, 1)
+	assert.NotContains(t, rows.Rows[0], "title")
+
+	require.NoError(t, taskRepo.UpdateStatusForced(ctx, taskID, models.TaskStatusCompleted, nil, nil, nil, nil, true))
+	require.NoError(t, repo.Record(ctx, &models.OperationJournalEntry{
+		Operation: "feature complete",
+		EntityKey: "E01-F01",
+		Snapshot:  &models.JournalSnapshot{Updated: []models.JournalRows{*rows}},
+	}))
+
+	entry, err := repo.GetLatestUndoable(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	require.NoError(t, repo.Undo(ctx, entry))
+
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusTodo, task.Status)
+	assert.False(t, task.CompletedAt.Valid)
+}
+
+func TestOperationJournalRepository_UndoFileReassignment(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	epicRepo := NewEpicRepository(db)
+	path := "docs/plan/E01/epic.md"
+	require.NoError(t, epicRepo.UpdateFilePath(ctx, "E01", &path))
+	epic, err := epicRepo.GetByKey(ctx, "E01")
+	require.NoError(t, err)
+
+	// Move the epic's file to the task, as a forced create does
+	repo := NewOperationJournalRepository(db)
+	rows, err := repo.CaptureColumns(ctx, "epics", "id", []interface{}{epic.ID}, []string{"file_path"})
+	require.NoError(t, err)
+	require.NoError(t, epicRepo.UpdateFilePath(ctx, "E01", nil))
+	require.NoError(t, taskRepo.UpdateFilePath(ctx, task.Key, &path))
+	cleared := models.JournalRows{Table: "tasks", Columns: []string{"file_path"}, Rows: []map[string]interface{}{{models.JournalRowIDKey: taskID, "file_path": nil}}}
+	require.NoError(t, repo.Record(ctx, &models.OperationJournalEntry{
+		Operation: "task create",
+		EntityKey: task.Key,
+		Snapshot:  &models.JournalSnapshot{Updated: []models.JournalRows{*rows, cleared}},
+	}))
+
+	entry, err := repo.GetLatestUndoable(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Undo(ctx, entry), "the task releases the file before the epic claims it again")
+
+	claim, err := NewFileClaimRepository(db).GetByPath(ctx, path)
+	require.NoError(t, err)
+	require.NotNil(t, claim)
+	assert.Equal(t, "E01", claim.EntityKey)
+}
+
+func TestOperationJournalRepository_RecordValidatesAndPrunes(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewOperationJournalRepository(db)
+	assert.Error(t, repo.Record(ctx, &models.OperationJournalEntry{Operation: "task delete", EntityKey: "T-E01-F01-001"}))
+
+	rows := models.JournalRows{Table: "tasks", Rows: []map[string]interface{}{{models.JournalRowIDKey: int64(1)}}}
+	for i := 0; i < MaxJournalEntries+5; i++ {
+		require.NoError(t, repo.Record(ctx, &models.OperationJournalEntry{
+			Operation: "task delete",
+			EntityKey: "T-E01-F01-001",
+			Snapshot:  &models.JournalSnapshot{Updated: []models.JournalRows{rows}},
+		}))
+	}
+
+	entries, err := repo.List(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, entries, MaxJournalEntries)
+	assert.Greater(t, entries[0].ID, entries[1].ID)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ProgressSnapshotRepository handles persistence of epic and feature progress history
+type ProgressSnapshotRepository struct {
+	db *DB
+}
+
+// NewProgressSnapshotRepository creates a new ProgressSnapshotRepository
+func NewProgressSnapshotRepository(db *DB) *ProgressSnapshotRepository {
+	return &ProgressSnapshotRepository{db: db}
+}
+
+// Create records a new progress snapshot.
+// If RecordedAt is zero, the current time is used.
+func (r *ProgressSnapshotRepository) Create(ctx context.Context, snapshot *models.ProgressSnapshot) error {
+	if err := snapshot.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if snapshot.RecordedAt.IsZero() {
+		snapshot.RecordedAt = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO progress_snapshots (
+			entity_type, entity_id, entity_key, progress_pct,
+			total_tasks, completed_tasks, recorded_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		snapshot.EntityType,
+		snapshot.EntityID,
+		snapshot.EntityKey,
+		snapshot.ProgressPct,
+		snapshot.TotalTasks,
+		snapshot.CompletedTasks,
+		snapshot.RecordedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create progress snapshot: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	snapshot.ID = id
+	return nil
+}
+
+// ListByEntity retrieves snapshots for an epic or feature in chronological order.
+// If since is non-nil, only snapshots recorded at or after that time are
+// returned, whatever its time zone.
+func (r *ProgressSnapshotRepository) ListByEntity(ctx context.Context, entityType string, entityID int64, since *time.Time) ([]*models.ProgressSnapshot, error) {
+	query := `
+		SELECT id, entity_type, entity_id, entity_key, progress_pct,
+		       total_tasks, completed_tasks, recorded_at
+		FROM progress_snapshots
+		WHERE entity_type = ? AND entity_id = ?
+	`
+	args := []interface{}{entityType, entityID}
+
+	if since != nil {
+		query += " AND recorded_at >= ?"
+		args = append(args, since.UTC())
+	}
+
+	query += " ORDER BY recorded_at ASC, id ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query progress snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []*models.ProgressSnapshot{}
+	for rows.Next() {
+		snapshot := &models.ProgressSnapshot{}
+		err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.EntityType,
+			&snapshot.EntityID,
+			&snapshot.EntityKey,
+			&snapshot.ProgressPct,
+			&snapshot.TotalTasks,
+			&snapshot.CompletedTasks,
+			&snapshot.RecordedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan progress snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating progress snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetTaskCounts returns the total and completed task counts for an epic or feature.
+// Completed and archived tasks count as completed.
+func (r *ProgressSnapshotRepository) GetTaskCounts(ctx context.Context, entityType string, entityID int64) (total int, completed int, err error) {
+	var query string
+	switch entityType {
+	case models.SnapshotEntityEpic:
+		query = `
+			SELECT COUNT(*),
+			       COALESCE(SUM(CASE WHEN t.status IN ('completed', 'archived') THEN 1 ELSE 0 END), 0)
+			FROM tasks t
+			JOIN features f ON t.feature_id = f.id
+			WHERE f.epic_id = ? AND t.deleted_at IS NULL
+		`
+	case models.SnapshotEntityFeature:
+		query = `
+			SELECT COUNT(*),
+			       COALESCE(SUM(CASE WHEN status IN ('completed', 'archived') THEN 1 ELSE 0 END), 0)
+			FROM tasks
+			WHERE feature_id = ? AND deleted_at IS NULL
+		`
+	default:
+		return 0, 0, models.ErrInvalidSnapshotEntity
+	}
+
+	if err := r.db.QueryRowContext(ctx, query, entityID).Scan(&total, &completed); err != nil {
+		return 0, 0, fmt.Errorf("failed to count %s tasks: %w", entityType, err)
+	}
+
+	return total, completed, nil
+}
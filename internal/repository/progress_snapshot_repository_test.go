@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProgressSnapshotTest(t *testing.T) (*DB, *models.Epic) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+
+	epic := &models.Epic{
+		Key:      "E01",
+		Title:    "Test Epic",
+		Status:   "active",
+		Priority: "high",
+	}
+	require.NoError(t, NewEpicRepository(database).Create(context.Background(), epic))
+
+	return database, epic
+}
+
+func TestProgressSnapshotRepository_CreateAndList(t *testing.T) {
+	database, epic := setupProgressSnapshotTest(t)
+	defer database.Close()
+
+	repo := NewProgressSnapshotRepository(database)
+	ctx := context.Background()
+
+	day1 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	for _, s := range []*models.ProgressSnapshot{
+		{EntityType: models.SnapshotEntityEpic, EntityID: epic.ID, EntityKey: epic.Key, ProgressPct: 40, TotalTasks: 10, CompletedTasks: 4, RecordedAt: day2},
+		{EntityType: models.SnapshotEntityEpic, EntityID: epic.ID, EntityKey: epic.Key, ProgressPct: 20, TotalTasks: 10, CompletedTasks: 2, RecordedAt: day1},
+		{EntityType: models.SnapshotEntityFeature, EntityID: epic.ID, EntityKey: "E01-F01", ProgressPct: 50, RecordedAt: day1},
+	} {
+		require.NoError(t, repo.Create(ctx, s))
+		assert.NotZero(t, s.ID)
+	}
+
+	snapshots, err := repo.ListByEntity(ctx, models.SnapshotEntityEpic, epic.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 20.0, snapshots[0].ProgressPct)
+	assert.Equal(t, 40.0, snapshots[1].ProgressPct)
+	assert.Equal(t, 4, snapshots[1].CompletedTasks)
+
+	since := day1.Add(time.Hour)
+	snapshots, err = repo.ListByEntity(ctx, models.SnapshotEntityEpic, epic.ID, &since)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, 40.0, snapshots[0].ProgressPct)
+
+	// since compares by instant, not by its time zone's wall clock
+	west := since.In(time.FixedZone("UTC-10", -10*60*60))
+	snapshots, err = repo.ListByEntity(ctx, models.SnapshotEntityEpic, epic.ID, &west)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, 40.0, snapshots[0].ProgressPct)
+}
+
+func TestProgressSnapshotRepository_CreateValidation(t *testing.T) {
+	database, epic := setupProgressSnapshotTest(t)
+	defer database.Close()
+
+	repo := NewProgressSnapshotRepository(database)
+	ctx := context.Background()
+
+	err := repo.Create(ctx, &models.ProgressSnapshot{EntityType: "task", EntityID: epic.ID, EntityKey: "x"})
+	assert.ErrorIs(t, err, models.ErrInvalidSnapshotEntity)
+
+	err = repo.Create(ctx, &models.ProgressSnapshot{EntityType: models.SnapshotEntityEpic, EntityID: epic.ID, EntityKey: epic.Key, ProgressPct: 120})
+	assert.ErrorIs(t, err, models.ErrInvalidProgressPct)
+}
+
+func TestProgressSnapshotRepository_GetTaskCounts(t *testing.T) {
+	database, epic := setupProgressSnapshotTest(t)
+	defer database.Close()
+
+	repo := NewProgressSnapshotRepository(database)
+	ctx := context.Background()
+
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Feature", Status: models.FeatureStatusActive}
+	require.NoError(t, NewFeatureRepository(database).Create(ctx, feature))
+
+	_, err := database.ExecContext(ctx, `
+		INSERT INTO tasks (feature_id, key, title, status, priority) VALUES
+		(?, 'T-E01-F01-001', 'Done', 'completed', 5),
+		(?, 'T-E01-F01-002', 'Open', 'todo', 5)
+	`, feature.ID, feature.ID)
+	require.NoError(t, err)
+
+	total, completed, err := repo.GetTaskCounts(ctx, models.SnapshotEntityEpic, epic.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, completed)
+
+	total, completed, err = repo.GetTaskCounts(ctx, models.SnapshotEntityFeature, feature.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, completed)
+}
@@ -0,0 +1,521 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Entity types in a renumber plan
+const (
+	RenumberEntityEpic    = "epic"
+	RenumberEntityFeature = "feature"
+	RenumberEntityTask    = "task"
+)
+
+var (
+	renumberEpicPattern    = regexp.MustCompile(`^E(\d+)$`)
+	renumberFeaturePattern = regexp.MustCompile(`^E\d+-F(\d+)$`)
+	renumberTaskPattern    = regexp.MustCompile(`^T-E\d+-F\d+-(\d+)$`)
+)
+
+// KeyChange is one epic, feature, or task whose key changes in a renumber or move
+type KeyChange struct {
+	EntityType  string  `json:"entity_type"`
+	ID          int64   `json:"-"`
+	NewParentID int64   `json:"-"` // Epic or feature the entity moves to (0: unchanged)
+	OldKey      string  `json:"old_key"`
+	NewKey      string  `json:"new_key"`
+	OldFilePath *string `json:"old_file_path,omitempty"`
+	NewFilePath *string `json:"new_file_path,omitempty"`
+}
+
+// RenumberPlan maps old epic, feature, and task keys to new ones, either to
+// make numbering contiguous (epics become E01..En, each epic's features
+// F01..Fn, and each feature's tasks 001..n, keeping their current relative
+// order) or to move a task or feature to a new parent
+type RenumberPlan struct {
+	Changes        []*KeyChange `json:"changes"`
+	keyMap         map[string]string
+	pathMoves      map[string]string // Moved file or directory -> new location
+	resetSequences bool
+	keepOldKeys    bool // Record old task keys in task_key_aliases
+	retitle        *renumberRetitle
+}
+
+// renumberRetitle is a new title and slug for a renamed feature
+type renumberRetitle struct {
+	id    int64
+	title string
+	slug  string
+}
+
+// PathMove is a file or directory that moves to a different directory
+type PathMove struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MapKey returns the new key for an exact old key, or the key unchanged
+func (p *RenumberPlan) MapKey(key string) string {
+	if newKey, ok := p.keyMap[key]; ok {
+		return newKey
+	}
+	return key
+}
+
+// RewriteName rewrites a name that starts with a renumbered key, such as a
+// directory ("E07-payments" -> "E02-payments"), a file ("T-E07-F01-003.md"),
+// or a frontmatter slug key. The longest matching key wins, so "E07-F03-api"
+// follows its feature rather than its epic.
+func (p *RenumberPlan) RewriteName(name string) string {
+	best := ""
+	for oldKey := range p.keyMap {
+		if len(oldKey) <= len(best) || !strings.HasPrefix(name, oldKey) {
+			continue
+		}
+		if rest := name[len(oldKey):]; rest == "" || rest[0] == '-' || rest[0] == '.' {
+			best = oldKey
+		}
+	}
+	if best == "" {
+		return name
+	}
+	return p.keyMap[best] + name[len(best):]
+}
+
+// RewritePath moves a slash-separated path with its moved parent directory, if
+// any, and rewrites the remaining segments with RewriteName
+func (p *RenumberPlan) RewritePath(path string) string {
+	moved, rest := p.splitMovedPath(path)
+	if rest == "" {
+		return moved
+	}
+	segments := strings.Split(rest, "/")
+	for i, segment := range segments {
+		segments[i] = p.RewriteName(segment)
+	}
+	if moved == "" {
+		return strings.Join(segments, "/")
+	}
+	return moved + "/" + strings.Join(segments, "/")
+}
+
+// MovedPath applies only the plan's path moves to a path, leaving key names
+// below the moved directory as they are
+func (p *RenumberPlan) MovedPath(path string) string {
+	moved, rest := p.splitMovedPath(path)
+	if moved == "" {
+		return rest
+	}
+	if rest == "" {
+		return moved
+	}
+	return moved + "/" + rest
+}
+
+// PathMoves returns the files and directories the plan moves, shortest first
+func (p *RenumberPlan) PathMoves() []PathMove {
+	moves := make([]PathMove, 0, len(p.pathMoves))
+	for from, to := range p.pathMoves {
+		moves = append(moves, PathMove{From: from, To: to})
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if len(moves[i].From) != len(moves[j].From) {
+			return len(moves[i].From) < len(moves[j].From)
+		}
+		return moves[i].From < moves[j].From
+	})
+	return moves
+}
+
+// splitMovedPath splits a path into the new location of its longest moved
+// prefix and the rest of the path. moved is "" if no prefix moves.
+func (p *RenumberPlan) splitMovedPath(path string) (moved, rest string) {
+	best := ""
+	for from := range p.pathMoves {
+		if len(from) > len(best) && (path == from || strings.HasPrefix(path, from+"/")) {
+			best = from
+		}
+	}
+	if best == "" {
+		return "", path
+	}
+	return p.pathMoves[best], strings.TrimPrefix(path[len(best):], "/")
+}
+
+// RenumberRepository renumbers epic, feature, and task keys
+type RenumberRepository struct {
+	db *DB
+}
+
+// NewRenumberRepository creates a new RenumberRepository
+func NewRenumberRepository(db *DB) *RenumberRepository {
+	return &RenumberRepository{db: db}
+}
+
+// renumberRow is an entity read for planning
+type renumberRow struct {
+	id       int64
+	parentID int64
+	key      string
+	number   int
+	filePath *string
+}
+
+// Plan computes the key changes needed to make numbering contiguous. Trashed
+// and archived entities are included since they still hold their keys. Keys
+// that don't follow the standard formats are left alone.
+func (r *RenumberRepository) Plan(ctx context.Context) (*RenumberPlan, error) {
+	epics, err := r.loadRows(ctx, "SELECT id, 0, key, file_path FROM epics", renumberEpicPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load epics: %w", err)
+	}
+	features, err := r.loadRows(ctx, "SELECT id, epic_id, key, file_path FROM features", renumberFeaturePattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load features: %w", err)
+	}
+	tasks, err := r.loadRows(ctx, "SELECT id, feature_id, key, file_path FROM tasks", renumberTaskPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	plan := &RenumberPlan{keyMap: make(map[string]string), resetSequences: true}
+	var pending []*KeyChange
+
+	newEpicKeys := make(map[int64]string)
+	for i, epic := range sortRenumberRows(epics[0]) {
+		newKey := fmt.Sprintf("E%02d", i+1)
+		newEpicKeys[epic.id] = newKey
+		pending = appendKeyChange(pending, plan, RenumberEntityEpic, epic, newKey)
+	}
+
+	newFeatureKeys := make(map[int64]string)
+	for _, epicID := range sortedParentIDs(features) {
+		epicKey, ok := newEpicKeys[epicID]
+		if !ok {
+			continue
+		}
+		for i, feature := range sortRenumberRows(features[epicID]) {
+			newKey := fmt.Sprintf("%s-F%02d", epicKey, i+1)
+			newFeatureKeys[feature.id] = newKey
+			pending = appendKeyChange(pending, plan, RenumberEntityFeature, feature, newKey)
+		}
+	}
+
+	for _, featureID := range sortedParentIDs(tasks) {
+		featureKey, ok := newFeatureKeys[featureID]
+		if !ok {
+			continue
+		}
+		for i, task := range sortRenumberRows(tasks[featureID]) {
+			pending = appendKeyChange(pending, plan, RenumberEntityTask, task, fmt.Sprintf("T-%s-%03d", featureKey, i+1))
+		}
+	}
+
+	// File paths depend on the complete key map (a task file moves with its
+	// epic and feature directories), so they are rewritten last
+	for _, change := range pending {
+		if change.OldFilePath != nil {
+			newPath := plan.RewritePath(*change.OldFilePath)
+			change.NewFilePath = &newPath
+		}
+		if change.OldKey != change.NewKey || (change.NewFilePath != nil && *change.NewFilePath != *change.OldFilePath) {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	return plan, nil
+}
+
+// loadRows reads entities grouped by parent ID, skipping keys that don't match pattern
+func (r *RenumberRepository) loadRows(ctx context.Context, query string, pattern *regexp.Regexp) (map[int64][]*renumberRow, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int64][]*renumberRow)
+	for rows.Next() {
+		row := &renumberRow{}
+		var filePath sql.NullString
+		if err := rows.Scan(&row.id, &row.parentID, &row.key, &filePath); err != nil {
+			return nil, err
+		}
+		match := pattern.FindStringSubmatch(row.key)
+		if match == nil {
+			continue
+		}
+		row.number, _ = strconv.Atoi(match[1])
+		if filePath.Valid && filePath.String != "" {
+			row.filePath = &filePath.String
+		}
+		result[row.parentID] = append(result[row.parentID], row)
+	}
+	return result, rows.Err()
+}
+
+// sortRenumberRows orders rows by their current key number
+func sortRenumberRows(rows []*renumberRow) []*renumberRow {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].number != rows[j].number {
+			return rows[i].number < rows[j].number
+		}
+		return rows[i].id < rows[j].id
+	})
+	return rows
+}
+
+// sortedParentIDs returns the parent IDs of grouped rows in ascending order
+func sortedParentIDs(groups map[int64][]*renumberRow) []int64 {
+	ids := make([]int64, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// appendKeyChange records an entity's new key in the plan's key map and queues it
+func appendKeyChange(pending []*KeyChange, plan *RenumberPlan, entityType string, row *renumberRow, newKey string) []*KeyChange {
+	if row.key != newKey {
+		plan.keyMap[row.key] = newKey
+	}
+	return append(pending, &KeyChange{
+		EntityType:  entityType,
+		ID:          row.id,
+		OldKey:      row.key,
+		NewKey:      newKey,
+		OldFilePath: row.filePath,
+	})
+}
+
+// renumberKeyColumns are the other columns that hold an exact epic, feature,
+// or task key, so history and references follow the renumbered entities
+var renumberKeyColumns = []struct{ table, column string }{
+	{"audit_log", "entity_key"},
+	{"operation_journal", "entity_key"},
+	{"progress_snapshots", "entity_key"},
+	{"task_recurrences", "last_task_key"},
+	{"ideas", "converted_to_key"},
+	{"task_search_fts", "task_key"},
+}
+
+// Apply changes keys, parents, file paths, and a renamed feature's title in a
+// single transaction, along with task dependencies, audit and journal entries,
+// progress snapshots, idea conversion links, linked document paths, and the
+// search index. After a renumber the epic, feature, and task key sequences are
+// reset so new keys continue from the renumbered maximum; after a resequence
+// the old task keys are recorded so they still resolve.
+func (r *RenumberRepository) Apply(ctx context.Context, plan *RenumberPlan) error {
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Move every changed key out of the way first so no intermediate state
+	// collides with the UNIQUE key indexes
+	for _, change := range plan.Changes {
+		if change.OldKey == change.NewKey {
+			continue
+		}
+		tempKey := fmt.Sprintf("~renumber-%s-%d", change.EntityType, change.ID)
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET key = ? WHERE id = ?", renumberTable(change.EntityType)), tempKey, change.ID); err != nil {
+			return fmt.Errorf("failed to renumber %s %s: %w", change.EntityType, change.OldKey, err)
+		}
+	}
+	for _, change := range plan.Changes {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET key = ?, file_path = ? WHERE id = ?", renumberTable(change.EntityType)),
+			change.NewKey, r.db.projectPathPtr(change.NewFilePath), change.ID); err != nil {
+			return fmt.Errorf("failed to renumber %s %s to %s: %w", change.EntityType, change.OldKey, change.NewKey, err)
+		}
+		if change.NewParentID != 0 {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?", renumberTable(change.EntityType), renumberParentColumn(change.EntityType)),
+				change.NewParentID, change.ID); err != nil {
+				return fmt.Errorf("failed to move %s %s: %w", change.EntityType, change.OldKey, err)
+			}
+		}
+	}
+
+	if plan.retitle != nil {
+		if _, err := tx.ExecContext(ctx, "UPDATE features SET title = ?, slug = ? WHERE id = ?",
+			plan.retitle.title, plan.retitle.slug, plan.retitle.id); err != nil {
+			return fmt.Errorf("failed to rename %s: %w", plan.Changes[0].OldKey, err)
+		}
+	}
+
+	if err := renumberDependsOn(ctx, tx.Tx, plan); err != nil {
+		return err
+	}
+	for _, target := range renumberKeyColumns {
+		if err := renumberColumn(ctx, tx.Tx, target.table, target.column, plan.MapKey); err != nil {
+			return err
+		}
+	}
+	if err := renumberColumn(ctx, tx.Tx, "documents", "file_path", plan.RewritePath); err != nil {
+		return err
+	}
+
+	if plan.keepOldKeys {
+		if err := recordOldTaskKeys(ctx, tx.Tx, plan); err != nil {
+			return err
+		}
+	}
+
+	if plan.resetSequences {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM key_sequences WHERE scope = 'epic' OR scope LIKE 'feature:%' OR scope LIKE 'task:%'"); err != nil {
+			return fmt.Errorf("failed to reset key sequences: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit renumber: %w", err)
+	}
+	return nil
+}
+
+// recordOldTaskKeys maps the old key of each renumbered task to the task.
+// Keys that a task holds after the renumber aren't recorded, and earlier
+// records of them are dropped, since the task holding a key always wins.
+func recordOldTaskKeys(ctx context.Context, tx *sql.Tx, plan *RenumberPlan) error {
+	newKeys := make(map[string]bool)
+	for _, change := range plan.Changes {
+		if change.EntityType == RenumberEntityTask && change.OldKey != change.NewKey {
+			newKeys[change.NewKey] = true
+		}
+	}
+	for key := range newKeys {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM task_key_aliases WHERE old_key = ?", key); err != nil {
+			return fmt.Errorf("failed to update old task keys: %w", err)
+		}
+	}
+	for _, change := range plan.Changes {
+		if change.EntityType != RenumberEntityTask || change.OldKey == change.NewKey || newKeys[change.OldKey] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO task_key_aliases (old_key, task_id) VALUES (?, ?)
+			ON CONFLICT(old_key) DO UPDATE SET task_id = excluded.task_id, created_at = CURRENT_TIMESTAMP
+		`, change.OldKey, change.ID); err != nil {
+			return fmt.Errorf("failed to record old key %s: %w", change.OldKey, err)
+		}
+	}
+	return nil
+}
+
+// renumberTable returns the table holding an entity type
+func renumberTable(entityType string) string {
+	switch entityType {
+	case RenumberEntityEpic:
+		return "epics"
+	case RenumberEntityFeature:
+		return "features"
+	default:
+		return "tasks"
+	}
+}
+
+// renumberParentColumn returns the column linking an entity type to its parent
+func renumberParentColumn(entityType string) string {
+	if entityType == RenumberEntityFeature {
+		return "epic_id"
+	}
+	return "feature_id"
+}
+
+// renumberDependsOn rewrites the task keys in every task's depends_on list
+func renumberDependsOn(ctx context.Context, tx *sql.Tx, plan *RenumberPlan) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, depends_on FROM tasks WHERE depends_on IS NOT NULL AND depends_on != ''")
+	if err != nil {
+		return fmt.Errorf("failed to read task dependencies: %w", err)
+	}
+
+	updates := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var dependsOn string
+		if err := rows.Scan(&id, &dependsOn); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan task dependencies: %w", err)
+		}
+		var deps []string
+		if err := json.Unmarshal([]byte(dependsOn), &deps); err != nil {
+			continue
+		}
+		changed := false
+		for i, dep := range deps {
+			if newKey := plan.MapKey(dep); newKey != dep {
+				deps[i] = newKey
+				changed = true
+			}
+		}
+		if changed {
+			data, _ := json.Marshal(deps)
+			updates[id] = string(data)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read task dependencies: %w", err)
+	}
+
+	for id, dependsOn := range updates {
+		if _, err := tx.ExecContext(ctx, "UPDATE tasks SET depends_on = ? WHERE id = ?", dependsOn, id); err != nil {
+			return fmt.Errorf("failed to update task dependencies: %w", err)
+		}
+	}
+	return nil
+}
+
+// renumberColumn rewrites a text column row by row, so chains like E03->E02
+// and E02->E01 can't cascade. Tables that don't exist (the optional search
+// index) are skipped.
+func renumberColumn(ctx context.Context, tx *sql.Tx, table, column string, rewrite func(string) string) error {
+	var exists int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name = ?", table).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check for %s: %w", table, err)
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT rowid, %s FROM %s WHERE %s IS NOT NULL", column, table, column))
+	if err != nil {
+		return fmt.Errorf("failed to read %s.%s: %w", table, column, err)
+	}
+
+	updates := make(map[int64]string)
+	for rows.Next() {
+		var rowID int64
+		var value string
+		if err := rows.Scan(&rowID, &value); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan %s.%s: %w", table, column, err)
+		}
+		if newValue := rewrite(value); newValue != value {
+			updates[rowID] = newValue
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read %s.%s: %w", table, column, err)
+	}
+
+	for rowID, value := range updates {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET %s = ? WHERE rowid = ?", table, column), value, rowID); err != nil {
+			return fmt.Errorf("failed to update %s.%s: %w", table, column, err)
+		}
+	}
+	return nil
+}
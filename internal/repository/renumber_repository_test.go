@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenumberRepository_PlanAndApply(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epicRepo := NewEpicRepository(database)
+	featureRepo := NewFeatureRepository(database)
+	taskRepo := NewTaskRepository(database)
+
+	strPtr := func(s string) *string { return &s }
+
+	// Sparse keys: E02 and E07, with E07's only feature at F03
+	e02 := &models.Epic{Key: "E02", Title: "Auth", Status: "active", Priority: "high", FilePath: strPtr("docs/plan/E02-auth/epic.md")}
+	e07 := &models.Epic{Key: "E07", Title: "Payments", Status: "active", Priority: "high", FilePath: strPtr("docs/plan/E07-payments/epic.md")}
+	require.NoError(t, epicRepo.Create(ctx, e02))
+	require.NoError(t, epicRepo.Create(ctx, e07))
+
+	f0201 := &models.Feature{EpicID: e02.ID, Key: "E02-F01", Title: "Login", Status: "active"}
+	f0703 := &models.Feature{EpicID: e07.ID, Key: "E07-F03", Title: "Checkout", Status: "active", FilePath: strPtr("docs/plan/E07-payments/E07-F03-checkout/feature.md")}
+	require.NoError(t, featureRepo.Create(ctx, f0201))
+	require.NoError(t, featureRepo.Create(ctx, f0703))
+
+	t1 := &models.Task{FeatureID: f0703.ID, Key: "T-E07-F03-002", Title: "Cart", Status: models.TaskStatusTodo, Priority: 5,
+		FilePath: strPtr("docs/plan/E07-payments/E07-F03-checkout/tasks/T-E07-F03-002.md")}
+	require.NoError(t, taskRepo.Create(ctx, t1))
+	t2 := &models.Task{FeatureID: f0703.ID, Key: "T-E07-F03-005", Title: "Pay", Status: models.TaskStatusTodo, Priority: 5,
+		DependsOn: strPtr(`["T-E07-F03-002"]`)}
+	require.NoError(t, taskRepo.Create(ctx, t2))
+
+	_, err = database.ExecContext(ctx, `INSERT INTO audit_log (entity_type, entity_key, action) VALUES ('task', 'T-E07-F03-002', 'create')`)
+	require.NoError(t, err)
+
+	renumberRepo := NewRenumberRepository(database)
+	plan, err := renumberRepo.Plan(ctx)
+	require.NoError(t, err)
+
+	newKeys := make(map[string]string)
+	for _, change := range plan.Changes {
+		newKeys[change.OldKey] = change.NewKey
+	}
+	assert.Equal(t, map[string]string{
+		"E02":           "E01",
+		"E02-F01":       "E01-F01",
+		"E07":           "E02",
+		"E07-F03":       "E02-F01",
+		"T-E07-F03-002": "T-E02-F01-001",
+		"T-E07-F03-005": "T-E02-F01-002",
+	}, newKeys)
+	assert.Equal(t, "docs/plan/E02-payments/E02-F01-checkout/tasks/T-E02-F01-001.md", plan.RewritePath(*t1.FilePath))
+
+	require.NoError(t, renumberRepo.Apply(ctx, plan))
+
+	epic, err := epicRepo.GetByKey(ctx, "E02")
+	require.NoError(t, err)
+	assert.Equal(t, e07.ID, epic.ID)
+	require.NotNil(t, epic.FilePath)
+	assert.Equal(t, "docs/plan/E02-payments/epic.md", *epic.FilePath)
+
+	task, err := taskRepo.GetByKey(ctx, "T-E02-F01-002")
+	require.NoError(t, err)
+	assert.Equal(t, t2.ID, task.ID)
+	require.NotNil(t, task.DependsOn)
+	assert.JSONEq(t, `["T-E02-F01-001"]`, *task.DependsOn)
+
+	var auditKey string
+	require.NoError(t, database.QueryRowContext(ctx, `SELECT entity_key FROM audit_log`).Scan(&auditKey))
+	assert.Equal(t, "T-E02-F01-001", auditKey)
+
+	// New keys continue from the renumbered maximum
+	nextKey, err := epicRepo.NextKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "E03", nextKey)
+
+	// Renumbering again is a no-op
+	plan, err = renumberRepo.Plan(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+}
+
+func TestRenumberPlan_RewriteName(t *testing.T) {
+	plan := &RenumberPlan{keyMap: map[string]string{"E07": "E02", "E07-F03": "E02-F01", "T-E07-F03-002": "T-E02-F01-001"}}
+
+	assert.Equal(t, "E02-payments", plan.RewriteName("E07-payments"))
+	assert.Equal(t, "E02-F01-checkout", plan.RewriteName("E07-F03-checkout"))
+	assert.Equal(t, "E02-F09-other", plan.RewriteName("E07-F09-other"))
+	assert.Equal(t, "T-E02-F01-001.md", plan.RewriteName("T-E07-F03-002.md"))
+	assert.Equal(t, "E070-other", plan.RewriteName("E070-other"))
+	assert.Equal(t, "tasks", plan.RewriteName("tasks"))
+}
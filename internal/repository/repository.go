@@ -3,24 +3,210 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
 )
 
 // DB wraps the database connection for repositories
 type DB struct {
 	*sql.DB
+	cache  *ReadCache   // Nil unless EnableCache was called
+	logger *slog.Logger // Nil means slog.Default()
+	retry  *RetryPolicy // Nil means DefaultRetryPolicy
+
+	projectRoot string // Empty means the working directory
+}
+
+// maxLoggedQueryLen truncates statements in debug logs
+const maxLoggedQueryLen = 200
+
+// execer runs statements on a DB or inside a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 }
 
 // NewDB creates a new DB instance
 func NewDB(db *sql.DB) *DB {
-	return &DB{db}
+	return &DB{DB: db}
+}
+
+// Tx is a transaction on a DB. Committing it invalidates the DB's read cache.
+type Tx struct {
+	*sql.Tx
+	db *DB
+}
+
+// Commit commits the transaction and invalidates the read cache. The cache is
+// invalidated after the commit, not when the transaction begins, so a read made
+// while the transaction is open can't cache rows it is about to replace.
+func (tx *Tx) Commit() error {
+	defer tx.db.cache.Invalidate()
+	return tx.Tx.Commit()
 }
 
-// BeginTxContext starts a new transaction with context
-func (db *DB) BeginTxContext(ctx context.Context) (*sql.Tx, error) {
-	return db.DB.BeginTx(ctx, nil)
+// BeginTxContext starts a new transaction with context, retrying transient
+// errors. Transactions are assumed to write, so committing one invalidates the
+// read cache.
+func (db *DB) BeginTxContext(ctx context.Context) (*Tx, error) {
+	var tx *sql.Tx
+	err := db.withRetry(ctx, "begin", "BEGIN", func() error {
+		var err error
+		tx, err = db.DB.BeginTx(ctx, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, db: db}, nil
 }
 
 // BeginTx starts a new transaction (deprecated: use BeginTxContext)
-func (db *DB) BeginTx() (*sql.Tx, error) {
-	return db.Begin()
+func (db *DB) BeginTx() (*Tx, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, db: db}, nil
+}
+
+// SetLogger sets the logger used by this DB and the repositories and services
+// built on it
+func (db *DB) SetLogger(logger *slog.Logger) {
+	db.logger = logger
+}
+
+// Logger returns the DB's logger, or the default logger if none was set
+func (db *DB) Logger() *slog.Logger {
+	if db == nil || db.logger == nil {
+		return slog.Default()
+	}
+	return db.logger
+}
+
+// SetProjectRoot sets the root of the project the database belongs to, where
+// repositories look for .sharkconfig.json
+func (db *DB) SetProjectRoot(root string) {
+	db.projectRoot = root
+}
+
+// ProjectRoot returns the project root set with SetProjectRoot, else the
+// working directory
+func (db *DB) ProjectRoot() string {
+	if db.projectRoot != "" {
+		return db.projectRoot
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return wd
+}
+
+// projectWorkflow returns the workflow config in the project root's
+// .sharkconfig.json (or the stored workflow), or nil if there is none or it
+// can't be loaded, for progress weights
+func (db *DB) projectWorkflow() *config.WorkflowConfig {
+	root := db.ProjectRoot()
+	if root == "" {
+		return nil
+	}
+	workflow, err := config.LoadWorkflowConfig(filepath.Join(root, ".sharkconfig.json"))
+	if err != nil {
+		return nil
+	}
+	return workflow
+}
+
+// ProjectPath returns path in the form file_path columns and file claims
+// store it: relative to the project root, with forward slashes. Paths outside
+// the project root stay absolute.
+func (db *DB) ProjectPath(path string) string {
+	if path == "" {
+		return path
+	}
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	path = filepath.Clean(path)
+	root := db.ProjectRoot()
+	if root == "" {
+		return path
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// projectPathPtr is ProjectPath for nullable file_path values
+func (db *DB) projectPathPtr(path *string) *string {
+	if path == nil {
+		return nil
+	}
+	stored := db.ProjectPath(*path)
+	return &stored
+}
+
+// QueryContext runs a query, retrying transient errors and logging it at
+// debug level
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := db.withRetry(ctx, "query", query, func() error {
+		var err error
+		start := time.Now()
+		rows, err = db.DB.QueryContext(ctx, query, args...)
+		db.logQuery(ctx, "query", query, start, err)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRowContext runs a single-row query, retrying transient errors and
+// logging it at debug level
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	_ = db.withRetry(ctx, "query", query, func() error {
+		start := time.Now()
+		row = db.DB.QueryRowContext(ctx, query, args...)
+		db.logQuery(ctx, "query", query, start, row.Err())
+		return row.Err()
+	})
+	return row
+}
+
+// logQuery logs a statement and how long it took at debug level
+func (db *DB) logQuery(ctx context.Context, op, query string, start time.Time, err error) {
+	logger := db.Logger()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("component", "db"),
+		slog.String("op", op),
+		slog.String("query", compactQuery(query)),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "sql", attrs...)
+}
+
+// compactQuery puts a statement on one line and truncates it for logging
+func compactQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) > maxLoggedQueryLen {
+		query = query[:maxLoggedQueryLen] + "..."
+	}
+	return query
 }
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the DB retries statements that fail with a
+// transient error, such as a local database that stayed locked past its busy
+// timeout or a dropped connection to a remote libsql server
+type RetryPolicy struct {
+	MaxRetries     int           // Retries after the first attempt; 0 disables retrying
+	InitialBackoff time.Duration // Wait before the first retry, doubled for each one after
+	MaxBackoff     time.Duration // Longest wait between retries
+}
+
+// DefaultRetryPolicy is used by a DB until SetRetryPolicy is called
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     time.Second,
+}
+
+// transientErrorMessages are lowercased fragments of driver errors that are
+// worth retrying. The SQLite errors come from mattn/go-sqlite3; the rest are
+// what libsql-client-go returns when a remote server or the network fails.
+var transientErrorMessages = []string{
+	"database is locked",
+	"database table is locked",
+	"sqlite_busy",
+	"sqlite_locked",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"unexpected eof",
+	"stream is closed",
+	"error code 429",
+	"error code 502",
+	"error code 503",
+	"error code 504",
+}
+
+// IsTransientError reports whether err is worth retrying: the database was
+// busy or locked, or a remote database couldn't be reached. Errors from a
+// canceled or expired context never are.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, sql.ErrNoRows) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range transientErrorMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRetryPolicy sets how this DB retries transient errors
+func (db *DB) SetRetryPolicy(policy RetryPolicy) {
+	db.retry = &policy
+}
+
+// RetryPolicy returns the DB's retry policy, or DefaultRetryPolicy if none
+// was set
+func (db *DB) RetryPolicy() RetryPolicy {
+	if db == nil || db.retry == nil {
+		return DefaultRetryPolicy
+	}
+	return *db.retry
+}
+
+// backoff returns how long to wait before the given retry (1 for the first)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// withRetry calls attempt until it succeeds, fails with an error that isn't
+// transient, or the retry policy or ctx runs out. Each retry is logged at info
+// level, so --verbose shows them.
+func (db *DB) withRetry(ctx context.Context, op, query string, attempt func() error) error {
+	policy := db.RetryPolicy()
+	err := attempt()
+	for retry := 1; retry <= policy.MaxRetries && IsTransientError(err); retry++ {
+		wait := policy.backoff(retry)
+		db.Logger().LogAttrs(ctx, slog.LevelInfo, "retrying after transient database error",
+			slog.String("component", "db"),
+			slog.String("op", op),
+			slog.String("query", compactQuery(query)),
+			slog.Int("retry", retry),
+			slog.Int("max_retries", policy.MaxRetries),
+			slog.Duration("backoff", wait),
+			slog.Any("error", err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = attempt()
+	}
+	return err
+}
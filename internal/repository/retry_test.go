@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, IsTransientError(errors.New("database is locked")))
+	assert.True(t, IsTransientError(fmt.Errorf("failed to update task: %w", errors.New("database table is locked (SQLITE_LOCKED)"))))
+	assert.True(t, IsTransientError(fmt.Errorf("stream is closed: %w", driver.ErrBadConn)))
+	assert.True(t, IsTransientError(errors.New("failed to execute SQL: read tcp: connection reset by peer")))
+	assert.True(t, IsTransientError(errors.New("failed to execute SQL: SELECT 1\nerror code 503: service unavailable")))
+
+	assert.False(t, IsTransientError(nil))
+	assert.False(t, IsTransientError(sql.ErrNoRows))
+	assert.False(t, IsTransientError(context.DeadlineExceeded))
+	assert.False(t, IsTransientError(errors.New("UNIQUE constraint failed: tasks.key")))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, InitialBackoff: 50 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	assert.Equal(t, 50*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, 300*time.Millisecond, policy.backoff(4), "capped at MaxBackoff")
+
+	var database *DB
+	assert.Equal(t, DefaultRetryPolicy, database.RetryPolicy())
+}
+
+func TestDB_RetriesLockedDatabase(t *testing.T) {
+	// Two pools on one file, neither waiting on locks, so the second sees
+	// SQLITE_BUSY while the first holds a write transaction
+	path := filepath.Join(t.TempDir(), "retry.db")
+	holder, err := sql.Open("sqlite3", path+"?_busy_timeout=0")
+	require.NoError(t, err)
+	defer holder.Close()
+	_, err = holder.Exec("CREATE TABLE items (name TEXT)")
+	require.NoError(t, err)
+
+	sqlDB, err := sql.Open("sqlite3", path+"?_busy_timeout=0")
+	require.NoError(t, err)
+	database := NewDB(sqlDB)
+	defer database.Close()
+
+	var logs bytes.Buffer
+	database.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	database.SetRetryPolicy(RetryPolicy{MaxRetries: 0})
+
+	ctx := context.Background()
+	lock, err := holder.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = lock.Exec("INSERT INTO items (name) VALUES ('held')")
+	require.NoError(t, err)
+
+	_, err = database.ExecContext(ctx, "INSERT INTO items (name) VALUES ('first')")
+	require.Error(t, err, "fails straight away without retries")
+	assert.True(t, IsTransientError(err))
+
+	database.SetRetryPolicy(RetryPolicy{MaxRetries: 10, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 100 * time.Millisecond})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		lock.Commit()
+	}()
+	_, err = database.ExecContext(ctx, "INSERT INTO items (name) VALUES ('second')")
+	require.NoError(t, err, "succeeds once the lock is released")
+	assert.Contains(t, logs.String(), "retrying after transient database error")
+
+	var count int
+	require.NoError(t, database.QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 2, count)
+}
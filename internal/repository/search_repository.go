@@ -43,6 +43,7 @@ func (r *SearchRepository) RebuildIndex(ctx context.Context) error {
 			COALESCE((SELECT GROUP_CONCAT(criterion, ' ') FROM task_criteria WHERE task_id = t.id), ''),
 			COALESCE(t.agent_type || ' ' || t.status, '')
 		FROM tasks t
+		WHERE t.deleted_at IS NULL
 	`
 
 	if _, err := r.db.ExecContext(ctx, query); err != nil {
@@ -77,7 +78,7 @@ func (r *SearchRepository) IndexTask(ctx context.Context, taskID int64) error {
 			COALESCE((SELECT GROUP_CONCAT(criterion, ' ') FROM task_criteria WHERE task_id = t.id), ''),
 			COALESCE(t.agent_type || ' ' || t.status, '')
 		FROM tasks t
-		WHERE t.id = ?
+		WHERE t.id = ? AND t.deleted_at IS NULL
 	`
 
 	if _, err := r.db.ExecContext(ctx, query, taskID); err != nil {
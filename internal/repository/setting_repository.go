@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// SettingRepository handles the project settings stored in the database.
+// Only known settings (see models.SettingDefinitions) can be set, and values
+// are validated against their definition first.
+type SettingRepository struct {
+	db *DB
+}
+
+// NewSettingRepository creates a new SettingRepository
+func NewSettingRepository(db *DB) *SettingRepository {
+	return &SettingRepository{db: db}
+}
+
+// Get returns a setting's stored value, or nil if it isn't set
+func (r *SettingRepository) Get(ctx context.Context, key string) (*models.Setting, error) {
+	def, err := models.LookupSetting(key)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := &models.Setting{}
+	err = r.db.QueryRowContext(ctx, `SELECT key, value, updated_at FROM settings WHERE key = ?`, def.Key).
+		Scan(&setting.Key, &setting.Value, &setting.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setting %s: %w", def.Key, err)
+	}
+	return setting, nil
+}
+
+// GetInt returns an integer setting and whether it is set
+func (r *SettingRepository) GetInt(ctx context.Context, key string) (int, bool, error) {
+	setting, err := r.Get(ctx, key)
+	if err != nil || setting == nil {
+		return 0, false, err
+	}
+	n, err := strconv.Atoi(setting.Value)
+	if err != nil {
+		return 0, false, fmt.Errorf("setting %s is not a whole number: %q", setting.Key, setting.Value)
+	}
+	return n, true, nil
+}
+
+// GetFloat returns a numeric setting and whether it is set
+func (r *SettingRepository) GetFloat(ctx context.Context, key string) (float64, bool, error) {
+	setting, err := r.Get(ctx, key)
+	if err != nil || setting == nil {
+		return 0, false, err
+	}
+	f, err := strconv.ParseFloat(setting.Value, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("setting %s is not a number: %q", setting.Key, setting.Value)
+	}
+	return f, true, nil
+}
+
+// GetString returns a setting's value and whether it is set
+func (r *SettingRepository) GetString(ctx context.Context, key string) (string, bool, error) {
+	setting, err := r.Get(ctx, key)
+	if err != nil || setting == nil {
+		return "", false, err
+	}
+	return setting.Value, true, nil
+}
+
+// FloatsWithPrefix returns the numeric settings whose key starts with prefix,
+// by key
+func (r *SettingRepository) FloatsWithPrefix(ctx context.Context, prefix string) (map[string]float64, error) {
+	settings, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]float64)
+	for _, setting := range settings {
+		if !strings.HasPrefix(setting.Key, prefix) {
+			continue
+		}
+		f, err := strconv.ParseFloat(setting.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("setting %s is not a number: %q", setting.Key, setting.Value)
+		}
+		values[setting.Key] = f
+	}
+	return values, nil
+}
+
+// Set validates and stores a setting, replacing any value it had, and returns
+// the stored setting
+func (r *SettingRepository) Set(ctx context.Context, key, value string) (*models.Setting, error) {
+	def, err := models.LookupSetting(key)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := def.Normalize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO settings (key, value, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+	`, def.Key, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set %s: %w", def.Key, err)
+	}
+	return r.Get(ctx, def.Key)
+}
+
+// SetInt stores an integer setting
+func (r *SettingRepository) SetInt(ctx context.Context, key string, value int) (*models.Setting, error) {
+	return r.Set(ctx, key, strconv.Itoa(value))
+}
+
+// SetFloat stores a numeric setting
+func (r *SettingRepository) SetFloat(ctx context.Context, key string, value float64) (*models.Setting, error) {
+	return r.Set(ctx, key, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Unset removes a setting so its default applies again. Returns false if it
+// wasn't set.
+func (r *SettingRepository) Unset(ctx context.Context, key string) (bool, error) {
+	def, err := models.LookupSetting(key)
+	if err != nil {
+		return false, err
+	}
+	result, err := r.db.ExecContext(ctx, `DELETE FROM settings WHERE key = ?`, def.Key)
+	if err != nil {
+		return false, fmt.Errorf("failed to unset %s: %w", def.Key, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// List returns every stored setting, ordered by key
+func (r *SettingRepository) List(ctx context.Context) ([]*models.Setting, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT key, value, updated_at FROM settings ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := []*models.Setting{}
+	for rows.Next() {
+		setting := &models.Setting{}
+		if err := rows.Scan(&setting.Key, &setting.Value, &setting.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan setting: %w", err)
+		}
+		settings = append(settings, setting)
+	}
+	return settings, rows.Err()
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+	repo := NewSettingRepository(db)
+
+	_, ok, err := repo.GetInt(ctx, models.SettingTaskDefaultPriority)
+	require.NoError(t, err)
+	assert.False(t, ok, "unset settings report not set")
+
+	setting, err := repo.SetInt(ctx, models.SettingTaskDefaultPriority, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "3", setting.Value)
+	priority, ok, err := repo.GetInt(ctx, "Task.Default_Priority ")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, priority, "keys are case-insensitive")
+
+	_, err = repo.Set(ctx, models.SettingTaskDefaultPriority, "11")
+	assert.ErrorIs(t, err, models.ErrInvalidSettingValue)
+	_, err = repo.Set(ctx, "task.colour", "blue")
+	assert.ErrorIs(t, err, models.ErrUnknownSetting)
+
+	_, err = repo.Set(ctx, models.SettingEpicDefaultPriority, "HIGH")
+	require.NoError(t, err)
+	value, _, err := repo.GetString(ctx, models.SettingEpicDefaultPriority)
+	require.NoError(t, err)
+	assert.Equal(t, "high", value)
+
+	_, err = repo.SetFloat(ctx, models.SettingHealthProgressWarning, 60)
+	require.NoError(t, err)
+	_, err = repo.Set(ctx, models.SettingHealthBlockedCritical, "5.50")
+	require.NoError(t, err)
+	health, err := repo.FloatsWithPrefix(ctx, "health.")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{models.SettingHealthProgressWarning: 60, models.SettingHealthBlockedCritical: 5.5}, health)
+
+	settings, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, settings, 4)
+
+	removed, err := repo.Unset(ctx, models.SettingTaskDefaultPriority)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = repo.Unset(ctx, models.SettingTaskDefaultPriority)
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
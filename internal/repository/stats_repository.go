@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StatsRepository computes throughput statistics from tasks and their history
+type StatsRepository struct {
+	db *DB
+}
+
+// NewStatsRepository creates a new StatsRepository
+func NewStatsRepository(db *DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// StatsFilter narrows throughput statistics
+type StatsFilter struct {
+	Since   time.Time
+	EpicKey *string
+	// ReviewStatuses are the statuses a task waits in for review. A move from
+	// one of them to one of the DoneStatuses is an approval.
+	ReviewStatuses []string
+	DoneStatuses   []string
+}
+
+// CompletionCount is the number of tasks completed in one day or week
+type CompletionCount struct {
+	Period    string `json:"period"` // YYYY-MM-DD; weeks are keyed by their Monday
+	Completed int    `json:"completed"`
+}
+
+// ReviewStats summarizes review outcomes in the period
+type ReviewStats struct {
+	Approvals          int      `json:"approvals"`
+	Rejections         int      `json:"rejections"`
+	RejectionRate      float64  `json:"rejection_rate"`                  // Rejections as a share of approvals and rejections, 0-1
+	AvgHoursToApproval *float64 `json:"avg_hours_to_approval,omitempty"` // Nil when nothing was approved
+}
+
+// AgentTypeStats is the activity of tasks for one agent type in the period
+type AgentTypeStats struct {
+	AgentType     string `json:"agent_type"` // Empty for tasks without an agent type
+	Completed     int    `json:"completed"`
+	StatusChanges int    `json:"status_changes"`
+}
+
+// EpicVelocity is an epic's completion rate in the period
+type EpicVelocity struct {
+	EpicKey   string  `json:"epic_key"`
+	EpicTitle string  `json:"epic_title"`
+	Completed int     `json:"completed"`
+	PerWeek   float64 `json:"per_week"`
+	Remaining int     `json:"remaining"` // Open tasks (not completed or archived)
+}
+
+// ThroughputStats is the result of StatsRepository.Throughput
+type ThroughputStats struct {
+	Since      time.Time          `json:"since"`
+	Until      time.Time          `json:"until"`
+	Completed  int                `json:"completed"`
+	PerDay     []*CompletionCount `json:"per_day"`
+	PerWeek    []*CompletionCount `json:"per_week"`
+	Review     ReviewStats        `json:"review"`
+	AgentTypes []*AgentTypeStats  `json:"agent_types"` // Busiest first
+	Epics      []*EpicVelocity    `json:"epics"`       // Fastest first
+}
+
+// Throughput computes completion, review, agent type, and epic statistics for
+// tasks between filter.Since and now. Trashed tasks are left out.
+func (r *StatsRepository) Throughput(ctx context.Context, filter StatsFilter, now time.Time) (*ThroughputStats, error) {
+	stats := &ThroughputStats{Since: filter.Since, Until: now}
+	since := filter.Since.UTC().Format("2006-01-02 15:04:05")
+
+	var err error
+	if stats.PerDay, err = r.completionCounts(ctx, filter, since, "date(t.completed_at, 'localtime')"); err != nil {
+		return nil, err
+	}
+	if stats.PerWeek, err = r.completionCounts(ctx, filter, since, "date(t.completed_at, 'localtime', 'weekday 0', '-6 days')"); err != nil {
+		return nil, err
+	}
+	for _, day := range stats.PerDay {
+		stats.Completed += day.Completed
+	}
+
+	if err := r.reviewStats(ctx, filter, since, &stats.Review); err != nil {
+		return nil, err
+	}
+	if stats.AgentTypes, err = r.agentTypeStats(ctx, filter, since); err != nil {
+		return nil, err
+	}
+
+	weeks := now.Sub(filter.Since).Hours() / (24 * 7)
+	if stats.Epics, err = r.epicVelocity(ctx, filter, since, weeks); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// statsScope is the FROM and WHERE clause shared by the statistics queries.
+// from must join tasks as t; the scope limits them to the filter's epic and
+// leaves out trashed tasks.
+func statsScope(filter StatsFilter, from string) (string, []interface{}) {
+	scope := `
+		FROM ` + from + `
+		INNER JOIN features f ON t.feature_id = f.id
+		INNER JOIN epics e ON f.epic_id = e.id
+		WHERE t.deleted_at IS NULL`
+	var args []interface{}
+	if filter.EpicKey != nil {
+		scope += " AND e.key = ?"
+		args = append(args, *filter.EpicKey)
+	}
+	return scope, args
+}
+
+// completionCounts counts tasks completed since the start of the period,
+// grouped by the period expression
+func (r *StatsRepository) completionCounts(ctx context.Context, filter StatsFilter, since, period string) ([]*CompletionCount, error) {
+	scope, args := statsScope(filter, "tasks t")
+	query := "SELECT " + period + " AS period, COUNT(*)" + scope + `
+		AND t.completed_at IS NOT NULL AND julianday(t.completed_at) >= julianday(?)
+		GROUP BY period ORDER BY period`
+	args = append(args, since)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count completed tasks: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []*CompletionCount{}
+	for rows.Next() {
+		count := &CompletionCount{}
+		if err := rows.Scan(&count.Period, &count.Completed); err != nil {
+			return nil, fmt.Errorf("failed to scan completion count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating completion counts: %w", err)
+	}
+	return counts, nil
+}
+
+// reviewStats counts approvals and rejections in the period and averages the
+// time from a task's last move into review to its approval
+func (r *StatsRepository) reviewStats(ctx context.Context, filter StatsFilter, since string, review *ReviewStats) error {
+	if len(filter.ReviewStatuses) > 0 && len(filter.DoneStatuses) > 0 {
+		reviewIn := placeholders(len(filter.ReviewStatuses))
+		scope, args := statsScope(filter, "task_history th INNER JOIN tasks t ON th.task_id = t.id")
+		query := `
+			SELECT COUNT(*), AVG((julianday(th.timestamp) - julianday((
+				SELECT MAX(rh.timestamp) FROM task_history rh
+				WHERE rh.task_id = th.task_id AND rh.new_status IN (` + reviewIn + `) AND rh.timestamp <= th.timestamp
+			))) * 24)` + scope + `
+			AND th.old_status IN (` + reviewIn + `) AND th.new_status IN (` + placeholders(len(filter.DoneStatuses)) + `)
+			AND th.timestamp >= ?`
+		args = append(stringArgs(filter.ReviewStatuses), args...)
+		args = append(args, stringArgs(filter.ReviewStatuses)...)
+		args = append(args, stringArgs(filter.DoneStatuses)...)
+		args = append(args, since)
+
+		var avgHours *float64
+		if err := r.db.QueryRowContext(ctx, query, args...).Scan(&review.Approvals, &avgHours); err != nil {
+			return fmt.Errorf("failed to count review approvals: %w", err)
+		}
+		review.AvgHoursToApproval = avgHours
+	}
+
+	scope, args := statsScope(filter, "task_history th INNER JOIN tasks t ON th.task_id = t.id")
+	query := "SELECT COUNT(*)" + scope + `
+		AND th.rejection_reason IS NOT NULL AND th.timestamp >= ?`
+	args = append(args, since)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&review.Rejections); err != nil {
+		return fmt.Errorf("failed to count review rejections: %w", err)
+	}
+
+	if total := review.Approvals + review.Rejections; total > 0 {
+		review.RejectionRate = float64(review.Rejections) / float64(total)
+	}
+	return nil
+}
+
+// agentTypeStats counts completed tasks and status changes per agent type,
+// busiest first
+func (r *StatsRepository) agentTypeStats(ctx context.Context, filter StatsFilter, since string) ([]*AgentTypeStats, error) {
+	scope, args := statsScope(filter, "tasks t LEFT JOIN task_history th ON th.task_id = t.id AND th.timestamp >= ?")
+	query := `
+		SELECT COALESCE(t.agent_type, '') AS agent_type,
+		       COUNT(DISTINCT CASE WHEN t.completed_at IS NOT NULL AND julianday(t.completed_at) >= julianday(?) THEN t.id END) AS completed,
+		       COUNT(th.id) AS status_changes` + scope + `
+		GROUP BY agent_type
+		HAVING completed > 0 OR status_changes > 0
+		ORDER BY completed DESC, status_changes DESC, agent_type`
+	args = append([]interface{}{since, since}, args...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate agent type statistics: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*AgentTypeStats{}
+	for rows.Next() {
+		row := &AgentTypeStats{}
+		if err := rows.Scan(&row.AgentType, &row.Completed, &row.StatusChanges); err != nil {
+			return nil, fmt.Errorf("failed to scan agent type statistics: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agent type statistics: %w", err)
+	}
+	return result, nil
+}
+
+// epicVelocity counts each epic's completed and open tasks, fastest first.
+// Epics with neither are left out.
+func (r *StatsRepository) epicVelocity(ctx context.Context, filter StatsFilter, since string, weeks float64) ([]*EpicVelocity, error) {
+	scope, args := statsScope(filter, "tasks t")
+	query := `
+		SELECT e.key, e.title,
+		       COALESCE(SUM(CASE WHEN t.completed_at IS NOT NULL AND julianday(t.completed_at) >= julianday(?) THEN 1 ELSE 0 END), 0) AS completed,
+		       COALESCE(SUM(CASE WHEN t.status NOT IN ('completed', 'archived') THEN 1 ELSE 0 END), 0) AS remaining` + scope + `
+		GROUP BY e.id
+		HAVING completed > 0 OR remaining > 0
+		ORDER BY completed DESC, e.key`
+	args = append([]interface{}{since}, args...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate epic velocity: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*EpicVelocity{}
+	for rows.Next() {
+		row := &EpicVelocity{}
+		if err := rows.Scan(&row.EpicKey, &row.EpicTitle, &row.Completed, &row.Remaining); err != nil {
+			return nil, fmt.Errorf("failed to scan epic velocity: %w", err)
+		}
+		if weeks > 0 {
+			row.PerWeek = float64(row.Completed) / weeks
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating epic velocity: %w", err)
+	}
+	return result, nil
+}
+
+// ProjectTotals are all-time counts across the project, for metrics exporters
+type ProjectTotals struct {
+	TasksByStatus map[string]int // Tasks outside the trash, by status
+	Completions   int            // Moves into a done status from any other status
+	Rejections    int            // Status changes recorded with a rejection reason
+}
+
+// Totals counts tasks by status and every completion and rejection in the
+// task history. Completions and rejections only grow, so they can be exported
+// as counters; history of trashed tasks is kept in them.
+func (r *StatsRepository) Totals(ctx context.Context, doneStatuses []string) (*ProjectTotals, error) {
+	totals := &ProjectTotals{TasksByStatus: make(map[string]int)}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT status, COUNT(*) FROM tasks WHERE deleted_at IS NULL GROUP BY status")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks by status: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan task count: %w", err)
+		}
+		totals.TasksByStatus[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task counts: %w", err)
+	}
+
+	if len(doneStatuses) > 0 {
+		done := placeholders(len(doneStatuses))
+		query := `SELECT COUNT(*) FROM task_history
+			WHERE new_status IN (` + done + `) AND (old_status IS NULL OR old_status NOT IN (` + done + `))`
+		args := append(stringArgs(doneStatuses), stringArgs(doneStatuses)...)
+		if err := r.db.QueryRowContext(ctx, query, args...).Scan(&totals.Completions); err != nil {
+			return nil, fmt.Errorf("failed to count completions: %w", err)
+		}
+	}
+
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM task_history WHERE rejection_reason IS NOT NULL").Scan(&totals.Rejections); err != nil {
+		return nil, fmt.Errorf("failed to count rejections: %w", err)
+	}
+	return totals, nil
+}
+
+// CycleTimeFilter selects the tasks CycleTimes measures
+type CycleTimeFilter struct {
+	Since         time.Time // Only tasks completed on or after this time
+	StartStatuses []string  // Moving into one of these starts the clock
+	DoneStatuses  []string  // Moving into one of these afterwards stops it
+}
+
+// CycleTimes returns, in hours, how long each task completed since
+// filter.Since took from its first move into a start status to its first
+// move into a done status after that. Tasks that never entered a start status
+// and trashed tasks are left out.
+func (r *StatsRepository) CycleTimes(ctx context.Context, filter CycleTimeFilter) ([]float64, error) {
+	if len(filter.StartStatuses) == 0 || len(filter.DoneStatuses) == 0 {
+		return []float64{}, nil
+	}
+
+	scope, args := statsScope(StatsFilter{}, "task_history th INNER JOIN tasks t ON th.task_id = t.id")
+	query := `
+		SELECT (julianday(MIN(d.timestamp)) - julianday(s.started)) * 24
+		FROM (
+			SELECT th.task_id, MIN(th.timestamp) AS started` + scope + `
+			AND th.new_status IN (` + placeholders(len(filter.StartStatuses)) + `)
+			GROUP BY th.task_id
+		) s
+		INNER JOIN task_history d ON d.task_id = s.task_id
+			AND d.new_status IN (` + placeholders(len(filter.DoneStatuses)) + `)
+			AND julianday(d.timestamp) >= julianday(s.started)
+		GROUP BY s.task_id
+		HAVING julianday(MIN(d.timestamp)) >= julianday(?)`
+	args = append(args, stringArgs(filter.StartStatuses)...)
+	args = append(args, stringArgs(filter.DoneStatuses)...)
+	args = append(args, filter.Since.UTC().Format("2006-01-02 15:04:05"))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate cycle times: %w", err)
+	}
+	defer rows.Close()
+
+	hours := []float64{}
+	for rows.Next() {
+		var h float64
+		if err := rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("failed to scan cycle time: %w", err)
+		}
+		hours = append(hours, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cycle times: %w", err)
+	}
+	return hours, nil
+}
+
+// stringArgs converts strings to query arguments
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRepository_Throughput(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	strPtr := func(s string) *string { return &s }
+
+	e01 := &models.Epic{Key: "E01", Title: "Auth", Status: "active", Priority: "high"}
+	e02 := &models.Epic{Key: "E02", Title: "Payments", Status: "active", Priority: "high"}
+	require.NoError(t, NewEpicRepository(database).Create(ctx, e01))
+	require.NoError(t, NewEpicRepository(database).Create(ctx, e02))
+	f01 := &models.Feature{EpicID: e01.ID, Key: "E01-F01", Title: "Login", Status: "active"}
+	f02 := &models.Feature{EpicID: e02.ID, Key: "E02-F01", Title: "Checkout", Status: "active"}
+	require.NoError(t, NewFeatureRepository(database).Create(ctx, f01))
+	require.NoError(t, NewFeatureRepository(database).Create(ctx, f02))
+
+	taskRepo := NewTaskRepository(database)
+	newTask := func(feature *models.Feature, key, agentType string) *models.Task {
+		task := &models.Task{FeatureID: feature.ID, Key: key, Title: key, Status: models.TaskStatusTodo, Priority: 5, AgentType: strPtr(agentType)}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		return task
+	}
+	t1 := newTask(f01, "T-E01-F01-001", "backend")
+	t2 := newTask(f01, "T-E01-F01-002", "backend")
+	t3 := newTask(f02, "T-E02-F01-001", "frontend")
+	newTask(f02, "T-E02-F01-002", "frontend")
+	old := newTask(f02, "T-E02-F01-003", "frontend")
+
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	complete := func(task *models.Task, at time.Time) {
+		_, err := database.ExecContext(ctx, "UPDATE tasks SET status = 'completed', completed_at = ? WHERE id = ?", at, task.ID)
+		require.NoError(t, err)
+	}
+	complete(t1, time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)) // Monday
+	complete(t2, time.Date(2026, 3, 17, 10, 0, 0, 0, time.UTC))
+	complete(t3, time.Date(2026, 3, 12, 10, 0, 0, 0, time.UTC)) // Previous week
+	complete(old, time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)) // Before the period
+
+	history := func(task *models.Task, from, to, at string, rejection *string) {
+		_, err := database.ExecContext(ctx, `
+			INSERT INTO task_history (task_id, old_status, new_status, rejection_reason, timestamp)
+			VALUES (?, ?, ?, ?, ?)`, task.ID, from, to, rejection, at)
+		require.NoError(t, err)
+	}
+	// t1 is rejected once, then approved 4 hours after its second review request
+	history(t1, "in_progress", "ready_for_review", "2026-03-15 08:00:00", nil)
+	history(t1, "ready_for_review", "in_progress", "2026-03-15 09:00:00", strPtr("Missing tests"))
+	history(t1, "in_progress", "ready_for_review", "2026-03-16 06:00:00", nil)
+	history(t1, "ready_for_review", "completed", "2026-03-16 10:00:00", nil)
+	// t2 is approved 2 hours after review
+	history(t2, "in_progress", "ready_for_review", "2026-03-17 08:00:00", nil)
+	history(t2, "ready_for_review", "completed", "2026-03-17 10:00:00", nil)
+
+	statsRepo := NewStatsRepository(database)
+	filter := StatsFilter{
+		Since:          time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC),
+		ReviewStatuses: []string{"ready_for_review"},
+		DoneStatuses:   []string{"completed"},
+	}
+	stats, err := statsRepo.Throughput(ctx, filter, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, stats.Completed)
+	assert.Len(t, stats.PerDay, 3)
+	require.Len(t, stats.PerWeek, 2)
+	assert.Equal(t, CompletionCount{Period: "2026-03-09", Completed: 1}, *stats.PerWeek[0])
+	assert.Equal(t, CompletionCount{Period: "2026-03-16", Completed: 2}, *stats.PerWeek[1])
+
+	assert.Equal(t, 2, stats.Review.Approvals)
+	assert.Equal(t, 1, stats.Review.Rejections)
+	assert.InDelta(t, 1.0/3, stats.Review.RejectionRate, 0.001)
+	require.NotNil(t, stats.Review.AvgHoursToApproval)
+	assert.InDelta(t, 3.0, *stats.Review.AvgHoursToApproval, 0.01)
+
+	require.Len(t, stats.AgentTypes, 2)
+	assert.Equal(t, AgentTypeStats{AgentType: "backend", Completed: 2, StatusChanges: 6}, *stats.AgentTypes[0])
+	assert.Equal(t, AgentTypeStats{AgentType: "frontend", Completed: 1, StatusChanges: 0}, *stats.AgentTypes[1])
+
+	require.Len(t, stats.Epics, 2)
+	assert.Equal(t, "E01", stats.Epics[0].EpicKey)
+	assert.Equal(t, 2, stats.Epics[0].Completed)
+	assert.Equal(t, 0, stats.Epics[0].Remaining)
+	assert.InDelta(t, 1.0, stats.Epics[0].PerWeek, 0.001, "two tasks in two weeks")
+	assert.Equal(t, "E02", stats.Epics[1].EpicKey)
+	assert.Equal(t, 1, stats.Epics[1].Remaining)
+
+	// The epic filter scopes every statistic
+	filter.EpicKey = strPtr("E02")
+	stats, err = statsRepo.Throughput(ctx, filter, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Completed)
+	assert.Equal(t, 0, stats.Review.Approvals)
+	assert.Nil(t, stats.Review.AvgHoursToApproval)
+	require.Len(t, stats.Epics, 1)
+	assert.Equal(t, "E02", stats.Epics[0].EpicKey)
+}
+
+func TestStatsRepository_Totals(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epic := &models.Epic{Key: "E01", Title: "Auth", Status: "active", Priority: "high"}
+	require.NoError(t, NewEpicRepository(database).Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Login", Status: "active"}
+	require.NoError(t, NewFeatureRepository(database).Create(ctx, feature))
+
+	taskRepo := NewTaskRepository(database)
+	var tasks []*models.Task
+	for i, status := range []models.TaskStatus{models.TaskStatusTodo, models.TaskStatusTodo, models.TaskStatusCompleted, models.TaskStatusArchived} {
+		task := &models.Task{FeatureID: feature.ID, Key: fmt.Sprintf("T-E01-F01-%03d", i+1), Title: "Task", Status: status, Priority: 5}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		tasks = append(tasks, task)
+	}
+	_, err = database.ExecContext(ctx, "UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", tasks[1].ID)
+	require.NoError(t, err)
+
+	history := func(task *models.Task, from, to string, rejection *string) {
+		_, err := database.ExecContext(ctx, `INSERT INTO task_history (task_id, old_status, new_status, rejection_reason) VALUES (?, ?, ?, ?)`,
+			task.ID, from, to, rejection)
+		require.NoError(t, err)
+	}
+	reason := "Missing tests"
+	history(tasks[2], "ready_for_review", "in_progress", &reason)
+	history(tasks[2], "ready_for_review", "completed", nil)
+	history(tasks[3], "completed", "archived", nil) // Still done, not another completion
+	history(tasks[1], "ready_for_review", "completed", nil)
+
+	totals, err := NewStatsRepository(database).Totals(ctx, []string{"completed", "archived"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"todo": 1, "completed": 1, "archived": 1}, totals.TasksByStatus, "trashed tasks aren't counted")
+	assert.Equal(t, 2, totals.Completions, "completions of trashed tasks are kept")
+	assert.Equal(t, 1, totals.Rejections)
+}
+
+func TestStatsRepository_CycleTimes(t *testing.T) {
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	epic := &models.Epic{Key: "E01", Title: "Auth", Status: "active", Priority: "high"}
+	require.NoError(t, NewEpicRepository(database).Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Login", Status: "active"}
+	require.NoError(t, NewFeatureRepository(database).Create(ctx, feature))
+
+	taskRepo := NewTaskRepository(database)
+	var tasks []*models.Task
+	for i := 0; i < 4; i++ {
+		task := &models.Task{FeatureID: feature.ID, Key: fmt.Sprintf("T-E01-F01-%03d", i+1), Title: "Task", Status: models.TaskStatusCompleted, Priority: 5}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		tasks = append(tasks, task)
+	}
+
+	history := func(task *models.Task, from, to, at string) {
+		_, err := database.ExecContext(ctx, `INSERT INTO task_history (task_id, old_status, new_status, timestamp) VALUES (?, ?, ?, ?)`,
+			task.ID, from, to, at)
+		require.NoError(t, err)
+	}
+	// Started twice and rejected once: measured from the first start to the first completion
+	history(tasks[0], "todo", "in_progress", "2026-03-10 09:00:00")
+	history(tasks[0], "in_progress", "completed", "2026-03-10 15:00:00")
+	history(tasks[0], "completed", "in_progress", "2026-03-11 09:00:00")
+	history(tasks[0], "in_progress", "completed", "2026-03-11 10:00:00")
+	history(tasks[1], "todo", "in_progress", "2026-03-10 09:00:00")
+	history(tasks[1], "in_progress", "completed", "2026-03-12 09:00:00")
+	history(tasks[2], "todo", "completed", "2026-03-10 09:00:00")   // Never started
+	history(tasks[3], "todo", "in_progress", "2026-01-01 09:00:00") // Completed before the window
+	history(tasks[3], "in_progress", "completed", "2026-01-02 09:00:00")
+
+	hours, err := NewStatsRepository(database).CycleTimes(ctx, CycleTimeFilter{
+		Since:         time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		StartStatuses: []string{"in_progress"},
+		DoneStatuses:  []string{"completed"},
+	})
+	require.NoError(t, err)
+	require.Len(t, hours, 2)
+	assert.ElementsMatch(t, []float64{6, 48}, []float64{math.Round(hours[0]), math.Round(hours[1])})
+}
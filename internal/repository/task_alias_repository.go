@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// TaskAliasRepository handles the short names task commands accept in place
+// of a task key
+type TaskAliasRepository struct {
+	db *DB
+}
+
+// NewTaskAliasRepository creates a new TaskAliasRepository
+func NewTaskAliasRepository(db *DB) *TaskAliasRepository {
+	return &TaskAliasRepository{db: db}
+}
+
+// Create adds an alias for a task. The alias must be normalized and unused.
+func (r *TaskAliasRepository) Create(ctx context.Context, alias string, taskID int64) error {
+	if taskID <= 0 {
+		return models.ErrInvalidTaskID
+	}
+	_, err := r.db.ExecContext(ctx, `INSERT INTO task_aliases (alias, task_id) VALUES (?, ?)`, alias, taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("alias %q already exists", alias)
+		}
+		return fmt.Errorf("failed to create task alias: %w", err)
+	}
+	return nil
+}
+
+// GetTaskKey returns the key of the task an alias points at, or "" if there
+// is no such alias or its task was deleted
+func (r *TaskAliasRepository) GetTaskKey(ctx context.Context, alias string) (string, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT t.key
+		FROM task_aliases a
+		JOIN tasks t ON t.id = a.task_id
+		WHERE a.alias = ? AND t.deleted_at IS NULL
+	`, alias).Scan(&key)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get task alias: %w", err)
+	}
+	return key, nil
+}
+
+// GetTaskKeyByOldKey returns the current key of the task that had oldKey
+// before it was resequenced, or "" if no task had it, its task was deleted,
+// or another task holds the key now
+func (r *TaskAliasRepository) GetTaskKeyByOldKey(ctx context.Context, oldKey string) (string, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT t.key
+		FROM task_key_aliases a
+		JOIN tasks t ON t.id = a.task_id
+		WHERE a.old_key = ? AND t.deleted_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM tasks WHERE key = a.old_key)
+	`, oldKey).Scan(&key)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up old task key: %w", err)
+	}
+	return key, nil
+}
+
+// List returns every alias with its task's current key, ordered by alias
+func (r *TaskAliasRepository) List(ctx context.Context) ([]*models.TaskAlias, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.alias, a.task_id, t.key, a.created_at
+		FROM task_aliases a
+		JOIN tasks t ON t.id = a.task_id
+		WHERE t.deleted_at IS NULL
+		ORDER BY a.alias
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []*models.TaskAlias{}
+	for rows.Next() {
+		alias := &models.TaskAlias{}
+		if err := rows.Scan(&alias.Alias, &alias.TaskID, &alias.TaskKey, &alias.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, rows.Err()
+}
+
+// Delete removes an alias
+func (r *TaskAliasRepository) Delete(ctx context.Context, alias string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_aliases WHERE alias = ?`, alias)
+	if err != nil {
+		return fmt.Errorf("failed to delete task alias: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("alias %q not found", alias)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskAliasRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskAliasRepository(db)
+
+	require.NoError(t, repo.Create(ctx, "login", taskID))
+	assert.Error(t, repo.Create(ctx, "login", taskID), "aliases are unique")
+
+	key, err := repo.GetTaskKey(ctx, "login")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F01-001", key)
+
+	key, err = repo.GetTaskKey(ctx, "signup")
+	require.NoError(t, err)
+	assert.Empty(t, key, "an unknown alias resolves to nothing")
+
+	aliases, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, aliases, 1)
+	assert.Equal(t, "login", aliases[0].Alias)
+	assert.Equal(t, "T-E01-F01-001", aliases[0].TaskKey)
+
+	require.NoError(t, repo.Delete(ctx, "login"))
+	assert.Error(t, repo.Delete(ctx, "login"))
+}
+
+func TestTaskRepository_FindKeys(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	createTestTask(t, db)
+	_, err := db.ExecContext(ctx, `UPDATE tasks SET slug = 'build-login-form' WHERE key = 'T-E01-F01-001'`)
+	require.NoError(t, err)
+	repo := NewTaskRepository(db)
+
+	found, err := repo.FindKeysBySuffix(ctx, "-001", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-001"}, found)
+
+	found, err = repo.FindKeysBySuffix(ctx, "-F01-001", "E02")
+	require.NoError(t, err)
+	assert.Empty(t, found, "matches are limited to the epic")
+
+	found, err = repo.FindKeysBySlug(ctx, "build-login", "E01")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-001"}, found, "a slug prefix matches")
+
+	found, err = repo.FindKeysBySlug(ctx, "login", "")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
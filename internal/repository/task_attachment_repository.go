@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// TaskAttachmentRepository handles CRUD operations for task attachments.
+// It only records attachments; copying the file itself is up to the caller.
+type TaskAttachmentRepository struct {
+	db *DB
+}
+
+// NewTaskAttachmentRepository creates a new TaskAttachmentRepository
+func NewTaskAttachmentRepository(db *DB) *TaskAttachmentRepository {
+	return &TaskAttachmentRepository{db: db}
+}
+
+// Create records an attachment on a task
+func (r *TaskAttachmentRepository) Create(ctx context.Context, attachment *models.TaskAttachment) error {
+	if err := attachment.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_attachments (task_id, filename, file_path, size_bytes, sha256, content_type, attached_by, note)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, attachment.TaskID, attachment.Filename, attachment.FilePath, attachment.SizeBytes,
+		attachment.SHA256, attachment.ContentType, attachment.AttachedBy, attachment.Note)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	attachment.ID = id
+	if err := r.db.QueryRowContext(ctx, `SELECT created_at FROM task_attachments WHERE id = ?`, id).Scan(&attachment.CreatedAt); err != nil {
+		return fmt.Errorf("failed to read attachment created_at: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an attachment by its ID
+func (r *TaskAttachmentRepository) GetByID(ctx context.Context, id int64) (*models.TaskAttachment, error) {
+	attachment := &models.TaskAttachment{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, task_id, filename, file_path, size_bytes, sha256, content_type, attached_by, note, created_at
+		FROM task_attachments
+		WHERE id = ?
+	`, id).Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.Filename,
+		&attachment.FilePath,
+		&attachment.SizeBytes,
+		&attachment.SHA256,
+		&attachment.ContentType,
+		&attachment.AttachedBy,
+		&attachment.Note,
+		&attachment.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("attachment not found with id %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListByTaskID returns the attachments of a task in the order they were added
+func (r *TaskAttachmentRepository) ListByTaskID(ctx context.Context, taskID int64) ([]*models.TaskAttachment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, task_id, filename, file_path, size_bytes, sha256, content_type, attached_by, note, created_at
+		FROM task_attachments
+		WHERE task_id = ?
+		ORDER BY id
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*models.TaskAttachment{}
+	for rows.Next() {
+		attachment := &models.TaskAttachment{}
+		if err := rows.Scan(
+			&attachment.ID,
+			&attachment.TaskID,
+			&attachment.Filename,
+			&attachment.FilePath,
+			&attachment.SizeBytes,
+			&attachment.SHA256,
+			&attachment.ContentType,
+			&attachment.AttachedBy,
+			&attachment.Note,
+			&attachment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Delete removes an attachment record of a task. The copied file is left in place.
+func (r *TaskAttachmentRepository) Delete(ctx context.Context, taskID, attachmentID int64) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM task_attachments WHERE id = ? AND task_id = ?
+	`, attachmentID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("attachment %d not found on this task", attachmentID)
+	}
+
+	return nil
+}
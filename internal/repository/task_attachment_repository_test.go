@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskAttachmentRepository_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskAttachmentRepository(db)
+
+	agent := "qa-agent"
+	first := &models.TaskAttachment{
+		TaskID:     taskID,
+		Filename:   "error.log",
+		FilePath:   "docs/plan/E01/E01-F01/attachments/T-E01-F01-001/error.log",
+		SizeBytes:  42,
+		SHA256:     "abc123",
+		AttachedBy: &agent,
+	}
+	require.NoError(t, repo.Create(ctx, first))
+	assert.NotZero(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second := &models.TaskAttachment{TaskID: taskID, Filename: "screen.png", FilePath: "screen.png", SizeBytes: 7, SHA256: "def456"}
+	require.NoError(t, repo.Create(ctx, second))
+
+	attachments, err := repo.ListByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "error.log", attachments[0].Filename)
+	assert.Equal(t, int64(42), attachments[0].SizeBytes)
+	assert.Equal(t, &agent, attachments[0].AttachedBy)
+	assert.Nil(t, attachments[1].AttachedBy)
+
+	got, err := repo.GetByID(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "def456", got.SHA256)
+
+	assert.Error(t, repo.Delete(ctx, taskID+1000, first.ID), "an attachment can't be removed through another task")
+	require.NoError(t, repo.Delete(ctx, taskID, first.ID))
+	attachments, err = repo.ListByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Len(t, attachments, 1)
+}
+
+func TestTaskAttachmentRepository_Validation(t *testing.T) {
+	db := setupCriteriaTestDB(t)
+	repo := NewTaskAttachmentRepository(db)
+
+	err := repo.Create(context.Background(), &models.TaskAttachment{TaskID: 1, Filename: "error.log"})
+	assert.ErrorIs(t, err, models.ErrInvalidAttachment)
+}
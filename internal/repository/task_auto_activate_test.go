@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createDraftTask creates a todo task under a draft feature and draft epic
+func createDraftTask(t *testing.T, db *DB, key string) (*models.Epic, *models.Feature, *models.Task) {
+	ctx := context.Background()
+
+	epic := &models.Epic{Key: "E01", Title: "Draft Epic", Status: models.EpicStatusDraft, Priority: models.PriorityMedium}
+	require.NoError(t, NewEpicRepository(db).Create(ctx, epic))
+
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Draft Feature", Status: models.FeatureStatusDraft}
+	require.NoError(t, NewFeatureRepository(db).Create(ctx, feature))
+
+	task := &models.Task{FeatureID: feature.ID, Key: key, Title: "First Task", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, NewTaskRepository(db).Create(ctx, task))
+
+	return epic, feature, task
+}
+
+// parentStatuses returns the stored status of a feature and epic
+func parentStatuses(t *testing.T, db *DB, feature *models.Feature, epic *models.Epic) (models.FeatureStatus, models.EpicStatus) {
+	ctx := context.Background()
+	f, err := NewFeatureRepository(db).GetByID(ctx, feature.ID)
+	require.NoError(t, err)
+	e, err := NewEpicRepository(db).GetByID(ctx, epic.ID)
+	require.NoError(t, err)
+	return f.Status, e.Status
+}
+
+func TestUpdateStatus_AutoActivatesDraftParents(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	epic, feature, task := createDraftTask(t, db, "T-E01-F01-001")
+	taskRepo := NewTaskRepository(db)
+
+	// Non-development statuses leave parents in draft
+	require.NoError(t, taskRepo.UpdateStatus(ctx, task.ID, models.TaskStatusBlocked, nil, nil))
+	featureStatus, epicStatus := parentStatuses(t, db, feature, epic)
+	assert.Equal(t, models.FeatureStatusDraft, featureStatus)
+	assert.Equal(t, models.EpicStatusDraft, epicStatus)
+
+	require.NoError(t, taskRepo.UpdateStatus(ctx, task.ID, models.TaskStatusInProgress, nil, nil))
+	featureStatus, epicStatus = parentStatuses(t, db, feature, epic)
+	assert.Equal(t, models.FeatureStatusActive, featureStatus)
+	assert.Equal(t, models.EpicStatusActive, epicStatus)
+}
+
+func TestUpdateStatus_AutoActivateRespectsOverrideAndConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("status_override", func(t *testing.T) {
+		db := setupCriteriaTestDB(t)
+		defer db.Close()
+
+		epic, feature, task := createDraftTask(t, db, "T-E01-F01-001")
+		_, err := db.ExecContext(ctx, "UPDATE features SET status_override = 1 WHERE id = ?", feature.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, NewTaskRepository(db).UpdateStatus(ctx, task.ID, models.TaskStatusInProgress, nil, nil))
+		featureStatus, epicStatus := parentStatuses(t, db, feature, epic)
+		assert.Equal(t, models.FeatureStatusDraft, featureStatus)
+		assert.Equal(t, models.EpicStatusActive, epicStatus)
+	})
+
+	t.Run("auto_activate_disabled", func(t *testing.T) {
+		db := setupCriteriaTestDB(t)
+		defer db.Close()
+
+		epic, feature, task := createDraftTask(t, db, "T-E01-F01-001")
+		cfg := config.DefaultWorkflow()
+		disabled := false
+		cfg.AutoActivate = &disabled
+
+		require.NoError(t, NewTaskRepositoryWithWorkflow(db, cfg).UpdateStatus(ctx, task.ID, models.TaskStatusInProgress, nil, nil))
+		featureStatus, epicStatus := parentStatuses(t, db, feature, epic)
+		assert.Equal(t, models.FeatureStatusDraft, featureStatus)
+		assert.Equal(t, models.EpicStatusDraft, epicStatus)
+	})
+}
+
+func TestClaimTask_AutoActivatesDraftParents(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	epic, feature, task := createDraftTask(t, db, "T-E01-F01-001")
+
+	claimed, err := NewTaskRepository(db).ClaimTask(ctx, task.ID, models.TaskStatusTodo, models.TaskStatusInProgress, nil)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	featureStatus, epicStatus := parentStatuses(t, db, feature, epic)
+	assert.Equal(t, models.FeatureStatusActive, featureStatus)
+	assert.Equal(t, models.EpicStatusActive, epicStatus)
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// TaskChecklistRepository handles CRUD operations for task checklist items
+type TaskChecklistRepository struct {
+	db *DB
+}
+
+// NewTaskChecklistRepository creates a new TaskChecklistRepository
+func NewTaskChecklistRepository(db *DB) *TaskChecklistRepository {
+	return &TaskChecklistRepository{db: db}
+}
+
+// ChecklistSummary counts the checklist items of a task
+type ChecklistSummary struct {
+	TaskID    int64 `json:"task_id"`
+	Total     int   `json:"total"`
+	Done      int   `json:"done"`
+	Remaining int   `json:"remaining"`
+}
+
+// Create adds a checklist item to a task
+func (r *TaskChecklistRepository) Create(ctx context.Context, item *models.TaskChecklistItem) error {
+	if err := item.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_checklist_items (task_id, content, done, completed_at)
+		VALUES (?, ?, ?, ?)
+	`, item.TaskID, item.Content, item.Done, item.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create checklist item: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	item.ID = id
+	return nil
+}
+
+// GetByID retrieves a checklist item by its ID
+func (r *TaskChecklistRepository) GetByID(ctx context.Context, id int64) (*models.TaskChecklistItem, error) {
+	item := &models.TaskChecklistItem{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, task_id, content, done, completed_at, created_at
+		FROM task_checklist_items
+		WHERE id = ?
+	`, id).Scan(
+		&item.ID,
+		&item.TaskID,
+		&item.Content,
+		&item.Done,
+		&item.CompletedAt,
+		&item.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("checklist item not found with id %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist item: %w", err)
+	}
+
+	return item, nil
+}
+
+// ListByTaskID returns the checklist items of a task in the order they were added
+func (r *TaskChecklistRepository) ListByTaskID(ctx context.Context, taskID int64) ([]*models.TaskChecklistItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, task_id, content, done, completed_at, created_at
+		FROM task_checklist_items
+		WHERE task_id = ?
+		ORDER BY id
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checklist items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.TaskChecklistItem{}
+	for rows.Next() {
+		item := &models.TaskChecklistItem{}
+		if err := rows.Scan(
+			&item.ID,
+			&item.TaskID,
+			&item.Content,
+			&item.Done,
+			&item.CompletedAt,
+			&item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checklist items: %w", err)
+	}
+
+	return items, nil
+}
+
+// SetDone marks a checklist item of a task as done or not done.
+// The item must belong to the given task so a mistyped ID cannot tick
+// another task's checklist.
+func (r *TaskChecklistRepository) SetDone(ctx context.Context, taskID, itemID int64, done bool) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_checklist_items
+		SET done = ?,
+		    completed_at = CASE WHEN ? THEN COALESCE(completed_at, CURRENT_TIMESTAMP) ELSE NULL END
+		WHERE id = ? AND task_id = ?
+	`, done, done, itemID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to update checklist item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("checklist item %d not found on this task", itemID)
+	}
+
+	return nil
+}
+
+// Delete removes a checklist item from a task
+func (r *TaskChecklistRepository) Delete(ctx context.Context, taskID, itemID int64) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM task_checklist_items WHERE id = ? AND task_id = ?
+	`, itemID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete checklist item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("checklist item %d not found on this task", itemID)
+	}
+
+	return nil
+}
+
+// GetSummaryByTaskID counts the total and completed checklist items of a task
+func (r *TaskChecklistRepository) GetSummaryByTaskID(ctx context.Context, taskID int64) (*ChecklistSummary, error) {
+	summary := &ChecklistSummary{TaskID: taskID}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(CASE WHEN done THEN 1 ELSE 0 END), 0)
+		FROM task_checklist_items
+		WHERE task_id = ?
+	`, taskID).Scan(&summary.Total, &summary.Done)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist summary: %w", err)
+	}
+
+	summary.Remaining = summary.Total - summary.Done
+	return summary, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskChecklistRepository_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskChecklistRepository(db)
+
+	first := &models.TaskChecklistItem{TaskID: taskID, Content: "write tests"}
+	require.NoError(t, repo.Create(ctx, first))
+	second := &models.TaskChecklistItem{TaskID: taskID, Content: "update docs"}
+	require.NoError(t, repo.Create(ctx, second))
+
+	items, err := repo.ListByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "write tests", items[0].Content)
+	assert.False(t, items[0].Done)
+
+	require.NoError(t, repo.SetDone(ctx, taskID, first.ID, true))
+	item, err := repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.True(t, item.Done)
+	assert.NotNil(t, item.CompletedAt)
+
+	summary, err := repo.GetSummaryByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Total)
+	assert.Equal(t, 1, summary.Done)
+	assert.Equal(t, 1, summary.Remaining)
+
+	require.NoError(t, repo.SetDone(ctx, taskID, first.ID, false))
+	item, err = repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.False(t, item.Done)
+	assert.Nil(t, item.CompletedAt)
+
+	require.NoError(t, repo.Delete(ctx, taskID, second.ID))
+	items, err = repo.ListByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
+
+func TestTaskChecklistRepository_WrongTask(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskChecklistRepository(db)
+
+	item := &models.TaskChecklistItem{TaskID: taskID, Content: "write tests"}
+	require.NoError(t, repo.Create(ctx, item))
+
+	assert.Error(t, repo.SetDone(ctx, taskID+1, item.ID, true))
+	assert.Error(t, repo.Delete(ctx, taskID+1, item.ID))
+	assert.Error(t, repo.Create(ctx, &models.TaskChecklistItem{TaskID: taskID}))
+}
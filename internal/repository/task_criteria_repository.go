@@ -27,9 +27,9 @@ func (r *TaskCriteriaRepository) Create(ctx context.Context, criteria *models.Ta
 
 	query := `
 		INSERT INTO task_criteria (
-			task_id, criterion, status, verified_at, verification_notes
+			task_id, criterion, status, verified_at, verified_by, verification_notes
 		)
-		VALUES (?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -37,6 +37,7 @@ func (r *TaskCriteriaRepository) Create(ctx context.Context, criteria *models.Ta
 		criteria.Criterion,
 		criteria.Status,
 		criteria.VerifiedAt,
+		criteria.VerifiedBy,
 		criteria.VerificationNotes,
 	)
 	if err != nil {
@@ -55,7 +56,7 @@ func (r *TaskCriteriaRepository) Create(ctx context.Context, criteria *models.Ta
 // GetByID retrieves a task criterion by its ID
 func (r *TaskCriteriaRepository) GetByID(ctx context.Context, id int64) (*models.TaskCriteria, error) {
 	query := `
-		SELECT id, task_id, criterion, status, verified_at, verification_notes, created_at
+		SELECT id, task_id, criterion, status, verified_at, verified_by, verification_notes, created_at
 		FROM task_criteria
 		WHERE id = ?
 	`
@@ -67,6 +68,7 @@ func (r *TaskCriteriaRepository) GetByID(ctx context.Context, id int64) (*models
 		&criteria.Criterion,
 		&criteria.Status,
 		&criteria.VerifiedAt,
+		&criteria.VerifiedBy,
 		&criteria.VerificationNotes,
 		&criteria.CreatedAt,
 	)
@@ -84,7 +86,7 @@ func (r *TaskCriteriaRepository) GetByID(ctx context.Context, id int64) (*models
 // GetByTaskID retrieves all criteria for a task
 func (r *TaskCriteriaRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskCriteria, error) {
 	query := `
-		SELECT id, task_id, criterion, status, verified_at, verification_notes, created_at
+		SELECT id, task_id, criterion, status, verified_at, verified_by, verification_notes, created_at
 		FROM task_criteria
 		WHERE task_id = ?
 		ORDER BY created_at ASC
@@ -105,6 +107,7 @@ func (r *TaskCriteriaRepository) GetByTaskID(ctx context.Context, taskID int64)
 			&criterion.Criterion,
 			&criterion.Status,
 			&criterion.VerifiedAt,
+			&criterion.VerifiedBy,
 			&criterion.VerificationNotes,
 			&criterion.CreatedAt,
 		)
@@ -129,7 +132,7 @@ func (r *TaskCriteriaRepository) Update(ctx context.Context, criteria *models.Ta
 
 	query := `
 		UPDATE task_criteria
-		SET criterion = ?, status = ?, verified_at = ?, verification_notes = ?
+		SET criterion = ?, status = ?, verified_at = ?, verified_by = ?, verification_notes = ?
 		WHERE id = ?
 	`
 
@@ -137,6 +140,7 @@ func (r *TaskCriteriaRepository) Update(ctx context.Context, criteria *models.Ta
 		criteria.Criterion,
 		criteria.Status,
 		criteria.VerifiedAt,
+		criteria.VerifiedBy,
 		criteria.VerificationNotes,
 		criteria.ID,
 	)
@@ -158,6 +162,12 @@ func (r *TaskCriteriaRepository) Update(ctx context.Context, criteria *models.Ta
 
 // UpdateStatus updates the status of a criterion and optionally sets verification fields
 func (r *TaskCriteriaRepository) UpdateStatus(ctx context.Context, id int64, status models.CriteriaStatus, notes *string) error {
+	return r.UpdateStatusBy(ctx, id, status, nil, notes)
+}
+
+// UpdateStatusBy updates the status of a criterion like UpdateStatus, recording
+// verifiedBy as the reviewer when the criterion is marked complete or failed
+func (r *TaskCriteriaRepository) UpdateStatusBy(ctx context.Context, id int64, status models.CriteriaStatus, verifiedBy, notes *string) error {
 	if err := models.ValidateCriteriaStatus(string(status)); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -166,15 +176,17 @@ func (r *TaskCriteriaRepository) UpdateStatus(ctx context.Context, id int64, sta
 	if status == models.CriteriaStatusComplete || status == models.CriteriaStatusFailed {
 		now := time.Now()
 		verifiedAt = &now
+	} else {
+		verifiedBy = nil
 	}
 
 	query := `
 		UPDATE task_criteria
-		SET status = ?, verified_at = ?, verification_notes = ?
+		SET status = ?, verified_at = ?, verified_by = ?, verification_notes = ?
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, status, verifiedAt, notes, id)
+	result, err := r.db.ExecContext(ctx, query, status, verifiedAt, verifiedBy, notes, id)
 	if err != nil {
 		return fmt.Errorf("failed to update criterion status: %w", err)
 	}
@@ -236,16 +248,22 @@ type CriteriaSummary struct {
 	CompletionPct   float64
 }
 
+// UnverifiedCount returns the number of criteria that are not yet complete or
+// not applicable: pending, in progress, or failed
+func (s *CriteriaSummary) UnverifiedCount() int {
+	return s.PendingCount + s.InProgressCount + s.FailedCount
+}
+
 // GetSummaryByTaskID calculates a summary of criteria for a task
 func (r *TaskCriteriaRepository) GetSummaryByTaskID(ctx context.Context, taskID int64) (*CriteriaSummary, error) {
 	query := `
 		SELECT
 			COUNT(*) as total,
-			SUM(CASE WHEN status = 'pending' THEN 1 ELSE 0 END) as pending,
-			SUM(CASE WHEN status = 'in_progress' THEN 1 ELSE 0 END) as in_progress,
-			SUM(CASE WHEN status = 'complete' THEN 1 ELSE 0 END) as complete,
-			SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END) as failed,
-			SUM(CASE WHEN status = 'na' THEN 1 ELSE 0 END) as na
+			COALESCE(SUM(CASE WHEN status = 'pending' THEN 1 ELSE 0 END), 0) as pending,
+			COALESCE(SUM(CASE WHEN status = 'in_progress' THEN 1 ELSE 0 END), 0) as in_progress,
+			COALESCE(SUM(CASE WHEN status = 'complete' THEN 1 ELSE 0 END), 0) as complete,
+			COALESCE(SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END), 0) as failed,
+			COALESCE(SUM(CASE WHEN status = 'na' THEN 1 ELSE 0 END), 0) as na
 		FROM task_criteria
 		WHERE task_id = ?
 	`
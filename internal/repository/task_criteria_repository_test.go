@@ -262,4 +262,45 @@ func TestTaskCriteriaRepository_GetSummary(t *testing.T) {
 
 	// Completion % = (complete + na) / total = (3 + 1) / 8 = 50%
 	assert.InDelta(t, 50.0, summary.CompletionPct, 0.01)
+
+	// Pending, in progress, and failed criteria still need verification
+	assert.Equal(t, 4, summary.UnverifiedCount())
+}
+
+func TestTaskCriteriaRepository_GetSummary_NoCriteria(t *testing.T) {
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskCriteriaRepository(db)
+
+	summary, err := repo.GetSummaryByTaskID(context.Background(), taskID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.TotalCount)
+	assert.Equal(t, 0.0, summary.CompletionPct)
+}
+
+func TestTaskCriteriaRepository_UpdateStatusBy(t *testing.T) {
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskCriteriaRepository(db)
+	ctx := context.Background()
+
+	criteria := &models.TaskCriteria{TaskID: taskID, Criterion: "Tests pass", Status: models.CriteriaStatusPending}
+	require.NoError(t, repo.Create(ctx, criteria))
+
+	reviewer := "reviewer-agent"
+	require.NoError(t, repo.UpdateStatusBy(ctx, criteria.ID, models.CriteriaStatusComplete, &reviewer, nil))
+
+	verified, err := repo.GetByID(ctx, criteria.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CriteriaStatusComplete, verified.Status)
+	require.NotNil(t, verified.VerifiedBy)
+	assert.Equal(t, reviewer, *verified.VerifiedBy)
+	assert.NotNil(t, verified.VerifiedAt)
+
+	// Reopening a criterion clears who verified it
+	require.NoError(t, repo.UpdateStatusBy(ctx, criteria.ID, models.CriteriaStatusPending, &reviewer, nil))
+	reopened, err := repo.GetByID(ctx, criteria.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reopened.VerifiedBy)
+	assert.Nil(t, reopened.VerifiedAt)
 }
@@ -157,13 +157,13 @@ func (r *TaskRepository) ReopenTaskWithAutoBlock(ctx context.Context, taskID int
 	}
 
 	// Reopen the task (using existing ReopenTaskForced since we're in a transaction)
-	err = r.reopenTaskInTx(ctx, tx, taskID, agent, notes, false)
+	err = r.reopenTaskInTx(ctx, tx.Tx, taskID, agent, notes, false)
 	if err != nil {
 		return fmt.Errorf("failed to reopen task: %w", err)
 	}
 
 	// Get all dependents (need to query before transaction commits)
-	dependents, err := r.getTaskDependentsInTx(ctx, tx, taskKey)
+	dependents, err := r.getTaskDependentsInTx(ctx, tx.Tx, taskKey)
 	if err != nil {
 		return fmt.Errorf("failed to get dependents: %w", err)
 	}
@@ -171,7 +171,7 @@ func (r *TaskRepository) ReopenTaskWithAutoBlock(ctx context.Context, taskID int
 	// Block all non-completed dependents and their transitive dependents
 	blockedTasks := make(map[string]bool)
 	for _, dependent := range dependents {
-		if err := r.blockTaskAndDependentsInTx(ctx, tx, dependent, taskKey, blockedTasks); err != nil {
+		if err := r.blockTaskAndDependentsInTx(ctx, tx.Tx, dependent, taskKey, blockedTasks); err != nil {
 			return fmt.Errorf("failed to block dependent %s: %w", dependent.Key, err)
 		}
 	}
@@ -237,9 +237,9 @@ func (r *TaskRepository) getTaskDependentsInTx(ctx context.Context, tx *sql.Tx,
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 	`
 
 	rows, err := tx.QueryContext(ctx, query, featureID)
@@ -257,7 +257,7 @@ func (r *TaskRepository) getTaskDependentsInTx(ctx context.Context, tx *sql.Tx,
 			&task.AssignedAgent, &task.FilePath, &task.BlockedReason, &task.ExecutionOrder,
 			&task.CreatedAt, &task.StartedAt, &task.CompletedAt, &task.BlockedAt, &task.UpdatedAt,
 			&task.CompletedBy, &task.CompletionNotes, &task.FilesChanged, &task.TestsPassed,
-			&task.VerificationStatus, &task.TimeSpentMinutes, &task.ContextData,
+			&task.VerificationStatus, &task.TimeSpentMinutes, &task.ContextData, &task.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
@@ -332,3 +332,154 @@ func (r *TaskRepository) blockTaskAndDependentsInTx(ctx context.Context, tx *sql
 
 	return nil
 }
+
+// AddTaskDependency makes taskKey depend on dependsOnKey by adding it to the
+// task's depends_on array. The read, validation, and write happen in one
+// transaction. Both tasks must exist, a task cannot depend on itself, and the
+// new dependency must not create a cycle. Adding a dependency that is already
+// present changes nothing. Returns the task's dependencies afterwards.
+func (r *TaskRepository) AddTaskDependency(ctx context.Context, taskKey, dependsOnKey string) ([]string, error) {
+	if taskKey == dependsOnKey {
+		return nil, fmt.Errorf("task cannot depend on itself: %s", taskKey)
+	}
+
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	taskID, deps, err := r.getDependsOnInTx(ctx, tx.Tx, taskKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM tasks WHERE key = ? AND deleted_at IS NULL)", dependsOnKey).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up task %s: %w", dependsOnKey, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("dependency does not exist: %s", dependsOnKey)
+	}
+
+	for _, dep := range deps {
+		if dep == dependsOnKey {
+			return deps, nil
+		}
+	}
+
+	// Cycles may pass through any feature, so the graph covers every task
+	rows, err := tx.QueryContext(ctx, `
+		SELECT key, depends_on FROM tasks
+		WHERE deleted_at IS NULL AND depends_on IS NOT NULL AND depends_on NOT IN ('', '[]')`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	detector := dependency.NewDetector()
+	for rows.Next() {
+		var key, dependsOn string
+		if err := rows.Scan(&key, &dependsOn); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan dependencies: %w", err)
+		}
+		var keys []string
+		if err := json.Unmarshal([]byte(dependsOn), &keys); err != nil {
+			continue
+		}
+		for _, dep := range keys {
+			detector.AddDependency(key, dep)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	if err := detector.ValidateDependency(ctx, taskKey, dependsOnKey); err != nil {
+		return nil, err
+	}
+
+	deps = append(deps, dependsOnKey)
+	if err := setDependsOnInTx(ctx, tx.Tx, taskID, deps); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return deps, nil
+}
+
+// RemoveTaskDependency removes dependsOnKey from taskKey's depends_on array in
+// one transaction. Returns the task's dependencies afterwards, or an error if
+// the task did not depend on dependsOnKey.
+func (r *TaskRepository) RemoveTaskDependency(ctx context.Context, taskKey, dependsOnKey string) ([]string, error) {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	taskID, deps, err := r.getDependsOnInTx(ctx, tx.Tx, taskKey)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := []string{}
+	for _, dep := range deps {
+		if dep != dependsOnKey {
+			remaining = append(remaining, dep)
+		}
+	}
+	if len(remaining) == len(deps) {
+		return nil, fmt.Errorf("%s does not depend on %s", taskKey, dependsOnKey)
+	}
+
+	if err := setDependsOnInTx(ctx, tx.Tx, taskID, remaining); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return remaining, nil
+}
+
+// getDependsOnInTx returns a task's ID and its parsed depends_on array
+func (r *TaskRepository) getDependsOnInTx(ctx context.Context, tx *sql.Tx, taskKey string) (int64, []string, error) {
+	var taskID int64
+	var dependsOn sql.NullString
+	err := tx.QueryRowContext(ctx, "SELECT id, depends_on FROM tasks WHERE key = ? AND deleted_at IS NULL", taskKey).Scan(&taskID, &dependsOn)
+	if err == sql.ErrNoRows {
+		return 0, nil, fmt.Errorf("task not found: %s", taskKey)
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get task %s: %w", taskKey, err)
+	}
+
+	deps := []string{}
+	if dependsOn.Valid && dependsOn.String != "" {
+		if err := json.Unmarshal([]byte(dependsOn.String), &deps); err != nil {
+			return 0, nil, fmt.Errorf("task %s has invalid depends_on JSON: %w", taskKey, err)
+		}
+	}
+	return taskID, deps, nil
+}
+
+// setDependsOnInTx writes a task's depends_on array, storing NULL when empty
+func setDependsOnInTx(ctx context.Context, tx *sql.Tx, taskID int64, deps []string) error {
+	var dependsOn *string
+	if len(deps) > 0 {
+		data, err := json.Marshal(deps)
+		if err != nil {
+			return fmt.Errorf("failed to marshal dependencies: %w", err)
+		}
+		s := string(data)
+		dependsOn = &s
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET depends_on = ? WHERE id = ?", dependsOn, taskID); err != nil {
+		return fmt.Errorf("failed to update depends_on: %w", err)
+	}
+	return nil
+}
@@ -305,3 +305,50 @@ func TestBuildDependencyGraph(t *testing.T) {
 }
 
 // This function has been moved to TaskRepository.BuildDependencyGraphForFeature
+
+func TestTaskRepository_AddRemoveTaskDependency(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskRepo := NewTaskRepository(db)
+	first, err := taskRepo.GetByID(ctx, createTestTask(t, db))
+	require.NoError(t, err)
+	for _, key := range []string{"T-E01-F01-002", "T-E01-F01-003"} {
+		task := &models.Task{FeatureID: first.FeatureID, Key: key, Title: "Task", Status: models.TaskStatusTodo, Priority: 5}
+		require.NoError(t, taskRepo.Create(ctx, task))
+	}
+
+	deps, err := taskRepo.AddTaskDependency(ctx, "T-E01-F01-003", "T-E01-F01-002")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-002"}, deps)
+	deps, err = taskRepo.AddTaskDependency(ctx, "T-E01-F01-003", first.Key)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T-E01-F01-002", first.Key}, deps)
+
+	// Adding an existing dependency changes nothing
+	deps, err = taskRepo.AddTaskDependency(ctx, "T-E01-F01-003", "T-E01-F01-002")
+	require.NoError(t, err)
+	assert.Len(t, deps, 2)
+
+	_, err = taskRepo.AddTaskDependency(ctx, "T-E01-F01-002", "T-E01-F01-002")
+	assert.ErrorContains(t, err, "cannot depend on itself")
+	_, err = taskRepo.AddTaskDependency(ctx, "T-E01-F01-002", "T-E01-F01-099")
+	assert.ErrorContains(t, err, "does not exist")
+	_, err = taskRepo.AddTaskDependency(ctx, "T-E01-F01-099", "T-E01-F01-002")
+	assert.ErrorContains(t, err, "task not found")
+	_, err = taskRepo.AddTaskDependency(ctx, "T-E01-F01-002", "T-E01-F01-003")
+	assert.ErrorContains(t, err, "circular dependency")
+
+	deps, err = taskRepo.RemoveTaskDependency(ctx, "T-E01-F01-003", "T-E01-F01-002")
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Key}, deps)
+	_, err = taskRepo.RemoveTaskDependency(ctx, "T-E01-F01-003", "T-E01-F01-002")
+	assert.ErrorContains(t, err, "does not depend on")
+
+	_, err = taskRepo.RemoveTaskDependency(ctx, "T-E01-F01-003", first.Key)
+	require.NoError(t, err)
+	task, err := taskRepo.GetByKey(ctx, "T-E01-F01-003")
+	require.NoError(t, err)
+	assert.Nil(t, task.DependsOn, "removing the last dependency clears depends_on")
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/progress"
+)
+
+// TaskEstimateRepository handles task estimates and actual effort
+type TaskEstimateRepository struct {
+	db *DB
+}
+
+// NewTaskEstimateRepository creates a new TaskEstimateRepository
+func NewTaskEstimateRepository(db *DB) *TaskEstimateRepository {
+	return &TaskEstimateRepository{db: db}
+}
+
+// CapacityFilter narrows a capacity report
+type CapacityFilter struct {
+	AgentType *string
+	EpicKey   *string
+}
+
+// CapacityRow is the remaining work for one agent type within one epic.
+// Remaining effort is each open task's estimate less its actual effort so far.
+type CapacityRow struct {
+	AgentType        string  `json:"agent_type"` // Empty for tasks without an agent type
+	EpicKey          string  `json:"epic_key"`
+	EpicTitle        string  `json:"epic_title"`
+	OpenTasks        int     `json:"open_tasks"`
+	UnestimatedTasks int     `json:"unestimated_tasks"`
+	RemainingPoints  float64 `json:"remaining_points"`
+	RemainingHours   float64 `json:"remaining_hours"`
+}
+
+// Set creates or replaces a task's estimate
+func (r *TaskEstimateRepository) Set(ctx context.Context, estimate *models.TaskEstimate) error {
+	if err := estimate.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_estimates (task_id, estimate, actual_effort, unit, updated_at)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(task_id) DO UPDATE SET
+			estimate = excluded.estimate,
+			actual_effort = excluded.actual_effort,
+			unit = excluded.unit,
+			updated_at = excluded.updated_at
+	`, estimate.TaskID, estimate.Estimate, estimate.ActualEffort, estimate.Unit); err != nil {
+		return fmt.Errorf("failed to set task estimate: %w", err)
+	}
+	return nil
+}
+
+// GetByTaskID returns a task's estimate, or nil if the task has none
+func (r *TaskEstimateRepository) GetByTaskID(ctx context.Context, taskID int64) (*models.TaskEstimate, error) {
+	estimate := &models.TaskEstimate{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT task_id, estimate, actual_effort, unit, updated_at
+		FROM task_estimates
+		WHERE task_id = ?
+	`, taskID).Scan(
+		&estimate.TaskID,
+		&estimate.Estimate,
+		&estimate.ActualEffort,
+		&estimate.Unit,
+		&estimate.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task estimate: %w", err)
+	}
+	return estimate, nil
+}
+
+// Capacity sums the remaining effort of open (not completed or archived)
+// tasks, grouped by agent type and epic
+func (r *TaskEstimateRepository) Capacity(ctx context.Context, filter CapacityFilter) ([]*CapacityRow, error) {
+	query := `
+		SELECT COALESCE(t.agent_type, ''), e.key, e.title,
+		       COUNT(*),
+		       COALESCE(SUM(CASE WHEN te.estimate IS NULL THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN te.unit = 'points' THEN MAX(te.estimate - COALESCE(te.actual_effort, 0), 0) END), 0),
+		       COALESCE(SUM(CASE WHEN te.unit = 'hours' THEN MAX(te.estimate - COALESCE(te.actual_effort, 0), 0) END), 0)
+		FROM tasks t
+		INNER JOIN features f ON t.feature_id = f.id
+		INNER JOIN epics e ON f.epic_id = e.id
+		LEFT JOIN task_estimates te ON te.task_id = t.id
+		WHERE t.status NOT IN ('completed', 'archived') AND t.deleted_at IS NULL
+	`
+	var args []interface{}
+	if filter.AgentType != nil {
+		query += " AND t.agent_type = ?"
+		args = append(args, *filter.AgentType)
+	}
+	if filter.EpicKey != nil {
+		query += " AND e.key = ?"
+		args = append(args, *filter.EpicKey)
+	}
+	query += " GROUP BY COALESCE(t.agent_type, ''), e.key ORDER BY COALESCE(t.agent_type, ''), e.key"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate capacity: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*CapacityRow{}
+	for rows.Next() {
+		row := &CapacityRow{}
+		if err := rows.Scan(
+			&row.AgentType,
+			&row.EpicKey,
+			&row.EpicTitle,
+			&row.OpenTasks,
+			&row.UnestimatedTasks,
+			&row.RemainingPoints,
+			&row.RemainingHours,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan capacity row: %w", err)
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating capacity rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// EstimateUnit returns the unit weighted progress counts estimates in: the
+// unit most estimates use, or points when there are none or it's a tie. It
+// also returns how many estimates use the other unit. Points and hours can't
+// be added together, so those estimates are left out, as if the tasks weren't
+// estimated.
+func (r *TaskEstimateRepository) EstimateUnit(ctx context.Context) (models.EstimateUnit, int, error) {
+	var points, hours int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(CASE WHEN te.unit = 'points' THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN te.unit = 'hours' THEN 1 ELSE 0 END), 0)
+		FROM task_estimates te
+		INNER JOIN tasks t ON t.id = te.task_id
+		WHERE te.estimate IS NOT NULL AND t.deleted_at IS NULL
+	`).Scan(&points, &hours)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to count estimate units: %w", err)
+	}
+	if hours > points {
+		return models.EstimateUnitHours, points, nil
+	}
+	return models.EstimateUnitPoints, hours, nil
+}
+
+// EstimateTotalsByFeature returns the task counts and estimate sums of each
+// feature with tasks, keyed by feature ID, for estimate-weighted progress
+func (r *TaskEstimateRepository) EstimateTotalsByFeature(ctx context.Context) (map[int64]progress.EstimateTotals, error) {
+	return r.estimateTotals(ctx, "t.feature_id")
+}
+
+// EstimateTotalsByEpic returns the task counts and estimate sums of each epic
+// with tasks, keyed by epic ID, for estimate-weighted progress
+func (r *TaskEstimateRepository) EstimateTotalsByEpic(ctx context.Context) (map[int64]progress.EstimateTotals, error) {
+	return r.estimateTotals(ctx, "f.epic_id")
+}
+
+// estimateTotals aggregates task estimates grouped by column. Each task counts
+// toward done by its status's progress weight, as in unweighted progress, and
+// only estimates in the EstimateUnit are summed.
+func (r *TaskEstimateRepository) estimateTotals(ctx context.Context, column string) (map[int64]progress.EstimateTotals, error) {
+	unit, _, err := r.EstimateUnit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weight, weightArgs := statusWeightSQL("t.status", r.db.projectWorkflow())
+	var args []interface{}
+	args = append(args, weightArgs...)
+	args = append(args, weightArgs...)
+	args = append(args, weightArgs...)
+	args = append(args, unit)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+column+`,
+		       COUNT(*),
+		       SUM(`+weight+`),
+		       COUNT(te.estimate),
+		       SUM(CASE WHEN te.estimate IS NOT NULL THEN `+weight+` ELSE 0 END),
+		       COALESCE(SUM(te.estimate), 0),
+		       COALESCE(SUM(te.estimate * `+weight+`), 0)
+		FROM tasks t
+		INNER JOIN features f ON t.feature_id = f.id
+		LEFT JOIN task_estimates te ON te.task_id = t.id AND te.unit = ?
+		WHERE t.deleted_at IS NULL AND f.deleted_at IS NULL
+		GROUP BY `+column, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum task estimates: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int64]progress.EstimateTotals)
+	for rows.Next() {
+		var id int64
+		var totals progress.EstimateTotals
+		if err := rows.Scan(
+			&id,
+			&totals.Tasks,
+			&totals.CompletedTasks,
+			&totals.EstimatedTasks,
+			&totals.CompletedEstimatedTasks,
+			&totals.Estimate,
+			&totals.CompletedEstimate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan task estimate totals: %w", err)
+		}
+		result[id] = totals
+	}
+	return result, rows.Err()
+}
+
+// statusWeightSQL returns a SQL expression for the progress weight of the
+// status in column, and its arguments. Statuses get the progress_weight in
+// workflow's status metadata; without a workflow, completed and archived
+// weigh 1 and the rest 0, as in progress.CalculateProgress.
+func statusWeightSQL(column string, workflow *config.WorkflowConfig) (string, []interface{}) {
+	if workflow == nil {
+		return "CASE WHEN " + column + " IN ('completed', 'archived') THEN 1.0 ELSE 0.0 END", nil
+	}
+
+	statuses := make([]string, 0, len(workflow.StatusMetadata))
+	for status := range workflow.StatusMetadata {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	var sb strings.Builder
+	var args []interface{}
+	sb.WriteString("CASE " + column)
+	for _, status := range statuses {
+		if weight := workflow.StatusMetadata[status].ProgressWeight; weight != 0 {
+			sb.WriteString(" WHEN ? THEN ?")
+			args = append(args, status, weight)
+		}
+	}
+	sb.WriteString(" ELSE 0.0 END")
+	if len(args) == 0 {
+		return "0.0", nil
+	}
+	return sb.String(), args
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestTaskEstimateRepository_SetAndGet(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskEstimateRepository(db)
+
+	estimate, err := repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Nil(t, estimate, "task should have no estimate yet")
+
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: taskID, Estimate: floatPtr(5), Unit: models.EstimateUnitPoints}))
+	estimate, err = repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	require.NotNil(t, estimate)
+	assert.Equal(t, 5.0, *estimate.Estimate)
+	assert.Nil(t, estimate.ActualEffort)
+	assert.Equal(t, models.EstimateUnitPoints, estimate.Unit)
+
+	// Setting again replaces the row
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: taskID, Estimate: floatPtr(8), ActualEffort: floatPtr(2.5), Unit: models.EstimateUnitHours}))
+	estimate, err = repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, *estimate.Estimate)
+	assert.Equal(t, 2.5, *estimate.ActualEffort)
+	assert.Equal(t, models.EstimateUnitHours, estimate.Unit)
+
+	err = repo.Set(ctx, &models.TaskEstimate{TaskID: taskID, Estimate: floatPtr(1), Unit: "days"})
+	assert.Error(t, err)
+}
+
+func TestTaskEstimateRepository_Capacity(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	repo := NewTaskEstimateRepository(db)
+
+	first, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	backend, frontend := "backend", "frontend"
+	addTask := func(key string, agentType *string, status models.TaskStatus) int64 {
+		task := &models.Task{FeatureID: first.FeatureID, Key: key, Title: key, Status: status, Priority: 5, AgentType: agentType}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		return task.ID
+	}
+	second := addTask("T-E01-F01-002", &backend, models.TaskStatusInProgress)
+	done := addTask("T-E01-F01-003", &backend, models.TaskStatusCompleted)
+	addTask("T-E01-F01-004", &backend, models.TaskStatusTodo) // unestimated
+	ui := addTask("T-E01-F01-005", &frontend, models.TaskStatusTodo)
+
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: taskID, Estimate: floatPtr(5), Unit: models.EstimateUnitPoints}))
+	// Actual effort counts against the estimate, never below zero
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: second, Estimate: floatPtr(3), ActualEffort: floatPtr(1), Unit: models.EstimateUnitPoints}))
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: done, Estimate: floatPtr(13), Unit: models.EstimateUnitPoints}))
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: ui, Estimate: floatPtr(4), ActualEffort: floatPtr(6), Unit: models.EstimateUnitHours}))
+
+	rows, err := repo.Capacity(ctx, CapacityFilter{})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "backend", rows[0].AgentType)
+	assert.Equal(t, "E01", rows[0].EpicKey)
+	assert.Equal(t, 3, rows[0].OpenTasks)
+	assert.Equal(t, 1, rows[0].UnestimatedTasks)
+	assert.Equal(t, 7.0, rows[0].RemainingPoints)
+	assert.Equal(t, 0.0, rows[0].RemainingHours)
+
+	assert.Equal(t, "frontend", rows[1].AgentType)
+	assert.Equal(t, 1, rows[1].OpenTasks)
+	assert.Equal(t, 0.0, rows[1].RemainingHours)
+
+	rows, err = repo.Capacity(ctx, CapacityFilter{AgentType: &frontend})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "frontend", rows[0].AgentType)
+
+	missing := "E99"
+	rows, err = repo.Capacity(ctx, CapacityFilter{EpicKey: &missing})
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestTaskEstimateRepository_EstimateTotals(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	repo := NewTaskEstimateRepository(db)
+
+	first, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	done := &models.Task{FeatureID: first.FeatureID, Key: "T-E01-F01-002", Title: "Done", Status: models.TaskStatusCompleted, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, done))
+	unestimated := &models.Task{FeatureID: first.FeatureID, Key: "T-E01-F01-003", Title: "Open", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, unestimated))
+
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: taskID, Estimate: floatPtr(2), Unit: models.EstimateUnitPoints}))
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: done.ID, Estimate: floatPtr(8), Unit: models.EstimateUnitPoints}))
+
+	byFeature, err := repo.EstimateTotalsByFeature(ctx)
+	require.NoError(t, err)
+	totals := byFeature[first.FeatureID]
+	assert.Equal(t, 3, totals.Tasks)
+	assert.Equal(t, 1.0, totals.CompletedTasks)
+	assert.Equal(t, 2, totals.EstimatedTasks)
+	assert.Equal(t, 10.0, totals.Estimate)
+	assert.Equal(t, 8.0, totals.CompletedEstimate)
+	// The unestimated task weighs the average estimate of 5: 8 / 15
+	assert.InDelta(t, 53.33, totals.WeightedPct(), 0.01)
+
+	byEpic, err := repo.EstimateTotalsByEpic(ctx)
+	require.NoError(t, err)
+	require.Len(t, byEpic, 1)
+	for _, epicTotals := range byEpic {
+		assert.Equal(t, totals, epicTotals)
+	}
+}
+
+func TestTaskEstimateRepository_EstimateTotalsWeightsStatusesAndUnits(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	root := t.TempDir()
+	db.SetProjectRoot(root)
+	t.Cleanup(config.ClearWorkflowCache)
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".sharkconfig.json"), []byte(`{
+  "status_flow": {
+    "todo": ["in_progress"],
+    "in_progress": ["completed"],
+    "completed": []
+  },
+  "status_metadata": {
+    "in_progress": {"progress_weight": 0.5},
+    "completed": {"progress_weight": 1.0}
+  }
+}`), 0644))
+
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	repo := NewTaskEstimateRepository(db)
+	first, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	addTask := func(key string, status models.TaskStatus) int64 {
+		task := &models.Task{FeatureID: first.FeatureID, Key: key, Title: key, Status: status, Priority: 5}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		return task.ID
+	}
+	working := addTask("T-E01-F01-002", models.TaskStatusInProgress)
+	done := addTask("T-E01-F01-003", models.TaskStatusCompleted)
+
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: taskID, Estimate: floatPtr(3), Unit: models.EstimateUnitHours}))
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: working, Estimate: floatPtr(4), Unit: models.EstimateUnitPoints}))
+	require.NoError(t, repo.Set(ctx, &models.TaskEstimate{TaskID: done, Estimate: floatPtr(6), Unit: models.EstimateUnitPoints}))
+
+	unit, ignored, err := repo.EstimateUnit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, models.EstimateUnitPoints, unit)
+	assert.Equal(t, 1, ignored)
+
+	byFeature, err := repo.EstimateTotalsByFeature(ctx)
+	require.NoError(t, err)
+	totals := byFeature[first.FeatureID]
+	assert.Equal(t, 3, totals.Tasks)
+	// In progress counts half, completed in full
+	assert.Equal(t, 1.5, totals.CompletedTasks)
+	// The hours estimate is left out, as if the task were unestimated
+	assert.Equal(t, 2, totals.EstimatedTasks)
+	assert.Equal(t, 10.0, totals.Estimate)
+	assert.Equal(t, 8.0, totals.CompletedEstimate)
+}
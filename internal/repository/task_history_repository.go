@@ -38,8 +38,8 @@ func (r *TaskHistoryRepository) Create(ctx context.Context, history *models.Task
 	}
 
 	query := `
-		INSERT INTO task_history (task_id, old_status, new_status, agent, notes, rejection_reason)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO task_history (task_id, old_status, new_status, agent, notes, rejection_reason, forced)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -49,6 +49,7 @@ func (r *TaskHistoryRepository) Create(ctx context.Context, history *models.Task
 		history.Agent,
 		history.Notes,
 		history.RejectionReason,
+		history.Forced,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create task history: %w", err)
@@ -66,7 +67,7 @@ func (r *TaskHistoryRepository) Create(ctx context.Context, history *models.Task
 // ListByTask retrieves all history records for a task
 func (r *TaskHistoryRepository) ListByTask(ctx context.Context, taskID int64) ([]*models.TaskHistory, error) {
 	query := `
-		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, timestamp
+		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, COALESCE(forced, 0), timestamp
 		FROM task_history
 		WHERE task_id = ?
 		ORDER BY timestamp DESC
@@ -89,6 +90,7 @@ func (r *TaskHistoryRepository) ListByTask(ctx context.Context, taskID int64) ([
 			&history.Agent,
 			&history.Notes,
 			&history.RejectionReason,
+			&history.Forced,
 			&history.Timestamp,
 		)
 		if err != nil {
@@ -153,7 +155,7 @@ func (r *TaskHistoryRepository) ListWithFilters(ctx context.Context, filters His
 
 	// Build query with filters
 	query := `
-		SELECT DISTINCT th.id, th.task_id, th.old_status, th.new_status, th.agent, th.notes, th.rejection_reason, th.timestamp
+		SELECT DISTINCT th.id, th.task_id, th.old_status, th.new_status, th.agent, th.notes, th.rejection_reason, COALESCE(th.forced, 0), th.timestamp
 		FROM task_history th
 	`
 
@@ -234,6 +236,7 @@ func (r *TaskHistoryRepository) ListWithFilters(ctx context.Context, filters His
 			&history.Agent,
 			&history.Notes,
 			&history.RejectionReason,
+			&history.Forced,
 			&history.Timestamp,
 		)
 		if err != nil {
@@ -249,10 +252,63 @@ func (r *TaskHistoryRepository) ListWithFilters(ctx context.Context, filters His
 	return histories, nil
 }
 
+// ListAfterID retrieves up to limit history records with an id greater than
+// afterID, oldest first. Used to follow new status changes as they happen.
+func (r *TaskHistoryRepository) ListAfterID(ctx context.Context, afterID int64, limit int) ([]*models.TaskHistory, error) {
+	query := `
+		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, COALESCE(forced, 0), timestamp
+		FROM task_history
+		WHERE id > ?
+		ORDER BY id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task history: %w", err)
+	}
+	defer rows.Close()
+
+	var histories []*models.TaskHistory
+	for rows.Next() {
+		history := &models.TaskHistory{}
+		err := rows.Scan(
+			&history.ID,
+			&history.TaskID,
+			&history.OldStatus,
+			&history.NewStatus,
+			&history.Agent,
+			&history.Notes,
+			&history.RejectionReason,
+			&history.Forced,
+			&history.Timestamp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task history: %w", err)
+		}
+		histories = append(histories, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task history: %w", err)
+	}
+
+	return histories, nil
+}
+
+// LatestID returns the id of the most recent history record, or 0 if there are none
+func (r *TaskHistoryRepository) LatestID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM task_history").Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest task history id: %w", err)
+	}
+	return id, nil
+}
+
 // GetHistoryByTaskKey retrieves all history records for a task by its key
 func (r *TaskHistoryRepository) GetHistoryByTaskKey(ctx context.Context, taskKey string) ([]*models.TaskHistory, error) {
 	query := `
-		SELECT th.id, th.task_id, th.old_status, th.new_status, th.agent, th.notes, th.rejection_reason, th.timestamp
+		SELECT th.id, th.task_id, th.old_status, th.new_status, th.agent, th.notes, th.rejection_reason, COALESCE(th.forced, 0), th.timestamp
 		FROM task_history th
 		INNER JOIN tasks t ON th.task_id = t.id
 		WHERE t.key = ?
@@ -276,6 +332,7 @@ func (r *TaskHistoryRepository) GetHistoryByTaskKey(ctx context.Context, taskKey
 			&history.Agent,
 			&history.Notes,
 			&history.RejectionReason,
+			&history.Forced,
 			&history.Timestamp,
 		)
 		if err != nil {
@@ -294,7 +351,7 @@ func (r *TaskHistoryRepository) GetHistoryByTaskKey(ctx context.Context, taskKey
 // GetByID retrieves a single history record by ID
 func (r *TaskHistoryRepository) GetByID(ctx context.Context, id int64) (*models.TaskHistory, error) {
 	query := `
-		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, timestamp
+		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, COALESCE(forced, 0), timestamp
 		FROM task_history
 		WHERE id = ?
 	`
@@ -308,6 +365,7 @@ func (r *TaskHistoryRepository) GetByID(ctx context.Context, id int64) (*models.
 		&history.Agent,
 		&history.Notes,
 		&history.RejectionReason,
+		&history.Forced,
 		&history.Timestamp,
 	)
 	if err != nil {
@@ -320,7 +378,7 @@ func (r *TaskHistoryRepository) GetByID(ctx context.Context, id int64) (*models.
 // GetRejectionHistoryForTask retrieves all rejection records (records with rejection_reason) for a task
 func (r *TaskHistoryRepository) GetRejectionHistoryForTask(ctx context.Context, taskID int64) ([]*models.TaskHistory, error) {
 	query := `
-		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, timestamp
+		SELECT id, task_id, old_status, new_status, agent, notes, rejection_reason, COALESCE(forced, 0), timestamp
 		FROM task_history
 		WHERE task_id = ? AND rejection_reason IS NOT NULL
 		ORDER BY timestamp DESC
@@ -343,6 +401,7 @@ func (r *TaskHistoryRepository) GetRejectionHistoryForTask(ctx context.Context,
 			&history.Agent,
 			&history.Notes,
 			&history.RejectionReason,
+			&history.Forced,
 			&history.Timestamp,
 		)
 		if err != nil {
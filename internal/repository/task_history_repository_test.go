@@ -327,6 +327,7 @@ func TestGetHistoryByTaskKey(t *testing.T) {
 		NewStatus: string(models.TaskStatusReadyForReview),
 		Agent:     &agent2,
 		Notes:     &notes2,
+		Forced:    true,
 	}
 
 	err = historyRepo.Create(ctx, history2)
@@ -358,6 +359,10 @@ func TestGetHistoryByTaskKey(t *testing.T) {
 	assert.NotNil(t, histories[1].Notes)
 	assert.Equal(t, notes2, *histories[1].Notes)
 
+	// Verify the forced flag round-trips
+	assert.False(t, histories[0].Forced)
+	assert.True(t, histories[1].Forced)
+
 	// Cleanup
 	defer func() { _, _ = database.ExecContext(ctx, "DELETE FROM task_history WHERE task_id = ?", task.ID) }()
 
@@ -640,3 +645,48 @@ func TestTaskHistoryRepository_GetRejectionHistoryForTask(t *testing.T) {
 	assert.Contains(t, reasons, rejectionReason2)
 	assert.Contains(t, reasons, rejectionReason3)
 }
+
+// TestTaskHistoryRepository_ListAfterID tests following new history records by id
+func TestTaskHistoryRepository_ListAfterID(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	db := NewDB(database)
+	historyRepo := NewTaskHistoryRepository(db)
+	taskRepo := NewTaskRepository(db)
+
+	_, featureID := test.SeedTestData()
+	_, _ = database.ExecContext(ctx, "DELETE FROM tasks WHERE key = 'T-E99-F99-911'")
+
+	task := &models.Task{
+		FeatureID: featureID,
+		Key:       "T-E99-F99-911",
+		Title:     "History Follow Task",
+		Status:    models.TaskStatusTodo,
+		Priority:  5,
+	}
+	require.NoError(t, taskRepo.Create(ctx, task))
+
+	startID, err := historyRepo.LatestID(ctx)
+	require.NoError(t, err)
+
+	agent := "test-agent-follow"
+	for _, status := range []string{"in_progress", "ready_for_review", "completed"} {
+		require.NoError(t, historyRepo.Create(ctx, &models.TaskHistory{TaskID: task.ID, NewStatus: status, Agent: &agent}))
+	}
+
+	histories, err := historyRepo.ListAfterID(ctx, startID, 2)
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, "in_progress", histories[0].NewStatus)
+	assert.Equal(t, "ready_for_review", histories[1].NewStatus)
+	assert.Greater(t, histories[0].ID, startID)
+
+	rest, err := historyRepo.ListAfterID(ctx, histories[1].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "completed", rest[0].NewStatus)
+
+	latest, err := historyRepo.LatestID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rest[0].ID, latest)
+}
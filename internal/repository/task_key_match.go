@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// FindKeysBySuffix returns the keys of tasks ending in suffix (e.g. "-003" or
+// "-F01-003"), limited to one epic unless epicKey is empty. Task commands use
+// it to accept a bare task number when it is unambiguous.
+func (r *TaskRepository) FindKeysBySuffix(ctx context.Context, suffix, epicKey string) ([]string, error) {
+	return r.findKeys(ctx, "substr(t.key, -length(?)) = ?", []interface{}{suffix, suffix}, epicKey)
+}
+
+// FindKeysBySlug returns the keys of tasks whose slug is slug, or, if there are
+// none, whose slug starts with it, limited to one epic unless epicKey is empty
+func (r *TaskRepository) FindKeysBySlug(ctx context.Context, slug, epicKey string) ([]string, error) {
+	keys, err := r.findKeys(ctx, "t.slug = ?", []interface{}{slug}, epicKey)
+	if err != nil || len(keys) > 0 {
+		return keys, err
+	}
+	return r.findKeys(ctx, "substr(t.slug, 1, length(?)) = ?", []interface{}{slug, slug}, epicKey)
+}
+
+// findKeys returns the keys of live tasks matching condition, in key order
+func (r *TaskRepository) findKeys(ctx context.Context, condition string, args []interface{}, epicKey string) ([]string, error) {
+	query := `
+		SELECT t.key
+		FROM tasks t
+		INNER JOIN features f ON t.feature_id = f.id
+		INNER JOIN epics e ON f.epic_id = e.id
+		WHERE t.deleted_at IS NULL AND ` + condition
+	if epicKey != "" {
+		query += ` AND e.key = ?`
+		args = append(args, epicKey)
+	}
+	query += ` ORDER BY t.key`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match task keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan task key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// AvailableTaskFilter narrows the candidates returned by ListAvailable
+type AvailableTaskFilter struct {
+	Status    models.TaskStatus // Status of candidate tasks (default: todo)
+	EpicKey   string
+	AgentType string
+	Labels    []string // Tasks must carry every label
+
+	// LeaseHolder sees tasks it has leased; tasks with an unexpired lease held
+	// by any other agent are skipped
+	LeaseHolder string
+}
+
+// availableTaskColumns lists the task columns selected by ListAvailable, aliased as t
+const availableTaskColumns = `t.id, t.feature_id, t.key, t.title, t.slug, t.description, t.status, t.agent_type, t.priority,
+	       t.depends_on, t.assigned_agent, t.file_path, t.blocked_reason, t.execution_order,
+	       t.created_at, t.started_at, t.completed_at, t.blocked_at, t.updated_at,
+	       t.completed_by, t.completion_notes, t.files_changed, t.tests_passed,
+	       t.verification_status, t.time_spent_minutes, t.context_data, t.version`
+
+// ListAvailable returns tasks whose dependencies are all completed or archived,
+// ordered by execution_order (nulls last), priority, and creation time.
+//
+// Dependencies come from both the depends_on JSON column and depends_on rows in
+// task_relationships. A dependency on a task key that does not exist makes the
+// task unavailable; a malformed depends_on value is treated as no dependencies.
+func (r *TaskRepository) ListAvailable(ctx context.Context, filter AvailableTaskFilter) ([]*models.Task, error) {
+	status := filter.Status
+	if status == "" {
+		status = models.TaskStatusTodo
+	}
+
+	query := `
+		SELECT ` + availableTaskColumns + `
+		FROM tasks t
+		INNER JOIN features f ON t.feature_id = f.id
+		INNER JOIN epics e ON f.epic_id = e.id
+		WHERE t.status = ? AND t.deleted_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM json_each(CASE WHEN json_valid(t.depends_on) AND json_type(t.depends_on) = 'array' THEN t.depends_on ELSE '[]' END) dep
+			LEFT JOIN tasks dt ON dt.key = dep.value
+			WHERE dt.id IS NULL OR dt.status NOT IN (?, ?)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM task_relationships rel
+			INNER JOIN tasks rt ON rt.id = rel.to_task_id
+			WHERE rel.from_task_id = t.id
+			  AND rel.relationship_type = 'depends_on'
+			  AND rt.status NOT IN (?, ?)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM task_leases l
+			WHERE l.task_id = t.id AND l.expires_at > ? AND l.agent != ?
+		  )`
+
+	args := []interface{}{
+		status,
+		models.TaskStatusCompleted, models.TaskStatusArchived,
+		models.TaskStatusCompleted, models.TaskStatusArchived,
+		leaseNow(), filter.LeaseHolder,
+	}
+
+	if filter.EpicKey != "" {
+		query += " AND e.key = ?"
+		args = append(args, filter.EpicKey)
+	}
+	if filter.AgentType != "" {
+		query += " AND t.agent_type = ?"
+		args = append(args, filter.AgentType)
+	}
+	if len(filter.Labels) > 0 {
+		condition, labelArgs := TaskLabelFilterSQL("t", filter.Labels)
+		query += " AND " + condition
+		args = append(args, labelArgs...)
+	}
+
+	query += " ORDER BY t.execution_order NULLS LAST, t.priority ASC, t.created_at ASC, t.key ASC"
+
+	return r.queryTasks(ctx, query, args...)
+}
+
+// ClaimTask atomically moves a task from fromStatus to toStatus and assigns it to agent.
+// The update only applies while the task still has fromStatus, so concurrent callers
+// cannot claim the same task twice. Returns false if another caller got there first,
+// and a *WIPLimitError if the task's agent type is at its WIP limit (see SetWIPLimits).
+func (r *TaskRepository) ClaimTask(ctx context.Context, taskID int64, fromStatus, toStatus models.TaskStatus, agent *string) (bool, error) {
+	if !r.isValidStatusEnum(toStatus) {
+		return false, fmt.Errorf("invalid status: %s", toStatus)
+	}
+	if !r.isValidTransition(fromStatus, toStatus) {
+		return false, fmt.Errorf("invalid status transition from %s to %s", fromStatus, toStatus)
+	}
+
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if toStatus == models.TaskStatusInProgress {
+		if err := lockTasksForWrite(ctx, tx.Tx); err != nil {
+			return false, err
+		}
+		if err := r.checkWIPLimitTx(ctx, tx.Tx, taskID); err != nil {
+			return false, err
+		}
+	}
+
+	query := "UPDATE tasks SET status = ?, assigned_agent = COALESCE(?, assigned_agent)"
+	args := []interface{}{toStatus, agent}
+	if toStatus == models.TaskStatusInProgress {
+		query += ", started_at = COALESCE(started_at, ?)"
+		args = append(args, time.Now())
+	}
+	query += ` WHERE id = ? AND status = ?
+		AND NOT EXISTS (
+			SELECT 1 FROM task_leases l
+			WHERE l.task_id = tasks.id AND l.expires_at > ? AND l.agent != COALESCE(?, '')
+		)`
+	args = append(args, taskID, fromStatus, leaseNow(), agent)
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim task: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if r.workflow != nil && r.workflow.ActivatesParents(string(toStatus)) {
+		if err := activateParentsTx(ctx, tx.Tx, taskID); err != nil {
+			return false, err
+		}
+	}
+
+	// Starting the task ends any lease on it
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_leases WHERE task_id = ?", taskID); err != nil {
+		return false, fmt.Errorf("failed to release task lease: %w", err)
+	}
+
+	notes := "claimed via task next"
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO task_history (task_id, old_status, new_status, agent, notes, forced)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, taskID, fromStatus, toStatus, agent, notes, false); err != nil {
+		return false, fmt.Errorf("failed to create history record: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// leaseNow returns the current time in the form lease timestamps are stored and compared
+func leaseNow() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// LeaseTask atomically leases a task to agent for ttl. The lease is only taken
+// while the task still has fromStatus and has no unexpired lease held by
+// another agent; an agent leasing a task it already holds extends the lease.
+// Returns nil if another caller got there first.
+func (r *TaskRepository) LeaseTask(ctx context.Context, taskID int64, fromStatus models.TaskStatus, agent string, ttl time.Duration) (*models.TaskLease, error) {
+	if agent == "" {
+		return nil, fmt.Errorf("a lease needs an agent")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lease duration must be positive")
+	}
+
+	now := leaseNow()
+	lease := &models.TaskLease{TaskID: taskID, Agent: agent, LeasedAt: now, ExpiresAt: now.Add(ttl)}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_leases (task_id, agent, leased_at, expires_at)
+		SELECT id, ?, ?, ? FROM tasks WHERE id = ? AND status = ? AND deleted_at IS NULL
+		ON CONFLICT(task_id) DO UPDATE SET
+			agent = excluded.agent,
+			leased_at = excluded.leased_at,
+			expires_at = excluded.expires_at
+		WHERE task_leases.expires_at <= ? OR task_leases.agent = excluded.agent
+	`, agent, lease.LeasedAt, lease.ExpiresAt, taskID, fromStatus, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lease task: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+
+	return lease, nil
+}
+
+// GetActiveLease returns the unexpired lease on a todo task, or nil if there is none
+func (r *TaskRepository) GetActiveLease(ctx context.Context, taskID int64) (*models.TaskLease, error) {
+	lease := &models.TaskLease{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT l.task_id, t.key, l.agent, l.leased_at, l.expires_at
+		FROM task_leases l
+		INNER JOIN tasks t ON t.id = l.task_id
+		WHERE l.task_id = ? AND l.expires_at > ? AND t.status = ? AND t.deleted_at IS NULL
+	`, taskID, leaseNow(), models.TaskStatusTodo).Scan(&lease.TaskID, &lease.TaskKey, &lease.Agent, &lease.LeasedAt, &lease.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task lease: %w", err)
+	}
+	return lease, nil
+}
+
+// CountInProgressByAgentType counts in_progress tasks per agent type, for WIP
+// limits. Tasks without an agent type are not counted.
+func (r *TaskRepository) CountInProgressByAgentType(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT agent_type, COUNT(*)
+		FROM tasks
+		WHERE status = ? AND deleted_at IS NULL AND agent_type IS NOT NULL AND agent_type != ''
+		GROUP BY agent_type`, models.TaskStatusInProgress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count in_progress tasks: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var agentType string
+		var count int
+		if err := rows.Scan(&agentType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan in_progress count: %w", err)
+		}
+		counts[agentType] = count
+	}
+	return counts, rows.Err()
+}
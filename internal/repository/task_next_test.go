@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addNextTestTask adds a task to a feature. depends_on is written directly so
+// tests can store dangling or malformed values that Create would reject.
+func addNextTestTask(t *testing.T, db *DB, featureID int64, key string, status models.TaskStatus, priority int, order *int, dependsOn *string) *models.Task {
+	task := &models.Task{
+		FeatureID:      featureID,
+		Key:            key,
+		Title:          key,
+		Status:         status,
+		Priority:       priority,
+		ExecutionOrder: order,
+	}
+	require.NoError(t, NewTaskRepository(db).Create(context.Background(), task))
+	if dependsOn != nil {
+		_, err := db.Exec("UPDATE tasks SET depends_on = ? WHERE id = ?", *dependsOn, task.ID)
+		require.NoError(t, err)
+	}
+	return task
+}
+
+func TestTaskRepository_ListAvailable(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewTaskRepository(db)
+	first, err := repo.GetByID(ctx, createTestTask(t, db)) // T-E01-F01-001, todo, priority 5
+	require.NoError(t, err)
+
+	one, two := 1, 2
+	doneDep := `["T-E01-F01-002"]`
+	todoDep := `["T-E01-F01-001"]`
+	missingDep := `["T-E01-F01-999"]`
+	malformed := `not json`
+
+	addNextTestTask(t, db, first.FeatureID, "T-E01-F01-002", models.TaskStatusCompleted, 5, nil, nil)
+	addNextTestTask(t, db, first.FeatureID, "T-E01-F01-003", models.TaskStatusTodo, 9, &one, &doneDep)
+	addNextTestTask(t, db, first.FeatureID, "T-E01-F01-004", models.TaskStatusTodo, 1, &two, &todoDep)
+	addNextTestTask(t, db, first.FeatureID, "T-E01-F01-005", models.TaskStatusTodo, 1, nil, &missingDep)
+	addNextTestTask(t, db, first.FeatureID, "T-E01-F01-006", models.TaskStatusTodo, 1, nil, &malformed)
+	blockedByRel := addNextTestTask(t, db, first.FeatureID, "T-E01-F01-007", models.TaskStatusTodo, 1, nil, nil)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO task_relationships (from_task_id, to_task_id, relationship_type) VALUES (?, ?, 'depends_on')`,
+		blockedByRel.ID, first.ID)
+	require.NoError(t, err)
+
+	tasks, err := repo.ListAvailable(ctx, AvailableTaskFilter{})
+	require.NoError(t, err)
+
+	var keys []string
+	for _, task := range tasks {
+		keys = append(keys, task.Key)
+	}
+	// Execution order comes before priority; unmet, missing, and relationship
+	// dependencies exclude tasks; malformed depends_on counts as none
+	assert.Equal(t, []string{"T-E01-F01-003", "T-E01-F01-006", "T-E01-F01-001"}, keys)
+
+	tasks, err = repo.ListAvailable(ctx, AvailableTaskFilter{AgentType: "backend", EpicKey: "E01"})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "T-E01-F01-001", tasks[0].Key)
+
+	tasks, err = repo.ListAvailable(ctx, AvailableTaskFilter{EpicKey: "E99"})
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestTaskRepository_ClaimTask(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewTaskRepository(db)
+	taskID := createTestTask(t, db)
+	agent := "agent-1"
+
+	claimed, err := repo.ClaimTask(ctx, taskID, models.TaskStatusTodo, models.TaskStatusInProgress, &agent)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	task, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusInProgress, task.Status)
+	require.NotNil(t, task.AssignedAgent)
+	assert.Equal(t, agent, *task.AssignedAgent)
+	assert.True(t, task.StartedAt.Valid)
+
+	// A second claim loses the race instead of failing
+	other := "agent-2"
+	claimed, err = repo.ClaimTask(ctx, taskID, models.TaskStatusTodo, models.TaskStatusInProgress, &other)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	history, err := NewTaskHistoryRepository(db).ListByTask(ctx, taskID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, string(models.TaskStatusInProgress), history[0].NewStatus)
+}
+
+func TestTaskRepository_LeaseTask(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewTaskRepository(db)
+	first, err := repo.GetByID(ctx, createTestTask(t, db)) // T-E01-F01-001
+	require.NoError(t, err)
+	second := addNextTestTask(t, db, first.FeatureID, "T-E01-F01-002", models.TaskStatusTodo, 5, nil, nil)
+
+	lease, err := repo.LeaseTask(ctx, first.ID, models.TaskStatusTodo, "backend-1", 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, 5*time.Minute, lease.ExpiresAt.Sub(lease.LeasedAt))
+
+	// Another agent cannot take the lease or see the task, the holder still can
+	lease, err = repo.LeaseTask(ctx, first.ID, models.TaskStatusTodo, "backend-2", 5*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+
+	tasks, err := repo.ListAvailable(ctx, AvailableTaskFilter{LeaseHolder: "backend-2"})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, second.Key, tasks[0].Key)
+
+	tasks, err = repo.ListAvailable(ctx, AvailableTaskFilter{LeaseHolder: "backend-1"})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	active, err := repo.GetActiveLease(ctx, first.ID)
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, "backend-1", active.Agent)
+	assert.Equal(t, first.Key, active.TaskKey)
+
+	// The holder renews; another agent cannot claim while it is held
+	lease, err = repo.LeaseTask(ctx, first.ID, models.TaskStatusTodo, "backend-1", 10*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+
+	other := "backend-2"
+	claimed, err := repo.ClaimTask(ctx, first.ID, models.TaskStatusTodo, models.TaskStatusInProgress, &other)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	// Once expired, the lease is free for anyone
+	_, err = db.ExecContext(ctx, `UPDATE task_leases SET expires_at = ? WHERE task_id = ?`, leaseNow().Add(-time.Second), first.ID)
+	require.NoError(t, err)
+
+	active, err = repo.GetActiveLease(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	lease, err = repo.LeaseTask(ctx, first.ID, models.TaskStatusTodo, "backend-2", 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+
+	// Claiming the task releases the lease
+	claimed, err = repo.ClaimTask(ctx, first.ID, models.TaskStatusTodo, models.TaskStatusInProgress, &other)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM task_leases`).Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestTaskRepository_CountInProgressByAgentType(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewTaskRepository(db)
+	first, err := repo.GetByID(ctx, createTestTask(t, db))
+	require.NoError(t, err)
+
+	for i, status := range []models.TaskStatus{models.TaskStatusInProgress, models.TaskStatusInProgress, models.TaskStatusTodo} {
+		task := addNextTestTask(t, db, first.FeatureID, fmt.Sprintf("T-E01-F01-%03d", i+2), status, 5, nil, nil)
+		_, err := db.ExecContext(ctx, "UPDATE tasks SET agent_type = 'backend' WHERE id = ?", task.ID)
+		require.NoError(t, err)
+	}
+	addNextTestTask(t, db, first.FeatureID, "T-E01-F01-005", models.TaskStatusInProgress, 5, nil, nil) // No agent type
+
+	counts, err := repo.CountInProgressByAgentType(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"backend": 2}, counts)
+}
+
+func TestTaskRepository_WIPLimits(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	repo := NewTaskRepository(db)
+	first, err := repo.GetByID(ctx, createTestTask(t, db))
+	require.NoError(t, err)
+
+	var backend []*models.Task
+	for i, status := range []models.TaskStatus{models.TaskStatusInProgress, models.TaskStatusTodo, models.TaskStatusTodo} {
+		task := addNextTestTask(t, db, first.FeatureID, fmt.Sprintf("T-E01-F01-%03d", i+2), status, 5, nil, nil)
+		_, err := db.ExecContext(ctx, "UPDATE tasks SET agent_type = 'backend' WHERE id = ?", task.ID)
+		require.NoError(t, err)
+		backend = append(backend, task)
+	}
+	repo.SetWIPLimits(map[string]int{"backend": 1})
+
+	// A task without a limited agent type starts as usual
+	_, err = db.ExecContext(ctx, "UPDATE tasks SET agent_type = 'frontend' WHERE id = ?", first.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateStatus(ctx, first.ID, models.TaskStatusInProgress, nil, nil))
+
+	err = repo.UpdateStatus(ctx, backend[1].ID, models.TaskStatusInProgress, nil, nil)
+	var wipErr *WIPLimitError
+	require.ErrorAs(t, err, &wipErr)
+	assert.Equal(t, WIPLimitError{AgentType: "backend", InProgress: 1, Limit: 1}, *wipErr)
+	assert.ErrorIs(t, err, ErrWIPLimit)
+
+	agent := "agent-1"
+	ok, err := repo.ClaimTask(ctx, backend[2].ID, models.TaskStatusTodo, models.TaskStatusInProgress, &agent)
+	assert.ErrorIs(t, err, ErrWIPLimit)
+	assert.False(t, ok)
+
+	// A slot frees up once the in-progress task moves on
+	require.NoError(t, repo.UpdateStatus(ctx, backend[0].ID, models.TaskStatusReadyForReview, nil, nil))
+	ok, err = repo.ClaimTask(ctx, backend[2].ID, models.TaskStatusTodo, models.TaskStatusInProgress, &agent)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	repo.SetWIPLimits(nil)
+	require.NoError(t, repo.UpdateStatus(ctx, backend[1].ID, models.TaskStatusInProgress, nil, nil))
+}
+
+func TestTaskRepository_WIPLimitHoldsUnderConcurrentStarts(t *testing.T) {
+	testDB, err := db.InitDB(filepath.Join(t.TempDir(), "wip.db"))
+	require.NoError(t, err)
+	database := &DB{DB: testDB}
+	defer database.Close()
+
+	ctx := context.Background()
+	repo := NewTaskRepository(database)
+	first, err := repo.GetByID(ctx, createTestTask(t, database))
+	require.NoError(t, err)
+
+	const workers = 8
+	tasks := make([]*models.Task, workers)
+	for i := range tasks {
+		tasks[i] = addNextTestTask(t, database, first.FeatureID, fmt.Sprintf("T-E01-F01-%03d", i+2), models.TaskStatusTodo, 5, nil, nil)
+		_, err := database.ExecContext(ctx, "UPDATE tasks SET agent_type = 'backend' WHERE id = ?", tasks[i].ID)
+		require.NoError(t, err)
+	}
+	repo.SetWIPLimits(map[string]int{"backend": 2})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task *models.Task) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				err = repo.UpdateStatus(ctx, task.ID, models.TaskStatusInProgress, nil, nil)
+			} else {
+				agent := fmt.Sprintf("agent-%d", i)
+				_, err = repo.ClaimTask(ctx, task.ID, models.TaskStatusTodo, models.TaskStatusInProgress, &agent)
+			}
+			if err != nil && !errors.Is(err, ErrWIPLimit) {
+				errs <- err
+			}
+		}(i, task)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	counts, err := repo.CountInProgressByAgentType(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts["backend"])
+}
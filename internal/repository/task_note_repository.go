@@ -189,7 +189,7 @@ func (r *TaskNoteRepository) Search(ctx context.Context, query string, noteTypes
 			INNER JOIN tasks AS t ON tn.task_id = t.id
 			INNER JOIN features AS f ON t.feature_id = f.id
 			INNER JOIN epics AS e ON f.epic_id = e.id
-			WHERE tn.content LIKE ?
+			WHERE tn.content LIKE ? AND t.deleted_at IS NULL
 		`
 		args = append(args, "%"+query+"%")
 
@@ -290,7 +290,7 @@ func (r *TaskNoteRepository) SearchWithTimePeriod(ctx context.Context, query str
 			INNER JOIN tasks AS t ON tn.task_id = t.id
 			INNER JOIN features AS f ON t.feature_id = f.id
 			INNER JOIN epics AS e ON f.epic_id = e.id
-			WHERE tn.content LIKE ?
+			WHERE tn.content LIKE ? AND t.deleted_at IS NULL
 		`
 		args = append(args, "%"+query+"%")
 
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// TaskRecurrenceRepository handles recurrence rules for recurring tasks
+type TaskRecurrenceRepository struct {
+	db *DB
+}
+
+// NewTaskRecurrenceRepository creates a new TaskRecurrenceRepository
+func NewTaskRecurrenceRepository(db *DB) *TaskRecurrenceRepository {
+	return &TaskRecurrenceRepository{db: db}
+}
+
+// recurrenceColumns is the column list shared by recurrence queries
+const recurrenceColumns = `
+	r.task_id, t.key, r.rule, r.next_run_at, r.last_run_at, r.last_task_key, r.created_at
+`
+
+// Set makes a task recur, replacing any existing rule, and archives the task
+// so it serves only as the template for its occurrences
+func (r *TaskRecurrenceRepository) Set(ctx context.Context, rec *models.TaskRecurrence) error {
+	if err := rec.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var currentStatus string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM tasks WHERE id = ?`, rec.TaskID).Scan(&currentStatus)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task not found with id %d", rec.TaskID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get task status: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO task_recurrences (task_id, rule, next_run_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(task_id) DO UPDATE SET rule = excluded.rule, next_run_at = excluded.next_run_at
+	`, rec.TaskID, rec.Rule, rec.NextRunAt.UTC()); err != nil {
+		return fmt.Errorf("failed to set task recurrence: %w", err)
+	}
+
+	if currentStatus != string(models.TaskStatusArchived) {
+		if _, err := tx.ExecContext(ctx, `UPDATE tasks SET status = ? WHERE id = ?`, models.TaskStatusArchived, rec.TaskID); err != nil {
+			return fmt.Errorf("failed to archive recurring task template: %w", err)
+		}
+		notes := fmt.Sprintf("Archived as recurring task template (every %s)", rec.Rule)
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO task_history (task_id, old_status, new_status, notes, forced)
+			VALUES (?, ?, ?, ?, ?)
+		`, rec.TaskID, currentStatus, models.TaskStatusArchived, notes, true); err != nil {
+			return fmt.Errorf("failed to create history record: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetByTaskID returns a task's recurrence, or nil if the task does not recur
+func (r *TaskRecurrenceRepository) GetByTaskID(ctx context.Context, taskID int64) (*models.TaskRecurrence, error) {
+	rows, err := r.query(ctx, `WHERE r.task_id = ?`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0], nil
+}
+
+// List returns every recurrence, soonest first
+func (r *TaskRecurrenceRepository) List(ctx context.Context) ([]*models.TaskRecurrence, error) {
+	return r.query(ctx, `ORDER BY r.next_run_at, t.key`)
+}
+
+// ListDue returns the recurrences whose next run is at or before now
+func (r *TaskRecurrenceRepository) ListDue(ctx context.Context, now time.Time) ([]*models.TaskRecurrence, error) {
+	return r.query(ctx, `WHERE r.next_run_at <= ? AND t.deleted_at IS NULL ORDER BY r.next_run_at, t.key`, now.UTC())
+}
+
+// RecordRun stores the occurrence created for a recurrence and schedules the next one
+func (r *TaskRecurrenceRepository) RecordRun(ctx context.Context, taskID int64, runAt, nextRunAt time.Time, occurrenceKey string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_recurrences
+		SET last_run_at = ?, last_task_key = ?, next_run_at = ?
+		WHERE task_id = ?
+	`, runAt.UTC(), occurrenceKey, nextRunAt.UTC(), taskID)
+	if err != nil {
+		return fmt.Errorf("failed to record recurrence run: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no recurrence for task id %d", taskID)
+	}
+
+	return nil
+}
+
+// Delete stops a task from recurring. The template task stays archived.
+func (r *TaskRecurrenceRepository) Delete(ctx context.Context, taskID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_recurrences WHERE task_id = ?`, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete task recurrence: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no recurrence for task id %d", taskID)
+	}
+
+	return nil
+}
+
+// query runs a recurrence query with the given WHERE/ORDER clause
+func (r *TaskRecurrenceRepository) query(ctx context.Context, clause string, args ...interface{}) ([]*models.TaskRecurrence, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+recurrenceColumns+`
+		FROM task_recurrences r
+		JOIN tasks t ON t.id = r.task_id
+		`+clause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	recurrences := []*models.TaskRecurrence{}
+	for rows.Next() {
+		rec := &models.TaskRecurrence{}
+		if err := rows.Scan(
+			&rec.TaskID,
+			&rec.TaskKey,
+			&rec.Rule,
+			&rec.NextRunAt,
+			&rec.LastRunAt,
+			&rec.LastTaskKey,
+			&rec.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan task recurrence: %w", err)
+		}
+		recurrences = append(recurrences, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task recurrences: %w", err)
+	}
+
+	return recurrences, nil
+}
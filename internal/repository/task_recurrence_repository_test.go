@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRecurrenceRepository_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskRecurrenceRepository(db)
+
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	rec, err := repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Nil(t, rec, "task should not recur yet")
+
+	require.NoError(t, repo.Set(ctx, &models.TaskRecurrence{TaskID: taskID, Rule: "7d", NextRunAt: start}))
+
+	// The template task is archived, with a history record
+	task, err := NewTaskRepository(db).GetByID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusArchived, task.Status)
+	history, err := NewTaskHistoryRepository(db).ListByTask(ctx, taskID)
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+
+	rec, err = repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, "T-E01-F01-001", rec.TaskKey)
+	assert.Equal(t, "7d", rec.Rule)
+	assert.True(t, rec.NextRunAt.Equal(start))
+
+	// Only due recurrences are listed
+	due, err := repo.ListDue(ctx, start.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	due, err = repo.ListDue(ctx, start.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	next := start.AddDate(0, 0, 7)
+	require.NoError(t, repo.RecordRun(ctx, taskID, start.Add(time.Minute), next, "T-E01-F01-002"))
+	rec, err = repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.True(t, rec.NextRunAt.Equal(next))
+	require.NotNil(t, rec.LastTaskKey)
+	assert.Equal(t, "T-E01-F01-002", *rec.LastTaskKey)
+
+	// Setting the rule again replaces it
+	require.NoError(t, repo.Set(ctx, &models.TaskRecurrence{TaskID: taskID, Rule: "2w", NextRunAt: next}))
+	all, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "2w", all[0].Rule)
+
+	require.NoError(t, repo.Delete(ctx, taskID))
+	rec, err = repo.GetByTaskID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+	assert.Error(t, repo.Delete(ctx, taskID))
+}
+
+func TestTaskRecurrenceRepository_SetValidates(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskRecurrenceRepository(db)
+
+	err := repo.Set(ctx, &models.TaskRecurrence{TaskID: taskID, Rule: "weekly", NextRunAt: time.Now()})
+	assert.Error(t, err)
+
+	err = repo.Set(ctx, &models.TaskRecurrence{TaskID: 9999, Rule: "7d", NextRunAt: time.Now()})
+	assert.Error(t, err)
+}
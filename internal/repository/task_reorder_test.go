@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderFeatureTasks(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskRepo := NewTaskRepository(db)
+	first, err := taskRepo.GetByID(ctx, createTestTask(t, db))
+	require.NoError(t, err)
+
+	ids := []int64{first.ID}
+	for i := 2; i <= 3; i++ {
+		task := &models.Task{FeatureID: first.FeatureID, Key: fmt.Sprintf("T-E01-F01-%03d", i), Title: "Task", Status: models.TaskStatusTodo, Priority: 5}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		ids = append(ids, task.ID)
+	}
+
+	t.Run("rewrites execution order", func(t *testing.T) {
+		require.NoError(t, taskRepo.ReorderFeatureTasks(ctx, first.FeatureID, []int64{ids[2], ids[0], ids[1]}))
+
+		tasks, err := taskRepo.ListByFeature(ctx, first.FeatureID)
+		require.NoError(t, err)
+		require.Len(t, tasks, 3)
+		assert.Equal(t, []string{"T-E01-F01-003", "T-E01-F01-001", "T-E01-F01-002"}, []string{tasks[0].Key, tasks[1].Key, tasks[2].Key})
+		for i, task := range tasks {
+			require.NotNil(t, task.ExecutionOrder)
+			assert.Equal(t, i+1, *task.ExecutionOrder)
+		}
+	})
+
+	t.Run("rejects incomplete or invalid orders", func(t *testing.T) {
+		assert.ErrorContains(t, taskRepo.ReorderFeatureTasks(ctx, first.FeatureID, []int64{ids[0], ids[1]}), "every task must be listed")
+		assert.ErrorContains(t, taskRepo.ReorderFeatureTasks(ctx, first.FeatureID, []int64{ids[0], ids[0], ids[1]}), "more than once")
+		assert.ErrorContains(t, taskRepo.ReorderFeatureTasks(ctx, first.FeatureID, []int64{ids[0], ids[1], 9999}), "does not belong")
+
+		// Failed reorders leave the previous order in place
+		tasks, err := taskRepo.ListByFeature(ctx, first.FeatureID)
+		require.NoError(t, err)
+		assert.Equal(t, "T-E01-F01-003", tasks[0].Key)
+	})
+}
+
+func TestSetFeaturePriorities(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	taskRepo := NewTaskRepository(db)
+	first, err := taskRepo.GetByID(ctx, createTestTask(t, db))
+	require.NoError(t, err)
+	second := &models.Task{FeatureID: first.FeatureID, Key: "T-E01-F01-002", Title: "Task", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, second))
+
+	require.NoError(t, taskRepo.SetFeaturePriorities(ctx, first.FeatureID, map[int64]int{first.ID: 2, second.ID: 8}))
+	updated, err := taskRepo.GetByID(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 8, updated.Priority)
+
+	// An invalid entry leaves every priority unchanged
+	assert.ErrorContains(t, taskRepo.SetFeaturePriorities(ctx, first.FeatureID, map[int64]int{first.ID: 1, second.ID: 11}), "between 1 and 10")
+	assert.ErrorContains(t, taskRepo.SetFeaturePriorities(ctx, first.FeatureID, map[int64]int{first.ID: 1, 9999: 3}), "does not belong")
+	unchanged, err := taskRepo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, unchanged.Priority)
+}
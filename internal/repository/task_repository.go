@@ -6,9 +6,12 @@
 // - Distributed tracing
 // - Request-scoped values
 //
+// Statements run through DB (not a transaction) retry transient errors such
+// as SQLITE_BUSY; see RetryPolicy.
+//
 // Callers should create contexts appropriately:
 // - HTTP handlers: Use r.Context() from http.Request
-// - CLI commands: Use context.WithTimeout(context.Background(), timeout)
+// - CLI commands: Use cli.CommandContext, which honors --timeout
 // - Tests: Use context.Background() or context.WithTimeout()
 //
 // Example:
@@ -38,8 +41,9 @@ import (
 
 // TaskRepository handles CRUD operations for tasks
 type TaskRepository struct {
-	db       *DB
-	workflow *config.WorkflowConfig
+	db        *DB
+	workflow  *config.WorkflowConfig
+	wipLimits map[string]int // Per agent type; see SetWIPLimits
 }
 
 // NewTaskRepository creates a new TaskRepository with default workflow configuration
@@ -77,6 +81,12 @@ func (r *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 		return fmt.Errorf("dependency validation failed: %w", err)
 	}
 
+	return insertTask(ctx, r.db, task, r.db.projectPathPtr(task.FilePath))
+}
+
+// insertTask inserts a validated task on db or a transaction. filePath is the
+// task's file path as stored (see DB.ProjectPath).
+func insertTask(ctx context.Context, db execer, task *models.Task, filePath *string) error {
 	// Generate slug from title if not already set
 	if task.Slug == nil {
 		generatedSlug := slug.Generate(task.Title)
@@ -91,7 +101,7 @@ func (r *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		task.FeatureID,
 		task.Key,
 		task.Title,
@@ -102,7 +112,7 @@ func (r *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 		task.Priority,
 		task.DependsOn,
 		task.AssignedAgent,
-		task.FilePath,
+		filePath,
 		task.BlockedReason,
 		task.ExecutionOrder,
 	)
@@ -126,9 +136,9 @@ func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*models.Task, e
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	task := &models.Task{}
@@ -159,6 +169,7 @@ func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*models.Task, e
 		&task.VerificationStatus,
 		&task.TimeSpentMinutes,
 		&task.ContextData,
+		&task.Version,
 	)
 
 	if err == sql.ErrNoRows {
@@ -189,9 +200,9 @@ func (r *TaskRepository) GetByKey(ctx context.Context, key string) (*models.Task
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE key = ?
+		WHERE key = ? AND deleted_at IS NULL
 	`
 
 	task := &models.Task{}
@@ -222,6 +233,7 @@ func (r *TaskRepository) GetByKey(ctx context.Context, key string) (*models.Task
 		&task.VerificationStatus,
 		&task.TimeSpentMinutes,
 		&task.ContextData,
+		&task.Version,
 	)
 
 	if err == nil {
@@ -249,9 +261,9 @@ func (r *TaskRepository) GetByKey(ctx context.Context, key string) (*models.Task
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE key = ? AND slug = ?
+		WHERE key = ? AND slug = ? AND deleted_at IS NULL
 	`
 
 	err = r.db.QueryRowContext(ctx, queryWithSlug, numericKey, slug).Scan(
@@ -281,6 +293,7 @@ func (r *TaskRepository) GetByKey(ctx context.Context, key string) (*models.Task
 		&task.VerificationStatus,
 		&task.TimeSpentMinutes,
 		&task.ContextData,
+		&task.Version,
 	)
 
 	if err == sql.ErrNoRows {
@@ -356,13 +369,13 @@ func (r *TaskRepository) GetByFilePath(ctx context.Context, filePath string) (*m
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE file_path = ?
+		WHERE file_path = ? AND deleted_at IS NULL
 	`
 
 	task := &models.Task{}
-	err := r.db.QueryRowContext(ctx, query, filePath).Scan(
+	err := r.db.QueryRowContext(ctx, query, r.db.ProjectPath(filePath)).Scan(
 		&task.ID,
 		&task.FeatureID,
 		&task.Key,
@@ -389,6 +402,7 @@ func (r *TaskRepository) GetByFilePath(ctx context.Context, filePath string) (*m
 		&task.VerificationStatus,
 		&task.TimeSpentMinutes,
 		&task.ContextData,
+		&task.Version,
 	)
 
 	if err == sql.ErrNoRows {
@@ -401,6 +415,12 @@ func (r *TaskRepository) GetByFilePath(ctx context.Context, filePath string) (*m
 	return task, nil
 }
 
+// ProjectPath returns path in the form task file paths are stored (see
+// DB.ProjectPath)
+func (r *TaskRepository) ProjectPath(path string) string {
+	return r.db.ProjectPath(path)
+}
+
 // UpdateFilePath updates the file_path for a task
 // Pass nil to clear the file path
 func (r *TaskRepository) UpdateFilePath(ctx context.Context, taskKey string, newFilePath *string) error {
@@ -410,7 +430,7 @@ func (r *TaskRepository) UpdateFilePath(ctx context.Context, taskKey string, new
 		WHERE key = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query, newFilePath, taskKey)
+	result, err := r.db.ExecContext(ctx, query, r.db.projectPathPtr(newFilePath), taskKey)
 	if err != nil {
 		return fmt.Errorf("failed to update file path: %w", err)
 	}
@@ -427,6 +447,25 @@ func (r *TaskRepository) UpdateFilePath(ctx context.Context, taskKey string, new
 	return nil
 }
 
+// GetByTitle retrieves the task of a feature with a title, ignoring case and
+// surrounding whitespace, so a retried create can find the task it made.
+// Returns nil if there is none.
+func (r *TaskRepository) GetByTitle(ctx context.Context, featureID int64, title string) (*models.Task, error) {
+	var key string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT key FROM tasks
+		WHERE feature_id = ? AND LOWER(TRIM(title)) = LOWER(TRIM(?)) AND deleted_at IS NULL
+		ORDER BY id LIMIT 1
+	`, featureID, title).Scan(&key)
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get task by title: %w", err)
+	}
+	return r.GetByKey(ctx, key)
+}
+
 // ListByFeature retrieves all tasks for a feature
 func (r *TaskRepository) ListByFeature(ctx context.Context, featureID int64) ([]*models.Task, error) {
 	query := `
@@ -434,9 +473,9 @@ func (r *TaskRepository) ListByFeature(ctx context.Context, featureID int64) ([]
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, priority ASC, created_at ASC, key ASC
 	`
 
@@ -450,11 +489,11 @@ func (r *TaskRepository) ListByEpic(ctx context.Context, epicKey string) ([]*mod
 		       t.depends_on, t.assigned_agent, t.file_path, t.blocked_reason, t.execution_order,
 		       t.created_at, t.started_at, t.completed_at, t.blocked_at, t.updated_at,
 		       t.completed_by, t.completion_notes, t.files_changed, t.tests_passed,
-		       t.verification_status, t.time_spent_minutes, t.context_data
+		       t.verification_status, t.time_spent_minutes, t.context_data, t.version
 		FROM tasks t
 		INNER JOIN features f ON t.feature_id = f.id
 		INNER JOIN epics e ON f.epic_id = e.id
-		WHERE e.key = ?
+		WHERE e.key = ? AND t.deleted_at IS NULL
 		ORDER BY t.execution_order NULLS LAST, t.priority ASC, t.created_at ASC, t.key ASC
 	`
 
@@ -469,11 +508,11 @@ func (r *TaskRepository) ListBlockedTasksByEpic(ctx context.Context, epicKey str
 		       t.depends_on, t.assigned_agent, t.file_path, t.blocked_reason, t.execution_order,
 		       t.created_at, t.started_at, t.completed_at, t.blocked_at, t.updated_at,
 		       t.completed_by, t.completion_notes, t.files_changed, t.tests_passed,
-		       t.verification_status, t.time_spent_minutes, t.context_data
+		       t.verification_status, t.time_spent_minutes, t.context_data, t.version
 		FROM tasks t
 		INNER JOIN features f ON t.feature_id = f.id
 		INNER JOIN epics e ON f.epic_id = e.id
-		WHERE e.key = ? AND t.status = ?
+		WHERE e.key = ? AND t.status = ? AND t.deleted_at IS NULL
 		ORDER BY t.blocked_at DESC NULLS LAST, t.priority ASC, t.created_at ASC, t.key ASC
 	`
 
@@ -487,9 +526,9 @@ func (r *TaskRepository) FilterByStatus(ctx context.Context, status models.TaskS
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE status = ?
+		WHERE status = ? AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, priority ASC, created_at ASC, key ASC
 	`
 
@@ -503,9 +542,9 @@ func (r *TaskRepository) FilterByAgentType(ctx context.Context, agentType string
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE agent_type = ?
+		WHERE agent_type = ? AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, priority ASC, created_at ASC, key ASC
 	`
 
@@ -514,60 +553,117 @@ func (r *TaskRepository) FilterByAgentType(ctx context.Context, agentType string
 
 // FilterCombined retrieves tasks with multiple filter criteria
 func (r *TaskRepository) FilterCombined(ctx context.Context, status *models.TaskStatus, epicKey *string, agentType *string, maxPriority *int) ([]*models.Task, error) {
-	query := `
-		SELECT t.id, t.feature_id, t.key, t.title, t.slug, t.description, t.status, t.agent_type, t.priority,
-		       t.depends_on, t.assigned_agent, t.file_path, t.blocked_reason, t.execution_order,
-		       t.created_at, t.started_at, t.completed_at, t.blocked_at, t.updated_at,
-		       t.completed_by, t.completion_notes, t.files_changed, t.tests_passed,
-		       t.verification_status, t.time_spent_minutes, t.context_data
-		FROM tasks t
-	`
+	return r.ListFiltered(ctx, TaskListFilter{
+		Status:      status,
+		EpicKey:     epicKey,
+		AgentType:   agentType,
+		MaxPriority: maxPriority,
+	}, ListOptions{})
+}
+
+// TaskListFilter selects the tasks ListFiltered returns. Zero values don't filter.
+type TaskListFilter struct {
+	Status        *models.TaskStatus
+	EpicKey       *string
+	FeatureID     *int64
+	AgentType     *string
+	MaxPriority   *int
+	Blocked       bool     // Only blocked tasks
+	HasRejections bool     // Only tasks that were rejected at least once
+	HideCompleted bool     // Leave out completed tasks
+	Labels        []string // Only tasks carrying every label
+}
 
+// TaskSortFields are the fields ListFiltered can sort tasks by
+var TaskSortFields = map[string]string{
+	"key":             "t.key",
+	"title":           "t.title",
+	"status":          "t.status",
+	"priority":        "t.priority",
+	"execution_order": "t.execution_order",
+	"created_at":      "t.created_at",
+	"updated_at":      "t.updated_at",
+}
+
+// taskListWhere returns the FROM and WHERE clauses selecting filter's tasks
+func taskListWhere(filter TaskListFilter) (string, []interface{}) {
+	from := "\n\t\tFROM tasks t"
 	args := []interface{}{}
-	conditions := []string{}
+	conditions := []string{"t.deleted_at IS NULL"}
 
-	if epicKey != nil {
-		query += `
+	if filter.EpicKey != nil {
+		from += `
 		INNER JOIN features f ON t.feature_id = f.id
-		INNER JOIN epics e ON f.epic_id = e.id
-		`
+		INNER JOIN epics e ON f.epic_id = e.id`
 		conditions = append(conditions, "e.key = ?")
-		args = append(args, *epicKey)
+		args = append(args, *filter.EpicKey)
 	}
-
-	if status != nil {
+	if filter.FeatureID != nil {
+		conditions = append(conditions, "t.feature_id = ?")
+		args = append(args, *filter.FeatureID)
+	}
+	if filter.Status != nil {
 		conditions = append(conditions, "t.status = ?")
-		args = append(args, *status)
+		args = append(args, *filter.Status)
 	}
-
-	if agentType != nil {
+	if filter.AgentType != nil {
 		conditions = append(conditions, "t.agent_type = ?")
-		args = append(args, *agentType)
+		args = append(args, *filter.AgentType)
 	}
-
-	if maxPriority != nil {
+	if filter.MaxPriority != nil {
 		conditions = append(conditions, "t.priority <= ?")
-		args = append(args, *maxPriority)
+		args = append(args, *filter.MaxPriority)
 	}
-
-	if len(conditions) > 0 {
-		query += " WHERE "
-		for i, cond := range conditions {
-			if i > 0 {
-				query += " AND "
-			}
-			query += cond
-		}
+	if filter.Blocked {
+		conditions = append(conditions, "t.status = ?")
+		args = append(args, models.TaskStatusBlocked)
+	}
+	if filter.HasRejections {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM task_notes tn WHERE tn.task_id = t.id AND tn.note_type = 'rejection')")
+	}
+	if filter.HideCompleted {
+		conditions = append(conditions, "t.status != ?")
+		args = append(args, models.TaskStatusCompleted)
+	}
+	if len(filter.Labels) > 0 {
+		condition, labelArgs := TaskLabelFilterSQL("t", filter.Labels)
+		conditions = append(conditions, condition)
+		args = append(args, labelArgs...)
 	}
 
-	query += " ORDER BY t.execution_order NULLS LAST, t.priority ASC, t.created_at ASC, t.key ASC"
+	return from + "\n\t\tWHERE " + strings.Join(conditions, " AND "), args
+}
 
-	tasks, err := r.queryTasks(ctx, query, args...)
+// ListFiltered retrieves a page of the tasks matching filter, sorted by one of
+// TaskSortFields (execution order, then priority, by default)
+func (r *TaskRepository) ListFiltered(ctx context.Context, filter TaskListFilter, opts ListOptions) ([]*models.Task, error) {
+	where, args := taskListWhere(filter)
+	order, err := opts.orderSQL(TaskSortFields,
+		"t.execution_order NULLS LAST, t.priority ASC, t.created_at ASC, t.key ASC", "t.key ASC")
 	if err != nil {
 		return nil, err
 	}
+	page, pageArgs := opts.pageSQL()
 
-	return tasks, nil
+	query := `
+		SELECT t.id, t.feature_id, t.key, t.title, t.slug, t.description, t.status, t.agent_type, t.priority,
+		       t.depends_on, t.assigned_agent, t.file_path, t.blocked_reason, t.execution_order,
+		       t.created_at, t.started_at, t.completed_at, t.blocked_at, t.updated_at,
+		       t.completed_by, t.completion_notes, t.files_changed, t.tests_passed,
+		       t.verification_status, t.time_spent_minutes, t.context_data, t.version` + where + order + page
+
+	return r.queryTasks(ctx, query, append(args, pageArgs...)...)
+}
+
+// CountFiltered returns how many tasks match filter, ignoring paging
+func (r *TaskRepository) CountFiltered(ctx context.Context, filter TaskListFilter) (int, error) {
+	where, args := taskListWhere(filter)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	return count, nil
 }
 
 // List retrieves all tasks
@@ -577,8 +673,9 @@ func (r *TaskRepository) List(ctx context.Context) ([]*models.Task, error) {
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
+		WHERE deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, priority ASC, created_at ASC, key ASC
 	`
 
@@ -590,7 +687,9 @@ func (r *TaskRepository) List(ctx context.Context) ([]*models.Task, error) {
 	return tasks, nil
 }
 
-// Update updates an existing task
+// Update updates an existing task. If task.Version is set, the update fails
+// with a *VersionConflictError when the task was changed since it was read;
+// on success task.Version is set to the new version.
 func (r *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 	if err := task.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -624,10 +723,18 @@ func (r *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Refuse to overwrite changes made since the task was read
+	if err := lockTasksForWrite(ctx, tx.Tx); err != nil {
+		return err
+	}
+	if err := checkTaskVersion(ctx, tx.Tx, task.ID, task.Version); err != nil {
+		return err
+	}
+
 	// If cascade is needed, get all tasks BEFORE updating, then resequence ALL tasks
 	if needsCascade {
 		// Get all tasks in the same feature (before any updates)
-		allTasks, err := r.listByFeatureInTx(ctx, tx, task.FeatureID)
+		allTasks, err := r.listByFeatureInTx(ctx, tx.Tx, task.FeatureID)
 		if err != nil {
 			return fmt.Errorf("failed to list tasks for cascade: %w", err)
 		}
@@ -669,7 +776,7 @@ func (r *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 			task.Priority,
 			task.DependsOn,
 			task.AssignedAgent,
-			task.FilePath,
+			r.db.projectPathPtr(task.FilePath),
 			task.BlockedReason,
 			task.ContextData,
 			task.ID,
@@ -702,7 +809,7 @@ func (r *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 			task.Priority,
 			task.DependsOn,
 			task.AssignedAgent,
-			task.FilePath,
+			r.db.projectPathPtr(task.FilePath),
 			task.BlockedReason,
 			task.ExecutionOrder,
 			task.ContextData,
@@ -721,11 +828,17 @@ func (r *TaskRepository) Update(ctx context.Context, task *models.Task) error {
 		}
 	}
 
+	version, err := taskVersionInTx(ctx, tx.Tx, task.ID)
+	if err != nil {
+		return err
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	task.Version = version
 	return nil
 }
 
@@ -734,9 +847,9 @@ func (r *TaskRepository) listByFeatureInTx(ctx context.Context, tx *sql.Tx, feat
 	query := `
 		SELECT id, feature_id, key, title, slug, description, status, agent_type, priority,
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
-		       created_at, updated_at, context_data
+		       created_at, updated_at, context_data, version
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 		ORDER BY execution_order ASC
 	`
 
@@ -767,6 +880,7 @@ func (r *TaskRepository) listByFeatureInTx(ctx context.Context, tx *sql.Tx, feat
 			&task.CreatedAt,
 			&task.UpdatedAt,
 			&task.ContextData,
+			&task.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
@@ -781,6 +895,88 @@ func (r *TaskRepository) listByFeatureInTx(ctx context.Context, tx *sql.Tx, feat
 	return tasks, nil
 }
 
+// ReorderFeatureTasks sets execution_order to 1..n for a feature's tasks in
+// the given order. taskIDs must contain every task in the feature exactly once;
+// otherwise nothing is changed.
+func (r *TaskRepository) ReorderFeatureTasks(ctx context.Context, featureID int64, taskIDs []int64) error {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	tasks, err := r.listByFeatureInTx(ctx, tx.Tx, featureID)
+	if err != nil {
+		return err
+	}
+
+	inFeature := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		inFeature[task.ID] = true
+	}
+	seen := make(map[int64]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		if !inFeature[id] {
+			return fmt.Errorf("task %d does not belong to feature %d", id, featureID)
+		}
+		if seen[id] {
+			return fmt.Errorf("task %d is listed more than once", id)
+		}
+		seen[id] = true
+	}
+	if len(taskIDs) != len(tasks) {
+		return fmt.Errorf("order lists %d of %d tasks in the feature; every task must be listed", len(taskIDs), len(tasks))
+	}
+
+	for i, id := range taskIDs {
+		if _, err := tx.ExecContext(ctx, "UPDATE tasks SET execution_order = ? WHERE id = ?", i+1, id); err != nil {
+			return fmt.Errorf("failed to update order for task %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetFeaturePriorities sets the priority of some of a feature's tasks in one
+// transaction. Every task must belong to the feature and every priority must
+// be 1-10; otherwise nothing is changed.
+func (r *TaskRepository) SetFeaturePriorities(ctx context.Context, featureID int64, priorities map[int64]int) error {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	tasks, err := r.listByFeatureInTx(ctx, tx.Tx, featureID)
+	if err != nil {
+		return err
+	}
+	inFeature := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		inFeature[task.ID] = true
+	}
+
+	for id, priority := range priorities {
+		if !inFeature[id] {
+			return fmt.Errorf("task %d does not belong to feature %d", id, featureID)
+		}
+		if priority < 1 || priority > 10 {
+			return fmt.Errorf("priority %d for task %d must be between 1 and 10", priority, id)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE tasks SET priority = ? WHERE id = ?", priority, id); err != nil {
+			return fmt.Errorf("failed to update priority for task %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // isValidStatusEnum checks if a status is valid according to the workflow configuration
 func (r *TaskRepository) isValidStatusEnum(status models.TaskStatus) bool {
 	// Check if status exists in workflow config
@@ -830,6 +1026,14 @@ func (r *TaskRepository) UpdateStatus(ctx context.Context, taskID int64, newStat
 
 // UpdateStatusForced atomically updates task status with optional validation bypass
 func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, newStatus models.TaskStatus, agent *string, notes *string, rejectionReason *string, documentPath *string, force bool) error {
+	return r.UpdateStatusIfVersion(ctx, taskID, 0, newStatus, agent, notes, rejectionReason, documentPath, force)
+}
+
+// UpdateStatusIfVersion updates task status like UpdateStatusForced, but fails
+// with a *VersionConflictError if the task's version is no longer
+// expectedVersion (0 skips the check). The status write is always guarded
+// against changes made between reading and writing the task.
+func (r *TaskRepository) UpdateStatusIfVersion(ctx context.Context, taskID int64, expectedVersion int, newStatus models.TaskStatus, agent *string, notes *string, rejectionReason *string, documentPath *string, force bool) error {
 	// Validate status is valid enum
 	if !r.isValidStatusEnum(newStatus) {
 		return fmt.Errorf("invalid status: %s", newStatus)
@@ -840,24 +1044,31 @@ func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, n
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
+	if err := lockTasksForWrite(ctx, tx.Tx); err != nil {
+		return err
+	}
 
 	// Get current task state
 	var currentStatus string
 	var startedAt, completedAt, blockedAt sql.NullTime
-	err = tx.QueryRowContext(ctx, "SELECT status, started_at, completed_at, blocked_at FROM tasks WHERE id = ?", taskID).
-		Scan(&currentStatus, &startedAt, &completedAt, &blockedAt)
+	var version int
+	err = tx.QueryRowContext(ctx, "SELECT status, started_at, completed_at, blocked_at, version FROM tasks WHERE id = ?", taskID).
+		Scan(&currentStatus, &startedAt, &completedAt, &blockedAt, &version)
 	if err == sql.ErrNoRows {
 		return fmt.Errorf("task not found with id %d", taskID)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get current task status: %w", err)
 	}
+	if expectedVersion != 0 && version != expectedVersion {
+		return &VersionConflictError{TaskID: taskID, Expected: expectedVersion, Actual: version}
+	}
 
 	// Validate transition if not forcing
 	currentTaskStatus := models.TaskStatus(currentStatus)
 	if force {
 		// Log warning when force is used
-		fmt.Printf("WARNING: Forced status update from %s to %s (taskID=%d)\n", currentStatus, newStatus, taskID)
+		r.db.Logger().WarnContext(ctx, "forced status update", "from", currentStatus, "to", newStatus, "task_id", taskID)
 	} else {
 		// Check if transition is valid using workflow config
 		if !r.isValidTransition(currentTaskStatus, newStatus) {
@@ -887,6 +1098,13 @@ func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, n
 		}
 	}
 
+	// Enforce the agent type's WIP limit under the write lock
+	if newStatus == models.TaskStatusInProgress && currentTaskStatus != models.TaskStatusInProgress {
+		if err := r.checkWIPLimitTx(ctx, tx.Tx, taskID); err != nil {
+			return err
+		}
+	}
+
 	// Update status and timestamps
 	now := time.Now()
 	query := "UPDATE tasks SET status = ?"
@@ -904,20 +1122,33 @@ func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, n
 		args = append(args, now)
 	}
 
-	query += " WHERE id = ?"
-	args = append(args, taskID)
+	query += " WHERE id = ? AND version = ?"
+	args = append(args, taskID, version)
 
-	_, err = tx.ExecContext(ctx, query, args...)
+	result, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update task status: %w", err)
 	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		actual, _ := taskVersionInTx(ctx, tx.Tx, taskID)
+		return &VersionConflictError{TaskID: taskID, Expected: version, Actual: actual}
+	}
+
+	// Promote a draft feature and epic when work starts
+	if r.workflow != nil && r.workflow.ActivatesParents(string(newStatus)) {
+		if err := activateParentsTx(ctx, tx.Tx, taskID); err != nil {
+			return err
+		}
+	}
 
 	// Create history record with rejection reason support
 	historyQuery := `
 		INSERT INTO task_history (task_id, old_status, new_status, agent, notes, rejection_reason, forced)
 		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
-	result, err := tx.ExecContext(ctx, historyQuery, taskID, currentStatus, newStatus, agent, notes, rejectionReason, force)
+	result, err = tx.ExecContext(ctx, historyQuery, taskID, currentStatus, newStatus, agent, notes, rejectionReason, force)
 	if err != nil {
 		return fmt.Errorf("failed to create history record: %w", err)
 	}
@@ -935,7 +1166,7 @@ func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, n
 			isBackward, checkErr = r.workflow.IsBackwardTransition(currentStatus, string(newStatus))
 			if checkErr != nil {
 				// Log but don't fail - the transition already succeeded
-				fmt.Printf("WARNING: Failed to check backward transition for rejection note: %v\n", checkErr)
+				r.db.Logger().WarnContext(ctx, "failed to check backward transition for rejection note", "error", checkErr)
 			}
 		}
 
@@ -947,7 +1178,7 @@ func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, n
 			}
 
 			_, err := noteRepo.CreateRejectionNoteWithTx(
-				ctx, tx, taskID, historyID,
+				ctx, tx.Tx, taskID, historyID,
 				currentStatus, string(newStatus),
 				*rejectionReason, rejectedBy, documentPath,
 			)
@@ -965,6 +1196,33 @@ func (r *TaskRepository) UpdateStatusForced(ctx context.Context, taskID int64, n
 	return nil
 }
 
+// activateParentsTx promotes a task's feature and epic from draft to active within
+// the caller's transaction. Features with status_override set are left alone.
+func activateParentsTx(ctx context.Context, tx *sql.Tx, taskID int64) error {
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE features SET status = ?
+		WHERE id = (SELECT feature_id FROM tasks WHERE id = ?)
+		  AND status = ?
+		  AND COALESCE(status_override, 0) = 0
+	`, models.FeatureStatusActive, taskID, models.FeatureStatusDraft); err != nil {
+		return fmt.Errorf("failed to activate feature: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE epics SET status = ?
+		WHERE id = (
+			SELECT f.epic_id FROM features f
+			JOIN tasks t ON t.feature_id = f.id
+			WHERE t.id = ?
+		)
+		  AND status = ?
+	`, models.EpicStatusActive, taskID, models.EpicStatusDraft); err != nil {
+		return fmt.Errorf("failed to activate epic: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateStatusWithAction updates a task's status and returns the updated task with orchestrator action
 // This method combines status update with retrieval of orchestrator action from workflow config
 // Returns:
@@ -994,7 +1252,7 @@ func (r *TaskRepository) UpdateStatusWithAction(ctx context.Context, taskKey str
 	action, err := r.getOrchestratorAction(ctx, updatedTask, newStatus)
 	if err != nil {
 		// Log warning but don't fail - action is optional
-		fmt.Printf("WARNING: Failed to get orchestrator action for status %s: %v\n", newStatus, err)
+		r.db.Logger().WarnContext(ctx, "failed to get orchestrator action", "status", newStatus, "error", err)
 		action = nil
 	}
 
@@ -1061,7 +1319,7 @@ func (r *TaskRepository) BlockTaskForced(ctx context.Context, taskID int64, reas
 	// Validate transition if not forcing
 	currentTaskStatus := models.TaskStatus(currentStatus)
 	if force {
-		fmt.Printf("WARNING: Forced block from %s status (taskID=%d)\n", currentStatus, taskID)
+		r.db.Logger().WarnContext(ctx, "forced block", "from", currentStatus, "task_id", taskID)
 	} else {
 		// Validate transition using workflow config
 		if !r.isValidTransition(currentTaskStatus, models.TaskStatusBlocked) {
@@ -1125,7 +1383,7 @@ func (r *TaskRepository) UnblockTaskForced(ctx context.Context, taskID int64, ag
 	// Validate transition if not forcing
 	currentTaskStatus := models.TaskStatus(currentStatus)
 	if force {
-		fmt.Printf("WARNING: Forced unblock from %s status (taskID=%d)\n", currentStatus, taskID)
+		r.db.Logger().WarnContext(ctx, "forced unblock", "from", currentStatus, "task_id", taskID)
 	} else {
 		// Validate transition using workflow config
 		if !r.isValidTransition(currentTaskStatus, models.TaskStatusTodo) {
@@ -1179,6 +1437,30 @@ func (r *TaskRepository) ReopenTaskForced(ctx context.Context, taskID int64, age
 	return r.UpdateStatusForced(ctx, taskID, models.TaskStatusInProgress, agent, notes, rejectionReason, documentPath, force)
 }
 
+// UpdateDependsOn replaces a task's depends_on JSON array (nil clears it)
+func (r *TaskRepository) UpdateDependsOn(ctx context.Context, taskID int64, dependsOn *string) error {
+	if dependsOn != nil {
+		if err := models.ValidateDependsOn(*dependsOn); err != nil {
+			return err
+		}
+	}
+
+	result, err := r.db.ExecContext(ctx, "UPDATE tasks SET depends_on = ? WHERE id = ?", dependsOn, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to update depends_on: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("task not found with id %d", taskID)
+	}
+
+	return nil
+}
+
 // Delete deletes a task (and its history via CASCADE)
 func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
 	query := "DELETE FROM tasks WHERE id = ?"
@@ -1206,7 +1488,7 @@ func (r *TaskRepository) GetStatusBreakdown(ctx context.Context, featureID int64
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 		GROUP BY status
 	`
 
@@ -1279,7 +1561,7 @@ func (r *TaskRepository) GetStatusBreakdownMap(ctx context.Context, featureID in
 	query := `
 		SELECT status, COUNT(*) as count
 		FROM tasks
-		WHERE feature_id = ?
+		WHERE feature_id = ? AND deleted_at IS NULL
 		GROUP BY status
 	`
 
@@ -1335,7 +1617,7 @@ func (r *TaskRepository) GetStatusBreakdownMapBatch(ctx context.Context, feature
 	query := fmt.Sprintf(`
 		SELECT feature_id, status, COUNT(*) as count
 		FROM tasks
-		WHERE feature_id IN (%s)
+		WHERE feature_id IN (%s) AND deleted_at IS NULL
 		GROUP BY feature_id, status
 	`, strings.Join(placeholders, ","))
 
@@ -1423,7 +1705,7 @@ func (r *TaskRepository) getStatusMetadata(status string) config.StatusMetadata
 
 // GetTaskCountForFeature returns the total number of tasks for a given feature
 func (r *TaskRepository) GetTaskCountForFeature(ctx context.Context, featureID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM tasks WHERE feature_id = ?`
+	query := `SELECT COUNT(*) FROM tasks WHERE feature_id = ? AND deleted_at IS NULL`
 
 	var count int
 	err := r.db.QueryRowContext(ctx, query, featureID).Scan(&count)
@@ -1483,7 +1765,7 @@ func (r *TaskRepository) BulkCreate(ctx context.Context, tasks []*models.Task) (
 			task.Priority,
 			task.DependsOn,
 			task.AssignedAgent,
-			task.FilePath,
+			r.db.projectPathPtr(task.FilePath),
 			task.BlockedReason,
 		)
 		if err != nil {
@@ -1520,9 +1802,9 @@ func (r *TaskRepository) GetByKeys(ctx context.Context, keys []string) (map[stri
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE key IN (?` + strings.Repeat(", ?", len(keys)-1) + `)`
+		WHERE deleted_at IS NULL AND key IN (?` + strings.Repeat(", ?", len(keys)-1) + `)`
 
 	// Convert keys to []interface{} for query
 	args := make([]interface{}, len(keys))
@@ -1567,6 +1849,7 @@ func (r *TaskRepository) GetByKeys(ctx context.Context, keys []string) (map[stri
 			&task.VerificationStatus,
 			&task.TimeSpentMinutes,
 			&task.ContextData,
+			&task.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
@@ -1597,7 +1880,7 @@ func (r *TaskRepository) UpdateMetadata(ctx context.Context, task *models.Task)
 	result, err := r.db.ExecContext(ctx, query,
 		task.Title,
 		task.Description,
-		task.FilePath,
+		r.db.projectPathPtr(task.FilePath),
 		task.ID,
 	)
 	if err != nil {
@@ -1615,21 +1898,53 @@ func (r *TaskRepository) UpdateMetadata(ctx context.Context, task *models.Task)
 	return nil
 }
 
+// taskKeyMaxQuery returns the highest task number in use in a feature. Trashed
+// and archived tasks count, since they still hold their keys, and so do the old
+// keys of resequenced tasks, which still resolve to them.
+const taskKeyMaxQuery = `
+	SELECT MAX(
+		(SELECT COALESCE(MAX(CAST(SUBSTR(key, -3) AS INTEGER)), 0)
+		 FROM tasks
+		 WHERE feature_id = ? AND (key GLOB 'T-E*-F*-[0-9][0-9][0-9]' OR key GLOB 'T-BKL-[0-9][0-9][0-9]')),
+		(SELECT COALESCE(MAX(CAST(SUBSTR(old_key, -3) AS INTEGER)), 0)
+		 FROM task_key_aliases
+		 WHERE old_key GLOB 'T-' || (SELECT key FROM features WHERE id = ?) || '-[0-9][0-9][0-9]')
+	)
+`
+
+// NextKeyNumber reserves and returns the next task number for a feature.
+// Safe to call concurrently: each call returns a distinct number.
+func (r *TaskRepository) NextKeyNumber(ctx context.Context, featureID int64) (int, error) {
+	return reserveKeySequence(ctx, r.db, fmt.Sprintf("task:%d", featureID), taskKeyMaxQuery, featureID, featureID)
+}
+
+// PeekNextKeyNumber returns the number NextKeyNumber would return next for a
+// feature without reserving it
+func (r *TaskRepository) PeekNextKeyNumber(ctx context.Context, featureID int64) (int, error) {
+	return peekKeySequence(ctx, r.db, fmt.Sprintf("task:%d", featureID), taskKeyMaxQuery, featureID, featureID)
+}
+
 // GetMaxSequenceForFeature gets the maximum task sequence number for a feature
 // Returns 0 if no tasks exist for the feature
 func (r *TaskRepository) GetMaxSequenceForFeature(ctx context.Context, featureKey string) (int, error) {
 	// Task keys are in format: T-E##-F##-###
-	// We need to extract the sequence number (###) from the key
-	// Use SQL to parse the key and find the maximum sequence
+	// Only keys ending in exactly three digits are counted, so a key that
+	// doesn't follow the format can't throw off the sequence. Old keys of
+	// resequenced tasks count too, so they are never handed out again.
 	query := `
-		SELECT COALESCE(MAX(CAST(SUBSTR(t.key, -3) AS INTEGER)), 0) as max_sequence
-		FROM tasks t
-		INNER JOIN features f ON t.feature_id = f.id
-		WHERE f.key = ? AND t.key LIKE 'T-' || ? || '-%'
+		SELECT MAX(
+			(SELECT COALESCE(MAX(CAST(SUBSTR(t.key, -3) AS INTEGER)), 0)
+			 FROM tasks t
+			 INNER JOIN features f ON t.feature_id = f.id
+			 WHERE f.key = ? AND t.key GLOB 'T-' || ? || '-[0-9][0-9][0-9]'),
+			(SELECT COALESCE(MAX(CAST(SUBSTR(old_key, -3) AS INTEGER)), 0)
+			 FROM task_key_aliases
+			 WHERE old_key GLOB 'T-' || ? || '-[0-9][0-9][0-9]')
+		) as max_sequence
 	`
 
 	var maxSequence int
-	err := r.db.QueryRowContext(ctx, query, featureKey, featureKey).Scan(&maxSequence)
+	err := r.db.QueryRowContext(ctx, query, featureKey, featureKey, featureKey).Scan(&maxSequence)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get max sequence for feature %s: %w", featureKey, err)
 	}
@@ -1705,6 +2020,7 @@ func (r *TaskRepository) queryTasks(ctx context.Context, query string, args ...i
 			&task.VerificationStatus,
 			&task.TimeSpentMinutes,
 			&task.ContextData,
+			&task.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
@@ -1820,9 +2136,9 @@ func (r *TaskRepository) FindByFileChanged(ctx context.Context, filePath string)
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE files_changed IS NOT NULL
+		WHERE files_changed IS NOT NULL AND deleted_at IS NULL
 		  AND files_changed LIKE ?
 		ORDER BY completed_at DESC NULLS LAST
 	`
@@ -1840,9 +2156,9 @@ func (r *TaskRepository) GetUnverifiedTasks(ctx context.Context) ([]*models.Task
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE verification_status != 'verified'
+		WHERE verification_status != 'verified' AND deleted_at IS NULL
 		  AND status IN ('ready_for_review', 'completed')
 		ORDER BY completed_at DESC NULLS LAST
 	`
@@ -1878,9 +2194,9 @@ func (r *TaskRepository) FilterByMetadataAgentType(ctx context.Context, agentTyp
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE status IN (%s)
+		WHERE status IN (%s) AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, priority ASC, created_at ASC, key ASC
 	`, strings.Join(placeholders, ", "))
 
@@ -1915,9 +2231,9 @@ func (r *TaskRepository) FilterByMetadataPhase(ctx context.Context, phase string
 		       depends_on, assigned_agent, file_path, blocked_reason, execution_order,
 		       created_at, started_at, completed_at, blocked_at, updated_at,
 		       completed_by, completion_notes, files_changed, tests_passed,
-		       verification_status, time_spent_minutes, context_data
+		       verification_status, time_spent_minutes, context_data, version
 		FROM tasks
-		WHERE status IN (%s)
+		WHERE status IN (%s) AND deleted_at IS NULL
 		ORDER BY execution_order NULLS LAST, priority ASC, created_at ASC, key ASC
 	`, strings.Join(placeholders, ", "))
 
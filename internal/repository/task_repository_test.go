@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -552,3 +554,19 @@ func TestTaskRepository_UpdateStatusForced_StoresRejectionReason(t *testing.T) {
 	require.NotNil(t, lastEntry.Notes, "Notes should be stored")
 	require.Equal(t, notes, *lastEntry.Notes, "Notes should be stored")
 }
+
+// TestTaskRepository_UpdateStatusForced_LogsWarning verifies forced transitions
+// are reported through the DB's logger rather than printed, since stdout may
+// carry a protocol (LSP, MCP)
+func TestTaskRepository_UpdateStatusForced_LogsWarning(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+
+	var logs bytes.Buffer
+	db.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	taskID := createTestTask(t, db)
+	require.NoError(t, NewTaskRepository(db).UpdateStatusForced(ctx, taskID, models.TaskStatusCompleted, nil, nil, nil, nil, true))
+	assert.Contains(t, logs.String(), "forced status update")
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ListByStatuses returns the tasks in any of the given statuses, optionally
+// only those under epicKey and featureKey, ordered by key
+func (r *TaskRepository) ListByStatuses(ctx context.Context, statuses []string, epicKey, featureKey string) ([]*models.Task, error) {
+	if len(statuses) == 0 {
+		return []*models.Task{}, nil
+	}
+
+	query := `
+		SELECT t.id, t.feature_id, t.key, t.title, t.slug, t.description, t.status, t.agent_type, t.priority,
+		       t.depends_on, t.assigned_agent, t.file_path, t.blocked_reason, t.execution_order,
+		       t.created_at, t.started_at, t.completed_at, t.blocked_at, t.updated_at,
+		       t.completed_by, t.completion_notes, t.files_changed, t.tests_passed,
+		       t.verification_status, t.time_spent_minutes, t.context_data, t.version
+		FROM tasks t
+		JOIN features f ON t.feature_id = f.id
+		JOIN epics e ON f.epic_id = e.id
+		WHERE t.status IN (` + placeholders(len(statuses)) + `) AND t.deleted_at IS NULL`
+	args := stringArgs(statuses)
+	if epicKey != "" {
+		query += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+	if featureKey != "" {
+		query += " AND f.key = ?"
+		args = append(args, featureKey)
+	}
+	query += " ORDER BY t.key"
+
+	tasks, err := r.queryTasks(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+	return tasks, nil
+}
+
+// StatusEnteredAt returns when each task last moved into the status it is in
+// now, according to its history. Tasks whose history doesn't record the move
+// (e.g. created in that status) are left out.
+func (r *TaskRepository) StatusEnteredAt(ctx context.Context, taskIDs []int64) (map[int64]time.Time, error) {
+	entered := make(map[int64]time.Time)
+	if len(taskIDs) == 0 {
+		return entered, nil
+	}
+
+	args := make([]interface{}, len(taskIDs))
+	for i, id := range taskIDs {
+		args[i] = id
+	}
+	query := `
+		SELECT t.id, datetime(MAX(th.timestamp))
+		FROM tasks t
+		JOIN task_history th ON th.task_id = t.id AND th.new_status = t.status
+		WHERE t.id IN (` + placeholders(len(taskIDs)) + `)
+		GROUP BY t.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int64
+		var at sql.NullString
+		if err := rows.Scan(&taskID, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan status history: %w", err)
+		}
+		if !at.Valid {
+			continue
+		}
+		parsed, err := time.Parse("2006-01-02 15:04:05", at.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse status change time: %w", err)
+		}
+		entered[taskID] = parsed
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status history: %w", err)
+	}
+	return entered, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrVersionConflict is returned (wrapped in a *VersionConflictError) when a
+// task was changed by someone else since the caller read it
+var ErrVersionConflict = errors.New("task was modified concurrently")
+
+// VersionConflictError reports an optimistic locking failure on a task. The
+// caller should re-read the task and reapply its change, or give up.
+type VersionConflictError struct {
+	TaskID   int64
+	Expected int // Version the caller read
+	Actual   int // Version currently stored
+}
+
+// Error implements the error interface
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("task %d was modified concurrently (expected version %d, found %d)", e.TaskID, e.Expected, e.Actual)
+}
+
+// Unwrap lets errors.Is match ErrVersionConflict
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// lockTasksForWrite takes the database write lock at the start of a transaction
+// that reads a task's version before writing it. Without it, SQLite fails the
+// later write with SQLITE_BUSY, without honoring busy_timeout, whenever another
+// agent commits in between. The statement matches no rows, so no trigger fires.
+func lockTasksForWrite(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE tasks SET version = version WHERE 0"); err != nil {
+		return fmt.Errorf("failed to lock tasks for writing: %w", err)
+	}
+	return nil
+}
+
+// checkTaskVersion returns a *VersionConflictError if the task's stored version
+// differs from expected. An expected version of 0 skips the check.
+func checkTaskVersion(ctx context.Context, tx *sql.Tx, taskID int64, expected int) error {
+	if expected == 0 {
+		return nil
+	}
+
+	var actual int
+	err := tx.QueryRowContext(ctx, "SELECT version FROM tasks WHERE id = ? AND deleted_at IS NULL", taskID).Scan(&actual)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task not found with id %d", taskID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get task version: %w", err)
+	}
+	if actual != expected {
+		return &VersionConflictError{TaskID: taskID, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// taskVersionInTx returns the task's stored version
+func taskVersionInTx(ctx context.Context, tx *sql.Tx, taskID int64) (int, error) {
+	var version int
+	if err := tx.QueryRowContext(ctx, "SELECT version FROM tasks WHERE id = ?", taskID).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get task version: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRepository_UpdateDetectsConflict(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskRepository(db)
+
+	first, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	second, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	require.Positive(t, first.Version)
+
+	first.Title = "First agent's title"
+	require.NoError(t, repo.Update(ctx, first))
+	assert.Equal(t, second.Version+1, first.Version, "Update returns the new version")
+
+	// The second agent read the task before the first agent's write
+	second.Priority = 1
+	err = repo.Update(ctx, second)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrVersionConflict))
+	var conflict *VersionConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, second.Version, conflict.Expected)
+	assert.Equal(t, first.Version, conflict.Actual)
+
+	stored, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, "First agent's title", stored.Title, "the conflicting write was not applied")
+
+	// Re-reading and reapplying succeeds
+	stored.Priority = 1
+	require.NoError(t, repo.Update(ctx, stored))
+
+	// A task without a version (e.g. built by hand) is written unconditionally
+	stored.Version = 0
+	stored.Title = "Unversioned"
+	assert.NoError(t, repo.Update(ctx, stored))
+}
+
+func TestTaskRepository_EveryWriteIncrementsVersion(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskRepository(db)
+
+	task, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	dependsOn := "[]"
+	require.NoError(t, repo.UpdateDependsOn(ctx, taskID, &dependsOn))
+
+	err = repo.Update(ctx, task)
+	assert.True(t, errors.Is(err, ErrVersionConflict), "writes through other methods bump the version too")
+}
+
+func TestTaskRepository_UpdateStatusIfVersion(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	repo := NewTaskRepository(db)
+
+	task, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.UpdateStatusIfVersion(ctx, taskID, task.Version, models.TaskStatusInProgress, nil, nil, nil, nil, true))
+
+	// A second agent acting on the same read loses the race
+	err = repo.UpdateStatusIfVersion(ctx, taskID, task.Version, models.TaskStatusInProgress, nil, nil, nil, nil, true)
+	assert.True(t, errors.Is(err, ErrVersionConflict))
+
+	updated, err := repo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusInProgress, updated.Status)
+	assert.Greater(t, updated.Version, task.Version)
+}
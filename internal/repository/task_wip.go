@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// ErrWIPLimit is returned (wrapped in a *WIPLimitError) when moving a task into
+// in_progress would take its agent type past its WIP limit
+var ErrWIPLimit = errors.New("WIP limit reached")
+
+// WIPLimitError reports the agent type whose WIP limit stopped a task from
+// starting
+type WIPLimitError struct {
+	AgentType  string
+	InProgress int
+	Limit      int
+}
+
+// Error implements the error interface
+func (e *WIPLimitError) Error() string {
+	return fmt.Sprintf("%s already has %d of %d tasks in progress", e.AgentType, e.InProgress, e.Limit)
+}
+
+// Unwrap lets errors.Is match ErrWIPLimit
+func (e *WIPLimitError) Unwrap() error {
+	return ErrWIPLimit
+}
+
+// SetWIPLimits makes status changes into in_progress fail with a
+// *WIPLimitError while the task's agent type already has its limit of tasks in
+// progress. The count is taken in the same transaction as the status change,
+// so two agents can't both take the last slot. nil removes the limits.
+func (r *TaskRepository) SetWIPLimits(limits map[string]int) {
+	r.wipLimits = limits
+}
+
+// checkWIPLimitTx returns a *WIPLimitError if the task's agent type is at its
+// WIP limit. The caller must hold the write lock (see lockTasksForWrite) so the
+// count can't change before its status update.
+func (r *TaskRepository) checkWIPLimitTx(ctx context.Context, tx *sql.Tx, taskID int64) error {
+	if len(r.wipLimits) == 0 {
+		return nil
+	}
+
+	var agentType sql.NullString
+	err := tx.QueryRowContext(ctx, "SELECT agent_type FROM tasks WHERE id = ?", taskID).Scan(&agentType)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get task agent type: %w", err)
+	}
+	limit, ok := r.wipLimits[agentType.String]
+	if !agentType.Valid || !ok {
+		return nil
+	}
+
+	var inProgress int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM tasks
+		WHERE status = ? AND deleted_at IS NULL AND agent_type = ? AND id != ?
+	`, models.TaskStatusInProgress, agentType.String, taskID).Scan(&inProgress); err != nil {
+		return fmt.Errorf("failed to count in_progress tasks: %w", err)
+	}
+	if inProgress >= limit {
+		return &WIPLimitError{AgentType: agentType.String, InProgress: inProgress, Limit: limit}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// TrashRepository handles soft deletion of features and tasks. A trashed row
+// has deleted_at set; every normal query filters it out.
+type TrashRepository struct {
+	db *DB
+}
+
+// NewTrashRepository creates a new TrashRepository
+func NewTrashRepository(db *DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// Queries selecting trashed features and tasks as TrashedItem rows
+const (
+	trashedFeaturesQuery = `
+		SELECT 'feature', f.id, f.key, f.title, e.key, f.deleted_at
+		FROM features f
+		INNER JOIN epics e ON f.epic_id = e.id
+		WHERE f.deleted_at IS NOT NULL`
+	trashedTasksQuery = `
+		SELECT 'task', t.id, t.key, t.title, f.key, t.deleted_at
+		FROM tasks t
+		INNER JOIN features f ON t.feature_id = f.id
+		WHERE t.deleted_at IS NOT NULL`
+)
+
+// TrashTask moves a task to the trash
+func (r *TrashRepository) TrashTask(ctx context.Context, taskID int64) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE tasks SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL
+	`, time.Now().UTC(), taskID)
+	if err != nil {
+		return fmt.Errorf("failed to trash task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("task not found with id %d", taskID)
+	}
+
+	return nil
+}
+
+// TrashFeature moves a feature and its tasks to the trash. The tasks share the
+// feature's deleted_at, so restoring the feature brings back exactly those
+// tasks. Returns the number of tasks trashed with the feature.
+func (r *TrashRepository) TrashFeature(ctx context.Context, featureID int64) (int, error) {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	deletedAt := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE features SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL
+	`, deletedAt, featureID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trash feature: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return 0, fmt.Errorf("feature not found with id %d", featureID)
+	}
+
+	result, err = tx.ExecContext(ctx, `
+		UPDATE tasks SET deleted_at = ? WHERE feature_id = ? AND deleted_at IS NULL
+	`, deletedAt, featureID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trash feature tasks: %w", err)
+	}
+	tasks, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return int(tasks), nil
+}
+
+// List returns everything in the trash, most recently deleted first
+func (r *TrashRepository) List(ctx context.Context) ([]*models.TrashedItem, error) {
+	features, err := r.query(ctx, trashedFeaturesQuery)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := r.query(ctx, trashedTasksQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	items := append(features, tasks...)
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		return items[i].Key < items[j].Key
+	})
+	return items, nil
+}
+
+// GetByKey returns the trashed feature or task with the given key, or nil if
+// nothing with that key is in the trash
+func (r *TrashRepository) GetByKey(ctx context.Context, key string) (*models.TrashedItem, error) {
+	items, err := r.query(ctx, trashedTasksQuery+" AND t.key = ?", key)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		items, err = r.query(ctx, trashedFeaturesQuery+" AND f.key = ?", key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items[0], nil
+}
+
+// Restore takes an item out of the trash. Restoring a feature also restores
+// the tasks trashed with it; a task can't be restored while its feature is in
+// the trash. Returns the number of tasks restored along with a feature.
+func (r *TrashRepository) Restore(ctx context.Context, item *models.TrashedItem) (int, error) {
+	switch item.Type {
+	case models.TrashTypeTask:
+		var featureDeleted sql.NullTime
+		if err := r.db.QueryRowContext(ctx, `
+			SELECT f.deleted_at FROM tasks t
+			INNER JOIN features f ON t.feature_id = f.id
+			WHERE t.id = ?
+		`, item.ID).Scan(&featureDeleted); err != nil {
+			return 0, fmt.Errorf("failed to get task feature: %w", err)
+		}
+		if featureDeleted.Valid {
+			return 0, fmt.Errorf("feature %s is in the trash; restore it first", item.ParentKey)
+		}
+		if _, err := r.db.ExecContext(ctx, `UPDATE tasks SET deleted_at = NULL WHERE id = ?`, item.ID); err != nil {
+			return 0, fmt.Errorf("failed to restore task: %w", err)
+		}
+		return 0, nil
+
+	case models.TrashTypeFeature:
+		tx, err := r.db.BeginTxContext(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		// Restore the tasks first, while the feature's deleted_at identifies them
+		result, err := tx.ExecContext(ctx, `
+			UPDATE tasks SET deleted_at = NULL
+			WHERE feature_id = ? AND deleted_at = (SELECT deleted_at FROM features WHERE id = ?)
+		`, item.ID, item.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to restore feature tasks: %w", err)
+		}
+		tasks, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE features SET deleted_at = NULL WHERE id = ?`, item.ID); err != nil {
+			return 0, fmt.Errorf("failed to restore feature: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return 0, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return int(tasks), nil
+	}
+
+	return 0, fmt.Errorf("unknown trash item type %q", item.Type)
+}
+
+// Empty permanently deletes everything in the trash, with their history,
+// notes, and other dependent rows (via CASCADE). Returns the number of
+// features and tasks deleted.
+func (r *TrashRepository) Empty(ctx context.Context) (features int, tasks int, err error) {
+	tx, err := r.db.BeginTxContext(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Tasks first, so the count includes tasks trashed with their feature
+	result, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE deleted_at IS NOT NULL`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to delete trashed tasks: %w", err)
+	}
+	taskCount, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	result, err = tx.ExecContext(ctx, `DELETE FROM features WHERE deleted_at IS NOT NULL`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to delete trashed features: %w", err)
+	}
+	featureCount, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return int(featureCount), int(taskCount), nil
+}
+
+// query runs a trash query and scans TrashedItem rows
+func (r *TrashRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.TrashedItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.TrashedItem{}
+	for rows.Next() {
+		item := &models.TrashedItem{}
+		if err := rows.Scan(
+			&item.Type,
+			&item.ID,
+			&item.Key,
+			&item.Title,
+			&item.ParentKey,
+			&item.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan trash item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trash: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashRepository_TaskLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	repo := NewTrashRepository(db)
+
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.TrashTask(ctx, taskID))
+	assert.Error(t, repo.TrashTask(ctx, taskID), "a trashed task can't be trashed again")
+
+	// Trashed tasks are hidden from normal queries
+	_, err = taskRepo.GetByKey(ctx, task.Key)
+	assert.Error(t, err)
+	tasks, err := taskRepo.ListByFeature(ctx, task.FeatureID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+	count, err := taskRepo.GetTaskCountForFeature(ctx, task.FeatureID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	items, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, models.TrashTypeTask, items[0].Type)
+	assert.Equal(t, "T-E01-F01-001", items[0].Key)
+	assert.Equal(t, "E01-F01", items[0].ParentKey)
+
+	item, err := repo.GetByKey(ctx, "T-E01-F01-001")
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	_, err = repo.Restore(ctx, item)
+	require.NoError(t, err)
+
+	_, err = taskRepo.GetByKey(ctx, task.Key)
+	assert.NoError(t, err)
+	item, err = repo.GetByKey(ctx, "T-E01-F01-001")
+	require.NoError(t, err)
+	assert.Nil(t, item)
+}
+
+func TestTrashRepository_FeatureLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	taskID := createTestTask(t, db)
+	taskRepo := NewTaskRepository(db)
+	featureRepo := NewFeatureRepository(db)
+	repo := NewTrashRepository(db)
+
+	task, err := taskRepo.GetByID(ctx, taskID)
+	require.NoError(t, err)
+	second := &models.Task{FeatureID: task.FeatureID, Key: "T-E01-F01-002", Title: "Second", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, second))
+
+	// A task trashed on its own stays in the trash when its feature is restored
+	require.NoError(t, repo.TrashTask(ctx, second.ID))
+
+	trashed, err := repo.TrashFeature(ctx, task.FeatureID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trashed)
+
+	_, err = featureRepo.GetByKey(ctx, "E01-F01")
+	assert.Error(t, err)
+
+	// A task can't come back while its feature is in the trash
+	item, err := repo.GetByKey(ctx, "T-E01-F01-001")
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, item)
+	assert.ErrorContains(t, err, "E01-F01")
+
+	item, err = repo.GetByKey(ctx, "E01-F01")
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, models.TrashTypeFeature, item.Type)
+	assert.Equal(t, "E01", item.ParentKey)
+
+	restored, err := repo.Restore(ctx, item)
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored)
+
+	tasks, err := taskRepo.ListByFeature(ctx, task.FeatureID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "T-E01-F01-001", tasks[0].Key)
+
+	// Emptying the trash deletes the remaining task for good
+	features, taskCount, err := repo.Empty(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, features)
+	assert.Equal(t, 1, taskCount)
+	items, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// The emptied task's key is free again
+	maxSeq, err := taskRepo.GetMaxSequenceForFeature(ctx, "E01-F01")
+	require.NoError(t, err)
+	assert.Equal(t, 1, maxSeq)
+}
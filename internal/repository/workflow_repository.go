@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+)
+
+// StoredWorkflow is the workflow stored in the project database
+type StoredWorkflow struct {
+	Workflow  *config.WorkflowConfig
+	UpdatedAt time.Time
+}
+
+// WorkflowRepository handles the project workflow stored in the database. A
+// stored workflow takes precedence over the status_flow in .sharkconfig.json.
+type WorkflowRepository struct {
+	db *DB
+}
+
+// NewWorkflowRepository creates a new WorkflowRepository
+func NewWorkflowRepository(db *DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// Get returns the stored workflow, or nil if there is none. The workflow is
+// parsed but not validated.
+func (r *WorkflowRepository) Get(ctx context.Context) (*StoredWorkflow, error) {
+	var data string
+	var updatedAt time.Time
+	err := r.db.QueryRowContext(ctx, `SELECT config, updated_at FROM workflow_config WHERE id = 1`).Scan(&data, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored workflow: %w", err)
+	}
+
+	workflow, err := config.ParseWorkflowJSON([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("stored workflow: %w", err)
+	}
+	return &StoredWorkflow{Workflow: workflow, UpdatedAt: updatedAt}, nil
+}
+
+// Load returns the stored workflow once it is validated, or nil if there is
+// none. A stored workflow that is no longer valid is an error.
+func (r *WorkflowRepository) Load(ctx context.Context) (*config.WorkflowConfig, error) {
+	stored, err := r.Get(ctx)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	if err := config.ValidateWorkflow(stored.Workflow); err != nil {
+		return nil, fmt.Errorf("stored workflow is invalid: %w", err)
+	}
+	return stored.Workflow, nil
+}
+
+// Save validates a workflow and stores it, replacing any stored before
+func (r *WorkflowRepository) Save(ctx context.Context, workflow *config.WorkflowConfig) error {
+	if err := config.ValidateWorkflow(workflow); err != nil {
+		return err
+	}
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO workflow_config (id, config, updated_at) VALUES (1, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(id) DO UPDATE SET config = excluded.config, updated_at = excluded.updated_at
+	`, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to store workflow: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the stored workflow so .sharkconfig.json or the default
+// workflow applies again. Returns false if none was stored.
+func (r *WorkflowRepository) Delete(ctx context.Context) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM workflow_config`)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete stored workflow: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// StatusesNotIn counts the tasks, including trashed ones, whose status isn't
+// defined in the workflow, by status. Those tasks could not move anywhere
+// under it. Archived tasks are left out: archiving is outside the workflow.
+func (r *WorkflowRepository) StatusesNotIn(ctx context.Context, workflow *config.WorkflowConfig) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM tasks GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks by status: %w", err)
+	}
+	defer rows.Close()
+
+	missing := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan task count: %w", err)
+		}
+		if !workflow.HasStatus(status) && status != string(models.TaskStatusArchived) {
+			missing[status] = count
+		}
+	}
+	return missing, rows.Err()
+}
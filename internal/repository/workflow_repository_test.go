@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupCriteriaTestDB(t)
+	defer db.Close()
+	repo := NewWorkflowRepository(db)
+
+	stored, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	workflow := config.DefaultWorkflow()
+	workflow.StatusFlow["in_progress"] = []string{"in_code_review", "blocked"}
+	workflow.StatusFlow["in_code_review"] = []string{"ready_for_qa", "in_progress"}
+	workflow.StatusFlow["ready_for_qa"] = []string{"completed", "in_progress"}
+	delete(workflow.StatusFlow, "ready_for_review")
+	require.NoError(t, repo.Save(ctx, workflow))
+
+	stored, err = repo.Get(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, workflow.StatusFlow, stored.Workflow.StatusFlow)
+	assert.Equal(t, workflow.SpecialStatuses, stored.Workflow.SpecialStatuses)
+	assert.True(t, stored.Workflow.RequireRejectionReason)
+
+	// Invalid workflows are rejected and the stored one is kept
+	invalid := config.DefaultWorkflow()
+	invalid.StatusFlow["todo"] = []string{"nowhere"}
+	assert.Error(t, repo.Save(ctx, invalid))
+	stored, err = repo.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, stored.Workflow.HasStatus("in_code_review"))
+
+	// Tasks in statuses the workflow doesn't define are reported
+	epic := &models.Epic{Key: "E91", Title: "Workflow", Status: models.EpicStatusActive, Priority: models.PriorityMedium}
+	require.NoError(t, NewEpicRepository(db).Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E91-F01", Title: "Statuses", Status: models.FeatureStatusActive}
+	require.NoError(t, NewFeatureRepository(db).Create(ctx, feature))
+	task := &models.Task{FeatureID: feature.ID, Key: "T-E91-F01-001", Title: "Review me", Status: models.TaskStatusReadyForReview, Priority: 5}
+	require.NoError(t, NewTaskRepository(db).Create(ctx, task))
+
+	missing, err := repo.StatusesNotIn(ctx, stored.Workflow)
+	require.NoError(t, err)
+	assert.Equal(t, 1, missing["ready_for_review"])
+	assert.NotContains(t, missing, "todo")
+
+	removed, err := repo.Delete(ctx)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = repo.Delete(ctx)
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// writeMethods are the RPCs that change data. Read-scoped API keys can't call them.
+var writeMethods = map[string]bool{
+	sharkv1.EpicService_UpdateEpic_FullMethodName:       true,
+	sharkv1.FeatureService_UpdateFeature_FullMethodName: true,
+	sharkv1.TaskService_CreateTask_FullMethodName:       true,
+	sharkv1.TaskService_UpdateTaskStatus_FullMethodName: true,
+	sharkv1.TaskService_BlockTask_FullMethodName:        true,
+	sharkv1.TaskService_UnblockTask_FullMethodName:      true,
+	sharkv1.IdeaService_CreateIdea_FullMethodName:       true,
+	sharkv1.IdeaService_UpdateIdea_FullMethodName:       true,
+}
+
+// authenticator checks the bearer credential of a call: the server token,
+// which may call anything, or an API key, whose scope decides whether it may
+// call writeMethods
+type authenticator struct {
+	token string
+	keys  *repository.APIKeyRepository // nil unless API keys are enabled
+}
+
+// authorize checks that the request metadata carries "authorization: Bearer
+// <credential>" that may call method
+func (a *authenticator) authorize(ctx context.Context, method string) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return grpcstatus.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	for _, value := range md.Get("authorization") {
+		scheme, credential, found := strings.Cut(value, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			continue
+		}
+		credential = strings.TrimSpace(credential)
+		if a.token != "" && subtle.ConstantTimeCompare([]byte(credential), []byte(a.token)) == 1 {
+			return nil
+		}
+		if a.keys == nil {
+			return grpcstatus.Error(codes.Unauthenticated, "invalid auth token")
+		}
+
+		key, err := a.keys.Authenticate(ctx, credential)
+		if errors.Is(err, repository.ErrInvalidAPIKey) {
+			return grpcstatus.Error(codes.Unauthenticated, "invalid auth token or API key")
+		}
+		if err != nil {
+			return toStatusError(err)
+		}
+		if writeMethods[method] && !key.CanWrite() {
+			return grpcstatus.Errorf(codes.PermissionDenied, "API key %q has read scope and can't call %s", key.Name, method)
+		}
+		return nil
+	}
+	return grpcstatus.Error(codes.Unauthenticated, "missing bearer token: send \"authorization: Bearer <token>\"")
+}
+
+// unaryAuthInterceptor rejects unary calls without a valid credential
+func unaryAuthInterceptor(auth *authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := auth.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuthInterceptor rejects streaming calls without a valid credential
+func streamAuthInterceptor(auth *authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := auth.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stringValue returns the value of s, or "" if it is nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// intValue returns the value of n as an int32, or 0 if it is nil
+func intValue(n *int) int32 {
+	if n == nil {
+		return 0
+	}
+	return int32(*n)
+}
+
+// nullTimestamp converts a nullable time, returning nil when it isn't set
+func nullTimestamp(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+// timestamp converts a time, returning nil for the zero time
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// parseKeyList decodes a JSON array of keys as stored in depends_on
+func parseKeyList(raw *string) []string {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(*raw), &keys); err != nil {
+		return nil
+	}
+	return keys
+}
+
+// epicToProto converts an epic; progress is its calculated completion percentage
+func epicToProto(e *models.Epic, progress float64) *sharkv1.Epic {
+	pb := &sharkv1.Epic{
+		Id:          e.ID,
+		Key:         e.Key,
+		Title:       e.Title,
+		Description: stringValue(e.Description),
+		Status:      string(e.Status),
+		Priority:    string(e.Priority),
+		FilePath:    stringValue(e.FilePath),
+		ProgressPct: progress,
+		CreatedAt:   timestamp(e.CreatedAt),
+		UpdatedAt:   timestamp(e.UpdatedAt),
+	}
+	if e.BusinessValue != nil {
+		pb.BusinessValue = string(*e.BusinessValue)
+	}
+	return pb
+}
+
+// featureToProto converts a feature in the epic with the given key
+func featureToProto(f *models.Feature, epicKey string) *sharkv1.Feature {
+	return &sharkv1.Feature{
+		Id:             f.ID,
+		Key:            f.Key,
+		EpicKey:        epicKey,
+		Title:          f.Title,
+		Description:    stringValue(f.Description),
+		Status:         string(f.Status),
+		StatusOverride: f.StatusOverride,
+		ProgressPct:    f.ProgressPct,
+		ExecutionOrder: intValue(f.ExecutionOrder),
+		FilePath:       stringValue(f.FilePath),
+		CreatedAt:      timestamp(f.CreatedAt),
+		UpdatedAt:      timestamp(f.UpdatedAt),
+	}
+}
+
+// taskToProto converts a task in the feature with the given key
+func taskToProto(t *models.Task, featureKey string) *sharkv1.Task {
+	return &sharkv1.Task{
+		Id:             t.ID,
+		Key:            t.Key,
+		FeatureKey:     featureKey,
+		Title:          t.Title,
+		Description:    stringValue(t.Description),
+		Status:         string(t.Status),
+		AgentType:      stringValue(t.AgentType),
+		Priority:       int32(t.Priority),
+		DependsOn:      parseKeyList(t.DependsOn),
+		AssignedAgent:  stringValue(t.AssignedAgent),
+		BlockedReason:  stringValue(t.BlockedReason),
+		FilePath:       stringValue(t.FilePath),
+		ExecutionOrder: intValue(t.ExecutionOrder),
+		Version:        int32(t.Version),
+		CreatedAt:      timestamp(t.CreatedAt),
+		StartedAt:      nullTimestamp(t.StartedAt),
+		CompletedAt:    nullTimestamp(t.CompletedAt),
+		BlockedAt:      nullTimestamp(t.BlockedAt),
+		UpdatedAt:      timestamp(t.UpdatedAt),
+	}
+}
+
+// ideaToProto converts an idea
+func ideaToProto(i *models.Idea) *sharkv1.Idea {
+	return &sharkv1.Idea{
+		Id:              i.ID,
+		Key:             i.Key,
+		Title:           i.Title,
+		Description:     stringValue(i.Description),
+		Status:          string(i.Status),
+		Priority:        intValue(i.Priority),
+		Notes:           stringValue(i.Notes),
+		ConvertedToType: stringValue(i.ConvertedToType),
+		ConvertedToKey:  stringValue(i.ConvertedToKey),
+		CreatedAt:       timestamp(i.CreatedAt),
+		UpdatedAt:       timestamp(i.UpdatedAt),
+	}
+}
+
+// historyToEvent converts a task history record for the task with the given key
+func historyToEvent(h *models.TaskHistory, taskKey string) *sharkv1.TaskStatusEvent {
+	return &sharkv1.TaskStatusEvent{
+		Id:        h.ID,
+		TaskKey:   taskKey,
+		OldStatus: stringValue(h.OldStatus),
+		NewStatus: h.NewStatus,
+		Agent:     stringValue(h.Agent),
+		Notes:     stringValue(h.Notes),
+		Timestamp: timestamp(h.Timestamp),
+	}
+}
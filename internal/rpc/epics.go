@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/keys"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// epicService implements sharkv1.EpicServiceServer
+type epicService struct {
+	sharkv1.UnimplementedEpicServiceServer
+	server *Server
+}
+
+// ListEpics returns all epics, optionally filtered by status
+func (e *epicService) ListEpics(ctx context.Context, req *sharkv1.ListEpicsRequest) (*sharkv1.ListEpicsResponse, error) {
+	var statusFilter *models.EpicStatus
+	if req.GetStatus() != "" {
+		if err := models.ValidateEpicStatus(req.GetStatus()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		epicStatus := models.EpicStatus(req.GetStatus())
+		statusFilter = &epicStatus
+	}
+
+	epics, err := e.server.epicRepo.List(ctx, statusFilter)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &sharkv1.ListEpicsResponse{Epics: make([]*sharkv1.Epic, 0, len(epics))}
+	for _, epic := range epics {
+		progress, err := e.server.epicRepo.CalculateProgress(ctx, epic.ID)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		resp.Epics = append(resp.Epics, epicToProto(epic, progress))
+	}
+	return resp, nil
+}
+
+// GetEpic returns an epic by key
+func (e *epicService) GetEpic(ctx context.Context, req *sharkv1.GetEpicRequest) (*sharkv1.Epic, error) {
+	epic, err := e.server.getEpic(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return e.server.epicResponse(ctx, epic)
+}
+
+// UpdateEpic changes the fields set in the request
+func (e *epicService) UpdateEpic(ctx context.Context, req *sharkv1.UpdateEpicRequest) (*sharkv1.Epic, error) {
+	epic, err := e.server.getEpic(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.AuditChange)
+	if req.Title != nil && req.GetTitle() != epic.Title {
+		if strings.TrimSpace(req.GetTitle()) == "" {
+			return nil, grpcstatus.Error(codes.InvalidArgument, "title cannot be empty")
+		}
+		changes["title"] = models.AuditChange{Old: epic.Title, New: req.GetTitle()}
+		epic.Title = req.GetTitle()
+	}
+	if req.Description != nil && req.GetDescription() != stringValue(epic.Description) {
+		changes["description"] = models.AuditChange{Old: stringValue(epic.Description), New: req.GetDescription()}
+		description := req.GetDescription()
+		epic.Description = &description
+	}
+	if req.Status != nil && req.GetStatus() != string(epic.Status) {
+		if err := models.ValidateEpicStatus(req.GetStatus()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		changes["status"] = models.AuditChange{Old: string(epic.Status), New: req.GetStatus()}
+		epic.Status = models.EpicStatus(req.GetStatus())
+	}
+	if req.Priority != nil && req.GetPriority() != string(epic.Priority) {
+		if err := models.ValidatePriority(req.GetPriority()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		changes["priority"] = models.AuditChange{Old: string(epic.Priority), New: req.GetPriority()}
+		epic.Priority = models.Priority(req.GetPriority())
+	}
+
+	if len(changes) > 0 {
+		if err := e.server.epicRepo.Update(ctx, epic); err != nil {
+			return nil, toStatusError(err)
+		}
+		e.server.recordAudit(ctx, &models.AuditEntry{
+			EntityType: models.AuditEntityEpic,
+			EntityKey:  epic.Key,
+			Action:     models.AuditActionUpdate,
+			Changes:    changes,
+			Summary:    fmt.Sprintf("Updated %s", changedFields(changes)),
+			Actor:      DefaultAgent,
+		})
+	}
+	return e.server.epicResponse(ctx, epic)
+}
+
+// getEpic looks up an epic by key, accepting any form the CLI accepts
+func (s *Server) getEpic(ctx context.Context, key string) (*models.Epic, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "epic key is required")
+	}
+	key = keys.Normalize(key)
+	epic, err := s.epicRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, notFound("epic", key)
+	}
+	return epic, nil
+}
+
+// epicResponse converts an epic with its current progress
+func (s *Server) epicResponse(ctx context.Context, epic *models.Epic) (*sharkv1.Epic, error) {
+	progress, err := s.epicRepo.CalculateProgress(ctx, epic.ID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return epicToProto(epic, progress), nil
+}
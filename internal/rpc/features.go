@@ -0,0 +1,172 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/keys"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// featureService implements sharkv1.FeatureServiceServer
+type featureService struct {
+	sharkv1.UnimplementedFeatureServiceServer
+	server *Server
+}
+
+// ListFeatures returns features, optionally filtered by epic and status
+func (f *featureService) ListFeatures(ctx context.Context, req *sharkv1.ListFeaturesRequest) (*sharkv1.ListFeaturesResponse, error) {
+	if req.GetStatus() != "" {
+		if err := models.ValidateFeatureStatus(req.GetStatus()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	var features []*models.Feature
+	var err error
+	if req.GetEpicKey() != "" {
+		epic, lookupErr := f.server.getEpic(ctx, req.GetEpicKey())
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		features, err = f.server.featureRepo.ListByEpic(ctx, epic.ID)
+	} else {
+		features, err = f.server.featureRepo.List(ctx)
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	epicKeys, err := f.server.epicKeysByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sharkv1.ListFeaturesResponse{Features: make([]*sharkv1.Feature, 0, len(features))}
+	for _, feature := range features {
+		if req.GetStatus() != "" && string(feature.Status) != req.GetStatus() {
+			continue
+		}
+		resp.Features = append(resp.Features, featureToProto(feature, epicKeys[feature.EpicID]))
+	}
+	return resp, nil
+}
+
+// GetFeature returns a feature by key
+func (f *featureService) GetFeature(ctx context.Context, req *sharkv1.GetFeatureRequest) (*sharkv1.Feature, error) {
+	feature, err := f.server.getFeature(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return f.server.featureResponse(ctx, feature)
+}
+
+// UpdateFeature changes the fields set in the request. Like shark feature
+// update --status, setting a status overrides the status calculated from tasks.
+func (f *featureService) UpdateFeature(ctx context.Context, req *sharkv1.UpdateFeatureRequest) (*sharkv1.Feature, error) {
+	feature, err := f.server.getFeature(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.AuditChange)
+	if req.Title != nil && req.GetTitle() != feature.Title {
+		if strings.TrimSpace(req.GetTitle()) == "" {
+			return nil, grpcstatus.Error(codes.InvalidArgument, "title cannot be empty")
+		}
+		changes["title"] = models.AuditChange{Old: feature.Title, New: req.GetTitle()}
+		feature.Title = req.GetTitle()
+	}
+	if req.Description != nil && req.GetDescription() != stringValue(feature.Description) {
+		changes["description"] = models.AuditChange{Old: stringValue(feature.Description), New: req.GetDescription()}
+		description := req.GetDescription()
+		feature.Description = &description
+	}
+	if req.ExecutionOrder != nil && req.GetExecutionOrder() != intValue(feature.ExecutionOrder) {
+		changes["execution_order"] = models.AuditChange{Old: intValue(feature.ExecutionOrder), New: req.GetExecutionOrder()}
+		order := int(req.GetExecutionOrder())
+		feature.ExecutionOrder = &order
+	}
+	overrideStatus := false
+	if req.Status != nil && req.GetStatus() != string(feature.Status) {
+		if err := models.ValidateFeatureStatus(req.GetStatus()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		changes["status"] = models.AuditChange{Old: string(feature.Status), New: req.GetStatus()}
+		feature.Status = models.FeatureStatus(req.GetStatus())
+		overrideStatus = true
+	}
+
+	if len(changes) > 0 {
+		if overrideStatus {
+			if err := f.server.featureRepo.SetStatusOverride(ctx, feature.ID, true); err != nil {
+				return nil, toStatusError(err)
+			}
+			feature.StatusOverride = true
+		}
+		if err := f.server.featureRepo.Update(ctx, feature); err != nil {
+			return nil, toStatusError(err)
+		}
+		f.server.recordAudit(ctx, &models.AuditEntry{
+			EntityType: models.AuditEntityFeature,
+			EntityKey:  feature.Key,
+			Action:     models.AuditActionUpdate,
+			Changes:    changes,
+			Summary:    fmt.Sprintf("Updated %s", changedFields(changes)),
+			Actor:      DefaultAgent,
+		})
+	}
+	return f.server.featureResponse(ctx, feature)
+}
+
+// getFeature looks up a feature by key, accepting any form the CLI accepts
+func (s *Server) getFeature(ctx context.Context, key string) (*models.Feature, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "feature key is required")
+	}
+	key = keys.Normalize(key)
+	feature, err := s.featureRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, notFound("feature", key)
+	}
+	return feature, nil
+}
+
+// featureResponse converts a feature with its epic's key
+func (s *Server) featureResponse(ctx context.Context, feature *models.Feature) (*sharkv1.Feature, error) {
+	epic, err := s.epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return featureToProto(feature, epic.Key), nil
+}
+
+// epicKeysByID maps epic IDs to keys
+func (s *Server) epicKeysByID(ctx context.Context) (map[int64]string, error) {
+	epics, err := s.epicRepo.List(ctx, nil)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	epicKeys := make(map[int64]string, len(epics))
+	for _, epic := range epics {
+		epicKeys[epic.ID] = epic.Key
+	}
+	return epicKeys, nil
+}
+
+// featureKeysByID maps feature IDs to keys
+func (s *Server) featureKeysByID(ctx context.Context) (map[int64]string, error) {
+	features, err := s.featureRepo.List(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	featureKeys := make(map[int64]string, len(features))
+	for _, feature := range features {
+		featureKeys[feature.ID] = feature.Key
+	}
+	return featureKeys, nil
+}
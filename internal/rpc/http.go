@@ -0,0 +1,324 @@
+package rpc
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultHTTPAgent is recorded in task history and the audit log for status
+// changes made over HTTP that don't name an agent
+const DefaultHTTPAgent = "web"
+
+// Endpoints without an RPC are named by their route in logs and authorized as
+// reads
+const (
+	workflowMethod = "GET /api/workflow"
+	statusMethod   = "GET /api/v1/status"
+)
+
+//go:embed ui
+var uiFiles embed.FS
+
+// httpGateway serves the JSON API used by the web UI. Each endpoint calls the
+// same service method as the matching RPC, so it reads and writes the database
+// the same way and is authorized as that RPC.
+type httpGateway struct {
+	server   *Server
+	auth     *authenticator // nil when no credential is required
+	epics    *epicService
+	features *featureService
+	tasks    *taskService
+}
+
+// NewHTTPHandler returns a handler serving the web UI at /ui/ and the JSON API
+// it uses at /api/, including the status dashboard at /api/v1/status. When token is non-empty or API keys are enabled, API calls
+// must send "Authorization: Bearer <token>" with the token or an API key; the
+// UI's static files are served to anyone.
+func NewHTTPHandler(s *Server, token string) http.Handler {
+	g := &httpGateway{
+		server:   s,
+		epics:    &epicService{server: s},
+		features: &featureService{server: s},
+		tasks:    &taskService{server: s},
+	}
+	if token != "" || s.apiKeys {
+		g.auth = &authenticator{token: token}
+		if s.apiKeys {
+			g.auth.keys = repository.NewAPIKeyRepository(s.db)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/epics", g.listEpics)
+	mux.HandleFunc("GET /api/epics/{key}", g.getEpic)
+	mux.HandleFunc("GET /api/features", g.listFeatures)
+	mux.HandleFunc("GET /api/features/{key}", g.getFeature)
+	mux.HandleFunc("GET /api/tasks", g.listTasks)
+	mux.HandleFunc("GET /api/tasks/{key}", g.getTask)
+	mux.HandleFunc("POST /api/tasks/{key}/status", g.updateTaskStatus)
+	mux.HandleFunc("GET /api/workflow", g.getWorkflow)
+	mux.Handle(statusMethod, g.requireAuth(statusMethod, g.statusHandler()))
+
+	ui, _ := fs.Sub(uiFiles, "ui")
+	mux.Handle("GET /ui/", http.StripPrefix("/ui/", http.FileServer(http.FS(ui))))
+	mux.Handle("GET /{$}", http.RedirectHandler("/ui/", http.StatusFound))
+	return mux
+}
+
+// call authorizes a request as method, runs it, and writes the response or
+// error as JSON
+func (g *httpGateway) call(w http.ResponseWriter, r *http.Request, method string, run func(ctx context.Context) (proto.Message, error)) {
+	start := time.Now()
+	ctx := r.Context()
+	var resp proto.Message
+	err := g.authorize(ctx, r, method)
+	if err == nil {
+		resp, err = run(ctx)
+	}
+	logCall(ctx, g.server.logger, method, start, err)
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		writeHTTPError(w, toStatusError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// authorize checks the request's Authorization header as the RPC interceptors
+// check call metadata
+func (g *httpGateway) authorize(ctx context.Context, r *http.Request, method string) error {
+	if g.auth == nil {
+		return nil
+	}
+	md := metadata.MD{}
+	if header := r.Header.Get("Authorization"); header != "" {
+		md.Set("authorization", header)
+	}
+	return g.auth.authorize(metadata.NewIncomingContext(ctx, md), method)
+}
+
+// requireAuth wraps a handler that has no RPC so it is authorized as method
+func (g *httpGateway) requireAuth(method string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := g.authorize(r.Context(), r, method); err != nil {
+			logCall(r.Context(), g.server.logger, method, time.Now(), err)
+			writeHTTPError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// statusHandler serves the status dashboard JSON of shark status, rating epic
+// health with the project's health rules as the status server does. The rules
+// come from UseSettings when it has been called, so reloads apply.
+func (g *httpGateway) statusHandler() http.Handler {
+	var rules *workspace.HealthRules
+	if g.server.currentSettings() == nil {
+		var err error
+		rules, err = status.LoadProjectHealthRules(context.Background(), g.server.projectRoot, g.server.db)
+		if err != nil {
+			g.server.logger.Warn("Failed to load health rules; using defaults", "error", err)
+		}
+	}
+	defaults := func() *status.StatusRequest {
+		if settings := g.server.currentSettings(); settings != nil {
+			return &status.StatusRequest{Health: settings.Health}
+		}
+		return &status.StatusRequest{Health: rules}
+	}
+	return status.NewHTTPHandler(status.NewStatusService(g.server.db), defaults)
+}
+
+func (g *httpGateway) listEpics(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, sharkv1.EpicService_ListEpics_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		return g.epics.ListEpics(ctx, &sharkv1.ListEpicsRequest{Status: r.URL.Query().Get("status")})
+	})
+}
+
+func (g *httpGateway) getEpic(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, sharkv1.EpicService_GetEpic_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		return g.epics.GetEpic(ctx, &sharkv1.GetEpicRequest{Key: r.PathValue("key")})
+	})
+}
+
+func (g *httpGateway) listFeatures(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	g.call(w, r, sharkv1.FeatureService_ListFeatures_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		return g.features.ListFeatures(ctx, &sharkv1.ListFeaturesRequest{EpicKey: query.Get("epic_key"), Status: query.Get("status")})
+	})
+}
+
+func (g *httpGateway) getFeature(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, sharkv1.FeatureService_GetFeature_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		return g.features.GetFeature(ctx, &sharkv1.GetFeatureRequest{Key: r.PathValue("key")})
+	})
+}
+
+func (g *httpGateway) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	g.call(w, r, sharkv1.TaskService_ListTasks_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		return g.tasks.ListTasks(ctx, &sharkv1.ListTasksRequest{
+			EpicKey:    query.Get("epic_key"),
+			FeatureKey: query.Get("feature_key"),
+			Status:     query.Get("status"),
+			AgentType:  query.Get("agent_type"),
+		})
+	})
+}
+
+func (g *httpGateway) getTask(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, sharkv1.TaskService_GetTask_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		return g.tasks.GetTask(ctx, &sharkv1.GetTaskRequest{Key: r.PathValue("key")})
+	})
+}
+
+// updateTaskStatus takes an UpdateTaskStatusRequest as JSON; the key comes
+// from the path
+func (g *httpGateway) updateTaskStatus(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, sharkv1.TaskService_UpdateTaskStatus_FullMethodName, func(ctx context.Context) (proto.Message, error) {
+		req := &sharkv1.UpdateTaskStatusRequest{}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+		}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, req); err != nil {
+			return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+		}
+		req.Key = r.PathValue("key")
+		if req.Agent == "" {
+			req.Agent = DefaultHTTPAgent
+		}
+		return g.tasks.UpdateTaskStatus(ctx, req)
+	})
+}
+
+// WorkflowStatusJSON is a workflow status and the statuses it can move to
+type WorkflowStatusJSON struct {
+	Status      string   `json:"status"`
+	Phase       string   `json:"phase,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Color       string   `json:"color,omitempty"`
+	Next        []string `json:"next"`
+	Backward    []string `json:"backward"` // Next statuses that need a rejection reason
+}
+
+// WorkflowJSON is the workflow the server validates status changes against
+type WorkflowJSON struct {
+	Statuses               []WorkflowStatusJSON `json:"statuses"` // In workflow order
+	Start                  []string             `json:"start"`
+	Complete               []string             `json:"complete"`
+	RequireRejectionReason bool                 `json:"require_rejection_reason"`
+}
+
+// getWorkflow returns the workflow, so the UI only offers allowed transitions
+func (g *httpGateway) getWorkflow(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := g.authorize(r.Context(), r, workflowMethod)
+	logCall(r.Context(), g.server.logger, workflowMethod, start, err)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(workflowJSON(g.server.currentWorkflow()))
+}
+
+// workflowJSON lists a workflow's statuses in the order tasks move through
+// them, starting from its start statuses; unreachable statuses come last
+func workflowJSON(workflow *config.WorkflowConfig) *WorkflowJSON {
+	result := &WorkflowJSON{
+		Statuses:               []WorkflowStatusJSON{},
+		Start:                  append([]string{}, workflow.SpecialStatuses[config.StartStatusKey]...),
+		Complete:               append([]string{}, workflow.SpecialStatuses[config.CompleteStatusKey]...),
+		RequireRejectionReason: workflow.RequireRejectionReason,
+	}
+
+	var order []string
+	seen := make(map[string]bool)
+	queue := append([]string{}, result.Start...)
+	for len(queue) > 0 {
+		status := queue[0]
+		queue = queue[1:]
+		if seen[status] || !workflow.HasStatus(status) {
+			continue
+		}
+		seen[status] = true
+		order = append(order, status)
+		queue = append(queue, workflow.StatusFlow[status]...)
+	}
+	for _, status := range workflow.Statuses() {
+		if !seen[status] {
+			order = append(order, status)
+		}
+	}
+
+	for _, status := range order {
+		meta, _ := workflow.GetStatusMetadata(status)
+		entry := WorkflowStatusJSON{
+			Status:      status,
+			Phase:       meta.Phase,
+			Description: meta.Description,
+			Color:       meta.Color,
+			Next:        append([]string{}, workflow.StatusFlow[status]...),
+			Backward:    []string{},
+		}
+		if workflow.RequireRejectionReason {
+			for _, next := range entry.Next {
+				if backward, err := workflow.IsBackwardTransition(status, next); err == nil && backward {
+					entry.Backward = append(entry.Backward, next)
+				}
+			}
+		}
+		result.Statuses = append(result.Statuses, entry)
+	}
+	return result
+}
+
+// httpStatusCodes maps gRPC codes to HTTP status codes; others are 500
+var httpStatusCodes = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.NotFound:           http.StatusNotFound,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Canceled:           499,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+}
+
+// writeHTTPError writes {"error": message, "code": grpc-code} with the
+// matching HTTP status
+func writeHTTPError(w http.ResponseWriter, err error) {
+	st := grpcstatus.Convert(toStatusError(err))
+	httpStatus, ok := httpStatusCodes[st.Code()]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": st.Message(), "code": st.Code().String()})
+}
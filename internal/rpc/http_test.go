@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestHTTPServer serves a test database (see seedTestDB) over HTTP with
+// testToken or an API key required
+func setupTestHTTPServer(t *testing.T) (*httptest.Server, *repository.DB) {
+	repoDb := seedTestDB(t)
+	server := NewServer(repoDb, nil, t.TempDir())
+	server.EnableAPIKeys()
+	ts := httptest.NewServer(NewHTTPHandler(server, testToken))
+	t.Cleanup(ts.Close)
+	return ts, repoDb
+}
+
+// doHTTP sends a request with a bearer token ("" for none) and decodes the
+// JSON response into out
+func doHTTP(t *testing.T, method, url, token, body string, out interface{}) int {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestHTTPAPI(t *testing.T) {
+	ts, repoDb := setupTestHTTPServer(t)
+
+	var errBody map[string]string
+	assert.Equal(t, http.StatusUnauthorized, doHTTP(t, "GET", ts.URL+"/api/tasks", "", "", &errBody))
+	assert.Equal(t, "Unauthenticated", errBody["code"])
+
+	var tasks struct {
+		Tasks []struct {
+			Key     string `json:"key"`
+			Status  string `json:"status"`
+			Version int    `json:"version"`
+		} `json:"tasks"`
+	}
+	require.Equal(t, http.StatusOK, doHTTP(t, "GET", ts.URL+"/api/tasks?feature_key=E01-F01", testToken, "", &tasks))
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, "T-E01-F01-001", tasks.Tasks[0].Key)
+
+	var epics struct {
+		Epics []map[string]interface{} `json:"epics"`
+	}
+	require.Equal(t, http.StatusOK, doHTTP(t, "GET", ts.URL+"/api/epics", testToken, "", &epics))
+	require.Len(t, epics.Epics, 1)
+	assert.Contains(t, epics.Epics[0], "progress_pct", "zero values are included")
+
+	var task map[string]interface{}
+	status := doHTTP(t, "POST", ts.URL+"/api/tasks/T-E01-F01-001/status", testToken, `{"status": "in_progress", "notes": "from the UI"}`, &task)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "in_progress", task["status"])
+
+	history, err := repository.NewTaskHistoryRepository(repoDb).GetHistoryByTaskKey(context.Background(), "T-E01-F01-001")
+	require.NoError(t, err)
+	agents := []string{}
+	for _, entry := range history {
+		if entry.Agent != nil {
+			agents = append(agents, *entry.Agent)
+		}
+	}
+	assert.Contains(t, agents, DefaultHTTPAgent)
+
+	assert.Equal(t, http.StatusNotFound, doHTTP(t, "GET", ts.URL+"/api/tasks/T-E01-F01-999", testToken, "", nil))
+	assert.Equal(t, http.StatusBadRequest, doHTTP(t, "POST", ts.URL+"/api/tasks/T-E01-F01-001/status", testToken, `{"status":`, nil))
+
+	// Read-scoped API keys can look but not change tasks
+	_, readKey, err := repository.NewAPIKeyRepository(repoDb).Create(context.Background(), "dashboard", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, doHTTP(t, "GET", ts.URL+"/api/features", readKey, "", nil))
+	assert.Equal(t, http.StatusForbidden, doHTTP(t, "POST", ts.URL+"/api/tasks/T-E01-F01-001/status", readKey, `{"status": "ready_for_review"}`, nil))
+}
+
+func TestHTTPWorkflowStatusAndUI(t *testing.T) {
+	ts, _ := setupTestHTTPServer(t)
+
+	var workflow WorkflowJSON
+	require.Equal(t, http.StatusOK, doHTTP(t, "GET", ts.URL+"/api/workflow", testToken, "", &workflow))
+	require.NotEmpty(t, workflow.Statuses)
+	assert.Equal(t, workflow.Start[0], workflow.Statuses[0].Status, "statuses start where tasks start")
+	assert.NotEmpty(t, workflow.Statuses[0].Next)
+
+	var dashboard struct {
+		Summary struct {
+			Tasks struct {
+				Total int `json:"total"`
+			} `json:"tasks"`
+		} `json:"summary"`
+	}
+	assert.Equal(t, http.StatusUnauthorized, doHTTP(t, "GET", ts.URL+"/api/v1/status", "", "", nil))
+	require.Equal(t, http.StatusOK, doHTTP(t, "GET", ts.URL+"/api/v1/status", testToken, "", &dashboard))
+	assert.Equal(t, 1, dashboard.Summary.Tasks.Total)
+
+	// The UI itself needs no token
+	resp, err := http.Get(ts.URL + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	page, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `<script src="app.js">`)
+
+	script, err := http.Get(ts.URL + "/ui/app.js")
+	require.NoError(t, err)
+	defer script.Body.Close()
+	assert.Equal(t, http.StatusOK, script.StatusCode)
+}
@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// ideaService implements sharkv1.IdeaServiceServer
+type ideaService struct {
+	sharkv1.UnimplementedIdeaServiceServer
+	server *Server
+}
+
+// ListIdeas returns ideas, optionally filtered by status
+func (i *ideaService) ListIdeas(ctx context.Context, req *sharkv1.ListIdeasRequest) (*sharkv1.ListIdeasResponse, error) {
+	filter := &repository.IdeaFilter{}
+	if req.GetStatus() != "" {
+		if err := models.ValidateIdeaStatus(req.GetStatus()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		ideaStatus := models.IdeaStatus(req.GetStatus())
+		filter.Status = &ideaStatus
+	}
+
+	ideas, err := i.server.ideaRepo.List(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &sharkv1.ListIdeasResponse{Ideas: make([]*sharkv1.Idea, 0, len(ideas))}
+	for _, idea := range ideas {
+		resp.Ideas = append(resp.Ideas, ideaToProto(idea))
+	}
+	return resp, nil
+}
+
+// GetIdea returns an idea by key
+func (i *ideaService) GetIdea(ctx context.Context, req *sharkv1.GetIdeaRequest) (*sharkv1.Idea, error) {
+	idea, err := i.server.getIdea(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return ideaToProto(idea), nil
+}
+
+// CreateIdea captures a new idea with a key for today's date
+func (i *ideaService) CreateIdea(ctx context.Context, req *sharkv1.CreateIdeaRequest) (*sharkv1.Idea, error) {
+	if strings.TrimSpace(req.GetTitle()) == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "title is required")
+	}
+
+	now := time.Now()
+	dateStr := now.Format("2006-01-02")
+	seq, err := i.server.ideaRepo.GetNextSequenceForDate(ctx, dateStr)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	idea := &models.Idea{
+		Key:         fmt.Sprintf("I-%s-%02d", dateStr, seq),
+		Title:       req.GetTitle(),
+		CreatedDate: now,
+		Status:      models.IdeaStatusNew,
+	}
+	if req.GetDescription() != "" {
+		description := req.GetDescription()
+		idea.Description = &description
+	}
+	if req.GetPriority() > 0 {
+		priority := int(req.GetPriority())
+		idea.Priority = &priority
+	}
+	if req.GetNotes() != "" {
+		notes := req.GetNotes()
+		idea.Notes = &notes
+	}
+
+	if err := i.server.ideaRepo.Create(ctx, idea); err != nil {
+		return nil, toStatusError(err)
+	}
+	created, err := i.server.ideaRepo.GetByKey(ctx, idea.Key)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return ideaToProto(created), nil
+}
+
+// UpdateIdea changes the fields set in the request
+func (i *ideaService) UpdateIdea(ctx context.Context, req *sharkv1.UpdateIdeaRequest) (*sharkv1.Idea, error) {
+	idea, err := i.server.getIdea(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		if strings.TrimSpace(req.GetTitle()) == "" {
+			return nil, grpcstatus.Error(codes.InvalidArgument, "title cannot be empty")
+		}
+		idea.Title = req.GetTitle()
+	}
+	if req.Description != nil {
+		description := req.GetDescription()
+		idea.Description = &description
+	}
+	if req.Status != nil {
+		if err := models.ValidateIdeaStatus(req.GetStatus()); err != nil {
+			return nil, grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		idea.Status = models.IdeaStatus(req.GetStatus())
+	}
+	if req.Priority != nil {
+		priority := int(req.GetPriority())
+		idea.Priority = &priority
+	}
+	if req.Notes != nil {
+		notes := req.GetNotes()
+		idea.Notes = &notes
+	}
+
+	if err := i.server.ideaRepo.Update(ctx, idea); err != nil {
+		return nil, toStatusError(err)
+	}
+	updated, err := i.server.ideaRepo.GetByID(ctx, idea.ID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return ideaToProto(updated), nil
+}
+
+// getIdea looks up an idea by key
+func (s *Server) getIdea(ctx context.Context, key string) (*models.Idea, error) {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if key == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "idea key is required")
+	}
+	idea, err := s.ideaRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, notFound("idea", key)
+	}
+	return idea, nil
+}
@@ -0,0 +1,261 @@
+// Package rpc implements the gRPC API served by shark serve --grpc, and the
+// JSON API and web UI served by shark serve --http.
+//
+// The services defined in proto/shark/v1/shark.proto are thin wrappers around
+// the repositories used by the CLI, so an RPC and the equivalent shark command
+// read and write the database the same way, including task history, audit
+// entries, and feature/epic status cascades. The JSON API calls the same
+// service methods.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/hooks"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/reload"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// DefaultAgent is recorded in task history and the audit log when a request
+// doesn't name an agent
+const DefaultAgent = "grpc"
+
+// DefaultPollInterval is how often WatchTaskStatus checks for new status changes
+const DefaultPollInterval = time.Second
+
+// Server holds the state shared by the Epic, Feature, Task, and Idea services
+type Server struct {
+	db           *repository.DB
+	projectRoot  string
+	pollInterval time.Duration
+	logger       *slog.Logger
+	apiKeys      bool
+
+	epicRepo    *repository.EpicRepository
+	featureRepo *repository.FeatureRepository
+	historyRepo *repository.TaskHistoryRepository
+	ideaRepo    *repository.IdeaRepository
+
+	// Guards the settings that UseSettings replaces while serving
+	mu       sync.RWMutex
+	workflow *config.WorkflowConfig
+	taskRepo *repository.TaskRepository
+	settings *reload.Settings // nil until UseSettings is called
+}
+
+// NewServer creates a Server for the project at projectRoot. A nil workflow
+// uses the default workflow.
+func NewServer(db *repository.DB, workflow *config.WorkflowConfig, projectRoot string) *Server {
+	if workflow == nil {
+		workflow = config.DefaultWorkflow()
+	}
+	return &Server{
+		db:           db,
+		workflow:     workflow,
+		projectRoot:  projectRoot,
+		pollInterval: DefaultPollInterval,
+		logger:       db.Logger().With("component", "rpc"),
+		epicRepo:     repository.NewEpicRepository(db),
+		featureRepo:  repository.NewFeatureRepository(db),
+		taskRepo:     repository.NewTaskRepositoryWithWorkflow(db, workflow),
+		historyRepo:  repository.NewTaskHistoryRepository(db),
+		ideaRepo:     repository.NewIdeaRepository(db),
+	}
+}
+
+// UseSettings makes the server validate status changes against settings'
+// workflow, rate health with its health rules, and run its hooks, in place of
+// the workflow it was created with and the hooks and health rules read from
+// .shark.yaml. A server that reloads its settings calls it after each reload;
+// calls already running finish with the settings they started with.
+func (s *Server) UseSettings(settings *reload.Settings) {
+	workflow := settings.Workflow
+	if workflow == nil {
+		workflow = config.DefaultWorkflow()
+	}
+	taskRepo := repository.NewTaskRepositoryWithWorkflow(s.db, workflow)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workflow = workflow
+	s.taskRepo = taskRepo
+	s.settings = settings
+}
+
+// currentWorkflow returns the workflow status changes are validated against
+func (s *Server) currentWorkflow() *config.WorkflowConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.workflow
+}
+
+// tasks returns the task repository for the current workflow
+func (s *Server) tasks() *repository.TaskRepository {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.taskRepo
+}
+
+// currentSettings returns the settings set with UseSettings, or nil
+func (s *Server) currentSettings() *reload.Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings
+}
+
+// projectHooks returns the hooks from UseSettings, or reads them from
+// .shark.yaml when it hasn't been called
+func (s *Server) projectHooks() ([]workspace.Hook, error) {
+	if settings := s.currentSettings(); settings != nil {
+		return settings.Hooks, nil
+	}
+	return hooks.Load(s.projectRoot)
+}
+
+// SetPollInterval changes how often WatchTaskStatus checks for new status changes
+func (s *Server) SetPollInterval(interval time.Duration) {
+	if interval > 0 {
+		s.pollInterval = interval
+	}
+}
+
+// EnableAPIKeys lets calls authenticate with an API key from shark apikey
+// create, besides the server token. Keys with read scope can't call RPCs that
+// change data.
+func (s *Server) EnableAPIKeys() {
+	s.apiKeys = true
+}
+
+// Register adds the Epic, Feature, Task, and Idea services to a gRPC server
+func (s *Server) Register(gs *grpc.Server) {
+	sharkv1.RegisterEpicServiceServer(gs, &epicService{server: s})
+	sharkv1.RegisterFeatureServiceServer(gs, &featureService{server: s})
+	sharkv1.RegisterTaskServiceServer(gs, &taskService{server: s})
+	sharkv1.RegisterIdeaServiceServer(gs, &ideaService{server: s})
+}
+
+// NewGRPCServer returns a gRPC server with the services registered. Every call
+// is logged; when token is non-empty or API keys are enabled every call must
+// authenticate with the token or an API key.
+func NewGRPCServer(s *Server, token string, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryLoggingInterceptor(s.logger)),
+		grpc.ChainStreamInterceptor(streamLoggingInterceptor(s.logger)),
+	)
+	if token != "" || s.apiKeys {
+		auth := &authenticator{token: token}
+		if s.apiKeys {
+			auth.keys = repository.NewAPIKeyRepository(s.db)
+		}
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(unaryAuthInterceptor(auth)),
+			grpc.ChainStreamInterceptor(streamAuthInterceptor(auth)),
+		)
+	}
+	gs := grpc.NewServer(opts...)
+	s.Register(gs)
+	return gs
+}
+
+// cascadeStatus recalculates the status of a feature and its epic after its
+// tasks change, as the CLI does
+func (s *Server) cascadeStatus(ctx context.Context, featureID int64) {
+	if _, err := status.NewCalculationService(s.db, s.currentWorkflow()).CascadeFromFeatureID(ctx, featureID); err != nil {
+		s.logger.Warn("Status cascade failed", "feature_id", featureID, "error", err)
+	}
+	s.fireMilestoneHooks(ctx, featureID)
+}
+
+// fireMilestoneHooks runs the .shark.yaml hooks for milestones reached by a
+// change to a feature's tasks. Failures are logged, not returned.
+func (s *Server) fireMilestoneHooks(ctx context.Context, featureID int64) {
+	configured, err := s.projectHooks()
+	if err != nil {
+		s.logger.Warn("Failed to load hooks", "error", err)
+		return
+	}
+	if len(configured) == 0 {
+		return
+	}
+
+	events, err := hooks.Check(ctx, s.db, configured, featureID)
+	if err != nil {
+		s.logger.Warn("Milestone hooks failed", "feature_id", featureID, "error", err)
+		return
+	}
+	for _, err := range hooks.NewRunner(configured, s.projectRoot).Fire(ctx, events) {
+		s.logger.Warn("Hook failed", "error", err)
+	}
+}
+
+// recordAudit writes an audit log entry. Failures don't fail the request: the
+// change has already been made.
+func (s *Server) recordAudit(ctx context.Context, entry *models.AuditEntry) {
+	if err := repository.NewAuditLogRepository(s.db).Record(ctx, entry); err != nil {
+		s.logger.Warn("Failed to record audit entry", "entity", entry.EntityKey, "error", err)
+	}
+}
+
+// agentOrDefault returns agent, or DefaultAgent if it's empty
+func agentOrDefault(agent string) string {
+	if agent = strings.TrimSpace(agent); agent != "" {
+		return agent
+	}
+	return DefaultAgent
+}
+
+// changedFields lists the changed fields of an audited update, for its summary
+func changedFields(changes map[string]models.AuditChange) string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
+
+// notFound returns a NotFound error for an entity key
+func notFound(entity, key string) error {
+	return grpcstatus.Errorf(codes.NotFound, "%s %s not found", entity, key)
+}
+
+// toStatusError maps a repository error to a gRPC status error
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := grpcstatus.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return grpcstatus.FromContextError(err).Err()
+	}
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return grpcstatus.Error(codes.Aborted, err.Error())
+	}
+
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "not found"):
+		return grpcstatus.Error(codes.NotFound, message)
+	case strings.Contains(message, "transition"), strings.Contains(message, "required"):
+		return grpcstatus.Error(codes.FailedPrecondition, message)
+	case strings.Contains(message, "validation failed"), strings.Contains(message, "invalid"), strings.Contains(message, "must"):
+		return grpcstatus.Error(codes.InvalidArgument, message)
+	}
+	return grpcstatus.Error(codes.Internal, message)
+}
@@ -0,0 +1,293 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testToken = "test-token"
+
+// testClient holds clients connected to an in-process server over bufconn
+type testClient struct {
+	epics    sharkv1.EpicServiceClient
+	features sharkv1.FeatureServiceClient
+	tasks    sharkv1.TaskServiceClient
+	ideas    sharkv1.IdeaServiceClient
+}
+
+// setupTestServer seeds a test database (see seedTestDB) and serves it with
+// testToken or an API key required
+func setupTestServer(t *testing.T) (*testClient, *repository.DB) {
+	repoDb := seedTestDB(t)
+	server := NewServer(repoDb, nil, t.TempDir())
+	server.SetPollInterval(10 * time.Millisecond)
+	server.EnableAPIKeys()
+	gs := NewGRPCServer(server, testToken)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = gs.Serve(listener) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &testClient{
+		epics:    sharkv1.NewEpicServiceClient(conn),
+		features: sharkv1.NewFeatureServiceClient(conn),
+		tasks:    sharkv1.NewTaskServiceClient(conn),
+		ideas:    sharkv1.NewIdeaServiceClient(conn),
+	}, repoDb
+}
+
+// seedTestDB returns a temporary database with epic E01, feature E01-F01, and
+// todo task T-E01-F01-001
+func seedTestDB(t *testing.T) *repository.DB {
+	// A file database: task creation uses more than one connection
+	sqlDB, err := db.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	repoDb := &repository.DB{DB: sqlDB}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	ctx := context.Background()
+	epic := &models.Epic{Key: "E01", Title: "Platform", Status: models.EpicStatusActive, Priority: models.PriorityHigh}
+	require.NoError(t, repository.NewEpicRepository(repoDb).Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Login", Status: models.FeatureStatusActive}
+	require.NoError(t, repository.NewFeatureRepository(repoDb).Create(ctx, feature))
+	task := &models.Task{FeatureID: feature.ID, Key: "T-E01-F01-001", Title: "Build login form", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, repository.NewTaskRepository(repoDb).Create(ctx, task))
+	return repoDb
+}
+
+// authContext returns a context carrying the bearer token
+func authContext(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestAuthRequiresToken(t *testing.T) {
+	client, _ := setupTestServer(t)
+
+	_, err := client.epics.ListEpics(context.Background(), &sharkv1.ListEpicsRequest{})
+	assert.Equal(t, codes.Unauthenticated, grpcstatus.Code(err))
+
+	_, err = client.epics.ListEpics(authContext("wrong"), &sharkv1.ListEpicsRequest{})
+	assert.Equal(t, codes.Unauthenticated, grpcstatus.Code(err))
+
+	stream, err := client.tasks.WatchTaskStatus(context.Background(), &sharkv1.WatchTaskStatusRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, grpcstatus.Code(err))
+
+	resp, err := client.epics.ListEpics(authContext(testToken), &sharkv1.ListEpicsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Epics, 1)
+	assert.Equal(t, "E01", resp.Epics[0].Key)
+}
+
+func TestAuthAPIKeyScopes(t *testing.T) {
+	client, repoDb := setupTestServer(t)
+	keys := repository.NewAPIKeyRepository(repoDb)
+	_, readKey, err := keys.Create(context.Background(), "dashboard", models.APIKeyScopeRead)
+	require.NoError(t, err)
+	_, writeKey, err := keys.Create(context.Background(), "ci", models.APIKeyScopeWrite)
+	require.NoError(t, err)
+
+	resp, err := client.epics.ListEpics(authContext(readKey), &sharkv1.ListEpicsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Epics, 1)
+
+	title := "Platform Foundations"
+	_, err = client.epics.UpdateEpic(authContext(readKey), &sharkv1.UpdateEpicRequest{Key: "E01", Title: &title})
+	assert.Equal(t, codes.PermissionDenied, grpcstatus.Code(err), "read keys can't change data")
+
+	_, err = client.epics.UpdateEpic(authContext(writeKey), &sharkv1.UpdateEpicRequest{Key: "E01", Title: &title})
+	require.NoError(t, err)
+
+	listed, err := keys.List(context.Background())
+	require.NoError(t, err)
+	for _, key := range listed {
+		assert.NotNil(t, key.LastUsedAt, "use of %s is recorded", key.Name)
+	}
+
+	require.NoError(t, keys.Revoke(context.Background(), "ci"))
+	_, err = client.epics.ListEpics(authContext(writeKey), &sharkv1.ListEpicsRequest{})
+	assert.Equal(t, codes.Unauthenticated, grpcstatus.Code(err), "revoked keys are rejected")
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	interceptor := unaryLoggingInterceptor(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/shark.v1.TaskService/GetTask"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, notFound("task", "T-E01-F01-999")
+	})
+	require.Error(t, err)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "/shark.v1.TaskService/GetTask", record["method"])
+	assert.Equal(t, "NotFound", record["code"])
+	assert.Equal(t, "task T-E01-F01-999 not found", record["error"])
+	assert.Contains(t, record, "duration_ms")
+}
+
+func TestUpdateTaskStatus(t *testing.T) {
+	client, _ := setupTestServer(t)
+	ctx := authContext(testToken)
+
+	task, err := client.tasks.GetTask(ctx, &sharkv1.GetTaskRequest{Key: "e01-f01-001"})
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F01-001", task.Key)
+	assert.Equal(t, "E01-F01", task.FeatureKey)
+
+	started, err := client.tasks.UpdateTaskStatus(ctx, &sharkv1.UpdateTaskStatusRequest{
+		Key:             task.Key,
+		Status:          "in_progress",
+		ExpectedVersion: task.Version,
+		Agent:           "orchestrator",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", started.Status)
+	assert.NotNil(t, started.StartedAt)
+	assert.Greater(t, started.Version, task.Version)
+
+	// The stale version is rejected
+	_, err = client.tasks.UpdateTaskStatus(ctx, &sharkv1.UpdateTaskStatusRequest{
+		Key:             task.Key,
+		Status:          "ready_for_review",
+		ExpectedVersion: task.Version,
+	})
+	assert.Equal(t, codes.Aborted, grpcstatus.Code(err))
+
+	_, err = client.tasks.UpdateTaskStatus(ctx, &sharkv1.UpdateTaskStatusRequest{Key: "T-E01-F01-999", Status: "in_progress"})
+	assert.Equal(t, codes.NotFound, grpcstatus.Code(err))
+
+	blocked, err := client.tasks.BlockTask(ctx, &sharkv1.BlockTaskRequest{Key: task.Key, Reason: "Waiting on API"})
+	require.NoError(t, err)
+	assert.Equal(t, "blocked", blocked.Status)
+	assert.Equal(t, "Waiting on API", blocked.BlockedReason)
+}
+
+func TestWatchTaskStatus(t *testing.T) {
+	client, repoDb := setupTestServer(t)
+	ctx, cancel := context.WithTimeout(authContext(testToken), 5*time.Second)
+	defer cancel()
+
+	// A second task in another feature, which the filter excludes
+	feature := &models.Feature{EpicID: 1, Key: "E01-F02", Title: "Signup", Status: models.FeatureStatusActive}
+	require.NoError(t, repository.NewFeatureRepository(repoDb).Create(ctx, feature))
+	other := &models.Task{FeatureID: feature.ID, Key: "T-E01-F02-001", Title: "Build signup form", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, repository.NewTaskRepository(repoDb).Create(ctx, other))
+
+	stream, err := client.tasks.WatchTaskStatus(ctx, &sharkv1.WatchTaskStatusRequest{FeatureKey: "E01-F01"})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	// A change made directly in the database, as the CLI would
+	agent := "cli-agent"
+	require.NoError(t, repository.NewTaskRepository(repoDb).UpdateStatus(ctx, other.ID, models.TaskStatusInProgress, &agent, nil))
+	_, err = client.tasks.UpdateTaskStatus(ctx, &sharkv1.UpdateTaskStatusRequest{Key: "T-E01-F01-001", Status: "in_progress", Agent: "orchestrator"})
+	require.NoError(t, err)
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F01-001", event.TaskKey)
+	assert.Equal(t, "todo", event.OldStatus)
+	assert.Equal(t, "in_progress", event.NewStatus)
+	assert.Equal(t, "orchestrator", event.Agent)
+}
+
+func TestUpdateEpicAndFeature(t *testing.T) {
+	client, _ := setupTestServer(t)
+	ctx := authContext(testToken)
+
+	title := "Platform Foundations"
+	epic, err := client.epics.UpdateEpic(ctx, &sharkv1.UpdateEpicRequest{Key: "E01", Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, title, epic.Title)
+	assert.Equal(t, "high", epic.Priority)
+
+	badStatus := "finished"
+	_, err = client.epics.UpdateEpic(ctx, &sharkv1.UpdateEpicRequest{Key: "E01", Status: &badStatus})
+	assert.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+
+	status := "completed"
+	feature, err := client.features.UpdateFeature(ctx, &sharkv1.UpdateFeatureRequest{Key: "E01-F01", Status: &status})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", feature.Status)
+	assert.True(t, feature.StatusOverride)
+	assert.Equal(t, "E01", feature.EpicKey)
+
+	features, err := client.features.ListFeatures(ctx, &sharkv1.ListFeaturesRequest{EpicKey: "E01", Status: "completed"})
+	require.NoError(t, err)
+	require.Len(t, features.Features, 1)
+}
+
+func TestCreateTask(t *testing.T) {
+	client, _ := setupTestServer(t)
+	ctx := authContext(testToken)
+
+	task, err := client.tasks.CreateTask(ctx, &sharkv1.CreateTaskRequest{
+		EpicKey:    "E01",
+		FeatureKey: "F01",
+		Title:      "Add password reset",
+		AgentType:  "backend",
+		DependsOn:  []string{"T-E01-F01-001"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F01-002", task.Key)
+	assert.Equal(t, []string{"T-E01-F01-001"}, task.DependsOn)
+
+	tasks, err := client.tasks.ListTasks(ctx, &sharkv1.ListTasksRequest{FeatureKey: "E01-F01", AgentType: "backend"})
+	require.NoError(t, err)
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, task.Key, tasks.Tasks[0].Key)
+
+	_, err = client.tasks.CreateTask(ctx, &sharkv1.CreateTaskRequest{FeatureKey: "E01-F09", Title: "Nowhere"})
+	assert.Equal(t, codes.NotFound, grpcstatus.Code(err))
+}
+
+func TestIdeas(t *testing.T) {
+	client, _ := setupTestServer(t)
+	ctx := authContext(testToken)
+
+	idea, err := client.ideas.CreateIdea(ctx, &sharkv1.CreateIdeaRequest{Title: "Dark mode", Priority: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "new", idea.Status)
+	assert.Equal(t, int32(3), idea.Priority)
+
+	onHold := "on_hold"
+	updated, err := client.ideas.UpdateIdea(ctx, &sharkv1.UpdateIdeaRequest{Key: idea.Key, Status: &onHold})
+	require.NoError(t, err)
+	assert.Equal(t, "on_hold", updated.Status)
+
+	ideas, err := client.ideas.ListIdeas(ctx, &sharkv1.ListIdeasRequest{Status: "on_hold"})
+	require.NoError(t, err)
+	require.Len(t, ideas.Ideas, 1)
+	assert.Equal(t, idea.Key, ideas.Ideas[0].Key)
+}
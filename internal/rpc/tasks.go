@@ -0,0 +1,359 @@
+package rpc
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/keys"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/rpc/sharkv1"
+	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/templates"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// defaultTaskPriority is the priority of created tasks that don't set one,
+// matching shark task create
+const defaultTaskPriority = 5
+
+// watchBatchSize is how many history records WatchTaskStatus reads per query
+const watchBatchSize = 100
+
+// taskService implements sharkv1.TaskServiceServer
+type taskService struct {
+	sharkv1.UnimplementedTaskServiceServer
+	server *Server
+}
+
+// ListTasks returns tasks matching the request's filters
+func (t *taskService) ListTasks(ctx context.Context, req *sharkv1.ListTasksRequest) (*sharkv1.ListTasksResponse, error) {
+	var statusFilter *models.TaskStatus
+	if req.GetStatus() != "" {
+		taskStatus := models.TaskStatus(req.GetStatus())
+		statusFilter = &taskStatus
+	}
+	var epicKey *string
+	if req.GetEpicKey() != "" {
+		key := keys.Normalize(req.GetEpicKey())
+		epicKey = &key
+	}
+	var agentType *string
+	if req.GetAgentType() != "" {
+		agent := req.GetAgentType()
+		agentType = &agent
+	}
+	var maxPriority *int
+	if req.GetMaxPriority() > 0 {
+		priority := int(req.GetMaxPriority())
+		maxPriority = &priority
+	}
+
+	var featureID int64
+	if req.GetFeatureKey() != "" {
+		feature, err := t.server.getFeature(ctx, req.GetFeatureKey())
+		if err != nil {
+			return nil, err
+		}
+		featureID = feature.ID
+	}
+
+	tasks, err := t.server.tasks().FilterCombined(ctx, statusFilter, epicKey, agentType, maxPriority)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	featureKeys, err := t.server.featureKeysByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sharkv1.ListTasksResponse{Tasks: make([]*sharkv1.Task, 0, len(tasks))}
+	for _, task := range tasks {
+		if featureID != 0 && task.FeatureID != featureID {
+			continue
+		}
+		resp.Tasks = append(resp.Tasks, taskToProto(task, featureKeys[task.FeatureID]))
+	}
+	return resp, nil
+}
+
+// GetTask returns a task by key
+func (t *taskService) GetTask(ctx context.Context, req *sharkv1.GetTaskRequest) (*sharkv1.Task, error) {
+	task, err := t.server.getTask(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return t.server.taskResponse(ctx, task)
+}
+
+// CreateTask creates a task and its markdown file, as shark task create does.
+// feature_key may be a full key (E07-F01), or F01 with epic_key set.
+func (t *taskService) CreateTask(ctx context.Context, req *sharkv1.CreateTaskRequest) (*sharkv1.Task, error) {
+	if strings.TrimSpace(req.GetTitle()) == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "title is required")
+	}
+	featureKey := req.GetFeatureKey()
+	if req.GetEpicKey() != "" && featureKey != "" && !strings.Contains(featureKey, "-") {
+		featureKey = req.GetEpicKey() + "-" + featureKey
+	}
+	feature, err := t.server.getFeature(ctx, featureKey)
+	if err != nil {
+		return nil, err
+	}
+	epic, err := t.server.epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	priority := int(req.GetPriority())
+	if priority == 0 {
+		priority = defaultTaskPriority
+	}
+
+	s := t.server
+	taskRepo := s.tasks()
+	keygen := taskcreation.NewKeyGenerator(taskRepo, s.featureRepo)
+	validator := taskcreation.NewValidator(s.epicRepo, s.featureRepo, taskRepo)
+	loader := templates.NewLoader("")
+	registry := templates.NewRegistry(filepath.Join(s.projectRoot, templates.DefaultProjectTemplateDir))
+	renderer := templates.NewRendererWithRegistry(loader, registry)
+	creator := taskcreation.NewCreator(s.db, keygen, validator, renderer, taskRepo, s.historyRepo, s.epicRepo, s.featureRepo, s.projectRoot, nil)
+
+	result, err := creator.CreateTask(ctx, taskcreation.CreateTaskInput{
+		EpicKey:     epic.Key,
+		FeatureKey:  feature.Key,
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		AgentType:   req.GetAgentType(),
+		Priority:    priority,
+		DependsOn:   strings.Join(req.GetDependsOn(), ","),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	s.recordAudit(ctx, &models.AuditEntry{
+		EntityType: models.AuditEntityTask,
+		EntityKey:  result.Task.Key,
+		Action:     models.AuditActionCreate,
+		Summary:    result.Task.Title,
+		Actor:      DefaultAgent,
+	})
+	s.cascadeStatus(ctx, feature.ID)
+
+	return s.taskResponse(ctx, result.Task)
+}
+
+// UpdateTaskStatus moves a task to a new status, validating the transition
+// against the workflow unless force is set
+func (t *taskService) UpdateTaskStatus(ctx context.Context, req *sharkv1.UpdateTaskStatusRequest) (*sharkv1.Task, error) {
+	if req.GetStatus() == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "status is required")
+	}
+	task, err := t.server.getTask(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	agent := agentOrDefault(req.GetAgent())
+	var notes, reason *string
+	if req.GetNotes() != "" {
+		value := req.GetNotes()
+		notes = &value
+	}
+	if req.GetRejectionReason() != "" {
+		value := req.GetRejectionReason()
+		reason = &value
+	}
+
+	err = t.server.tasks().UpdateStatusIfVersion(ctx, task.ID, int(req.GetExpectedVersion()), models.TaskStatus(req.GetStatus()), &agent, notes, reason, nil, req.GetForce())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return t.server.afterStatusChange(ctx, task)
+}
+
+// BlockTask marks a task as blocked with a reason
+func (t *taskService) BlockTask(ctx context.Context, req *sharkv1.BlockTaskRequest) (*sharkv1.Task, error) {
+	if strings.TrimSpace(req.GetReason()) == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "reason is required")
+	}
+	task, err := t.server.getTask(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	agent := agentOrDefault(req.GetAgent())
+	if err := t.server.tasks().BlockTask(ctx, task.ID, req.GetReason(), &agent); err != nil {
+		return nil, toStatusError(err)
+	}
+	return t.server.afterStatusChange(ctx, task)
+}
+
+// UnblockTask returns a blocked task to todo
+func (t *taskService) UnblockTask(ctx context.Context, req *sharkv1.UnblockTaskRequest) (*sharkv1.Task, error) {
+	task, err := t.server.getTask(ctx, req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	agent := agentOrDefault(req.GetAgent())
+	if err := t.server.tasks().UnblockTask(ctx, task.ID, &agent); err != nil {
+		return nil, toStatusError(err)
+	}
+	return t.server.afterStatusChange(ctx, task)
+}
+
+// WatchTaskStatus polls task history and streams each status change recorded
+// after the call starts that matches the request's filters
+func (t *taskService) WatchTaskStatus(req *sharkv1.WatchTaskStatusRequest, stream sharkv1.TaskService_WatchTaskStatusServer) error {
+	ctx := stream.Context()
+	s := t.server
+
+	filter := watchFilter{}
+	if req.GetTaskKey() != "" {
+		task, err := s.getTask(ctx, req.GetTaskKey())
+		if err != nil {
+			return err
+		}
+		filter.taskKey = task.Key
+	}
+	if req.GetFeatureKey() != "" {
+		feature, err := s.getFeature(ctx, req.GetFeatureKey())
+		if err != nil {
+			return err
+		}
+		filter.featureKey = feature.Key
+	}
+	if req.GetEpicKey() != "" {
+		epic, err := s.getEpic(ctx, req.GetEpicKey())
+		if err != nil {
+			return err
+		}
+		filter.epicKey = epic.Key
+	}
+
+	lastID, err := s.historyRepo.LatestID(ctx)
+	if err != nil {
+		return toStatusError(err)
+	}
+	// Headers tell the client the watch has started: changes made after
+	// stream.Header() returns are streamed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	tasks := make(map[int64]watchedTask)
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		for {
+			histories, err := s.historyRepo.ListAfterID(ctx, lastID, watchBatchSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return toStatusError(err)
+			}
+			for _, history := range histories {
+				lastID = history.ID
+				task, err := s.watchedTask(ctx, tasks, history.TaskID)
+				if err != nil || !filter.matches(task) {
+					continue
+				}
+				if err := stream.Send(historyToEvent(history, task.key)); err != nil {
+					return err
+				}
+			}
+			if len(histories) < watchBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// watchedTask is the key and feature of a task seen by WatchTaskStatus
+type watchedTask struct {
+	key        string
+	featureKey string
+}
+
+// watchFilter selects the tasks whose status changes are streamed
+type watchFilter struct {
+	epicKey    string
+	featureKey string
+	taskKey    string
+}
+
+// matches reports whether a task passes every filter that is set
+func (f watchFilter) matches(task watchedTask) bool {
+	if f.taskKey != "" && task.key != f.taskKey {
+		return false
+	}
+	if f.featureKey != "" && task.featureKey != f.featureKey {
+		return false
+	}
+	if f.epicKey != "" && !strings.HasPrefix(task.featureKey, f.epicKey+"-") {
+		return false
+	}
+	return true
+}
+
+// watchedTask looks up a task's key and feature, caching the result
+func (s *Server) watchedTask(ctx context.Context, cache map[int64]watchedTask, taskID int64) (watchedTask, error) {
+	if task, ok := cache[taskID]; ok {
+		return task, nil
+	}
+	task, err := s.tasks().GetByID(ctx, taskID)
+	if err != nil {
+		return watchedTask{}, err
+	}
+	feature, err := s.featureRepo.GetByID(ctx, task.FeatureID)
+	if err != nil {
+		return watchedTask{}, err
+	}
+	cache[taskID] = watchedTask{key: task.Key, featureKey: feature.Key}
+	return cache[taskID], nil
+}
+
+// getTask looks up a task by key, accepting any form the CLI accepts
+func (s *Server) getTask(ctx context.Context, key string) (*models.Task, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "task key is required")
+	}
+	normalized, err := keys.NormalizeTaskKey(key)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid task key %q", key)
+	}
+	task, err := s.tasks().GetByKey(ctx, normalized)
+	if err != nil {
+		return nil, notFound("task", normalized)
+	}
+	return task, nil
+}
+
+// taskResponse converts a task with its feature's key
+func (s *Server) taskResponse(ctx context.Context, task *models.Task) (*sharkv1.Task, error) {
+	feature, err := s.featureRepo.GetByID(ctx, task.FeatureID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return taskToProto(task, feature.Key), nil
+}
+
+// afterStatusChange cascades a task's status change to its feature and epic
+// and returns the updated task
+func (s *Server) afterStatusChange(ctx context.Context, task *models.Task) (*sharkv1.Task, error) {
+	s.cascadeStatus(ctx, task.FeatureID)
+	updated, err := s.tasks().GetByID(ctx, task.ID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return s.taskResponse(ctx, updated)
+}
@@ -0,0 +1,220 @@
+// Web UI for shark serve --http. It only uses the JSON API under /api/, with
+// the token from the token form sent as a bearer credential.
+"use strict";
+
+const tokenKey = "shark-token";
+const state = { workflow: null, epics: [], features: [], selectEpic: null };
+
+function $(id) {
+  return document.getElementById(id);
+}
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    if (name === "style") node.style.cssText = value;
+    else node.setAttribute(name, value);
+  }
+  for (const child of children) {
+    node.append(child instanceof Node ? child : String(child ?? ""));
+  }
+  return node;
+}
+
+class AuthError extends Error {}
+
+async function api(path, options = {}) {
+  const headers = { ...(options.headers || {}) };
+  const token = localStorage.getItem(tokenKey);
+  if (token) headers.Authorization = "Bearer " + token;
+  const response = await fetch("/api/" + path, { ...options, headers });
+  const body = await response.json().catch(() => ({}));
+  if (response.status === 401) throw new AuthError(body.error || "unauthenticated");
+  if (!response.ok) throw new Error(body.error || response.statusText);
+  return body;
+}
+
+function showMessage(text, isError) {
+  const message = $("message");
+  message.textContent = text;
+  message.className = isError ? "error" : "";
+  message.hidden = !text;
+}
+
+function handleError(err) {
+  if (err instanceof AuthError) {
+    localStorage.removeItem(tokenKey);
+    showView(null);
+    $("token-form").hidden = false;
+    showMessage(err.message, true);
+    return;
+  }
+  showMessage(err.message, true);
+}
+
+function statusBadge(status) {
+  const meta = (state.workflow?.statuses || []).find((s) => s.status === status);
+  const badge = el("span", { class: "status" }, status);
+  if (meta?.color) badge.style.borderColor = meta.color;
+  if (meta?.description) badge.title = meta.description;
+  return badge;
+}
+
+function progressBar(pct) {
+  const rounded = Math.round(pct || 0);
+  return el("div", { class: "progress" },
+    el("div", { class: "bar" }, el("span", { style: "width: " + rounded + "%" })),
+    rounded + "%");
+}
+
+function fillSelect(select, values, label) {
+  const current = select.value;
+  select.replaceChildren(el("option", { value: "" }, "All"));
+  for (const value of values) {
+    select.append(el("option", { value: value.key ?? value }, label ? label(value) : value));
+  }
+  select.value = current;
+}
+
+async function loadWorkflow() {
+  if (!state.workflow) state.workflow = await api("workflow");
+  return state.workflow;
+}
+
+function countCard(value, label) {
+  return el("div", { class: "count" }, el("strong", {}, value), label);
+}
+
+async function renderDashboard() {
+  const [workflow, dashboard, tasks] = await Promise.all([loadWorkflow(), api("v1/status"), api("tasks")]);
+  const summary = dashboard.summary;
+  $("summary").replaceChildren(
+    countCard(Math.round(summary.overall_progress) + "%", "overall progress"),
+    countCard(summary.epics.active + " / " + summary.epics.total, "active epics"),
+    countCard(summary.features.active + " / " + summary.features.total, "active features"),
+    countCard(summary.blocked_count, "blocked tasks"));
+
+  // Counted from the task list, so custom workflow statuses show up too
+  const counts = {};
+  for (const task of tasks.tasks) counts[task.status] = (counts[task.status] || 0) + 1;
+  $("status-counts").replaceChildren(...workflow.statuses
+    .filter((s) => counts[s.status])
+    .map((s) => countCard(counts[s.status], statusBadge(s.status))));
+
+  $("epic-rows").replaceChildren(...(dashboard.epics || []).map((epic) => {
+    const link = el("a", { href: "#tasks" }, epic.key);
+    link.addEventListener("click", () => { state.selectEpic = epic.key; });
+    return el("tr", {},
+      el("td", {}, link),
+      el("td", {}, epic.title),
+      el("td", {}, el("span", { class: "health " + epic.health }, epic.health)),
+      el("td", {}, epic.tasks_completed + " / " + epic.tasks_total),
+      el("td", {}, progressBar(epic.progress_percent)));
+  }));
+
+  const blocked = dashboard.blocked_tasks || [];
+  $("blocked-rows").replaceChildren(...blocked.map((task) => el("tr", {},
+    el("td", {}, task.key), el("td", {}, task.title), el("td", {}, task.blocked_reason), el("td", {}, task.blocked_for))));
+  if (!blocked.length) $("blocked-rows").append(el("tr", {}, el("td", { colspan: "4" }, "Nothing is blocked")));
+}
+
+async function renderTasks() {
+  const workflow = await loadWorkflow();
+  if (!state.epics.length) state.epics = (await api("epics")).epics;
+  fillSelect($("filter-epic"), state.epics, (e) => e.key + " " + e.title);
+  if (state.selectEpic) {
+    $("filter-epic").value = state.selectEpic;
+    $("filter-feature").value = "";
+    state.selectEpic = null;
+  }
+  fillSelect($("filter-status"), workflow.statuses.map((s) => s.status));
+
+  const epicKey = $("filter-epic").value;
+  state.features = (await api("features" + (epicKey ? "?epic_key=" + encodeURIComponent(epicKey) : ""))).features;
+  fillSelect($("filter-feature"), state.features, (f) => f.key + " " + f.title);
+
+  const params = new URLSearchParams();
+  if (epicKey) params.set("epic_key", epicKey);
+  if ($("filter-feature").value) params.set("feature_key", $("filter-feature").value);
+  if ($("filter-status").value) params.set("status", $("filter-status").value);
+  const tasks = (await api("tasks?" + params)).tasks;
+
+  $("task-rows").replaceChildren(...tasks.map(taskRow));
+  if (!tasks.length) $("task-rows").append(el("tr", {}, el("td", { colspan: "6" }, "No tasks match")));
+}
+
+function taskRow(task) {
+  const meta = state.workflow.statuses.find((s) => s.status === task.status);
+  const next = meta ? meta.next : [];
+  const move = el("td", {});
+  if (next.length) {
+    const select = el("select", {}, ...next.map((status) => el("option", { value: status }, status)));
+    const button = el("button", { type: "button" }, "Move");
+    button.addEventListener("click", () => moveTask(task, select.value, meta.backward.includes(select.value)));
+    move.append(select, " ", button);
+  }
+  return el("tr", {},
+    el("td", {}, task.key),
+    el("td", {}, task.title),
+    el("td", {}, task.agent_type),
+    el("td", {}, task.priority),
+    el("td", {}, statusBadge(task.status)),
+    move);
+}
+
+async function moveTask(task, status, needsReason) {
+  const body = { status, expected_version: task.version };
+  if (needsReason) {
+    const reason = prompt("Moving " + task.key + " back to " + status + " needs a reason:");
+    if (!reason) return;
+    body.rejection_reason = reason;
+  }
+  try {
+    await api("tasks/" + encodeURIComponent(task.key) + "/status", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(body),
+    });
+    showMessage(task.key + " moved to " + status);
+    await renderTasks();
+  } catch (err) {
+    handleError(err);
+  }
+}
+
+function showView(name) {
+  for (const view of ["dashboard", "tasks"]) $(view).hidden = view !== name;
+  for (const link of document.querySelectorAll("nav a")) link.classList.toggle("active", link.dataset.view === name);
+}
+
+async function route() {
+  const name = location.hash === "#tasks" ? "tasks" : "dashboard";
+  $("token-form").hidden = true;
+  $("sign-out").hidden = !localStorage.getItem(tokenKey);
+  showView(name);
+  try {
+    if (name === "tasks") await renderTasks();
+    else await renderDashboard();
+  } catch (err) {
+    handleError(err);
+  }
+}
+
+$("token-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  localStorage.setItem(tokenKey, $("token").value.trim());
+  $("token").value = "";
+  showMessage("");
+  route();
+});
+$("sign-out").addEventListener("click", () => {
+  localStorage.removeItem(tokenKey);
+  state.workflow = null;
+  route();
+});
+$("filter-epic").addEventListener("change", () => { $("filter-feature").value = ""; route(); });
+$("filter-feature").addEventListener("change", route);
+$("filter-status").addEventListener("change", route);
+$("refresh").addEventListener("click", route);
+window.addEventListener("hashchange", route);
+route();
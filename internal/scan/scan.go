@@ -0,0 +1,503 @@
+// Package scan finds markdown files under the plan directories that no epic,
+// feature, task, document, idea, or attachment records, suggests what each
+// one is from the conventions of its path and name, and adopts files by
+// creating the records they suggest, pointing at the existing files.
+//
+// Adoption only creates records. Files are never written, and existing
+// records are never changed.
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/parser"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/taskcreation"
+	"github.com/jwwelbor/shark-task-manager/internal/workflow"
+)
+
+// DefaultDir is the plan directory scanned when none is given
+const DefaultDir = "docs/plan"
+
+// Kinds of record a file can be adopted as
+const (
+	KindEpic     = "epic"
+	KindFeature  = "feature"
+	KindTask     = "task"
+	KindDocument = "document"
+)
+
+// adoptOrder creates parents before the records that need them
+var adoptOrder = []string{KindEpic, KindFeature, KindTask, KindDocument}
+
+var (
+	// Task files are named after their key: T-E01-F02-003.md, T-E01-F02-003-slug.md
+	taskFilePattern = regexp.MustCompile(`^(T-E\d{2}-F\d{2}-\d{3})(?:-.*)?\.md$`)
+
+	// Epic and feature directories start with their key: E01-slug, E01-F02-slug
+	epicDirPattern    = regexp.MustCompile(`^(E\d{2})(?:-(.*))?$`)
+	featureDirPattern = regexp.MustCompile(`^(E\d{2}-F\d{2})(?:-(.*))?$`)
+)
+
+// File is a markdown file that no record claims
+type File struct {
+	Path    string `json:"path"`              // Relative to the project root
+	Kind    string `json:"kind"`              // Suggested record: epic, feature, task, or document
+	Key     string `json:"key,omitempty"`     // Key of the epic, feature, or task; empty for a task numbered on adoption
+	Parent  string `json:"parent,omitempty"`  // Epic of a feature, feature of a task, epic or feature a document is linked to
+	Title   string `json:"title"`             // From the frontmatter, the first heading, or the name
+	Reason  string `json:"reason"`            // Convention the suggestion is based on
+	Problem string `json:"problem,omitempty"` // Why the file can't be adopted as suggested
+	Adopted bool   `json:"adopted"`
+	Error   string `json:"error,omitempty"` // Why adoption failed
+
+	description string
+	priority    int
+	agentType   string
+}
+
+// Adoptable reports whether the file can be adopted as suggested
+func (f *File) Adoptable() bool {
+	return f.Problem == "" && !f.Adopted
+}
+
+// knownRecord is an existing epic, feature, or task
+type knownRecord struct {
+	filePath string
+	deleted  bool
+}
+
+// Scanner finds and adopts untracked markdown files in one project
+type Scanner struct {
+	db          *repository.DB
+	projectRoot string
+}
+
+// NewScanner creates a Scanner for the project at projectRoot
+func NewScanner(db *repository.DB, projectRoot string) *Scanner {
+	return &Scanner{db: db, projectRoot: projectRoot}
+}
+
+// Scan walks dirs (relative to the project root) for markdown files that no
+// record claims and suggests a record for each. Epics, features, and tasks
+// without a recorded file own the file at their key, so it isn't reported.
+func (s *Scanner) Scan(ctx context.Context, dirs []string) ([]*File, error) {
+	claimed, err := s.claimedPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known, err := s.knownRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*File
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		root := s.absPath(dir)
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory", dir)
+		}
+
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if path != root && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.EqualFold(filepath.Ext(path), ".md") {
+				return nil
+			}
+
+			rel := s.relPath(path)
+			if claimed[rel] || seen[rel] {
+				return nil
+			}
+			seen[rel] = true
+
+			file := suggest(rel)
+			if record, ok := known[file.Key]; ok && file.Key != "" && file.Kind != KindDocument && record.filePath == "" && !record.deleted {
+				return nil
+			}
+			readDetails(path, file)
+			files = append(files, file)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	checkProblems(files, known)
+	return files, nil
+}
+
+// suggest picks a record for the file at rel from its path and name
+func suggest(rel string) *File {
+	file := &File{Path: rel, Kind: KindDocument}
+	name := filepath.Base(rel)
+	dirs := strings.Split(filepath.ToSlash(filepath.Dir(rel)), "/")
+
+	// The nearest epic and feature folders the file is in
+	var epicKey, featureKey, folderSlug string
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if m := featureDirPattern.FindStringSubmatch(dirs[i]); m != nil && featureKey == "" {
+			featureKey = m[1]
+			if i == len(dirs)-1 {
+				folderSlug = m[2]
+			}
+		} else if m := epicDirPattern.FindStringSubmatch(dirs[i]); m != nil && epicKey == "" {
+			epicKey = m[1]
+			if i == len(dirs)-1 {
+				folderSlug = m[2]
+			}
+		}
+	}
+	inDir := dirs[len(dirs)-1]
+
+	switch {
+	case taskFilePattern.MatchString(name):
+		m := taskFilePattern.FindStringSubmatch(name)
+		file.Kind, file.Key = KindTask, m[1]
+		file.Parent = strings.TrimPrefix(m[1], "T-")[:7]
+		file.Reason = "named after a task key"
+	case inDir == "tasks" && len(dirs) > 1 && featureDirPattern.MatchString(dirs[len(dirs)-2]):
+		file.Kind, file.Parent = KindTask, featureKey
+		file.Reason = fmt.Sprintf("in the tasks folder of feature %s", featureKey)
+	case name == "feature.md" && featureDirPattern.MatchString(inDir):
+		file.Kind, file.Key, file.Parent = KindFeature, featureKey, featureKey[:3]
+		file.Reason = "feature.md in a feature folder"
+		file.Title = slugTitle(folderSlug)
+	case name == "epic.md" && epicDirPattern.MatchString(inDir):
+		file.Kind, file.Key = KindEpic, epicKey
+		file.Reason = "epic.md in an epic folder"
+		file.Title = slugTitle(folderSlug)
+	case featureKey != "":
+		file.Parent = featureKey
+		file.Reason = fmt.Sprintf("in the folder of feature %s", featureKey)
+	case epicKey != "":
+		file.Parent = epicKey
+		file.Reason = fmt.Sprintf("in the folder of epic %s", epicKey)
+	default:
+		file.Reason = "not in an epic or feature folder"
+	}
+
+	if file.Title == "" {
+		file.Title = slugTitle(strings.TrimSuffix(name, filepath.Ext(name)))
+	}
+	return file
+}
+
+// readDetails fills in the title, description, and task fields from the
+// file's frontmatter and first heading
+func readDetails(path string, file *File) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	content := string(data)
+
+	title := parser.ExtractTitleFromMarkdown(content)
+	if file.Key != "" {
+		// Headings often repeat the key: "# T-E01-F02-003: Add login"
+		title = strings.TrimSpace(strings.TrimPrefix(title, file.Key+":"))
+	}
+	if fm, err := parser.ParseFrontmatter(content); err == nil && fm.HasFrontmatter {
+		if fm.Title != "" {
+			title = fm.Title
+		}
+		file.description = fm.Description
+		file.priority = fm.Priority
+		file.agentType = fm.AgentType
+	}
+	if title != "" {
+		file.Title = title
+	}
+	if file.description == "" {
+		file.description = parser.ExtractDescriptionFromMarkdown(content)
+	}
+}
+
+// checkProblems marks files that can't be adopted as suggested: their key is
+// taken, or their parent neither exists nor is adopted with them
+func checkProblems(files []*File, known map[string]knownRecord) {
+	adopted := make(map[string]bool)
+	for _, kind := range adoptOrder {
+		for _, file := range files {
+			if file.Kind != kind {
+				continue
+			}
+
+			if record, ok := known[file.Key]; ok && file.Key != "" && kind != KindDocument {
+				switch {
+				case record.deleted:
+					file.Problem = fmt.Sprintf("%s %s is in the trash; restore it or delete the file", kind, file.Key)
+				default:
+					file.Problem = fmt.Sprintf("%s %s already exists with file %s", kind, file.Key, record.filePath)
+				}
+				continue
+			}
+			if file.Key != "" && adopted[file.Key] {
+				file.Problem = fmt.Sprintf("another file is adopted as %s %s", kind, file.Key)
+				continue
+			}
+
+			if file.Parent == "" {
+				if kind == KindDocument {
+					file.Problem = "no epic or feature to link it to; use 'shark doc add' or move it into an epic or feature folder"
+				}
+			} else if record, ok := known[file.Parent]; (!ok || record.deleted) && !adopted[file.Parent] {
+				parentKind := KindEpic
+				if strings.Contains(file.Parent, "-F") {
+					parentKind = KindFeature
+				}
+				file.Problem = fmt.Sprintf("%s %s doesn't exist; create or adopt it first", parentKind, file.Parent)
+			}
+
+			if file.Problem == "" && file.Key != "" {
+				adopted[file.Key] = true
+			}
+		}
+	}
+}
+
+// Adopt creates a record for each adoptable file, parents first, and returns
+// how many were adopted. Files that fail are marked with the error.
+func (s *Scanner) Adopt(ctx context.Context, files []*File) int {
+	count := 0
+	for _, kind := range adoptOrder {
+		for _, file := range files {
+			if file.Kind != kind || !file.Adoptable() {
+				continue
+			}
+
+			var err error
+			switch kind {
+			case KindEpic:
+				err = s.adoptEpic(ctx, file)
+			case KindFeature:
+				err = s.adoptFeature(ctx, file)
+			case KindTask:
+				err = s.adoptTask(ctx, file)
+			case KindDocument:
+				err = s.adoptDocument(ctx, file)
+			}
+			if err != nil {
+				file.Error = err.Error()
+				continue
+			}
+			file.Adopted = true
+			count++
+		}
+	}
+	return count
+}
+
+// adoptEpic creates a draft epic for an epic file
+func (s *Scanner) adoptEpic(ctx context.Context, file *File) error {
+	epic := &models.Epic{
+		Key:         file.Key,
+		Title:       file.Title,
+		Description: optional(file.description),
+		Status:      models.EpicStatusDraft,
+		Priority:    models.PriorityMedium,
+		FilePath:    &file.Path,
+	}
+	return repository.NewEpicRepository(s.db).Create(ctx, epic)
+}
+
+// adoptFeature creates a draft feature for a feature file
+func (s *Scanner) adoptFeature(ctx context.Context, file *File) error {
+	epic, err := repository.NewEpicRepository(s.db).GetByKey(ctx, file.Parent)
+	if err != nil {
+		return fmt.Errorf("epic %s not found", file.Parent)
+	}
+	feature := &models.Feature{
+		EpicID:      epic.ID,
+		Key:         file.Key,
+		Title:       file.Title,
+		Description: optional(file.description),
+		Status:      models.FeatureStatusDraft,
+		FilePath:    &file.Path,
+	}
+	return repository.NewFeatureRepository(s.db).Create(ctx, feature)
+}
+
+// adoptTask creates a task in the workflow's initial status for a task file,
+// numbering it after the feature's last task when the file isn't named after
+// a task key
+func (s *Scanner) adoptTask(ctx context.Context, file *File) error {
+	featureRepo := repository.NewFeatureRepository(s.db)
+	taskRepo := repository.NewTaskRepository(s.db)
+
+	feature, err := featureRepo.GetByKey(ctx, file.Parent)
+	if err != nil {
+		return fmt.Errorf("feature %s not found", file.Parent)
+	}
+	key := file.Key
+	if key == "" {
+		if key, err = taskcreation.NewKeyGenerator(taskRepo, featureRepo).GenerateTaskKey(ctx, file.Parent[:3], file.Parent); err != nil {
+			return err
+		}
+	}
+
+	priority := file.priority
+	if models.ValidateTaskPriority(priority) != nil {
+		priority = 5
+	}
+	var agentType *string
+	if file.agentType != "" && models.ValidateAgentType(file.agentType) == nil {
+		agentType = &file.agentType
+	}
+
+	status := workflow.NewService(s.projectRoot).GetInitialStatus()
+	task := &models.Task{
+		FeatureID:   feature.ID,
+		Key:         key,
+		Title:       file.Title,
+		Description: optional(file.description),
+		Status:      status,
+		AgentType:   agentType,
+		Priority:    priority,
+		FilePath:    &file.Path,
+	}
+	if err := taskRepo.Create(ctx, task); err != nil {
+		return err
+	}
+	file.Key = key
+
+	notes := "Adopted from " + file.Path
+	return repository.NewTaskHistoryRepository(s.db).Create(ctx, &models.TaskHistory{
+		TaskID:    task.ID,
+		NewStatus: string(status),
+		Notes:     &notes,
+		Timestamp: time.Now(),
+	})
+}
+
+// adoptDocument links a document file to the epic or feature folder it is in
+func (s *Scanner) adoptDocument(ctx context.Context, file *File) error {
+	docRepo := repository.NewDocumentRepository(s.db)
+	doc, err := docRepo.CreateOrGet(ctx, file.Title, file.Path)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(file.Parent, "-F") {
+		feature, err := repository.NewFeatureRepository(s.db).GetByKey(ctx, file.Parent)
+		if err != nil {
+			return fmt.Errorf("feature %s not found", file.Parent)
+		}
+		return docRepo.LinkToFeature(ctx, feature.ID, doc.ID)
+	}
+	epic, err := repository.NewEpicRepository(s.db).GetByKey(ctx, file.Parent)
+	if err != nil {
+		return fmt.Errorf("epic %s not found", file.Parent)
+	}
+	return docRepo.LinkToEpic(ctx, epic.ID, doc.ID)
+}
+
+// claimedPaths returns the files recorded on any record, relative to the
+// project root. Trashed features and tasks keep their files.
+func (s *Scanner) claimedPaths(ctx context.Context) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT file_path FROM epics WHERE file_path IS NOT NULL
+		UNION ALL SELECT file_path FROM features WHERE file_path IS NOT NULL
+		UNION ALL SELECT file_path FROM tasks WHERE file_path IS NOT NULL
+		UNION ALL SELECT file_path FROM documents
+		UNION ALL SELECT file_path FROM ideas WHERE file_path IS NOT NULL
+		UNION ALL SELECT file_path FROM task_attachments
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list claimed files: %w", err)
+	}
+	defer rows.Close()
+
+	claimed := make(map[string]bool)
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan claimed file: %w", err)
+		}
+		if path != "" {
+			claimed[s.relPath(path)] = true
+		}
+	}
+	return claimed, rows.Err()
+}
+
+// knownRecords returns every epic, feature, and task key with its file
+func (s *Scanner) knownRecords(ctx context.Context) (map[string]knownRecord, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT key, COALESCE(file_path, ''), 0 FROM epics
+		UNION ALL SELECT key, COALESCE(file_path, ''), deleted_at IS NOT NULL FROM features
+		UNION ALL SELECT key, COALESCE(file_path, ''), deleted_at IS NOT NULL FROM tasks
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	defer rows.Close()
+
+	known := make(map[string]knownRecord)
+	for rows.Next() {
+		var key string
+		var record knownRecord
+		if err := rows.Scan(&key, &record.filePath, &record.deleted); err != nil {
+			return nil, fmt.Errorf("failed to scan key: %w", err)
+		}
+		if record.filePath != "" {
+			record.filePath = s.relPath(record.filePath)
+		}
+		known[key] = record
+	}
+	return known, rows.Err()
+}
+
+// relPath returns path relative to the project root, with forward slashes
+func (s *Scanner) relPath(path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(s.projectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// absPath returns path resolved against the project root
+func (s *Scanner) absPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(s.projectRoot, filepath.FromSlash(path))
+}
+
+// slugTitle turns a slug such as user-login into a title: User Login
+func slugTitle(slug string) string {
+	words := strings.FieldsFunc(slug, func(r rune) bool { return r == '-' || r == '_' || r == ' ' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// optional returns nil for an empty string
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
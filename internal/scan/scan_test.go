@@ -0,0 +1,132 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		path   string
+		kind   string
+		key    string
+		parent string
+		title  string
+	}{
+		{"docs/plan/E05-payments/epic.md", KindEpic, "E05", "", "Payments"},
+		{"docs/plan/E05-payments/E05-F02-refunds/feature.md", KindFeature, "E05-F02", "E05", "Refunds"},
+		{"docs/plan/E05-payments/E05-F02-refunds/tasks/T-E05-F02-003.md", KindTask, "T-E05-F02-003", "E05-F02", "T E05 F02 003"},
+		{"docs/plan/E05-payments/E05-F02-refunds/tasks/partial-refunds.md", KindTask, "", "E05-F02", "Partial Refunds"},
+		{"docs/plan/E05-payments/E05-F02-refunds/prd.md", KindDocument, "", "E05-F02", "Prd"},
+		{"docs/plan/E05-payments/research/vendors.md", KindDocument, "", "E05", "Vendors"},
+		{"docs/plan/notes.md", KindDocument, "", "", "Notes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			file := suggest(tt.path)
+			assert.Equal(t, tt.kind, file.Kind)
+			assert.Equal(t, tt.key, file.Key)
+			assert.Equal(t, tt.parent, file.Parent)
+			assert.Equal(t, tt.title, file.Title)
+			assert.NotEmpty(t, file.Reason)
+		})
+	}
+}
+
+func TestScanAndAdopt(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &repository.DB{DB: testDB}
+	defer database.Close()
+
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	// E01 is tracked; its epic file is claimed and its tasks folder has a new task
+	trackedPath := "docs/plan/E01-auth/epic.md"
+	require.NoError(t, repository.NewEpicRepository(database).Create(ctx, &models.Epic{
+		Key: "E01", Title: "Auth", Status: models.EpicStatusActive, Priority: models.PriorityHigh, FilePath: &trackedPath,
+	}))
+	write(trackedPath, "# Auth\n")
+	write("docs/plan/E01-auth/research.md", "# Auth research\n")
+
+	// E02 and its feature and task are untracked
+	write("docs/plan/E02-growth/epic.md", "---\ntitle: Growth push\n---\n# Ignored\n")
+	write("docs/plan/E02-growth/E02-F01-referrals/feature.md", "# E02-F01: Referral program\n\nInvite friends.\n")
+	write("docs/plan/E02-growth/E02-F01-referrals/tasks/invite-link.md", "# Build invite link\n")
+
+	// Neither can be adopted: E03 doesn't exist and notes.md has no parent
+	write("docs/plan/E03-x/overview.md", "# Overview\n")
+	write("docs/plan/notes.md", "# Notes\n")
+	write("docs/plan/.drafts/skip.md", "# Hidden\n")
+
+	scanner := NewScanner(database, root)
+	files, err := scanner.Scan(ctx, []string{DefaultDir})
+	require.NoError(t, err)
+	require.Len(t, files, 6)
+
+	byPath := make(map[string]*File)
+	for _, file := range files {
+		byPath[file.Path] = file
+	}
+	assert.Equal(t, "Growth push", byPath["docs/plan/E02-growth/epic.md"].Title)
+	assert.Equal(t, "Referral program", byPath["docs/plan/E02-growth/E02-F01-referrals/feature.md"].Title)
+	assert.NotEmpty(t, byPath["docs/plan/E03-x/overview.md"].Problem)
+	assert.NotEmpty(t, byPath["docs/plan/notes.md"].Problem)
+
+	assert.Equal(t, 4, scanner.Adopt(ctx, files))
+	task := byPath["docs/plan/E02-growth/E02-F01-referrals/tasks/invite-link.md"]
+	assert.True(t, task.Adopted, task.Error)
+	assert.Equal(t, "T-E02-F01-001", task.Key)
+
+	epic, err := repository.NewEpicRepository(database).GetByKey(ctx, "E02")
+	require.NoError(t, err)
+	assert.Equal(t, "Growth push", epic.Title)
+	require.NotNil(t, epic.FilePath)
+	assert.Equal(t, "docs/plan/E02-growth/epic.md", *epic.FilePath)
+
+	created, err := repository.NewTaskRepository(database).GetByKey(ctx, "T-E02-F01-001")
+	require.NoError(t, err)
+	assert.Equal(t, "Build invite link", created.Title)
+
+	// Adopted files are tracked on the next scan
+	files, err = scanner.Scan(ctx, []string{DefaultDir})
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestScan_KeyTaken(t *testing.T) {
+	ctx := context.Background()
+	testDB, err := db.InitDB(":memory:")
+	require.NoError(t, err)
+	database := &repository.DB{DB: testDB}
+	defer database.Close()
+
+	root := t.TempDir()
+	otherPath := "docs/epics/auth.md"
+	require.NoError(t, repository.NewEpicRepository(database).Create(ctx, &models.Epic{
+		Key: "E01", Title: "Auth", Status: models.EpicStatusActive, Priority: models.PriorityHigh, FilePath: &otherPath,
+	}))
+	dir := filepath.Join(root, "docs/plan/E01-auth")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "epic.md"), []byte("# Auth\n"), 0644))
+
+	files, err := NewScanner(database, root).Scan(ctx, []string{DefaultDir})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, files[0].Problem, "already exists")
+	assert.False(t, files[0].Adoptable())
+}
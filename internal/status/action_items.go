@@ -5,6 +5,7 @@ import (
 
 	"github.com/jwwelbor/shark-task-manager/internal/config"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
 )
 
 // GetActionItems categorizes tasks requiring immediate attention
@@ -14,7 +15,8 @@ import (
 // - Blocked: tasks in blocked status with their blocking reasons
 // - InProgress: tasks in in_progress or in_development status
 //
-// For waiting tasks, it calculates how many days they've been waiting.
+// For waiting tasks, it calculates how long they've been waiting (AgeDays and
+// the humanized Age). Blocked tasks get an Age when their blocked_at is known.
 // For blocked tasks, it extracts the reason for blocking.
 //
 // Parameters:
@@ -42,23 +44,31 @@ func GetActionItems(tasks []*models.Task, cfg *config.WorkflowConfig) *ActionIte
 			// Calculate age in days for waiting tasks (human approval)
 			// Note: ready_for_code_review is not included here as it's for automated checks
 			ageDays := int(now.Sub(task.UpdatedAt).Hours() / 24)
+			age := utils.HumanizeDuration(now.Sub(task.UpdatedAt))
 			items.AwaitingApproval = append(items.AwaitingApproval, &TaskActionItem{
 				TaskKey:       task.Key,
 				Title:         task.Title,
 				Status:        statusStr,
 				AgeDays:       &ageDays,
+				Age:           &age,
 				BlockedReason: nil,
 			})
 
 		case "blocked":
-			// For blocked tasks, include the block reason if available
+			// For blocked tasks, include how long they have been blocked when known
 			// Note: block reason would typically come from task history or additional field
 			// For now, we use a pointer to nil since the reason isn't in the current model
+			var age *string
+			if task.BlockedAt.Valid {
+				blockedFor := utils.HumanizeDuration(now.Sub(task.BlockedAt.Time))
+				age = &blockedFor
+			}
 			items.Blocked = append(items.Blocked, &TaskActionItem{
 				TaskKey:       task.Key,
 				Title:         task.Title,
 				Status:        statusStr,
 				AgeDays:       nil,
+				Age:           age,
 				BlockedReason: nil,
 			})
 
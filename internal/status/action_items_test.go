@@ -1,6 +1,7 @@
 package status
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -372,6 +373,46 @@ func TestGetActionItems_BlockedTaskMetadata(t *testing.T) {
 	}
 }
 
+func TestGetActionItems_HumanizedAge(t *testing.T) {
+	now := time.Now()
+	tasks := []*models.Task{
+		{
+			ID:        1,
+			Key:       "E07-F01-001",
+			Title:     "Waiting task",
+			Status:    "ready_for_approval",
+			UpdatedAt: now.Add(-72 * time.Hour),
+		},
+		{
+			ID:        2,
+			Key:       "E07-F01-002",
+			Title:     "Blocked task",
+			Status:    "blocked",
+			UpdatedAt: now,
+			BlockedAt: sql.NullTime{Time: now.Add(-5 * time.Hour), Valid: true},
+		},
+		{
+			ID:        3,
+			Key:       "E07-F01-003",
+			Title:     "Blocked task without timestamp",
+			Status:    "blocked",
+			UpdatedAt: now,
+		},
+	}
+
+	items := GetActionItems(tasks, nil)
+
+	if age := items.AwaitingApproval[0].Age; age == nil || *age != "3 days" {
+		t.Errorf("Awaiting approval Age: got %v, want '3 days'", age)
+	}
+	if age := items.Blocked[0].Age; age == nil || *age != "5 hours" {
+		t.Errorf("Blocked Age: got %v, want '5 hours'", age)
+	}
+	if age := items.Blocked[1].Age; age != nil {
+		t.Errorf("Blocked Age without blocked_at: got %v, want nil", *age)
+	}
+}
+
 func TestGetActionItems_InProgressTaskMetadata(t *testing.T) {
 	tasks := []*models.Task{
 		{
@@ -0,0 +1,53 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+// formatAgentWorkloads formats the tasks held by each agent
+func formatAgentWorkloads(workloads []*models.AgentWorkload, noColor bool) string {
+	var sb strings.Builder
+
+	// Header
+	if noColor {
+		sb.WriteString("\n=== AGENTS ===\n")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint("AGENTS"))
+		sb.WriteString("\n")
+	}
+
+	if len(workloads) == 0 {
+		sb.WriteString("\nNo agents registered or holding tasks (register one with 'shark agent register')\n")
+		return sb.String()
+	}
+
+	for _, workload := range workloads {
+		name := workload.Agent
+		if workload.AgentType != nil {
+			name += " (" + *workload.AgentType + ")"
+		}
+		seen := "not registered"
+		if workload.LastSeen != nil {
+			seen = "last seen " + utils.FormatRelativeTime(*workload.LastSeen)
+		}
+		if !noColor {
+			name = pterm.Cyan(name)
+			seen = pterm.Gray(seen)
+		}
+		sb.WriteString(fmt.Sprintf("\n%s  %s\n", name, seen))
+
+		line := fmt.Sprintf("   %d in progress, %d blocked, %d completed", workload.InProgress, workload.Blocked, workload.Completed)
+		if len(workload.Tasks) > 0 {
+			line += ": " + strings.Join(workload.Tasks, ", ")
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,58 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+func TestGetDashboard_ByAgent(t *testing.T) {
+	ctx := context.Background()
+	database := setupQuotaTestDB(t, []string{"in_progress", "in_progress", "blocked", "todo"})
+	backend := "backend"
+	if err := repository.NewAgentRepository(database).Register(ctx, &models.Agent{Name: "worker-1", AgentType: &backend}); err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+	if _, err := database.ExecContext(ctx, `
+		UPDATE tasks SET assigned_agent = CASE key WHEN 'T-E01-F01-001' THEN 'worker-1' ELSE 'someone' END
+		WHERE key IN ('T-E01-F01-001', 'T-E01-F01-002', 'T-E01-F01-003')
+	`); err != nil {
+		t.Fatalf("Failed to assign tasks: %v", err)
+	}
+	service := NewStatusService(database)
+
+	dashboard, err := service.GetDashboard(ctx, &StatusRequest{})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if dashboard.Agents != nil {
+		t.Errorf("Expected no agents without ByAgent, got %+v", dashboard.Agents)
+	}
+
+	dashboard, err = service.GetDashboard(ctx, &StatusRequest{ByAgent: true})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if len(dashboard.Agents) != 2 {
+		t.Fatalf("Expected two agents, got %+v", dashboard.Agents)
+	}
+	someone, worker := dashboard.Agents[0], dashboard.Agents[1]
+	if someone.Agent != "someone" || someone.Registered || someone.InProgress != 1 || someone.Blocked != 1 {
+		t.Errorf("Expected unregistered someone with 1 in progress and 1 blocked, got %+v", someone)
+	}
+	if worker.Agent != "worker-1" || !worker.Registered || worker.InProgress != 1 || worker.Tasks[0] != "T-E01-F01-001" {
+		t.Errorf("Expected worker-1 holding T-E01-F01-001, got %+v", worker)
+	}
+	if dashboard.Filter == nil || !dashboard.Filter.ByAgent {
+		t.Errorf("Expected the filter to record ByAgent, got %+v", dashboard.Filter)
+	}
+
+	output := FormatDashboard(dashboard, true)
+	if !strings.Contains(output, "worker-1 (backend)  last seen") || !strings.Contains(output, "someone  not registered") ||
+		!strings.Contains(output, "   1 in progress, 0 blocked, 0 completed: T-E01-F01-001\n") {
+		t.Errorf("Unexpected agent output:\n%s", output)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
 )
 
@@ -18,8 +19,12 @@ type CalculationService struct {
 	cfg         *config.WorkflowConfig
 }
 
-// NewCalculationService creates a new StatusCalculationService
+// NewCalculationService creates a new StatusCalculationService.
+// A nil cfg (no status_flow in .sharkconfig.json) uses the default workflow.
 func NewCalculationService(db *repository.DB, cfg *config.WorkflowConfig) *CalculationService {
+	if cfg == nil {
+		cfg = config.DefaultWorkflow()
+	}
 	return &CalculationService{
 		featureRepo: repository.NewFeatureRepository(db),
 		epicRepo:    repository.NewEpicRepository(db),
@@ -76,6 +81,13 @@ func (s *CalculationService) RecalculateFeatureStatus(ctx context.Context, featu
 		return result, nil
 	}
 
+	// Draft features stay draft when auto-activation is turned off
+	if !s.cfg.AutoActivateEnabled() && feature.Status == models.FeatureStatusDraft && derivedStatus == models.FeatureStatusActive {
+		result.WasSkipped = true
+		result.SkipReason = "auto_activate disabled"
+		return result, nil
+	}
+
 	// Update the status
 	updated, err := s.featureRepo.UpdateStatusIfNotOverridden(ctx, featureID, derivedStatus)
 	if err != nil {
@@ -134,6 +146,13 @@ func (s *CalculationService) RecalculateEpicStatus(ctx context.Context, epicID i
 		return result, nil
 	}
 
+	// Draft epics stay draft when auto-activation is turned off
+	if !s.cfg.AutoActivateEnabled() && epic.Status == models.EpicStatusDraft && derivedStatus == models.EpicStatusActive {
+		result.WasSkipped = true
+		result.SkipReason = "auto_activate disabled"
+		return result, nil
+	}
+
 	// Update the status (epics don't have override yet, so always update)
 	err = s.epicRepo.UpdateStatus(ctx, epicID, derivedStatus)
 	if err != nil {
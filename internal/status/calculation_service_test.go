@@ -50,6 +50,15 @@ func createTestWorkflowConfig() *config.WorkflowConfig {
 	}
 }
 
+// workflowWithoutAutoActivate returns the default workflow with auto-activation off,
+// so task status updates in test setup leave feature and epic status to the service
+func workflowWithoutAutoActivate() *config.WorkflowConfig {
+	cfg := config.DefaultWorkflow()
+	disabled := false
+	cfg.AutoActivate = &disabled
+	return cfg
+}
+
 func TestCalculationService_RecalculateFeatureStatus(t *testing.T) {
 	ctx := context.Background()
 	testDB := setupTestDB(t)
@@ -57,7 +66,7 @@ func TestCalculationService_RecalculateFeatureStatus(t *testing.T) {
 
 	featureRepo := repository.NewFeatureRepository(testDB)
 	epicRepo := repository.NewEpicRepository(testDB)
-	taskRepo := repository.NewTaskRepository(testDB)
+	taskRepo := repository.NewTaskRepositoryWithWorkflow(testDB, workflowWithoutAutoActivate())
 	cfg := createTestWorkflowConfig()
 	calcService := NewCalculationService(testDB, cfg)
 
@@ -321,7 +330,7 @@ func TestCalculationService_RecalculateAll(t *testing.T) {
 
 	featureRepo := repository.NewFeatureRepository(testDB)
 	epicRepo := repository.NewEpicRepository(testDB)
-	taskRepo := repository.NewTaskRepository(testDB)
+	taskRepo := repository.NewTaskRepositoryWithWorkflow(testDB, workflowWithoutAutoActivate())
 	cfg := createTestWorkflowConfig()
 	calcService := NewCalculationService(testDB, cfg)
 
@@ -383,3 +392,41 @@ func TestCalculationService_RecalculateAll(t *testing.T) {
 	// Duration may be 0 for fast operations (sub-millisecond)
 	assert.GreaterOrEqual(t, summary.DurationMs, int64(0))
 }
+
+func TestCalculationService_AutoActivateDisabled(t *testing.T) {
+	ctx := context.Background()
+	testDB := setupTestDB(t)
+	defer testDB.Close()
+
+	featureRepo := repository.NewFeatureRepository(testDB)
+	epicRepo := repository.NewEpicRepository(testDB)
+	taskRepo := repository.NewTaskRepositoryWithWorkflow(testDB, workflowWithoutAutoActivate())
+	calcService := NewCalculationService(testDB, workflowWithoutAutoActivate())
+
+	epic := &models.Epic{Key: "E01", Title: "Test Epic", Status: models.EpicStatusDraft, Priority: models.PriorityMedium}
+	require.NoError(t, epicRepo.Create(ctx, epic))
+	feature := &models.Feature{EpicID: epic.ID, Key: "E01-F01", Title: "Test Feature", Status: models.FeatureStatusDraft}
+	require.NoError(t, featureRepo.Create(ctx, feature))
+	task := &models.Task{FeatureID: feature.ID, Key: "T-E01-F01-001", Title: "Task", Status: models.TaskStatusTodo, Priority: 5}
+	require.NoError(t, taskRepo.Create(ctx, task))
+	require.NoError(t, taskRepo.UpdateStatus(ctx, task.ID, models.TaskStatusInProgress, nil, nil))
+
+	results, err := calcService.CascadeFromFeatureID(ctx, feature.ID)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Feature promotion is skipped, so the epic has no active feature either
+	assert.False(t, results[0].WasChanged)
+	assert.True(t, results[0].WasSkipped)
+	assert.Equal(t, "auto_activate disabled", results[0].SkipReason)
+	assert.False(t, results[1].WasChanged)
+	assert.Equal(t, "draft", results[1].NewStatus)
+
+	// Draft epics are not promoted either, even with an active feature
+	feature.Status = models.FeatureStatusActive
+	require.NoError(t, featureRepo.Update(ctx, feature))
+	epicResult, err := calcService.RecalculateEpicStatus(ctx, epic.ID)
+	require.NoError(t, err)
+	assert.True(t, epicResult.WasSkipped)
+	assert.Equal(t, "auto_activate disabled", epicResult.SkipReason)
+}
@@ -392,3 +392,12 @@ func TestIsFeatureCompletedStatus(t *testing.T) {
 		})
 	}
 }
+
+// TestDeriveFeatureStatus_BlockedPhase verifies the default workflow's "blocked" phase counts as active work
+func TestDeriveFeatureStatus_BlockedPhase(t *testing.T) {
+	cfg := config.DefaultWorkflow()
+	got := DeriveFeatureStatus(map[string]int{"todo": 2, "blocked": 1}, cfg)
+	if got != models.FeatureStatusActive {
+		t.Errorf("DeriveFeatureStatus() = %v, want %v", got, models.FeatureStatusActive)
+	}
+}
@@ -0,0 +1,157 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// EpicStatusReport is the output of the epic status dashboard
+type EpicStatusReport struct {
+	Epics  []*EpicStatus    `json:"epics"`
+	Filter *DashboardFilter `json:"filter,omitempty"`
+}
+
+// EpicStatus extends an epic summary with its status and task breakdown
+type EpicStatus struct {
+	*EpicSummary
+	Status       string             `json:"status"`
+	Tasks        *StatusBreakdown   `json:"tasks"`
+	StatusCounts map[string]int     `json:"status_counts"` // Task counts for every status, including custom workflow statuses
+	BlockedTasks []*BlockedTaskInfo `json:"blocked_tasks"`
+}
+
+// GetEpicStatus generates the per-epic status dashboard. The request's
+// RecentWindow and quota settings are ignored.
+func (s *StatusService) GetEpicStatus(ctx context.Context, req *StatusRequest) (*EpicStatusReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	summaries, err := s.getEpics(ctx, req.EpicKey, req.Labels, req.Weighted, req.Health)
+	if err != nil {
+		return nil, err
+	}
+
+	epicStatuses, statusCounts, err := s.getEpicTaskStatusCounts(ctx, req.EpicKey, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedTasks, err := s.getBlockedTasks(ctx, req.EpicKey, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	blockedByEpic := make(map[string][]*BlockedTaskInfo)
+	for _, task := range blockedTasks {
+		blockedByEpic[task.Epic] = append(blockedByEpic[task.Epic], task)
+	}
+
+	report := &EpicStatusReport{Epics: make([]*EpicStatus, 0, len(summaries))}
+	for _, summary := range summaries {
+		counts := statusCounts[summary.Key]
+		if counts == nil {
+			counts = map[string]int{}
+		}
+		blocked := blockedByEpic[summary.Key]
+		if blocked == nil {
+			blocked = []*BlockedTaskInfo{}
+		}
+
+		report.Epics = append(report.Epics, &EpicStatus{
+			EpicSummary:  summary,
+			Status:       epicStatuses[summary.Key],
+			Tasks:        statusBreakdownFromCounts(counts),
+			StatusCounts: counts,
+			BlockedTasks: blocked,
+		})
+	}
+
+	if req.EpicKey != "" || len(req.Labels) > 0 {
+		report.Filter = &DashboardFilter{Labels: req.Labels}
+		if req.EpicKey != "" {
+			report.Filter.EpicKey = &req.EpicKey
+		}
+	}
+
+	return report, nil
+}
+
+// getEpicTaskStatusCounts returns the status of each epic and its task counts by status
+func (s *StatusService) getEpicTaskStatusCounts(ctx context.Context, epicKey string, labels []string) (map[string]string, map[string]map[string]int, error) {
+	// Label filter restricts counted tasks; join arguments precede the WHERE arguments
+	taskJoinFilter, args := taskLabelJoinFilter(labels)
+
+	var epicFilter string
+	if epicKey != "" {
+		epicFilter = "WHERE e.key = ?"
+		args = append(args, epicKey)
+	}
+
+	query := `
+		SELECT e.key, e.status, t.status, COUNT(DISTINCT t.id)
+		FROM epics e
+		LEFT JOIN features f ON e.id = f.epic_id AND f.deleted_at IS NULL
+		LEFT JOIN tasks t ON f.id = t.feature_id AND t.deleted_at IS NULL` + taskJoinFilter + `
+		` + epicFilter + `
+		GROUP BY e.key, e.status, t.status
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query epic task status counts: %w", err)
+	}
+	defer rows.Close()
+
+	epicStatuses := make(map[string]string)
+	counts := make(map[string]map[string]int)
+	for rows.Next() {
+		var key, epicStatus string
+		var taskStatus sql.NullString
+		var count int
+		if err := rows.Scan(&key, &epicStatus, &taskStatus, &count); err != nil {
+			return nil, nil, fmt.Errorf("scan epic task status row: %w", err)
+		}
+
+		epicStatuses[key] = epicStatus
+		if !taskStatus.Valid {
+			continue
+		}
+		if counts[key] == nil {
+			counts[key] = make(map[string]int)
+		}
+		counts[key][taskStatus.String] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterate epic task status rows: %w", err)
+	}
+
+	return epicStatuses, counts, nil
+}
+
+// statusBreakdownFromCounts builds a StatusBreakdown from task counts by status.
+// Statuses outside the breakdown's fields only contribute to the total.
+func statusBreakdownFromCounts(counts map[string]int) *StatusBreakdown {
+	breakdown := &StatusBreakdown{}
+	for taskStatus, count := range counts {
+		breakdown.Total += count
+		switch taskStatus {
+		case "todo":
+			breakdown.Todo = count
+		case "in_progress":
+			breakdown.InProgress = count
+		case "ready_for_review":
+			breakdown.ReadyForReview = count
+		case "completed":
+			breakdown.Completed = count
+		case "blocked":
+			breakdown.Blocked = count
+		}
+	}
+	return breakdown
+}
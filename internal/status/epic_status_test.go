@@ -0,0 +1,137 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/test"
+)
+
+// TestGetEpicStatus_BreakdownAndBlocked verifies per-epic status counts and blocked task grouping
+func TestGetEpicStatus_BreakdownAndBlocked(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	db := repository.NewDB(database)
+	service := NewStatusService(db)
+
+	epicKey := "E97"
+	cleanup := func() {
+		_, _ = database.ExecContext(ctx, "DELETE FROM tasks WHERE feature_id IN (SELECT id FROM features WHERE epic_id IN (SELECT id FROM epics WHERE key = ?))", epicKey)
+		_, _ = database.ExecContext(ctx, "DELETE FROM features WHERE epic_id IN (SELECT id FROM epics WHERE key = ?)", epicKey)
+		_, _ = database.ExecContext(ctx, "DELETE FROM epics WHERE key = ?", epicKey)
+	}
+	cleanup()
+	defer cleanup()
+
+	result, err := database.ExecContext(ctx, `
+		INSERT INTO epics (key, title, description, status, priority)
+		VALUES (?, 'Epic Status Test', 'Test epic', 'active', 'high')
+	`, epicKey)
+	if err != nil {
+		t.Fatalf("Failed to create test epic: %v", err)
+	}
+	epicID, _ := result.LastInsertId()
+
+	result, err = database.ExecContext(ctx, `
+		INSERT INTO features (epic_id, key, title, status)
+		VALUES (?, 'E97-F01', 'Feature One', 'active'), (?, 'E97-F02', 'Feature Two', 'draft')
+	`, epicID, epicID)
+	if err != nil {
+		t.Fatalf("Failed to create test features: %v", err)
+	}
+	featureID, _ := result.LastInsertId()
+
+	_, err = database.ExecContext(ctx, `
+		INSERT INTO tasks (feature_id, key, title, status, agent_type, priority, depends_on, blocked_reason)
+		VALUES
+			(?, 'T-E97-F02-001', 'Done', 'completed', 'backend', 5, '[]', NULL),
+			(?, 'T-E97-F02-002', 'Done too', 'completed', 'backend', 5, '[]', NULL),
+			(?, 'T-E97-F02-003', 'Review', 'ready_for_review', 'backend', 5, '[]', NULL),
+			(?, 'T-E97-F02-004', 'Stuck', 'blocked', 'backend', 5, '[]', 'Waiting on API')
+	`, featureID, featureID, featureID, featureID)
+	if err != nil {
+		t.Fatalf("Failed to create test tasks: %v", err)
+	}
+
+	report, err := service.GetEpicStatus(ctx, &StatusRequest{EpicKey: epicKey})
+	if err != nil {
+		t.Fatalf("GetEpicStatus failed: %v", err)
+	}
+
+	if len(report.Epics) != 1 {
+		t.Fatalf("Expected 1 epic, got %d", len(report.Epics))
+	}
+	epic := report.Epics[0]
+
+	if epic.Status != "active" {
+		t.Errorf("Expected epic status active, got %s", epic.Status)
+	}
+	if epic.FeaturesTotal != 2 || epic.FeaturesActive != 1 {
+		t.Errorf("Expected 1/2 active features, got %d/%d", epic.FeaturesActive, epic.FeaturesTotal)
+	}
+	if epic.Tasks.Total != 4 || epic.Tasks.Completed != 2 || epic.Tasks.ReadyForReview != 1 || epic.Tasks.Blocked != 1 {
+		t.Errorf("Unexpected task breakdown: %+v", epic.Tasks)
+	}
+	if epic.StatusCounts["completed"] != 2 {
+		t.Errorf("Expected status_counts completed=2, got %d", epic.StatusCounts["completed"])
+	}
+	// 50% progress with a blocked task
+	if epic.Health != "warning" {
+		t.Errorf("Expected warning health, got %s", epic.Health)
+	}
+
+	if len(epic.BlockedTasks) != 1 || epic.BlockedTasks[0].Key != "T-E97-F02-004" {
+		t.Fatalf("Expected blocked task T-E97-F02-004, got %+v", epic.BlockedTasks)
+	}
+	if epic.BlockedTasks[0].BlockedReason == nil || *epic.BlockedTasks[0].BlockedReason != "Waiting on API" {
+		t.Error("Expected blocked reason to be set")
+	}
+
+	// Epic summary fields are flattened into each epic like in 'shark status'
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Failed to marshal report: %v", err)
+	}
+	var decoded struct {
+		Epics []map[string]interface{} `json:"epics"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal report: %v", err)
+	}
+	for _, field := range []string{"key", "health", "progress_percent", "status", "tasks", "blocked_tasks"} {
+		if _, ok := decoded.Epics[0][field]; !ok {
+			t.Errorf("JSON missing %q field", field)
+		}
+	}
+}
+
+// TestGetEpicStatus_EpicWithoutTasks verifies empty epics report zero counts
+func TestGetEpicStatus_EpicWithoutTasks(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	service := NewStatusService(repository.NewDB(database))
+
+	epicKey := "E96"
+	_, _ = database.ExecContext(ctx, "DELETE FROM epics WHERE key = ?", epicKey)
+	defer func() { _, _ = database.ExecContext(ctx, "DELETE FROM epics WHERE key = ?", epicKey) }()
+
+	if _, err := database.ExecContext(ctx, `
+		INSERT INTO epics (key, title, status, priority) VALUES (?, 'Empty Epic', 'draft', 'low')
+	`, epicKey); err != nil {
+		t.Fatalf("Failed to create test epic: %v", err)
+	}
+
+	report, err := service.GetEpicStatus(ctx, &StatusRequest{EpicKey: epicKey})
+	if err != nil {
+		t.Fatalf("GetEpicStatus failed: %v", err)
+	}
+	if len(report.Epics) != 1 {
+		t.Fatalf("Expected 1 epic, got %d", len(report.Epics))
+	}
+	epic := report.Epics[0]
+	if epic.Status != "draft" || epic.Tasks.Total != 0 || len(epic.StatusCounts) != 0 || len(epic.BlockedTasks) != 0 {
+		t.Errorf("Unexpected empty epic status: %+v", epic)
+	}
+}
@@ -138,21 +138,7 @@ func formatEpicTable(epics []*EpicSummary, noColor bool, termWidth int) string {
 
 	for _, epic := range epics {
 		// Format health indicator
-		var healthStr string
-		if noColor {
-			healthStr = epic.Health
-		} else {
-			switch epic.Health {
-			case "healthy":
-				healthStr = pterm.Green("●") + " healthy"
-			case "warning":
-				healthStr = pterm.Yellow("●") + " warning"
-			case "critical":
-				healthStr = pterm.Red("●") + " critical"
-			default:
-				healthStr = epic.Health
-			}
-		}
+		healthStr := formatHealth(epic.Health, noColor)
 
 		// Progress bar
 		progressBar := renderProgressBar(epic.ProgressPercent, progressBarWidth, noColor)
@@ -177,24 +163,100 @@ func formatEpicTable(epics []*EpicSummary, noColor bool, termWidth int) string {
 	}
 
 	// Render table
+	sb.WriteString(renderTable(tableData, noColor))
+
+	return sb.String()
+}
+
+// formatFeatureDetail formats each epic's features with health, blocked
+// counts, and the agents holding in-progress tasks
+func formatFeatureDetail(epics []*EpicSummary, noColor bool) string {
+	var sb strings.Builder
+
 	if noColor {
-		// Simple text table for no-color mode
-		for i, row := range tableData {
-			if i == 0 {
-				sb.WriteString(strings.Join(row, " | "))
-				sb.WriteString("\n")
-				sb.WriteString(strings.Repeat("-", 80))
-				sb.WriteString("\n")
-			} else {
-				sb.WriteString(strings.Join(row, " | "))
-				sb.WriteString("\n")
+		sb.WriteString("\n=== FEATURES ===\n")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint("FEATURES"))
+		sb.WriteString("\n")
+	}
+
+	for _, epic := range epics {
+		sb.WriteString("\n")
+		if noColor {
+			sb.WriteString(fmt.Sprintf("## %s: %s\n", epic.Key, epic.Title))
+		} else {
+			sb.WriteString(pterm.DefaultSection.Sprintf("%s: %s", epic.Key, epic.Title))
+			sb.WriteString("\n")
+		}
+
+		if len(epic.Features) == 0 {
+			sb.WriteString("  No features\n")
+			continue
+		}
+
+		tableData := pterm.TableData{
+			{"Key", "Title", "Progress", "Health", "Tasks", "Blocked", "Active Agents"},
+		}
+		for _, feature := range epic.Features {
+			agents := make([]string, 0, len(feature.ActiveAgents))
+			for _, assignment := range feature.ActiveAgents {
+				agents = append(agents, fmt.Sprintf("%s (%s)", assignment.Agent, strings.Join(assignment.Tasks, ", ")))
 			}
+			agentsStr := strings.Join(agents, "; ")
+			if agentsStr == "" {
+				agentsStr = "-"
+			}
+
+			tableData = append(tableData, []string{
+				feature.Key,
+				feature.Title,
+				renderProgressBar(feature.ProgressPercent, 20, noColor),
+				formatHealth(feature.Health, noColor),
+				fmt.Sprintf("%d/%d", feature.TasksCompleted, feature.TasksTotal),
+				fmt.Sprintf("%d", feature.TasksBlocked),
+				agentsStr,
+			})
 		}
-	} else {
+		sb.WriteString(renderTable(tableData, noColor))
+	}
+
+	return sb.String()
+}
+
+// formatHealth formats an epic health value with a colored indicator
+func formatHealth(health string, noColor bool) string {
+	if noColor {
+		return health
+	}
+	switch health {
+	case "healthy":
+		return pterm.Green("●") + " healthy"
+	case "warning":
+		return pterm.Yellow("●") + " warning"
+	case "critical":
+		return pterm.Red("●") + " critical"
+	default:
+		return health
+	}
+}
+
+// renderTable renders table data as plain text in no-color mode or as a pterm table
+func renderTable(tableData pterm.TableData, noColor bool) string {
+	if !noColor {
 		tableStr, _ := pterm.DefaultTable.WithHasHeader().WithData(tableData).Srender()
-		sb.WriteString(tableStr)
+		return tableStr
 	}
 
+	var sb strings.Builder
+	for i, row := range tableData {
+		sb.WriteString(strings.Join(row, " | "))
+		sb.WriteString("\n")
+		if i == 0 {
+			sb.WriteString(strings.Repeat("-", 80))
+			sb.WriteString("\n")
+		}
+	}
 	return sb.String()
 }
 
@@ -303,6 +365,9 @@ func formatBlockedTasks(blockedTasks []*BlockedTaskInfo, noColor bool) string {
 			sb.WriteString(fmt.Sprintf("%d. %s: %s\n", i+1, task.Key, task.Title))
 			sb.WriteString(fmt.Sprintf("   Feature: %s\n", task.Feature))
 			sb.WriteString(fmt.Sprintf("   Reason: %s\n", reason))
+			if task.BlockedFor != nil {
+				sb.WriteString(fmt.Sprintf("   Blocked for: %s\n", *task.BlockedFor))
+			}
 		} else {
 			sb.WriteString(fmt.Sprintf("%d. %s: %s\n",
 				i+1,
@@ -310,6 +375,35 @@ func formatBlockedTasks(blockedTasks []*BlockedTaskInfo, noColor bool) string {
 				task.Title))
 			sb.WriteString(fmt.Sprintf("   Feature: %s\n", pterm.Gray(task.Feature)))
 			sb.WriteString(fmt.Sprintf("   Reason: %s\n", pterm.Yellow(reason)))
+			if task.BlockedFor != nil {
+				sb.WriteString(fmt.Sprintf("   Blocked for: %s\n", pterm.Cyan(*task.BlockedFor)))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// formatLeasedTasks formats todo tasks reserved by agents with 'task next --lease'
+func formatLeasedTasks(leasedTasks []*LeasedTaskInfo, noColor bool) string {
+	var sb strings.Builder
+
+	// Header
+	if noColor {
+		sb.WriteString("\n=== LEASED TASKS ===\n")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint("LEASED TASKS"))
+		sb.WriteString("\n")
+	}
+
+	for _, task := range leasedTasks {
+		if noColor {
+			sb.WriteString(fmt.Sprintf("\n%s: %s\n", task.Key, task.Title))
+			sb.WriteString(fmt.Sprintf("   Leased by: %s (expires in %s)\n", task.LeasedBy, task.ExpiresIn))
+		} else {
+			sb.WriteString(fmt.Sprintf("\n%s: %s\n", pterm.Cyan(task.Key), task.Title))
+			sb.WriteString(fmt.Sprintf("   Leased by: %s (expires in %s)\n", task.LeasedBy, pterm.Gray(task.ExpiresIn)))
 		}
 	}
 
@@ -357,6 +451,33 @@ func formatRecentCompletions(completions []*CompletionInfo, noColor bool) string
 	return sb.String()
 }
 
+// formatQuotaWarnings formats exceeded soft limits with suggested archival commands
+func formatQuotaWarnings(warnings []*QuotaWarning, noColor bool) string {
+	var sb strings.Builder
+
+	// Header
+	if noColor {
+		sb.WriteString("\n=== QUOTA WARNINGS ===\n")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint("QUOTA WARNINGS"))
+		sb.WriteString("\n")
+	}
+
+	for _, warning := range warnings {
+		sb.WriteString("\n")
+		if noColor {
+			sb.WriteString(fmt.Sprintf("! %s\n", warning.Message))
+			sb.WriteString(fmt.Sprintf("  Suggestion: %s\n", warning.Suggestion))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s %s\n", pterm.Yellow("!"), warning.Message))
+			sb.WriteString(fmt.Sprintf("  Suggestion: %s\n", pterm.Gray(warning.Suggestion)))
+		}
+	}
+
+	return sb.String()
+}
+
 // FormatDashboard formats the complete dashboard for terminal output
 func FormatDashboard(dashboard *StatusDashboard, noColor bool) string {
 	var sb strings.Builder
@@ -371,6 +492,12 @@ func FormatDashboard(dashboard *StatusDashboard, noColor bool) string {
 	sb.WriteString(formatEpicTable(dashboard.Epics, noColor, termWidth))
 	sb.WriteString("\n")
 
+	// Per-feature health (--detail=feature)
+	if dashboard.Filter != nil && dashboard.Filter.Detail == DetailFeature {
+		sb.WriteString(formatFeatureDetail(dashboard.Epics, noColor))
+		sb.WriteString("\n")
+	}
+
 	// Active tasks
 	sb.WriteString(formatActiveTasks(dashboard.ActiveTasks, noColor))
 	sb.WriteString("\n")
@@ -381,11 +508,92 @@ func FormatDashboard(dashboard *StatusDashboard, noColor bool) string {
 		sb.WriteString("\n")
 	}
 
+	// Leased tasks
+	if len(dashboard.LeasedTasks) > 0 {
+		sb.WriteString(formatLeasedTasks(dashboard.LeasedTasks, noColor))
+		sb.WriteString("\n")
+	}
+
 	// Recent completions
 	if len(dashboard.RecentCompletions) > 0 {
 		sb.WriteString(formatRecentCompletions(dashboard.RecentCompletions, noColor))
 		sb.WriteString("\n")
 	}
 
+	// Burnup history (--history)
+	if dashboard.History != nil {
+		sb.WriteString(formatBurnupHistory(dashboard.History, noColor))
+		sb.WriteString("\n")
+	}
+
+	// Agent workload (--by-agent)
+	if dashboard.Filter != nil && dashboard.Filter.ByAgent {
+		sb.WriteString(formatAgentWorkloads(dashboard.Agents, noColor))
+		sb.WriteString("\n")
+	}
+
+	// WIP limits
+	if len(dashboard.WIP) > 0 {
+		sb.WriteString(formatWIPUsage(dashboard.WIP, noColor))
+		sb.WriteString("\n")
+	}
+
+	// Quota warnings
+	if len(dashboard.QuotaWarnings) > 0 {
+		sb.WriteString(formatQuotaWarnings(dashboard.QuotaWarnings, noColor))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// FormatEpicStatus formats the epic status dashboard for terminal output
+func FormatEpicStatus(report *EpicStatusReport, noColor bool) string {
+	var sb strings.Builder
+
+	if len(report.Epics) == 0 {
+		return "No epics found\n"
+	}
+
+	if noColor {
+		sb.WriteString("=== EPIC STATUS ===\n\n")
+	} else {
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint("EPIC STATUS"))
+		sb.WriteString("\n\n")
+	}
+
+	tableData := pterm.TableData{
+		{"Key", "Title", "Status", "Health", "Progress", "Features", "Todo", "In Progress", "Review", "Done", "Blocked"},
+	}
+
+	var blockedTasks []*BlockedTaskInfo
+	for _, epic := range report.Epics {
+		blocked := fmt.Sprintf("%d", epic.Tasks.Blocked)
+		if epic.Tasks.Blocked > 0 && !noColor {
+			blocked = pterm.Red(blocked)
+		}
+
+		tableData = append(tableData, []string{
+			epic.Key,
+			epic.Title,
+			epic.Status,
+			formatHealth(epic.Health, noColor),
+			fmt.Sprintf("%.0f%%", epic.ProgressPercent),
+			fmt.Sprintf("%d/%d active", epic.FeaturesActive, epic.FeaturesTotal),
+			fmt.Sprintf("%d", epic.Tasks.Todo),
+			fmt.Sprintf("%d", epic.Tasks.InProgress),
+			fmt.Sprintf("%d", epic.Tasks.ReadyForReview),
+			fmt.Sprintf("%d/%d", epic.Tasks.Completed, epic.Tasks.Total),
+			blocked,
+		})
+		blockedTasks = append(blockedTasks, epic.BlockedTasks...)
+	}
+
+	sb.WriteString(renderTable(tableData, noColor))
+
+	if len(blockedTasks) > 0 {
+		sb.WriteString(formatBlockedTasks(blockedTasks, noColor))
+	}
+
 	return sb.String()
 }
@@ -0,0 +1,319 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+)
+
+// Health levels of an epic or feature
+const (
+	HealthHealthy  = "healthy"
+	HealthWarning  = "warning"
+	HealthCritical = "critical"
+)
+
+// Health rules, configured under health: in .shark.yaml
+const (
+	HealthRuleProgress = "progress" // Percent of tasks completed
+	HealthRuleBlocked  = "blocked"  // Blocked tasks
+	HealthRuleStale    = "stale"    // Days since the last change to the epic or feature or its tasks
+)
+
+// Points a rule scores at each level, before its weight is applied. With the
+// default weights and scores, one rule at critical makes the rating critical
+// and any rule at warning makes it warning, as before rules were configurable.
+const (
+	warningPoints  = 1.0
+	criticalPoints = 3.0
+)
+
+// Default health rules: warning below 75% completed or with any blocked task,
+// critical below 25% completed or with more than 3 blocked tasks. The stale
+// rule is off unless configured.
+var (
+	defaultProgressRule  = healthRule{name: HealthRuleProgress, warning: ptr(75.0), critical: ptr(25.0), weight: 1, below: true}
+	defaultBlockedRule   = healthRule{name: HealthRuleBlocked, warning: ptr(0.0), critical: ptr(3.0), weight: 1}
+	defaultWarningScore  = warningPoints
+	defaultCriticalScore = criticalPoints
+)
+
+// HealthCheck is one rule's part in an epic or feature's health rating
+type HealthCheck struct {
+	Rule   string  `json:"rule"`   // "progress", "blocked", or "stale"
+	Value  float64 `json:"value"`  // Percent completed, blocked tasks, or days since the last change
+	Level  string  `json:"level"`  // Level the rule alone rates the value: "healthy", "warning", or "critical"
+	Weight float64 `json:"weight"` // Configured weight of the rule
+	Score  float64 `json:"score"`  // Points for the level times the weight
+}
+
+// healthRule is a health rule with its defaults applied
+type healthRule struct {
+	name              string
+	warning, critical *float64 // nil never trips
+	weight            float64
+	below             bool // Trips when the value is below the thresholds rather than above
+}
+
+// healthMetrics are the values the health rules rate
+type healthMetrics struct {
+	progress  float64
+	blocked   int
+	staleDays float64
+}
+
+// LoadHealthRules reads the health rules configured in root's .shark.yaml. A
+// missing file or one without health rules returns nil, for the defaults.
+func LoadHealthRules(root string) (*workspace.HealthRules, error) {
+	project, err := workspace.LoadProjectFile(root)
+	if err != nil || project == nil || project.Health == nil {
+		return nil, err
+	}
+	if err := ValidateHealthRules(project.Health); err != nil {
+		return nil, fmt.Errorf("invalid health rules in %s: %w", workspace.ProjectFileName, err)
+	}
+	return project.Health, nil
+}
+
+// LoadProjectHealthRules returns the health rules in root's .shark.yaml with
+// the health.* settings stored in the project database applied over them:
+// database settings take precedence. An empty root skips .shark.yaml. Returns
+// nil, for the defaults, when neither configures anything.
+func LoadProjectHealthRules(ctx context.Context, root string, db *repository.DB) (*workspace.HealthRules, error) {
+	var rules *workspace.HealthRules
+	if root != "" {
+		project, err := workspace.LoadProjectFile(root)
+		if err != nil {
+			return nil, err
+		}
+		if project != nil {
+			rules = project.Health
+		}
+	}
+
+	settings, err := repository.NewSettingRepository(db).FloatsWithPrefix(ctx, "health.")
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		if err := ValidateHealthRules(rules); err != nil {
+			return nil, fmt.Errorf("invalid health rules in %s: %w", workspace.ProjectFileName, err)
+		}
+		return rules, nil
+	}
+
+	rules = ApplyHealthSettings(rules, settings)
+	if err := ValidateHealthRules(rules); err != nil {
+		return nil, fmt.Errorf("invalid health rules in %s and health.* settings: %w", workspace.ProjectFileName, err)
+	}
+	return rules, nil
+}
+
+// ApplyHealthSettings returns a copy of rules with the values of health.*
+// settings, by setting key, replacing the fields they correspond to. Unknown
+// keys are ignored.
+func ApplyHealthSettings(rules *workspace.HealthRules, settings map[string]float64) *workspace.HealthRules {
+	merged := &workspace.HealthRules{}
+	if rules != nil {
+		*merged = *rules
+		merged.Progress = copyHealthRule(rules.Progress)
+		merged.Blocked = copyHealthRule(rules.Blocked)
+		merged.Stale = copyHealthRule(rules.Stale)
+	}
+
+	rule := func(r **workspace.HealthRule) *workspace.HealthRule {
+		if *r == nil {
+			*r = &workspace.HealthRule{}
+		}
+		return *r
+	}
+	for key, value := range settings {
+		v := value
+		switch key {
+		case models.SettingHealthProgressWarning:
+			rule(&merged.Progress).Warning = &v
+		case models.SettingHealthProgressCritical:
+			rule(&merged.Progress).Critical = &v
+		case models.SettingHealthProgressWeight:
+			rule(&merged.Progress).Weight = &v
+		case models.SettingHealthBlockedWarning:
+			rule(&merged.Blocked).Warning = &v
+		case models.SettingHealthBlockedCritical:
+			rule(&merged.Blocked).Critical = &v
+		case models.SettingHealthBlockedWeight:
+			rule(&merged.Blocked).Weight = &v
+		case models.SettingHealthStaleWarning:
+			rule(&merged.Stale).Warning = &v
+		case models.SettingHealthStaleCritical:
+			rule(&merged.Stale).Critical = &v
+		case models.SettingHealthStaleWeight:
+			rule(&merged.Stale).Weight = &v
+		case models.SettingHealthWarningScore:
+			merged.WarningScore = &v
+		case models.SettingHealthCriticalScore:
+			merged.CriticalScore = &v
+		}
+	}
+	return merged
+}
+
+// copyHealthRule returns a copy of a configured rule, or nil
+func copyHealthRule(rule *workspace.HealthRule) *workspace.HealthRule {
+	if rule == nil {
+		return nil
+	}
+	copied := *rule
+	return &copied
+}
+
+// ValidateHealthRules checks that thresholds are in range and ordered, and
+// that weights and scores are usable. nil rules are valid.
+func ValidateHealthRules(rules *workspace.HealthRules) error {
+	if rules == nil {
+		return nil
+	}
+
+	ruleSet, warningScore, criticalScore := resolveHealthRules(rules)
+	for _, rule := range ruleSet {
+		if rule.weight < 0 {
+			return fmt.Errorf("%s weight must not be negative", rule.name)
+		}
+		for _, threshold := range []*float64{rule.warning, rule.critical} {
+			if threshold != nil && *threshold < 0 {
+				return fmt.Errorf("%s thresholds must not be negative", rule.name)
+			}
+		}
+		if rule.name == HealthRuleProgress {
+			for _, threshold := range []*float64{rule.warning, rule.critical} {
+				if threshold != nil && *threshold > 100 {
+					return fmt.Errorf("progress thresholds must be between 0 and 100")
+				}
+			}
+		}
+		if rule.warning == nil || rule.critical == nil {
+			continue
+		}
+		if rule.below && *rule.critical > *rule.warning {
+			return fmt.Errorf("%s critical threshold (%g) must not be above its warning threshold (%g)", rule.name, *rule.critical, *rule.warning)
+		}
+		if !rule.below && *rule.critical < *rule.warning {
+			return fmt.Errorf("%s critical threshold (%g) must not be below its warning threshold (%g)", rule.name, *rule.critical, *rule.warning)
+		}
+	}
+	if rules.Stale != nil && rules.Stale.Warning == nil && rules.Stale.Critical == nil {
+		return fmt.Errorf("stale needs a warning or critical threshold in days")
+	}
+
+	if warningScore <= 0 || criticalScore <= 0 {
+		return fmt.Errorf("warning_score and critical_score must be positive")
+	}
+	if warningScore > criticalScore {
+		return fmt.Errorf("warning_score (%g) must not be above critical_score (%g)", warningScore, criticalScore)
+	}
+	return nil
+}
+
+// resolveHealthRules applies the defaults to configured health rules
+func resolveHealthRules(rules *workspace.HealthRules) (ruleSet []healthRule, warningScore, criticalScore float64) {
+	progressRule, blockedRule := defaultProgressRule, defaultBlockedRule
+	warningScore, criticalScore = defaultWarningScore, defaultCriticalScore
+	if rules == nil {
+		return []healthRule{progressRule, blockedRule}, warningScore, criticalScore
+	}
+
+	applyHealthRule(&progressRule, rules.Progress)
+	applyHealthRule(&blockedRule, rules.Blocked)
+	ruleSet = []healthRule{progressRule, blockedRule}
+	if rules.Stale != nil {
+		staleRule := healthRule{name: HealthRuleStale, weight: 1}
+		applyHealthRule(&staleRule, rules.Stale)
+		ruleSet = append(ruleSet, staleRule)
+	}
+
+	if rules.WarningScore != nil {
+		warningScore = *rules.WarningScore
+	}
+	if rules.CriticalScore != nil {
+		criticalScore = *rules.CriticalScore
+	}
+	return ruleSet, warningScore, criticalScore
+}
+
+// applyHealthRule overrides a rule's defaults with the configured fields
+func applyHealthRule(rule *healthRule, configured *workspace.HealthRule) {
+	if configured == nil {
+		return
+	}
+	if configured.Warning != nil {
+		rule.warning = configured.Warning
+	}
+	if configured.Critical != nil {
+		rule.critical = configured.Critical
+	}
+	if configured.Weight != nil {
+		rule.weight = *configured.Weight
+	}
+}
+
+// evaluateHealth rates metrics against the health rules. Each rule scores
+// warningPoints or criticalPoints times its weight at that level; the rating
+// is critical once the total reaches the critical score and warning once it
+// reaches the warning score. The stale rule never trips once every task is
+// completed.
+func evaluateHealth(rules *workspace.HealthRules, metrics healthMetrics) (string, float64, []*HealthCheck) {
+	ruleSet, warningScore, criticalScore := resolveHealthRules(rules)
+
+	total := 0.0
+	checks := make([]*HealthCheck, 0, len(ruleSet))
+	for _, rule := range ruleSet {
+		var value float64
+		switch rule.name {
+		case HealthRuleProgress:
+			value = metrics.progress
+		case HealthRuleBlocked:
+			value = float64(metrics.blocked)
+		case HealthRuleStale:
+			value = math.Round(metrics.staleDays*10) / 10
+		}
+
+		check := &HealthCheck{Rule: rule.name, Value: value, Level: HealthHealthy, Weight: rule.weight}
+		if rule.name != HealthRuleStale || metrics.progress < 100 {
+			switch {
+			case rule.trips(rule.critical, value):
+				check.Level, check.Score = HealthCritical, criticalPoints*rule.weight
+			case rule.trips(rule.warning, value):
+				check.Level, check.Score = HealthWarning, warningPoints*rule.weight
+			}
+		}
+		total += check.Score
+		checks = append(checks, check)
+	}
+
+	switch {
+	case total >= criticalScore:
+		return HealthCritical, total, checks
+	case total >= warningScore:
+		return HealthWarning, total, checks
+	}
+	return HealthHealthy, total, checks
+}
+
+// trips reports whether value is past threshold
+func (r healthRule) trips(threshold *float64, value float64) bool {
+	if threshold == nil {
+		return false
+	}
+	if r.below {
+		return value < *threshold
+	}
+	return value > *threshold
+}
+
+// ptr returns a pointer to v
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,205 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
+)
+
+func TestEvaluateHealth_Weights(t *testing.T) {
+	// Defaults: two rules at warning stay below the critical score
+	health, score, checks := evaluateHealth(nil, healthMetrics{progress: 50, blocked: 2})
+	if health != HealthWarning || score != 2 {
+		t.Errorf("Expected warning with score 2, got %s with %g", health, score)
+	}
+	if len(checks) != 2 || checks[0].Rule != HealthRuleProgress || checks[1].Level != HealthWarning {
+		t.Errorf("Unexpected checks: %+v %+v", checks[0], checks[1])
+	}
+
+	// A light blocked rule alone no longer makes an epic a warning
+	rules := &workspace.HealthRules{Blocked: &workspace.HealthRule{Weight: ptr(0.5)}}
+	health, score, _ = evaluateHealth(rules, healthMetrics{progress: 100, blocked: 1})
+	if health != HealthHealthy || score != 0.5 {
+		t.Errorf("Expected healthy with score 0.5, got %s with %g", health, score)
+	}
+	health, _, _ = evaluateHealth(rules, healthMetrics{progress: 100, blocked: 4})
+	if health != HealthWarning {
+		t.Errorf("Expected warning for 4 blocked tasks at half weight, got %s", health)
+	}
+
+	// Stricter thresholds and a lower critical score
+	rules = &workspace.HealthRules{
+		Progress:      &workspace.HealthRule{Warning: ptr(90.0)},
+		CriticalScore: ptr(2.0),
+	}
+	health, _, _ = evaluateHealth(rules, healthMetrics{progress: 80, blocked: 1})
+	if health != HealthCritical {
+		t.Errorf("Expected critical, got %s", health)
+	}
+}
+
+func TestEvaluateHealth_Stale(t *testing.T) {
+	rules := &workspace.HealthRules{Stale: &workspace.HealthRule{Warning: ptr(7.0), Critical: ptr(30.0)}}
+
+	health, _, checks := evaluateHealth(rules, healthMetrics{progress: 80, staleDays: 12.04})
+	if health != HealthWarning {
+		t.Errorf("Expected warning, got %s", health)
+	}
+	if len(checks) != 3 || checks[2].Rule != HealthRuleStale || checks[2].Value != 12 {
+		t.Errorf("Expected a stale check of 12 days, got %+v", checks)
+	}
+
+	health, _, _ = evaluateHealth(rules, healthMetrics{progress: 80, staleDays: 45})
+	if health != HealthCritical {
+		t.Errorf("Expected critical, got %s", health)
+	}
+
+	// Finished work is never stale
+	health, _, _ = evaluateHealth(rules, healthMetrics{progress: 100, staleDays: 45})
+	if health != HealthHealthy {
+		t.Errorf("Expected healthy, got %s", health)
+	}
+}
+
+func TestValidateHealthRules(t *testing.T) {
+	valid := []*workspace.HealthRules{
+		nil,
+		{},
+		{Progress: &workspace.HealthRule{Warning: ptr(60.0), Critical: ptr(10.0)}},
+		{Blocked: &workspace.HealthRule{Critical: ptr(0.0), Weight: ptr(0.0)}},
+		{Stale: &workspace.HealthRule{Warning: ptr(14.0)}},
+	}
+	for i, rules := range valid {
+		if err := ValidateHealthRules(rules); err != nil {
+			t.Errorf("Expected rules %d to be valid, got %v", i, err)
+		}
+	}
+
+	invalid := []*workspace.HealthRules{
+		{Progress: &workspace.HealthRule{Warning: ptr(20.0)}}, // Below the default critical threshold
+		{Progress: &workspace.HealthRule{Critical: ptr(120.0), Warning: ptr(150.0)}},
+		{Blocked: &workspace.HealthRule{Warning: ptr(5.0), Critical: ptr(2.0)}},
+		{Blocked: &workspace.HealthRule{Weight: ptr(-1.0)}},
+		{Stale: &workspace.HealthRule{Weight: ptr(2.0)}},
+		{WarningScore: ptr(4.0)},
+		{CriticalScore: ptr(0.0)},
+	}
+	for i, rules := range invalid {
+		if err := ValidateHealthRules(rules); err == nil {
+			t.Errorf("Expected rules %d to be invalid", i)
+		}
+	}
+}
+
+func TestLoadHealthRules(t *testing.T) {
+	root := t.TempDir()
+	rules, err := LoadHealthRules(root)
+	if err != nil || rules != nil {
+		t.Fatalf("Expected no rules without .shark.yaml, got %+v, %v", rules, err)
+	}
+
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(root, workspace.ProjectFileName), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", workspace.ProjectFileName, err)
+		}
+	}
+
+	write("health:\n  blocked:\n    critical: 1\n  stale:\n    warning: 14\n")
+	rules, err = LoadHealthRules(root)
+	if err != nil {
+		t.Fatalf("LoadHealthRules failed: %v", err)
+	}
+	if rules.Blocked == nil || *rules.Blocked.Critical != 1 || rules.Stale == nil || *rules.Stale.Warning != 14 {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+
+	write("health:\n  warning_score: 5\n")
+	if _, err := LoadHealthRules(root); err == nil {
+		t.Error("Expected an error for a warning score above the critical score")
+	}
+}
+
+func TestLoadProjectHealthRules_SettingsTakePrecedence(t *testing.T) {
+	ctx := context.Background()
+	database := setupTestDB(t)
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, workspace.ProjectFileName), []byte("health:\n  blocked:\n    warning: 1\n    critical: 4\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", workspace.ProjectFileName, err)
+	}
+
+	settings := repository.NewSettingRepository(database)
+	if _, err := settings.Set(ctx, models.SettingHealthBlockedCritical, "6"); err != nil {
+		t.Fatalf("Failed to set setting: %v", err)
+	}
+	if _, err := settings.Set(ctx, models.SettingHealthStaleWarning, "14"); err != nil {
+		t.Fatalf("Failed to set setting: %v", err)
+	}
+
+	rules, err := LoadProjectHealthRules(ctx, root, database)
+	if err != nil {
+		t.Fatalf("LoadProjectHealthRules failed: %v", err)
+	}
+	if *rules.Blocked.Warning != 1 || *rules.Blocked.Critical != 6 {
+		t.Errorf("Expected the file's warning and the setting's critical threshold, got %+v", rules.Blocked)
+	}
+	if rules.Stale == nil || *rules.Stale.Warning != 14 {
+		t.Errorf("Expected the stale rule from settings, got %+v", rules.Stale)
+	}
+
+	if _, err := settings.Set(ctx, models.SettingHealthBlockedCritical, "0"); err != nil {
+		t.Fatalf("Failed to set setting: %v", err)
+	}
+	if _, err := LoadProjectHealthRules(ctx, root, database); err == nil {
+		t.Error("Expected an error for a critical threshold below the warning threshold")
+	}
+}
+
+func TestGetDashboard_HealthRules(t *testing.T) {
+	ctx := context.Background()
+	database := setupQuotaTestDB(t, []string{"completed", "completed", "completed", "in_progress"})
+	service := NewStatusService(database)
+
+	// Age everything by 20 days; the updated_at triggers would undo it
+	for _, table := range []string{"tasks", "features", "epics"} {
+		if _, err := database.ExecContext(ctx, "DROP TRIGGER "+table+"_updated_at"); err != nil {
+			t.Fatalf("Failed to drop trigger: %v", err)
+		}
+		if _, err := database.ExecContext(ctx, "UPDATE "+table+" SET updated_at = datetime('now', '-20 days')"); err != nil {
+			t.Fatalf("Failed to age %s: %v", table, err)
+		}
+	}
+
+	dashboard, err := service.GetDashboard(ctx, &StatusRequest{Detail: DetailFeature})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	epic := dashboard.Epics[0]
+	if epic.Health != HealthHealthy || len(epic.HealthChecks) != 2 {
+		t.Errorf("Expected healthy with two checks by default, got %s with %+v", epic.Health, epic.HealthChecks)
+	}
+
+	rules := &workspace.HealthRules{Stale: &workspace.HealthRule{Warning: ptr(14.0)}}
+	dashboard, err = service.GetDashboard(ctx, &StatusRequest{Detail: DetailFeature, Health: rules})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	epic = dashboard.Epics[0]
+	if epic.Health != HealthWarning {
+		t.Errorf("Expected a stale epic to be a warning, got %s", epic.Health)
+	}
+	if len(epic.HealthChecks) != 3 || epic.HealthChecks[2].Value < 19.9 || epic.HealthChecks[2].Value > 20.1 {
+		t.Errorf("Expected a stale check of 20 days, got %+v", epic.HealthChecks)
+	}
+	if feature := epic.Features[0]; feature.Health != HealthWarning || feature.HealthScore != 1 {
+		t.Errorf("Expected the feature to be a warning with score 1, got %s with %g", feature.Health, feature.HealthScore)
+	}
+
+	if _, err := service.GetDashboard(ctx, &StatusRequest{Health: &workspace.HealthRules{CriticalScore: ptr(-1.0)}}); err == nil {
+		t.Error("Expected invalid health rules to be rejected")
+	}
+}
@@ -0,0 +1,211 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/pterm/pterm"
+)
+
+// burnupTask is a task's creation day and the days it moved into or out of
+// completed, in order
+type burnupTask struct {
+	epicKey    string
+	featureKey string
+	created    string // YYYY-MM-DD, local time
+	changes    []burnupChange
+}
+
+// burnupChange is a move into (completed) or out of completed on a day
+type burnupChange struct {
+	day       string
+	completed bool
+}
+
+// historyDays returns the local dates of the window's days, oldest first and
+// ending today. A window shorter than a day covers today only.
+func historyDays(window string, now time.Time) []string {
+	count := int(recentWindowDuration(window) / (24 * time.Hour))
+	if count < 1 {
+		count = 1
+	}
+	days := make([]string, count)
+	for i := range days {
+		days[i] = now.AddDate(0, 0, i-count+1).Format("2006-01-02")
+	}
+	return days
+}
+
+// addHistory attaches burnup history to the dashboard, its epics, and, with
+// feature detail, their features. Completions come from task_history; tasks
+// completed without a history entry count from their completed_at.
+func (s *StatusService) addHistory(ctx context.Context, dashboard *StatusDashboard, req *StatusRequest) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	window := req.RecentWindow
+	if window == "" {
+		window = DefaultHistoryWindow
+	}
+
+	tasks, err := s.loadBurnupTasks(ctx, req.EpicKey, req.Labels)
+	if err != nil {
+		return err
+	}
+
+	days := historyDays(window, time.Now())
+	dashboard.History = buildBurnup(window, days, tasks, func(*burnupTask) bool { return true })
+	for _, epic := range dashboard.Epics {
+		epicKey := epic.Key
+		epic.History = buildBurnup(window, days, tasks, func(t *burnupTask) bool { return t.epicKey == epicKey })
+		for _, feature := range epic.Features {
+			featureKey := feature.Key
+			feature.History = buildBurnup(window, days, tasks, func(t *burnupTask) bool { return t.featureKey == featureKey })
+		}
+	}
+	return nil
+}
+
+// loadBurnupTasks loads the tasks counted by the dashboard with their moves
+// into and out of completed
+func (s *StatusService) loadBurnupTasks(ctx context.Context, epicKey string, labels []string) ([]*burnupTask, error) {
+	scope := `
+		FROM tasks t
+		JOIN features f ON t.feature_id = f.id AND f.deleted_at IS NULL
+		JOIN epics e ON f.epic_id = e.id
+		WHERE t.deleted_at IS NULL`
+	var args []interface{}
+	if epicKey != "" {
+		scope += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+	if len(labels) > 0 {
+		condition, labelArgs := repository.TaskLabelFilterSQL("t", labels)
+		scope += " AND " + condition
+		args = append(args, labelArgs...)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT t.id, e.key, f.key, COALESCE(date(t.created_at, 'localtime'), ''), COALESCE(date(t.completed_at, 'localtime'), '')`+scope+`
+		ORDER BY t.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query burnup tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []*burnupTask{}
+	byID := map[int64]*burnupTask{}
+	completedOn := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		task := &burnupTask{}
+		var completed string
+		if err := rows.Scan(&id, &task.epicKey, &task.featureKey, &task.created, &completed); err != nil {
+			return nil, fmt.Errorf("scan burnup task row: %w", err)
+		}
+		tasks = append(tasks, task)
+		byID[id] = task
+		completedOn[id] = completed
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate burnup task rows: %w", err)
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT th.task_id, date(th.timestamp, 'localtime'), th.new_status = 'completed'
+		FROM task_history th
+		JOIN (SELECT t.id`+scope+`) scoped ON th.task_id = scoped.id
+		WHERE th.new_status = 'completed' OR th.old_status = 'completed'
+		ORDER BY julianday(th.timestamp), th.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query burnup history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var change burnupChange
+		if err := rows.Scan(&id, &change.day, &change.completed); err != nil {
+			return nil, fmt.Errorf("scan burnup history row: %w", err)
+		}
+		if task := byID[id]; task != nil {
+			task.changes = append(task.changes, change)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate burnup history rows: %w", err)
+	}
+
+	for id, task := range byID {
+		if len(task.changes) == 0 && completedOn[id] != "" {
+			task.changes = []burnupChange{{day: completedOn[id], completed: true}}
+		}
+	}
+	return tasks, nil
+}
+
+// buildBurnup computes a burnup point per day for the tasks include accepts
+func buildBurnup(window string, days []string, tasks []*burnupTask, include func(*burnupTask) bool) *BurnupHistory {
+	history := &BurnupHistory{Window: window, Days: make([]*BurnupPoint, len(days))}
+	for i, day := range days {
+		point := &BurnupPoint{Date: day}
+		for _, task := range tasks {
+			if !include(task) || task.created > day {
+				continue
+			}
+			point.Total++
+
+			done, completedToday := false, false
+			for _, change := range task.changes {
+				if change.day > day {
+					break
+				}
+				done = change.completed
+				if change.completed && change.day == day {
+					completedToday = true
+				}
+			}
+			if done {
+				point.CumulativeCompleted++
+			}
+			if completedToday {
+				point.Completed++
+			}
+		}
+		history.Days[i] = point
+	}
+	return history
+}
+
+// formatBurnupHistory formats the dashboard's burnup history as a table
+func formatBurnupHistory(history *BurnupHistory, noColor bool) string {
+	var sb strings.Builder
+
+	// Header
+	title := fmt.Sprintf("BURNUP (%s)", history.Window)
+	if noColor {
+		sb.WriteString("\n=== " + title + " ===\n")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint(title))
+		sb.WriteString("\n")
+	}
+
+	tableData := pterm.TableData{{"Date", "Completed", "Done", "Total"}}
+	for _, point := range history.Days {
+		tableData = append(tableData, []string{
+			point.Date,
+			fmt.Sprintf("%d", point.Completed),
+			fmt.Sprintf("%d", point.CumulativeCompleted),
+			fmt.Sprintf("%d", point.Total),
+		})
+	}
+	sb.WriteString("\n")
+	sb.WriteString(renderTable(tableData, noColor))
+
+	return sb.String()
+}
@@ -0,0 +1,99 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryDays(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+
+	assert.Equal(t, []string{"2026-03-02"}, historyDays("24h", now))
+	assert.Equal(t, []string{"2026-03-01", "2026-03-02"}, historyDays("48h", now))
+	assert.Len(t, historyDays("30d", now), 30)
+}
+
+func TestGetDashboard_History(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	service := NewStatusService(repository.NewDB(database))
+
+	_, _ = database.ExecContext(ctx, "DELETE FROM task_history")
+	_, _ = database.ExecContext(ctx, "DELETE FROM tasks")
+	_, _ = database.ExecContext(ctx, "DELETE FROM features")
+	_, _ = database.ExecContext(ctx, "DELETE FROM epics")
+
+	result, err := database.ExecContext(ctx, `INSERT INTO epics (key, title, status, priority) VALUES ('E01', 'Burnup', 'active', 'high')`)
+	require.NoError(t, err)
+	epicID, _ := result.LastInsertId()
+	result, err = database.ExecContext(ctx, `INSERT INTO features (epic_id, key, title, status) VALUES (?, 'E01-F01', 'Charts', 'active')`, epicID)
+	require.NoError(t, err)
+	featureID, _ := result.LastInsertId()
+
+	// Times are noon local time, a whole number of days ago
+	now := time.Now()
+	noon := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local)
+	daysAgo := func(days int) string { return noon.AddDate(0, 0, -days).UTC().Format("2006-01-02 15:04:05") }
+
+	addTask := func(key, status string, created string, completedAt interface{}) int64 {
+		result, err := database.ExecContext(ctx, `
+			INSERT INTO tasks (feature_id, key, title, status, priority, depends_on, created_at, completed_at)
+			VALUES (?, ?, ?, ?, 5, '[]', ?, ?)`, featureID, key, key, status, created, completedAt)
+		require.NoError(t, err)
+		id, _ := result.LastInsertId()
+		return id
+	}
+	addHistory := func(taskID int64, oldStatus, newStatus, timestamp string) {
+		_, err := database.ExecContext(ctx, `INSERT INTO task_history (task_id, old_status, new_status, timestamp) VALUES (?, ?, ?, ?)`,
+			taskID, oldStatus, newStatus, timestamp)
+		require.NoError(t, err)
+	}
+
+	// Completed three days ago
+	done := addTask("T-E01-F01-001", "completed", daysAgo(10), daysAgo(3))
+	addHistory(done, "in_progress", "completed", daysAgo(3))
+	// Completed two days ago, reopened yesterday
+	reopened := addTask("T-E01-F01-002", "in_progress", daysAgo(10), nil)
+	addHistory(reopened, "in_progress", "completed", daysAgo(2))
+	addHistory(reopened, "completed", "in_progress", daysAgo(1))
+	// Imported as completed yesterday, with no history
+	addTask("T-E01-F01-003", "completed", daysAgo(1), daysAgo(1))
+	// Created today
+	addTask("T-E01-F01-004", "todo", daysAgo(0), nil)
+
+	dashboard, err := service.GetDashboard(ctx, &StatusRequest{RecentWindow: "7d", History: true})
+	require.NoError(t, err)
+	require.NotNil(t, dashboard.History)
+	assert.Equal(t, "7d", dashboard.History.Window)
+	require.Len(t, dashboard.History.Days, 7)
+	assert.True(t, dashboard.Filter.History)
+
+	type day struct{ completed, cumulative, total int }
+	var got []day
+	for _, point := range dashboard.History.Days[3:] {
+		got = append(got, day{point.Completed, point.CumulativeCompleted, point.Total})
+	}
+	assert.Equal(t, []day{
+		{1, 1, 2}, // three days ago
+		{1, 2, 2}, // two days ago
+		{1, 2, 3}, // yesterday: one reopened, one imported
+		{0, 2, 4}, // today
+	}, got)
+	assert.Equal(t, noon.Format("2006-01-02"), dashboard.History.Days[6].Date)
+
+	require.Len(t, dashboard.Epics, 1)
+	require.NotNil(t, dashboard.Epics[0].History)
+	assert.Equal(t, 2, dashboard.Epics[0].History.Days[6].CumulativeCompleted)
+
+	// Without --history there is no history
+	dashboard, err = service.GetDashboard(ctx, &StatusRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, dashboard.History)
+	assert.Nil(t, dashboard.Epics[0].History)
+}
@@ -0,0 +1,310 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// DashboardProvider builds status dashboards; *StatusService implements it
+type DashboardProvider interface {
+	GetDashboard(ctx context.Context, req *StatusRequest) (*StatusDashboard, error)
+}
+
+// Dashboard page refresh interval bounds, in seconds
+const (
+	DefaultRefreshSeconds = 30
+	MinRefreshSeconds     = 5
+)
+
+// NewHTTPHandler serves the status dashboard over HTTP:
+//
+//	GET /api/v1/status  the StatusDashboard as JSON (same as shark status --json)
+//	GET /dashboard      an HTML page with progress bars and blocked tasks that
+//	                    reloads itself every refresh seconds
+//
+// Both accept the query parameters epic, recent, label (repeatable or
+// comma-separated), detail, include_archived, history, and by_agent. Invalid
+// parameters get a 400.
+func NewHTTPHandler(provider DashboardProvider, quotas func() *StatusRequest) http.Handler {
+	h := &httpHandler{provider: provider, defaults: quotas}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/status", h.serveStatus)
+	mux.HandleFunc("GET /dashboard", h.serveDashboard)
+	return mux
+}
+
+// APIKeyAuthenticator looks up API keys; *repository.APIKeyRepository implements it
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (*models.APIKey, error)
+	CountActive(ctx context.Context) (int, error)
+}
+
+// RequireAPIKey wraps an HTTP handler so every request must send
+// "Authorization: Bearer <api key>". Keys with read scope may only make GET
+// and HEAD requests. Failures get a 401 or 403 with a JSON error.
+//
+// While no active key exists, requests are let through, so a new project's
+// API works until its first key is created. The check is made on every
+// request: creating a key closes the API and revoking the last one opens it
+// again, without a restart.
+func RequireAPIKey(keys APIKeyAuthenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		active, err := keys.CountActive(r.Context())
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if active == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, credential, found := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSONError(w, http.StatusUnauthorized, errors.New("missing bearer token: send \"Authorization: Bearer <api key>\""))
+			return
+		}
+
+		key, err := keys.Authenticate(r.Context(), strings.TrimSpace(credential))
+		if errors.Is(err, repository.ErrInvalidAPIKey) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSONError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !key.CanWrite() {
+			writeJSONError(w, http.StatusForbidden, fmt.Errorf("API key %q has read scope and can't make %s requests", key.Name, r.Method))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type httpHandler struct {
+	provider DashboardProvider
+	defaults func() *StatusRequest // Base request (e.g. quota limits); nil for none
+}
+
+// requestFromQuery builds a StatusRequest from URL query parameters
+func (h *httpHandler) requestFromQuery(r *http.Request) (*StatusRequest, error) {
+	req := &StatusRequest{}
+	if h.defaults != nil {
+		if base := h.defaults(); base != nil {
+			req = base
+		}
+	}
+
+	query := r.URL.Query()
+	req.EpicKey = strings.TrimSpace(query.Get("epic"))
+	req.RecentWindow = strings.TrimSpace(query.Get("recent"))
+	req.Detail = strings.TrimSpace(query.Get("detail"))
+
+	if value := query.Get("include_archived"); value != "" {
+		includeArchived, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include_archived: %s (expected true or false)", value)
+		}
+		req.IncludeArchived = includeArchived
+	}
+
+	if value := query.Get("history"); value != "" {
+		history, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid history: %s (expected true or false)", value)
+		}
+		req.History = history
+	}
+
+	if value := query.Get("by_agent"); value != "" {
+		byAgent, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid by_agent: %s (expected true or false)", value)
+		}
+		req.ByAgent = byAgent
+	}
+
+	req.Labels = nil
+	for _, value := range query["label"] {
+		for _, label := range strings.Split(value, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				req.Labels = append(req.Labels, label)
+			}
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// serveStatus handles GET /api/v1/status
+func (h *httpHandler) serveStatus(w http.ResponseWriter, r *http.Request) {
+	req, err := h.requestFromQuery(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	dashboard, err := h.provider.GetDashboard(r.Context(), req)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to get dashboard: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(dashboard)
+}
+
+// serveDashboard handles GET /dashboard
+func (h *httpHandler) serveDashboard(w http.ResponseWriter, r *http.Request) {
+	refresh := DefaultRefreshSeconds
+	if value := r.URL.Query().Get("refresh"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < MinRefreshSeconds {
+			http.Error(w, fmt.Sprintf("invalid refresh: %s (expected seconds, at least %d)", value, MinRefreshSeconds), http.StatusBadRequest)
+			return
+		}
+		refresh = seconds
+	}
+
+	req, err := h.requestFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dashboard, err := h.provider.GetDashboard(r.Context(), req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get dashboard: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := dashboardPage{Dashboard: dashboard, Refresh: refresh, JSONLink: "/api/v1/status"}
+	if r.URL.RawQuery != "" {
+		data.JSONLink += "?" + r.URL.RawQuery
+	}
+	if err := dashboardTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to render dashboard: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// writeJSONError writes {"error": "..."} with the given status code
+func writeJSONError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// dashboardPage is the data the dashboard template renders
+type dashboardPage struct {
+	Dashboard *StatusDashboard
+	Refresh   int
+	JSONLink  string // The same view as /api/v1/status JSON
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"percent": func(value float64) string { return strconv.FormatFloat(value, 'f', 1, 64) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>Shark Dashboard</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+h1 { margin-bottom: 0.25rem; }
+.meta { color: #666; margin-bottom: 1.5rem; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { text-align: left; padding: 0.4rem 0.6rem; border-bottom: 1px solid #eee; }
+.feature td:first-child { padding-left: 2rem; }
+.bar { background: #eee; border-radius: 4px; width: 200px; height: 12px; display: inline-block; vertical-align: middle; }
+.fill { height: 100%; border-radius: 4px; }
+.healthy .fill { background: #2e9e44; }
+.warning .fill { background: #e0a100; }
+.critical .fill { background: #d33; }
+.blocked { color: #d33; }
+</style>
+</head>
+<body>
+{{with .Dashboard}}
+<h1>Project Status</h1>
+<div class="meta">
+{{with .Summary}}{{.Epics.Total}} epics · {{.Features.Total}} features · {{.Tasks.Total}} tasks ({{.Tasks.Completed}} completed, {{.Tasks.InProgress}} in progress, <span class="blocked">{{.BlockedCount}} blocked</span>) · {{percent .OverallProgress}}% overall{{end}}
+· refreshes every {{$.Refresh}}s · <a href="{{$.JSONLink}}">JSON</a>
+</div>
+
+<h2>Epics</h2>
+{{if .Epics}}
+<table>
+<tr><th>Key</th><th>Title</th><th>Progress</th><th>Tasks</th><th>Blocked</th></tr>
+{{range .Epics}}
+<tr class="{{.Health}}">
+<td>{{.Key}}</td><td>{{.Title}}</td>
+<td><span class="bar"><span class="fill" style="display:block;width:{{percent .ProgressPercent}}%"></span></span> {{percent .ProgressPercent}}%</td>
+<td>{{.TasksCompleted}}/{{.TasksTotal}}</td><td>{{.TasksBlocked}}</td>
+</tr>
+{{range .Features}}
+<tr class="feature {{.Health}}">
+<td>{{.Key}}</td><td>{{.Title}}</td>
+<td><span class="bar"><span class="fill" style="display:block;width:{{percent .ProgressPercent}}%"></span></span> {{percent .ProgressPercent}}%</td>
+<td>{{.TasksCompleted}}/{{.TasksTotal}}</td><td>{{.TasksBlocked}}</td>
+</tr>
+{{end}}
+{{end}}
+</table>
+{{else}}
+<p>No epics.</p>
+{{end}}
+
+<h2>Blocked Tasks</h2>
+{{if .BlockedTasks}}
+<table>
+<tr><th>Key</th><th>Title</th><th>Reason</th><th>Blocked For</th></tr>
+{{range .BlockedTasks}}
+<tr><td class="blocked">{{.Key}}</td><td>{{.Title}}</td><td>{{if .BlockedReason}}{{.BlockedReason}}{{end}}</td><td>{{if .BlockedFor}}{{.BlockedFor}}{{end}}</td></tr>
+{{end}}
+</table>
+{{else}}
+<p>No blocked tasks.</p>
+{{end}}
+
+{{if .LeasedTasks}}
+<h2>Leased Tasks</h2>
+<table>
+<tr><th>Key</th><th>Title</th><th>Leased By</th><th>Expires In</th></tr>
+{{range .LeasedTasks}}
+<tr><td>{{.Key}}</td><td>{{.Title}}</td><td>{{.LeasedBy}}</td><td>{{.ExpiresIn}}</td></tr>
+{{end}}
+</table>
+{{end}}
+
+{{if .RecentCompletions}}
+<h2>Recent Completions</h2>
+<table>
+<tr><th>Key</th><th>Title</th><th>Completed</th></tr>
+{{range .RecentCompletions}}
+<tr><td>{{.Key}}</td><td>{{.Title}}</td><td>{{if .CompletedAgo}}{{.CompletedAgo}}{{end}}</td></tr>
+{{end}}
+</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
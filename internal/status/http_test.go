@@ -0,0 +1,226 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// fakeDashboardProvider records the last request and returns a fixed dashboard
+type fakeDashboardProvider struct {
+	lastRequest *StatusRequest
+	dashboard   *StatusDashboard
+}
+
+func (f *fakeDashboardProvider) GetDashboard(ctx context.Context, req *StatusRequest) (*StatusDashboard, error) {
+	f.lastRequest = req
+	return f.dashboard, nil
+}
+
+func newFakeDashboardProvider() *fakeDashboardProvider {
+	reason := "Waiting on API keys"
+	return &fakeDashboardProvider{dashboard: &StatusDashboard{
+		Summary: &ProjectSummary{
+			Epics:           &CountBreakdown{Total: 1},
+			Features:        &CountBreakdown{Total: 1},
+			Tasks:           &StatusBreakdown{Total: 4, Completed: 2, Blocked: 1},
+			OverallProgress: 50.0,
+			BlockedCount:    1,
+		},
+		Epics: []*EpicSummary{
+			{Key: "E05", Title: "Payments <beta>", ProgressPercent: 50.0, Health: "warning", TasksTotal: 4, TasksCompleted: 2, TasksBlocked: 1},
+		},
+		ActiveTasks: map[string][]*TaskInfo{},
+		BlockedTasks: []*BlockedTaskInfo{
+			{Key: "T-E05-F01-003", Title: "Wire checkout", BlockedReason: &reason},
+		},
+	}}
+}
+
+func TestHTTPHandler_StatusJSON(t *testing.T) {
+	provider := newFakeDashboardProvider()
+	handler := NewHTTPHandler(provider, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status?epic=E05&recent=7d&label=backend,api&label=urgent&history=true&by_agent=true", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %q", ct)
+	}
+
+	var result StatusDashboard
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("response is not a StatusDashboard: %v", err)
+	}
+	if len(result.Epics) != 1 || result.Epics[0].Key != "E05" {
+		t.Errorf("expected epic E05 in response, got %+v", result.Epics)
+	}
+
+	req := provider.lastRequest
+	if req.EpicKey != "E05" || req.RecentWindow != "7d" {
+		t.Errorf("expected epic E05 and recent 7d, got %q and %q", req.EpicKey, req.RecentWindow)
+	}
+	if strings.Join(req.Labels, ",") != "backend,api,urgent" {
+		t.Errorf("expected labels backend,api,urgent, got %v", req.Labels)
+	}
+	if !req.History {
+		t.Error("expected history to be requested")
+	}
+	if !req.ByAgent {
+		t.Error("expected agent workload to be requested")
+	}
+}
+
+func TestHTTPHandler_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"bad epic key", "/api/v1/status?epic=nope"},
+		{"bad recent window", "/api/v1/status?recent=soon"},
+		{"bad include_archived", "/api/v1/status?include_archived=maybe"},
+		{"bad history", "/api/v1/status?history=sometimes"},
+		{"bad by_agent", "/api/v1/status?by_agent=perhaps"},
+		{"refresh too short", "/dashboard?refresh=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHTTPHandler(newFakeDashboardProvider(), nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHTTPHandler_DefaultsAreFreshPerRequest(t *testing.T) {
+	provider := newFakeDashboardProvider()
+	calls := 0
+	handler := NewHTTPHandler(provider, func() *StatusRequest {
+		calls++
+		return &StatusRequest{DatabaseSizeBytes: 42}
+	})
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected defaults to be built per request, got %d calls", calls)
+	}
+	if provider.lastRequest.DatabaseSizeBytes != 42 {
+		t.Errorf("expected defaults to carry over, got %d", provider.lastRequest.DatabaseSizeBytes)
+	}
+}
+
+func TestHTTPHandler_DashboardHTML(t *testing.T) {
+	handler := NewHTTPHandler(newFakeDashboardProvider(), nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard?epic=E05&refresh=10", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`content="10"`,
+		"Payments &lt;beta&gt;",
+		"width:50.0%",
+		"T-E05-F01-003",
+		"Waiting on API keys",
+		`href="/api/v1/status?epic=E05&amp;refresh=10"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected dashboard HTML to contain %q", want)
+		}
+	}
+}
+
+// fakeAPIKeys authenticates a fixed set of keys
+type fakeAPIKeys map[string]*models.APIKey
+
+func (f fakeAPIKeys) Authenticate(ctx context.Context, secret string) (*models.APIKey, error) {
+	if key, ok := f[secret]; ok {
+		return key, nil
+	}
+	return nil, repository.ErrInvalidAPIKey
+}
+
+func (f fakeAPIKeys) CountActive(ctx context.Context) (int, error) {
+	return len(f), nil
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	keys := fakeAPIKeys{
+		"read-key":  {Name: "dashboard", Scope: models.APIKeyScopeRead},
+		"write-key": {Name: "ci", Scope: models.APIKeyScopeWrite},
+	}
+	handler := RequireAPIKey(keys, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		want   int
+	}{
+		{"no header", http.MethodGet, "", http.StatusUnauthorized},
+		{"unknown key", http.MethodGet, "Bearer other", http.StatusUnauthorized},
+		{"read key reads", http.MethodGet, "Bearer read-key", http.StatusNoContent},
+		{"read key can't write", http.MethodPost, "Bearer read-key", http.StatusForbidden},
+		{"write key writes", http.MethodPost, "bearer write-key", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/status", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireAPIKey_OpenWithoutKeys(t *testing.T) {
+	keys := fakeAPIKeys{}
+	handler := RequireAPIKey(keys, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status", nil))
+		return rec.Code
+	}
+
+	if code := serve(); code != http.StatusNoContent {
+		t.Errorf("without keys: status = %d, want %d", code, http.StatusNoContent)
+	}
+
+	// The first key closes the API without re-wrapping the handler
+	keys["ci"] = &models.APIKey{Name: "ci", Scope: models.APIKeyScopeRead}
+	if code := serve(); code != http.StatusUnauthorized {
+		t.Errorf("with a key: status = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 )
 
 // ValidTimeframes defines the allowed values for recent completion windows
@@ -16,6 +20,15 @@ var ValidTimeframes = map[string]bool{
 	"90d": true,
 }
 
+// DefaultHistoryWindow is the burnup history window when no recent window is given
+const DefaultHistoryWindow = "7d"
+
+// Dashboard detail levels
+const (
+	DetailEpic    = "epic"    // Epic summaries only (default)
+	DetailFeature = "feature" // Epic summaries with per-feature health under each epic
+)
+
 // AgentTypesOrder defines canonical ordering for agent types in output
 var AgentTypesOrder = []string{
 	"frontend",
@@ -29,12 +42,17 @@ var AgentTypesOrder = []string{
 
 // StatusDashboard is the complete dashboard output structure
 type StatusDashboard struct {
-	Summary           *ProjectSummary        `json:"summary"`
-	Epics             []*EpicSummary         `json:"epics"`
-	ActiveTasks       map[string][]*TaskInfo `json:"active_tasks"`
-	BlockedTasks      []*BlockedTaskInfo     `json:"blocked_tasks"`
-	RecentCompletions []*CompletionInfo      `json:"recent_completions,omitempty"`
-	Filter            *DashboardFilter       `json:"filter,omitempty"`
+	Summary           *ProjectSummary         `json:"summary"`
+	Epics             []*EpicSummary          `json:"epics"`
+	ActiveTasks       map[string][]*TaskInfo  `json:"active_tasks"`
+	BlockedTasks      []*BlockedTaskInfo      `json:"blocked_tasks"`
+	LeasedTasks       []*LeasedTaskInfo       `json:"leased_tasks,omitempty"`
+	RecentCompletions []*CompletionInfo       `json:"recent_completions,omitempty"`
+	QuotaWarnings     []*QuotaWarning         `json:"quota_warnings,omitempty"`
+	WIP               []*WIPUsage             `json:"wip,omitempty"`
+	Agents            []*models.AgentWorkload `json:"agents,omitempty"`  // Populated only with --by-agent
+	History           *BurnupHistory          `json:"history,omitempty"` // Populated only with --history
+	Filter            *DashboardFilter        `json:"filter,omitempty"`
 }
 
 // ProjectSummary contains high-level statistics about the entire project
@@ -64,15 +82,61 @@ type StatusBreakdown struct {
 
 // EpicSummary contains aggregated information about a single epic
 type EpicSummary struct {
-	Key             string  `json:"key"`
-	Title           string  `json:"title"`
-	ProgressPercent float64 `json:"progress_percent"`
-	Health          string  `json:"health"` // "healthy", "warning", "critical"
-	TasksTotal      int     `json:"tasks_total"`
-	TasksCompleted  int     `json:"tasks_completed"`
-	TasksBlocked    int     `json:"tasks_blocked"`
-	FeaturesTotal   int     `json:"features_total"`
-	FeaturesActive  int     `json:"features_active"`
+	Key             string         `json:"key"`
+	Title           string         `json:"title"`
+	ProgressPercent float64        `json:"progress_percent"`
+	Health          string         `json:"health"` // "healthy", "warning", "critical"
+	HealthScore     float64        `json:"health_score"`
+	HealthChecks    []*HealthCheck `json:"health_checks"` // How each health rule rated the epic
+	TasksTotal      int            `json:"tasks_total"`
+	TasksCompleted  int            `json:"tasks_completed"`
+	TasksBlocked    int            `json:"tasks_blocked"`
+	FeaturesTotal   int            `json:"features_total"`
+	FeaturesActive  int            `json:"features_active"`
+
+	// Populated only with --detail=feature
+	Features []*FeatureSummary `json:"features,omitempty"`
+
+	// Populated only with --history
+	History *BurnupHistory `json:"history,omitempty"`
+}
+
+// FeatureSummary contains per-feature health for the feature detail view
+type FeatureSummary struct {
+	Key             string             `json:"key"`
+	Title           string             `json:"title"`
+	Status          string             `json:"status"`
+	ProgressPercent float64            `json:"progress_percent"`
+	Health          string             `json:"health"` // "healthy", "warning", "critical"
+	HealthScore     float64            `json:"health_score"`
+	HealthChecks    []*HealthCheck     `json:"health_checks"` // How each health rule rated the feature
+	TasksTotal      int                `json:"tasks_total"`
+	TasksCompleted  int                `json:"tasks_completed"`
+	TasksInProgress int                `json:"tasks_in_progress"`
+	TasksBlocked    int                `json:"tasks_blocked"`
+	ActiveAgents    []*AgentAssignment `json:"active_agents"`
+	History         *BurnupHistory     `json:"history,omitempty"` // Populated only with --history
+}
+
+// BurnupHistory is one day per point of the recent window, oldest first, for
+// plotting burnup charts
+type BurnupHistory struct {
+	Window string         `json:"window"` // e.g. "7d"
+	Days   []*BurnupPoint `json:"days"`
+}
+
+// BurnupPoint is the work completed on one day and the totals at its end
+type BurnupPoint struct {
+	Date                string `json:"date"`                 // YYYY-MM-DD, local time
+	Completed           int    `json:"completed"`            // Tasks completed that day
+	CumulativeCompleted int    `json:"cumulative_completed"` // Tasks completed at the end of the day
+	Total               int    `json:"total"`                // Tasks that existed at the end of the day
+}
+
+// AgentAssignment lists the in-progress tasks held by one agent
+type AgentAssignment struct {
+	Agent string   `json:"agent"` // assigned_agent, else agent_type, else "unassigned"
+	Tasks []string `json:"tasks"`
 }
 
 // TaskInfo represents an active task in the dashboard
@@ -94,9 +158,21 @@ type BlockedTaskInfo struct {
 	Epic          string  `json:"epic"`
 	BlockedReason *string `json:"blocked_reason,omitempty"`
 	BlockedAt     *string `json:"blocked_at,omitempty"`
+	BlockedFor    *string `json:"blocked_for,omitempty"` // e.g. "3 days"
 	AgentType     *string `json:"agent_type,omitempty"`
 }
 
+// LeasedTaskInfo represents a todo task reserved by an agent with 'task next --lease'
+type LeasedTaskInfo struct {
+	Key       string `json:"key"`
+	Title     string `json:"title"`
+	Feature   string `json:"feature"`
+	Epic      string `json:"epic"`
+	LeasedBy  string `json:"leased_by"`
+	ExpiresAt string `json:"expires_at"`
+	ExpiresIn string `json:"expires_in"` // e.g. "4 minutes"
+}
+
 // CompletionInfo represents a recently completed task
 type CompletionInfo struct {
 	Key          string    `json:"key"`
@@ -104,22 +180,36 @@ type CompletionInfo struct {
 	Feature      string    `json:"feature"`
 	Epic         string    `json:"epic"`
 	CompletedAt  time.Time `json:"completed_at"`
-	CompletedAgo *string   `json:"completed_ago,omitempty"`
+	CompletedAgo *string   `json:"completed_ago,omitempty"` // e.g. "2 hours ago"
 	AgentType    *string   `json:"agent_type,omitempty"`
 }
 
 // DashboardFilter contains the filter criteria applied to the dashboard
 type DashboardFilter struct {
-	EpicKey         *string `json:"epic_key,omitempty"`
-	RecentWindow    *string `json:"recent_window,omitempty"`
-	IncludeArchived bool    `json:"include_archived"`
+	EpicKey         *string  `json:"epic_key,omitempty"`
+	RecentWindow    *string  `json:"recent_window,omitempty"`
+	IncludeArchived bool     `json:"include_archived"`
+	Labels          []string `json:"labels,omitempty"`
+	Detail          string   `json:"detail,omitempty"`
+	Weighted        bool     `json:"weighted,omitempty"` // Progress is weighted by task estimates
+	History         bool     `json:"history,omitempty"`  // Burnup history is included
+	ByAgent         bool     `json:"by_agent,omitempty"` // Per-agent workload is included
 }
 
 // StatusRequest represents the request parameters for generating a dashboard
 type StatusRequest struct {
-	EpicKey         string
-	RecentWindow    string
-	IncludeArchived bool
+	EpicKey           string
+	RecentWindow      string
+	IncludeArchived   bool
+	Labels            []string               // Only count tasks carrying all of these labels
+	Detail            string                 // DetailEpic (default) or DetailFeature
+	Weighted          bool                   // Weight progress by task estimates instead of task counts
+	History           bool                   // Add burnup history over RecentWindow (DefaultHistoryWindow if empty)
+	ByAgent           bool                   // Add the workload of each agent holding tasks
+	Quotas            *config.QuotaLimits    // Soft limits to check (nil skips quota checks)
+	Health            *workspace.HealthRules // Health rules from .shark.yaml (nil for the defaults)
+	WIPLimits         map[string]int         // Maximum in_progress tasks per agent type (empty skips WIP usage)
+	DatabaseSizeBytes int64                  // Local database size for the size quota (0 skips it)
 }
 
 // Validate checks if the request parameters are valid
@@ -134,6 +224,14 @@ func (r *StatusRequest) Validate() error {
 		return fmt.Errorf("invalid timeframe: %s (valid: 24h, 1d, 48h, 7d, 30d, 90d)", r.RecentWindow)
 	}
 
+	if r.Detail != "" && r.Detail != DetailEpic && r.Detail != DetailFeature {
+		return fmt.Errorf("invalid detail: %s (valid: %s, %s)", r.Detail, DetailEpic, DetailFeature)
+	}
+
+	if err := ValidateHealthRules(r.Health); err != nil {
+		return fmt.Errorf("invalid health rules: %w", err)
+	}
+
 	return nil
 }
 
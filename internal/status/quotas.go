@@ -0,0 +1,129 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+)
+
+// Quota scopes
+const (
+	QuotaScopeEpic     = "epic"
+	QuotaScopeFeature  = "feature"
+	QuotaScopeDatabase = "database"
+)
+
+// QuotaWarning describes a soft limit that has been exceeded, with a suggested fix
+type QuotaWarning struct {
+	Scope      string `json:"scope"`         // epic, feature, or database
+	Key        string `json:"key,omitempty"` // Epic or feature key (empty for database)
+	Current    int64  `json:"current"`
+	Limit      int64  `json:"limit"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion"`
+}
+
+// CheckQuotas compares open task counts and database size against soft limits.
+// Open tasks are tasks that are neither completed nor archived. dbSizeBytes is
+// the size of the local database file (0 to skip the size check, e.g. for cloud databases).
+func (s *StatusService) CheckQuotas(ctx context.Context, limits config.QuotaLimits, epicKey string, dbSizeBytes int64) ([]*QuotaWarning, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var warnings []*QuotaWarning
+
+	if limits.MaxOpenTasksPerEpic > 0 {
+		query := `
+			SELECT e.key, COUNT(t.id)
+			FROM epics e
+			JOIN features f ON f.epic_id = e.id
+			JOIN tasks t ON t.feature_id = f.id
+			WHERE t.status NOT IN ('completed', 'archived') AND t.deleted_at IS NULL`
+		epicWarnings, err := s.openTaskQuotaWarnings(ctx, query, "e", QuotaScopeEpic, epicKey, limits.MaxOpenTasksPerEpic)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, epicWarnings...)
+	}
+
+	if limits.MaxOpenTasksPerFeature > 0 {
+		query := `
+			SELECT f.key, COUNT(t.id)
+			FROM features f
+			JOIN epics e ON e.id = f.epic_id
+			JOIN tasks t ON t.feature_id = f.id
+			WHERE t.status NOT IN ('completed', 'archived') AND t.deleted_at IS NULL`
+		featureWarnings, err := s.openTaskQuotaWarnings(ctx, query, "f", QuotaScopeFeature, epicKey, limits.MaxOpenTasksPerFeature)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, featureWarnings...)
+	}
+
+	if limits.MaxDatabaseSizeMB > 0 && dbSizeBytes > 0 {
+		limitBytes := int64(limits.MaxDatabaseSizeMB) * 1024 * 1024
+		if dbSizeBytes > limitBytes {
+			warnings = append(warnings, &QuotaWarning{
+				Scope:      QuotaScopeDatabase,
+				Current:    dbSizeBytes,
+				Limit:      limitBytes,
+				Message:    fmt.Sprintf("Database is %.1f MB (limit %d MB)", float64(dbSizeBytes)/(1024*1024), limits.MaxDatabaseSizeMB),
+				Suggestion: "Delete finished epics with 'shark epic delete <epic-key>' and remove old backups with 'shark db prune'",
+			})
+		}
+	}
+
+	return warnings, nil
+}
+
+// openTaskQuotaWarnings runs an open-task count query grouped by the given table alias
+// and returns a warning for each epic or feature above the limit
+func (s *StatusService) openTaskQuotaWarnings(ctx context.Context, baseQuery, alias, scope, epicKey string, limit int) ([]*QuotaWarning, error) {
+	query := baseQuery
+	args := []interface{}{}
+	if epicKey != "" {
+		query += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+	query += fmt.Sprintf(" GROUP BY %[1]s.id, %[1]s.key HAVING COUNT(t.id) > ? ORDER BY COUNT(t.id) DESC, %[1]s.key ASC", alias)
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query %s quotas: %w", scope, err)
+	}
+	defer rows.Close()
+
+	var warnings []*QuotaWarning
+	for rows.Next() {
+		var key string
+		var open int64
+		if err := rows.Scan(&key, &open); err != nil {
+			return nil, fmt.Errorf("scan %s quota row: %w", scope, err)
+		}
+		warnings = append(warnings, &QuotaWarning{
+			Scope:      scope,
+			Key:        key,
+			Current:    open,
+			Limit:      int64(limit),
+			Message:    fmt.Sprintf("%s %s has %d open tasks (limit %d)", scope, key, open, limit),
+			Suggestion: quotaSuggestion(scope, key),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate %s quota rows: %w", scope, err)
+	}
+
+	return warnings, nil
+}
+
+// quotaSuggestion returns the suggested archival command for an oversized epic or feature
+func quotaSuggestion(scope, key string) string {
+	if scope == QuotaScopeEpic {
+		return fmt.Sprintf("Review stale work with 'shark task list %s --status=todo' and archive finished features with 'shark feature update <feature-key> --status=archived'", key)
+	}
+	return fmt.Sprintf("Review stale work with 'shark task list %s --status=todo' and delete tasks that will not be done with 'shark task delete <task-key>'", key)
+}
@@ -0,0 +1,118 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/config"
+	"github.com/jwwelbor/shark-task-manager/internal/db"
+	"github.com/jwwelbor/shark-task-manager/internal/repository"
+)
+
+// setupQuotaTestDB creates an isolated database with epic E01 (feature E01-F01)
+// holding the given task statuses
+func setupQuotaTestDB(t *testing.T, statuses []string) *repository.DB {
+	t.Helper()
+
+	sqlDB, err := db.InitDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to init database: %v", err)
+	}
+	database := repository.NewDB(sqlDB)
+	t.Cleanup(func() { database.Close() })
+
+	ctx := context.Background()
+	result, err := database.ExecContext(ctx, `
+		INSERT INTO epics (key, title, status, priority) VALUES ('E01', 'Big Epic', 'active', 'high')
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create epic: %v", err)
+	}
+	epicID, _ := result.LastInsertId()
+
+	result, err = database.ExecContext(ctx, `
+		INSERT INTO features (epic_id, key, title, status) VALUES (?, 'E01-F01', 'Big Feature', 'active')
+	`, epicID)
+	if err != nil {
+		t.Fatalf("Failed to create feature: %v", err)
+	}
+	featureID, _ := result.LastInsertId()
+
+	for i, status := range statuses {
+		_, err := database.ExecContext(ctx, `
+			INSERT INTO tasks (feature_id, key, title, status, priority, depends_on)
+			VALUES (?, ?, 'Task', ?, 5, '[]')
+		`, featureID, fmt.Sprintf("T-E01-F01-%03d", i+1), status)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	return database
+}
+
+func TestCheckQuotas_OpenTasks(t *testing.T) {
+	database := setupQuotaTestDB(t, []string{"todo", "todo", "in_progress", "completed", "archived"})
+	service := NewStatusService(database)
+
+	limits := config.QuotaLimits{MaxOpenTasksPerEpic: 2, MaxOpenTasksPerFeature: 5}
+	warnings, err := service.CheckQuotas(context.Background(), limits, "", 0)
+	if err != nil {
+		t.Fatalf("CheckQuotas() failed: %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(warnings))
+	}
+	w := warnings[0]
+	if w.Scope != QuotaScopeEpic || w.Key != "E01" || w.Current != 3 || w.Limit != 2 {
+		t.Errorf("Unexpected warning: %+v", w)
+	}
+	if !strings.Contains(w.Suggestion, "shark task list E01") {
+		t.Errorf("Suggestion should reference the epic, got %q", w.Suggestion)
+	}
+
+	// Filtering by another epic suppresses the warning
+	warnings, err = service.CheckQuotas(context.Background(), limits, "E02", 0)
+	if err != nil {
+		t.Fatalf("CheckQuotas() failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings for other epic, got %d", len(warnings))
+	}
+}
+
+func TestCheckQuotas_DatabaseSize(t *testing.T) {
+	database := setupQuotaTestDB(t, nil)
+	service := NewStatusService(database)
+
+	limits := config.QuotaLimits{MaxDatabaseSizeMB: 1}
+	warnings, err := service.CheckQuotas(context.Background(), limits, "", 2*1024*1024)
+	if err != nil {
+		t.Fatalf("CheckQuotas() failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Scope != QuotaScopeDatabase {
+		t.Fatalf("Expected one database warning, got %+v", warnings)
+	}
+
+	// Zero limits disable every check
+	warnings, err = service.CheckQuotas(context.Background(), config.QuotaLimits{}, "", 2*1024*1024)
+	if err != nil {
+		t.Fatalf("CheckQuotas() failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings with disabled limits, got %d", len(warnings))
+	}
+}
+
+func TestFormatQuotaWarnings(t *testing.T) {
+	output := formatQuotaWarnings([]*QuotaWarning{
+		{Scope: QuotaScopeEpic, Key: "E01", Message: "epic E01 has 250 open tasks (limit 200)", Suggestion: "archive things"},
+	}, true)
+
+	if !strings.Contains(output, "QUOTA WARNINGS") || !strings.Contains(output, "epic E01 has 250 open tasks") || !strings.Contains(output, "archive things") {
+		t.Errorf("Unexpected output:\n%s", output)
+	}
+}
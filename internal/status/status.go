@@ -3,10 +3,17 @@ package status
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jwwelbor/shark-task-manager/internal/progress"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
+	"github.com/jwwelbor/shark-task-manager/internal/utils"
+	"github.com/jwwelbor/shark-task-manager/internal/workspace"
 )
 
 // StatusService provides dashboard and reporting functionality
@@ -16,6 +23,7 @@ type StatusService struct {
 	featureRepo     *repository.FeatureRepository
 	taskRepo        *repository.TaskRepository
 	taskHistoryRepo *repository.TaskHistoryRepository
+	logger          *slog.Logger
 }
 
 // NewStatusService creates a new StatusService instance
@@ -26,10 +34,13 @@ func NewStatusService(database *repository.DB) *StatusService {
 		featureRepo:     repository.NewFeatureRepository(database),
 		taskRepo:        repository.NewTaskRepository(database),
 		taskHistoryRepo: repository.NewTaskHistoryRepository(database),
+		logger:          database.Logger().With("component", "status"),
 	}
 }
 
-// GetDashboard generates a complete status dashboard based on the request
+// GetDashboard generates a complete status dashboard based on the request.
+// When the database's read cache is enabled, dashboards are cached per request
+// and shared between callers, so the result must not be modified.
 func (s *StatusService) GetDashboard(ctx context.Context, req *StatusRequest) (*StatusDashboard, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
@@ -41,26 +52,52 @@ func (s *StatusService) GetDashboard(ctx context.Context, req *StatusRequest) (*
 		return nil, ctx.Err()
 	}
 
+	key, err := json.Marshal(req)
+	if err != nil {
+		return s.buildDashboard(ctx, req)
+	}
+	return repository.CachedRead(s.db.Cache(), "dashboard:"+string(key), func() (*StatusDashboard, error) {
+		return s.buildDashboard(ctx, req)
+	})
+}
+
+// buildDashboard queries the database for a status dashboard
+func (s *StatusService) buildDashboard(ctx context.Context, req *StatusRequest) (*StatusDashboard, error) {
+	start := time.Now()
+
 	// Get project summary
-	summary, err := s.getProjectSummary(ctx, req.EpicKey)
+	summary, err := s.getProjectSummary(ctx, req.EpicKey, req.Labels, req.Weighted)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get epic breakdown
-	epics, err := s.getEpics(ctx, req.EpicKey)
+	epics, err := s.getEpics(ctx, req.EpicKey, req.Labels, req.Weighted, req.Health)
 	if err != nil {
 		return nil, err
 	}
 
+	// Add per-feature health under each epic
+	if req.Detail == DetailFeature {
+		if err := s.addFeatureDetail(ctx, epics, req.EpicKey, req.Labels, req.Weighted, req.Health); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get active tasks
-	activeTasks, err := s.getActiveTasks(ctx, req.EpicKey)
+	activeTasks, err := s.getActiveTasks(ctx, req.EpicKey, req.Labels)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get blocked tasks
-	blockedTasks, err := s.getBlockedTasks(ctx, req.EpicKey)
+	blockedTasks, err := s.getBlockedTasks(ctx, req.EpicKey, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get tasks reserved with task next --lease
+	leasedTasks, err := s.getLeasedTasks(ctx, req.EpicKey, req.Labels)
 	if err != nil {
 		return nil, err
 	}
@@ -74,18 +111,53 @@ func (s *StatusService) GetDashboard(ctx context.Context, req *StatusRequest) (*
 		}
 	}
 
+	// Check soft limits
+	var quotaWarnings []*QuotaWarning
+	if req.Quotas != nil {
+		quotaWarnings, err = s.CheckQuotas(ctx, *req.Quotas, req.EpicKey, req.DatabaseSizeBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compare in_progress tasks with WIP limits
+	wip, err := s.GetWIPUsage(ctx, req.WIPLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	dashboard := &StatusDashboard{
 		Summary:           summary,
 		Epics:             epics,
 		ActiveTasks:       activeTasks,
 		BlockedTasks:      blockedTasks,
+		LeasedTasks:       leasedTasks,
 		RecentCompletions: recentCompletions,
+		QuotaWarnings:     quotaWarnings,
+		WIP:               wip,
+	}
+
+	// Add burnup history over the recent window
+	if req.History {
+		if err := s.addHistory(ctx, dashboard, req); err != nil {
+			return nil, err
+		}
+	}
+
+	// Add the workload of each agent
+	if req.ByAgent {
+		if dashboard.Agents, err = repository.NewAgentRepository(s.db).Workloads(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	// Add filter info if applicable
-	if req.EpicKey != "" || req.RecentWindow != "" || req.IncludeArchived {
+	if req.EpicKey != "" || req.RecentWindow != "" || req.IncludeArchived || len(req.Labels) > 0 || req.Detail == DetailFeature || req.Weighted || req.History || req.ByAgent {
 		dashboard.Filter = &DashboardFilter{
 			IncludeArchived: req.IncludeArchived,
+			Weighted:        req.Weighted,
+			History:         req.History,
+			ByAgent:         req.ByAgent,
 		}
 		if req.EpicKey != "" {
 			dashboard.Filter.EpicKey = &req.EpicKey
@@ -93,19 +165,32 @@ func (s *StatusService) GetDashboard(ctx context.Context, req *StatusRequest) (*
 		if req.RecentWindow != "" {
 			dashboard.Filter.RecentWindow = &req.RecentWindow
 		}
+		dashboard.Filter.Labels = req.Labels
+		if req.Detail == DetailFeature {
+			dashboard.Filter.Detail = req.Detail
+		}
 	}
 
+	s.logger.Debug("Built dashboard", "epic", req.EpicKey, "detail", req.Detail,
+		"duration_ms", time.Since(start).Milliseconds())
 	return dashboard, nil
 }
 
 // getProjectSummary retrieves overall project statistics
-func (s *StatusService) getProjectSummary(ctx context.Context, epicKey string) (*ProjectSummary, error) {
+func (s *StatusService) getProjectSummary(ctx context.Context, epicKey string, labels []string, weighted bool) (*ProjectSummary, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
+	// Label filter restricts counted tasks; join arguments precede the WHERE arguments
+	taskJoinFilter, args := taskLabelJoinFilter(labels)
+	unit, err := s.estimateUnit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, unit)
+
 	var epicFilter string
-	var args []interface{}
 	if epicKey != "" {
 		epicFilter = "WHERE e.key = ?"
 		args = append(args, epicKey)
@@ -122,28 +207,29 @@ func (s *StatusService) getProjectSummary(ctx context.Context, epicKey string) (
 			COUNT(DISTINCT CASE WHEN t.status = 'in_progress' THEN t.id END) as in_progress_tasks,
 			COUNT(DISTINCT CASE WHEN t.status = 'ready_for_review' THEN t.id END) as ready_for_review_tasks,
 			COUNT(DISTINCT CASE WHEN t.status = 'completed' THEN t.id END) as completed_tasks,
-			COUNT(DISTINCT CASE WHEN t.status = 'blocked' THEN t.id END) as blocked_tasks
+			COUNT(DISTINCT CASE WHEN t.status = 'blocked' THEN t.id END) as blocked_tasks,` + estimateColumns + `
 		FROM epics e
-		LEFT JOIN features f ON e.id = f.epic_id
-		LEFT JOIN tasks t ON f.id = t.feature_id
+		LEFT JOIN features f ON e.id = f.epic_id AND f.deleted_at IS NULL
+		LEFT JOIN tasks t ON f.id = t.feature_id AND t.deleted_at IS NULL` + taskJoinFilter + `
+		LEFT JOIN task_estimates te ON te.task_id = t.id AND te.unit = ?
 		` + epicFilter
 
 	var totalEpics, activeEpics, totalFeatures, activeFeatures int
 	var totalTasks, todoTasks, inProgressTasks, readyForReviewTasks, completedTasks, blockedTasks int
+	var estimates progress.EstimateTotals
 
-	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+	err = s.db.QueryRowContext(ctx, query, args...).Scan(
 		&totalEpics, &activeEpics, &totalFeatures, &activeFeatures,
 		&totalTasks, &todoTasks, &inProgressTasks, &readyForReviewTasks, &completedTasks, &blockedTasks,
+		&estimates.EstimatedTasks, &estimates.CompletedEstimatedTasks, &estimates.Estimate, &estimates.CompletedEstimate,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("query project summary: %w", err)
 	}
 
 	// Calculate overall progress
-	var overallProgress float64
-	if totalTasks > 0 {
-		overallProgress = (float64(completedTasks) / float64(totalTasks)) * 100.0
-	}
+	estimates.Tasks, estimates.CompletedTasks = totalTasks, float64(completedTasks)
+	overallProgress := progressPercent(estimates, weighted)
 
 	return &ProjectSummary{
 		Epics:    &CountBreakdown{Total: totalEpics, Active: activeEpics},
@@ -161,14 +247,21 @@ func (s *StatusService) getProjectSummary(ctx context.Context, epicKey string) (
 	}, nil
 }
 
-// getEpics retrieves epic breakdown with progress
-func (s *StatusService) getEpics(ctx context.Context, epicKey string) ([]*EpicSummary, error) {
+// getEpics retrieves epic breakdown with progress and health
+func (s *StatusService) getEpics(ctx context.Context, epicKey string, labels []string, weighted bool, health *workspace.HealthRules) ([]*EpicSummary, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
+	// Label filter restricts counted tasks; join arguments precede the WHERE arguments
+	taskJoinFilter, args := taskLabelJoinFilter(labels)
+	unit, err := s.estimateUnit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, unit)
+
 	var epicFilter string
-	var args []interface{}
 	if epicKey != "" {
 		epicFilter = "WHERE e.key = ?"
 		args = append(args, epicKey)
@@ -181,10 +274,12 @@ func (s *StatusService) getEpics(ctx context.Context, epicKey string) ([]*EpicSu
 			SUM(CASE WHEN f.status = 'active' THEN 1 ELSE 0 END) as active_features,
 			COUNT(DISTINCT t.id) as total_tasks,
 			SUM(CASE WHEN t.status = 'completed' THEN 1 ELSE 0 END) as completed_tasks,
-			SUM(CASE WHEN t.status = 'blocked' THEN 1 ELSE 0 END) as blocked_tasks
+			SUM(CASE WHEN t.status = 'blocked' THEN 1 ELSE 0 END) as blocked_tasks,
+			` + staleDaysColumn("e.updated_at", "f.updated_at", "t.updated_at") + ` as stale_days,` + estimateColumns + `
 		FROM epics e
-		LEFT JOIN features f ON e.id = f.epic_id
-		LEFT JOIN tasks t ON f.id = t.feature_id
+		LEFT JOIN features f ON e.id = f.epic_id AND f.deleted_at IS NULL
+		LEFT JOIN tasks t ON f.id = t.feature_id AND t.deleted_at IS NULL` + taskJoinFilter + `
+		LEFT JOIN task_estimates te ON te.task_id = t.id AND te.unit = ?
 		` + epicFilter + `
 		GROUP BY e.id, e.key, e.title
 		ORDER BY e.key ASC
@@ -201,25 +296,28 @@ func (s *StatusService) getEpics(ctx context.Context, epicKey string) ([]*EpicSu
 		var id int64
 		var key, title string
 		var totalFeatures, activeFeatures, totalTasks, completedTasks, blockedTasks int
+		var staleDays float64
+		var estimates progress.EstimateTotals
 
-		if err := rows.Scan(&id, &key, &title, &totalFeatures, &activeFeatures, &totalTasks, &completedTasks, &blockedTasks); err != nil {
+		if err := rows.Scan(&id, &key, &title, &totalFeatures, &activeFeatures, &totalTasks, &completedTasks, &blockedTasks, &staleDays,
+			&estimates.EstimatedTasks, &estimates.CompletedEstimatedTasks, &estimates.Estimate, &estimates.CompletedEstimate); err != nil {
 			return nil, fmt.Errorf("scan epic row: %w", err)
 		}
 
 		// Calculate progress
-		var progress float64
-		if totalTasks > 0 {
-			progress = (float64(completedTasks) / float64(totalTasks)) * 100.0
-		}
+		estimates.Tasks, estimates.CompletedTasks = totalTasks, float64(completedTasks)
+		progress := progressPercent(estimates, weighted)
 
 		// Determine health
-		health := s.determineEpicHealth(progress, blockedTasks)
+		rating, score, checks := evaluateHealth(health, healthMetrics{progress: progress, blocked: blockedTasks, staleDays: staleDays})
 
 		epics = append(epics, &EpicSummary{
 			Key:             key,
 			Title:           title,
 			ProgressPercent: progress,
-			Health:          health,
+			Health:          rating,
+			HealthScore:     score,
+			HealthChecks:    checks,
 			TasksTotal:      totalTasks,
 			TasksCompleted:  completedTasks,
 			TasksBlocked:    blockedTasks,
@@ -235,8 +333,149 @@ func (s *StatusService) getEpics(ctx context.Context, epicKey string) ([]*EpicSu
 	return epics, nil
 }
 
+// addFeatureDetail attaches each epic's features, with health, blocked counts,
+// and the agents holding in-progress tasks
+func (s *StatusService) addFeatureDetail(ctx context.Context, epics []*EpicSummary, epicKey string, labels []string, weighted bool, health *workspace.HealthRules) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Label filter restricts counted tasks; join arguments precede the WHERE arguments
+	taskJoinFilter, args := taskLabelJoinFilter(labels)
+	unit, err := s.estimateUnit(ctx)
+	if err != nil {
+		return err
+	}
+	args = append(args, unit)
+
+	epicFilter := "WHERE f.deleted_at IS NULL"
+	if epicKey != "" {
+		epicFilter += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+
+	query := `
+		SELECT
+			e.key, f.key, f.title, f.status,
+			COUNT(DISTINCT t.id) as total_tasks,
+			SUM(CASE WHEN t.status = 'completed' THEN 1 ELSE 0 END) as completed_tasks,
+			SUM(CASE WHEN t.status = 'in_progress' THEN 1 ELSE 0 END) as in_progress_tasks,
+			SUM(CASE WHEN t.status = 'blocked' THEN 1 ELSE 0 END) as blocked_tasks,
+			` + staleDaysColumn("f.updated_at", "t.updated_at") + ` as stale_days,` + estimateColumns + `
+		FROM features f
+		JOIN epics e ON f.epic_id = e.id
+		LEFT JOIN tasks t ON f.id = t.feature_id AND t.deleted_at IS NULL` + taskJoinFilter + `
+		LEFT JOIN task_estimates te ON te.task_id = t.id AND te.unit = ?
+		` + epicFilter + `
+		GROUP BY f.id, e.key, f.key, f.title, f.status
+		ORDER BY f.key ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query features: %w", err)
+	}
+	defer rows.Close()
+
+	byEpic := make(map[string]*EpicSummary, len(epics))
+	for _, epic := range epics {
+		epic.Features = []*FeatureSummary{}
+		byEpic[epic.Key] = epic
+	}
+	byKey := make(map[string]*FeatureSummary)
+
+	for rows.Next() {
+		var epicKeyStr string
+		var feature FeatureSummary
+		var completed, inProgress, blocked sql.NullInt64
+		var staleDays float64
+		var estimates progress.EstimateTotals
+
+		if err := rows.Scan(&epicKeyStr, &feature.Key, &feature.Title, &feature.Status, &feature.TasksTotal, &completed, &inProgress, &blocked, &staleDays,
+			&estimates.EstimatedTasks, &estimates.CompletedEstimatedTasks, &estimates.Estimate, &estimates.CompletedEstimate); err != nil {
+			return fmt.Errorf("scan feature row: %w", err)
+		}
+		feature.TasksCompleted = int(completed.Int64)
+		feature.TasksInProgress = int(inProgress.Int64)
+		feature.TasksBlocked = int(blocked.Int64)
+
+		estimates.Tasks, estimates.CompletedTasks = feature.TasksTotal, float64(feature.TasksCompleted)
+		feature.ProgressPercent = progressPercent(estimates, weighted)
+		// Features use the same rules as epics
+		feature.Health, feature.HealthScore, feature.HealthChecks = evaluateHealth(health,
+			healthMetrics{progress: feature.ProgressPercent, blocked: feature.TasksBlocked, staleDays: staleDays})
+		feature.ActiveAgents = []*AgentAssignment{}
+
+		if epic, ok := byEpic[epicKeyStr]; ok {
+			epic.Features = append(epic.Features, &feature)
+			byKey[feature.Key] = &feature
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate feature rows: %w", err)
+	}
+
+	return s.addActiveAgents(ctx, byKey, epicKey, labels)
+}
+
+// addActiveAgents groups each feature's in-progress tasks by the agent holding them
+func (s *StatusService) addActiveAgents(ctx context.Context, features map[string]*FeatureSummary, epicKey string, labels []string) error {
+	var args []interface{}
+
+	query := `
+		SELECT
+			f.key, t.key,
+			COALESCE(NULLIF(t.assigned_agent, ''), NULLIF(t.agent_type, ''), 'unassigned') as agent
+		FROM tasks t
+		JOIN features f ON t.feature_id = f.id
+		JOIN epics e ON f.epic_id = e.id
+		WHERE t.status = 'in_progress' AND t.deleted_at IS NULL
+	`
+
+	if epicKey != "" {
+		query += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+
+	if len(labels) > 0 {
+		condition, labelArgs := repository.TaskLabelFilterSQL("t", labels)
+		query += " AND " + condition
+		args = append(args, labelArgs...)
+	}
+
+	query += " ORDER BY agent ASC, t.key ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query active agents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var featureKey, taskKey, agent string
+		if err := rows.Scan(&featureKey, &taskKey, &agent); err != nil {
+			return fmt.Errorf("scan active agent row: %w", err)
+		}
+
+		feature, ok := features[featureKey]
+		if !ok {
+			continue
+		}
+		var assignment *AgentAssignment
+		if n := len(feature.ActiveAgents); n > 0 && feature.ActiveAgents[n-1].Agent == agent {
+			assignment = feature.ActiveAgents[n-1]
+		} else {
+			assignment = &AgentAssignment{Agent: agent}
+			feature.ActiveAgents = append(feature.ActiveAgents, assignment)
+		}
+		assignment.Tasks = append(assignment.Tasks, taskKey)
+	}
+
+	return rows.Err()
+}
+
 // getActiveTasks retrieves in-progress tasks grouped by agent type
-func (s *StatusService) getActiveTasks(ctx context.Context, epicKey string) (map[string][]*TaskInfo, error) {
+func (s *StatusService) getActiveTasks(ctx context.Context, epicKey string, labels []string) (map[string][]*TaskInfo, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -250,7 +489,7 @@ func (s *StatusService) getActiveTasks(ctx context.Context, epicKey string) (map
 		FROM tasks t
 		JOIN features f ON t.feature_id = f.id
 		JOIN epics e ON f.epic_id = e.id
-		WHERE t.status = 'in_progress'
+		WHERE t.status = 'in_progress' AND t.deleted_at IS NULL
 	`
 
 	if epicKey != "" {
@@ -258,6 +497,12 @@ func (s *StatusService) getActiveTasks(ctx context.Context, epicKey string) (map
 		args = append(args, epicKey)
 	}
 
+	if len(labels) > 0 {
+		condition, labelArgs := repository.TaskLabelFilterSQL("t", labels)
+		query += " AND " + condition
+		args = append(args, labelArgs...)
+	}
+
 	query += " ORDER BY t.agent_type ASC, t.key ASC"
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
@@ -302,7 +547,7 @@ func (s *StatusService) getActiveTasks(ctx context.Context, epicKey string) (map
 }
 
 // getBlockedTasks retrieves blocked tasks
-func (s *StatusService) getBlockedTasks(ctx context.Context, epicKey string) ([]*BlockedTaskInfo, error) {
+func (s *StatusService) getBlockedTasks(ctx context.Context, epicKey string, labels []string) ([]*BlockedTaskInfo, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -316,7 +561,7 @@ func (s *StatusService) getBlockedTasks(ctx context.Context, epicKey string) ([]
 		FROM tasks t
 		JOIN features f ON t.feature_id = f.id
 		JOIN epics e ON f.epic_id = e.id
-		WHERE t.status = 'blocked'
+		WHERE t.status = 'blocked' AND t.deleted_at IS NULL
 	`
 
 	if epicKey != "" {
@@ -324,6 +569,12 @@ func (s *StatusService) getBlockedTasks(ctx context.Context, epicKey string) ([]
 		args = append(args, epicKey)
 	}
 
+	if len(labels) > 0 {
+		condition, labelArgs := repository.TaskLabelFilterSQL("t", labels)
+		query += " AND " + condition
+		args = append(args, labelArgs...)
+	}
+
 	query += " ORDER BY t.priority DESC, t.blocked_at DESC"
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
@@ -355,6 +606,8 @@ func (s *StatusService) getBlockedTasks(ctx context.Context, epicKey string) ([]
 		if blockedAt.Valid {
 			blockedAtStr := blockedAt.Time.Format(time.RFC3339)
 			task.BlockedAt = &blockedAtStr
+			blockedFor := utils.HumanizeDuration(time.Since(blockedAt.Time))
+			task.BlockedFor = &blockedFor
 		}
 
 		blockedTasks = append(blockedTasks, &task)
@@ -367,28 +620,194 @@ func (s *StatusService) getBlockedTasks(ctx context.Context, epicKey string) ([]
 	return blockedTasks, nil
 }
 
+// getLeasedTasks retrieves todo tasks with an unexpired lease, soonest to expire first
+func (s *StatusService) getLeasedTasks(ctx context.Context, epicKey string, labels []string) ([]*LeasedTaskInfo, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	args := []interface{}{now}
+
+	query := `
+		SELECT t.key, t.title, f.key, e.key, l.agent, l.expires_at
+		FROM task_leases l
+		JOIN tasks t ON t.id = l.task_id
+		JOIN features f ON t.feature_id = f.id
+		JOIN epics e ON f.epic_id = e.id
+		WHERE l.expires_at > ? AND t.status = 'todo' AND t.deleted_at IS NULL
+	`
+
+	if epicKey != "" {
+		query += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+
+	if len(labels) > 0 {
+		condition, labelArgs := repository.TaskLabelFilterSQL("t", labels)
+		query += " AND " + condition
+		args = append(args, labelArgs...)
+	}
+
+	query += " ORDER BY l.expires_at, t.key"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query leased tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var leasedTasks []*LeasedTaskInfo
+	for rows.Next() {
+		var task LeasedTaskInfo
+		var expiresAt time.Time
+		if err := rows.Scan(&task.Key, &task.Title, &task.Feature, &task.Epic, &task.LeasedBy, &expiresAt); err != nil {
+			return nil, fmt.Errorf("scan leased task row: %w", err)
+		}
+		task.ExpiresAt = expiresAt.Format(time.RFC3339)
+		task.ExpiresIn = utils.HumanizeDuration(time.Until(expiresAt))
+		leasedTasks = append(leasedTasks, &task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate leased task rows: %w", err)
+	}
+
+	return leasedTasks, nil
+}
+
 // getRecentCompletions retrieves recently completed tasks
 func (s *StatusService) getRecentCompletions(ctx context.Context, epicKey string, window string) ([]*CompletionInfo, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	// For now, return empty list - will implement timeframe filtering in future iteration
-	return []*CompletionInfo{}, nil
+	now := time.Now()
+	cutoff := now.Add(-recentWindowDuration(window))
+
+	// julianday() normalizes the stored timestamp format and time zone
+	query := `
+		SELECT
+			t.key, t.title, t.agent_type, t.completed_at,
+			f.key as feature_key, e.key as epic_key
+		FROM tasks t
+		JOIN features f ON t.feature_id = f.id
+		JOIN epics e ON f.epic_id = e.id
+		WHERE t.status = 'completed'
+		  AND t.deleted_at IS NULL
+		  AND t.completed_at IS NOT NULL
+		  AND julianday(t.completed_at) >= julianday(?)
+	`
+	args := []interface{}{cutoff.UTC().Format("2006-01-02 15:04:05")}
+
+	if epicKey != "" {
+		query += " AND e.key = ?"
+		args = append(args, epicKey)
+	}
+
+	query += " ORDER BY julianday(t.completed_at) DESC, t.key ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query recent completions: %w", err)
+	}
+	defer rows.Close()
+
+	completions := []*CompletionInfo{}
+	for rows.Next() {
+		var completion CompletionInfo
+		var agentType sql.NullString
+		var completedAt sql.NullTime
+
+		if err := rows.Scan(&completion.Key, &completion.Title, &agentType, &completedAt, &completion.Feature, &completion.Epic); err != nil {
+			return nil, fmt.Errorf("scan recent completion row: %w", err)
+		}
+
+		if agentType.Valid && agentType.String != "" {
+			completion.AgentType = &agentType.String
+		}
+		if completedAt.Valid {
+			completion.CompletedAt = completedAt.Time
+			completedAgo := utils.FormatRelativeTimeFrom(completedAt.Time, now)
+			completion.CompletedAgo = &completedAgo
+		}
+
+		completions = append(completions, &completion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate recent completion rows: %w", err)
+	}
+
+	return completions, nil
 }
 
-// determineEpicHealth calculates health status based on progress and blocked count
+// recentWindowDuration converts a timeframe from ValidTimeframes ("24h", "7d", ...) to a duration
+func recentWindowDuration(window string) time.Duration {
+	if days, ok := strings.CutSuffix(window, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil {
+			return time.Duration(n) * 24 * time.Hour
+		}
+	}
+	if d, err := time.ParseDuration(window); err == nil {
+		return d
+	}
+	return 24 * time.Hour
+}
+
+// determineEpicHealth calculates health status based on progress and blocked
+// count with the default health rules
 func (s *StatusService) determineEpicHealth(progress float64, blockedCount int) string {
 	// Critical: <25% progress OR >3 blocked tasks
-	if progress < 25.0 || blockedCount > 3 {
-		return "critical"
+	// Warning: 25-74% progress OR 1-3 blocked tasks
+	// Healthy: ≥75% progress AND no blocked tasks
+	health, _, _ := evaluateHealth(nil, healthMetrics{progress: progress, blocked: blockedCount})
+	return health
+}
+
+// staleDaysColumn returns the days since the latest of the given timestamp
+// columns, for the stale health rule. Aggregated columns may be NULL.
+func staleDaysColumn(column string, aggregated ...string) string {
+	latest := "COALESCE(julianday(" + column + "), 0)"
+	for _, c := range aggregated {
+		latest += ", COALESCE(MAX(julianday(" + c + ")), 0)"
 	}
+	return "COALESCE(julianday('now') - MAX(" + latest + "), 0)"
+}
 
-	// Warning: 25-74% progress OR 1-3 blocked tasks
-	if progress < 75.0 || blockedCount > 0 {
-		return "warning"
+// estimateColumns sums task estimates for progress weighted by estimates. It
+// expects tasks as t, left joined to task_estimates as te.
+const estimateColumns = `
+			COUNT(DISTINCT CASE WHEN te.estimate IS NOT NULL THEN t.id END) as estimated_tasks,
+			COUNT(DISTINCT CASE WHEN t.status = 'completed' AND te.estimate IS NOT NULL THEN t.id END) as completed_estimated_tasks,
+			COALESCE(SUM(te.estimate), 0) as total_estimate,
+			COALESCE(SUM(CASE WHEN t.status = 'completed' THEN te.estimate END), 0) as completed_estimate`
+
+// progressPercent returns completed tasks as a percentage of all tasks, or,
+// when weighted, completed estimate as a percentage of total estimate
+func progressPercent(totals progress.EstimateTotals, weighted bool) float64 {
+	if weighted {
+		return totals.WeightedPct()
 	}
+	if totals.Tasks == 0 {
+		return 0
+	}
+	return (totals.CompletedTasks / float64(totals.Tasks)) * 100.0
+}
 
-	// Healthy: ≥75% progress AND no blocked tasks
-	return "healthy"
+// estimateUnit returns the unit estimate-weighted progress sums estimates in;
+// estimates in the other unit are left out
+func (s *StatusService) estimateUnit(ctx context.Context) (string, error) {
+	unit, _, err := repository.NewTaskEstimateRepository(s.db).EstimateUnit(ctx)
+	return string(unit), err
+}
+
+// taskLabelJoinFilter returns an extra LEFT JOIN condition limiting tasks to those
+// carrying every label, or an empty string when no labels are given
+func taskLabelJoinFilter(labels []string) (string, []interface{}) {
+	if len(labels) == 0 {
+		return "", nil
+	}
+	condition, args := repository.TaskLabelFilterSQL("t", labels)
+	return " AND " + condition, args
 }
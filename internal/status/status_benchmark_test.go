@@ -107,7 +107,7 @@ func BenchmarkGetProjectSummary(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := service.getProjectSummary(ctx, "")
+		_, err := service.getProjectSummary(ctx, "", nil, false)
 		if err != nil {
 			b.Fatalf("getProjectSummary failed: %v", err)
 		}
@@ -125,7 +125,7 @@ func BenchmarkGetEpics(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := service.getEpics(ctx, "")
+		_, err := service.getEpics(ctx, "", nil, false, nil)
 		if err != nil {
 			b.Fatalf("getEpics failed: %v", err)
 		}
@@ -143,7 +143,7 @@ func BenchmarkGetActiveTasks(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := service.getActiveTasks(ctx, "")
+		_, err := service.getActiveTasks(ctx, "", nil)
 		if err != nil {
 			b.Fatalf("getActiveTasks failed: %v", err)
 		}
@@ -161,7 +161,7 @@ func BenchmarkGetBlockedTasks(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := service.getBlockedTasks(ctx, "")
+		_, err := service.getBlockedTasks(ctx, "", nil)
 		if err != nil {
 			b.Fatalf("getBlockedTasks failed: %v", err)
 		}
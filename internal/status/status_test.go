@@ -238,6 +238,13 @@ func TestStatusRequestValidate_InvalidInputs(t *testing.T) {
 			},
 			expectedErr: "invalid timeframe",
 		},
+		{
+			name: "Invalid detail",
+			req: &StatusRequest{
+				Detail: "task",
+			},
+			expectedErr: "invalid detail",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -838,6 +845,147 @@ func TestGetDashboard_MultipleAgentTypes(t *testing.T) {
 	}
 }
 
+// TestGetDashboard_FeatureDetail tests per-feature health under each epic
+func TestGetDashboard_FeatureDetail(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	db := repository.NewDB(database)
+	service := NewStatusService(db)
+
+	// Clear and seed test data
+	_, _ = database.ExecContext(ctx, "DELETE FROM tasks")
+	_, _ = database.ExecContext(ctx, "DELETE FROM features")
+	_, _ = database.ExecContext(ctx, "DELETE FROM epics")
+
+	result, _ := database.ExecContext(ctx, `
+		INSERT INTO epics (key, title, description, status, priority)
+		VALUES ('E01', 'Test Epic', 'Test epic', 'active', 'high')
+	`)
+	epicID, _ := result.LastInsertId()
+
+	result, _ = database.ExecContext(ctx, `
+		INSERT INTO features (epic_id, key, title, description, status)
+		VALUES (?, 'E01-F01', 'Busy Feature', 'Has work', 'active')
+	`, epicID)
+	featureID, _ := result.LastInsertId()
+	_, _ = database.ExecContext(ctx, `
+		INSERT INTO features (epic_id, key, title, description, status)
+		VALUES (?, 'E01-F02', 'Empty Feature', 'No tasks', 'draft')
+	`, epicID)
+
+	_, _ = database.ExecContext(ctx, `
+		INSERT INTO tasks (feature_id, key, title, status, agent_type, assigned_agent, priority, depends_on)
+		VALUES
+			(?, 'T-E01-F01-001', 'Done', 'completed', 'backend', NULL, 5, '[]'),
+			(?, 'T-E01-F01-002', 'Claimed', 'in_progress', 'backend', 'agent-7', 5, '[]'),
+			(?, 'T-E01-F01-003', 'Unclaimed', 'in_progress', 'backend', NULL, 5, '[]'),
+			(?, 'T-E01-F01-004', 'Stuck', 'blocked', 'frontend', NULL, 5, '[]')
+	`, featureID, featureID, featureID, featureID)
+
+	// Without the detail flag, features are left out
+	dashboard, err := service.GetDashboard(ctx, &StatusRequest{})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if dashboard.Epics[0].Features != nil {
+		t.Error("Expected no features without --detail=feature")
+	}
+	data, _ := json.Marshal(dashboard)
+	if contains(string(data), `"features":[`) {
+		t.Error("Expected no features in JSON without --detail=feature")
+	}
+
+	dashboard, err = service.GetDashboard(ctx, &StatusRequest{Detail: DetailFeature})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	features := dashboard.Epics[0].Features
+	if len(features) != 2 {
+		t.Fatalf("Expected 2 features, got %d", len(features))
+	}
+
+	busy := features[0]
+	if busy.Key != "E01-F01" || busy.TasksTotal != 4 || busy.TasksCompleted != 1 || busy.TasksInProgress != 2 || busy.TasksBlocked != 1 {
+		t.Errorf("Unexpected counts for E01-F01: %+v", busy)
+	}
+	if busy.Health != "warning" {
+		t.Errorf("Expected warning health at 25%% with 1 blocked, got %s", busy.Health)
+	}
+	if len(busy.ActiveAgents) != 2 || busy.ActiveAgents[0].Agent != "agent-7" || busy.ActiveAgents[1].Agent != "backend" {
+		t.Fatalf("Expected agent-7 then backend, got %+v", busy.ActiveAgents)
+	}
+	if busy.ActiveAgents[1].Tasks[0] != "T-E01-F01-003" {
+		t.Errorf("Expected T-E01-F01-003 held by backend, got %v", busy.ActiveAgents[1].Tasks)
+	}
+
+	empty := features[1]
+	if empty.TasksTotal != 0 || empty.Health != "critical" || len(empty.ActiveAgents) != 0 {
+		t.Errorf("Unexpected summary for empty feature: %+v", empty)
+	}
+	if dashboard.Filter == nil || dashboard.Filter.Detail != DetailFeature {
+		t.Error("Expected filter to record the detail level")
+	}
+}
+
+// TestGetDashboard_Weighted tests progress weighted by task estimates
+func TestGetDashboard_Weighted(t *testing.T) {
+	ctx := context.Background()
+	database := test.GetTestDB()
+	db := repository.NewDB(database)
+	service := NewStatusService(db)
+
+	// Clear and seed test data
+	_, _ = database.ExecContext(ctx, "DELETE FROM task_estimates")
+	_, _ = database.ExecContext(ctx, "DELETE FROM tasks")
+	_, _ = database.ExecContext(ctx, "DELETE FROM features")
+	_, _ = database.ExecContext(ctx, "DELETE FROM epics")
+
+	result, _ := database.ExecContext(ctx, `
+		INSERT INTO epics (key, title, description, status, priority)
+		VALUES ('E01', 'Test Epic', 'Test epic', 'active', 'high')
+	`)
+	epicID, _ := result.LastInsertId()
+	result, _ = database.ExecContext(ctx, `
+		INSERT INTO features (epic_id, key, title, description, status)
+		VALUES (?, 'E01-F01', 'Feature', 'Has work', 'active')
+	`, epicID)
+	featureID, _ := result.LastInsertId()
+
+	_, _ = database.ExecContext(ctx, `
+		INSERT INTO tasks (feature_id, key, title, status, agent_type, priority, depends_on)
+		VALUES
+			(?, 'T-E01-F01-001', 'Big and done', 'completed', 'backend', 5, '[]'),
+			(?, 'T-E01-F01-002', 'Small and open', 'todo', 'backend', 5, '[]')
+	`, featureID, featureID)
+	_, _ = database.ExecContext(ctx, `
+		INSERT INTO task_estimates (task_id, estimate, unit)
+		SELECT id, CASE key WHEN 'T-E01-F01-001' THEN 8 ELSE 2 END, 'points' FROM tasks
+	`)
+
+	dashboard, err := service.GetDashboard(ctx, &StatusRequest{Detail: DetailFeature})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if dashboard.Summary.OverallProgress != 50.0 || dashboard.Epics[0].ProgressPercent != 50.0 {
+		t.Errorf("Expected 50%% unweighted progress, got %.1f / %.1f", dashboard.Summary.OverallProgress, dashboard.Epics[0].ProgressPercent)
+	}
+
+	dashboard, err = service.GetDashboard(ctx, &StatusRequest{Detail: DetailFeature, Weighted: true})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if dashboard.Summary.OverallProgress != 80.0 {
+		t.Errorf("Expected 80%% weighted overall progress, got %.1f", dashboard.Summary.OverallProgress)
+	}
+	if dashboard.Epics[0].ProgressPercent != 80.0 || dashboard.Epics[0].Features[0].ProgressPercent != 80.0 {
+		t.Errorf("Expected 80%% weighted epic and feature progress, got %.1f / %.1f",
+			dashboard.Epics[0].ProgressPercent, dashboard.Epics[0].Features[0].ProgressPercent)
+	}
+	if dashboard.Filter == nil || !dashboard.Filter.Weighted {
+		t.Error("Expected filter to record weighted progress")
+	}
+}
+
 // TestGetDashboard_NoActiveTasks tests dashboard when no tasks are in progress
 func TestGetDashboard_NoActiveTasks(t *testing.T) {
 	ctx := context.Background()
@@ -909,7 +1057,7 @@ func TestGetProjectSummary_ZeroDivision(t *testing.T) {
 		VALUES (?, 'E01-F01', 'Test Feature', 'Test feature', 'active')
 	`, epicID)
 
-	summary, err := service.getProjectSummary(ctx, "")
+	summary, err := service.getProjectSummary(ctx, "", nil, false)
 	if err != nil {
 		t.Fatalf("getProjectSummary failed: %v", err)
 	}
@@ -920,22 +1068,101 @@ func TestGetProjectSummary_ZeroDivision(t *testing.T) {
 	}
 }
 
-// TestGetRecentCompletions_NotImplemented verifies recent completions stub behavior
-func TestGetRecentCompletions_NotImplemented(t *testing.T) {
+// TestGetRecentCompletions_Window verifies completions are limited to the window,
+// newest first, with a humanized CompletedAgo
+func TestGetRecentCompletions_Window(t *testing.T) {
 	ctx := context.Background()
 	database := test.GetTestDB()
 	db := repository.NewDB(database)
 	service := NewStatusService(db)
 
-	// Call with various parameters
+	// Clear and seed test data
+	_, _ = database.ExecContext(ctx, "DELETE FROM tasks")
+	_, _ = database.ExecContext(ctx, "DELETE FROM features")
+	_, _ = database.ExecContext(ctx, "DELETE FROM epics")
+
+	result, _ := database.ExecContext(ctx, `
+		INSERT INTO epics (key, title, description, status, priority)
+		VALUES ('E01', 'Test Epic', 'Test epic', 'active', 'high')
+	`)
+	epicID, _ := result.LastInsertId()
+
+	result, _ = database.ExecContext(ctx, `
+		INSERT INTO features (epic_id, key, title, description, status)
+		VALUES (?, 'E01-F01', 'Test Feature', 'Test feature', 'active')
+	`, epicID)
+	featureID, _ := result.LastInsertId()
+
+	now := time.Now().UTC()
+	_, err := database.ExecContext(ctx, `
+		INSERT INTO tasks (feature_id, key, title, status, priority, depends_on, agent_type, completed_at)
+		VALUES
+			(?, 'T-E01-F01-001', 'Done this morning', 'completed', 5, '[]', 'backend', ?),
+			(?, 'T-E01-F01-002', 'Done this week', 'completed', 5, '[]', NULL, ?),
+			(?, 'T-E01-F01-003', 'Done last month', 'completed', 5, '[]', NULL, ?),
+			(?, 'T-E01-F01-004', 'Still open', 'todo', 5, '[]', NULL, NULL)
+	`, featureID, now.Add(-2*time.Hour).Format(time.RFC3339),
+		featureID, now.Add(-72*time.Hour).Format(time.RFC3339),
+		featureID, now.Add(-40*24*time.Hour).Format(time.RFC3339),
+		featureID)
+	if err != nil {
+		t.Fatalf("Failed to seed tasks: %v", err)
+	}
+
 	completions, err := service.getRecentCompletions(ctx, "", "24h")
 	if err != nil {
-		t.Errorf("getRecentCompletions should not error, got: %v", err)
+		t.Fatalf("getRecentCompletions failed: %v", err)
+	}
+	if len(completions) != 1 {
+		t.Fatalf("Expected 1 completion within 24h, got %d", len(completions))
+	}
+	first := completions[0]
+	if first.Key != "T-E01-F01-001" || first.Feature != "E01-F01" || first.Epic != "E01" {
+		t.Errorf("Unexpected completion: %+v", first)
+	}
+	if first.CompletedAgo == nil || *first.CompletedAgo != "2 hours ago" {
+		t.Errorf("Expected CompletedAgo '2 hours ago', got %v", first.CompletedAgo)
+	}
+	if first.AgentType == nil || *first.AgentType != "backend" {
+		t.Errorf("Expected agent type backend, got %v", first.AgentType)
+	}
+
+	completions, err = service.getRecentCompletions(ctx, "", "7d")
+	if err != nil {
+		t.Fatalf("getRecentCompletions failed: %v", err)
+	}
+	if len(completions) != 2 {
+		t.Fatalf("Expected 2 completions within 7d, got %d", len(completions))
+	}
+	if completions[1].Key != "T-E01-F01-002" {
+		t.Errorf("Expected older completion second, got %s", completions[1].Key)
+	}
+	if completions[1].CompletedAgo == nil || *completions[1].CompletedAgo != "3 days ago" {
+		t.Errorf("Expected CompletedAgo '3 days ago', got %v", completions[1].CompletedAgo)
 	}
 
-	// Currently returns empty list
+	completions, err = service.getRecentCompletions(ctx, "E99", "90d")
+	if err != nil {
+		t.Fatalf("getRecentCompletions failed: %v", err)
+	}
 	if len(completions) != 0 {
-		t.Errorf("Expected empty list (not yet implemented), got %d items", len(completions))
+		t.Errorf("Expected no completions for other epic, got %d", len(completions))
+	}
+}
+
+// TestRecentWindowDuration verifies timeframe parsing
+func TestRecentWindowDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"24h": 24 * time.Hour,
+		"1d":  24 * time.Hour,
+		"48h": 48 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+		"90d": 90 * 24 * time.Hour,
+	}
+	for window, expected := range tests {
+		if got := recentWindowDuration(window); got != expected {
+			t.Errorf("recentWindowDuration(%q) = %v, want %v", window, got, expected)
+		}
 	}
 }
 
@@ -1004,7 +1231,7 @@ func TestGetBlockedTasks_OrderedByPriority(t *testing.T) {
 			(?, 'T-E01-F01-003', 'Medium Priority', 'blocked', 5, '[]', ?, 'Reason 3')
 	`, featureID, earlier.Format(time.RFC3339), featureID, now.Format(time.RFC3339), featureID, earlier.Format(time.RFC3339))
 
-	blockedTasks, err := service.getBlockedTasks(ctx, "")
+	blockedTasks, err := service.getBlockedTasks(ctx, "", nil)
 	if err != nil {
 		t.Fatalf("getBlockedTasks failed: %v", err)
 	}
@@ -1018,6 +1245,10 @@ func TestGetBlockedTasks_OrderedByPriority(t *testing.T) {
 	if blockedTasks[0].Key != "T-E01-F01-002" {
 		t.Errorf("Expected first task to be high priority, got %s", blockedTasks[0].Key)
 	}
+	// Blocked duration is humanized from blocked_at
+	if blockedTasks[1].BlockedFor == nil || *blockedTasks[1].BlockedFor != "1 hour" {
+		t.Errorf("Expected BlockedFor '1 hour', got %v", blockedTasks[1].BlockedFor)
+	}
 }
 
 // TestGetActiveTasks_WithNullAgentType tests handling of NULL agent_type
@@ -1054,7 +1285,7 @@ func TestGetActiveTasks_WithNullAgentType(t *testing.T) {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
-	activeTasks, err := service.getActiveTasks(ctx, "")
+	activeTasks, err := service.getActiveTasks(ctx, "", nil)
 	if err != nil {
 		t.Fatalf("getActiveTasks failed: %v", err)
 	}
@@ -1105,7 +1336,7 @@ func TestGetBlockedTasks_WithNullBlockedReason(t *testing.T) {
 		VALUES (?, 'T-E01-F01-001', 'Blocked Task', 'blocked', 5, '[]', NULL)
 	`, featureID)
 
-	blockedTasks, err := service.getBlockedTasks(ctx, "")
+	blockedTasks, err := service.getBlockedTasks(ctx, "", nil)
 	if err != nil {
 		t.Fatalf("getBlockedTasks failed: %v", err)
 	}
@@ -1200,7 +1431,7 @@ func TestGetEpics_NullSafeAggregation(t *testing.T) {
 		VALUES (?, 'Empty Epic', 'Epic with no features', 'active', 'high')
 	`, epicKey)
 
-	epics, err := service.getEpics(ctx, epicKey)
+	epics, err := service.getEpics(ctx, epicKey, nil, false, nil)
 	if err != nil {
 		t.Fatalf("getEpics failed: %v", err)
 	}
@@ -1277,7 +1508,7 @@ func TestGetActiveTasks_EmptyAgentTypeString(t *testing.T) {
 		VALUES (?, ?, 'Empty Agent Task', 'in_progress', '', 5, '[]')
 	`, featureID, taskKey)
 
-	activeTasks, err := service.getActiveTasks(ctx, "")
+	activeTasks, err := service.getActiveTasks(ctx, "", nil)
 	if err != nil {
 		t.Fatalf("getActiveTasks failed: %v", err)
 	}
@@ -1291,3 +1522,42 @@ func TestGetActiveTasks_EmptyAgentTypeString(t *testing.T) {
 		t.Errorf("Expected 1 unassigned task, got %d", len(unassignedTasks))
 	}
 }
+
+// TestGetDashboard_FilterByLabel tests that --label limits counted tasks to labeled ones
+func TestGetDashboard_FilterByLabel(t *testing.T) {
+	ctx := context.Background()
+	database := setupQuotaTestDB(t, []string{"todo", "in_progress", "blocked", "completed"})
+
+	labelRepo := repository.NewLabelRepository(database)
+	taskRepo := repository.NewTaskRepository(database)
+	for _, key := range []string{"T-E01-F01-002", "T-E01-F01-004"} {
+		task, err := taskRepo.GetByKey(ctx, key)
+		if err != nil {
+			t.Fatalf("Failed to get task %s: %v", key, err)
+		}
+		if err := labelRepo.AddTaskLabels(ctx, task.ID, []string{"security"}); err != nil {
+			t.Fatalf("Failed to add label: %v", err)
+		}
+	}
+
+	dashboard, err := NewStatusService(database).GetDashboard(ctx, &StatusRequest{Labels: []string{"security"}})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+
+	if dashboard.Summary.Tasks.Total != 2 {
+		t.Errorf("Expected 2 labeled tasks, got %d", dashboard.Summary.Tasks.Total)
+	}
+	if dashboard.Summary.Tasks.Completed != 1 {
+		t.Errorf("Expected 1 completed labeled task, got %d", dashboard.Summary.Tasks.Completed)
+	}
+	if len(dashboard.Epics) != 1 || dashboard.Epics[0].TasksTotal != 2 {
+		t.Errorf("Expected epic E01 with 2 labeled tasks, got %+v", dashboard.Epics)
+	}
+	if len(dashboard.BlockedTasks) != 0 {
+		t.Errorf("Expected no labeled blocked tasks, got %d", len(dashboard.BlockedTasks))
+	}
+	if dashboard.Filter == nil || len(dashboard.Filter.Labels) != 1 {
+		t.Errorf("Expected label filter in dashboard, got %+v", dashboard.Filter)
+	}
+}
@@ -40,6 +40,7 @@ type TaskActionItem struct {
 	Title         string  // Task title
 	Status        string  // Current status
 	AgeDays       *int    // Days in current status (for waiting tasks)
+	Age           *string // Humanized time in current status, e.g. "3 days"
 	BlockedReason *string // Reason for blocking (if blocked)
 }
 
@@ -0,0 +1,88 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+)
+
+// WIPUsage compares the in_progress tasks of an agent type with its WIP limit
+type WIPUsage struct {
+	AgentType  string `json:"agent_type"`
+	InProgress int    `json:"in_progress"`
+	Limit      int    `json:"limit"`
+	AtLimit    bool   `json:"at_limit"` // No more tasks of this agent type can be started
+}
+
+// GetWIPUsage returns the usage of each agent type with a WIP limit, sorted by
+// agent type. WIP limits apply across the project, so they ignore filters.
+func (s *StatusService) GetWIPUsage(ctx context.Context, limits map[string]int) ([]*WIPUsage, error) {
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	counts, err := s.taskRepo.CountInProgressByAgentType(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]*WIPUsage, 0, len(limits))
+	for agentType, limit := range limits {
+		usage = append(usage, &WIPUsage{
+			AgentType:  agentType,
+			InProgress: counts[agentType],
+			Limit:      limit,
+			AtLimit:    counts[agentType] >= limit,
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].AgentType < usage[j].AgentType })
+	return usage, nil
+}
+
+// formatWIPUsage formats in_progress tasks against the WIP limit of each agent type
+func formatWIPUsage(usage []*WIPUsage, noColor bool) string {
+	var sb strings.Builder
+
+	// Header
+	if noColor {
+		sb.WriteString("\n=== WIP LIMITS ===\n")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(pterm.DefaultHeader.WithFullWidth().Sprint("WIP LIMITS"))
+		sb.WriteString("\n")
+	}
+
+	width := 0
+	for _, u := range usage {
+		width = max(width, len(u.AgentType))
+	}
+
+	sb.WriteString("\n")
+	for _, u := range usage {
+		count := fmt.Sprintf("%d/%d in progress", u.InProgress, u.Limit)
+		note := ""
+		switch {
+		case u.InProgress > u.Limit:
+			note = "over limit"
+		case u.AtLimit:
+			note = "at limit"
+		}
+
+		line := fmt.Sprintf("%-*s  %s", width, u.AgentType, count)
+		if note != "" {
+			if noColor {
+				line += "  (" + note + ")"
+			} else if u.InProgress > u.Limit {
+				line += "  " + pterm.Red(note)
+			} else {
+				line += "  " + pterm.Yellow(note)
+			}
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String()
+}
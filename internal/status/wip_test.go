@@ -0,0 +1,44 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGetDashboard_WIPUsage(t *testing.T) {
+	ctx := context.Background()
+	database := setupQuotaTestDB(t, []string{"in_progress", "in_progress", "in_progress", "todo"})
+	if _, err := database.ExecContext(ctx, "UPDATE tasks SET agent_type = 'backend' WHERE key != 'T-E01-F01-003'"); err != nil {
+		t.Fatalf("Failed to set agent types: %v", err)
+	}
+	service := NewStatusService(database)
+
+	dashboard, err := service.GetDashboard(ctx, &StatusRequest{})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if dashboard.WIP != nil {
+		t.Errorf("Expected no WIP usage without limits, got %+v", dashboard.WIP)
+	}
+
+	dashboard, err = service.GetDashboard(ctx, &StatusRequest{WIPLimits: map[string]int{"frontend": 3, "backend": 2}})
+	if err != nil {
+		t.Fatalf("GetDashboard failed: %v", err)
+	}
+	if len(dashboard.WIP) != 2 {
+		t.Fatalf("Expected usage for two agent types, got %+v", dashboard.WIP)
+	}
+	backend, frontend := dashboard.WIP[0], dashboard.WIP[1]
+	if backend.AgentType != "backend" || backend.InProgress != 2 || backend.Limit != 2 || !backend.AtLimit {
+		t.Errorf("Expected backend at 2 of 2, got %+v", backend)
+	}
+	if frontend.AgentType != "frontend" || frontend.InProgress != 0 || frontend.AtLimit {
+		t.Errorf("Expected frontend at 0 of 3, got %+v", frontend)
+	}
+
+	output := formatWIPUsage(dashboard.WIP, true)
+	if !strings.Contains(output, "backend   2/2 in progress  (at limit)") || !strings.Contains(output, "frontend  0/3 in progress\n") {
+		t.Errorf("Unexpected WIP output:\n%s", output)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	dbpkg "github.com/jwwelbor/shark-task-manager/internal/db"
 	"github.com/jwwelbor/shark-task-manager/internal/keygen"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/parser"
@@ -18,6 +19,7 @@ import (
 // SyncEngine orchestrates synchronization between filesystem and database
 type SyncEngine struct {
 	db              *sql.DB
+	repoDb          *repository.DB
 	taskRepo        *repository.TaskRepository
 	epicRepo        *repository.EpicRepository
 	featureRepo     *repository.FeatureRepository
@@ -38,7 +40,7 @@ func NewSyncEngine(dbPath string) (*SyncEngine, error) {
 // NewSyncEngineWithPatterns creates a new SyncEngine instance with specific patterns enabled
 func NewSyncEngineWithPatterns(dbPath string, patternTypes []PatternType) (*SyncEngine, error) {
 	// Open database connection
-	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
+	db, err := dbpkg.OpenSQLite(dbPath + "?_foreign_keys=on")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -49,18 +51,19 @@ func NewSyncEngineWithPatterns(dbPath string, patternTypes []PatternType) (*Sync
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Create repository wrapper
-	repoDb := repository.NewDB(db)
-	taskRepo := repository.NewTaskRepository(repoDb)
-	epicRepo := repository.NewEpicRepository(repoDb)
-	featureRepo := repository.NewFeatureRepository(repoDb)
-
 	// Determine docs root (look for .sharkconfig.json)
 	docsRoot, err := findDocsRoot()
 	if err != nil {
 		docsRoot = "." // Fallback to current directory
 	}
 
+	// Create repository wrapper
+	repoDb := repository.NewDB(db)
+	repoDb.SetProjectRoot(docsRoot)
+	taskRepo := repository.NewTaskRepository(repoDb)
+	epicRepo := repository.NewEpicRepository(repoDb)
+	featureRepo := repository.NewFeatureRepository(repoDb)
+
 	// Load pattern registry from .sharkconfig.json
 	configPath := filepath.Join(docsRoot, ".sharkconfig.json")
 	patternRegistry, err := loadPatternRegistry(configPath)
@@ -74,6 +77,7 @@ func NewSyncEngineWithPatterns(dbPath string, patternTypes []PatternType) (*Sync
 
 	return &SyncEngine{
 		db:              db,
+		repoDb:          repoDb,
 		taskRepo:        taskRepo,
 		epicRepo:        epicRepo,
 		featureRepo:     featureRepo,
@@ -308,7 +312,7 @@ func (e *SyncEngine) parseFiles(files []TaskFileInfo, report *SyncReport) ([]*Ta
 		taskData := &TaskMetadata{
 			Key:        metadata.TaskKey,
 			Title:      metadata.Title,
-			FilePath:   file.FilePath,
+			FilePath:   e.repoDb.ProjectPath(file.FilePath),
 			ModifiedAt: file.ModifiedAt,
 		}
 
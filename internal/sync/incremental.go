@@ -3,7 +3,7 @@ package sync
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
@@ -74,7 +74,7 @@ func (f *IncrementalFilter) Filter(ctx context.Context, files []TaskFileInfo, op
 
 	for _, file := range files {
 		// Check if file is new (not in database)
-		isNewFile := !existingFiles[file.FilePath]
+		isNewFile := !existingFiles[f.taskRepo.ProjectPath(file.FilePath)]
 
 		// If file is new, always include it
 		if isNewFile {
@@ -117,8 +117,8 @@ func (f *IncrementalFilter) Filter(ctx context.Context, files []TaskFileInfo, op
 
 	// Log filtering statistics
 	if opts.LastSyncTime != nil {
-		log.Printf("Incremental filter: %d total files, %d changed, %d skipped, %d new",
-			result.TotalFiles, result.FilteredFiles, result.SkippedFiles, result.NewFiles)
+		slog.Debug("Incremental filter",
+			"total", result.TotalFiles, "changed", result.FilteredFiles, "skipped", result.SkippedFiles, "new", result.NewFiles)
 	}
 
 	return filteredFiles, result, nil
@@ -2,7 +2,6 @@ package taskcreation
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -82,10 +81,13 @@ type CreateTaskInput struct {
 	Priority       int
 	DependsOn      string
 	ExecutionOrder int
-	CustomKey      string // Custom key override (optional)
-	Filename       string // Custom filename path (relative to project root)
-	Force          bool   // Force reassignment if file already claimed
-	Create         bool   // Create file if it doesn't exist (when Filename is specified)
+	CustomKey      string            // Custom key override (optional)
+	Filename       string            // Custom filename path (relative to project root)
+	Force          bool              // Force reassignment if file already claimed
+	Create         bool              // Create file if it doesn't exist (when Filename is specified)
+	Template       string            // Named template or template file path (optional)
+	Vars           map[string]string // User-defined template variables (optional)
+	Chain          bool              // Depend on the previous task in execution order within the feature
 }
 
 // CreateTaskResult holds the result of task creation
@@ -111,6 +113,13 @@ func (c *Creator) CreateTask(ctx context.Context, input CreateTaskInput) (*Creat
 		return nil, err
 	}
 
+	// Wire the dependency on the previous task for sequential plans
+	if input.Chain {
+		if err := c.applyChain(ctx, validated, &input); err != nil {
+			return nil, err
+		}
+	}
+
 	// 2. Generate or use custom task key
 	var key string
 	if input.CustomKey != "" {
@@ -119,6 +128,10 @@ func (c *Creator) CreateTask(ctx context.Context, input CreateTaskInput) (*Creat
 		if err == nil && existing != nil {
 			return nil, fmt.Errorf("task with key %s already exists", input.CustomKey)
 		}
+		// Old keys of resequenced tasks still resolve to them, so they stay taken
+		if current, err := repository.NewTaskAliasRepository(c.db).GetTaskKeyByOldKey(ctx, input.CustomKey); err == nil && current != "" {
+			return nil, fmt.Errorf("key %s is the old key of task %s and still resolves to it", input.CustomKey, current)
+		}
 		key = input.CustomKey
 	} else {
 		// Auto-generate task key
@@ -179,7 +192,13 @@ func (c *Creator) CreateTask(ctx context.Context, input CreateTaskInput) (*Creat
 		// 2. Resolve task path based on feature's base path
 		// Note: We use PathResolver's logic but can't call it directly since task doesn't exist yet
 		useFeaturePath := false
-		if feature.FilePath != nil && *feature.FilePath != "" {
+		if feature.Key == models.BacklogKey {
+			// Standalone tasks live together in the backlog directory
+			relPath := filepath.Join(filepath.FromSlash(models.BacklogDir), key+".md")
+			fullFilePath = filepath.Join(c.projectRoot, relPath)
+			filePath = relPath
+			useFeaturePath = true
+		} else if feature.FilePath != nil && *feature.FilePath != "" {
 			// Feature has a file path - check if it's in a proper feature folder structure
 			// Example: feature.FilePath = "docs/plan/E10-advanced-task.../E10-F01-task-notes/feature.md"
 			// Task path should be:      "docs/plan/E10-advanced-task.../E10-F01-task-notes/tasks/T-E10-F01-001.md"
@@ -238,24 +257,32 @@ func (c *Creator) CreateTask(ctx context.Context, input CreateTaskInput) (*Creat
 		}
 	}
 
-	// Check for file collision (another task already claims this file)
-	existingTask, err := c.taskRepo.GetByFilePath(ctx, filePath)
-	if err != nil && err != sql.ErrNoRows {
+	// Check for file collision (an epic, feature, or task already claims this file)
+	claim, err := repository.NewFileClaimRepository(c.db).GetByPath(ctx, filePath)
+	if err != nil {
 		return nil, fmt.Errorf("failed to check file collision: %w", err)
 	}
 
-	if existingTask != nil {
-		// Another task already uses this file
+	if claim != nil {
+		// Another entity already uses this file
 		if !input.Force {
 			return nil, fmt.Errorf(
-				"file '%s' is already claimed by task %s ('%s'). Use --force to reassign",
-				filePath, existingTask.Key, existingTask.Title,
+				"file '%s' is already claimed by %s %s ('%s'). Use --force to reassign",
+				filePath, claim.EntityType, claim.EntityKey, claim.EntityTitle,
 			)
 		}
 
-		// Force mode: clear file path from old task
-		if err := c.taskRepo.UpdateFilePath(ctx, existingTask.Key, nil); err != nil {
-			return nil, fmt.Errorf("failed to unassign file from %s: %w", existingTask.Key, err)
+		// Force mode: clear file path from the old owner
+		switch claim.EntityType {
+		case "epic":
+			err = c.epicRepo.UpdateFilePath(ctx, claim.EntityKey, nil)
+		case "feature":
+			err = c.featureRepo.UpdateFilePath(ctx, claim.EntityKey, nil)
+		default:
+			err = c.taskRepo.UpdateFilePath(ctx, claim.EntityKey, nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to unassign file from %s: %w", claim.EntityKey, err)
 		}
 	}
 
@@ -337,10 +364,17 @@ func (c *Creator) CreateTask(ctx context.Context, input CreateTaskInput) (*Creat
 		AgentType:   validated.AgentType,
 		Priority:    input.Priority,
 		DependsOn:   validated.ValidatedDependencies,
+		Vars:        input.Vars,
 		CreatedAt:   now,
 	}
+	c.populateBuiltinTemplateVars(ctx, &templateData, validated.FeatureID)
 
-	markdown, err := c.renderer.Render(validated.AgentType, templateData)
+	var markdown string
+	if input.Template != "" {
+		markdown, err = c.renderer.RenderNamed(input.Template, templateData)
+	} else {
+		markdown, err = c.renderer.Render(validated.AgentType, templateData)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to render template: %w", err)
 	}
@@ -394,6 +428,100 @@ func (c *Creator) CreateTask(ctx context.Context, input CreateTaskInput) (*Creat
 	}, nil
 }
 
+// FindExisting returns the task CreateTask would duplicate: the task with the
+// custom key, or else the task of the feature with the same title. Returns nil
+// if there is none, or if the feature doesn't exist (CreateTask reports that).
+func (c *Creator) FindExisting(ctx context.Context, input CreateTaskInput) (*models.Task, error) {
+	if input.CustomKey != "" {
+		existing, err := c.taskRepo.GetByKey(ctx, input.CustomKey)
+		if err == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, nil
+	}
+
+	feature, err := c.featureRepo.GetByKey(ctx, normalizeFeatureKey(input.EpicKey, input.FeatureKey))
+	if err != nil {
+		return nil, nil
+	}
+	return c.taskRepo.GetByTitle(ctx, feature.ID, input.Title)
+}
+
+// applyChain adds the task preceding the new one in execution order as a dependency.
+// Without an explicit execution order the new task is placed after the last task.
+func (c *Creator) applyChain(ctx context.Context, validated *ValidatedTaskData, input *CreateTaskInput) error {
+	tasks, err := c.taskRepo.ListByFeature(ctx, validated.FeatureID)
+	if err != nil {
+		return fmt.Errorf("failed to list feature tasks for --chain: %w", err)
+	}
+
+	if input.ExecutionOrder <= 0 {
+		input.ExecutionOrder = nextExecutionOrder(tasks)
+	}
+
+	prev := chainPredecessor(tasks, input.ExecutionOrder)
+	if prev == nil {
+		return nil
+	}
+	for _, dep := range validated.ValidatedDependencies {
+		if dep == prev.Key {
+			return nil
+		}
+	}
+	validated.ValidatedDependencies = append(validated.ValidatedDependencies, prev.Key)
+	return nil
+}
+
+// nextExecutionOrder returns the execution order following the highest one in tasks
+func nextExecutionOrder(tasks []*models.Task) int {
+	maxOrder := 0
+	for _, t := range tasks {
+		if t.ExecutionOrder != nil && *t.ExecutionOrder > maxOrder {
+			maxOrder = *t.ExecutionOrder
+		}
+	}
+	return maxOrder + 1
+}
+
+// chainPredecessor returns the task with the highest execution order below order.
+// Tasks are expected in ListByFeature order, so ties resolve to the last listed task.
+// Tasks without an execution order are only used when no task has one.
+func chainPredecessor(tasks []*models.Task, order int) *models.Task {
+	var prev *models.Task
+	for _, t := range tasks {
+		if t.ExecutionOrder == nil || *t.ExecutionOrder >= order {
+			continue
+		}
+		if prev == nil || *t.ExecutionOrder >= *prev.ExecutionOrder {
+			prev = t
+		}
+	}
+	if prev == nil && len(tasks) > 0 && nextExecutionOrder(tasks) == 1 {
+		return tasks[len(tasks)-1]
+	}
+	return prev
+}
+
+// populateBuiltinTemplateVars fills in built-in template variables (epic title,
+// feature title and slug) from the parent entities. Lookup failures are ignored
+// because these variables are informational only.
+func (c *Creator) populateBuiltinTemplateVars(ctx context.Context, data *templates.TemplateData, featureID int64) {
+	feature, err := c.featureRepo.GetByID(ctx, featureID)
+	if err != nil {
+		return
+	}
+	data.FeatureTitle = feature.Title
+	if feature.Slug != nil {
+		data.FeatureSlug = *feature.Slug
+	}
+
+	epic, err := c.epicRepo.GetByID(ctx, feature.EpicID)
+	if err != nil {
+		return
+	}
+	data.EpicTitle = epic.Title
+}
+
 // ValidateCustomFilename validates custom file paths for tasks, epics, and features.
 // It enforces several security and naming constraints:
 // - Filenames must be relative to the project root (no absolute paths)
@@ -761,3 +761,46 @@ func TestCreator_StandaloneFeatureFileCreatesTaskInProperFolder(t *testing.T) {
 	_, _ = database.ExecContext(ctx, "DELETE FROM features WHERE id = ?", feature.ID)
 	_, _ = database.ExecContext(ctx, "DELETE FROM epics WHERE id = ?", epic.ID)
 }
+
+func TestChainPredecessor(t *testing.T) {
+	order := func(n int) *int { return &n }
+
+	ordered := []*models.Task{
+		{Key: "T-E01-F01-001", ExecutionOrder: order(1)},
+		{Key: "T-E01-F01-002", ExecutionOrder: order(2)},
+		{Key: "T-E01-F01-003", ExecutionOrder: order(4)},
+		{Key: "T-E01-F01-004"},
+	}
+	unordered := []*models.Task{
+		{Key: "T-E01-F01-001"},
+		{Key: "T-E01-F01-002"},
+	}
+
+	tests := []struct {
+		name    string
+		tasks   []*models.Task
+		order   int
+		wantKey string
+	}{
+		{"appends after highest order", ordered, nextExecutionOrder(ordered), "T-E01-F01-003"},
+		{"inserts into gap", ordered, 3, "T-E01-F01-002"},
+		{"first position has no predecessor", ordered, 1, ""},
+		{"falls back to last task without orders", unordered, nextExecutionOrder(unordered), "T-E01-F01-002"},
+		{"empty feature", nil, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := chainPredecessor(tt.tasks, tt.order)
+			if tt.wantKey == "" {
+				assert.Nil(t, prev)
+				return
+			}
+			require.NotNil(t, prev)
+			assert.Equal(t, tt.wantKey, prev.Key)
+		})
+	}
+
+	assert.Equal(t, 5, nextExecutionOrder(ordered))
+	assert.Equal(t, 1, nextExecutionOrder(unordered))
+}
@@ -8,9 +8,14 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/jwwelbor/shark-task-manager/internal/models"
 	"github.com/jwwelbor/shark-task-manager/internal/repository"
 )
 
+// taskNumberPattern captures the number of a task key, including standalone
+// task keys (T-BKL-###)
+var taskNumberPattern = regexp.MustCompile(`^T-(?:E\d{2}-F\d{2}|BKL)-(\d{3})$`)
+
 // KeyGenerator handles automatic task key generation
 type KeyGenerator struct {
 	taskRepo    *repository.TaskRepository
@@ -25,10 +30,25 @@ func NewKeyGenerator(taskRepo *repository.TaskRepository, featureRepo *repositor
 	}
 }
 
-// GenerateTaskKey generates the next available task key for a feature
+// GenerateTaskKey reserves the next available task key for a feature
 // Format: T-<epic-key>-<feature-key>-<zero-padded-number>
-// Example: T-E01-F02-003
+// Example: T-E01-F02-003 (T-BKL-003 in the backlog)
+//
+// Numbers come from a per-feature sequence seeded from every task in the
+// feature, trashed and archived ones included, so a key is never handed out
+// while a task still holds it.
 func (kg *KeyGenerator) GenerateTaskKey(ctx context.Context, epicKey, featureKey string) (string, error) {
+	return kg.nextTaskKey(ctx, epicKey, featureKey, kg.taskRepo.NextKeyNumber)
+}
+
+// PeekTaskKey returns the key GenerateTaskKey would return next without
+// reserving it, for previews
+func (kg *KeyGenerator) PeekTaskKey(ctx context.Context, epicKey, featureKey string) (string, error) {
+	return kg.nextTaskKey(ctx, epicKey, featureKey, kg.taskRepo.PeekNextKeyNumber)
+}
+
+// nextTaskKey formats the task key for the number next returns
+func (kg *KeyGenerator) nextTaskKey(ctx context.Context, epicKey, featureKey string, next func(context.Context, int64) (int, error)) (string, error) {
 	// Normalize feature key (prepend epic if needed)
 	normalizedFeatureKey := normalizeFeatureKey(epicKey, featureKey)
 
@@ -38,41 +58,17 @@ func (kg *KeyGenerator) GenerateTaskKey(ctx context.Context, epicKey, featureKey
 		return "", fmt.Errorf("feature %s does not exist", normalizedFeatureKey)
 	}
 
-	// Get all tasks for this feature to find the highest number
-	tasks, err := kg.taskRepo.ListByFeature(ctx, feature.ID)
+	nextNumber, err := next(ctx, feature.ID)
 	if err != nil {
-		return "", fmt.Errorf("failed to list tasks for feature: %w", err)
+		return "", fmt.Errorf("failed to generate task key for feature: %w", err)
 	}
 
-	// Find the highest task number
-	maxNumber := 0
-	keyPattern := regexp.MustCompile(`^T-E\d{2}-F\d{2}-(\d{3})$`)
-
-	for _, task := range tasks {
-		matches := keyPattern.FindStringSubmatch(task.Key)
-		if len(matches) == 2 {
-			num, err := strconv.Atoi(matches[1])
-			if err == nil && num > maxNumber {
-				maxNumber = num
-			}
-		}
-	}
-
-	// Calculate next number
-	nextNumber := maxNumber + 1
-
 	// Check if we've exceeded the maximum
 	if nextNumber > 999 {
 		return "", fmt.Errorf("feature %s has reached maximum task count (999)", normalizedFeatureKey)
 	}
 
-	// Extract just the feature part (F01, F02, etc.) from the normalized key
-	featurePart := extractFeaturePart(normalizedFeatureKey)
-
-	// Generate the key with zero-padded number
-	key := fmt.Sprintf("T-%s-%s-%03d", epicKey, featurePart, nextNumber)
-
-	return key, nil
+	return formatTaskKey(epicKey, normalizedFeatureKey, nextNumber), nil
 }
 
 // GenerateTaskKeyWithTx generates a task key within a transaction for concurrent safety
@@ -117,13 +113,17 @@ func (kg *KeyGenerator) GenerateTaskKeyWithTx(ctx context.Context, tx *sql.Tx, e
 		return "", fmt.Errorf("feature %s has reached maximum task count (999)", normalizedFeatureKey)
 	}
 
-	// Extract feature part
-	featurePart := extractFeaturePart(normalizedFeatureKey)
-
-	// Generate key
-	key := fmt.Sprintf("T-%s-%s-%03d", epicKey, featurePart, nextNumber)
+	return formatTaskKey(epicKey, normalizedFeatureKey, nextNumber), nil
+}
 
-	return key, nil
+// formatTaskKey formats a task key with a zero-padded number. Standalone tasks
+// in the backlog feature are keyed T-BKL-###.
+func formatTaskKey(epicKey, normalizedFeatureKey string, number int) string {
+	if normalizedFeatureKey == models.BacklogKey {
+		return fmt.Sprintf("T-%s-%03d", models.BacklogKey, number)
+	}
+	// Extract just the feature part (F01, F02, etc.) from the normalized key
+	return fmt.Sprintf("T-%s-%s-%03d", epicKey, extractFeaturePart(normalizedFeatureKey), number)
 }
 
 // normalizeFeatureKey prepends epic key to feature key if needed
@@ -156,8 +156,7 @@ func extractFeaturePart(fullFeatureKey string) string {
 // extractNumberFromKey extracts the numeric part from a task key
 // Example: T-E01-F02-042 -> 42
 func extractNumberFromKey(key string) int {
-	pattern := regexp.MustCompile(`^T-E\d{2}-F\d{2}-(\d{3})$`)
-	matches := pattern.FindStringSubmatch(key)
+	matches := taskNumberPattern.FindStringSubmatch(key)
 	if len(matches) == 2 {
 		num, err := strconv.Atoi(matches[1])
 		if err == nil {
@@ -166,6 +166,64 @@ func TestKeyGenerator_GenerateTaskKey_MultipleTasksWithGaps(t *testing.T) {
 	assert.Equal(t, "T-E01-F03-006", key)
 }
 
+func TestKeyGenerator_GenerateTaskKey_TrashedTaskHoldsKey(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Setup - the highest task is in the trash, where it still holds its key
+	ctx := context.Background()
+	epic := createTestEpic(t, db, "E01")
+	feature := createTestFeature(t, db, epic.ID, "E01-F05")
+	createTestTask(t, db, feature.ID, "T-E01-F05-001", "First Task")
+	trashed := createTestTask(t, db, feature.ID, "T-E01-F05-002", "Second Task")
+	require.NoError(t, repository.NewTrashRepository(db).TrashTask(ctx, trashed.ID))
+
+	taskRepo := repository.NewTaskRepository(db)
+	kg := NewKeyGenerator(taskRepo, repository.NewFeatureRepository(db))
+
+	// A preview doesn't reserve the key
+	key, err := kg.PeekTaskKey(ctx, "E01", "F05")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F05-003", key)
+
+	key, err = kg.GenerateTaskKey(ctx, "E01", "F05")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F05-003", key)
+	createTestTask(t, db, feature.ID, key, "Third Task")
+
+	// Each call reserves a distinct key
+	key, err = kg.GenerateTaskKey(ctx, "E01", "F05")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F05-004", key)
+	key, err = kg.GenerateTaskKey(ctx, "E01", "F05")
+	require.NoError(t, err)
+	assert.Equal(t, "T-E01-F05-005", key)
+}
+
+func TestKeyGenerator_GenerateTaskKey_Backlog(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Setup: the backlog is created on first use and holds T-BKL-### tasks
+	ctx := context.Background()
+	featureRepo := repository.NewFeatureRepository(db)
+	backlog, err := featureRepo.GetOrCreateBacklog(ctx)
+	require.NoError(t, err)
+	again, err := featureRepo.GetOrCreateBacklog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, backlog.ID, again.ID, "the backlog should only be created once")
+	createTestTask(t, db, backlog.ID, "T-BKL-001", "Fix typo")
+
+	kg := NewKeyGenerator(repository.NewTaskRepository(db), featureRepo)
+
+	// Test
+	key, err := kg.GenerateTaskKey(ctx, models.BacklogKey, models.BacklogKey)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "T-BKL-002", key)
+}
+
 func TestKeyGenerator_GenerateTaskKey_NormalizeFeatureKey(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -171,8 +171,5 @@ func (v *Validator) ValidateFeature(ctx context.Context, epicKey, featureKey str
 
 // ValidatePriority validates the priority value
 func ValidatePriority(priority int) error {
-	if priority < 1 || priority > 10 {
-		return fmt.Errorf("priority must be between 1 and 10, got %d", priority)
-	}
-	return nil
+	return models.ValidateTaskPriority(priority)
 }
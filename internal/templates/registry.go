@@ -0,0 +1,234 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultProjectTemplateDir is the project-relative directory scanned for user-defined task templates
+const DefaultProjectTemplateDir = "shark-templates/tasks"
+
+// DefaultProjectEpicTemplateDir is the project-relative directory scanned for user-defined epic templates
+const DefaultProjectEpicTemplateDir = "shark-templates/epics"
+
+// Template kinds
+const (
+	KindTask = "task"
+	KindEpic = "epic"
+)
+
+// Template sources
+const (
+	SourceEmbedded = "embedded"
+	SourceProject  = "project"
+	SourceFile     = "file"
+)
+
+// TemplateInfo describes a template known to the registry
+type TemplateInfo struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Source string `json:"source"`
+	Path   string `json:"path,omitempty"`
+}
+
+// Registry resolves named task or epic templates.
+// Project task templates (shark-templates/tasks/<name>.md) override the
+// embedded agent templates (task_templates/task-<name>.md) with the same name.
+// Epic templates come only from the project (shark-templates/epics/<name>.md).
+type Registry struct {
+	projectDir string
+	kind       string
+}
+
+// NewRegistry creates a task template registry.
+// projectDir is the directory holding user-defined templates; if empty, only
+// embedded templates are available.
+func NewRegistry(projectDir string) *Registry {
+	return &Registry{projectDir: projectDir, kind: KindTask}
+}
+
+// NewEpicRegistry creates an epic template registry reading the user-defined
+// templates in projectDir
+func NewEpicRegistry(projectDir string) *Registry {
+	return &Registry{projectDir: projectDir, kind: KindEpic}
+}
+
+// Kind returns the kind of template the registry holds: KindTask or KindEpic
+func (r *Registry) Kind() string {
+	return r.kind
+}
+
+// List returns all registered templates sorted by name
+func (r *Registry) List() ([]TemplateInfo, error) {
+	byName := make(map[string]TemplateInfo)
+
+	if r.kind == KindTask {
+		entries, err := fs.ReadDir(embeddedTemplates, "task_templates")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded templates: %w", err)
+		}
+		for _, entry := range entries {
+			name, ok := templateNameFromFile(entry.Name(), "task-")
+			if !ok {
+				continue
+			}
+			byName[name] = TemplateInfo{Name: name, Kind: r.kind, Source: SourceEmbedded}
+		}
+	}
+
+	if r.projectDir != "" {
+		entries, err := os.ReadDir(r.projectDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read template directory %s: %w", r.projectDir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			name, ok := templateNameFromFile(entry.Name(), "")
+			if !ok {
+				continue
+			}
+			byName[name] = TemplateInfo{
+				Name:   name,
+				Kind:   r.kind,
+				Source: SourceProject,
+				Path:   filepath.Join(r.projectDir, entry.Name()),
+			}
+		}
+	}
+
+	infos := make([]TemplateInfo, 0, len(byName))
+	for _, info := range byName {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	return infos, nil
+}
+
+// Get returns the raw content of a template.
+// name may be a registered template name or a path to a markdown file.
+func (r *Registry) Get(name string) (string, TemplateInfo, error) {
+	if name == "" {
+		return "", TemplateInfo{}, fmt.Errorf("template name cannot be empty")
+	}
+
+	// Explicit file path (e.g. ./my-template.md)
+	if strings.HasSuffix(name, ".md") || strings.ContainsRune(name, filepath.Separator) {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return "", TemplateInfo{}, fmt.Errorf("failed to read template file %s: %w", name, err)
+		}
+		return string(content), TemplateInfo{Name: name, Kind: r.kind, Source: SourceFile, Path: name}, nil
+	}
+
+	if r.projectDir != "" {
+		path := filepath.Join(r.projectDir, name+".md")
+		if content, err := os.ReadFile(path); err == nil {
+			return string(content), TemplateInfo{Name: name, Kind: r.kind, Source: SourceProject, Path: path}, nil
+		}
+	}
+
+	if r.kind == KindTask {
+		content, err := embeddedTemplates.ReadFile(filepath.Join("task_templates", "task-"+name+".md"))
+		if err == nil {
+			return string(content), TemplateInfo{Name: name, Kind: r.kind, Source: SourceEmbedded}, nil
+		}
+	}
+
+	return "", TemplateInfo{}, fmt.Errorf("%s template not found: %s (run 'shark template list' to see available templates)", r.kind, name)
+}
+
+// Validate parses a template and renders it against sample data to catch
+// syntax errors and references to unknown fields or functions.
+func (r *Registry) Validate(name string) error {
+	content, _, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var data interface{} = sampleTemplateData()
+	if r.kind == KindEpic {
+		data = sampleEpicTemplateData()
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// templateNameFromFile extracts a template name from a markdown filename,
+// stripping the given prefix. Returns false if the file is not a template.
+func templateNameFromFile(filename, prefix string) (string, bool) {
+	if !strings.HasSuffix(filename, ".md") || strings.EqualFold(filename, "README.md") {
+		return "", false
+	}
+	if !strings.HasPrefix(filename, prefix) {
+		return "", false
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(filename, prefix), ".md")
+	return name, name != ""
+}
+
+// sampleTemplateData returns representative data used for template validation
+func sampleTemplateData() TemplateData {
+	return TemplateData{
+		Key:          "T-E01-F01-001",
+		Title:        "Sample Task",
+		Description:  "Sample description",
+		Epic:         "E01",
+		EpicTitle:    "Sample Epic",
+		Feature:      "E01-F01",
+		FeatureTitle: "Sample Feature",
+		FeatureSlug:  "sample-feature",
+		AgentType:    "general",
+		Priority:     5,
+		DependsOn:    []string{"T-E01-F01-000"},
+		Vars:         map[string]string{},
+		CreatedAt:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// sampleEpicTemplateData returns representative data used for epic template validation
+func sampleEpicTemplateData() EpicTemplateData {
+	return EpicTemplateData{
+		EpicKey:     "E01",
+		EpicSlug:    "E01-sample-epic",
+		Title:       "Sample Epic",
+		Description: "Sample description",
+		FilePath:    "docs/plan/E01-sample-epic/epic.md",
+		Date:        "2025-01-01",
+		Vars:        map[string]string{},
+	}
+}
+
+// ParseVars parses key=value pairs (as passed via repeated --var flags) into a map
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid template variable %q: expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
@@ -0,0 +1,143 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_List_Embedded(t *testing.T) {
+	registry := NewRegistry("")
+
+	infos, err := registry.List()
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+		assert.Equal(t, SourceEmbedded, info.Source)
+	}
+	assert.Contains(t, names, "general")
+	assert.Contains(t, names, "backend")
+	assert.Contains(t, names, "bugfix")
+}
+
+func TestRegistry_List_ProjectOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "general.md"), []byte("# {{.Title}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spike.md"), []byte("# Spike: {{.Title}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("docs"), 0644))
+
+	registry := NewRegistry(dir)
+	infos, err := registry.List()
+	require.NoError(t, err)
+
+	byName := make(map[string]TemplateInfo)
+	for _, info := range infos {
+		byName[info.Name] = info
+	}
+	assert.Equal(t, SourceProject, byName["general"].Source)
+	assert.Equal(t, SourceProject, byName["spike"].Source)
+	assert.Equal(t, SourceEmbedded, byName["backend"].Source)
+	assert.NotContains(t, byName, "README")
+
+	content, info, err := registry.Get("general")
+	require.NoError(t, err)
+	assert.Equal(t, "# {{.Title}}", content)
+	assert.Equal(t, SourceProject, info.Source)
+}
+
+func TestRegistry_Get_FilePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.md")
+	require.NoError(t, os.WriteFile(path, []byte("Custom {{.Key}}"), 0644))
+
+	content, info, err := NewRegistry("").Get(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Custom {{.Key}}", content)
+	assert.Equal(t, SourceFile, info.Source)
+}
+
+func TestRegistry_Get_NotFound(t *testing.T) {
+	_, _, err := NewRegistry("").Get("does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "good.md"), []byte("{{.Title}} {{.Vars.component}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unclosed.md"), []byte("{{.Title"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unknown.md"), []byte("{{.NoSuchField}}"), 0644))
+
+	registry := NewRegistry(dir)
+	assert.NoError(t, registry.Validate("good"))
+	assert.NoError(t, registry.Validate("bugfix"))
+	assert.Error(t, registry.Validate("unclosed"))
+	assert.Error(t, registry.Validate("unknown"))
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"component=auth", "note=a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"component": "auth", "note": "a=b", "empty": ""}, vars)
+
+	_, err = ParseVars([]string{"missing-separator"})
+	assert.Error(t, err)
+
+	_, err = ParseVars([]string{"=value"})
+	assert.Error(t, err)
+}
+
+func TestRenderer_RenderNamed(t *testing.T) {
+	renderer := NewRendererWithRegistry(NewLoader(""), NewRegistry(""))
+
+	data := TemplateData{
+		Key:          "T-E01-F02-003",
+		Title:        "Fix token refresh",
+		Epic:         "E01",
+		EpicTitle:    "Identity",
+		Feature:      "E01-F02",
+		FeatureTitle: "Authentication",
+		FeatureSlug:  "authentication",
+		AgentType:    "backend",
+		Priority:     3,
+		Vars:         map[string]string{"component": "auth-service"},
+		CreatedAt:    time.Date(2025, 12, 14, 10, 30, 0, 0, time.UTC),
+	}
+
+	result, err := renderer.RenderNamed("bugfix", data)
+	require.NoError(t, err)
+	assert.Contains(t, result, "# Bug Fix: Fix token refresh")
+	assert.Contains(t, result, "E01 - Identity")
+	assert.Contains(t, result, "E01-F02 - Authentication")
+	assert.Contains(t, result, "**Component:** auth-service")
+	assert.NotContains(t, result, "**Issue:**")
+}
+
+func TestEpicRegistry(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "initiative.md"), []byte("# {{.EpicKey}}: {{.Title}}\n\nOwner: {{.Vars.owner}}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.md"), []byte("{{.Key}}"), 0644))
+
+	registry := NewEpicRegistry(dir)
+	infos, err := registry.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2, "epic registries have no embedded templates")
+	assert.Equal(t, "broken", infos[0].Name)
+	assert.Equal(t, KindEpic, infos[1].Kind)
+	assert.Equal(t, SourceProject, infos[1].Source)
+
+	assert.NoError(t, registry.Validate("initiative"))
+	assert.Error(t, registry.Validate("broken"), "task fields are unknown to epic templates")
+	_, _, err = registry.Get("bugfix")
+	assert.Error(t, err)
+
+	content, _, err := registry.Get("initiative")
+	require.NoError(t, err)
+	result, err := RenderEpic(content, EpicTemplateData{EpicKey: "E07", Title: "Billing Revamp", Vars: map[string]string{"owner": "payments"}})
+	require.NoError(t, err)
+	assert.Equal(t, "# E07: Billing Revamp\n\nOwner: payments\n", result)
+}
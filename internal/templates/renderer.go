@@ -10,20 +10,44 @@ import (
 
 // TemplateData holds all variables available to task templates
 type TemplateData struct {
-	Key         string
+	Key          string
+	Title        string
+	Description  string
+	Epic         string
+	EpicTitle    string
+	Feature      string
+	FeatureTitle string
+	FeatureSlug  string
+	AgentType    string
+	Priority     int
+	DependsOn    []string
+	Vars         map[string]string // User-defined variables (--var key=value)
+	CreatedAt    time.Time
+}
+
+// EpicTemplateData holds all variables available to epic templates
+type EpicTemplateData struct {
+	EpicKey     string
+	EpicSlug    string
 	Title       string
 	Description string
-	Epic        string
-	Feature     string
-	AgentType   string
-	Priority    int
-	DependsOn   []string
-	CreatedAt   time.Time
+	FilePath    string
+	Date        string
+	Vars        map[string]string // User-defined variables (--var key=value)
+}
+
+// RenderEpic renders epic template content with the given data
+func RenderEpic(tmplContent string, data EpicTemplateData) (string, error) {
+	if data.Vars == nil {
+		data.Vars = map[string]string{}
+	}
+	return executeData(tmplContent, data)
 }
 
 // Renderer handles template rendering for task markdown files
 type Renderer struct {
-	loader *Loader
+	loader   *Loader
+	registry *Registry
 }
 
 // NewRenderer creates a new template renderer
@@ -33,6 +57,14 @@ func NewRenderer(loader *Loader) *Renderer {
 	}
 }
 
+// NewRendererWithRegistry creates a renderer that can also render named templates
+func NewRendererWithRegistry(loader *Loader, registry *Registry) *Renderer {
+	return &Renderer{
+		loader:   loader,
+		registry: registry,
+	}
+}
+
 // Render renders a task template with the given data
 // Accepts any non-empty agent type string and falls back to general template if needed
 func (r *Renderer) Render(agentType string, data TemplateData) (string, error) {
@@ -42,8 +74,36 @@ func (r *Renderer) Render(agentType string, data TemplateData) (string, error) {
 		return "", fmt.Errorf("failed to load template: %w", err)
 	}
 
+	return execute(tmplContent, data)
+}
+
+// RenderNamed renders a named template (or template file path) from the registry
+func (r *Renderer) RenderNamed(name string, data TemplateData) (string, error) {
+	registry := r.registry
+	if registry == nil {
+		registry = NewRegistry("")
+	}
+
+	tmplContent, _, err := registry.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return execute(tmplContent, data)
+}
+
+// execute parses and executes template content with the custom function set
+func execute(tmplContent string, data TemplateData) (string, error) {
+	if data.Vars == nil {
+		data.Vars = map[string]string{}
+	}
+	return executeData(tmplContent, data)
+}
+
+// executeData parses and executes template content against any data value
+func executeData(tmplContent string, data interface{}) (string, error) {
 	// Create template with custom functions
-	tmpl, err := template.New("task").Funcs(templateFuncs()).Parse(tmplContent)
+	tmpl, err := template.New("template").Funcs(templateFuncs()).Parse(tmplContent)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
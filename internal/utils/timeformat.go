@@ -33,6 +33,18 @@ func FormatRelativeTimeFrom(t time.Time, from time.Time) string {
 	return formatted + " ago"
 }
 
+// HumanizeDuration formats an elapsed duration for display, e.g. "3 days" or
+// "2 hours". Durations under a minute are "less than a minute".
+func HumanizeDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	if d < time.Minute {
+		return "less than a minute"
+	}
+	return formatDuration(d)
+}
+
 // formatDuration converts a duration to a human-readable string
 func formatDuration(d time.Duration) string {
 	seconds := d.Seconds()
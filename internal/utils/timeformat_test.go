@@ -186,3 +186,43 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expected string
+	}{
+		{
+			name:     "zero",
+			duration: 0,
+			expected: "less than a minute",
+		},
+		{
+			name:     "30 seconds",
+			duration: 30 * time.Second,
+			expected: "less than a minute",
+		},
+		{
+			name:     "5 minutes",
+			duration: 5 * time.Minute,
+			expected: "5 minutes",
+		},
+		{
+			name:     "3 days",
+			duration: 72 * time.Hour,
+			expected: "3 days",
+		},
+		{
+			name:     "negative duration uses magnitude",
+			duration: -2 * time.Hour,
+			expected: "2 hours",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HumanizeDuration(tt.duration))
+		})
+	}
+}
@@ -0,0 +1,577 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+)
+
+// Doctor check names
+const (
+	CheckMissingParent     = "missing_parent"
+	CheckMissingDependency = "missing_dependency"
+	CheckMissingFile       = "missing_file"
+	CheckDuplicateFile     = "duplicate_file_claim"
+	CheckOrphanedFile      = "orphaned_file"
+	CheckStaleProgress     = "stale_progress"
+	CheckQuotaExceeded     = "quota_exceeded"
+)
+
+// DoctorChecks lists the checks run by Doctor, in report order
+var DoctorChecks = []string{
+	CheckMissingParent,
+	CheckMissingDependency,
+	CheckMissingFile,
+	CheckDuplicateFile,
+	CheckOrphanedFile,
+	CheckStaleProgress,
+	CheckQuotaExceeded,
+}
+
+// progressTolerance is how far a stored progress value may drift from the
+// calculated one before it is reported as stale
+const progressTolerance = 0.01
+
+var (
+	// Task files are named after their key: T-E01-F02-003.md, T-E01-F02-003-slug.md
+	taskFilePattern = regexp.MustCompile(`^(T-E\d{2}-F\d{2}-\d{3})(?:-.*)?\.md$`)
+
+	// Epic and feature directories start with their key: E01-slug, E01-F02-slug
+	epicDirPattern    = regexp.MustCompile(`^(E\d{2})(?:-|$)`)
+	featureDirPattern = regexp.MustCompile(`^(E\d{2}-F\d{2})(?:-|$)`)
+)
+
+// DoctorRepository defines the database operations needed by Doctor
+type DoctorRepository interface {
+	Repository
+	CalculateFeatureProgress(ctx context.Context, featureID int64) (float64, error)
+	UpdateFeatureProgress(ctx context.Context, featureID int64) error
+	UpdateTaskDependsOn(ctx context.Context, taskID int64, dependsOn *string) error
+}
+
+// DoctorIssue describes one consistency problem found by Doctor
+type DoctorIssue struct {
+	Check      string `json:"check"`
+	EntityType string `json:"entity_type,omitempty"`
+	EntityKey  string `json:"entity_key,omitempty"`
+	FilePath   string `json:"file_path,omitempty"`
+	Issue      string `json:"issue"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Fixable    bool   `json:"fixable"`
+	Fixed      bool   `json:"fixed"`
+	FixError   string `json:"fix_error,omitempty"`
+
+	fix func(ctx context.Context) error
+}
+
+// DoctorSummary counts issues by outcome
+type DoctorSummary struct {
+	TotalIssues int            `json:"total_issues"`
+	Fixable     int            `json:"fixable"`
+	Fixed       int            `json:"fixed"`
+	Remaining   int            `json:"remaining"`
+	ByCheck     map[string]int `json:"by_check"`
+}
+
+// DoctorReport holds the results of a Doctor run
+type DoctorReport struct {
+	Issues     []*DoctorIssue `json:"issues"`
+	Summary    DoctorSummary  `json:"summary"`
+	FixApplied bool           `json:"fix_applied"`
+	DurationMs int64          `json:"duration_ms"`
+}
+
+// IsHealthy returns true if no issues remain
+func (r *DoctorReport) IsHealthy() bool {
+	return r.Summary.Remaining == 0
+}
+
+// Doctor checks referential and filesystem consistency between the database
+// and the project's markdown files
+type Doctor struct {
+	repo        DoctorRepository
+	projectRoot string
+	quotas      QuotaCheck // Nil skips the quota check
+}
+
+// QuotaCheck returns the project's exceeded soft limits, e.g. from
+// status.StatusService.CheckQuotas with the project's quota settings
+type QuotaCheck func(ctx context.Context) ([]*status.QuotaWarning, error)
+
+// NewDoctor creates a Doctor for the project at projectRoot
+func NewDoctor(repo DoctorRepository, projectRoot string) *Doctor {
+	return &Doctor{repo: repo, projectRoot: projectRoot}
+}
+
+// SetQuotaCheck enables the quota check, which reports each exceeded soft
+// limit with its suggested archival command
+func (d *Doctor) SetQuotaCheck(check QuotaCheck) {
+	d.quotas = check
+}
+
+// Run performs all checks. With fix set, safe repairs are applied: stale
+// progress is recalculated and depends_on entries naming missing tasks are
+// removed. Other issues are reported with a suggestion.
+func (d *Doctor) Run(ctx context.Context, fix bool) (*DoctorReport, error) {
+	startTime := time.Now()
+
+	epics, err := d.repo.GetAllEpics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epics: %w", err)
+	}
+	features, err := d.repo.GetAllFeatures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get features: %w", err)
+	}
+	tasks, err := d.repo.GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	var issues []*DoctorIssue
+	issues = append(issues, checkMissingParents(epics, features, tasks)...)
+	issues = append(issues, d.checkDependencies(tasks)...)
+	issues = append(issues, d.checkFiles(epics, features, tasks)...)
+	orphaned, err := d.checkOrphanedFiles(epics, features, tasks)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, orphaned...)
+	stale, err := d.checkProgress(ctx, features)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, stale...)
+	quotas, err := d.checkQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, quotas...)
+
+	report := &DoctorReport{
+		Issues:     issues,
+		FixApplied: fix,
+		Summary:    DoctorSummary{ByCheck: make(map[string]int)},
+	}
+	if report.Issues == nil {
+		report.Issues = []*DoctorIssue{}
+	}
+
+	for _, issue := range issues {
+		report.Summary.TotalIssues++
+		report.Summary.ByCheck[issue.Check]++
+		if issue.Fixable {
+			report.Summary.Fixable++
+		}
+		if fix && issue.fix != nil {
+			if err := issue.fix(ctx); err != nil {
+				issue.FixError = err.Error()
+			} else {
+				issue.Fixed = true
+				report.Summary.Fixed++
+			}
+		}
+	}
+	report.Summary.Remaining = report.Summary.TotalIssues - report.Summary.Fixed
+	report.DurationMs = time.Since(startTime).Milliseconds()
+
+	return report, nil
+}
+
+// checkQuotas reports each soft limit the project exceeds
+func (d *Doctor) checkQuotas(ctx context.Context) ([]*DoctorIssue, error) {
+	if d.quotas == nil {
+		return nil, nil
+	}
+	warnings, err := d.quotas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check quotas: %w", err)
+	}
+
+	var issues []*DoctorIssue
+	for _, warning := range warnings {
+		issue := &DoctorIssue{
+			Check:      CheckQuotaExceeded,
+			Issue:      warning.Message,
+			Suggestion: warning.Suggestion,
+		}
+		if warning.Key != "" {
+			issue.EntityType = warning.Scope
+			issue.EntityKey = warning.Key
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// checkMissingParents finds features whose epic and tasks whose feature no longer exist
+func checkMissingParents(epics []*models.Epic, features []*models.Feature, tasks []*models.Task) []*DoctorIssue {
+	epicIDs := make(map[int64]bool, len(epics))
+	for _, epic := range epics {
+		epicIDs[epic.ID] = true
+	}
+	featureIDs := make(map[int64]bool, len(features))
+	for _, feature := range features {
+		featureIDs[feature.ID] = true
+	}
+
+	var issues []*DoctorIssue
+	for _, feature := range features {
+		if !epicIDs[feature.EpicID] {
+			issues = append(issues, &DoctorIssue{
+				Check:      CheckMissingParent,
+				EntityType: "feature",
+				EntityKey:  feature.Key,
+				Issue:      fmt.Sprintf("Parent epic with ID %d does not exist", feature.EpicID),
+				Suggestion: fmt.Sprintf("Delete the feature ('shark feature delete %s') or restore its epic", feature.Key),
+			})
+		}
+	}
+	for _, task := range tasks {
+		if !featureIDs[task.FeatureID] {
+			issues = append(issues, &DoctorIssue{
+				Check:      CheckMissingParent,
+				EntityType: "task",
+				EntityKey:  task.Key,
+				Issue:      fmt.Sprintf("Parent feature with ID %d does not exist", task.FeatureID),
+				Suggestion: fmt.Sprintf("Delete the task ('shark task delete %s') or restore its feature", task.Key),
+			})
+		}
+	}
+	return issues
+}
+
+// checkDependencies finds depends_on entries that name tasks which don't exist
+func (d *Doctor) checkDependencies(tasks []*models.Task) []*DoctorIssue {
+	taskKeys := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		taskKeys[task.Key] = true
+	}
+
+	var issues []*DoctorIssue
+	for _, task := range tasks {
+		if task.DependsOn == nil || strings.TrimSpace(*task.DependsOn) == "" {
+			continue
+		}
+
+		var deps []string
+		if err := json.Unmarshal([]byte(*task.DependsOn), &deps); err != nil {
+			issues = append(issues, &DoctorIssue{
+				Check:      CheckMissingDependency,
+				EntityType: "task",
+				EntityKey:  task.Key,
+				Issue:      fmt.Sprintf("depends_on is not a JSON array: %s", *task.DependsOn),
+				Suggestion: fmt.Sprintf("Reset dependencies: 'shark task update %s --depends-on=...'", task.Key),
+			})
+			continue
+		}
+
+		var kept, missing []string
+		for _, dep := range deps {
+			if taskKeys[dep] {
+				kept = append(kept, dep)
+			} else {
+				missing = append(missing, dep)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		taskID := task.ID
+		issues = append(issues, &DoctorIssue{
+			Check:      CheckMissingDependency,
+			EntityType: "task",
+			EntityKey:  task.Key,
+			Issue:      fmt.Sprintf("Depends on tasks that do not exist: %s", strings.Join(missing, ", ")),
+			Suggestion: "Run 'shark doctor --fix' to remove the missing dependencies",
+			Fixable:    true,
+			fix: func(ctx context.Context) error {
+				var dependsOn *string
+				if len(kept) > 0 {
+					encoded, err := json.Marshal(kept)
+					if err != nil {
+						return err
+					}
+					value := string(encoded)
+					dependsOn = &value
+				}
+				return d.repo.UpdateTaskDependsOn(ctx, taskID, dependsOn)
+			},
+		})
+	}
+	return issues
+}
+
+// fileClaim is an entity that records a markdown file path
+type fileClaim struct {
+	entityType string
+	key        string
+	path       string
+}
+
+// fileClaims returns the explicit file paths recorded on epics, features, and tasks
+func fileClaims(epics []*models.Epic, features []*models.Feature, tasks []*models.Task) []fileClaim {
+	var claims []fileClaim
+	for _, epic := range epics {
+		if epic.FilePath != nil && *epic.FilePath != "" {
+			claims = append(claims, fileClaim{"epic", epic.Key, *epic.FilePath})
+		}
+	}
+	for _, feature := range features {
+		if feature.FilePath != nil && *feature.FilePath != "" {
+			claims = append(claims, fileClaim{"feature", feature.Key, *feature.FilePath})
+		}
+	}
+	for _, task := range tasks {
+		if task.FilePath != nil && *task.FilePath != "" {
+			claims = append(claims, fileClaim{"task", task.Key, *task.FilePath})
+		}
+	}
+	return claims
+}
+
+// relPath returns path relative to the project root, with forward slashes
+func (d *Doctor) relPath(path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(d.projectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// absPath returns path resolved against the project root
+func (d *Doctor) absPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(d.projectRoot, filepath.FromSlash(path))
+}
+
+// checkFiles finds recorded file paths that are missing on disk or claimed by
+// more than one entity
+func (d *Doctor) checkFiles(epics []*models.Epic, features []*models.Feature, tasks []*models.Task) []*DoctorIssue {
+	var issues []*DoctorIssue
+	owners := make(map[string][]fileClaim)
+
+	for _, claim := range fileClaims(epics, features, tasks) {
+		rel := d.relPath(claim.path)
+		owners[rel] = append(owners[rel], claim)
+
+		if _, err := os.Stat(d.absPath(claim.path)); os.IsNotExist(err) {
+			issues = append(issues, &DoctorIssue{
+				Check:      CheckMissingFile,
+				EntityType: claim.entityType,
+				EntityKey:  claim.key,
+				FilePath:   rel,
+				Issue:      "File does not exist (may have been moved or deleted)",
+				Suggestion: fmt.Sprintf("Restore the file, run 'shark sync', or point the %s at the new file with 'shark %s update %s --file=...'", claim.entityType, claim.entityType, claim.key),
+			})
+		}
+	}
+
+	paths := make([]string, 0, len(owners))
+	for path, claims := range owners {
+		if len(claims) > 1 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		var names []string
+		for _, claim := range owners[path] {
+			names = append(names, fmt.Sprintf("%s %s", claim.entityType, claim.key))
+		}
+		issues = append(issues, &DoctorIssue{
+			Check:      CheckDuplicateFile,
+			EntityType: owners[path][0].entityType,
+			EntityKey:  owners[path][0].key,
+			FilePath:   path,
+			Issue:      fmt.Sprintf("File is claimed by %s", strings.Join(names, ", ")),
+			Suggestion: "Give each entity its own file with '--file=... --force' on update",
+		})
+	}
+
+	return issues
+}
+
+// checkOrphanedFiles finds entity files under docs/plan that no entity owns:
+// task files named after a task key, and epic.md/feature.md files in
+// directories named after an epic or feature key
+func (d *Doctor) checkOrphanedFiles(epics []*models.Epic, features []*models.Feature, tasks []*models.Task) ([]*DoctorIssue, error) {
+	planDir := filepath.Join(d.projectRoot, "docs", "plan")
+	if info, err := os.Stat(planDir); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+
+	claimed := make(map[string]bool)
+	for _, claim := range fileClaims(epics, features, tasks) {
+		claimed[d.relPath(claim.path)] = true
+	}
+	known := make(map[string]bool, len(epics)+len(features)+len(tasks))
+	for _, epic := range epics {
+		known[epic.Key] = true
+	}
+	for _, feature := range features {
+		known[feature.Key] = true
+	}
+	for _, task := range tasks {
+		known[task.Key] = true
+	}
+
+	var issues []*DoctorIssue
+	err := filepath.WalkDir(planDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel := d.relPath(path)
+		if claimed[rel] {
+			return nil
+		}
+
+		var entityType, key string
+		name := entry.Name()
+		dirName := filepath.Base(filepath.Dir(path))
+		switch {
+		case taskFilePattern.MatchString(name):
+			entityType, key = "task", taskFilePattern.FindStringSubmatch(name)[1]
+		case name == "feature.md" && featureDirPattern.MatchString(dirName):
+			entityType, key = "feature", featureDirPattern.FindStringSubmatch(dirName)[1]
+		case name == "epic.md" && epicDirPattern.MatchString(dirName):
+			entityType, key = "epic", epicDirPattern.FindStringSubmatch(dirName)[1]
+		default:
+			return nil
+		}
+
+		// Epics and features without a recorded path own their default file
+		if known[key] {
+			return nil
+		}
+
+		issues = append(issues, &DoctorIssue{
+			Check:      CheckOrphanedFile,
+			EntityType: entityType,
+			EntityKey:  key,
+			FilePath:   rel,
+			Issue:      fmt.Sprintf("No %s %s exists for this file", entityType, key),
+			Suggestion: "Adopt it with 'shark scan --adopt' or delete the file",
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan docs/plan: %w", err)
+	}
+
+	return issues, nil
+}
+
+// checkProgress finds features whose stored progress differs from their tasks
+func (d *Doctor) checkProgress(ctx context.Context, features []*models.Feature) ([]*DoctorIssue, error) {
+	var issues []*DoctorIssue
+	for _, feature := range features {
+		calculated, err := d.repo.CalculateFeatureProgress(ctx, feature.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate progress for %s: %w", feature.Key, err)
+		}
+		if math.Abs(calculated-feature.ProgressPct) <= progressTolerance {
+			continue
+		}
+
+		featureID := feature.ID
+		issues = append(issues, &DoctorIssue{
+			Check:      CheckStaleProgress,
+			EntityType: "feature",
+			EntityKey:  feature.Key,
+			Issue:      fmt.Sprintf("Stored progress %.1f%% does not match its tasks (%.1f%%)", feature.ProgressPct, calculated),
+			Suggestion: "Run 'shark doctor --fix' to recalculate progress",
+			Fixable:    true,
+			fix: func(ctx context.Context) error {
+				return d.repo.UpdateFeatureProgress(ctx, featureID)
+			},
+		})
+	}
+	return issues, nil
+}
+
+// FormatJSON outputs the doctor report as JSON
+func (r *DoctorReport) FormatJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// FormatHuman outputs the doctor report grouped by check
+func (r *DoctorReport) FormatHuman(w io.Writer) error {
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Shark Doctor")
+	fmt.Fprintln(w, "============")
+	fmt.Fprintln(w, "")
+
+	for _, check := range DoctorChecks {
+		count := r.Summary.ByCheck[check]
+		if count == 0 {
+			fmt.Fprintf(w, "  ✓ %s\n", check)
+			continue
+		}
+		fmt.Fprintf(w, "  ✗ %s (%d)\n", check, count)
+		for _, issue := range r.Issues {
+			if issue.Check != check {
+				continue
+			}
+			subject := issue.FilePath
+			if issue.EntityKey != "" {
+				subject = fmt.Sprintf("%s %s", issue.EntityType, issue.EntityKey)
+				if issue.FilePath != "" {
+					subject += " (" + issue.FilePath + ")"
+				}
+			}
+			if subject == "" {
+				fmt.Fprintf(w, "      - %s\n", issue.Issue)
+			} else {
+				fmt.Fprintf(w, "      - %s: %s\n", subject, issue.Issue)
+			}
+			switch {
+			case issue.Fixed:
+				fmt.Fprintln(w, "        Fixed")
+			case issue.FixError != "":
+				fmt.Fprintf(w, "        Fix failed: %s\n", issue.FixError)
+			case issue.Suggestion != "":
+				fmt.Fprintf(w, "        Suggestion: %s\n", issue.Suggestion)
+			}
+		}
+	}
+	fmt.Fprintln(w, "")
+
+	switch {
+	case r.Summary.TotalIssues == 0:
+		fmt.Fprintln(w, "✓ No problems found")
+	case r.IsHealthy():
+		fmt.Fprintf(w, "✓ Fixed all %d issue(s)\n", r.Summary.Fixed)
+	default:
+		fmt.Fprintf(w, "✗ %d issue(s) found", r.Summary.TotalIssues)
+		if r.Summary.Fixed > 0 {
+			fmt.Fprintf(w, ", %d fixed", r.Summary.Fixed)
+		}
+		fmt.Fprintln(w, "")
+		if !r.FixApplied && r.Summary.Fixable > 0 {
+			fmt.Fprintf(w, "  Run 'shark doctor --fix' to repair %d of them automatically\n", r.Summary.Fixable)
+		}
+	}
+	fmt.Fprintln(w, "")
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package validation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDoctorRepository adds progress and dependency repairs to MockRepository
+type MockDoctorRepository struct {
+	MockRepository
+	progress         map[int64]float64
+	updatedProgress  []int64
+	updatedDependsOn map[int64]*string
+}
+
+func (m *MockDoctorRepository) CalculateFeatureProgress(ctx context.Context, featureID int64) (float64, error) {
+	return m.progress[featureID], nil
+}
+
+func (m *MockDoctorRepository) UpdateFeatureProgress(ctx context.Context, featureID int64) error {
+	m.updatedProgress = append(m.updatedProgress, featureID)
+	return nil
+}
+
+func (m *MockDoctorRepository) UpdateTaskDependsOn(ctx context.Context, taskID int64, dependsOn *string) error {
+	if m.updatedDependsOn == nil {
+		m.updatedDependsOn = make(map[int64]*string)
+	}
+	m.updatedDependsOn[taskID] = dependsOn
+	return nil
+}
+
+// writeFile creates a file (and its directories) under root
+func writeFile(t *testing.T, root, rel string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("# file\n"), 0644))
+}
+
+// issuesFor returns the issues reported by a check
+func issuesFor(report *DoctorReport, check string) []*DoctorIssue {
+	var issues []*DoctorIssue
+	for _, issue := range report.Issues {
+		if issue.Check == check {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func newDoctorFixture(t *testing.T) (*MockDoctorRepository, string) {
+	root := t.TempDir()
+	writeFile(t, root, "docs/plan/E01-auth/epic.md")
+	writeFile(t, root, "docs/plan/E01-auth/E01-F01-login/feature.md")
+	writeFile(t, root, "docs/plan/E01-auth/E01-F01-login/tasks/T-E01-F01-001.md")
+	writeFile(t, root, "docs/plan/E01-auth/E01-F01-login/tasks/T-E01-F01-009-stray.md")
+	writeFile(t, root, "docs/plan/E01-auth/E01-F07-old/feature.md")
+	writeFile(t, root, "docs/plan/E01-auth/notes.md")
+
+	repo := &MockDoctorRepository{
+		MockRepository: MockRepository{
+			epics: []*models.Epic{{ID: 1, Key: "E01"}},
+			features: []*models.Feature{
+				{ID: 1, EpicID: 1, Key: "E01-F01", ProgressPct: 0},
+				{ID: 2, EpicID: 99, Key: "E99-F01", ProgressPct: 50},
+			},
+			tasks: []*models.Task{
+				{ID: 1, FeatureID: 1, Key: "T-E01-F01-001", FilePath: strPtr("docs/plan/E01-auth/E01-F01-login/tasks/T-E01-F01-001.md"), DependsOn: strPtr(`["T-E01-F01-002","T-E01-F01-404"]`)},
+				{ID: 2, FeatureID: 1, Key: "T-E01-F01-002", FilePath: strPtr(filepath.Join(root, "docs/plan/E01-auth/E01-F01-login/tasks/T-E01-F01-001.md"))},
+				{ID: 3, FeatureID: 1, Key: "T-E01-F01-003", FilePath: strPtr("docs/plan/E01-auth/E01-F01-login/tasks/T-E01-F01-003.md")},
+				{ID: 4, FeatureID: 42, Key: "T-E42-F01-001"},
+			},
+		},
+		progress: map[int64]float64{1: 33.3, 2: 50},
+	}
+	return repo, root
+}
+
+func TestDoctor_Run(t *testing.T) {
+	repo, root := newDoctorFixture(t)
+
+	report, err := NewDoctor(repo, root).Run(context.Background(), false)
+	require.NoError(t, err)
+
+	parents := issuesFor(report, CheckMissingParent)
+	require.Len(t, parents, 2)
+	assert.Equal(t, "E99-F01", parents[0].EntityKey)
+	assert.Equal(t, "T-E42-F01-001", parents[1].EntityKey)
+
+	deps := issuesFor(report, CheckMissingDependency)
+	require.Len(t, deps, 1)
+	assert.Contains(t, deps[0].Issue, "T-E01-F01-404")
+	assert.True(t, deps[0].Fixable)
+
+	missing := issuesFor(report, CheckMissingFile)
+	require.Len(t, missing, 1)
+	assert.Equal(t, "T-E01-F01-003", missing[0].EntityKey)
+
+	// Relative and absolute paths to the same file are one claim
+	duplicates := issuesFor(report, CheckDuplicateFile)
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, "docs/plan/E01-auth/E01-F01-login/tasks/T-E01-F01-001.md", duplicates[0].FilePath)
+
+	orphaned := issuesFor(report, CheckOrphanedFile)
+	require.Len(t, orphaned, 2)
+	assert.Equal(t, "T-E01-F01-009", orphaned[0].EntityKey)
+	assert.Equal(t, "E01-F07", orphaned[1].EntityKey)
+
+	stale := issuesFor(report, CheckStaleProgress)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "E01-F01", stale[0].EntityKey)
+
+	assert.Equal(t, 8, report.Summary.TotalIssues)
+	assert.Equal(t, 2, report.Summary.Fixable)
+	assert.Equal(t, 0, report.Summary.Fixed)
+	assert.False(t, report.IsHealthy())
+	assert.Empty(t, repo.updatedProgress, "checks must not repair without --fix")
+	assert.Empty(t, repo.updatedDependsOn)
+}
+
+func TestDoctor_Fix(t *testing.T) {
+	repo, root := newDoctorFixture(t)
+
+	report, err := NewDoctor(repo, root).Run(context.Background(), true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Summary.Fixed)
+	assert.Equal(t, report.Summary.TotalIssues-2, report.Summary.Remaining)
+	assert.Equal(t, []int64{1}, repo.updatedProgress)
+	require.Contains(t, repo.updatedDependsOn, int64(1))
+	assert.Equal(t, `["T-E01-F01-002"]`, *repo.updatedDependsOn[1])
+}
+
+func TestDoctor_Healthy(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "docs/plan/E01-auth/epic.md")
+
+	repo := &MockDoctorRepository{
+		MockRepository: MockRepository{epics: []*models.Epic{{ID: 1, Key: "E01"}}},
+	}
+
+	report, err := NewDoctor(repo, root).Run(context.Background(), false)
+	require.NoError(t, err)
+	assert.True(t, report.IsHealthy())
+	assert.Empty(t, report.Issues)
+}
+
+func TestDoctor_Quotas(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "docs/plan/E01-auth/epic.md")
+
+	repo := &MockDoctorRepository{
+		MockRepository: MockRepository{epics: []*models.Epic{{ID: 1, Key: "E01"}}},
+	}
+	doctor := NewDoctor(repo, root)
+	doctor.SetQuotaCheck(func(ctx context.Context) ([]*status.QuotaWarning, error) {
+		return []*status.QuotaWarning{
+			{Scope: status.QuotaScopeEpic, Key: "E01", Current: 250, Limit: 200, Message: "epic E01 has 250 open tasks (limit 200)", Suggestion: "Archive finished work"},
+			{Scope: status.QuotaScopeDatabase, Current: 2 << 20, Limit: 1 << 20, Message: "Database is 2.0 MB (limit 1 MB)", Suggestion: "Prune old backups"},
+		}, nil
+	})
+
+	report, err := doctor.Run(context.Background(), false)
+	require.NoError(t, err)
+
+	quotas := issuesFor(report, CheckQuotaExceeded)
+	require.Len(t, quotas, 2)
+	assert.Equal(t, "epic", quotas[0].EntityType)
+	assert.Equal(t, "E01", quotas[0].EntityKey)
+	assert.Equal(t, "Archive finished work", quotas[0].Suggestion)
+	assert.Empty(t, quotas[1].EntityKey)
+	assert.False(t, quotas[1].Fixable)
+	assert.False(t, report.IsHealthy())
+
+	var out strings.Builder
+	require.NoError(t, report.FormatHuman(&out))
+	assert.Contains(t, out.String(), "      - Database is 2.0 MB (limit 1 MB)\n")
+	assert.Contains(t, out.String(), "Suggestion: Archive finished work")
+}
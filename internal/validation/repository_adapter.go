@@ -51,3 +51,18 @@ func (a *RepositoryAdapter) GetEpicByID(ctx context.Context, id int64) (*models.
 func (a *RepositoryAdapter) GetFeatureByID(ctx context.Context, id int64) (*models.Feature, error) {
 	return a.featureRepo.GetByID(ctx, id)
 }
+
+// CalculateFeatureProgress calculates a feature's progress from its tasks
+func (a *RepositoryAdapter) CalculateFeatureProgress(ctx context.Context, featureID int64) (float64, error) {
+	return a.featureRepo.CalculateProgress(ctx, featureID)
+}
+
+// UpdateFeatureProgress recalculates and stores a feature's progress
+func (a *RepositoryAdapter) UpdateFeatureProgress(ctx context.Context, featureID int64) error {
+	return a.featureRepo.UpdateProgress(ctx, featureID)
+}
+
+// UpdateTaskDependsOn replaces a task's depends_on value
+func (a *RepositoryAdapter) UpdateTaskDependsOn(ctx context.Context, taskID int64, dependsOn *string) error {
+	return a.taskRepo.UpdateDependsOn(ctx, taskID, dependsOn)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -21,7 +22,8 @@ type Repository interface {
 
 // Validator performs database integrity validation
 type Validator struct {
-	repo Repository
+	repo        Repository
+	projectRoot string // Relative file paths are resolved against it
 }
 
 // NewValidator creates a new Validator instance
@@ -31,6 +33,12 @@ func NewValidator(repo Repository) *Validator {
 	}
 }
 
+// SetProjectRoot sets the directory relative file paths are resolved against,
+// the working directory by default
+func (v *Validator) SetProjectRoot(root string) {
+	v.projectRoot = root
+}
+
 // Validate performs all validation checks and returns a comprehensive result
 func (v *Validator) Validate(ctx context.Context) (*ValidationResult, error) {
 	startTime := time.Now()
@@ -91,9 +99,13 @@ func (v *Validator) validateTaskFilePaths(tasks []*models.Task, result *Validati
 		}
 
 		filePath := *task.FilePath
+		statPath := filePath
+		if !filepath.IsAbs(statPath) && v.projectRoot != "" {
+			statPath = filepath.Join(v.projectRoot, filepath.FromSlash(statPath))
+		}
 
 		// Check if file exists
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		if _, err := os.Stat(statPath); os.IsNotExist(err) {
 			result.BrokenFilePaths = append(result.BrokenFilePaths, ValidationFailure{
 				EntityType:   "task",
 				EntityKey:    task.Key,
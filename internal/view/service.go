@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/jwwelbor/shark-task-manager/internal/cli/scope"
 	"github.com/jwwelbor/shark-task-manager/internal/models"
@@ -93,18 +94,50 @@ func (s *Service) LaunchViewer(ctx context.Context, filePath string, viewerCmd s
 		return fmt.Errorf("file not found: %s", filePath)
 	}
 
-	// Construct command
-	cmd := exec.CommandContext(ctx, viewerCmd, filePath)
-
-	// Connect to stdin, stdout, stderr for interactive viewers
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
 	// Execute viewer
-	if err := cmd.Run(); err != nil {
+	if err := run(ctx, viewerCmd, nil, filePath); err != nil {
 		return fmt.Errorf("failed to launch viewer %q: %w", viewerCmd, err)
 	}
 
 	return nil
 }
+
+// DefaultEditor is the editor used when neither $VISUAL nor $EDITOR is set
+const DefaultEditor = "vi"
+
+// Editor returns the user's editor: $VISUAL, else $EDITOR, else DefaultEditor
+func Editor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return DefaultEditor
+}
+
+// LaunchEditor opens the file in an editor and waits for it to exit. Unlike a
+// viewer, the editor command may include arguments, as $EDITOR often does
+// ("code --wait"). Returns an error if the file doesn't exist or the editor fails.
+func (s *Service) LaunchEditor(ctx context.Context, filePath string, editor string) error {
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return fmt.Errorf("file not found: %s", filePath)
+	}
+
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return fmt.Errorf("no editor given")
+	}
+	if err := run(ctx, fields[0], fields[1:], filePath); err != nil {
+		return fmt.Errorf("failed to launch editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// run runs a program on a file, connected to the terminal for interactive use
+func run(ctx context.Context, program string, args []string, filePath string) error {
+	cmd := exec.CommandContext(ctx, program, append(args, filePath)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
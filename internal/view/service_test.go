@@ -240,3 +240,36 @@ func TestService_LaunchViewer_InvalidCommand(t *testing.T) {
 		t.Error("LaunchViewer() expected error for invalid command, got nil")
 	}
 }
+
+func TestService_LaunchEditor(t *testing.T) {
+	service := NewService(nil, nil, nil)
+
+	// Editor commands may carry arguments, as $EDITOR often does
+	if err := service.LaunchEditor(context.Background(), "/dev/null", "cat -u"); err != nil {
+		t.Errorf("LaunchEditor() with arguments error = %v", err)
+	}
+	if err := service.LaunchEditor(context.Background(), "/non/existent/file.md", "cat"); err == nil {
+		t.Error("LaunchEditor() expected error for non-existent file, got nil")
+	}
+	if err := service.LaunchEditor(context.Background(), "/dev/null", "  "); err == nil {
+		t.Error("LaunchEditor() expected error for an empty editor, got nil")
+	}
+}
+
+func TestEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := Editor(); got != DefaultEditor {
+		t.Errorf("Editor() = %q, want %q", got, DefaultEditor)
+	}
+
+	t.Setenv("EDITOR", "nano")
+	if got := Editor(); got != "nano" {
+		t.Errorf("Editor() = %q, want $EDITOR", got)
+	}
+
+	t.Setenv("VISUAL", "code --wait")
+	if got := Editor(); got != "code --wait" {
+		t.Errorf("Editor() = %q, want $VISUAL to take precedence", got)
+	}
+}
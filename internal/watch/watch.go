@@ -0,0 +1,422 @@
+// Package watch keeps the database in step with task markdown files that
+// agents edit directly. It watches task files with fsnotify and, when a file's
+// frontmatter status or acceptance-criteria checklist changes, applies the
+// change to the database or reports it as drift.
+//
+// Only changes are acted on: each file is compared with its content when it
+// was last seen, not with the database, so a file whose status marker was
+// never updated does not undo status changes made through the CLI.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/jwwelbor/shark-task-manager/internal/taskfile"
+)
+
+// Change kinds
+const (
+	FieldStatus    = "status"
+	FieldCriterion = "criterion"
+)
+
+// Result actions
+const (
+	ActionUpdated = "updated"
+	ActionCreated = "created"
+	ActionDrift   = "drift"
+)
+
+// TaskStore is the task data access the reconciler needs
+type TaskStore interface {
+	GetByKey(ctx context.Context, key string) (*models.Task, error)
+	UpdateStatus(ctx context.Context, taskID int64, newStatus models.TaskStatus, agent *string, notes *string) error
+}
+
+// CriteriaStore is the acceptance criteria data access the reconciler needs
+type CriteriaStore interface {
+	GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskCriteria, error)
+	Create(ctx context.Context, criteria *models.TaskCriteria) error
+	UpdateStatus(ctx context.Context, id int64, status models.CriteriaStatus, notes *string) error
+}
+
+// Snapshot is the part of a task file the watcher tracks
+type Snapshot struct {
+	Status   string
+	Criteria map[string]models.CriteriaStatus // criterion text -> pending or complete
+}
+
+// FileChange is one difference between two snapshots of a task file
+type FileChange struct {
+	Field string // FieldStatus or FieldCriterion
+	Item  string // Criterion text; empty for status
+	From  string // Empty when a criterion was added
+	To    string
+}
+
+// Result describes what the reconciler did about one file change
+type Result struct {
+	TaskKey string `json:"task_key"`
+	Path    string `json:"path"`
+	Field   string `json:"field"`
+	Item    string `json:"item,omitempty"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to"`
+	Action  string `json:"action"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// ParseSnapshot extracts the status marker and checklist from task file
+// content. Blank lines before the frontmatter are allowed, and a file without
+// frontmatter has only a checklist.
+func ParseSnapshot(content string) (*Snapshot, error) {
+	snapshot := &Snapshot{Criteria: make(map[string]models.CriteriaStatus)}
+
+	body := strings.TrimLeft(content, " \t\r\n")
+	if strings.HasPrefix(body, "---") {
+		file, err := taskfile.ParseTaskFileContent(body)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Status = strings.TrimSpace(file.Metadata.Status)
+		body = file.Content
+	}
+
+	for _, item := range taskfile.ParseCriteria(body) {
+		snapshot.Criteria[item.Criterion] = item.Status
+	}
+	return snapshot, nil
+}
+
+// ReadSnapshot reads and parses a task file
+func ReadSnapshot(path string) (*Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSnapshot(string(content))
+}
+
+// Diff lists what changed between two snapshots of the same file: a new
+// status marker, checklist items that were checked or unchecked, and new
+// checklist items. Removed items and a removed status marker are ignored.
+func Diff(before, after *Snapshot) []FileChange {
+	var changes []FileChange
+
+	if after.Status != "" && after.Status != before.Status {
+		changes = append(changes, FileChange{Field: FieldStatus, From: before.Status, To: after.Status})
+	}
+
+	items := make([]string, 0, len(after.Criteria))
+	for item := range after.Criteria {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+
+	for _, item := range items {
+		status := after.Criteria[item]
+		previous, existed := before.Criteria[item]
+		if existed && previous == status {
+			continue
+		}
+		change := FileChange{Field: FieldCriterion, Item: item, To: string(status)}
+		if existed {
+			change.From = string(previous)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// Reconciler applies task file changes to the database
+type Reconciler struct {
+	tasks      TaskStore
+	criteria   CriteriaStore
+	agent      string
+	reportOnly bool
+
+	files     map[string]string // absolute file path -> task key
+	snapshots map[string]*Snapshot
+}
+
+// NewReconciler creates a reconciler. With reportOnly, changes are reported as
+// drift and the database is left alone.
+func NewReconciler(tasks TaskStore, criteria CriteriaStore, agent string, reportOnly bool) *Reconciler {
+	return &Reconciler{
+		tasks:      tasks,
+		criteria:   criteria,
+		agent:      agent,
+		reportOnly: reportOnly,
+		files:      make(map[string]string),
+		snapshots:  make(map[string]*Snapshot),
+	}
+}
+
+// Track starts tracking a task file, recording its current content as the
+// baseline for later changes. Unreadable files are tracked with an empty
+// baseline.
+func (r *Reconciler) Track(path, taskKey string) {
+	r.files[path] = taskKey
+	snapshot, err := ReadSnapshot(path)
+	if err != nil {
+		snapshot = &Snapshot{Criteria: make(map[string]models.CriteriaStatus)}
+	}
+	r.snapshots[path] = snapshot
+}
+
+// Tracks reports whether a file is tracked
+func (r *Reconciler) Tracks(path string) bool {
+	_, ok := r.files[path]
+	return ok
+}
+
+// Dirs returns the directories holding tracked files, sorted
+func (r *Reconciler) Dirs() []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for path := range r.files {
+		dir := filepath.Dir(path)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Reconcile compares a tracked file with its last snapshot and applies the
+// changes. The new content becomes the baseline even if some changes could
+// only be reported as drift.
+func (r *Reconciler) Reconcile(ctx context.Context, path string) ([]Result, error) {
+	taskKey, ok := r.files[path]
+	if !ok {
+		return nil, nil
+	}
+
+	after, err := ReadSnapshot(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	changes := Diff(r.snapshots[path], after)
+	r.snapshots[path] = after
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	task, err := r.tasks.GetByKey(ctx, taskKey)
+	if err != nil {
+		return nil, fmt.Errorf("task %s not found: %w", taskKey, err)
+	}
+
+	var results []Result
+	var criteria []*models.TaskCriteria
+	criteriaLoaded := false
+
+	for _, change := range changes {
+		result := Result{TaskKey: taskKey, Path: path, Field: change.Field, Item: change.Item, To: change.To}
+
+		if change.Field == FieldStatus {
+			result.From = string(task.Status)
+			if string(task.Status) == change.To {
+				continue
+			}
+			if r.applyStatus(ctx, task, change.To, &result) {
+				task.Status = models.TaskStatus(change.To)
+			}
+			results = append(results, result)
+			continue
+		}
+
+		if !criteriaLoaded {
+			criteria, err = r.criteria.GetByTaskID(ctx, task.ID)
+			if err != nil {
+				return results, fmt.Errorf("failed to get criteria for %s: %w", taskKey, err)
+			}
+			criteriaLoaded = true
+		}
+		if r.applyCriterion(ctx, task, criteria, change, &result) {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// applyStatus moves the task to the file's status, reporting whether the
+// database was changed. Transitions the workflow rejects are reported as drift.
+func (r *Reconciler) applyStatus(ctx context.Context, task *models.Task, status string, result *Result) bool {
+	if r.reportOnly {
+		result.Action = ActionDrift
+		result.Reason = fmt.Sprintf("database status is %s", task.Status)
+		return false
+	}
+
+	agent := r.agent
+	notes := "Status changed in task file"
+	if err := r.tasks.UpdateStatus(ctx, task.ID, models.TaskStatus(status), &agent, &notes); err != nil {
+		result.Action = ActionDrift
+		result.Reason = err.Error()
+		return false
+	}
+	result.Action = ActionUpdated
+	return true
+}
+
+// applyCriterion brings the task's matching criterion in line with the file,
+// reporting whether there is a result to show. A checked item marks the
+// criterion complete; an unchecked item only changes a complete criterion
+// back to pending, so failed, in_progress, and na criteria keep their status.
+func (r *Reconciler) applyCriterion(ctx context.Context, task *models.Task, criteria []*models.TaskCriteria, change FileChange, result *Result) bool {
+	var existing *models.TaskCriteria
+	for _, c := range criteria {
+		if c.Criterion == change.Item {
+			existing = c
+			break
+		}
+	}
+
+	status := models.CriteriaStatus(change.To)
+	if existing == nil {
+		result.From = ""
+		if r.reportOnly {
+			result.Action = ActionDrift
+			result.Reason = "criterion is not in the database"
+			return true
+		}
+		if err := r.criteria.Create(ctx, &models.TaskCriteria{TaskID: task.ID, Criterion: change.Item, Status: status}); err != nil {
+			result.Action = ActionDrift
+			result.Reason = err.Error()
+			return true
+		}
+		result.Action = ActionCreated
+		return true
+	}
+
+	result.From = string(existing.Status)
+	inSync := existing.Status == status ||
+		(status == models.CriteriaStatusPending && existing.Status != models.CriteriaStatusComplete)
+	if inSync {
+		return false
+	}
+
+	if r.reportOnly {
+		result.Action = ActionDrift
+		result.Reason = fmt.Sprintf("database status is %s", existing.Status)
+		return true
+	}
+	if err := r.criteria.UpdateStatus(ctx, existing.ID, status, nil); err != nil {
+		result.Action = ActionDrift
+		result.Reason = err.Error()
+		return true
+	}
+	existing.Status = status
+	result.Action = ActionUpdated
+	return true
+}
+
+// Watch watches dirs and calls onChange with the absolute path of each
+// markdown file written or created in them, once the file has been quiet for
+// the debounce interval. Editors that save by writing a temporary file and
+// renaming it are covered because directories, not files, are watched.
+// Watch returns when ctx is cancelled.
+func Watch(ctx context.Context, dirs []string, debounce time.Duration, onChange func(path string)) error {
+	isMarkdown := func(path string) bool {
+		return strings.EqualFold(filepath.Ext(path), ".md")
+	}
+	return watchDirs(ctx, dirs, debounce, fsnotify.Write|fsnotify.Create, isMarkdown, onChange)
+}
+
+// WatchFiles calls onChange with the absolute path of each of paths when it is
+// written, created, removed, or replaced, once it has been quiet for the
+// debounce interval. The files' directories are watched, so a file that
+// doesn't exist yet is picked up when it is created. WatchFiles returns when
+// ctx is cancelled.
+func WatchFiles(ctx context.Context, paths []string, debounce time.Duration, onChange func(path string)) error {
+	watched := make(map[string]bool, len(paths))
+	var dirs []string
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		watched[abs] = true
+		if dir := filepath.Dir(abs); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	isWatched := func(path string) bool {
+		return watched[path]
+	}
+	return watchDirs(ctx, dirs, debounce, fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename, isWatched, onChange)
+}
+
+// watchDirs watches dirs and calls onChange with the absolute path of each
+// file that match accepts once one of ops has happened to it and it has been
+// quiet for the debounce interval
+func watchDirs(ctx context.Context, dirs []string, debounce time.Duration, ops fsnotify.Op, match func(path string) bool, onChange func(path string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	pending := make(map[string]time.Time)
+	ticker := time.NewTicker(max(debounce/2, 10*time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&ops == 0 {
+				continue
+			}
+			path, err := filepath.Abs(event.Name)
+			if err != nil || !match(path) {
+				continue
+			}
+			pending[path] = time.Now()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("file watcher failed: %w", err)
+		case now := <-ticker.C:
+			var ready []string
+			for path, changedAt := range pending {
+				if now.Sub(changedAt) >= debounce {
+					ready = append(ready, path)
+				}
+			}
+			sort.Strings(ready)
+			for _, path := range ready {
+				delete(pending, path)
+				onChange(path)
+			}
+		}
+	}
+}
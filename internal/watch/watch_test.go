@@ -0,0 +1,213 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jwwelbor/shark-task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore implements TaskStore and CriteriaStore in memory
+type fakeStore struct {
+	task      *models.Task
+	criteria  []*models.TaskCriteria
+	rejectTo  models.TaskStatus
+	nextID    int64
+	statusLog []models.TaskStatus
+}
+
+func (f *fakeStore) GetByKey(ctx context.Context, key string) (*models.Task, error) {
+	if key != f.task.Key {
+		return nil, fmt.Errorf("task not found: %s", key)
+	}
+	task := *f.task
+	return &task, nil
+}
+
+func (f *fakeStore) UpdateStatus(ctx context.Context, taskID int64, newStatus models.TaskStatus, agent *string, notes *string) error {
+	if newStatus == f.rejectTo {
+		return fmt.Errorf("invalid status transition from %s to %s", f.task.Status, newStatus)
+	}
+	f.task.Status = newStatus
+	f.statusLog = append(f.statusLog, newStatus)
+	return nil
+}
+
+func (f *fakeStore) GetByTaskID(ctx context.Context, taskID int64) ([]*models.TaskCriteria, error) {
+	return f.criteria, nil
+}
+
+func (f *fakeStore) Create(ctx context.Context, criteria *models.TaskCriteria) error {
+	f.nextID++
+	criteria.ID = f.nextID
+	f.criteria = append(f.criteria, criteria)
+	return nil
+}
+
+// criteriaStore adapts fakeStore's criteria status updates to CriteriaStore
+type criteriaStore struct{ *fakeStore }
+
+func (c criteriaStore) UpdateStatus(ctx context.Context, id int64, status models.CriteriaStatus, notes *string) error {
+	for _, criterion := range c.criteria {
+		if criterion.ID == id {
+			criterion.Status = status
+			return nil
+		}
+	}
+	return fmt.Errorf("criterion %d not found", id)
+}
+
+// taskFileContent builds a task file; like files from the task template, it
+// starts with a blank line
+func taskFileContent(status string, checklist ...string) string {
+	content := "\n---\nkey: T-E01-F01-001\nstatus: " + status + "\n---\n\n# Task\n\n"
+	for _, line := range checklist {
+		content += line + "\n"
+	}
+	return content
+}
+
+func writeTaskFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDiff(t *testing.T) {
+	before, err := ParseSnapshot(taskFileContent("todo", "- [ ] Tests pass", "- [x] Docs updated", "- [ ] Removed later"))
+	require.NoError(t, err)
+	after, err := ParseSnapshot(taskFileContent("in_progress", "- [x] Tests pass", "- [x] Docs updated", "- [ ] New item"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []FileChange{
+		{Field: FieldStatus, From: "todo", To: "in_progress"},
+		{Field: FieldCriterion, Item: "New item", To: "pending"},
+		{Field: FieldCriterion, Item: "Tests pass", From: "pending", To: "complete"},
+	}, Diff(before, after))
+
+	assert.Empty(t, Diff(after, after))
+
+	noStatus, err := ParseSnapshot(taskFileContent(""))
+	require.NoError(t, err)
+	assert.Empty(t, Diff(before, &Snapshot{Status: noStatus.Status, Criteria: before.Criteria}), "a removed status marker is ignored")
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "T-E01-F01-001.md")
+	writeTaskFile(t, path, taskFileContent("todo", "- [ ] Tests pass", "- [ ] Docs updated"))
+
+	store := &fakeStore{
+		task: &models.Task{ID: 1, Key: "T-E01-F01-001", Status: models.TaskStatusInProgress},
+		criteria: []*models.TaskCriteria{
+			{ID: 1, TaskID: 1, Criterion: "Tests pass", Status: models.CriteriaStatusPending},
+			{ID: 2, TaskID: 1, Criterion: "Docs updated", Status: models.CriteriaStatusFailed},
+		},
+		nextID: 2,
+	}
+	r := NewReconciler(store, criteriaStore{store}, "shark-watch", false)
+	r.Track(path, "T-E01-F01-001")
+	assert.Equal(t, []string{filepath.Dir(path)}, r.Dirs())
+
+	t.Run("unchanged file does nothing", func(t *testing.T) {
+		results, err := r.Reconcile(ctx, path)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Empty(t, store.statusLog, "a stale status marker does not revert the database")
+	})
+
+	t.Run("applies status and checklist changes", func(t *testing.T) {
+		writeTaskFile(t, path, taskFileContent("ready_for_review", "- [x] Tests pass", "- [ ] Docs updated", "- [x] Added item"))
+
+		results, err := r.Reconcile(ctx, path)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, Result{TaskKey: "T-E01-F01-001", Path: path, Field: FieldStatus, From: "in_progress", To: "ready_for_review", Action: ActionUpdated}, results[0])
+		assert.Equal(t, ActionCreated, results[1].Action)
+		assert.Equal(t, "Added item", results[1].Item)
+		assert.Equal(t, ActionUpdated, results[2].Action)
+		assert.Equal(t, "Tests pass", results[2].Item)
+
+		assert.Equal(t, models.TaskStatusReadyForReview, store.task.Status)
+		assert.Equal(t, models.CriteriaStatusComplete, store.criteria[0].Status)
+		assert.Equal(t, models.CriteriaStatusFailed, store.criteria[1].Status, "unchecked items keep richer statuses")
+		assert.Len(t, store.criteria, 3)
+	})
+
+	t.Run("rejected transition is drift", func(t *testing.T) {
+		store.rejectTo = models.TaskStatusCompleted
+		writeTaskFile(t, path, taskFileContent("completed", "- [x] Tests pass", "- [ ] Docs updated", "- [x] Added item"))
+
+		results, err := r.Reconcile(ctx, path)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, ActionDrift, results[0].Action)
+		assert.Contains(t, results[0].Reason, "invalid status transition")
+		assert.Equal(t, models.TaskStatusReadyForReview, store.task.Status)
+	})
+}
+
+func TestReconcileReportOnly(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "T-E01-F01-001.md")
+	writeTaskFile(t, path, taskFileContent("todo", "- [ ] Tests pass"))
+
+	store := &fakeStore{task: &models.Task{ID: 1, Key: "T-E01-F01-001", Status: models.TaskStatusTodo}}
+	r := NewReconciler(store, criteriaStore{store}, "shark-watch", true)
+	r.Track(path, "T-E01-F01-001")
+
+	writeTaskFile(t, path, taskFileContent("in_progress", "- [x] Tests pass"))
+	results, err := r.Reconcile(ctx, path)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.Equal(t, ActionDrift, result.Action)
+	}
+	assert.Empty(t, store.statusLog)
+	assert.Empty(t, store.criteria)
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "T-E01-F01-001.md")
+	writeTaskFile(t, path, taskFileContent("todo"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changed := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, []string{dir}, 20*time.Millisecond, func(p string) { changed <- p })
+	}()
+
+	// Give the watcher time to register the directory, then write repeatedly;
+	// the writes are debounced into one callback
+	time.Sleep(100 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		writeTaskFile(t, path, taskFileContent("in_progress"))
+	}
+	writeTaskFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	select {
+	case p := <-changed:
+		assert.Equal(t, path, p)
+	case <-ctx.Done():
+		t.Fatal("no change reported")
+	}
+
+	select {
+	case p := <-changed:
+		t.Fatalf("unexpected second change: %s", p)
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,316 @@
+// Package workspace manages the registry of shark projects on a machine and
+// the .shark.yaml file that marks a project's root.
+//
+// The registry (~/.shark/workspaces.yaml) maps names to project roots and
+// records the current workspace, used when a command runs outside any project,
+// and the epic each project is focused on.
+// A .shark.yaml in a project root marks the root for commands run from any
+// subdirectory and can point at a database other than shark-tasks.db.
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the file that marks a project root
+const ProjectFileName = ".shark.yaml"
+
+// RegistryFileName is the registry file in the shark home directory
+const RegistryFileName = "workspaces.yaml"
+
+// HomeEnv overrides the shark home directory (default ~/.shark)
+const HomeEnv = "SHARK_HOME"
+
+// Workspace is a named project root
+type Workspace struct {
+	Name string `yaml:"name" json:"name"`
+	Root string `yaml:"root" json:"root"`
+}
+
+// Registry is the set of known workspaces
+type Registry struct {
+	Current    string            `yaml:"current,omitempty" json:"current,omitempty"`
+	Workspaces []Workspace       `yaml:"workspaces" json:"workspaces"`
+	Focus      map[string]string `yaml:"focus,omitempty" json:"-"` // Epic key focused with shark focus, by project root
+}
+
+// ProjectFile is the content of .shark.yaml
+type ProjectFile struct {
+	Name   string       `yaml:"name,omitempty"`   // Workspace name used by shark workspace add
+	DB     string       `yaml:"db,omitempty"`     // Database path, relative to the project root; default shark-tasks.db
+	Hooks  []Hook       `yaml:"hooks,omitempty"`  // Commands and webhooks run on progress milestones
+	Health *HealthRules `yaml:"health,omitempty"` // How shark status rates epic and feature health
+}
+
+// Hook runs a shell command or calls a webhook when an epic or feature reaches
+// a milestone. See the hooks package for the events and their payload.
+type Hook struct {
+	Event     string `yaml:"event"`               // "progress" or "unblocked"
+	Threshold int    `yaml:"threshold,omitempty"` // Progress percentage that fires a progress hook
+	Scope     string `yaml:"scope,omitempty"`     // "epic" or "feature" for progress hooks; default both
+	Run       string `yaml:"run,omitempty"`       // Shell command, run from the project root
+	Webhook   string `yaml:"webhook,omitempty"`   // URL that receives the event as a JSON POST
+}
+
+// HealthRules tunes how epics and features are rated healthy, warning, or
+// critical. Unset fields keep their defaults; see the status package for the
+// defaults and how rules are scored.
+type HealthRules struct {
+	Progress      *HealthRule `yaml:"progress,omitempty"`       // Percent of tasks completed; trips below its thresholds
+	Blocked       *HealthRule `yaml:"blocked,omitempty"`        // Blocked tasks; trips above its thresholds
+	Stale         *HealthRule `yaml:"stale,omitempty"`          // Days since the last change; trips above its thresholds. Off unless set
+	WarningScore  *float64    `yaml:"warning_score,omitempty"`  // Total score at which health is warning
+	CriticalScore *float64    `yaml:"critical_score,omitempty"` // Total score at which health is critical
+}
+
+// HealthRule sets the thresholds and weight of one health rule
+type HealthRule struct {
+	Warning  *float64 `yaml:"warning,omitempty"`
+	Critical *float64 `yaml:"critical,omitempty"`
+	Weight   *float64 `yaml:"weight,omitempty"` // Multiplies the rule's score; 0 turns it off
+}
+
+// Home returns the shark home directory: $SHARK_HOME, or ~/.shark
+func Home() (string, error) {
+	if home := os.Getenv(HomeEnv); home != "" {
+		return home, nil
+	}
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(userHome, ".shark"), nil
+}
+
+// RegistryPath returns the path of the workspace registry
+func RegistryPath() (string, error) {
+	home, err := Home()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, RegistryFileName), nil
+}
+
+// LoadRegistry reads the registry at path. A missing file is an empty registry.
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Registry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace registry: %w", err)
+	}
+
+	registry := &Registry{}
+	if err := yaml.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace registry %s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// Save writes the registry to path atomically, creating its directory
+func (r *Registry) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace registry: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write workspace registry: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write workspace registry: %w", err)
+	}
+	return nil
+}
+
+// Get returns the workspace with the given name, or nil
+func (r *Registry) Get(name string) *Workspace {
+	for i := range r.Workspaces {
+		if strings.EqualFold(r.Workspaces[i].Name, name) {
+			return &r.Workspaces[i]
+		}
+	}
+	return nil
+}
+
+// Add registers a workspace. Names are unique ignoring case, and a root can
+// only be registered once.
+func (r *Registry) Add(ws Workspace) error {
+	if err := ValidateName(ws.Name); err != nil {
+		return err
+	}
+	if existing := r.Get(ws.Name); existing != nil {
+		return fmt.Errorf("workspace %q already exists (%s)", existing.Name, existing.Root)
+	}
+	for _, existing := range r.Workspaces {
+		if existing.Root == ws.Root {
+			return fmt.Errorf("%s is already registered as workspace %q", ws.Root, existing.Name)
+		}
+	}
+	r.Workspaces = append(r.Workspaces, ws)
+	sort.Slice(r.Workspaces, func(i, j int) bool { return r.Workspaces[i].Name < r.Workspaces[j].Name })
+	return nil
+}
+
+// Remove unregisters a workspace, clearing it as current
+func (r *Registry) Remove(name string) error {
+	for i := range r.Workspaces {
+		if strings.EqualFold(r.Workspaces[i].Name, name) {
+			if strings.EqualFold(r.Current, r.Workspaces[i].Name) {
+				r.Current = ""
+			}
+			r.Workspaces = append(r.Workspaces[:i], r.Workspaces[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("workspace %q not found", name)
+}
+
+// Use makes a registered workspace current
+func (r *Registry) Use(name string) error {
+	ws := r.Get(name)
+	if ws == nil {
+		return fmt.Errorf("workspace %q not found", name)
+	}
+	r.Current = ws.Name
+	return nil
+}
+
+// CurrentWorkspace returns the current workspace, or nil if none is set
+func (r *Registry) CurrentWorkspace() *Workspace {
+	if r.Current == "" {
+		return nil
+	}
+	return r.Get(r.Current)
+}
+
+// FocusedEpic returns the epic focused in the project at root, or ""
+func (r *Registry) FocusedEpic(root string) string {
+	return r.Focus[root]
+}
+
+// SetFocus focuses the project at root on an epic; an empty key clears it
+func (r *Registry) SetFocus(root, epicKey string) {
+	if epicKey == "" {
+		delete(r.Focus, root)
+		return
+	}
+	if r.Focus == nil {
+		r.Focus = map[string]string{}
+	}
+	r.Focus[root] = epicKey
+}
+
+// ValidateName checks that a workspace name is usable as a flag value
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("workspace name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t/\\") {
+		return fmt.Errorf("invalid workspace name %q: no spaces or slashes", name)
+	}
+	return nil
+}
+
+// FindProjectFile walks up from dir to the nearest .shark.yaml and returns the
+// directory containing it. Returns "" if there is none.
+func FindProjectFile(dir string) string {
+	current := dir
+	for {
+		if _, err := os.Stat(filepath.Join(current, ProjectFileName)); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return ""
+		}
+		current = parent
+	}
+}
+
+// rootMarkers are the files that mark a project root, most specific first
+var rootMarkers = []string{ProjectFileName, ".sharkconfig.json", "shark-tasks.db", ".git"}
+
+// FindRoot walks up from dir to the project root: the nearest directory with a
+// .shark.yaml, else with a .sharkconfig.json, then a shark-tasks.db, then a
+// .git. Returns "" if there are no markers.
+func FindRoot(dir string) string {
+	found := make([]string, len(rootMarkers))
+	for current := dir; ; {
+		for i, marker := range rootMarkers {
+			if found[i] != "" {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+				found[i] = current
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	for _, root := range found {
+		if root != "" {
+			return root
+		}
+	}
+	return ""
+}
+
+// LoadProjectFile reads the .shark.yaml in root. A missing file returns nil.
+func LoadProjectFile(root string) (*ProjectFile, error) {
+	path := filepath.Join(root, ProjectFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	project := &ProjectFile{}
+	if err := yaml.Unmarshal(data, project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return project, nil
+}
+
+// WriteProjectFile writes a .shark.yaml to root
+func WriteProjectFile(root string, project *ProjectFile) error {
+	data, err := yaml.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", ProjectFileName, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ProjectFileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ProjectFileName, err)
+	}
+	return nil
+}
+
+// DatabasePath returns the database path set in root's .shark.yaml, made
+// absolute against root, or "" if none is set
+func DatabasePath(root string) string {
+	project, err := LoadProjectFile(root)
+	if err != nil || project == nil || project.DB == "" {
+		return ""
+	}
+	if filepath.IsAbs(project.DB) {
+		return project.DB
+	}
+	return filepath.Join(root, project.DB)
+}
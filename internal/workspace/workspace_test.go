@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_SaveAndLoad(t *testing.T) {
+	t.Setenv(HomeEnv, t.TempDir())
+	path, err := RegistryPath()
+	require.NoError(t, err)
+
+	// A missing registry is empty
+	registry, err := LoadRegistry(path)
+	require.NoError(t, err)
+	assert.Empty(t, registry.Workspaces)
+
+	require.NoError(t, registry.Add(Workspace{Name: "web", Root: "/src/web"}))
+	require.NoError(t, registry.Add(Workspace{Name: "api", Root: "/src/api"}))
+	require.NoError(t, registry.Use("API"))
+	require.NoError(t, registry.Save(path))
+
+	loaded, err := LoadRegistry(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Workspaces, 2)
+	assert.Equal(t, "api", loaded.Workspaces[0].Name, "workspaces are kept sorted")
+	assert.Equal(t, "/src/api", loaded.CurrentWorkspace().Root)
+}
+
+func TestRegistry_AddRejectsDuplicates(t *testing.T) {
+	registry := &Registry{}
+	require.NoError(t, registry.Add(Workspace{Name: "api", Root: "/src/api"}))
+
+	assert.Error(t, registry.Add(Workspace{Name: "Api", Root: "/src/other"}), "names are unique ignoring case")
+	assert.Error(t, registry.Add(Workspace{Name: "api2", Root: "/src/api"}), "a root is registered once")
+	assert.Error(t, registry.Add(Workspace{Name: "my api", Root: "/src/my-api"}))
+	assert.Error(t, registry.Use("web"))
+}
+
+func TestRegistry_RemoveClearsCurrent(t *testing.T) {
+	registry := &Registry{}
+	require.NoError(t, registry.Add(Workspace{Name: "api", Root: "/src/api"}))
+	require.NoError(t, registry.Use("api"))
+
+	require.NoError(t, registry.Remove("api"))
+	assert.Empty(t, registry.Workspaces)
+	assert.Nil(t, registry.CurrentWorkspace())
+	assert.Error(t, registry.Remove("api"))
+}
+
+func TestRegistry_Focus(t *testing.T) {
+	t.Setenv(HomeEnv, t.TempDir())
+	path, err := RegistryPath()
+	require.NoError(t, err)
+
+	registry := &Registry{}
+	assert.Empty(t, registry.FocusedEpic("/src/api"))
+
+	registry.SetFocus("/src/api", "E05")
+	require.NoError(t, registry.Save(path))
+
+	loaded, err := LoadRegistry(path)
+	require.NoError(t, err)
+	assert.Equal(t, "E05", loaded.FocusedEpic("/src/api"))
+	assert.Empty(t, loaded.FocusedEpic("/src/web"), "focus is kept per project")
+
+	loaded.SetFocus("/src/api", "")
+	assert.Empty(t, loaded.FocusedEpic("/src/api"))
+}
+
+func TestFindProjectFile(t *testing.T) {
+	root := t.TempDir()
+	deep := filepath.Join(root, "internal", "pkg")
+	require.NoError(t, os.MkdirAll(deep, 0755))
+
+	assert.Equal(t, "", FindProjectFile(deep))
+
+	require.NoError(t, WriteProjectFile(root, &ProjectFile{Name: "api"}))
+	assert.Equal(t, root, FindProjectFile(deep))
+}
+
+func TestFindRoot(t *testing.T) {
+	root := t.TempDir()
+	deep := filepath.Join(root, "docs", "plan", "E01-auth")
+	require.NoError(t, os.MkdirAll(deep, 0755))
+
+	assert.Equal(t, "", FindRoot(deep))
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	assert.Equal(t, root, FindRoot(deep))
+
+	// A database copy nearer than the config doesn't move the root
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", "shark-tasks.db"), nil, 0644))
+	assert.Equal(t, filepath.Join(root, "docs"), FindRoot(deep))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".sharkconfig.json"), []byte("{}"), 0644))
+	assert.Equal(t, root, FindRoot(deep))
+
+	require.NoError(t, WriteProjectFile(deep, &ProjectFile{Name: "auth"}))
+	assert.Equal(t, deep, FindRoot(deep))
+}
+
+func TestDatabasePath(t *testing.T) {
+	root := t.TempDir()
+	assert.Equal(t, "", DatabasePath(root), "no .shark.yaml")
+
+	require.NoError(t, WriteProjectFile(root, &ProjectFile{Name: "api"}))
+	assert.Equal(t, "", DatabasePath(root), "no db set")
+
+	require.NoError(t, WriteProjectFile(root, &ProjectFile{Name: "api", DB: "data/tasks.db"}))
+	assert.Equal(t, filepath.Join(root, "data", "tasks.db"), DatabasePath(root))
+
+	require.NoError(t, WriteProjectFile(root, &ProjectFile{DB: "/var/shark/api.db"}))
+	assert.Equal(t, "/var/shark/api.db", DatabasePath(root))
+}